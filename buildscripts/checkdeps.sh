@@ -7,7 +7,7 @@ _init() {
 
     ## Minimum required versions for build dependencies
     GIT_VERSION="1.0"
-    GO_VERSION="1.16"
+    GO_VERSION="1.20"
     OSX_VERSION="10.8"
     KNAME=$(uname -s)
     ARCH=$(uname -m)
@@ -19,6 +19,7 @@ package cmd
 
 import (
 	"bytes"
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"encoding/xml"
@@ -26,6 +27,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -34,11 +36,13 @@ import (
 	"github.com/klauspost/compress/zip"
 	"github.com/minio/kes"
 	"github.com/minio/madmin-go"
+	"github.com/minio/minio-go/v7/pkg/s3utils"
 	"github.com/minio/minio-go/v7/pkg/tags"
 	"github.com/minio/pkg/bucket/policy"
 	iampolicy "github.com/minio/pkg/iam/policy"
 	"github.com/qkbyte/minio/internal/bucket/lifecycle"
 	objectlock "github.com/qkbyte/minio/internal/bucket/object/lock"
+	"github.com/qkbyte/minio/internal/bucket/replication"
 	"github.com/qkbyte/minio/internal/bucket/versioning"
 	"github.com/qkbyte/minio/internal/event"
 	"github.com/qkbyte/minio/internal/kms"
@@ -46,8 +50,15 @@ import (
 )
 
 const (
-	bucketQuotaConfigFile = "quota.json"
-	bucketTargetsFile     = "bucket-targets.json"
+	bucketQuotaConfigFile         = "quota.json"
+	bucketTargetsFile             = "bucket-targets.json"
+	bucketTrashConfigFile         = "trash.json"
+	bucketInlineConfigFile        = "inline.json"
+	bucketSuspendConfigFile       = "suspend.json"
+	bucketIndexerConfigFile       = "indexer.json"
+	bucketShareLinksConfigFile    = "share-links.json"
+	bucketChangesFeedConfigFile   = "changes-feed.json"
+	bucketAuditManifestConfigFile = "audit-manifest.json"
 )
 
 // PutBucketQuotaConfigHandler - PUT Bucket quota configuration.
@@ -151,6 +162,883 @@ func (a adminAPIHandlers) GetBucketQuotaConfigHandler(w http.ResponseWriter, r *
 	writeSuccessResponseJSON(w, configData)
 }
 
+// PutBucketTrashConfigHandler - PUT /minio/admin/v3/set-bucket-trash?bucket=mybucket
+// ----------
+// Configures the recycle bin of a non-versioned bucket, when enabled
+// deleted objects are kept for the configured TTL and can be undeleted.
+func (a adminAPIHandlers) PutBucketTrashConfigHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "PutBucketTrashConfig")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ConfigUpdateAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := pathClean(vars["bucket"])
+
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket, BucketOptions{}); err != nil {
+		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+		return
+	}
+
+	if _, err = parseBucketTrashConfig(data); err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrAdminConfigBadJSON, err), r.URL)
+		return
+	}
+
+	if _, err = globalBucketMetadataSys.Update(ctx, bucket, bucketTrashConfigFile, data); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// GetBucketTrashConfigHandler - GET /minio/admin/v3/get-bucket-trash?bucket=mybucket
+// ----------
+// Returns the recycle bin configuration of a bucket.
+func (a adminAPIHandlers) GetBucketTrashConfigHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetBucketTrashConfig")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServerInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := pathClean(vars["bucket"])
+
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket, BucketOptions{}); err != nil {
+		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	cfg, _, err := globalBucketMetadataSys.GetTrashConfig(bucket)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	if cfg == nil {
+		cfg = &bucketTrashConfig{}
+	}
+
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
+
+// ListBucketTrashHandler - GET /minio/admin/v3/list-bucket-trash?bucket=mybucket&prefix=myprefix
+// ----------
+// Lists the objects in the recycle bin of a bucket.
+func (a adminAPIHandlers) ListBucketTrashHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ListBucketTrash")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServerInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := pathClean(vars["bucket"])
+
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket, BucketOptions{}); err != nil {
+		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	entries, err := listBucketTrash(ctx, objectAPI, bucket, r.Form.Get("prefix"))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(entries)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
+
+// UndeleteObjectHandler - POST /minio/admin/v3/undelete-object?bucket=mybucket&id=entryid
+// ----------
+// Restores an object from the recycle bin of a bucket to its original
+// name, restoring over an existing object is refused.
+func (a adminAPIHandlers) UndeleteObjectHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "UndeleteObject")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ConfigUpdateAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := pathClean(vars["bucket"])
+
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket, BucketOptions{}); err != nil {
+		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	object, err := restoreObjectFromTrash(ctx, objectAPI, bucket, vars["id"])
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(struct {
+		Object string `json:"object"`
+	}{Object: object})
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
+
+// PutBucketInlineConfigHandler - PUT /minio/admin/v3/set-bucket-inline?bucket=mybucket
+// ----------
+// Overrides the shard size below which object data written to the bucket
+// is inlined in xl.meta, an empty body removes the override.
+func (a adminAPIHandlers) PutBucketInlineConfigHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "PutBucketInlineConfig")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ConfigUpdateAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := pathClean(vars["bucket"])
+
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket, BucketOptions{}); err != nil {
+		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+		return
+	}
+
+	if len(data) > 0 {
+		if _, err = parseBucketInlineConfig(data); err != nil {
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrAdminConfigBadJSON, err), r.URL)
+			return
+		}
+	} else {
+		data = nil
+	}
+
+	if _, err = globalBucketMetadataSys.Update(ctx, bucket, bucketInlineConfigFile, data); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// GetBucketInlineConfigHandler - GET /minio/admin/v3/get-bucket-inline?bucket=mybucket
+// ----------
+// Returns the inline data threshold override of a bucket.
+func (a adminAPIHandlers) GetBucketInlineConfigHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetBucketInlineConfig")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServerInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := pathClean(vars["bucket"])
+
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket, BucketOptions{}); err != nil {
+		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	cfg, _, err := globalBucketMetadataSys.GetInlineConfig(bucket)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	if cfg == nil {
+		cfg = &bucketInlineConfig{}
+	}
+
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
+
+// PutBucketSuspendConfigHandler - PUT /minio/admin/v3/set-bucket-suspend?bucket=mybucket
+// ----------
+// Suspends all S3 access or all S3 writes to the bucket, e.g. while it is
+// being abused, an empty body resumes the bucket.
+func (a adminAPIHandlers) PutBucketSuspendConfigHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "PutBucketSuspendConfig")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ConfigUpdateAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := pathClean(vars["bucket"])
+
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket, BucketOptions{}); err != nil {
+		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+		return
+	}
+
+	if len(data) > 0 {
+		cfg, err := parseBucketSuspendConfig(data)
+		if err != nil {
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrAdminConfigBadJSON, err), r.URL)
+			return
+		}
+		cfg.Since = UTCNow()
+		if data, err = json.Marshal(cfg); err != nil {
+			writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+			return
+		}
+	} else {
+		data = nil
+	}
+
+	if _, err = globalBucketMetadataSys.Update(ctx, bucket, bucketSuspendConfigFile, data); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// GetBucketSuspendConfigHandler - GET /minio/admin/v3/get-bucket-suspend?bucket=mybucket
+// ----------
+// Returns the suspend configuration of a bucket.
+func (a adminAPIHandlers) GetBucketSuspendConfigHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetBucketSuspendConfig")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServerInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := pathClean(vars["bucket"])
+
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket, BucketOptions{}); err != nil {
+		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	cfg, _, err := globalBucketMetadataSys.GetSuspendConfig(bucket)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	if cfg == nil {
+		cfg = &bucketSuspendConfig{}
+	}
+
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
+
+// PutBucketIndexerConfigHandler - PUT /minio/admin/v3/set-bucket-indexer?bucket=mybucket
+// ----------
+// Configures the external indexer object changes of the bucket are streamed
+// to and searches are proxied to, an empty body removes the indexer.
+func (a adminAPIHandlers) PutBucketIndexerConfigHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "PutBucketIndexerConfig")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ConfigUpdateAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := pathClean(vars["bucket"])
+
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket, BucketOptions{}); err != nil {
+		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+		return
+	}
+
+	if len(data) > 0 {
+		if _, err = parseBucketIndexerConfig(data); err != nil {
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrAdminConfigBadJSON, err), r.URL)
+			return
+		}
+	} else {
+		data = nil
+	}
+
+	if _, err = globalBucketMetadataSys.Update(ctx, bucket, bucketIndexerConfigFile, data); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// GetBucketIndexerConfigHandler - GET /minio/admin/v3/get-bucket-indexer?bucket=mybucket
+// ----------
+// Returns the external indexer of a bucket, the auth token is redacted.
+func (a adminAPIHandlers) GetBucketIndexerConfigHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetBucketIndexerConfig")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServerInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := pathClean(vars["bucket"])
+
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket, BucketOptions{}); err != nil {
+		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	cfg, _, err := globalBucketMetadataSys.GetIndexerConfig(bucket)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	var redacted bucketIndexerConfig
+	if cfg != nil {
+		redacted.Endpoint = cfg.Endpoint
+		if cfg.AuthToken != "" {
+			redacted.AuthToken = "*REDACTED*"
+		}
+	}
+
+	data, err := json.Marshal(redacted)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
+
+// PutBucketChangesFeedConfigHandler - PUT /minio/admin/v3/set-bucket-changes-feed?bucket=mybucket
+// ----------
+// Enables or disables the changes feed of the bucket, the recorded
+// changes are removed when the feed is disabled.
+func (a adminAPIHandlers) PutBucketChangesFeedConfigHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "PutBucketChangesFeedConfig")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ConfigUpdateAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := pathClean(vars["bucket"])
+
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket, BucketOptions{}); err != nil {
+		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+		return
+	}
+
+	var cfg *bucketChangesFeedConfig
+	if len(data) > 0 {
+		if cfg, err = parseBucketChangesFeedConfig(data); err != nil {
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrAdminConfigBadJSON, err), r.URL)
+			return
+		}
+	}
+	if cfg == nil {
+		data = nil
+	}
+
+	if _, err = globalBucketMetadataSys.Update(ctx, bucket, bucketChangesFeedConfigFile, data); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	if cfg == nil {
+		if err = deleteChangesFeed(ctx, objectAPI, bucket); err != nil {
+			writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+			return
+		}
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// GetBucketChangesFeedConfigHandler - GET /minio/admin/v3/get-bucket-changes-feed?bucket=mybucket
+// ----------
+// Returns the changes feed settings of a bucket.
+func (a adminAPIHandlers) GetBucketChangesFeedConfigHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetBucketChangesFeedConfig")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServerInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := pathClean(vars["bucket"])
+
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket, BucketOptions{}); err != nil {
+		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	cfg, _, err := globalBucketMetadataSys.GetChangesFeedConfig(bucket)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	if cfg == nil {
+		cfg = &bucketChangesFeedConfig{}
+	} else {
+		cfg = &bucketChangesFeedConfig{Enabled: true, Retention: cfg.retention.String()}
+	}
+
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
+
+// PutBucketAuditManifestConfigHandler - PUT /minio/admin/v3/set-bucket-audit-manifest?bucket=mybucket
+// ----------
+// Enables or disables the audit manifest of the bucket, recorded
+// segments are kept when the manifest is disabled.
+func (a adminAPIHandlers) PutBucketAuditManifestConfigHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "PutBucketAuditManifestConfig")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ConfigUpdateAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := pathClean(vars["bucket"])
+
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket, BucketOptions{}); err != nil {
+		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+		return
+	}
+
+	var cfg *bucketAuditManifestConfig
+	if len(data) > 0 {
+		if cfg, err = parseBucketAuditManifestConfig(data); err != nil {
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrAdminConfigBadJSON, err), r.URL)
+			return
+		}
+	}
+	if cfg == nil {
+		data = nil
+	}
+
+	if _, err = globalBucketMetadataSys.Update(ctx, bucket, bucketAuditManifestConfigFile, data); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// GetBucketAuditManifestConfigHandler - GET /minio/admin/v3/get-bucket-audit-manifest?bucket=mybucket
+// ----------
+// Returns the audit manifest settings of a bucket.
+func (a adminAPIHandlers) GetBucketAuditManifestConfigHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetBucketAuditManifestConfig")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServerInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := pathClean(vars["bucket"])
+
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket, BucketOptions{}); err != nil {
+		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	cfg, _, err := globalBucketMetadataSys.GetAuditManifestConfig(bucket)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	if cfg == nil {
+		cfg = &bucketAuditManifestConfig{}
+	} else {
+		cfg = &bucketAuditManifestConfig{Enabled: true, SealInterval: cfg.sealInterval.String()}
+	}
+
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
+
+// ReadBucketAuditManifestHandler - GET /minio/admin/v3/audit-manifest?bucket=mybucket&marker=marker&max=100
+// ----------
+// Returns the sealed segments of the audit manifest of a bucket, the
+// manifest of a deleted bucket can still be read.
+func (a adminAPIHandlers) ReadBucketAuditManifestHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ReadBucketAuditManifest")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServerInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := pathClean(vars["bucket"])
+	if s3utils.CheckValidBucketName(bucket) != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidBucketName), r.URL)
+		return
+	}
+
+	var maxSegments int
+	if v := r.Form.Get("max"); v != "" {
+		var err error
+		if maxSegments, err = strconv.Atoi(v); err != nil {
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidMaxKeys), r.URL)
+			return
+		}
+	}
+
+	page, err := readAuditManifest(ctx, objectAPI, bucket, r.Form.Get("marker"), maxSegments)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(page)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
+
+// VerifyBucketAuditManifestHandler - GET /minio/admin/v3/verify-audit-manifest?bucket=mybucket
+// ----------
+// Verifies the hash chains of the audit manifest of a bucket and
+// reports the result per node.
+func (a adminAPIHandlers) VerifyBucketAuditManifestHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "VerifyBucketAuditManifest")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServerInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := pathClean(vars["bucket"])
+	if s3utils.CheckValidBucketName(bucket) != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidBucketName), r.URL)
+		return
+	}
+
+	status, err := verifyAuditManifest(ctx, objectAPI, bucket)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(status)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
+
+// RotateAuditManifestKeyHandler - POST /minio/admin/v3/rotate-audit-manifest-key
+// ----------
+// Adds a new key audit manifest segments are sealed with from now on,
+// the segments sealed with the previous keys still verify.
+func (a adminAPIHandlers) RotateAuditManifestKeyHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "RotateAuditManifestKey")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ConfigUpdateAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	keyID, err := rotateAuditManifestKey(ctx, objectAPI)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(map[string]string{"keyId": keyID})
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
+
+// RetentionReportHandler - GET /minio/admin/v3/retention-report?bucket=mybucket&prefix=prefix&max=1000
+// ----------
+// Reports the object versions of an object lock enabled bucket whose
+// explicit retention deviates from the bucket default retention.
+func (a adminAPIHandlers) RetentionReportHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "RetentionReport")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServerInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := pathClean(vars["bucket"])
+
+	maxDeviations := 1000
+	if v := r.Form.Get("max"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+			return
+		}
+		maxDeviations = n
+	}
+
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket, BucketOptions{}); err != nil {
+		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	report, err := buildRetentionReport(ctx, objectAPI, bucket, r.Form.Get("prefix"), maxDeviations)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
+
+// SSEComplianceReportHandler - GET /minio/admin/v3/sse-compliance-report?bucket=mybucket
+// ----------
+// Returns the requests rejected by the bucket encryption compliance mode
+// on every node of the cluster.
+func (a adminAPIHandlers) SSEComplianceReportHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SSEComplianceReport")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServerInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := pathClean(vars["bucket"])
+
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket, BucketOptions{}); err != nil {
+		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	reports := []sseComplianceReport{globalSSEComplianceReporter.report(bucket)}
+	if globalNotificationSys != nil {
+		reports = append(reports, globalNotificationSys.GetSSEComplianceReports(ctx, bucket)...)
+	}
+
+	data, err := json.Marshal(reports)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
+
+// bucketVersioningExcludes - prefix-level versioning exclusions of a
+// bucket and the replication rules they conflict with.
+type bucketVersioningExcludes struct {
+	Bucket           string   `json:"bucket"`
+	Status           string   `json:"status,omitempty"`
+	ExcludedPrefixes []string `json:"excludedPrefixes,omitempty"`
+	ExcludeFolders   bool     `json:"excludeFolders,omitempty"`
+	Warnings         []string `json:"warnings,omitempty"`
+}
+
+func getBucketVersioningExcludes(ctx context.Context, bucket string) (bucketVersioningExcludes, error) {
+	excludes := bucketVersioningExcludes{Bucket: bucket}
+	vc, err := globalBucketVersioningSys.Get(bucket)
+	if err != nil {
+		return excludes, err
+	}
+	excludes.Status = string(vc.Status)
+	excludes.ExcludeFolders = vc.ExcludeFolders
+	for _, sprefix := range vc.ExcludedPrefixes {
+		excludes.ExcludedPrefixes = append(excludes.ExcludedPrefixes, sprefix.Prefix)
+	}
+	if rcfg, err := getReplicationConfig(ctx, bucket); err == nil {
+		excludes.Warnings = versioningReplicationWarnings(vc, rcfg)
+	}
+	return excludes, nil
+}
+
+// VersioningExcludesHandler - GET /minio/admin/v3/versioning-excludes?bucket=mybucket
+// ----------
+// Returns the prefixes excluded from versioning of the bucket, or of all
+// buckets excluding prefixes if no bucket is given, with a warning for
+// every replication rule which cannot replicate the excluded objects.
+func (a adminAPIHandlers) VersioningExcludesHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "VersioningExcludes")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServerInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	var buckets []string
+	single := r.Form.Get("bucket") != ""
+	if single {
+		bucket := pathClean(r.Form.Get("bucket"))
+		if _, err := objectAPI.GetBucketInfo(ctx, bucket, BucketOptions{}); err != nil {
+			writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+			return
+		}
+		buckets = append(buckets, bucket)
+	} else {
+		bucketsInfo, err := objectAPI.ListBuckets(ctx, BucketOptions{})
+		if err != nil {
+			writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+			return
+		}
+		for _, bi := range bucketsInfo {
+			buckets = append(buckets, bi.Name)
+		}
+	}
+
+	report := []bucketVersioningExcludes{}
+	for _, bucket := range buckets {
+		excludes, err := getBucketVersioningExcludes(ctx, bucket)
+		if err != nil {
+			writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+			return
+		}
+		if !single && len(excludes.ExcludedPrefixes) == 0 && !excludes.ExcludeFolders {
+			continue
+		}
+		report = append(report, excludes)
+	}
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
+
 // SetRemoteTargetHandler - sets a remote target for bucket
 func (a adminAPIHandlers) SetRemoteTargetHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "SetBucketTarget")
@@ -924,7 +1812,7 @@ func (a adminAPIHandlers) ImportBucketMetadataHandler(w http.ResponseWriter, r *
 			}
 
 			// Validate the transition storage ARNs
-			if err = validateTransitionTier(bucketLifecycle); err != nil {
+			if err = validateTransitionTier(bucket, bucketLifecycle); err != nil {
 				rpt.SetStatus(bucket, fileName, err)
 				continue
 			}
@@ -1082,6 +1970,69 @@ func (a adminAPIHandlers) ImportBucketMetadataHandler(w http.ResponseWriter, r *
 	writeSuccessResponseJSON(w, rptData)
 }
 
+// ImportAWSBucketConfigHandler - PUT converts an AWS export of the
+// configuration of a bucket (policy, lifecycle, CORS and notification)
+// to MinIO bucket metadata, creating the bucket if needed. Elements
+// without a MinIO equivalent are listed in the returned report.
+func (a adminAPIHandlers) ImportAWSBucketConfigHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ImportAWSBucketConfig")
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	if globalIsGateway {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ImportBucketMetadataAction)
+	if objectAPI == nil {
+		return
+	}
+
+	if s3utils.CheckValidBucketNameStrict(bucket) != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidBucketName), r.URL)
+		return
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r.Body, maxAWSBucketConfigSize+1))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+		return
+	}
+	if len(data) > maxAWSBucketConfigSize {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrEntityTooLarge), r.URL)
+		return
+	}
+
+	imp, err := convertAWSBucketConfig(bucket, data, awsBucketImportOptions{
+		Region:         globalSite.Region,
+		Targets:        globalEventNotifier.targetList,
+		EventBridgeARN: r.Form.Get("eventbridge-arn"),
+		ValidTier:      globalTierConfigMgr.IsTierValid,
+	})
+	if err != nil {
+		apiErr := errorCodes.ToAPIErr(ErrInvalidRequest)
+		apiErr.Description = err.Error()
+		writeErrorResponseJSON(ctx, w, apiErr, r.URL)
+		return
+	}
+
+	imp.report.DryRun = r.Form.Get("dry-run") == "true"
+	if !imp.report.DryRun {
+		imp.report.Imported = imp.apply(ctx, objectAPI, bucket)
+	}
+
+	rptData, err := json.Marshal(imp.report)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, rptData)
+}
+
 // ReplicationDiffHandler - POST returns info on unreplicated versions for a remote target ARN
 // to the connected HTTP client.
 func (a adminAPIHandlers) ReplicationDiffHandler(w http.ResponseWriter, r *http.Request) {
@@ -1150,3 +2101,71 @@ func (a adminAPIHandlers) ReplicationDiffHandler(w http.ResponseWriter, r *http.
 		}
 	}
 }
+
+// ReplicationDeletePreviewHandler - POST reports how many pending delete markers
+// and permanent deletes would be replicated under the replication config in the
+// request body, or the current config of the bucket if the body is empty.
+func (a adminAPIHandlers) ReplicationDeletePreviewHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ReplicationDeletePreview")
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	if globalIsGateway {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ReplicationDiff)
+	if objectAPI == nil {
+		return
+	}
+
+	// Check if bucket exists.
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket, BucketOptions{}); err != nil {
+		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	var cfg *replication.Config
+	var err error
+	if r.ContentLength > 0 {
+		cfg, err = replication.ParseConfig(io.LimitReader(r.Body, r.ContentLength))
+		if err != nil {
+			apiErr := errorCodes.ToAPIErr(ErrMalformedXML)
+			apiErr.Description = err.Error()
+			writeErrorResponseJSON(ctx, w, apiErr, r.URL)
+			return
+		}
+		sameTarget, apiErr := validateReplicationDestination(ctx, bucket, cfg, false)
+		if apiErr != noError {
+			writeErrorResponseJSON(ctx, w, apiErr, r.URL)
+			return
+		}
+		if err = cfg.Validate(bucket, sameTarget); err != nil {
+			writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+			return
+		}
+	} else {
+		cfg, err = getReplicationConfig(ctx, bucket)
+		if err != nil {
+			writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+			return
+		}
+	}
+
+	preview, err := previewDeleteReplication(ctx, objectAPI, bucket, r.Form.Get("prefix"), cfg)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(preview)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
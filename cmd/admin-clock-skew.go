@@ -0,0 +1,167 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	iampolicy "github.com/minio/pkg/iam/policy"
+	"github.com/qkbyte/minio/internal/logger"
+)
+
+// clockSkewDefaultThreshold is the clock offset above which a node is
+// reported unhealthy, unless overridden by the caller.
+const clockSkewDefaultThreshold = time.Second
+
+// nodeClockOffset is the clock offset of a peer relative to the node
+// serving the request, positive when the peer clock is ahead.
+type nodeClockOffset struct {
+	Node   string        `json:"node"`
+	Offset time.Duration `json:"offset"`
+	RTT    time.Duration `json:"rtt"`
+	Error  string        `json:"error,omitempty"`
+}
+
+// clockSkewReport compares the clocks of all nodes of the cluster.
+type clockSkewReport struct {
+	Node        string            `json:"node"`
+	Time        time.Time         `json:"time"`
+	AllowedSkew time.Duration     `json:"allowedSkew"`
+	Threshold   time.Duration     `json:"threshold"`
+	MaxOffset   time.Duration     `json:"maxOffset"`
+	Healthy     bool              `json:"healthy"`
+	Peers       []nodeClockOffset `json:"peers,omitempty"`
+}
+
+// estimateClockOffset estimates the offset of a remote clock from the
+// time it reported and the local send and receive times, assuming the
+// remote read its clock halfway through the round trip.
+func estimateClockOffset(sent, received, remote time.Time) (offset, rtt time.Duration) {
+	rtt = received.Sub(sent)
+	return remote.Sub(sent.Add(rtt / 2)), rtt
+}
+
+// newClockSkewReport summarizes the peer offsets, the cluster is healthy
+// if every peer answered and no clock is off by more than threshold.
+func newClockSkewReport(peers []nodeClockOffset, threshold time.Duration, now time.Time) clockSkewReport {
+	report := clockSkewReport{
+		Node:        globalLocalNodeName,
+		Time:        now,
+		AllowedSkew: globalAPIConfig.getRequestTimeSkew(),
+		Threshold:   threshold,
+		Healthy:     true,
+		Peers:       peers,
+	}
+	for _, peer := range peers {
+		if peer.Error != "" {
+			report.Healthy = false
+			continue
+		}
+		offset := peer.Offset
+		if offset < 0 {
+			offset = -offset
+		}
+		if offset > report.MaxOffset {
+			report.MaxOffset = offset
+		}
+	}
+	if report.MaxOffset > threshold {
+		report.Healthy = false
+	}
+	sort.Slice(report.Peers, func(i, j int) bool {
+		return report.Peers[i].Node < report.Peers[j].Node
+	})
+	return report
+}
+
+// GetClockOffsets measures the clock offset of all peers relative to
+// this node, errors are reported per peer.
+func (sys *NotificationSys) GetClockOffsets(ctx context.Context) []nodeClockOffset {
+	offsets := make([]nodeClockOffset, len(sys.peerClients))
+	var wg sync.WaitGroup
+	for index, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		wg.Add(1)
+		go func(index int, client *peerRESTClient) {
+			defer wg.Done()
+			offsets[index].Node = client.host.String()
+			sent := time.Now()
+			remote, err := client.GetLocalTime(ctx)
+			if err != nil {
+				offsets[index].Error = err.Error()
+				return
+			}
+			offsets[index].Offset, offsets[index].RTT = estimateClockOffset(sent, time.Now(), remote)
+		}(index, client)
+	}
+	wg.Wait()
+
+	result := offsets[:0]
+	for _, offset := range offsets {
+		if offset.Node != "" {
+			result = append(result, offset)
+		}
+	}
+	return result
+}
+
+// ClockSkewHandler - GET /minio/admin/v3/clock-skew?threshold=1s
+// ----------
+// Compares the clocks of all nodes with the clock of this node. Drifting
+// clocks make signed requests fail with RequestTimeTooSkewed, the report
+// helps telling such failures apart from credential problems.
+func (a adminAPIHandlers) ClockSkewHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ClockSkew")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServerInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	threshold := clockSkewDefaultThreshold
+	if v := r.Form.Get("threshold"); v != "" {
+		var err error
+		threshold, err = time.ParseDuration(v)
+		if err != nil || threshold <= 0 {
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+			return
+		}
+	}
+
+	var peers []nodeClockOffset
+	if globalNotificationSys != nil {
+		peers = globalNotificationSys.GetClockOffsets(ctx)
+	}
+
+	data, err := json.Marshal(newClockSkewReport(peers, threshold, UTCNow()))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
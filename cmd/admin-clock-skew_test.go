@@ -0,0 +1,115 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEstimateClockOffset(t *testing.T) {
+	sent := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	received := sent.Add(100 * time.Millisecond)
+
+	// Remote clock read halfway through the round trip and 2s ahead.
+	offset, rtt := estimateClockOffset(sent, received, sent.Add(50*time.Millisecond+2*time.Second))
+	if rtt != 100*time.Millisecond {
+		t.Fatalf("expected rtt of 100ms, got %s", rtt)
+	}
+	if offset != 2*time.Second {
+		t.Fatalf("expected offset of 2s, got %s", offset)
+	}
+
+	// Remote clock 3s behind.
+	offset, _ = estimateClockOffset(sent, received, sent.Add(50*time.Millisecond-3*time.Second))
+	if offset != -3*time.Second {
+		t.Fatalf("expected offset of -3s, got %s", offset)
+	}
+}
+
+func TestNewClockSkewReport(t *testing.T) {
+	testCases := []struct {
+		peers     []nodeClockOffset
+		maxOffset time.Duration
+		healthy   bool
+	}{
+		// (1) Single node deployment.
+		{healthy: true},
+		// (2) Peers within the threshold.
+		{
+			peers: []nodeClockOffset{
+				{Node: "node2:9000", Offset: 200 * time.Millisecond},
+				{Node: "node1:9000", Offset: -300 * time.Millisecond},
+			},
+			maxOffset: 300 * time.Millisecond,
+			healthy:   true,
+		},
+		// (3) A peer behind by more than the threshold.
+		{
+			peers: []nodeClockOffset{
+				{Node: "node1:9000", Offset: 100 * time.Millisecond},
+				{Node: "node2:9000", Offset: -5 * time.Second},
+			},
+			maxOffset: 5 * time.Second,
+			healthy:   false,
+		},
+		// (4) An unreachable peer.
+		{
+			peers: []nodeClockOffset{
+				{Node: "node1:9000", Offset: 100 * time.Millisecond},
+				{Node: "node2:9000", Error: "connection refused"},
+			},
+			maxOffset: 100 * time.Millisecond,
+			healthy:   false,
+		},
+	}
+
+	for i, testCase := range testCases {
+		report := newClockSkewReport(testCase.peers, time.Second, UTCNow())
+		if report.MaxOffset != testCase.maxOffset {
+			t.Errorf("Test %d: expected max offset %s, got %s", i+1, testCase.maxOffset, report.MaxOffset)
+		}
+		if report.Healthy != testCase.healthy {
+			t.Errorf("Test %d: expected healthy %v, got %v", i+1, testCase.healthy, report.Healthy)
+		}
+		for j := 1; j < len(report.Peers); j++ {
+			if report.Peers[j-1].Node > report.Peers[j].Node {
+				t.Errorf("Test %d: expected peers sorted by node", i+1)
+			}
+		}
+	}
+}
+
+func TestAuthFailureKind(t *testing.T) {
+	testCases := []struct {
+		errCode  APIErrorCode
+		expected string
+	}{
+		{ErrRequestTimeTooSkewed, authFailureClockSkew},
+		{ErrRequestNotReadyYet, authFailureClockSkew},
+		{ErrSignatureDoesNotMatch, authFailureCredentials},
+		{ErrInvalidAccessKeyID, authFailureCredentials},
+		{ErrMalformedDate, ""},
+		{ErrAccessDenied, ""},
+	}
+	for i, testCase := range testCases {
+		if kind := authFailureKind(testCase.errCode); kind != testCase.expected {
+			t.Errorf("Test %d: expected %q, got %q", i+1, testCase.expected, kind)
+		}
+	}
+}
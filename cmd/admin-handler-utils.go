@@ -130,6 +130,12 @@ func toAdminAPIErr(ctx context.Context, err error) APIError {
 				Description:    err.Error(),
 				HTTPStatusCode: http.StatusNotFound,
 			}
+		case errors.Is(err, errBucketTrashEntryNotFound):
+			apiErr = APIError{
+				Code:           "XMinioAdminNoSuchTrashEntry",
+				Description:    err.Error(),
+				HTTPStatusCode: http.StatusNotFound,
+			}
 		case errors.Is(err, errIAMActionNotAllowed):
 			apiErr = APIError{
 				Code:           "XMinioIAMActionNotAllowed",
@@ -148,6 +154,8 @@ func toAdminAPIErr(ctx context.Context, err error) APIError {
 				Description:    err.Error(),
 				HTTPStatusCode: http.StatusBadRequest,
 			}
+		case errors.Is(err, errServiceAccountLimitExceeded):
+			apiErr = errorCodes.ToAPIErr(ErrAdminServiceAccountLimitExceeded)
 		case errors.Is(err, errIAMNotInitialized):
 			apiErr = APIError{
 				Code:           "XMinioIAMNotInitialized",
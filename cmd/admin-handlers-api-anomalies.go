@@ -0,0 +1,56 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	iampolicy "github.com/minio/pkg/iam/policy"
+	"github.com/qkbyte/minio/internal/logger"
+)
+
+// APIAnomaliesHandler - GET /minio/admin/v3/api-anomalies
+// ----------
+// Returns the per API error rates and latencies of the last window
+// compared to their trailing baselines, along with the recent anomaly
+// alerts, for every node of the cluster.
+func (a adminAPIHandlers) APIAnomaliesHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "APIAnomalies")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServerInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	reports := []apiAnomalyReport{globalAPIAnomalyDetector.report(time.Now())}
+	if globalNotificationSys != nil {
+		reports = append(reports, globalNotificationSys.GetAPIAnomalies(ctx)...)
+	}
+
+	data, err := json.Marshal(reports)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
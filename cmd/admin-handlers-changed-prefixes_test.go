@@ -0,0 +1,58 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestChangedSinceOldestCycle(t *testing.T) {
+	now := time.Now()
+	cycleInfo := currentScannerCycle{
+		next: 10,
+		cycleCompleted: []time.Time{
+			now.Add(-3 * time.Hour), // cycle 7
+			now.Add(-2 * time.Hour), // cycle 8
+			now.Add(-1 * time.Hour), // cycle 9
+		},
+	}
+
+	testCases := []struct {
+		since  time.Time
+		oldest uint64
+		ok     bool
+	}{
+		// Changes after the last completed cycle are in the current filters.
+		{since: now.Add(-30 * time.Minute), oldest: 9, ok: true},
+		{since: now.Add(-90 * time.Minute), oldest: 8, ok: true},
+		{since: now.Add(-2 * time.Hour), oldest: 8, ok: true},
+		// Older than the completed cycles history.
+		{since: now.Add(-4 * time.Hour), ok: false},
+	}
+	for i, testCase := range testCases {
+		oldest, ok := changedSinceOldestCycle(cycleInfo, testCase.since)
+		if ok != testCase.ok || oldest != testCase.oldest {
+			t.Errorf("Test %d: expected (%d, %t), got (%d, %t)", i+1, testCase.oldest, testCase.ok, oldest, ok)
+		}
+	}
+
+	if _, ok := changedSinceOldestCycle(currentScannerCycle{next: 1}, now); ok {
+		t.Error("expected no result without completed cycles")
+	}
+}
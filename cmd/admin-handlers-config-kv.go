@@ -77,6 +77,7 @@ func (a adminAPIHandlers) DelConfigKVHandler(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	before := cfg.Clone()
 	if err = cfg.DelFrom(bytes.NewReader(kvBytes)); err != nil {
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
 		return
@@ -91,6 +92,7 @@ func (a adminAPIHandlers) DelConfigKVHandler(w http.ResponseWriter, r *http.Requ
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
 		return
 	}
+	recordConfigChange(ctx, objectAPI, r, cred, "DeleteConfigKV", subSys, before, cfg)
 
 	// freshly retrieve the config so that default values are loaded for reset config
 	if cfg, err = getValidConfig(objectAPI); err != nil {
@@ -148,6 +150,7 @@ func (a adminAPIHandlers) SetConfigKVHandler(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	before := cfg.Clone()
 	dynamic, err := cfg.ReadConfig(bytes.NewReader(kvBytes))
 	if err != nil {
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
@@ -170,6 +173,7 @@ func (a adminAPIHandlers) SetConfigKVHandler(w http.ResponseWriter, r *http.Requ
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
 		return
 	}
+	recordConfigChange(ctx, objectAPI, r, cred, "SetConfigKV", subSys, before, cfg)
 
 	// Write to the config input KV to history.
 	if err = saveServerConfigHistory(ctx, objectAPI, kvBytes); err != nil {
@@ -278,7 +282,7 @@ func (a adminAPIHandlers) RestoreConfigHistoryKVHandler(w http.ResponseWriter, r
 
 	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
 
-	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ConfigUpdateAdminAction)
+	objectAPI, cred := validateAdminReq(ctx, w, r, iampolicy.ConfigUpdateAdminAction)
 	if objectAPI == nil {
 		return
 	}
@@ -302,6 +306,7 @@ func (a adminAPIHandlers) RestoreConfigHistoryKVHandler(w http.ResponseWriter, r
 		return
 	}
 
+	before := cfg.Clone()
 	if _, err = cfg.ReadConfig(bytes.NewReader(kvBytes)); err != nil {
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
 		return
@@ -316,6 +321,7 @@ func (a adminAPIHandlers) RestoreConfigHistoryKVHandler(w http.ResponseWriter, r
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
 		return
 	}
+	recordConfigChange(ctx, objectAPI, r, cred, "RestoreConfigHistoryKV", "", before, cfg)
 
 	delServerConfigHistory(ctx, objectAPI, restoreID)
 }
@@ -412,6 +418,11 @@ func (a adminAPIHandlers) SetConfigHandler(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	before, err := readServerConfig(ctx, objectAPI)
+	if err != nil {
+		before = newServerConfig()
+	}
+
 	cfg := newServerConfig()
 	if _, err = cfg.ReadConfig(bytes.NewReader(kvBytes)); err != nil {
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
@@ -428,6 +439,7 @@ func (a adminAPIHandlers) SetConfigHandler(w http.ResponseWriter, r *http.Reques
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
 		return
 	}
+	recordConfigChange(ctx, objectAPI, r, cred, "SetConfig", "", before, cfg)
 
 	// Write to the config input KV to history.
 	if err = saveServerConfigHistory(ctx, objectAPI, kvBytes); err != nil {
@@ -452,13 +464,21 @@ func (a adminAPIHandlers) GetConfigHandler(w http.ResponseWriter, r *http.Reques
 
 	cfg := globalServerConfig.Clone()
 
-	var s strings.Builder
-	hkvs := config.HelpSubSysMap[""]
-	var count int
-	for _, hkv := range hkvs {
-		count += len(cfg[hkv.Key])
+	password := cred.SecretKey
+	econfigData, err := madmin.EncryptData(password, []byte(serverConfigText(cfg)))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
 	}
-	for _, hkv := range hkvs {
+
+	writeSuccessResponseJSON(w, econfigData)
+}
+
+// serverConfigText returns the configuration of all sub-systems in
+// the format accepted by SetConfigHandler.
+func serverConfigText(cfg config.Config) string {
+	var s strings.Builder
+	for _, hkv := range config.HelpSubSysMap[""] {
 		// We ignore the error below, as we cannot get one.
 		cfgSubsysItems, _ := cfg.GetSubsysInfo(hkv.Key, "")
 
@@ -485,13 +505,5 @@ func (a adminAPIHandlers) GetConfigHandler(w http.ResponseWriter, r *http.Reques
 			item.AddString(&s, off)
 		}
 	}
-
-	password := cred.SecretKey
-	econfigData, err := madmin.EncryptData(password, []byte(s.String()))
-	if err != nil {
-		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
-		return
-	}
-
-	writeSuccessResponseJSON(w, econfigData)
+	return s.String()
 }
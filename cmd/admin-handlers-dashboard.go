@@ -0,0 +1,261 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	iampolicy "github.com/minio/pkg/iam/policy"
+	"github.com/qkbyte/minio/internal/logger"
+)
+
+const (
+	dashboardDefaultCount = 5
+	dashboardMaxCount     = 100
+)
+
+// dashboardCapacity is the raw and usable capacity of the cluster in bytes.
+type dashboardCapacity struct {
+	Raw        uint64 `json:"raw"`
+	RawFree    uint64 `json:"rawFree"`
+	Usable     uint64 `json:"usable"`
+	UsableFree uint64 `json:"usableFree"`
+}
+
+// dashboardDrives counts the drives of the cluster by state.
+type dashboardDrives struct {
+	Online  int `json:"online"`
+	Offline int `json:"offline"`
+	Healing int `json:"healing"`
+}
+
+// dashboardUsage is the usage last computed by the scanner.
+type dashboardUsage struct {
+	LastUpdate time.Time `json:"lastUpdate"`
+	Buckets    uint64    `json:"buckets"`
+	Objects    uint64    `json:"objects"`
+	Versions   uint64    `json:"versions"`
+	Size       uint64    `json:"size"`
+}
+
+type dashboardBucket struct {
+	Name    string `json:"name"`
+	Size    uint64 `json:"size"`
+	Objects uint64 `json:"objects"`
+}
+
+// dashboardHeal is the healing backlog, objects left on drives being
+// healed and operations queued for the most recent failures (MRF).
+type dashboardHeal struct {
+	Backlog         int    `json:"backlog"`
+	MRFPendingItems uint64 `json:"mrfPendingItems"`
+	MRFPendingBytes uint64 `json:"mrfPendingBytes"`
+}
+
+// dashboardReplication is the replication backlog of all buckets.
+type dashboardReplication struct {
+	PendingCount uint64 `json:"pendingCount"`
+	PendingSize  uint64 `json:"pendingSize"`
+	FailedCount  uint64 `json:"failedCount"`
+	FailedSize   uint64 `json:"failedSize"`
+}
+
+// dashboardError is an error recently logged by a node.
+type dashboardError struct {
+	Node    string    `json:"node"`
+	Time    time.Time `json:"time"`
+	API     string    `json:"api,omitempty"`
+	Message string    `json:"message"`
+}
+
+// dashboardSummary is a compact view of the cluster health, enough to
+// render the console dashboard without a Prometheus deployment.
+type dashboardSummary struct {
+	Time         time.Time            `json:"time"`
+	Capacity     dashboardCapacity    `json:"capacity"`
+	Drives       dashboardDrives      `json:"drives"`
+	Usage        dashboardUsage       `json:"usage"`
+	TopBuckets   []dashboardBucket    `json:"topBuckets"`
+	Heal         dashboardHeal        `json:"heal"`
+	Replication  dashboardReplication `json:"replication"`
+	RecentErrors []dashboardError     `json:"recentErrors"`
+	NodeErrors   map[string]string    `json:"nodeErrors,omitempty"`
+}
+
+// localRecentErrors returns the n most recent errors kept in the
+// console log buffer of this node, newest first.
+func localRecentErrors(n int) []dashboardError {
+	var errs []dashboardError
+	for _, entry := range globalConsoleSys.Content() {
+		if entry.Level != logger.ErrorLvl.String() {
+			continue
+		}
+		e := dashboardError{
+			Node:    globalLocalNodeName,
+			Time:    entry.Time,
+			Message: entry.Message,
+		}
+		if entry.Trace != nil && entry.Trace.Message != "" {
+			e.Message = entry.Trace.Message
+		}
+		if entry.API != nil {
+			e.API = entry.API.Name
+		}
+		errs = append(errs, e)
+	}
+	return newestErrors(errs, n)
+}
+
+// newestErrors sorts errs newest first and keeps at most n of them.
+func newestErrors(errs []dashboardError, n int) []dashboardError {
+	sort.SliceStable(errs, func(i, j int) bool {
+		return errs[i].Time.After(errs[j].Time)
+	})
+	if len(errs) > n {
+		errs = errs[:n]
+	}
+	return errs
+}
+
+// topBuckets returns the n largest buckets.
+func topBuckets(usage map[string]BucketUsageInfo, n int) []dashboardBucket {
+	buckets := make([]dashboardBucket, 0, len(usage))
+	for name, u := range usage {
+		buckets = append(buckets, dashboardBucket{Name: name, Size: u.Size, Objects: u.ObjectsCount})
+	}
+	sort.Slice(buckets, func(i, j int) bool {
+		if buckets[i].Size != buckets[j].Size {
+			return buckets[i].Size > buckets[j].Size
+		}
+		return buckets[i].Name < buckets[j].Name
+	})
+	if len(buckets) > n {
+		buckets = buckets[:n]
+	}
+	return buckets
+}
+
+// newDashboardSummary gathers the dashboard summary, sources that are
+// not available are logged and left empty.
+func newDashboardSummary(ctx context.Context, objAPI ObjectLayer, n int) dashboardSummary {
+	summary := dashboardSummary{
+		Time:         UTCNow(),
+		TopBuckets:   []dashboardBucket{},
+		RecentErrors: []dashboardError{},
+	}
+
+	storageInfo, _ := objAPI.StorageInfo(ctx)
+	summary.Capacity = dashboardCapacity{
+		Raw:        GetTotalCapacity(storageInfo.Disks),
+		RawFree:    GetTotalCapacityFree(storageInfo.Disks),
+		Usable:     GetTotalUsableCapacity(storageInfo.Disks, storageInfo),
+		UsableFree: GetTotalUsableCapacityFree(storageInfo.Disks, storageInfo),
+	}
+	onlineDisks, offlineDisks := getOnlineOfflineDisksStats(storageInfo.Disks)
+	summary.Drives.Online = onlineDisks.Sum()
+	summary.Drives.Offline = offlineDisks.Sum()
+	for _, disk := range storageInfo.Disks {
+		if disk.Healing {
+			summary.Drives.Healing++
+		}
+	}
+
+	if usage, err := loadDataUsageFromBackend(ctx, objAPI); err != nil {
+		logger.LogIf(ctx, err)
+	} else {
+		summary.Usage = dashboardUsage{
+			LastUpdate: usage.LastUpdate,
+			Buckets:    usage.BucketsCount,
+			Objects:    usage.ObjectsTotalCount,
+			Versions:   usage.VersionsTotalCount,
+			Size:       usage.ObjectsTotalSize,
+		}
+		summary.TopBuckets = topBuckets(usage.BucketsUsage, n)
+		for _, tgt := range usage.ReplicationInfo {
+			summary.Replication.PendingCount += tgt.ReplicationPendingCount
+			summary.Replication.PendingSize += tgt.ReplicationPendingSize
+			summary.Replication.FailedCount += tgt.ReplicationFailedCount
+			summary.Replication.FailedSize += tgt.ReplicationFailedSize
+		}
+	}
+
+	// Background healing only runs on erasure coded setups.
+	if healState, err := getAggregatedBackgroundHealState(ctx, objAPI); err != nil {
+		if err != errServerNotInitialized {
+			logger.LogIf(ctx, err)
+		}
+	} else {
+		for _, set := range healState.Sets {
+			summary.Heal.Backlog += set.TotalObjects
+		}
+		for _, mrf := range healState.MRF {
+			summary.Heal.MRFPendingItems += mrf.TotalItems - mrf.ItemsHealed
+			summary.Heal.MRFPendingBytes += mrf.TotalBytes - mrf.BytesHealed
+		}
+	}
+
+	recentErrors := localRecentErrors(n)
+	if globalNotificationSys != nil {
+		var peerErrors []dashboardError
+		peerErrors, summary.NodeErrors = globalNotificationSys.GetRecentErrors(ctx, n)
+		recentErrors = append(recentErrors, peerErrors...)
+	}
+	if len(recentErrors) > 0 {
+		summary.RecentErrors = newestErrors(recentErrors, n)
+	}
+	return summary
+}
+
+// DashboardHandler - GET /minio/admin/v3/dashboard?n=5
+// ----------
+// Returns a compact summary of capacity, drives, usage, the n largest
+// buckets, healing and replication backlogs and the n most recent
+// errors of all nodes, as shown by the embedded console dashboard.
+func (a adminAPIHandlers) DashboardHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "Dashboard")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServerInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	n := dashboardDefaultCount
+	if v := r.Form.Get("n"); v != "" {
+		var err error
+		n, err = strconv.Atoi(v)
+		if err != nil || n <= 0 || n > dashboardMaxCount {
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+			return
+		}
+	}
+
+	data, err := json.Marshal(newDashboardSummary(ctx, objectAPI, n))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDashboardTopBuckets(t *testing.T) {
+	usage := map[string]BucketUsageInfo{
+		"logs":    {Size: 100, ObjectsCount: 10},
+		"backups": {Size: 500, ObjectsCount: 2},
+		"media":   {Size: 100, ObjectsCount: 1},
+		"tmp":     {Size: 1},
+	}
+	buckets := topBuckets(usage, 3)
+	if len(buckets) != 3 {
+		t.Fatalf("expected 3 buckets, got %d", len(buckets))
+	}
+	for i, name := range []string{"backups", "logs", "media"} {
+		if buckets[i].Name != name {
+			t.Errorf("expected bucket %d to be %s, got %s", i, name, buckets[i].Name)
+		}
+	}
+	if buckets[0].Objects != 2 {
+		t.Errorf("expected 2 objects in backups, got %d", buckets[0].Objects)
+	}
+}
+
+func TestDashboardNewestErrors(t *testing.T) {
+	now := time.Now()
+	errs := []dashboardError{
+		{Node: "node1", Time: now.Add(-time.Minute), Message: "old"},
+		{Node: "node2", Time: now, Message: "new"},
+		{Node: "node1", Time: now.Add(-time.Hour), Message: "oldest"},
+	}
+	errs = newestErrors(errs, 2)
+	if len(errs) != 2 || errs[0].Message != "new" || errs[1].Message != "old" {
+		t.Fatalf("unexpected errors %+v", errs)
+	}
+}
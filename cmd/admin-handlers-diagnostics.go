@@ -0,0 +1,310 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/klauspost/compress/zip"
+	"github.com/minio/madmin-go"
+	iampolicy "github.com/minio/pkg/iam/policy"
+	xhttp "github.com/qkbyte/minio/internal/http"
+	"github.com/qkbyte/minio/internal/logger"
+	"github.com/qkbyte/minio/internal/logger/message/log"
+)
+
+const (
+	diagDefaultLogCount = 1000
+	diagMaxLogCount     = 10000
+
+	// diagMaxNetperfDuration caps the network throughput test, which
+	// saturates the links between all nodes while it runs.
+	diagMaxNetperfDuration = time.Minute
+
+	// diagCollectTimeout bounds the time spent collecting each section
+	// of the bundle, unreachable nodes must not stall it.
+	diagCollectTimeout = 30 * time.Second
+)
+
+// diagLogEntry is a console log entry of a node, stripped of the trace
+// variables and object metadata which may hold sensitive values.
+type diagLogEntry struct {
+	Node string `json:"node"`
+	log.Entry
+}
+
+// newDiagLogEntry drops request variables and object metadata from the
+// entry, they may carry user data and are not needed for diagnosis.
+func newDiagLogEntry(node string, entry log.Entry) diagLogEntry {
+	if entry.Trace != nil {
+		trace := *entry.Trace
+		trace.Variables = nil
+		entry.Trace = &trace
+	}
+	if entry.API != nil && entry.API.Args != nil {
+		api, args := *entry.API, *entry.API.Args
+		args.Metadata = nil
+		api.Args = &args
+		entry.API = &api
+	}
+	return diagLogEntry{Node: node, Entry: entry}
+}
+
+// localRecentLogs returns the n most recent entries kept in the console
+// log buffer of this node, oldest first.
+func localRecentLogs(n int) []diagLogEntry {
+	var entries []diagLogEntry
+	for _, entry := range globalConsoleSys.Content() {
+		entries = append(entries, newDiagLogEntry(globalLocalNodeName, entry))
+	}
+	return newestLogs(entries, n)
+}
+
+// newestLogs keeps the n newest entries, sorted oldest first.
+func newestLogs(entries []diagLogEntry, n int) []diagLogEntry {
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Time.Before(entries[j].Time)
+	})
+	if len(entries) > n {
+		entries = entries[len(entries)-n:]
+	}
+	return entries
+}
+
+// diagVersionInfo identifies the deployment and the release of each node.
+type diagVersionInfo struct {
+	DeploymentID string    `json:"deploymentID"`
+	GeneratedAt  time.Time `json:"generatedAt"`
+	Version      string    `json:"version"`
+	ReleaseTag   string    `json:"releaseTag"`
+	CommitID     string    `json:"commitID"`
+	GoVersion    string    `json:"goVersion"`
+	Platform     string    `json:"platform"`
+
+	Nodes []diagNodeInfo `json:"nodes"`
+}
+
+type diagNodeInfo struct {
+	Endpoint string `json:"endpoint"`
+	State    string `json:"state"`
+	Version  string `json:"version,omitempty"`
+	CommitID string `json:"commitID,omitempty"`
+	Uptime   int64  `json:"uptime,omitempty"`
+}
+
+// diagnosticsBundle assembles the sections of a diagnostics bundle, the
+// sections that cannot be collected are listed in errors.json instead
+// of failing the bundle.
+type diagnosticsBundle struct {
+	buf    bytes.Buffer
+	zw     *zip.Writer
+	errors map[string]string
+}
+
+func newDiagnosticsBundle() *diagnosticsBundle {
+	b := &diagnosticsBundle{errors: make(map[string]string)}
+	b.zw = zip.NewWriter(&b.buf)
+	return b
+}
+
+func (b *diagnosticsBundle) addError(section string, err error) {
+	b.errors[section] = err.Error()
+}
+
+func (b *diagnosticsBundle) addFile(name string, data []byte) {
+	if err := embedFileInZip(b.zw, name, data); err != nil {
+		b.addError(name, err)
+	}
+}
+
+func (b *diagnosticsBundle) addJSON(name string, v interface{}) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		b.addError(name, err)
+		return
+	}
+	b.addFile(name, data)
+}
+
+func (b *diagnosticsBundle) close() ([]byte, error) {
+	if len(b.errors) > 0 {
+		b.addJSON("errors.json", b.errors)
+	}
+	if err := b.zw.Close(); err != nil {
+		return nil, err
+	}
+	return b.buf.Bytes(), nil
+}
+
+func collectDiagVersionInfo(r *http.Request) diagVersionInfo {
+	info := diagVersionInfo{
+		DeploymentID: globalDeploymentID,
+		GeneratedAt:  UTCNow(),
+		Version:      Version,
+		ReleaseTag:   ReleaseTag,
+		CommitID:     CommitID,
+		GoVersion:    runtime.Version(),
+		Platform:     fmt.Sprintf("%s/%s", runtime.GOOS, runtime.GOARCH),
+	}
+	servers := []madmin.ServerProperties{getLocalServerProperty(globalEndpoints, r)}
+	if globalNotificationSys != nil {
+		servers = append(servers, globalNotificationSys.ServerInfo()...)
+	}
+	for _, server := range servers {
+		info.Nodes = append(info.Nodes, diagNodeInfo{
+			Endpoint: server.Endpoint,
+			State:    server.State,
+			Version:  server.Version,
+			CommitID: server.CommitID,
+			Uptime:   server.Uptime,
+		})
+	}
+	return info
+}
+
+// DiagnosticsBundleHandler - GET /minio/admin/v3/diagnostics-bundle?logs={n}&netperf={duration}
+// ----------
+// Returns a zip archive with the redacted configuration, the recent
+// console logs of all nodes, the storage and heal status and the
+// version of each node, for offline support without SUBNET. A network
+// throughput test of at most a minute is included when a netperf
+// duration is given.
+func (a adminAPIHandlers) DiagnosticsBundleHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "DiagnosticsBundle")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.HealthInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	logCount := diagDefaultLogCount
+	if v := r.Form.Get("logs"); v != "" {
+		var err error
+		logCount, err = strconv.Atoi(v)
+		if err != nil || logCount < 0 || logCount > diagMaxLogCount {
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+			return
+		}
+	}
+
+	var netperfDuration time.Duration
+	if v := r.Form.Get("netperf"); v != "" {
+		var err error
+		netperfDuration, err = time.ParseDuration(v)
+		if err != nil || netperfDuration < 0 {
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+			return
+		}
+	}
+
+	b := newDiagnosticsBundle()
+
+	b.addJSON("version.json", collectDiagVersionInfo(r))
+
+	// Secrets are redacted and the root credentials removed.
+	globalServerConfigMu.RLock()
+	cfg := globalServerConfig.Clone()
+	globalServerConfigMu.RUnlock()
+	b.addFile("config.txt", []byte(serverConfigText(cfg.RedactSensitiveInfo())))
+
+	if logCount > 0 {
+		logs := localRecentLogs(logCount)
+		if globalNotificationSys != nil {
+			lctx, cancel := context.WithTimeout(ctx, diagCollectTimeout)
+			peerLogs, nodeErrs := globalNotificationSys.GetRecentLogs(lctx, logCount)
+			cancel()
+			logs = append(logs, peerLogs...)
+			for node, err := range nodeErrs {
+				b.errors["logs.json:"+node] = err
+			}
+		}
+		b.addJSON("logs.json", newestLogs(logs, logCount))
+	}
+
+	sctx, cancel := context.WithTimeout(ctx, diagCollectTimeout)
+	storageInfo, errs := objectAPI.StorageInfo(sctx)
+	cancel()
+	for _, err := range errs {
+		if err != nil {
+			b.addError("storage.json", err)
+			break
+		}
+	}
+	b.addJSON("storage.json", storageInfo)
+
+	if !globalIsGateway {
+		hctx, cancel := context.WithTimeout(ctx, diagCollectTimeout)
+		healState, err := getAggregatedBackgroundHealState(hctx, objectAPI)
+		cancel()
+		if err != nil {
+			b.addError("heal.json", err)
+		} else {
+			b.addJSON("heal.json", healState)
+		}
+	}
+
+	if netperfDuration > 0 {
+		if !globalIsDistErasure {
+			b.addError("netperf.json", errors.New("network test requires a distributed setup"))
+		} else if result, err := diagNetperf(ctx, objectAPI, netperfDuration); err != nil {
+			b.addError("netperf.json", err)
+		} else {
+			b.addJSON("netperf.json", result)
+		}
+	}
+
+	data, err := b.close()
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	filename := fmt.Sprintf("diagnostics-%s-%s.zip", globalDeploymentID, UTCNow().Format("20060102150405"))
+	w.Header().Set(xhttp.ContentDisposition, fmt.Sprintf("attachment; filename=%q", filename))
+	writeResponse(w, http.StatusOK, data, mimeZip)
+}
+
+// diagNetperf runs the mesh network throughput test, only one may run
+// in the cluster at a time.
+func diagNetperf(ctx context.Context, objectAPI ObjectLayer, duration time.Duration) (madmin.NetperfResult, error) {
+	nsLock := objectAPI.NewNSLock(minioMetaBucket, "netperf")
+	lkctx, err := nsLock.GetLock(ctx, globalOperationTimeout)
+	if err != nil {
+		return madmin.NetperfResult{}, err
+	}
+	defer nsLock.Unlock(lkctx.Cancel)
+
+	if duration < globalNetPerfMinDuration {
+		duration = globalNetPerfMinDuration
+	}
+	if duration > diagMaxNetperfDuration {
+		duration = diagMaxNetperfDuration
+	}
+	return madmin.NetperfResult{NodeResults: globalNotificationSys.Netperf(lkctx.Context(), duration.Round(time.Second))}, nil
+}
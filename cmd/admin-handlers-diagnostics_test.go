@@ -0,0 +1,104 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/zip"
+	"github.com/qkbyte/minio/internal/logger/message/log"
+)
+
+func TestDiagLogEntrySanitized(t *testing.T) {
+	entry := log.Entry{
+		Trace: &log.Trace{Message: "boom", Variables: map[string]interface{}{"secret": "x"}},
+		API: &log.API{Name: "PutObject", Args: &log.Args{
+			Bucket:   "bucket",
+			Metadata: map[string]string{"x-amz-meta-secret": "x"},
+		}},
+	}
+	got := newDiagLogEntry("node1", entry)
+	if got.Node != "node1" || got.Trace.Message != "boom" || got.API.Args.Bucket != "bucket" {
+		t.Fatalf("unexpected entry %+v", got)
+	}
+	if got.Trace.Variables != nil || got.API.Args.Metadata != nil {
+		t.Fatal("expected variables and metadata to be removed")
+	}
+	if entry.Trace.Variables == nil || entry.API.Args.Metadata == nil {
+		t.Fatal("original entry must not be modified")
+	}
+}
+
+func TestDiagNewestLogs(t *testing.T) {
+	now := time.Now()
+	entries := []diagLogEntry{
+		{Node: "node1", Entry: log.Entry{Time: now, Message: "new"}},
+		{Node: "node2", Entry: log.Entry{Time: now.Add(-time.Hour), Message: "oldest"}},
+		{Node: "node1", Entry: log.Entry{Time: now.Add(-time.Minute), Message: "old"}},
+	}
+	entries = newestLogs(entries, 2)
+	if len(entries) != 2 || entries[0].Message != "old" || entries[1].Message != "new" {
+		t.Fatalf("unexpected entries %+v", entries)
+	}
+}
+
+func TestDiagnosticsBundle(t *testing.T) {
+	b := newDiagnosticsBundle()
+	b.addFile("config.txt", []byte("region name=us-east-1\n"))
+	b.addJSON("storage.json", map[string]int{"disks": 4})
+	b.addError("heal.json", errors.New("heal status unavailable"))
+	data, err := b.close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := make(map[string][]byte)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[f.Name], err = io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(files) != 3 {
+		t.Fatalf("expected 3 files, got %d", len(files))
+	}
+	if string(files["config.txt"]) != "region name=us-east-1\n" {
+		t.Errorf("unexpected config.txt %q", files["config.txt"])
+	}
+	var errs map[string]string
+	if err := json.Unmarshal(files["errors.json"], &errs); err != nil {
+		t.Fatal(err)
+	}
+	if errs["heal.json"] != "heal status unavailable" {
+		t.Errorf("unexpected errors.json %v", errs)
+	}
+}
@@ -0,0 +1,228 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/minio/minio-go/v7/pkg/set"
+	iampolicy "github.com/minio/pkg/iam/policy"
+	"github.com/qkbyte/minio/internal/config/dns"
+	xhttp "github.com/qkbyte/minio/internal/http"
+	"github.com/qkbyte/minio/internal/logger"
+)
+
+// bucketDNSRecords is the response of the bucket DNS admin APIs.
+type bucketDNSRecords struct {
+	Backend    string                     `json:"backend"`
+	Federation bool                       `json:"federation"`
+	Records    map[string][]dns.SrvRecord `json:"records,omitempty"`
+}
+
+// toDNSAPIErr maps errors of the bucket DNS store to API errors.
+func toDNSAPIErr(ctx context.Context, err error) APIError {
+	if errors.Is(err, dns.ErrNotImplemented) {
+		return errorCodes.ToAPIErr(ErrNotImplemented)
+	}
+	return toAdminAPIErr(ctx, err)
+}
+
+// validateDNSAdminReq validates the admin request and that a bucket
+// DNS store is configured.
+func validateDNSAdminReq(ctx context.Context, w http.ResponseWriter, r *http.Request, action iampolicy.AdminAction) ObjectLayer {
+	objectAPI, _ := validateAdminReq(ctx, w, r, action)
+	if objectAPI == nil {
+		return nil
+	}
+	if globalDNSConfig == nil {
+		apiErr := errorCodes.ToAPIErr(ErrNotImplemented)
+		apiErr.Description = "Bucket DNS is not configured"
+		writeErrorResponseJSON(ctx, w, apiErr, r.URL)
+		return nil
+	}
+	return objectAPI
+}
+
+// ListBucketDNSHandler - GET /minio/admin/v3/dns/records?bucket=mybucket
+// ----------
+// Lists the DNS records of all buckets in the bucket DNS store, or of
+// a single bucket, along with the name of the store.
+func (a adminAPIHandlers) ListBucketDNSHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ListBucketDNS")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	if validateDNSAdminReq(ctx, w, r, iampolicy.ServerInfoAdminAction) == nil {
+		return
+	}
+
+	info := bucketDNSRecords{
+		Backend:    globalDNSConfig.String(),
+		Federation: globalBucketFederation,
+	}
+	if bucket := r.Form.Get("bucket"); bucket != "" {
+		records, err := globalDNSConfig.Get(bucket)
+		if err != nil && err != dns.ErrNoEntriesFound {
+			writeErrorResponseJSON(ctx, w, toDNSAPIErr(ctx, err), r.URL)
+			return
+		}
+		if len(records) > 0 {
+			info.Records = map[string][]dns.SrvRecord{bucket: records}
+		}
+	} else {
+		records, err := globalDNSConfig.List()
+		if err != nil && !IsErrIgnored(err, dns.ErrNoEntriesFound, dns.ErrDomainMissing) {
+			writeErrorResponseJSON(ctx, w, toDNSAPIErr(ctx, err), r.URL)
+			return
+		}
+		info.Records = records
+	}
+
+	data, err := json.Marshal(info)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, data)
+}
+
+// RegisterBucketDNSHandler - PUT /minio/admin/v3/dns/records?bucket=mybucket
+// ----------
+// Registers a local bucket in the bucket DNS store, replacing the
+// records of this deployment. Fails if the bucket is registered by
+// another deployment.
+func (a adminAPIHandlers) RegisterBucketDNSHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "RegisterBucketDNS")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI := validateDNSAdminReq(ctx, w, r, iampolicy.ConfigUpdateAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	bucket := r.Form.Get("bucket")
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket, BucketOptions{}); err != nil {
+		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	records, err := globalDNSConfig.Get(bucket)
+	if err != nil && !IsErrIgnored(err, dns.ErrNoEntriesFound, dns.ErrNotImplemented) {
+		writeErrorResponseJSON(ctx, w, toDNSAPIErr(ctx, err), r.URL)
+		return
+	}
+	if len(records) > 0 && globalDomainIPs.Intersection(set.CreateStringSet(getHostsSlice(records)...)).IsEmpty() {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrBucketAlreadyExists), r.URL)
+		return
+	}
+
+	if err = globalDNSConfig.Put(bucket); err != nil {
+		writeErrorResponseJSON(ctx, w, toDNSAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// UnregisterBucketDNSHandler - DELETE /minio/admin/v3/dns/records?bucket=mybucket
+// ----------
+// Removes the records of a bucket of this deployment from the bucket
+// DNS store, the bucket itself is left as is.
+func (a adminAPIHandlers) UnregisterBucketDNSHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "UnregisterBucketDNS")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	if validateDNSAdminReq(ctx, w, r, iampolicy.ConfigUpdateAdminAction) == nil {
+		return
+	}
+
+	bucket := r.Form.Get("bucket")
+	records, err := globalDNSConfig.Get(bucket)
+	if err != nil && !IsErrIgnored(err, dns.ErrNoEntriesFound, dns.ErrNotImplemented) {
+		writeErrorResponseJSON(ctx, w, toDNSAPIErr(ctx, err), r.URL)
+		return
+	}
+	// Records of other deployments are theirs to remove.
+	if len(records) > 0 && globalDomainIPs.Intersection(set.CreateStringSet(getHostsSlice(records)...)).IsEmpty() {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAccessDenied), r.URL)
+		return
+	}
+
+	if err = globalDNSConfig.Delete(bucket); err != nil {
+		writeErrorResponseJSON(ctx, w, toDNSAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessNoContent(w)
+}
+
+// SyncBucketDNSHandler - POST /minio/admin/v3/dns/sync
+// ----------
+// Reconciles the bucket DNS store with the local buckets, as done at
+// startup: missing buckets are registered and records of buckets which
+// no longer exist are removed.
+func (a adminAPIHandlers) SyncBucketDNSHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SyncBucketDNS")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI := validateDNSAdminReq(ctx, w, r, iampolicy.ConfigUpdateAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	buckets, err := objectAPI.ListBuckets(ctx, BucketOptions{})
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	initFederatorBackend(buckets, objectAPI)
+	writeSuccessNoContent(w)
+}
+
+// ExportBucketDNSZoneHandler - GET /minio/admin/v3/dns/zone
+// ----------
+// Exports the records of the bucket DNS store as an RFC 1035 zone file,
+// to be loaded by an authoritative DNS server.
+func (a adminAPIHandlers) ExportBucketDNSZoneHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ExportBucketDNSZone")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	if validateDNSAdminReq(ctx, w, r, iampolicy.ServerInfoAdminAction) == nil {
+		return
+	}
+
+	records, err := globalDNSConfig.List()
+	if err != nil && !IsErrIgnored(err, dns.ErrNoEntriesFound, dns.ErrDomainMissing) {
+		writeErrorResponseJSON(ctx, w, toDNSAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err = dns.WriteZone(&buf, globalDomainNames, records); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	w.Header().Set(xhttp.ContentType, "text/dns")
+	writeResponse(w, http.StatusOK, buf.Bytes(), mimeNone)
+}
@@ -0,0 +1,137 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	iampolicy "github.com/minio/pkg/iam/policy"
+	"github.com/qkbyte/minio/internal/logger"
+)
+
+// Drive identity states reported by the drive identity admin API.
+const (
+	driveIdentityOK          = "ok"
+	driveIdentityOffline     = "offline"
+	driveIdentityUnformatted = "unformatted"
+	driveIdentityMismatch    = "mismatch"
+	driveIdentityUnknown     = "unknown"
+)
+
+// DriveIdentity maps a physical drive, identified by its serial
+// number, to its position in the erasure sets.
+type DriveIdentity struct {
+	Pool           int    `json:"pool"`
+	Set            int    `json:"set"`
+	Index          int    `json:"index"`
+	Endpoint       string `json:"endpoint"`
+	UUID           string `json:"uuid,omitempty"`
+	Serial         string `json:"serial,omitempty"`
+	RecordedSerial string `json:"recordedSerial,omitempty"`
+	State          string `json:"state"`
+	Action         string `json:"action,omitempty"`
+}
+
+// driveIdentities returns the identity of all the drives in the
+// cluster, offline drives are reported with the last known identity
+// unavailable.
+func (z *erasureServerPools) driveIdentities(ctx context.Context) []DriveIdentity {
+	var ids []DriveIdentity
+	for poolIdx, pool := range z.serverPools {
+		for setIdx, set := range pool.sets {
+			disks := set.getDisks()
+			for diskIdx, endpoint := range set.getEndpoints() {
+				id := DriveIdentity{
+					Pool:     poolIdx,
+					Set:      setIdx,
+					Index:    diskIdx,
+					Endpoint: endpoint.String(),
+					State:    driveIdentityOffline,
+					Action:   "bring the drive online or replace it with a fresh drive",
+				}
+				if diskIdx < len(disks) && disks[diskIdx] != nil {
+					fillDriveIdentity(ctx, disks[diskIdx], &id)
+				}
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids
+}
+
+func fillDriveIdentity(ctx context.Context, disk StorageAPI, id *DriveIdentity) {
+	info, err := disk.DiskInfo(ctx)
+	id.UUID = info.ID
+	id.Serial = info.Serial
+	id.RecordedSerial = info.RecordedSerial
+	switch {
+	case errors.Is(err, errUnformattedDisk):
+		id.State = driveIdentityUnformatted
+		id.Action = "drive is fresh and will be healed automatically"
+	case err != nil:
+		// keep the offline state.
+	case info.IdentityMismatch:
+		id.State = driveIdentityMismatch
+		id.Action = "drive returned with a different serial number and refuses writes, replace it with the drive whose serial is " + info.RecordedSerial + " or wipe it to be healed as a fresh drive"
+	case info.Serial == "":
+		id.State = driveIdentityUnknown
+		id.Action = ""
+	default:
+		id.State = driveIdentityOK
+		id.Action = ""
+	}
+}
+
+// DriveIdentityHandler - GET /minio/admin/v3/drives/identity?serial={serial}
+// ----------
+// Lists the physical drives by serial number along with their pool, set
+// and index, optionally filtered to a single serial number to locate the
+// drive that needs replacement.
+func (a adminAPIHandlers) DriveIdentityHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "DriveIdentity")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServerInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	pools, ok := objectAPI.(*erasureServerPools)
+	if !ok {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	serial := r.Form.Get("serial")
+	ids := pools.driveIdentities(ctx)
+	if serial != "" {
+		filtered := ids[:0]
+		for _, id := range ids {
+			if id.Serial == serial || id.RecordedSerial == serial {
+				filtered = append(filtered, id)
+			}
+		}
+		ids = filtered
+	}
+
+	logger.LogIf(ctx, json.NewEncoder(w).Encode(ids))
+}
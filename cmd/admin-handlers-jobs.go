@@ -0,0 +1,129 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+
+	iampolicy "github.com/minio/pkg/iam/policy"
+	"github.com/qkbyte/minio/internal/logger"
+)
+
+// ListAdminJobsHandler - GET /minio/admin/v3/jobs?type=heal&status=running
+// ----------
+// Lists the long running admin operations started on all nodes, such as
+// heals, decommissions, prefix scans and xl.meta maintenance, along with
+// the history of those which finished, most recently started first. The
+// optional type and status parameters filter the jobs.
+func (a adminAPIHandlers) ListAdminJobsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ListAdminJobs")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServerInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	jobs := globalAdminJobs.list()
+	jobs = append(jobs, globalNotificationSys.ListAdminJobs(ctx)...)
+
+	jobType, status := r.Form.Get("type"), r.Form.Get("status")
+	filtered := make([]adminJobInfo, 0, len(jobs))
+	for _, job := range jobs {
+		if (jobType == "" || job.Type == jobType) && (status == "" || job.Status == status) {
+			filtered = append(filtered, job)
+		}
+	}
+	sort.Slice(filtered, func(i, j int) bool { return filtered[i].Started.After(filtered[j].Started) })
+
+	data, err := json.Marshal(filtered)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, data)
+}
+
+// AdminJobStatusHandler - GET /minio/admin/v3/job?id=jobid
+// ----------
+// Returns the status of an admin job, running or finished.
+func (a adminAPIHandlers) AdminJobStatusHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "AdminJobStatus")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServerInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	id, proxied := proxyRequestByToken(ctx, w, r, r.Form.Get("id"))
+	if proxied {
+		return
+	}
+
+	job, ok := globalAdminJobs.get(id)
+	if !ok {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrInvalidRequest, errAdminJobNotFound), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(job)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, data)
+}
+
+// CancelAdminJobHandler - DELETE /minio/admin/v3/job?id=jobid
+// ----------
+// Cancels a running admin job, the caller needs the admin action which
+// starts jobs of its type.
+func (a adminAPIHandlers) CancelAdminJobHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "CancelAdminJob")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.HealAdminAction, iampolicy.DecommissionAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	id, proxied := proxyRequestByToken(ctx, w, r, r.Form.Get("id"))
+	if proxied {
+		return
+	}
+
+	if jobType, ok := globalAdminJobs.jobType(id); ok {
+		if _, errCode := checkAdminRequestAuth(ctx, r, adminJobActions[jobType], ""); errCode != ErrNone {
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(errCode), r.URL)
+			return
+		}
+	}
+
+	if err := globalAdminJobs.cancel(id); err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrInvalidRequest, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
@@ -24,6 +24,7 @@ import (
 
 	"github.com/gorilla/mux"
 	iampolicy "github.com/minio/pkg/iam/policy"
+	xhttp "github.com/qkbyte/minio/internal/http"
 	"github.com/qkbyte/minio/internal/logger"
 )
 
@@ -73,6 +74,7 @@ func (a adminAPIHandlers) StartDecommission(w http.ResponseWriter, r *http.Reque
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
 		return
 	}
+	w.Header().Set(xhttp.MinIOJobID, registerDecommissionJob(pools, idx))
 }
 
 func (a adminAPIHandlers) CancelDecommission(w http.ResponseWriter, r *http.Request) {
@@ -123,6 +125,44 @@ func (a adminAPIHandlers) CancelDecommission(w http.ResponseWriter, r *http.Requ
 	}
 }
 
+// SetPoolState - sets the administrative state of a pool, supported
+// states are "active", "readonly", "draining" and "maintenance".
+func (a adminAPIHandlers) SetPoolState(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SetPoolState")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.DecommissionAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	// Legacy args style such as non-ellipses style is not supported with this API.
+	if globalEndpoints.Legacy() {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	pools, ok := objectAPI.(*erasureServerPools)
+	if !ok {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	vars := mux.Vars(r)
+	idx := globalEndpoints.GetPoolIdx(vars["pool"])
+	if idx == -1 {
+		// We didn't find any matching pools, invalid input
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, errInvalidArgument), r.URL)
+		return
+	}
+
+	if err := pools.SetPoolState(ctx, idx, vars["state"]); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+}
+
 func (a adminAPIHandlers) StatusPool(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "StatusPool")
 
@@ -0,0 +1,129 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	iampolicy "github.com/minio/pkg/iam/policy"
+	"github.com/qkbyte/minio/internal/logger"
+)
+
+// StartPrefixScanHandler - POST /minio/admin/v3/prefix-scan?bucket=mybucket&prefix=data/
+// ----------
+// Starts an immediate deep scan of the objects under the prefix instead
+// of waiting for the next scanner cycle: their usage is accounted, the
+// bucket lifecycle is applied and all versions are deep healed. Returns
+// the id of the scan to query its progress with, the id of the running
+// scan is returned if the prefix is already being scanned.
+func (a adminAPIHandlers) StartPrefixScanHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "StartPrefixScan")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.HealAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	bucket := pathClean(mux.Vars(r)["bucket"])
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket, BucketOptions{}); err != nil {
+		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	id, err := globalPrefixScans.start(objectAPI, bucket, r.Form.Get("prefix"))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrSlowDown, err), r.URL)
+		return
+	}
+	if globalIsDistErasure {
+		// Status requests are proxied to this node.
+		id = fmt.Sprintf("%s@%d", id, GetProxyEndpointLocalIndex(globalProxyEndpoints))
+	}
+
+	data, err := json.Marshal(map[string]string{"id": id})
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, data)
+}
+
+// PrefixScanStatusHandler - GET /minio/admin/v3/prefix-scan?id=scanid
+// ----------
+// Returns the progress of a prefix scan, along with its outcome once done.
+func (a adminAPIHandlers) PrefixScanStatusHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "PrefixScanStatus")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.HealAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	id, proxied := proxyRequestByToken(ctx, w, r, r.Form.Get("id"))
+	if proxied {
+		return
+	}
+
+	job, ok := globalPrefixScans.get(id)
+	if !ok {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrInvalidRequest, errPrefixScanNotFound), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(job.getStatus())
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, data)
+}
+
+// CancelPrefixScanHandler - DELETE /minio/admin/v3/prefix-scan?id=scanid
+// ----------
+// Cancels a running prefix scan.
+func (a adminAPIHandlers) CancelPrefixScanHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "CancelPrefixScan")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.HealAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	id, proxied := proxyRequestByToken(ctx, w, r, r.Form.Get("id"))
+	if proxied {
+		return
+	}
+
+	job, ok := globalPrefixScans.get(id)
+	if !ok {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrInvalidRequest, errPrefixScanNotFound), r.URL)
+		return
+	}
+	job.cancel()
+
+	writeSuccessResponseHeadersOnly(w)
+}
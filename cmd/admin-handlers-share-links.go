@@ -0,0 +1,222 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	iampolicy "github.com/minio/pkg/iam/policy"
+	"github.com/qkbyte/minio/internal/logger"
+)
+
+var errShareLinkNotFound = errors.New("share link not found")
+
+// shareLinkInfo - a share link as reported to admins, without its
+// secret hash and along with the bytes it served.
+type shareLinkInfo struct {
+	ID        string    `json:"id"`
+	Prefix    string    `json:"prefix,omitempty"`
+	Expiry    time.Time `json:"expiry"`
+	MaxBytes  int64     `json:"maxBytes,omitempty"`
+	RateLimit int64     `json:"rateLimit,omitempty"`
+	UsedBytes int64     `json:"usedBytes"`
+	Created   time.Time `json:"created"`
+	CreatedBy string    `json:"createdBy,omitempty"`
+}
+
+// newShareLinkResponse - the response to a share link creation.
+type newShareLinkResponse struct {
+	ID     string    `json:"id"`
+	Token  string    `json:"token"`
+	Expiry time.Time `json:"expiry"`
+}
+
+// parseShareLinkInt parses an optional non-negative integer parameter.
+func parseShareLinkInt(r *http.Request, name string) (int64, error) {
+	v := r.Form.Get(name)
+	if v == "" {
+		return 0, nil
+	}
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err == nil && n < 0 {
+		err = errors.New(name + " must not be negative")
+	}
+	return n, err
+}
+
+// AddShareLinkHandler - POST /minio/admin/v3/share-link?bucket=mybucket&prefix=data/&expiry=24h&max-bytes=1073741824&rate=1048576
+// ----------
+// Creates a link granting anonymous read and list access to the objects
+// of the bucket under the prefix until it expires. The bytes served
+// through the link may be capped across all nodes by max-bytes, which
+// requests admitted before the usage of the other nodes is known may
+// exceed slightly, and per node by rate in bytes per second. The returned
+// token is sent by clients in the X-Minio-Share-Token header or the
+// x-minio-share-token query parameter, it cannot be retrieved again.
+func (a adminAPIHandlers) AddShareLinkHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "AddShareLink")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, cred := validateAdminReq(ctx, w, r, iampolicy.ConfigUpdateAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	bucket := pathClean(mux.Vars(r)["bucket"])
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket, BucketOptions{}); err != nil {
+		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	expiry, err := time.ParseDuration(r.Form.Get("expiry"))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrInvalidRequest, err), r.URL)
+		return
+	}
+	maxBytes, err := parseShareLinkInt(r, "max-bytes")
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrInvalidRequest, err), r.URL)
+		return
+	}
+	rateLimit, err := parseShareLinkInt(r, "rate")
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrInvalidRequest, err), r.URL)
+		return
+	}
+
+	link, token, err := newShareLink(bucket, r.Form.Get("prefix"), expiry, maxBytes, rateLimit, cred.AccessKey, UTCNow())
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrInvalidRequest, err), r.URL)
+		return
+	}
+
+	if err = updateShareLinks(ctx, objectAPI, bucket, func(links []shareLink) ([]shareLink, error) {
+		return append(links, link), nil
+	}); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(newShareLinkResponse{ID: link.ID, Token: token, Expiry: link.Expiry})
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, data)
+}
+
+// ListShareLinksHandler - GET /minio/admin/v3/share-links?bucket=mybucket
+// ----------
+// Lists the share links of the bucket which did not expire along with
+// the bytes they served on all nodes.
+func (a adminAPIHandlers) ListShareLinksHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ListShareLinks")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServerInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	bucket := pathClean(mux.Vars(r)["bucket"])
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket, BucketOptions{}); err != nil {
+		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	cfg, _, err := globalBucketMetadataSys.GetShareLinksConfig(bucket)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	usage := globalShareLinkUsage.localUsage()
+	if globalNotificationSys != nil {
+		for key, n := range globalNotificationSys.GetShareLinkUsage(ctx) {
+			usage[key] += n
+		}
+	}
+
+	infos := []shareLinkInfo{}
+	for _, link := range cfg.active(UTCNow()) {
+		infos = append(infos, shareLinkInfo{
+			ID:        link.ID,
+			Prefix:    link.Prefix,
+			Expiry:    link.Expiry,
+			MaxBytes:  link.MaxBytes,
+			RateLimit: link.RateLimit,
+			UsedBytes: usage[shareLinkKey(bucket, link.ID)],
+			Created:   link.Created,
+			CreatedBy: link.CreatedBy,
+		})
+	}
+
+	data, err := json.Marshal(infos)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, data)
+}
+
+// RemoveShareLinkHandler - DELETE /minio/admin/v3/share-link?bucket=mybucket&id=linkid
+// ----------
+// Revokes a share link of the bucket, its token is rejected right away.
+func (a adminAPIHandlers) RemoveShareLinkHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "RemoveShareLink")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ConfigUpdateAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	bucket := pathClean(mux.Vars(r)["bucket"])
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket, BucketOptions{}); err != nil {
+		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	id := r.Form.Get("id")
+	err := updateShareLinks(ctx, objectAPI, bucket, func(links []shareLink) ([]shareLink, error) {
+		for i, link := range links {
+			if link.ID == id {
+				return append(links[:i], links[i+1:]...), nil
+			}
+		}
+		return nil, errShareLinkNotFound
+	})
+	if errors.Is(err, errShareLinkNotFound) {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrInvalidRequest, err), r.URL)
+		return
+	}
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
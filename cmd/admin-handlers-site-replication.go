@@ -515,3 +515,159 @@ func (a adminAPIHandlers) SRPeerRemove(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+// SiteReplicationFollowerStatus - GET /minio/admin/v3/site-replication/follower
+//
+// returns whether this site is a read-only follower and its primary site.
+func (a adminAPIHandlers) SiteReplicationFollowerStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SiteReplicationFollowerStatus")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.SiteReplicationInfoAction)
+	if objectAPI == nil {
+		return
+	}
+
+	body, err := json.Marshal(globalSiteReplicationSys.followerStatus())
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, body)
+}
+
+// SiteReplicationFollower - PUT /minio/admin/v3/site-replication/follower?primary=site
+//
+// makes this site serve all buckets read-only, writes are rejected and
+// clients are pointed to the optional primary site.
+func (a adminAPIHandlers) SiteReplicationFollower(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SiteReplicationFollower")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.SiteReplicationOperationAction)
+	if objectAPI == nil {
+		return
+	}
+
+	status, err := globalSiteReplicationSys.SetFollower(ctx, r.Form.Get("primary"))
+	if err != nil {
+		logger.LogIf(ctx, err)
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	body, err := json.Marshal(status)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, body)
+}
+
+// SiteReplicationPromote - PUT /minio/admin/v3/site-replication/promote
+//
+// makes a read-only follower site read-write again, e.g. on failover.
+func (a adminAPIHandlers) SiteReplicationPromote(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SiteReplicationPromote")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.SiteReplicationOperationAction)
+	if objectAPI == nil {
+		return
+	}
+
+	status, err := globalSiteReplicationSys.Promote(ctx)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	body, err := json.Marshal(status)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, body)
+}
+
+// SiteReplicationBuckets - GET /minio/admin/v3/site-replication/buckets
+//
+// returns the buckets included and excluded from site replication.
+func (a adminAPIHandlers) SiteReplicationBuckets(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SiteReplicationBuckets")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.SiteReplicationInfoAction)
+	if objectAPI == nil {
+		return
+	}
+
+	filter, err := globalSiteReplicationSys.GetBucketFilter()
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	body, err := json.Marshal(filter)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, body)
+}
+
+// SiteReplicationSetBuckets - PUT /minio/admin/v3/site-replication/buckets
+//
+// sets the buckets included and excluded from site replication on all
+// sites, the body is a JSON document with "include" and "exclude" lists.
+func (a adminAPIHandlers) SiteReplicationSetBuckets(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SiteReplicationSetBuckets")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.SiteReplicationOperationAction)
+	if objectAPI == nil {
+		return
+	}
+
+	var filter srBucketFilter
+	if err := parseJSONBody(ctx, r.Body, &filter, ""); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	if err := globalSiteReplicationSys.SetBucketFilter(ctx, filter); err != nil {
+		logger.LogIf(ctx, err)
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+}
+
+// SRPeerSetBucketFilter - PUT /minio/admin/v3/site-replication/peer/bucket-filter
+func (a adminAPIHandlers) SRPeerSetBucketFilter(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SRPeerSetBucketFilter")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.SiteReplicationOperationAction)
+	if objectAPI == nil {
+		return
+	}
+
+	var filter srBucketFilter
+	if err := parseJSONBody(ctx, r.Body, &filter, ""); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	if err := globalSiteReplicationSys.PeerSetBucketFilter(ctx, filter); err != nil {
+		logger.LogIf(ctx, err)
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	iampolicy "github.com/minio/pkg/iam/policy"
+	"github.com/qkbyte/minio/internal/logger"
+)
+
+// maxTenantConfigSize - maximum size of a tenant definition.
+const maxTenantConfigSize = 1 << 20
+
+// SetTenantHandler - PUT /minio/admin/v3/tenant?name=acme
+// ----------
+// Creates or replaces a tenant, the body is a JSON document listing its
+// member buckets and/or bucket name prefix along with the aggregate size,
+// object count and bucket count quotas enforced across all its buckets.
+func (a adminAPIHandlers) SetTenantHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SetTenant")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.SetBucketQuotaAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r.Body, maxTenantConfigSize))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+		return
+	}
+
+	var t tenant
+	if err = json.Unmarshal(data, &t); err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrInvalidRequest, err), r.URL)
+		return
+	}
+	t.Name = mux.Vars(r)["name"]
+	if err = t.validate(); err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrInvalidRequest, err), r.URL)
+		return
+	}
+
+	if err = globalTenantSys.Set(ctx, objectAPI, t); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// RemoveTenantHandler - DELETE /minio/admin/v3/tenant?name=acme
+// ----------
+// Removes a tenant, its buckets and their own quotas are left untouched.
+func (a adminAPIHandlers) RemoveTenantHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "RemoveTenant")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.SetBucketQuotaAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	if err := globalTenantSys.Remove(ctx, objectAPI, mux.Vars(r)["name"]); err != nil {
+		if err == errTenantNotFound {
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrInvalidRequest, err), r.URL)
+			return
+		}
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// ListTenantsHandler - GET /minio/admin/v3/tenants
+// ----------
+// Lists the tenants along with their member buckets and aggregate usage
+// as last computed by the data scanner.
+func (a adminAPIHandlers) ListTenantsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ListTenants")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.GetBucketQuotaAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	dui, err := loadDataUsageFromBackend(ctx, objectAPI)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(globalTenantSys.Usage(dui))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, data)
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	iampolicy "github.com/minio/pkg/iam/policy"
+	"github.com/qkbyte/minio/internal/logger"
+)
+
+// TopObjectsHandler - GET /minio/admin/v3/top-objects?n=10
+// ----------
+// Returns the objects with the most requests and with the highest
+// average latency over the last hour, estimated from sampled requests
+// of all nodes of the cluster.
+func (a adminAPIHandlers) TopObjectsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "TopObjects")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServerInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	n := hotObjectsDefaultCount
+	if v := r.Form.Get("n"); v != "" {
+		var err error
+		n, err = strconv.Atoi(v)
+		if err != nil || n <= 0 || n > hotObjectsMaxCount {
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+			return
+		}
+	}
+
+	reports := []hotObjectsReport{globalHotObjects.report(n, time.Now())}
+	var nodeErrs map[string]string
+	if globalNotificationSys != nil {
+		var peerReports []hotObjectsReport
+		peerReports, nodeErrs = globalNotificationSys.GetHotObjects(ctx, n)
+		reports = append(reports, peerReports...)
+	}
+
+	report := mergeHotObjectsReports(reports, n)
+	report.NodeErrors = nodeErrs
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	iampolicy "github.com/minio/pkg/iam/policy"
+	xhttp "github.com/qkbyte/minio/internal/http"
+	"github.com/qkbyte/minio/internal/logger"
+)
+
+// ExportAccessKeyUsageHandler - GET /minio/admin/v3/usage/access-keys?from=&to=&format=csv&access-key=
+// ----------
+// Exports the hourly requests, errors and bytes received and sent per
+// access key of the whole cluster between from and to (RFC 3339, the
+// last 24 hours by default) as JSON or CSV. Anonymous requests are
+// accounted with an empty access key. The hours of other nodes are
+// up to a minute behind.
+func (a adminAPIHandlers) ExportAccessKeyUsageHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ExportAccessKeyUsage")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServerInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	now := time.Now().UTC()
+	to, from := now, now.Add(-24*time.Hour)
+	var err error
+	if v := r.Form.Get("to"); v != "" {
+		if to, err = time.Parse(time.RFC3339, v); err != nil {
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+			return
+		}
+	}
+	if v := r.Form.Get("from"); v != "" {
+		if from, err = time.Parse(time.RFC3339, v); err != nil {
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+			return
+		}
+	} else if r.Form.Get("to") != "" {
+		from = to.Add(-24 * time.Hour)
+	}
+	if !from.Before(to) || to.Sub(from) > usageAccountingMaxExport {
+		apiErr := errorCodes.ToAPIErr(ErrInvalidRequest)
+		apiErr.Description = "from must be before to and at most 31 days apart"
+		writeErrorResponseJSON(ctx, w, apiErr, r.URL)
+		return
+	}
+
+	format := r.Form.Get("format")
+	if format != "" && format != "json" && format != "csv" {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+		return
+	}
+
+	// Include the latest requests served by this node.
+	logger.LogIf(ctx, globalUsageAccounting.flush(ctx, objectAPI, now))
+
+	records, err := readUsageRecords(ctx, objectAPI, from, to, r.Form.Get("access-key"))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	if format == "csv" {
+		var buf bytes.Buffer
+		if err = writeUsageCSV(&buf, records); err != nil {
+			writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+			return
+		}
+		w.Header().Set(xhttp.ContentType, "text/csv")
+		writeResponse(w, http.StatusOK, buf.Bytes(), mimeNone)
+		return
+	}
+
+	if records == nil {
+		records = []usageRecord{}
+	}
+	data, err := json.Marshal(records)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, data)
+}
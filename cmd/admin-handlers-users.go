@@ -32,6 +32,7 @@ import (
 	"github.com/minio/madmin-go"
 	iampolicy "github.com/minio/pkg/iam/policy"
 	"github.com/qkbyte/minio/internal/auth"
+	"github.com/qkbyte/minio/internal/config"
 	"github.com/qkbyte/minio/internal/config/dns"
 	"github.com/qkbyte/minio/internal/logger"
 )
@@ -441,7 +442,7 @@ func (a adminAPIHandlers) AddUser(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Not allowed to add a user with same access key as root credential
-	if owner && accessKey == cred.AccessKey {
+	if accessKey == globalActiveCred.AccessKey {
 		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAddUserInvalidArgument), r.URL)
 		return
 	}
@@ -673,6 +674,12 @@ func (a adminAPIHandlers) AddServiceAccount(w http.ResponseWriter, r *http.Reque
 		// latter, a group notion is not supported.
 	}
 
+	limits := globalAPIConfig.getCredentialLimits()
+	if len(createReq.Policy) > limits.policyMaxSize(svcAcctSessionPolicyMaxLen) {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrPolicyTooLarge), r.URL)
+		return
+	}
+
 	var sp *iampolicy.Policy
 	if len(createReq.Policy) > 0 {
 		sp, err = iampolicy.ParseConfig(bytes.NewReader(createReq.Policy))
@@ -682,6 +689,18 @@ func (a adminAPIHandlers) AddServiceAccount(w http.ResponseWriter, r *http.Reque
 		}
 	}
 
+	// Enforce the service accounts limit of the target user,
+	// the root user is not subject to the limit.
+	if !owner {
+		groups := targetGroups
+		if len(groups) == 0 {
+			if userInfo, err := globalIAMSys.GetUserInfo(ctx, targetUser); err == nil {
+				groups = userInfo.MemberOf
+			}
+		}
+		opts.maxServiceAccounts = limits.maxServiceAccounts(groups)
+	}
+
 	opts.sessionPolicy = sp
 	newCred, updatedAt, err := globalIAMSys.NewServiceAccount(ctx, targetUser, targetGroups, opts)
 	if err != nil {
@@ -797,6 +816,11 @@ func (a adminAPIHandlers) UpdateServiceAccount(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	if len(updateReq.NewPolicy) > globalAPIConfig.getCredentialLimits().policyMaxSize(svcAcctSessionPolicyMaxLen) {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrPolicyTooLarge), r.URL)
+		return
+	}
+
 	var sp *iampolicy.Policy
 	if len(updateReq.NewPolicy) > 0 {
 		sp, err = iampolicy.ParseConfig(bytes.NewReader(updateReq.NewPolicy))
@@ -1580,22 +1604,71 @@ const (
 	groupPolicyMappingsFile    = "group_mappings.json"
 	stsUserPolicyMappingsFile  = "stsuser_mappings.json"
 	stsGroupPolicyMappingsFile = "stsgroup_mappings.json"
+	stsConfigFile              = "sts_config.json"
 	iamAssetsDir               = "iam-assets"
 )
 
+// stsConfigSubSystems are the identity and policy plugin sub-systems
+// exported along with the IAM assets, so that STS credentials can be
+// issued the same way after a restore on another cluster.
+var stsConfigSubSystems = []string{
+	config.IdentityOpenIDSubSys,
+	config.IdentityLDAPSubSys,
+	config.IdentityTLSSubSys,
+	config.IdentityPluginSubSys,
+	config.PolicyPluginSubSys,
+}
+
+// restoreRedactedKVS keeps the current values of the keys redacted in
+// an unencrypted export, redacted keys without a current value are
+// removed.
+func restoreRedactedKVS(targets, current map[string]config.KVS) {
+	for target, kvs := range targets {
+		restored := kvs[:0]
+		for _, kv := range kvs {
+			if kv.Value == config.RedactedValue {
+				v, ok := current[target].Lookup(kv.Key)
+				if !ok || v == "" {
+					continue
+				}
+				kv.Value = v
+			}
+			restored = append(restored, kv)
+		}
+		targets[target] = restored
+	}
+}
+
 // ExportIAMHandler - exports all iam info as a zipped file
+// ----------
+// GET /minio/admin/v3/export-iam?encrypt=true
+//
+// When `encrypt` is set the whole archive is encrypted with the
+// secret key of the requester, which keeps the service account
+// secrets and identity provider settings protected at rest. The
+// secrets of the identity provider settings are redacted otherwise.
 func (a adminAPIHandlers) ExportIAM(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "ExportIAM")
 	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
 
 	// Get current object layer instance.
-	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ExportIAMAction)
+	objectAPI, cred := validateAdminReq(ctx, w, r, iampolicy.ExportIAMAction)
 	if objectAPI == nil {
 		return
 	}
+
+	var (
+		out     io.Writer = w
+		archive bytes.Buffer
+	)
+	encrypt := r.Form.Get("encrypt") == "true"
+	if encrypt {
+		out = &archive
+	}
+
 	// Initialize a zip writer which will provide a zipped content
 	// of bucket metadata
-	zipWriter := zip.NewWriter(w)
+	zipWriter := zip.NewWriter(out)
 	defer zipWriter.Close()
 	rawDataFn := func(r io.Reader, filename string, sz int) error {
 		header, zerr := zip.FileInfoHeader(dummyFileInfo{
@@ -1631,6 +1704,7 @@ func (a adminAPIHandlers) ExportIAM(w http.ResponseWriter, r *http.Request) {
 		groupPolicyMappingsFile,
 		stsUserPolicyMappingsFile,
 		stsGroupPolicyMappingsFile,
+		stsConfigFile,
 	}
 	for _, f := range iamFiles {
 		iamFile := pathJoin(iamAssetsDir, f)
@@ -1826,8 +1900,46 @@ func (a adminAPIHandlers) ExportIAM(w http.ResponseWriter, r *http.Request) {
 				writeErrorResponse(ctx, w, exportError(ctx, err, iamFile, ""), r.URL)
 				return
 			}
+		case stsConfigFile:
+			cfg, err := readServerConfig(ctx, objectAPI)
+			if err != nil {
+				writeErrorResponse(ctx, w, exportError(ctx, err, iamFile, ""), r.URL)
+				return
+			}
+			stsConfig := make(config.Config, len(stsConfigSubSystems))
+			for _, subSys := range stsConfigSubSystems {
+				if kvs, ok := cfg[subSys]; ok {
+					stsConfig[subSys] = kvs
+				}
+			}
+			if !encrypt {
+				stsConfig = stsConfig.RedactSensitiveInfo()
+			}
+			stsCfgData, err := json.Marshal(stsConfig)
+			if err != nil {
+				writeErrorResponse(ctx, w, exportError(ctx, err, iamFile, ""), r.URL)
+				return
+			}
+			if err = rawDataFn(bytes.NewReader(stsCfgData), iamFile, len(stsCfgData)); err != nil {
+				writeErrorResponse(ctx, w, exportError(ctx, err, iamFile, ""), r.URL)
+				return
+			}
 		}
 	}
+
+	if !encrypt {
+		return
+	}
+	if err := zipWriter.Close(); err != nil {
+		writeErrorResponse(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	data, err := madmin.EncryptData(cred.SecretKey, archive.Bytes())
+	if err != nil {
+		writeErrorResponse(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, data)
 }
 
 // ImportIAM - imports all IAM info into MinIO
@@ -1847,11 +1959,25 @@ func (a adminAPIHandlers) ImportIAM(w http.ResponseWriter, r *http.Request) {
 		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(s3Err), r.URL)
 		return
 	}
+
+	w, done := registerRequestJob(w, adminJobIAMImport)
+	defer done()
+
 	data, err := io.ReadAll(r.Body)
 	if err != nil {
 		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
 		return
 	}
+	// Archives exported with `encrypt=true` are encrypted with the
+	// secret key of the exporting admin.
+	if madmin.IsEncrypted(data) {
+		data, err = madmin.DecryptData(cred.SecretKey, bytes.NewReader(data))
+		if err != nil {
+			logger.LogIf(ctx, err, logger.Application)
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminConfigBadJSON), r.URL)
+			return
+		}
+	}
 	reader := bytes.NewReader(data)
 	zr, err := zip.NewReader(reader, int64(len(data)))
 	if err != nil {
@@ -1917,7 +2043,7 @@ func (a adminAPIHandlers) ImportIAM(w http.ResponseWriter, r *http.Request) {
 			}
 			for accessKey, ureq := range userAccts {
 				// Not allowed to add a user with same access key as root credential
-				if owner && accessKey == cred.AccessKey {
+				if accessKey == globalActiveCred.AccessKey {
 					writeErrorResponseJSON(ctx, w, importErrorWithAPIErr(ctx, ErrAddUserInvalidArgument, err, allUsersFile, accessKey), r.URL)
 					return
 				}
@@ -2240,4 +2366,63 @@ func (a adminAPIHandlers) ImportIAM(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 	}
+
+	// import identity and policy plugin configuration, changes to these
+	// sub-systems take effect after the next server restart.
+	{
+		f, err := zr.Open(pathJoin(iamAssetsDir, stsConfigFile))
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+		case err != nil:
+			writeErrorResponseJSON(ctx, w, importErrorWithAPIErr(ctx, ErrInvalidRequest, err, stsConfigFile, ""), r.URL)
+			return
+		default:
+			defer f.Close()
+			var stsConfig config.Config
+			data, err := io.ReadAll(f)
+			if err != nil {
+				writeErrorResponseJSON(ctx, w, importErrorWithAPIErr(ctx, ErrInvalidRequest, err, stsConfigFile, ""), r.URL)
+				return
+			}
+			if err = json.Unmarshal(data, &stsConfig); err != nil {
+				writeErrorResponseJSON(ctx, w, importErrorWithAPIErr(ctx, ErrAdminConfigBadJSON, err, stsConfigFile, ""), r.URL)
+				return
+			}
+			if len(stsConfig) == 0 {
+				return
+			}
+			if !globalIAMSys.IsAllowed(iampolicy.Args{
+				AccountName:     cred.AccessKey,
+				Groups:          cred.Groups,
+				Action:          iampolicy.ConfigUpdateAdminAction,
+				ConditionValues: getConditionValues(r, "", cred.AccessKey, claims),
+				IsOwner:         owner,
+				Claims:          claims,
+			}) {
+				writeErrorResponseJSON(ctx, w, importErrorWithAPIErr(ctx, ErrAccessDenied, err, stsConfigFile, ""), r.URL)
+				return
+			}
+			cfg, err := readServerConfig(ctx, objectAPI)
+			if err != nil {
+				writeErrorResponseJSON(ctx, w, importError(ctx, err, stsConfigFile, ""), r.URL)
+				return
+			}
+			for _, subSys := range stsConfigSubSystems {
+				kvs, ok := stsConfig[subSys]
+				if !ok {
+					continue
+				}
+				restoreRedactedKVS(kvs, cfg[subSys])
+				cfg[subSys] = kvs
+				if err = validateConfig(cfg, subSys); err != nil {
+					writeErrorResponseJSON(ctx, w, importErrorWithAPIErr(ctx, ErrAdminConfigBadJSON, err, stsConfigFile, subSys), r.URL)
+					return
+				}
+			}
+			if err = saveServerConfig(ctx, objectAPI, cfg); err != nil {
+				writeErrorResponseJSON(ctx, w, importError(ctx, err, stsConfigFile, ""), r.URL)
+				return
+			}
+		}
+	}
 }
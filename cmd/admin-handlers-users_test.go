@@ -33,6 +33,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/klauspost/compress/zip"
 	"github.com/minio/madmin-go"
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
@@ -41,6 +42,7 @@ import (
 	"github.com/minio/minio-go/v7/pkg/set"
 	"github.com/minio/minio-go/v7/pkg/signer"
 	"github.com/qkbyte/minio/internal/auth"
+	"github.com/qkbyte/minio/internal/config"
 )
 
 const (
@@ -209,6 +211,7 @@ func TestIAMInternalIDPServerSuite(t *testing.T) {
 				suite.TestServiceAccountOpsByAdmin(c)
 				suite.TestServiceAccountOpsByUser(c)
 				suite.TestAddServiceAccountPerms(c)
+				suite.TestExportImportIAM(c)
 				suite.TearDownSuite(c)
 			},
 		)
@@ -1412,3 +1415,92 @@ func mustGenerateCredentials(c *check) (string, string) {
 	}
 	return ak, sk
 }
+
+func (s *TestSuiteIAM) TestExportImportIAM(c *check) {
+	ctx, cancel := context.WithTimeout(context.Background(), testDefaultTimeout)
+	defer cancel()
+
+	accessKey, secretKey := mustGenerateCredentials(c)
+	err := s.adm.SetUser(ctx, accessKey, secretKey, madmin.AccountEnabled)
+	if err != nil {
+		c.Fatalf("Unable to set user: %v", err)
+	}
+
+	// 1. Export the IAM assets as an encrypted archive.
+	ep := s.adm.GetEndpointURL()
+	u := fmt.Sprintf("%s://%s/minio/admin/v3/export-iam?encrypt=true", ep.Scheme, ep.Host)
+	req, err := newTestSignedRequestV4(http.MethodGet, u, 0, nil, s.accessKey, s.secretKey, nil)
+	if err != nil {
+		c.Fatalf("unexpected new request err: %v", err)
+	}
+	resp, err := s.TestSuiteCommon.client.Do(req)
+	if err != nil {
+		c.Fatalf("unexpected request err: %v", err)
+	}
+	archive, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		c.Fatalf("unexpected read err: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		c.Fatalf("got unexpected response: %d %s", resp.StatusCode, archive)
+	}
+	if !madmin.IsEncrypted(archive) {
+		c.Fatal("expected an encrypted archive")
+	}
+
+	// 2. The decrypted archive carries the STS configuration.
+	data, err := madmin.DecryptData(s.secretKey, bytes.NewReader(archive))
+	if err != nil {
+		c.Fatalf("unable to decrypt archive: %v", err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		c.Fatalf("unable to read archive: %v", err)
+	}
+	f, err := zr.Open(pathJoin(iamAssetsDir, stsConfigFile))
+	if err != nil {
+		c.Fatalf("expected %s in archive: %v", stsConfigFile, err)
+	}
+	f.Close()
+
+	// 3. Remove the user and restore it from the encrypted archive.
+	if err = s.adm.RemoveUser(ctx, accessKey); err != nil {
+		c.Fatalf("Unable to remove user: %v", err)
+	}
+	if err = s.adm.ImportIAM(ctx, io.NopCloser(bytes.NewReader(archive))); err != nil {
+		c.Fatalf("Unable to import IAM: %v", err)
+	}
+	if _, err = s.adm.GetUserInfo(ctx, accessKey); err != nil {
+		c.Fatalf("user was not restored: %v", err)
+	}
+}
+
+func TestRestoreRedactedKVS(t *testing.T) {
+	current := map[string]config.KVS{
+		config.Default: {
+			config.KV{Key: "client_id", Value: "id"},
+			config.KV{Key: "client_secret", Value: "secret"},
+		},
+	}
+	imported := map[string]config.KVS{
+		config.Default: {
+			config.KV{Key: "client_id", Value: "new-id"},
+			config.KV{Key: "client_secret", Value: config.RedactedValue},
+		},
+		"other": {
+			config.KV{Key: "client_secret", Value: config.RedactedValue},
+		},
+	}
+	restoreRedactedKVS(imported, current)
+
+	if v, _ := imported[config.Default].Lookup("client_id"); v != "new-id" {
+		t.Fatalf("expected the imported value to be kept, got %s", v)
+	}
+	if v, _ := imported[config.Default].Lookup("client_secret"); v != "secret" {
+		t.Fatalf("expected the current secret to be kept, got %s", v)
+	}
+	if _, ok := imported["other"].Lookup("client_secret"); ok {
+		t.Fatal("expected a redacted key without current value to be removed")
+	}
+}
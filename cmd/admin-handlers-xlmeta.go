@@ -0,0 +1,190 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	iampolicy "github.com/minio/pkg/iam/policy"
+	"github.com/qkbyte/minio/internal/logger"
+)
+
+// StartXLMetaMaintenanceHandler - POST /minio/admin/v3/xlmeta-maintenance?bucket=mybucket&prefix=data/&max-versions=1000&dry-run=true
+// ----------
+// Starts a job checking the xl.meta files under the prefix on all drives,
+// all buckets are checked when no bucket is given. Legacy formats are
+// converted, corrupted and orphaned inline data is dropped and objects
+// with a corrupted xl.meta are healed from the other drives, unless
+// dry-run is set. Objects with more than max-versions versions are
+// reported. Returns the id of the job to query its progress with.
+func (a adminAPIHandlers) StartXLMetaMaintenanceHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "StartXLMetaMaintenance")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.HealAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	z, ok := objectAPI.(*erasureServerPools)
+	if !ok {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	bucket := r.Form.Get("bucket")
+	if bucket != "" {
+		if _, err := objectAPI.GetBucketInfo(ctx, bucket, BucketOptions{}); err != nil {
+			writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+			return
+		}
+	}
+
+	maxVersions := xlMetaMaintenanceDefaultMaxVersions
+	if v := r.Form.Get("max-versions"); v != "" {
+		var err error
+		if maxVersions, err = strconv.Atoi(v); err != nil || maxVersions <= 0 {
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+			return
+		}
+	}
+	dryRun := r.Form.Get("dry-run") == "true"
+
+	id, err := globalXLMetaMaintenance.start(z, bucket, r.Form.Get("prefix"), maxVersions, dryRun)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrSlowDown, err), r.URL)
+		return
+	}
+	if globalIsDistErasure {
+		// Status requests are proxied to this node.
+		id = fmt.Sprintf("%s@%d", id, GetProxyEndpointLocalIndex(globalProxyEndpoints))
+	}
+
+	data, err := json.Marshal(map[string]string{"id": id})
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, data)
+}
+
+// XLMetaMaintenanceStatusHandler - GET /minio/admin/v3/xlmeta-maintenance?id=jobid
+// ----------
+// Returns the progress and statistics of an xl.meta maintenance job.
+func (a adminAPIHandlers) XLMetaMaintenanceStatusHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "XLMetaMaintenanceStatus")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.HealAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	id, proxied := proxyRequestByToken(ctx, w, r, r.Form.Get("id"))
+	if proxied {
+		return
+	}
+
+	job, ok := globalXLMetaMaintenance.get(id)
+	if !ok {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrInvalidRequest, errXLMetaMaintenanceNotFound), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(job.getStatus())
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, data)
+}
+
+// CancelXLMetaMaintenanceHandler - DELETE /minio/admin/v3/xlmeta-maintenance?id=jobid
+// ----------
+// Cancels a running xl.meta maintenance job.
+func (a adminAPIHandlers) CancelXLMetaMaintenanceHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "CancelXLMetaMaintenance")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.HealAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	id, proxied := proxyRequestByToken(ctx, w, r, r.Form.Get("id"))
+	if proxied {
+		return
+	}
+
+	job, ok := globalXLMetaMaintenance.get(id)
+	if !ok {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrInvalidRequest, errXLMetaMaintenanceNotFound), r.URL)
+		return
+	}
+	job.cancel()
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// InspectObjectHandler - GET /minio/admin/v3/inspect-object?bucket=mybucket&object=myobject&versionId=uuid&verify=true
+// ----------
+// Returns the parsed xl.meta of an object version as recorded on every
+// drive of its erasure sets, along with which drives hold its shards,
+// their bitrot checksums and whether the data is inlined. The latest
+// version is inspected when no version is given. With verify set the
+// shards are read back and checked against their checksums.
+func (a adminAPIHandlers) InspectObjectHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "InspectObject")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.InspectDataAction)
+	if objectAPI == nil {
+		return
+	}
+
+	z, ok := objectAPI.(*erasureServerPools)
+	if !ok {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	bucket, object := r.Form.Get("bucket"), r.Form.Get("object")
+	if object == "" {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+		return
+	}
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket, BucketOptions{}); err != nil {
+		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	report := z.inspectObject(ctx, bucket, object, r.Form.Get("versionId"), r.Form.Get("verify") == "true")
+	data, err := json.Marshal(report)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, data)
+}
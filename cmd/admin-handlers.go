@@ -91,6 +91,7 @@ func (a adminAPIHandlers) ServerUpdateHandler(w http.ResponseWriter, r *http.Req
 
 	vars := mux.Vars(r)
 	updateURL := vars["updateURL"]
+	rolling := globalIsDistErasure && r.Form.Get("rolling") == "true"
 	mode := getMinioMode()
 	if updateURL == "" {
 		updateURL = minioReleaseInfoURL
@@ -124,6 +125,15 @@ func (a adminAPIHandlers) ServerUpdateHandler(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	// Conflicting rolling restarts are refused before the new binary
+	// is committed on any node.
+	if rolling {
+		if !prepareRollingRestart(ctx, w, r, "update to "+lrTime.Format(minioReleaseTagTimeLayout)) {
+			return
+		}
+		defer globalRollingRestart.release()
+	}
+
 	if lrTime.Sub(crTime) <= 0 {
 		updateStatus := madmin.ServerUpdateStatus{
 			CurrentVersion: Version,
@@ -192,6 +202,13 @@ func (a adminAPIHandlers) ServerUpdateHandler(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	// Restart one node per erasure set at a time instead of all nodes
+	// at once, the reply is the plan of the rolling restart.
+	if rolling {
+		startRollingRestart(ctx, w, r, objectAPI)
+		return
+	}
+
 	updateStatus := madmin.ServerUpdateStatus{
 		CurrentVersion: Version,
 		UpdatedVersion: lrTime.Format(minioReleaseTagTimeLayout),
@@ -220,7 +237,7 @@ func (a adminAPIHandlers) ServerUpdateHandler(w http.ResponseWriter, r *http.Req
 // ServiceHandler - POST /minio/admin/v3/service?action={action}
 // ----------
 // Supports following actions:
-// - restart (restarts all the MinIO instances in a setup)
+// - restart (restarts all the MinIO instances in a setup, rolling=true restarts one node per erasure set at a time)
 // - stop (stops all the MinIO instances in a setup)
 // - freeze (freezes all incoming S3 API calls)
 // - unfreeze (unfreezes previously frozen S3 API calls)
@@ -261,6 +278,14 @@ func (a adminAPIHandlers) ServiceHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	// Restart one node per erasure set at a time, replying with the plan.
+	if serviceSig == serviceRestart && globalIsDistErasure && r.Form.Get("rolling") == "true" {
+		if prepareRollingRestart(ctx, w, r, "restart") {
+			startRollingRestart(ctx, w, r, objectAPI)
+		}
+		return
+	}
+
 	// Notify all other MinIO peers signal service.
 	for _, nerr := range globalNotificationSys.SignalService(serviceSig) {
 		if nerr.Err != nil {
@@ -325,6 +350,8 @@ type ServerHTTPStats struct {
 	TotalS3RejectedTime    uint64             `json:"totalS3RejectedTime"`
 	TotalS3RejectedHeader  uint64             `json:"totalS3RejectedHeader"`
 	TotalS3RejectedInvalid uint64             `json:"totalS3RejectedInvalid"`
+	TotalS3RejectedSkew    uint64             `json:"totalS3RejectedSkew"`
+	TotalS3RejectedCreds   uint64             `json:"totalS3RejectedCredentials"`
 }
 
 // StorageInfoHandler - GET /minio/admin/v3/storageinfo
@@ -470,6 +497,74 @@ func (a adminAPIHandlers) MetricsHandler(w http.ResponseWriter, r *http.Request)
 	}
 }
 
+// OSMetricsHandler - GET /minio/admin/v3/os-metrics
+// ----------
+// Returns the operating system call counts and last minute latencies
+// of drive I/O on every node, keyed by node address.
+func (a adminAPIHandlers) OSMetricsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "OSMetrics")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServerInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	var m madmin.RealtimeMetrics
+	mLocal := collectLocalMetrics(madmin.MetricsOS, nil, nil)
+	m.Merge(&mLocal)
+	mRemote := collectRemoteMetrics(ctx, madmin.MetricsOS, nil, nil)
+	m.Merge(&mRemote)
+
+	resp := struct {
+		Nodes  map[string]*madmin.OSMetrics `json:"nodes"`
+		Errors []string                     `json:"errors,omitempty"`
+	}{
+		Nodes:  make(map[string]*madmin.OSMetrics, len(m.ByHost)),
+		Errors: m.Errors,
+	}
+	for host, hm := range m.ByHost {
+		resp.Nodes[host] = hm.OS
+	}
+
+	data, err := json.Marshal(resp)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
+
+// SlowDirsHandler - GET /minio/admin/v3/slow-dirs
+// ----------
+// Returns the directories whose enumeration exceeded the configured
+// duration or entry count on every node, slowest first.
+func (a adminAPIHandlers) SlowDirsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SlowDirs")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServerInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	reports := []slowDirsReport{globalReadDirStats.report()}
+	if globalNotificationSys != nil {
+		reports = append(reports, globalNotificationSys.GetSlowDirs(ctx)...)
+	}
+
+	data, err := json.Marshal(reports)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
+
 // DataUsageInfoHandler - GET /minio/admin/v3/datausage
 // ----------
 // Get server/cluster data usage info
@@ -1077,6 +1172,9 @@ func (a adminAPIHandlers) HealHandler(w http.ResponseWriter, r *http.Request) {
 		nh := newHealSequence(GlobalContext, hip.bucket, hip.objPrefix, handlers.GetSourceIP(r), hip.hs, hip.forceStart)
 		go func() {
 			respBytes, apiErr, errMsg := globalAllHealState.LaunchNewHealSequence(nh, objectAPI)
+			if apiErr.Code == "" {
+				registerHealJob(nh)
+			}
 			hr := healResp{respBytes, apiErr, errMsg}
 			respCh <- hr
 		}()
@@ -1111,6 +1209,12 @@ func getAggregatedBackgroundHealState(ctx context.Context, o ObjectLayer) (madmi
 			return madmin.BgHealState{}, fmt.Errorf("all remote servers failed to report heal status, cluster is unhealthy")
 		}
 		bgHealStates.Merge(peersHealStates...)
+
+		// Heal progress of drives is only known to the node healing
+		// them, estimate the backlog again from the merged drives.
+		for i := range bgHealStates.Sets {
+			bgHealStates.Sets[i].TotalObjects = estimateHealBacklog(bgHealStates.Sets[i].Disks)
+		}
 	}
 
 	return bgHealStates, nil
@@ -1785,6 +1889,94 @@ func (a adminAPIHandlers) KMSKeyStatusHandler(w http.ResponseWriter, r *http.Req
 	writeSuccessResponseJSON(w, resp)
 }
 
+// KMSReportHandler - GET /minio/admin/v3/kms/report
+// ----------
+// Reports the KMS health and latency along with the master keys in use
+// and the buckets and object counts per key from the last scanner cycle.
+func (a adminAPIHandlers) KMSReportHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "KMSReport")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.KMSListKeysAction)
+	if objectAPI == nil {
+		return
+	}
+
+	if GlobalKMS == nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrKMSNotConfigured), r.URL)
+		return
+	}
+
+	report, err := buildKMSReport(ctx, objectAPI, GlobalKMS)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	resp, err := json.Marshal(report)
+	if err != nil {
+		writeCustomErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInternalError), err.Error(), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, resp)
+}
+
+// MetaDivergencesHandler - GET /minio/admin/v3/metadata-divergences?n=100
+// ----------
+// Returns the number of objects the drives disagreed on per source,
+// summed over all nodes, and the n most recent ones logged when the
+// divergence log is enabled.
+func (a adminAPIHandlers) MetaDivergencesHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "MetaDivergences")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServerInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	n := metaDivergenceDefaultMax
+	if v := r.Form.Get("n"); v != "" {
+		var err error
+		n, err = strconv.Atoi(v)
+		if err != nil || n <= 0 || n > metaDivergenceLogSize {
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+			return
+		}
+	}
+
+	resp, err := json.Marshal(getMetaDivergences(ctx, n))
+	if err != nil {
+		writeCustomErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInternalError), err.Error(), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, resp)
+}
+
+// VersionSkewHandler - GET /minio/admin/v3/version-skew
+// ----------
+// Returns the release and deployment ID each peer announced on its
+// last internode response, flagging peers that differ from this node.
+func (a adminAPIHandlers) VersionSkewHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "VersionSkew")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServerInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	resp, err := json.Marshal(getVersionSkewReport())
+	if err != nil {
+		writeCustomErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInternalError), err.Error(), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, resp)
+}
+
 func getServerInfo(ctx context.Context, r *http.Request) madmin.InfoMessage {
 	kmsStat := fetchKMSStatus()
 
@@ -2422,7 +2614,10 @@ func (a adminAPIHandlers) ServerInfoHandler(w http.ResponseWriter, r *http.Reque
 	}
 
 	// Marshal API response
-	jsonBytes, err := json.Marshal(getServerInfo(ctx, r))
+	jsonBytes, err := json.Marshal(infoMessageWithTopology{
+		InfoMessage: getServerInfo(ctx, r),
+		Topology:    getTopologyInfo(globalEndpoints),
+	})
 	if err != nil {
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
 		return
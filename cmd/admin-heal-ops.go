@@ -133,6 +133,7 @@ func (ahs *allHealState) updateHealStatus(tracker *healingTracker) {
 	ahs.Lock()
 	defer ahs.Unlock()
 	ahs.healStatus[tracker.ID] = *tracker
+	publishHealStatus(tracker, false)
 }
 
 // Sort by zone, set and disk index
@@ -643,6 +644,17 @@ func (h *healSequence) pushHealResultItem(r madmin.HealResultItem) error {
 // signals. When either event happens, it sets the finish status for
 // the heal-sequence.
 func (h *healSequence) healSequenceStart(objAPI ObjectLayer) {
+	shutdownDone, ok := globalShutdownCoordinator.register("heal")
+	if !ok {
+		h.mutex.Lock()
+		h.endTime = UTCNow()
+		h.currentStatus.Summary = healStoppedStatus
+		h.currentStatus.FailureDetail = errShuttingDown.Error()
+		h.mutex.Unlock()
+		return
+	}
+	defer shutdownDone()
+
 	// Set status as running
 	h.mutex.Lock()
 	h.currentStatus.Summary = healRunningStatus
@@ -668,6 +680,18 @@ func (h *healSequence) healSequenceStart(objAPI ObjectLayer) {
 			h.currentStatus.FailureDetail = err.Error()
 		}
 		h.mutex.Unlock()
+	case <-globalShutdownCoordinator.Done():
+		// Stop the traversal at its next safe point and record
+		// the sequence as stopped by the shutdown.
+		h.stop()
+		h.mutex.Lock()
+		h.endTime = UTCNow()
+		h.currentStatus.Summary = healStoppedStatus
+		h.currentStatus.FailureDetail = errShuttingDown.Error()
+		h.mutex.Unlock()
+
+		// Hold the shutdown until the traversal has returned.
+		<-h.traverseAndHealDoneCh
 	case <-h.ctx.Done():
 		h.mutex.Lock()
 		h.endTime = UTCNow()
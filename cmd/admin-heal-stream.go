@@ -0,0 +1,167 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/minio/madmin-go"
+	iampolicy "github.com/minio/pkg/iam/policy"
+	"github.com/qkbyte/minio/internal/logger"
+	"github.com/qkbyte/minio/internal/pubsub"
+)
+
+// healStatusUpdate is a heal progress update of a single drive,
+// published every time its healing tracker is saved.
+type healStatusUpdate struct {
+	Node string             `json:"node"`
+	Time time.Time          `json:"time"`
+	Done bool               `json:"done,omitempty"`
+	Disk madmin.HealingDisk `json:"disk"`
+}
+
+// Mask - heal status updates are not filtered by type.
+func (u healStatusUpdate) Mask() uint64 {
+	return uint64(pubsub.MaskAll)
+}
+
+// publishHealStatus publishes the current progress of the healing
+// tracker to the local heal status stream subscribers.
+func publishHealStatus(h *healingTracker, done bool) {
+	if globalHealStatusStream.NumSubscribers(nil) == 0 {
+		return
+	}
+	globalHealStatusStream.Publish(healStatusUpdate{
+		Node: globalLocalNodeName,
+		Time: UTCNow(),
+		Done: done,
+		Disk: h.toHealingDisk(),
+	})
+}
+
+// healStatusSnapshot returns the heal progress of all drives currently
+// healing, sent to new stream subscribers before any live update.
+func healStatusSnapshot(state madmin.BgHealState) (updates []healStatusUpdate) {
+	now := UTCNow()
+	for _, set := range state.Sets {
+		for _, disk := range set.Disks {
+			if disk.HealInfo == nil {
+				continue
+			}
+			var node string
+			if u, err := url.Parse(disk.Endpoint); err == nil {
+				node = u.Host
+			}
+			updates = append(updates, healStatusUpdate{
+				Node: node,
+				Time: now,
+				Disk: *disk.HealInfo,
+			})
+		}
+	}
+	return updates
+}
+
+// HealStatusStreamHandler - GET /minio/admin/v3/background-heal/status/stream
+// ----------
+// Streams drive heal progress of all nodes as server-sent events, the
+// current progress of every healing drive is sent first, followed by
+// an event every time a healing tracker is updated.
+func (a adminAPIHandlers) HealStatusStreamHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "HealStatusStream")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.HealAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	state, err := getAggregatedBackgroundHealState(ctx, objectAPI)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Publisher and peer clients use nonblocking sends and hence do not
+	// wait for slow receivers, use a buffered channel to absorb bursts.
+	healCh := make(chan pubsub.Maskable, 1000)
+	if err = globalHealStatusStream.Subscribe(pubsub.MaskAll, healCh, ctx.Done(), nil); err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrSlowDown), r.URL)
+		return
+	}
+
+	peers, _ := newPeerRestClients(globalEndpoints)
+	for _, peer := range peers {
+		if peer == nil {
+			continue
+		}
+		peer.HealStatusStream(healCh, ctx.Done())
+	}
+
+	setEventStreamHeaders(w)
+
+	writeEvent := func(u healStatusUpdate) error {
+		data, err := json.Marshal(u)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write([]byte("data: " + string(data) + "\n\n"))
+		return err
+	}
+
+	for _, u := range healStatusSnapshot(state) {
+		if writeEvent(u) != nil {
+			return
+		}
+	}
+	w.(http.Flusher).Flush()
+
+	keepAliveTicker := time.NewTicker(time.Second)
+	defer keepAliveTicker.Stop()
+
+	for {
+		select {
+		case entry := <-healCh:
+			u, ok := entry.(healStatusUpdate)
+			if !ok {
+				continue
+			}
+			if writeEvent(u) != nil {
+				return
+			}
+			if len(healCh) == 0 {
+				// Flush if nothing is queued
+				w.(http.Flusher).Flush()
+			}
+		case <-keepAliveTicker.C:
+			if len(healCh) > 0 {
+				continue
+			}
+			if _, err := w.Write([]byte(": keepalive\n\n")); err != nil {
+				return
+			}
+			w.(http.Flusher).Flush()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/minio/madmin-go"
+	"github.com/qkbyte/minio/internal/pubsub"
+)
+
+func TestHealStatusStream(t *testing.T) {
+	tracker := &healingTracker{
+		ID:          "disk-1",
+		Endpoint:    "http://node1:9000/data1",
+		ItemsHealed: 10,
+		BytesDone:   1024,
+	}
+
+	// No subscribers, nothing should be published.
+	publishHealStatus(tracker, false)
+
+	doneCh := make(chan struct{})
+	defer close(doneCh)
+	ch := make(chan pubsub.Maskable, 2)
+	if err := globalHealStatusStream.Subscribe(pubsub.MaskAll, ch, doneCh, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	publishHealStatus(tracker, false)
+	tracker.ItemsHealed = 20
+	publishHealStatus(tracker, true)
+
+	for i, want := range []struct {
+		items uint64
+		done  bool
+	}{{10, false}, {20, true}} {
+		select {
+		case entry := <-ch:
+			u, ok := entry.(healStatusUpdate)
+			if !ok {
+				t.Fatalf("%d: unexpected entry %T", i, entry)
+			}
+			if u.Disk.ID != tracker.ID || u.Disk.ItemsHealed != want.items || u.Done != want.done {
+				t.Fatalf("%d: unexpected update %+v", i, u)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("%d: timed out waiting for heal status update", i)
+		}
+	}
+
+	snapshot := healStatusSnapshot(madmin.BgHealState{
+		Sets: []madmin.SetStatus{{
+			Disks: []madmin.Disk{
+				{Endpoint: "http://node1:9000/data1", HealInfo: &madmin.HealingDisk{ID: "disk-1"}},
+				{Endpoint: "http://node1:9000/data2"},
+			},
+		}},
+	})
+	if len(snapshot) != 1 || snapshot[0].Node != "node1:9000" || snapshot[0].Disk.ID != "disk-1" {
+		t.Fatalf("unexpected snapshot %+v", snapshot)
+	}
+}
@@ -0,0 +1,393 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	iampolicy "github.com/minio/pkg/iam/policy"
+	xhttp "github.com/qkbyte/minio/internal/http"
+	"github.com/qkbyte/minio/internal/logger"
+)
+
+const (
+	// Maximum number of finished jobs remembered by a node.
+	adminJobHistoryMax = 1000
+
+	// Interval at which finished jobs are moved to the history.
+	adminJobReapInterval = time.Minute
+)
+
+// Types of jobs reported by the admin job APIs.
+const (
+	adminJobHeal              = "heal"
+	adminJobDecommission      = "decommission"
+	adminJobPrefixScan        = "prefix-scan"
+	adminJobXLMetaMaintenance = "xlmeta-maintenance"
+	adminJobIAMImport         = "iam-import"
+)
+
+var (
+	errAdminJobNotFound      = errors.New("job not found")
+	errAdminJobNotRunning    = errors.New("job is not running")
+	errAdminJobNotCancelable = errors.New("job cannot be canceled")
+)
+
+// adminJobActions - the admin action needed to cancel a job of each type.
+var adminJobActions = map[string]iampolicy.AdminAction{
+	adminJobHeal:              iampolicy.HealAdminAction,
+	adminJobDecommission:      iampolicy.DecommissionAdminAction,
+	adminJobPrefixScan:        iampolicy.HealAdminAction,
+	adminJobXLMetaMaintenance: iampolicy.HealAdminAction,
+	adminJobIAMImport:         iampolicy.ImportIAMAction,
+}
+
+// adminJobInfo - a long running admin operation as reported by the
+// unified job APIs, Detail holds the job type specific status.
+type adminJobInfo struct {
+	ID       string          `json:"id"`
+	Type     string          `json:"type"`
+	Node     string          `json:"node"`
+	Status   string          `json:"status"`
+	Error    string          `json:"error,omitempty"`
+	Started  time.Time       `json:"started"`
+	Finished time.Time       `json:"finished,omitempty"`
+	Detail   json.RawMessage `json:"detail,omitempty"`
+}
+
+// adminJobState - the state of a job as reported by its implementation,
+// a zero Finished time of a finished job is replaced by the time its end
+// was first observed.
+type adminJobState struct {
+	Status   string
+	Error    string
+	Finished time.Time
+	Detail   interface{}
+}
+
+type adminJob struct {
+	info   adminJobInfo
+	state  func() adminJobState
+	cancel func() error
+}
+
+// adminJobs tracks the jobs started on this node and the history of
+// those which finished, persisted in the backend.
+type adminJobs struct {
+	mu      sync.Mutex
+	jobs    map[string]*adminJob
+	history []adminJobInfo
+	dirty   bool
+}
+
+var globalAdminJobs = newAdminJobs()
+
+func newAdminJobs() *adminJobs {
+	return &adminJobs{jobs: make(map[string]*adminJob)}
+}
+
+// adminJobPublicID returns the id clients use for a job started on this
+// node, requests for it are proxied to this node.
+func adminJobPublicID(id string) string {
+	if globalIsDistErasure {
+		return fmt.Sprintf("%s@%d", id, GetProxyEndpointLocalIndex(globalProxyEndpoints))
+	}
+	return id
+}
+
+// adminJobHistoryFile - the object holding the job history of this node.
+func adminJobHistoryFile() string {
+	idx := GetProxyEndpointLocalIndex(globalProxyEndpoints)
+	if idx < 0 {
+		idx = 0
+	}
+	return fmt.Sprintf("jobs/history-%d.json", idx)
+}
+
+// register tracks a job started on this node under its local id, state
+// reports its progress and cancel stops it. The public id is returned.
+func (a *adminJobs) register(jobType, id string, started time.Time, state func() adminJobState, cancel func() error) string {
+	job := &adminJob{
+		info: adminJobInfo{
+			ID:      adminJobPublicID(id),
+			Type:    jobType,
+			Node:    globalLocalNodeName,
+			Status:  jobStatusRunning,
+			Started: started,
+		},
+		state:  state,
+		cancel: cancel,
+	}
+	a.mu.Lock()
+	a.jobs[id] = job
+	a.mu.Unlock()
+	return job.info.ID
+}
+
+// snapshot returns the current info of a job.
+func (j *adminJob) snapshot(now time.Time) adminJobInfo {
+	info := j.info
+	st := j.state()
+	info.Status, info.Error, info.Finished = st.Status, st.Error, st.Finished
+	if info.Status != jobStatusRunning && info.Finished.IsZero() {
+		info.Finished = now
+	}
+	if st.Detail != nil {
+		if detail, err := json.Marshal(st.Detail); err == nil {
+			info.Detail = detail
+		}
+	}
+	return info
+}
+
+// refresh moves the jobs which finished to the history and returns the
+// info of those still running.
+func (a *adminJobs) refresh() []adminJobInfo {
+	a.mu.Lock()
+	jobs := make(map[string]*adminJob, len(a.jobs))
+	for id, job := range a.jobs {
+		jobs[id] = job
+	}
+	a.mu.Unlock()
+
+	now := UTCNow()
+	running := make([]adminJobInfo, 0, len(jobs))
+	var finished []adminJobInfo
+	var finishedIDs []string
+	for id, job := range jobs {
+		info := job.snapshot(now)
+		if info.Status == jobStatusRunning {
+			running = append(running, info)
+			continue
+		}
+		finished = append(finished, info)
+		finishedIDs = append(finishedIDs, id)
+	}
+
+	if len(finished) > 0 {
+		sort.Slice(finished, func(i, j int) bool { return finished[i].Finished.Before(finished[j].Finished) })
+		a.mu.Lock()
+		for _, id := range finishedIDs {
+			delete(a.jobs, id)
+		}
+		a.history = append(a.history, finished...)
+		if n := len(a.history) - adminJobHistoryMax; n > 0 {
+			a.history = append([]adminJobInfo(nil), a.history[n:]...)
+		}
+		a.dirty = true
+		a.mu.Unlock()
+	}
+	return running
+}
+
+// list returns the running and finished jobs of this node.
+func (a *adminJobs) list() []adminJobInfo {
+	jobs := a.refresh()
+	a.mu.Lock()
+	jobs = append(jobs, a.history...)
+	a.mu.Unlock()
+	return jobs
+}
+
+// get returns the info of the job with the local id.
+func (a *adminJobs) get(id string) (adminJobInfo, bool) {
+	a.mu.Lock()
+	job, ok := a.jobs[id]
+	a.mu.Unlock()
+	if ok {
+		return job.snapshot(UTCNow()), true
+	}
+
+	publicID := adminJobPublicID(id)
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for i := len(a.history) - 1; i >= 0; i-- {
+		if a.history[i].ID == publicID {
+			return a.history[i], true
+		}
+	}
+	return adminJobInfo{}, false
+}
+
+// jobType returns the type of the running job with the local id.
+func (a *adminJobs) jobType(id string) (string, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	job, ok := a.jobs[id]
+	if !ok {
+		return "", false
+	}
+	return job.info.Type, true
+}
+
+// cancel stops the running job with the local id.
+func (a *adminJobs) cancel(id string) error {
+	a.mu.Lock()
+	job, ok := a.jobs[id]
+	a.mu.Unlock()
+	if !ok {
+		if _, ok = a.get(id); ok {
+			return errAdminJobNotRunning
+		}
+		return errAdminJobNotFound
+	}
+	if job.snapshot(UTCNow()).Status != jobStatusRunning {
+		return errAdminJobNotRunning
+	}
+	return job.cancel()
+}
+
+// save persists the history if it changed.
+func (a *adminJobs) save(ctx context.Context, objAPI ObjectLayer) error {
+	a.mu.Lock()
+	if !a.dirty {
+		a.mu.Unlock()
+		return nil
+	}
+	data, err := json.Marshal(a.history)
+	a.dirty = false
+	a.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return saveConfig(ctx, objAPI, adminJobHistoryFile(), data)
+}
+
+// init loads the persisted history and periodically moves finished jobs
+// to it.
+func (a *adminJobs) init(ctx context.Context, objAPI ObjectLayer) {
+	data, err := readConfig(ctx, objAPI, adminJobHistoryFile())
+	if err != nil && !errors.Is(err, errConfigNotFound) {
+		logger.LogIf(ctx, fmt.Errorf("Unable to load admin job history: %w", err))
+	}
+	if len(data) > 0 {
+		var history []adminJobInfo
+		if err = json.Unmarshal(data, &history); err != nil {
+			logger.LogIf(ctx, fmt.Errorf("Unable to parse admin job history: %w", err))
+		}
+		a.mu.Lock()
+		a.history = append(history, a.history...)
+		a.mu.Unlock()
+	}
+
+	go func() {
+		t := time.NewTicker(adminJobReapInterval)
+		defer t.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-t.C:
+				a.refresh()
+				logger.LogIf(ctx, a.save(ctx, objAPI))
+			}
+		}
+	}()
+}
+
+// registerHealJob tracks a heal sequence started by a client.
+func registerHealJob(h *healSequence) string {
+	return globalAdminJobs.register(adminJobHeal, h.clientToken, h.startTime, func() adminJobState {
+		h.mutex.RLock()
+		summary, detail, endTime := h.currentStatus.Summary, h.currentStatus.FailureDetail, h.endTime
+		h.mutex.RUnlock()
+		st := adminJobState{
+			Finished: endTime,
+			Detail: map[string]interface{}{
+				"path":    pathJoin(h.bucket, h.object),
+				"scanned": h.getScannedItemsMap(),
+				"healed":  h.getHealedItemsMap(),
+				"failed":  h.gethealFailedItemsMap(),
+			},
+		}
+		switch {
+		case summary == healStoppedStatus:
+			st.Status = jobStatusCanceled
+		case summary == healFinishedStatus && detail != "":
+			st.Status, st.Error = jobStatusFailed, detail
+		case summary == healFinishedStatus:
+			st.Status = jobStatusDone
+		default:
+			st.Status = jobStatusRunning
+		}
+		return st
+	}, func() error {
+		h.stop()
+		return nil
+	})
+}
+
+// registerDecommissionJob tracks the decommission of the pool idx.
+func registerDecommissionJob(z *erasureServerPools, idx int) string {
+	return globalAdminJobs.register(adminJobDecommission, mustGetUUID(), UTCNow(), func() adminJobState {
+		ps, err := z.Status(GlobalContext, idx)
+		if err != nil {
+			return adminJobState{Status: jobStatusRunning, Error: err.Error()}
+		}
+		st := adminJobState{Status: jobStatusRunning, Detail: ps}
+		switch d := ps.Decommission; {
+		case d == nil:
+			st.Status = jobStatusCanceled
+		case d.Complete:
+			st.Status = jobStatusDone
+		case d.Failed:
+			st.Status = jobStatusFailed
+		case d.Canceled:
+			st.Status = jobStatusCanceled
+		}
+		return st
+	}, func() error {
+		return z.DecommissionCancel(GlobalContext, idx)
+	})
+}
+
+// registerRequestJob tracks an admin operation served within a single
+// request, its id is sent with the response. The returned writer must be
+// used for the response and done called once it was served, the job
+// failed if the response is an error.
+func registerRequestJob(w http.ResponseWriter, jobType string) (rw http.ResponseWriter, done func()) {
+	var (
+		mu sync.Mutex
+		st = adminJobState{Status: jobStatusRunning}
+	)
+	id := globalAdminJobs.register(jobType, mustGetUUID(), UTCNow(), func() adminJobState {
+		mu.Lock()
+		defer mu.Unlock()
+		return st
+	}, func() error {
+		return errAdminJobNotCancelable
+	})
+	w.Header().Set(xhttp.MinIOJobID, id)
+
+	sw := logger.NewResponseWriter(w)
+	return sw, func() {
+		mu.Lock()
+		defer mu.Unlock()
+		st.Status, st.Finished = jobStatusDone, UTCNow()
+		if sw.StatusCode >= http.StatusBadRequest {
+			st.Status, st.Error = jobStatusFailed, http.StatusText(sw.StatusCode)
+		}
+	}
+}
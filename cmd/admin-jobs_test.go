@@ -0,0 +1,119 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	xhttp "github.com/qkbyte/minio/internal/http"
+)
+
+func TestAdminJobs(t *testing.T) {
+	jobs := newAdminJobs()
+
+	var mu sync.Mutex
+	status := jobStatusRunning
+	state := func() adminJobState {
+		mu.Lock()
+		defer mu.Unlock()
+		return adminJobState{Status: status, Detail: map[string]int{"done": 1}}
+	}
+	cancel := func() error {
+		mu.Lock()
+		status = jobStatusCanceled
+		mu.Unlock()
+		return nil
+	}
+
+	id := jobs.register(adminJobPrefixScan, "job1", UTCNow(), state, cancel)
+	if id != "job1" {
+		t.Fatalf("Expected public id job1, got %s", id)
+	}
+
+	info, ok := jobs.get("job1")
+	if !ok || info.Status != jobStatusRunning || info.Type != adminJobPrefixScan || string(info.Detail) != `{"done":1}` {
+		t.Fatalf("Unexpected job info %+v", info)
+	}
+	if jobType, ok := jobs.jobType("job1"); !ok || jobType != adminJobPrefixScan {
+		t.Fatalf("Unexpected job type %s", jobType)
+	}
+
+	if err := jobs.cancel("job1"); err != nil {
+		t.Fatalf("Failed to cancel job: %v", err)
+	}
+	if err := jobs.cancel("job1"); err != errAdminJobNotRunning {
+		t.Fatalf("Expected %v, got %v", errAdminJobNotRunning, err)
+	}
+	if err := jobs.cancel("nosuchjob"); err != errAdminJobNotFound {
+		t.Fatalf("Expected %v, got %v", errAdminJobNotFound, err)
+	}
+
+	// Finished jobs move to the history.
+	list := jobs.list()
+	if len(list) != 1 || list[0].Status != jobStatusCanceled || list[0].Finished.IsZero() {
+		t.Fatalf("Unexpected job list %+v", list)
+	}
+	if _, ok = jobs.jobType("job1"); ok {
+		t.Fatal("Expected job to be moved to the history")
+	}
+	if info, ok = jobs.get("job1"); !ok || info.Status != jobStatusCanceled {
+		t.Fatalf("Expected job in the history, got %+v", info)
+	}
+	if !jobs.dirty {
+		t.Fatal("Expected history to be marked for saving")
+	}
+
+	// The history is bounded.
+	done := func() adminJobState { return adminJobState{Status: jobStatusDone} }
+	for i := 0; i < adminJobHistoryMax+10; i++ {
+		jobs.register(adminJobHeal, mustGetUUID(), UTCNow(), done, nil)
+	}
+	if list = jobs.list(); len(list) != adminJobHistoryMax {
+		t.Fatalf("Expected %d jobs, got %d", adminJobHistoryMax, len(list))
+	}
+}
+
+func TestRequestJob(t *testing.T) {
+	defer func(jobs *adminJobs) { globalAdminJobs = jobs }(globalAdminJobs)
+	globalAdminJobs = newAdminJobs()
+
+	for _, code := range []int{http.StatusOK, http.StatusBadRequest} {
+		rec := httptest.NewRecorder()
+		w, done := registerRequestJob(rec, adminJobIAMImport)
+		id := rec.Header().Get(xhttp.MinIOJobID)
+		if info, ok := globalAdminJobs.get(id); !ok || info.Status != jobStatusRunning {
+			t.Fatalf("Expected running job %s, got %+v", id, info)
+		}
+		if err := globalAdminJobs.cancel(id); err != errAdminJobNotCancelable {
+			t.Fatalf("Expected %v, got %v", errAdminJobNotCancelable, err)
+		}
+
+		w.WriteHeader(code)
+		done()
+		info, _ := globalAdminJobs.get(id)
+		switch {
+		case code == http.StatusOK && info.Status != jobStatusDone:
+			t.Fatalf("Expected job to be done, got %+v", info)
+		case code != http.StatusOK && info.Status != jobStatusFailed:
+			t.Fatalf("Expected job to fail, got %+v", info)
+		}
+	}
+}
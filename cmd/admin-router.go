@@ -57,6 +57,9 @@ func registerAdminRouter(router *mux.Router, enableConfigOps bool) {
 		adminRouter.Methods(http.MethodPost).Path(adminVersion+"/service").HandlerFunc(gz(httpTraceAll(adminAPI.ServiceHandler))).Queries("action", "{action:.*}")
 		// Update MinIO servers.
 		adminRouter.Methods(http.MethodPost).Path(adminVersion+"/update").HandlerFunc(gz(httpTraceAll(adminAPI.ServerUpdateHandler))).Queries("updateURL", "{updateURL:.*}")
+		// Rolling restart progress and abort.
+		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/rolling-restart/status").HandlerFunc(gz(httpTraceAll(adminAPI.RollingRestartStatusHandler)))
+		adminRouter.Methods(http.MethodPost).Path(adminVersion + "/rolling-restart/abort").HandlerFunc(gz(httpTraceAll(adminAPI.RollingRestartAbortHandler)))
 
 		// Info operations
 		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/info").HandlerFunc(gz(httpTraceAll(adminAPI.ServerInfoHandler)))
@@ -66,8 +69,35 @@ func registerAdminRouter(router *mux.Router, enableConfigOps bool) {
 		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/storageinfo").HandlerFunc(gz(httpTraceAll(adminAPI.StorageInfoHandler)))
 		// DataUsageInfo operations
 		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/datausageinfo").HandlerFunc(gz(httpTraceAll(adminAPI.DataUsageInfoHandler)))
+		adminRouter.Methods(http.MethodGet).Path(adminVersion+"/changed-prefixes").HandlerFunc(gz(httpTraceAll(adminAPI.ChangedPrefixesHandler))).Queries("bucket", "{bucket:.*}", "since", "{since:.*}")
+		// API anomalies operation
+		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/api-anomalies").HandlerFunc(gz(httpTraceAll(adminAPI.APIAnomaliesHandler)))
 		// Metrics operation
 		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/metrics").HandlerFunc(gz(httpTraceAll(adminAPI.MetricsHandler)))
+		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/os-metrics").HandlerFunc(gz(httpTraceAll(adminAPI.OSMetricsHandler)))
+		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/slow-dirs").HandlerFunc(gz(httpTraceAll(adminAPI.SlowDirsHandler)))
+
+		// Top objects by requests and by latency
+		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/top-objects").HandlerFunc(gz(httpTraceAll(adminAPI.TopObjectsHandler)))
+
+		// Clock offsets of all nodes
+		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/clock-skew").HandlerFunc(gz(httpTraceAll(adminAPI.ClockSkewHandler)))
+
+		// Membership and health of all nodes
+		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/membership").HandlerFunc(gz(httpTraceAll(adminAPI.MembershipHandler)))
+
+		// Console dashboard summary
+		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/dashboard").HandlerFunc(gz(httpTraceAll(adminAPI.DashboardHandler)))
+
+		// Diagnostics bundle for offline support
+		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/diagnostics-bundle").HandlerFunc(httpTraceHdrs(adminAPI.DiagnosticsBundleHandler))
+
+		// Objects the drives disagreed on while resolving them
+		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/metadata-divergences").HandlerFunc(gz(httpTraceAll(adminAPI.MetaDivergencesHandler)))
+		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/version-skew").HandlerFunc(gz(httpTraceAll(adminAPI.VersionSkewHandler)))
+
+		// Reed-Solomon acceleration path of all nodes
+		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/erasure-simd").HandlerFunc(gz(httpTraceAll(adminAPI.ErasureSIMDHandler)))
 
 		if globalIsDistErasure || globalIsErasure {
 			// Heal operations
@@ -77,6 +107,26 @@ func registerAdminRouter(router *mux.Router, enableConfigOps bool) {
 			adminRouter.Methods(http.MethodPost).Path(adminVersion + "/heal/{bucket}").HandlerFunc(gz(httpTraceAll(adminAPI.HealHandler)))
 			adminRouter.Methods(http.MethodPost).Path(adminVersion + "/heal/{bucket}/{prefix:.*}").HandlerFunc(gz(httpTraceAll(adminAPI.HealHandler)))
 			adminRouter.Methods(http.MethodPost).Path(adminVersion + "/background-heal/status").HandlerFunc(gz(httpTraceAll(adminAPI.BackgroundHealStatusHandler)))
+			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/background-heal/status/stream").HandlerFunc(gz(httpTraceHdrs(adminAPI.HealStatusStreamHandler)))
+
+			// On-demand deep scan of a bucket prefix
+			adminRouter.Methods(http.MethodPost).Path(adminVersion+"/prefix-scan").HandlerFunc(gz(httpTraceAll(adminAPI.StartPrefixScanHandler))).Queries("bucket", "{bucket:.*}")
+			adminRouter.Methods(http.MethodGet).Path(adminVersion+"/prefix-scan").HandlerFunc(gz(httpTraceAll(adminAPI.PrefixScanStatusHandler))).Queries("id", "{id:.*}")
+			adminRouter.Methods(http.MethodDelete).Path(adminVersion+"/prefix-scan").HandlerFunc(gz(httpTraceAll(adminAPI.CancelPrefixScanHandler))).Queries("id", "{id:.*}")
+
+			// xl.meta compaction and repair
+			adminRouter.Methods(http.MethodPost).Path(adminVersion + "/xlmeta-maintenance").HandlerFunc(gz(httpTraceAll(adminAPI.StartXLMetaMaintenanceHandler)))
+			adminRouter.Methods(http.MethodGet).Path(adminVersion+"/xlmeta-maintenance").HandlerFunc(gz(httpTraceAll(adminAPI.XLMetaMaintenanceStatusHandler))).Queries("id", "{id:.*}")
+			adminRouter.Methods(http.MethodDelete).Path(adminVersion+"/xlmeta-maintenance").HandlerFunc(gz(httpTraceAll(adminAPI.CancelXLMetaMaintenanceHandler))).Queries("id", "{id:.*}")
+			adminRouter.Methods(http.MethodGet).Path(adminVersion+"/inspect-object").HandlerFunc(gz(httpTraceAll(adminAPI.InspectObjectHandler))).Queries("bucket", "{bucket:.*}", "object", "{object:.*}")
+
+			// Long running admin jobs
+			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/jobs").HandlerFunc(gz(httpTraceAll(adminAPI.ListAdminJobsHandler)))
+			adminRouter.Methods(http.MethodGet).Path(adminVersion+"/job").HandlerFunc(gz(httpTraceAll(adminAPI.AdminJobStatusHandler))).Queries("id", "{id:.*}")
+			adminRouter.Methods(http.MethodDelete).Path(adminVersion+"/job").HandlerFunc(gz(httpTraceAll(adminAPI.CancelAdminJobHandler))).Queries("id", "{id:.*}")
+
+			// Access key usage accounting
+			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/usage/access-keys").HandlerFunc(gz(httpTraceAll(adminAPI.ExportAccessKeyUsageHandler)))
 
 			// Pool operations
 			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/pools/list").HandlerFunc(gz(httpTraceAll(adminAPI.ListPools)))
@@ -84,6 +134,17 @@ func registerAdminRouter(router *mux.Router, enableConfigOps bool) {
 
 			adminRouter.Methods(http.MethodPost).Path(adminVersion+"/pools/decommission").HandlerFunc(gz(httpTraceAll(adminAPI.StartDecommission))).Queries("pool", "{pool:.*}")
 			adminRouter.Methods(http.MethodPost).Path(adminVersion+"/pools/cancel").HandlerFunc(gz(httpTraceAll(adminAPI.CancelDecommission))).Queries("pool", "{pool:.*}")
+			adminRouter.Methods(http.MethodPost).Path(adminVersion+"/pools/state").HandlerFunc(gz(httpTraceAll(adminAPI.SetPoolState))).Queries("pool", "{pool:.*}", "state", "{state:.*}")
+
+			// Drive identity operations
+			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/drives/identity").HandlerFunc(gz(httpTraceAll(adminAPI.DriveIdentityHandler)))
+		} else if globalIsErasureSD {
+			// Heal operations, on a single drive these verify the
+			// integrity of the namespace without repairing it.
+			adminRouter.Methods(http.MethodPost).Path(adminVersion + "/heal/").HandlerFunc(gz(httpTraceAll(adminAPI.HealHandler)))
+			adminRouter.Methods(http.MethodPost).Path(adminVersion + "/heal/{bucket}").HandlerFunc(gz(httpTraceAll(adminAPI.HealHandler)))
+			adminRouter.Methods(http.MethodPost).Path(adminVersion + "/heal/{bucket}/{prefix:.*}").HandlerFunc(gz(httpTraceAll(adminAPI.HealHandler)))
+			adminRouter.Methods(http.MethodPost).Path(adminVersion + "/background-heal/status").HandlerFunc(gz(httpTraceAll(adminAPI.BackgroundHealStatusHandler)))
 		}
 
 		// Profiling operations - deprecated API
@@ -108,6 +169,7 @@ func registerAdminRouter(router *mux.Router, enableConfigOps bool) {
 			adminRouter.Methods(http.MethodGet).Path(adminVersion+"/list-config-history-kv").HandlerFunc(gz(httpTraceAll(adminAPI.ListConfigHistoryKVHandler))).Queries("count", "{count:[0-9]+}")
 			adminRouter.Methods(http.MethodDelete).Path(adminVersion+"/clear-config-history-kv").HandlerFunc(gz(httpTraceHdrs(adminAPI.ClearConfigHistoryKVHandler))).Queries("restoreId", "{restoreId:.*}")
 			adminRouter.Methods(http.MethodPut).Path(adminVersion+"/restore-config-history-kv").HandlerFunc(gz(httpTraceHdrs(adminAPI.RestoreConfigHistoryKVHandler))).Queries("restoreId", "{restoreId:.*}")
+			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/config-journal").HandlerFunc(gz(httpTraceAll(adminAPI.ConfigJournalHandler)))
 		}
 
 		// Config import/export bulk operations
@@ -192,6 +254,80 @@ func registerAdminRouter(router *mux.Router, enableConfigOps bool) {
 		adminRouter.Methods(http.MethodPut).Path(adminVersion+"/set-bucket-quota").HandlerFunc(
 			gz(httpTraceHdrs(adminAPI.PutBucketQuotaConfigHandler))).Queries("bucket", "{bucket:.*}")
 
+		// Bucket recycle bin operations
+		adminRouter.Methods(http.MethodPut).Path(adminVersion+"/set-bucket-trash").HandlerFunc(
+			gz(httpTraceHdrs(adminAPI.PutBucketTrashConfigHandler))).Queries("bucket", "{bucket:.*}")
+		adminRouter.Methods(http.MethodGet).Path(adminVersion+"/get-bucket-trash").HandlerFunc(
+			gz(httpTraceHdrs(adminAPI.GetBucketTrashConfigHandler))).Queries("bucket", "{bucket:.*}")
+		adminRouter.Methods(http.MethodGet).Path(adminVersion+"/list-bucket-trash").HandlerFunc(
+			gz(httpTraceHdrs(adminAPI.ListBucketTrashHandler))).Queries("bucket", "{bucket:.*}")
+		adminRouter.Methods(http.MethodPost).Path(adminVersion+"/undelete-object").HandlerFunc(
+			gz(httpTraceHdrs(adminAPI.UndeleteObjectHandler))).Queries("bucket", "{bucket:.*}", "id", "{id:.*}")
+
+		// Bucket inline data threshold
+		adminRouter.Methods(http.MethodPut).Path(adminVersion+"/set-bucket-inline").HandlerFunc(
+			gz(httpTraceHdrs(adminAPI.PutBucketInlineConfigHandler))).Queries("bucket", "{bucket:.*}")
+		adminRouter.Methods(http.MethodGet).Path(adminVersion+"/get-bucket-inline").HandlerFunc(
+			gz(httpTraceHdrs(adminAPI.GetBucketInlineConfigHandler))).Queries("bucket", "{bucket:.*}")
+
+		// Bucket suspension
+		adminRouter.Methods(http.MethodPut).Path(adminVersion+"/set-bucket-suspend").HandlerFunc(
+			gz(httpTraceHdrs(adminAPI.PutBucketSuspendConfigHandler))).Queries("bucket", "{bucket:.*}")
+		adminRouter.Methods(http.MethodGet).Path(adminVersion+"/get-bucket-suspend").HandlerFunc(
+			gz(httpTraceHdrs(adminAPI.GetBucketSuspendConfigHandler))).Queries("bucket", "{bucket:.*}")
+
+		// Bucket external metadata indexer
+		adminRouter.Methods(http.MethodPut).Path(adminVersion+"/set-bucket-indexer").HandlerFunc(
+			gz(httpTraceHdrs(adminAPI.PutBucketIndexerConfigHandler))).Queries("bucket", "{bucket:.*}")
+		adminRouter.Methods(http.MethodGet).Path(adminVersion+"/get-bucket-indexer").HandlerFunc(
+			gz(httpTraceHdrs(adminAPI.GetBucketIndexerConfigHandler))).Queries("bucket", "{bucket:.*}")
+
+		// Bucket changes feed
+		adminRouter.Methods(http.MethodPut).Path(adminVersion+"/set-bucket-changes-feed").HandlerFunc(
+			gz(httpTraceHdrs(adminAPI.PutBucketChangesFeedConfigHandler))).Queries("bucket", "{bucket:.*}")
+		adminRouter.Methods(http.MethodGet).Path(adminVersion+"/get-bucket-changes-feed").HandlerFunc(
+			gz(httpTraceHdrs(adminAPI.GetBucketChangesFeedConfigHandler))).Queries("bucket", "{bucket:.*}")
+
+		// Bucket audit manifest
+		adminRouter.Methods(http.MethodPut).Path(adminVersion+"/set-bucket-audit-manifest").HandlerFunc(
+			gz(httpTraceHdrs(adminAPI.PutBucketAuditManifestConfigHandler))).Queries("bucket", "{bucket:.*}")
+		adminRouter.Methods(http.MethodGet).Path(adminVersion+"/get-bucket-audit-manifest").HandlerFunc(
+			gz(httpTraceHdrs(adminAPI.GetBucketAuditManifestConfigHandler))).Queries("bucket", "{bucket:.*}")
+		adminRouter.Methods(http.MethodGet).Path(adminVersion+"/audit-manifest").HandlerFunc(
+			gz(httpTraceHdrs(adminAPI.ReadBucketAuditManifestHandler))).Queries("bucket", "{bucket:.*}")
+		adminRouter.Methods(http.MethodGet).Path(adminVersion+"/verify-audit-manifest").HandlerFunc(
+			gz(httpTraceHdrs(adminAPI.VerifyBucketAuditManifestHandler))).Queries("bucket", "{bucket:.*}")
+		adminRouter.Methods(http.MethodPost).Path(adminVersion + "/rotate-audit-manifest-key").HandlerFunc(
+			gz(httpTraceHdrs(adminAPI.RotateAuditManifestKeyHandler)))
+
+		// Bucket share links
+		adminRouter.Methods(http.MethodPost).Path(adminVersion+"/share-link").HandlerFunc(
+			gz(httpTraceHdrs(adminAPI.AddShareLinkHandler))).Queries("bucket", "{bucket:.*}")
+		adminRouter.Methods(http.MethodGet).Path(adminVersion+"/share-links").HandlerFunc(
+			gz(httpTraceHdrs(adminAPI.ListShareLinksHandler))).Queries("bucket", "{bucket:.*}")
+		adminRouter.Methods(http.MethodDelete).Path(adminVersion+"/share-link").HandlerFunc(
+			gz(httpTraceHdrs(adminAPI.RemoveShareLinkHandler))).Queries("bucket", "{bucket:.*}")
+
+		// Tenants with aggregate quotas across their buckets
+		adminRouter.Methods(http.MethodPut).Path(adminVersion+"/tenant").HandlerFunc(
+			gz(httpTraceHdrs(adminAPI.SetTenantHandler))).Queries("name", "{name:.+}")
+		adminRouter.Methods(http.MethodDelete).Path(adminVersion+"/tenant").HandlerFunc(
+			gz(httpTraceHdrs(adminAPI.RemoveTenantHandler))).Queries("name", "{name:.+}")
+		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/tenants").HandlerFunc(
+			gz(httpTraceHdrs(adminAPI.ListTenantsHandler)))
+
+		// Object retention deviating from the bucket default
+		adminRouter.Methods(http.MethodGet).Path(adminVersion+"/retention-report").HandlerFunc(
+			gz(httpTraceHdrs(adminAPI.RetentionReportHandler))).Queries("bucket", "{bucket:.*}")
+
+		// Bucket encryption compliance report
+		adminRouter.Methods(http.MethodGet).Path(adminVersion+"/sse-compliance-report").HandlerFunc(
+			gz(httpTraceHdrs(adminAPI.SSEComplianceReportHandler))).Queries("bucket", "{bucket:.*}")
+
+		// Prefix-level versioning exclusions
+		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/versioning-excludes").HandlerFunc(
+			gz(httpTraceHdrs(adminAPI.VersioningExcludesHandler)))
+
 		// Bucket replication operations
 		// GetBucketTargetHandler
 		adminRouter.Methods(http.MethodGet).Path(adminVersion+"/list-remote-targets").HandlerFunc(
@@ -205,6 +341,9 @@ func registerAdminRouter(router *mux.Router, enableConfigOps bool) {
 		// ReplicationDiff - MinIO extension API
 		adminRouter.Methods(http.MethodPost).Path(adminVersion+"/replication/diff").HandlerFunc(
 			gz(httpTraceHdrs(adminAPI.ReplicationDiffHandler))).Queries("bucket", "{bucket:.*}")
+		// ReplicationDeletePreview - MinIO extension API
+		adminRouter.Methods(http.MethodPost).Path(adminVersion+"/replication/delete-preview").HandlerFunc(
+			gz(httpTraceHdrs(adminAPI.ReplicationDeletePreviewHandler))).Queries("bucket", "{bucket:.*}")
 
 		// Bucket migration operations
 		// ExportBucketMetaHandler
@@ -213,6 +352,18 @@ func registerAdminRouter(router *mux.Router, enableConfigOps bool) {
 		// ImportBucketMetaHandler
 		adminRouter.Methods(http.MethodPut).Path(adminVersion + "/import-bucket-metadata").HandlerFunc(
 			gz(httpTraceHdrs(adminAPI.ImportBucketMetadataHandler)))
+		// ImportAWSBucketConfigHandler
+		adminRouter.Methods(http.MethodPut).Path(adminVersion+"/import-aws-bucket-config").HandlerFunc(
+			gz(httpTraceHdrs(adminAPI.ImportAWSBucketConfigHandler))).Queries("bucket", "{bucket:.*}")
+
+		// Bucket DNS federation operations
+		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/dns/records").HandlerFunc(gz(httpTraceHdrs(adminAPI.ListBucketDNSHandler)))
+		adminRouter.Methods(http.MethodPut).Path(adminVersion+"/dns/records").HandlerFunc(
+			gz(httpTraceHdrs(adminAPI.RegisterBucketDNSHandler))).Queries("bucket", "{bucket:.*}")
+		adminRouter.Methods(http.MethodDelete).Path(adminVersion+"/dns/records").HandlerFunc(
+			gz(httpTraceHdrs(adminAPI.UnregisterBucketDNSHandler))).Queries("bucket", "{bucket:.*}")
+		adminRouter.Methods(http.MethodPost).Path(adminVersion + "/dns/sync").HandlerFunc(gz(httpTraceHdrs(adminAPI.SyncBucketDNSHandler)))
+		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/dns/zone").HandlerFunc(gz(httpTraceHdrs(adminAPI.ExportBucketDNSZoneHandler)))
 
 		// Remote Tier management operations
 		adminRouter.Methods(http.MethodPut).Path(adminVersion + "/tier").HandlerFunc(gz(httpTraceHdrs(adminAPI.AddTierHandler)))
@@ -238,6 +389,12 @@ func registerAdminRouter(router *mux.Router, enableConfigOps bool) {
 		adminRouter.Methods(http.MethodPut).Path(adminVersion + "/site-replication/edit").HandlerFunc(gz(httpTraceHdrs(adminAPI.SiteReplicationEdit)))
 		adminRouter.Methods(http.MethodPut).Path(adminVersion + "/site-replication/peer/edit").HandlerFunc(gz(httpTraceHdrs(adminAPI.SRPeerEdit)))
 		adminRouter.Methods(http.MethodPut).Path(adminVersion + "/site-replication/peer/remove").HandlerFunc(gz(httpTraceHdrs(adminAPI.SRPeerRemove)))
+		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/site-replication/follower").HandlerFunc(gz(httpTraceHdrs(adminAPI.SiteReplicationFollowerStatus)))
+		adminRouter.Methods(http.MethodPut).Path(adminVersion + "/site-replication/follower").HandlerFunc(gz(httpTraceHdrs(adminAPI.SiteReplicationFollower)))
+		adminRouter.Methods(http.MethodPut).Path(adminVersion + "/site-replication/promote").HandlerFunc(gz(httpTraceHdrs(adminAPI.SiteReplicationPromote)))
+		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/site-replication/buckets").HandlerFunc(gz(httpTraceHdrs(adminAPI.SiteReplicationBuckets)))
+		adminRouter.Methods(http.MethodPut).Path(adminVersion + "/site-replication/buckets").HandlerFunc(gz(httpTraceHdrs(adminAPI.SiteReplicationSetBuckets)))
+		adminRouter.Methods(http.MethodPut).Path(adminVersion + "/site-replication/peer/bucket-filter").HandlerFunc(gz(httpTraceHdrs(adminAPI.SRPeerSetBucketFilter)))
 
 		if globalIsDistErasure {
 			// Top locks
@@ -251,6 +408,7 @@ func registerAdminRouter(router *mux.Router, enableConfigOps bool) {
 		adminRouter.Methods(http.MethodPost).Path(adminVersion + "/speedtest/object").HandlerFunc(httpTraceHdrs(adminAPI.ObjectSpeedTestHandler))
 		adminRouter.Methods(http.MethodPost).Path(adminVersion + "/speedtest/drive").HandlerFunc(httpTraceHdrs(adminAPI.DriveSpeedtestHandler))
 		adminRouter.Methods(http.MethodPost).Path(adminVersion + "/speedtest/net").HandlerFunc(httpTraceHdrs(adminAPI.NetperfHandler))
+		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/net-monitor").HandlerFunc(gz(httpTraceAll(adminAPI.NetMonitorHandler)))
 
 		// HTTP Trace
 		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/trace").HandlerFunc(gz(http.HandlerFunc(adminAPI.TraceHandler)))
@@ -263,6 +421,7 @@ func registerAdminRouter(router *mux.Router, enableConfigOps bool) {
 		adminRouter.Methods(http.MethodPost).Path(adminVersion + "/kms/status").HandlerFunc(gz(httpTraceAll(adminAPI.KMSStatusHandler)))
 		adminRouter.Methods(http.MethodPost).Path(adminVersion+"/kms/key/create").HandlerFunc(gz(httpTraceAll(adminAPI.KMSCreateKeyHandler))).Queries("key-id", "{key-id:.*}")
 		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/kms/key/status").HandlerFunc(gz(httpTraceAll(adminAPI.KMSKeyStatusHandler)))
+		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/kms/report").HandlerFunc(gz(httpTraceAll(adminAPI.KMSReportHandler)))
 
 		if !globalIsGateway {
 			// Keep obdinfo for backward compatibility with mc
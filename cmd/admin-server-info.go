@@ -39,6 +39,7 @@ func getLocalServerProperty(endpointServerPools EndpointServerPools, r *http.Req
 		addr = globalLocalNodeName
 	}
 	network := make(map[string]string)
+	members := gossipNetworkStates(UTCNow())
 	for _, ep := range endpointServerPools {
 		for _, endpoint := range ep.Endpoints {
 			nodeName := endpoint.Host
@@ -53,7 +54,9 @@ func getLocalServerProperty(endpointServerPools EndpointServerPools, r *http.Req
 			}
 			_, present := network[nodeName]
 			if !present {
-				if err := isServerResolvable(endpoint, 5*time.Second); err == nil {
+				if state, ok := members[nodeName]; ok {
+					network[nodeName] = state
+				} else if err := isServerResolvable(endpoint, 5*time.Second); err == nil {
 					network[nodeName] = string(madmin.ItemOnline)
 				} else {
 					network[nodeName] = string(madmin.ItemOffline)
@@ -0,0 +1,291 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/qkbyte/minio/internal/logger"
+)
+
+const (
+	// Length of the window over which error rates and
+	// latencies are compared against the baseline.
+	apiAnomalyWindow = time.Minute
+
+	// Weight of the latest window in the trailing baseline.
+	apiAnomalyBaselineWeight = 0.1
+
+	// Number of windows needed before a baseline is trusted.
+	apiAnomalyMinBaselineWindows = 5
+
+	// Windows with fewer requests are not evaluated.
+	apiAnomalyMinRequests = 20
+
+	// A window is anomalous when its 5xx error rate is more than
+	// apiAnomalyErrorFactor times the baseline and above
+	// apiAnomalyMinErrorRate, or its average latency is more than
+	// apiAnomalyLatencyFactor times the baseline and above
+	// apiAnomalyMinLatency.
+	apiAnomalyErrorFactor   = 3
+	apiAnomalyMinErrorRate  = 0.05
+	apiAnomalyLatencyFactor = 3
+	apiAnomalyMinLatency    = 100 * time.Millisecond
+
+	// Number of recent alerts kept per node.
+	apiAnomalyMaxAlerts = 100
+)
+
+// Kinds of API anomalies.
+const (
+	apiAnomalyErrorRate = "error-rate"
+	apiAnomalyLatency   = "latency"
+)
+
+// apiAnomalyAlert describes an API whose error rate or latency deviated
+// sharply from its trailing baseline.
+type apiAnomalyAlert struct {
+	Time     time.Time `json:"time"`
+	Node     string    `json:"node"`
+	API      string    `json:"api"`
+	Kind     string    `json:"kind"`
+	Value    float64   `json:"value"`
+	Baseline float64   `json:"baseline"`
+	Requests uint64    `json:"requests"`
+}
+
+func (a apiAnomalyAlert) String() string {
+	if a.Kind == apiAnomalyLatency {
+		return fmt.Sprintf("API %s on %s: average latency %s over %d requests, baseline is %s",
+			a.API, a.Node, time.Duration(a.Value), a.Requests, time.Duration(a.Baseline))
+	}
+	return fmt.Sprintf("API %s on %s: 5xx error rate %.2f%% over %d requests, baseline is %.2f%%",
+		a.API, a.Node, a.Value*100, a.Requests, a.Baseline*100)
+}
+
+// apiAnomalyStats are the statistics of an API for the last
+// completed window along with its trailing baseline.
+type apiAnomalyStats struct {
+	API               string        `json:"api"`
+	Requests          uint64        `json:"requests"`
+	Errors            uint64        `json:"errors"`
+	ErrorRate         float64       `json:"errorRate"`
+	BaselineErrorRate float64       `json:"baselineErrorRate"`
+	AvgLatency        time.Duration `json:"avgLatency"`
+	BaselineLatency   time.Duration `json:"baselineLatency"`
+}
+
+// apiAnomalyReport is the per node API anomaly report.
+type apiAnomalyReport struct {
+	Node   string            `json:"node"`
+	Window time.Duration     `json:"window"`
+	APIs   []apiAnomalyStats `json:"apis,omitempty"`
+	Alerts []apiAnomalyAlert `json:"alerts,omitempty"`
+	Error  string            `json:"error,omitempty"`
+}
+
+type apiWindowStats struct {
+	requests uint64
+	errors   uint64
+	latency  time.Duration
+}
+
+func (s apiWindowStats) errorRate() float64 {
+	if s.requests == 0 {
+		return 0
+	}
+	return float64(s.errors) / float64(s.requests)
+}
+
+func (s apiWindowStats) avgLatency() time.Duration {
+	if s.requests == 0 {
+		return 0
+	}
+	return s.latency / time.Duration(s.requests)
+}
+
+type apiBaseline struct {
+	errorRate float64
+	latency   float64
+	windows   int
+}
+
+func (b *apiBaseline) update(s apiWindowStats) {
+	if b.windows == 0 {
+		b.errorRate, b.latency = s.errorRate(), float64(s.avgLatency())
+	} else {
+		b.errorRate += apiAnomalyBaselineWeight * (s.errorRate() - b.errorRate)
+		b.latency += apiAnomalyBaselineWeight * (float64(s.avgLatency()) - b.latency)
+	}
+	b.windows++
+}
+
+// apiWindowCounters are the live counters of an API for the current
+// window, updated atomically on every request.
+type apiWindowCounters struct {
+	requests uint64
+	errors   uint64
+	latency  int64
+}
+
+// swap returns the counters accumulated so far and resets them.
+func (c *apiWindowCounters) swap() apiWindowStats {
+	return apiWindowStats{
+		requests: atomic.SwapUint64(&c.requests, 0),
+		errors:   atomic.SwapUint64(&c.errors, 0),
+		latency:  time.Duration(atomic.SwapInt64(&c.latency, 0)),
+	}
+}
+
+// apiAnomalyDetector tracks 5xx error rates and latencies per API of
+// this node and raises alerts when a window deviates sharply from the
+// trailing baseline of the API.
+type apiAnomalyDetector struct {
+	// windowStart is the start of the current window in unix
+	// nanoseconds, current holds the *apiWindowCounters per API.
+	// Both are updated without the lock on every request.
+	windowStart int64
+	current     sync.Map
+
+	// mu protects the state updated once per window.
+	mu       sync.Mutex
+	last     map[string]apiWindowStats
+	baseline map[string]*apiBaseline
+	alerts   []apiAnomalyAlert
+}
+
+func newAPIAnomalyDetector() *apiAnomalyDetector {
+	return &apiAnomalyDetector{
+		windowStart: time.Now().UnixNano(),
+		last:        make(map[string]apiWindowStats),
+		baseline:    make(map[string]*apiBaseline),
+	}
+}
+
+// record accounts a served request of the API.
+func (d *apiAnomalyDetector) record(api string, statusCode int, latency time.Duration, now time.Time) {
+	for _, alert := range d.rotate(now) {
+		logger.LogAlwaysIf(GlobalContext, fmt.Errorf("API anomaly detected: %s", alert))
+	}
+
+	v, ok := d.current.Load(api)
+	if !ok {
+		v, _ = d.current.LoadOrStore(api, &apiWindowCounters{})
+	}
+	c := v.(*apiWindowCounters)
+	atomic.AddUint64(&c.requests, 1)
+	if statusCode >= http.StatusInternalServerError {
+		atomic.AddUint64(&c.errors, 1)
+	}
+	atomic.AddInt64(&c.latency, int64(latency))
+}
+
+// rotate completes the current window if it has elapsed, evaluating it
+// against the baselines. Only the caller that moves the window start
+// forward evaluates the window.
+func (d *apiAnomalyDetector) rotate(now time.Time) (alerts []apiAnomalyAlert) {
+	start := atomic.LoadInt64(&d.windowStart)
+	if now.UnixNano()-start < int64(apiAnomalyWindow) {
+		return nil
+	}
+	if !atomic.CompareAndSwapInt64(&d.windowStart, start, now.UnixNano()) {
+		return nil
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.last = make(map[string]apiWindowStats, len(d.last))
+	d.current.Range(func(k, v interface{}) bool {
+		api := k.(string)
+		s := v.(*apiWindowCounters).swap()
+		if s.requests == 0 {
+			return true
+		}
+		d.last[api] = s
+		b, ok := d.baseline[api]
+		if !ok {
+			b = &apiBaseline{}
+			d.baseline[api] = b
+		}
+		if s.requests < apiAnomalyMinRequests {
+			return true
+		}
+		if b.windows >= apiAnomalyMinBaselineWindows {
+			if rate := s.errorRate(); rate >= apiAnomalyMinErrorRate && rate > b.errorRate*apiAnomalyErrorFactor {
+				alerts = append(alerts, apiAnomalyAlert{
+					Time: now, Node: globalLocalNodeName, API: api, Kind: apiAnomalyErrorRate,
+					Value: rate, Baseline: b.errorRate, Requests: s.requests,
+				})
+			}
+			if avg := s.avgLatency(); avg >= apiAnomalyMinLatency && float64(avg) > b.latency*apiAnomalyLatencyFactor {
+				alerts = append(alerts, apiAnomalyAlert{
+					Time: now, Node: globalLocalNodeName, API: api, Kind: apiAnomalyLatency,
+					Value: float64(avg), Baseline: b.latency, Requests: s.requests,
+				})
+			}
+		}
+		b.update(s)
+		return true
+	})
+
+	d.alerts = append(d.alerts, alerts...)
+	if n := len(d.alerts) - apiAnomalyMaxAlerts; n > 0 {
+		d.alerts = append([]apiAnomalyAlert(nil), d.alerts[n:]...)
+	}
+	return alerts
+}
+
+// report returns the statistics of the last completed window
+// along with the baselines and the recent alerts.
+func (d *apiAnomalyDetector) report(now time.Time) apiAnomalyReport {
+	alerts := d.rotate(now)
+	d.mu.Lock()
+	report := apiAnomalyReport{
+		Node:   globalLocalNodeName,
+		Window: apiAnomalyWindow,
+		Alerts: append([]apiAnomalyAlert(nil), d.alerts...),
+	}
+	for api, b := range d.baseline {
+		s := d.last[api]
+		report.APIs = append(report.APIs, apiAnomalyStats{
+			API:               api,
+			Requests:          s.requests,
+			Errors:            s.errors,
+			ErrorRate:         s.errorRate(),
+			BaselineErrorRate: b.errorRate,
+			AvgLatency:        s.avgLatency(),
+			BaselineLatency:   time.Duration(b.latency),
+		})
+	}
+	d.mu.Unlock()
+
+	for _, alert := range alerts {
+		logger.LogAlwaysIf(GlobalContext, fmt.Errorf("API anomaly detected: %s", alert))
+	}
+
+	sort.Slice(report.APIs, func(i, j int) bool {
+		return report.APIs[i].API < report.APIs[j].API
+	})
+	return report
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestAPIAnomalyDetector(t *testing.T) {
+	d := newAPIAnomalyDetector()
+	now := time.Unix(0, d.windowStart)
+
+	// fill records n requests of the API in the current window, of
+	// which errs fail with a server error, and completes the window.
+	fill := func(api string, n, errs int, latency time.Duration) {
+		for i := 0; i < n; i++ {
+			code := http.StatusOK
+			if i < errs {
+				code = http.StatusInternalServerError
+			}
+			d.record(api, code, latency, now)
+		}
+		now = now.Add(apiAnomalyWindow)
+	}
+
+	// Build a baseline of 1% errors and 10ms latency.
+	for i := 0; i < apiAnomalyMinBaselineWindows; i++ {
+		fill("GetObject", 100, 1, 10*time.Millisecond)
+	}
+	if report := d.report(now); len(report.Alerts) != 0 {
+		t.Fatalf("unexpected alerts for a steady baseline: %v", report.Alerts)
+	}
+
+	// Errors spike to 20%.
+	fill("GetObject", 100, 20, 10*time.Millisecond)
+	report := d.report(now)
+	if len(report.Alerts) != 1 || report.Alerts[0].Kind != apiAnomalyErrorRate {
+		t.Fatalf("expected an error rate alert, got %v", report.Alerts)
+	}
+	if len(report.APIs) != 1 || report.APIs[0].Errors != 20 || report.APIs[0].Requests != 100 {
+		t.Fatalf("unexpected API stats: %v", report.APIs)
+	}
+
+	// Latency spikes to 500ms.
+	fill("GetObject", 100, 1, 500*time.Millisecond)
+	report = d.report(now)
+	if len(report.Alerts) != 2 || report.Alerts[1].Kind != apiAnomalyLatency {
+		t.Fatalf("expected a latency alert, got %v", report.Alerts)
+	}
+
+	// APIs without enough traffic are not evaluated.
+	for i := 0; i < apiAnomalyMinBaselineWindows; i++ {
+		fill("PutObject", 50, 0, 10*time.Millisecond)
+	}
+	fill("PutObject", apiAnomalyMinRequests-1, apiAnomalyMinRequests-1, time.Second)
+	if report = d.report(now); len(report.Alerts) != 2 {
+		t.Fatalf("unexpected alerts for low traffic: %v", report.Alerts)
+	}
+}
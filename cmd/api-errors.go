@@ -33,7 +33,9 @@ import (
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/tags"
 	"github.com/qkbyte/minio/internal/auth"
+	"github.com/qkbyte/minio/internal/bucket/cors"
 	"github.com/qkbyte/minio/internal/bucket/lifecycle"
+	"github.com/qkbyte/minio/internal/bucket/logging"
 	"github.com/qkbyte/minio/internal/bucket/replication"
 	"github.com/qkbyte/minio/internal/config/dns"
 	"github.com/qkbyte/minio/internal/crypto"
@@ -42,6 +44,7 @@ import (
 	"github.com/minio/pkg/bucket/policy"
 	objectlock "github.com/qkbyte/minio/internal/bucket/object/lock"
 	"github.com/qkbyte/minio/internal/bucket/versioning"
+	"github.com/qkbyte/minio/internal/bucket/website"
 	"github.com/qkbyte/minio/internal/event"
 	"github.com/qkbyte/minio/internal/hash"
 )
@@ -112,8 +115,10 @@ const (
 	ErrNoSuchLifecycleConfiguration
 	ErrInvalidLifecycleWithObjectLock
 	ErrNoSuchBucketSSEConfig
+	ErrBucketSSEComplianceViolation
 	ErrNoSuchCORSConfiguration
 	ErrNoSuchWebsiteConfiguration
+	ErrInvalidTargetBucketForLogging
 	ErrReplicationConfigurationNotFoundError
 	ErrRemoteDestinationNotFoundError
 	ErrReplicationDestinationMissingLock
@@ -230,6 +235,8 @@ const (
 	ErrFilterValueInvalid
 	ErrOverlappingConfigs
 	ErrUnsupportedNotification
+	ErrSyncNotificationInvalid
+	ErrSyncNotificationFailed
 
 	// S3 extended errors.
 	ErrContentSHA256Mismatch
@@ -248,6 +255,7 @@ const (
 	ErrInvalidResourceName
 	ErrServerNotInitialized
 	ErrOperationTimedOut
+	ErrRequestDeadlineExceeded
 	ErrClientDisconnected
 	ErrOperationMaxedOut
 	ErrInvalidRequest
@@ -286,10 +294,21 @@ const (
 	ErrSiteReplicationBucketMetaError
 	ErrSiteReplicationIAMError
 	ErrSiteReplicationConfigMissing
+	ErrSiteReplicationReadOnly
+	ErrBucketSuspended
+	ErrTagIndexNotFound
+	ErrIndexerNotConfigured
+	ErrIndexerUnavailable
+	ErrChangesFeedNotEnabled
+	ErrInvalidChangesCursor
+	ErrInvalidRenamePrefix
+	ErrRenamePrefixDestinationNotEmpty
+	ErrRenamePrefixTooManyObjects
 
 	// Bucket Quota error codes
 	ErrAdminBucketQuotaExceeded
 	ErrAdminNoSuchQuotaConfiguration
+	ErrAdminTenantQuotaExceeded
 
 	ErrHealNotImplemented
 	ErrHealNoSuchProcess
@@ -393,6 +412,7 @@ const (
 	ErrAdminAccountNotEligible
 	ErrAccountNotEligible
 	ErrAdminServiceAccountNotFound
+	ErrAdminServiceAccountLimitExceeded
 	ErrPostPolicyConditionInvalidFormat
 
 	ErrInvalidChecksum
@@ -605,6 +625,11 @@ var errorCodes = errorCodeMap{
 		Description:    "The server side encryption configuration was not found",
 		HTTPStatusCode: http.StatusNotFound,
 	},
+	ErrBucketSSEComplianceViolation: {
+		Code:           "AccessDenied",
+		Description:    "The server side encryption headers do not match the bucket encryption configuration",
+		HTTPStatusCode: http.StatusForbidden,
+	},
 	ErrNoSuchKey: {
 		Code:           "NoSuchKey",
 		Description:    "The specified key does not exist.",
@@ -870,6 +895,11 @@ var errorCodes = errorCodeMap{
 		Description:    "The CORS configuration does not exist",
 		HTTPStatusCode: http.StatusNotFound,
 	},
+	ErrInvalidTargetBucketForLogging: {
+		Code:           "InvalidTargetBucketForLogging",
+		Description:    "The target bucket for logging does not exist",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 	ErrNoSuchWebsiteConfiguration: {
 		Code:           "NoSuchWebsiteConfiguration",
 		Description:    "The specified bucket does not have a website configuration",
@@ -1057,6 +1087,16 @@ var errorCodes = errorCodeMap{
 		Description:    "MinIO server does not support Topic or Cloud Function based notifications.",
 		HTTPStatusCode: http.StatusBadRequest,
 	},
+	ErrSyncNotificationInvalid: {
+		Code:           "InvalidArgument",
+		Description:    "The synchronous delivery timeout must be a duration up to 1m and the failure policy either fail or ignore.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrSyncNotificationFailed: {
+		Code:           "XMinioSyncNotificationFailed",
+		Description:    "The operation succeeded and the object was stored, but the event could not be delivered to a synchronous notification target.",
+		HTTPStatusCode: http.StatusServiceUnavailable,
+	},
 	ErrInvalidCopyPartRange: {
 		Code:           "InvalidArgument",
 		Description:    "The x-amz-copy-source-range value must be of the form bytes=first-last where first and last are the zero-based offsets of the first and last bytes to copy",
@@ -1302,6 +1342,11 @@ var errorCodes = errorCodeMap{
 		Description:    "Bucket quota exceeded",
 		HTTPStatusCode: http.StatusBadRequest,
 	},
+	ErrAdminTenantQuotaExceeded: {
+		Code:           "XMinioAdminTenantQuotaExceeded",
+		Description:    "Tenant quota exceeded",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 	ErrAdminNoSuchQuotaConfiguration: {
 		Code:           "XMinioAdminNoSuchQuotaConfiguration",
 		Description:    "The quota configuration does not exist",
@@ -1317,6 +1362,11 @@ var errorCodes = errorCodeMap{
 		Description:    "A timeout occurred while trying to lock a resource, please reduce your request rate",
 		HTTPStatusCode: http.StatusServiceUnavailable,
 	},
+	ErrRequestDeadlineExceeded: {
+		Code:           "RequestTimeout",
+		Description:    "The request did not complete within the deadline configured for this API, please retry",
+		HTTPStatusCode: http.StatusServiceUnavailable,
+	},
 	ErrClientDisconnected: {
 		Code:           "ClientDisconnected",
 		Description:    "Client disconnected before response was ready",
@@ -1378,6 +1428,56 @@ var errorCodes = errorCodeMap{
 		Description:    "Site not found in site replication configuration",
 		HTTPStatusCode: http.StatusBadRequest,
 	},
+	ErrSiteReplicationReadOnly: {
+		Code:           "XMinioSiteReplicationReadOnly",
+		Description:    "This site is a read-only site replication follower, writes must be sent to the primary site",
+		HTTPStatusCode: http.StatusForbidden,
+	},
+	ErrBucketSuspended: {
+		Code:           "XMinioBucketSuspended",
+		Description:    "Access to this bucket is temporarily suspended by the administrator",
+		HTTPStatusCode: http.StatusForbidden,
+	},
+	ErrTagIndexNotFound: {
+		Code:           "XMinioTagIndexNotFound",
+		Description:    "The tag index of this bucket is not available, tag search must be enabled and the bucket scanned first",
+		HTTPStatusCode: http.StatusServiceUnavailable,
+	},
+	ErrIndexerNotConfigured: {
+		Code:           "XMinioIndexerNotConfigured",
+		Description:    "No external metadata indexer is configured for this bucket",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrIndexerUnavailable: {
+		Code:           "XMinioIndexerUnavailable",
+		Description:    "The external metadata indexer of this bucket is unavailable",
+		HTTPStatusCode: http.StatusServiceUnavailable,
+	},
+	ErrChangesFeedNotEnabled: {
+		Code:           "XMinioChangesFeedNotEnabled",
+		Description:    "The changes feed is not enabled for this bucket",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrInvalidChangesCursor: {
+		Code:           "XMinioInvalidChangesCursor",
+		Description:    "The changes feed cursor is invalid",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrInvalidRenamePrefix: {
+		Code:           "XMinioInvalidRenamePrefix",
+		Description:    "Source and destination must be distinct, non-overlapping prefixes ending with '/'",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrRenamePrefixDestinationNotEmpty: {
+		Code:           "XMinioRenamePrefixDestinationNotEmpty",
+		Description:    "The destination prefix of the rename is not empty",
+		HTTPStatusCode: http.StatusConflict,
+	},
+	ErrRenamePrefixTooManyObjects: {
+		Code:           "XMinioRenamePrefixTooManyObjects",
+		Description:    fmt.Sprintf("The source prefix of the rename holds more than %d objects", maxRenamePrefixObjects),
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 	ErrMaximumExpires: {
 		Code:           "AuthorizationQueryParametersError",
 		Description:    "X-Amz-Expires must be less than a week (in seconds); that is, the given X-Amz-Expires must be less than 604800 seconds",
@@ -1883,6 +1983,11 @@ var errorCodes = errorCodeMap{
 		Description:    "The specified service account is not found",
 		HTTPStatusCode: http.StatusNotFound,
 	},
+	ErrAdminServiceAccountLimitExceeded: {
+		Code:           "XMinioAdminServiceAccountLimitExceeded",
+		Description:    "The user has reached the maximum number of service accounts",
+		HTTPStatusCode: http.StatusForbidden,
+	},
 	ErrPostPolicyConditionInvalidFormat: {
 		Code:           "PostPolicyInvalidKeyName",
 		Description:    "Invalid according to Policy: Policy Condition failed",
@@ -1910,11 +2015,21 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		if ctx.Err() == context.Canceled {
 			return ErrClientDisconnected
 		}
+		// Whatever failed, it failed because the API deadline expired.
+		if ctx.Err() == context.DeadlineExceeded {
+			return ErrRequestDeadlineExceeded
+		}
 	}
 
 	switch err {
 	case errInvalidArgument:
 		apiErr = ErrAdminInvalidArgument
+	case errInvalidRenamePrefix:
+		apiErr = ErrInvalidRenamePrefix
+	case errRenamePrefixDestinationNotEmpty:
+		apiErr = ErrRenamePrefixDestinationNotEmpty
+	case errRenamePrefixTooManyObjects:
+		apiErr = ErrRenamePrefixTooManyObjects
 	case errNoSuchUser:
 		apiErr = ErrAdminNoSuchUser
 	case errNoSuchServiceAccount:
@@ -1939,7 +2054,7 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		apiErr = ErrAdminInvalidAccessKey
 	case auth.ErrInvalidSecretKeyLength:
 		apiErr = ErrAdminInvalidSecretKey
-	case errInvalidStorageClass:
+	case errInvalidStorageClass, errTierInternalSameBucket, errTierInternalLoop:
 		apiErr = ErrInvalidStorageClass
 	// SSE errors
 	case errInvalidEncryptionParameters:
@@ -1973,7 +2088,7 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 
 	case context.Canceled, context.DeadlineExceeded:
 		apiErr = ErrOperationTimedOut
-	case errDiskNotFound:
+	case errDiskNotFound, errPoolUnderMaintenance:
 		apiErr = ErrSlowDown
 	case objectlock.ErrInvalidRetentionDate:
 		apiErr = ErrInvalidRetentionDate
@@ -2085,6 +2200,10 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		apiErr = ErrNoSuchBucketSSEConfig
 	case BucketTaggingNotFound:
 		apiErr = ErrBucketTaggingNotFound
+	case BucketCorsNotFound:
+		apiErr = ErrNoSuchCORSConfiguration
+	case BucketWebsiteNotFound:
+		apiErr = ErrNoSuchWebsiteConfiguration
 	case BucketObjectLockConfigNotFound:
 		apiErr = ErrObjectLockConfigurationNotFound
 	case BucketQuotaConfigNotFound:
@@ -2118,6 +2237,8 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 
 	case BucketQuotaExceeded:
 		apiErr = ErrAdminBucketQuotaExceeded
+	case TenantQuotaExceeded:
+		apiErr = ErrAdminTenantQuotaExceeded
 	case *event.ErrInvalidEventName:
 		apiErr = ErrEventNotification
 	case *event.ErrInvalidARN:
@@ -2140,6 +2261,8 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		apiErr = ErrOverlappingFilterNotification
 	case *event.ErrUnsupportedConfiguration:
 		apiErr = ErrUnsupportedNotification
+	case *event.ErrInvalidSynchronous:
+		apiErr = ErrSyncNotificationInvalid
 	case OperationTimedOut:
 		apiErr = ErrOperationTimedOut
 	case BackendDown:
@@ -2209,6 +2332,11 @@ func toAPIError(ctx context.Context, err error) APIError {
 		}
 	}
 
+	if e, ok := err.(StorageFull); ok && e.Reason != "" {
+		apiErr.Description = fmt.Sprintf("%s (%s)", apiErr.Description, e.Reason)
+		return apiErr
+	}
+
 	if apiErr.Code == "XMinioBackendDown" {
 		apiErr.Description = fmt.Sprintf("%s (%v)", apiErr.Description, err)
 		return apiErr
@@ -2246,6 +2374,24 @@ func toAPIError(ctx context.Context, err error) APIError {
 				Description:    fmt.Sprintf("Versioning configuration specified in the request is invalid. (%s)", e.Error()),
 				HTTPStatusCode: http.StatusBadRequest,
 			}
+		case cors.Error:
+			apiErr = APIError{
+				Code:           "MalformedXML",
+				Description:    e.Error(),
+				HTTPStatusCode: http.StatusBadRequest,
+			}
+		case website.Error:
+			apiErr = APIError{
+				Code:           "MalformedXML",
+				Description:    e.Error(),
+				HTTPStatusCode: http.StatusBadRequest,
+			}
+		case logging.Error:
+			apiErr = APIError{
+				Code:           "MalformedXML",
+				Description:    e.Error(),
+				HTTPStatusCode: http.StatusBadRequest,
+			}
 		case lifecycle.Error:
 			apiErr = APIError{
 				Code:           "InvalidRequest",
@@ -23,6 +23,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/qkbyte/minio/internal/crypto"
 	"github.com/qkbyte/minio/internal/hash"
@@ -80,3 +81,14 @@ func TestAPIErrCode(t *testing.T) {
 		}
 	}
 }
+
+func TestAPIErrCodeDeadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	<-ctx.Done()
+
+	// Quorum errors caused by an expired API deadline are reported as such.
+	if errCode := toAPIErrorCode(ctx, InsufficientReadQuorum{}); errCode != ErrRequestDeadlineExceeded {
+		t.Errorf("Expected error code %d, got %d", ErrRequestDeadlineExceeded, errCode)
+	}
+}
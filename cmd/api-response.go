@@ -268,6 +268,9 @@ type DeleteMarkerVersion struct {
 
 	IsLatest  bool
 	VersionID string `xml:"VersionId"`
+
+	// MinIO extension, only set when listing with status=true.
+	ReplicationStatus string `xml:"ReplicationStatus,omitempty"`
 }
 
 // Metadata metadata items implemented to ensure XML marshaling works.
@@ -347,6 +350,19 @@ type Object struct {
 
 	// UserMetadata user-defined metadata
 	UserMetadata *Metadata `xml:"UserMetadata,omitempty"`
+
+	// MinIO extensions, only set when listing with status=true.
+	ReplicationStatus string `xml:"ReplicationStatus,omitempty"`
+	TransitionStatus  string `xml:"TransitionStatus,omitempty"`
+	TransitionTier    string `xml:"TransitionTier,omitempty"`
+}
+
+// setStatus marks the entry with the replication and tiering
+// status recorded in the object metadata.
+func (o *Object) setStatus(object ObjectInfo) {
+	o.ReplicationStatus = string(object.ReplicationStatus)
+	o.TransitionStatus = object.TransitionedObject.Status
+	o.TransitionTier = object.TransitionedObject.Tier
 }
 
 // CopyObjectResponse container returns ETag and LastModified of the successfully copied object
@@ -447,6 +463,10 @@ func getObjectLocation(r *http.Request, domains []string, bucket, object string)
 		Scheme: proto,
 	}
 	// If domain is set then we need to use bucket DNS style.
+	if b, ok := vhostBucket(r.Host); ok && b == bucket {
+		u.Path = path.Join(SlashSeparator, object)
+		return u.String()
+	}
 	for _, domain := range domains {
 		if strings.HasPrefix(r.Host, bucket+"."+domain) {
 			u.Path = path.Join(SlashSeparator, object)
@@ -480,7 +500,7 @@ func generateListBucketsResponse(buckets []BucketInfo) ListBucketsResponse {
 }
 
 // generates an ListBucketVersions response for the said bucket with other enumerated options.
-func generateListVersionsResponse(bucket, prefix, marker, versionIDMarker, delimiter, encodingType string, maxKeys int, resp ListObjectVersionsInfo) ListVersionsResponse {
+func generateListVersionsResponse(bucket, prefix, marker, versionIDMarker, delimiter, encodingType string, maxKeys int, resp ListObjectVersionsInfo, status bool) ListVersionsResponse {
 	versions := make([]ObjectVersion, 0, len(resp.Objects))
 	deleteMarkers := make([]DeleteMarkerVersion, 0, len(resp.Objects))
 
@@ -506,6 +526,9 @@ func generateListVersionsResponse(bucket, prefix, marker, versionIDMarker, delim
 				deleteMarker.VersionID = nullVersionID
 			}
 			deleteMarker.IsLatest = object.IsLatest
+			if status {
+				deleteMarker.ReplicationStatus = string(object.ReplicationStatus)
+			}
 			deleteMarkers = append(deleteMarkers, deleteMarker)
 			continue
 		}
@@ -527,6 +550,9 @@ func generateListVersionsResponse(bucket, prefix, marker, versionIDMarker, delim
 			content.VersionID = nullVersionID
 		}
 		content.IsLatest = object.IsLatest
+		if status {
+			content.setStatus(object)
+		}
 		versions = append(versions, content)
 	}
 
@@ -604,7 +630,7 @@ func generateListObjectsV1Response(bucket, prefix, marker, delimiter, encodingTy
 }
 
 // generates an ListObjectsV2 response for the said bucket with other enumerated options.
-func generateListObjectsV2Response(bucket, prefix, token, nextToken, startAfter, delimiter, encodingType string, fetchOwner, isTruncated bool, maxKeys int, objects []ObjectInfo, prefixes []string, metadata bool) ListObjectsV2Response {
+func generateListObjectsV2Response(bucket, prefix, token, nextToken, startAfter, delimiter, encodingType string, fetchOwner, isTruncated bool, maxKeys int, objects []ObjectInfo, prefixes []string, metadata, status bool) ListObjectsV2Response {
 	contents := make([]Object, 0, len(objects))
 	owner := Owner{
 		ID:          globalMinioDefaultOwnerID,
@@ -652,6 +678,9 @@ func generateListObjectsV2Response(bucket, prefix, token, nextToken, startAfter,
 				content.UserMetadata.Set(k, v)
 			}
 		}
+		if status {
+			content.setStatus(object)
+		}
 		contents = append(contents, content)
 	}
 	data.Name = bucket
@@ -829,6 +858,8 @@ const (
 	mimeJSON mimeType = "application/json"
 	// Means response type is XML.
 	mimeXML mimeType = "application/xml"
+	// Means response type is a zip archive.
+	mimeZip mimeType = "application/zip"
 )
 
 // writeSuccessResponseJSON writes success headers and response if any,
@@ -862,9 +893,12 @@ func writeSuccessResponseHeadersOnly(w http.ResponseWriter) {
 func writeErrorResponse(ctx context.Context, w http.ResponseWriter, err APIError, reqURL *url.URL) {
 	switch err.Code {
 	case "SlowDown", "XMinioServerNotInitialized", "XMinioReadQuorum", "XMinioWriteQuorum":
-		// Set retry-after header to indicate user-agents to retry request after 120secs.
+		// Set retry-after header to indicate user-agents to retry request after 120secs,
+		// unless the throttling handler already computed a hint from the queue depth.
 		// https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Retry-After
-		w.Header().Set(xhttp.RetryAfter, "120")
+		if w.Header().Get(xhttp.RetryAfter) == "" {
+			w.Header().Set(xhttp.RetryAfter, "120")
+		}
 	case "InvalidRegion":
 		err.Description = fmt.Sprintf("Region does not match; expecting '%s'.", globalSite.Region)
 	case "AuthorizationHeaderMalformed":
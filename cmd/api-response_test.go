@@ -20,6 +20,9 @@ package cmd
 import (
 	"net/http"
 	"testing"
+
+	"github.com/qkbyte/minio/internal/bucket/lifecycle"
+	"github.com/qkbyte/minio/internal/bucket/replication"
 )
 
 // Tests object location.
@@ -123,3 +126,40 @@ func TestGetURLScheme(t *testing.T) {
 		t.Errorf("Expected %s, got %s", httpsScheme, gotScheme)
 	}
 }
+
+// Tests that listings only mark entries with their replication and
+// tiering status when requested.
+func TestGenerateListStatus(t *testing.T) {
+	objects := []ObjectInfo{
+		{
+			Name:               "pending",
+			VersionID:          "v1",
+			ReplicationStatus:  replication.Pending,
+			TransitionedObject: TransitionedObject{Status: lifecycle.TransitionComplete, Tier: "WARM"},
+		},
+		{
+			Name:              "deleted",
+			VersionID:         "v2",
+			DeleteMarker:      true,
+			ReplicationStatus: replication.Failed,
+		},
+	}
+
+	resp := generateListObjectsV2Response("bucket", "", "", "", "", "", "", false, false, 1000, objects[:1], nil, false, false)
+	if c := resp.Contents[0]; c.ReplicationStatus != "" || c.TransitionStatus != "" || c.TransitionTier != "" {
+		t.Fatalf("expected no status without status=true, got %+v", c)
+	}
+
+	resp = generateListObjectsV2Response("bucket", "", "", "", "", "", "", false, false, 1000, objects[:1], nil, false, true)
+	if c := resp.Contents[0]; c.ReplicationStatus != "PENDING" || c.TransitionStatus != lifecycle.TransitionComplete || c.TransitionTier != "WARM" {
+		t.Fatalf("unexpected status %+v", c)
+	}
+
+	versions := generateListVersionsResponse("bucket", "", "", "", "", "", 1000, ListObjectVersionsInfo{Objects: objects}, true)
+	if len(versions.Versions) != 1 || versions.Versions[0].ReplicationStatus != "PENDING" {
+		t.Fatalf("unexpected versions %+v", versions.Versions)
+	}
+	if len(versions.DeleteMarkers) != 1 || versions.DeleteMarkers[0].ReplicationStatus != "FAILED" {
+		t.Fatalf("unexpected delete markers %+v", versions.DeleteMarkers)
+	}
+}
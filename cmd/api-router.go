@@ -28,6 +28,7 @@ import (
 	"github.com/minio/pkg/wildcard"
 	xhttp "github.com/qkbyte/minio/internal/http"
 	"github.com/qkbyte/minio/internal/logger"
+	"github.com/quic-go/quic-go/http3"
 	"github.com/rs/cors"
 )
 
@@ -43,6 +44,30 @@ func setHTTPServer(h *xhttp.Server) {
 	globalObjLayerMutex.Unlock()
 }
 
+func newInternodeHTTPServerFn() *xhttp.Server {
+	globalObjLayerMutex.RLock()
+	defer globalObjLayerMutex.RUnlock()
+	return globalInternodeHTTPServer
+}
+
+func setInternodeHTTPServer(h *xhttp.Server) {
+	globalObjLayerMutex.Lock()
+	globalInternodeHTTPServer = h
+	globalObjLayerMutex.Unlock()
+}
+
+func newHTTP3ServerFn() *http3.Server {
+	globalObjLayerMutex.RLock()
+	defer globalObjLayerMutex.RUnlock()
+	return globalHTTP3Server
+}
+
+func setHTTP3Server(srv *http3.Server) {
+	globalObjLayerMutex.Lock()
+	globalHTTP3Server = srv
+	globalObjLayerMutex.Unlock()
+}
+
 func newConsoleServerFn() *restapi.Server {
 	globalObjLayerMutex.RLock()
 	defer globalObjLayerMutex.RUnlock()
@@ -127,24 +152,14 @@ var rejectedBucketAPIs = []rejectedAPI{
 		methods: []string{http.MethodGet, http.MethodPut, http.MethodDelete},
 		queries: []string{"inventory", ""},
 	},
-	{
-		api:     "cors",
-		methods: []string{http.MethodPut, http.MethodDelete},
-		queries: []string{"cors", ""},
-	},
 	{
 		api:     "metrics",
 		methods: []string{http.MethodGet, http.MethodPut, http.MethodDelete},
 		queries: []string{"metrics", ""},
 	},
-	{
-		api:     "website",
-		methods: []string{http.MethodPut},
-		queries: []string{"website", ""},
-	},
 	{
 		api:     "logging",
-		methods: []string{http.MethodPut, http.MethodDelete},
+		methods: []string{http.MethodDelete},
 		queries: []string{"logging", ""},
 	},
 	{
@@ -154,7 +169,7 @@ var rejectedBucketAPIs = []rejectedAPI{
 	},
 	{
 		api:     "requestPayment",
-		methods: []string{http.MethodPut, http.MethodDelete},
+		methods: []string{http.MethodDelete},
 		queries: []string{"requestPayment", ""},
 	},
 	{
@@ -195,7 +210,9 @@ func registerAPIRouter(router *mux.Router) {
 	// API Router
 	apiRouter := router.PathPrefix(SlashSeparator).Subrouter()
 
-	var routers []*mux.Router
+	// Domains configured at runtime go first, they only take the
+	// requests they match more specifically than MINIO_DOMAIN.
+	routers := []*mux.Router{apiRouter.MatcherFunc(vhostMatcher).Subrouter()}
 	for _, domainName := range globalDomainNames {
 		if IsKubernetes() {
 			routers = append(routers, apiRouter.MatcherFunc(func(r *http.Request, match *mux.RouteMatch) bool {
@@ -354,21 +371,36 @@ func registerAPIRouter(router *mux.Router) {
 		// PutBucketACL -- this is a dummy call.
 		router.Methods(http.MethodPut).HandlerFunc(
 			collectAPIStats("putbucketacl", maxClients(gz(httpTraceAll(api.PutBucketACLHandler))))).Queries("acl", "")
-		// GetBucketCors - this is a dummy call.
+		// GetBucketCors
 		router.Methods(http.MethodGet).HandlerFunc(
 			collectAPIStats("getbucketcors", maxClients(gz(httpTraceAll(api.GetBucketCorsHandler))))).Queries("cors", "")
-		// GetBucketWebsiteHandler - this is a dummy call.
+		// PutBucketCors
+		router.Methods(http.MethodPut).HandlerFunc(
+			collectAPIStats("putbucketcors", maxClients(gz(httpTraceAll(api.PutBucketCorsHandler))))).Queries("cors", "")
+		// DeleteBucketCors
+		router.Methods(http.MethodDelete).HandlerFunc(
+			collectAPIStats("deletebucketcors", maxClients(gz(httpTraceAll(api.DeleteBucketCorsHandler))))).Queries("cors", "")
+		// GetBucketWebsite
 		router.Methods(http.MethodGet).HandlerFunc(
 			collectAPIStats("getbucketwebsite", maxClients(gz(httpTraceAll(api.GetBucketWebsiteHandler))))).Queries("website", "")
+		// PutBucketWebsite
+		router.Methods(http.MethodPut).HandlerFunc(
+			collectAPIStats("putbucketwebsite", maxClients(gz(httpTraceAll(api.PutBucketWebsiteHandler))))).Queries("website", "")
 		// GetBucketAccelerateHandler - this is a dummy call.
 		router.Methods(http.MethodGet).HandlerFunc(
 			collectAPIStats("getbucketaccelerate", maxClients(gz(httpTraceAll(api.GetBucketAccelerateHandler))))).Queries("accelerate", "")
-		// GetBucketRequestPaymentHandler - this is a dummy call.
+		// GetBucketRequestPayment
 		router.Methods(http.MethodGet).HandlerFunc(
 			collectAPIStats("getbucketrequestpayment", maxClients(gz(httpTraceAll(api.GetBucketRequestPaymentHandler))))).Queries("requestPayment", "")
-		// GetBucketLoggingHandler - this is a dummy call.
+		// PutBucketRequestPayment
+		router.Methods(http.MethodPut).HandlerFunc(
+			collectAPIStats("putbucketrequestpayment", maxClients(gz(httpTraceAll(api.PutBucketRequestPaymentHandler))))).Queries("requestPayment", "")
+		// GetBucketLoggingHandler
 		router.Methods(http.MethodGet).HandlerFunc(
 			collectAPIStats("getbucketlogging", maxClients(gz(httpTraceAll(api.GetBucketLoggingHandler))))).Queries("logging", "")
+		// PutBucketLoggingHandler
+		router.Methods(http.MethodPut).HandlerFunc(
+			collectAPIStats("putbucketlogging", maxClients(gz(httpTraceAll(api.PutBucketLoggingHandler))))).Queries("logging", "")
 		// GetBucketTaggingHandler
 		router.Methods(http.MethodGet).HandlerFunc(
 			collectAPIStats("getbuckettagging", maxClients(gz(httpTraceAll(api.GetBucketTaggingHandler))))).Queries("tagging", "")
@@ -382,6 +414,15 @@ func registerAPIRouter(router *mux.Router) {
 		// ListMultipartUploads
 		router.Methods(http.MethodGet).HandlerFunc(
 			collectAPIStats("listmultipartuploads", maxClients(gz(httpTraceAll(api.ListMultipartUploadsHandler))))).Queries("uploads", "")
+		// ListObjectsByTags - MinIO extension API
+		router.Methods(http.MethodGet).HandlerFunc(
+			collectAPIStats("listobjectsbytags", maxClients(gz(httpTraceAll(api.ListObjectsByTagsHandler))))).Queries("tag-search", "")
+		// MetadataSearch - MinIO extension API
+		router.Methods(http.MethodGet).HandlerFunc(
+			collectAPIStats("metadatasearch", maxClients(gz(httpTraceAll(api.MetadataSearchHandler))))).Queries("metadata-search", "")
+		// ListBucketChanges - MinIO extension API
+		router.Methods(http.MethodGet).HandlerFunc(
+			collectAPIStats("listbucketchanges", maxClients(gz(httpTraceAll(api.ListBucketChangesHandler))))).Queries("changes", "")
 		// ListObjectsV2M
 		router.Methods(http.MethodGet).HandlerFunc(
 			collectAPIStats("listobjectsv2M", maxClients(gz(httpTraceAll(api.ListObjectsV2MHandler))))).Queries("list-type", "2", "metadata", "true")
@@ -436,6 +477,12 @@ func registerAPIRouter(router *mux.Router) {
 		// DeleteMultipleObjects
 		router.Methods(http.MethodPost).HandlerFunc(
 			collectAPIStats("deletemultipleobjects", maxClients(gz(httpTraceAll(api.DeleteMultipleObjectsHandler))))).Queries("delete", "")
+		// CopyObjects - MinIO extension API
+		router.Methods(http.MethodPost).HandlerFunc(
+			collectAPIStats("copyobjects", maxClients(gz(httpTraceAll(api.CopyObjectsHandler))))).Queries("copy-objects", "")
+		// RenamePrefix - MinIO extension API
+		router.Methods(http.MethodPost).HandlerFunc(
+			collectAPIStats("renameprefix", maxClients(gz(httpTraceAll(api.RenamePrefixHandler))))).Queries("rename-prefix", "")
 		// DeleteBucketPolicy
 		router.Methods(http.MethodDelete).HandlerFunc(
 			collectAPIStats("deletebucketpolicy", maxClients(gz(httpTraceAll(api.DeleteBucketPolicyHandler))))).Queries("policy", "")
@@ -490,7 +537,8 @@ func registerAPIRouter(router *mux.Router) {
 	apiRouter.MethodNotAllowedHandler = collectAPIStats("methodnotallowed", httpTraceAll(methodNotAllowedHandler("S3")))
 }
 
-// corsHandler handler for CORS (Cross Origin Resource Sharing)
+// corsHandler handler for CORS (Cross Origin Resource Sharing), the
+// global CORS settings apply to buckets without a CORS configuration.
 func corsHandler(handler http.Handler) http.Handler {
 	commonS3Headers := []string{
 		xhttp.Date,
@@ -514,7 +562,7 @@ func corsHandler(handler http.Handler) http.Handler {
 		"*",
 	}
 
-	return cors.New(cors.Options{
+	globalCors := cors.New(cors.Options{
 		AllowOriginFunc: func(origin string) bool {
 			for _, allowedOrigin := range globalAPIConfig.getCorsAllowOrigins() {
 				if wildcard.MatchSimple(allowedOrigin, origin) {
@@ -536,4 +584,13 @@ func corsHandler(handler http.Handler) http.Handler {
 		ExposedHeaders:   commonS3Headers,
 		AllowCredentials: true,
 	}).Handler(handler)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Buckets with a CORS configuration are not subject
+		// to the global CORS settings.
+		if serveBucketCors(w, r, handler) {
+			return
+		}
+		globalCors.ServeHTTP(w, r)
+	})
 }
@@ -47,267 +47,284 @@ func _() {
 	_ = x[ErrNoSuchLifecycleConfiguration-36]
 	_ = x[ErrInvalidLifecycleWithObjectLock-37]
 	_ = x[ErrNoSuchBucketSSEConfig-38]
-	_ = x[ErrNoSuchCORSConfiguration-39]
-	_ = x[ErrNoSuchWebsiteConfiguration-40]
-	_ = x[ErrReplicationConfigurationNotFoundError-41]
-	_ = x[ErrRemoteDestinationNotFoundError-42]
-	_ = x[ErrReplicationDestinationMissingLock-43]
-	_ = x[ErrRemoteTargetNotFoundError-44]
-	_ = x[ErrReplicationRemoteConnectionError-45]
-	_ = x[ErrReplicationBandwidthLimitError-46]
-	_ = x[ErrBucketRemoteIdenticalToSource-47]
-	_ = x[ErrBucketRemoteAlreadyExists-48]
-	_ = x[ErrBucketRemoteLabelInUse-49]
-	_ = x[ErrBucketRemoteArnTypeInvalid-50]
-	_ = x[ErrBucketRemoteArnInvalid-51]
-	_ = x[ErrBucketRemoteRemoveDisallowed-52]
-	_ = x[ErrRemoteTargetNotVersionedError-53]
-	_ = x[ErrReplicationSourceNotVersionedError-54]
-	_ = x[ErrReplicationNeedsVersioningError-55]
-	_ = x[ErrReplicationBucketNeedsVersioningError-56]
-	_ = x[ErrReplicationDenyEditError-57]
-	_ = x[ErrReplicationNoExistingObjects-58]
-	_ = x[ErrObjectRestoreAlreadyInProgress-59]
-	_ = x[ErrNoSuchKey-60]
-	_ = x[ErrNoSuchUpload-61]
-	_ = x[ErrInvalidVersionID-62]
-	_ = x[ErrNoSuchVersion-63]
-	_ = x[ErrNotImplemented-64]
-	_ = x[ErrPreconditionFailed-65]
-	_ = x[ErrRequestTimeTooSkewed-66]
-	_ = x[ErrSignatureDoesNotMatch-67]
-	_ = x[ErrMethodNotAllowed-68]
-	_ = x[ErrInvalidPart-69]
-	_ = x[ErrInvalidPartOrder-70]
-	_ = x[ErrAuthorizationHeaderMalformed-71]
-	_ = x[ErrMalformedPOSTRequest-72]
-	_ = x[ErrPOSTFileRequired-73]
-	_ = x[ErrSignatureVersionNotSupported-74]
-	_ = x[ErrBucketNotEmpty-75]
-	_ = x[ErrAllAccessDisabled-76]
-	_ = x[ErrMalformedPolicy-77]
-	_ = x[ErrMissingFields-78]
-	_ = x[ErrMissingCredTag-79]
-	_ = x[ErrCredMalformed-80]
-	_ = x[ErrInvalidRegion-81]
-	_ = x[ErrInvalidServiceS3-82]
-	_ = x[ErrInvalidServiceSTS-83]
-	_ = x[ErrInvalidRequestVersion-84]
-	_ = x[ErrMissingSignTag-85]
-	_ = x[ErrMissingSignHeadersTag-86]
-	_ = x[ErrMalformedDate-87]
-	_ = x[ErrMalformedPresignedDate-88]
-	_ = x[ErrMalformedCredentialDate-89]
-	_ = x[ErrMalformedCredentialRegion-90]
-	_ = x[ErrMalformedExpires-91]
-	_ = x[ErrNegativeExpires-92]
-	_ = x[ErrAuthHeaderEmpty-93]
-	_ = x[ErrExpiredPresignRequest-94]
-	_ = x[ErrRequestNotReadyYet-95]
-	_ = x[ErrUnsignedHeaders-96]
-	_ = x[ErrMissingDateHeader-97]
-	_ = x[ErrInvalidQuerySignatureAlgo-98]
-	_ = x[ErrInvalidQueryParams-99]
-	_ = x[ErrBucketAlreadyOwnedByYou-100]
-	_ = x[ErrInvalidDuration-101]
-	_ = x[ErrBucketAlreadyExists-102]
-	_ = x[ErrTooManyBuckets-103]
-	_ = x[ErrMetadataTooLarge-104]
-	_ = x[ErrUnsupportedMetadata-105]
-	_ = x[ErrMaximumExpires-106]
-	_ = x[ErrSlowDown-107]
-	_ = x[ErrInvalidPrefixMarker-108]
-	_ = x[ErrBadRequest-109]
-	_ = x[ErrKeyTooLongError-110]
-	_ = x[ErrInvalidBucketObjectLockConfiguration-111]
-	_ = x[ErrObjectLockConfigurationNotFound-112]
-	_ = x[ErrObjectLockConfigurationNotAllowed-113]
-	_ = x[ErrNoSuchObjectLockConfiguration-114]
-	_ = x[ErrObjectLocked-115]
-	_ = x[ErrInvalidRetentionDate-116]
-	_ = x[ErrPastObjectLockRetainDate-117]
-	_ = x[ErrUnknownWORMModeDirective-118]
-	_ = x[ErrBucketTaggingNotFound-119]
-	_ = x[ErrObjectLockInvalidHeaders-120]
-	_ = x[ErrInvalidTagDirective-121]
-	_ = x[ErrInvalidEncryptionMethod-122]
-	_ = x[ErrInvalidEncryptionKeyID-123]
-	_ = x[ErrInsecureSSECustomerRequest-124]
-	_ = x[ErrSSEMultipartEncrypted-125]
-	_ = x[ErrSSEEncryptedObject-126]
-	_ = x[ErrInvalidEncryptionParameters-127]
-	_ = x[ErrInvalidSSECustomerAlgorithm-128]
-	_ = x[ErrInvalidSSECustomerKey-129]
-	_ = x[ErrMissingSSECustomerKey-130]
-	_ = x[ErrMissingSSECustomerKeyMD5-131]
-	_ = x[ErrSSECustomerKeyMD5Mismatch-132]
-	_ = x[ErrInvalidSSECustomerParameters-133]
-	_ = x[ErrIncompatibleEncryptionMethod-134]
-	_ = x[ErrKMSNotConfigured-135]
-	_ = x[ErrKMSKeyNotFoundException-136]
-	_ = x[ErrNoAccessKey-137]
-	_ = x[ErrInvalidToken-138]
-	_ = x[ErrEventNotification-139]
-	_ = x[ErrARNNotification-140]
-	_ = x[ErrRegionNotification-141]
-	_ = x[ErrOverlappingFilterNotification-142]
-	_ = x[ErrFilterNameInvalid-143]
-	_ = x[ErrFilterNamePrefix-144]
-	_ = x[ErrFilterNameSuffix-145]
-	_ = x[ErrFilterValueInvalid-146]
-	_ = x[ErrOverlappingConfigs-147]
-	_ = x[ErrUnsupportedNotification-148]
-	_ = x[ErrContentSHA256Mismatch-149]
-	_ = x[ErrContentChecksumMismatch-150]
-	_ = x[ErrReadQuorum-151]
-	_ = x[ErrWriteQuorum-152]
-	_ = x[ErrStorageFull-153]
-	_ = x[ErrRequestBodyParse-154]
-	_ = x[ErrObjectExistsAsDirectory-155]
-	_ = x[ErrInvalidObjectName-156]
-	_ = x[ErrInvalidObjectNamePrefixSlash-157]
-	_ = x[ErrInvalidResourceName-158]
-	_ = x[ErrServerNotInitialized-159]
-	_ = x[ErrOperationTimedOut-160]
-	_ = x[ErrClientDisconnected-161]
-	_ = x[ErrOperationMaxedOut-162]
-	_ = x[ErrInvalidRequest-163]
-	_ = x[ErrTransitionStorageClassNotFoundError-164]
-	_ = x[ErrInvalidStorageClass-165]
-	_ = x[ErrBackendDown-166]
-	_ = x[ErrMalformedJSON-167]
-	_ = x[ErrAdminNoSuchUser-168]
-	_ = x[ErrAdminNoSuchGroup-169]
-	_ = x[ErrAdminGroupNotEmpty-170]
-	_ = x[ErrAdminNoSuchPolicy-171]
-	_ = x[ErrAdminInvalidArgument-172]
-	_ = x[ErrAdminInvalidAccessKey-173]
-	_ = x[ErrAdminInvalidSecretKey-174]
-	_ = x[ErrAdminConfigNoQuorum-175]
-	_ = x[ErrAdminConfigTooLarge-176]
-	_ = x[ErrAdminConfigBadJSON-177]
-	_ = x[ErrAdminNoSuchConfigTarget-178]
-	_ = x[ErrAdminConfigEnvOverridden-179]
-	_ = x[ErrAdminConfigDuplicateKeys-180]
-	_ = x[ErrAdminCredentialsMismatch-181]
-	_ = x[ErrInsecureClientRequest-182]
-	_ = x[ErrObjectTampered-183]
-	_ = x[ErrSiteReplicationInvalidRequest-184]
-	_ = x[ErrSiteReplicationPeerResp-185]
-	_ = x[ErrSiteReplicationBackendIssue-186]
-	_ = x[ErrSiteReplicationServiceAccountError-187]
-	_ = x[ErrSiteReplicationBucketConfigError-188]
-	_ = x[ErrSiteReplicationBucketMetaError-189]
-	_ = x[ErrSiteReplicationIAMError-190]
-	_ = x[ErrSiteReplicationConfigMissing-191]
-	_ = x[ErrAdminBucketQuotaExceeded-192]
-	_ = x[ErrAdminNoSuchQuotaConfiguration-193]
-	_ = x[ErrHealNotImplemented-194]
-	_ = x[ErrHealNoSuchProcess-195]
-	_ = x[ErrHealInvalidClientToken-196]
-	_ = x[ErrHealMissingBucket-197]
-	_ = x[ErrHealAlreadyRunning-198]
-	_ = x[ErrHealOverlappingPaths-199]
-	_ = x[ErrIncorrectContinuationToken-200]
-	_ = x[ErrEmptyRequestBody-201]
-	_ = x[ErrUnsupportedFunction-202]
-	_ = x[ErrInvalidExpressionType-203]
-	_ = x[ErrBusy-204]
-	_ = x[ErrUnauthorizedAccess-205]
-	_ = x[ErrExpressionTooLong-206]
-	_ = x[ErrIllegalSQLFunctionArgument-207]
-	_ = x[ErrInvalidKeyPath-208]
-	_ = x[ErrInvalidCompressionFormat-209]
-	_ = x[ErrInvalidFileHeaderInfo-210]
-	_ = x[ErrInvalidJSONType-211]
-	_ = x[ErrInvalidQuoteFields-212]
-	_ = x[ErrInvalidRequestParameter-213]
-	_ = x[ErrInvalidDataType-214]
-	_ = x[ErrInvalidTextEncoding-215]
-	_ = x[ErrInvalidDataSource-216]
-	_ = x[ErrInvalidTableAlias-217]
-	_ = x[ErrMissingRequiredParameter-218]
-	_ = x[ErrObjectSerializationConflict-219]
-	_ = x[ErrUnsupportedSQLOperation-220]
-	_ = x[ErrUnsupportedSQLStructure-221]
-	_ = x[ErrUnsupportedSyntax-222]
-	_ = x[ErrUnsupportedRangeHeader-223]
-	_ = x[ErrLexerInvalidChar-224]
-	_ = x[ErrLexerInvalidOperator-225]
-	_ = x[ErrLexerInvalidLiteral-226]
-	_ = x[ErrLexerInvalidIONLiteral-227]
-	_ = x[ErrParseExpectedDatePart-228]
-	_ = x[ErrParseExpectedKeyword-229]
-	_ = x[ErrParseExpectedTokenType-230]
-	_ = x[ErrParseExpected2TokenTypes-231]
-	_ = x[ErrParseExpectedNumber-232]
-	_ = x[ErrParseExpectedRightParenBuiltinFunctionCall-233]
-	_ = x[ErrParseExpectedTypeName-234]
-	_ = x[ErrParseExpectedWhenClause-235]
-	_ = x[ErrParseUnsupportedToken-236]
-	_ = x[ErrParseUnsupportedLiteralsGroupBy-237]
-	_ = x[ErrParseExpectedMember-238]
-	_ = x[ErrParseUnsupportedSelect-239]
-	_ = x[ErrParseUnsupportedCase-240]
-	_ = x[ErrParseUnsupportedCaseClause-241]
-	_ = x[ErrParseUnsupportedAlias-242]
-	_ = x[ErrParseUnsupportedSyntax-243]
-	_ = x[ErrParseUnknownOperator-244]
-	_ = x[ErrParseMissingIdentAfterAt-245]
-	_ = x[ErrParseUnexpectedOperator-246]
-	_ = x[ErrParseUnexpectedTerm-247]
-	_ = x[ErrParseUnexpectedToken-248]
-	_ = x[ErrParseUnexpectedKeyword-249]
-	_ = x[ErrParseExpectedExpression-250]
-	_ = x[ErrParseExpectedLeftParenAfterCast-251]
-	_ = x[ErrParseExpectedLeftParenValueConstructor-252]
-	_ = x[ErrParseExpectedLeftParenBuiltinFunctionCall-253]
-	_ = x[ErrParseExpectedArgumentDelimiter-254]
-	_ = x[ErrParseCastArity-255]
-	_ = x[ErrParseInvalidTypeParam-256]
-	_ = x[ErrParseEmptySelect-257]
-	_ = x[ErrParseSelectMissingFrom-258]
-	_ = x[ErrParseExpectedIdentForGroupName-259]
-	_ = x[ErrParseExpectedIdentForAlias-260]
-	_ = x[ErrParseUnsupportedCallWithStar-261]
-	_ = x[ErrParseNonUnaryAgregateFunctionCall-262]
-	_ = x[ErrParseMalformedJoin-263]
-	_ = x[ErrParseExpectedIdentForAt-264]
-	_ = x[ErrParseAsteriskIsNotAloneInSelectList-265]
-	_ = x[ErrParseCannotMixSqbAndWildcardInSelectList-266]
-	_ = x[ErrParseInvalidContextForWildcardInSelectList-267]
-	_ = x[ErrIncorrectSQLFunctionArgumentType-268]
-	_ = x[ErrValueParseFailure-269]
-	_ = x[ErrEvaluatorInvalidArguments-270]
-	_ = x[ErrIntegerOverflow-271]
-	_ = x[ErrLikeInvalidInputs-272]
-	_ = x[ErrCastFailed-273]
-	_ = x[ErrInvalidCast-274]
-	_ = x[ErrEvaluatorInvalidTimestampFormatPattern-275]
-	_ = x[ErrEvaluatorInvalidTimestampFormatPatternSymbolForParsing-276]
-	_ = x[ErrEvaluatorTimestampFormatPatternDuplicateFields-277]
-	_ = x[ErrEvaluatorTimestampFormatPatternHourClockAmPmMismatch-278]
-	_ = x[ErrEvaluatorUnterminatedTimestampFormatPatternToken-279]
-	_ = x[ErrEvaluatorInvalidTimestampFormatPatternToken-280]
-	_ = x[ErrEvaluatorInvalidTimestampFormatPatternSymbol-281]
-	_ = x[ErrEvaluatorBindingDoesNotExist-282]
-	_ = x[ErrMissingHeaders-283]
-	_ = x[ErrInvalidColumnIndex-284]
-	_ = x[ErrAdminConfigNotificationTargetsFailed-285]
-	_ = x[ErrAdminProfilerNotEnabled-286]
-	_ = x[ErrInvalidDecompressedSize-287]
-	_ = x[ErrAddUserInvalidArgument-288]
-	_ = x[ErrAdminResourceInvalidArgument-289]
-	_ = x[ErrAdminAccountNotEligible-290]
-	_ = x[ErrAccountNotEligible-291]
-	_ = x[ErrAdminServiceAccountNotFound-292]
-	_ = x[ErrPostPolicyConditionInvalidFormat-293]
-	_ = x[ErrInvalidChecksum-294]
+	_ = x[ErrBucketSSEComplianceViolation-39]
+	_ = x[ErrNoSuchCORSConfiguration-40]
+	_ = x[ErrNoSuchWebsiteConfiguration-41]
+	_ = x[ErrInvalidTargetBucketForLogging-42]
+	_ = x[ErrReplicationConfigurationNotFoundError-43]
+	_ = x[ErrRemoteDestinationNotFoundError-44]
+	_ = x[ErrReplicationDestinationMissingLock-45]
+	_ = x[ErrRemoteTargetNotFoundError-46]
+	_ = x[ErrReplicationRemoteConnectionError-47]
+	_ = x[ErrReplicationBandwidthLimitError-48]
+	_ = x[ErrBucketRemoteIdenticalToSource-49]
+	_ = x[ErrBucketRemoteAlreadyExists-50]
+	_ = x[ErrBucketRemoteLabelInUse-51]
+	_ = x[ErrBucketRemoteArnTypeInvalid-52]
+	_ = x[ErrBucketRemoteArnInvalid-53]
+	_ = x[ErrBucketRemoteRemoveDisallowed-54]
+	_ = x[ErrRemoteTargetNotVersionedError-55]
+	_ = x[ErrReplicationSourceNotVersionedError-56]
+	_ = x[ErrReplicationNeedsVersioningError-57]
+	_ = x[ErrReplicationBucketNeedsVersioningError-58]
+	_ = x[ErrReplicationDenyEditError-59]
+	_ = x[ErrReplicationNoExistingObjects-60]
+	_ = x[ErrObjectRestoreAlreadyInProgress-61]
+	_ = x[ErrNoSuchKey-62]
+	_ = x[ErrNoSuchUpload-63]
+	_ = x[ErrInvalidVersionID-64]
+	_ = x[ErrNoSuchVersion-65]
+	_ = x[ErrNotImplemented-66]
+	_ = x[ErrPreconditionFailed-67]
+	_ = x[ErrRequestTimeTooSkewed-68]
+	_ = x[ErrSignatureDoesNotMatch-69]
+	_ = x[ErrMethodNotAllowed-70]
+	_ = x[ErrInvalidPart-71]
+	_ = x[ErrInvalidPartOrder-72]
+	_ = x[ErrAuthorizationHeaderMalformed-73]
+	_ = x[ErrMalformedPOSTRequest-74]
+	_ = x[ErrPOSTFileRequired-75]
+	_ = x[ErrSignatureVersionNotSupported-76]
+	_ = x[ErrBucketNotEmpty-77]
+	_ = x[ErrAllAccessDisabled-78]
+	_ = x[ErrMalformedPolicy-79]
+	_ = x[ErrMissingFields-80]
+	_ = x[ErrMissingCredTag-81]
+	_ = x[ErrCredMalformed-82]
+	_ = x[ErrInvalidRegion-83]
+	_ = x[ErrInvalidServiceS3-84]
+	_ = x[ErrInvalidServiceSTS-85]
+	_ = x[ErrInvalidRequestVersion-86]
+	_ = x[ErrMissingSignTag-87]
+	_ = x[ErrMissingSignHeadersTag-88]
+	_ = x[ErrMalformedDate-89]
+	_ = x[ErrMalformedPresignedDate-90]
+	_ = x[ErrMalformedCredentialDate-91]
+	_ = x[ErrMalformedCredentialRegion-92]
+	_ = x[ErrMalformedExpires-93]
+	_ = x[ErrNegativeExpires-94]
+	_ = x[ErrAuthHeaderEmpty-95]
+	_ = x[ErrExpiredPresignRequest-96]
+	_ = x[ErrRequestNotReadyYet-97]
+	_ = x[ErrUnsignedHeaders-98]
+	_ = x[ErrMissingDateHeader-99]
+	_ = x[ErrInvalidQuerySignatureAlgo-100]
+	_ = x[ErrInvalidQueryParams-101]
+	_ = x[ErrBucketAlreadyOwnedByYou-102]
+	_ = x[ErrInvalidDuration-103]
+	_ = x[ErrBucketAlreadyExists-104]
+	_ = x[ErrTooManyBuckets-105]
+	_ = x[ErrMetadataTooLarge-106]
+	_ = x[ErrUnsupportedMetadata-107]
+	_ = x[ErrMaximumExpires-108]
+	_ = x[ErrSlowDown-109]
+	_ = x[ErrInvalidPrefixMarker-110]
+	_ = x[ErrBadRequest-111]
+	_ = x[ErrKeyTooLongError-112]
+	_ = x[ErrInvalidBucketObjectLockConfiguration-113]
+	_ = x[ErrObjectLockConfigurationNotFound-114]
+	_ = x[ErrObjectLockConfigurationNotAllowed-115]
+	_ = x[ErrNoSuchObjectLockConfiguration-116]
+	_ = x[ErrObjectLocked-117]
+	_ = x[ErrInvalidRetentionDate-118]
+	_ = x[ErrPastObjectLockRetainDate-119]
+	_ = x[ErrUnknownWORMModeDirective-120]
+	_ = x[ErrBucketTaggingNotFound-121]
+	_ = x[ErrObjectLockInvalidHeaders-122]
+	_ = x[ErrInvalidTagDirective-123]
+	_ = x[ErrInvalidEncryptionMethod-124]
+	_ = x[ErrInvalidEncryptionKeyID-125]
+	_ = x[ErrInsecureSSECustomerRequest-126]
+	_ = x[ErrSSEMultipartEncrypted-127]
+	_ = x[ErrSSEEncryptedObject-128]
+	_ = x[ErrInvalidEncryptionParameters-129]
+	_ = x[ErrInvalidSSECustomerAlgorithm-130]
+	_ = x[ErrInvalidSSECustomerKey-131]
+	_ = x[ErrMissingSSECustomerKey-132]
+	_ = x[ErrMissingSSECustomerKeyMD5-133]
+	_ = x[ErrSSECustomerKeyMD5Mismatch-134]
+	_ = x[ErrInvalidSSECustomerParameters-135]
+	_ = x[ErrIncompatibleEncryptionMethod-136]
+	_ = x[ErrKMSNotConfigured-137]
+	_ = x[ErrKMSKeyNotFoundException-138]
+	_ = x[ErrNoAccessKey-139]
+	_ = x[ErrInvalidToken-140]
+	_ = x[ErrEventNotification-141]
+	_ = x[ErrARNNotification-142]
+	_ = x[ErrRegionNotification-143]
+	_ = x[ErrOverlappingFilterNotification-144]
+	_ = x[ErrFilterNameInvalid-145]
+	_ = x[ErrFilterNamePrefix-146]
+	_ = x[ErrFilterNameSuffix-147]
+	_ = x[ErrFilterValueInvalid-148]
+	_ = x[ErrOverlappingConfigs-149]
+	_ = x[ErrUnsupportedNotification-150]
+	_ = x[ErrSyncNotificationInvalid-151]
+	_ = x[ErrSyncNotificationFailed-152]
+	_ = x[ErrContentSHA256Mismatch-153]
+	_ = x[ErrContentChecksumMismatch-154]
+	_ = x[ErrReadQuorum-155]
+	_ = x[ErrWriteQuorum-156]
+	_ = x[ErrStorageFull-157]
+	_ = x[ErrRequestBodyParse-158]
+	_ = x[ErrObjectExistsAsDirectory-159]
+	_ = x[ErrInvalidObjectName-160]
+	_ = x[ErrInvalidObjectNamePrefixSlash-161]
+	_ = x[ErrInvalidResourceName-162]
+	_ = x[ErrServerNotInitialized-163]
+	_ = x[ErrOperationTimedOut-164]
+	_ = x[ErrRequestDeadlineExceeded-165]
+	_ = x[ErrClientDisconnected-166]
+	_ = x[ErrOperationMaxedOut-167]
+	_ = x[ErrInvalidRequest-168]
+	_ = x[ErrTransitionStorageClassNotFoundError-169]
+	_ = x[ErrInvalidStorageClass-170]
+	_ = x[ErrBackendDown-171]
+	_ = x[ErrMalformedJSON-172]
+	_ = x[ErrAdminNoSuchUser-173]
+	_ = x[ErrAdminNoSuchGroup-174]
+	_ = x[ErrAdminGroupNotEmpty-175]
+	_ = x[ErrAdminNoSuchPolicy-176]
+	_ = x[ErrAdminInvalidArgument-177]
+	_ = x[ErrAdminInvalidAccessKey-178]
+	_ = x[ErrAdminInvalidSecretKey-179]
+	_ = x[ErrAdminConfigNoQuorum-180]
+	_ = x[ErrAdminConfigTooLarge-181]
+	_ = x[ErrAdminConfigBadJSON-182]
+	_ = x[ErrAdminNoSuchConfigTarget-183]
+	_ = x[ErrAdminConfigEnvOverridden-184]
+	_ = x[ErrAdminConfigDuplicateKeys-185]
+	_ = x[ErrAdminCredentialsMismatch-186]
+	_ = x[ErrInsecureClientRequest-187]
+	_ = x[ErrObjectTampered-188]
+	_ = x[ErrSiteReplicationInvalidRequest-189]
+	_ = x[ErrSiteReplicationPeerResp-190]
+	_ = x[ErrSiteReplicationBackendIssue-191]
+	_ = x[ErrSiteReplicationServiceAccountError-192]
+	_ = x[ErrSiteReplicationBucketConfigError-193]
+	_ = x[ErrSiteReplicationBucketMetaError-194]
+	_ = x[ErrSiteReplicationIAMError-195]
+	_ = x[ErrSiteReplicationConfigMissing-196]
+	_ = x[ErrSiteReplicationReadOnly-197]
+	_ = x[ErrBucketSuspended-198]
+	_ = x[ErrTagIndexNotFound-199]
+	_ = x[ErrIndexerNotConfigured-200]
+	_ = x[ErrIndexerUnavailable-201]
+	_ = x[ErrChangesFeedNotEnabled-202]
+	_ = x[ErrInvalidChangesCursor-203]
+	_ = x[ErrInvalidRenamePrefix-204]
+	_ = x[ErrRenamePrefixDestinationNotEmpty-205]
+	_ = x[ErrRenamePrefixTooManyObjects-206]
+	_ = x[ErrAdminBucketQuotaExceeded-207]
+	_ = x[ErrAdminNoSuchQuotaConfiguration-208]
+	_ = x[ErrAdminTenantQuotaExceeded-209]
+	_ = x[ErrHealNotImplemented-210]
+	_ = x[ErrHealNoSuchProcess-211]
+	_ = x[ErrHealInvalidClientToken-212]
+	_ = x[ErrHealMissingBucket-213]
+	_ = x[ErrHealAlreadyRunning-214]
+	_ = x[ErrHealOverlappingPaths-215]
+	_ = x[ErrIncorrectContinuationToken-216]
+	_ = x[ErrEmptyRequestBody-217]
+	_ = x[ErrUnsupportedFunction-218]
+	_ = x[ErrInvalidExpressionType-219]
+	_ = x[ErrBusy-220]
+	_ = x[ErrUnauthorizedAccess-221]
+	_ = x[ErrExpressionTooLong-222]
+	_ = x[ErrIllegalSQLFunctionArgument-223]
+	_ = x[ErrInvalidKeyPath-224]
+	_ = x[ErrInvalidCompressionFormat-225]
+	_ = x[ErrInvalidFileHeaderInfo-226]
+	_ = x[ErrInvalidJSONType-227]
+	_ = x[ErrInvalidQuoteFields-228]
+	_ = x[ErrInvalidRequestParameter-229]
+	_ = x[ErrInvalidDataType-230]
+	_ = x[ErrInvalidTextEncoding-231]
+	_ = x[ErrInvalidDataSource-232]
+	_ = x[ErrInvalidTableAlias-233]
+	_ = x[ErrMissingRequiredParameter-234]
+	_ = x[ErrObjectSerializationConflict-235]
+	_ = x[ErrUnsupportedSQLOperation-236]
+	_ = x[ErrUnsupportedSQLStructure-237]
+	_ = x[ErrUnsupportedSyntax-238]
+	_ = x[ErrUnsupportedRangeHeader-239]
+	_ = x[ErrLexerInvalidChar-240]
+	_ = x[ErrLexerInvalidOperator-241]
+	_ = x[ErrLexerInvalidLiteral-242]
+	_ = x[ErrLexerInvalidIONLiteral-243]
+	_ = x[ErrParseExpectedDatePart-244]
+	_ = x[ErrParseExpectedKeyword-245]
+	_ = x[ErrParseExpectedTokenType-246]
+	_ = x[ErrParseExpected2TokenTypes-247]
+	_ = x[ErrParseExpectedNumber-248]
+	_ = x[ErrParseExpectedRightParenBuiltinFunctionCall-249]
+	_ = x[ErrParseExpectedTypeName-250]
+	_ = x[ErrParseExpectedWhenClause-251]
+	_ = x[ErrParseUnsupportedToken-252]
+	_ = x[ErrParseUnsupportedLiteralsGroupBy-253]
+	_ = x[ErrParseExpectedMember-254]
+	_ = x[ErrParseUnsupportedSelect-255]
+	_ = x[ErrParseUnsupportedCase-256]
+	_ = x[ErrParseUnsupportedCaseClause-257]
+	_ = x[ErrParseUnsupportedAlias-258]
+	_ = x[ErrParseUnsupportedSyntax-259]
+	_ = x[ErrParseUnknownOperator-260]
+	_ = x[ErrParseMissingIdentAfterAt-261]
+	_ = x[ErrParseUnexpectedOperator-262]
+	_ = x[ErrParseUnexpectedTerm-263]
+	_ = x[ErrParseUnexpectedToken-264]
+	_ = x[ErrParseUnexpectedKeyword-265]
+	_ = x[ErrParseExpectedExpression-266]
+	_ = x[ErrParseExpectedLeftParenAfterCast-267]
+	_ = x[ErrParseExpectedLeftParenValueConstructor-268]
+	_ = x[ErrParseExpectedLeftParenBuiltinFunctionCall-269]
+	_ = x[ErrParseExpectedArgumentDelimiter-270]
+	_ = x[ErrParseCastArity-271]
+	_ = x[ErrParseInvalidTypeParam-272]
+	_ = x[ErrParseEmptySelect-273]
+	_ = x[ErrParseSelectMissingFrom-274]
+	_ = x[ErrParseExpectedIdentForGroupName-275]
+	_ = x[ErrParseExpectedIdentForAlias-276]
+	_ = x[ErrParseUnsupportedCallWithStar-277]
+	_ = x[ErrParseNonUnaryAgregateFunctionCall-278]
+	_ = x[ErrParseMalformedJoin-279]
+	_ = x[ErrParseExpectedIdentForAt-280]
+	_ = x[ErrParseAsteriskIsNotAloneInSelectList-281]
+	_ = x[ErrParseCannotMixSqbAndWildcardInSelectList-282]
+	_ = x[ErrParseInvalidContextForWildcardInSelectList-283]
+	_ = x[ErrIncorrectSQLFunctionArgumentType-284]
+	_ = x[ErrValueParseFailure-285]
+	_ = x[ErrEvaluatorInvalidArguments-286]
+	_ = x[ErrIntegerOverflow-287]
+	_ = x[ErrLikeInvalidInputs-288]
+	_ = x[ErrCastFailed-289]
+	_ = x[ErrInvalidCast-290]
+	_ = x[ErrEvaluatorInvalidTimestampFormatPattern-291]
+	_ = x[ErrEvaluatorInvalidTimestampFormatPatternSymbolForParsing-292]
+	_ = x[ErrEvaluatorTimestampFormatPatternDuplicateFields-293]
+	_ = x[ErrEvaluatorTimestampFormatPatternHourClockAmPmMismatch-294]
+	_ = x[ErrEvaluatorUnterminatedTimestampFormatPatternToken-295]
+	_ = x[ErrEvaluatorInvalidTimestampFormatPatternToken-296]
+	_ = x[ErrEvaluatorInvalidTimestampFormatPatternSymbol-297]
+	_ = x[ErrEvaluatorBindingDoesNotExist-298]
+	_ = x[ErrMissingHeaders-299]
+	_ = x[ErrInvalidColumnIndex-300]
+	_ = x[ErrAdminConfigNotificationTargetsFailed-301]
+	_ = x[ErrAdminProfilerNotEnabled-302]
+	_ = x[ErrInvalidDecompressedSize-303]
+	_ = x[ErrAddUserInvalidArgument-304]
+	_ = x[ErrAdminResourceInvalidArgument-305]
+	_ = x[ErrAdminAccountNotEligible-306]
+	_ = x[ErrAccountNotEligible-307]
+	_ = x[ErrAdminServiceAccountNotFound-308]
+	_ = x[ErrAdminServiceAccountLimitExceeded-309]
+	_ = x[ErrPostPolicyConditionInvalidFormat-310]
+	_ = x[ErrInvalidChecksum-311]
 }
 
-const _APIErrorCode_name = "NoneAccessDeniedBadDigestEntityTooSmallEntityTooLargePolicyTooLargeIncompleteBodyInternalErrorInvalidAccessKeyIDAccessKeyDisabledInvalidBucketNameInvalidDigestInvalidRangeInvalidRangePartNumberInvalidCopyPartRangeInvalidCopyPartRangeSourceInvalidMaxKeysInvalidEncodingMethodInvalidMaxUploadsInvalidMaxPartsInvalidPartNumberMarkerInvalidPartNumberInvalidRequestBodyInvalidCopySourceInvalidMetadataDirectiveInvalidCopyDestInvalidPolicyDocumentInvalidObjectStateMalformedXMLMissingContentLengthMissingContentMD5MissingRequestBodyErrorMissingSecurityHeaderNoSuchBucketNoSuchBucketPolicyNoSuchBucketLifecycleNoSuchLifecycleConfigurationInvalidLifecycleWithObjectLockNoSuchBucketSSEConfigNoSuchCORSConfigurationNoSuchWebsiteConfigurationReplicationConfigurationNotFoundErrorRemoteDestinationNotFoundErrorReplicationDestinationMissingLockRemoteTargetNotFoundErrorReplicationRemoteConnectionErrorReplicationBandwidthLimitErrorBucketRemoteIdenticalToSourceBucketRemoteAlreadyExistsBucketRemoteLabelInUseBucketRemoteArnTypeInvalidBucketRemoteArnInvalidBucketRemoteRemoveDisallowedRemoteTargetNotVersionedErrorReplicationSourceNotVersionedErrorReplicationNeedsVersioningErrorReplicationBucketNeedsVersioningErrorReplicationDenyEditErrorReplicationNoExistingObjectsObjectRestoreAlreadyInProgressNoSuchKeyNoSuchUploadInvalidVersionIDNoSuchVersionNotImplementedPreconditionFailedRequestTimeTooSkewedSignatureDoesNotMatchMethodNotAllowedInvalidPartInvalidPartOrderAuthorizationHeaderMalformedMalformedPOSTRequestPOSTFileRequiredSignatureVersionNotSupportedBucketNotEmptyAllAccessDisabledMalformedPolicyMissingFieldsMissingCredTagCredMalformedInvalidRegionInvalidServiceS3InvalidServiceSTSInvalidRequestVersionMissingSignTagMissingSignHeadersTagMalformedDateMalformedPresignedDateMalformedCredentialDateMalformedCredentialRegionMalformedExpiresNegativeExpiresAuthHeaderEmptyExpiredPresignRequestRequestNotReadyYetUnsignedHeadersMissingDateHeaderInvalidQuerySignatureAlgoInvalidQueryParamsBucketAlreadyOwnedByYouInvalidDurationBucketAlreadyExistsTooManyBucketsMetadataTooLargeUnsupportedMetadataMaximumExpiresSlowDownInvalidPrefixMarkerBadRequestKeyTooLongErrorInvalidBucketObjectLockConfigurationObjectLockConfigurationNotFoundObjectLockConfigurationNotAllowedNoSuchObjectLockConfigurationObjectLockedInvalidRetentionDatePastObjectLockRetainDateUnknownWORMModeDirectiveBucketTaggingNotFoundObjectLockInvalidHeadersInvalidTagDirectiveInvalidEncryptionMethodInvalidEncryptionKeyIDInsecureSSECustomerRequestSSEMultipartEncryptedSSEEncryptedObjectInvalidEncryptionParametersInvalidSSECustomerAlgorithmInvalidSSECustomerKeyMissingSSECustomerKeyMissingSSECustomerKeyMD5SSECustomerKeyMD5MismatchInvalidSSECustomerParametersIncompatibleEncryptionMethodKMSNotConfiguredKMSKeyNotFoundExceptionNoAccessKeyInvalidTokenEventNotificationARNNotificationRegionNotificationOverlappingFilterNotificationFilterNameInvalidFilterNamePrefixFilterNameSuffixFilterValueInvalidOverlappingConfigsUnsupportedNotificationContentSHA256MismatchContentChecksumMismatchReadQuorumWriteQuorumStorageFullRequestBodyParseObjectExistsAsDirectoryInvalidObjectNameInvalidObjectNamePrefixSlashInvalidResourceNameServerNotInitializedOperationTimedOutClientDisconnectedOperationMaxedOutInvalidRequestTransitionStorageClassNotFoundErrorInvalidStorageClassBackendDownMalformedJSONAdminNoSuchUserAdminNoSuchGroupAdminGroupNotEmptyAdminNoSuchPolicyAdminInvalidArgumentAdminInvalidAccessKeyAdminInvalidSecretKeyAdminConfigNoQuorumAdminConfigTooLargeAdminConfigBadJSONAdminNoSuchConfigTargetAdminConfigEnvOverriddenAdminConfigDuplicateKeysAdminCredentialsMismatchInsecureClientRequestObjectTamperedSiteReplicationInvalidRequestSiteReplicationPeerRespSiteReplicationBackendIssueSiteReplicationServiceAccountErrorSiteReplicationBucketConfigErrorSiteReplicationBucketMetaErrorSiteReplicationIAMErrorSiteReplicationConfigMissingAdminBucketQuotaExceededAdminNoSuchQuotaConfigurationHealNotImplementedHealNoSuchProcessHealInvalidClientTokenHealMissingBucketHealAlreadyRunningHealOverlappingPathsIncorrectContinuationTokenEmptyRequestBodyUnsupportedFunctionInvalidExpressionTypeBusyUnauthorizedAccessExpressionTooLongIllegalSQLFunctionArgumentInvalidKeyPathInvalidCompressionFormatInvalidFileHeaderInfoInvalidJSONTypeInvalidQuoteFieldsInvalidRequestParameterInvalidDataTypeInvalidTextEncodingInvalidDataSourceInvalidTableAliasMissingRequiredParameterObjectSerializationConflictUnsupportedSQLOperationUnsupportedSQLStructureUnsupportedSyntaxUnsupportedRangeHeaderLexerInvalidCharLexerInvalidOperatorLexerInvalidLiteralLexerInvalidIONLiteralParseExpectedDatePartParseExpectedKeywordParseExpectedTokenTypeParseExpected2TokenTypesParseExpectedNumberParseExpectedRightParenBuiltinFunctionCallParseExpectedTypeNameParseExpectedWhenClauseParseUnsupportedTokenParseUnsupportedLiteralsGroupByParseExpectedMemberParseUnsupportedSelectParseUnsupportedCaseParseUnsupportedCaseClauseParseUnsupportedAliasParseUnsupportedSyntaxParseUnknownOperatorParseMissingIdentAfterAtParseUnexpectedOperatorParseUnexpectedTermParseUnexpectedTokenParseUnexpectedKeywordParseExpectedExpressionParseExpectedLeftParenAfterCastParseExpectedLeftParenValueConstructorParseExpectedLeftParenBuiltinFunctionCallParseExpectedArgumentDelimiterParseCastArityParseInvalidTypeParamParseEmptySelectParseSelectMissingFromParseExpectedIdentForGroupNameParseExpectedIdentForAliasParseUnsupportedCallWithStarParseNonUnaryAgregateFunctionCallParseMalformedJoinParseExpectedIdentForAtParseAsteriskIsNotAloneInSelectListParseCannotMixSqbAndWildcardInSelectListParseInvalidContextForWildcardInSelectListIncorrectSQLFunctionArgumentTypeValueParseFailureEvaluatorInvalidArgumentsIntegerOverflowLikeInvalidInputsCastFailedInvalidCastEvaluatorInvalidTimestampFormatPatternEvaluatorInvalidTimestampFormatPatternSymbolForParsingEvaluatorTimestampFormatPatternDuplicateFieldsEvaluatorTimestampFormatPatternHourClockAmPmMismatchEvaluatorUnterminatedTimestampFormatPatternTokenEvaluatorInvalidTimestampFormatPatternTokenEvaluatorInvalidTimestampFormatPatternSymbolEvaluatorBindingDoesNotExistMissingHeadersInvalidColumnIndexAdminConfigNotificationTargetsFailedAdminProfilerNotEnabledInvalidDecompressedSizeAddUserInvalidArgumentAdminResourceInvalidArgumentAdminAccountNotEligibleAccountNotEligibleAdminServiceAccountNotFoundPostPolicyConditionInvalidFormatInvalidChecksum"
+const _APIErrorCode_name = "NoneAccessDeniedBadDigestEntityTooSmallEntityTooLargePolicyTooLargeIncompleteBodyInternalErrorInvalidAccessKeyIDAccessKeyDisabledInvalidBucketNameInvalidDigestInvalidRangeInvalidRangePartNumberInvalidCopyPartRangeInvalidCopyPartRangeSourceInvalidMaxKeysInvalidEncodingMethodInvalidMaxUploadsInvalidMaxPartsInvalidPartNumberMarkerInvalidPartNumberInvalidRequestBodyInvalidCopySourceInvalidMetadataDirectiveInvalidCopyDestInvalidPolicyDocumentInvalidObjectStateMalformedXMLMissingContentLengthMissingContentMD5MissingRequestBodyErrorMissingSecurityHeaderNoSuchBucketNoSuchBucketPolicyNoSuchBucketLifecycleNoSuchLifecycleConfigurationInvalidLifecycleWithObjectLockNoSuchBucketSSEConfigBucketSSEComplianceViolationNoSuchCORSConfigurationNoSuchWebsiteConfigurationInvalidTargetBucketForLoggingReplicationConfigurationNotFoundErrorRemoteDestinationNotFoundErrorReplicationDestinationMissingLockRemoteTargetNotFoundErrorReplicationRemoteConnectionErrorReplicationBandwidthLimitErrorBucketRemoteIdenticalToSourceBucketRemoteAlreadyExistsBucketRemoteLabelInUseBucketRemoteArnTypeInvalidBucketRemoteArnInvalidBucketRemoteRemoveDisallowedRemoteTargetNotVersionedErrorReplicationSourceNotVersionedErrorReplicationNeedsVersioningErrorReplicationBucketNeedsVersioningErrorReplicationDenyEditErrorReplicationNoExistingObjectsObjectRestoreAlreadyInProgressNoSuchKeyNoSuchUploadInvalidVersionIDNoSuchVersionNotImplementedPreconditionFailedRequestTimeTooSkewedSignatureDoesNotMatchMethodNotAllowedInvalidPartInvalidPartOrderAuthorizationHeaderMalformedMalformedPOSTRequestPOSTFileRequiredSignatureVersionNotSupportedBucketNotEmptyAllAccessDisabledMalformedPolicyMissingFieldsMissingCredTagCredMalformedInvalidRegionInvalidServiceS3InvalidServiceSTSInvalidRequestVersionMissingSignTagMissingSignHeadersTagMalformedDateMalformedPresignedDateMalformedCredentialDateMalformedCredentialRegionMalformedExpiresNegativeExpiresAuthHeaderEmptyExpiredPresignRequestRequestNotReadyYetUnsignedHeadersMissingDateHeaderInvalidQuerySignatureAlgoInvalidQueryParamsBucketAlreadyOwnedByYouInvalidDurationBucketAlreadyExistsTooManyBucketsMetadataTooLargeUnsupportedMetadataMaximumExpiresSlowDownInvalidPrefixMarkerBadRequestKeyTooLongErrorInvalidBucketObjectLockConfigurationObjectLockConfigurationNotFoundObjectLockConfigurationNotAllowedNoSuchObjectLockConfigurationObjectLockedInvalidRetentionDatePastObjectLockRetainDateUnknownWORMModeDirectiveBucketTaggingNotFoundObjectLockInvalidHeadersInvalidTagDirectiveInvalidEncryptionMethodInvalidEncryptionKeyIDInsecureSSECustomerRequestSSEMultipartEncryptedSSEEncryptedObjectInvalidEncryptionParametersInvalidSSECustomerAlgorithmInvalidSSECustomerKeyMissingSSECustomerKeyMissingSSECustomerKeyMD5SSECustomerKeyMD5MismatchInvalidSSECustomerParametersIncompatibleEncryptionMethodKMSNotConfiguredKMSKeyNotFoundExceptionNoAccessKeyInvalidTokenEventNotificationARNNotificationRegionNotificationOverlappingFilterNotificationFilterNameInvalidFilterNamePrefixFilterNameSuffixFilterValueInvalidOverlappingConfigsUnsupportedNotificationSyncNotificationInvalidSyncNotificationFailedContentSHA256MismatchContentChecksumMismatchReadQuorumWriteQuorumStorageFullRequestBodyParseObjectExistsAsDirectoryInvalidObjectNameInvalidObjectNamePrefixSlashInvalidResourceNameServerNotInitializedOperationTimedOutRequestDeadlineExceededClientDisconnectedOperationMaxedOutInvalidRequestTransitionStorageClassNotFoundErrorInvalidStorageClassBackendDownMalformedJSONAdminNoSuchUserAdminNoSuchGroupAdminGroupNotEmptyAdminNoSuchPolicyAdminInvalidArgumentAdminInvalidAccessKeyAdminInvalidSecretKeyAdminConfigNoQuorumAdminConfigTooLargeAdminConfigBadJSONAdminNoSuchConfigTargetAdminConfigEnvOverriddenAdminConfigDuplicateKeysAdminCredentialsMismatchInsecureClientRequestObjectTamperedSiteReplicationInvalidRequestSiteReplicationPeerRespSiteReplicationBackendIssueSiteReplicationServiceAccountErrorSiteReplicationBucketConfigErrorSiteReplicationBucketMetaErrorSiteReplicationIAMErrorSiteReplicationConfigMissingSiteReplicationReadOnlyBucketSuspendedTagIndexNotFoundIndexerNotConfiguredIndexerUnavailableChangesFeedNotEnabledInvalidChangesCursorInvalidRenamePrefixRenamePrefixDestinationNotEmptyRenamePrefixTooManyObjectsAdminBucketQuotaExceededAdminNoSuchQuotaConfigurationAdminTenantQuotaExceededHealNotImplementedHealNoSuchProcessHealInvalidClientTokenHealMissingBucketHealAlreadyRunningHealOverlappingPathsIncorrectContinuationTokenEmptyRequestBodyUnsupportedFunctionInvalidExpressionTypeBusyUnauthorizedAccessExpressionTooLongIllegalSQLFunctionArgumentInvalidKeyPathInvalidCompressionFormatInvalidFileHeaderInfoInvalidJSONTypeInvalidQuoteFieldsInvalidRequestParameterInvalidDataTypeInvalidTextEncodingInvalidDataSourceInvalidTableAliasMissingRequiredParameterObjectSerializationConflictUnsupportedSQLOperationUnsupportedSQLStructureUnsupportedSyntaxUnsupportedRangeHeaderLexerInvalidCharLexerInvalidOperatorLexerInvalidLiteralLexerInvalidIONLiteralParseExpectedDatePartParseExpectedKeywordParseExpectedTokenTypeParseExpected2TokenTypesParseExpectedNumberParseExpectedRightParenBuiltinFunctionCallParseExpectedTypeNameParseExpectedWhenClauseParseUnsupportedTokenParseUnsupportedLiteralsGroupByParseExpectedMemberParseUnsupportedSelectParseUnsupportedCaseParseUnsupportedCaseClauseParseUnsupportedAliasParseUnsupportedSyntaxParseUnknownOperatorParseMissingIdentAfterAtParseUnexpectedOperatorParseUnexpectedTermParseUnexpectedTokenParseUnexpectedKeywordParseExpectedExpressionParseExpectedLeftParenAfterCastParseExpectedLeftParenValueConstructorParseExpectedLeftParenBuiltinFunctionCallParseExpectedArgumentDelimiterParseCastArityParseInvalidTypeParamParseEmptySelectParseSelectMissingFromParseExpectedIdentForGroupNameParseExpectedIdentForAliasParseUnsupportedCallWithStarParseNonUnaryAgregateFunctionCallParseMalformedJoinParseExpectedIdentForAtParseAsteriskIsNotAloneInSelectListParseCannotMixSqbAndWildcardInSelectListParseInvalidContextForWildcardInSelectListIncorrectSQLFunctionArgumentTypeValueParseFailureEvaluatorInvalidArgumentsIntegerOverflowLikeInvalidInputsCastFailedInvalidCastEvaluatorInvalidTimestampFormatPatternEvaluatorInvalidTimestampFormatPatternSymbolForParsingEvaluatorTimestampFormatPatternDuplicateFieldsEvaluatorTimestampFormatPatternHourClockAmPmMismatchEvaluatorUnterminatedTimestampFormatPatternTokenEvaluatorInvalidTimestampFormatPatternTokenEvaluatorInvalidTimestampFormatPatternSymbolEvaluatorBindingDoesNotExistMissingHeadersInvalidColumnIndexAdminConfigNotificationTargetsFailedAdminProfilerNotEnabledInvalidDecompressedSizeAddUserInvalidArgumentAdminResourceInvalidArgumentAdminAccountNotEligibleAccountNotEligibleAdminServiceAccountNotFoundAdminServiceAccountLimitExceededPostPolicyConditionInvalidFormatInvalidChecksum"
 
-var _APIErrorCode_index = [...]uint16{0, 4, 16, 25, 39, 53, 67, 81, 94, 112, 129, 146, 159, 171, 193, 213, 239, 253, 274, 291, 306, 329, 346, 364, 381, 405, 420, 441, 459, 471, 491, 508, 531, 552, 564, 582, 603, 631, 661, 682, 705, 731, 768, 798, 831, 856, 888, 918, 947, 972, 994, 1020, 1042, 1070, 1099, 1133, 1164, 1201, 1225, 1253, 1283, 1292, 1304, 1320, 1333, 1347, 1365, 1385, 1406, 1422, 1433, 1449, 1477, 1497, 1513, 1541, 1555, 1572, 1587, 1600, 1614, 1627, 1640, 1656, 1673, 1694, 1708, 1729, 1742, 1764, 1787, 1812, 1828, 1843, 1858, 1879, 1897, 1912, 1929, 1954, 1972, 1995, 2010, 2029, 2043, 2059, 2078, 2092, 2100, 2119, 2129, 2144, 2180, 2211, 2244, 2273, 2285, 2305, 2329, 2353, 2374, 2398, 2417, 2440, 2462, 2488, 2509, 2527, 2554, 2581, 2602, 2623, 2647, 2672, 2700, 2728, 2744, 2767, 2778, 2790, 2807, 2822, 2840, 2869, 2886, 2902, 2918, 2936, 2954, 2977, 2998, 3021, 3031, 3042, 3053, 3069, 3092, 3109, 3137, 3156, 3176, 3193, 3211, 3228, 3242, 3277, 3296, 3307, 3320, 3335, 3351, 3369, 3386, 3406, 3427, 3448, 3467, 3486, 3504, 3527, 3551, 3575, 3599, 3620, 3634, 3663, 3686, 3713, 3747, 3779, 3809, 3832, 3860, 3884, 3913, 3931, 3948, 3970, 3987, 4005, 4025, 4051, 4067, 4086, 4107, 4111, 4129, 4146, 4172, 4186, 4210, 4231, 4246, 4264, 4287, 4302, 4321, 4338, 4355, 4379, 4406, 4429, 4452, 4469, 4491, 4507, 4527, 4546, 4568, 4589, 4609, 4631, 4655, 4674, 4716, 4737, 4760, 4781, 4812, 4831, 4853, 4873, 4899, 4920, 4942, 4962, 4986, 5009, 5028, 5048, 5070, 5093, 5124, 5162, 5203, 5233, 5247, 5268, 5284, 5306, 5336, 5362, 5390, 5423, 5441, 5464, 5499, 5539, 5581, 5613, 5630, 5655, 5670, 5687, 5697, 5708, 5746, 5800, 5846, 5898, 5946, 5989, 6033, 6061, 6075, 6093, 6129, 6152, 6175, 6197, 6225, 6248, 6266, 6293, 6325, 6340}
+var _APIErrorCode_index = [...]uint16{0, 4, 16, 25, 39, 53, 67, 81, 94, 112, 129, 146, 159, 171, 193, 213, 239, 253, 274, 291, 306, 329, 346, 364, 381, 405, 420, 441, 459, 471, 491, 508, 531, 552, 564, 582, 603, 631, 661, 682, 710, 733, 759, 788, 825, 855, 888, 913, 945, 975, 1004, 1029, 1051, 1077, 1099, 1127, 1156, 1190, 1221, 1258, 1282, 1310, 1340, 1349, 1361, 1377, 1390, 1404, 1422, 1442, 1463, 1479, 1490, 1506, 1534, 1554, 1570, 1598, 1612, 1629, 1644, 1657, 1671, 1684, 1697, 1713, 1730, 1751, 1765, 1786, 1799, 1821, 1844, 1869, 1885, 1900, 1915, 1936, 1954, 1969, 1986, 2011, 2029, 2052, 2067, 2086, 2100, 2116, 2135, 2149, 2157, 2176, 2186, 2201, 2237, 2268, 2301, 2330, 2342, 2362, 2386, 2410, 2431, 2455, 2474, 2497, 2519, 2545, 2566, 2584, 2611, 2638, 2659, 2680, 2704, 2729, 2757, 2785, 2801, 2824, 2835, 2847, 2864, 2879, 2897, 2926, 2943, 2959, 2975, 2993, 3011, 3034, 3057, 3079, 3100, 3123, 3133, 3144, 3155, 3171, 3194, 3211, 3239, 3258, 3278, 3295, 3318, 3336, 3353, 3367, 3402, 3421, 3432, 3445, 3460, 3476, 3494, 3511, 3531, 3552, 3573, 3592, 3611, 3629, 3652, 3676, 3700, 3724, 3745, 3759, 3788, 3811, 3838, 3872, 3904, 3934, 3957, 3985, 4008, 4023, 4039, 4059, 4077, 4098, 4118, 4137, 4168, 4194, 4218, 4247, 4271, 4289, 4306, 4328, 4345, 4363, 4383, 4409, 4425, 4444, 4465, 4469, 4487, 4504, 4530, 4544, 4568, 4589, 4604, 4622, 4645, 4660, 4679, 4696, 4713, 4737, 4764, 4787, 4810, 4827, 4849, 4865, 4885, 4904, 4926, 4947, 4967, 4989, 5013, 5032, 5074, 5095, 5118, 5139, 5170, 5189, 5211, 5231, 5257, 5278, 5300, 5320, 5344, 5367, 5386, 5406, 5428, 5451, 5482, 5520, 5561, 5591, 5605, 5626, 5642, 5664, 5694, 5720, 5748, 5781, 5799, 5822, 5857, 5897, 5939, 5971, 5988, 6013, 6028, 6045, 6055, 6066, 6104, 6158, 6204, 6256, 6304, 6347, 6391, 6419, 6433, 6451, 6487, 6510, 6533, 6555, 6583, 6606, 6624, 6651, 6683, 6715, 6730}
 
 func (i APIErrorCode) String() string {
 	if i < 0 || i >= APIErrorCode(len(_APIErrorCode_index)-1) {
@@ -24,6 +24,7 @@ import (
 	"encoding/base64"
 	"encoding/hex"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/url"
@@ -37,6 +38,7 @@ import (
 	"github.com/qkbyte/minio/internal/auth"
 	objectlock "github.com/qkbyte/minio/internal/bucket/object/lock"
 	"github.com/qkbyte/minio/internal/etag"
+	"github.com/qkbyte/minio/internal/handlers"
 	"github.com/qkbyte/minio/internal/hash"
 	xhttp "github.com/qkbyte/minio/internal/http"
 	xjwt "github.com/qkbyte/minio/internal/jwt"
@@ -48,14 +50,15 @@ func isRequestJWT(r *http.Request) bool {
 	return strings.HasPrefix(r.Header.Get(xhttp.Authorization), jwtAlgorithm)
 }
 
-// Verify if request has AWS Signature Version '4'.
+// Verify if request has AWS Signature Version '4' or '4A'.
 func isRequestSignatureV4(r *http.Request) bool {
-	return strings.HasPrefix(r.Header.Get(xhttp.Authorization), signV4Algorithm)
+	return strings.HasPrefix(r.Header.Get(xhttp.Authorization), signV4Algorithm) ||
+		isRequestSignatureV4A(r)
 }
 
 // Verify if request has AWS Signature Version '2'.
 func isRequestSignatureV2(r *http.Request) bool {
-	return (!strings.HasPrefix(r.Header.Get(xhttp.Authorization), signV4Algorithm) &&
+	return (!isRequestSignatureV4(r) &&
 		strings.HasPrefix(r.Header.Get(xhttp.Authorization), signV2Algorithm))
 }
 
@@ -307,6 +310,11 @@ func authenticateRequest(ctx context.Context, r *http.Request, action policy.Act
 		logger.LogIf(ctx, errors.New("unexpected context.Context does not have a logger.ReqInfo"), logger.Minio)
 		return ErrAccessDenied
 	}
+	defer func() {
+		if s3Err != ErrNone {
+			recordAuthFailure(ctx, s3Err)
+		}
+	}()
 
 	var cred auth.Credentials
 	var owner bool
@@ -335,6 +343,7 @@ func authenticateRequest(ctx context.Context, r *http.Request, action policy.Act
 
 	logger.GetReqInfo(ctx).Cred = cred
 	logger.GetReqInfo(ctx).Owner = owner
+	setUsageAccessKey(ctx, cred.AccessKey)
 
 	// region is valid only for CreateBucketAction.
 	var region string
@@ -404,6 +413,12 @@ func authorizeRequest(ctx context.Context, r *http.Request, action policy.Action
 			}
 		}
 
+		// Share links grant read access to a bucket prefix beyond
+		// the bucket policy.
+		if isShareLinkAllowed(r, action, bucket, object) {
+			return ErrNone
+		}
+
 		return ErrAccessDenied
 	}
 
@@ -476,8 +491,12 @@ func isReqAuthenticatedV2(r *http.Request) (s3Error APIErrorCode) {
 func reqSignatureV4Verify(r *http.Request, region string, stype serviceType) (s3Error APIErrorCode) {
 	sha256sum := getContentSha256Cksum(r, stype)
 	switch {
+	case isRequestSignatureV4A(r):
+		return doesSignatureV4AMatch(sha256sum, r, region, stype)
 	case isRequestSignatureV4(r):
 		return doesSignatureMatch(sha256sum, r, region, stype)
+	case isRequestPresignedSignatureV4A(r):
+		return doesPresignedSignatureV4AMatch(sha256sum, r, region, stype)
 	case isRequestPresignedSignatureV4(r):
 		return doesPresignedSignatureMatch(sha256sum, r, region, stype)
 	default:
@@ -523,6 +542,62 @@ func isReqAuthenticated(ctx context.Context, r *http.Request, region string, sty
 	return ErrNone
 }
 
+// Kinds of authentication failures, tagged on the request so that
+// clock drift can be told apart from bad credentials in logs.
+const (
+	authFailureClockSkew   = "clockSkew"
+	authFailureCredentials = "credentials"
+)
+
+// authFailureKind returns the kind of authentication failure of errCode,
+// or an empty string for failures that are neither.
+func authFailureKind(errCode APIErrorCode) string {
+	switch errCode {
+	case ErrRequestTimeTooSkewed, ErrRequestNotReadyYet:
+		return authFailureClockSkew
+	case ErrSignatureDoesNotMatch, ErrInvalidAccessKeyID, ErrAccessKeyDisabled, ErrInvalidToken:
+		return authFailureCredentials
+	}
+	return ""
+}
+
+// recordAuthFailure counts a failed authentication by kind and tags the
+// request with it for audit and error logs.
+func recordAuthFailure(ctx context.Context, errCode APIErrorCode) {
+	kind := authFailureKind(errCode)
+	switch kind {
+	case authFailureClockSkew:
+		atomic.AddUint64(&globalHTTPStats.rejectedRequestsSkew, 1)
+	case authFailureCredentials:
+		atomic.AddUint64(&globalHTTPStats.rejectedRequestsCreds, 1)
+	default:
+		return
+	}
+	if reqInfo := logger.GetReqInfo(ctx); reqInfo != nil {
+		reqInfo.SetTags("authFailure", kind)
+	}
+}
+
+// logClockSkew logs requests rejected for a skewed client clock, once per
+// client and direction, skew is positive when the client is ahead.
+func logClockSkew(r *http.Request, skew, maxSkew time.Duration) {
+	direction := "ahead of"
+	if skew < 0 {
+		direction = "behind"
+	}
+	// Log once per connection peer, forwarded headers would let any
+	// client flood the log with made up sources.
+	source := getRemoteHost(r)
+	ctx := logger.SetReqInfo(r.Context(), &logger.ReqInfo{
+		RemoteHost: handlers.GetSourceIP(r),
+		UserAgent:  r.UserAgent(),
+		API:        "handler.Auth",
+	})
+	logger.GetReqInfo(ctx).SetTags("authFailure", authFailureClockSkew)
+	logger.LogOnceIf(ctx, fmt.Errorf("requests from %s are rejected, the client clock is %s the server clock by more than the allowed request time skew of %s",
+		source, direction, maxSkew), "request-time-skew-"+direction+"-"+source)
+}
+
 // List of all support S3 auth types.
 var supportedS3AuthTypes = map[authType]struct{}{
 	authTypeAnonymous:       {},
@@ -563,17 +638,19 @@ func setAuthHandler(h http.Handler) http.Handler {
 				atomic.AddUint64(&globalHTTPStats.rejectedRequestsTime, 1)
 				return
 			}
-			// Verify if the request date header is shifted by less than globalMaxSkewTime parameter in the past
-			// or in the future, reject request otherwise.
+			// Verify if the request date header is shifted by less than the allowed request time skew
+			// in the past or in the future, reject request otherwise.
 			curTime := UTCNow()
-			if curTime.Sub(amzDate) > globalMaxSkewTime || amzDate.Sub(curTime) > globalMaxSkewTime {
+			if maxSkew := globalAPIConfig.getRequestTimeSkew(); curTime.Sub(amzDate) > maxSkew || amzDate.Sub(curTime) > maxSkew {
 				if ok {
 					tc.funcName = "handler.Auth"
 					tc.responseRecorder.LogErrBody = true
 				}
 
+				logClockSkew(r, amzDate.Sub(curTime), maxSkew)
 				writeErrorResponse(r.Context(), w, errorCodes.ToAPIErr(ErrRequestTimeTooSkewed), r.URL)
 				atomic.AddUint64(&globalHTTPStats.rejectedRequestsTime, 1)
+				atomic.AddUint64(&globalHTTPStats.rejectedRequestsSkew, 1)
 				return
 			}
 		}
@@ -681,6 +758,7 @@ func isPutActionAllowed(ctx context.Context, atype authType, bucketName, objectN
 
 	logger.GetReqInfo(ctx).Cred = cred
 	logger.GetReqInfo(ctx).Owner = owner
+	setUsageAccessKey(ctx, cred.AccessKey)
 	logger.GetReqInfo(ctx).Region = region
 
 	// Do not check for PutObjectRetentionAction permission,
@@ -252,6 +252,13 @@ func (h *healingTracker) toHealingDisk() madmin.HealingDisk {
 }
 
 func initAutoHeal(ctx context.Context, objAPI ObjectLayer) {
+	if _, ok := objAPI.(*erasureSingle); ok {
+		// No drives to heal, only serve the integrity
+		// checks queued by the scanner and the MRF.
+		initBackgroundHealing(ctx, objAPI)
+		return
+	}
+
 	z, ok := objAPI.(*erasureServerPools)
 	if !ok {
 		return
@@ -380,6 +387,7 @@ func healFreshDisk(ctx context.Context, z *erasureServerPools, endpoint Endpoint
 	}
 
 	logger.LogIf(ctx, tracker.delete(ctx))
+	publishHealStatus(tracker, true)
 
 	return nil
 }
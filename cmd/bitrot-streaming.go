@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"hash"
 	"io"
+	"os"
 	"sync"
 
 	xhttp "github.com/qkbyte/minio/internal/http"
@@ -202,3 +203,114 @@ func newStreamingBitrotReader(disk StorageAPI, data []byte, volume, filePath str
 		hashBytes:  make([]byte, h.Size()),
 	}
 }
+
+// rawShardReader serves a range of a plain single part object from the
+// part files of its data shards, as written by streamingBitrotWriter.
+// Every shard is verified against its hash before any of its content
+// is served. Once a shard fails to be read or verified the remaining
+// range is served by decoding the object through fallback.
+type rawShardReader struct {
+	files      []*os.File
+	h          hash.Hash
+	size       int64
+	blockSize  int64
+	shardSize  int64
+	dataBlocks int64
+
+	pos, end int64
+	buf      []byte
+	cur      []byte // verified content left in the current shard
+
+	fallback func(offset, length int64) io.ReadCloser
+	decoded  io.ReadCloser
+}
+
+// fill makes sure cur holds verified content, switching to decoding on
+// any error.
+func (r *rawShardReader) fill() {
+	if len(r.cur) > 0 || r.decoded != nil || r.pos >= r.end {
+		return
+	}
+	hashSize := int64(r.h.Size())
+	block := r.pos / r.blockSize
+	blockOffset := r.pos - block*r.blockSize
+	blockLength := r.blockSize
+	if rest := r.size - block*r.blockSize; rest < blockLength {
+		blockLength = rest
+	}
+	blockShardSize := ceilFrac(blockLength, r.dataBlocks)
+	shard := blockOffset / blockShardSize
+	shardOffset := blockOffset % blockShardSize
+
+	// Each block is split in dataBlocks shards of equal size, the n-th
+	// shard of every block is stored in the part file of the n-th data
+	// drive after the hash of that shard.
+	b := r.buf[:hashSize+blockShardSize]
+	_, err := r.files[shard].ReadAt(b, block*(r.shardSize+hashSize))
+	if err == nil {
+		r.h.Reset()
+		r.h.Write(b[hashSize:])
+		if !bytes.Equal(r.h.Sum(nil), b[:hashSize]) {
+			err = errFileCorrupt
+		}
+	}
+	if err != nil {
+		r.decoded = r.fallback(r.pos, r.end-r.pos)
+		return
+	}
+
+	n := blockShardSize - shardOffset
+	if rest := blockLength - blockOffset; rest < n {
+		n = rest
+	}
+	if rest := r.end - r.pos; rest < n {
+		n = rest
+	}
+	r.cur = b[hashSize+shardOffset : hashSize+shardOffset+n]
+	r.pos += n
+}
+
+// Read implements io.Reader.
+func (r *rawShardReader) Read(p []byte) (int, error) {
+	r.fill()
+	if r.decoded != nil {
+		return r.decoded.Read(p)
+	}
+	if len(r.cur) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(p, r.cur)
+	r.cur = r.cur[n:]
+	return n, nil
+}
+
+// WriteTo implements io.WriterTo.
+func (r *rawShardReader) WriteTo(w io.Writer) (total int64, err error) {
+	for {
+		r.fill()
+		if r.decoded != nil {
+			n, err := io.Copy(w, r.decoded)
+			return total + n, err
+		}
+		if len(r.cur) == 0 {
+			return total, nil
+		}
+		n, err := w.Write(r.cur)
+		total += int64(n)
+		r.cur = r.cur[n:]
+		if err != nil {
+			return total, err
+		}
+	}
+}
+
+// Close closes the part files and stops the decoding, if any.
+func (r *rawShardReader) Close() error {
+	for _, f := range r.files {
+		f.Close()
+	}
+	if r.decoded != nil {
+		r.decoded.Close()
+	}
+	return nil
+}
@@ -140,6 +140,9 @@ func closeBitrotWriters(ws []io.Writer) {
 
 // Returns hash sum for whole-bitrot, nil for streaming-bitrot.
 func bitrotWriterSum(w io.Writer) []byte {
+	if tw, ok := w.(*healThrottledWriter); ok {
+		w = tw.Writer
+	}
 	if bw, ok := w.(*wholeBitrotWriter); ok {
 		return bw.Sum(nil)
 	}
@@ -0,0 +1,304 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/qkbyte/minio/internal/hash"
+	xhttp "github.com/qkbyte/minio/internal/http"
+	"github.com/qkbyte/minio/internal/logger"
+	"github.com/qkbyte/minio/internal/logger/message/audit"
+	"github.com/qkbyte/minio/internal/logger/target/types"
+)
+
+const (
+	// Access logs are delivered at least this often, or earlier
+	// once the pending log of a bucket grows above accessLogMaxSize.
+	accessLogFlushInterval = 5 * time.Minute
+	accessLogMaxSize       = 4 << 20
+
+	// Time format used by the S3 server access log records.
+	accessLogTimeFormat = "[02/Jan/2006:15:04:05 -0700]"
+	// Time format used in the names of the delivered log objects.
+	accessLogObjectTimeFormat = "2006-01-02-15-04-05"
+)
+
+// accessLogKey identifies the log objects of a source bucket
+// delivered to a target bucket and prefix.
+type accessLogKey struct {
+	bucket       string
+	targetBucket string
+	targetPrefix string
+}
+
+// bucketAccessLog is an audit target converting the audit entries of
+// buckets with server access logging enabled to the S3 server access
+// log format and periodically delivering them as objects to the
+// configured target bucket.
+type bucketAccessLog struct {
+	objAPI ObjectLayer
+
+	mu      sync.Mutex
+	pending map[accessLogKey]*bytes.Buffer
+
+	flushCh chan struct{}
+	doneCh  chan struct{}
+	once    sync.Once
+}
+
+// initBucketAccessLog registers the server access logging audit target.
+func initBucketAccessLog(objAPI ObjectLayer) error {
+	return logger.AddAuditTarget(&bucketAccessLog{
+		objAPI:  objAPI,
+		pending: make(map[accessLogKey]*bytes.Buffer),
+		flushCh: make(chan struct{}, 1),
+		doneCh:  make(chan struct{}),
+	})
+}
+
+// String returns the name of the target.
+func (l *bucketAccessLog) String() string {
+	return "bucket-access-log"
+}
+
+// Endpoint returns the endpoint of the target.
+func (l *bucketAccessLog) Endpoint() string {
+	return ""
+}
+
+// Type returns the type of the target.
+func (l *bucketAccessLog) Type() types.TargetType {
+	return types.TargetAccessLog
+}
+
+// Init starts the delivery of the access logs.
+func (l *bucketAccessLog) Init() error {
+	go l.run()
+	return nil
+}
+
+// Cancel stops the delivery of the access logs, pending
+// logs are delivered before returning.
+func (l *bucketAccessLog) Cancel() {
+	l.once.Do(func() {
+		close(l.doneCh)
+	})
+	l.flush(GlobalContext)
+}
+
+// Send records the audit entry if access logging is enabled
+// for its bucket, entries of other buckets are ignored.
+func (l *bucketAccessLog) Send(e interface{}) error {
+	entry, ok := e.(audit.Entry)
+	if !ok || entry.API.Bucket == "" {
+		return nil
+	}
+	config, _, err := globalBucketMetadataSys.GetLoggingConfig(entry.API.Bucket)
+	if err != nil || !config.Enabled() {
+		return nil
+	}
+
+	key := accessLogKey{
+		bucket:       entry.API.Bucket,
+		targetBucket: config.LoggingEnabled.TargetBucket,
+		targetPrefix: config.LoggingEnabled.TargetPrefix,
+	}
+	line := accessLogRecord(entry)
+
+	l.mu.Lock()
+	buf, ok := l.pending[key]
+	if !ok {
+		buf = &bytes.Buffer{}
+		l.pending[key] = buf
+	}
+	buf.WriteString(line)
+	buf.WriteByte('\n')
+	full := buf.Len() >= accessLogMaxSize
+	l.mu.Unlock()
+
+	if full {
+		select {
+		case l.flushCh <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+func (l *bucketAccessLog) run() {
+	ticker := time.NewTicker(accessLogFlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-l.doneCh:
+			return
+		case <-ticker.C:
+		case <-l.flushCh:
+		}
+		l.flush(GlobalContext)
+	}
+}
+
+// flush delivers all pending access logs to their target buckets.
+func (l *bucketAccessLog) flush(ctx context.Context) {
+	l.mu.Lock()
+	pending := l.pending
+	l.pending = make(map[accessLogKey]*bytes.Buffer, len(pending))
+	l.mu.Unlock()
+
+	now := UTCNow()
+	for key, buf := range pending {
+		object := accessLogObjectName(key.targetPrefix, now)
+		if err := l.deliver(ctx, key.targetBucket, object, buf.Bytes()); err != nil {
+			logger.LogIf(ctx, fmt.Errorf("unable to deliver access log of bucket %s to %s/%s: %w",
+				key.bucket, key.targetBucket, object, err))
+		}
+	}
+}
+
+func (l *bucketAccessLog) deliver(ctx context.Context, bucket, object string, data []byte) error {
+	hashReader, err := hash.NewReader(bytes.NewReader(data), int64(len(data)), "", "", int64(len(data)))
+	if err != nil {
+		return err
+	}
+	_, err = l.objAPI.PutObject(ctx, bucket, object, NewPutObjReader(hashReader), ObjectOptions{
+		UserDefined: map[string]string{xhttp.ContentType: "text/plain"},
+	})
+	return err
+}
+
+// accessLogObjectName returns the name of a delivered log object
+// in the format TargetPrefixYYYY-mm-DD-HH-MM-SS-UniqueString.
+func accessLogObjectName(prefix string, t time.Time) string {
+	unique := strings.ToUpper(strings.ReplaceAll(mustGetUUID(), "-", ""))[:16]
+	return prefix + t.Format(accessLogObjectTimeFormat) + "-" + unique
+}
+
+// accessLogRecord converts an audit entry to a record in the S3
+// server access log format.
+func accessLogRecord(e audit.Entry) string {
+	dash := func(s string) string {
+		if s == "" {
+			return "-"
+		}
+		return s
+	}
+	quote := func(s string) string {
+		if s == "" {
+			return "-"
+		}
+		return strconv.Quote(s)
+	}
+	millis := func(d string) string {
+		ns, err := strconv.ParseInt(strings.TrimSuffix(d, "ns"), 10, 64)
+		if err != nil {
+			return "-"
+		}
+		return strconv.FormatInt(time.Duration(ns).Milliseconds(), 10)
+	}
+
+	resource := "BUCKET"
+	if e.API.Object != "" {
+		resource = "OBJECT"
+	}
+	operation := "REST." + e.ReqMethod + "." + resource
+
+	var key string
+	if e.API.Object != "" {
+		key = url.PathEscape(e.API.Object)
+	}
+
+	bytesSent := "-"
+	if e.API.OutputBytes > 0 {
+		bytesSent = strconv.FormatInt(e.API.OutputBytes, 10)
+	}
+
+	sigVersion, authType := "-", "-"
+	switch auth := e.ReqHeader[xhttp.Authorization]; {
+	case strings.HasPrefix(auth, signV4Algorithm):
+		sigVersion, authType = "SigV4", "AuthHeader"
+	case strings.HasPrefix(auth, signV4AAlgorithm):
+		sigVersion, authType = "SigV4A", "AuthHeader"
+	case strings.HasPrefix(auth, signV2Algorithm):
+		sigVersion, authType = "SigV2", "AuthHeader"
+	case e.ReqQuery[xhttp.AmzAlgorithm] == signV4Algorithm:
+		sigVersion, authType = "SigV4", "QueryString"
+	case e.ReqQuery[xhttp.AmzAlgorithm] == signV4AAlgorithm:
+		sigVersion, authType = "SigV4A", "QueryString"
+	case e.ReqQuery[xhttp.AmzAccessKeyID] != "":
+		sigVersion, authType = "SigV2", "QueryString"
+	}
+
+	return strings.Join([]string{
+		globalMinioDefaultOwnerID,
+		e.API.Bucket,
+		e.Time.UTC().Format(accessLogTimeFormat),
+		dash(e.RemoteHost),
+		dash(e.AccessKey),
+		dash(e.RequestID),
+		operation,
+		dash(key),
+		strconv.Quote(e.ReqMethod + " " + accessLogRequestURI(e) + " HTTP/1.1"),
+		strconv.Itoa(e.API.StatusCode),
+		"-", // error code
+		bytesSent,
+		"-", // object size
+		millis(e.API.TimeToResponse),
+		millis(e.API.TimeToFirstByte),
+		quote(e.ReqHeader["Referer"]),
+		quote(e.UserAgent),
+		dash(e.ReqQuery[xhttp.VersionID]),
+		"-", // host id
+		sigVersion,
+		"-", // cipher suite
+		authType,
+		dash(e.ReqHost),
+		"-", // TLS version
+	}, " ")
+}
+
+// accessLogRequestURI returns the request URI of the entry,
+// request signatures are left out of the logged query.
+func accessLogRequestURI(e audit.Entry) string {
+	keys := make([]string, 0, len(e.ReqQuery))
+	for k := range e.ReqQuery {
+		if k == xhttp.AmzSignature || k == "Signature" {
+			continue
+		}
+		keys = append(keys, k)
+	}
+	if len(keys) == 0 {
+		return e.ReqPath
+	}
+	sort.Strings(keys)
+	query := make(url.Values, len(keys))
+	for _, k := range keys {
+		query.Set(k, e.ReqQuery[k])
+	}
+	return e.ReqPath + "?" + query.Encode()
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/qkbyte/minio/internal/bucket/logging"
+	"github.com/qkbyte/minio/internal/logger/message/audit"
+)
+
+func TestAccessLogRecord(t *testing.T) {
+	entry := audit.NewEntry("")
+	entry.Time = time.Date(2022, time.March, 4, 5, 6, 7, 0, time.UTC)
+	entry.API.Bucket = "bucket"
+	entry.API.Object = "dir/my object"
+	entry.API.StatusCode = 200
+	entry.API.OutputBytes = 1024
+	entry.API.TimeToResponse = "25000000ns"
+	entry.RemoteHost = "10.0.0.1"
+	entry.AccessKey = "minio"
+	entry.RequestID = "16B8A7F4D2A0C1E3"
+	entry.UserAgent = "aws-cli/2.0"
+	entry.ReqMethod = "GET"
+	entry.ReqPath = "/bucket/dir/my%20object"
+	entry.ReqHost = "localhost:9000"
+	entry.ReqHeader = map[string]string{"Authorization": "AWS4-HMAC-SHA256 Credential=minio/..."}
+	entry.ReqQuery = map[string]string{"versionId": "v1"}
+
+	expected := globalMinioDefaultOwnerID + ` bucket [04/Mar/2022:05:06:07 +0000] 10.0.0.1 minio 16B8A7F4D2A0C1E3 REST.GET.OBJECT dir%2Fmy%20object "GET /bucket/dir/my%20object?versionId=v1 HTTP/1.1" 200 - 1024 - 25 - - "aws-cli/2.0" v1 - SigV4 - AuthHeader localhost:9000 -`
+	if got := accessLogRecord(entry); got != expected {
+		t.Fatalf("expected\n%s\ngot\n%s", expected, got)
+	}
+
+	// Presigned request signatures are not logged.
+	entry.ReqHeader = nil
+	entry.ReqQuery = map[string]string{"X-Amz-Algorithm": signV4Algorithm, "X-Amz-Signature": "secret"}
+	record := accessLogRecord(entry)
+	if strings.Contains(record, "secret") {
+		t.Fatalf("signature must not be logged: %s", record)
+	}
+	if !strings.Contains(record, " SigV4 - QueryString ") {
+		t.Fatalf("expected query string authentication: %s", record)
+	}
+}
+
+func TestBucketAccessLogSend(t *testing.T) {
+	defer func(sys *BucketMetadataSys) { globalBucketMetadataSys = sys }(globalBucketMetadataSys)
+	globalBucketMetadataSys = NewBucketMetadataSys()
+	meta := newBucketMetadata("bucket")
+	meta.loggingConfig = &logging.Config{LoggingEnabled: &logging.LoggingEnabled{
+		TargetBucket: "logs",
+		TargetPrefix: "access/",
+	}}
+	globalBucketMetadataSys.Set("bucket", meta)
+	globalBucketMetadataSys.Set("other", newBucketMetadata("other"))
+
+	l := &bucketAccessLog{
+		pending: make(map[accessLogKey]*bytes.Buffer),
+		flushCh: make(chan struct{}, 1),
+	}
+	for _, bucket := range []string{"bucket", "other", "bucket", ""} {
+		entry := audit.NewEntry("")
+		entry.API.Bucket = bucket
+		entry.ReqMethod = "GET"
+		if err := l.Send(entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(l.pending) != 1 {
+		t.Fatalf("expected logs for a single bucket, got %d", len(l.pending))
+	}
+	buf, ok := l.pending[accessLogKey{bucket: "bucket", targetBucket: "logs", targetPrefix: "access/"}]
+	if !ok {
+		t.Fatal("expected pending logs for bucket")
+	}
+	if n := strings.Count(buf.String(), "\n"); n != 2 {
+		t.Fatalf("expected 2 records, got %d", n)
+	}
+
+	name := accessLogObjectName("access/", time.Date(2022, time.March, 4, 5, 6, 7, 0, time.UTC))
+	if !strings.HasPrefix(name, "access/2022-03-04-05-06-07-") || len(name) != len("access/2022-03-04-05-06-07-")+16 {
+		t.Fatalf("unexpected log object name %s", name)
+	}
+}
@@ -0,0 +1,773 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/qkbyte/minio/internal/config"
+	"github.com/qkbyte/minio/internal/event"
+	"github.com/qkbyte/minio/internal/kms"
+	"github.com/qkbyte/minio/internal/logger"
+	"github.com/zeebo/xxh3"
+)
+
+//go:generate msgp -file $GOFILE -unexported
+
+//msgp:ignore bucketAuditManifestConfig auditManifestSegmentInfo auditManifestNodeStatus auditManifestPage auditManifestRequest auditManifestChain bucketAuditManifest auditManifestKey auditManifestKeyring auditManifestKeys
+
+const (
+	auditManifestPrefix = "audit-manifest"
+
+	auditManifestQueueSize     = 10000
+	auditManifestSegmentSize   = 10000
+	auditManifestCheckInterval = time.Second
+
+	// Records kept in memory while segments cannot be sealed, object
+	// changes wait for the queue beyond.
+	auditManifestMaxPending = 100000
+
+	// auditManifestGapEvent marks where records of a node may be missing,
+	// such as records not sealed before the node was restarted.
+	auditManifestGapEvent = "minio:AuditManifest:Gap"
+
+	auditManifestDefaultSealInterval = 5 * time.Minute
+	auditManifestMinSealInterval     = 10 * time.Second
+
+	// Maximum number of segments returned by a single read.
+	auditManifestMaxSegments = 100
+
+	// Maximum time an object change waits for room in the queue, the
+	// change is recorded as a gap beyond.
+	auditManifestEnqueueTimeout = 5 * time.Second
+
+	// Nodes pick up keys rotated by other nodes within this long.
+	auditManifestKeyringTTL = time.Minute
+)
+
+// auditManifestKeyringFile - the keys sealing the audit manifest
+// segments of all buckets.
+var auditManifestKeyringFile = pathJoin(minioConfigPrefix, auditManifestPrefix, "keyring.json")
+
+// bucketAuditManifestConfig - records object creations and deletions of
+// a bucket in an append-only manifest. Records are sealed into a hash
+// chained segment every SealInterval, segments are never removed.
+type bucketAuditManifestConfig struct {
+	Enabled      bool   `json:"enabled"`
+	SealInterval string `json:"sealInterval,omitempty"`
+
+	sealInterval time.Duration
+}
+
+// parseBucketAuditManifestConfig parses the audit manifest settings of
+// a bucket, nil is returned when the manifest is disabled.
+func parseBucketAuditManifestConfig(data []byte) (*bucketAuditManifestConfig, error) {
+	cfg := &bucketAuditManifestConfig{}
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, err
+	}
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	cfg.sealInterval = auditManifestDefaultSealInterval
+	if cfg.SealInterval != "" {
+		d, err := time.ParseDuration(cfg.SealInterval)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid audit manifest seal interval '%s': %w", cfg.SealInterval, err)
+		}
+		if d < auditManifestMinSealInterval {
+			return nil, fmt.Errorf("Audit manifest seal interval must be at least %s", auditManifestMinSealInterval)
+		}
+		cfg.sealInterval = d
+	}
+	return cfg, nil
+}
+
+// auditManifestRecord - an object creation or deletion in the audit
+// manifest. Sequence numbers increase per node without gaps.
+type auditManifestRecord struct {
+	Seq          uint64            `json:"seq" msg:"s"`
+	Time         time.Time         `json:"time" msg:"t"`
+	Event        string            `json:"event" msg:"e"`
+	Key          string            `json:"key" msg:"k"`
+	VersionID    string            `json:"versionId,omitempty" msg:"v,omitempty"`
+	DeleteMarker bool              `json:"deleteMarker,omitempty" msg:"dm,omitempty"`
+	Size         int64             `json:"size,omitempty" msg:"sz,omitempty"`
+	ETag         string            `json:"etag,omitempty" msg:"et,omitempty"`
+	Checksums    map[string]string `json:"checksums,omitempty" msg:"cs,omitempty"`
+	Principal    string            `json:"principal,omitempty" msg:"p,omitempty"`
+	SourceIP     string            `json:"sourceIP,omitempty" msg:"ip,omitempty"`
+}
+
+// auditManifestSegment - records of one node sealed together. Hash is
+// the HMAC-SHA256 of the segment with an empty Hash under the key KeyID,
+// it covers the hash of the previous segment which chains all segments
+// of a node.
+type auditManifestSegment struct {
+	Node     string                `json:"node" msg:"n"`
+	Index    uint64                `json:"index" msg:"i"`
+	Sealed   time.Time             `json:"sealed" msg:"st"`
+	PrevHash string                `json:"prevHash,omitempty" msg:"ph"`
+	Records  []auditManifestRecord `json:"records" msg:"r"`
+	KeyID    string                `json:"keyId" msg:"kid"`
+	Hash     string                `json:"hash" msg:"h"`
+}
+
+// digest returns the hash sealing the segment with key.
+func (s auditManifestSegment) digest(key []byte) (string, error) {
+	s.Hash = ""
+	data, err := s.MarshalMsg(nil)
+	if err != nil {
+		return "", err
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+// auditManifestKey - a key sealing audit manifest segments.
+type auditManifestKey struct {
+	ID      string    `json:"id"`
+	Key     []byte    `json:"key"`
+	Created time.Time `json:"created"`
+}
+
+// auditManifestKeyring - the keys sealing audit manifest segments, kept
+// encrypted by the KMS when one is configured. New segments are sealed
+// with the newest key, rotated keys are kept to verify the segments
+// they sealed.
+type auditManifestKeyring struct {
+	Keys []auditManifestKey `json:"keys"`
+}
+
+func (kr *auditManifestKeyring) lookup(id string) (auditManifestKey, bool) {
+	for _, k := range kr.Keys {
+		if k.ID == id {
+			return k, true
+		}
+	}
+	return auditManifestKey{}, false
+}
+
+func loadAuditManifestKeyring(ctx context.Context, objAPI ObjectLayer) (*auditManifestKeyring, error) {
+	kr := &auditManifestKeyring{}
+	data, err := readConfig(ctx, objAPI, auditManifestKeyringFile)
+	if err != nil {
+		if err == errConfigNotFound {
+			return kr, nil
+		}
+		return nil, err
+	}
+	if GlobalKMS != nil {
+		data, err = config.DecryptBytes(GlobalKMS, data, kms.Context{
+			minioMetaBucket: path.Join(minioMetaBucket, auditManifestKeyringFile),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err = json.Unmarshal(data, kr); err != nil {
+		return nil, err
+	}
+	return kr, nil
+}
+
+// addAuditManifestKey adds a new key to the keyring, unless rotate is
+// false and the keyring already holds a key. The keyring is locked
+// such that nodes adding the first key at once agree on it.
+func addAuditManifestKey(ctx context.Context, objAPI ObjectLayer, rotate bool) (*auditManifestKeyring, error) {
+	lk := objAPI.NewNSLock(minioMetaBucket, pathJoin(minioConfigPrefix, auditManifestPrefix, "keyring.lock"))
+	lkctx, err := lk.GetLock(ctx, globalOperationTimeout)
+	if err != nil {
+		return nil, err
+	}
+	ctx = lkctx.Context()
+	defer lk.Unlock(lkctx.Cancel)
+
+	kr, err := loadAuditManifestKeyring(ctx, objAPI)
+	if err != nil {
+		return nil, err
+	}
+	if !rotate && len(kr.Keys) > 0 {
+		return kr, nil
+	}
+	key := make([]byte, 32)
+	if _, err = rand.Read(key); err != nil {
+		return nil, err
+	}
+	kr.Keys = append(kr.Keys, auditManifestKey{
+		ID:      mustGetUUID(),
+		Key:     key,
+		Created: UTCNow(),
+	})
+	data, err := json.Marshal(kr)
+	if err != nil {
+		return nil, err
+	}
+	if GlobalKMS != nil {
+		data, err = config.EncryptBytes(GlobalKMS, data, kms.Context{
+			minioMetaBucket: path.Join(minioMetaBucket, auditManifestKeyringFile),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err = saveConfig(ctx, objAPI, auditManifestKeyringFile, data); err != nil {
+		return nil, err
+	}
+	return kr, nil
+}
+
+// auditManifestKeys caches the keyring of this node.
+type auditManifestKeys struct {
+	mu      sync.Mutex
+	keyring *auditManifestKeyring
+	loaded  time.Time
+}
+
+var globalAuditManifestKeys = &auditManifestKeys{}
+
+func (k *auditManifestKeys) set(kr *auditManifestKeyring) {
+	k.mu.Lock()
+	k.keyring, k.loaded = kr, time.Now()
+	k.mu.Unlock()
+}
+
+func (k *auditManifestKeys) get(ctx context.Context, objAPI ObjectLayer, reload bool) (*auditManifestKeyring, error) {
+	k.mu.Lock()
+	kr, loaded := k.keyring, k.loaded
+	k.mu.Unlock()
+	if kr != nil && !reload && time.Since(loaded) < auditManifestKeyringTTL {
+		return kr, nil
+	}
+	kr, err := loadAuditManifestKeyring(ctx, objAPI)
+	if err != nil {
+		return nil, err
+	}
+	k.set(kr)
+	return kr, nil
+}
+
+// sealing returns the key new segments are sealed with, the first key
+// is created when the keyring is empty.
+func (k *auditManifestKeys) sealing(ctx context.Context, objAPI ObjectLayer) (auditManifestKey, error) {
+	kr, err := k.get(ctx, objAPI, false)
+	if err != nil {
+		return auditManifestKey{}, err
+	}
+	if len(kr.Keys) == 0 {
+		if kr, err = addAuditManifestKey(ctx, objAPI, false); err != nil {
+			return auditManifestKey{}, err
+		}
+		k.set(kr)
+	}
+	return kr.Keys[len(kr.Keys)-1], nil
+}
+
+// lookup returns the key id, the keyring is reloaded for keys added
+// by other nodes.
+func (k *auditManifestKeys) lookup(ctx context.Context, objAPI ObjectLayer, id string) (auditManifestKey, bool, error) {
+	kr, err := k.get(ctx, objAPI, false)
+	if err != nil {
+		return auditManifestKey{}, false, err
+	}
+	if key, ok := kr.lookup(id); ok {
+		return key, true, nil
+	}
+	if kr, err = k.get(ctx, objAPI, true); err != nil {
+		return auditManifestKey{}, false, err
+	}
+	key, ok := kr.lookup(id)
+	return key, ok, nil
+}
+
+// rotateAuditManifestKey adds a new key new segments are sealed with,
+// the ID of the new key is returned.
+func rotateAuditManifestKey(ctx context.Context, objAPI ObjectLayer) (string, error) {
+	kr, err := addAuditManifestKey(ctx, objAPI, true)
+	if err != nil {
+		return "", err
+	}
+	globalAuditManifestKeys.set(kr)
+	return kr.Keys[len(kr.Keys)-1].ID, nil
+}
+
+// newAuditManifestRecord returns the record of the change described by
+// args, false is returned for events that do not create or delete objects.
+func newAuditManifestRecord(args eventArgs) (auditManifestRecord, bool) {
+	switch args.EventName {
+	case event.ObjectCreatedPut, event.ObjectCreatedPost, event.ObjectCreatedCopy,
+		event.ObjectCreatedCompleteMultipartUpload,
+		event.ObjectRemovedDelete, event.ObjectRemovedDeleteMarkerCreated:
+	default:
+		return auditManifestRecord{}, false
+	}
+	oi := args.Object
+	rec := auditManifestRecord{
+		Time:         UTCNow(),
+		Event:        args.EventName.String(),
+		Key:          oi.Name,
+		VersionID:    oi.VersionID,
+		DeleteMarker: oi.DeleteMarker,
+		Principal:    args.ReqParams["principalId"],
+		SourceIP:     args.ReqParams["sourceIPAddress"],
+	}
+	if args.EventName.Mask()&event.ObjectCreatedAll.Mask() != 0 {
+		rec.Size, _ = oi.GetActualSize()
+		rec.ETag = oi.ETag
+		rec.Checksums = oi.decryptChecksums()
+	}
+	return rec, true
+}
+
+// auditManifestDir returns the directory of the segments of bucket, it
+// is kept outside of the bucket metadata which is removed along with
+// the bucket.
+func auditManifestDir(bucket string) string {
+	return pathJoin(auditManifestPrefix, bucket)
+}
+
+// auditManifestNode returns the name of the directory the segments
+// sealed by this node are kept in.
+func auditManifestNode() string {
+	return fmt.Sprintf("%016x", xxh3.HashString(globalLocalNodeName))
+}
+
+// auditManifestSegmentFile returns the object of a segment, names sort
+// by segment index.
+func auditManifestSegmentFile(bucket, node string, index uint64) string {
+	return pathJoin(auditManifestDir(bucket), node, fmt.Sprintf("%020d.bin", index))
+}
+
+// auditManifestSegmentInfo - a segment object as listed.
+type auditManifestSegmentInfo struct {
+	file  string
+	node  string
+	index uint64
+}
+
+// listAuditManifestSegments lists the segments of bucket per node, each
+// in index order.
+func listAuditManifestSegments(ctx context.Context, objAPI ObjectLayer, bucket string) (map[string][]auditManifestSegmentInfo, error) {
+	segments := make(map[string][]auditManifestSegmentInfo)
+	prefix := auditManifestDir(bucket) + SlashSeparator
+	marker := ""
+	for {
+		res, err := objAPI.ListObjects(ctx, minioMetaBucket, prefix, marker, "", maxObjectList)
+		if err != nil {
+			return nil, err
+		}
+		for _, obj := range res.Objects {
+			node, name := path.Split(strings.TrimPrefix(obj.Name, prefix))
+			node = strings.TrimSuffix(node, SlashSeparator)
+			index, err := strconv.ParseUint(strings.TrimSuffix(name, ".bin"), 10, 64)
+			if err != nil || node == "" || !strings.HasSuffix(name, ".bin") {
+				continue
+			}
+			segments[node] = append(segments[node], auditManifestSegmentInfo{
+				file:  obj.Name,
+				node:  node,
+				index: index,
+			})
+		}
+		if !res.IsTruncated {
+			break
+		}
+		marker = res.NextMarker
+	}
+	for _, s := range segments {
+		sort.Slice(s, func(i, j int) bool { return s[i].index < s[j].index })
+	}
+	return segments, nil
+}
+
+// saveAuditManifestSegment seals seg and writes it.
+func saveAuditManifestSegment(ctx context.Context, objAPI ObjectLayer, bucket string, seg *auditManifestSegment) error {
+	key, err := globalAuditManifestKeys.sealing(ctx, objAPI)
+	if err != nil {
+		return err
+	}
+	seg.KeyID = key.ID
+	if seg.Hash, err = seg.digest(key.Key); err != nil {
+		return err
+	}
+	data, err := seg.MarshalMsg(nil)
+	if err != nil {
+		return err
+	}
+	file := auditManifestSegmentFile(bucket, seg.Node, seg.Index)
+	if GlobalKMS != nil {
+		data, err = config.EncryptBytes(GlobalKMS, data, kms.Context{
+			minioMetaBucket: path.Join(minioMetaBucket, file),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return saveConfig(ctx, objAPI, file, data)
+}
+
+// readAuditManifestSegment reads a segment as stored, it is not verified.
+func readAuditManifestSegment(ctx context.Context, objAPI ObjectLayer, file string) (*auditManifestSegment, error) {
+	data, err := readConfig(ctx, objAPI, file)
+	if err != nil {
+		return nil, err
+	}
+	if GlobalKMS != nil {
+		data, err = config.DecryptBytes(GlobalKMS, data, kms.Context{
+			minioMetaBucket: path.Join(minioMetaBucket, file),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	seg := &auditManifestSegment{}
+	if _, err = seg.UnmarshalMsg(data); err != nil {
+		return nil, err
+	}
+	return seg, nil
+}
+
+// auditManifestNodeStatus - result of verifying the segments of a node.
+// LastHash anchors the chain, it should be kept outside of MinIO to
+// detect the removal of the newest segments.
+type auditManifestNodeStatus struct {
+	Node       string    `json:"node"`
+	Segments   int       `json:"segments"`
+	Records    uint64    `json:"records"`
+	LastSealed time.Time `json:"lastSealed,omitempty"`
+	LastHash   string    `json:"lastHash,omitempty"`
+	Valid      bool      `json:"valid"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// verifyAuditManifest verifies the hash chain of the segments of every
+// node, the first inconsistency of a node is reported.
+func verifyAuditManifest(ctx context.Context, objAPI ObjectLayer, bucket string) ([]auditManifestNodeStatus, error) {
+	segments, err := listAuditManifestSegments(ctx, objAPI, bucket)
+	if err != nil {
+		return nil, err
+	}
+	nodes := make([]string, 0, len(segments))
+	for node := range segments {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+
+	status := make([]auditManifestNodeStatus, 0, len(nodes))
+	for _, node := range nodes {
+		st := auditManifestNodeStatus{Node: node}
+		prevHash := ""
+		nextSeq := uint64(1)
+	segments:
+		for i, s := range segments[node] {
+			if s.index != uint64(i)+1 {
+				st.Error = fmt.Sprintf("segment %d is missing", i+1)
+				break
+			}
+			seg, err := readAuditManifestSegment(ctx, objAPI, s.file)
+			if err != nil {
+				st.Error = fmt.Sprintf("segment %d is unreadable: %v", s.index, err)
+				break
+			}
+			key, ok, err := globalAuditManifestKeys.lookup(ctx, objAPI, seg.KeyID)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				st.Error = fmt.Sprintf("segment %d was sealed with unknown key '%s'", s.index, seg.KeyID)
+				break
+			}
+			digest, err := seg.digest(key.Key)
+			if err != nil {
+				return nil, err
+			}
+			switch {
+			case !hmac.Equal([]byte(digest), []byte(seg.Hash)):
+				st.Error = fmt.Sprintf("segment %d was modified", s.index)
+			case seg.Node != node || seg.Index != s.index:
+				st.Error = fmt.Sprintf("segment %d was moved", s.index)
+			case seg.PrevHash != prevHash:
+				st.Error = fmt.Sprintf("segment %d does not chain to segment %d", s.index, s.index-1)
+			}
+			if st.Error != "" {
+				break
+			}
+			for _, rec := range seg.Records {
+				if rec.Seq != nextSeq {
+					st.Error = fmt.Sprintf("record %d of segment %d is missing", nextSeq, s.index)
+					break segments
+				}
+				nextSeq++
+			}
+			st.Segments++
+			st.Records += uint64(len(seg.Records))
+			st.LastSealed = seg.Sealed
+			st.LastHash = seg.Hash
+			prevHash = seg.Hash
+		}
+		st.Valid = st.Error == ""
+		status = append(status, st)
+	}
+	return status, nil
+}
+
+// auditManifestPage - segments of an audit manifest read at once, the
+// next page is read from NextMarker.
+type auditManifestPage struct {
+	Segments   []*auditManifestSegment `json:"segments"`
+	NextMarker string                  `json:"nextMarker,omitempty"`
+}
+
+// readAuditManifest returns up to maxSegments segments following marker,
+// segments are ordered by node and index.
+func readAuditManifest(ctx context.Context, objAPI ObjectLayer, bucket, marker string, maxSegments int) (auditManifestPage, error) {
+	var page auditManifestPage
+	if maxSegments <= 0 || maxSegments > auditManifestMaxSegments {
+		maxSegments = auditManifestMaxSegments
+	}
+	segments, err := listAuditManifestSegments(ctx, objAPI, bucket)
+	if err != nil {
+		return page, err
+	}
+	var files []string
+	prefix := auditManifestDir(bucket) + SlashSeparator
+	for _, segs := range segments {
+		for _, s := range segs {
+			if name := strings.TrimPrefix(s.file, prefix); name > marker {
+				files = append(files, name)
+			}
+		}
+	}
+	sort.Strings(files)
+	if len(files) > maxSegments {
+		files = files[:maxSegments]
+		page.NextMarker = files[len(files)-1]
+	}
+	for _, name := range files {
+		seg, err := readAuditManifestSegment(ctx, objAPI, prefix+name)
+		if err != nil {
+			return page, err
+		}
+		page.Segments = append(page.Segments, seg)
+	}
+	return page, nil
+}
+
+type auditManifestRequest struct {
+	bucket string
+	rec    auditManifestRecord
+}
+
+// auditManifestChain - the end of the chain of segments of this node.
+type auditManifestChain struct {
+	index uint64
+	hash  string
+	seq   uint64
+}
+
+// loadAuditManifestChain returns the end of the chain of node from its
+// newest segment.
+func loadAuditManifestChain(ctx context.Context, objAPI ObjectLayer, bucket, node string) (auditManifestChain, error) {
+	segments, err := listAuditManifestSegments(ctx, objAPI, bucket)
+	if err != nil {
+		return auditManifestChain{}, err
+	}
+	own := segments[node]
+	if len(own) == 0 {
+		return auditManifestChain{}, nil
+	}
+	seg, err := readAuditManifestSegment(ctx, objAPI, own[len(own)-1].file)
+	if err != nil {
+		return auditManifestChain{}, err
+	}
+	chain := auditManifestChain{index: seg.Index, hash: seg.Hash}
+	if n := len(seg.Records); n > 0 {
+		chain.seq = seg.Records[n-1].Seq
+	}
+	return chain, nil
+}
+
+// bucketAuditManifest records the changes of buckets with an enabled
+// audit manifest, records are sealed into a new segment once per seal
+// interval of the bucket. Object changes wait for the queue for up to
+// auditManifestEnqueueTimeout, changes dropped beyond are recorded as a
+// gap. Records that could not be sealed are retried.
+type bucketAuditManifest struct {
+	once    sync.Once
+	queue   chan auditManifestRequest
+	dropped sync.Map // buckets with dropped changes
+}
+
+var globalAuditManifest = &bucketAuditManifest{}
+
+// record queues the change described by args if the bucket has its
+// audit manifest enabled.
+func (m *bucketAuditManifest) record(args eventArgs) {
+	if globalBucketMetadataSys == nil {
+		return
+	}
+	cfg, _, _ := globalBucketMetadataSys.GetAuditManifestConfig(args.BucketName)
+	if cfg == nil {
+		return
+	}
+	rec, ok := newAuditManifestRecord(args)
+	if !ok {
+		return
+	}
+	m.once.Do(func() {
+		m.queue = make(chan auditManifestRequest, auditManifestQueueSize)
+		go m.run(GlobalContext)
+	})
+	timer := time.NewTimer(auditManifestEnqueueTimeout)
+	defer timer.Stop()
+	select {
+	case m.queue <- auditManifestRequest{bucket: args.BucketName, rec: rec}:
+	case <-timer.C:
+		m.dropped.Store(args.BucketName, true)
+		logger.LogOnceIf(GlobalContext, fmt.Errorf("audit manifest of %s is falling behind, dropped the change of %s", args.BucketName, rec.Key), "audit-manifest-full-"+args.BucketName)
+	case <-GlobalContext.Done():
+		// The node is shutting down, the gap is recorded when the
+		// manifest is resumed.
+	}
+}
+
+func (m *bucketAuditManifest) run(ctx context.Context) {
+	ticker := time.NewTicker(auditManifestCheckInterval)
+	defer ticker.Stop()
+
+	node := auditManifestNode()
+	chains := make(map[string]auditManifestChain)
+	pending := make(map[string][]auditManifestRecord)
+	pendingSince := make(map[string]time.Time)
+	var pendingCount int
+
+	// Records accepted while the manifest was enabled are sealed even if
+	// it was disabled meanwhile. Records are kept until sealed.
+	seal := func(bucket string) {
+		objAPI := newObjectLayerFn()
+		if objAPI == nil {
+			return
+		}
+		chain, ok := chains[bucket]
+		if !ok {
+			var err error
+			if chain, err = loadAuditManifestChain(ctx, objAPI, bucket, node); err != nil {
+				logger.LogOnceIf(ctx, fmt.Errorf("unable to resume the audit manifest of %s: %w", bucket, err), "audit-manifest-"+bucket)
+				return
+			}
+			if chain.index > 0 {
+				// Records not sealed by the previous run of this node,
+				// if any, are lost.
+				pending[bucket] = append([]auditManifestRecord{{
+					Time:  UTCNow(),
+					Event: auditManifestGapEvent,
+				}}, pending[bucket]...)
+				pendingCount++
+			}
+			chains[bucket] = chain
+		}
+		for len(pending[bucket]) > 0 {
+			records := pending[bucket]
+			if len(records) > auditManifestSegmentSize {
+				records = records[:auditManifestSegmentSize]
+			}
+			for i := range records {
+				records[i].Seq = chain.seq + uint64(i) + 1
+			}
+			seg := &auditManifestSegment{
+				Node:     node,
+				Index:    chain.index + 1,
+				Sealed:   UTCNow(),
+				PrevHash: chain.hash,
+				Records:  records,
+			}
+			if err := saveAuditManifestSegment(ctx, objAPI, bucket, seg); err != nil {
+				logger.LogOnceIf(ctx, fmt.Errorf("unable to seal %d audit manifest records of %s: %w", len(records), bucket, err), "audit-manifest-"+bucket)
+				// Retried with the next check.
+				return
+			}
+			chain = auditManifestChain{
+				index: seg.Index,
+				hash:  seg.Hash,
+				seq:   chain.seq + uint64(len(records)),
+			}
+			chains[bucket] = chain
+			pending[bucket] = pending[bucket][len(records):]
+			pendingCount -= len(records)
+		}
+		delete(pending, bucket)
+		delete(pendingSince, bucket)
+	}
+	for {
+		// Stop taking new records while too many cannot be sealed,
+		// object changes wait for the queue meanwhile.
+		queue := m.queue
+		if pendingCount >= auditManifestMaxPending {
+			queue = nil
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case req := <-queue:
+			if len(pending[req.bucket]) == 0 {
+				pendingSince[req.bucket] = time.Now()
+			}
+			pending[req.bucket] = append(pending[req.bucket], req.rec)
+			pendingCount++
+			if len(pending[req.bucket]) >= auditManifestSegmentSize {
+				seal(req.bucket)
+			}
+		case <-ticker.C:
+			m.dropped.Range(func(key, _ interface{}) bool {
+				bucket := key.(string)
+				m.dropped.Delete(bucket)
+				if len(pending[bucket]) == 0 {
+					pendingSince[bucket] = time.Now()
+				}
+				pending[bucket] = append(pending[bucket], auditManifestRecord{
+					Time:  UTCNow(),
+					Event: auditManifestGapEvent,
+				})
+				pendingCount++
+				return true
+			})
+			for bucket := range pending {
+				interval := auditManifestDefaultSealInterval
+				if cfg, _, _ := globalBucketMetadataSys.GetAuditManifestConfig(bucket); cfg != nil {
+					interval = cfg.sealInterval
+				}
+				if time.Since(pendingSince[bucket]) >= interval || pendingCount >= auditManifestMaxPending {
+					seal(bucket)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,223 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseBucketAuditManifestConfig(t *testing.T) {
+	testCases := []struct {
+		data     string
+		enabled  bool
+		interval time.Duration
+		wantErr  bool
+	}{
+		{`{"enabled":false}`, false, 0, false},
+		{`{"enabled":true}`, true, auditManifestDefaultSealInterval, false},
+		{`{"enabled":true,"sealInterval":"1m"}`, true, time.Minute, false},
+		{`{"enabled":true,"sealInterval":"1s"}`, false, 0, true},
+		{`{"enabled":true,"sealInterval":"x"}`, false, 0, true},
+		{`{`, false, 0, true},
+	}
+	for i, tc := range testCases {
+		cfg, err := parseBucketAuditManifestConfig([]byte(tc.data))
+		if (err != nil) != tc.wantErr {
+			t.Fatalf("case %d: unexpected error %v", i, err)
+		}
+		if (cfg != nil) != tc.enabled {
+			t.Fatalf("case %d: expected enabled %v", i, tc.enabled)
+		}
+		if cfg != nil && cfg.sealInterval != tc.interval {
+			t.Fatalf("case %d: expected seal interval %s, got %s", i, tc.interval, cfg.sealInterval)
+		}
+	}
+}
+
+func TestVerifyAuditManifest(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+	setObjectLayer(objLayer)
+	defer setObjectLayer(nil)
+
+	if err = newTestConfig(globalMinioDefaultRegion, objLayer); err != nil {
+		t.Fatal(err)
+	}
+
+	initAllSubsystems()
+
+	const (
+		bucket = "bucket"
+		node   = "0000000000000001"
+	)
+	var (
+		chain auditManifestChain
+		segs  []*auditManifestSegment
+	)
+	for i := 0; i < 3; i++ {
+		seg := &auditManifestSegment{
+			Node:     node,
+			Index:    chain.index + 1,
+			Sealed:   UTCNow(),
+			PrevHash: chain.hash,
+		}
+		for j := 0; j < 2; j++ {
+			chain.seq++
+			seg.Records = append(seg.Records, auditManifestRecord{
+				Seq:   chain.seq,
+				Time:  UTCNow(),
+				Event: "s3:ObjectCreated:Put",
+				Key:   "object",
+				ETag:  "etag",
+			})
+		}
+		if err = saveAuditManifestSegment(ctx, objLayer, bucket, seg); err != nil {
+			t.Fatal(err)
+		}
+		chain = auditManifestChain{index: seg.Index, hash: seg.Hash, seq: chain.seq}
+		segs = append(segs, seg)
+	}
+
+	loaded, err := loadAuditManifestChain(ctx, objLayer, bucket, node)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded != chain {
+		t.Fatalf("expected chain %+v, got %+v", chain, loaded)
+	}
+
+	verify := func(wantErr string) auditManifestNodeStatus {
+		t.Helper()
+		status, err := verifyAuditManifest(ctx, objLayer, bucket)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(status) != 1 {
+			t.Fatalf("expected status of one node, got %d", len(status))
+		}
+		st := status[0]
+		if wantErr == "" && !st.Valid {
+			t.Fatalf("expected a valid manifest, got %s", st.Error)
+		}
+		if !strings.Contains(st.Error, wantErr) {
+			t.Fatalf("expected error %q, got %q", wantErr, st.Error)
+		}
+		return st
+	}
+	st := verify("")
+	if st.Segments != 3 || st.Records != 6 || st.LastHash != chain.hash {
+		t.Fatalf("unexpected status %+v", st)
+	}
+
+	page, err := readAuditManifest(ctx, objLayer, bucket, "", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Segments) != 2 || page.NextMarker == "" {
+		t.Fatalf("unexpected first page %d %q", len(page.Segments), page.NextMarker)
+	}
+	page, err = readAuditManifest(ctx, objLayer, bucket, page.NextMarker, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Segments) != 1 || page.Segments[0].Index != 3 || page.NextMarker != "" {
+		t.Fatalf("unexpected second page %+v", page)
+	}
+
+	// Segments sealed before a key rotation, or a change of the root
+	// credentials, still verify along with those sealed after.
+	savedCred := globalActiveCred
+	globalActiveCred.SecretKey = "other-secret-key"
+	firstKey := segs[0].KeyID
+	keyID, err := rotateAuditManifestKey(ctx, objLayer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	seg := &auditManifestSegment{Node: node, Index: 4, Sealed: UTCNow(), PrevHash: chain.hash}
+	if err = saveAuditManifestSegment(ctx, objLayer, bucket, seg); err != nil {
+		t.Fatal(err)
+	}
+	if seg.KeyID != keyID || keyID == firstKey {
+		t.Fatalf("expected segment 4 sealed with the rotated key %s, got %s", keyID, seg.KeyID)
+	}
+	if st = verify(""); st.Segments != 4 {
+		t.Fatalf("unexpected status %+v", st)
+	}
+	globalActiveCred = savedCred
+	if err = deleteConfig(ctx, objLayer, auditManifestSegmentFile(bucket, node, 4)); err != nil {
+		t.Fatal(err)
+	}
+
+	// Segments sealed with a key not in the keyring do not verify.
+	unknown := *segs[0]
+	unknown.KeyID = "unknown"
+	data, err := unknown.MarshalMsg(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = saveConfig(ctx, objLayer, auditManifestSegmentFile(bucket, node, 1), data); err != nil {
+		t.Fatal(err)
+	}
+	verify("segment 1 was sealed with unknown key")
+	if data, err = segs[0].MarshalMsg(nil); err != nil {
+		t.Fatal(err)
+	}
+	if err = saveConfig(ctx, objLayer, auditManifestSegmentFile(bucket, node, 1), data); err != nil {
+		t.Fatal(err)
+	}
+
+	// The manifest outlives the bucket metadata.
+	if strings.HasPrefix(auditManifestSegmentFile(bucket, node, 1), bucketMetaPrefix) {
+		t.Fatal("expected the manifest to be kept outside of the bucket metadata")
+	}
+
+	// Modify a record keeping the seal.
+	tampered := *segs[1]
+	tampered.Records = append([]auditManifestRecord{}, segs[1].Records...)
+	tampered.Records[0].Key = "other"
+	data, err = tampered.MarshalMsg(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = saveConfig(ctx, objLayer, auditManifestSegmentFile(bucket, node, 2), data); err != nil {
+		t.Fatal(err)
+	}
+	verify("segment 2 was modified")
+
+	// Reseal the modified segment, the chain breaks.
+	if err = saveAuditManifestSegment(ctx, objLayer, bucket, &tampered); err != nil {
+		t.Fatal(err)
+	}
+	verify("segment 3 does not chain to segment 2")
+
+	// Remove a segment.
+	if err = deleteConfig(ctx, objLayer, auditManifestSegmentFile(bucket, node, 1)); err != nil {
+		t.Fatal(err)
+	}
+	verify("segment 1 is missing")
+}
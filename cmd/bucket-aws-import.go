@@ -0,0 +1,674 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	"github.com/minio/madmin-go"
+	"github.com/minio/pkg/bucket/policy"
+	"github.com/qkbyte/minio/internal/bucket/cors"
+	"github.com/qkbyte/minio/internal/bucket/lifecycle"
+	"github.com/qkbyte/minio/internal/event"
+)
+
+// maxAWSBucketConfigSize is the maximum size of an AWS bucket
+// configuration export.
+const maxAWSBucketConfigSize = 1 * humanize.MiByte
+
+// Sections of an AWS bucket configuration export.
+const (
+	awsSectionPolicy       = "Policy"
+	awsSectionLifecycle    = "LifecycleConfiguration"
+	awsSectionCORS         = "CORSConfiguration"
+	awsSectionNotification = "NotificationConfiguration"
+)
+
+// awsEventBridgeEvents are the events delivered to the target standing
+// in for the EventBridge bus of a bucket.
+var awsEventBridgeEvents = []string{
+	"s3:ObjectCreated:*",
+	"s3:ObjectRemoved:*",
+	"s3:ObjectRestore:*",
+	"s3:ObjectTransition:*",
+}
+
+// awsEventAliases maps AWS event names to the MinIO events raised for
+// the same operations.
+var awsEventAliases = map[string][]string{
+	"s3:ObjectTagging:*":      {"s3:ObjectCreated:PutTagging", "s3:ObjectCreated:DeleteTagging"},
+	"s3:ObjectTagging:Put":    {"s3:ObjectCreated:PutTagging"},
+	"s3:ObjectTagging:Delete": {"s3:ObjectCreated:DeleteTagging"},
+	"s3:LifecycleTransition":  {"s3:ObjectTransition:Complete"},
+}
+
+// awsBucketConfig is an AWS export of the configuration of a bucket,
+// each section holds the output of the matching `aws s3api get-bucket-*`
+// command.
+type awsBucketConfig struct {
+	Policy       json.RawMessage        `json:"Policy,omitempty"`
+	Lifecycle    *awsLifecycleConfig    `json:"LifecycleConfiguration,omitempty"`
+	CORS         *awsCORSConfig         `json:"CORSConfiguration,omitempty"`
+	Notification *awsNotificationConfig `json:"NotificationConfiguration,omitempty"`
+}
+
+type awsTag struct {
+	Key   string `json:"Key" xml:"Key"`
+	Value string `json:"Value" xml:"Value"`
+}
+
+type awsLifecycleAnd struct {
+	Prefix                *string  `json:"Prefix,omitempty" xml:"Prefix,omitempty"`
+	Tags                  []awsTag `json:"Tags,omitempty" xml:"Tag,omitempty"`
+	ObjectSizeGreaterThan *int64   `json:"ObjectSizeGreaterThan,omitempty" xml:"-"`
+	ObjectSizeLessThan    *int64   `json:"ObjectSizeLessThan,omitempty" xml:"-"`
+}
+
+type awsLifecycleFilter struct {
+	Prefix                *string          `json:"Prefix,omitempty" xml:"Prefix,omitempty"`
+	Tag                   *awsTag          `json:"Tag,omitempty" xml:"Tag,omitempty"`
+	And                   *awsLifecycleAnd `json:"And,omitempty" xml:"And,omitempty"`
+	ObjectSizeGreaterThan *int64           `json:"ObjectSizeGreaterThan,omitempty" xml:"-"`
+	ObjectSizeLessThan    *int64           `json:"ObjectSizeLessThan,omitempty" xml:"-"`
+}
+
+type awsLifecycleExpiration struct {
+	Date                      string `json:"Date,omitempty" xml:"Date,omitempty"`
+	Days                      int    `json:"Days,omitempty" xml:"Days,omitempty"`
+	ExpiredObjectDeleteMarker bool   `json:"ExpiredObjectDeleteMarker,omitempty" xml:"ExpiredObjectDeleteMarker,omitempty"`
+}
+
+type awsLifecycleTransition struct {
+	Date         string `json:"Date,omitempty" xml:"Date,omitempty"`
+	Days         int    `json:"Days,omitempty" xml:"Days,omitempty"`
+	StorageClass string `json:"StorageClass" xml:"StorageClass"`
+}
+
+type awsNoncurrentExpiration struct {
+	NoncurrentDays          int `json:"NoncurrentDays,omitempty" xml:"NoncurrentDays,omitempty"`
+	NewerNoncurrentVersions int `json:"NewerNoncurrentVersions,omitempty" xml:"NewerNoncurrentVersions,omitempty"`
+}
+
+type awsNoncurrentTransition struct {
+	NoncurrentDays          int    `json:"NoncurrentDays" xml:"NoncurrentDays"`
+	StorageClass            string `json:"StorageClass" xml:"StorageClass"`
+	NewerNoncurrentVersions int    `json:"NewerNoncurrentVersions,omitempty" xml:"-"`
+}
+
+type awsAbortIncompleteUpload struct {
+	DaysAfterInitiation int `json:"DaysAfterInitiation"`
+}
+
+// awsLifecycleRule is a lifecycle rule as exported by AWS, it is
+// marshaled to XML as the MinIO rule it converts to.
+type awsLifecycleRule struct {
+	XMLName                        xml.Name                  `json:"-" xml:"Rule"`
+	ID                             string                    `json:"ID,omitempty" xml:"ID,omitempty"`
+	Status                         string                    `json:"Status" xml:"Status"`
+	Prefix                         *string                   `json:"Prefix,omitempty" xml:"Prefix,omitempty"`
+	Filter                         *awsLifecycleFilter       `json:"Filter,omitempty" xml:"Filter,omitempty"`
+	Expiration                     *awsLifecycleExpiration   `json:"Expiration,omitempty" xml:"Expiration,omitempty"`
+	Transitions                    []awsLifecycleTransition  `json:"Transitions,omitempty" xml:"Transition,omitempty"`
+	NoncurrentVersionExpiration    *awsNoncurrentExpiration  `json:"NoncurrentVersionExpiration,omitempty" xml:"NoncurrentVersionExpiration,omitempty"`
+	NoncurrentVersionTransitions   []awsNoncurrentTransition `json:"NoncurrentVersionTransitions,omitempty" xml:"NoncurrentVersionTransition,omitempty"`
+	AbortIncompleteMultipartUpload *awsAbortIncompleteUpload `json:"AbortIncompleteMultipartUpload,omitempty" xml:"-"`
+}
+
+type awsLifecycleConfig struct {
+	XMLName xml.Name           `json:"-" xml:"LifecycleConfiguration"`
+	Rules   []awsLifecycleRule `json:"Rules" xml:"Rule"`
+}
+
+type awsCORSRule struct {
+	ID             string   `json:"ID,omitempty"`
+	AllowedHeaders []string `json:"AllowedHeaders,omitempty"`
+	AllowedMethods []string `json:"AllowedMethods"`
+	AllowedOrigins []string `json:"AllowedOrigins"`
+	ExposeHeaders  []string `json:"ExposeHeaders,omitempty"`
+	MaxAgeSeconds  int      `json:"MaxAgeSeconds,omitempty"`
+}
+
+type awsCORSConfig struct {
+	CORSRules []awsCORSRule `json:"CORSRules"`
+}
+
+type awsFilterRule struct {
+	Name  string `json:"Name" xml:"Name"`
+	Value string `json:"Value" xml:"Value"`
+}
+
+type awsNotificationFilter struct {
+	Key struct {
+		FilterRules []awsFilterRule `json:"FilterRules"`
+	} `json:"Key"`
+}
+
+// awsNotification is a queue, topic or lambda notification as exported
+// by AWS, only one of the ARNs is set.
+type awsNotification struct {
+	ID                string                 `json:"Id,omitempty"`
+	QueueArn          string                 `json:"QueueArn,omitempty"`
+	TopicArn          string                 `json:"TopicArn,omitempty"`
+	LambdaFunctionArn string                 `json:"LambdaFunctionArn,omitempty"`
+	Events            []string               `json:"Events"`
+	Filter            *awsNotificationFilter `json:"Filter,omitempty"`
+}
+
+type awsNotificationConfig struct {
+	QueueConfigurations          []awsNotification `json:"QueueConfigurations,omitempty"`
+	TopicConfigurations          []awsNotification `json:"TopicConfigurations,omitempty"`
+	LambdaFunctionConfigurations []awsNotification `json:"LambdaFunctionConfigurations,omitempty"`
+	EventBridgeConfiguration     *struct{}         `json:"EventBridgeConfiguration,omitempty"`
+}
+
+// minioQueue is the XML form of a MinIO queue configuration.
+type minioQueue struct {
+	XMLName     xml.Name        `xml:"QueueConfiguration"`
+	ID          string          `xml:"Id,omitempty"`
+	FilterRules []awsFilterRule `xml:"Filter>S3Key>FilterRule,omitempty"`
+	Events      []string        `xml:"Event"`
+	ARN         string          `xml:"Queue"`
+}
+
+type minioNotificationConfig struct {
+	XMLName xml.Name     `xml:"NotificationConfiguration"`
+	Queues  []minioQueue `xml:"QueueConfiguration"`
+}
+
+// awsUnsupportedElement is an element of an AWS export that has no
+// MinIO equivalent and was left out of the import.
+type awsUnsupportedElement struct {
+	Section string `json:"section"`
+	Element string `json:"element"`
+	Reason  string `json:"reason"`
+}
+
+// awsBucketImportReport reports the outcome of an AWS bucket
+// configuration import.
+type awsBucketImportReport struct {
+	Bucket      string                  `json:"bucket"`
+	DryRun      bool                    `json:"dryRun,omitempty"`
+	Imported    []string                `json:"imported,omitempty"`
+	Unsupported []awsUnsupportedElement `json:"unsupported,omitempty"`
+	Errors      map[string]string       `json:"errors,omitempty"`
+}
+
+func (rpt *awsBucketImportReport) unsupported(section, element, format string, args ...interface{}) {
+	rpt.Unsupported = append(rpt.Unsupported, awsUnsupportedElement{
+		Section: section,
+		Element: element,
+		Reason:  fmt.Sprintf(format, args...),
+	})
+}
+
+func (rpt *awsBucketImportReport) setError(section string, err error) {
+	if rpt.Errors == nil {
+		rpt.Errors = make(map[string]string)
+	}
+	rpt.Errors[section] = err.Error()
+}
+
+// awsBucketImport is an AWS bucket configuration converted to MinIO
+// bucket metadata, sections that could not be converted are nil.
+type awsBucketImport struct {
+	policy       *policy.Policy
+	lifecycle    *lifecycle.Lifecycle
+	cors         *cors.Config
+	notification *event.Config
+
+	report awsBucketImportReport
+}
+
+// awsBucketImportOptions carries the server state the conversion
+// validates against.
+type awsBucketImportOptions struct {
+	Region  string
+	Targets *event.TargetList
+	// EventBridgeARN is the MinIO target receiving the events that
+	// AWS sends to the EventBridge bus of the bucket.
+	EventBridgeARN string
+	// ValidTier reports whether a lifecycle storage class names a
+	// configured remote tier.
+	ValidTier func(string) bool
+}
+
+// convertAWSBucketConfig converts an AWS export of the configuration of
+// a bucket to MinIO bucket metadata. Elements without a MinIO
+// equivalent are dropped and listed in the report, a section that
+// fails validation as a whole is reported as an error.
+func convertAWSBucketConfig(bucket string, data []byte, opts awsBucketImportOptions) (*awsBucketImport, error) {
+	var sections map[string]json.RawMessage
+	if err := json.Unmarshal(data, &sections); err != nil {
+		return nil, err
+	}
+	var cfg awsBucketConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, err
+	}
+
+	imp := &awsBucketImport{report: awsBucketImportReport{Bucket: bucket}}
+	keys := make([]string, 0, len(sections))
+	for key := range sections {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		switch key {
+		case awsSectionPolicy, awsSectionLifecycle, awsSectionCORS, awsSectionNotification:
+		default:
+			imp.report.unsupported(key, key, "configuration is not supported by the import")
+		}
+	}
+
+	if len(cfg.Policy) > 0 && string(cfg.Policy) != "null" {
+		imp.convertPolicy(bucket, cfg.Policy)
+	}
+	if cfg.Lifecycle != nil {
+		imp.convertLifecycle(cfg.Lifecycle, opts)
+	}
+	if cfg.CORS != nil {
+		imp.convertCORS(cfg.CORS)
+	}
+	if cfg.Notification != nil {
+		imp.convertNotification(cfg.Notification, opts)
+	}
+	return imp, nil
+}
+
+// convertPolicy keeps the statements of the bucket policy that MinIO
+// can evaluate, a deny statement that cannot be kept fails the policy
+// import as the result would grant more than the source. The policy is either the JSON document or, as printed
+// by `aws s3api get-bucket-policy`, the document encoded as a string.
+func (imp *awsBucketImport) convertPolicy(bucket string, raw json.RawMessage) {
+	var doc string
+	if err := json.Unmarshal(raw, &doc); err == nil {
+		raw = json.RawMessage(doc)
+	}
+	var awsPolicy struct {
+		ID        string          `json:"Id,omitempty"`
+		Version   string          `json:"Version"`
+		Statement json.RawMessage `json:"Statement"`
+	}
+	if err := json.Unmarshal(raw, &awsPolicy); err != nil {
+		imp.report.setError(awsSectionPolicy, err)
+		return
+	}
+	if awsPolicy.Version == "" {
+		imp.report.setError(awsSectionPolicy, fmt.Errorf(ErrMalformedPolicy.String()))
+		return
+	}
+	statements := []json.RawMessage{awsPolicy.Statement}
+	if bytes.HasPrefix(bytes.TrimSpace(awsPolicy.Statement), []byte("[")) {
+		statements = nil
+		if err := json.Unmarshal(awsPolicy.Statement, &statements); err != nil {
+			imp.report.setError(awsSectionPolicy, err)
+			return
+		}
+	}
+
+	p := policy.Policy{ID: policy.ID(awsPolicy.ID), Version: awsPolicy.Version}
+	for i, statement := range statements {
+		element := fmt.Sprintf("Statement[%d]", i)
+		single, err := json.Marshal(map[string]interface{}{
+			"Version":   awsPolicy.Version,
+			"Statement": []json.RawMessage{statement},
+		})
+		if err != nil {
+			imp.report.unsupported(awsSectionPolicy, element, "%v", err)
+			continue
+		}
+		parsed, err := policy.ParseConfig(bytes.NewReader(single), bucket)
+		if err != nil {
+			// Skipping a Deny statement would grant more than the
+			// source policy, refuse to import the policy instead.
+			if isAWSDenyStatement(statement) {
+				imp.report.setError(awsSectionPolicy, fmt.Errorf("%s: deny statement cannot be imported: %w", element, err))
+				return
+			}
+			imp.report.unsupported(awsSectionPolicy, element, "%v", err)
+			continue
+		}
+		for _, st := range parsed.Statements {
+			if st.SID != "" {
+				element = fmt.Sprintf("Statement[%s]", st.SID)
+			}
+			// AWS account, role and service principals do not
+			// exist on MinIO, such statements would never match.
+			if !st.Principal.AWS.Contains("*") {
+				if st.Effect == policy.Deny {
+					imp.report.setError(awsSectionPolicy, fmt.Errorf("%s: deny statement for principal %v cannot be imported, only \"*\" is supported", element, st.Principal.AWS.ToSlice()))
+					return
+				}
+				imp.report.unsupported(awsSectionPolicy, element, "principal %v has no MinIO equivalent, only \"*\" is supported", st.Principal.AWS.ToSlice())
+				continue
+			}
+			p.Statements = append(p.Statements, st)
+		}
+	}
+	if len(p.Statements) == 0 {
+		imp.report.setError(awsSectionPolicy, fmt.Errorf("no statement of the policy could be imported"))
+		return
+	}
+	imp.policy = &p
+}
+
+// isAWSDenyStatement reports whether the raw policy statement denies access.
+func isAWSDenyStatement(statement json.RawMessage) bool {
+	var st struct {
+		Effect string `json:"Effect"`
+	}
+	return json.Unmarshal(statement, &st) == nil && strings.EqualFold(st.Effect, string(policy.Deny))
+}
+
+// convertLifecycle converts the lifecycle rules, MinIO supports a single
+// transition per rule into a configured remote tier.
+func (imp *awsBucketImport) convertLifecycle(cfg *awsLifecycleConfig, opts awsBucketImportOptions) {
+	rules := make([]awsLifecycleRule, 0, len(cfg.Rules))
+	for i, rule := range cfg.Rules {
+		element := fmt.Sprintf("Rule[%d]", i)
+		if rule.ID != "" {
+			element = fmt.Sprintf("Rule[%s]", rule.ID)
+		}
+		if f := rule.Filter; f != nil && (f.ObjectSizeGreaterThan != nil || f.ObjectSizeLessThan != nil ||
+			(f.And != nil && (f.And.ObjectSizeGreaterThan != nil || f.And.ObjectSizeLessThan != nil))) {
+			// Dropping the size condition would widen the rule.
+			imp.report.unsupported(awsSectionLifecycle, element, "object size filters are not supported, rule skipped")
+			continue
+		}
+		if rule.AbortIncompleteMultipartUpload != nil {
+			imp.report.unsupported(awsSectionLifecycle, element+".AbortIncompleteMultipartUpload",
+				"stale multipart uploads are cleaned up by the server")
+			rule.AbortIncompleteMultipartUpload = nil
+		}
+
+		var transitions []awsLifecycleTransition
+		for _, t := range rule.Transitions {
+			switch {
+			case !opts.ValidTier(t.StorageClass):
+				imp.report.unsupported(awsSectionLifecycle, element+".Transition",
+					"storage class %s is not a configured remote tier", t.StorageClass)
+			case len(transitions) > 0:
+				imp.report.unsupported(awsSectionLifecycle, element+".Transition",
+					"only one transition per rule is supported, transition to %s skipped", t.StorageClass)
+			default:
+				transitions = append(transitions, t)
+			}
+		}
+		rule.Transitions = transitions
+
+		var ncTransitions []awsNoncurrentTransition
+		for _, t := range rule.NoncurrentVersionTransitions {
+			switch {
+			case !opts.ValidTier(t.StorageClass):
+				imp.report.unsupported(awsSectionLifecycle, element+".NoncurrentVersionTransition",
+					"storage class %s is not a configured remote tier", t.StorageClass)
+			case len(ncTransitions) > 0:
+				imp.report.unsupported(awsSectionLifecycle, element+".NoncurrentVersionTransition",
+					"only one noncurrent version transition per rule is supported, transition to %s skipped", t.StorageClass)
+			default:
+				if t.NewerNoncurrentVersions > 0 {
+					imp.report.unsupported(awsSectionLifecycle, element+".NoncurrentVersionTransition.NewerNoncurrentVersions",
+						"retaining newer noncurrent versions is only supported by expiration")
+					t.NewerNoncurrentVersions = 0
+				}
+				ncTransitions = append(ncTransitions, t)
+			}
+		}
+		rule.NoncurrentVersionTransitions = ncTransitions
+
+		if rule.Expiration == nil && rule.NoncurrentVersionExpiration == nil &&
+			len(rule.Transitions) == 0 && len(rule.NoncurrentVersionTransitions) == 0 {
+			imp.report.unsupported(awsSectionLifecycle, element, "no supported action left, rule skipped")
+			continue
+		}
+		rules = append(rules, rule)
+	}
+	if len(rules) == 0 {
+		imp.report.setError(awsSectionLifecycle, fmt.Errorf("no rule of the lifecycle configuration could be imported"))
+		return
+	}
+
+	data, err := xml.Marshal(awsLifecycleConfig{Rules: rules})
+	if err != nil {
+		imp.report.setError(awsSectionLifecycle, err)
+		return
+	}
+	lc, err := lifecycle.ParseLifecycleConfig(bytes.NewReader(data))
+	if err != nil {
+		imp.report.setError(awsSectionLifecycle, err)
+		return
+	}
+	if err = lc.Validate(); err != nil {
+		imp.report.setError(awsSectionLifecycle, err)
+		return
+	}
+	imp.lifecycle = lc
+}
+
+// convertCORS converts the CORS rules, which map one to one.
+func (imp *awsBucketImport) convertCORS(cfg *awsCORSConfig) {
+	config := &cors.Config{Rules: make([]cors.Rule, 0, len(cfg.CORSRules))}
+	for _, rule := range cfg.CORSRules {
+		config.Rules = append(config.Rules, cors.Rule(rule))
+	}
+	if err := config.Validate(); err != nil {
+		imp.report.setError(awsSectionCORS, err)
+		return
+	}
+	imp.cors = config
+}
+
+// awsTargetID maps the ARN of an AWS queue, topic or lambda function to
+// the MinIO target with the same ID as the resource name. MinIO ARNs
+// are accepted as they are.
+func awsTargetID(arn string, targets *event.TargetList) (event.TargetID, error) {
+	if strings.HasPrefix(arn, "arn:minio:sqs:") {
+		tokens := strings.Split(arn, ":")
+		if len(tokens) != 6 {
+			return event.TargetID{}, fmt.Errorf("invalid ARN %s", arn)
+		}
+		tid := event.TargetID{ID: tokens[4], Name: tokens[5]}
+		if !targets.Exists(tid) {
+			return event.TargetID{}, fmt.Errorf("no target %s is configured", tid)
+		}
+		return tid, nil
+	}
+
+	name := arn[strings.LastIndex(arn, ":")+1:]
+	var found []event.TargetID
+	for _, tid := range targets.List() {
+		if tid.ID == name {
+			found = append(found, tid)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return event.TargetID{}, fmt.Errorf("no notification target with ID %s is configured", name)
+	case 1:
+		return found[0], nil
+	default:
+		return event.TargetID{}, fmt.Errorf("notification target ID %s is ambiguous", name)
+	}
+}
+
+// convertNotification maps the queue, topic and lambda notifications to
+// MinIO queue configurations delivering to the target of the same name.
+// The EventBridge bus is mapped to the target given in the options.
+func (imp *awsBucketImport) convertNotification(cfg *awsNotificationConfig, opts awsBucketImportOptions) {
+	var notifications []awsNotification
+	notifications = append(notifications, cfg.QueueConfigurations...)
+	notifications = append(notifications, cfg.TopicConfigurations...)
+	notifications = append(notifications, cfg.LambdaFunctionConfigurations...)
+
+	var config minioNotificationConfig
+	for i, n := range notifications {
+		element := fmt.Sprintf("Configuration[%d]", i)
+		if n.ID != "" {
+			element = fmt.Sprintf("Configuration[%s]", n.ID)
+		}
+		arn := n.QueueArn + n.TopicArn + n.LambdaFunctionArn
+		tid, err := awsTargetID(arn, opts.Targets)
+		if err != nil {
+			imp.report.unsupported(awsSectionNotification, element, "%v", err)
+			continue
+		}
+
+		var events []string
+		seen := make(map[string]struct{}, len(n.Events))
+		for _, name := range n.Events {
+			names, ok := awsEventAliases[name]
+			if !ok {
+				names = []string{name}
+			}
+			for _, name := range names {
+				if _, err := event.ParseName(name); err != nil {
+					imp.report.unsupported(awsSectionNotification, element+".Event", "%v", err)
+					continue
+				}
+				if _, ok := seen[name]; !ok {
+					seen[name] = struct{}{}
+					events = append(events, name)
+				}
+			}
+		}
+		if len(events) == 0 {
+			imp.report.unsupported(awsSectionNotification, element, "no supported event left, configuration skipped")
+			continue
+		}
+
+		queue := minioQueue{ID: n.ID, Events: events, ARN: tid.ToARN(opts.Region).String()}
+		if n.Filter != nil {
+			for _, rule := range n.Filter.Key.FilterRules {
+				queue.FilterRules = append(queue.FilterRules, awsFilterRule{
+					Name:  strings.ToLower(rule.Name),
+					Value: rule.Value,
+				})
+			}
+		}
+		config.Queues = append(config.Queues, queue)
+	}
+
+	if cfg.EventBridgeConfiguration != nil {
+		if opts.EventBridgeARN == "" {
+			imp.report.unsupported(awsSectionNotification, "EventBridgeConfiguration",
+				"EventBridge has no MinIO equivalent, name a target to receive its events")
+		} else if tid, err := awsTargetID(opts.EventBridgeARN, opts.Targets); err != nil {
+			imp.report.unsupported(awsSectionNotification, "EventBridgeConfiguration", "%v", err)
+		} else {
+			config.Queues = append(config.Queues, minioQueue{
+				ID:     "EventBridge",
+				Events: awsEventBridgeEvents,
+				ARN:    tid.ToARN(opts.Region).String(),
+			})
+		}
+	}
+	if len(config.Queues) == 0 {
+		imp.report.setError(awsSectionNotification, fmt.Errorf("no notification could be imported"))
+		return
+	}
+
+	data, err := xml.Marshal(config)
+	if err != nil {
+		imp.report.setError(awsSectionNotification, err)
+		return
+	}
+	nConfig, err := event.ParseConfig(bytes.NewReader(data), opts.Region, opts.Targets)
+	if err != nil {
+		imp.report.setError(awsSectionNotification, err)
+		return
+	}
+	imp.notification = nConfig
+}
+
+// apply saves the converted sections as the metadata of the bucket,
+// creating the bucket if it does not exist yet, and returns the
+// sections that were imported.
+func (imp *awsBucketImport) apply(ctx context.Context, objectAPI ObjectLayer, bucket string) (imported []string) {
+	rpt := &imp.report
+	if imp.policy == nil && imp.lifecycle == nil && imp.cors == nil && imp.notification == nil {
+		return nil
+	}
+	if err := objectAPI.MakeBucketWithLocation(ctx, bucket, MakeBucketOptions{}); err != nil {
+		if _, ok := err.(BucketExists); !ok {
+			rpt.setError("Bucket", err)
+			return nil
+		}
+	}
+
+	if imp.policy != nil {
+		if configData, err := json.Marshal(imp.policy); err != nil {
+			rpt.setError(awsSectionPolicy, err)
+		} else if len(configData) > maxBucketPolicySize {
+			rpt.setError(awsSectionPolicy, fmt.Errorf(ErrPolicyTooLarge.String()))
+		} else if updatedAt, err := globalBucketMetadataSys.Update(ctx, bucket, bucketPolicyConfig, configData); err != nil {
+			rpt.setError(awsSectionPolicy, err)
+		} else {
+			imported = append(imported, awsSectionPolicy)
+			// Call site replication hook.
+			if err = globalSiteReplicationSys.BucketMetaHook(ctx, madmin.SRBucketMeta{
+				Type:      madmin.SRBucketMetaTypePolicy,
+				Bucket:    bucket,
+				Policy:    configData,
+				UpdatedAt: updatedAt,
+			}); err != nil {
+				rpt.setError(awsSectionPolicy, err)
+			}
+		}
+	}
+
+	if imp.lifecycle != nil {
+		if err := validateTransitionTier(bucket, imp.lifecycle); err != nil {
+			rpt.setError(awsSectionLifecycle, err)
+		} else if configData, err := xml.Marshal(imp.lifecycle); err != nil {
+			rpt.setError(awsSectionLifecycle, err)
+		} else if _, err = globalBucketMetadataSys.Update(ctx, bucket, bucketLifecycleConfig, configData); err != nil {
+			rpt.setError(awsSectionLifecycle, err)
+		} else {
+			imported = append(imported, awsSectionLifecycle)
+		}
+	}
+
+	if imp.cors != nil {
+		if configData, err := xml.Marshal(imp.cors); err != nil {
+			rpt.setError(awsSectionCORS, err)
+		} else if _, err = globalBucketMetadataSys.Update(ctx, bucket, bucketCorsConfig, configData); err != nil {
+			rpt.setError(awsSectionCORS, err)
+		} else {
+			imported = append(imported, awsSectionCORS)
+		}
+	}
+
+	if imp.notification != nil {
+		if configData, err := xml.Marshal(imp.notification); err != nil {
+			rpt.setError(awsSectionNotification, err)
+		} else if _, err = globalBucketMetadataSys.Update(ctx, bucket, bucketNotificationConfig, configData); err != nil {
+			rpt.setError(awsSectionNotification, err)
+		} else {
+			globalEventNotifier.AddRulesMap(bucket, imp.notification.ToRulesMap())
+			imported = append(imported, awsSectionNotification)
+		}
+	}
+	return imported
+}
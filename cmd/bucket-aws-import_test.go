@@ -0,0 +1,161 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/qkbyte/minio/internal/event"
+)
+
+type awsImportTestTarget struct {
+	id event.TargetID
+}
+
+func (t awsImportTestTarget) ID() event.TargetID      { return t.id }
+func (t awsImportTestTarget) IsActive() (bool, error) { return true, nil }
+func (t awsImportTestTarget) Save(event.Event) error  { return nil }
+func (t awsImportTestTarget) Send(string) error       { return nil }
+func (t awsImportTestTarget) Close() error            { return nil }
+
+const awsBucketConfigExport = `{
+  "Policy": "{\"Version\":\"2012-10-17\",\"Statement\":[{\"Sid\":\"Public\",\"Effect\":\"Allow\",\"Principal\":\"*\",\"Action\":\"s3:GetObject\",\"Resource\":\"arn:aws:s3:::photos/*\"},{\"Sid\":\"Account\",\"Effect\":\"Allow\",\"Principal\":{\"AWS\":\"arn:aws:iam::123456789012:root\"},\"Action\":\"s3:PutObject\",\"Resource\":\"arn:aws:s3:::photos/*\"}]}",
+  "LifecycleConfiguration": {
+    "Rules": [
+      {
+        "ID": "expire-logs",
+        "Filter": {"Prefix": "logs/"},
+        "Status": "Enabled",
+        "Expiration": {"Days": 30},
+        "Transitions": [{"Days": 7, "StorageClass": "WARM"}, {"Days": 14, "StorageClass": "GLACIER"}],
+        "AbortIncompleteMultipartUpload": {"DaysAfterInitiation": 7}
+      },
+      {
+        "ID": "large-objects",
+        "Filter": {"ObjectSizeGreaterThan": 1048576},
+        "Status": "Enabled",
+        "Expiration": {"Days": 10}
+      }
+    ]
+  },
+  "CORSConfiguration": {
+    "CORSRules": [
+      {"AllowedMethods": ["GET", "PUT"], "AllowedOrigins": ["https://*.example.com"], "MaxAgeSeconds": 3000}
+    ]
+  },
+  "NotificationConfiguration": {
+    "QueueConfigurations": [
+      {
+        "Id": "uploads",
+        "QueueArn": "arn:aws:sqs:us-east-1:123456789012:uploads",
+        "Events": ["s3:ObjectCreated:*", "s3:ObjectTagging:Put"],
+        "Filter": {"Key": {"FilterRules": [{"Name": "Prefix", "Value": "images/"}]}}
+      }
+    ],
+    "TopicConfigurations": [
+      {"Id": "deletes", "TopicArn": "arn:aws:sns:us-east-1:123456789012:deletes", "Events": ["s3:ObjectRemoved:*"]}
+    ],
+    "EventBridgeConfiguration": {}
+  },
+  "ReplicationConfiguration": {"Role": "arn:aws:iam::123456789012:role/replication", "Rules": []}
+}`
+
+func TestConvertAWSBucketConfig(t *testing.T) {
+	targets := event.NewTargetList()
+	if err := targets.Add(awsImportTestTarget{event.TargetID{ID: "uploads", Name: "webhook"}}); err != nil {
+		t.Fatal(err)
+	}
+	opts := awsBucketImportOptions{
+		Targets:   targets,
+		ValidTier: func(tier string) bool { return tier == "WARM" },
+	}
+
+	imp, err := convertAWSBucketConfig("photos", []byte(awsBucketConfigExport), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(imp.report.Errors) != 0 {
+		t.Fatalf("unexpected errors %v", imp.report.Errors)
+	}
+
+	if imp.policy == nil || len(imp.policy.Statements) != 1 || imp.policy.Statements[0].SID != "Public" {
+		t.Fatalf("expected only the public statement to be imported, got %v", imp.policy)
+	}
+
+	if imp.lifecycle == nil || len(imp.lifecycle.Rules) != 1 {
+		t.Fatalf("expected one lifecycle rule, got %v", imp.lifecycle)
+	}
+	rule := imp.lifecycle.Rules[0]
+	if rule.ID != "expire-logs" || rule.Transition.StorageClass != "WARM" || rule.Expiration.Days != 30 {
+		t.Fatalf("unexpected lifecycle rule %v", rule)
+	}
+
+	if imp.cors == nil || len(imp.cors.Rules) != 1 || imp.cors.Rules[0].MaxAgeSeconds != 3000 {
+		t.Fatalf("unexpected CORS config %v", imp.cors)
+	}
+
+	if imp.notification == nil || len(imp.notification.QueueList) != 1 {
+		t.Fatalf("expected one queue configuration, got %v", imp.notification)
+	}
+	queue := imp.notification.QueueList[0]
+	if queue.ARN.TargetID.ID != "uploads" || len(queue.Events) != 2 || queue.Filter.RuleList.Pattern() != "images/*" {
+		t.Fatalf("unexpected queue configuration %v", queue)
+	}
+
+	unsupported := make(map[string]bool, len(imp.report.Unsupported))
+	for _, u := range imp.report.Unsupported {
+		unsupported[u.Section+"/"+u.Element] = true
+	}
+	for _, element := range []string{
+		"ReplicationConfiguration/ReplicationConfiguration",
+		"Policy/Statement[Account]",
+		"LifecycleConfiguration/Rule[expire-logs].Transition",
+		"LifecycleConfiguration/Rule[expire-logs].AbortIncompleteMultipartUpload",
+		"LifecycleConfiguration/Rule[large-objects]",
+		"NotificationConfiguration/Configuration[deletes]",
+		"NotificationConfiguration/EventBridgeConfiguration",
+	} {
+		if !unsupported[element] {
+			t.Errorf("expected %s to be reported as unsupported, got %v", element, imp.report.Unsupported)
+		}
+	}
+
+	// EventBridge events are delivered to the named target.
+	opts.EventBridgeARN = "arn:minio:sqs::uploads:webhook"
+	imp, err = convertAWSBucketConfig("photos", []byte(awsBucketConfigExport), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if imp.notification == nil || len(imp.notification.QueueList) != 2 {
+		t.Fatalf("expected the EventBridge configuration to be mapped, got %v", imp.notification)
+	}
+
+	// Dropping a deny statement would widen the policy.
+	denyExport := `{"Policy": {"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"arn:aws:s3:::photos/*"},{"Sid":"DenyAccount","Effect":"Deny","Principal":{"AWS":"arn:aws:iam::123456789012:root"},"Action":"s3:GetObject","Resource":"arn:aws:s3:::photos/*"}]}}`
+	imp, err = convertAWSBucketConfig("photos", []byte(denyExport), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if imp.policy != nil || imp.report.Errors[awsSectionPolicy] == "" {
+		t.Fatalf("expected the policy with an unsupported deny statement to fail, got %v", imp.policy)
+	}
+
+	if _, err = convertAWSBucketConfig("photos", []byte("not json"), opts); err == nil {
+		t.Fatal("expected malformed export to fail")
+	}
+}
@@ -0,0 +1,604 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/qkbyte/minio/internal/config"
+	"github.com/qkbyte/minio/internal/event"
+	"github.com/qkbyte/minio/internal/kms"
+	"github.com/qkbyte/minio/internal/logger"
+	"github.com/zeebo/xxh3"
+)
+
+//go:generate msgp -file $GOFILE -unexported
+
+//msgp:ignore bucketChangesFeedConfig changesSegmentInfo changesSegmentIndex changesFeedCursor changesFeedResult changeRecordRequest bucketChangesFeed
+
+const (
+	changesFeedPrefix = "changes"
+
+	changesFeedQueueSize     = 10000
+	changesFeedSegmentSize   = 1000
+	changesFeedFlushInterval = 5 * time.Second
+	changesFeedPruneInterval = time.Minute
+
+	// Polls reuse the listed segments of a bucket for this long.
+	changesFeedIndexTTL = time.Second
+
+	changesFeedDefaultRetention = 24 * time.Hour
+	changesFeedMinRetention     = time.Minute
+
+	// Maximum number of records returned by a single poll.
+	changesFeedMaxRecords = 1000
+
+	// Maximum time a request waits for room in the queue of changes.
+	changesFeedEnqueueTimeout = 5 * time.Second
+)
+
+// errInvalidChangesCursor - the cursor of a changes feed poll is malformed.
+var errInvalidChangesCursor = errors.New("invalid changes feed cursor")
+
+// bucketChangesFeedConfig - records object creations and deletions of a
+// bucket in a feed that clients poll with a cursor, records are kept for
+// at least Retention.
+type bucketChangesFeedConfig struct {
+	Enabled   bool   `json:"enabled"`
+	Retention string `json:"retention,omitempty"`
+
+	retention time.Duration
+}
+
+// parseBucketChangesFeedConfig parses the changes feed settings of a
+// bucket, nil is returned when the feed is disabled.
+func parseBucketChangesFeedConfig(data []byte) (*bucketChangesFeedConfig, error) {
+	cfg := &bucketChangesFeedConfig{}
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, err
+	}
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	cfg.retention = changesFeedDefaultRetention
+	if cfg.Retention != "" {
+		d, err := time.ParseDuration(cfg.Retention)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid changes feed retention '%s': %w", cfg.Retention, err)
+		}
+		if d < changesFeedMinRetention {
+			return nil, fmt.Errorf("Changes feed retention must be at least %s", changesFeedMinRetention)
+		}
+		cfg.retention = d
+	}
+	return cfg, nil
+}
+
+// changeRecord - an object creation or deletion in the changes feed.
+// Sequence numbers increase per node, Time is stamped along with the
+// sequence number when the record is flushed, Node is set when read back.
+type changeRecord struct {
+	Node         string    `json:"node" msg:"-"`
+	Seq          uint64    `json:"seq" msg:"s"`
+	Time         time.Time `json:"time" msg:"t"`
+	Event        string    `json:"event" msg:"e"`
+	Key          string    `json:"key" msg:"k"`
+	VersionID    string    `json:"versionId,omitempty" msg:"v,omitempty"`
+	DeleteMarker bool      `json:"deleteMarker,omitempty" msg:"dm,omitempty"`
+	Size         int64     `json:"size,omitempty" msg:"sz,omitempty"`
+	ETag         string    `json:"etag,omitempty" msg:"et,omitempty"`
+}
+
+// changeSegment - records of one node flushed together.
+type changeSegment struct {
+	Records []changeRecord `msg:"r"`
+}
+
+// newChangeRecord returns the record of the change described by args,
+// false is returned for events that do not create or delete objects.
+func newChangeRecord(args eventArgs) (changeRecord, bool) {
+	switch args.EventName {
+	case event.ObjectCreatedPut, event.ObjectCreatedPost, event.ObjectCreatedCopy,
+		event.ObjectCreatedCompleteMultipartUpload,
+		event.ObjectRemovedDelete, event.ObjectRemovedDeleteMarkerCreated:
+	default:
+		return changeRecord{}, false
+	}
+	oi := args.Object
+	rec := changeRecord{
+		Event:        args.EventName.String(),
+		Key:          oi.Name,
+		VersionID:    oi.VersionID,
+		DeleteMarker: oi.DeleteMarker,
+	}
+	if args.EventName.Mask()&event.ObjectCreatedAll.Mask() != 0 {
+		rec.Size, _ = oi.GetActualSize()
+		rec.ETag = oi.ETag
+	}
+	return rec, true
+}
+
+// changesFeedNode returns the name of the directory the changes recorded
+// by this node are kept in.
+func changesFeedNode() string {
+	return fmt.Sprintf("%016x", xxh3.HashString(globalLocalNodeName))
+}
+
+func changesFeedDir(bucket string) string {
+	return pathJoin(bucketMetaPrefix, bucket, changesFeedPrefix)
+}
+
+// changesSegmentFile returns the object of a segment, names sort by
+// sequence and carry the sequence range of the records.
+func changesSegmentFile(bucket, node string, first, last uint64) string {
+	return pathJoin(changesFeedDir(bucket), node, fmt.Sprintf("%020d-%020d.bin", first, last))
+}
+
+// changesSegmentInfo - a segment object as listed.
+type changesSegmentInfo struct {
+	file        string
+	node        string
+	first, last uint64
+	modTime     time.Time
+}
+
+// listChangesSegments lists the segments of bucket per node, each in
+// sequence order.
+func listChangesSegments(ctx context.Context, objAPI ObjectLayer, bucket string) (map[string][]changesSegmentInfo, error) {
+	segments := make(map[string][]changesSegmentInfo)
+	prefix := changesFeedDir(bucket) + SlashSeparator
+	marker := ""
+	for {
+		res, err := objAPI.ListObjects(ctx, minioMetaBucket, prefix, marker, "", maxObjectList)
+		if err != nil {
+			return nil, err
+		}
+		for _, obj := range res.Objects {
+			node, name := path.Split(strings.TrimPrefix(obj.Name, prefix))
+			node = strings.TrimSuffix(node, SlashSeparator)
+			first, last, ok := parseChangesSegmentName(name)
+			if !ok || node == "" {
+				continue
+			}
+			segments[node] = append(segments[node], changesSegmentInfo{
+				file:    obj.Name,
+				node:    node,
+				first:   first,
+				last:    last,
+				modTime: obj.ModTime,
+			})
+		}
+		if !res.IsTruncated {
+			break
+		}
+		marker = res.NextMarker
+	}
+	for _, s := range segments {
+		sort.Slice(s, func(i, j int) bool { return s[i].first < s[j].first })
+	}
+	return segments, nil
+}
+
+// changesSegmentIndex caches the listed segments of buckets for polls,
+// it is invalidated when this node changes the segments of a bucket.
+type changesSegmentIndex struct {
+	mu      sync.Mutex
+	buckets map[string]changesSegmentIndexEntry
+}
+
+type changesSegmentIndexEntry struct {
+	segments map[string][]changesSegmentInfo
+	listed   time.Time
+}
+
+var globalChangesSegmentIndex = &changesSegmentIndex{
+	buckets: make(map[string]changesSegmentIndexEntry),
+}
+
+// get returns the segments of bucket, listed again once stale.
+func (idx *changesSegmentIndex) get(ctx context.Context, objAPI ObjectLayer, bucket string) (map[string][]changesSegmentInfo, error) {
+	idx.mu.Lock()
+	e, ok := idx.buckets[bucket]
+	idx.mu.Unlock()
+	if ok && time.Since(e.listed) < changesFeedIndexTTL {
+		return e.segments, nil
+	}
+
+	listed := time.Now()
+	segments, err := listChangesSegments(ctx, objAPI, bucket)
+	if err != nil {
+		return nil, err
+	}
+	idx.mu.Lock()
+	idx.buckets[bucket] = changesSegmentIndexEntry{segments: segments, listed: listed}
+	idx.mu.Unlock()
+	return segments, nil
+}
+
+func (idx *changesSegmentIndex) invalidate(bucket string) {
+	idx.mu.Lock()
+	delete(idx.buckets, bucket)
+	idx.mu.Unlock()
+}
+
+func parseChangesSegmentName(name string) (first, last uint64, ok bool) {
+	name = strings.TrimSuffix(name, ".bin")
+	f, l, ok := strings.Cut(name, "-")
+	if !ok {
+		return 0, 0, false
+	}
+	var err error
+	if first, err = strconv.ParseUint(f, 10, 64); err != nil {
+		return 0, 0, false
+	}
+	if last, err = strconv.ParseUint(l, 10, 64); err != nil {
+		return 0, 0, false
+	}
+	return first, last, first <= last
+}
+
+// saveChangesSegment persists records, already numbered, as a segment.
+func saveChangesSegment(ctx context.Context, objAPI ObjectLayer, bucket, node string, records []changeRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+	seg := changeSegment{Records: records}
+	data, err := seg.MarshalMsg(nil)
+	if err != nil {
+		return err
+	}
+	file := changesSegmentFile(bucket, node, records[0].Seq, records[len(records)-1].Seq)
+	if GlobalKMS != nil {
+		data, err = config.EncryptBytes(GlobalKMS, data, kms.Context{
+			minioMetaBucket: path.Join(minioMetaBucket, file),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	defer globalChangesSegmentIndex.invalidate(bucket)
+	return saveConfig(ctx, objAPI, file, data)
+}
+
+// readChangesSegment reads the records of a segment.
+func readChangesSegment(ctx context.Context, objAPI ObjectLayer, s changesSegmentInfo) ([]changeRecord, error) {
+	data, err := readConfig(ctx, objAPI, s.file)
+	if err != nil {
+		return nil, err
+	}
+	if GlobalKMS != nil {
+		data, err = config.DecryptBytes(GlobalKMS, data, kms.Context{
+			minioMetaBucket: path.Join(minioMetaBucket, s.file),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	var seg changeSegment
+	if _, err = seg.UnmarshalMsg(data); err != nil {
+		return nil, err
+	}
+	for i := range seg.Records {
+		seg.Records[i].Node = s.node
+	}
+	return seg.Records, nil
+}
+
+// pruneChangesSegments removes the segments of node flushed before the
+// retention window. The newest segment is always kept, it carries the
+// sequence the node continues from.
+func pruneChangesSegments(ctx context.Context, objAPI ObjectLayer, bucket, node string, retention time.Duration) error {
+	segments, err := listChangesSegments(ctx, objAPI, bucket)
+	if err != nil {
+		return err
+	}
+	own := segments[node]
+	if len(own) == 0 {
+		return nil
+	}
+	defer globalChangesSegmentIndex.invalidate(bucket)
+	expiry := UTCNow().Add(-retention)
+	for _, s := range own[:len(own)-1] {
+		if !s.modTime.Before(expiry) {
+			break
+		}
+		if err = deleteConfig(ctx, objAPI, s.file); err != nil && err != errConfigNotFound {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteChangesFeed removes all recorded changes of bucket.
+func deleteChangesFeed(ctx context.Context, objAPI ObjectLayer, bucket string) error {
+	defer globalChangesSegmentIndex.invalidate(bucket)
+	if err := deleteConfig(ctx, objAPI, changesFeedDir(bucket)); err != nil && err != errConfigNotFound {
+		return err
+	}
+	return nil
+}
+
+// changesFeedCursor - the last sequence a client has seen per node.
+type changesFeedCursor map[string]uint64
+
+func (c changesFeedCursor) String() string {
+	if len(c) == 0 {
+		return ""
+	}
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func parseChangesFeedCursor(s string) (changesFeedCursor, error) {
+	c := make(changesFeedCursor)
+	if s == "" {
+		return c, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errInvalidChangesCursor
+	}
+	if err = json.Unmarshal(data, &c); err != nil {
+		return nil, errInvalidChangesCursor
+	}
+	return c, nil
+}
+
+// changesFeedResult - a page of the changes feed.
+type changesFeedResult struct {
+	Records     []changeRecord `json:"records"`
+	Cursor      string         `json:"cursor"`
+	IsTruncated bool           `json:"isTruncated"`
+	// Gap is set when records after the cursor were already removed
+	// by retention, the client missed changes.
+	Gap bool `json:"gap,omitempty"`
+}
+
+// readChanges returns up to max records recorded after cursor. The
+// records of each node are returned in sequence order, those of
+// different nodes are merged by time.
+func readChanges(ctx context.Context, objAPI ObjectLayer, bucket string, cursor changesFeedCursor, max int) (changesFeedResult, error) {
+	segments, err := globalChangesSegmentIndex.get(ctx, objAPI, bucket)
+	if err != nil {
+		return changesFeedResult{}, err
+	}
+
+	var result changesFeedResult
+	byNode := make(map[string][]changeRecord, len(segments))
+	for node, own := range segments {
+		after, seen := cursor[node]
+		// Failed flushes leave holes in the sequence between segments,
+		// only records older than the oldest segment were removed.
+		if seen && len(own) > 0 && own[0].first > after+1 {
+			result.Gap = true
+		}
+		var records []changeRecord
+		for _, s := range own {
+			if s.last <= after {
+				continue
+			}
+			if len(records) >= max {
+				// More to read than fits in this page.
+				result.IsTruncated = true
+				break
+			}
+			recs, err := readChangesSegment(ctx, objAPI, s)
+			if err != nil {
+				if err == errConfigNotFound {
+					// Pruned meanwhile.
+					result.Gap = result.Gap || seen
+					continue
+				}
+				return changesFeedResult{}, err
+			}
+			for _, rec := range recs {
+				if rec.Seq > after {
+					records = append(records, rec)
+				}
+			}
+		}
+		if len(records) > 0 {
+			sort.Slice(records, func(i, j int) bool { return records[i].Seq < records[j].Seq })
+			byNode[node] = records
+		}
+	}
+
+	// Take the oldest of the next record of each node until the page is
+	// full, every node advances by a prefix of its sequence and the
+	// cursor never moves past a record that was left out.
+	next := make(changesFeedCursor, len(cursor))
+	for node, seq := range cursor {
+		next[node] = seq
+	}
+	var records []changeRecord
+	for len(byNode) > 0 {
+		if len(records) >= max {
+			result.IsTruncated = true
+			break
+		}
+		var node string
+		for n, recs := range byNode {
+			if node == "" {
+				node = n
+				continue
+			}
+			a, b := recs[0], byNode[node][0]
+			if a.Time.Before(b.Time) || (a.Time.Equal(b.Time) && n < node) {
+				node = n
+			}
+		}
+		rec := byNode[node][0]
+		records = append(records, rec)
+		next[node] = rec.Seq
+		if byNode[node] = byNode[node][1:]; len(byNode[node]) == 0 {
+			delete(byNode, node)
+		}
+	}
+
+	result.Records = records
+	result.Cursor = next.String()
+	return result, nil
+}
+
+type changeRecordRequest struct {
+	bucket string
+	rec    changeRecord
+}
+
+// bucketChangesFeed records the changes of buckets with an enabled
+// changes feed, records are flushed to a new segment every few seconds.
+// Recording a change blocks while too many wait to be flushed, for up to
+// changesFeedEnqueueTimeout after which the change is dropped.
+type bucketChangesFeed struct {
+	once  sync.Once
+	queue chan changeRecordRequest
+}
+
+var globalChangesFeed = &bucketChangesFeed{}
+
+// record queues the change described by args if the bucket has its
+// changes feed enabled.
+func (f *bucketChangesFeed) record(args eventArgs) {
+	if globalBucketMetadataSys == nil {
+		return
+	}
+	cfg, _, _ := globalBucketMetadataSys.GetChangesFeedConfig(args.BucketName)
+	if cfg == nil {
+		return
+	}
+	rec, ok := newChangeRecord(args)
+	if !ok {
+		return
+	}
+	f.once.Do(func() {
+		f.queue = make(chan changeRecordRequest, changesFeedQueueSize)
+		go f.run(GlobalContext)
+	})
+	timer := time.NewTimer(changesFeedEnqueueTimeout)
+	defer timer.Stop()
+	select {
+	case f.queue <- changeRecordRequest{bucket: args.BucketName, rec: rec}:
+	case <-timer.C:
+		logger.LogOnceIf(GlobalContext, fmt.Errorf("changes feed of %s is falling behind, dropped the change of %s", args.BucketName, rec.Key), "changes-feed-full-"+args.BucketName)
+	case <-GlobalContext.Done():
+	}
+}
+
+// nextChangesSeq returns the sequence following the newest segment of node.
+func nextChangesSeq(ctx context.Context, objAPI ObjectLayer, bucket, node string) (uint64, error) {
+	segments, err := listChangesSegments(ctx, objAPI, bucket)
+	if err != nil {
+		return 0, err
+	}
+	own := segments[node]
+	if len(own) == 0 {
+		return 1, nil
+	}
+	return own[len(own)-1].last + 1, nil
+}
+
+func (f *bucketChangesFeed) run(ctx context.Context) {
+	ticker := time.NewTicker(changesFeedFlushInterval)
+	defer ticker.Stop()
+
+	node := changesFeedNode()
+	nextSeq := make(map[string]uint64)
+	lastPrune := make(map[string]time.Time)
+	pending := make(map[string][]changeRecord)
+	failed := make(map[string]bool)
+	queued := 0
+
+	flush := func(bucket string) {
+		objAPI := newObjectLayerFn()
+		if objAPI == nil {
+			return
+		}
+		cfg, _, _ := globalBucketMetadataSys.GetChangesFeedConfig(bucket)
+		if cfg == nil {
+			// Disabled meanwhile.
+			queued -= len(pending[bucket])
+			delete(pending, bucket)
+			delete(failed, bucket)
+			return
+		}
+		records := pending[bucket]
+		seq, ok := nextSeq[bucket]
+		if !ok {
+			var err error
+			if seq, err = nextChangesSeq(ctx, objAPI, bucket, node); err != nil {
+				logger.LogOnceIf(ctx, fmt.Errorf("unable to resume the changes feed of %s: %w", bucket, err), "changes-feed-"+bucket)
+				failed[bucket] = true
+				return
+			}
+		}
+		now := UTCNow()
+		for i := range records {
+			records[i].Seq = seq + uint64(i)
+			records[i].Time = now
+		}
+		// A failed flush may have written the segment partially, its
+		// sequence numbers are never handed out again.
+		nextSeq[bucket] = seq + uint64(len(records))
+		if err := saveChangesSegment(ctx, objAPI, bucket, node, records); err != nil {
+			// Retried with the next flush.
+			logger.LogOnceIf(ctx, fmt.Errorf("unable to record %d changes of %s: %w", len(records), bucket, err), "changes-feed-"+bucket)
+			failed[bucket] = true
+			return
+		}
+		queued -= len(records)
+		delete(pending, bucket)
+		delete(failed, bucket)
+
+		if time.Since(lastPrune[bucket]) >= changesFeedPruneInterval {
+			lastPrune[bucket] = time.Now()
+			logger.LogIf(ctx, pruneChangesSegments(ctx, objAPI, bucket, node, cfg.retention))
+		}
+	}
+	for {
+		// Changes are not received while too many wait to be flushed,
+		// recording them blocks until flushing catches up.
+		queue := f.queue
+		if queued >= changesFeedQueueSize {
+			queue = nil
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case req := <-queue:
+			pending[req.bucket] = append(pending[req.bucket], req.rec)
+			queued++
+			if len(pending[req.bucket]) >= changesFeedSegmentSize && !failed[req.bucket] {
+				flush(req.bucket)
+			}
+		case <-ticker.C:
+			for bucket := range pending {
+				flush(bucket)
+			}
+		}
+	}
+}
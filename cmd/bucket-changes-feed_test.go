@@ -0,0 +1,221 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestParseBucketChangesFeedConfig(t *testing.T) {
+	testCases := []struct {
+		data      string
+		enabled   bool
+		retention time.Duration
+		success   bool
+	}{
+		{`{"enabled":false}`, false, 0, true},
+		{`{"enabled":true}`, true, changesFeedDefaultRetention, true},
+		{`{"enabled":true,"retention":"72h"}`, true, 72 * time.Hour, true},
+		{`{"enabled":true,"retention":"1s"}`, false, 0, false},
+		{`{"enabled":true,"retention":"bogus"}`, false, 0, false},
+		{`{`, false, 0, false},
+	}
+	for i, testCase := range testCases {
+		cfg, err := parseBucketChangesFeedConfig([]byte(testCase.data))
+		if (err == nil) != testCase.success {
+			t.Fatalf("Test %d: expected success %v, got %v", i+1, testCase.success, err)
+		}
+		if (cfg != nil) != testCase.enabled {
+			t.Fatalf("Test %d: expected enabled %v, got %v", i+1, testCase.enabled, cfg != nil)
+		}
+		if cfg != nil && cfg.retention != testCase.retention {
+			t.Fatalf("Test %d: expected retention %s, got %s", i+1, testCase.retention, cfg.retention)
+		}
+	}
+}
+
+func TestChangesFeedCursor(t *testing.T) {
+	c, err := parseChangesFeedCursor("")
+	if err != nil || len(c) != 0 {
+		t.Fatalf("expected an empty cursor, got %v, %v", c, err)
+	}
+	c = changesFeedCursor{"a": 10, "b": 3}
+	parsed, err := parseChangesFeedCursor(c.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed["a"] != 10 || parsed["b"] != 3 {
+		t.Fatalf("cursor does not round trip, got %v", parsed)
+	}
+	if _, err = parseChangesFeedCursor("!!"); err != errInvalidChangesCursor {
+		t.Fatalf("expected %v, got %v", errInvalidChangesCursor, err)
+	}
+}
+
+func TestReadChanges(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+	setObjectLayer(objLayer)
+	defer setObjectLayer(nil)
+
+	if err = newTestConfig(globalMinioDefaultRegion, objLayer); err != nil {
+		t.Fatal(err)
+	}
+
+	initAllSubsystems()
+
+	const bucket = "bucket"
+	start := UTCNow().Add(-time.Hour)
+	newRecords := func(first uint64, n int, offset time.Duration) []changeRecord {
+		recs := make([]changeRecord, n)
+		for i := range recs {
+			recs[i] = changeRecord{
+				Seq:   first + uint64(i),
+				Time:  start.Add(offset + time.Duration(i)*time.Second),
+				Event: "s3:ObjectCreated:Put",
+				Key:   "object",
+			}
+		}
+		return recs
+	}
+	// Node a records changes at even, node b at odd seconds.
+	for _, seg := range []struct {
+		node    string
+		records []changeRecord
+	}{
+		{"a", newRecords(1, 3, 0)},
+		{"a", newRecords(4, 3, 3*time.Second)},
+		{"b", newRecords(1, 2, 500*time.Millisecond)},
+	} {
+		if err = saveChangesSegment(ctx, objLayer, bucket, seg.node, seg.records); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if seq, err := nextChangesSeq(ctx, objLayer, bucket, "a"); err != nil || seq != 7 {
+		t.Fatalf("expected node a to continue at 7, got %d, %v", seq, err)
+	}
+	if seq, err := nextChangesSeq(ctx, objLayer, bucket, "c"); err != nil || seq != 1 {
+		t.Fatalf("expected node c to start at 1, got %d, %v", seq, err)
+	}
+
+	res, err := readChanges(ctx, objLayer, bucket, changesFeedCursor{}, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Records) != 4 || !res.IsTruncated || res.Gap {
+		t.Fatalf("unexpected first page %+v", res)
+	}
+	for i := 1; i < len(res.Records); i++ {
+		if res.Records[i].Time.Before(res.Records[i-1].Time) {
+			t.Fatalf("records are not ordered by time: %+v", res.Records)
+		}
+	}
+
+	cursor, err := parseChangesFeedCursor(res.Cursor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err = readChanges(ctx, objLayer, bucket, cursor, changesFeedMaxRecords)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Records) != 4 || res.IsTruncated {
+		t.Fatalf("unexpected second page %+v", res)
+	}
+	cursor, _ = parseChangesFeedCursor(res.Cursor)
+	if cursor["a"] != 6 || cursor["b"] != 2 {
+		t.Fatalf("unexpected cursor %v", cursor)
+	}
+
+	res, err = readChanges(ctx, objLayer, bucket, cursor, changesFeedMaxRecords)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Records) != 0 || res.Cursor != cursor.String() {
+		t.Fatalf("expected no new records, got %+v", res)
+	}
+
+	// Retention removes all but the newest segment of node a, a client
+	// behind it learns that it missed changes.
+	if err = pruneChangesSegments(ctx, objLayer, bucket, "a", 0); err != nil {
+		t.Fatal(err)
+	}
+	res, err = readChanges(ctx, objLayer, bucket, changesFeedCursor{"a": 1}, changesFeedMaxRecords)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Gap || len(res.Records) != 5 {
+		t.Fatalf("expected a gap and 5 records, got %+v", res)
+	}
+
+	// Sequence numbers burned by a failed flush are not a gap.
+	if err = saveChangesSegment(ctx, objLayer, bucket, "a", newRecords(10, 2, 10*time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	res, err = readChanges(ctx, objLayer, bucket, changesFeedCursor{"a": 6, "b": 2}, changesFeedMaxRecords)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Gap || len(res.Records) != 2 {
+		t.Fatalf("expected 2 records without a gap, got %+v", res)
+	}
+
+	// A page never moves the cursor of a node past a record it left out,
+	// even when the times of the node run against its sequence.
+	skewed := newRecords(1, 3, 20*time.Second)
+	skewed[0].Time, skewed[2].Time = skewed[2].Time, skewed[0].Time
+	if err = saveChangesSegment(ctx, objLayer, bucket, "c", skewed); err != nil {
+		t.Fatal(err)
+	}
+	cursor = changesFeedCursor{"a": 11, "b": 2}
+	var seqs []uint64
+	for i := 0; i < 4; i++ {
+		res, err = readChanges(ctx, objLayer, bucket, cursor, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, rec := range res.Records {
+			seqs = append(seqs, rec.Seq)
+		}
+		cursor, _ = parseChangesFeedCursor(res.Cursor)
+	}
+	if len(seqs) != 3 || seqs[0] != 1 || seqs[1] != 2 || seqs[2] != 3 {
+		t.Fatalf("expected records 1, 2 and 3 of node c, got %v", seqs)
+	}
+
+	if err = deleteChangesFeed(ctx, objLayer, bucket); err != nil {
+		t.Fatal(err)
+	}
+	res, err = readChanges(ctx, objLayer, bucket, changesFeedCursor{}, changesFeedMaxRecords)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Records) != 0 {
+		t.Fatalf("expected the feed to be removed, got %+v", res)
+	}
+}
@@ -0,0 +1,290 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/xml"
+	"net/http"
+	"net/textproto"
+	"strings"
+	"sync"
+	"time"
+
+	sse "github.com/qkbyte/minio/internal/bucket/encryption"
+	objectlock "github.com/qkbyte/minio/internal/bucket/object/lock"
+	"github.com/qkbyte/minio/internal/bucket/replication"
+	"github.com/qkbyte/minio/internal/crypto"
+	xhttp "github.com/qkbyte/minio/internal/http"
+)
+
+const (
+	// Limit number of objects copied in a CopyObjects call.
+	maxCopyObjectsList = 1000
+
+	// Number of objects of a CopyObjects call copied in parallel.
+	copyObjectsConcurrency = 16
+)
+
+// CopyObjectsMetadata - a metadata entry of the copy, used with the
+// REPLACE metadata directive.
+type CopyObjectsMetadata struct {
+	Name  string
+	Value string
+}
+
+// CopyObjectsEntry - an object copied by a CopyObjects call, the source
+// bucket defaults to the bucket of the request.
+type CopyObjectsEntry struct {
+	SourceBucket      string                `xml:"SourceBucket,omitempty"`
+	SourceKey         string                `xml:"SourceKey"`
+	SourceVersionID   string                `xml:"SourceVersionId,omitempty"`
+	Key               string                `xml:"Key"`
+	MetadataDirective string                `xml:"MetadataDirective,omitempty"`
+	Metadata          []CopyObjectsMetadata `xml:"Metadata>Entry,omitempty"`
+}
+
+// CopyObjectsRequest - xml carrying the objects to be copied.
+type CopyObjectsRequest struct {
+	XMLName xml.Name `xml:"CopyObjects" json:"-"`
+	// Element to enable quiet mode for the request
+	Quiet bool
+	// List of objects to be copied
+	Objects []CopyObjectsEntry `xml:"Object"`
+}
+
+// CopiedObject - an object successfully copied by a CopyObjects call.
+type CopiedObject struct {
+	SourceKey    string `xml:"SourceKey"`
+	Key          string `xml:"Key"`
+	VersionID    string `xml:"VersionId,omitempty"`
+	ETag         string `xml:"ETag"`
+	LastModified string `xml:"LastModified"`
+}
+
+// CopyError - error of an object of a CopyObjects call.
+type CopyError struct {
+	Code      string
+	Message   string
+	SourceKey string
+	Key       string
+}
+
+// CopyObjectsResponse container for multiple object copies.
+type CopyObjectsResponse struct {
+	XMLName xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ CopyObjectsResult" json:"-"`
+
+	// Collection of all copied objects
+	CopiedObjects []CopiedObject `xml:"Copied,omitempty"`
+
+	// Collection of errors copying certain objects.
+	Errors []CopyError `xml:"Error,omitempty"`
+}
+
+// isReplaceableMetadata returns true for the metadata a REPLACE
+// metadata directive replaces, tags are kept.
+func isReplaceableMetadata(key string) bool {
+	lkey := strings.ToLower(key)
+	if lkey == strings.ToLower(xhttp.AmzObjectTagging) || lkey == strings.ToLower(xhttp.AmzBucketReplicationStatus) {
+		return false
+	}
+	for _, h := range supportedHeaders {
+		if lkey == strings.ToLower(h) {
+			return true
+		}
+	}
+	for _, prefix := range userMetadataKeyPrefixes {
+		if strings.HasPrefix(lkey, strings.ToLower(prefix)) {
+			return true
+		}
+	}
+	return false
+}
+
+// replaceCopyMetadata replaces the user settable metadata with entries.
+func replaceCopyMetadata(ctx context.Context, metadata map[string]string, entries []CopyObjectsMetadata) error {
+	for k := range metadata {
+		if isReplaceableMetadata(k) {
+			delete(metadata, k)
+		}
+	}
+	h := make(textproto.MIMEHeader, len(entries))
+	for _, e := range entries {
+		h.Add(e.Name, e.Value)
+	}
+	replaced := make(map[string]string, len(entries))
+	if err := extractMetadataFromMime(ctx, h, replaced); err != nil {
+		return err
+	}
+	for k, v := range replaced {
+		if isReplaceableMetadata(k) {
+			metadata[k] = v
+		}
+	}
+	return nil
+}
+
+// copyObjectsEntry copies the object of entry into bucket without
+// decrypting or decompressing it, encrypted objects are resealed for
+// their new name and the encryption configured on bucket. The copy is
+// created with versionID when not empty, userDefined is added to its
+// metadata. noError is returned on success.
+func copyObjectsEntry(ctx context.Context, objAPI ObjectLayer, r *http.Request, bucket string, entry CopyObjectsEntry, versionID string, userDefined map[string]string) (ObjectInfo, APIError) {
+	srcBucket := entry.SourceBucket
+	if srcBucket == "" {
+		srcBucket = bucket
+	}
+
+	gr, err := objAPI.GetObjectNInfo(ctx, srcBucket, entry.SourceKey, nil, http.Header{}, readLock, ObjectOptions{
+		VersionID:    entry.SourceVersionID,
+		NoDecryption: true,
+	})
+	if err != nil {
+		return ObjectInfo{}, toAPIError(ctx, err)
+	}
+	defer gr.Close()
+
+	srcInfo := gr.ObjInfo
+	if srcInfo.TransitionedObject.Status != "" {
+		return ObjectInfo{}, toAPIError(ctx, NotImplemented{Message: "Objects transitioned to a remote tier cannot be copied in a batch"})
+	}
+	if err = enforceBucketQuotaHard(ctx, bucket, srcInfo.Size); err != nil {
+		return ObjectInfo{}, toAPIError(ctx, err)
+	}
+
+	metadata := rawObjectMetadata(srcInfo)
+	metadata = filterReplicationStatusMetadata(metadata)
+	metadata = objectlock.FilterObjectLockMetadata(metadata, true, true)
+	for _, k := range []string{ReplicationStatus, ReplicationTimestamp, ReplicaStatus, ReplicaTimestamp} {
+		delete(metadata, ReservedMetadataPrefixLower+k)
+	}
+	if isDirectiveReplace(entry.MetadataDirective) {
+		if err = replaceCopyMetadata(ctx, metadata, entry.Metadata); err != nil {
+			return ObjectInfo{}, toAPIError(ctx, err)
+		}
+	}
+	delete(metadata, ReservedMetadataPrefixLower+renamePrefixID)
+	for k, v := range userDefined {
+		metadata[k] = v
+	}
+
+	// The copy takes the encryption of the destination bucket, the
+	// content is copied as is and cannot be encrypted on the way.
+	sseConfig, _ := globalBucketSSEConfigSys.Get(bucket)
+	dstHeader := make(http.Header)
+	sseConfig.Apply(dstHeader, sse.ApplyOptions{AutoEncrypt: globalAutoEncryption})
+	var dstKind crypto.Type
+	switch {
+	case crypto.S3KMS.IsRequested(dstHeader):
+		dstKind = crypto.S3KMS
+	case crypto.S3.IsRequested(dstHeader):
+		dstKind = crypto.S3
+	}
+	dstKeyID := strings.TrimPrefix(dstHeader.Get(xhttp.AmzServerSideEncryptionKmsID), crypto.ARNPrefix)
+
+	kind, encrypted := crypto.IsEncrypted(metadata)
+	if !encrypted && dstKind != nil {
+		return ObjectInfo{}, toAPIError(ctx, NotImplemented{Message: "Unencrypted objects cannot be copied in a batch into a bucket with default encryption"})
+	}
+	if encrypted {
+		if err = resealObjectKey(ctx, metadata, srcBucket, entry.SourceKey, bucket, entry.Key, dstKind, dstKeyID); err != nil {
+			return ObjectInfo{}, toAPIError(ctx, err)
+		}
+		if dstKind != nil {
+			kind = dstKind
+		}
+	}
+
+	// Check the encryption of the copy against the destination
+	// bucket in compliance mode.
+	copyHeader := make(http.Header)
+	switch kind {
+	case crypto.S3:
+		copyHeader.Set(xhttp.AmzServerSideEncryption, xhttp.AmzEncryptionAES)
+	case crypto.S3KMS:
+		copyHeader.Set(xhttp.AmzServerSideEncryption, xhttp.AmzEncryptionKMS)
+		if keyID, _, _, _, err := crypto.S3KMS.ParseMetadata(metadata); err == nil {
+			copyHeader.Set(xhttp.AmzServerSideEncryptionKmsID, keyID)
+		}
+	}
+	if s3Err := enforceBucketSSECompliance(ctx, r, copyHeader, bucket, entry.Key, sseConfig); s3Err != ErrNone {
+		return ObjectInfo{}, errorCodes.ToAPIErr(s3Err)
+	}
+
+	retentionMode, retentionDate, legalHold, s3Err := checkPutObjectLockAllowed(ctx, r, bucket, entry.Key, objAPI.GetObjectInfo, ErrNone, ErrNone)
+	if s3Err != ErrNone {
+		return ObjectInfo{}, errorCodes.ToAPIErr(s3Err)
+	}
+	if retentionMode.Valid() {
+		metadata[strings.ToLower(xhttp.AmzObjectLockMode)] = string(retentionMode)
+		metadata[strings.ToLower(xhttp.AmzObjectLockRetainUntilDate)] = retentionDate.UTC().Format(iso8601TimeFormat)
+		setRetentionSource(metadata, r, bucket, retentionMode)
+	}
+	if legalHold.Status.Valid() {
+		metadata[strings.ToLower(xhttp.AmzObjectLockLegalHold)] = string(legalHold.Status)
+	}
+
+	opts := ObjectOptions{
+		VersionID:        versionID,
+		UserDefined:      metadata,
+		Versioned:        globalBucketVersioningSys.PrefixEnabled(bucket, entry.Key),
+		VersionSuspended: globalBucketVersioningSys.PrefixSuspended(bucket, entry.Key),
+	}
+	dsc := mustReplicate(ctx, bucket, entry.Key, getMustReplicateOptions(ObjectInfo{
+		UserDefined: metadata,
+	}, replication.ObjectReplicationType, opts))
+	if dsc.ReplicateAny() {
+		metadata[ReservedMetadataPrefixLower+ReplicationTimestamp] = UTCNow().Format(time.RFC3339Nano)
+		metadata[ReservedMetadataPrefixLower+ReplicationStatus] = dsc.PendingStatus()
+	}
+
+	objInfo, err := copyObjectRaw(ctx, objAPI, gr, bucket, entry.Key, opts)
+	if err != nil {
+		return ObjectInfo{}, toAPIError(ctx, err)
+	}
+	if dsc.ReplicateAny() {
+		scheduleReplication(ctx, objInfo.Clone(), objAPI, dsc, replication.ObjectReplicationType)
+	}
+	return objInfo, noError
+}
+
+// copyObjects copies objects into bucket in parallel, entries with an
+// error already set in errs are skipped. Results are returned in the
+// order of objects.
+func copyObjects(ctx context.Context, objAPI ObjectLayer, r *http.Request, bucket string, objects []CopyObjectsEntry, errs []APIError) []ObjectInfo {
+	objInfos := make([]ObjectInfo, len(objects))
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, copyObjectsConcurrency)
+	for i := range objects {
+		if errs[i].Code != "" {
+			continue
+		}
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			objInfos[i], errs[i] = copyObjectsEntry(ctx, objAPI, r, bucket, objects[i], "", nil)
+		}(i)
+	}
+	wg.Wait()
+	return objInfos
+}
@@ -0,0 +1,120 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestCopyObjects(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+	setObjectLayer(objLayer)
+	defer setObjectLayer(nil)
+
+	if err = newTestConfig(globalMinioDefaultRegion, objLayer); err != nil {
+		t.Fatal(err)
+	}
+
+	initAllSubsystems()
+
+	const bucket = "bucket"
+	if err = objLayer.MakeBucketWithLocation(ctx, bucket, MakeBucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	data := []byte("hello, world")
+	_, err = objLayer.PutObject(ctx, bucket, "src", mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{
+		UserDefined: map[string]string{
+			"content-type":     "text/plain",
+			"X-Amz-Meta-Color": "red",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	objects := []CopyObjectsEntry{
+		{SourceKey: "src", Key: "dst/copy"},
+		{
+			SourceKey:         "src",
+			Key:               "dst/replace",
+			MetadataDirective: replaceDirective,
+			Metadata: []CopyObjectsMetadata{
+				{Name: "Content-Type", Value: "application/json"},
+				{Name: "X-Amz-Meta-Shape", Value: "round"},
+			},
+		},
+		{SourceKey: "missing", Key: "dst/missing"},
+		{SourceKey: "src", Key: "dst/skipped"},
+	}
+	errs := make([]APIError, len(objects))
+	errs[3] = errorCodes.ToAPIErr(ErrAccessDenied)
+
+	r := httptest.NewRequest(http.MethodPost, "/"+bucket+"?copy-objects", nil)
+	objInfos := copyObjects(ctx, objLayer, r, bucket, objects, errs)
+
+	for i, wantErr := range []string{"", "", "NoSuchKey", "AccessDenied"} {
+		if errs[i].Code != wantErr {
+			t.Fatalf("%s: expected error %q, got %q", objects[i].Key, wantErr, errs[i].Code)
+		}
+	}
+	if objInfos[0].Size != int64(len(data)) {
+		t.Fatalf("expected size %d, got %d", len(data), objInfos[0].Size)
+	}
+
+	for _, test := range []struct {
+		object      string
+		contentType string
+		meta        map[string]string
+	}{
+		{"dst/copy", "text/plain", map[string]string{"X-Amz-Meta-Color": "red"}},
+		{"dst/replace", "application/json", map[string]string{"X-Amz-Meta-Shape": "round", "X-Amz-Meta-Color": ""}},
+	} {
+		var buf bytes.Buffer
+		if err = GetObject(ctx, objLayer, bucket, test.object, 0, -1, &buf, "", ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(buf.Bytes(), data) {
+			t.Fatalf("%s: content mismatch", test.object)
+		}
+		oi, err := objLayer.GetObjectInfo(ctx, bucket, test.object, ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if oi.ContentType != test.contentType {
+			t.Fatalf("%s: expected content-type %q, got %q", test.object, test.contentType, oi.ContentType)
+		}
+		for k, v := range test.meta {
+			if got := oi.UserDefined[k]; got != v {
+				t.Fatalf("%s: expected %s=%q, got %q", test.object, k, v, got)
+			}
+		}
+	}
+}
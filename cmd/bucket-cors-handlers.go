@@ -0,0 +1,250 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/minio/pkg/bucket/policy"
+	"github.com/qkbyte/minio/internal/bucket/cors"
+	xhttp "github.com/qkbyte/minio/internal/http"
+	"github.com/qkbyte/minio/internal/logger"
+)
+
+// PutBucketCorsHandler - PUT Bucket CORS.
+// ----------
+// Sets the CORS configuration of the bucket, the configuration
+// replaces the global CORS settings for requests to the bucket.
+func (api objectAPIHandlers) PutBucketCorsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "PutBucketCors")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	// There is no dedicated CORS policy action, CORS
+	// configuration is managed along with the bucket policy.
+	if s3Error := checkRequestAuthType(ctx, r, policy.PutBucketPolicyAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+		return
+	}
+
+	// Check if bucket exists.
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket, BucketOptions{}); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	config, err := cors.ParseConfig(io.LimitReader(r.Body, r.ContentLength))
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	configData, err := xml.Marshal(config)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	if _, err = globalBucketMetadataSys.Update(ctx, bucket, bucketCorsConfig, configData); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	// Write success response.
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// GetBucketCorsHandler - GET Bucket CORS.
+// ----------
+func (api objectAPIHandlers) GetBucketCorsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetBucketCors")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.GetBucketPolicyAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+		return
+	}
+
+	// Validate if bucket exists, before proceeding further...
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket, BucketOptions{}); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	config, _, err := globalBucketMetadataSys.GetCorsConfig(bucket)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	configData, err := xml.Marshal(config)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	// Write success response.
+	writeSuccessResponseXML(w, configData)
+}
+
+// DeleteBucketCorsHandler - DELETE Bucket CORS.
+// ----------
+func (api objectAPIHandlers) DeleteBucketCorsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "DeleteBucketCors")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.DeleteBucketPolicyAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+		return
+	}
+
+	// Check if bucket exists.
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket, BucketOptions{}); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	if _, err := globalBucketMetadataSys.Update(ctx, bucket, bucketCorsConfig, nil); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	// Write success response.
+	writeSuccessNoContent(w)
+}
+
+// getCorsRequestBucket returns the bucket targeted by
+// a path-style or virtual-host-style request.
+func getCorsRequestBucket(r *http.Request) string {
+	resource, err := getResource(r.URL.Path, r.Host, globalDomainNames)
+	if err != nil {
+		return ""
+	}
+	bucket, _ := path2BucketObject(resource)
+	if bucket == minioReservedBucket {
+		return ""
+	}
+	return bucket
+}
+
+// setCorsAllowOrigin sets the allowed origin of the response
+// as per the matching rule.
+func setCorsAllowOrigin(h http.Header, rule *cors.Rule, origin string) {
+	if rule.AllowsAnyOrigin() {
+		h.Set(xhttp.AccessControlAllowOrigin, "*")
+		return
+	}
+	h.Set(xhttp.AccessControlAllowOrigin, origin)
+	h.Set(xhttp.AccessControlAllowCredentials, "true")
+}
+
+// serveBucketCors handles cross origin requests to buckets with a CORS
+// configuration, it returns false without writing anything when the
+// request is not a cross origin request or the bucket has no CORS
+// configuration.
+func serveBucketCors(w http.ResponseWriter, r *http.Request, next http.Handler) bool {
+	origin := r.Header.Get(xhttp.Origin)
+	if origin == "" || globalBucketMetadataSys == nil {
+		return false
+	}
+	bucket := getCorsRequestBucket(r)
+	if bucket == "" {
+		return false
+	}
+	config, _, err := globalBucketMetadataSys.GetCorsConfig(bucket)
+	if err != nil {
+		return false
+	}
+
+	h := w.Header()
+	h.Add(xhttp.Vary, xhttp.Origin)
+
+	if method := r.Header.Get(xhttp.AccessControlRequestMethod); r.Method == http.MethodOptions && method != "" {
+		h.Add(xhttp.Vary, xhttp.AccessControlRequestMethod)
+		h.Add(xhttp.Vary, xhttp.AccessControlRequestHeaders)
+
+		requestHeaders := r.Header.Get(xhttp.AccessControlRequestHeaders)
+		var headers []string
+		if requestHeaders != "" {
+			headers = strings.Split(requestHeaders, ",")
+		}
+		rule := config.Match(origin, method, headers)
+		if rule == nil {
+			writeErrorResponse(r.Context(), w, APIError{
+				Code:           "AccessForbidden",
+				Description:    "CORSResponse: This CORS request is not allowed. This is usually because the evalution of Origin, request method / Access-Control-Request-Method or Access-Control-Request-Headers are not whitelisted by the resource's CORS spec.",
+				HTTPStatusCode: http.StatusForbidden,
+			}, r.URL)
+			return true
+		}
+
+		setCorsAllowOrigin(h, rule, origin)
+		h.Set(xhttp.AccessControlAllowMethods, strings.Join(rule.AllowedMethods, ", "))
+		if requestHeaders != "" {
+			h.Set(xhttp.AccessControlAllowHeaders, requestHeaders)
+		}
+		if rule.MaxAgeSeconds > 0 {
+			h.Set(xhttp.AccessControlMaxAge, strconv.Itoa(rule.MaxAgeSeconds))
+		}
+		w.WriteHeader(http.StatusOK)
+		return true
+	}
+
+	if rule := config.Match(origin, r.Method, nil); rule != nil {
+		setCorsAllowOrigin(h, rule, origin)
+		if len(rule.ExposeHeaders) > 0 {
+			h.Set(xhttp.AccessControlExposeHeaders, strings.Join(rule.ExposeHeaders, ", "))
+		}
+	}
+	next.ServeHTTP(w, r)
+	return true
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/qkbyte/minio/internal/bucket/cors"
+	xhttp "github.com/qkbyte/minio/internal/http"
+)
+
+func TestServeBucketCors(t *testing.T) {
+	config, err := cors.ParseConfig(strings.NewReader(`<CORSConfiguration><CORSRule><AllowedOrigin>https://app.example.com</AllowedOrigin><AllowedMethod>GET</AllowedMethod><AllowedHeader>*</AllowedHeader><ExposeHeader>ETag</ExposeHeader><MaxAgeSeconds>60</MaxAgeSeconds></CORSRule></CORSConfiguration>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func(sys *BucketMetadataSys) { globalBucketMetadataSys = sys }(globalBucketMetadataSys)
+	globalBucketMetadataSys = NewBucketMetadataSys()
+	meta := newBucketMetadata("bucket")
+	meta.corsConfig = config
+	globalBucketMetadataSys.Set("bucket", meta)
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	testCases := []struct {
+		method        string
+		path          string
+		headers       map[string]string
+		handled       bool
+		status        int
+		allowedOrigin string
+	}{
+		// Preflight request allowed by the bucket CORS configuration.
+		{
+			method:        http.MethodOptions,
+			path:          "/bucket/object",
+			headers:       map[string]string{xhttp.Origin: "https://app.example.com", xhttp.AccessControlRequestMethod: http.MethodGet, xhttp.AccessControlRequestHeaders: "x-amz-date"},
+			handled:       true,
+			status:        http.StatusOK,
+			allowedOrigin: "https://app.example.com",
+		},
+		// Preflight request for a method not allowed.
+		{
+			method:  http.MethodOptions,
+			path:    "/bucket/object",
+			headers: map[string]string{xhttp.Origin: "https://app.example.com", xhttp.AccessControlRequestMethod: http.MethodDelete},
+			handled: true,
+			status:  http.StatusForbidden,
+		},
+		// Actual request from an allowed origin.
+		{
+			method:        http.MethodGet,
+			path:          "/bucket/object",
+			headers:       map[string]string{xhttp.Origin: "https://app.example.com"},
+			handled:       true,
+			status:        http.StatusNoContent,
+			allowedOrigin: "https://app.example.com",
+		},
+		// Actual request from an origin not allowed is served without CORS headers.
+		{
+			method:  http.MethodGet,
+			path:    "/bucket/object",
+			headers: map[string]string{xhttp.Origin: "https://other.org"},
+			handled: true,
+			status:  http.StatusNoContent,
+		},
+		// Bucket without CORS configuration falls back to global CORS.
+		{
+			method:  http.MethodGet,
+			path:    "/other-bucket/object",
+			headers: map[string]string{xhttp.Origin: "https://app.example.com"},
+		},
+		// Not a cross origin request.
+		{method: http.MethodGet, path: "/bucket/object"},
+	}
+
+	for i, testCase := range testCases {
+		r := httptest.NewRequest(testCase.method, "http://localhost:9000"+testCase.path, nil)
+		for k, v := range testCase.headers {
+			r.Header.Set(k, v)
+		}
+		w := httptest.NewRecorder()
+		if handled := serveBucketCors(w, r, next); handled != testCase.handled {
+			t.Fatalf("Test %d: expected handled %t, got %t", i+1, testCase.handled, handled)
+		}
+		if !testCase.handled {
+			continue
+		}
+		if w.Code != testCase.status {
+			t.Errorf("Test %d: expected status %d, got %d", i+1, testCase.status, w.Code)
+		}
+		if origin := w.Header().Get(xhttp.AccessControlAllowOrigin); origin != testCase.allowedOrigin {
+			t.Errorf("Test %d: expected allowed origin '%s', got '%s'", i+1, testCase.allowedOrigin, origin)
+		}
+	}
+}
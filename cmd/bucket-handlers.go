@@ -22,6 +22,7 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -59,6 +60,11 @@ const (
 	objectLockConfig        = "object-lock.xml"
 	bucketTaggingConfig     = "tagging.xml"
 	bucketReplicationConfig = "replication.xml"
+	bucketCorsConfig        = "cors.xml"
+	bucketWebsiteConfig     = "website.xml"
+	bucketLoggingConfig     = "logging.xml"
+
+	bucketRequestPaymentConfig = "request-payment.xml"
 )
 
 // Check if there are buckets on server without corresponding entry in etcd backend and
@@ -571,6 +577,25 @@ func (api objectAPIHandlers) DeleteMultipleObjectsHandler(w http.ResponseWriter,
 			}
 		}
 
+		// Keep a copy of the object in the recycle bin of non-versioned buckets.
+		if _, dup := objectsToDelete[object]; !dup && isBucketTrashEnabled(bucket, opts) {
+			_, trashed, err := moveObjectToTrash(ctx, objectAPI, bucket, object.ObjectName, opts)
+			if err != nil {
+				apiErr := toAPIError(ctx, err)
+				deleteResults[index].errInfo = DeleteError{
+					Code:      apiErr.Code,
+					Message:   apiErr.Description,
+					Key:       object.ObjectName,
+					VersionID: object.VersionID,
+				}
+				continue
+			}
+			if trashed {
+				deleteResults[index].delInfo = DeletedObject{ObjectName: object.ObjectName}
+				continue
+			}
+		}
+
 		// Avoid duplicate objects, we use map to filter them out.
 		if _, ok := objectsToDelete[object]; !ok {
 			objectsToDelete[object] = index
@@ -690,6 +715,241 @@ func (api objectAPIHandlers) DeleteMultipleObjectsHandler(w http.ResponseWriter,
 	}
 }
 
+// CopyObjectsHandler - copies multiple objects into the bucket, this
+// is a MinIO extension.
+func (api objectAPIHandlers) CopyObjectsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "CopyObjects")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	// Content-Length is required and should be non-zero
+	if r.ContentLength <= 0 {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrMissingContentLength), r.URL)
+		return
+	}
+
+	// The max. XML contains 1000 pairs of object names (each at most 1024
+	// bytes long) along with their metadata + XML overhead
+	const maxBodySize = 16 * maxCopyObjectsList * 1024
+
+	// Unmarshal list of objects to be copied.
+	copyObjectsReq := &CopyObjectsRequest{}
+	if err := xmlDecoder(r.Body, copyObjectsReq, maxBodySize); err != nil {
+		logger.LogIf(ctx, err, logger.Application)
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	// Return Malformed XML as for multi object delete if the number of objects is empty
+	if len(copyObjectsReq.Objects) == 0 || len(copyObjectsReq.Objects) > maxCopyObjectsList {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrMalformedXML), r.URL)
+		return
+	}
+
+	// Call checkRequestAuthType to populate ReqInfo.AccessKey before GetBucketInfo()
+	// Ignore errors here to preserve the S3 error behavior of GetBucketInfo()
+	checkRequestAuthType(ctx, r, policy.PutObjectAction, bucket, "")
+
+	// Before proceeding validate if bucket exists.
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket, BucketOptions{}); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	objects := copyObjectsReq.Objects
+	errs := make([]APIError, len(objects))
+	for i := range objects {
+		object := &objects[i]
+		object.SourceKey = trimLeadingSlash(object.SourceKey)
+		object.Key = trimLeadingSlash(object.Key)
+		if object.SourceBucket == "" {
+			object.SourceBucket = bucket
+		}
+
+		if apiErrCode := checkRequestAuthType(ctx, r, policy.GetObjectAction, object.SourceBucket, object.SourceKey); apiErrCode != ErrNone {
+			if apiErrCode == ErrSignatureDoesNotMatch || apiErrCode == ErrInvalidAccessKeyID {
+				writeErrorResponse(ctx, w, errorCodes.ToAPIErr(apiErrCode), r.URL)
+				return
+			}
+			errs[i] = errorCodes.ToAPIErr(apiErrCode)
+			continue
+		}
+		if apiErrCode := checkRequestAuthType(ctx, r, policy.PutObjectAction, bucket, object.Key); apiErrCode != ErrNone {
+			errs[i] = errorCodes.ToAPIErr(apiErrCode)
+			continue
+		}
+
+		switch {
+		case object.SourceKey == "" || object.Key == "":
+			errs[i] = errorCodes.ToAPIErr(ErrInvalidObjectName)
+		case object.MetadataDirective != "" && !isDirectiveValid(object.MetadataDirective):
+			errs[i] = errorCodes.ToAPIErr(ErrInvalidMetadataDirective)
+		case object.SourceBucket == bucket && object.SourceKey == object.Key:
+			errs[i] = errorCodes.ToAPIErr(ErrInvalidCopyDest)
+		}
+	}
+
+	objInfos := copyObjects(ctx, objectAPI, r, bucket, objects, errs)
+
+	// Generate response
+	response := CopyObjectsResponse{}
+	for i, object := range objects {
+		if errs[i].Code != "" {
+			response.Errors = append(response.Errors, CopyError{
+				Code:      errs[i].Code,
+				Message:   errs[i].Description,
+				SourceKey: object.SourceKey,
+				Key:       object.Key,
+			})
+			continue
+		}
+		if copyObjectsReq.Quiet {
+			continue
+		}
+		response.CopiedObjects = append(response.CopiedObjects, CopiedObject{
+			SourceKey:    object.SourceKey,
+			Key:          object.Key,
+			VersionID:    objInfos[i].VersionID,
+			ETag:         "\"" + objInfos[i].ETag + "\"",
+			LastModified: objInfos[i].ModTime.UTC().Format(iso8601TimeFormat),
+		})
+	}
+
+	// Write success response.
+	writeSuccessResponseXML(w, encodeResponse(response))
+
+	for i := range objects {
+		if errs[i].Code != "" {
+			continue
+		}
+		// Notify object created event.
+		sendEvent(eventArgs{
+			EventName:    event.ObjectCreatedCopy,
+			BucketName:   bucket,
+			Object:       objInfos[i],
+			ReqParams:    extractReqParams(r),
+			RespElements: extractRespElements(w),
+			UserAgent:    r.UserAgent(),
+			Host:         handlers.GetSourceIP(r),
+		})
+	}
+}
+
+// RenamePrefixHandler - renames all objects below a prefix, either all
+// objects are renamed or none. This is a MinIO extension.
+func (api objectAPIHandlers) RenamePrefixHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "RenamePrefix")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.ListBucketAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+		return
+	}
+
+	// The XML contains two prefixes (each at most 1024 bytes long) + XML overhead
+	const maxBodySize = 4 * 1024
+
+	renameReq := &RenamePrefixRequest{}
+	if err := xmlDecoder(r.Body, renameReq, maxBodySize); err != nil {
+		logger.LogIf(ctx, err, logger.Application)
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+	src, dst := trimLeadingSlash(renameReq.Source), trimLeadingSlash(renameReq.Destination)
+	if !validRenamePrefixes(src, dst) {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidRenamePrefix), r.URL)
+		return
+	}
+
+	// Deletes of the sources are not replicated, a rename would leave
+	// replicas behind.
+	if _, err := getReplicationConfig(ctx, bucket); err == nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, NotImplemented{Message: "Prefixes cannot be renamed in buckets with replication"}), r.URL)
+		return
+	}
+
+	// Renames of the same prefixes are serialized, the destination
+	// stays empty until the rename is journaled.
+	lk := objectAPI.NewNSLock(bucket, src, dst)
+	lkctx, err := lk.GetLock(ctx, globalOperationTimeout)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+	ctx = lkctx.Context()
+	defer lk.Unlock(lkctx.Cancel)
+
+	j, err := newRenamePrefixJournal(ctx, objectAPI, bucket, src, dst)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	for _, e := range j.Objects {
+		for _, check := range []struct {
+			action policy.Action
+			object string
+		}{
+			{policy.GetObjectAction, j.source(e)},
+			{policy.DeleteObjectAction, j.source(e)},
+			{policy.PutObjectAction, j.destination(e)},
+		} {
+			if s3Error := checkRequestAuthType(ctx, r, check.action, bucket, check.object); s3Error != ErrNone {
+				writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+				return
+			}
+		}
+	}
+
+	objInfos, apiErr := renamePrefix(ctx, objectAPI, r, j)
+	if apiErr.Code != "" {
+		writeErrorResponse(ctx, w, apiErr, r.URL)
+		return
+	}
+
+	writeSuccessResponseXML(w, encodeResponse(RenamePrefixResponse{Objects: len(objInfos)}))
+
+	for i, e := range j.Objects {
+		sendEvent(eventArgs{
+			EventName:    event.ObjectCreatedCopy,
+			BucketName:   bucket,
+			Object:       objInfos[i],
+			ReqParams:    extractReqParams(r),
+			RespElements: extractRespElements(w),
+			UserAgent:    r.UserAgent(),
+			Host:         handlers.GetSourceIP(r),
+		})
+		sendEvent(eventArgs{
+			EventName:    event.ObjectRemovedDelete,
+			BucketName:   bucket,
+			Object:       ObjectInfo{Name: j.source(e)},
+			ReqParams:    extractReqParams(r),
+			RespElements: extractRespElements(w),
+			UserAgent:    r.UserAgent(),
+			Host:         handlers.GetSourceIP(r),
+		})
+	}
+}
+
 // PutBucketHandler - PUT Bucket
 // ----------
 // This implementation of the PUT operation creates a new bucket for authenticated request
@@ -777,6 +1037,12 @@ func (api objectAPIHandlers) PutBucketHandler(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	// check if the bucket exceeds the bucket count quota of its tenants.
+	if err := enforceTenantBucketCount(ctx, objectAPI, bucket); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
 	opts := MakeBucketOptions{
 		Location:    location,
 		LockEnabled: objectLockEnabled,
@@ -926,8 +1192,6 @@ func (api objectAPIHandlers) PostPolicyBucketHandler(w http.ResponseWriter, r *h
 		return
 	}
 
-	// Here the parameter is the size of the form data that should
-	// be loaded in memory, the remaining being put in temporary files.
 	reader, err := r.MultipartReader()
 	if err != nil {
 		logger.LogIf(ctx, err)
@@ -935,20 +1199,14 @@ func (api objectAPIHandlers) PostPolicyBucketHandler(w http.ResponseWriter, r *h
 		return
 	}
 
-	// Read multipart data and save in memory and in the disk if needed
-	form, err := reader.ReadForm(maxFormMemory)
-	if err != nil {
-		logger.LogIf(ctx, err, logger.Application)
-		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrMalformedPOSTRequest), r.URL)
-		return
-	}
-
-	// Remove all tmp files created during multipart upload
-	defer form.RemoveAll()
-
-	// Extract all form fields
-	fileBody, fileName, fileSize, formValues, err := extractPostPolicyFormValues(ctx, form)
+	// Extract all form fields, the file is kept in memory or in
+	// a temporary file depending on its size.
+	fileBody, fileName, fileSize, formValues, err := extractPostPolicyFormValues(ctx, reader)
 	if err != nil {
+		if errors.Is(err, errDataTooLarge) {
+			writeErrorResponse(ctx, w, toAPIError(ctx, errDataTooLarge), r.URL)
+			return
+		}
 		logger.LogIf(ctx, err, logger.Application)
 		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrMalformedPOSTRequest), r.URL)
 		return
@@ -971,6 +1229,11 @@ func (api objectAPIHandlers) PostPolicyBucketHandler(w http.ResponseWriter, r *h
 	}
 	object := trimLeadingSlash(formValues.Get("Key"))
 
+	// Tag browser based uploads in the audit log.
+	logger.GetReqInfo(ctx).SetTags("postPolicyUpload", true).
+		SetTags("postPolicyContentType", formValues.Get(xhttp.ContentType)).
+		SetTags("postPolicyFileSize", fileSize)
+
 	successRedirect := formValues.Get("success_action_redirect")
 	successStatus := formValues.Get("success_action_status")
 	var redirectURL *url.URL
@@ -998,7 +1261,7 @@ func (api objectAPIHandlers) PostPolicyBucketHandler(w http.ResponseWriter, r *h
 		ConditionValues: getConditionValues(r, "", cred.AccessKey, cred.Claims),
 		BucketName:      bucket,
 		ObjectName:      object,
-		IsOwner:         globalActiveCred.AccessKey == cred.AccessKey,
+		IsOwner:         globalActiveCred.AccessKey == cred.AccessKey && isOwnerPrivileged(cred.AccessKey),
 		Claims:          cred.Claims,
 	}) {
 		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrAccessDenied), r.URL)
@@ -1063,6 +1326,10 @@ func (api objectAPIHandlers) PostPolicyBucketHandler(w http.ResponseWriter, r *h
 
 	// Check if bucket encryption is enabled
 	sseConfig, _ := globalBucketSSEConfigSys.Get(bucket)
+	if s3Err := enforceBucketSSECompliance(ctx, r, formValues, bucket, object, sseConfig); s3Err != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Err), r.URL)
+		return
+	}
 	sseConfig.Apply(r.Header, sse.ApplyOptions{
 		AutoEncrypt: globalAutoEncryption,
 		Passthrough: globalIsGateway && globalGatewayName == S3BackendGateway,
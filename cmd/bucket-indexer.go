@@ -0,0 +1,307 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/minio-go/v7/pkg/tags"
+	"github.com/qkbyte/minio/internal/crypto"
+	"github.com/qkbyte/minio/internal/event"
+	xhttp "github.com/qkbyte/minio/internal/http"
+	"github.com/qkbyte/minio/internal/logger"
+)
+
+const (
+	indexerQueueSize     = 10000
+	indexerBatchSize     = 100
+	indexerFlushInterval = time.Second
+	indexerTimeout       = 30 * time.Second
+)
+
+// bucketIndexerConfig - external metadata indexer of a bucket. Object
+// create, tag and delete events are posted with the object metadata to
+// <endpoint>/index, searches are proxied to <endpoint>/search. Adapters
+// for OpenSearch, Postgres etc. implement these two calls.
+type bucketIndexerConfig struct {
+	Endpoint  string `json:"endpoint"`
+	AuthToken string `json:"authToken,omitempty"`
+
+	endpoint *url.URL
+}
+
+func parseBucketIndexerConfig(data []byte) (*bucketIndexerConfig, error) {
+	cfg := &bucketIndexerConfig{}
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, err
+	}
+	u, err := url.Parse(cfg.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid indexer endpoint %s: %w", cfg.Endpoint, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("Invalid indexer endpoint %s: expected an http(s) URL", cfg.Endpoint)
+	}
+	cfg.endpoint = u
+	return cfg, nil
+}
+
+// url returns the URL of the indexer call with the given name.
+func (cfg *bucketIndexerConfig) url(call string, query url.Values) string {
+	u := *cfg.endpoint
+	u.Path = path.Join(u.Path, call)
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+func (cfg *bucketIndexerConfig) newRequest(ctx context.Context, method, call string, query url.Values, body []byte) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, cfg.url(call, query), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if cfg.AuthToken != "" {
+		req.Header.Set(xhttp.Authorization, "Bearer "+cfg.AuthToken)
+	}
+	req.Header.Set(xhttp.ContentType, "application/json")
+	return req, nil
+}
+
+// Operations of indexed documents.
+const (
+	indexOpPut    = "put"
+	indexOpTag    = "tag"
+	indexOpDelete = "delete"
+)
+
+// indexDocument - object change sent to an external indexer.
+type indexDocument struct {
+	Op           string            `json:"op"`
+	Bucket       string            `json:"bucket"`
+	Key          string            `json:"key"`
+	VersionID    string            `json:"versionId,omitempty"`
+	DeleteMarker bool              `json:"deleteMarker,omitempty"`
+	Size         int64             `json:"size,omitempty"`
+	ETag         string            `json:"etag,omitempty"`
+	ContentType  string            `json:"contentType,omitempty"`
+	StorageClass string            `json:"storageClass,omitempty"`
+	LastModified time.Time         `json:"lastModified,omitempty"`
+	UserMetadata map[string]string `json:"userMetadata,omitempty"`
+	Tags         map[string]string `json:"tags,omitempty"`
+}
+
+// newIndexDocument returns the document indexing the change of args,
+// false is returned for events not changing the indexed metadata.
+func newIndexDocument(args eventArgs) (indexDocument, bool) {
+	var op string
+	switch args.EventName {
+	case event.ObjectCreatedPutTagging, event.ObjectCreatedDeleteTagging:
+		op = indexOpTag
+	case event.ObjectCreatedPut, event.ObjectCreatedPost, event.ObjectCreatedCopy,
+		event.ObjectCreatedCompleteMultipartUpload:
+		op = indexOpPut
+	case event.ObjectRemovedDelete, event.ObjectRemovedDeleteMarkerCreated:
+		op = indexOpDelete
+	default:
+		return indexDocument{}, false
+	}
+
+	oi := args.Object
+	doc := indexDocument{
+		Op:           op,
+		Bucket:       args.BucketName,
+		Key:          oi.Name,
+		VersionID:    oi.VersionID,
+		DeleteMarker: oi.DeleteMarker,
+	}
+	if op == indexOpDelete {
+		return doc, true
+	}
+
+	doc.Size, _ = oi.GetActualSize()
+	doc.ETag = oi.ETag
+	doc.ContentType = oi.ContentType
+	doc.StorageClass = oi.StorageClass
+	doc.LastModified = oi.ModTime.UTC()
+	for k, v := range oi.UserDefined {
+		if strings.HasPrefix(strings.ToLower(k), "x-amz-meta-") {
+			if doc.UserMetadata == nil {
+				doc.UserMetadata = make(map[string]string)
+			}
+			doc.UserMetadata[k] = v
+		}
+	}
+	crypto.RemoveSensitiveEntries(doc.UserMetadata)
+	crypto.RemoveInternalEntries(doc.UserMetadata)
+	if t, err := tags.ParseObjectTags(oi.UserTags); err == nil && oi.UserTags != "" {
+		doc.Tags = t.ToMap()
+	}
+	return doc, true
+}
+
+type indexRequest struct {
+	cfg *bucketIndexerConfig
+	doc indexDocument
+}
+
+// metadataIndexer streams object changes of buckets with an external
+// indexer in batches, changes are dropped if the indexer falls behind.
+type metadataIndexer struct {
+	once  sync.Once
+	queue chan indexRequest
+}
+
+var globalMetadataIndexer = &metadataIndexer{}
+
+func (m *metadataIndexer) client() *http.Client {
+	return &http.Client{Transport: globalRemoteTargetTransport, Timeout: indexerTimeout}
+}
+
+// enqueue queues the change described by args if the bucket has an
+// external indexer.
+func (m *metadataIndexer) enqueue(args eventArgs) {
+	if globalBucketMetadataSys == nil {
+		return
+	}
+	cfg, _, _ := globalBucketMetadataSys.GetIndexerConfig(args.BucketName)
+	if cfg == nil {
+		return
+	}
+	doc, ok := newIndexDocument(args)
+	if !ok {
+		return
+	}
+	m.once.Do(func() {
+		m.queue = make(chan indexRequest, indexerQueueSize)
+		go m.run(GlobalContext)
+	})
+	select {
+	case m.queue <- indexRequest{cfg: cfg, doc: doc}:
+	default:
+		logger.LogOnceIf(GlobalContext, errors.New("metadata indexer queue is full, object changes are dropped"), "metadata-indexer-full")
+	}
+}
+
+func (m *metadataIndexer) run(ctx context.Context) {
+	ticker := time.NewTicker(indexerFlushInterval)
+	defer ticker.Stop()
+
+	batches := make(map[*bucketIndexerConfig][]indexDocument)
+	pending := 0
+	flush := func() {
+		for cfg, docs := range batches {
+			if err := m.post(ctx, cfg, docs); err != nil {
+				logger.LogOnceIf(ctx, fmt.Errorf("unable to index %d object changes at %s: %w", len(docs), cfg.Endpoint, err), cfg.Endpoint)
+			}
+			delete(batches, cfg)
+		}
+		pending = 0
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case req := <-m.queue:
+			batches[req.cfg] = append(batches[req.cfg], req.doc)
+			if pending++; pending >= indexerBatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+// post sends a batch of documents to the indexer.
+func (m *metadataIndexer) post(ctx context.Context, cfg *bucketIndexerConfig, docs []indexDocument) error {
+	body, err := json.Marshal(docs)
+	if err != nil {
+		return err
+	}
+	req, err := cfg.newRequest(ctx, http.MethodPost, "index", nil, body)
+	if err != nil {
+		return err
+	}
+	resp, err := m.client().Do(req)
+	if err != nil {
+		return err
+	}
+	defer xhttp.DrainBody(resp.Body)
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("unexpected response %s", resp.Status)
+	}
+	return nil
+}
+
+// indexSearchResult - response of the search call of an indexer.
+type indexSearchResult struct {
+	Objects     []indexSearchObject `json:"objects"`
+	NextMarker  string              `json:"nextMarker,omitempty"`
+	IsTruncated bool                `json:"isTruncated"`
+}
+
+type indexSearchObject struct {
+	Key          string    `json:"key"`
+	VersionID    string    `json:"versionId,omitempty"`
+	Size         int64     `json:"size"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified time.Time `json:"lastModified"`
+}
+
+// search proxies a search of bucket objects to the indexer, the query
+// syntax is up to the indexer.
+func (m *metadataIndexer) search(ctx context.Context, cfg *bucketIndexerConfig, bucket, query, marker string, maxKeys int) (result indexSearchResult, err error) {
+	req, err := cfg.newRequest(ctx, http.MethodGet, "search", url.Values{
+		"bucket":   []string{bucket},
+		"q":        []string{query},
+		"marker":   []string{marker},
+		"max-keys": []string{strconv.Itoa(maxKeys)},
+	}, nil)
+	if err != nil {
+		return result, err
+	}
+	resp, err := m.client().Do(req)
+	if err != nil {
+		return result, err
+	}
+	defer xhttp.DrainBody(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return result, fmt.Errorf("unexpected response %s", resp.Status)
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return result, err
+	}
+	if len(result.Objects) > maxKeys {
+		result.Objects = result.Objects[:maxKeys]
+		result.IsTruncated = true
+	}
+	if result.IsTruncated && result.NextMarker == "" && len(result.Objects) > 0 {
+		result.NextMarker = result.Objects[len(result.Objects)-1].Key
+	}
+	return result, nil
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/qkbyte/minio/internal/event"
+)
+
+func TestParseBucketIndexerConfig(t *testing.T) {
+	testCases := []struct {
+		data    string
+		success bool
+	}{
+		{`{"endpoint":"http://indexer:8080/minio"}`, true},
+		{`{"endpoint":"https://indexer","authToken":"secret"}`, true},
+		{`{"endpoint":"ftp://indexer"}`, false},
+		{`{"endpoint":"indexer:8080"}`, false},
+		{`{}`, false},
+	}
+	for i, tc := range testCases {
+		if _, err := parseBucketIndexerConfig([]byte(tc.data)); (err == nil) != tc.success {
+			t.Errorf("Test %d: expected success %v, got %v", i+1, tc.success, err)
+		}
+	}
+}
+
+func TestNewIndexDocument(t *testing.T) {
+	oi := ObjectInfo{
+		Bucket:      "bucket",
+		Name:        "object",
+		VersionID:   "v1",
+		Size:        10,
+		ContentType: "text/plain",
+		UserTags:    "env=prod",
+		UserDefined: map[string]string{
+			"X-Amz-Meta-Owner":             "alice",
+			"X-Minio-Internal-actual-size": "10",
+		},
+	}
+
+	doc, ok := newIndexDocument(eventArgs{EventName: event.ObjectCreatedPut, BucketName: "bucket", Object: oi})
+	if !ok || doc.Op != indexOpPut {
+		t.Fatalf("expected put document, got %v", doc)
+	}
+	if len(doc.UserMetadata) != 1 || doc.UserMetadata["X-Amz-Meta-Owner"] != "alice" {
+		t.Fatalf("unexpected user metadata %v", doc.UserMetadata)
+	}
+	if doc.Tags["env"] != "prod" || doc.Size != 10 {
+		t.Fatalf("unexpected document %v", doc)
+	}
+
+	if doc, ok = newIndexDocument(eventArgs{EventName: event.ObjectCreatedPutTagging, Object: oi}); !ok || doc.Op != indexOpTag {
+		t.Fatalf("expected tag document, got %v", doc)
+	}
+	if doc, ok = newIndexDocument(eventArgs{EventName: event.ObjectRemovedDelete, Object: oi}); !ok || doc.Op != indexOpDelete || doc.UserMetadata != nil {
+		t.Fatalf("expected delete document, got %v", doc)
+	}
+	if _, ok = newIndexDocument(eventArgs{EventName: event.ObjectAccessedGet, Object: oi}); ok {
+		t.Fatal("expected accessed events to be ignored")
+	}
+}
+
+func TestMetadataIndexerPostSearch(t *testing.T) {
+	var indexed []indexDocument
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/minio/index":
+			json.NewDecoder(r.Body).Decode(&indexed)
+		case "/minio/search":
+			if r.URL.Query().Get("bucket") != "bucket" || r.URL.Query().Get("q") != "owner:alice" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			json.NewEncoder(w).Encode(indexSearchResult{Objects: []indexSearchObject{{Key: "a"}, {Key: "b"}}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	cfg, err := parseBucketIndexerConfig([]byte(`{"endpoint":"` + srv.URL + `/minio","authToken":"token"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := &metadataIndexer{}
+	ctx := context.Background()
+	if err = m.post(ctx, cfg, []indexDocument{{Op: indexOpPut, Bucket: "bucket", Key: "a"}}); err != nil {
+		t.Fatal(err)
+	}
+	if len(indexed) != 1 || indexed[0].Key != "a" {
+		t.Fatalf("unexpected indexed documents %v", indexed)
+	}
+
+	result, err := m.search(ctx, cfg, "bucket", "owner:alice", "", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Objects) != 1 || !result.IsTruncated || result.NextMarker != "a" {
+		t.Fatalf("unexpected search result %v", result)
+	}
+
+	cfg.AuthToken = ""
+	if _, err = m.search(ctx, cfg, "bucket", "owner:alice", "", 1); err == nil {
+		t.Fatal("expected unauthorized search to fail")
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
 	"strings"
 	"sync"
@@ -79,12 +80,36 @@ type expiryTask struct {
 	objInfo        ObjectInfo
 	versionExpiry  bool
 	restoredObject bool
+	queued         time.Time
+}
+
+// expiryAgeIntervals are the buckets of the object age at expiry histogram.
+var expiryAgeIntervals = [...]struct {
+	name       string
+	start, end time.Duration
+}{
+	{"LESS_THAN_1_DAY", 0, 24 * time.Hour},
+	{"BETWEEN_1_DAY_AND_1_WEEK", 24 * time.Hour, 7 * 24 * time.Hour},
+	{"BETWEEN_1_WEEK_AND_1_MONTH", 7 * 24 * time.Hour, 30 * 24 * time.Hour},
+	{"BETWEEN_1_MONTH_AND_1_YEAR", 30 * 24 * time.Hour, 365 * 24 * time.Hour},
+	{"GREATER_THAN_1_YEAR", 365 * 24 * time.Hour, math.MaxInt64},
 }
 
 type expiryState struct {
 	once                sync.Once
 	byDaysCh            chan expiryTask
 	byNewerNoncurrentCh chan newerNoncurrentTask
+
+	ctx        context.Context
+	objAPI     ObjectLayer
+	mu         sync.Mutex
+	numWorkers int
+	killCh     chan struct{}
+
+	expired   uint64
+	shed      uint64
+	queueWait int64 // wait of the last task picked up, in nanoseconds.
+	ages      [len(expiryAgeIntervals)]uint64
 }
 
 // PendingTasks returns the number of pending ILM expiry tasks.
@@ -100,52 +125,182 @@ func (es *expiryState) close() {
 	})
 }
 
+// shedAfterWait waits for the configured expiry queue wait for send
+// to succeed, expiries that still find the queue full are left to the
+// next scanner cycle.
+func (es *expiryState) shedAfterWait(send func(timeout <-chan time.Time) bool) {
+	var timeout <-chan time.Time
+	if wait := globalAPIConfig.getExpiryQueueWait(); wait > 0 {
+		t := time.NewTimer(wait)
+		defer t.Stop()
+		timeout = t.C
+	} else {
+		c := make(chan time.Time)
+		close(c)
+		timeout = c
+	}
+	if !send(timeout) {
+		atomic.AddUint64(&es.shed, 1)
+	}
+}
+
 // enqueueByDays enqueues object versions expired by days for expiry.
 func (es *expiryState) enqueueByDays(oi ObjectInfo, restoredObject bool, rmVersion bool) {
+	task := expiryTask{objInfo: oi, versionExpiry: rmVersion, restoredObject: restoredObject, queued: UTCNow()}
 	select {
 	case <-GlobalContext.Done():
 		es.close()
-	case es.byDaysCh <- expiryTask{objInfo: oi, versionExpiry: rmVersion, restoredObject: restoredObject}:
+		return
+	case es.byDaysCh <- task:
+		return
 	default:
 	}
+	es.shedAfterWait(func(timeout <-chan time.Time) bool {
+		select {
+		case es.byDaysCh <- task:
+			return true
+		case <-timeout:
+			return false
+		}
+	})
 }
 
 // enqueueByNewerNoncurrent enqueues object versions expired by
 // NewerNoncurrentVersions limit for expiry.
 func (es *expiryState) enqueueByNewerNoncurrent(bucket string, versions []ObjectToDelete) {
+	task := newerNoncurrentTask{bucket: bucket, versions: versions, queued: UTCNow()}
 	select {
 	case <-GlobalContext.Done():
 		es.close()
-	case es.byNewerNoncurrentCh <- newerNoncurrentTask{bucket: bucket, versions: versions}:
+		return
+	case es.byNewerNoncurrentCh <- task:
+		return
 	default:
 	}
+	es.shedAfterWait(func(timeout <-chan time.Time) bool {
+		select {
+		case es.byNewerNoncurrentCh <- task:
+			return true
+		case <-timeout:
+			return false
+		}
+	})
 }
 
-var globalExpiryState *expiryState
+// UpdateWorkers at the end of this function leaves n goroutines waiting for
+// expiry tasks.
+func (es *expiryState) UpdateWorkers(n int) {
+	es.mu.Lock()
+	defer es.mu.Unlock()
 
-func newExpiryState() *expiryState {
-	return &expiryState{
-		byDaysCh:            make(chan expiryTask, 10000),
-		byNewerNoncurrentCh: make(chan newerNoncurrentTask, 10000),
+	for es.numWorkers < n {
+		go es.worker(es.ctx, es.objAPI)
+		es.numWorkers++
+	}
+
+	for es.numWorkers > n {
+		go func() { es.killCh <- struct{}{} }()
+		es.numWorkers--
 	}
 }
 
-func initBackgroundExpiry(ctx context.Context, objectAPI ObjectLayer) {
-	globalExpiryState = newExpiryState()
-	go func() {
-		for t := range globalExpiryState.byDaysCh {
+// Workers returns the number of expiry workers.
+func (es *expiryState) Workers() int {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+	return es.numWorkers
+}
+
+func (es *expiryState) worker(ctx context.Context, objectAPI ObjectLayer) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-es.killCh:
+			return
+		case t, ok := <-es.byDaysCh:
+			if !ok {
+				return
+			}
+			atomic.StoreInt64(&es.queueWait, int64(time.Since(t.queued)))
+			var expired bool
 			if t.objInfo.TransitionedObject.Status != "" {
-				applyExpiryOnTransitionedObject(ctx, objectAPI, t.objInfo, t.restoredObject)
+				expired = applyExpiryOnTransitionedObject(ctx, objectAPI, t.objInfo, t.restoredObject)
 			} else {
-				applyExpiryOnNonTransitionedObjects(ctx, objectAPI, t.objInfo, t.versionExpiry)
+				expired = applyExpiryOnNonTransitionedObjects(ctx, objectAPI, t.objInfo, t.versionExpiry)
 			}
-		}
-	}()
-	go func() {
-		for t := range globalExpiryState.byNewerNoncurrentCh {
+			if expired {
+				es.recordExpiry(UTCNow().Sub(t.objInfo.ModTime))
+			}
+		case t, ok := <-es.byNewerNoncurrentCh:
+			if !ok {
+				return
+			}
+			atomic.StoreInt64(&es.queueWait, int64(time.Since(t.queued)))
 			deleteObjectVersions(ctx, objectAPI, t.bucket, t.versions)
+			atomic.AddUint64(&es.expired, uint64(len(t.versions)))
 		}
-	}()
+	}
+}
+
+// recordExpiry accounts an expired object version of the given age.
+func (es *expiryState) recordExpiry(age time.Duration) {
+	atomic.AddUint64(&es.expired, 1)
+	for i, interval := range expiryAgeIntervals {
+		if age >= interval.start && age < interval.end {
+			atomic.AddUint64(&es.ages[i], 1)
+			return
+		}
+	}
+	// Clock skew, count it as just expired.
+	atomic.AddUint64(&es.ages[0], 1)
+}
+
+// Expired returns the number of object versions expired since server start.
+func (es *expiryState) Expired() uint64 {
+	return atomic.LoadUint64(&es.expired)
+}
+
+// Shed returns the number of expiries left to the next scanner
+// cycle because the queue was full.
+func (es *expiryState) Shed() uint64 {
+	return atomic.LoadUint64(&es.shed)
+}
+
+// QueueWait returns how long the last expiry task picked up waited in the queue.
+func (es *expiryState) QueueWait() time.Duration {
+	return time.Duration(atomic.LoadInt64(&es.queueWait))
+}
+
+// AgeHistogram returns the number of expired object versions by age at expiry.
+func (es *expiryState) AgeHistogram() map[string]uint64 {
+	hist := make(map[string]uint64, len(expiryAgeIntervals))
+	for i, interval := range expiryAgeIntervals {
+		hist[interval.name] = atomic.LoadUint64(&es.ages[i])
+	}
+	return hist
+}
+
+var globalExpiryState *expiryState
+
+func newExpiryState(ctx context.Context, objAPI ObjectLayer) *expiryState {
+	return &expiryState{
+		byDaysCh:            make(chan expiryTask, 10000),
+		byNewerNoncurrentCh: make(chan newerNoncurrentTask, 10000),
+		ctx:                 ctx,
+		objAPI:              objAPI,
+		killCh:              make(chan struct{}),
+	}
+}
+
+func initBackgroundExpiry(ctx context.Context, objectAPI ObjectLayer) {
+	globalExpiryState = newExpiryState(ctx, objectAPI)
+	n := globalAPIConfig.getExpiryWorkers()
+	if n == 0 {
+		// Config is not loaded yet, it resizes the workers once it is.
+		n = 1
+	}
+	globalExpiryState.UpdateWorkers(n)
 }
 
 // newerNoncurrentTask encapsulates arguments required by worker to expire objects
@@ -153,6 +308,7 @@ func initBackgroundExpiry(ctx context.Context, objectAPI ObjectLayer) {
 type newerNoncurrentTask struct {
 	bucket   string
 	versions []ObjectToDelete
+	queued   time.Time
 }
 
 type transitionState struct {
@@ -284,22 +440,57 @@ func initBackgroundTransition(ctx context.Context, objectAPI ObjectLayer) {
 
 var errInvalidStorageClass = errors.New("invalid storage class")
 
-func validateTransitionTier(lc *lifecycle.Lifecycle) error {
+func validateTransitionTier(bucket string, lc *lifecycle.Lifecycle) error {
 	for _, rule := range lc.Rules {
-		if rule.Transition.StorageClass != "" {
-			if valid := globalTierConfigMgr.IsTierValid(rule.Transition.StorageClass); !valid {
-				return errInvalidStorageClass
+		for _, tier := range []string{rule.Transition.StorageClass, rule.NoncurrentVersionTransition.StorageClass} {
+			if tier == "" {
+				continue
 			}
-		}
-		if rule.NoncurrentVersionTransition.StorageClass != "" {
-			if valid := globalTierConfigMgr.IsTierValid(rule.NoncurrentVersionTransition.StorageClass); !valid {
+			if valid := globalTierConfigMgr.IsTierValid(tier); !valid {
 				return errInvalidStorageClass
 			}
+			// Transitioning into the source bucket would loop forever.
+			if tierBucket, ok := globalTierConfigMgr.internalTierBucket(tier); ok && tierBucket == bucket {
+				return errTierInternalSameBucket
+			}
+		}
+	}
+
+	// Chained transitions, e.g. from bucket A to B and from B back to A,
+	// loop as well. Follow the internal tiers of every bucket reached.
+	seen := map[string]bool{bucket: true}
+	next := internalTierBuckets(lc)
+	for len(next) > 0 {
+		tierBucket := next[0]
+		next = next[1:]
+		if tierBucket == bucket {
+			return errTierInternalLoop
+		}
+		if seen[tierBucket] {
+			continue
+		}
+		seen[tierBucket] = true
+		if tierLC, err := globalLifecycleSys.Get(tierBucket); err == nil {
+			next = append(next, internalTierBuckets(tierLC)...)
 		}
 	}
 	return nil
 }
 
+// internalTierBuckets returns the buckets the rules of lc transition
+// objects to through internal tiers.
+func internalTierBuckets(lc *lifecycle.Lifecycle) []string {
+	var buckets []string
+	for _, rule := range lc.Rules {
+		for _, tier := range []string{rule.Transition.StorageClass, rule.NoncurrentVersionTransition.StorageClass} {
+			if tierBucket, ok := globalTierConfigMgr.internalTierBucket(tier); ok {
+				buckets = append(buckets, tierBucket)
+			}
+		}
+	}
+	return buckets
+}
+
 // enqueueTransitionImmediate enqueues obj for transition if eligible.
 // This is to be called after a successful upload of an object (version).
 func enqueueTransitionImmediate(obj ObjectInfo) {
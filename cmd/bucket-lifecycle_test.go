@@ -239,9 +239,49 @@ func TestValidateTransitionTier(t *testing.T) {
 			t.Fatalf("Test %d: Failed to parse lifecycle config %v", i+1, err)
 		}
 
-		err = validateTransitionTier(lc)
+		err = validateTransitionTier("bucket", lc)
 		if err != tc.expectedErr {
 			t.Fatalf("Test %d: Expected %v but got %v", i+1, tc.expectedErr, err)
 		}
 	}
 }
+
+func TestExpiryStateBackpressure(t *testing.T) {
+	es := &expiryState{
+		byDaysCh:            make(chan expiryTask, 1),
+		byNewerNoncurrentCh: make(chan newerNoncurrentTask, 1),
+		killCh:              make(chan struct{}),
+	}
+	defer func(wait time.Duration) {
+		globalAPIConfig.mu.Lock()
+		globalAPIConfig.expiryQueueWait = wait
+		globalAPIConfig.mu.Unlock()
+	}(globalAPIConfig.getExpiryQueueWait())
+
+	es.enqueueByDays(ObjectInfo{Name: "a"}, false, false)
+	es.enqueueByDays(ObjectInfo{Name: "b"}, false, false)
+	if es.PendingTasks() != 1 || es.Shed() != 1 {
+		t.Fatalf("expected 1 pending and 1 shed task, got %d and %d", es.PendingTasks(), es.Shed())
+	}
+
+	// A queue wait lets the expiry through once a worker frees room.
+	globalAPIConfig.mu.Lock()
+	globalAPIConfig.expiryQueueWait = 10 * time.Second
+	globalAPIConfig.mu.Unlock()
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		<-es.byDaysCh
+	}()
+	es.enqueueByDays(ObjectInfo{Name: "c"}, false, false)
+	if task := <-es.byDaysCh; task.objInfo.Name != "c" || es.Shed() != 1 {
+		t.Fatalf("expected c to be queued, got %s with %d shed", task.objInfo.Name, es.Shed())
+	}
+
+	es.recordExpiry(time.Hour)
+	es.recordExpiry(3 * 24 * time.Hour)
+	es.recordExpiry(3 * 24 * time.Hour)
+	hist := es.AgeHistogram()
+	if hist["LESS_THAN_1_DAY"] != 1 || hist["BETWEEN_1_DAY_AND_1_WEEK"] != 2 || es.Expired() != 3 {
+		t.Fatalf("unexpected age histogram %v", hist)
+	}
+}
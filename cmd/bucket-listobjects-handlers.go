@@ -19,7 +19,12 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
 	"net/http"
+	"net/url"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -88,6 +93,12 @@ func (api objectAPIHandlers) ListObjectVersionsHandler(w http.ResponseWriter, r
 		return
 	}
 
+	ctx, errCode = listBarrierContext(ctx, objectAPI, bucket, prefix, r.Header)
+	if errCode != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(errCode), r.URL)
+		return
+	}
+
 	listObjectVersions := objectAPI.ListObjectVersions
 
 	// Inititate a list object versions operation based on the input params.
@@ -104,12 +115,86 @@ func (api objectAPIHandlers) ListObjectVersionsHandler(w http.ResponseWriter, r
 		return
 	}
 
-	response := generateListVersionsResponse(bucket, prefix, marker, versionIDMarker, delimiter, encodingType, maxkeys, listObjectVersionsInfo)
+	response := generateListVersionsResponse(bucket, prefix, marker, versionIDMarker, delimiter, encodingType, maxkeys, listObjectVersionsInfo,
+		listStatusRequested(urlValues))
 
 	// Write success response.
 	writeSuccessResponseXML(w, encodeResponse(response))
 }
 
+// ListBucketChangesHandler - GET Bucket?changes&cursor=cursor&max-records=n
+// ----------
+// MinIO extension API returning the object creations and deletions
+// recorded after cursor in the changes feed of the bucket, clients poll
+// with the returned cursor.
+func (api objectAPIHandlers) ListBucketChangesHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ListBucketChanges")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.ListBucketAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+		return
+	}
+
+	maxRecords := changesFeedMaxRecords
+	if v := r.Form.Get("max-records"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidMaxKeys), r.URL)
+			return
+		}
+		if n > 0 && n < maxRecords {
+			maxRecords = n
+		}
+	}
+	cursor, err := parseChangesFeedCursor(r.Form.Get("cursor"))
+	if err != nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidChangesCursor), r.URL)
+		return
+	}
+
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket, BucketOptions{}); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	cfg, _, err := globalBucketMetadataSys.GetChangesFeedConfig(bucket)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+	if cfg == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrChangesFeedNotEnabled), r.URL)
+		return
+	}
+
+	result, err := readChanges(ctx, objectAPI, bucket, cursor, maxRecords)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+	if result.Records == nil {
+		result.Records = []changeRecord{}
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, data)
+}
+
 // ListObjectsV2MHandler - GET Bucket (List Objects) Version 2 with metadata.
 // --------------------------
 // This implementation of the GET operation returns some or all (up to 1000)
@@ -153,6 +238,12 @@ func (api objectAPIHandlers) ListObjectsV2MHandler(w http.ResponseWriter, r *htt
 		return
 	}
 
+	ctx, errCode = listBarrierContext(ctx, objectAPI, bucket, prefix, r.Header)
+	if errCode != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(errCode), r.URL)
+		return
+	}
+
 	listObjectsV2 := objectAPI.ListObjectsV2
 
 	// Inititate a list objects operation based on the input params.
@@ -174,7 +265,7 @@ func (api objectAPIHandlers) ListObjectsV2MHandler(w http.ResponseWriter, r *htt
 
 	response := generateListObjectsV2Response(bucket, prefix, token, nextContinuationToken, startAfter,
 		delimiter, encodingType, fetchOwner, listObjectsV2Info.IsTruncated,
-		maxKeys, listObjectsV2Info.Objects, listObjectsV2Info.Prefixes, true)
+		maxKeys, listObjectsV2Info.Objects, listObjectsV2Info.Prefixes, true, listStatusRequested(urlValues))
 
 	// Write success response.
 	writeSuccessResponseXML(w, encodeResponse(response))
@@ -223,6 +314,12 @@ func (api objectAPIHandlers) ListObjectsV2Handler(w http.ResponseWriter, r *http
 		return
 	}
 
+	ctx, errCode = listBarrierContext(ctx, objectAPI, bucket, prefix, r.Header)
+	if errCode != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(errCode), r.URL)
+		return
+	}
+
 	var (
 		listObjectsV2Info ListObjectsV2Info
 		err               error
@@ -249,12 +346,19 @@ func (api objectAPIHandlers) ListObjectsV2Handler(w http.ResponseWriter, r *http
 
 	response := generateListObjectsV2Response(bucket, prefix, token, listObjectsV2Info.NextContinuationToken, startAfter,
 		delimiter, encodingType, fetchOwner, listObjectsV2Info.IsTruncated,
-		maxKeys, listObjectsV2Info.Objects, listObjectsV2Info.Prefixes, false)
+		maxKeys, listObjectsV2Info.Objects, listObjectsV2Info.Prefixes, false, listStatusRequested(urlValues))
 
 	// Write success response.
 	writeSuccessResponseXML(w, encodeResponse(response))
 }
 
+// listStatusRequested returns whether the listing should mark every
+// entry with its replication and tiering status, a MinIO extension
+// letting operators find unreplicated objects without HEAD requests.
+func listStatusRequested(values url.Values) bool {
+	return values.Get("status") == "true"
+}
+
 func parseRequestToken(token string) (subToken string, nodeIndex int) {
 	if token == "" {
 		return token, -1
@@ -330,6 +434,12 @@ func (api objectAPIHandlers) ListObjectsV1Handler(w http.ResponseWriter, r *http
 		return
 	}
 
+	ctx, s3Error = listBarrierContext(ctx, objectAPI, bucket, prefix, r.Header)
+	if s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+		return
+	}
+
 	listObjects := objectAPI.ListObjects
 
 	// Inititate a list objects operation based on the input params.
@@ -351,3 +461,227 @@ func (api objectAPIHandlers) ListObjectsV1Handler(w http.ResponseWriter, r *http
 	// Write success response.
 	writeSuccessResponseXML(w, encodeResponse(response))
 }
+
+// TagSearchResult - response of the tag search MinIO extension API.
+type TagSearchResult struct {
+	XMLName xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ TagSearchResult" json:"-"`
+
+	Name        string
+	Prefix      string
+	Marker      string
+	NextMarker  string `xml:"NextMarker,omitempty"`
+	MaxKeys     int
+	IsTruncated bool
+
+	// Time the tag index was last updated, objects tagged since are
+	// not found.
+	IndexUpdated string
+
+	EncodingType string `xml:"EncodingType,omitempty"`
+
+	Contents []TagSearchObject
+}
+
+// TagSearchObject - object matching a tag search.
+type TagSearchObject struct {
+	Key  string
+	Tags []TagSearchTag `xml:"TagSet>Tag"`
+}
+
+// TagSearchTag - tag of an object matching a tag search.
+type TagSearchTag struct {
+	Key   string
+	Value string
+}
+
+// ListObjectsByTagsHandler - GET Bucket?tag-search&tag=key=value
+// ----------
+// MinIO extension API returning the objects whose tags match all given
+// key=value pairs, served from the tag index of the bucket. Only objects
+// whose tags the request is allowed to read are returned.
+func (api objectAPIHandlers) ListObjectsByTagsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ListObjectsByTags")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	// Tag search reveals object names, the tags of each object are
+	// authorized below.
+	if s3Error := checkRequestAuthType(ctx, r, policy.ListBucketAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+		return
+	}
+
+	prefix, marker, _, maxKeys, encodingType, errCode := getListObjectsV1Args(r.Form)
+	if errCode != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(errCode), r.URL)
+		return
+	}
+	if s3Error := validateListObjectsArgs(marker, "", encodingType, maxKeys); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+		return
+	}
+	if maxKeys == 0 || maxKeys > maxObjectList {
+		maxKeys = maxObjectList
+	}
+
+	query, err := parseTagQuery(r.Form["tag"])
+	if err != nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErrWithErr(ErrInvalidRequest, err), r.URL)
+		return
+	}
+
+	if _, err = objectAPI.GetBucketInfo(ctx, bucket, BucketOptions{}); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	allow := func(object string) bool {
+		return checkRequestAuthType(ctx, r, policy.GetObjectTaggingAction, bucket, object) == ErrNone
+	}
+	res, err := searchTagIndex(ctx, objectAPI, bucket, query, prefix, marker, maxKeys, allow)
+	if err != nil {
+		if errors.Is(err, errTagIndexNotFound) {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrTagIndexNotFound), r.URL)
+			return
+		}
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	response := TagSearchResult{
+		Name:         bucket,
+		Prefix:       s3EncodeName(prefix, encodingType),
+		Marker:       s3EncodeName(marker, encodingType),
+		MaxKeys:      maxKeys,
+		IsTruncated:  res.truncated,
+		IndexUpdated: res.updated.UTC().Format(iso8601TimeFormat),
+		EncodingType: s3EncodeName(encodingType, ""),
+		Contents:     make([]TagSearchObject, 0, len(res.entries)),
+	}
+	for _, e := range res.entries {
+		obj := TagSearchObject{Key: s3EncodeName(e.Name, encodingType)}
+		for k, v := range e.Tags {
+			obj.Tags = append(obj.Tags, TagSearchTag{Key: k, Value: v})
+		}
+		sort.Slice(obj.Tags, func(i, j int) bool { return obj.Tags[i].Key < obj.Tags[j].Key })
+		response.Contents = append(response.Contents, obj)
+	}
+	if res.truncated {
+		response.NextMarker = s3EncodeName(res.next, encodingType)
+	}
+
+	writeSuccessResponseXML(w, encodeResponse(response))
+}
+
+// MetadataSearchResult - response of the metadata search MinIO extension API.
+type MetadataSearchResult struct {
+	XMLName xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ MetadataSearchResult" json:"-"`
+
+	Name        string
+	Query       string
+	Marker      string
+	NextMarker  string `xml:"NextMarker,omitempty"`
+	MaxKeys     int
+	IsTruncated bool
+
+	Contents []MetadataSearchObject
+}
+
+// MetadataSearchObject - object matching a metadata search.
+type MetadataSearchObject struct {
+	Key          string
+	VersionID    string `xml:"VersionId,omitempty"`
+	LastModified string
+	ETag         string `xml:"ETag,omitempty"`
+	Size         int64
+}
+
+// MetadataSearchHandler - GET Bucket?metadata-search&q=query
+// ----------
+// MinIO extension API proxying an object search to the external metadata
+// indexer of the bucket, the query syntax is defined by the indexer.
+func (api objectAPIHandlers) MetadataSearchHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "MetadataSearch")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.ListBucketAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+		return
+	}
+
+	_, marker, _, maxKeys, _, errCode := getListObjectsV1Args(r.Form)
+	if errCode != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(errCode), r.URL)
+		return
+	}
+	if maxKeys < 0 {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidMaxKeys), r.URL)
+		return
+	}
+	if maxKeys == 0 || maxKeys > maxObjectList {
+		maxKeys = maxObjectList
+	}
+	query := r.Form.Get("q")
+
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket, BucketOptions{}); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	cfg, _, err := globalBucketMetadataSys.GetIndexerConfig(bucket)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+	if cfg == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrIndexerNotConfigured), r.URL)
+		return
+	}
+
+	result, err := globalMetadataIndexer.search(ctx, cfg, bucket, query, marker, maxKeys)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrIndexerUnavailable), r.URL)
+		return
+	}
+
+	response := MetadataSearchResult{
+		Name:        bucket,
+		Query:       query,
+		Marker:      marker,
+		NextMarker:  result.NextMarker,
+		MaxKeys:     maxKeys,
+		IsTruncated: result.IsTruncated,
+		Contents:    make([]MetadataSearchObject, 0, len(result.Objects)),
+	}
+	for _, o := range result.Objects {
+		response.Contents = append(response.Contents, MetadataSearchObject{
+			Key:          o.Key,
+			VersionID:    o.VersionID,
+			LastModified: o.LastModified.UTC().Format(iso8601TimeFormat),
+			ETag:         o.ETag,
+			Size:         o.Size,
+		})
+	}
+
+	writeSuccessResponseXML(w, encodeResponse(response))
+}
@@ -0,0 +1,161 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/minio/pkg/bucket/policy"
+	"github.com/qkbyte/minio/internal/bucket/logging"
+	"github.com/qkbyte/minio/internal/logger"
+)
+
+// Bucket logging policy actions, these are not part of the policy
+// package yet, statements matching them with a wildcard such as
+// "s3:*" or "s3:PutBucket*" grant or deny them.
+const (
+	// getBucketLoggingAction - GetBucketLogging Rest API action.
+	getBucketLoggingAction policy.Action = "s3:GetBucketLogging"
+
+	// putBucketLoggingAction - PutBucketLogging Rest API action.
+	putBucketLoggingAction policy.Action = "s3:PutBucketLogging"
+)
+
+// PutBucketLoggingHandler - PUT Bucket logging.
+// ----------
+// Sets the server access logging configuration of the bucket, an
+// empty BucketLoggingStatus disables access logging.
+func (api objectAPIHandlers) PutBucketLoggingHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "PutBucketLogging")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	if s3Error := checkRequestAuthType(ctx, r, putBucketLoggingAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+		return
+	}
+
+	// Check if bucket exists.
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket, BucketOptions{}); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	config, err := logging.ParseConfig(io.LimitReader(r.Body, r.ContentLength))
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	if !config.Enabled() {
+		if _, err = globalBucketMetadataSys.Update(ctx, bucket, bucketLoggingConfig, nil); err != nil {
+			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+			return
+		}
+		writeSuccessResponseHeadersOnly(w)
+		return
+	}
+
+	// The target bucket must exist on this deployment.
+	if _, err = objectAPI.GetBucketInfo(ctx, config.LoggingEnabled.TargetBucket, BucketOptions{}); err != nil {
+		if errors.As(err, &BucketNotFound{}) {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidTargetBucketForLogging), r.URL)
+			return
+		}
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	configData, err := xml.Marshal(config)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	if _, err = globalBucketMetadataSys.Update(ctx, bucket, bucketLoggingConfig, configData); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	// Write success response.
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// GetBucketLoggingHandler - GET Bucket logging.
+// ----------
+// Returns an empty BucketLoggingStatus when access logging
+// is not enabled, same as AWS S3.
+func (api objectAPIHandlers) GetBucketLoggingHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetBucketLogging")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	if s3Error := checkRequestAuthType(ctx, r, getBucketLoggingAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+		return
+	}
+
+	// Validate if bucket exists, before proceeding further...
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket, BucketOptions{}); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	config, _, err := globalBucketMetadataSys.GetLoggingConfig(bucket)
+	if err != nil {
+		if !errors.As(err, &BucketLoggingNotFound{}) {
+			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+			return
+		}
+		config = &logging.Config{}
+	}
+
+	configData, err := xml.Marshal(logging.Config{
+		XMLNS:          "http://s3.amazonaws.com/doc/2006-03-01/",
+		LoggingEnabled: config.LoggingEnabled,
+	})
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	// Write success response.
+	writeSuccessResponseXML(w, configData)
+}
@@ -0,0 +1,174 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/minio/madmin-go"
+	"github.com/qkbyte/minio/internal/logger"
+)
+
+// globalBucketMetadataHealInterval is the interval at which the config
+// objects of all buckets are verified and repaired, zero disables it.
+var globalBucketMetadataHealInterval = time.Hour
+
+var bucketMetadataHealLockTimeout = newDynamicTimeoutWithOpts(dynamicTimeoutOpts{
+	timeout:       30 * time.Second,
+	minimum:       10 * time.Second,
+	retryInterval: time.Second,
+})
+
+// bucketConfigObjects returns the config objects kept for bucket under
+// .minio.sys/buckets/<bucket>/.
+func bucketConfigObjects(bucket string) []string {
+	objects := make([]string, 0, len(legacyBucketConfigs)+2)
+	objects = append(objects, pathJoin(bucketMetaPrefix, bucket, bucketMetadataFile))
+	for _, config := range legacyBucketConfigs {
+		objects = append(objects, pathJoin(bucketMetaPrefix, bucket, config))
+	}
+	return append(objects, pathJoin(bucketMetaPrefix, bucket, replicationDir, resyncFileName))
+}
+
+// healResultRepaired returns true if a drive that was missing or held a
+// stale or corrupt copy of the healed item is now in sync.
+func healResultRepaired(res madmin.HealResultItem) bool {
+	for i, before := range res.Before.Drives {
+		if i >= len(res.After.Drives) {
+			break
+		}
+		if before.State != madmin.DriveStateOk && res.After.Drives[i].State == madmin.DriveStateOk {
+			return true
+		}
+	}
+	return false
+}
+
+// healBucketMetadata verifies that all config objects of bucket have
+// quorum and are in sync across the drives of their set, stale or missing
+// copies are repaired. Returns true if bucketMetadataFile was repaired.
+func healBucketMetadata(ctx context.Context, objAPI ObjectLayer, bucket string, opts madmin.HealOpts) (repaired bool, err error) {
+	opts.Recreate = false
+	metaFile := pathJoin(bucketMetaPrefix, bucket, bucketMetadataFile)
+	for _, object := range bucketConfigObjects(bucket) {
+		res, herr := objAPI.HealObject(ctx, minioMetaBucket, object, "", opts)
+		if herr != nil {
+			if isErrObjectNotFound(herr) || isErrVersionNotFound(herr) {
+				continue
+			}
+			if err == nil {
+				err = fmt.Errorf("unable to heal %s/%s: %w", minioMetaBucket, object, herr)
+			}
+			continue
+		}
+		if object == metaFile && !opts.DryRun && healResultRepaired(res) {
+			repaired = true
+		}
+	}
+	return repaired, err
+}
+
+// reloadBucketMetadata reloads the metadata of bucket on this node and
+// all peers, so that no node keeps serving a stale copy.
+func reloadBucketMetadata(ctx context.Context, objAPI ObjectLayer, bucket string) {
+	meta, err := loadBucketMetadata(ctx, objAPI, bucket)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return
+	}
+	globalBucketMetadataSys.Set(bucket, meta)
+	if meta.notificationConfig != nil {
+		globalEventNotifier.AddRulesMap(bucket, meta.notificationConfig.ToRulesMap())
+	}
+	if meta.bucketTargetConfig != nil {
+		globalBucketTargetSys.UpdateAllTargets(bucket, meta.bucketTargetConfig)
+	}
+	if globalNotificationSys != nil {
+		globalNotificationSys.LoadBucketMetadata(ctx, bucket)
+	}
+}
+
+// initBucketMetadataHeal starts the periodic healing of bucket config
+// objects, a single leader node in the cluster performs it.
+func initBucketMetadataHeal(ctx context.Context, objAPI ObjectLayer) {
+	if globalBucketMetadataHealInterval <= 0 {
+		return
+	}
+	go func() {
+		r := rand.New(rand.NewSource(time.Now().UnixNano()))
+		for {
+			runBucketMetadataHeal(ctx, objAPI)
+
+			// healing running on a different node.
+			// sleep for some time and try again.
+			duration := time.Duration(r.Float64() * float64(time.Minute))
+			if duration < time.Second {
+				// Make sure to sleep atleast a second to avoid high CPU ticks.
+				duration = time.Second
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(duration):
+			}
+		}
+	}()
+}
+
+func runBucketMetadataHeal(ctx context.Context, objAPI ObjectLayer) {
+	// Make sure only one node heals bucket metadata on the cluster.
+	locker := objAPI.NewNSLock(minioMetaBucket, "buckets/heal-metadata.lock")
+	lkctx, err := locker.GetLock(ctx, bucketMetadataHealLockTimeout)
+	if err != nil {
+		return
+	}
+	ctx = lkctx.Context()
+	defer lkctx.Cancel()
+	// No unlock for "leader" lock.
+
+	timer := time.NewTimer(globalBucketMetadataHealInterval)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+			buckets, err := objAPI.ListBuckets(ctx, BucketOptions{})
+			if err != nil {
+				logger.LogIf(ctx, err)
+			}
+			for _, bucket := range buckets {
+				repaired, err := healBucketMetadata(ctx, objAPI, bucket.Name, madmin.HealOpts{
+					ScanMode: madmin.HealDeepScan,
+				})
+				if err != nil && !errors.Is(err, context.Canceled) {
+					logger.LogIf(ctx, err)
+				}
+				if repaired {
+					reloadBucketMetadata(ctx, objAPI, bucket.Name)
+				}
+			}
+			timer.Reset(globalBucketMetadataHealInterval)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/minio/madmin-go"
+)
+
+func TestHealResultRepaired(t *testing.T) {
+	drives := func(states ...string) []madmin.HealDriveInfo {
+		infos := make([]madmin.HealDriveInfo, len(states))
+		for i, state := range states {
+			infos[i].State = state
+		}
+		return infos
+	}
+	testCases := []struct {
+		before, after []madmin.HealDriveInfo
+		repaired      bool
+	}{
+		{drives(madmin.DriveStateOk, madmin.DriveStateOk), drives(madmin.DriveStateOk, madmin.DriveStateOk), false},
+		{drives(madmin.DriveStateOk, madmin.DriveStateMissing), drives(madmin.DriveStateOk, madmin.DriveStateOk), true},
+		{drives(madmin.DriveStateCorrupt, madmin.DriveStateOk), drives(madmin.DriveStateOk, madmin.DriveStateOk), true},
+		{drives(madmin.DriveStateOk, madmin.DriveStateOffline), drives(madmin.DriveStateOk, madmin.DriveStateOffline), false},
+		{drives(madmin.DriveStateMissing), nil, false},
+	}
+	for i, tc := range testCases {
+		res := madmin.HealResultItem{}
+		res.Before.Drives = tc.before
+		res.After.Drives = tc.after
+		if repaired := healResultRepaired(res); repaired != tc.repaired {
+			t.Errorf("Test %d: expected repaired %v, got %v", i+1, tc.repaired, repaired)
+		}
+	}
+}
+
+func TestHealBucketMetadata(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	resetGlobalHealState()
+	defer resetGlobalHealState()
+
+	fsDirs, err := getRandomDisks(16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+
+	objLayer, _, err := initObjectLayer(ctx, mustGetPoolEndpoints(fsDirs...))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bucket := getRandomBucketName()
+	if err = objLayer.MakeBucketWithLocation(ctx, bucket, MakeBucketOptions{}); err != nil {
+		t.Fatalf("Failed to make a bucket - %v", err)
+	}
+
+	metaPath := func(dir string) string {
+		return filepath.Join(dir, minioMetaBucket, bucketMetaPrefix, bucket, bucketMetadataFile, xlStorageFormatFile)
+	}
+	if err = os.RemoveAll(filepath.Dir(metaPath(fsDirs[0]))); err != nil {
+		t.Fatal(err)
+	}
+
+	// A dry run must not repair anything.
+	repaired, err := healBucketMetadata(ctx, objLayer, bucket, madmin.HealOpts{DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if repaired {
+		t.Fatal("expected dry run to not repair bucket metadata")
+	}
+	if _, err = os.Stat(metaPath(fsDirs[0])); !os.IsNotExist(err) {
+		t.Fatalf("expected bucket metadata to be missing after dry run, got %v", err)
+	}
+
+	repaired, err = healBucketMetadata(ctx, objLayer, bucket, madmin.HealOpts{ScanMode: madmin.HealDeepScan})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !repaired {
+		t.Fatal("expected bucket metadata to be repaired")
+	}
+	for _, dir := range fsDirs {
+		if _, err = os.Stat(metaPath(dir)); err != nil {
+			t.Fatalf("expected bucket metadata on %s: %v", dir, err)
+		}
+	}
+
+	// Nothing left to repair.
+	repaired, err = healBucketMetadata(ctx, objLayer, bucket, madmin.HealOpts{ScanMode: madmin.HealDeepScan})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if repaired {
+		t.Fatal("expected healthy bucket metadata to not be repaired")
+	}
+}
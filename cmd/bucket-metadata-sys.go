@@ -28,11 +28,14 @@ import (
 	"github.com/minio/madmin-go"
 	"github.com/minio/minio-go/v7/pkg/tags"
 	"github.com/minio/pkg/bucket/policy"
+	"github.com/qkbyte/minio/internal/bucket/cors"
 	bucketsse "github.com/qkbyte/minio/internal/bucket/encryption"
 	"github.com/qkbyte/minio/internal/bucket/lifecycle"
+	"github.com/qkbyte/minio/internal/bucket/logging"
 	objectlock "github.com/qkbyte/minio/internal/bucket/object/lock"
 	"github.com/qkbyte/minio/internal/bucket/replication"
 	"github.com/qkbyte/minio/internal/bucket/versioning"
+	"github.com/qkbyte/minio/internal/bucket/website"
 	"github.com/qkbyte/minio/internal/event"
 	"github.com/qkbyte/minio/internal/kms"
 	"github.com/qkbyte/minio/internal/logger"
@@ -143,6 +146,39 @@ func (sys *BucketMetadataSys) Update(ctx context.Context, bucket string, configF
 	case bucketReplicationConfig:
 		meta.ReplicationConfigXML = configData
 		meta.ReplicationConfigUpdatedAt = updatedAt
+	case bucketCorsConfig:
+		meta.CorsConfigXML = configData
+		meta.CorsConfigUpdatedAt = updatedAt
+	case bucketWebsiteConfig:
+		meta.WebsiteConfigXML = configData
+		meta.WebsiteConfigUpdatedAt = updatedAt
+	case bucketLoggingConfig:
+		meta.LoggingConfigXML = configData
+		meta.LoggingConfigUpdatedAt = updatedAt
+	case bucketTrashConfigFile:
+		meta.TrashConfigJSON = configData
+		meta.TrashConfigUpdatedAt = updatedAt
+	case bucketInlineConfigFile:
+		meta.InlineConfigJSON = configData
+		meta.InlineConfigUpdatedAt = updatedAt
+	case bucketSuspendConfigFile:
+		meta.SuspendConfigJSON = configData
+		meta.SuspendConfigUpdatedAt = updatedAt
+	case bucketIndexerConfigFile:
+		meta.IndexerConfigJSON = configData
+		meta.IndexerConfigUpdatedAt = updatedAt
+	case bucketRequestPaymentConfig:
+		meta.RequestPaymentConfigXML = configData
+		meta.RequestPaymentConfigUpdatedAt = updatedAt
+	case bucketShareLinksConfigFile:
+		meta.ShareLinksConfigJSON = configData
+		meta.ShareLinksConfigUpdatedAt = updatedAt
+	case bucketChangesFeedConfigFile:
+		meta.ChangesFeedConfigJSON = configData
+		meta.ChangesFeedConfigUpdatedAt = updatedAt
+	case bucketAuditManifestConfigFile:
+		meta.AuditManifestConfigJSON = configData
+		meta.AuditManifestConfigUpdatedAt = updatedAt
 	case bucketTargetsFile:
 		meta.BucketTargetsConfigJSON, meta.BucketTargetsConfigMetaJSON, err = encryptBucketMetadata(ctx, meta.Name, configData, kms.Context{
 			bucket:            meta.Name,
@@ -220,6 +256,176 @@ func (sys *BucketMetadataSys) GetTaggingConfig(bucket string) (*tags.Tags, time.
 	return meta.taggingConfig, meta.TaggingConfigUpdatedAt, nil
 }
 
+// GetCorsConfig returns configured CORS config, only the in-memory
+// bucket metadata is consulted since it is used for every request.
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetCorsConfig(bucket string) (*cors.Config, time.Time, error) {
+	meta, err := sys.Get(bucket)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return nil, time.Time{}, BucketCorsNotFound{Bucket: bucket}
+		}
+		return nil, time.Time{}, err
+	}
+	if meta.corsConfig == nil {
+		return nil, time.Time{}, BucketCorsNotFound{Bucket: bucket}
+	}
+	return meta.corsConfig, meta.CorsConfigUpdatedAt, nil
+}
+
+// GetWebsiteConfig returns configured static website config, only the
+// in-memory bucket metadata is consulted since it is used for every
+// anonymous request. The returned object may not be modified.
+func (sys *BucketMetadataSys) GetWebsiteConfig(bucket string) (*website.Config, time.Time, error) {
+	meta, err := sys.Get(bucket)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return nil, time.Time{}, BucketWebsiteNotFound{Bucket: bucket}
+		}
+		return nil, time.Time{}, err
+	}
+	if meta.websiteConfig == nil {
+		return nil, time.Time{}, BucketWebsiteNotFound{Bucket: bucket}
+	}
+	return meta.websiteConfig, meta.WebsiteConfigUpdatedAt, nil
+}
+
+// GetLoggingConfig returns configured server access logging config, only
+// the in-memory bucket metadata is consulted since it is looked up for
+// every audited request. The returned object may not be modified.
+func (sys *BucketMetadataSys) GetLoggingConfig(bucket string) (*logging.Config, time.Time, error) {
+	meta, err := sys.Get(bucket)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return nil, time.Time{}, BucketLoggingNotFound{Bucket: bucket}
+		}
+		return nil, time.Time{}, err
+	}
+	if meta.loggingConfig == nil {
+		return nil, time.Time{}, BucketLoggingNotFound{Bucket: bucket}
+	}
+	return meta.loggingConfig, meta.LoggingConfigUpdatedAt, nil
+}
+
+// GetTrashConfig returns the recycle bin config of the bucket, nil is
+// returned when the bucket has no recycle bin configured. Only the
+// in-memory bucket metadata is consulted since it is looked up for every
+// delete. The returned object may not be modified.
+func (sys *BucketMetadataSys) GetTrashConfig(bucket string) (*bucketTrashConfig, time.Time, error) {
+	meta, err := sys.Get(bucket)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return nil, time.Time{}, nil
+		}
+		return nil, time.Time{}, err
+	}
+	return meta.trashConfig, meta.TrashConfigUpdatedAt, nil
+}
+
+// GetInlineConfig returns the inline data threshold override of the
+// bucket, nil is returned when the bucket has none. Only the in-memory
+// bucket metadata is consulted since it is looked up for every write.
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetInlineConfig(bucket string) (*bucketInlineConfig, time.Time, error) {
+	meta, err := sys.Get(bucket)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return nil, time.Time{}, nil
+		}
+		return nil, time.Time{}, err
+	}
+	return meta.inlineConfig, meta.InlineConfigUpdatedAt, nil
+}
+
+// GetSuspendConfig returns the suspend configuration of the bucket, nil
+// is returned when the bucket is not suspended. Only the in-memory bucket
+// metadata is consulted since it is looked up for every request.
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetSuspendConfig(bucket string) (*bucketSuspendConfig, time.Time, error) {
+	meta, err := sys.Get(bucket)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return nil, time.Time{}, nil
+		}
+		return nil, time.Time{}, err
+	}
+	return meta.suspendConfig, meta.SuspendConfigUpdatedAt, nil
+}
+
+// GetIndexerConfig returns the external metadata indexer of the bucket,
+// nil is returned when the bucket has none. Only the in-memory bucket
+// metadata is consulted since it is looked up for every event.
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetIndexerConfig(bucket string) (*bucketIndexerConfig, time.Time, error) {
+	meta, err := sys.Get(bucket)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return nil, time.Time{}, nil
+		}
+		return nil, time.Time{}, err
+	}
+	return meta.indexerConfig, meta.IndexerConfigUpdatedAt, nil
+}
+
+// GetRequestPaymentConfig returns the request payment config of the
+// bucket, the default config where the bucket owner pays is returned when
+// the bucket has none. Only the in-memory bucket metadata is consulted
+// since it is looked up for every request.
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetRequestPaymentConfig(bucket string) (*requestPaymentConfig, time.Time, error) {
+	meta, err := sys.Get(bucket)
+	if err != nil && !errors.Is(err, errConfigNotFound) {
+		return nil, time.Time{}, err
+	}
+	if meta.requestPaymentConfig == nil {
+		return defaultRequestPaymentConfig, meta.RequestPaymentConfigUpdatedAt, nil
+	}
+	return meta.requestPaymentConfig, meta.RequestPaymentConfigUpdatedAt, nil
+}
+
+// GetShareLinksConfig returns the share links of the bucket, only the
+// in-memory bucket metadata is consulted since share link tokens are
+// validated for every anonymous request carrying one.
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetShareLinksConfig(bucket string) (*bucketShareLinksConfig, time.Time, error) {
+	meta, err := sys.Get(bucket)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	return meta.shareLinksConfig, meta.ShareLinksConfigUpdatedAt, nil
+}
+
+// GetChangesFeedConfig returns the changes feed settings of the bucket,
+// nil is returned when the feed is disabled. Only the in-memory bucket
+// metadata is consulted since it is looked up for every event.
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetChangesFeedConfig(bucket string) (*bucketChangesFeedConfig, time.Time, error) {
+	meta, err := sys.Get(bucket)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return nil, time.Time{}, nil
+		}
+		return nil, time.Time{}, err
+	}
+	return meta.changesFeedConfig, meta.ChangesFeedConfigUpdatedAt, nil
+}
+
+// GetAuditManifestConfig returns the audit manifest settings of the
+// bucket, nil is returned when the manifest is disabled. Only the
+// in-memory bucket metadata is consulted since it is looked up for
+// every event.
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetAuditManifestConfig(bucket string) (*bucketAuditManifestConfig, time.Time, error) {
+	meta, err := sys.Get(bucket)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return nil, time.Time{}, nil
+		}
+		return nil, time.Time{}, err
+	}
+	return meta.auditManifestConfig, meta.AuditManifestConfigUpdatedAt, nil
+}
+
 // GetObjectLockConfig returns configured object lock config
 // The returned object may not be modified.
 func (sys *BucketMetadataSys) GetObjectLockConfig(bucket string) (*objectlock.Config, time.Time, error) {
@@ -33,11 +33,14 @@ import (
 	"github.com/minio/minio-go/v7/pkg/tags"
 	"github.com/minio/pkg/bucket/policy"
 	"github.com/minio/sio"
+	"github.com/qkbyte/minio/internal/bucket/cors"
 	bucketsse "github.com/qkbyte/minio/internal/bucket/encryption"
 	"github.com/qkbyte/minio/internal/bucket/lifecycle"
+	"github.com/qkbyte/minio/internal/bucket/logging"
 	objectlock "github.com/qkbyte/minio/internal/bucket/object/lock"
 	"github.com/qkbyte/minio/internal/bucket/replication"
 	"github.com/qkbyte/minio/internal/bucket/versioning"
+	"github.com/qkbyte/minio/internal/bucket/website"
 	"github.com/qkbyte/minio/internal/crypto"
 	"github.com/qkbyte/minio/internal/event"
 	"github.com/qkbyte/minio/internal/fips"
@@ -67,27 +70,49 @@ var (
 // bucketMetadataFormat refers to the format.
 // bucketMetadataVersion can be used to track a rolling upgrade of a field.
 type BucketMetadata struct {
-	Name                        string
-	Created                     time.Time
-	LockEnabled                 bool // legacy not used anymore.
-	PolicyConfigJSON            []byte
-	NotificationConfigXML       []byte
-	LifecycleConfigXML          []byte
-	ObjectLockConfigXML         []byte
-	VersioningConfigXML         []byte
-	EncryptionConfigXML         []byte
-	TaggingConfigXML            []byte
-	QuotaConfigJSON             []byte
-	ReplicationConfigXML        []byte
-	BucketTargetsConfigJSON     []byte
-	BucketTargetsConfigMetaJSON []byte
-	PolicyConfigUpdatedAt       time.Time
-	ObjectLockConfigUpdatedAt   time.Time
-	EncryptionConfigUpdatedAt   time.Time
-	TaggingConfigUpdatedAt      time.Time
-	QuotaConfigUpdatedAt        time.Time
-	ReplicationConfigUpdatedAt  time.Time
-	VersioningConfigUpdatedAt   time.Time
+	Name                          string
+	Created                       time.Time
+	LockEnabled                   bool // legacy not used anymore.
+	PolicyConfigJSON              []byte
+	NotificationConfigXML         []byte
+	LifecycleConfigXML            []byte
+	ObjectLockConfigXML           []byte
+	VersioningConfigXML           []byte
+	EncryptionConfigXML           []byte
+	TaggingConfigXML              []byte
+	QuotaConfigJSON               []byte
+	ReplicationConfigXML          []byte
+	BucketTargetsConfigJSON       []byte
+	BucketTargetsConfigMetaJSON   []byte
+	CorsConfigXML                 []byte
+	WebsiteConfigXML              []byte
+	LoggingConfigXML              []byte
+	TrashConfigJSON               []byte
+	InlineConfigJSON              []byte
+	SuspendConfigJSON             []byte
+	IndexerConfigJSON             []byte
+	RequestPaymentConfigXML       []byte
+	ShareLinksConfigJSON          []byte
+	ChangesFeedConfigJSON         []byte
+	AuditManifestConfigJSON       []byte
+	PolicyConfigUpdatedAt         time.Time
+	ObjectLockConfigUpdatedAt     time.Time
+	EncryptionConfigUpdatedAt     time.Time
+	TaggingConfigUpdatedAt        time.Time
+	QuotaConfigUpdatedAt          time.Time
+	ReplicationConfigUpdatedAt    time.Time
+	VersioningConfigUpdatedAt     time.Time
+	CorsConfigUpdatedAt           time.Time
+	WebsiteConfigUpdatedAt        time.Time
+	LoggingConfigUpdatedAt        time.Time
+	TrashConfigUpdatedAt          time.Time
+	InlineConfigUpdatedAt         time.Time
+	SuspendConfigUpdatedAt        time.Time
+	IndexerConfigUpdatedAt        time.Time
+	RequestPaymentConfigUpdatedAt time.Time
+	ShareLinksConfigUpdatedAt     time.Time
+	ChangesFeedConfigUpdatedAt    time.Time
+	AuditManifestConfigUpdatedAt  time.Time
 
 	// Unexported fields. Must be updated atomically.
 	policyConfig           *policy.Policy
@@ -101,6 +126,17 @@ type BucketMetadata struct {
 	replicationConfig      *replication.Config
 	bucketTargetConfig     *madmin.BucketTargets
 	bucketTargetConfigMeta map[string]string
+	corsConfig             *cors.Config
+	websiteConfig          *website.Config
+	loggingConfig          *logging.Config
+	trashConfig            *bucketTrashConfig
+	inlineConfig           *bucketInlineConfig
+	suspendConfig          *bucketSuspendConfig
+	indexerConfig          *bucketIndexerConfig
+	requestPaymentConfig   *requestPaymentConfig
+	shareLinksConfig       *bucketShareLinksConfig
+	changesFeedConfig      *bucketChangesFeedConfig
+	auditManifestConfig    *bucketAuditManifestConfig
 }
 
 // newBucketMetadata creates BucketMetadata with the supplied name and Created to Now.
@@ -244,7 +280,7 @@ func (b *BucketMetadata) parseAllConfigs(ctx context.Context, objectAPI ObjectLa
 	}
 
 	if len(b.VersioningConfigXML) != 0 {
-		b.versioningConfig, err = versioning.ParseConfig(bytes.NewReader(b.VersioningConfigXML))
+		b.versioningConfig, err = versioning.ParseStoredConfig(bytes.NewReader(b.VersioningConfigXML))
 		if err != nil {
 			return err
 		}
@@ -257,6 +293,62 @@ func (b *BucketMetadata) parseAllConfigs(ctx context.Context, objectAPI ObjectLa
 		}
 	}
 
+	if len(b.TrashConfigJSON) != 0 {
+		b.trashConfig, err = parseBucketTrashConfig(b.TrashConfigJSON)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(b.InlineConfigJSON) != 0 {
+		b.inlineConfig, err = parseBucketInlineConfig(b.InlineConfigJSON)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(b.SuspendConfigJSON) != 0 {
+		b.suspendConfig, err = parseBucketSuspendConfig(b.SuspendConfigJSON)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(b.IndexerConfigJSON) != 0 {
+		b.indexerConfig, err = parseBucketIndexerConfig(b.IndexerConfigJSON)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(b.RequestPaymentConfigXML) != 0 {
+		b.requestPaymentConfig, err = parseRequestPaymentConfig(bytes.NewReader(b.RequestPaymentConfigXML))
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(b.ShareLinksConfigJSON) != 0 {
+		b.shareLinksConfig, err = parseBucketShareLinksConfig(b.ShareLinksConfigJSON)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(b.ChangesFeedConfigJSON) != 0 {
+		b.changesFeedConfig, err = parseBucketChangesFeedConfig(b.ChangesFeedConfigJSON)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(b.AuditManifestConfigJSON) != 0 {
+		b.auditManifestConfig, err = parseBucketAuditManifestConfig(b.AuditManifestConfigJSON)
+		if err != nil {
+			return err
+		}
+	}
+
 	if len(b.ReplicationConfigXML) != 0 {
 		b.replicationConfig, err = replication.ParseConfig(bytes.NewReader(b.ReplicationConfigXML))
 		if err != nil {
@@ -274,23 +366,52 @@ func (b *BucketMetadata) parseAllConfigs(ctx context.Context, objectAPI ObjectLa
 	} else {
 		b.bucketTargetConfig = &madmin.BucketTargets{}
 	}
+
+	if len(b.CorsConfigXML) != 0 {
+		b.corsConfig, err = cors.ParseConfig(bytes.NewReader(b.CorsConfigXML))
+		if err != nil {
+			return err
+		}
+	} else {
+		b.corsConfig = nil
+	}
+
+	if len(b.WebsiteConfigXML) != 0 {
+		b.websiteConfig, err = website.ParseConfig(bytes.NewReader(b.WebsiteConfigXML))
+		if err != nil {
+			return err
+		}
+	} else {
+		b.websiteConfig = nil
+	}
+
+	if len(b.LoggingConfigXML) != 0 {
+		b.loggingConfig, err = logging.ParseConfig(bytes.NewReader(b.LoggingConfigXML))
+		if err != nil {
+			return err
+		}
+	} else {
+		b.loggingConfig = nil
+	}
 	return nil
 }
 
-func (b *BucketMetadata) convertLegacyConfigs(ctx context.Context, objectAPI ObjectLayer) error {
-	legacyConfigs := []string{
-		legacyBucketObjectLockEnabledConfigFile,
-		bucketPolicyConfig,
-		bucketNotificationConfig,
-		bucketLifecycleConfig,
-		bucketQuotaConfigFile,
-		bucketSSEConfig,
-		bucketTaggingConfig,
-		bucketReplicationConfig,
-		bucketTargetsFile,
-		objectLockConfig,
-	}
+// legacyBucketConfigs are the per bucket config files used before
+// all bucket configs were merged into bucketMetadataFile.
+var legacyBucketConfigs = []string{
+	legacyBucketObjectLockEnabledConfigFile,
+	bucketPolicyConfig,
+	bucketNotificationConfig,
+	bucketLifecycleConfig,
+	bucketQuotaConfigFile,
+	bucketSSEConfig,
+	bucketTaggingConfig,
+	bucketReplicationConfig,
+	bucketTargetsFile,
+	objectLockConfig,
+}
 
+func (b *BucketMetadata) convertLegacyConfigs(ctx context.Context, objectAPI ObjectLayer) error {
 	configs := make(map[string][]byte)
 
 	// Handle migration from lockEnabled to newer format.
@@ -300,7 +421,7 @@ func (b *BucketMetadata) convertLegacyConfigs(ctx context.Context, objectAPI Obj
 		// we are only interested in b.ObjectLockConfigXML or objectLockConfig value
 	}
 
-	for _, legacyFile := range legacyConfigs {
+	for _, legacyFile := range legacyBucketConfigs {
 		configFile := path.Join(bucketMetaPrefix, b.Name, legacyFile)
 
 		configData, err := readConfig(ctx, objectAPI, configFile)
@@ -398,6 +519,50 @@ func (b *BucketMetadata) defaultTimestamps() {
 		b.ReplicationConfigUpdatedAt = b.Created
 	}
 
+	if b.CorsConfigUpdatedAt.IsZero() {
+		b.CorsConfigUpdatedAt = b.Created
+	}
+
+	if b.WebsiteConfigUpdatedAt.IsZero() {
+		b.WebsiteConfigUpdatedAt = b.Created
+	}
+
+	if b.LoggingConfigUpdatedAt.IsZero() {
+		b.LoggingConfigUpdatedAt = b.Created
+	}
+
+	if b.TrashConfigUpdatedAt.IsZero() {
+		b.TrashConfigUpdatedAt = b.Created
+	}
+
+	if b.InlineConfigUpdatedAt.IsZero() {
+		b.InlineConfigUpdatedAt = b.Created
+	}
+
+	if b.SuspendConfigUpdatedAt.IsZero() {
+		b.SuspendConfigUpdatedAt = b.Created
+	}
+
+	if b.IndexerConfigUpdatedAt.IsZero() {
+		b.IndexerConfigUpdatedAt = b.Created
+	}
+
+	if b.RequestPaymentConfigUpdatedAt.IsZero() {
+		b.RequestPaymentConfigUpdatedAt = b.Created
+	}
+
+	if b.ShareLinksConfigUpdatedAt.IsZero() {
+		b.ShareLinksConfigUpdatedAt = b.Created
+	}
+
+	if b.ChangesFeedConfigUpdatedAt.IsZero() {
+		b.ChangesFeedConfigUpdatedAt = b.Created
+	}
+
+	if b.AuditManifestConfigUpdatedAt.IsZero() {
+		b.AuditManifestConfigUpdatedAt = b.Created
+	}
+
 	if b.VersioningConfigUpdatedAt.IsZero() {
 		b.VersioningConfigUpdatedAt = b.Created
 	}
@@ -432,6 +597,8 @@ func deleteBucketMetadata(ctx context.Context, obj objectDeleter, bucket string)
 		dataUsageCacheName,
 		bucketMetadataFile,
 		path.Join(replicationDir, resyncFileName),
+		bucketTrashDir,
+		changesFeedPrefix,
 	}
 	for _, metaFile := range metadataFiles {
 		configFile := path.Join(bucketMetaPrefix, bucket, metaFile)
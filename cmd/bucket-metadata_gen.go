@@ -108,6 +108,72 @@ func (z *BucketMetadata) DecodeMsg(dc *msgp.Reader) (err error) {
 				err = msgp.WrapError(err, "BucketTargetsConfigMetaJSON")
 				return
 			}
+		case "CorsConfigXML":
+			z.CorsConfigXML, err = dc.ReadBytes(z.CorsConfigXML)
+			if err != nil {
+				err = msgp.WrapError(err, "CorsConfigXML")
+				return
+			}
+		case "WebsiteConfigXML":
+			z.WebsiteConfigXML, err = dc.ReadBytes(z.WebsiteConfigXML)
+			if err != nil {
+				err = msgp.WrapError(err, "WebsiteConfigXML")
+				return
+			}
+		case "LoggingConfigXML":
+			z.LoggingConfigXML, err = dc.ReadBytes(z.LoggingConfigXML)
+			if err != nil {
+				err = msgp.WrapError(err, "LoggingConfigXML")
+				return
+			}
+		case "TrashConfigJSON":
+			z.TrashConfigJSON, err = dc.ReadBytes(z.TrashConfigJSON)
+			if err != nil {
+				err = msgp.WrapError(err, "TrashConfigJSON")
+				return
+			}
+		case "InlineConfigJSON":
+			z.InlineConfigJSON, err = dc.ReadBytes(z.InlineConfigJSON)
+			if err != nil {
+				err = msgp.WrapError(err, "InlineConfigJSON")
+				return
+			}
+		case "SuspendConfigJSON":
+			z.SuspendConfigJSON, err = dc.ReadBytes(z.SuspendConfigJSON)
+			if err != nil {
+				err = msgp.WrapError(err, "SuspendConfigJSON")
+				return
+			}
+		case "IndexerConfigJSON":
+			z.IndexerConfigJSON, err = dc.ReadBytes(z.IndexerConfigJSON)
+			if err != nil {
+				err = msgp.WrapError(err, "IndexerConfigJSON")
+				return
+			}
+		case "RequestPaymentConfigXML":
+			z.RequestPaymentConfigXML, err = dc.ReadBytes(z.RequestPaymentConfigXML)
+			if err != nil {
+				err = msgp.WrapError(err, "RequestPaymentConfigXML")
+				return
+			}
+		case "ShareLinksConfigJSON":
+			z.ShareLinksConfigJSON, err = dc.ReadBytes(z.ShareLinksConfigJSON)
+			if err != nil {
+				err = msgp.WrapError(err, "ShareLinksConfigJSON")
+				return
+			}
+		case "ChangesFeedConfigJSON":
+			z.ChangesFeedConfigJSON, err = dc.ReadBytes(z.ChangesFeedConfigJSON)
+			if err != nil {
+				err = msgp.WrapError(err, "ChangesFeedConfigJSON")
+				return
+			}
+		case "AuditManifestConfigJSON":
+			z.AuditManifestConfigJSON, err = dc.ReadBytes(z.AuditManifestConfigJSON)
+			if err != nil {
+				err = msgp.WrapError(err, "AuditManifestConfigJSON")
+				return
+			}
 		case "PolicyConfigUpdatedAt":
 			z.PolicyConfigUpdatedAt, err = dc.ReadTime()
 			if err != nil {
@@ -150,6 +216,72 @@ func (z *BucketMetadata) DecodeMsg(dc *msgp.Reader) (err error) {
 				err = msgp.WrapError(err, "VersioningConfigUpdatedAt")
 				return
 			}
+		case "CorsConfigUpdatedAt":
+			z.CorsConfigUpdatedAt, err = dc.ReadTime()
+			if err != nil {
+				err = msgp.WrapError(err, "CorsConfigUpdatedAt")
+				return
+			}
+		case "WebsiteConfigUpdatedAt":
+			z.WebsiteConfigUpdatedAt, err = dc.ReadTime()
+			if err != nil {
+				err = msgp.WrapError(err, "WebsiteConfigUpdatedAt")
+				return
+			}
+		case "LoggingConfigUpdatedAt":
+			z.LoggingConfigUpdatedAt, err = dc.ReadTime()
+			if err != nil {
+				err = msgp.WrapError(err, "LoggingConfigUpdatedAt")
+				return
+			}
+		case "TrashConfigUpdatedAt":
+			z.TrashConfigUpdatedAt, err = dc.ReadTime()
+			if err != nil {
+				err = msgp.WrapError(err, "TrashConfigUpdatedAt")
+				return
+			}
+		case "InlineConfigUpdatedAt":
+			z.InlineConfigUpdatedAt, err = dc.ReadTime()
+			if err != nil {
+				err = msgp.WrapError(err, "InlineConfigUpdatedAt")
+				return
+			}
+		case "SuspendConfigUpdatedAt":
+			z.SuspendConfigUpdatedAt, err = dc.ReadTime()
+			if err != nil {
+				err = msgp.WrapError(err, "SuspendConfigUpdatedAt")
+				return
+			}
+		case "IndexerConfigUpdatedAt":
+			z.IndexerConfigUpdatedAt, err = dc.ReadTime()
+			if err != nil {
+				err = msgp.WrapError(err, "IndexerConfigUpdatedAt")
+				return
+			}
+		case "RequestPaymentConfigUpdatedAt":
+			z.RequestPaymentConfigUpdatedAt, err = dc.ReadTime()
+			if err != nil {
+				err = msgp.WrapError(err, "RequestPaymentConfigUpdatedAt")
+				return
+			}
+		case "ShareLinksConfigUpdatedAt":
+			z.ShareLinksConfigUpdatedAt, err = dc.ReadTime()
+			if err != nil {
+				err = msgp.WrapError(err, "ShareLinksConfigUpdatedAt")
+				return
+			}
+		case "ChangesFeedConfigUpdatedAt":
+			z.ChangesFeedConfigUpdatedAt, err = dc.ReadTime()
+			if err != nil {
+				err = msgp.WrapError(err, "ChangesFeedConfigUpdatedAt")
+				return
+			}
+		case "AuditManifestConfigUpdatedAt":
+			z.AuditManifestConfigUpdatedAt, err = dc.ReadTime()
+			if err != nil {
+				err = msgp.WrapError(err, "AuditManifestConfigUpdatedAt")
+				return
+			}
 		default:
 			err = dc.Skip()
 			if err != nil {
@@ -163,9 +295,9 @@ func (z *BucketMetadata) DecodeMsg(dc *msgp.Reader) (err error) {
 
 // EncodeMsg implements msgp.Encodable
 func (z *BucketMetadata) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 21
+	// map header, size 43
 	// write "Name"
-	err = en.Append(0xde, 0x0, 0x15, 0xa4, 0x4e, 0x61, 0x6d, 0x65)
+	err = en.Append(0xde, 0x0, 0x2b, 0xa4, 0x4e, 0x61, 0x6d, 0x65)
 	if err != nil {
 		return
 	}
@@ -304,6 +436,116 @@ func (z *BucketMetadata) EncodeMsg(en *msgp.Writer) (err error) {
 		err = msgp.WrapError(err, "BucketTargetsConfigMetaJSON")
 		return
 	}
+	// write "CorsConfigXML"
+	err = en.Append(0xad, 0x43, 0x6f, 0x72, 0x73, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x58, 0x4d, 0x4c)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes(z.CorsConfigXML)
+	if err != nil {
+		err = msgp.WrapError(err, "CorsConfigXML")
+		return
+	}
+	// write "WebsiteConfigXML"
+	err = en.Append(0xb0, 0x57, 0x65, 0x62, 0x73, 0x69, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x58, 0x4d, 0x4c)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes(z.WebsiteConfigXML)
+	if err != nil {
+		err = msgp.WrapError(err, "WebsiteConfigXML")
+		return
+	}
+	// write "LoggingConfigXML"
+	err = en.Append(0xb0, 0x4c, 0x6f, 0x67, 0x67, 0x69, 0x6e, 0x67, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x58, 0x4d, 0x4c)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes(z.LoggingConfigXML)
+	if err != nil {
+		err = msgp.WrapError(err, "LoggingConfigXML")
+		return
+	}
+	// write "TrashConfigJSON"
+	err = en.Append(0xaf, 0x54, 0x72, 0x61, 0x73, 0x68, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x4a, 0x53, 0x4f, 0x4e)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes(z.TrashConfigJSON)
+	if err != nil {
+		err = msgp.WrapError(err, "TrashConfigJSON")
+		return
+	}
+	// write "InlineConfigJSON"
+	err = en.Append(0xb0, 0x49, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x4a, 0x53, 0x4f, 0x4e)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes(z.InlineConfigJSON)
+	if err != nil {
+		err = msgp.WrapError(err, "InlineConfigJSON")
+		return
+	}
+	// write "SuspendConfigJSON"
+	err = en.Append(0xb1, 0x53, 0x75, 0x73, 0x70, 0x65, 0x6e, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x4a, 0x53, 0x4f, 0x4e)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes(z.SuspendConfigJSON)
+	if err != nil {
+		err = msgp.WrapError(err, "SuspendConfigJSON")
+		return
+	}
+	// write "IndexerConfigJSON"
+	err = en.Append(0xb1, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x4a, 0x53, 0x4f, 0x4e)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes(z.IndexerConfigJSON)
+	if err != nil {
+		err = msgp.WrapError(err, "IndexerConfigJSON")
+		return
+	}
+	// write "RequestPaymentConfigXML"
+	err = en.Append(0xb7, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x58, 0x4d, 0x4c)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes(z.RequestPaymentConfigXML)
+	if err != nil {
+		err = msgp.WrapError(err, "RequestPaymentConfigXML")
+		return
+	}
+	// write "ShareLinksConfigJSON"
+	err = en.Append(0xb4, 0x53, 0x68, 0x61, 0x72, 0x65, 0x4c, 0x69, 0x6e, 0x6b, 0x73, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x4a, 0x53, 0x4f, 0x4e)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes(z.ShareLinksConfigJSON)
+	if err != nil {
+		err = msgp.WrapError(err, "ShareLinksConfigJSON")
+		return
+	}
+	// write "ChangesFeedConfigJSON"
+	err = en.Append(0xb5, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x46, 0x65, 0x65, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x4a, 0x53, 0x4f, 0x4e)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes(z.ChangesFeedConfigJSON)
+	if err != nil {
+		err = msgp.WrapError(err, "ChangesFeedConfigJSON")
+		return
+	}
+	// write "AuditManifestConfigJSON"
+	err = en.Append(0xb7, 0x41, 0x75, 0x64, 0x69, 0x74, 0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x4a, 0x53, 0x4f, 0x4e)
+	if err != nil {
+		return
+	}
+	err = en.WriteBytes(z.AuditManifestConfigJSON)
+	if err != nil {
+		err = msgp.WrapError(err, "AuditManifestConfigJSON")
+		return
+	}
 	// write "PolicyConfigUpdatedAt"
 	err = en.Append(0xb5, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74)
 	if err != nil {
@@ -374,15 +616,125 @@ func (z *BucketMetadata) EncodeMsg(en *msgp.Writer) (err error) {
 		err = msgp.WrapError(err, "VersioningConfigUpdatedAt")
 		return
 	}
+	// write "CorsConfigUpdatedAt"
+	err = en.Append(0xb3, 0x43, 0x6f, 0x72, 0x73, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteTime(z.CorsConfigUpdatedAt)
+	if err != nil {
+		err = msgp.WrapError(err, "CorsConfigUpdatedAt")
+		return
+	}
+	// write "WebsiteConfigUpdatedAt"
+	err = en.Append(0xb6, 0x57, 0x65, 0x62, 0x73, 0x69, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteTime(z.WebsiteConfigUpdatedAt)
+	if err != nil {
+		err = msgp.WrapError(err, "WebsiteConfigUpdatedAt")
+		return
+	}
+	// write "LoggingConfigUpdatedAt"
+	err = en.Append(0xb6, 0x4c, 0x6f, 0x67, 0x67, 0x69, 0x6e, 0x67, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteTime(z.LoggingConfigUpdatedAt)
+	if err != nil {
+		err = msgp.WrapError(err, "LoggingConfigUpdatedAt")
+		return
+	}
+	// write "TrashConfigUpdatedAt"
+	err = en.Append(0xb4, 0x54, 0x72, 0x61, 0x73, 0x68, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteTime(z.TrashConfigUpdatedAt)
+	if err != nil {
+		err = msgp.WrapError(err, "TrashConfigUpdatedAt")
+		return
+	}
+	// write "InlineConfigUpdatedAt"
+	err = en.Append(0xb5, 0x49, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteTime(z.InlineConfigUpdatedAt)
+	if err != nil {
+		err = msgp.WrapError(err, "InlineConfigUpdatedAt")
+		return
+	}
+	// write "SuspendConfigUpdatedAt"
+	err = en.Append(0xb6, 0x53, 0x75, 0x73, 0x70, 0x65, 0x6e, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteTime(z.SuspendConfigUpdatedAt)
+	if err != nil {
+		err = msgp.WrapError(err, "SuspendConfigUpdatedAt")
+		return
+	}
+	// write "IndexerConfigUpdatedAt"
+	err = en.Append(0xb6, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteTime(z.IndexerConfigUpdatedAt)
+	if err != nil {
+		err = msgp.WrapError(err, "IndexerConfigUpdatedAt")
+		return
+	}
+	// write "RequestPaymentConfigUpdatedAt"
+	err = en.Append(0xbd, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteTime(z.RequestPaymentConfigUpdatedAt)
+	if err != nil {
+		err = msgp.WrapError(err, "RequestPaymentConfigUpdatedAt")
+		return
+	}
+	// write "ShareLinksConfigUpdatedAt"
+	err = en.Append(0xb9, 0x53, 0x68, 0x61, 0x72, 0x65, 0x4c, 0x69, 0x6e, 0x6b, 0x73, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteTime(z.ShareLinksConfigUpdatedAt)
+	if err != nil {
+		err = msgp.WrapError(err, "ShareLinksConfigUpdatedAt")
+		return
+	}
+	// write "ChangesFeedConfigUpdatedAt"
+	err = en.Append(0xba, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x46, 0x65, 0x65, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteTime(z.ChangesFeedConfigUpdatedAt)
+	if err != nil {
+		err = msgp.WrapError(err, "ChangesFeedConfigUpdatedAt")
+		return
+	}
+	// write "AuditManifestConfigUpdatedAt"
+	err = en.Append(0xbc, 0x41, 0x75, 0x64, 0x69, 0x74, 0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteTime(z.AuditManifestConfigUpdatedAt)
+	if err != nil {
+		err = msgp.WrapError(err, "AuditManifestConfigUpdatedAt")
+		return
+	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
 func (z *BucketMetadata) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 21
+	// map header, size 43
 	// string "Name"
-	o = append(o, 0xde, 0x0, 0x15, 0xa4, 0x4e, 0x61, 0x6d, 0x65)
+	o = append(o, 0xde, 0x0, 0x2b, 0xa4, 0x4e, 0x61, 0x6d, 0x65)
 	o = msgp.AppendString(o, z.Name)
 	// string "Created"
 	o = append(o, 0xa7, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64)
@@ -423,6 +775,39 @@ func (z *BucketMetadata) MarshalMsg(b []byte) (o []byte, err error) {
 	// string "BucketTargetsConfigMetaJSON"
 	o = append(o, 0xbb, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x4d, 0x65, 0x74, 0x61, 0x4a, 0x53, 0x4f, 0x4e)
 	o = msgp.AppendBytes(o, z.BucketTargetsConfigMetaJSON)
+	// string "CorsConfigXML"
+	o = append(o, 0xad, 0x43, 0x6f, 0x72, 0x73, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x58, 0x4d, 0x4c)
+	o = msgp.AppendBytes(o, z.CorsConfigXML)
+	// string "WebsiteConfigXML"
+	o = append(o, 0xb0, 0x57, 0x65, 0x62, 0x73, 0x69, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x58, 0x4d, 0x4c)
+	o = msgp.AppendBytes(o, z.WebsiteConfigXML)
+	// string "LoggingConfigXML"
+	o = append(o, 0xb0, 0x4c, 0x6f, 0x67, 0x67, 0x69, 0x6e, 0x67, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x58, 0x4d, 0x4c)
+	o = msgp.AppendBytes(o, z.LoggingConfigXML)
+	// string "TrashConfigJSON"
+	o = append(o, 0xaf, 0x54, 0x72, 0x61, 0x73, 0x68, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x4a, 0x53, 0x4f, 0x4e)
+	o = msgp.AppendBytes(o, z.TrashConfigJSON)
+	// string "InlineConfigJSON"
+	o = append(o, 0xb0, 0x49, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x4a, 0x53, 0x4f, 0x4e)
+	o = msgp.AppendBytes(o, z.InlineConfigJSON)
+	// string "SuspendConfigJSON"
+	o = append(o, 0xb1, 0x53, 0x75, 0x73, 0x70, 0x65, 0x6e, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x4a, 0x53, 0x4f, 0x4e)
+	o = msgp.AppendBytes(o, z.SuspendConfigJSON)
+	// string "IndexerConfigJSON"
+	o = append(o, 0xb1, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x4a, 0x53, 0x4f, 0x4e)
+	o = msgp.AppendBytes(o, z.IndexerConfigJSON)
+	// string "RequestPaymentConfigXML"
+	o = append(o, 0xb7, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x58, 0x4d, 0x4c)
+	o = msgp.AppendBytes(o, z.RequestPaymentConfigXML)
+	// string "ShareLinksConfigJSON"
+	o = append(o, 0xb4, 0x53, 0x68, 0x61, 0x72, 0x65, 0x4c, 0x69, 0x6e, 0x6b, 0x73, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x4a, 0x53, 0x4f, 0x4e)
+	o = msgp.AppendBytes(o, z.ShareLinksConfigJSON)
+	// string "ChangesFeedConfigJSON"
+	o = append(o, 0xb5, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x46, 0x65, 0x65, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x4a, 0x53, 0x4f, 0x4e)
+	o = msgp.AppendBytes(o, z.ChangesFeedConfigJSON)
+	// string "AuditManifestConfigJSON"
+	o = append(o, 0xb7, 0x41, 0x75, 0x64, 0x69, 0x74, 0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x4a, 0x53, 0x4f, 0x4e)
+	o = msgp.AppendBytes(o, z.AuditManifestConfigJSON)
 	// string "PolicyConfigUpdatedAt"
 	o = append(o, 0xb5, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74)
 	o = msgp.AppendTime(o, z.PolicyConfigUpdatedAt)
@@ -444,6 +829,39 @@ func (z *BucketMetadata) MarshalMsg(b []byte) (o []byte, err error) {
 	// string "VersioningConfigUpdatedAt"
 	o = append(o, 0xb9, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x69, 0x6e, 0x67, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74)
 	o = msgp.AppendTime(o, z.VersioningConfigUpdatedAt)
+	// string "CorsConfigUpdatedAt"
+	o = append(o, 0xb3, 0x43, 0x6f, 0x72, 0x73, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74)
+	o = msgp.AppendTime(o, z.CorsConfigUpdatedAt)
+	// string "WebsiteConfigUpdatedAt"
+	o = append(o, 0xb6, 0x57, 0x65, 0x62, 0x73, 0x69, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74)
+	o = msgp.AppendTime(o, z.WebsiteConfigUpdatedAt)
+	// string "LoggingConfigUpdatedAt"
+	o = append(o, 0xb6, 0x4c, 0x6f, 0x67, 0x67, 0x69, 0x6e, 0x67, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74)
+	o = msgp.AppendTime(o, z.LoggingConfigUpdatedAt)
+	// string "TrashConfigUpdatedAt"
+	o = append(o, 0xb4, 0x54, 0x72, 0x61, 0x73, 0x68, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74)
+	o = msgp.AppendTime(o, z.TrashConfigUpdatedAt)
+	// string "InlineConfigUpdatedAt"
+	o = append(o, 0xb5, 0x49, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74)
+	o = msgp.AppendTime(o, z.InlineConfigUpdatedAt)
+	// string "SuspendConfigUpdatedAt"
+	o = append(o, 0xb6, 0x53, 0x75, 0x73, 0x70, 0x65, 0x6e, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74)
+	o = msgp.AppendTime(o, z.SuspendConfigUpdatedAt)
+	// string "IndexerConfigUpdatedAt"
+	o = append(o, 0xb6, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74)
+	o = msgp.AppendTime(o, z.IndexerConfigUpdatedAt)
+	// string "RequestPaymentConfigUpdatedAt"
+	o = append(o, 0xbd, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74)
+	o = msgp.AppendTime(o, z.RequestPaymentConfigUpdatedAt)
+	// string "ShareLinksConfigUpdatedAt"
+	o = append(o, 0xb9, 0x53, 0x68, 0x61, 0x72, 0x65, 0x4c, 0x69, 0x6e, 0x6b, 0x73, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74)
+	o = msgp.AppendTime(o, z.ShareLinksConfigUpdatedAt)
+	// string "ChangesFeedConfigUpdatedAt"
+	o = append(o, 0xba, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x46, 0x65, 0x65, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74)
+	o = msgp.AppendTime(o, z.ChangesFeedConfigUpdatedAt)
+	// string "AuditManifestConfigUpdatedAt"
+	o = append(o, 0xbc, 0x41, 0x75, 0x64, 0x69, 0x74, 0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74)
+	o = msgp.AppendTime(o, z.AuditManifestConfigUpdatedAt)
 	return
 }
 
@@ -549,6 +967,72 @@ func (z *BucketMetadata) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				err = msgp.WrapError(err, "BucketTargetsConfigMetaJSON")
 				return
 			}
+		case "CorsConfigXML":
+			z.CorsConfigXML, bts, err = msgp.ReadBytesBytes(bts, z.CorsConfigXML)
+			if err != nil {
+				err = msgp.WrapError(err, "CorsConfigXML")
+				return
+			}
+		case "WebsiteConfigXML":
+			z.WebsiteConfigXML, bts, err = msgp.ReadBytesBytes(bts, z.WebsiteConfigXML)
+			if err != nil {
+				err = msgp.WrapError(err, "WebsiteConfigXML")
+				return
+			}
+		case "LoggingConfigXML":
+			z.LoggingConfigXML, bts, err = msgp.ReadBytesBytes(bts, z.LoggingConfigXML)
+			if err != nil {
+				err = msgp.WrapError(err, "LoggingConfigXML")
+				return
+			}
+		case "TrashConfigJSON":
+			z.TrashConfigJSON, bts, err = msgp.ReadBytesBytes(bts, z.TrashConfigJSON)
+			if err != nil {
+				err = msgp.WrapError(err, "TrashConfigJSON")
+				return
+			}
+		case "InlineConfigJSON":
+			z.InlineConfigJSON, bts, err = msgp.ReadBytesBytes(bts, z.InlineConfigJSON)
+			if err != nil {
+				err = msgp.WrapError(err, "InlineConfigJSON")
+				return
+			}
+		case "SuspendConfigJSON":
+			z.SuspendConfigJSON, bts, err = msgp.ReadBytesBytes(bts, z.SuspendConfigJSON)
+			if err != nil {
+				err = msgp.WrapError(err, "SuspendConfigJSON")
+				return
+			}
+		case "IndexerConfigJSON":
+			z.IndexerConfigJSON, bts, err = msgp.ReadBytesBytes(bts, z.IndexerConfigJSON)
+			if err != nil {
+				err = msgp.WrapError(err, "IndexerConfigJSON")
+				return
+			}
+		case "RequestPaymentConfigXML":
+			z.RequestPaymentConfigXML, bts, err = msgp.ReadBytesBytes(bts, z.RequestPaymentConfigXML)
+			if err != nil {
+				err = msgp.WrapError(err, "RequestPaymentConfigXML")
+				return
+			}
+		case "ShareLinksConfigJSON":
+			z.ShareLinksConfigJSON, bts, err = msgp.ReadBytesBytes(bts, z.ShareLinksConfigJSON)
+			if err != nil {
+				err = msgp.WrapError(err, "ShareLinksConfigJSON")
+				return
+			}
+		case "ChangesFeedConfigJSON":
+			z.ChangesFeedConfigJSON, bts, err = msgp.ReadBytesBytes(bts, z.ChangesFeedConfigJSON)
+			if err != nil {
+				err = msgp.WrapError(err, "ChangesFeedConfigJSON")
+				return
+			}
+		case "AuditManifestConfigJSON":
+			z.AuditManifestConfigJSON, bts, err = msgp.ReadBytesBytes(bts, z.AuditManifestConfigJSON)
+			if err != nil {
+				err = msgp.WrapError(err, "AuditManifestConfigJSON")
+				return
+			}
 		case "PolicyConfigUpdatedAt":
 			z.PolicyConfigUpdatedAt, bts, err = msgp.ReadTimeBytes(bts)
 			if err != nil {
@@ -591,6 +1075,72 @@ func (z *BucketMetadata) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				err = msgp.WrapError(err, "VersioningConfigUpdatedAt")
 				return
 			}
+		case "CorsConfigUpdatedAt":
+			z.CorsConfigUpdatedAt, bts, err = msgp.ReadTimeBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "CorsConfigUpdatedAt")
+				return
+			}
+		case "WebsiteConfigUpdatedAt":
+			z.WebsiteConfigUpdatedAt, bts, err = msgp.ReadTimeBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "WebsiteConfigUpdatedAt")
+				return
+			}
+		case "LoggingConfigUpdatedAt":
+			z.LoggingConfigUpdatedAt, bts, err = msgp.ReadTimeBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "LoggingConfigUpdatedAt")
+				return
+			}
+		case "TrashConfigUpdatedAt":
+			z.TrashConfigUpdatedAt, bts, err = msgp.ReadTimeBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "TrashConfigUpdatedAt")
+				return
+			}
+		case "InlineConfigUpdatedAt":
+			z.InlineConfigUpdatedAt, bts, err = msgp.ReadTimeBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "InlineConfigUpdatedAt")
+				return
+			}
+		case "SuspendConfigUpdatedAt":
+			z.SuspendConfigUpdatedAt, bts, err = msgp.ReadTimeBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "SuspendConfigUpdatedAt")
+				return
+			}
+		case "IndexerConfigUpdatedAt":
+			z.IndexerConfigUpdatedAt, bts, err = msgp.ReadTimeBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "IndexerConfigUpdatedAt")
+				return
+			}
+		case "RequestPaymentConfigUpdatedAt":
+			z.RequestPaymentConfigUpdatedAt, bts, err = msgp.ReadTimeBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "RequestPaymentConfigUpdatedAt")
+				return
+			}
+		case "ShareLinksConfigUpdatedAt":
+			z.ShareLinksConfigUpdatedAt, bts, err = msgp.ReadTimeBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ShareLinksConfigUpdatedAt")
+				return
+			}
+		case "ChangesFeedConfigUpdatedAt":
+			z.ChangesFeedConfigUpdatedAt, bts, err = msgp.ReadTimeBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ChangesFeedConfigUpdatedAt")
+				return
+			}
+		case "AuditManifestConfigUpdatedAt":
+			z.AuditManifestConfigUpdatedAt, bts, err = msgp.ReadTimeBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "AuditManifestConfigUpdatedAt")
+				return
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -605,6 +1155,6 @@ func (z *BucketMetadata) UnmarshalMsg(bts []byte) (o []byte, err error) {
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
 func (z *BucketMetadata) Msgsize() (s int) {
-	s = 3 + 5 + msgp.StringPrefixSize + len(z.Name) + 8 + msgp.TimeSize + 12 + msgp.BoolSize + 17 + msgp.BytesPrefixSize + len(z.PolicyConfigJSON) + 22 + msgp.BytesPrefixSize + len(z.NotificationConfigXML) + 19 + msgp.BytesPrefixSize + len(z.LifecycleConfigXML) + 20 + msgp.BytesPrefixSize + len(z.ObjectLockConfigXML) + 20 + msgp.BytesPrefixSize + len(z.VersioningConfigXML) + 20 + msgp.BytesPrefixSize + len(z.EncryptionConfigXML) + 17 + msgp.BytesPrefixSize + len(z.TaggingConfigXML) + 16 + msgp.BytesPrefixSize + len(z.QuotaConfigJSON) + 21 + msgp.BytesPrefixSize + len(z.ReplicationConfigXML) + 24 + msgp.BytesPrefixSize + len(z.BucketTargetsConfigJSON) + 28 + msgp.BytesPrefixSize + len(z.BucketTargetsConfigMetaJSON) + 22 + msgp.TimeSize + 26 + msgp.TimeSize + 26 + msgp.TimeSize + 23 + msgp.TimeSize + 21 + msgp.TimeSize + 27 + msgp.TimeSize + 26 + msgp.TimeSize
+	s = 3 + 5 + msgp.StringPrefixSize + len(z.Name) + 8 + msgp.TimeSize + 12 + msgp.BoolSize + 17 + msgp.BytesPrefixSize + len(z.PolicyConfigJSON) + 22 + msgp.BytesPrefixSize + len(z.NotificationConfigXML) + 19 + msgp.BytesPrefixSize + len(z.LifecycleConfigXML) + 20 + msgp.BytesPrefixSize + len(z.ObjectLockConfigXML) + 20 + msgp.BytesPrefixSize + len(z.VersioningConfigXML) + 20 + msgp.BytesPrefixSize + len(z.EncryptionConfigXML) + 17 + msgp.BytesPrefixSize + len(z.TaggingConfigXML) + 16 + msgp.BytesPrefixSize + len(z.QuotaConfigJSON) + 21 + msgp.BytesPrefixSize + len(z.ReplicationConfigXML) + 24 + msgp.BytesPrefixSize + len(z.BucketTargetsConfigJSON) + 28 + msgp.BytesPrefixSize + len(z.BucketTargetsConfigMetaJSON) + 14 + msgp.BytesPrefixSize + len(z.CorsConfigXML) + 17 + msgp.BytesPrefixSize + len(z.WebsiteConfigXML) + 17 + msgp.BytesPrefixSize + len(z.LoggingConfigXML) + 16 + msgp.BytesPrefixSize + len(z.TrashConfigJSON) + 17 + msgp.BytesPrefixSize + len(z.InlineConfigJSON) + 18 + msgp.BytesPrefixSize + len(z.SuspendConfigJSON) + 18 + msgp.BytesPrefixSize + len(z.IndexerConfigJSON) + 24 + msgp.BytesPrefixSize + len(z.RequestPaymentConfigXML) + 21 + msgp.BytesPrefixSize + len(z.ShareLinksConfigJSON) + 22 + msgp.BytesPrefixSize + len(z.ChangesFeedConfigJSON) + 24 + msgp.BytesPrefixSize + len(z.AuditManifestConfigJSON) + 22 + msgp.TimeSize + 26 + msgp.TimeSize + 26 + msgp.TimeSize + 23 + msgp.TimeSize + 21 + msgp.TimeSize + 27 + msgp.TimeSize + 26 + msgp.TimeSize + 20 + msgp.TimeSize + 23 + msgp.TimeSize + 23 + msgp.TimeSize + 21 + msgp.TimeSize + 22 + msgp.TimeSize + 23 + msgp.TimeSize + 23 + msgp.TimeSize + 30 + msgp.TimeSize + 26 + msgp.TimeSize + 27 + msgp.TimeSize + 29 + msgp.TimeSize
 	return
 }
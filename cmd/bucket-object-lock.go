@@ -359,6 +359,53 @@ func checkPutObjectLockAllowed(ctx context.Context, rq *http.Request, bucket, ob
 	return mode, retainDate, legalHold, ErrNone
 }
 
+// Sources of object retention.
+const (
+	retentionSourceDefault  = "DEFAULT"
+	retentionSourceExplicit = "EXPLICIT"
+)
+
+// retentionInherited returns true if the retention mode of an object
+// written by r was inherited from the bucket default retention.
+func retentionInherited(r *http.Request, bucket string, mode objectlock.RetMode) bool {
+	if !mode.Valid() || objectlock.IsObjectLockRetentionRequested(r.Header) {
+		return false
+	}
+	cfg, err := globalBucketObjectLockSys.Get(bucket)
+	return err == nil && cfg.Validity > 0 && cfg.Mode == mode
+}
+
+// setRetentionSource records in metadata whether the retention of an
+// object written by r was inherited from the bucket default retention.
+func setRetentionSource(metadata map[string]string, r *http.Request, bucket string, mode objectlock.RetMode) {
+	if retentionInherited(r, bucket, mode) {
+		metadata[ReservedMetadataPrefixLower+ObjectLockRetentionInherited] = "true"
+	}
+}
+
+// getRetentionSource returns the source of the retention of an object,
+// an empty string is returned if the object has no retention.
+func getRetentionSource(meta map[string]string) string {
+	if !objectlock.GetObjectRetentionMeta(meta).Mode.Valid() {
+		return ""
+	}
+	if meta[ReservedMetadataPrefixLower+ObjectLockRetentionInherited] == "true" {
+		return retentionSourceDefault
+	}
+	return retentionSourceExplicit
+}
+
+// setRetentionSourceHeader sets the retention source response header if
+// requested, meta must already be filtered by the permissions of r.
+func setRetentionSourceHeader(w http.ResponseWriter, r *http.Request, meta map[string]string) {
+	if r.Header.Get(xhttp.MinIOIncludeRetentionSource) != "true" {
+		return
+	}
+	if source := getRetentionSource(meta); source != "" {
+		w.Header().Set(xhttp.MinIOObjectLockRetentionSource, source)
+	}
+}
+
 // NewBucketObjectLockSys returns initialized BucketObjectLockSys
 func NewBucketObjectLockSys() *BucketObjectLockSys {
 	return &BucketObjectLockSys{}
@@ -93,6 +93,9 @@ func getConditionValues(r *http.Request, lc string, username string, claims map[
 		signatureVersion = signV2Algorithm
 	case authTypeSigned, authTypePresigned, authTypeStreamingSigned, authTypePostPolicy:
 		signatureVersion = signV4Algorithm
+		if isRequestSignatureV4A(r) || isRequestPresignedSignatureV4A(r) {
+			signatureVersion = signV4AAlgorithm
+		}
 	}
 
 	var authtype string
@@ -64,16 +64,25 @@ func (sys *BucketQuotaSys) Init(objAPI ObjectLayer) {
 	})
 }
 
-// GetBucketUsageInfo return bucket usage info for a given bucket
-func (sys *BucketQuotaSys) GetBucketUsageInfo(bucket string) (BucketUsageInfo, error) {
+// getDataUsageInfo returns the cached data usage info of all buckets.
+func (sys *BucketQuotaSys) getDataUsageInfo() (DataUsageInfo, error) {
 	v, err := sys.bucketStorageCache.Get()
 	if err != nil {
-		return BucketUsageInfo{}, err
+		return DataUsageInfo{}, err
 	}
 
 	dui, ok := v.(DataUsageInfo)
 	if !ok {
-		return BucketUsageInfo{}, fmt.Errorf("internal error: Unexpected DUI data type: %T", v)
+		return DataUsageInfo{}, fmt.Errorf("internal error: Unexpected DUI data type: %T", v)
+	}
+	return dui, nil
+}
+
+// GetBucketUsageInfo return bucket usage info for a given bucket
+func (sys *BucketQuotaSys) GetBucketUsageInfo(bucket string) (BucketUsageInfo, error) {
+	dui, err := sys.getDataUsageInfo()
+	if err != nil {
+		return BucketUsageInfo{}, err
 	}
 
 	bui := dui.BucketsUsage[bucket]
@@ -124,5 +133,8 @@ func enforceBucketQuotaHard(ctx context.Context, bucket string, size int64) erro
 	if globalBucketQuotaSys == nil {
 		return nil
 	}
-	return globalBucketQuotaSys.enforceQuotaHard(ctx, bucket, size)
+	if err := globalBucketQuotaSys.enforceQuotaHard(ctx, bucket, size); err != nil {
+		return err
+	}
+	return enforceTenantQuota(ctx, bucket, size)
 }
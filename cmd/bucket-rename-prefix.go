@@ -0,0 +1,584 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"math/rand"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/qkbyte/minio/internal/config"
+	"github.com/qkbyte/minio/internal/crypto"
+	"github.com/qkbyte/minio/internal/kms"
+	"github.com/qkbyte/minio/internal/logger"
+)
+
+const (
+	// Limit number of objects renamed by a RenamePrefix call.
+	maxRenamePrefixObjects = 10000
+
+	// renamePrefixJournalDir holds the journals of prefix renames in progress.
+	renamePrefixJournalDir = minioConfigPrefix + "/rename-prefix"
+
+	// renamePrefixRecoveryInterval is the interval between scans for
+	// journals left behind by interrupted prefix renames.
+	renamePrefixRecoveryInterval = 10 * time.Minute
+
+	// States of a prefix rename journal, a pending rename is rolled
+	// back and a committed one rolled forward after an interruption.
+	renamePrefixPending   = "pending"
+	renamePrefixCommitted = "committed"
+
+	// renamePrefixID marks the copies written by a prefix rename with
+	// its journal ID, a rollback only deletes copies it wrote.
+	renamePrefixID = "rename-prefix-id"
+)
+
+var (
+	errRenameNotSupported              = errors.New("object cannot be renamed without copying its data")
+	errInvalidRenamePrefix             = errors.New("source and destination must be distinct, non-overlapping prefixes ending with '/'")
+	errRenamePrefixDestinationNotEmpty = errors.New("destination prefix is not empty")
+	errRenamePrefixTooManyObjects      = errors.New("too many objects below source prefix")
+)
+
+// RenamePrefixRequest - xml carrying the prefixes of a rename.
+type RenamePrefixRequest struct {
+	XMLName     xml.Name `xml:"RenamePrefix" json:"-"`
+	Source      string   `xml:"Source"`
+	Destination string   `xml:"Destination"`
+}
+
+// RenamePrefixResponse - response of a prefix rename.
+type RenamePrefixResponse struct {
+	XMLName xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ RenamePrefixResult" json:"-"`
+	Objects int      `xml:"Objects"`
+}
+
+// objectRenamer is implemented by object layers able to move an object
+// within a bucket without copying its data.
+type objectRenamer interface {
+	// renameObject moves srcObject to dstObject, errRenameNotSupported
+	// is returned when the layout or the object doesn't allow it.
+	renameObject(ctx context.Context, bucket, srcObject, dstObject string) (ObjectInfo, error)
+}
+
+// canRenameObjectDir returns true if an object can be moved along with
+// its metadata, sealed object keys are bound to the object name.
+func canRenameObjectDir(oi ObjectInfo) bool {
+	_, encrypted := crypto.IsEncrypted(oi.UserDefined)
+	return !encrypted && !oi.DeleteMarker
+}
+
+// renamePrefixJournal - journal of a prefix rename. Destination objects
+// are created first, the rename is committed by switching the state to
+// committed after which source objects are deleted.
+type renamePrefixJournal struct {
+	ID          string              `json:"id"`
+	Bucket      string              `json:"bucket"`
+	Source      string              `json:"source"`
+	Destination string              `json:"destination"`
+	State       string              `json:"state"`
+	Created     time.Time           `json:"created"`
+	Objects     []renamePrefixEntry `json:"objects"`
+}
+
+// renamePrefixEntry - an object of a prefix rename, relative to the
+// source and destination prefixes. VersionID is the version the copy
+// is created with in versioned buckets, ETag and ModTime identify the
+// source object copied or moved by the rename.
+type renamePrefixEntry struct {
+	Name      string    `json:"name"`
+	VersionID string    `json:"versionId,omitempty"`
+	ETag      string    `json:"etag,omitempty"`
+	ModTime   time.Time `json:"modTime"`
+}
+
+func (j *renamePrefixJournal) file() string {
+	return path.Join(renamePrefixJournalDir, j.ID+".json")
+}
+
+func (j *renamePrefixJournal) source(e renamePrefixEntry) string {
+	return j.Source + e.Name
+}
+
+func (j *renamePrefixJournal) destination(e renamePrefixEntry) string {
+	return j.Destination + e.Name
+}
+
+// matches returns true if oi is the source object of e, as it was
+// copied or moved by the rename.
+func (e renamePrefixEntry) matches(oi ObjectInfo) bool {
+	return e.ETag != "" && oi.ETag == e.ETag && oi.ModTime.Equal(e.ModTime)
+}
+
+// wrote returns true if the destination object oi of e was written by
+// the rename, either as a copy or as the moved source object.
+func (j *renamePrefixJournal) wrote(e renamePrefixEntry, oi ObjectInfo) bool {
+	if oi.UserDefined[ReservedMetadataPrefixLower+renamePrefixID] == j.ID {
+		return true
+	}
+	return e.matches(oi)
+}
+
+func saveRenamePrefixJournal(ctx context.Context, objAPI ObjectLayer, j *renamePrefixJournal) error {
+	data, err := json.Marshal(j)
+	if err != nil {
+		return err
+	}
+	file := j.file()
+	if GlobalKMS != nil {
+		data, err = config.EncryptBytes(GlobalKMS, data, kms.Context{
+			minioMetaBucket: path.Join(minioMetaBucket, file),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return saveConfig(ctx, objAPI, file, data)
+}
+
+func readRenamePrefixJournal(ctx context.Context, objAPI ObjectLayer, file string) (*renamePrefixJournal, error) {
+	data, err := readConfig(ctx, objAPI, file)
+	if err != nil {
+		return nil, err
+	}
+	if GlobalKMS != nil {
+		data, err = config.DecryptBytes(GlobalKMS, data, kms.Context{
+			minioMetaBucket: path.Join(minioMetaBucket, file),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	j := &renamePrefixJournal{}
+	if err = json.Unmarshal(data, j); err != nil {
+		return nil, err
+	}
+	return j, nil
+}
+
+// validRenamePrefixes returns true if src can be renamed to dst.
+func validRenamePrefixes(src, dst string) bool {
+	if !HasSuffix(src, SlashSeparator) || !HasSuffix(dst, SlashSeparator) {
+		return false
+	}
+	if !IsValidObjectPrefix(src) || !IsValidObjectPrefix(dst) {
+		return false
+	}
+	return !HasPrefix(src, dst) && !HasPrefix(dst, src)
+}
+
+// newRenamePrefixJournal lists the objects below src and returns the
+// journal renaming them to dst. The destination must be empty, callers
+// hold the namespace lock of src and dst so that concurrent renames
+// cannot fill it.
+func newRenamePrefixJournal(ctx context.Context, objAPI ObjectLayer, bucket, src, dst string) (*renamePrefixJournal, error) {
+	res, err := objAPI.ListObjects(ctx, bucket, dst, "", "", 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(res.Objects) > 0 || len(res.Prefixes) > 0 {
+		return nil, errRenamePrefixDestinationNotEmpty
+	}
+
+	j := &renamePrefixJournal{
+		ID:          mustGetUUID(),
+		Bucket:      bucket,
+		Source:      src,
+		Destination: dst,
+		State:       renamePrefixPending,
+		Created:     UTCNow(),
+	}
+	marker := ""
+	for {
+		res, err = objAPI.ListObjects(ctx, bucket, src, marker, "", maxObjectList)
+		if err != nil {
+			return nil, err
+		}
+		for _, obj := range res.Objects {
+			e := renamePrefixEntry{
+				Name:    strings.TrimPrefix(obj.Name, src),
+				ETag:    obj.ETag,
+				ModTime: obj.ModTime,
+			}
+			switch {
+			case globalBucketVersioningSys.PrefixEnabled(bucket, j.destination(e)):
+				e.VersionID = mustGetUUID()
+			case globalBucketVersioningSys.PrefixSuspended(bucket, j.destination(e)):
+				e.VersionID = nullVersionID
+			}
+			j.Objects = append(j.Objects, e)
+		}
+		if len(j.Objects) > maxRenamePrefixObjects {
+			return nil, errRenamePrefixTooManyObjects
+		}
+		if !res.IsTruncated {
+			break
+		}
+		marker = res.NextMarker
+	}
+	return j, nil
+}
+
+// hasNestedObjects returns the indexes of the objects of j which have
+// other objects below their name, moving the directory of such an
+// object would move the nested objects along.
+func (j *renamePrefixJournal) hasNestedObjects() map[int]bool {
+	names := make(map[string]int, len(j.Objects))
+	for i, e := range j.Objects {
+		names[e.Name] = i
+	}
+	nested := make(map[int]bool)
+	for _, e := range j.Objects {
+		name := e.Name
+		for {
+			idx := strings.LastIndex(strings.TrimSuffix(name, SlashSeparator), SlashSeparator)
+			if idx < 0 {
+				break
+			}
+			name = name[:idx]
+			for _, parent := range []string{name, name + SlashSeparator} {
+				if i, ok := names[parent]; ok {
+					nested[i] = true
+				}
+			}
+		}
+	}
+	return nested
+}
+
+// renamePrefix moves all objects below src to dst within bucket. Objects
+// of unversioned buckets are moved without copying their data when the
+// object layer supports it, other objects are copied and deleted once
+// all copies succeeded. Either all objects are renamed or none, the
+// journal of the rename lets an interrupted rename be rolled back or
+// forward. Returns the renamed objects.
+func renamePrefix(ctx context.Context, objAPI ObjectLayer, r *http.Request, j *renamePrefixJournal) ([]ObjectInfo, APIError) {
+	// The journal itself is locked while it's written, lock the rename
+	// by its journal ID.
+	lk := objAPI.NewNSLock(minioMetaBucket, path.Join(renamePrefixJournalDir, j.ID))
+	lkctx, err := lk.GetLock(ctx, globalOperationTimeout)
+	if err != nil {
+		return nil, toAPIError(ctx, err)
+	}
+	ctx = lkctx.Context()
+	defer lk.Unlock(lkctx.Cancel)
+
+	if err = saveRenamePrefixJournal(ctx, objAPI, j); err != nil {
+		return nil, toAPIError(ctx, err)
+	}
+
+	renamer, _ := objAPI.(objectRenamer)
+	nested := j.hasNestedObjects()
+	objInfos := make([]ObjectInfo, len(j.Objects))
+	moved := make([]bool, len(j.Objects))
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr APIError
+	)
+	failed := func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return firstErr.Code != ""
+	}
+	sem := make(chan struct{}, copyObjectsConcurrency)
+	for i := range j.Objects {
+		if failed() {
+			break
+		}
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			e := j.Objects[i]
+			if renamer != nil && e.VersionID == "" && !nested[i] {
+				oi, err := renamer.renameObject(ctx, j.Bucket, j.source(e), j.destination(e))
+				if err == nil {
+					objInfos[i], moved[i] = oi, true
+					// The moved object may have changed since it was listed.
+					j.Objects[i].ETag, j.Objects[i].ModTime = oi.ETag, oi.ModTime
+					return
+				}
+				if err != errRenameNotSupported {
+					mu.Lock()
+					if firstErr.Code == "" {
+						firstErr = toAPIError(ctx, err)
+					}
+					mu.Unlock()
+					return
+				}
+			}
+			oi, apiErr := copyRenamePrefixEntry(ctx, objAPI, r, j, i)
+			if apiErr.Code != "" {
+				mu.Lock()
+				if firstErr.Code == "" {
+					firstErr = apiErr
+				}
+				mu.Unlock()
+				return
+			}
+			objInfos[i] = oi
+		}(i)
+	}
+	wg.Wait()
+
+	if firstErr.Code != "" {
+		if err = rollbackRenamePrefix(ctx, objAPI, j); err != nil {
+			// The journal is kept, the rollback is retried in background.
+			logger.LogIf(ctx, err)
+			return nil, firstErr
+		}
+		logger.LogIf(ctx, deleteConfig(ctx, objAPI, j.file()))
+		return nil, firstErr
+	}
+
+	j.State = renamePrefixCommitted
+	if err = saveRenamePrefixJournal(ctx, objAPI, j); err != nil {
+		logger.LogIf(ctx, rollbackRenamePrefix(ctx, objAPI, j))
+		return nil, toAPIError(ctx, err)
+	}
+
+	// The rename is committed, sources left behind by a failure here are
+	// deleted in background.
+	if err = rollforwardRenamePrefix(ctx, objAPI, j); err != nil {
+		logger.LogIf(ctx, err)
+		return objInfos, noError
+	}
+	logger.LogIf(ctx, deleteConfig(ctx, objAPI, j.file()))
+	return objInfos, noError
+}
+
+// copyRenamePrefixEntry copies the i-th object of j to its destination.
+// The source is read locked while it's copied, its identity in the
+// journal is updated to the copied object so that the source is only
+// deleted by the rollforward if it wasn't overwritten meanwhile.
+func copyRenamePrefixEntry(ctx context.Context, objAPI ObjectLayer, r *http.Request, j *renamePrefixJournal, i int) (ObjectInfo, APIError) {
+	e := j.Objects[i]
+	src := j.source(e)
+	lk := objAPI.NewNSLock(j.Bucket, src)
+	lkctx, err := lk.GetRLock(ctx, globalOperationTimeout)
+	if err != nil {
+		return ObjectInfo{}, toAPIError(ctx, err)
+	}
+	ctx = lkctx.Context()
+	defer lk.RUnlock(lkctx.Cancel)
+
+	srcInfo, err := objAPI.GetObjectInfo(ctx, j.Bucket, src, ObjectOptions{NoLock: true})
+	if err != nil {
+		return ObjectInfo{}, toAPIError(ctx, err)
+	}
+	j.Objects[i].ETag, j.Objects[i].ModTime = srcInfo.ETag, srcInfo.ModTime
+
+	return copyObjectsEntry(ctx, objAPI, r, j.Bucket, CopyObjectsEntry{
+		SourceKey: src,
+		Key:       j.destination(e),
+	}, e.VersionID, map[string]string{
+		ReservedMetadataPrefixLower + renamePrefixID: j.ID,
+	})
+}
+
+// renamePrefixObjectExists returns true if object exists, errors other
+// than a missing object are returned.
+func renamePrefixObjectExists(ctx context.Context, objAPI ObjectLayer, bucket, object string) (bool, error) {
+	_, err := objAPI.GetObjectInfo(ctx, bucket, object, ObjectOptions{})
+	switch {
+	case err == nil:
+		return true, nil
+	case isErrObjectNotFound(err), isErrVersionNotFound(err), isErrMethodNotAllowed(err):
+		return false, nil
+	}
+	return false, err
+}
+
+// rollbackRenamePrefix undoes a pending rename, copies are deleted and
+// moved objects are moved back. Objects written concurrently below the
+// destination are kept.
+func rollbackRenamePrefix(ctx context.Context, objAPI ObjectLayer, j *renamePrefixJournal) error {
+	renamer, _ := objAPI.(objectRenamer)
+	for _, e := range j.Objects {
+		src, dst := j.source(e), j.destination(e)
+		if e.VersionID != "" && e.VersionID != nullVersionID {
+			// The version was generated for the copy, only the
+			// rename may have written it.
+			_, err := objAPI.DeleteObject(ctx, j.Bucket, dst, ObjectOptions{
+				VersionID: e.VersionID,
+			})
+			if err != nil && !isErrObjectNotFound(err) && !isErrVersionNotFound(err) {
+				return err
+			}
+			continue
+		}
+		oi, err := objAPI.GetObjectInfo(ctx, j.Bucket, dst, ObjectOptions{
+			VersionID: e.VersionID,
+		})
+		switch {
+		case isErrObjectNotFound(err), isErrVersionNotFound(err), isErrMethodNotAllowed(err):
+			continue
+		case err != nil:
+			return err
+		}
+		if !j.wrote(e, oi) {
+			continue
+		}
+		srcExists, err := renamePrefixObjectExists(ctx, objAPI, j.Bucket, src)
+		if err != nil {
+			return err
+		}
+		if !srcExists && renamer != nil && e.VersionID == "" {
+			if _, err = renamer.renameObject(ctx, j.Bucket, dst, src); err != nil {
+				return err
+			}
+			continue
+		}
+		_, err = objAPI.DeleteObject(ctx, j.Bucket, dst, ObjectOptions{
+			VersionID: e.VersionID,
+		})
+		if err != nil && !isErrObjectNotFound(err) && !isErrVersionNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// rollforwardRenamePrefix completes a committed rename by deleting the
+// source objects still present. Sources written after the rename
+// started are kept.
+func rollforwardRenamePrefix(ctx context.Context, objAPI ObjectLayer, j *renamePrefixJournal) error {
+	for _, e := range j.Objects {
+		if err := deleteRenamePrefixSource(ctx, objAPI, j, e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteRenamePrefixSource deletes the source object of e if it's still
+// the object the rename copied, the object is locked between the check
+// and the delete so that a concurrent write cannot be deleted.
+func deleteRenamePrefixSource(ctx context.Context, objAPI ObjectLayer, j *renamePrefixJournal, e renamePrefixEntry) error {
+	src := j.source(e)
+	lk := objAPI.NewNSLock(j.Bucket, src)
+	lkctx, err := lk.GetLock(ctx, globalDeleteOperationTimeout)
+	if err != nil {
+		return err
+	}
+	ctx = lkctx.Context()
+	defer lk.Unlock(lkctx.Cancel)
+
+	oi, err := objAPI.GetObjectInfo(ctx, j.Bucket, src, ObjectOptions{NoLock: true})
+	switch {
+	case isErrObjectNotFound(err), isErrVersionNotFound(err), isErrMethodNotAllowed(err):
+		return nil
+	case err != nil:
+		return err
+	}
+	if !e.matches(oi) {
+		return nil
+	}
+	_, err = objAPI.DeleteObject(ctx, j.Bucket, src, ObjectOptions{
+		Versioned:        globalBucketVersioningSys.PrefixEnabled(j.Bucket, src),
+		VersionSuspended: globalBucketVersioningSys.PrefixSuspended(j.Bucket, src),
+		NoLock:           true,
+	})
+	if err != nil && !isErrObjectNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// recoverRenamePrefixes rolls back or forward the prefix renames which
+// were interrupted. Journals of renames still in progress are locked
+// and skipped.
+func recoverRenamePrefixes(ctx context.Context, objAPI ObjectLayer) error {
+	prefix := renamePrefixJournalDir + SlashSeparator
+	marker := ""
+	for {
+		res, err := objAPI.ListObjects(ctx, minioMetaBucket, prefix, marker, "", maxObjectList)
+		if err != nil {
+			return err
+		}
+		for _, obj := range res.Objects {
+			if err = recoverRenamePrefix(ctx, objAPI, obj.Name); err != nil {
+				logger.LogIf(ctx, err)
+			}
+		}
+		if !res.IsTruncated {
+			return nil
+		}
+		marker = res.NextMarker
+	}
+}
+
+func recoverRenamePrefix(ctx context.Context, objAPI ObjectLayer, file string) error {
+	lk := objAPI.NewNSLock(minioMetaBucket, strings.TrimSuffix(file, ".json"))
+	lkctx, err := lk.GetLock(ctx, newDynamicTimeout(time.Second, time.Second))
+	if err != nil {
+		// Rename still in progress.
+		return nil
+	}
+	ctx = lkctx.Context()
+	defer lk.Unlock(lkctx.Cancel)
+
+	j, err := readRenamePrefixJournal(ctx, objAPI, file)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return nil
+		}
+		return err
+	}
+	switch j.State {
+	case renamePrefixCommitted:
+		err = rollforwardRenamePrefix(ctx, objAPI, j)
+	default:
+		err = rollbackRenamePrefix(ctx, objAPI, j)
+	}
+	if err != nil {
+		return err
+	}
+	return deleteConfig(ctx, objAPI, file)
+}
+
+func initRenamePrefixRecovery(ctx context.Context, objAPI ObjectLayer) {
+	go func() {
+		r := rand.New(rand.NewSource(time.Now().UnixNano()))
+		timer := time.NewTimer(time.Duration(r.Int63n(int64(renamePrefixRecoveryInterval / 10))))
+		defer timer.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-timer.C:
+				logger.LogIf(ctx, recoverRenamePrefixes(ctx, objAPI))
+				timer.Reset(renamePrefixRecoveryInterval)
+			}
+		}
+	}()
+}
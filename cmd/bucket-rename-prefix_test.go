@@ -0,0 +1,255 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestValidRenamePrefixes(t *testing.T) {
+	testCases := []struct {
+		src, dst string
+		valid    bool
+	}{
+		{"tmp/", "out/", true},
+		{"job/_temporary/0/", "job/out/", true},
+		{"tmp", "out/", false},
+		{"tmp/", "out", false},
+		{"tmp/", "tmp/", false},
+		{"tmp/", "tmp/out/", false},
+		{"out/tmp/", "out/", false},
+		{"", "out/", false},
+	}
+	for _, tc := range testCases {
+		if got := validRenamePrefixes(tc.src, tc.dst); got != tc.valid {
+			t.Errorf("%q -> %q: expected %v, got %v", tc.src, tc.dst, tc.valid, got)
+		}
+	}
+}
+
+func TestRenamePrefixFS(t *testing.T) {
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+	testRenamePrefix(t, objLayer)
+}
+
+func TestRenamePrefixErasure(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	objLayer, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+	testRenamePrefix(t, objLayer)
+}
+
+func testRenamePrefix(t *testing.T, objLayer ObjectLayer) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	setObjectLayer(objLayer)
+	defer setObjectLayer(nil)
+
+	if err := newTestConfig(globalMinioDefaultRegion, objLayer); err != nil {
+		t.Fatal(err)
+	}
+
+	initAllSubsystems()
+
+	const bucket = "bucket"
+	if err := objLayer.MakeBucketWithLocation(ctx, bucket, MakeBucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	objects := []string{"part-0", "part-1", "dir/part-2"}
+	putObjects := func(prefix string) {
+		for _, object := range objects {
+			data := []byte(prefix + object)
+			_, err := objLayer.PutObject(ctx, bucket, prefix+object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	checkObjects := func(prefix, content string, present bool) {
+		t.Helper()
+		for _, object := range objects {
+			var buf bytes.Buffer
+			err := GetObject(ctx, objLayer, bucket, prefix+object, 0, -1, &buf, "", ObjectOptions{})
+			if !present {
+				if !isErrObjectNotFound(err) {
+					t.Fatalf("%s: expected object to be absent, got %v", prefix+object, err)
+				}
+				continue
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if buf.String() != content+object {
+				t.Fatalf("%s: unexpected content %q", prefix+object, buf.String())
+			}
+		}
+	}
+	checkNoJournals := func() {
+		t.Helper()
+		res, err := objLayer.ListObjects(ctx, minioMetaBucket, renamePrefixJournalDir+SlashSeparator, "", "", 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(res.Objects) != 0 {
+			t.Fatalf("expected no journals, got %d", len(res.Objects))
+		}
+	}
+
+	putObjects("tmp/")
+	r := httptest.NewRequest(http.MethodPost, "/"+bucket+"?rename-prefix", nil)
+
+	j, err := newRenamePrefixJournal(ctx, objLayer, bucket, "tmp/", "out/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	objInfos, apiErr := renamePrefix(ctx, objLayer, r, j)
+	if apiErr.Code != "" {
+		t.Fatal(apiErr.Description)
+	}
+	if len(objInfos) != len(objects) {
+		t.Fatalf("expected %d objects, got %d", len(objects), len(objInfos))
+	}
+	checkObjects("tmp/", "", false)
+	checkObjects("out/", "tmp/", true)
+	checkNoJournals()
+
+	// The destination must be empty.
+	putObjects("tmp/")
+	if _, err = newRenamePrefixJournal(ctx, objLayer, bucket, "tmp/", "out/"); err != errRenamePrefixDestinationNotEmpty {
+		t.Fatalf("expected %v, got %v", errRenamePrefixDestinationNotEmpty, err)
+	}
+
+	// An interrupted pending rename is rolled back, objects written
+	// concurrently below the destination are kept.
+	j, err = newRenamePrefixJournal(ctx, objLayer, bucket, "tmp/", "new/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = saveRenamePrefixJournal(ctx, objLayer, j); err != nil {
+		t.Fatal(err)
+	}
+	const concurrent = "new/part-1"
+	for _, e := range j.Objects {
+		if j.destination(e) == concurrent {
+			continue
+		}
+		_, apiErr = copyObjectsEntry(ctx, objLayer, r, bucket, CopyObjectsEntry{
+			SourceKey: j.source(e),
+			Key:       j.destination(e),
+		}, e.VersionID, map[string]string{
+			ReservedMetadataPrefixLower + renamePrefixID: j.ID,
+		})
+		if apiErr.Code != "" {
+			t.Fatal(apiErr.Description)
+		}
+	}
+	data := []byte("concurrent")
+	if _, err = objLayer.PutObject(ctx, bucket, concurrent, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err = recoverRenamePrefixes(ctx, objLayer); err != nil {
+		t.Fatal(err)
+	}
+	checkObjects("tmp/", "tmp/", true)
+	for _, object := range objects {
+		_, err = objLayer.GetObjectInfo(ctx, bucket, "new/"+object, ObjectOptions{})
+		if "new/"+object == concurrent {
+			if err != nil {
+				t.Fatalf("%s: expected the concurrent write to be kept, got %v", concurrent, err)
+			}
+		} else if !isErrObjectNotFound(err) {
+			t.Fatalf("new/%s: expected object to be absent, got %v", object, err)
+		}
+	}
+	checkNoJournals()
+
+	// An interrupted committed rename is rolled forward.
+	putObjects("new/")
+	j.State = renamePrefixCommitted
+	if err = saveRenamePrefixJournal(ctx, objLayer, j); err != nil {
+		t.Fatal(err)
+	}
+	if err = recoverRenamePrefixes(ctx, objLayer); err != nil {
+		t.Fatal(err)
+	}
+	checkObjects("tmp/", "", false)
+	checkObjects("new/", "new/", true)
+	checkNoJournals()
+
+	// Sources overwritten after the rename started are not deleted
+	// by the roll forward.
+	putObjects("tmp/")
+	j, err = newRenamePrefixJournal(ctx, objLayer, bucket, "tmp/", "last/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	const overwritten = "tmp/part-0"
+	data = []byte("overwritten")
+	if _, err = objLayer.PutObject(ctx, bucket, overwritten, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	j.State = renamePrefixCommitted
+	if err = saveRenamePrefixJournal(ctx, objLayer, j); err != nil {
+		t.Fatal(err)
+	}
+	if err = recoverRenamePrefixes(ctx, objLayer); err != nil {
+		t.Fatal(err)
+	}
+	for _, object := range objects {
+		var buf bytes.Buffer
+		err = GetObject(ctx, objLayer, bucket, "tmp/"+object, 0, -1, &buf, "", ObjectOptions{})
+		if "tmp/"+object == overwritten {
+			if err != nil || buf.String() != "overwritten" {
+				t.Fatalf("%s: expected the overwrite to be kept, got %q, %v", overwritten, buf.String(), err)
+			}
+		} else if !isErrObjectNotFound(err) {
+			t.Fatalf("tmp/%s: expected object to be absent, got %v", object, err)
+		}
+	}
+	checkNoJournals()
+}
+
+func TestRenamePrefixNestedObjects(t *testing.T) {
+	j := &renamePrefixJournal{Objects: []renamePrefixEntry{
+		{Name: "a"},
+		{Name: "a/b"},
+		{Name: "c/"},
+		{Name: "c/d/e"},
+		{Name: "f"},
+	}}
+	nested := j.hasNestedObjects()
+	if len(nested) != 2 || !nested[0] || !nested[2] {
+		t.Fatalf("expected objects a and c/ to have nested objects, got %v", nested)
+	}
+}
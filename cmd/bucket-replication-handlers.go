@@ -90,6 +90,11 @@ func (api objectAPIHandlers) PutBucketReplicationConfigHandler(w http.ResponseWr
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
 		return
 	}
+	if vc, err := globalBucketVersioningSys.Get(bucket); err == nil {
+		for _, warning := range versioningReplicationWarnings(vc, replicationConfig) {
+			logger.LogIf(ctx, fmt.Errorf("bucket %s: %s", bucket, warning))
+		}
+	}
 
 	// Write success response.
 	writeSuccessResponseHeadersOnly(w)
@@ -0,0 +1,125 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// replicationHealDedupWindow is how long a version queued for
+	// replication heal is not queued again by listings and scanner passes.
+	replicationHealDedupWindow = 10 * time.Minute
+
+	// replicationHealDedupMaxEntries bounds the versions remembered per
+	// window, versions are queued without deduplication beyond it.
+	replicationHealDedupMaxEntries = 500000
+)
+
+// replicationHealDedup remembers the versions recently queued for
+// replication heal so repeated calls to queueReplicationHeal within
+// the window don't queue them again. Versions are remembered in two
+// generations of one window each, the older one is dropped as a whole
+// when the window elapses.
+type replicationHealDedup struct {
+	mu       sync.Mutex
+	window   time.Duration
+	current  map[string]time.Time
+	previous map[string]time.Time
+	rotated  time.Time
+
+	queued     uint64
+	suppressed uint64
+}
+
+var globalReplicationHealDedup = newReplicationHealDedup(replicationHealDedupWindow)
+
+func newReplicationHealDedup(window time.Duration) *replicationHealDedup {
+	return &replicationHealDedup{
+		window:   window,
+		current:  make(map[string]time.Time),
+		previous: make(map[string]time.Time),
+		rotated:  time.Now(),
+	}
+}
+
+func replicationHealDedupKey(bucket, object, versionID string) string {
+	return pathJoin(bucket, object) + "@" + versionID
+}
+
+// admit returns true if the version was not queued within the window.
+// Versions admitted must be recorded once enqueued.
+func (d *replicationHealDedup) admit(bucket, object, versionID string, now time.Time) bool {
+	key := replicationHealDedupKey(bucket, object, versionID)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.rotate(now)
+	t, ok := d.current[key]
+	if !ok {
+		t, ok = d.previous[key]
+	}
+	if ok && now.Sub(t) < d.window {
+		atomic.AddUint64(&d.suppressed, 1)
+		return false
+	}
+	return true
+}
+
+// record remembers the version as queued now.
+func (d *replicationHealDedup) record(bucket, object, versionID string, now time.Time) {
+	key := replicationHealDedupKey(bucket, object, versionID)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.rotate(now)
+	if len(d.current) < replicationHealDedupMaxEntries {
+		d.current[key] = now
+	}
+	atomic.AddUint64(&d.queued, 1)
+}
+
+// rotate starts a new generation once the window elapsed, forgetting
+// the versions queued before the previous one.
+func (d *replicationHealDedup) rotate(now time.Time) {
+	switch elapsed := now.Sub(d.rotated); {
+	case elapsed >= 2*d.window:
+		d.previous = make(map[string]time.Time)
+	case elapsed >= d.window:
+		d.previous = d.current
+	default:
+		return
+	}
+	d.current = make(map[string]time.Time)
+	d.rotated = now
+}
+
+// Queued returns the number of versions queued for replication heal.
+func (d *replicationHealDedup) Queued() uint64 {
+	return atomic.LoadUint64(&d.queued)
+}
+
+// Suppressed returns the number of replication heals not queued
+// because the version was already queued within the window.
+func (d *replicationHealDedup) Suppressed() uint64 {
+	return atomic.LoadUint64(&d.suppressed)
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReplicationHealDedup(t *testing.T) {
+	d := newReplicationHealDedup(time.Minute)
+	now := time.Now()
+
+	if !d.admit("bucket", "object", "v1", now) {
+		t.Fatal("expected first heal to be queued")
+	}
+	if !d.admit("bucket", "object", "v1", now) {
+		t.Fatal("expected heal which was not enqueued to be retried")
+	}
+	d.record("bucket", "object", "v1", now)
+	if d.admit("bucket", "object", "v1", now.Add(30*time.Second)) {
+		t.Fatal("expected repeated heal within the window to be suppressed")
+	}
+	if !d.admit("bucket", "object", "v2", now.Add(30*time.Second)) {
+		t.Fatal("expected heal of another version to be queued")
+	}
+	d.record("bucket", "object", "v2", now.Add(30*time.Second))
+	// v2 moved to the previous generation and is still remembered.
+	if d.admit("bucket", "object", "v2", now.Add(80*time.Second)) {
+		t.Fatal("expected heal within the window across generations to be suppressed")
+	}
+	if !d.admit("bucket", "object", "v1", now.Add(2*time.Minute)) {
+		t.Fatal("expected heal after the window to be queued")
+	}
+	d.record("bucket", "object", "v1", now.Add(2*time.Minute))
+	if d.Queued() != 3 || d.Suppressed() != 2 {
+		t.Fatalf("expected 3 queued and 2 suppressed, got %d and %d", d.Queued(), d.Suppressed())
+	}
+	// The expired generations are dropped as a whole.
+	d.admit("bucket", "object", "v3", now.Add(5*time.Minute))
+	if len(d.current)+len(d.previous) != 0 {
+		t.Fatalf("expected expired entries to be dropped, %d remain", len(d.current)+len(d.previous))
+	}
+}
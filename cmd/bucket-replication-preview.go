@@ -0,0 +1,98 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+
+	"github.com/qkbyte/minio/internal/bucket/replication"
+)
+
+// replicationDeleteCounts counts pending delete markers and pending
+// permanent (versioned) deletes.
+type replicationDeleteCounts struct {
+	DeleteMarkers  uint64 `json:"deleteMarkers"`
+	VersionDeletes uint64 `json:"versionDeletes"`
+}
+
+// replicationDeletePreview reports how many of the pending deletes of
+// a bucket would be replicated under a proposed replication config.
+type replicationDeletePreview struct {
+	Bucket    string                             `json:"bucket"`
+	Prefix    string                             `json:"prefix,omitempty"`
+	Pending   replicationDeleteCounts            `json:"pending"`
+	Replicate replicationDeleteCounts            `json:"replicate"`
+	Rules     map[string]replicationDeleteCounts `json:"rules,omitempty"`
+}
+
+// add evaluates a single object version against the proposed config.
+func (p *replicationDeletePreview) add(cfg *replication.Config, oi ObjectInfo) {
+	opts := replication.ObjectOpts{
+		Name:         oi.Name,
+		UserTags:     oi.UserTags,
+		DeleteMarker: oi.DeleteMarker,
+		OpType:       replication.DeleteReplicationType,
+	}
+	switch {
+	case oi.VersionPurgeStatus.Pending():
+		// Permanent delete of a version not yet propagated.
+		opts.VersionID = oi.VersionID
+		p.Pending.VersionDeletes++
+	case oi.DeleteMarker && oi.ReplicationStatus != replication.Completed && oi.ReplicationStatus != replication.Replica:
+		p.Pending.DeleteMarkers++
+	default:
+		return
+	}
+
+	if !cfg.Replicate(opts) {
+		return
+	}
+	// Replicate decides on the highest priority matching rule.
+	rule := cfg.FilterActionableRules(opts)[0]
+	counts := p.Rules[rule.ID]
+	if opts.VersionID != "" {
+		p.Replicate.VersionDeletes++
+		counts.VersionDeletes++
+	} else {
+		p.Replicate.DeleteMarkers++
+		counts.DeleteMarkers++
+	}
+	p.Rules[rule.ID] = counts
+}
+
+// previewDeleteReplication walks all versions of bucket under prefix and
+// reports the pending deletes that cfg would replicate, nothing is queued.
+func previewDeleteReplication(ctx context.Context, objAPI ObjectLayer, bucket, prefix string, cfg *replication.Config) (replicationDeletePreview, error) {
+	preview := replicationDeletePreview{
+		Bucket: bucket,
+		Prefix: prefix,
+		Rules:  make(map[string]replicationDeleteCounts),
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	objInfoCh := make(chan ObjectInfo)
+	if err := objAPI.Walk(ctx, bucket, prefix, objInfoCh, ObjectOptions{}); err != nil {
+		return preview, err
+	}
+	for oi := range objInfoCh {
+		preview.add(cfg, oi)
+	}
+	return preview, ctx.Err()
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/qkbyte/minio/internal/bucket/replication"
+)
+
+func TestReplicationDeletePreview(t *testing.T) {
+	const cfgXML = `<ReplicationConfiguration>` +
+		`<Rule><ID>legal</ID><Status>Enabled</Status><Priority>2</Priority>` +
+		`<DeleteMarkerReplication><Status>Enabled</Status></DeleteMarkerReplication>` +
+		`<DeleteReplication><Status>Enabled</Status></DeleteReplication>` +
+		`<Filter><Prefix>legal/</Prefix></Filter>` +
+		`<Destination><Bucket>arn:minio:replication:us-east-1:legal:dest</Bucket></Destination></Rule>` +
+		`<Rule><ID>keep</ID><Status>Enabled</Status><Priority>1</Priority>` +
+		`<DeleteMarkerReplication><Status>Disabled</Status></DeleteMarkerReplication>` +
+		`<DeleteReplication><Status>Disabled</Status></DeleteReplication>` +
+		`<Filter><Prefix>keep/</Prefix></Filter>` +
+		`<Destination><Bucket>arn:minio:replication:us-east-1:keep:dest</Bucket></Destination></Rule>` +
+		`</ReplicationConfiguration>`
+	cfg, err := replication.ParseConfig(strings.NewReader(cfgXML))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	preview := replicationDeletePreview{Rules: make(map[string]replicationDeleteCounts)}
+	for _, oi := range []ObjectInfo{
+		{Name: "legal/a", DeleteMarker: true},
+		{Name: "legal/b", DeleteMarker: true, ReplicationStatus: replication.Failed},
+		{Name: "legal/c", DeleteMarker: true, ReplicationStatus: replication.Completed},
+		{Name: "legal/d", VersionID: "v1", VersionPurgeStatus: Pending},
+		{Name: "keep/a", DeleteMarker: true},
+		{Name: "keep/b", VersionID: "v1", VersionPurgeStatus: Failed},
+		{Name: "other/a", DeleteMarker: true},
+		{Name: "legal/e"},
+	} {
+		preview.add(cfg, oi)
+	}
+
+	if preview.Pending != (replicationDeleteCounts{DeleteMarkers: 4, VersionDeletes: 2}) {
+		t.Fatalf("unexpected pending counts %+v", preview.Pending)
+	}
+	if preview.Replicate != (replicationDeleteCounts{DeleteMarkers: 2, VersionDeletes: 1}) {
+		t.Fatalf("unexpected replicate counts %+v", preview.Replicate)
+	}
+	if len(preview.Rules) != 1 || preview.Rules["legal"] != preview.Replicate {
+		t.Fatalf("unexpected per rule counts %+v", preview.Rules)
+	}
+}
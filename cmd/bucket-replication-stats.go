@@ -43,6 +43,102 @@ type ReplicationStats struct {
 	sync.RWMutex                 // mutex for Cache
 	ulock           sync.RWMutex // mutex for UsageCache
 	dlock           sync.RWMutex // mutex for mostRecentStats
+
+	// objects pending replication per bucket and target
+	backlog map[string]map[string]*replicationBacklog
+	block   sync.Mutex // mutex for backlog
+}
+
+// Maximum number of pending objects tracked per bucket target on a node,
+// objects queued beyond it are not tracked. Since objects are tracked in
+// queue order the oldest pending object is only missed when all tracked
+// ones are done before it.
+const replicationBacklogMaxEntries = 10000
+
+// replicationBacklog tracks the modification time of objects queued for
+// replication to a target until they are replicated or failed.
+type replicationBacklog struct {
+	entries map[string]time.Time
+}
+
+func (b *replicationBacklog) add(key string, modTime time.Time) {
+	if len(b.entries) >= replicationBacklogMaxEntries {
+		return
+	}
+	b.entries[key] = modTime
+}
+
+func (b *replicationBacklog) remove(key string) {
+	delete(b.entries, key)
+}
+
+// oldest returns the modification time of the oldest pending object.
+func (b *replicationBacklog) oldest() (t time.Time) {
+	for _, modTime := range b.entries {
+		if t.IsZero() || modTime.Before(t) {
+			t = modTime
+		}
+	}
+	return t
+}
+
+// earliest returns the earliest of the non zero times a and b.
+func earliest(a, b time.Time) time.Time {
+	if a.IsZero() || (!b.IsZero() && b.Before(a)) {
+		return b
+	}
+	return a
+}
+
+func replicationBacklogKey(object, versionID string) string {
+	return object + SlashSeparator + versionID
+}
+
+// trackPending records object version as queued for replication to arn.
+func (r *ReplicationStats) trackPending(bucket, arn, object, versionID string, modTime time.Time) {
+	if r == nil {
+		return
+	}
+	r.block.Lock()
+	defer r.block.Unlock()
+
+	targets, ok := r.backlog[bucket]
+	if !ok {
+		targets = make(map[string]*replicationBacklog)
+		r.backlog[bucket] = targets
+	}
+	b, ok := targets[arn]
+	if !ok {
+		b = &replicationBacklog{entries: make(map[string]time.Time)}
+		targets[arn] = b
+	}
+	b.add(replicationBacklogKey(object, versionID), modTime)
+}
+
+// untrackPending removes object version from the replication backlog of arn.
+func (r *ReplicationStats) untrackPending(bucket, arn, object, versionID string) {
+	if r == nil {
+		return
+	}
+	r.block.Lock()
+	defer r.block.Unlock()
+
+	if b, ok := r.backlog[bucket][arn]; ok {
+		b.remove(replicationBacklogKey(object, versionID))
+	}
+}
+
+// setOldestPending fills in the oldest pending object of each target of
+// bucket in bs.
+func (r *ReplicationStats) setOldestPending(bucket string, bs BucketReplicationStats) {
+	r.block.Lock()
+	defer r.block.Unlock()
+
+	for arn, b := range r.backlog[bucket] {
+		if st, ok := bs.Stats[arn]; ok {
+			st.OldestPending = b.oldest()
+		}
+	}
 }
 
 // Delete deletes in-memory replication statistics for a bucket.
@@ -58,6 +154,10 @@ func (r *ReplicationStats) Delete(bucket string) {
 	r.ulock.Lock()
 	defer r.ulock.Unlock()
 	delete(r.UsageCache, bucket)
+
+	r.block.Lock()
+	defer r.block.Unlock()
+	delete(r.backlog, bucket)
 }
 
 // UpdateReplicaStat updates in-memory replica statistics with new values.
@@ -161,6 +261,7 @@ func (r *ReplicationStats) GetAll() map[string]BucketReplicationStats {
 	bucketReplicationStats := make(map[string]BucketReplicationStats, len(r.Cache))
 	for k, v := range r.Cache {
 		bucketReplicationStats[k] = v.Clone()
+		r.setOldestPending(k, bucketReplicationStats[k])
 	}
 
 	return bucketReplicationStats
@@ -179,7 +280,9 @@ func (r *ReplicationStats) Get(bucket string) BucketReplicationStats {
 	if !ok {
 		return BucketReplicationStats{}
 	}
-	return st.Clone()
+	bs := st.Clone()
+	r.setOldestPending(bucket, bs)
+	return bs
 }
 
 // NewReplicationStats initialize in-memory replication statistics
@@ -187,6 +290,7 @@ func NewReplicationStats(ctx context.Context, objectAPI ObjectLayer) *Replicatio
 	return &ReplicationStats{
 		Cache:      make(map[string]*BucketReplicationStats),
 		UsageCache: make(map[string]*BucketReplicationStats),
+		backlog:    make(map[string]map[string]*replicationBacklog),
 	}
 }
 
@@ -297,6 +401,7 @@ func (r *ReplicationStats) calculateBucketReplicationStats(bucket string, u Buck
 				Latency:        stat.Latency.merge(oldst.Latency),
 				PendingCount:   stat.PendingCount + oldst.PendingCount,
 				PendingSize:    stat.PendingSize + oldst.PendingSize,
+				OldestPending:  earliest(stat.OldestPending, oldst.OldestPending),
 			}
 		}
 	}
@@ -346,6 +451,7 @@ func (r *ReplicationStats) calculateBucketReplicationStats(bucket string, u Buck
 		st.PendingSize = int64(math.Max(float64(tgtstat.PendingSize), 0))
 		st.PendingCount = int64(math.Max(float64(tgtstat.PendingCount), 0))
 		st.Latency = tgtstat.Latency
+		st.OldestPending = tgtstat.OldestPending
 
 		s.Stats[arn] = &st
 		s.FailedSize += st.FailedSize
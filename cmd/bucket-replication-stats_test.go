@@ -0,0 +1,105 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/qkbyte/minio/internal/bucket/replication"
+)
+
+func TestReplicationStatsOldestPending(t *testing.T) {
+	const (
+		bucket = "bucket"
+		arn    = "arn:minio:replication::1:target"
+	)
+	r := NewReplicationStats(context.Background(), nil)
+
+	older := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	newer := older.Add(time.Hour)
+	r.Update(bucket, arn, 10, 0, replication.Pending, "", replication.ObjectReplicationType)
+	r.Update(bucket, arn, 20, 0, replication.Pending, "", replication.ObjectReplicationType)
+	r.trackPending(bucket, arn, "newer", "", newer)
+	r.trackPending(bucket, arn, "older", "v1", older)
+
+	st := r.Get(bucket).Stats[arn]
+	if st.PendingCount != 2 || st.PendingSize != 30 {
+		t.Fatalf("expected 2 pending objects of 30 bytes, got %d of %d bytes", st.PendingCount, st.PendingSize)
+	}
+	if !st.OldestPending.Equal(older) {
+		t.Fatalf("expected oldest pending %v, got %v", older, st.OldestPending)
+	}
+
+	r.untrackPending(bucket, arn, "older", "v1")
+	if st = r.GetAll()[bucket].Stats[arn]; !st.OldestPending.Equal(newer) {
+		t.Fatalf("expected oldest pending %v, got %v", newer, st.OldestPending)
+	}
+
+	r.untrackPending(bucket, arn, "newer", "")
+	if st = r.Get(bucket).Stats[arn]; !st.OldestPending.IsZero() {
+		t.Fatalf("expected no pending objects, got %v", st.OldestPending)
+	}
+}
+
+func TestReplicationBacklogBounded(t *testing.T) {
+	b := &replicationBacklog{entries: make(map[string]time.Time)}
+	now := time.Now()
+	for i := 0; i < replicationBacklogMaxEntries+10; i++ {
+		b.add(replicationBacklogKey("object", strconv.Itoa(i)), now.Add(time.Duration(i)))
+	}
+	if len(b.entries) != replicationBacklogMaxEntries {
+		t.Fatalf("expected %d tracked entries, got %d", replicationBacklogMaxEntries, len(b.entries))
+	}
+	if !b.oldest().Equal(now) {
+		t.Fatalf("expected oldest pending %v, got %v", now, b.oldest())
+	}
+}
+
+func TestEarliest(t *testing.T) {
+	a := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	b := a.Add(time.Minute)
+	testCases := []struct {
+		a, b, want time.Time
+	}{
+		{time.Time{}, time.Time{}, time.Time{}},
+		{a, time.Time{}, a},
+		{time.Time{}, b, b},
+		{a, b, a},
+		{b, a, a},
+	}
+	for i, tc := range testCases {
+		if got := earliest(tc.a, tc.b); !got.Equal(tc.want) {
+			t.Errorf("Test %d: expected %v, got %v", i+1, tc.want, got)
+		}
+	}
+}
+
+func TestReplicationLatencyAverage(t *testing.T) {
+	var rl ReplicationLatency
+	if avg := rl.getAvgUploadLatency(); avg != 0 {
+		t.Fatalf("expected no latency, got %v", avg)
+	}
+	rl.update(1024, 100*time.Millisecond)
+	rl.update(10<<20, 300*time.Millisecond)
+	if avg := rl.getAvgUploadLatency(); avg != 200*time.Millisecond {
+		t.Fatalf("expected 200ms average latency, got %v", avg)
+	}
+}
@@ -65,6 +65,8 @@ const (
 	TaggingTimestamp = "tagging-timestamp"
 	// ObjectLockRetentionTimestamp - the last time a object lock metadata modification happened on this cluster for this object version
 	ObjectLockRetentionTimestamp = "objectlock-retention-timestamp"
+	// ObjectLockRetentionInherited - set when the retention of this object version was inherited from the bucket default retention
+	ObjectLockRetentionInherited = "objectlock-retention-inherited"
 	// ObjectLockLegalHoldTimestamp - the last time a legal hold metadata modification happened on this cluster for this object version
 	ObjectLockLegalHoldTimestamp = "objectlock-legalhold-timestamp"
 	// ReplicationWorkerMultiplier is suggested worker multiplier if traffic exceeds replication worker capacity
@@ -1002,6 +1004,9 @@ func replicateObject(ctx context.Context, ri ReplicateObjectInfo, objectAPI Obje
 			if rinfo.ReplicationStatus != rinfo.PrevReplicationStatus {
 				globalReplicationStats.Update(bucket, rinfo.Arn, rinfo.Size, rinfo.Duration, rinfo.ReplicationStatus, rinfo.PrevReplicationStatus, opType)
 			}
+			if rinfo.ReplicationStatus != replication.Pending {
+				globalReplicationStats.untrackPending(bucket, rinfo.Arn, objInfo.Name, objInfo.VersionID)
+			}
 		}
 	}
 
@@ -1727,9 +1732,11 @@ func (p *ReplicationPool) ResizeFailedWorkers(n int) {
 	}
 }
 
-func (p *ReplicationPool) queueReplicaTask(ri ReplicateObjectInfo) {
+// queueReplicaTask returns true if the task was enqueued, it is saved
+// for the MRF otherwise.
+func (p *ReplicationPool) queueReplicaTask(ri ReplicateObjectInfo) bool {
 	if p == nil {
-		return
+		return false
 	}
 	var ch, healCh chan ReplicateObjectInfo
 	switch ri.OpType {
@@ -1750,7 +1757,9 @@ func (p *ReplicationPool) queueReplicaTask(ri ReplicateObjectInfo) {
 			close(p.saveStateCh)
 		})
 	case healCh <- ri:
+		return true
 	case ch <- ri:
+		return true
 	default:
 		globalReplicationPool.queueMRFSave(ri.ToMRFEntry())
 		p.mu.RLock()
@@ -1771,22 +1780,29 @@ func (p *ReplicationPool) queueReplicaTask(ri ReplicateObjectInfo) {
 		}
 		p.mu.RUnlock()
 	}
+	return false
 }
 
-func queueReplicateDeletesWrapper(doi DeletedObjectReplicationInfo, existingObjectResync ResyncDecision) {
+func queueReplicateDeletesWrapper(doi DeletedObjectReplicationInfo, existingObjectResync ResyncDecision) (queued bool) {
+	queued = true
 	for k, v := range existingObjectResync.targets {
 		if v.Replicate {
 			doi.ResetID = v.ResetID
 			doi.TargetArn = k
 
-			globalReplicationPool.queueReplicaDeleteTask(doi)
+			if !globalReplicationPool.queueReplicaDeleteTask(doi) {
+				queued = false
+			}
 		}
 	}
+	return queued
 }
 
-func (p *ReplicationPool) queueReplicaDeleteTask(doi DeletedObjectReplicationInfo) {
+// queueReplicaDeleteTask returns true if the task was enqueued, it is
+// saved for the MRF otherwise.
+func (p *ReplicationPool) queueReplicaDeleteTask(doi DeletedObjectReplicationInfo) bool {
 	if p == nil {
-		return
+		return false
 	}
 	var ch chan DeletedObjectReplicationInfo
 	switch doi.OpType {
@@ -1805,6 +1821,7 @@ func (p *ReplicationPool) queueReplicaDeleteTask(doi DeletedObjectReplicationInf
 			close(p.existingReplicaDeleteCh)
 		})
 	case ch <- doi:
+		return true
 	default:
 		globalReplicationPool.queueMRFSave(doi.ToMRFEntry())
 		p.mu.RLock()
@@ -1821,6 +1838,7 @@ func (p *ReplicationPool) queueReplicaDeleteTask(doi DeletedObjectReplicationInf
 		}
 		p.mu.RUnlock()
 	}
+	return false
 }
 
 type replicationPoolOpts struct {
@@ -1998,6 +2016,11 @@ func scheduleReplication(ctx context.Context, objInfo ObjectInfo, o ObjectLayer,
 	if dsc.Synchronous() {
 		replicateObject(ctx, ri, o)
 	} else {
+		if objInfo.ReplicationStatus == replication.Pending {
+			for arn := range dsc.targetsMap {
+				globalReplicationStats.trackPending(objInfo.Bucket, arn, objInfo.Name, objInfo.VersionID, objInfo.ModTime)
+			}
+		}
 		globalReplicationPool.queueReplicaTask(ri)
 	}
 	if sz, err := objInfo.GetActualSize(); err == nil {
@@ -2598,6 +2621,16 @@ func queueReplicationHeal(ctx context.Context, bucket string, oi ObjectInfo, rcf
 	if oi.ReplicationStatus == replication.Completed && oi.VersionPurgeStatus.Empty() && !roi.ExistingObjResync.mustResync() {
 		return
 	}
+	// skip versions already queued by a recent listing or scanner pass.
+	admit := func() bool {
+		return globalReplicationHealDedup.admit(roi.Bucket, roi.Name, roi.VersionID, UTCNow())
+	}
+	// versions not enqueued are retried by the next pass.
+	record := func(queued bool) {
+		if queued {
+			globalReplicationHealDedup.record(roi.Bucket, roi.Name, roi.VersionID, UTCNow())
+		}
+	}
 
 	if roi.DeleteMarker || !roi.VersionPurgeStatus.Empty() {
 		versionID := ""
@@ -2625,12 +2658,16 @@ func queueReplicationHeal(ctx context.Context, bucket string, oi ObjectInfo, rcf
 		if roi.ReplicationStatus == replication.Pending ||
 			roi.ReplicationStatus == replication.Failed ||
 			roi.VersionPurgeStatus == Failed || roi.VersionPurgeStatus == Pending {
-			globalReplicationPool.queueReplicaDeleteTask(dv)
+			if admit() {
+				record(globalReplicationPool.queueReplicaDeleteTask(dv))
+			}
 			return
 		}
 		// if replication status is Complete on DeleteMarker and existing object resync required
 		if roi.ExistingObjResync.mustResync() && (roi.ReplicationStatus == replication.Completed || roi.ReplicationStatus.Empty()) {
-			queueReplicateDeletesWrapper(dv, roi.ExistingObjResync)
+			if admit() {
+				record(queueReplicateDeletesWrapper(dv, roi.ExistingObjResync))
+			}
 			return
 		}
 		return
@@ -2641,12 +2678,16 @@ func queueReplicationHeal(ctx context.Context, bucket string, oi ObjectInfo, rcf
 	switch roi.ReplicationStatus {
 	case replication.Pending, replication.Failed:
 		roi.EventType = ReplicateHeal
-		globalReplicationPool.queueReplicaTask(roi)
+		if admit() {
+			record(globalReplicationPool.queueReplicaTask(roi))
+		}
 		return
 	}
 	if roi.ExistingObjResync.mustResync() {
 		roi.EventType = ReplicateExisting
-		globalReplicationPool.queueReplicaTask(roi)
+		if admit() {
+			record(globalReplicationPool.queueReplicaTask(roi))
+		}
 	}
 	return
 }
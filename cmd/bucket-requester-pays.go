@@ -0,0 +1,268 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	xhttp "github.com/qkbyte/minio/internal/http"
+	"github.com/qkbyte/minio/internal/logger"
+)
+
+// Payers of requests to a bucket.
+const (
+	requestPayerBucketOwner = "BucketOwner"
+	requestPayerRequester   = "Requester"
+)
+
+// Value of the x-amz-request-payer header acknowledging
+// that the requester pays for the request.
+const requestPayerHeaderValue = "requester"
+
+// Accounted access key of anonymous requests.
+const requesterPaysAnonymous = "anonymous"
+
+// Maximum number of requesters accounted per bucket, requests of other
+// access keys are accounted to requesterPaysOther to bound the metrics.
+const (
+	requesterPaysMaxRequesters = 1000
+	requesterPaysOther         = "other"
+)
+
+// requestPaymentConfig - request payment configuration of a bucket.
+type requestPaymentConfig struct {
+	XMLNS   string   `xml:"xmlns,attr,omitempty"`
+	XMLName xml.Name `xml:"RequestPaymentConfiguration"`
+	Payer   string   `xml:"Payer"`
+}
+
+var defaultRequestPaymentConfig = &requestPaymentConfig{
+	XMLNS: "http://s3.amazonaws.com/doc/2006-03-01/",
+	Payer: requestPayerBucketOwner,
+}
+
+var errInvalidRequestPayer = errors.New("Payer must be either BucketOwner or Requester")
+
+// parseRequestPaymentConfig parses and validates a request payment
+// configuration.
+func parseRequestPaymentConfig(reader io.Reader) (*requestPaymentConfig, error) {
+	var config requestPaymentConfig
+	if err := xml.NewDecoder(reader).Decode(&config); err != nil {
+		return nil, err
+	}
+	if config.Payer != requestPayerBucketOwner && config.Payer != requestPayerRequester {
+		return nil, errInvalidRequestPayer
+	}
+	return &config, nil
+}
+
+// requesterPays returns true if requesters pay for requests to the bucket.
+func (c *requestPaymentConfig) requesterPays() bool {
+	return c != nil && c.Payer == requestPayerRequester
+}
+
+// Query parameters of bucket listings, bucket level GET and HEAD
+// requests with other parameters manage the bucket configuration.
+var requesterPaysListingParams = map[string]struct{}{
+	"prefix":             {},
+	"delimiter":          {},
+	"marker":             {},
+	"max-keys":           {},
+	"encoding-type":      {},
+	"list-type":          {},
+	"continuation-token": {},
+	"start-after":        {},
+	"fetch-owner":        {},
+	"versions":           {},
+	"key-marker":         {},
+	"version-id-marker":  {},
+	"uploads":            {},
+	"upload-id-marker":   {},
+	"max-uploads":        {},
+}
+
+// isRequesterPaysRequest returns true if the requester pays for r on a
+// requester pays bucket, that is for object requests, listings and POST
+// policy uploads. The bucket owner pays for managing the bucket.
+func isRequesterPaysRequest(r *http.Request, object string) bool {
+	if object != "" {
+		return true
+	}
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		for param := range r.URL.Query() {
+			if _, ok := requesterPaysListingParams[param]; !ok {
+				return false
+			}
+		}
+		return true
+	case http.MethodPost:
+		return r.URL.RawQuery == ""
+	}
+	return false
+}
+
+// requesterAccessKey returns the access key that signed r without
+// validating it, an empty string is returned for anonymous requests.
+func requesterAccessKey(r *http.Request) string {
+	if getRequestAuthType(r) == authTypeAnonymous {
+		return ""
+	}
+	if ch, s3Err := getReqCredentialHeaderV4(r, globalSite.Region, serviceS3); s3Err == ErrNone {
+		return ch.accessKey
+	}
+	cred, _, _ := getReqAccessKeyV2(r)
+	return cred.AccessKey
+}
+
+// requesterPaysStats - requests and bytes of a requester on a
+// requester pays bucket.
+type requesterPaysStats struct {
+	Requests      uint64
+	ReceivedBytes uint64
+	SentBytes     uint64
+}
+
+// requesterPaysUsage accounts requests to requester pays buckets per
+// bucket and authenticated access key for chargeback.
+type requesterPaysUsage struct {
+	mu    sync.Mutex
+	usage map[string]map[string]*requesterPaysStats
+}
+
+func newRequesterPaysUsage() *requesterPaysUsage {
+	return &requesterPaysUsage{usage: make(map[string]map[string]*requesterPaysStats)}
+}
+
+func (u *requesterPaysUsage) record(bucket, accessKey string, received, sent uint64) {
+	if accessKey == "" {
+		accessKey = requesterPaysAnonymous
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	requesters, ok := u.usage[bucket]
+	if !ok {
+		requesters = make(map[string]*requesterPaysStats)
+		u.usage[bucket] = requesters
+	}
+	st, ok := requesters[accessKey]
+	if !ok && len(requesters) >= requesterPaysMaxRequesters {
+		accessKey = requesterPaysOther
+		st, ok = requesters[accessKey]
+	}
+	if !ok {
+		st = &requesterPaysStats{}
+		requesters[accessKey] = st
+	}
+	st.Requests++
+	st.ReceivedBytes += received
+	st.SentBytes += sent
+}
+
+// snapshot returns a copy of the usage per bucket and access key.
+func (u *requesterPaysUsage) snapshot() map[string]map[string]requesterPaysStats {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	usage := make(map[string]map[string]requesterPaysStats, len(u.usage))
+	for bucket, requesters := range u.usage {
+		usage[bucket] = make(map[string]requesterPaysStats, len(requesters))
+		for accessKey, st := range requesters {
+			usage[bucket][accessKey] = *st
+		}
+	}
+	return usage
+}
+
+// countingReadCloser counts the bytes read from the wrapped body.
+type countingReadCloser struct {
+	io.ReadCloser
+	n uint64
+}
+
+func (c *countingReadCloser) Read(p []byte) (int, error) {
+	n, err := c.ReadCloser.Read(p)
+	atomic.AddUint64(&c.n, uint64(n))
+	return n, err
+}
+
+// setRequesterPaysHandler refuses requests to requester pays buckets that
+// do not acknowledge the charges with the x-amz-request-payer header,
+// unless sent by the bucket owner, and accounts the accepted ones to the
+// access key they were authenticated with.
+func setRequesterPaysHandler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if guessIsHealthCheckReq(r) || guessIsMetricsReq(r) || guessIsRPCReq(r) ||
+			guessIsLoginSTSReq(r) || isAdminReq(r) || isKMSReq(r) {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		bucket, object := request2BucketObjectName(r)
+		if bucket == "" || !isRequesterPaysRequest(r, object) {
+			h.ServeHTTP(w, r)
+			return
+		}
+		config, _, err := globalBucketMetadataSys.GetRequestPaymentConfig(bucket)
+		if err != nil || !config.requesterPays() {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		// The claimed access key only decides whether the payer header is
+		// required, requests are charged once authenticated.
+		if accessKey := requesterAccessKey(r); accessKey != "" && accessKey == globalActiveCred.AccessKey && isOwnerPrivileged(accessKey) {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		if !strings.EqualFold(r.Header.Get(xhttp.AmzRequestPayer), requestPayerHeaderValue) {
+			ctx := newContext(r, w, "RequesterPays")
+
+			defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+			if tc, ok := r.Context().Value(contextTraceReqKey).(*traceCtxt); ok {
+				tc.funcName = "handler.RequesterPays"
+				tc.responseRecorder.LogErrBody = true
+			}
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrAccessDenied), r.URL)
+			return
+		}
+
+		w.Header().Set(xhttp.AmzRequestCharged, requestPayerHeaderValue)
+		body := &countingReadCloser{ReadCloser: r.Body}
+		if r.Body != nil {
+			r.Body = body
+		}
+		ctx, usage := withUsageAccessKey(r.Context())
+		r = r.WithContext(ctx)
+		statsWriter := logger.NewResponseWriter(w)
+		h.ServeHTTP(statsWriter, r)
+		if usage.authenticated && usage.accessKey != globalActiveCred.AccessKey {
+			globalRequesterPaysUsage.record(bucket, usage.accessKey, atomic.LoadUint64(&body.n), uint64(statsWriter.Size()))
+		}
+	})
+}
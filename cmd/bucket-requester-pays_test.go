@@ -0,0 +1,194 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/qkbyte/minio/internal/auth"
+	xhttp "github.com/qkbyte/minio/internal/http"
+)
+
+func TestParseRequestPaymentConfig(t *testing.T) {
+	testCases := []struct {
+		config        string
+		requesterPays bool
+		shouldFail    bool
+	}{
+		{config: `<RequestPaymentConfiguration><Payer>Requester</Payer></RequestPaymentConfiguration>`, requesterPays: true},
+		{config: `<RequestPaymentConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Payer>BucketOwner</Payer></RequestPaymentConfiguration>`},
+		{config: `<RequestPaymentConfiguration><Payer>Someone</Payer></RequestPaymentConfiguration>`, shouldFail: true},
+		{config: `<RequestPaymentConfiguration></RequestPaymentConfiguration>`, shouldFail: true},
+		{config: `<RequestPaymentConfiguration>`, shouldFail: true},
+	}
+	for i, test := range testCases {
+		config, err := parseRequestPaymentConfig(strings.NewReader(test.config))
+		if test.shouldFail {
+			if err == nil {
+				t.Errorf("Test %d: expected to fail", i+1)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Test %d: unexpected error %v", i+1, err)
+			continue
+		}
+		if config.requesterPays() != test.requesterPays {
+			t.Errorf("Test %d: expected requester pays %v", i+1, test.requesterPays)
+		}
+	}
+	if defaultRequestPaymentConfig.requesterPays() {
+		t.Error("expected the bucket owner to pay by default")
+	}
+}
+
+func TestIsRequesterPaysRequest(t *testing.T) {
+	testCases := []struct {
+		method string
+		target string
+		object string
+		pays   bool
+	}{
+		{http.MethodGet, "/bucket/object", "object", true},
+		{http.MethodPut, "/bucket/object?tagging", "object", true},
+		{http.MethodGet, "/bucket", "", true},
+		{http.MethodGet, "/bucket?list-type=2&prefix=a", "", true},
+		{http.MethodGet, "/bucket?versions", "", true},
+		{http.MethodHead, "/bucket", "", true},
+		{http.MethodPost, "/bucket", "", true},
+		{http.MethodGet, "/bucket?requestPayment", "", false},
+		{http.MethodGet, "/bucket?policy", "", false},
+		{http.MethodPut, "/bucket?requestPayment", "", false},
+		{http.MethodPost, "/bucket?delete", "", false},
+		{http.MethodDelete, "/bucket", "", false},
+	}
+	for i, test := range testCases {
+		r := httptest.NewRequest(test.method, test.target, nil)
+		if pays := isRequesterPaysRequest(r, test.object); pays != test.pays {
+			t.Errorf("Test %d: %s %s expected %v, got %v", i+1, test.method, test.target, test.pays, pays)
+		}
+	}
+}
+
+func TestRequesterPaysHandler(t *testing.T) {
+	defer func(sys *BucketMetadataSys) { globalBucketMetadataSys = sys }(globalBucketMetadataSys)
+	defer func(usage *requesterPaysUsage) { globalRequesterPaysUsage = usage }(globalRequesterPaysUsage)
+	defer func(cred auth.Credentials) { globalActiveCred = cred }(globalActiveCred)
+
+	globalActiveCred = auth.Credentials{AccessKey: "owneraccesskey", SecretKey: "ownersecretkey"}
+	globalRequesterPaysUsage = newRequesterPaysUsage()
+	globalBucketMetadataSys = NewBucketMetadataSys()
+	pays := newBucketMetadata("pays")
+	pays.requestPaymentConfig = &requestPaymentConfig{Payer: requestPayerRequester}
+	globalBucketMetadataSys.Set("pays", pays)
+	globalBucketMetadataSys.Set("bucket", newBucketMetadata("bucket"))
+
+	// Requests signed with "forgedaccesskey" fail authentication.
+	var okHandler http.HandlerFunc = func(w http.ResponseWriter, r *http.Request) {
+		accessKey := requesterAccessKey(r)
+		if accessKey == "forgedaccesskey" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		setUsageAccessKey(r.Context(), accessKey)
+		io.Copy(io.Discard, r.Body)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("hello"))
+	}
+
+	signedBy := func(accessKey string) string {
+		return signV4Algorithm + " Credential=" + accessKey + "/20220101/" + globalSite.Region +
+			"/s3/aws4_request, SignedHeaders=host, Signature=abcdef"
+	}
+
+	testCases := []struct {
+		method     string
+		target     string
+		auth       string
+		payer      string
+		shouldFail bool
+		charged    bool
+	}{
+		{method: http.MethodGet, target: "/bucket/object"},
+		{method: http.MethodGet, target: "/pays/object", shouldFail: true},
+		{method: http.MethodGet, target: "/pays/object", auth: signedBy("someaccesskey"), shouldFail: true},
+		{method: http.MethodGet, target: "/pays?requestPayment", auth: signedBy("someaccesskey")},
+		{method: http.MethodGet, target: "/pays/object", auth: signedBy("owneraccesskey")},
+		{method: http.MethodPut, target: "/pays/object", auth: signedBy("someaccesskey"), payer: "requester", charged: true},
+		{method: http.MethodGet, target: "/pays?list-type=2", payer: "requester", charged: true},
+		{method: http.MethodGet, target: "/pays/object", auth: signedBy("forgedaccesskey"), payer: "requester", shouldFail: true, charged: true},
+	}
+	for i, test := range testCases {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(test.method, test.target, strings.NewReader("body"))
+		if test.auth != "" {
+			r.Header.Set(xhttp.Authorization, test.auth)
+		}
+		if test.payer != "" {
+			r.Header.Set(xhttp.AmzRequestPayer, test.payer)
+		}
+
+		setRequesterPaysHandler(okHandler).ServeHTTP(w, r)
+
+		switch {
+		case test.shouldFail && w.Code != http.StatusForbidden:
+			t.Errorf("Test %d: expected access denied, got %d", i+1, w.Code)
+		case !test.shouldFail && w.Code != http.StatusOK:
+			t.Errorf("Test %d: expected success, got %d", i+1, w.Code)
+		}
+		if charged := w.Header().Get(xhttp.AmzRequestCharged) == requestPayerHeaderValue; charged != test.charged {
+			t.Errorf("Test %d: expected charged %v, got %v", i+1, test.charged, charged)
+		}
+	}
+
+	usage := globalRequesterPaysUsage.snapshot()
+	if len(usage) != 1 || len(usage["pays"]) != 2 {
+		t.Fatalf("expected usage of two requesters on a single bucket, got %v", usage)
+	}
+	want := requesterPaysStats{Requests: 1, ReceivedBytes: 4, SentBytes: 5}
+	if st := usage["pays"]["someaccesskey"]; st != want {
+		t.Errorf("expected %+v, got %+v", want, st)
+	}
+	if st := usage["pays"][requesterPaysAnonymous]; st != want {
+		t.Errorf("expected %+v, got %+v", want, st)
+	}
+}
+
+func TestRequesterPaysUsageBounded(t *testing.T) {
+	u := newRequesterPaysUsage()
+	for i := 0; i < requesterPaysMaxRequesters+10; i++ {
+		u.record("bucket", fmt.Sprintf("accesskey%d", i), 1, 1)
+	}
+	u.record("bucket", "accesskey0", 1, 1)
+
+	usage := u.snapshot()["bucket"]
+	if len(usage) != requesterPaysMaxRequesters+1 {
+		t.Fatalf("expected %d requesters, got %d", requesterPaysMaxRequesters+1, len(usage))
+	}
+	if st := usage[requesterPaysOther]; st.Requests != 10 {
+		t.Errorf("expected 10 requests of other requesters, got %d", st.Requests)
+	}
+	if st := usage["accesskey0"]; st.Requests != 2 {
+		t.Errorf("expected 2 requests of an accounted requester, got %d", st.Requests)
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"time"
+
+	objectlock "github.com/qkbyte/minio/internal/bucket/object/lock"
+)
+
+// Explicit retention within this distance of the retention the bucket
+// default would have given is not reported, default retention periods
+// are whole days.
+const retentionDeviationTolerance = 24 * time.Hour
+
+// retentionDeviation - object version whose explicit retention differs
+// from the bucket default retention.
+type retentionDeviation struct {
+	Object             string    `json:"object"`
+	VersionID          string    `json:"versionId,omitempty"`
+	Mode               string    `json:"mode"`
+	RetainUntil        time.Time `json:"retainUntil"`
+	DefaultRetainUntil time.Time `json:"defaultRetainUntil,omitempty"`
+}
+
+// retentionReport - objects of a bucket whose explicit retention deviates
+// from the bucket default retention.
+type retentionReport struct {
+	Bucket          string               `json:"bucket"`
+	Prefix          string               `json:"prefix,omitempty"`
+	DefaultMode     string               `json:"defaultMode,omitempty"`
+	DefaultValidity string               `json:"defaultValidity,omitempty"`
+	Versions        int64                `json:"versions"`
+	Inherited       int64                `json:"inherited"`
+	Explicit        int64                `json:"explicit"`
+	Deviations      []retentionDeviation `json:"deviations"`
+	Truncated       bool                 `json:"truncated"`
+}
+
+// retentionDeviates returns the deviation of the retention of oi from the
+// default retention def, false is returned if the object has no explicit
+// retention or it matches the default.
+func retentionDeviates(def objectlock.Retention, oi ObjectInfo) (retentionDeviation, bool) {
+	if getRetentionSource(oi.UserDefined) != retentionSourceExplicit {
+		return retentionDeviation{}, false
+	}
+	ret := objectlock.GetObjectRetentionMeta(oi.UserDefined)
+	d := retentionDeviation{
+		Object:      oi.Name,
+		VersionID:   oi.VersionID,
+		Mode:        string(ret.Mode),
+		RetainUntil: ret.RetainUntilDate.UTC(),
+	}
+	if def.Validity <= 0 {
+		return d, true
+	}
+	d.DefaultRetainUntil = oi.ModTime.Add(def.Validity).UTC()
+	diff := d.RetainUntil.Sub(d.DefaultRetainUntil)
+	if diff < 0 {
+		diff = -diff
+	}
+	return d, ret.Mode != def.Mode || diff > retentionDeviationTolerance
+}
+
+// buildRetentionReport walks all object versions of bucket under prefix
+// and reports up to maxDeviations versions whose explicit retention
+// deviates from the bucket default retention.
+func buildRetentionReport(ctx context.Context, objAPI ObjectLayer, bucket, prefix string, maxDeviations int) (retentionReport, error) {
+	report := retentionReport{Bucket: bucket, Prefix: prefix, Deviations: []retentionDeviation{}}
+
+	def, err := globalBucketObjectLockSys.Get(bucket)
+	if err != nil {
+		return report, err
+	}
+	if !def.LockEnabled {
+		return report, BucketObjectLockConfigNotFound{Bucket: bucket}
+	}
+	if def.Validity > 0 {
+		report.DefaultMode = string(def.Mode)
+		report.DefaultValidity = def.Validity.String()
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan ObjectInfo, 100)
+	if err = objAPI.Walk(ctx, bucket, prefix, results, ObjectOptions{}); err != nil {
+		return report, err
+	}
+	for oi := range results {
+		if oi.DeleteMarker {
+			continue
+		}
+		report.Versions++
+		switch getRetentionSource(oi.UserDefined) {
+		case retentionSourceDefault:
+			report.Inherited++
+			continue
+		case retentionSourceExplicit:
+			report.Explicit++
+		default:
+			continue
+		}
+		d, ok := retentionDeviates(def, oi)
+		if !ok {
+			continue
+		}
+		if len(report.Deviations) == maxDeviations {
+			report.Truncated = true
+			// Drain the walk to keep counting.
+			continue
+		}
+		report.Deviations = append(report.Deviations, d)
+	}
+	return report, ctx.Err()
+}
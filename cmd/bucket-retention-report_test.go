@@ -0,0 +1,112 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	objectlock "github.com/qkbyte/minio/internal/bucket/object/lock"
+	xhttp "github.com/qkbyte/minio/internal/http"
+)
+
+func retentionMeta(mode objectlock.RetMode, until time.Time, inherited bool) map[string]string {
+	meta := map[string]string{
+		xhttp.AmzObjectLockMode:            string(mode),
+		xhttp.AmzObjectLockRetainUntilDate: until.Format(time.RFC3339),
+	}
+	if inherited {
+		meta[ReservedMetadataPrefixLower+ObjectLockRetentionInherited] = "true"
+	}
+	return meta
+}
+
+func TestGetRetentionSource(t *testing.T) {
+	until := time.Now().Add(time.Hour)
+	testCases := []struct {
+		meta   map[string]string
+		source string
+	}{
+		{map[string]string{}, ""},
+		{retentionMeta(objectlock.RetGovernance, until, false), retentionSourceExplicit},
+		{retentionMeta(objectlock.RetCompliance, until, true), retentionSourceDefault},
+		{map[string]string{ReservedMetadataPrefixLower + ObjectLockRetentionInherited: "true"}, ""},
+	}
+	for i, tc := range testCases {
+		if source := getRetentionSource(tc.meta); source != tc.source {
+			t.Errorf("Test %d: expected %q, got %q", i+1, tc.source, source)
+		}
+	}
+}
+
+func TestSetRetentionSourceHeader(t *testing.T) {
+	meta := retentionMeta(objectlock.RetGovernance, time.Now().Add(time.Hour), true)
+
+	r := httptest.NewRequest(http.MethodHead, "/bucket/object", nil)
+	w := httptest.NewRecorder()
+	setRetentionSourceHeader(w, r, meta)
+	if v := w.Header().Get(xhttp.MinIOObjectLockRetentionSource); v != "" {
+		t.Fatalf("unexpected retention source %q without request header", v)
+	}
+
+	r.Header.Set(xhttp.MinIOIncludeRetentionSource, "true")
+	setRetentionSourceHeader(w, r, meta)
+	if v := w.Header().Get(xhttp.MinIOObjectLockRetentionSource); v != retentionSourceDefault {
+		t.Fatalf("expected retention source %q, got %q", retentionSourceDefault, v)
+	}
+}
+
+func TestRetentionDeviates(t *testing.T) {
+	modTime := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	def := objectlock.Retention{
+		Mode:        objectlock.RetGovernance,
+		Validity:    30 * 24 * time.Hour,
+		LockEnabled: true,
+	}
+	testCases := []struct {
+		def      objectlock.Retention
+		meta     map[string]string
+		deviates bool
+	}{
+		// Inherited retention never deviates.
+		{def, retentionMeta(objectlock.RetCompliance, modTime.Add(time.Hour), true), false},
+		// Explicit retention matching the default.
+		{def, retentionMeta(objectlock.RetGovernance, modTime.Add(def.Validity), false), false},
+		// Explicit retention within the tolerance.
+		{def, retentionMeta(objectlock.RetGovernance, modTime.Add(def.Validity+time.Hour), false), false},
+		// Explicit retention with a different mode.
+		{def, retentionMeta(objectlock.RetCompliance, modTime.Add(def.Validity), false), true},
+		// Explicit retention shorter than the default.
+		{def, retentionMeta(objectlock.RetGovernance, modTime.Add(24*time.Hour), false), true},
+		// Explicit retention without a bucket default.
+		{objectlock.Retention{LockEnabled: true}, retentionMeta(objectlock.RetGovernance, modTime.Add(def.Validity), false), true},
+	}
+	for i, tc := range testCases {
+		oi := ObjectInfo{Name: "object", ModTime: modTime, UserDefined: tc.meta}
+		d, ok := retentionDeviates(tc.def, oi)
+		if ok != tc.deviates {
+			t.Errorf("Test %d: expected deviates %v, got %v", i+1, tc.deviates, ok)
+			continue
+		}
+		if ok && d.Object != oi.Name {
+			t.Errorf("Test %d: expected object %q, got %q", i+1, oi.Name, d.Object)
+		}
+	}
+}
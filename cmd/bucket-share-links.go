@@ -0,0 +1,421 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/minio/pkg/bucket/policy"
+	xhttp "github.com/qkbyte/minio/internal/http"
+	"golang.org/x/time/rate"
+)
+
+// Maximum lifetime of a share link.
+const maxShareLinkExpiry = 7 * 24 * time.Hour
+
+// Peer usage of share links is refreshed at most this often.
+const shareLinkUsageRefreshInterval = 5 * time.Second
+
+// Usage and rate limiters of expired share links are dropped at most
+// this often.
+const shareLinkUsageSweepInterval = time.Minute
+
+var (
+	errInvalidShareLinkToken  = errors.New("invalid share link token")
+	errInvalidShareLinkExpiry = errors.New("share link expiry must be positive and at most 7 days")
+)
+
+// shareLink grants anonymous read access to the objects of a bucket
+// under a prefix until it expires, is revoked or its quota is used up.
+type shareLink struct {
+	ID         string    `json:"id"`
+	Prefix     string    `json:"prefix,omitempty"`
+	Expiry     time.Time `json:"expiry"`
+	MaxBytes   int64     `json:"maxBytes,omitempty"`  // total bytes served by all nodes, 0 is unlimited
+	RateLimit  int64     `json:"rateLimit,omitempty"` // bytes per second per node, 0 is unlimited
+	SecretHash string    `json:"secretHash"`
+	Created    time.Time `json:"created"`
+	CreatedBy  string    `json:"createdBy,omitempty"`
+}
+
+func (l shareLink) expired(now time.Time) bool {
+	return !now.Before(l.Expiry)
+}
+
+// validSecret returns true if secret is the secret of the link.
+func (l shareLink) validSecret(secret string) bool {
+	sum := sha256.Sum256([]byte(secret))
+	return subtle.ConstantTimeCompare([]byte(hex.EncodeToString(sum[:])), []byte(l.SecretHash)) == 1
+}
+
+// allows returns true if the link grants action on object, or on the
+// listing of prefix when object is empty.
+func (l shareLink) allows(action policy.Action, object, prefix string) bool {
+	switch action {
+	case policy.GetObjectAction:
+		return object != "" && strings.HasPrefix(object, l.Prefix)
+	case policy.ListBucketAction, policy.ListBucketVersionsAction:
+		return object == "" && strings.HasPrefix(prefix, l.Prefix)
+	case policy.GetBucketLocationAction:
+		return true
+	}
+	return false
+}
+
+// bucketShareLinksConfig - share links of a bucket.
+type bucketShareLinksConfig struct {
+	Links []shareLink `json:"links"`
+}
+
+func parseBucketShareLinksConfig(data []byte) (*bucketShareLinksConfig, error) {
+	cfg := &bucketShareLinksConfig{}
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func (cfg *bucketShareLinksConfig) find(id string) (shareLink, bool) {
+	if cfg == nil {
+		return shareLink{}, false
+	}
+	for _, l := range cfg.Links {
+		if l.ID == id {
+			return l, true
+		}
+	}
+	return shareLink{}, false
+}
+
+// active returns the links of cfg that did not expire.
+func (cfg *bucketShareLinksConfig) active(now time.Time) []shareLink {
+	if cfg == nil {
+		return nil
+	}
+	links := make([]shareLink, 0, len(cfg.Links))
+	for _, l := range cfg.Links {
+		if !l.expired(now) {
+			links = append(links, l)
+		}
+	}
+	return links
+}
+
+// newShareLink returns a new link along with its token.
+func newShareLink(bucket, prefix string, expiry time.Duration, maxBytes, rateLimit int64, createdBy string, now time.Time) (shareLink, string, error) {
+	if expiry <= 0 || expiry > maxShareLinkExpiry {
+		return shareLink{}, "", errInvalidShareLinkExpiry
+	}
+	id := mustGetUUID()
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return shareLink{}, "", err
+	}
+	secret := base64.RawURLEncoding.EncodeToString(b)
+	sum := sha256.Sum256([]byte(secret))
+	link := shareLink{
+		ID:         id,
+		Prefix:     prefix,
+		Expiry:     now.Add(expiry).UTC(),
+		MaxBytes:   maxBytes,
+		RateLimit:  rateLimit,
+		SecretHash: hex.EncodeToString(sum[:]),
+		Created:    now.UTC(),
+		CreatedBy:  createdBy,
+	}
+	return link, encodeShareLinkToken(bucket, id, secret), nil
+}
+
+func encodeShareLinkToken(bucket, id, secret string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(bucket + SlashSeparator + id + SlashSeparator + secret))
+}
+
+func decodeShareLinkToken(token string) (bucket, id, secret string, err error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return "", "", "", errInvalidShareLinkToken
+	}
+	parts := strings.Split(string(data), SlashSeparator)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return "", "", "", errInvalidShareLinkToken
+	}
+	return parts[0], parts[1], parts[2], nil
+}
+
+// getShareLinkToken returns the share link token sent with r, if any.
+func getShareLinkToken(r *http.Request) string {
+	if token := r.Header.Get(xhttp.MinIOShareToken); token != "" {
+		return token
+	}
+	return r.URL.Query().Get(strings.ToLower(xhttp.MinIOShareToken))
+}
+
+// lookupShareLink returns the link of the token sent with the anonymous
+// request r to bucket, if it is valid, did not expire and its quota is
+// not used up.
+func lookupShareLink(r *http.Request, bucket string) (shareLink, bool) {
+	token := getShareLinkToken(r)
+	if token == "" || bucket == "" || getRequestAuthType(r) != authTypeAnonymous {
+		return shareLink{}, false
+	}
+	tokenBucket, id, secret, err := decodeShareLinkToken(token)
+	if err != nil || tokenBucket != bucket {
+		return shareLink{}, false
+	}
+	cfg, _, err := globalBucketMetadataSys.GetShareLinksConfig(bucket)
+	if err != nil {
+		return shareLink{}, false
+	}
+	link, ok := cfg.find(id)
+	if !ok || link.expired(UTCNow()) || !link.validSecret(secret) {
+		return shareLink{}, false
+	}
+	if link.MaxBytes > 0 && globalShareLinkUsage.used(bucket, id) >= link.MaxBytes {
+		return shareLink{}, false
+	}
+	return link, true
+}
+
+// isShareLinkAllowed returns true if the anonymous request r carries a
+// share link token granting action on bucket/object.
+func isShareLinkAllowed(r *http.Request, action policy.Action, bucket, object string) bool {
+	link, ok := lookupShareLink(r, bucket)
+	return ok && link.allows(action, object, r.URL.Query().Get("prefix"))
+}
+
+// shareLinkUsage accounts the bytes served per share link on this node,
+// along with the last known usage on the other nodes. The quota of a link
+// is checked against the usage of all nodes, which lags behind by up to
+// shareLinkUsageRefreshInterval, and requests already admitted complete,
+// so that a link may serve slightly more than its quota. The rate limit
+// applies to each node.
+type shareLinkUsage struct {
+	mu            sync.Mutex
+	local         map[string]int64
+	remote        map[string]int64
+	remoteUpdated time.Time
+	refreshing    int32
+	limiters      map[string]*rate.Limiter
+	expiry        map[string]time.Time // expiry of the links of local and limiters.
+	lastSweep     time.Time
+}
+
+func newShareLinkUsage() *shareLinkUsage {
+	return &shareLinkUsage{
+		local:    make(map[string]int64),
+		remote:   make(map[string]int64),
+		limiters: make(map[string]*rate.Limiter),
+		expiry:   make(map[string]time.Time),
+	}
+}
+
+func shareLinkKey(bucket, id string) string {
+	return pathJoin(bucket, id)
+}
+
+func (u *shareLinkUsage) add(bucket string, link shareLink, n int64) {
+	key := shareLinkKey(bucket, link.ID)
+	u.mu.Lock()
+	u.local[key] += n
+	u.expiry[key] = link.Expiry
+	u.sweep(time.Now())
+	u.mu.Unlock()
+}
+
+// sweep drops the usage and rate limiters of expired links, callers
+// must hold the lock.
+func (u *shareLinkUsage) sweep(now time.Time) {
+	if now.Sub(u.lastSweep) < shareLinkUsageSweepInterval {
+		return
+	}
+	u.lastSweep = now
+	for key, expiry := range u.expiry {
+		if !now.Before(expiry) {
+			delete(u.local, key)
+			delete(u.limiters, key)
+			delete(u.expiry, key)
+		}
+	}
+}
+
+// used returns the bytes served by the link on all nodes, the usage of
+// other nodes is refreshed in the background when stale.
+func (u *shareLinkUsage) used(bucket, id string) int64 {
+	key := shareLinkKey(bucket, id)
+	u.mu.Lock()
+	n := u.local[key] + u.remote[key]
+	stale := time.Since(u.remoteUpdated) > shareLinkUsageRefreshInterval
+	u.mu.Unlock()
+
+	if stale && globalNotificationSys != nil && atomic.CompareAndSwapInt32(&u.refreshing, 0, 1) {
+		go func() {
+			defer atomic.StoreInt32(&u.refreshing, 0)
+			u.setRemote(globalNotificationSys.GetShareLinkUsage(GlobalContext))
+		}()
+	}
+	return n
+}
+
+func (u *shareLinkUsage) setRemote(remote map[string]int64) {
+	u.mu.Lock()
+	u.remote = remote
+	u.remoteUpdated = time.Now()
+	u.mu.Unlock()
+}
+
+// localUsage returns a copy of the bytes served per link by this node.
+func (u *shareLinkUsage) localUsage() map[string]int64 {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	usage := make(map[string]int64, len(u.local))
+	for key, n := range u.local {
+		usage[key] = n
+	}
+	return usage
+}
+
+// limiter returns the rate limiter of the link, nil if it is unlimited.
+func (u *shareLinkUsage) limiter(bucket string, link shareLink) *rate.Limiter {
+	if link.RateLimit <= 0 {
+		return nil
+	}
+	key := shareLinkKey(bucket, link.ID)
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	l, ok := u.limiters[key]
+	if !ok || l.Limit() != rate.Limit(link.RateLimit) {
+		l = rate.NewLimiter(rate.Limit(link.RateLimit), int(link.RateLimit))
+		u.limiters[key] = l
+		u.expiry[key] = link.Expiry
+		u.sweep(time.Now())
+	}
+	return l
+}
+
+// shareLinkResponseWriter counts and throttles the bytes written
+// for a share link.
+type shareLinkResponseWriter struct {
+	http.ResponseWriter
+	ctx     context.Context
+	limiter *rate.Limiter
+	n       int64
+}
+
+func (w *shareLinkResponseWriter) Write(p []byte) (int, error) {
+	var written int
+	for len(p) > 0 {
+		chunk := p
+		if w.limiter != nil {
+			if burst := w.limiter.Burst(); len(chunk) > burst {
+				chunk = chunk[:burst]
+			}
+			if err := w.limiter.WaitN(w.ctx, len(chunk)); err != nil {
+				return written, err
+			}
+		}
+		n, err := w.ResponseWriter.Write(chunk)
+		written += n
+		w.n += int64(n)
+		if err != nil {
+			return written, err
+		}
+		p = p[n:]
+	}
+	return written, nil
+}
+
+func (w *shareLinkResponseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// setShareLinkHandler throttles and accounts the bytes served to anonymous
+// requests carrying a valid share link token, the access granted by the
+// link is checked along with the bucket policy when authorizing.
+func setShareLinkHandler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(xhttp.MinIOShareToken) == "" && !strings.Contains(r.URL.RawQuery, strings.ToLower(xhttp.MinIOShareToken)) {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		bucket, _ := request2BucketObjectName(r)
+		link, ok := lookupShareLink(r, bucket)
+		if !ok {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		sw := &shareLinkResponseWriter{
+			ResponseWriter: w,
+			ctx:            r.Context(),
+			limiter:        globalShareLinkUsage.limiter(bucket, link),
+		}
+		h.ServeHTTP(sw, r)
+		globalShareLinkUsage.add(bucket, link, sw.n)
+	})
+}
+
+// updateShareLinks replaces the share links of bucket by the result of
+// update, expired links are dropped along the way.
+func updateShareLinks(ctx context.Context, objAPI ObjectLayer, bucket string, update func(links []shareLink) ([]shareLink, error)) error {
+	lk := objAPI.NewNSLock(minioMetaBucket, pathJoin(bucketMetaPrefix, bucket, bucketShareLinksConfigFile))
+	lkctx, err := lk.GetLock(ctx, globalOperationTimeout)
+	if err != nil {
+		return err
+	}
+	ctx = lkctx.Context()
+	defer lk.Unlock(lkctx.Cancel)
+
+	meta, err := loadBucketMetadata(ctx, objAPI, bucket)
+	if err != nil {
+		return err
+	}
+	var cfg *bucketShareLinksConfig
+	if len(meta.ShareLinksConfigJSON) != 0 {
+		if cfg, err = parseBucketShareLinksConfig(meta.ShareLinksConfigJSON); err != nil {
+			return err
+		}
+	}
+	links, err := update(cfg.active(UTCNow()))
+	if err != nil {
+		return err
+	}
+
+	var data []byte
+	if len(links) > 0 {
+		if data, err = json.Marshal(bucketShareLinksConfig{Links: links}); err != nil {
+			return err
+		}
+	}
+	_, err = globalBucketMetadataSys.Update(ctx, bucket, bucketShareLinksConfigFile, data)
+	return err
+}
@@ -0,0 +1,155 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/minio/pkg/bucket/policy"
+	"golang.org/x/time/rate"
+)
+
+func TestShareLinkToken(t *testing.T) {
+	now := time.Now()
+	link, token, err := newShareLink("bucket", "data/", time.Hour, 100, 10, "minio", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bucket, id, secret, err := decodeShareLinkToken(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bucket != "bucket" || id != link.ID {
+		t.Fatalf("unexpected token bucket %s id %s", bucket, id)
+	}
+	if !link.validSecret(secret) {
+		t.Fatal("expected secret to be valid")
+	}
+	if link.validSecret(secret + "x") {
+		t.Fatal("expected modified secret to be invalid")
+	}
+	if link.expired(now) || !link.expired(now.Add(time.Hour)) {
+		t.Fatal("unexpected link expiry")
+	}
+
+	for _, token := range []string{"", "!!", encodeShareLinkToken("bucket", "", "secret")} {
+		if _, _, _, err := decodeShareLinkToken(token); err != errInvalidShareLinkToken {
+			t.Fatalf("expected %v for token %q, got %v", errInvalidShareLinkToken, token, err)
+		}
+	}
+
+	for _, expiry := range []time.Duration{0, -time.Hour, maxShareLinkExpiry + time.Second} {
+		if _, _, err := newShareLink("bucket", "", expiry, 0, 0, "", now); err != errInvalidShareLinkExpiry {
+			t.Fatalf("expected %v for expiry %v, got %v", errInvalidShareLinkExpiry, expiry, err)
+		}
+	}
+}
+
+func TestShareLinkAllows(t *testing.T) {
+	link := shareLink{Prefix: "data/"}
+	testCases := []struct {
+		action  policy.Action
+		object  string
+		prefix  string
+		allowed bool
+	}{
+		{policy.GetObjectAction, "data/a.csv", "", true},
+		{policy.GetObjectAction, "other/a.csv", "", false},
+		{policy.GetObjectAction, "", "data/", false},
+		{policy.ListBucketAction, "", "data/2022/", true},
+		{policy.ListBucketVersionsAction, "", "data/", true},
+		{policy.ListBucketAction, "", "", false},
+		{policy.GetBucketLocationAction, "", "", true},
+		{policy.PutObjectAction, "data/a.csv", "", false},
+		{policy.DeleteObjectAction, "data/a.csv", "", false},
+	}
+	for i, tc := range testCases {
+		if allowed := link.allows(tc.action, tc.object, tc.prefix); allowed != tc.allowed {
+			t.Errorf("case %d: expected %v, got %v", i+1, tc.allowed, allowed)
+		}
+	}
+}
+
+func TestShareLinksActive(t *testing.T) {
+	now := time.Now()
+	cfg := &bucketShareLinksConfig{Links: []shareLink{
+		{ID: "expired", Expiry: now.Add(-time.Minute)},
+		{ID: "active", Expiry: now.Add(time.Minute)},
+	}}
+	active := cfg.active(now)
+	if len(active) != 1 || active[0].ID != "active" {
+		t.Fatalf("unexpected active links %v", active)
+	}
+	if _, ok := cfg.find("expired"); !ok {
+		t.Fatal("expected to find link")
+	}
+	var nilCfg *bucketShareLinksConfig
+	if len(nilCfg.active(now)) != 0 {
+		t.Fatal("expected no active links")
+	}
+	if _, ok := nilCfg.find("active"); ok {
+		t.Fatal("expected no link")
+	}
+}
+
+func TestShareLinkResponseWriter(t *testing.T) {
+	rec := httptest.NewRecorder()
+	w := &shareLinkResponseWriter{
+		ResponseWriter: rec,
+		ctx:            context.Background(),
+		limiter:        rate.NewLimiter(rate.Inf, 4),
+	}
+	n, err := w.Write([]byte("0123456789"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 10 || w.n != 10 || rec.Body.String() != "0123456789" {
+		t.Fatalf("unexpected write of %d bytes, counted %d, body %q", n, w.n, rec.Body.String())
+	}
+
+	link := shareLink{ID: "id", Expiry: time.Now().Add(time.Hour)}
+	usage := newShareLinkUsage()
+	usage.add("bucket", link, w.n)
+	usage.add("bucket", link, 5)
+	usage.setRemote(map[string]int64{shareLinkKey("bucket", "id"): 20})
+	if used := usage.used("bucket", "id"); used != 35 {
+		t.Fatalf("expected 35 bytes used, got %d", used)
+	}
+	if l := usage.limiter("bucket", shareLink{ID: "id"}); l != nil {
+		t.Fatal("expected no limiter for unlimited link")
+	}
+	link.RateLimit = 100
+	if l := usage.limiter("bucket", link); l == nil || l.Burst() != 100 {
+		t.Fatal("expected limiter with a burst of the rate limit")
+	}
+
+	// Usage and limiters of expired links are dropped.
+	expired := shareLink{ID: "expired", Expiry: time.Now().Add(-time.Second), RateLimit: 100}
+	usage.limiter("bucket", expired)
+	usage.lastSweep = time.Time{}
+	usage.sweep(time.Now())
+	if _, ok := usage.limiters[shareLinkKey("bucket", "expired")]; ok {
+		t.Fatal("expected the limiter of an expired link to be dropped")
+	}
+	if len(usage.local) != 1 || len(usage.limiters) != 1 || len(usage.expiry) != 1 {
+		t.Fatalf("expected the usage of the active link only, got %v", usage.local)
+	}
+}
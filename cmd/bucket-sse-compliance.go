@@ -0,0 +1,141 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	iampolicy "github.com/minio/pkg/iam/policy"
+	sse "github.com/qkbyte/minio/internal/bucket/encryption"
+	xhttp "github.com/qkbyte/minio/internal/http"
+	"github.com/qkbyte/minio/internal/logger"
+)
+
+// sseComplianceMaxRecent is the number of recent rejections kept per bucket.
+const sseComplianceMaxRecent = 100
+
+// sseComplianceRejection describes a request rejected by the bucket
+// encryption compliance mode.
+type sseComplianceRejection struct {
+	Time       time.Time `json:"time"`
+	API        string    `json:"api"`
+	Object     string    `json:"object"`
+	AccessKey  string    `json:"accessKey,omitempty"`
+	RemoteHost string    `json:"remoteHost,omitempty"`
+	UserAgent  string    `json:"userAgent,omitempty"`
+	Reason     string    `json:"reason"`
+}
+
+// sseComplianceReport is the per node report of rejected requests for a bucket.
+type sseComplianceReport struct {
+	Node         string                   `json:"node"`
+	Bucket       string                   `json:"bucket"`
+	Rejected     uint64                   `json:"rejected"`
+	LastRejected time.Time                `json:"lastRejected,omitempty"`
+	Recent       []sseComplianceRejection `json:"recent,omitempty"`
+	Error        string                   `json:"error,omitempty"`
+}
+
+type sseComplianceStats struct {
+	rejected uint64
+	recent   []sseComplianceRejection
+}
+
+// sseComplianceReporter keeps track of requests rejected for not
+// matching the bucket encryption configuration in compliance mode.
+type sseComplianceReporter struct {
+	mu      sync.Mutex
+	buckets map[string]*sseComplianceStats
+}
+
+func newSSEComplianceReporter() *sseComplianceReporter {
+	return &sseComplianceReporter{buckets: make(map[string]*sseComplianceStats)}
+}
+
+func (s *sseComplianceReporter) record(bucket string, rej sseComplianceRejection) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats, ok := s.buckets[bucket]
+	if !ok {
+		stats = &sseComplianceStats{}
+		s.buckets[bucket] = stats
+	}
+	stats.rejected++
+	if len(stats.recent) >= sseComplianceMaxRecent {
+		copy(stats.recent, stats.recent[1:])
+		stats.recent = stats.recent[:len(stats.recent)-1]
+	}
+	stats.recent = append(stats.recent, rej)
+}
+
+func (s *sseComplianceReporter) report(bucket string) sseComplianceReport {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	report := sseComplianceReport{Node: globalLocalNodeName, Bucket: bucket}
+	if stats, ok := s.buckets[bucket]; ok {
+		report.Rejected = stats.rejected
+		report.Recent = append([]sseComplianceRejection(nil), stats.recent...)
+		if n := len(stats.recent); n > 0 {
+			report.LastRejected = stats.recent[n-1].Time
+		}
+	}
+	return report
+}
+
+// delete drops the collected rejections of a bucket.
+func (s *sseComplianceReporter) delete(bucket string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.buckets, bucket)
+}
+
+// enforceBucketSSECompliance rejects requests whose SSE headers do not
+// match the bucket encryption configuration when it is in compliance
+// mode, rejections are recorded for reporting. Replication requests
+// are exempt since they carry the encryption state of the source, as
+// the header can be set by any client the requester must be allowed
+// to replicate objects.
+func enforceBucketSSECompliance(ctx context.Context, r *http.Request, headers http.Header, bucket, object string, sseConfig *sse.BucketSSEConfig) APIErrorCode {
+	err := sseConfig.CheckCompliance(headers)
+	if err == nil {
+		return ErrNone
+	}
+	if r.Header.Get(xhttp.MinIOSourceReplicationRequest) == "true" &&
+		isPutActionAllowed(ctx, getRequestAuthType(r), bucket, object, r, iampolicy.ReplicateObjectAction) == ErrNone {
+		return ErrNone
+	}
+
+	rej := sseComplianceRejection{
+		Time:   UTCNow(),
+		Object: object,
+		Reason: err.Error(),
+	}
+	if reqInfo := logger.GetReqInfo(ctx); reqInfo != nil {
+		rej.API = reqInfo.API
+		rej.AccessKey = reqInfo.Cred.AccessKey
+		rej.RemoteHost = reqInfo.RemoteHost
+		rej.UserAgent = reqInfo.UserAgent
+	}
+	globalSSEComplianceReporter.record(bucket, rej)
+	return ErrBucketSSEComplianceViolation
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	sse "github.com/qkbyte/minio/internal/bucket/encryption"
+	xhttp "github.com/qkbyte/minio/internal/http"
+	"github.com/qkbyte/minio/internal/logger"
+)
+
+func TestEnforceBucketSSECompliance(t *testing.T) {
+	saved := globalSSEComplianceReporter
+	defer func() { globalSSEComplianceReporter = saved }()
+	globalSSEComplianceReporter = newSSEComplianceReporter()
+
+	config := &sse.BucketSSEConfig{
+		Rules: []sse.Rule{{
+			DefaultEncryptionAction: sse.EncryptionAction{Algorithm: sse.AES256},
+		}},
+	}
+
+	ctx := logger.SetReqInfo(context.Background(), &logger.ReqInfo{API: "PutObject", RemoteHost: "10.0.0.1", UserAgent: "test-client"})
+	r := httptest.NewRequest(http.MethodPut, "/bucket/object", nil)
+
+	// Without compliance mode nothing is rejected.
+	if s3Err := enforceBucketSSECompliance(ctx, r, r.Header, "bucket", "object", config); s3Err != ErrNone {
+		t.Fatalf("unexpected error %v", s3Err)
+	}
+
+	config.ComplianceMode = true
+	if s3Err := enforceBucketSSECompliance(ctx, r, r.Header, "bucket", "object", config); s3Err != ErrBucketSSEComplianceViolation {
+		t.Fatalf("expected compliance violation, got %v", s3Err)
+	}
+
+	// Replication requests are only exempt when the requester is
+	// allowed to replicate objects.
+	r.Header.Set(xhttp.MinIOSourceReplicationRequest, "true")
+	if s3Err := enforceBucketSSECompliance(ctx, r, r.Header, "bucket", "object", config); s3Err != ErrBucketSSEComplianceViolation {
+		t.Fatalf("expected compliance violation for unauthenticated replication request, got %v", s3Err)
+	}
+	r.Header.Del(xhttp.MinIOSourceReplicationRequest)
+
+	r.Header.Set(xhttp.AmzServerSideEncryption, xhttp.AmzEncryptionAES)
+	if s3Err := enforceBucketSSECompliance(ctx, r, r.Header, "bucket", "object", config); s3Err != ErrNone {
+		t.Fatalf("unexpected error for compliant request %v", s3Err)
+	}
+
+	report := globalSSEComplianceReporter.report("bucket")
+	if report.Rejected != 2 || len(report.Recent) != 2 {
+		t.Fatalf("expected two rejections, got %+v", report)
+	}
+	rej := report.Recent[0]
+	if rej.API != "PutObject" || rej.Object != "object" || rej.RemoteHost != "10.0.0.1" || rej.UserAgent != "test-client" {
+		t.Fatalf("unexpected rejection %+v", rej)
+	}
+}
+
+func TestSSEComplianceReporterRecent(t *testing.T) {
+	s := newSSEComplianceReporter()
+	for i := 0; i < sseComplianceMaxRecent+10; i++ {
+		s.record("bucket", sseComplianceRejection{Time: UTCNow(), Object: fmt.Sprint(i)})
+	}
+	report := s.report("bucket")
+	if report.Rejected != sseComplianceMaxRecent+10 {
+		t.Fatalf("expected %d rejections, got %d", sseComplianceMaxRecent+10, report.Rejected)
+	}
+	if len(report.Recent) != sseComplianceMaxRecent || report.Recent[0].Object != "10" {
+		t.Fatalf("unexpected recent rejections: %d, first %s", len(report.Recent), report.Recent[0].Object)
+	}
+
+	s.delete("bucket")
+	if report := s.report("bucket"); report.Rejected != 0 {
+		t.Fatalf("expected no rejections after delete, got %d", report.Rejected)
+	}
+}
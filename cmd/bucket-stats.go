@@ -47,6 +47,15 @@ func (rl ReplicationLatency) getUploadLatency() (ret map[string]uint64) {
 	return
 }
 
+// Get average upload latency across all object sizes
+func (rl ReplicationLatency) getAvgUploadLatency() time.Duration {
+	var total AccElem
+	for _, elem := range rl.UploadHistogram.GetAvgData() {
+		total.merge(elem)
+	}
+	return total.avg()
+}
+
 // Update replication upload latency with a new value
 func (rl *ReplicationLatency) update(size int64, duration time.Duration) {
 	rl.UploadHistogram.Add(size, duration)
@@ -120,6 +129,8 @@ type BucketReplicationStat struct {
 	FailedCount int64 `json:"failedReplicationCount"`
 	// Replication latency information
 	Latency ReplicationLatency `json:"replicationLatency"`
+	// Modification time of the oldest object pending replication
+	OldestPending time.Time `json:"oldestPendingReplication,omitempty"`
 }
 
 func (bs *BucketReplicationStat) hasReplicationUsage() bool {
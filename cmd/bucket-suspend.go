@@ -0,0 +1,104 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/qkbyte/minio/internal/logger"
+)
+
+// Modes of a suspended bucket.
+const (
+	// bucketSuspendAll rejects all S3 requests to the bucket.
+	bucketSuspendAll = "all"
+	// bucketSuspendWrites only rejects S3 requests modifying the bucket.
+	bucketSuspendWrites = "writes"
+)
+
+// bucketSuspendConfig - temporarily suspends S3 access to a bucket, e.g.
+// while responding to an incident. Admin APIs are not affected so that
+// the bucket can be inspected and resumed.
+type bucketSuspendConfig struct {
+	Mode   string    `json:"mode"`
+	Reason string    `json:"reason,omitempty"`
+	Since  time.Time `json:"since"`
+}
+
+func parseBucketSuspendConfig(data []byte) (*bucketSuspendConfig, error) {
+	cfg := &bucketSuspendConfig{}
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, err
+	}
+	switch cfg.Mode {
+	case bucketSuspendAll, bucketSuspendWrites:
+	default:
+		return nil, fmt.Errorf("Invalid suspend mode '%s', expected '%s' or '%s'",
+			cfg.Mode, bucketSuspendAll, bucketSuspendWrites)
+	}
+	return cfg, nil
+}
+
+// rejects returns true if r is not allowed on the suspended bucket.
+func (cfg *bucketSuspendConfig) rejects(r *http.Request) bool {
+	if cfg == nil {
+		return false
+	}
+	return cfg.Mode == bucketSuspendAll || isS3WriteRequest(r)
+}
+
+// bucketSuspended returns the suspend configuration of bucket, nil is
+// returned if the bucket is not suspended.
+func bucketSuspended(bucket string) *bucketSuspendConfig {
+	if globalBucketMetadataSys == nil || isMinioMetaBucketName(bucket) {
+		return nil
+	}
+	cfg, _, _ := globalBucketMetadataSys.GetSuspendConfig(bucket)
+	return cfg
+}
+
+// setBucketSuspendHandler rejects S3 requests to suspended buckets before
+// they reach the API handlers, rejected requests are audited.
+func setBucketSuspendHandler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if guessIsHealthCheckReq(r) || guessIsMetricsReq(r) || guessIsRPCReq(r) ||
+			guessIsLoginSTSReq(r) || isAdminReq(r) || isKMSReq(r) {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		bucket, _ := request2BucketObjectName(r)
+		if bucket == "" || !bucketSuspended(bucket).rejects(r) {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		ctx := newContext(r, w, "BucketSuspended")
+
+		defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+		if tc, ok := r.Context().Value(contextTraceReqKey).(*traceCtxt); ok {
+			tc.funcName = "handler.BucketSuspended"
+			tc.responseRecorder.LogErrBody = true
+		}
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrBucketSuspended), r.URL)
+	})
+}
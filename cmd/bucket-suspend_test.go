@@ -0,0 +1,87 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseBucketSuspendConfig(t *testing.T) {
+	testCases := []struct {
+		data    string
+		success bool
+	}{
+		{`{"mode":"all"}`, true},
+		{`{"mode":"writes","reason":"abuse"}`, true},
+		{`{"mode":"reads"}`, false},
+		{`{}`, false},
+		{`not-json`, false},
+	}
+	for i, tc := range testCases {
+		if _, err := parseBucketSuspendConfig([]byte(tc.data)); (err == nil) != tc.success {
+			t.Errorf("Test %d: expected success %v, got %v", i+1, tc.success, err)
+		}
+	}
+}
+
+func TestBucketSuspendHandler(t *testing.T) {
+	defer func(sys *BucketMetadataSys) { globalBucketMetadataSys = sys }(globalBucketMetadataSys)
+	globalBucketMetadataSys = NewBucketMetadataSys()
+	all := newBucketMetadata("suspended")
+	all.suspendConfig = &bucketSuspendConfig{Mode: bucketSuspendAll}
+	globalBucketMetadataSys.Set("suspended", all)
+	writes := newBucketMetadata("readonly")
+	writes.suspendConfig = &bucketSuspendConfig{Mode: bucketSuspendWrites}
+	globalBucketMetadataSys.Set("readonly", writes)
+	globalBucketMetadataSys.Set("bucket", newBucketMetadata("bucket"))
+
+	var okHandler http.HandlerFunc = func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	testCases := []struct {
+		method     string
+		target     string
+		shouldFail bool
+	}{
+		{method: http.MethodGet, target: "/"},
+		{method: http.MethodPut, target: "/bucket/object"},
+		{method: http.MethodGet, target: "/suspended/object", shouldFail: true},
+		{method: http.MethodGet, target: "/suspended?list-type=2", shouldFail: true},
+		{method: http.MethodPut, target: "/suspended/object", shouldFail: true},
+		{method: http.MethodGet, target: "/readonly/object"},
+		{method: http.MethodPost, target: "/readonly/object?select&select-type=2"},
+		{method: http.MethodPut, target: "/readonly/object", shouldFail: true},
+		{method: http.MethodDelete, target: "/readonly/object", shouldFail: true},
+	}
+	for i, test := range testCases {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(test.method, test.target, nil)
+
+		setBucketSuspendHandler(okHandler).ServeHTTP(w, r)
+
+		switch {
+		case test.shouldFail && w.Code != http.StatusForbidden:
+			t.Errorf("Test %d: expected HTTP 403, got HTTP %d", i+1, w.Code)
+		case !test.shouldFail && w.Code != http.StatusOK:
+			t.Errorf("Test %d: expected HTTP 200, got HTTP %d", i+1, w.Code)
+		}
+	}
+}
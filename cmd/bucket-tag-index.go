@@ -0,0 +1,519 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/minio-go/v7/pkg/tags"
+	"github.com/qkbyte/minio/internal/event"
+	"github.com/qkbyte/minio/internal/logger"
+)
+
+//go:generate msgp -file $GOFILE -unexported
+
+//msgp:ignore tagQuery tagSearchResult tagIndexUpdate tagIndexer
+
+const (
+	tagIndexDir = ".tag-index"
+
+	tagIndexQueueSize = 10000
+
+	// Updates of a bucket applied at once.
+	tagIndexBatchSize = 10000
+
+	// Maximum number of entries of a page, larger pages are split.
+	tagIndexPageSize = 1000
+
+	// Maximum number of pages read by a single search.
+	tagIndexMaxSearchPages = 100
+)
+
+// globalTagIndexInterval is the interval at which changed and scanned
+// objects are applied to the tag index of buckets, zero disables tag
+// search.
+var globalTagIndexInterval time.Duration
+
+// errTagIndexNotFound - the tag index of a bucket was not built yet.
+var errTagIndexNotFound = errors.New("tag index not found")
+
+// tagIndexEntry - tags of the latest version of an object.
+type tagIndexEntry struct {
+	Name string            `msg:"n"`
+	Tags map[string]string `msg:"t"`
+}
+
+// tagIndexPageInfo - a page of the tag index, it holds the entries from
+// First up to the First of the next page.
+type tagIndexPageInfo struct {
+	First string `msg:"f"`
+	ID    string `msg:"id"`
+}
+
+// tagIndexManifest - pages of the tag index of a bucket sorted by First,
+// the first page starts at "". Pages are never modified in place, an
+// updated page is written under a new ID before the manifest refers
+// to it. The index is stored in .minio.sys so that objects can be
+// searched by tags without listing the bucket.
+type tagIndexManifest struct {
+	Updated time.Time          `msg:"u"`
+	Pages   []tagIndexPageInfo `msg:"p"`
+}
+
+// tagIndexPage - entries of a page sorted by name.
+type tagIndexPage struct {
+	Entries []tagIndexEntry `msg:"e"`
+}
+
+// page returns the position of the page holding name.
+func (m *tagIndexManifest) page(name string) int {
+	return sort.Search(len(m.Pages), func(i int) bool {
+		return m.Pages[i].First > name
+	}) - 1
+}
+
+// tagQuery - conjunction of tag key/value pairs objects must match.
+type tagQuery map[string]string
+
+// parseTagQuery parses "key=value" terms.
+func parseTagQuery(terms []string) (tagQuery, error) {
+	if len(terms) == 0 {
+		return nil, errors.New("at least one tag must be given")
+	}
+	q := make(tagQuery, len(terms))
+	for _, term := range terms {
+		kv := strings.SplitN(term, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, errors.New("tags must be given as key=value")
+		}
+		q[kv[0]] = kv[1]
+	}
+	return q, nil
+}
+
+func (q tagQuery) match(e tagIndexEntry) bool {
+	for k, v := range q {
+		if tv, ok := e.Tags[k]; !ok || tv != v {
+			return false
+		}
+	}
+	return true
+}
+
+func tagIndexManifestPath(bucket string) string {
+	return pathJoin(bucketMetaPrefix, bucket, tagIndexDir, "index.bin")
+}
+
+func tagIndexPagePath(bucket, id string) string {
+	return pathJoin(bucketMetaPrefix, bucket, tagIndexDir, id+".bin")
+}
+
+// loadTagIndexManifest loads the manifest of the tag index of bucket.
+func loadTagIndexManifest(ctx context.Context, objAPI ObjectLayer, bucket string) (*tagIndexManifest, error) {
+	data, err := readConfig(ctx, objAPI, tagIndexManifestPath(bucket))
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return nil, errTagIndexNotFound
+		}
+		return nil, err
+	}
+	m := &tagIndexManifest{}
+	if _, err = m.UnmarshalMsg(data); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func loadTagIndexPage(ctx context.Context, objAPI ObjectLayer, bucket, id string) (*tagIndexPage, error) {
+	data, err := readConfig(ctx, objAPI, tagIndexPagePath(bucket, id))
+	if err != nil {
+		return nil, err
+	}
+	p := &tagIndexPage{}
+	if _, err = p.UnmarshalMsg(data); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// tagSearchResult - entries found by a tag search, the search continues
+// after next when truncated.
+type tagSearchResult struct {
+	entries   []tagIndexEntry
+	truncated bool
+	next      string
+	updated   time.Time
+}
+
+// searchTagIndex returns up to maxKeys entries under prefix after marker
+// matching q whose objects are allowed by allow. Only the pages holding
+// the searched names are read, up to tagIndexMaxSearchPages of them.
+func searchTagIndex(ctx context.Context, objAPI ObjectLayer, bucket string, q tagQuery, prefix, marker string, maxKeys int, allow func(name string) bool) (res tagSearchResult, err error) {
+	m, err := loadTagIndexManifest(ctx, objAPI, bucket)
+	if err != nil {
+		return res, err
+	}
+	res.updated = m.Updated
+
+	start := prefix
+	if marker > start {
+		start = marker
+	}
+	var examined string
+	for read, retries := 0, 0; ; {
+		i := m.page(start)
+		page, err := loadTagIndexPage(ctx, objAPI, bucket, m.Pages[i].ID)
+		if errors.Is(err, errConfigNotFound) && retries < 3 {
+			// Replaced by a concurrent update, continue with the
+			// pages of the new manifest.
+			retries++
+			if m, err = loadTagIndexManifest(ctx, objAPI, bucket); err != nil {
+				return res, err
+			}
+			continue
+		}
+		if err != nil {
+			return res, err
+		}
+		read++
+
+		j := sort.Search(len(page.Entries), func(j int) bool {
+			return page.Entries[j].Name >= start
+		})
+		for ; j < len(page.Entries); j++ {
+			e := page.Entries[j]
+			if e.Name == marker {
+				continue
+			}
+			if !strings.HasPrefix(e.Name, prefix) {
+				return res, nil
+			}
+			if !q.match(e) || !allow(e.Name) {
+				examined = e.Name
+				continue
+			}
+			if len(res.entries) == maxKeys {
+				res.truncated = true
+				res.next = res.entries[len(res.entries)-1].Name
+				return res, nil
+			}
+			res.entries = append(res.entries, e)
+			examined = e.Name
+		}
+
+		if i+1 == len(m.Pages) || !strings.HasPrefix(m.Pages[i+1].First, prefix) {
+			return res, nil
+		}
+		if read == tagIndexMaxSearchPages && examined != "" {
+			res.truncated = true
+			res.next = examined
+			return res, nil
+		}
+		start = m.Pages[i+1].First
+	}
+}
+
+// tagIndexUpdate - the tags of an object, or a request to read them from
+// the latest version of the object when refresh is set.
+type tagIndexUpdate struct {
+	bucket  string
+	name    string
+	tags    map[string]string
+	refresh bool
+}
+
+func equalTags(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if bv, ok := b[k]; !ok || bv != v {
+			return false
+		}
+	}
+	return true
+}
+
+// latestObjectTags returns the tags of the latest version of object, nil
+// if the object has no tags or does not exist.
+func latestObjectTags(ctx context.Context, objAPI ObjectLayer, bucket, object string) (map[string]string, error) {
+	oi, err := objAPI.GetObjectInfo(ctx, bucket, object, ObjectOptions{})
+	if err != nil {
+		if isErrObjectNotFound(err) || isErrVersionNotFound(err) || isErrMethodNotAllowed(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if oi.DeleteMarker || oi.UserTags == "" {
+		return nil, nil
+	}
+	t, err := tags.ParseObjectTags(oi.UserTags)
+	if err != nil {
+		return nil, nil
+	}
+	return t.ToMap(), nil
+}
+
+// applyTagIndexUpdates applies updates to the tag index of bucket, only
+// the pages holding updated objects are read and rewritten.
+func applyTagIndexUpdates(ctx context.Context, objAPI ObjectLayer, bucket string, updates []tagIndexUpdate) error {
+	if _, err := objAPI.GetBucketInfo(ctx, bucket, BucketOptions{}); err != nil {
+		if isErrBucketNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	// Later updates of an object supersede earlier ones.
+	latest := make(map[string]tagIndexUpdate, len(updates))
+	for _, u := range updates {
+		latest[u.name] = u
+	}
+	names := make([]string, 0, len(latest))
+	for name, u := range latest {
+		if u.refresh {
+			t, err := latestObjectTags(ctx, objAPI, bucket, name)
+			if err != nil {
+				if isErrBucketNotFound(err) {
+					return nil
+				}
+				// Caught up with by the scanner.
+				logger.LogIf(ctx, err)
+				continue
+			}
+			u.tags = t
+			latest[name] = u
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	lk := objAPI.NewNSLock(minioMetaBucket, pathJoin(bucketMetaPrefix, bucket, tagIndexDir, "index.lock"))
+	lkctx, err := lk.GetLock(ctx, globalOperationTimeout)
+	if err != nil {
+		return err
+	}
+	ctx = lkctx.Context()
+	defer lk.Unlock(lkctx.Cancel)
+
+	m, err := loadTagIndexManifest(ctx, objAPI, bucket)
+	created := errors.Is(err, errTagIndexNotFound)
+	if created {
+		m = &tagIndexManifest{Pages: []tagIndexPageInfo{{ID: mustGetUUID()}}}
+	} else if err != nil {
+		return err
+	}
+
+	var (
+		pages    []tagIndexPageInfo
+		replaced []string
+	)
+	for i, n := 0, 0; i < len(m.Pages); i++ {
+		info := m.Pages[i]
+		var pageNames []string
+		for ; n < len(names) && (i+1 == len(m.Pages) || names[n] < m.Pages[i+1].First); n++ {
+			pageNames = append(pageNames, names[n])
+		}
+		if len(pageNames) == 0 {
+			pages = append(pages, info)
+			continue
+		}
+
+		page := &tagIndexPage{}
+		if !created {
+			if page, err = loadTagIndexPage(ctx, objAPI, bucket, info.ID); err != nil {
+				return err
+			}
+		}
+		entries := make(map[string]map[string]string, len(page.Entries))
+		for _, e := range page.Entries {
+			entries[e.Name] = e.Tags
+		}
+		changed := false
+		for _, name := range pageNames {
+			t := latest[name].tags
+			old, ok := entries[name]
+			switch {
+			case len(t) == 0 && ok:
+				delete(entries, name)
+				changed = true
+			case len(t) > 0 && (!ok || !equalTags(old, t)):
+				entries[name] = t
+				changed = true
+			}
+		}
+		if !changed && !created {
+			pages = append(pages, info)
+			continue
+		}
+
+		page.Entries = page.Entries[:0]
+		for name, t := range entries {
+			page.Entries = append(page.Entries, tagIndexEntry{Name: name, Tags: t})
+		}
+		sort.Slice(page.Entries, func(i, j int) bool {
+			return page.Entries[i].Name < page.Entries[j].Name
+		})
+		if !created {
+			replaced = append(replaced, info.ID)
+		}
+		if len(page.Entries) == 0 && i > 0 {
+			// Merged into the previous page.
+			continue
+		}
+
+		// Split pages grown too large in halves of the page size.
+		chunk := tagIndexPageSize
+		if len(page.Entries) > tagIndexPageSize {
+			chunk = tagIndexPageSize / 2
+		}
+		for k := 0; k == 0 || k < len(page.Entries); k += chunk {
+			end := k + chunk
+			if end > len(page.Entries) {
+				end = len(page.Entries)
+			}
+			p := tagIndexPage{Entries: page.Entries[k:end]}
+			first := info.First
+			if k > 0 {
+				first = p.Entries[0].Name
+			}
+			id := mustGetUUID()
+			data, err := p.MarshalMsg(nil)
+			if err != nil {
+				return err
+			}
+			if err = saveConfig(ctx, objAPI, tagIndexPagePath(bucket, id), data); err != nil {
+				return err
+			}
+			pages = append(pages, tagIndexPageInfo{First: first, ID: id})
+		}
+	}
+	if len(replaced) == 0 && !created {
+		return nil
+	}
+
+	m.Pages = pages
+	m.Updated = UTCNow()
+	data, err := m.MarshalMsg(nil)
+	if err != nil {
+		return err
+	}
+	if err = saveConfig(ctx, objAPI, tagIndexManifestPath(bucket), data); err != nil {
+		return err
+	}
+	for _, id := range replaced {
+		if err = deleteConfig(ctx, objAPI, tagIndexPagePath(bucket, id)); err != nil && !errors.Is(err, errConfigNotFound) {
+			logger.LogIf(ctx, err)
+		}
+	}
+	return nil
+}
+
+// tagIndexer keeps the tag index of buckets current with the objects
+// changed by requests and the objects visited by the scanner. Updates
+// are applied every globalTagIndexInterval.
+type tagIndexer struct {
+	once  sync.Once
+	queue chan tagIndexUpdate
+}
+
+var globalTagIndexer = &tagIndexer{}
+
+func (t *tagIndexer) start() bool {
+	if globalTagIndexInterval <= 0 {
+		return false
+	}
+	t.once.Do(func() {
+		t.queue = make(chan tagIndexUpdate, tagIndexQueueSize)
+		go t.run(GlobalContext)
+	})
+	return true
+}
+
+// record queues the object changed by args to update its tags, changes
+// are dropped if the indexer falls behind, the scanner catches up with
+// them.
+func (t *tagIndexer) record(args eventArgs) {
+	switch args.EventName {
+	case event.ObjectCreatedPut, event.ObjectCreatedPost, event.ObjectCreatedCopy,
+		event.ObjectCreatedCompleteMultipartUpload,
+		event.ObjectCreatedPutTagging, event.ObjectCreatedDeleteTagging,
+		event.ObjectRemovedDelete, event.ObjectRemovedDeleteMarkerCreated:
+	default:
+		return
+	}
+	if !t.start() {
+		return
+	}
+	select {
+	case t.queue <- tagIndexUpdate{bucket: args.BucketName, name: args.Object.Name, refresh: true}:
+	default:
+		logger.LogOnceIf(GlobalContext, errors.New("tag index queue is full, object changes are indexed by the scanner"), "tag-index-full")
+	}
+}
+
+// scanned queues the tags of oi, the latest version of an object visited
+// by the scanner.
+func (t *tagIndexer) scanned(ctx context.Context, oi ObjectInfo) {
+	if !t.start() {
+		return
+	}
+	u := tagIndexUpdate{bucket: oi.Bucket, name: oi.Name}
+	if !oi.DeleteMarker && oi.UserTags != "" {
+		if tg, err := tags.ParseObjectTags(oi.UserTags); err == nil {
+			u.tags = tg.ToMap()
+		}
+	}
+	select {
+	case t.queue <- u:
+	case <-ctx.Done():
+	}
+}
+
+func (t *tagIndexer) run(ctx context.Context) {
+	ticker := time.NewTicker(globalTagIndexInterval)
+	defer ticker.Stop()
+
+	pending := make(map[string][]tagIndexUpdate)
+	flush := func(bucket string) {
+		if objAPI := newObjectLayerFn(); objAPI != nil {
+			logger.LogIf(ctx, applyTagIndexUpdates(ctx, objAPI, bucket, pending[bucket]))
+		}
+		delete(pending, bucket)
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case u := <-t.queue:
+			pending[u.bucket] = append(pending[u.bucket], u)
+			if len(pending[u.bucket]) >= tagIndexBatchSize {
+				flush(u.bucket)
+			}
+		case <-ticker.C:
+			for bucket := range pending {
+				flush(bucket)
+			}
+		}
+	}
+}
@@ -0,0 +1,178 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	xhttp "github.com/qkbyte/minio/internal/http"
+)
+
+func TestParseTagQuery(t *testing.T) {
+	testCases := []struct {
+		terms   []string
+		success bool
+	}{
+		{[]string{"env=prod"}, true},
+		{[]string{"env=prod", "team=storage"}, true},
+		{[]string{"env="}, true},
+		{nil, false},
+		{[]string{"env"}, false},
+		{[]string{"=prod"}, false},
+	}
+	for i, tc := range testCases {
+		if _, err := parseTagQuery(tc.terms); (err == nil) != tc.success {
+			t.Errorf("Test %d: expected success %v, got %v", i+1, tc.success, err)
+		}
+	}
+}
+
+func TestTagIndexUpdates(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	objLayer, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+
+	bucket := "tagged"
+	if err = objLayer.MakeBucketWithLocation(ctx, bucket, MakeBucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	objects := map[string]string{
+		"a/1": "env=prod&team=storage",
+		"a/2": "env=dev",
+		"a/3": "",
+		"b/1": "env=prod",
+	}
+	var updates []tagIndexUpdate
+	for name, tags := range objects {
+		opts := ObjectOptions{UserDefined: map[string]string{}}
+		if tags != "" {
+			opts.UserDefined[xhttp.AmzObjectTagging] = tags
+		}
+		data := []byte(name)
+		if _, err = objLayer.PutObject(ctx, bucket, name, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), opts); err != nil {
+			t.Fatal(err)
+		}
+		updates = append(updates, tagIndexUpdate{bucket: bucket, name: name, refresh: true})
+	}
+
+	if _, err = searchTagIndex(ctx, objLayer, bucket, tagQuery{"env": "prod"}, "", "", 10, nil); err != errTagIndexNotFound {
+		t.Fatalf("expected errTagIndexNotFound, got %v", err)
+	}
+	if err = applyTagIndexUpdates(ctx, objLayer, bucket, updates); err != nil {
+		t.Fatal(err)
+	}
+
+	allowAll := func(string) bool { return true }
+	search := func(q tagQuery, prefix, marker string, maxKeys int, allow func(string) bool) (names []string, res tagSearchResult) {
+		t.Helper()
+		res, err := searchTagIndex(ctx, objLayer, bucket, q, prefix, marker, maxKeys, allow)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, e := range res.entries {
+			names = append(names, e.Name)
+		}
+		return names, res
+	}
+
+	names, res := search(tagQuery{"env": "prod"}, "", "", 10, allowAll)
+	if len(names) != 2 || res.truncated || res.updated.IsZero() {
+		t.Fatalf("unexpected result %v, %+v", names, res)
+	}
+	names, _ = search(tagQuery{"env": "prod", "team": "storage"}, "", "", 10, allowAll)
+	if len(names) != 1 || names[0] != "a/1" {
+		t.Fatalf("unexpected result %v", names)
+	}
+	names, res = search(tagQuery{"env": "prod"}, "", "", 1, allowAll)
+	if len(names) != 1 || names[0] != "a/1" || !res.truncated || res.next != "a/1" {
+		t.Fatalf("unexpected result %v, %+v", names, res)
+	}
+	names, res = search(tagQuery{"env": "prod"}, "", res.next, 1, allowAll)
+	if len(names) != 1 || names[0] != "b/1" || res.truncated {
+		t.Fatalf("unexpected result %v, %+v", names, res)
+	}
+
+	// Objects whose tags may not be read are left out.
+	names, _ = search(tagQuery{"env": "prod"}, "", "", 10, func(name string) bool { return name != "a/1" })
+	if len(names) != 1 || names[0] != "b/1" {
+		t.Fatalf("unexpected result %v", names)
+	}
+
+	// Removed objects are removed from the index.
+	if _, err = objLayer.DeleteObject(ctx, bucket, "a/1", ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err = applyTagIndexUpdates(ctx, objLayer, bucket, []tagIndexUpdate{{bucket: bucket, name: "a/1", refresh: true}}); err != nil {
+		t.Fatal(err)
+	}
+	if names, _ = search(tagQuery{"env": "prod"}, "", "", 10, allowAll); len(names) != 1 || names[0] != "b/1" {
+		t.Fatalf("unexpected result %v", names)
+	}
+
+	// Pages growing too large are split, a search reads the pages of
+	// the searched names only.
+	before, err := loadTagIndexManifest(ctx, objLayer, bucket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	updates = updates[:0]
+	for i := 0; i < 3*tagIndexPageSize; i++ {
+		updates = append(updates, tagIndexUpdate{bucket: bucket, name: fmt.Sprintf("p/%05d", i), tags: map[string]string{"env": "prod"}})
+	}
+	if err = applyTagIndexUpdates(ctx, objLayer, bucket, updates); err != nil {
+		t.Fatal(err)
+	}
+	m, err := loadTagIndexManifest(ctx, objLayer, bucket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(m.Pages) < 6 || m.Pages[0].First != "" {
+		t.Fatalf("expected the index to be split in pages, got %+v", m.Pages)
+	}
+	var found int
+	marker := ""
+	for {
+		names, res = search(tagQuery{"env": "prod"}, "p/", marker, tagIndexPageSize, allowAll)
+		found += len(names)
+		if !res.truncated {
+			break
+		}
+		marker = res.next
+	}
+	if found != 3*tagIndexPageSize {
+		t.Fatalf("expected %d objects, got %d", 3*tagIndexPageSize, found)
+	}
+
+	// Replaced pages are removed.
+	if _, err = loadTagIndexPage(ctx, objLayer, bucket, before.Pages[0].ID); err != errConfigNotFound {
+		t.Fatalf("expected the replaced page to be removed, got %v", err)
+	}
+	for _, p := range m.Pages {
+		if _, err = loadTagIndexPage(ctx, objLayer, bucket, p.ID); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
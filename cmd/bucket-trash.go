@@ -0,0 +1,431 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/qkbyte/minio/internal/hash"
+	xhttp "github.com/qkbyte/minio/internal/http"
+	"github.com/qkbyte/minio/internal/logger"
+)
+
+const (
+	// bucketTrashDir is the directory below the bucket metadata
+	// prefix holding objects deleted from a bucket with a recycle bin.
+	bucketTrashDir = ".trash"
+
+	// defaultBucketTrashTTL is the time deleted objects are kept
+	// in the recycle bin when no TTL is configured.
+	defaultBucketTrashTTL = 7 * 24 * time.Hour
+
+	// bucketTrashPurgeInterval is the interval between recycle bin purges.
+	bucketTrashPurgeInterval = time.Hour
+
+	// Reserved metadata recording the original object name and
+	// deletion time of an object in the recycle bin.
+	bucketTrashObjectKey    = ReservedMetadataPrefixLower + "trash-object"
+	bucketTrashDeletedAtKey = ReservedMetadataPrefixLower + "trash-deleted-at"
+)
+
+var errBucketTrashEntryNotFound = errors.New("Recycle bin entry not found")
+
+// bucketTrashPurgeLeaderLockTimeout bounds the wait for the purge lock,
+// nodes which do not get it skip the purge.
+var bucketTrashPurgeLeaderLockTimeout = newDynamicTimeout(30*time.Second, 10*time.Second)
+
+// bucketTrashConfig - recycle bin configuration of a non-versioned
+// bucket, deleted objects are kept for TTL before being purged.
+type bucketTrashConfig struct {
+	Enabled bool   `json:"enabled"`
+	TTL     string `json:"ttl,omitempty"`
+
+	ttl time.Duration
+}
+
+// Expiry returns the time deleted objects are kept.
+func (c *bucketTrashConfig) Expiry() time.Duration {
+	if c == nil || c.ttl == 0 {
+		return defaultBucketTrashTTL
+	}
+	return c.ttl
+}
+
+func parseBucketTrashConfig(data []byte) (*bucketTrashConfig, error) {
+	cfg := &bucketTrashConfig{}
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, err
+	}
+	if cfg.TTL != "" {
+		ttl, err := time.ParseDuration(cfg.TTL)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid recycle bin TTL %s: %w", cfg.TTL, err)
+		}
+		if ttl <= 0 {
+			return nil, fmt.Errorf("Invalid recycle bin TTL %s: must be positive", cfg.TTL)
+		}
+		cfg.ttl = ttl
+	}
+	return cfg, nil
+}
+
+// bucketTrashEntry describes an object in the recycle bin.
+type bucketTrashEntry struct {
+	ID        string    `json:"id"`
+	Object    string    `json:"object"`
+	Size      int64     `json:"size"`
+	ETag      string    `json:"etag"`
+	ModTime   time.Time `json:"modTime"`
+	DeletedAt time.Time `json:"deletedAt"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+func bucketTrashPrefix(bucket string) string {
+	return path.Join(bucketMetaPrefix, bucket, bucketTrashDir) + SlashSeparator
+}
+
+// newBucketTrashID returns a new entry ID, IDs sort by deletion time.
+func newBucketTrashID(deletedAt time.Time) string {
+	return fmt.Sprintf("%016x-%s", deletedAt.UnixNano(), mustGetUUID())
+}
+
+// isBucketTrashEnabled returns true if deletes of the object go to the recycle bin.
+func isBucketTrashEnabled(bucket string, opts ObjectOptions) bool {
+	if opts.Versioned || opts.VersionSuspended || opts.DeletePrefix || opts.VersionID != "" {
+		return false
+	}
+	cfg, _, err := globalBucketMetadataSys.GetTrashConfig(bucket)
+	return err == nil && cfg != nil && cfg.Enabled
+}
+
+// copyObjectRaw writes the content of gr as is, without decrypting or
+// decompressing, to dstBucket/dstObject preserving the part layout so
+// that the copy can be moved back to its original name later. The
+// metadata, versioning and modification time of the copy are taken
+// from opts.
+func copyObjectRaw(ctx context.Context, objAPI ObjectLayer, gr *GetObjectReader, dstBucket, dstObject string, opts ObjectOptions) (ObjectInfo, error) {
+	objInfo := gr.ObjInfo
+	actualSize, err := objInfo.GetActualSize()
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+
+	if objInfo.isMultipart() {
+		res, err := objAPI.NewMultipartUpload(ctx, dstBucket, dstObject, opts)
+		if err != nil {
+			return ObjectInfo{}, err
+		}
+		defer objAPI.AbortMultipartUpload(ctx, dstBucket, dstObject, res.UploadID, ObjectOptions{})
+		parts := make([]CompletePart, len(objInfo.Parts))
+		for i, part := range objInfo.Parts {
+			hr, err := hash.NewReader(gr, part.Size, "", "", part.ActualSize)
+			if err != nil {
+				return ObjectInfo{}, err
+			}
+			index := part.Index
+			pi, err := objAPI.PutObjectPart(ctx, dstBucket, dstObject, res.UploadID, part.Number, NewPutObjReader(hr), ObjectOptions{
+				PreserveETag: part.ETag,
+				IndexCB: func() []byte {
+					return index
+				},
+			})
+			if err != nil {
+				return ObjectInfo{}, err
+			}
+			parts[i] = CompletePart{
+				ETag:           pi.ETag,
+				PartNumber:     pi.PartNumber,
+				ChecksumCRC32:  pi.ChecksumCRC32,
+				ChecksumCRC32C: pi.ChecksumCRC32C,
+				ChecksumSHA256: pi.ChecksumSHA256,
+				ChecksumSHA1:   pi.ChecksumSHA1,
+			}
+		}
+		return objAPI.CompleteMultipartUpload(ctx, dstBucket, dstObject, res.UploadID, parts, ObjectOptions{
+			MTime:            opts.MTime,
+			Versioned:        opts.Versioned,
+			VersionSuspended: opts.VersionSuspended,
+		})
+	}
+
+	hr, err := hash.NewReader(gr, objInfo.Size, "", "", actualSize)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	var index []byte
+	if len(objInfo.Parts) > 0 {
+		index = objInfo.Parts[0].Index
+	}
+	opts.PreserveETag = objInfo.ETag
+	opts.IndexCB = func() []byte {
+		return index
+	}
+	return objAPI.PutObject(ctx, dstBucket, dstObject, NewPutObjReader(hr), opts)
+}
+
+// rawObjectMetadata returns the metadata needed to recreate the object
+// from its raw content.
+func rawObjectMetadata(objInfo ObjectInfo) map[string]string {
+	userDefined := make(map[string]string, len(objInfo.UserDefined)+2)
+	for k, v := range objInfo.UserDefined {
+		userDefined[k] = v
+	}
+	if objInfo.UserTags != "" {
+		userDefined[xhttp.AmzObjectTagging] = objInfo.UserTags
+	}
+	if !objInfo.Expires.IsZero() {
+		userDefined[xhttp.Expires] = objInfo.Expires.UTC().Format(http.TimeFormat)
+	}
+	return userDefined
+}
+
+// moveObjectToTrash moves the object into the recycle bin of the bucket,
+// the copy and the delete happen under the write lock of the object so
+// that no concurrent write is lost in between. Missing objects and objects
+// transitioned to a remote tier are not moved, false is returned for them
+// and the caller deletes them as usual.
+func moveObjectToTrash(ctx context.Context, objAPI ObjectLayer, bucket, object string, opts ObjectOptions) (ObjectInfo, bool, error) {
+	lk := objAPI.NewNSLock(bucket, object)
+	lkctx, err := lk.GetLock(ctx, globalDeleteOperationTimeout)
+	if err != nil {
+		return ObjectInfo{}, false, err
+	}
+	ctx = lkctx.Context()
+	defer lk.Unlock(lkctx.Cancel)
+
+	trashObject, err := copyObjectToTrash(ctx, objAPI, bucket, object)
+	if err != nil || trashObject == "" {
+		return ObjectInfo{}, false, err
+	}
+
+	opts.NoLock = true
+	objInfo, err := objAPI.DeleteObject(ctx, bucket, object, opts)
+	if err != nil {
+		// Do not keep a copy of an object that was not deleted.
+		if _, derr := objAPI.DeleteObject(ctx, minioMetaBucket, trashObject, ObjectOptions{}); derr != nil {
+			logger.LogIf(ctx, derr)
+		}
+		return objInfo, false, err
+	}
+	return objInfo, true, nil
+}
+
+// copyObjectToTrash copies the object into the recycle bin of the bucket
+// and returns the name of the copy, the caller must hold the object lock.
+// An empty name is returned for objects which are not kept.
+func copyObjectToTrash(ctx context.Context, objAPI ObjectLayer, bucket, object string) (string, error) {
+	gr, err := objAPI.GetObjectNInfo(ctx, bucket, object, nil, http.Header{}, noLock, ObjectOptions{NoDecryption: true, NoLock: true})
+	if err != nil {
+		if isErrObjectNotFound(err) || isErrVersionNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	defer gr.Close()
+
+	if gr.ObjInfo.DeleteMarker || gr.ObjInfo.TransitionedObject.Status != "" {
+		return "", nil
+	}
+
+	deletedAt := UTCNow()
+	userDefined := rawObjectMetadata(gr.ObjInfo)
+	userDefined[bucketTrashObjectKey] = object
+	userDefined[bucketTrashDeletedAtKey] = deletedAt.Format(time.RFC3339Nano)
+
+	trashObject := bucketTrashPrefix(bucket) + newBucketTrashID(deletedAt)
+	if _, err = copyObjectRaw(ctx, objAPI, gr, minioMetaBucket, trashObject, ObjectOptions{
+		MTime:       gr.ObjInfo.ModTime,
+		UserDefined: userDefined,
+	}); err != nil {
+		return "", err
+	}
+	return trashObject, nil
+}
+
+// parseBucketTrashID returns the deletion time encoded in the entry ID.
+func parseBucketTrashID(id string) (time.Time, bool) {
+	i := strings.IndexByte(id, '-')
+	if i <= 0 {
+		return time.Time{}, false
+	}
+	nanos, err := strconv.ParseInt(id[:i], 16, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(0, nanos).UTC(), true
+}
+
+// listBucketTrash returns the entries in the recycle bin of the bucket
+// whose original object name starts with prefix, ordered by deletion time.
+func listBucketTrash(ctx context.Context, objAPI ObjectLayer, bucket, prefix string) ([]bucketTrashEntry, error) {
+	cfg, _, _ := globalBucketMetadataSys.GetTrashConfig(bucket)
+	trashPrefix := bucketTrashPrefix(bucket)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan ObjectInfo)
+	if err := objAPI.Walk(ctx, minioMetaBucket, trashPrefix, results, ObjectOptions{}); err != nil {
+		return nil, err
+	}
+
+	var entries []bucketTrashEntry
+	for oi := range results {
+		id := strings.TrimPrefix(oi.Name, trashPrefix)
+		object := oi.UserDefined[bucketTrashObjectKey]
+		deletedAt, ok := parseBucketTrashID(id)
+		if !ok || object == "" || !strings.HasPrefix(object, prefix) {
+			continue
+		}
+		size, err := oi.GetActualSize()
+		if err != nil {
+			size = oi.Size
+		}
+		entries = append(entries, bucketTrashEntry{
+			ID:        id,
+			Object:    object,
+			Size:      size,
+			ETag:      oi.ETag,
+			ModTime:   oi.ModTime,
+			DeletedAt: deletedAt,
+			ExpiresAt: deletedAt.Add(cfg.Expiry()),
+		})
+	}
+	return entries, nil
+}
+
+// restoreObjectFromTrash moves the recycle bin entry back to its original
+// object name, restoring over an existing object is refused.
+func restoreObjectFromTrash(ctx context.Context, objAPI ObjectLayer, bucket, id string) (string, error) {
+	if _, ok := parseBucketTrashID(id); !ok || strings.Contains(id, SlashSeparator) {
+		return "", errBucketTrashEntryNotFound
+	}
+	trashObject := bucketTrashPrefix(bucket) + id
+
+	object, err := copyObjectFromTrash(ctx, objAPI, bucket, trashObject)
+	if err != nil {
+		return object, err
+	}
+
+	_, err = objAPI.DeleteObject(ctx, minioMetaBucket, trashObject, ObjectOptions{})
+	return object, err
+}
+
+// copyObjectFromTrash copies the recycle bin entry to its original name,
+// the read lock on the entry is released once it returns.
+func copyObjectFromTrash(ctx context.Context, objAPI ObjectLayer, bucket, trashObject string) (string, error) {
+	gr, err := objAPI.GetObjectNInfo(ctx, minioMetaBucket, trashObject, nil, http.Header{}, readLock, ObjectOptions{NoDecryption: true})
+	if err != nil {
+		if isErrObjectNotFound(err) {
+			return "", errBucketTrashEntryNotFound
+		}
+		return "", err
+	}
+	defer gr.Close()
+
+	object := gr.ObjInfo.UserDefined[bucketTrashObjectKey]
+	if object == "" {
+		return "", errBucketTrashEntryNotFound
+	}
+
+	if _, err = objAPI.GetObjectInfo(ctx, bucket, object, ObjectOptions{}); err == nil {
+		return object, ObjectAlreadyExists{Bucket: bucket, Object: object}
+	} else if !isErrObjectNotFound(err) {
+		return object, err
+	}
+
+	userDefined := rawObjectMetadata(gr.ObjInfo)
+	delete(userDefined, bucketTrashObjectKey)
+	delete(userDefined, bucketTrashDeletedAtKey)
+	_, err = copyObjectRaw(ctx, objAPI, gr, bucket, object, ObjectOptions{
+		MTime:       gr.ObjInfo.ModTime,
+		UserDefined: userDefined,
+	})
+	return object, err
+}
+
+// purgeBucketTrash removes the recycle bin entries deleted before now-ttl.
+func purgeBucketTrash(ctx context.Context, objAPI ObjectLayer, bucket string, ttl time.Duration, now time.Time) error {
+	entries, err := listBucketTrash(ctx, objAPI, bucket, "")
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if now.Sub(entry.DeletedAt) < ttl {
+			continue
+		}
+		_, err := objAPI.DeleteObject(ctx, minioMetaBucket, bucketTrashPrefix(bucket)+entry.ID, ObjectOptions{})
+		if err != nil && !isErrObjectNotFound(err) {
+			logger.LogIf(ctx, err)
+		}
+	}
+	return nil
+}
+
+// initBucketTrashPurge periodically purges expired recycle bin entries
+// of all buckets that have a recycle bin configured.
+func initBucketTrashPurge(ctx context.Context, objAPI ObjectLayer) {
+	go func() {
+		r := rand.New(rand.NewSource(time.Now().UnixNano()))
+		timer := time.NewTimer(bucketTrashPurgeInterval + time.Duration(r.Int63n(int64(bucketTrashPurgeInterval/10))))
+		defer timer.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-timer.C:
+				runBucketTrashPurge(ctx, objAPI)
+				timer.Reset(bucketTrashPurgeInterval)
+			}
+		}
+	}()
+}
+
+// runBucketTrashPurge purges expired recycle bin entries of all buckets,
+// only one node in the cluster runs the purge at a time.
+func runBucketTrashPurge(ctx context.Context, objAPI ObjectLayer) {
+	locker := objAPI.NewNSLock(minioMetaBucket, "buckets/trash-purge.lock")
+	lkctx, err := locker.GetLock(ctx, bucketTrashPurgeLeaderLockTimeout)
+	if err != nil {
+		return
+	}
+	ctx = lkctx.Context()
+	defer locker.Unlock(lkctx.Cancel)
+
+	buckets, err := objAPI.ListBuckets(ctx, BucketOptions{})
+	if err != nil {
+		logger.LogIf(ctx, err)
+	}
+	for _, bucket := range buckets {
+		meta, err := globalBucketMetadataSys.Get(bucket.Name)
+		if err != nil || len(meta.TrashConfigJSON) == 0 {
+			continue
+		}
+		logger.LogIf(ctx, purgeBucketTrash(ctx, objAPI, bucket.Name, meta.trashConfig.Expiry(), UTCNow()))
+	}
+}
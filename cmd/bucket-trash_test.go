@@ -0,0 +1,204 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/dustin/go-humanize"
+)
+
+func TestParseBucketTrashConfig(t *testing.T) {
+	cfg, err := parseBucketTrashConfig([]byte(`{"enabled":true,"ttl":"48h"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cfg.Enabled || cfg.Expiry() != 48*time.Hour {
+		t.Fatalf("unexpected config %+v", cfg)
+	}
+
+	cfg, err = parseBucketTrashConfig([]byte(`{"enabled":true}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Expiry() != defaultBucketTrashTTL {
+		t.Fatalf("expected default TTL, got %s", cfg.Expiry())
+	}
+
+	for _, data := range []string{`{"enabled":true,"ttl":"-1h"}`, `{"enabled":true,"ttl":"1x"}`, `{`} {
+		if _, err = parseBucketTrashConfig([]byte(data)); err == nil {
+			t.Fatalf("expected error for %s", data)
+		}
+	}
+}
+
+func TestBucketTrash(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	objLayer, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+
+	defer func(sys *BucketMetadataSys) { globalBucketMetadataSys = sys }(globalBucketMetadataSys)
+	globalBucketMetadataSys = NewBucketMetadataSys()
+
+	bucket := "trash-bucket"
+	if err = objLayer.MakeBucketWithLocation(ctx, bucket, MakeBucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	meta := newBucketMetadata(bucket)
+	meta.trashConfig = &bucketTrashConfig{Enabled: true}
+	globalBucketMetadataSys.Set(bucket, meta)
+
+	if !isBucketTrashEnabled(bucket, ObjectOptions{}) {
+		t.Fatal("expected recycle bin to be enabled")
+	}
+	if isBucketTrashEnabled(bucket, ObjectOptions{Versioned: true}) {
+		t.Fatal("recycle bin must not be used for versioned buckets")
+	}
+
+	// Single part object.
+	data := bytes.Repeat([]byte("a"), 1024)
+	_, err = objLayer.PutObject(ctx, bucket, "dir/small", mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{
+		UserDefined: map[string]string{"content-type": "text/plain"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Multipart object.
+	part := bytes.Repeat([]byte("b"), 5*humanize.MiByte)
+	res, err := objLayer.NewMultipartUpload(ctx, bucket, "large", ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var parts []CompletePart
+	for i := 1; i <= 2; i++ {
+		pi, err := objLayer.PutObjectPart(ctx, bucket, "large", res.UploadID, i, mustGetPutObjReader(t, bytes.NewReader(part), int64(len(part)), "", ""), ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		parts = append(parts, CompletePart{PartNumber: pi.PartNumber, ETag: pi.ETag})
+	}
+	largeInfo, err := objLayer.CompleteMultipartUpload(ctx, bucket, "large", res.UploadID, parts, ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, object := range []string{"dir/small", "large", "missing"} {
+		_, trashed, err := moveObjectToTrash(ctx, objLayer, bucket, object, ObjectOptions{})
+		if err != nil {
+			t.Fatalf("%s: %v", object, err)
+		}
+		if trashed != (object != "missing") {
+			t.Fatalf("%s: unexpected trashed %v", object, trashed)
+		}
+		if _, err = objLayer.GetObjectInfo(ctx, bucket, object, ObjectOptions{}); !isErrObjectNotFound(err) {
+			t.Fatalf("%s: expected object to be deleted, got %v", object, err)
+		}
+	}
+
+	entries, err := listBucketTrash(ctx, objLayer, bucket, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+	entries, err = listBucketTrash(ctx, objLayer, bucket, "dir/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Object != "dir/small" || entries[0].Size != int64(len(data)) {
+		t.Fatalf("unexpected entries %+v", entries)
+	}
+
+	// Restore both objects and verify their content.
+	all, _ := listBucketTrash(ctx, objLayer, bucket, "")
+	for _, entry := range all {
+		object, err := restoreObjectFromTrash(ctx, objLayer, bucket, entry.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if object != entry.Object {
+			t.Fatalf("expected %s to be restored, got %s", entry.Object, object)
+		}
+	}
+
+	gr, err := objLayer.GetObjectNInfo(ctx, bucket, "dir/small", nil, http.Header{}, readLock, ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := io.ReadAll(gr)
+	gr.Close()
+	if err != nil || !bytes.Equal(got, data) {
+		t.Fatalf("restored object content mismatch: %v", err)
+	}
+	if gr.ObjInfo.ContentType != "text/plain" {
+		t.Fatalf("expected content type to be restored, got %s", gr.ObjInfo.ContentType)
+	}
+
+	oi, err := objLayer.GetObjectInfo(ctx, bucket, "large", ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if oi.ETag != largeInfo.ETag || len(oi.Parts) != 2 || oi.Size != largeInfo.Size {
+		t.Fatalf("restored multipart object mismatch: %s %d %d", oi.ETag, len(oi.Parts), oi.Size)
+	}
+
+	if entries, _ = listBucketTrash(ctx, objLayer, bucket, ""); len(entries) != 0 {
+		t.Fatalf("expected empty recycle bin, got %d", len(entries))
+	}
+
+	// Restoring over an existing object is refused.
+	if _, _, err = moveObjectToTrash(ctx, objLayer, bucket, "dir/small", ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = objLayer.PutObject(ctx, bucket, "dir/small", mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	entries, _ = listBucketTrash(ctx, objLayer, bucket, "")
+	if _, err = restoreObjectFromTrash(ctx, objLayer, bucket, entries[0].ID); err == nil {
+		t.Fatal("expected restore over an existing object to fail")
+	}
+	if _, err = restoreObjectFromTrash(ctx, objLayer, bucket, "0000000000000000-unknown"); err != errBucketTrashEntryNotFound {
+		t.Fatalf("expected entry not found, got %v", err)
+	}
+
+	// Entries are kept until the TTL has passed.
+	if err = purgeBucketTrash(ctx, objLayer, bucket, time.Hour, UTCNow()); err != nil {
+		t.Fatal(err)
+	}
+	if entries, _ = listBucketTrash(ctx, objLayer, bucket, ""); len(entries) != 1 {
+		t.Fatalf("expected entry to be kept, got %d", len(entries))
+	}
+	if err = purgeBucketTrash(ctx, objLayer, bucket, time.Hour, UTCNow().Add(2*time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if entries, _ = listBucketTrash(ctx, objLayer, bucket, ""); len(entries) != 0 {
+		t.Fatalf("expected entry to be purged, got %d", len(entries))
+	}
+}
@@ -20,6 +20,7 @@ package cmd
 import (
 	"encoding/base64"
 	"encoding/xml"
+	"fmt"
 	"io"
 	"net/http"
 
@@ -82,7 +83,8 @@ func (api objectAPIHandlers) PutBucketVersioningHandler(w http.ResponseWriter, r
 		}, r.URL)
 		return
 	}
-	if _, err := getReplicationConfig(ctx, bucket); err == nil && v.Suspended() {
+	rcfg, err := getReplicationConfig(ctx, bucket)
+	if err == nil && v.Suspended() {
 		writeErrorResponse(ctx, w, APIError{
 			Code:           "InvalidBucketState",
 			Description:    "A replication configuration is present on this bucket, bucket wide versioning cannot be suspended.",
@@ -90,6 +92,9 @@ func (api objectAPIHandlers) PutBucketVersioningHandler(w http.ResponseWriter, r
 		}, r.URL)
 		return
 	}
+	for _, warning := range versioningReplicationWarnings(v, rcfg) {
+		logger.LogIf(ctx, fmt.Errorf("bucket %s: %s", bucket, warning))
+	}
 
 	configData, err := xml.Marshal(v)
 	if err != nil {
@@ -18,8 +18,10 @@
 package cmd
 
 import (
+	"fmt"
 	"strings"
 
+	"github.com/qkbyte/minio/internal/bucket/replication"
 	"github.com/qkbyte/minio/internal/bucket/versioning"
 	"github.com/qkbyte/minio/internal/logger"
 )
@@ -82,6 +84,29 @@ func (sys *BucketVersioningSys) Get(bucket string) (*versioning.Versioning, erro
 	return vcfg, err
 }
 
+// versioningReplicationWarnings returns a warning for each enabled
+// replication rule covering objects excluded from versioning, such
+// objects are never replicated.
+func versioningReplicationWarnings(vc *versioning.Versioning, rcfg *replication.Config) (warnings []string) {
+	if vc == nil || rcfg == nil || !vc.PrefixesExcluded() {
+		return nil
+	}
+	for _, rule := range rcfg.Rules {
+		if rule.Status != replication.Enabled {
+			continue
+		}
+		if prefixes := vc.OverlappingExcludedPrefixes(rule.Prefix()); len(prefixes) > 0 {
+			warnings = append(warnings, fmt.Sprintf("replication rule '%s' (prefix '%s') overlaps prefixes excluded from versioning %v, objects under these prefixes will not be replicated",
+				rule.ID, rule.Prefix(), prefixes))
+		}
+		if vc.ExcludeFolders && vc.Enabled() {
+			warnings = append(warnings, fmt.Sprintf("replication rule '%s' (prefix '%s') covers folder objects excluded from versioning, these will not be replicated",
+				rule.ID, rule.Prefix()))
+		}
+	}
+	return warnings
+}
+
 // NewBucketVersioningSys - creates new versioning system.
 func NewBucketVersioningSys() *BucketVersioningSys {
 	return &BucketVersioningSys{}
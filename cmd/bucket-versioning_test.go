@@ -0,0 +1,63 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/qkbyte/minio/internal/bucket/replication"
+	"github.com/qkbyte/minio/internal/bucket/versioning"
+)
+
+func TestVersioningReplicationWarnings(t *testing.T) {
+	vc := &versioning.Versioning{
+		Status: versioning.Enabled,
+		ExcludedPrefixes: []versioning.ExcludedPrefix{
+			{Prefix: "logs/tmp/"},
+		},
+	}
+	rcfg := &replication.Config{
+		Rules: []replication.Rule{
+			{ID: "all", Status: replication.Enabled},
+			{ID: "logs", Status: replication.Enabled, Filter: replication.Filter{Prefix: "logs/"}},
+			{ID: "data", Status: replication.Enabled, Filter: replication.Filter{Prefix: "data/"}},
+			{ID: "disabled", Status: replication.Disabled, Filter: replication.Filter{Prefix: "logs/tmp/"}},
+		},
+	}
+
+	warnings := versioningReplicationWarnings(vc, rcfg)
+	if len(warnings) != 2 {
+		t.Fatalf("expected 2 warnings, got %v", warnings)
+	}
+	for i, id := range []string{"'all'", "'logs'"} {
+		if !strings.Contains(warnings[i], id) {
+			t.Fatalf("expected warning for rule %s, got %s", id, warnings[i])
+		}
+	}
+
+	vc.ExcludedPrefixes = nil
+	vc.ExcludeFolders = true
+	if warnings = versioningReplicationWarnings(vc, rcfg); len(warnings) != 3 {
+		t.Fatalf("expected a warning for every enabled rule, got %v", warnings)
+	}
+
+	if warnings = versioningReplicationWarnings(&versioning.Versioning{Status: versioning.Enabled}, rcfg); len(warnings) != 0 {
+		t.Fatalf("expected no warnings, got %v", warnings)
+	}
+}
@@ -0,0 +1,363 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/minio/pkg/bucket/policy"
+	"github.com/qkbyte/minio/internal/bucket/website"
+	xhttp "github.com/qkbyte/minio/internal/http"
+	"github.com/qkbyte/minio/internal/logger"
+)
+
+// PutBucketWebsiteHandler - PUT Bucket website.
+// ----------
+// Sets the static website configuration of the bucket, anonymous
+// GET and HEAD requests to the bucket are served as per the
+// configuration.
+func (api objectAPIHandlers) PutBucketWebsiteHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "PutBucketWebsite")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	// There is no dedicated website policy action, website
+	// configuration is managed along with the bucket policy.
+	if s3Error := checkRequestAuthType(ctx, r, policy.PutBucketPolicyAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+		return
+	}
+
+	// Check if bucket exists.
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket, BucketOptions{}); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	config, err := website.ParseConfig(io.LimitReader(r.Body, r.ContentLength))
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	configData, err := xml.Marshal(config)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	if _, err = globalBucketMetadataSys.Update(ctx, bucket, bucketWebsiteConfig, configData); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	// Write success response.
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// GetBucketWebsiteHandler - GET Bucket website.
+// ----------
+func (api objectAPIHandlers) GetBucketWebsiteHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetBucketWebsite")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.GetBucketPolicyAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+		return
+	}
+
+	// Validate if bucket exists, before proceeding further...
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket, BucketOptions{}); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	config, _, err := globalBucketMetadataSys.GetWebsiteConfig(bucket)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	configData, err := xml.Marshal(config)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	// Write success response.
+	writeSuccessResponseXML(w, configData)
+}
+
+// DeleteBucketWebsiteHandler - DELETE Bucket website.
+// ----------
+func (api objectAPIHandlers) DeleteBucketWebsiteHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "DeleteBucketWebsite")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.DeleteBucketPolicyAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+		return
+	}
+
+	// Check if bucket exists.
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket, BucketOptions{}); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	if _, err := globalBucketMetadataSys.Update(ctx, bucket, bucketWebsiteConfig, nil); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	// Write success response.
+	writeSuccessNoContent(w)
+}
+
+// websiteResponseWriter holds back error responses of website
+// requests so that they can be replaced by the error document
+// or a redirect, successful responses are passed through.
+type websiteResponseWriter struct {
+	http.ResponseWriter
+
+	// bufferAll holds back successful responses as well.
+	bufferAll  bool
+	header     http.Header
+	statusCode int
+	body       bytes.Buffer
+}
+
+func newWebsiteResponseWriter(w http.ResponseWriter, bufferAll bool) *websiteResponseWriter {
+	return &websiteResponseWriter{
+		ResponseWriter: w,
+		bufferAll:      bufferAll,
+		header:         make(http.Header),
+	}
+}
+
+func (w *websiteResponseWriter) passThrough() bool {
+	return !w.bufferAll && w.statusCode < http.StatusBadRequest
+}
+
+func (w *websiteResponseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *websiteResponseWriter) WriteHeader(statusCode int) {
+	if w.statusCode != 0 {
+		return
+	}
+	w.statusCode = statusCode
+	if w.passThrough() {
+		w.flushHeader(statusCode)
+	}
+}
+
+func (w *websiteResponseWriter) Write(p []byte) (int, error) {
+	if w.statusCode == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.passThrough() {
+		return w.ResponseWriter.Write(p)
+	}
+	return w.body.Write(p)
+}
+
+func (w *websiteResponseWriter) Flush() {
+	if w.statusCode != 0 && w.passThrough() {
+		if f, ok := w.ResponseWriter.(http.Flusher); ok {
+			f.Flush()
+		}
+	}
+}
+
+func (w *websiteResponseWriter) flushHeader(statusCode int) {
+	h := w.ResponseWriter.Header()
+	for k, v := range w.header {
+		h[k] = v
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+// replay writes the held back response with statusCode.
+func (w *websiteResponseWriter) replay(statusCode int) {
+	w.flushHeader(statusCode)
+	w.ResponseWriter.Write(w.body.Bytes())
+}
+
+// websiteRequest is an anonymous request to a bucket
+// with a static website configuration.
+type websiteRequest struct {
+	config *website.Config
+	bucket string
+	key    string
+
+	// pathStyle is set for path-style requests,
+	// where the bucket is the first path element.
+	pathStyle bool
+}
+
+// getWebsiteRequest returns the website request of r, it returns
+// false if r is not to be served as per a website configuration.
+func getWebsiteRequest(r *http.Request) (websiteRequest, bool) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return websiteRequest{}, false
+	}
+	if r.URL.RawQuery != "" || globalBucketMetadataSys == nil {
+		return websiteRequest{}, false
+	}
+	if getRequestAuthType(r) != authTypeAnonymous {
+		return websiteRequest{}, false
+	}
+	resource, err := getResource(r.URL.Path, r.Host, globalDomainNames)
+	if err != nil {
+		return websiteRequest{}, false
+	}
+	bucket, key := path2BucketObject(resource)
+	if bucket == "" || bucket == minioReservedBucket {
+		return websiteRequest{}, false
+	}
+	config, _, err := globalBucketMetadataSys.GetWebsiteConfig(bucket)
+	if err != nil {
+		return websiteRequest{}, false
+	}
+	return websiteRequest{
+		config:    config,
+		bucket:    bucket,
+		key:       key,
+		pathStyle: resource == r.URL.Path,
+	}, true
+}
+
+// objectPath returns the request path of key.
+func (wr websiteRequest) objectPath(key string) string {
+	if wr.pathStyle {
+		return SlashSeparator + wr.bucket + SlashSeparator + key
+	}
+	return SlashSeparator + key
+}
+
+// redirect redirects the request to key, empty host and
+// protocol refer to those of the request.
+func (wr websiteRequest) redirect(w http.ResponseWriter, r *http.Request, key, host, protocol string, statusCode int) {
+	u := url.URL{
+		Scheme: protocol,
+		Host:   host,
+		Path:   SlashSeparator + key,
+	}
+	if u.Scheme == "" {
+		u.Scheme = getURLScheme(globalIsTLS)
+	}
+	if u.Host == "" {
+		u.Host = r.Host
+		u.Path = wr.objectPath(key)
+	}
+	w.Header().Set(xhttp.Location, u.String())
+	w.WriteHeader(statusCode)
+}
+
+// serve serves key with the handler, the response is written to w.
+func (wr websiteRequest) serve(w http.ResponseWriter, r *http.Request, key string, handler http.Handler) {
+	r = r.Clone(r.Context())
+	r.URL.Path = wr.objectPath(key)
+	r.URL.RawPath = ""
+	r.RequestURI = r.URL.RequestURI()
+	handler.ServeHTTP(w, r)
+}
+
+// bucketWebsiteHandler serves anonymous GET and HEAD requests to
+// buckets with a static website configuration, directory keys are
+// served with the index document and errors with the error document
+// unless a routing rule redirects the request.
+func bucketWebsiteHandler(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		wr, ok := getWebsiteRequest(r)
+		if !ok {
+			handler.ServeHTTP(w, r)
+			return
+		}
+
+		config := wr.config
+		if to := config.RedirectAllRequestsTo; to != nil {
+			wr.redirect(w, r, wr.key, to.HostName, to.Protocol, http.StatusMovedPermanently)
+			return
+		}
+		if rule := config.MatchRoutingRule(wr.key, 0); rule != nil {
+			key, host, protocol, code := rule.Target(wr.key)
+			wr.redirect(w, r, key, host, protocol, code)
+			return
+		}
+
+		rw := newWebsiteResponseWriter(w, false)
+		wr.serve(rw, r, config.IndexKey(wr.key), handler)
+		if rw.passThrough() {
+			return
+		}
+
+		statusCode := rw.statusCode
+		if rule := config.MatchRoutingRule(wr.key, statusCode); rule != nil {
+			key, host, protocol, code := rule.Target(wr.key)
+			wr.redirect(w, r, key, host, protocol, code)
+			return
+		}
+		if doc := config.ErrorDocument; doc != nil && strings.TrimSpace(doc.Key) != "" {
+			ew := newWebsiteResponseWriter(w, true)
+			wr.serve(ew, r, doc.Key, handler)
+			if ew.statusCode < http.StatusBadRequest {
+				ew.replay(statusCode)
+				return
+			}
+		}
+		rw.replay(statusCode)
+	})
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/qkbyte/minio/internal/bucket/website"
+	xhttp "github.com/qkbyte/minio/internal/http"
+)
+
+func TestBucketWebsiteHandler(t *testing.T) {
+	config, err := website.ParseConfig(strings.NewReader(`<WebsiteConfiguration>
+<IndexDocument><Suffix>index.html</Suffix></IndexDocument>
+<ErrorDocument><Key>error.html</Key></ErrorDocument>
+<RoutingRules>
+<RoutingRule><Condition><KeyPrefixEquals>old/</KeyPrefixEquals></Condition><Redirect><ReplaceKeyPrefixWith>new/</ReplaceKeyPrefixWith></Redirect></RoutingRule>
+<RoutingRule><Condition><KeyPrefixEquals>moved/</KeyPrefixEquals><HttpErrorCodeReturnedEquals>404</HttpErrorCodeReturnedEquals></Condition><Redirect><HostName>example.com</HostName><Protocol>https</Protocol><HttpRedirectCode>302</HttpRedirectCode></Redirect></RoutingRule>
+</RoutingRules>
+</WebsiteConfiguration>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func(sys *BucketMetadataSys) { globalBucketMetadataSys = sys }(globalBucketMetadataSys)
+	globalBucketMetadataSys = NewBucketMetadataSys()
+	meta := newBucketMetadata("bucket")
+	meta.websiteConfig = config
+	globalBucketMetadataSys.Set("bucket", meta)
+
+	objects := map[string]string{
+		"/bucket/index.html":      "home",
+		"/bucket/blog/index.html": "blog",
+		"/bucket/error.html":      "oops",
+	}
+	handler := bucketWebsiteHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := objects[r.URL.Path]
+		if !ok {
+			w.Header().Set(xhttp.ContentType, "application/xml")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte("<Error><Code>NoSuchKey</Code></Error>"))
+			return
+		}
+		w.Write([]byte(body))
+	}))
+
+	testCases := []struct {
+		path     string
+		auth     bool
+		status   int
+		body     string
+		location string
+	}{
+		// Bucket root is served with the index document.
+		{path: "/bucket/", status: http.StatusOK, body: "home"},
+		// Directory keys are served with the index document.
+		{path: "/bucket/blog/", status: http.StatusOK, body: "blog"},
+		// Missing objects are served with the error document.
+		{path: "/bucket/missing.html", status: http.StatusNotFound, body: "oops"},
+		// Routing rule matching before the request is served.
+		{path: "/bucket/old/page.html", status: http.StatusMovedPermanently, location: "http://localhost/bucket/new/page.html"},
+		// Routing rule matching the returned error code.
+		{path: "/bucket/moved/page.html", status: http.StatusFound, location: "https://example.com/moved/page.html"},
+		// Authenticated requests are served as regular S3 requests.
+		{path: "/bucket/missing.html", auth: true, status: http.StatusNotFound, body: "<Error><Code>NoSuchKey</Code></Error>"},
+		// Buckets without a website configuration are not affected.
+		{path: "/other/", status: http.StatusNotFound, body: "<Error><Code>NoSuchKey</Code></Error>"},
+	}
+
+	for i, testCase := range testCases {
+		req := httptest.NewRequest(http.MethodGet, "http://localhost"+testCase.path, nil)
+		if testCase.auth {
+			req.Header.Set(xhttp.Authorization, "AWS4-HMAC-SHA256 Credential=minio/20220101/us-east-1/s3/aws4_request")
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != testCase.status {
+			t.Errorf("Test %d: expected status %d, got %d", i+1, testCase.status, rec.Code)
+		}
+		if testCase.body != "" && rec.Body.String() != testCase.body {
+			t.Errorf("Test %d: expected body %q, got %q", i+1, testCase.body, rec.Body.String())
+		}
+		if location := rec.Header().Get(xhttp.Location); location != testCase.location {
+			t.Errorf("Test %d: expected location %q, got %q", i+1, testCase.location, location)
+		}
+	}
+}
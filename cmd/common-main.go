@@ -693,6 +693,83 @@ func handleCommonEnvVars() {
 		globalRootDiskThreshold = size
 	}
 
+	readDirSlow, err := time.ParseDuration(env.Get(config.EnvReadDirSlowDuration, defaultReadDirSlowDuration.String()))
+	if err == nil && readDirSlow <= 0 {
+		err = errors.New("duration must be positive")
+	}
+	if err != nil {
+		logger.Fatal(err, fmt.Sprintf("Invalid %s value in environment variable", config.EnvReadDirSlowDuration))
+	}
+	readDirLarge, err := strconv.Atoi(env.Get(config.EnvReadDirLargeEntries, strconv.Itoa(defaultReadDirLargeEntries)))
+	if err == nil && readDirLarge <= 0 {
+		err = errors.New("entry count must be positive")
+	}
+	if err != nil {
+		logger.Fatal(err, fmt.Sprintf("Invalid %s value in environment variable", config.EnvReadDirLargeEntries))
+	}
+	globalReadDirStats.setThresholds(readDirSlow, readDirLarge)
+
+	shutdownGrace, err := time.ParseDuration(env.Get(config.EnvShutdownGracePeriod, defaultShutdownGracePeriod.String()))
+	if err == nil && shutdownGrace < 0 {
+		err = errors.New("duration must not be negative")
+	}
+	if err != nil {
+		logger.Fatal(err, fmt.Sprintf("Invalid %s value in environment variable", config.EnvShutdownGracePeriod))
+	}
+	globalShutdownCoordinator.setGracePeriod(shutdownGrace)
+
+	globalTagIndexInterval, err = time.ParseDuration(env.Get(config.EnvScannerTagIndexInterval, "0s"))
+	if err == nil && globalTagIndexInterval < 0 {
+		err = errors.New("duration must not be negative")
+	}
+	if err != nil {
+		logger.Fatal(err, fmt.Sprintf("Invalid %s value in environment variable", config.EnvScannerTagIndexInterval))
+	}
+
+	globalBucketMetadataHealInterval, err = time.ParseDuration(env.Get(config.EnvHealBucketMetadataInterval, globalBucketMetadataHealInterval.String()))
+	if err == nil && globalBucketMetadataHealInterval < 0 {
+		err = errors.New("duration must not be negative")
+	}
+	if err != nil {
+		logger.Fatal(err, fmt.Sprintf("Invalid %s value in environment variable", config.EnvHealBucketMetadataInterval))
+	}
+
+	globalIAMDenyByDefault, err = config.ParseBool(env.Get(config.EnvIAMDenyByDefault, config.EnableOff))
+	if err != nil {
+		logger.Fatal(err, fmt.Sprintf("Invalid %s value in environment variable", config.EnvIAMDenyByDefault))
+	}
+	globalIAMBreakGlassAccessKey = env.Get(config.EnvIAMBreakGlassAccessKey, "")
+
+	globalConfigJournalAudit, err = config.ParseBool(env.Get(config.EnvConfigJournalAudit, config.EnableOff))
+	if err != nil {
+		logger.Fatal(err, fmt.Sprintf("Invalid %s value in environment variable", config.EnvConfigJournalAudit))
+	}
+
+	globalErasureSIMD, err = newErasureSIMDInfo(env.Get(config.EnvErasureSIMD, erasureSIMDAuto))
+	if err != nil {
+		logger.Fatal(err, fmt.Sprintf("Invalid %s value in environment variable", config.EnvErasureSIMD))
+	}
+	globalErasureSIMDOptions = erasureSIMDOptions(globalErasureSIMD.Active)
+
+	if value := env.Get(config.EnvTransferStreams, ""); value != "" {
+		streams, err := parseTransferStreams(value)
+		if err != nil {
+			logger.Fatal(err, fmt.Sprintf("Invalid %s value in environment variable", config.EnvTransferStreams))
+		}
+		globalTransferManager = newTransferManager(streams)
+	}
+
+	globalHTTP3Enabled, err = config.ParseBool(env.Get(config.EnvHTTP3, config.EnableOff))
+	if err != nil {
+		logger.Fatal(err, fmt.Sprintf("Invalid %s value in environment variable", config.EnvHTTP3))
+	}
+
+	netMonitorCfg, err := lookupNetMonitorConfig()
+	if err != nil {
+		logger.Fatal(err, "Invalid network monitor settings in environment variables")
+	}
+	globalNetMonitor = newNetMonitor(netMonitorCfg)
+
 	domains := env.Get(config.EnvDomain, "")
 	if len(domains) != 0 {
 		for _, domainName := range strings.Split(domains, config.ValueSeparator) {
@@ -453,6 +453,34 @@ func lookupConfigs(s config.Config, objAPI ObjectLayer) {
 		}
 	}
 
+	var consulFederation bool
+	if globalDNSConfig == nil && len(globalDomainNames) != 0 && !globalDomainIPs.IsEmpty() {
+		if consulURL := env.Get(config.EnvDNSConsulEndpoint, ""); consulURL != "" {
+			globalDNSConfig, err = dns.NewConsulDNS(consulURL,
+				dns.ConsulToken(env.Get(config.EnvDNSConsulToken, "")),
+				dns.ConsulPrefix(env.Get(config.EnvDNSConsulPrefix, "")),
+				dns.ConsulRootCAs(globalRootCAs),
+				dns.ConsulDomain(globalDomainNames, globalDomainIPs, globalMinioPort),
+				dns.ConsulHealthCheck(getURLScheme(globalIsTLS)))
+			if err != nil {
+				logger.LogIf(ctx, fmt.Errorf("Unable to initialize Consul DNS config: %w", err))
+			} else {
+				consulDNS := globalDNSConfig.(*dns.ConsulDNS)
+				go func() {
+					if err := consulDNS.Register(); err != nil {
+						logger.LogIf(ctx, fmt.Errorf("Unable to register the domain IPs with Consul: %w", err))
+					}
+				}()
+			}
+			consulFederation = err == nil
+		} else if zoneFile := env.Get(config.EnvDNSZoneFile, ""); zoneFile != "" {
+			globalDNSConfig, err = dns.NewZoneFileDNS(zoneFile, globalDomainNames, globalDomainIPs, globalMinioPort)
+			if err != nil {
+				logger.LogIf(ctx, fmt.Errorf("Unable to initialize zone file DNS config: %w", err))
+			}
+		}
+	}
+
 	etcdCfg, err := etcd.LookupConfig(s[config.EtcdSubSys][config.Default], globalRootCAs)
 	if err != nil {
 		if globalIsGateway {
@@ -501,7 +529,7 @@ func lookupConfigs(s config.Config, objAPI ObjectLayer) {
 	// if namespace was requested such as specifying etcdPathPrefix then
 	// we assume that users are interested in global bucket support
 	// but not federation.
-	globalBucketFederation = etcdCfg.PathPrefix == "" && etcdCfg.Enabled
+	globalBucketFederation = etcdCfg.PathPrefix == "" && etcdCfg.Enabled || consulFederation
 
 	globalSite, err = config.LookupSite(s[config.SiteSubSys][config.Default], s[config.RegionSubSys][config.Default])
 	if err != nil {
@@ -606,7 +634,10 @@ func applyDynamicConfigForSubSys(ctx context.Context, objAPI ObjectLayer, s conf
 		}
 		// update dynamic scanner values.
 		scannerCycle.Store(scannerCfg.Cycle)
+		scannerIOClass.Store(int32(scannerCfg.IOClass))
 		logger.LogIf(ctx, scannerSleeper.Update(scannerCfg.Delay, scannerCfg.MaxWait))
+		scannerLifecyclePool.resize(scannerCfg.LifecycleWorkers)
+		scannerReplicationPool.resize(scannerCfg.ReplicationWorkers)
 	case config.LoggerWebhookSubSys:
 		loggerCfg, err := logger.LookupConfigForSubSys(s, config.LoggerWebhookSubSys)
 		if err != nil {
@@ -0,0 +1,266 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	iampolicy "github.com/minio/pkg/iam/policy"
+	"github.com/qkbyte/minio/internal/auth"
+	"github.com/qkbyte/minio/internal/config"
+	"github.com/qkbyte/minio/internal/kms"
+	"github.com/qkbyte/minio/internal/logger"
+)
+
+const (
+	minioConfigJournalPrefix = minioConfigPrefix + "/journal"
+
+	// configJournalMaxEntries is the number of journal entries kept,
+	// older entries are removed when new changes are recorded.
+	configJournalMaxEntries = 1000
+
+	// configJournalListTTL is how long the cached listing of the
+	// journal is used to prune it, entries recorded by other nodes are
+	// pruned once it is refreshed.
+	configJournalListTTL = 10 * time.Minute
+)
+
+// configJournalEntry records who changed which keys of the server config
+// and when, values of sensitive keys are redacted.
+type configJournalEntry struct {
+	ID         string          `json:"id"`
+	Time       time.Time       `json:"time"`
+	Actor      string          `json:"actor"`
+	ParentUser string          `json:"parentUser,omitempty"`
+	SourceIP   string          `json:"sourceIP,omitempty"`
+	API        string          `json:"api"`
+	SubSys     string          `json:"subSys,omitempty"`
+	Changes    []config.Change `json:"changes"`
+}
+
+// configJournalFile returns the journal object of an entry, names sort
+// in the order the changes were made.
+func configJournalFile(entry configJournalEntry) string {
+	return pathJoin(minioConfigJournalPrefix, fmt.Sprintf("%020d-%s.json", entry.Time.UnixNano(), entry.ID))
+}
+
+// recordConfigChange saves the changes from before to after in the config
+// journal. Failures are logged, the config change itself already happened.
+func recordConfigChange(ctx context.Context, objAPI ObjectLayer, r *http.Request, cred auth.Credentials, api, subSys string, before, after config.Config) {
+	changes := config.Diff(before, after)
+	if len(changes) == 0 {
+		return
+	}
+
+	entry := configJournalEntry{
+		ID:         mustGetUUID(),
+		Time:       UTCNow(),
+		Actor:      cred.AccessKey,
+		ParentUser: cred.ParentUser,
+		SourceIP:   getRemoteHost(r),
+		API:        api,
+		SubSys:     subSys,
+		Changes:    changes,
+	}
+	if globalConfigJournalAudit {
+		logger.GetReqInfo(ctx).SetTags("configChanges", entry.Changes)
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return
+	}
+	journalFile := configJournalFile(entry)
+	if GlobalKMS != nil {
+		data, err = config.EncryptBytes(GlobalKMS, data, kms.Context{
+			minioMetaBucket: path.Join(minioMetaBucket, journalFile),
+		})
+		if err != nil {
+			logger.LogIf(ctx, err)
+			return
+		}
+	}
+	if err = saveConfig(ctx, objAPI, journalFile, data); err != nil {
+		logger.LogIf(ctx, fmt.Errorf("unable to record config change: %w", err))
+		return
+	}
+	globalConfigJournalFiles.add(journalFile)
+	logger.LogIf(ctx, pruneConfigJournal(ctx, objAPI, configJournalMaxEntries))
+}
+
+// listConfigJournalFiles lists the journal objects, oldest first.
+func listConfigJournalFiles(ctx context.Context, objAPI ObjectLayer) ([]string, error) {
+	var files []string
+	marker := ""
+	for {
+		res, err := objAPI.ListObjects(ctx, minioMetaBucket, minioConfigJournalPrefix+SlashSeparator, marker, "", maxObjectList)
+		if err != nil {
+			return nil, err
+		}
+		for _, obj := range res.Objects {
+			files = append(files, obj.Name)
+		}
+		if !res.IsTruncated {
+			break
+		}
+		marker = res.NextMarker
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// configJournalFiles caches the listing of the journal objects, so
+// that recording a change does not list the whole journal to prune it.
+type configJournalFiles struct {
+	mu     sync.Mutex
+	files  []string // oldest first
+	listed time.Time
+}
+
+// add records a journal object saved by this node.
+func (j *configJournalFiles) add(file string) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.listed.IsZero() {
+		// Not listed yet, the next prune lists the journal.
+		return
+	}
+	i := sort.SearchStrings(j.files, file)
+	j.files = append(j.files, "")
+	copy(j.files[i+1:], j.files[i:])
+	j.files[i] = file
+}
+
+// prune removes the oldest entries beyond max, the journal is listed
+// again when the cached listing is older than configJournalListTTL.
+func (j *configJournalFiles) prune(ctx context.Context, objAPI ObjectLayer, max int) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.listed.IsZero() || time.Since(j.listed) > configJournalListTTL {
+		files, err := listConfigJournalFiles(ctx, objAPI)
+		if err != nil {
+			return err
+		}
+		j.files, j.listed = files, time.Now()
+	}
+	for len(j.files) > max {
+		if err := deleteConfig(ctx, objAPI, j.files[0]); err != nil && err != errConfigNotFound {
+			return err
+		}
+		j.files = j.files[1:]
+	}
+	return nil
+}
+
+// pruneConfigJournal removes the oldest entries beyond max.
+func pruneConfigJournal(ctx context.Context, objAPI ObjectLayer, max int) error {
+	return globalConfigJournalFiles.prune(ctx, objAPI, max)
+}
+
+// readConfigJournal returns up to count journal entries, newest first,
+// optionally only those of a sub-system.
+func readConfigJournal(ctx context.Context, objAPI ObjectLayer, subSys string, count int) ([]configJournalEntry, error) {
+	files, err := listConfigJournalFiles(ctx, objAPI)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []configJournalEntry{}
+	for i := len(files) - 1; i >= 0 && len(entries) < count; i-- {
+		data, err := readConfig(ctx, objAPI, files[i])
+		if err != nil {
+			if err == errConfigNotFound {
+				continue
+			}
+			return nil, err
+		}
+		if GlobalKMS != nil {
+			data, err = config.DecryptBytes(GlobalKMS, data, kms.Context{
+				minioMetaBucket: path.Join(minioMetaBucket, files[i]),
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+		var entry configJournalEntry
+		if err = json.Unmarshal(data, &entry); err != nil {
+			return nil, err
+		}
+		if subSys != "" && !configJournalEntryHasSubSys(entry, subSys) {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func configJournalEntryHasSubSys(entry configJournalEntry, subSys string) bool {
+	for _, change := range entry.Changes {
+		if change.SubSys == subSys {
+			return true
+		}
+	}
+	return false
+}
+
+// ConfigJournalHandler - GET /minio/admin/v3/config-journal?count=100&subSys=api
+// ----------
+// Returns who changed which config keys and when, newest first.
+func (a adminAPIHandlers) ConfigJournalHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ConfigJournal")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ConfigUpdateAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	count := 100
+	if v := r.Form.Get("count"); v != "" {
+		var err error
+		count, err = strconv.Atoi(v)
+		if err != nil || count <= 0 || count > configJournalMaxEntries {
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+			return
+		}
+	}
+
+	entries, err := readConfigJournal(ctx, objectAPI, r.Form.Get("subSys"), count)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(entries)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
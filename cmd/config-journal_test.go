@@ -0,0 +1,101 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/qkbyte/minio/internal/auth"
+	"github.com/qkbyte/minio/internal/config"
+)
+
+func TestConfigJournal(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+	setObjectLayer(objLayer)
+	defer setObjectLayer(nil)
+
+	if err = newTestConfig(globalMinioDefaultRegion, objLayer); err != nil {
+		t.Fatal(err)
+	}
+
+	initAllSubsystems()
+
+	r, err := http.NewRequest(http.MethodPut, "http://localhost:9000/minio/admin/v3/set-config-kv", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cred := auth.Credentials{AccessKey: "admin"}
+
+	before := config.New()
+	after := before.Clone()
+	if _, err = after.SetKVS("api requests_max=1600", config.DefaultKVS); err != nil {
+		t.Fatal(err)
+	}
+	region := after.Clone()
+	if _, err = region.SetKVS("region name=eu-west-1", config.DefaultKVS); err != nil {
+		t.Fatal(err)
+	}
+
+	recordConfigChange(ctx, objLayer, r, cred, "SetConfigKV", config.APISubSys, before, after)
+	// Unchanged configs are not recorded.
+	recordConfigChange(ctx, objLayer, r, cred, "SetConfigKV", config.APISubSys, after, after.Clone())
+	recordConfigChange(ctx, objLayer, r, cred, "SetConfigKV", config.RegionSubSys, after, region)
+
+	entries, err := readConfigJournal(ctx, objLayer, "", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 journal entries, got %d", len(entries))
+	}
+	if entries[0].SubSys != config.RegionSubSys || entries[1].SubSys != config.APISubSys {
+		t.Fatalf("expected newest entry first, got %s, %s", entries[0].SubSys, entries[1].SubSys)
+	}
+	if entries[1].Actor != "admin" || len(entries[1].Changes) != 1 || entries[1].Changes[0].New != "1600" {
+		t.Fatalf("unexpected journal entry %+v", entries[1])
+	}
+
+	entries, err = readConfigJournal(ctx, objLayer, config.APISubSys, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].SubSys != config.APISubSys {
+		t.Fatalf("expected only the api change, got %+v", entries)
+	}
+
+	if err = pruneConfigJournal(ctx, objLayer, 1); err != nil {
+		t.Fatal(err)
+	}
+	entries, err = readConfigJournal(ctx, objLayer, "", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].SubSys != config.RegionSubSys {
+		t.Fatalf("expected the oldest entry to be pruned, got %+v", entries)
+	}
+}
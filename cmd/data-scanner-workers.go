@@ -0,0 +1,148 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// scannerWorkerQueue is the number of tasks a scanner worker pool
+// holds before the scanner waits for the workers.
+const scannerWorkerQueue = 10000
+
+// scannerWorkerPool runs work handed off by the scanner, so that
+// scanning and usage accounting never wait for it. A pool without
+// workers runs its tasks inline on the scanner.
+type scannerWorkerPool struct {
+	name   string
+	tasks  chan func(ctx context.Context)
+	killCh chan struct{}
+
+	mu      sync.Mutex
+	workers int32
+
+	completed uint64
+	waited    uint64
+}
+
+var (
+	scannerLifecyclePool   = newScannerWorkerPool("lifecycle")
+	scannerReplicationPool = newScannerWorkerPool("replication")
+)
+
+func newScannerWorkerPool(name string) *scannerWorkerPool {
+	return &scannerWorkerPool{
+		name:   name,
+		tasks:  make(chan func(ctx context.Context), scannerWorkerQueue),
+		killCh: make(chan struct{}),
+	}
+}
+
+// enabled returns whether tasks are run by workers.
+func (p *scannerWorkerPool) enabled() bool {
+	return atomic.LoadInt32(&p.workers) > 0
+}
+
+// resize sets the number of workers.
+func (p *scannerWorkerPool) resize(n int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for int(atomic.LoadInt32(&p.workers)) < n {
+		atomic.AddInt32(&p.workers, 1)
+		go p.worker(GlobalContext)
+	}
+	for int(atomic.LoadInt32(&p.workers)) > n {
+		atomic.AddInt32(&p.workers, -1)
+		go func() { p.killCh <- struct{}{} }()
+	}
+}
+
+func (p *scannerWorkerPool) worker(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-p.killCh:
+			return
+		case task := <-p.tasks:
+			// Workers are throttled like the scanner itself.
+			wait := scannerSleeper.Timer(ctx)
+			task(ctx)
+			wait()
+			atomic.AddUint64(&p.completed, 1)
+		}
+	}
+}
+
+// submit hands task to the workers, or runs it with ctx when the pool
+// has none. When the queue is full the scanner waits for the workers,
+// tasks are never dropped.
+func (p *scannerWorkerPool) submit(ctx context.Context, task func(ctx context.Context)) {
+	if !p.enabled() {
+		task(ctx)
+		atomic.AddUint64(&p.completed, 1)
+		return
+	}
+	select {
+	case p.tasks <- task:
+		return
+	default:
+	}
+
+	atomic.AddUint64(&p.waited, 1)
+	t := time.NewTicker(time.Second)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case p.tasks <- task:
+			return
+		case <-t.C:
+			if !p.enabled() {
+				// The workers were removed while waiting.
+				task(ctx)
+				atomic.AddUint64(&p.completed, 1)
+				return
+			}
+		}
+	}
+}
+
+// scannerWorkerPoolStats is a snapshot of a scanner worker pool.
+type scannerWorkerPoolStats struct {
+	Name      string
+	Workers   int
+	Queued    int
+	Completed uint64
+	Waited    uint64
+}
+
+func (p *scannerWorkerPool) stats() scannerWorkerPoolStats {
+	return scannerWorkerPoolStats{
+		Name:      p.name,
+		Workers:   int(atomic.LoadInt32(&p.workers)),
+		Queued:    len(p.tasks),
+		Completed: atomic.LoadUint64(&p.completed),
+		Waited:    atomic.LoadUint64(&p.waited),
+	}
+}
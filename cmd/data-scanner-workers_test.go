@@ -0,0 +1,76 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestScannerWorkerPool(t *testing.T) {
+	p := &scannerWorkerPool{
+		name:   "test",
+		tasks:  make(chan func(ctx context.Context), 1),
+		killCh: make(chan struct{}),
+	}
+
+	// Without workers tasks run inline.
+	var ran bool
+	p.submit(context.Background(), func(ctx context.Context) { ran = true })
+	if !ran {
+		t.Fatal("expected task to run inline")
+	}
+
+	p.resize(1)
+	defer p.resize(0)
+
+	started, release := make(chan struct{}), make(chan struct{})
+	p.submit(context.Background(), func(ctx context.Context) {
+		close(started)
+		<-release
+	})
+	<-started
+
+	done := make(chan struct{})
+	p.submit(context.Background(), func(ctx context.Context) { close(done) })
+
+	// The queue is full, the scanner waits for the workers.
+	submitted := make(chan struct{})
+	go func() {
+		p.submit(context.Background(), func(ctx context.Context) {})
+		close(submitted)
+	}()
+	select {
+	case <-submitted:
+		t.Fatal("expected submit to wait for a free queue slot")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	if st := p.stats(); st.Workers != 1 || st.Queued != 1 || st.Waited != 1 {
+		t.Fatalf("unexpected stats %+v", st)
+	}
+	close(release)
+	for _, ch := range []chan struct{}{done, submitted} {
+		select {
+		case <-ch:
+		case <-time.After(10 * time.Second):
+			t.Fatal("queued task did not run")
+		}
+	}
+}
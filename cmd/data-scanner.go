@@ -72,6 +72,8 @@ var (
 	// Sleeper values are updated when config is loaded.
 	scannerSleeper = newDynamicSleeper(10, 10*time.Second, true)
 	scannerCycle   = uatomic.NewDuration(dataScannerStartDelay)
+	// I/O class of the local drive scans, an ioprio.Class.
+	scannerIOClass = uatomic.NewInt32(0)
 )
 
 // initDataScanner will start the scanner in the background.
@@ -166,18 +168,7 @@ func runDataScanner(pctx context.Context, objAPI ObjectLayer) {
 	// No unlock for "leader" lock.
 
 	// Load current bloom cycle
-	var cycleInfo currentScannerCycle
-	cycleInfo.next = intDataUpdateTracker.current() + 1
-
-	buf, _ := readConfig(ctx, objAPI, dataUsageBloomNamePath)
-	if len(buf) == 8 {
-		cycleInfo.next = binary.LittleEndian.Uint64(buf)
-	} else if len(buf) > 8 {
-		cycleInfo.next = binary.LittleEndian.Uint64(buf[:8])
-		buf = buf[8:]
-		_, err = cycleInfo.UnmarshalMsg(buf)
-		logger.LogIf(ctx, err)
-	}
+	cycleInfo := loadScannerCycle(ctx, objAPI)
 
 	scannerTimer := time.NewTimer(scannerCycle.Load())
 	defer scannerTimer.Stop()
@@ -232,11 +223,29 @@ func runDataScanner(pctx context.Context, objAPI ObjectLayer) {
 				tmp, _ = cycleInfo.MarshalMsg(tmp)
 				err = saveConfig(ctx, objAPI, dataUsageBloomNamePath, tmp)
 				logger.LogIf(ctx, err)
+
 			}
 		}
 	}
 }
 
+// loadScannerCycle loads the persisted scanner cycle information.
+func loadScannerCycle(ctx context.Context, objAPI ObjectLayer) currentScannerCycle {
+	var cycleInfo currentScannerCycle
+	cycleInfo.next = intDataUpdateTracker.current() + 1
+
+	buf, _ := readConfig(ctx, objAPI, dataUsageBloomNamePath)
+	if len(buf) == 8 {
+		cycleInfo.next = binary.LittleEndian.Uint64(buf)
+	} else if len(buf) > 8 {
+		cycleInfo.next = binary.LittleEndian.Uint64(buf[:8])
+		buf = buf[8:]
+		_, err := cycleInfo.UnmarshalMsg(buf)
+		logger.LogIf(ctx, err)
+	}
+	return cycleInfo
+}
+
 type cachedFolder struct {
 	name              string
 	parent            *dataUsageHash
@@ -349,7 +358,7 @@ func scanDataFolder(ctx context.Context, poolIdx, setIdx int, basePath string, c
 	}
 
 	// Enable healing in XL mode.
-	if globalIsErasure && !cache.Info.SkipHealing {
+	if (globalIsErasure || globalIsErasureSD) && !cache.Info.SkipHealing {
 		// Include a clean folder one in n cycles.
 		s.healFolderInclude = healFolderIncludeProb
 		// Do a heal check on an object once every n cycles. Must divide into healFolderInclude
@@ -527,7 +536,7 @@ func (f *folderScanner) scanFolder(ctx context.Context, folder cachedFolder, int
 				replication: replicationCfg,
 			}
 
-			item.heal.enabled = thisHash.modAlt(f.oldCache.Info.NextCycle/folder.objectHealProbDiv, f.healObjectSelect/folder.objectHealProbDiv) && globalIsErasure
+			item.heal.enabled = thisHash.modAlt(f.oldCache.Info.NextCycle/folder.objectHealProbDiv, f.healObjectSelect/folder.objectHealProbDiv) && (globalIsErasure || globalIsErasureSD)
 			item.heal.bitrot = f.scanMode == madmin.HealDeepScan
 
 			// if the drive belongs to an erasure set
@@ -704,10 +713,11 @@ func (f *folderScanner) scanFolder(ctx context.Context, folder cachedFolder, int
 		// This means that the next run will not look for it.
 		// How to resolve results.
 		resolver := metadataResolutionParams{
-			dirQuorum: f.disksQuorum,
-			objQuorum: f.disksQuorum,
-			bucket:    "",
-			strict:    false,
+			dirQuorum:        f.disksQuorum,
+			objQuorum:        f.disksQuorum,
+			bucket:           "",
+			strict:           false,
+			divergenceSource: divergenceSourceScanner,
 		}
 
 		healObjectsPrefix := color.Green("healObjects:")
@@ -891,6 +901,8 @@ type sizeSummary struct {
 	failedCount     uint64
 	replTargetStats map[string]replTargetSizeSummary
 	tiers           map[string]tierStats
+	storageClasses  map[string]tierStats
+	kmsKeys         map[string]tierStats
 }
 
 // replTargetSizeSummary holds summary of replication stats by target
@@ -1075,9 +1087,18 @@ func (i *scannerItem) applyNewerNoncurrentVersionLimit(ctx context.Context, _ Ob
 }
 
 // applyVersionActions will apply lifecycle checks on all versions of a scanned item. Returns versions that remain
-// after applying lifecycle checks configured.
+// after applying lifecycle checks configured. When the lifecycle workers apply them all versions remain,
+// they are accounted for until expired.
 func (i *scannerItem) applyVersionActions(ctx context.Context, o ObjectLayer, fivs []FileInfo) ([]FileInfo, error) {
-	return i.applyNewerNoncurrentVersionLimit(ctx, o, fivs)
+	if i.lifeCycle == nil || !scannerLifecyclePool.enabled() {
+		return i.applyNewerNoncurrentVersionLimit(ctx, o, fivs)
+	}
+	item := *i
+	versions := append([]FileInfo(nil), fivs...)
+	scannerLifecyclePool.submit(ctx, func(ctx context.Context) {
+		item.applyNewerNoncurrentVersionLimit(ctx, o, versions)
+	})
+	return fivs, nil
 }
 
 // applyActions will apply lifecycle checks on to a scanned item.
@@ -1085,9 +1106,23 @@ func (i *scannerItem) applyVersionActions(ctx context.Context, o ObjectLayer, fi
 // The metadata will be compared to consensus on the object layer before any changes are applied.
 // If no metadata is supplied, -1 is returned if no action is taken.
 func (i *scannerItem) applyActions(ctx context.Context, o ObjectLayer, oi ObjectInfo, sizeS *sizeSummary) int64 {
-	done := globalScannerMetrics.time(scannerMetricILM)
-	applied, size := i.applyLifecycle(ctx, o, oi)
-	done()
+	var applied bool
+	var size int64
+	if i.lifeCycle != nil && scannerLifecyclePool.enabled() {
+		// Evaluated by the lifecycle workers, the version is
+		// accounted for until it is expired or transitioned.
+		size, _ = oi.GetActualSize()
+		item := *i
+		scannerLifecyclePool.submit(ctx, func(ctx context.Context) {
+			done := globalScannerMetrics.time(scannerMetricILM)
+			item.applyLifecycle(ctx, o, oi)
+			done()
+		})
+	} else {
+		done := globalScannerMetrics.time(scannerMetricILM)
+		applied, size = i.applyLifecycle(ctx, o, oi)
+		done()
+	}
 
 	// For instance, an applied lifecycle means we remove/transitioned an object
 	// from the current deployment, which means we don't have to call healing
@@ -1235,16 +1270,26 @@ func (i *scannerItem) healReplication(ctx context.Context, o ObjectLayer, oi Obj
 	if i.replication.Config == nil {
 		return
 	}
-	roi := queueReplicationHeal(ctx, oi.Bucket, oi, i.replication)
+	var tgtStatuses map[string]replication.StatusType
+	if scannerReplicationPool.enabled() {
+		rcfg := i.replication
+		scannerReplicationPool.submit(ctx, func(ctx context.Context) {
+			queueReplicationHeal(ctx, oi.Bucket, oi, rcfg)
+		})
+		tgtStatuses = replicationTargetStatuses(oi, rcfg)
+	} else {
+		roi := queueReplicationHeal(ctx, oi.Bucket, oi, i.replication)
+		tgtStatuses = roi.TargetStatuses
+	}
 	if oi.DeleteMarker || !oi.VersionPurgeStatus.Empty() {
 		return
 	}
 
-	if sizeS.replTargetStats == nil && len(roi.TargetStatuses) > 0 {
+	if sizeS.replTargetStats == nil && len(tgtStatuses) > 0 {
 		sizeS.replTargetStats = make(map[string]replTargetSizeSummary)
 	}
 
-	for arn, tgtStatus := range roi.TargetStatuses {
+	for arn, tgtStatus := range tgtStatuses {
 		tgtSizeS, ok := sizeS.replTargetStats[arn]
 		if !ok {
 			tgtSizeS = replTargetSizeSummary{}
@@ -1273,6 +1318,20 @@ func (i *scannerItem) healReplication(ctx context.Context, o ObjectLayer, oi Obj
 	}
 }
 
+// replicationTargetStatuses returns the per target replication status
+// of a version the way queueReplicationHeal reports it, without deciding
+// whether the version needs healing.
+func replicationTargetStatuses(oi ObjectInfo, rcfg replicationConfig) map[string]replication.StatusType {
+	if oi.ModTime.IsZero() || rcfg.Config == nil || rcfg.remotes == nil {
+		return nil
+	}
+	if rcfg.Config.RoleArn != "" && !oi.ReplicationStatus.Empty() {
+		// Objects replicated before multiple targets were supported.
+		return replicationStatusesMap(fmt.Sprintf("%s=%s;", rcfg.Config.RoleArn, oi.ReplicationStatus))
+	}
+	return replicationStatusesMap(oi.ReplicationStatusInternal)
+}
+
 type dynamicSleeper struct {
 	mu sync.RWMutex
 
@@ -55,7 +55,11 @@ type dataUsageEntry struct {
 	ObjSizes         sizeHistogram        `msg:"szs"`
 	ReplicationStats *replicationAllStats `msg:"rs,omitempty"`
 	AllTierStats     *allTierStats        `msg:"ats,omitempty"`
-	Compacted        bool                 `msg:"c"`
+	// Stats of data kept on the drives, keyed by storage class.
+	StorageClassStats *allTierStats `msg:"scs,omitempty"`
+	// Stats of encrypted data, keyed by KMS master key ID.
+	KMSKeyStats *allTierStats `msg:"kks,omitempty"`
+	Compacted   bool          `msg:"c"`
 }
 
 // allTierStats is a collection of per-tier stats across all configured remote
@@ -321,6 +325,18 @@ func (e *dataUsageEntry) addSizes(summary sizeSummary) {
 		}
 		e.AllTierStats.addSizes(summary)
 	}
+	if summary.storageClasses != nil {
+		if e.StorageClassStats == nil {
+			e.StorageClassStats = newAllTierStats()
+		}
+		e.StorageClassStats.merge(&allTierStats{Tiers: summary.storageClasses})
+	}
+	if len(summary.kmsKeys) > 0 {
+		if e.KMSKeyStats == nil {
+			e.KMSKeyStats = newAllTierStats()
+		}
+		e.KMSKeyStats.merge(&allTierStats{Tiers: summary.kmsKeys})
+	}
 }
 
 // merge other data usage entry into this, excluding children.
@@ -357,6 +373,18 @@ func (e *dataUsageEntry) merge(other dataUsageEntry) {
 		}
 		e.AllTierStats.merge(other.AllTierStats)
 	}
+	if other.StorageClassStats != nil {
+		if e.StorageClassStats == nil {
+			e.StorageClassStats = newAllTierStats()
+		}
+		e.StorageClassStats.merge(other.StorageClassStats)
+	}
+	if other.KMSKeyStats != nil {
+		if e.KMSKeyStats == nil {
+			e.KMSKeyStats = newAllTierStats()
+		}
+		e.KMSKeyStats.merge(other.KMSKeyStats)
+	}
 }
 
 // mod returns true if the hash mod cycles == cycle.
@@ -412,6 +440,12 @@ func (e dataUsageEntry) clone() dataUsageEntry {
 	if e.ReplicationStats != nil {
 		// Copy to new struct
 		r := *e.ReplicationStats
+		if r.Targets != nil {
+			r.Targets = make(map[string]replicationStats, len(e.ReplicationStats.Targets))
+			for arn, st := range e.ReplicationStats.Targets {
+				r.Targets[arn] = st
+			}
+		}
 		e.ReplicationStats = &r
 	}
 	if e.AllTierStats != nil {
@@ -419,6 +453,16 @@ func (e dataUsageEntry) clone() dataUsageEntry {
 		ats.merge(e.AllTierStats)
 		e.AllTierStats = ats
 	}
+	if e.StorageClassStats != nil {
+		scs := newAllTierStats()
+		scs.merge(e.StorageClassStats)
+		e.StorageClassStats = scs
+	}
+	if e.KMSKeyStats != nil {
+		kks := newAllTierStats()
+		kks.merge(e.KMSKeyStats)
+		e.KMSKeyStats = kks
+	}
 	return e
 }
 
@@ -542,6 +586,7 @@ func (d *dataUsageCache) dui(path string, buckets []BucketInfo) DataUsageInfo {
 		BucketsCount:       uint64(len(e.Children)),
 		BucketsUsage:       d.bucketsUsageInfo(buckets),
 		TierStats:          d.tiersUsageInfo(buckets),
+		StorageClassStats:  d.storageClassesUsageInfo(buckets),
 	}
 	return dui
 }
@@ -726,6 +771,8 @@ func (d *dataUsageCache) flattenChildrens(root dataUsageEntry) (m map[string]dat
 
 // flatten all children of the root into the root element and return it.
 func (d *dataUsageCache) flatten(root dataUsageEntry) dataUsageEntry {
+	// Don't merge into the stats of the cached entry.
+	root = root.clone()
 	for id := range root.Children {
 		e := d.Cache[id]
 		if len(e.Children) > 0 {
@@ -777,6 +824,25 @@ func (d *dataUsageCache) tiersUsageInfo(buckets []BucketInfo) *allTierStats {
 	return dst
 }
 
+func (d *dataUsageCache) storageClassesUsageInfo(buckets []BucketInfo) *allTierStats {
+	dst := newAllTierStats()
+	for _, bucket := range buckets {
+		e := d.find(bucket.Name)
+		if e == nil {
+			continue
+		}
+		flat := d.flatten(*e)
+		if flat.StorageClassStats == nil {
+			continue
+		}
+		dst.merge(flat.StorageClassStats)
+	}
+	if len(dst.Tiers) == 0 {
+		return nil
+	}
+	return dst
+}
+
 // bucketsUsageInfo returns the buckets usage info as a map, with
 // key as bucket name
 func (d *dataUsageCache) bucketsUsageInfo(buckets []BucketInfo) map[string]BucketUsageInfo {
@@ -792,6 +858,7 @@ func (d *dataUsageCache) bucketsUsageInfo(buckets []BucketInfo) map[string]Bucke
 			VersionsCount:        flat.Versions,
 			ObjectsCount:         flat.Objects,
 			ObjectSizesHistogram: flat.ObjSizes.toMap(),
+			KMSKeyStats:          flat.KMSKeyStats,
 		}
 		if flat.ReplicationStats != nil {
 			bui.ReplicaSize = flat.ReplicationStats.ReplicaSize
@@ -1632,6 +1632,42 @@ func (z *dataUsageEntry) DecodeMsg(dc *msgp.Reader) (err error) {
 					return
 				}
 			}
+		case "scs":
+			if dc.IsNil() {
+				err = dc.ReadNil()
+				if err != nil {
+					err = msgp.WrapError(err, "StorageClassStats")
+					return
+				}
+				z.StorageClassStats = nil
+			} else {
+				if z.StorageClassStats == nil {
+					z.StorageClassStats = new(allTierStats)
+				}
+				err = z.StorageClassStats.DecodeMsg(dc)
+				if err != nil {
+					err = msgp.WrapError(err, "StorageClassStats")
+					return
+				}
+			}
+		case "kks":
+			if dc.IsNil() {
+				err = dc.ReadNil()
+				if err != nil {
+					err = msgp.WrapError(err, "KMSKeyStats")
+					return
+				}
+				z.KMSKeyStats = nil
+			} else {
+				if z.KMSKeyStats == nil {
+					z.KMSKeyStats = new(allTierStats)
+				}
+				err = z.KMSKeyStats.DecodeMsg(dc)
+				if err != nil {
+					err = msgp.WrapError(err, "KMSKeyStats")
+					return
+				}
+			}
 		case "c":
 			z.Compacted, err = dc.ReadBool()
 			if err != nil {
@@ -1652,8 +1688,8 @@ func (z *dataUsageEntry) DecodeMsg(dc *msgp.Reader) (err error) {
 // EncodeMsg implements msgp.Encodable
 func (z *dataUsageEntry) EncodeMsg(en *msgp.Writer) (err error) {
 	// omitempty: check for empty values
-	zb0001Len := uint32(8)
-	var zb0001Mask uint8 /* 8 bits */
+	zb0001Len := uint32(10)
+	var zb0001Mask uint16 /* 10 bits */
 	_ = zb0001Mask
 	if z.ReplicationStats == nil {
 		zb0001Len--
@@ -1663,6 +1699,14 @@ func (z *dataUsageEntry) EncodeMsg(en *msgp.Writer) (err error) {
 		zb0001Len--
 		zb0001Mask |= 0x40
 	}
+	if z.StorageClassStats == nil {
+		zb0001Len--
+		zb0001Mask |= 0x80
+	}
+	if z.KMSKeyStats == nil {
+		zb0001Len--
+		zb0001Mask |= 0x100
+	}
 	// variable map header, size zb0001Len
 	err = en.Append(0x80 | uint8(zb0001Len))
 	if err != nil {
@@ -1766,6 +1810,44 @@ func (z *dataUsageEntry) EncodeMsg(en *msgp.Writer) (err error) {
 			}
 		}
 	}
+	if (zb0001Mask & 0x80) == 0 { // if not empty
+		// write "scs"
+		err = en.Append(0xa3, 0x73, 0x63, 0x73)
+		if err != nil {
+			return
+		}
+		if z.StorageClassStats == nil {
+			err = en.WriteNil()
+			if err != nil {
+				return
+			}
+		} else {
+			err = z.StorageClassStats.EncodeMsg(en)
+			if err != nil {
+				err = msgp.WrapError(err, "StorageClassStats")
+				return
+			}
+		}
+	}
+	if (zb0001Mask & 0x100) == 0 { // if not empty
+		// write "kks"
+		err = en.Append(0xa3, 0x6b, 0x6b, 0x73)
+		if err != nil {
+			return
+		}
+		if z.KMSKeyStats == nil {
+			err = en.WriteNil()
+			if err != nil {
+				return
+			}
+		} else {
+			err = z.KMSKeyStats.EncodeMsg(en)
+			if err != nil {
+				err = msgp.WrapError(err, "KMSKeyStats")
+				return
+			}
+		}
+	}
 	// write "c"
 	err = en.Append(0xa1, 0x63)
 	if err != nil {
@@ -1783,8 +1865,8 @@ func (z *dataUsageEntry) EncodeMsg(en *msgp.Writer) (err error) {
 func (z *dataUsageEntry) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
 	// omitempty: check for empty values
-	zb0001Len := uint32(8)
-	var zb0001Mask uint8 /* 8 bits */
+	zb0001Len := uint32(10)
+	var zb0001Mask uint16 /* 10 bits */
 	_ = zb0001Mask
 	if z.ReplicationStats == nil {
 		zb0001Len--
@@ -1794,6 +1876,14 @@ func (z *dataUsageEntry) MarshalMsg(b []byte) (o []byte, err error) {
 		zb0001Len--
 		zb0001Mask |= 0x40
 	}
+	if z.StorageClassStats == nil {
+		zb0001Len--
+		zb0001Mask |= 0x80
+	}
+	if z.KMSKeyStats == nil {
+		zb0001Len--
+		zb0001Mask |= 0x100
+	}
 	// variable map header, size zb0001Len
 	o = append(o, 0x80|uint8(zb0001Len))
 	if zb0001Len == 0 {
@@ -1847,6 +1937,32 @@ func (z *dataUsageEntry) MarshalMsg(b []byte) (o []byte, err error) {
 			}
 		}
 	}
+	if (zb0001Mask & 0x80) == 0 { // if not empty
+		// string "scs"
+		o = append(o, 0xa3, 0x73, 0x63, 0x73)
+		if z.StorageClassStats == nil {
+			o = msgp.AppendNil(o)
+		} else {
+			o, err = z.StorageClassStats.MarshalMsg(o)
+			if err != nil {
+				err = msgp.WrapError(err, "StorageClassStats")
+				return
+			}
+		}
+	}
+	if (zb0001Mask & 0x100) == 0 { // if not empty
+		// string "kks"
+		o = append(o, 0xa3, 0x6b, 0x6b, 0x73)
+		if z.KMSKeyStats == nil {
+			o = msgp.AppendNil(o)
+		} else {
+			o, err = z.KMSKeyStats.MarshalMsg(o)
+			if err != nil {
+				err = msgp.WrapError(err, "KMSKeyStats")
+				return
+			}
+		}
+	}
 	// string "c"
 	o = append(o, 0xa1, 0x63)
 	o = msgp.AppendBool(o, z.Compacted)
@@ -1947,6 +2063,40 @@ func (z *dataUsageEntry) UnmarshalMsg(bts []byte) (o []byte, err error) {
 					return
 				}
 			}
+		case "scs":
+			if msgp.IsNil(bts) {
+				bts, err = msgp.ReadNilBytes(bts)
+				if err != nil {
+					return
+				}
+				z.StorageClassStats = nil
+			} else {
+				if z.StorageClassStats == nil {
+					z.StorageClassStats = new(allTierStats)
+				}
+				bts, err = z.StorageClassStats.UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "StorageClassStats")
+					return
+				}
+			}
+		case "kks":
+			if msgp.IsNil(bts) {
+				bts, err = msgp.ReadNilBytes(bts)
+				if err != nil {
+					return
+				}
+				z.KMSKeyStats = nil
+			} else {
+				if z.KMSKeyStats == nil {
+					z.KMSKeyStats = new(allTierStats)
+				}
+				bts, err = z.KMSKeyStats.UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "KMSKeyStats")
+					return
+				}
+			}
 		case "c":
 			z.Compacted, bts, err = msgp.ReadBoolBytes(bts)
 			if err != nil {
@@ -1979,6 +2129,18 @@ func (z *dataUsageEntry) Msgsize() (s int) {
 	} else {
 		s += z.AllTierStats.Msgsize()
 	}
+	s += 4
+	if z.StorageClassStats == nil {
+		s += msgp.NilSize
+	} else {
+		s += z.StorageClassStats.Msgsize()
+	}
+	s += 4
+	if z.KMSKeyStats == nil {
+		s += msgp.NilSize
+	} else {
+		s += z.KMSKeyStats.Msgsize()
+	}
 	s += 2 + msgp.BoolSize
 	return
 }
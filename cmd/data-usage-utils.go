@@ -63,6 +63,8 @@ type BucketUsageInfo struct {
 	VersionsCount        uint64                           `json:"versionsCount"`
 	ReplicaSize          uint64                           `json:"objectReplicaTotalSize"`
 	ReplicationInfo      map[string]BucketTargetUsageInfo `json:"objectsReplicationInfo"`
+	// KMSKeyStats contains per KMS master key stats of encrypted data
+	KMSKeyStats *allTierStats `json:"kmsKeyStats,omitempty"`
 }
 
 // DataUsageInfo represents data usage stats of the underlying Object API
@@ -93,6 +95,10 @@ type DataUsageInfo struct {
 
 	// TierStats contains per-tier stats of all configured remote tiers
 	TierStats *allTierStats `json:"tierStats,omitempty"`
+
+	// StorageClassStats contains per storage class stats of the data
+	// not transitioned to a remote tier
+	StorageClassStats *allTierStats `json:"storageClassStats,omitempty"`
 }
 
 func (dui DataUsageInfo) tierStats() []madmin.TierInfo {
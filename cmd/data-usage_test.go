@@ -620,3 +620,74 @@ func equalAsJSON(a, b interface{}) bool {
 	}
 	return bytes.Equal(aj, bj)
 }
+
+func TestDataUsageStorageClassStats(t *testing.T) {
+	base := t.TempDir()
+	const bucket = "abucket"
+	files := []usageTestFile{
+		{name: "standard", size: 1000},
+		{name: "dir1/reduced", size: 200},
+		{name: "dir1/transitioned", size: 30},
+	}
+	createUsageTestFiles(t, base, bucket, files)
+
+	getSize := func(item scannerItem) (sizeS sizeSummary, err error) {
+		if item.Typ&os.ModeDir != 0 {
+			return
+		}
+		var s os.FileInfo
+		s, err = os.Stat(item.Path)
+		if err != nil {
+			return
+		}
+		sizeS.versions++
+		sizeS.totalSize = s.Size()
+		st := tierStats{TotalSize: uint64(s.Size()), NumVersions: 1, NumObjects: 1}
+		sizeS.tiers = make(map[string]tierStats)
+		sizeS.storageClasses = make(map[string]tierStats)
+		switch path.Base(item.Path) {
+		case "transitioned":
+			sizeS.tiers["WARM"] = st
+		case "reduced":
+			sizeS.tiers[minioHotTier] = st
+			sizeS.storageClasses["REDUCED_REDUNDANCY"] = st
+		default:
+			sizeS.tiers[minioHotTier] = st
+			sizeS.storageClasses["STANDARD"] = st
+		}
+		return
+	}
+	cache, err := scanDataFolder(context.Background(), 0, 0, base, dataUsageCache{Info: dataUsageCacheInfo{Name: bucket}}, getSize, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err = cache.serializeTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var got dataUsageCache
+	if err = got.deserialize(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	dui := got.dui(bucket, []BucketInfo{{Name: bucket}})
+	if dui.StorageClassStats == nil || dui.TierStats == nil {
+		t.Fatal("expected storage class and tier stats")
+	}
+	wantSC := map[string]uint64{"STANDARD": 1000, "REDUCED_REDUNDANCY": 200}
+	if len(dui.StorageClassStats.Tiers) != len(wantSC) {
+		t.Fatalf("unexpected storage classes %+v", dui.StorageClassStats.Tiers)
+	}
+	for sc, size := range wantSC {
+		if got := dui.StorageClassStats.Tiers[sc].TotalSize; got != size {
+			t.Errorf("expected %d bytes in %s, got %d", size, sc, got)
+		}
+	}
+	wantTiers := map[string]uint64{minioHotTier: 1200, "WARM": 30}
+	for tier, size := range wantTiers {
+		if got := dui.TierStats.Tiers[tier].TotalSize; got != size {
+			t.Errorf("expected %d bytes in tier %s, got %d", size, tier, got)
+		}
+	}
+}
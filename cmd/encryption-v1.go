@@ -346,6 +346,85 @@ func rotateKey(ctx context.Context, oldKey []byte, newKeyID string, newKey []byt
 	}
 }
 
+// resealObjectKey seals the object key of an SSE-S3 or SSE-KMS encrypted
+// object for a new name, the encrypted content can then be copied to
+// dstBucket/dstObject as is. A non-nil dstKind reseals the object key
+// for the encryption of the destination, with the KMS key dstKeyID,
+// instead of the encryption of the source. SSE-C object keys cannot be
+// resealed without the client key.
+func resealObjectKey(ctx context.Context, metadata map[string]string, srcBucket, srcObject, dstBucket, dstObject string, dstKind crypto.Type, dstKeyID string) error {
+	var (
+		objectKey crypto.ObjectKey
+		keyID     string
+		cryptoCtx kms.Context
+		err       error
+	)
+	kind, _ := crypto.IsEncrypted(metadata)
+	switch kind {
+	case crypto.S3:
+		if GlobalKMS == nil {
+			return errKMSNotConfigured
+		}
+		if objectKey, err = crypto.S3.UnsealObjectKey(GlobalKMS, metadata, srcBucket, srcObject); err != nil {
+			return err
+		}
+		keyID, _, _, err = crypto.S3.ParseMetadata(metadata)
+	case crypto.S3KMS:
+		if GlobalKMS == nil {
+			return errKMSNotConfigured
+		}
+		if objectKey, err = crypto.S3KMS.UnsealObjectKey(GlobalKMS, metadata, srcBucket, srcObject); err != nil {
+			return err
+		}
+		keyID, _, _, cryptoCtx, err = crypto.S3KMS.ParseMetadata(metadata)
+	case crypto.SSEC:
+		return NotImplemented{Message: "SSE-C encrypted objects cannot be copied without the customer key"}
+	default:
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if dstKind != nil {
+		if dstKind != kind {
+			cryptoCtx = nil
+		}
+		kind, keyID = dstKind, dstKeyID
+	}
+
+	delete(metadata, crypto.MetaSealedKeyS3)
+	delete(metadata, crypto.MetaSealedKeyKMS)
+	delete(metadata, crypto.MetaContext)
+	switch kind {
+	case crypto.S3:
+		newKey, err := GlobalKMS.GenerateKey(ctx, keyID, kms.Context{dstBucket: path.Join(dstBucket, dstObject)})
+		if err != nil {
+			return err
+		}
+		sealedKey := objectKey.Seal(newKey.Plaintext, crypto.GenerateIV(rand.Reader), crypto.S3.String(), dstBucket, dstObject)
+		crypto.S3.CreateMetadata(metadata, newKey.KeyID, newKey.Ciphertext, sealedKey)
+	case crypto.S3KMS:
+		// The stored context is kept as the client provided it,
+		// see rotateKey.
+		kmsCtx := kms.Context{}
+		for k, v := range cryptoCtx {
+			kmsCtx[k] = v
+		}
+		if _, ok := kmsCtx[dstBucket]; !ok {
+			kmsCtx[dstBucket] = path.Join(dstBucket, dstObject)
+		}
+		newKey, err := GlobalKMS.GenerateKey(ctx, keyID, kmsCtx)
+		if err != nil {
+			return err
+		}
+		sealedKey := objectKey.Seal(newKey.Plaintext, crypto.GenerateIV(rand.Reader), crypto.S3KMS.String(), dstBucket, dstObject)
+		crypto.S3KMS.CreateMetadata(metadata, newKey.KeyID, newKey.Ciphertext, sealedKey, cryptoCtx)
+	default:
+		return errInvalidEncryptionParameters
+	}
+	return nil
+}
+
 func newEncryptMetadata(ctx context.Context, kind crypto.Type, keyID string, key []byte, bucket, object string, metadata map[string]string, cryptoCtx kms.Context) (crypto.ObjectKey, error) {
 	var sealedKey crypto.SealedKey
 	switch kind {
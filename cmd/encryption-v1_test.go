@@ -19,6 +19,7 @@ package cmd
 
 import (
 	"bytes"
+	"context"
 	"encoding/base64"
 	"net/http"
 	"testing"
@@ -28,6 +29,7 @@ import (
 	"github.com/minio/sio"
 	"github.com/qkbyte/minio/internal/crypto"
 	xhttp "github.com/qkbyte/minio/internal/http"
+	"github.com/qkbyte/minio/internal/kms"
 )
 
 var encryptRequestTests = []struct {
@@ -651,3 +653,46 @@ func TestGetDefaultOpts(t *testing.T) {
 		}
 	}
 }
+
+func TestResealObjectKey(t *testing.T) {
+	defer func(k kms.KMS) { GlobalKMS = k }(GlobalKMS)
+
+	var err error
+	GlobalKMS, err = kms.New("my-key", make([]byte, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	metadata := make(map[string]string)
+	objectKey, err := newEncryptMetadata(ctx, crypto.S3, "", nil, "src", "object", metadata, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Keeps the source encryption without a destination encryption.
+	copied := make(map[string]string, len(metadata))
+	for k, v := range metadata {
+		copied[k] = v
+	}
+	if err = resealObjectKey(ctx, copied, "src", "object", "dst", "copy", nil, ""); err != nil {
+		t.Fatal(err)
+	}
+	if key, err := crypto.S3.UnsealObjectKey(GlobalKMS, copied, "dst", "copy"); err != nil || key != objectKey {
+		t.Fatalf("expected the SSE-S3 object key to be resealed for the copy: %v", err)
+	}
+
+	// Takes the destination encryption when set.
+	if err = resealObjectKey(ctx, metadata, "src", "object", "dst", "copy", crypto.S3KMS, "my-key"); err != nil {
+		t.Fatal(err)
+	}
+	if kind, _ := crypto.IsEncrypted(metadata); kind != crypto.S3KMS {
+		t.Fatalf("expected SSE-KMS metadata, got %v", metadata)
+	}
+	if _, ok := metadata[crypto.MetaSealedKeyS3]; ok {
+		t.Fatal("expected the SSE-S3 sealed key to be removed")
+	}
+	if key, err := crypto.S3KMS.UnsealObjectKey(GlobalKMS, metadata, "dst", "copy"); err != nil || key != objectKey {
+		t.Fatalf("expected the object key to be resealed with SSE-KMS: %v", err)
+	}
+}
@@ -393,3 +393,72 @@ func createServerEndpoints(serverAddr string, args ...string) (
 
 	return endpointServerPools, setupType, nil
 }
+
+// SpreadFailureDomains re-orders the endpoints of the pool at poolIdx,
+// when it has topology labels, such that the drives of each erasure set
+// are spread as evenly as possible across the declared failure domains,
+// drives of unlabeled hosts are treated as a failure domain of their own.
+//
+// Drives of a formatted pool must stay at the positions recorded in its
+// format.json, storedOrder is called with the spread order and returns
+// the order the pool was formatted with, or nil if it is not formatted
+// yet, its errors are returned. An error is also returned if a single
+// failure domain of a pool being spread still holds more drives of a
+// set than parity can tolerate.
+func (l EndpointServerPools) SpreadFailureDomains(poolIdx, parity int, storedOrder func(spread Endpoints) (Endpoints, error)) error {
+	pool := l[poolIdx]
+	labeled := false
+	for _, ep := range pool.Endpoints {
+		if ep.Topology.failureDomain() != "" {
+			labeled = true
+			break
+		}
+	}
+	if !labeled {
+		return nil
+	}
+
+	spread := spreadEndpoints(pool.Endpoints)
+	order, err := storedOrder(spread)
+	if err != nil {
+		return err
+	}
+	if order != nil {
+		l[poolIdx].Endpoints = order
+		return nil
+	}
+	l[poolIdx].Endpoints = spread
+
+	if warnings := l[poolIdx].topologyWarnings(poolIdx, parity); len(warnings) > 0 {
+		return config.ErrInvalidErasureEndpoints(nil).Msg(strings.Join(warnings, "; "))
+	}
+	return nil
+}
+
+// spreadEndpoints interleaves the endpoints across their failure domains,
+// preserving the relative order of the endpoints within a failure domain.
+func spreadEndpoints(endpoints Endpoints) Endpoints {
+	var domains []string
+	queues := make(map[string]Endpoints)
+	for _, ep := range endpoints {
+		domain := ep.Topology.failureDomain()
+		if domain == "" {
+			domain = "host:" + ep.Hostname()
+		}
+		if _, ok := queues[domain]; !ok {
+			domains = append(domains, domain)
+		}
+		queues[domain] = append(queues[domain], ep)
+	}
+
+	spread := make(Endpoints, 0, len(endpoints))
+	for len(spread) < len(endpoints) {
+		for _, domain := range domains {
+			if queue := queues[domain]; len(queue) > 0 {
+				spread = append(spread, queue[0])
+				queues[domain] = queue[1:]
+			}
+		}
+	}
+	return spread
+}
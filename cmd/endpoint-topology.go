@@ -0,0 +1,197 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/minio/madmin-go"
+	"github.com/minio/minio-go/v7/pkg/set"
+)
+
+// topologyLabels carries the failure domain of a server.
+type topologyLabels struct {
+	Zone string `json:"zone,omitempty"`
+	Rack string `json:"rack,omitempty"`
+}
+
+// failureDomain returns the smallest failure domain identified by
+// the labels, an empty string is returned for unlabeled servers.
+func (t topologyLabels) failureDomain() string {
+	switch {
+	case t.Zone == "" && t.Rack == "":
+		return ""
+	case t.Rack == "":
+		return t.Zone
+	}
+	return t.Zone + "/" + t.Rack
+}
+
+// parseTopologyLabels parses the topology specification of the
+// form `host1=zone:z1,rack:r1;host2=zone:z1,rack:r2` where host
+// is either a hostname or a host:port pair.
+func parseTopologyLabels(s string) (map[string]topologyLabels, error) {
+	topology := make(map[string]topologyLabels)
+	for _, entry := range strings.Split(s, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		host, labels, ok := strings.Cut(entry, "=")
+		host = strings.TrimSpace(host)
+		if !ok || host == "" {
+			return nil, fmt.Errorf("invalid topology entry '%s', expected host=zone:value,rack:value", entry)
+		}
+		if _, ok = topology[host]; ok {
+			return nil, fmt.Errorf("duplicate topology entry for host '%s'", host)
+		}
+		var t topologyLabels
+		for _, label := range strings.Split(labels, ",") {
+			k, v, ok := strings.Cut(strings.TrimSpace(label), ":")
+			if !ok || v == "" {
+				return nil, fmt.Errorf("invalid topology label '%s' for host '%s'", label, host)
+			}
+			switch k {
+			case "zone":
+				t.Zone = v
+			case "rack":
+				t.Rack = v
+			default:
+				return nil, fmt.Errorf("unknown topology label '%s' for host '%s', expected zone or rack", k, host)
+			}
+		}
+		topology[host] = t
+	}
+	return topology, nil
+}
+
+// SetTopology assigns the topology labels to all the endpoints,
+// labels specified for a host:port take precedence over labels
+// specified for the hostname alone.
+func (l EndpointServerPools) SetTopology(topology map[string]topologyLabels) {
+	for i := range l {
+		for j := range l[i].Endpoints {
+			ep := &l[i].Endpoints[j]
+			if ep.URL == nil {
+				continue
+			}
+			if t, ok := topology[ep.Host]; ok {
+				ep.Topology = t
+			} else if t, ok := topology[ep.Hostname()]; ok {
+				ep.Topology = t
+			}
+		}
+	}
+}
+
+// TopologyWarnings returns a warning for each erasure set where a
+// single failure domain holds more drives than the set can tolerate
+// losing, parity returns the parity drives for a set drive count. As
+// in the object layer the parity of the first pool applies to all the
+// pools.
+func (l EndpointServerPools) TopologyWarnings(parity func(setDriveCount int) int) []string {
+	if len(l) == 0 || l[0].DrivesPerSet == 0 {
+		return nil
+	}
+	wantAtMost := parity(l[0].DrivesPerSet)
+	var warnings []string
+	for poolIdx, pool := range l {
+		warnings = append(warnings, pool.topologyWarnings(poolIdx, wantAtMost)...)
+	}
+	return warnings
+}
+
+// topologyWarnings returns a warning for each erasure set of the pool
+// where a single failure domain holds more than wantAtMost drives.
+func (p PoolEndpoints) topologyWarnings(poolIdx, wantAtMost int) []string {
+	if p.DrivesPerSet == 0 {
+		return nil
+	}
+	var warnings []string
+	for setIdx := 0; setIdx < p.SetCount; setIdx++ {
+		setEndpoints := p.Endpoints[setIdx*p.DrivesPerSet : (setIdx+1)*p.DrivesPerSet]
+		domains := make(map[string]int, len(setEndpoints))
+		for _, ep := range setEndpoints {
+			domains[ep.Topology.failureDomain()]++
+		}
+		if _, ok := domains[""]; ok {
+			// Sets with unlabeled drives are not checked.
+			continue
+		}
+		names := make([]string, 0, len(domains))
+		for domain := range domains {
+			names = append(names, domain)
+		}
+		sort.Strings(names)
+		for _, domain := range names {
+			if count := domains[domain]; count > wantAtMost {
+				warnings = append(warnings, fmt.Sprintf("Pool %d set %d has %d drives in failure domain '%s' which is more than the %d parity drives, "+
+					"a failure of '%s' will result in data becoming unavailable", poolIdx+1, setIdx+1, count, domain, wantAtMost, domain))
+			}
+		}
+	}
+	return warnings
+}
+
+// serverTopology describes the failure domain of a server.
+type serverTopology struct {
+	Endpoint string `json:"endpoint"`
+	Pool     int    `json:"poolNumber"`
+	topologyLabels
+}
+
+// topologyInfo is the topology of the deployment
+// included in the server info admin API response.
+type topologyInfo struct {
+	Servers  []serverTopology `json:"servers,omitempty"`
+	Warnings []string         `json:"warnings,omitempty"`
+}
+
+// infoMessageWithTopology extends the server info with the
+// rack and zone labels of the servers.
+type infoMessageWithTopology struct {
+	madmin.InfoMessage
+	Topology *topologyInfo `json:"topology,omitempty"`
+}
+
+// getTopologyInfo returns the topology of the deployment, nil is
+// returned when none of the servers are labeled.
+func getTopologyInfo(l EndpointServerPools) *topologyInfo {
+	var info topologyInfo
+	seen := set.NewStringSet()
+	for poolIdx, pool := range l {
+		for _, ep := range pool.Endpoints {
+			if ep.URL == nil || ep.Topology.failureDomain() == "" || seen.Contains(ep.Host) {
+				continue
+			}
+			seen.Add(ep.Host)
+			info.Servers = append(info.Servers, serverTopology{
+				Endpoint:       ep.Host,
+				Pool:           poolIdx + 1,
+				topologyLabels: ep.Topology,
+			})
+		}
+	}
+	if len(info.Servers) == 0 {
+		return nil
+	}
+	info.Warnings = l.TopologyWarnings(ecDrivesNoConfig)
+	return &info
+}
@@ -0,0 +1,161 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+)
+
+func TestParseTopologyLabels(t *testing.T) {
+	testCases := []struct {
+		spec      string
+		expected  map[string]topologyLabels
+		shouldErr bool
+	}{
+		{spec: "", expected: map[string]topologyLabels{}},
+		{
+			spec: "host1=zone:z1,rack:r1; host2:9000=rack:r2",
+			expected: map[string]topologyLabels{
+				"host1":      {Zone: "z1", Rack: "r1"},
+				"host2:9000": {Rack: "r2"},
+			},
+		},
+		{spec: "host1", shouldErr: true},
+		{spec: "=zone:z1", shouldErr: true},
+		{spec: "host1=zone", shouldErr: true},
+		{spec: "host1=row:r1", shouldErr: true},
+		{spec: "host1=zone:z1;host1=zone:z2", shouldErr: true},
+	}
+
+	for i, testCase := range testCases {
+		topology, err := parseTopologyLabels(testCase.spec)
+		if testCase.shouldErr {
+			if err == nil {
+				t.Errorf("Test %d: expected error", i+1)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Test %d: unexpected error %v", i+1, err)
+			continue
+		}
+		if fmt.Sprint(topology) != fmt.Sprint(testCase.expected) {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, topology)
+		}
+	}
+}
+
+func TestTopologyWarnings(t *testing.T) {
+	var endpoints Endpoints
+	for i := 1; i <= 4; i++ {
+		for d := 1; d <= 2; d++ {
+			u, err := url.Parse(fmt.Sprintf("http://host%d:9000/disk%d", i, d))
+			if err != nil {
+				t.Fatal(err)
+			}
+			endpoints = append(endpoints, Endpoint{URL: u})
+		}
+	}
+	pools := EndpointServerPools{{SetCount: 1, DrivesPerSet: 8, Endpoints: endpoints}}
+	parity := func(int) int { return 4 }
+
+	if warnings := pools.TopologyWarnings(parity); len(warnings) != 0 {
+		t.Fatalf("unlabeled sets must not be checked, got %v", warnings)
+	}
+
+	// Two racks of four drives each can tolerate losing a rack.
+	pools.SetTopology(map[string]topologyLabels{
+		"host1":      {Rack: "r1"},
+		"host2":      {Rack: "r1"},
+		"host3:9000": {Rack: "r2"},
+		"host4":      {Rack: "r2"},
+	})
+	if warnings := pools.TopologyWarnings(parity); len(warnings) != 0 {
+		t.Fatalf("expected no warnings, got %v", warnings)
+	}
+	if info := getTopologyInfo(pools); info == nil || len(info.Servers) != 4 {
+		t.Fatalf("expected topology of 4 servers, got %v", info)
+	}
+
+	// Six drives in a single rack exceed the parity.
+	pools.SetTopology(map[string]topologyLabels{
+		"host3":      {Rack: "r2"},
+		"host3:9000": {Rack: "r1"},
+	})
+	if warnings := pools.TopologyWarnings(parity); len(warnings) != 1 {
+		t.Fatalf("expected a single warning, got %v", warnings)
+	}
+
+	// The parity of the first pool applies to all the pools.
+	pools = append(EndpointServerPools{{SetCount: 1, DrivesPerSet: 4, Endpoints: pools[0].Endpoints[:4]}}, pools...)
+	if warnings := pools.TopologyWarnings(func(drives int) int { return drives / 2 }); len(warnings) != 2 {
+		t.Fatalf("expected two warnings with the parity of the first pool, got %v", warnings)
+	}
+}
+
+func TestSpreadFailureDomains(t *testing.T) {
+	var endpoints Endpoints
+	for i := 1; i <= 4; i++ {
+		for d := 1; d <= 4; d++ {
+			u, err := url.Parse(fmt.Sprintf("http://host%d:9000/disk%d", i, d))
+			if err != nil {
+				t.Fatal(err)
+			}
+			endpoints = append(endpoints, Endpoint{URL: u})
+		}
+	}
+	pools := EndpointServerPools{{SetCount: 2, DrivesPerSet: 8, Endpoints: endpoints}}
+	pools.SetTopology(map[string]topologyLabels{
+		"host1": {Rack: "r1"},
+		"host2": {Rack: "r1"},
+		"host3": {Rack: "r2"},
+		"host4": {Rack: "r2"},
+	})
+
+	// Without spreading the first set is entirely on rack r1.
+	if warnings := pools.TopologyWarnings(func(int) int { return 4 }); len(warnings) != 2 {
+		t.Fatalf("expected both sets to be reported, got %v", warnings)
+	}
+
+	// Formatted pools keep the stored drive order.
+	stored := func(Endpoints) (Endpoints, error) { return endpoints, nil }
+	if err := pools.SpreadFailureDomains(0, 3, stored); err != nil {
+		t.Fatal(err)
+	}
+	for i, ep := range pools[0].Endpoints {
+		if ep.String() != endpoints[i].String() {
+			t.Fatalf("endpoint %d: expected %s, got %s", i, endpoints[i], ep)
+		}
+	}
+
+	unformatted := func(Endpoints) (Endpoints, error) { return nil, nil }
+	if err := pools.SpreadFailureDomains(0, 4, unformatted); err != nil {
+		t.Fatal(err)
+	}
+	for i, ep := range pools[0].Endpoints {
+		if want := []string{"r1", "r2"}[i%2]; ep.Topology.Rack != want {
+			t.Fatalf("endpoint %d: expected rack %s, got %s", i, want, ep.Topology.Rack)
+		}
+	}
+
+	if err := pools.SpreadFailureDomains(0, 3, unformatted); err == nil {
+		t.Fatal("expected an error when a rack holds more drives than parity")
+	}
+}
@@ -62,6 +62,9 @@ type ProxyEndpoint struct {
 type Endpoint struct {
 	*url.URL
 	IsLocal bool
+
+	// Topology is the failure domain of the server, set via MINIO_TOPOLOGY.
+	Topology topologyLabels
 }
 
 func (endpoint Endpoint) String() string {
@@ -60,7 +60,8 @@ func NewErasure(ctx context.Context, dataBlocks, parityBlocks int, blockSize int
 	var once sync.Once
 	e.encoder = func() reedsolomon.Encoder {
 		once.Do(func() {
-			e, err := reedsolomon.New(dataBlocks, parityBlocks, reedsolomon.WithAutoGoroutines(int(e.ShardSize())))
+			opts := append([]reedsolomon.Option{reedsolomon.WithAutoGoroutines(int(e.ShardSize()))}, globalErasureSIMDOptions...)
+			e, err := reedsolomon.New(dataBlocks, parityBlocks, opts...)
 			if err != nil {
 				// Error conditions should be checked above.
 				panic(err)
@@ -72,7 +72,48 @@ func (p *parallelWriter) Write(ctx context.Context, blocks [][]byte) error {
 	return reduceWriteQuorumErrs(ctx, p.errs, objectOpIgnoredErrs, p.writeQuorum)
 }
 
+// erasureEncodeDepth is the number of blocks in flight while encoding,
+// one being read, one being encoded and one being written to the disks.
+const erasureEncodeDepth = 3
+
+// encodeBufPool holds the additional block buffers used by the encode
+// pipeline, the first buffer is always provided by the caller.
+var encodeBufPool sync.Pool
+
+func getEncodeBuf(size, capacity int) []byte {
+	if b, ok := encodeBufPool.Get().([]byte); ok && cap(b) >= capacity {
+		return b[:size]
+	}
+	return make([]byte, size, capacity)
+}
+
+// encodeBlock is a single block moving through the encode pipeline.
+type encodeBlock struct {
+	buf    []byte
+	n      int
+	eof    bool
+	err    error
+	blocks [][]byte
+}
+
+// readEncodeBlock fills buf from src, eof is set once src is exhausted.
+func readEncodeBlock(src io.Reader, buf []byte) (n int, eof bool, err error) {
+	n, err = io.ReadFull(src, buf)
+	switch err {
+	case nil:
+		return n, false, nil
+	case io.EOF, io.ErrUnexpectedEOF:
+		return n, true, nil
+	}
+	return n, false, err
+}
+
 // Encode reads from the reader, erasure-encodes the data and writes to the writers.
+//
+// Objects larger than a single block are encoded by a pipeline where the
+// next block is read and the parity of the current block is computed
+// while the previous one is written, using at most erasureEncodeDepth
+// buffers of the size of buf.
 func (e *Erasure) Encode(ctx context.Context, src io.Reader, writers []io.Writer, buf []byte, quorum int) (total int64, err error) {
 	writer := &parallelWriter{
 		writers:     writers,
@@ -80,34 +121,133 @@ func (e *Erasure) Encode(ctx context.Context, src io.Reader, writers []io.Writer
 		errs:        make([]error, len(writers)),
 	}
 
-	for {
-		var blocks [][]byte
-		n, err := io.ReadFull(src, buf)
-		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
-			logger.LogIf(ctx, err)
-			return 0, err
-		}
-		eof := err == io.EOF || err == io.ErrUnexpectedEOF
-		if n == 0 && total != 0 {
-			// Reached EOF, nothing more to be done.
-			break
+	n, eof, err := readEncodeBlock(src, buf)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return 0, err
+	}
+	if !eof {
+		return e.encodePipeline(ctx, src, writer, buf, n)
+	}
+
+	// We take care of the situation where if n == 0 by creating empty data and parity files.
+	blocks, err := e.EncodeData(ctx, buf[:n])
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return 0, err
+	}
+	if err = writer.Write(ctx, blocks); err != nil {
+		logger.LogIf(ctx, err)
+		return 0, err
+	}
+	return int64(n), nil
+}
+
+// encodePipeline encodes src starting with the first n bytes already
+// read into first. Blocks are written in order, the pipeline stops at
+// the first read, encode or write error.
+func (e *Erasure) encodePipeline(ctx context.Context, src io.Reader, writer *parallelWriter, first []byte, n int) (total int64, err error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		free     = make(chan []byte, erasureEncodeDepth)
+		toEncode = make(chan *encodeBlock, 1)
+		toWrite  = make(chan *encodeBlock, 1)
+		extra    [][]byte // buffers taken from encodeBufPool
+		readDone bool     // src was read to the end
+	)
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		defer close(toEncode)
+
+		blk := &encodeBlock{buf: first, n: n}
+		for {
+			// The block belongs to the encoder once sent.
+			eof, failed := blk.eof, blk.err != nil
+			select {
+			case toEncode <- blk:
+			case <-ctx.Done():
+				return
+			}
+			if failed {
+				return
+			}
+			if eof {
+				readDone = true
+				return
+			}
+
+			var buf []byte
+			select {
+			case buf = <-free:
+			default:
+				if len(extra)+1 < erasureEncodeDepth {
+					buf = getEncodeBuf(len(first), cap(first))
+					extra = append(extra, buf)
+					break
+				}
+				select {
+				case buf = <-free:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			blk = &encodeBlock{buf: buf}
+			blk.n, blk.eof, blk.err = readEncodeBlock(src, buf)
+			if blk.n == 0 && blk.eof {
+				// Reached EOF on a block boundary, nothing more to be done.
+				readDone = true
+				return
+			}
 		}
-		// We take care of the situation where if n == 0 and total == 0 by creating empty data and parity files.
-		blocks, err = e.EncodeData(ctx, buf[:n])
-		if err != nil {
-			logger.LogIf(ctx, err)
+	}()
 
-			return 0, err
+	go func() {
+		defer wg.Done()
+		defer close(toWrite)
+
+		for blk := range toEncode {
+			if blk.err == nil {
+				blk.blocks, blk.err = e.EncodeData(ctx, blk.buf[:blk.n])
+			}
+			select {
+			case toWrite <- blk:
+			case <-ctx.Done():
+				return
+			}
 		}
+	}()
 
-		if err = writer.Write(ctx, blocks); err != nil {
-			logger.LogIf(ctx, err)
-			return 0, err
+	for blk := range toWrite {
+		if err = blk.err; err != nil {
+			break
 		}
-		total += int64(n)
-		if eof {
+		if err = writer.Write(ctx, blk.blocks); err != nil {
 			break
 		}
+		total += int64(blk.n)
+		free <- blk.buf
+	}
+
+	// Wait for the reader to let go of its buffer before returning, the
+	// caller owns the first buffer and puts it back into its pool.
+	cancel()
+	wg.Wait()
+	for _, buf := range extra {
+		encodeBufPool.Put(buf)
+	}
+
+	if err == nil && !readDone {
+		err = ctx.Err()
+	}
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return 0, err
 	}
 	return total, nil
 }
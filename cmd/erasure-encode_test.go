@@ -161,6 +161,109 @@ func TestErasureEncode(t *testing.T) {
 	}
 }
 
+type failingWriter struct {
+	writes int
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if w.writes == 0 {
+		return 0, errFaultyDisk
+	}
+	w.writes--
+	return len(p), nil
+}
+
+func TestErasureEncodePipeline(t *testing.T) {
+	const blockSize = 64 * humanize.KiByte
+	erasure, err := NewErasure(context.Background(), 4, 2, blockSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, size := range []int{blockSize + 1, 4 * blockSize, 10*blockSize + 17} {
+		data := make([]byte, size)
+		if _, err = io.ReadFull(rand.Reader, data); err != nil {
+			t.Fatal(err)
+		}
+
+		// Encode block by block to get the expected shards.
+		want := make([]bytes.Buffer, 6)
+		for off := 0; off < size; off += blockSize {
+			end := off + blockSize
+			if end > size {
+				end = size
+			}
+			blocks, err := erasure.EncodeData(context.Background(), append([]byte{}, data[off:end]...))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i := range blocks {
+				want[i].Write(blocks[i])
+			}
+		}
+
+		got := make([]bytes.Buffer, 6)
+		writers := make([]io.Writer, 6)
+		for i := range writers {
+			writers[i] = &got[i]
+		}
+		buffer := make([]byte, blockSize, 2*blockSize)
+		n, err := erasure.Encode(context.Background(), bytes.NewReader(data), writers, buffer, 5)
+		if err != nil {
+			t.Fatalf("size %d: %v", size, err)
+		}
+		if n != int64(size) {
+			t.Errorf("size %d: expected %d bytes, got %d", size, size, n)
+		}
+		for i := range got {
+			if !bytes.Equal(got[i].Bytes(), want[i].Bytes()) {
+				t.Errorf("size %d: shard %d does not match", size, i)
+			}
+		}
+	}
+}
+
+func TestErasureEncodePipelineErrors(t *testing.T) {
+	const blockSize = 64 * humanize.KiByte
+	erasure, err := NewErasure(context.Background(), 4, 2, blockSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := make([]byte, 8*blockSize)
+	buffer := make([]byte, blockSize, 2*blockSize)
+
+	// Read error in the middle of the stream.
+	writers := make([]io.Writer, 6)
+	for i := range writers {
+		writers[i] = io.Discard
+	}
+	src := io.MultiReader(bytes.NewReader(data[:3*blockSize]), iotestErrReader{errFaultyDisk})
+	if _, err = erasure.Encode(context.Background(), src, writers, buffer, 5); err != errFaultyDisk {
+		t.Errorf("expected %v, got %v", errFaultyDisk, err)
+	}
+
+	// Write quorum lost after a few blocks.
+	for i := range writers {
+		writers[i] = &failingWriter{writes: 2}
+	}
+	if _, err = erasure.Encode(context.Background(), bytes.NewReader(data), writers, buffer, 5); err == nil {
+		t.Error("expected write quorum error")
+	}
+
+	// Canceled context.
+	for i := range writers {
+		writers[i] = io.Discard
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err = erasure.Encode(ctx, bytes.NewReader(data), writers, buffer, 5); err != context.Canceled {
+		t.Errorf("expected %v, got %v", context.Canceled, err)
+	}
+}
+
+type iotestErrReader struct{ err error }
+
+func (r iotestErrReader) Read([]byte) (int, error) { return 0, r.err }
+
 // Benchmarks
 
 func benchmarkErasureEncode(data, parity, dataDown, parityDown int, size int64, b *testing.B) {
@@ -27,6 +27,8 @@ import (
 	"time"
 
 	"github.com/minio/madmin-go"
+	xhttp "github.com/qkbyte/minio/internal/http"
+	"github.com/qkbyte/minio/internal/ioprio"
 	"github.com/qkbyte/minio/internal/logger"
 	"github.com/qkbyte/minio/internal/sync/errgroup"
 )
@@ -379,13 +381,13 @@ func (er erasureObjects) healObject(ctx context.Context, bucket string, object s
 		}
 
 		// Is only 'true' if the opts.Recreate is true and
-		// the object shardSize < smallFileThreshold do not
+		// the object shardSize < inlineThreshold() do not
 		// set this to 'true' arbitrarily and must be only
 		// 'true' with caller ask.
 		recreate = (opts.Recreate &&
 			!latestMeta.InlineData() &&
 			len(latestMeta.Parts) == 1 &&
-			erasure.ShardFileSize(latestMeta.Parts[0].ActualSize) < smallFileThreshold)
+			erasure.ShardFileSize(latestMeta.Parts[0].ActualSize) < inlineThreshold(bucket, latestMeta.Metadata[xhttp.AmzStorageClass]))
 	}
 
 	// Loop to find number of disks with valid data, per-drive
@@ -564,6 +566,12 @@ func (er erasureObjects) healObject(ctx context.Context, bucket string, object s
 				}
 			}
 
+			// Rate limit heal traffic per drive when configured.
+			throttleHealReaders(ctx, readers, latestDisks)
+			if len(inlineBuffers) == 0 {
+				throttleHealWriters(ctx, writers, outDatedDisks)
+			}
+
 			// Heal each part. erasure.Heal() will write the healed
 			// part to .minio/tmp/uuid/ which needs to be renamed
 			// later to the final location.
@@ -982,6 +990,8 @@ func (er erasureObjects) HealObject(ctx context.Context, bucket, object, version
 		newReqInfo = logger.NewReqInfo("", "", globalDeploymentID, "", "Heal", bucket, object)
 	}
 	healCtx := logger.SetReqInfo(GlobalContext, newReqInfo)
+	// Yield the drives to foreground requests if configured.
+	healCtx = ioprio.WithClass(healCtx, globalHealConfig.IOPriority())
 
 	// Healing directories handle it separately.
 	if HasSuffix(object, SlashSeparator) {
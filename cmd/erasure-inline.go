@@ -0,0 +1,111 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/dustin/go-humanize"
+	"github.com/qkbyte/minio/internal/config/storageclass"
+	"github.com/qkbyte/minio/internal/sync/errgroup"
+)
+
+// bucketInlineConfig - per bucket override of the shard size below
+// which object data is inlined in xl.meta, a threshold of zero
+// disables inlining for the bucket.
+type bucketInlineConfig struct {
+	Threshold string `json:"threshold"`
+
+	threshold int64
+}
+
+func parseBucketInlineConfig(data []byte) (*bucketInlineConfig, error) {
+	cfg := &bucketInlineConfig{}
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, err
+	}
+	threshold, err := humanize.ParseBytes(cfg.Threshold)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid inline threshold %s: %w", cfg.Threshold, err)
+	}
+	if threshold > storageclass.MaxInlineThreshold {
+		return nil, fmt.Errorf("Invalid inline threshold %s: exceeds the maximum of %s",
+			cfg.Threshold, humanize.IBytes(storageclass.MaxInlineThreshold))
+	}
+	cfg.threshold = int64(threshold)
+	return cfg, nil
+}
+
+// inlineThreshold returns the shard size below which data of objects
+// written to bucket with storage class sc is inlined in xl.meta. The
+// bucket configuration takes precedence over the storage class one.
+func inlineThreshold(bucket, sc string) int64 {
+	if isMinioMetaBucketName(bucket) {
+		return smallFileThreshold
+	}
+	if globalBucketMetadataSys != nil {
+		if cfg, _, _ := globalBucketMetadataSys.GetInlineConfig(bucket); cfg != nil {
+			return cfg.threshold
+		}
+	}
+	if threshold := globalStorageClass.GetInlineThresholdForSC(sc); threshold > 0 {
+		return threshold
+	}
+	return smallFileThreshold
+}
+
+// shouldInline returns true if a shard of shardFileSize bytes is to be
+// inlined, versioned objects only inline an eighth of the threshold to
+// keep xl.meta of objects with many versions small.
+func shouldInline(shardFileSize, threshold int64, versioned bool) bool {
+	if shardFileSize < 0 {
+		return false
+	}
+	if !versioned {
+		return shardFileSize < threshold
+	}
+	return shardFileSize < threshold/8
+}
+
+// readMultipartInlineData reads the shard files of a multipart upload
+// consisting of a single part so that they can be inlined in xl.meta
+// upon completion. nil is returned if any online disk fails to return
+// its shard, in which case the part files are renamed as usual.
+func readMultipartInlineData(ctx context.Context, disks []StorageAPI, uploadIDPath string, fi FileInfo) [][]byte {
+	partPath := pathJoin(uploadIDPath, fi.DataDir, fmt.Sprintf("part.%d", fi.Parts[0].Number))
+	data := make([][]byte, len(disks))
+	g := errgroup.WithNErrs(len(disks))
+	for index := range disks {
+		index := index
+		g.Go(func() (err error) {
+			if disks[index] == nil {
+				return nil
+			}
+			data[index], err = disks[index].ReadAll(ctx, minioMetaMultipartBucket, partPath)
+			return err
+		}, index)
+	}
+	for _, err := range g.Wait() {
+		if err != nil {
+			return nil
+		}
+	}
+	return data
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+)
+
+func TestParseBucketInlineConfig(t *testing.T) {
+	testCases := []struct {
+		data      string
+		threshold int64
+		wantErr   bool
+	}{
+		{`{"threshold":"64KiB"}`, 64 << 10, false},
+		{`{"threshold":"0"}`, 0, false},
+		{`{"threshold":"1MiB"}`, 1 << 20, false},
+		{`{"threshold":"2MiB"}`, 0, true},
+		{`{"threshold":"abc"}`, 0, true},
+		{`{"threshold":`, 0, true},
+	}
+	for i, testCase := range testCases {
+		cfg, err := parseBucketInlineConfig([]byte(testCase.data))
+		if (err != nil) != testCase.wantErr {
+			t.Fatalf("Test %d: expected error %v, got %v", i+1, testCase.wantErr, err)
+		}
+		if err == nil && cfg.threshold != testCase.threshold {
+			t.Fatalf("Test %d: expected threshold %d, got %d", i+1, testCase.threshold, cfg.threshold)
+		}
+	}
+}
+
+func TestShouldInline(t *testing.T) {
+	testCases := []struct {
+		shardFileSize int64
+		threshold     int64
+		versioned     bool
+		expected      bool
+	}{
+		{-1, smallFileThreshold, false, false},
+		{0, smallFileThreshold, false, true},
+		{smallFileThreshold - 1, smallFileThreshold, false, true},
+		{smallFileThreshold, smallFileThreshold, false, false},
+		{smallFileThreshold / 8, smallFileThreshold, true, false},
+		{smallFileThreshold/8 - 1, smallFileThreshold, true, true},
+		{0, 0, false, false},
+	}
+	for i, testCase := range testCases {
+		if got := shouldInline(testCase.shardFileSize, testCase.threshold, testCase.versioned); got != testCase.expected {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, got)
+		}
+	}
+}
+
+func TestErasureInlineThreshold(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasure(ctx, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	z := obj.(*erasureServerPools)
+	xl := z.serverPools[0].sets[0]
+
+	bucket := "bucket"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, MakeBucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	data := bytes.Repeat([]byte{'a'}, 4<<10)
+	checkInline := func(object string, expected bool) {
+		t.Helper()
+		fi, _, _, err := xl.getObjectFileInfo(ctx, bucket, object, ObjectOptions{}, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fi.InlineData() != expected {
+			t.Fatalf("%s: expected inline %v, got %v", object, expected, fi.InlineData())
+		}
+		gr, err := obj.GetObjectNInfo(ctx, bucket, object, nil, nil, readLock, ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := io.ReadAll(gr)
+		gr.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, data) {
+			t.Fatalf("%s: corrupted data", object)
+		}
+	}
+
+	// A single part multipart upload of a small object is inlined.
+	res, err := obj.NewMultipartUpload(ctx, bucket, "multipart", ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pi, err := obj.PutObjectPart(ctx, bucket, "multipart", res.UploadID, 1, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = obj.CompleteMultipartUpload(ctx, bucket, "multipart", res.UploadID, []CompletePart{{PartNumber: 1, ETag: pi.ETag}}, ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkInline("multipart", true)
+
+	// A zero bucket threshold disables inlining.
+	meta := newBucketMetadata(bucket)
+	meta.inlineConfig = &bucketInlineConfig{Threshold: "0"}
+	globalBucketMetadataSys.Set(bucket, meta)
+	defer globalBucketMetadataSys.Set(bucket, newBucketMetadata(bucket))
+
+	_, err = obj.PutObject(ctx, bucket, "object", mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkInline("object", false)
+}
@@ -1159,6 +1159,7 @@ func (er erasureObjects) CompleteMultipartUpload(ctx context.Context, bucket str
 	if fi.Metadata["etag"] == "" {
 		fi.Metadata["etag"] = getCompleteMultipartMD5(parts)
 	}
+	setObjectCreated(fi.Metadata, UTCNow())
 
 	// Save the consolidated actual size.
 	fi.Metadata[ReservedMetadataPrefix+"actual-size"] = strconv.FormatInt(objectActualSize, 10)
@@ -1207,12 +1208,33 @@ func (er erasureObjects) CompleteMultipartUpload(ctx context.Context, bucket str
 		er.removePartMeta(bucket, object, uploadID, fi.DataDir, part.Number)
 	}
 
+	// All parts but the last are at least 5MiB, so only uploads of a
+	// single part may be small enough to be inlined in xl.meta.
+	var inlined bool
+	threshold := inlineThreshold(bucket, fi.Metadata[xhttp.AmzStorageClass])
+	if len(fi.Parts) == 1 && shouldInline(fi.Erasure.ShardFileSize(fi.Parts[0].Size), threshold, opts.Versioned) {
+		if data := readMultipartInlineData(ctx, onlineDisks, uploadIDPath, fi); data != nil {
+			for index := range partsMetadata {
+				if onlineDisks[index] != nil && partsMetadata[index].IsValid() {
+					partsMetadata[index].Data = data[index]
+					partsMetadata[index].SetInlineData()
+				}
+			}
+			inlined = true
+		}
+	}
+
 	// Rename the multipart object to final location.
 	if onlineDisks, err = renameData(ctx, onlineDisks, minioMetaMultipartBucket, uploadIDPath,
 		partsMetadata, bucket, object, writeQuorum); err != nil {
 		return oi, toObjectErr(err, bucket, object)
 	}
 
+	if inlined {
+		// The part files are left behind by renameData().
+		er.renameAll(ctx, minioMetaMultipartBucket, uploadIDPath)
+	}
+
 	defer NSUpdated(bucket, object)
 
 	// Check if there is any offline disk and add it to the MRF list
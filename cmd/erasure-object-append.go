@@ -0,0 +1,264 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/qkbyte/minio/internal/crypto"
+	"github.com/qkbyte/minio/internal/hash"
+	"github.com/qkbyte/minio/internal/logger"
+)
+
+// appendObject appends data to the latest version of an existing object,
+// the object is created if it doesn't exist. Objects whose data is
+// inlined in `xl.meta` are rewritten in full, other objects grow by a
+// new internal part written next to the existing parts, the ETag of
+// such objects follows the multipart ETag semantics. In versioned
+// buckets the appended object is written as a new version sharing the
+// data directory of the previous one, versions are never modified.
+func (er erasureObjects) appendObject(ctx context.Context, bucket, object string, r *PutObjReader, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	auditObjectErasureSet(ctx, object, &er)
+
+	if !opts.NoLock {
+		lk := er.NewNSLock(bucket, object)
+		lkctx, err := lk.GetLock(ctx, globalOperationTimeout)
+		if err != nil {
+			return ObjectInfo{}, err
+		}
+		ctx = lkctx.Context()
+		defer lk.Unlock(lkctx.Cancel)
+	}
+	opts.NoLock = true
+
+	fi, metaArr, onlineDisks, err := er.getObjectFileInfo(ctx, bucket, object, ObjectOptions{}, true)
+	if err != nil {
+		if isErrObjectNotFound(err) || isErrVersionNotFound(err) {
+			return er.putObject(ctx, bucket, object, r, opts)
+		}
+		return objInfo, toObjectErr(err, bucket, object)
+	}
+	if fi.Deleted {
+		return er.putObject(ctx, bucket, object, r, opts)
+	}
+
+	_, compressed := fi.Metadata[ReservedMetadataPrefix+"compression"]
+	_, encrypted := crypto.IsEncrypted(fi.Metadata)
+	if compressed || encrypted || fi.IsRemote() {
+		return objInfo, NotImplemented{Message: "Append is not supported for compressed, encrypted or transitioned objects"}
+	}
+
+	if r.Reader.Size() < 0 {
+		return objInfo, toObjectErr(errInvalidArgument)
+	}
+
+	// The null version replacing a version of a bucket with suspended
+	// versioning cannot share its data directory.
+	suspendedOverVersion := opts.VersionSuspended && fi.VersionID != "" && fi.VersionID != nullVersionID
+	if fi.InlineData() || suspendedOverVersion {
+		return er.rewriteAppendObject(ctx, bucket, object, r, fi, metaArr, onlineDisks, opts)
+	}
+
+	if len(fi.Parts) >= globalMaxPartID {
+		return objInfo, NotImplemented{Message: fmt.Sprintf("Object has reached the maximum of %d appends", globalMaxPartID)}
+	}
+
+	data := r.Reader
+	writeQuorum := fi.WriteQuorum(er.defaultWQuorum())
+	onlineDisks = shuffleDisks(onlineDisks, fi.Erasure.Distribution)
+
+	partNumber := fi.Parts[len(fi.Parts)-1].Number + 1
+	partSuffix := fmt.Sprintf("part.%d", partNumber)
+	tmpPart := mustGetUUID()
+	tmpPartPath := pathJoin(tmpPart, partSuffix)
+
+	// Delete the temporary part, if the append succeeds there is nothing to delete.
+	var online int
+	defer func() {
+		if online != len(onlineDisks) {
+			er.renameAll(context.Background(), minioMetaTmpBucket, tmpPart)
+		}
+	}()
+
+	erasure, err := NewErasure(ctx, fi.Erasure.DataBlocks, fi.Erasure.ParityBlocks, fi.Erasure.BlockSize)
+	if err != nil {
+		return objInfo, toObjectErr(err, bucket, object)
+	}
+
+	var buffer []byte
+	switch size := data.Size(); {
+	case size == 0:
+		buffer = make([]byte, 1) // Allocate atleast a byte to reach EOF
+	case size >= fi.Erasure.BlockSize:
+		buffer = er.bp.Get()
+		defer er.bp.Put(buffer)
+	default:
+		// No need to allocate fully fi.Erasure.BlockSize buffer if the incoming data is smaller.
+		buffer = make([]byte, size, 2*size+int64(fi.Erasure.ParityBlocks+fi.Erasure.DataBlocks-1))
+	}
+
+	writers := make([]io.Writer, len(onlineDisks))
+	for i, disk := range onlineDisks {
+		if disk == nil {
+			continue
+		}
+		writers[i] = newBitrotWriter(disk, minioMetaTmpBucket, tmpPartPath, erasure.ShardFileSize(data.Size()), DefaultBitrotAlgorithm, erasure.ShardSize())
+	}
+
+	n, err := erasure.Encode(ctx, data, writers, buffer, writeQuorum)
+	closeBitrotWriters(writers)
+	if err != nil {
+		return objInfo, toObjectErr(err, bucket, object)
+	}
+
+	// Should return IncompleteBody{} error when reader has fewer bytes
+	// than specified in request header.
+	if n < data.Size() {
+		return objInfo, IncompleteBody{Bucket: bucket, Object: object}
+	}
+
+	for i := range writers {
+		if writers[i] == nil {
+			onlineDisks[i] = nil
+		}
+	}
+
+	// Rename the temporary part next to the existing parts of the object,
+	// it is removed again unless the new metadata is written.
+	partPath := pathJoin(object, fi.DataDir, partSuffix)
+	committed := false
+	defer func() {
+		if !committed {
+			er.deleteAppendedPart(bucket, partPath, onlineDisks)
+		}
+	}()
+	onlineDisks, err = renamePart(ctx, onlineDisks, minioMetaTmpBucket, tmpPartPath, bucket, partPath, writeQuorum)
+	if err != nil {
+		return objInfo, toObjectErr(err, bucket, object)
+	}
+
+	// fi shares its metadata and parts with the version read from the
+	// drives, copy them before they are updated.
+	fi.Metadata = cloneMSS(fi.Metadata)
+	fi.Parts = append([]ObjectPartInfo(nil), fi.Parts...)
+	fi.Erasure.Checksums = append([]ChecksumInfo(nil), fi.Erasure.Checksums...)
+	mergeAppendMetadata(fi.Metadata, opts.UserDefined)
+
+	if opts.Versioned {
+		fi.VersionID = opts.VersionID
+		if fi.VersionID == "" {
+			fi.VersionID = mustGetUUID()
+		}
+	}
+
+	// Objects written with PutObject do not record the part ETag.
+	if fi.Parts[0].ETag == "" && len(fi.Parts) == 1 {
+		fi.Parts[0].ETag = fi.Metadata["etag"]
+	}
+
+	modTime := UTCNow()
+	fi.AddObjectPart(partNumber, r.MD5CurrentHexString(), n, data.ActualSize(), modTime, nil, nil)
+	fi.Erasure.AddChecksumInfo(ChecksumInfo{
+		PartNumber: partNumber,
+		Algorithm:  DefaultBitrotAlgorithm,
+	})
+
+	parts := make([]CompletePart, 0, len(fi.Parts))
+	for _, part := range fi.Parts {
+		parts = append(parts, CompletePart{PartNumber: part.Number, ETag: part.ETag})
+	}
+	fi.Metadata["etag"] = getCompleteMultipartMD5(parts)
+	fi.Size += n
+	fi.ModTime = modTime
+	fi.Data = nil
+
+	partsMetadata := make([]FileInfo, len(onlineDisks))
+	for i := range partsMetadata {
+		partsMetadata[i] = fi
+	}
+
+	if onlineDisks, err = writeUniqueFileInfo(ctx, onlineDisks, bucket, object, partsMetadata, writeQuorum); err != nil {
+		return objInfo, toObjectErr(err, bucket, object)
+	}
+	committed = true
+
+	for _, disk := range onlineDisks {
+		if disk != nil && disk.IsOnline() {
+			online++
+		}
+	}
+
+	// Whether a disk was initially or becomes offline
+	// during this append, send it to the MRF list.
+	if online != len(onlineDisks) {
+		er.addPartial(bucket, object, fi.VersionID, fi.Size)
+	}
+
+	return fi.ToObjectInfo(bucket, object, opts.Versioned || opts.VersionSuspended), nil
+}
+
+// deleteAppendedPart removes a part renamed into the data directory of
+// an object whose metadata failed to be updated.
+func (er erasureObjects) deleteAppendedPart(bucket, partPath string, disks []StorageAPI) {
+	for _, disk := range disks {
+		if disk != nil {
+			disk.Delete(context.Background(), bucket, partPath, DeleteOptions{})
+		}
+	}
+}
+
+// mergeAppendMetadata merges the metadata of an append request into the
+// metadata of the object. Internal metadata of the request, such as the
+// replication state, replaces the existing one, other keys are added.
+func mergeAppendMetadata(metadata, userDefined map[string]string) {
+	for k, v := range userDefined {
+		if _, ok := metadata[k]; !ok || HasPrefix(strings.ToLower(k), ReservedMetadataPrefixLower) {
+			metadata[k] = v
+		}
+	}
+}
+
+// rewriteAppendObject rewrites an object in full along with the appended
+// data, preserving its metadata. It is used for objects with inlined
+// data, which are small by definition, and for null versions replacing
+// a version in buckets with suspended versioning.
+func (er erasureObjects) rewriteAppendObject(ctx context.Context, bucket, object string, r *PutObjReader, fi FileInfo, metaArr []FileInfo, onlineDisks []StorageAPI, opts ObjectOptions) (ObjectInfo, error) {
+	var current bytes.Buffer
+	if err := er.getObjectWithFileInfo(ctx, bucket, object, 0, fi.Size, &current, fi, metaArr, onlineDisks); err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+
+	size := int64(current.Len()) + r.Reader.Size()
+	hr, err := hash.NewReader(io.MultiReader(&current, r), size, "", "", size)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+
+	userDefined := cloneMSS(fi.Metadata)
+	delete(userDefined, "etag")
+	mergeAppendMetadata(userDefined, opts.UserDefined)
+	opts.UserDefined = userDefined
+	opts.IndexCB = nil
+
+	return er.putObject(ctx, bucket, object, NewPutObjReader(hr), opts)
+}
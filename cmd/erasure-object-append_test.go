@@ -0,0 +1,185 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestErasureAppendObject(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	bucket := "bucket"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, MakeBucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		object  string
+		initial []byte
+		appends [][]byte
+		parts   int
+	}{
+		// Object created by the first append, data inlined in xl.meta.
+		{object: "inline", appends: [][]byte{[]byte("line 1\n"), []byte("line 2\n")}, parts: 1},
+		// Object with data on the drives grows by a new part per append.
+		{
+			object:  "large",
+			initial: bytes.Repeat([]byte("a"), 2<<20),
+			appends: [][]byte{bytes.Repeat([]byte("b"), 1<<20), []byte("tail\n")},
+			parts:   3,
+		},
+	}
+
+	for _, testCase := range testCases {
+		var want []byte
+		if testCase.initial != nil {
+			want = append(want, testCase.initial...)
+			_, err = obj.PutObject(ctx, bucket, testCase.object, mustGetPutObjReader(t, bytes.NewReader(testCase.initial), int64(len(testCase.initial)), "", ""), ObjectOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		var oi ObjectInfo
+		for _, data := range testCase.appends {
+			want = append(want, data...)
+			oi, err = obj.PutObject(ctx, bucket, testCase.object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{Append: true})
+			if err != nil {
+				t.Fatalf("%s: %v", testCase.object, err)
+			}
+		}
+		if oi.Size != int64(len(want)) {
+			t.Fatalf("%s: expected size %d, got %d", testCase.object, len(want), oi.Size)
+		}
+		if testCase.parts > 1 && !strings.HasSuffix(oi.ETag, "-3") {
+			t.Fatalf("%s: expected multipart ETag, got %s", testCase.object, oi.ETag)
+		}
+
+		gr, err := obj.GetObjectNInfo(ctx, bucket, testCase.object, nil, nil, readLock, ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var buf bytes.Buffer
+		_, err = buf.ReadFrom(gr)
+		gr.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(buf.Bytes(), want) {
+			t.Fatalf("%s: unexpected object content after append", testCase.object)
+		}
+		if len(gr.ObjInfo.Parts) != testCase.parts || gr.ObjInfo.ETag != oi.ETag {
+			t.Fatalf("%s: expected %d parts with ETag %s, got %d parts with ETag %s", testCase.object, testCase.parts, oi.ETag, len(gr.ObjInfo.Parts), gr.ObjInfo.ETag)
+		}
+	}
+}
+
+func TestErasureAppendObjectVersioned(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	bucket, object := "bucket", "object"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, MakeBucketOptions{VersioningEnabled: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	initial := bytes.Repeat([]byte("a"), 2<<20)
+	first, err := obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(initial), int64(len(initial)), "", ""), ObjectOptions{Versioned: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := []byte("tail\n")
+	replicationStatus := ReservedMetadataPrefixLower + ReplicationStatus
+	appended, err := obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{
+		Append:      true,
+		Versioned:   true,
+		UserDefined: map[string]string{replicationStatus: "PENDING"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if appended.VersionID == "" || appended.VersionID == first.VersionID {
+		t.Fatalf("expected a new version, got %q after %q", appended.VersionID, first.VersionID)
+	}
+	if appended.UserDefined[replicationStatus] != "PENDING" {
+		t.Fatalf("expected the replication status of the append to be kept, got %v", appended.UserDefined)
+	}
+
+	for _, testCase := range []struct {
+		versionID string
+		want      []byte
+	}{
+		{first.VersionID, initial},
+		{appended.VersionID, append(append([]byte(nil), initial...), data...)},
+	} {
+		gr, err := obj.GetObjectNInfo(ctx, bucket, object, nil, nil, readLock, ObjectOptions{VersionID: testCase.versionID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var buf bytes.Buffer
+		_, err = buf.ReadFrom(gr)
+		gr.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(buf.Bytes(), testCase.want) {
+			t.Fatalf("%s: unexpected content", testCase.versionID)
+		}
+	}
+}
+
+func TestSingleDriveAppendObjectNotImplemented(t *testing.T) {
+	obj, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots([]string{fsDir})
+
+	ctx := context.Background()
+	bucket := "bucket"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, MakeBucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	data := []byte("line 1\n")
+	_, err = obj.PutObject(ctx, bucket, "object", mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{Append: true})
+	if _, ok := err.(NotImplemented); !ok {
+		t.Fatalf("expected NotImplemented, got %v", err)
+	}
+}
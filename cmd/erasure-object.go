@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"os"
 	"path"
 	"strconv"
 	"strings"
@@ -37,6 +38,7 @@ import (
 	"github.com/qkbyte/minio/internal/bucket/lifecycle"
 	"github.com/qkbyte/minio/internal/bucket/object/lock"
 	"github.com/qkbyte/minio/internal/bucket/replication"
+	"github.com/qkbyte/minio/internal/crypto"
 	"github.com/qkbyte/minio/internal/event"
 	"github.com/qkbyte/minio/internal/hash"
 	xhttp "github.com/qkbyte/minio/internal/http"
@@ -271,6 +273,35 @@ func (er erasureObjects) GetObjectNInfo(ctx context.Context, bucket, object stri
 	if err != nil {
 		return nil, err
 	}
+
+	if opts.ZeroCopy {
+		decode := func(offset, length int64) io.ReadCloser {
+			pr, pw := xioutil.WaitPipe()
+			go func() {
+				pw.CloseWithError(er.getObjectWithFileInfo(ctx, bucket, object, offset, length, pw, fi, metaArr, onlineDisks))
+			}()
+			return pr
+		}
+		if raw := rawErasureReader(bucket, object, off, length, objInfo, fi, metaArr, onlineDisks, decode); raw != nil {
+			gr, err := fn(raw, h, func() { raw.Close() }, nsUnlocker)
+			if err != nil {
+				raw.Close()
+				return nil, err
+			}
+			unlockOnDefer = false
+			gr.raw = raw
+			return gr, nil
+		}
+	}
+
+	if rs != nil && globalRangeCache.eligible(length) {
+		data, err := er.readCachedRange(ctx, bucket, object, off, length, fi, metaArr, onlineDisks)
+		if err != nil {
+			return nil, toObjectErr(err, bucket, object)
+		}
+		unlockOnDefer = false
+		return fn(bytes.NewReader(data), h, nsUnlocker)
+	}
 	unlockOnDefer = false
 
 	pr, pw := xioutil.WaitPipe()
@@ -421,6 +452,68 @@ func (er erasureObjects) getObjectWithFileInfo(ctx context.Context, bucket, obje
 	return nil
 }
 
+// rawErasureReader returns a reader serving the given range of a plain
+// single part object straight from the part files of its data shards,
+// verifying the bitrot hash of every shard it serves. It returns nil
+// when any data shard is not on an online local drive with consistent
+// metadata, the caller then has to decode the object. Shards failing
+// verification make the reader decode the rest through fallback.
+func rawErasureReader(bucket, object string, offset, length int64, objInfo ObjectInfo, fi FileInfo, metaArr []FileInfo, onlineDisks []StorageAPI, fallback func(offset, length int64) io.ReadCloser) *rawShardReader {
+	if _, encrypted := crypto.IsEncrypted(objInfo.UserDefined); encrypted || objInfo.IsCompressed() {
+		return nil
+	}
+	if len(fi.Parts) != 1 || fi.Size == 0 || fi.XLV1 || fi.InlineData() {
+		return nil
+	}
+	if offset < 0 || length <= 0 || offset+length > fi.Size {
+		return nil
+	}
+
+	partNumber := fi.Parts[0].Number
+	checksumInfo := fi.Erasure.GetChecksumInfo(partNumber)
+	if checksumInfo.Algorithm != HighwayHash256S {
+		return nil
+	}
+	h := checksumInfo.Algorithm.New()
+	dataBlocks := int64(fi.Erasure.DataBlocks)
+	blockSize := fi.Erasure.BlockSize
+	shardSize := ceilFrac(blockSize, dataBlocks)
+	shardFileSize := bitrotShardFileSize(fi.Erasure.ShardFileSize(fi.Size), shardSize, checksumInfo.Algorithm)
+
+	onlineDisks, metaArr = shuffleDisksAndPartsMetadataByIndex(onlineDisks, metaArr, fi)
+	r := &rawShardReader{
+		files:      make([]*os.File, 0, dataBlocks),
+		h:          h,
+		size:       fi.Size,
+		blockSize:  blockSize,
+		shardSize:  shardSize,
+		dataBlocks: dataBlocks,
+		pos:        offset,
+		end:        offset + length,
+		buf:        make([]byte, int64(h.Size())+shardSize),
+		fallback:   fallback,
+	}
+	partPath := pathJoin(object, fi.DataDir, fmt.Sprintf("part.%d", partNumber))
+	for index := 0; index < int(dataBlocks); index++ {
+		disk := onlineDisks[index]
+		if disk == OfflineDisk || !disk.IsLocal() || !disk.IsOnline() || !metaArr[index].IsValid() {
+			r.Close()
+			return nil
+		}
+		f, err := os.Open(pathJoin(disk.Endpoint().Path, bucket, partPath))
+		if err != nil {
+			r.Close()
+			return nil
+		}
+		r.files = append(r.files, f)
+		if st, err := f.Stat(); err != nil || st.Size() != shardFileSize {
+			r.Close()
+			return nil
+		}
+	}
+	return r
+}
+
 // GetObjectInfo - reads object metadata and replies back ObjectInfo.
 func (er erasureObjects) GetObjectInfo(ctx context.Context, bucket, object string, opts ObjectOptions) (info ObjectInfo, err error) {
 	auditObjectErasureSet(ctx, object, &er)
@@ -905,6 +998,9 @@ func (er erasureObjects) putMetacacheObject(ctx context.Context, key string, r *
 // writes `xl.meta` which carries the necessary metadata for future
 // object operations.
 func (er erasureObjects) PutObject(ctx context.Context, bucket string, object string, data *PutObjReader, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	if opts.Append {
+		return er.appendObject(ctx, bucket, object, data, opts)
+	}
 	return er.putObject(ctx, bucket, object, data, opts)
 }
 
@@ -1051,20 +1147,15 @@ func (er erasureObjects) putObject(ctx context.Context, bucket string, object st
 	shardFileSize := erasure.ShardFileSize(data.Size())
 	writers := make([]io.Writer, len(onlineDisks))
 	var inlineBuffers []*bytes.Buffer
+	threshold := inlineThreshold(bucket, userDefined[xhttp.AmzStorageClass])
 	if shardFileSize >= 0 {
-		if !opts.Versioned && shardFileSize < smallFileThreshold {
-			inlineBuffers = make([]*bytes.Buffer, len(onlineDisks))
-		} else if shardFileSize < smallFileThreshold/8 {
+		if shouldInline(shardFileSize, threshold, opts.Versioned) {
 			inlineBuffers = make([]*bytes.Buffer, len(onlineDisks))
 		}
 	} else {
 		// If compressed, use actual size to determine.
-		if sz := erasure.ShardFileSize(data.ActualSize()); sz > 0 {
-			if !opts.Versioned && sz < smallFileThreshold {
-				inlineBuffers = make([]*bytes.Buffer, len(onlineDisks))
-			} else if sz < smallFileThreshold/8 {
-				inlineBuffers = make([]*bytes.Buffer, len(onlineDisks))
-			}
+		if sz := erasure.ShardFileSize(data.ActualSize()); sz > 0 && shouldInline(sz, threshold, opts.Versioned) {
+			inlineBuffers = make([]*bytes.Buffer, len(onlineDisks))
 		}
 	}
 	for i, disk := range onlineDisks {
@@ -1162,6 +1253,7 @@ func (er erasureObjects) putObject(ctx context.Context, bucket string, object st
 	if userDefined["content-type"] == "" {
 		userDefined["content-type"] = mimedb.TypeByExtension(path.Ext(object))
 	}
+	setObjectCreated(userDefined, UTCNow())
 
 	// Fill all the necessary metadata.
 	// Update `xl.meta` content on each disks.
@@ -2028,3 +2120,51 @@ func (er erasureObjects) restoreTransitionedObject(ctx context.Context, bucket s
 	})
 	return setRestoreHeaderFn(oi, err)
 }
+
+// renameObjectDir moves the directory holding srcObject to dstObject on
+// all drives of the set, no data is copied. The caller must hold the
+// namespace locks of both objects.
+func (er erasureObjects) renameObjectDir(ctx context.Context, bucket, srcObject, dstObject string) error {
+	src, dst := encodeDirObject(srcObject), encodeDirObject(dstObject)
+
+	disks := er.getDisks()
+	g := errgroup.WithNErrs(len(disks))
+	for index := range disks {
+		index := index
+		g.Go(func() error {
+			if disks[index] == nil {
+				return errDiskNotFound
+			}
+			return disks[index].RenameFile(ctx, bucket, retainSlash(src), bucket, retainSlash(dst))
+		}, index)
+	}
+	errs := g.Wait()
+	if err := reduceWriteQuorumErrs(ctx, errs, objectOpIgnoredErrs, er.defaultWQuorum()); err != nil {
+		// Undo the rename on the drives it succeeded on.
+		for index, rerr := range errs {
+			if rerr == nil {
+				logger.LogIf(ctx, disks[index].RenameFile(ctx, bucket, retainSlash(dst), bucket, retainSlash(src)))
+			}
+		}
+		return toObjectErr(err, bucket, srcObject)
+	}
+
+	var partial bool
+	for index, rerr := range errs {
+		if rerr == nil || rerr == errFileNotFound {
+			continue
+		}
+		partial = true
+		// Drop the stale source left on drives which missed the rename.
+		if disks[index] != nil {
+			disks[index].Delete(ctx, bucket, src, DeleteOptions{
+				Recursive: true,
+				Force:     true,
+			})
+		}
+	}
+	if partial {
+		er.addPartial(bucket, dstObject, "", -1)
+	}
+	return nil
+}
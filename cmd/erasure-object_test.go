@@ -1121,6 +1121,133 @@ func TestGetObjectInlineNotInline(t *testing.T) {
 	}
 }
 
+// TestGetObjectNInfoRawReader - plain objects are served from the data shards.
+func TestGetObjectNInfoRawReader(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	globalStorageClass = storageclass.Config{
+		Standard: storageclass.StorageClass{
+			Parity: 4,
+		},
+	}
+
+	bucket, object := "bucket", "object"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, MakeBucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	data := make([]byte, 5*blockSizeV2/2+17)
+	if _, err = crand.Read(data); err != nil {
+		t.Fatal(err)
+	}
+	_, err = obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gr, err := obj.GetObjectNInfo(ctx, bucket, object, nil, nil, readLock, ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr := gr.RawReader()
+	gr.Close()
+	if rr != nil {
+		t.Fatal("expected no raw reader without ZeroCopy")
+	}
+
+	testCases := []struct {
+		rs         *HTTPRangeSpec
+		start, end int
+	}{
+		{nil, 0, len(data)},
+		{&HTTPRangeSpec{Start: 3, End: 10}, 3, 11},
+		{&HTTPRangeSpec{Start: blockSizeV2 - 5, End: blockSizeV2 + 100}, blockSizeV2 - 5, blockSizeV2 + 101},
+		{&HTTPRangeSpec{IsSuffixLength: true, Start: -300}, len(data) - 300, len(data)},
+	}
+	for i, testCase := range testCases {
+		for _, writeTo := range []bool{false, true} {
+			gr, err := obj.GetObjectNInfo(ctx, bucket, object, testCase.rs, nil, readLock, ObjectOptions{ZeroCopy: true})
+			if err != nil {
+				t.Fatalf("Test %d: %v", i+1, err)
+			}
+			rr := gr.RawReader()
+			if rr == nil {
+				gr.Close()
+				t.Fatalf("Test %d: expected a raw reader for a plain object", i+1)
+			}
+			var got bytes.Buffer
+			if writeTo {
+				_, err = io.Copy(&got, rr)
+			} else {
+				_, err = got.ReadFrom(struct{ io.Reader }{rr})
+			}
+			gr.Close()
+			if err != nil {
+				t.Fatalf("Test %d: %v", i+1, err)
+			}
+			if !bytes.Equal(got.Bytes(), data[testCase.start:testCase.end]) {
+				t.Fatalf("Test %d: content mismatch, writeTo %v", i+1, writeTo)
+			}
+		}
+	}
+
+	// A corrupted data shard is detected and the rest of the object
+	// is decoded from the remaining shards.
+	er := obj.(*erasureServerPools).serverPools[0].sets[0]
+	fi, _, _, err := er.getObjectFileInfo(ctx, bucket, object, ObjectOptions{}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, index := range fi.Erasure.Distribution {
+		if index != 1 {
+			continue
+		}
+		partPath := pathJoin(fsDirs[i], bucket, object, fi.DataDir, "part.1")
+		f, err := os.OpenFile(partPath, os.O_RDWR, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Corrupt the first shard of the second block.
+		hashSize := int64(HighwayHash256S.New().Size())
+		shardSize := ceilFrac(fi.Erasure.BlockSize, int64(fi.Erasure.DataBlocks))
+		if _, err = f.WriteAt([]byte{0xff, 0xff, 0xff, 0xff}, shardSize+2*hashSize+10); err != nil {
+			t.Fatal(err)
+		}
+		f.Close()
+	}
+	for _, writeTo := range []bool{false, true} {
+		gr, err := obj.GetObjectNInfo(ctx, bucket, object, nil, nil, readLock, ObjectOptions{ZeroCopy: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		rr := gr.RawReader()
+		if rr == nil {
+			gr.Close()
+			t.Fatal("expected a raw reader for a plain object")
+		}
+		var got bytes.Buffer
+		if writeTo {
+			_, err = io.Copy(&got, rr)
+		} else {
+			_, err = got.ReadFrom(struct{ io.Reader }{rr})
+		}
+		gr.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got.Bytes(), data) {
+			t.Fatalf("content mismatch with a corrupted shard, writeTo %v", writeTo)
+		}
+	}
+}
+
 // Test reading an object with some outdated data in some disks
 func TestGetObjectWithOutdatedDisks(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
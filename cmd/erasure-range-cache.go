@@ -0,0 +1,210 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// rangeCacheBlockSize is the granularity at which small range
+// reads are cached, reads are always aligned to this size.
+const rangeCacheBlockSize = 128 << 10
+
+// globalRangeCache caches hot small range reads (e.g. parquet footers)
+// in memory, it is disabled by default and configured via the `api`
+// sub-system.
+var globalRangeCache = newRangeCache()
+
+// rangeCacheKey identifies a single block of an object version, since
+// the modTime and dataDir are part of the key any overwrite of the
+// object naturally invalidates previously cached blocks.
+type rangeCacheKey struct {
+	bucket    string
+	object    string
+	versionID string
+	dataDir   string
+	modTime   int64
+	block     int64
+}
+
+type rangeCacheEntry struct {
+	key  rangeCacheKey
+	data []byte
+}
+
+// rangeCache is a memory bounded LRU cache of object blocks.
+type rangeCache struct {
+	// Following fields are accessed atomically,
+	// keep them at the top for 64-bit alignment.
+	hits      uint64
+	misses    uint64
+	evictions uint64
+
+	mu       sync.Mutex
+	maxSize  int64
+	maxRange int64
+	size     int64
+	lru      *list.List
+	entries  map[rangeCacheKey]*list.Element
+}
+
+// rangeCacheStats is a point in time snapshot of the range cache.
+type rangeCacheStats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+	Size      int64
+	MaxSize   int64
+	Entries   int
+}
+
+func newRangeCache() *rangeCache {
+	return &rangeCache{
+		lru:     list.New(),
+		entries: make(map[rangeCacheKey]*list.Element),
+	}
+}
+
+// setLimits updates the memory bounds of the cache, shrinking the cache
+// evicts the least recently used blocks right away.
+func (c *rangeCache) setLimits(maxSize, maxRange int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.maxSize = maxSize
+	c.maxRange = maxRange
+	c.evictLocked()
+}
+
+// eligible returns true if a read of length bytes should be
+// served through the cache.
+func (c *rangeCache) eligible(length int64) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.maxSize > 0 && length > 0 && length <= c.maxRange
+}
+
+func (c *rangeCache) get(key rangeCacheKey) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		atomic.AddUint64(&c.misses, 1)
+		return nil, false
+	}
+	atomic.AddUint64(&c.hits, 1)
+	c.lru.MoveToFront(elem)
+	return elem.Value.(*rangeCacheEntry).data, true
+}
+
+func (c *rangeCache) add(key rangeCacheKey, data []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if int64(len(data)) > c.maxSize {
+		return
+	}
+	if elem, ok := c.entries[key]; ok {
+		c.lru.MoveToFront(elem)
+		return
+	}
+	c.entries[key] = c.lru.PushFront(&rangeCacheEntry{key: key, data: data})
+	c.size += int64(len(data))
+	c.evictLocked()
+}
+
+func (c *rangeCache) evictLocked() {
+	for c.size > c.maxSize {
+		elem := c.lru.Back()
+		if elem == nil {
+			return
+		}
+		entry := c.lru.Remove(elem).(*rangeCacheEntry)
+		delete(c.entries, entry.key)
+		c.size -= int64(len(entry.data))
+		atomic.AddUint64(&c.evictions, 1)
+	}
+}
+
+func (c *rangeCache) stats() rangeCacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return rangeCacheStats{
+		Hits:      atomic.LoadUint64(&c.hits),
+		Misses:    atomic.LoadUint64(&c.misses),
+		Evictions: atomic.LoadUint64(&c.evictions),
+		Size:      c.size,
+		MaxSize:   c.maxSize,
+		Entries:   len(c.entries),
+	}
+}
+
+// readCachedRange reads the requested range of an object via the range
+// cache, blocks not present in the cache are read from the drives and
+// cached for subsequent requests.
+func (er erasureObjects) readCachedRange(ctx context.Context, bucket, object string, offset, length int64, fi FileInfo, metaArr []FileInfo, onlineDisks []StorageAPI) ([]byte, error) {
+	if offset < 0 || length <= 0 || offset+length > fi.Size {
+		return nil, InvalidRange{offset, length, fi.Size}
+	}
+
+	buf := make([]byte, 0, length)
+	end := offset + length
+	for block := offset / rangeCacheBlockSize; block*rangeCacheBlockSize < end; block++ {
+		key := rangeCacheKey{
+			bucket:    bucket,
+			object:    object,
+			versionID: fi.VersionID,
+			dataDir:   fi.DataDir,
+			modTime:   fi.ModTime.UnixNano(),
+			block:     block,
+		}
+
+		blockStart := block * rangeCacheBlockSize
+		data, ok := globalRangeCache.get(key)
+		if !ok {
+			blockLength := int64(rangeCacheBlockSize)
+			if blockStart+blockLength > fi.Size {
+				blockLength = fi.Size - blockStart
+			}
+			var bb bytes.Buffer
+			bb.Grow(int(blockLength))
+			if err := er.getObjectWithFileInfo(ctx, bucket, object, blockStart, blockLength, &bb, fi, metaArr, onlineDisks); err != nil {
+				return nil, err
+			}
+			data = bb.Bytes()
+			globalRangeCache.add(key, data)
+		}
+
+		from, to := int64(0), int64(len(data))
+		if offset > blockStart {
+			from = offset - blockStart
+		}
+		if end < blockStart+to {
+			to = end - blockStart
+		}
+		buf = append(buf, data[from:to]...)
+	}
+	return buf, nil
+}
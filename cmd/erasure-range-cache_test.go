@@ -0,0 +1,118 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+func TestRangeCacheLRU(t *testing.T) {
+	c := newRangeCache()
+	c.setLimits(10, 5)
+
+	if c.eligible(6) {
+		t.Fatal("expected range larger than max range to be ineligible")
+	}
+	if !c.eligible(5) {
+		t.Fatal("expected range within max range to be eligible")
+	}
+
+	k1 := rangeCacheKey{bucket: "bucket", object: "object", block: 1}
+	k2 := rangeCacheKey{bucket: "bucket", object: "object", block: 2}
+	k3 := rangeCacheKey{bucket: "bucket", object: "object", block: 3}
+
+	c.add(k1, []byte("abcd"))
+	c.add(k2, []byte("efgh"))
+	// Touch k1 so that k2 becomes the least recently used.
+	if _, ok := c.get(k1); !ok {
+		t.Fatal("expected k1 to be cached")
+	}
+	c.add(k3, []byte("ijkl"))
+
+	if _, ok := c.get(k2); ok {
+		t.Fatal("expected k2 to be evicted")
+	}
+	if data, ok := c.get(k3); !ok || string(data) != "ijkl" {
+		t.Fatalf("unexpected k3 value %q", data)
+	}
+
+	st := c.stats()
+	if st.Size != 8 || st.Entries != 2 || st.Evictions != 1 {
+		t.Fatalf("unexpected stats %#v", st)
+	}
+
+	// Shrinking the cache evicts immediately.
+	c.setLimits(4, 4)
+	if st = c.stats(); st.Size != 4 || st.Entries != 1 {
+		t.Fatalf("unexpected stats after shrink %#v", st)
+	}
+
+	c.setLimits(0, 0)
+	if c.eligible(1) {
+		t.Fatal("expected disabled cache to be ineligible")
+	}
+}
+
+func TestRangeCacheErasureRead(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	defer globalRangeCache.setLimits(0, 0)
+	globalRangeCache.setLimits(4*rangeCacheBlockSize, 64<<10)
+
+	bucket, object := "bucket", "object"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, MakeBucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	data := bytes.Repeat([]byte("0123456789abcdef"), (3*rangeCacheBlockSize)/16)
+	if _, err = obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Range that straddles a block boundary.
+	offset, length := int64(rangeCacheBlockSize-100), int64(200)
+	for i := 0; i < 2; i++ {
+		rs := &HTTPRangeSpec{Start: offset, End: offset + length - 1}
+		gr, err := obj.GetObjectNInfo(ctx, bucket, object, rs, nil, readLock, ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var buf bytes.Buffer
+		if _, err = buf.ReadFrom(gr); err != nil {
+			t.Fatal(err)
+		}
+		gr.Close()
+		if !bytes.Equal(buf.Bytes(), data[offset:offset+length]) {
+			t.Fatalf("iteration %d: unexpected range data", i)
+		}
+	}
+
+	if st := globalRangeCache.stats(); st.Hits != 2 || st.Entries != 2 {
+		t.Fatalf("unexpected range cache stats %#v", st)
+	}
+}
@@ -0,0 +1,275 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/qkbyte/minio/internal/bucket/lifecycle"
+	"github.com/qkbyte/minio/internal/logger"
+)
+
+// Maximum number of divergent versions listed per kind in a decommission
+// verification report, all of them are counted.
+const decomVerifyMaxEntries = 10000
+
+// Reasons for a version left behind on a decommissioned pool.
+const (
+	decomVerifyMissing      = "missing"
+	decomVerifyMismatch     = "mismatch"
+	decomVerifyTransitioned = "transitioned"
+	decomVerifyExpired      = "expired"
+	decomVerifyDeleteMarker = "delete-marker"
+)
+
+// decomVerifyEntry - a version found on a pool after it was drained.
+type decomVerifyEntry struct {
+	Bucket    string    `json:"bucket"`
+	Object    string    `json:"object"`
+	VersionID string    `json:"versionId,omitempty"`
+	ModTime   time.Time `json:"modTime"`
+	Reason    string    `json:"reason"`
+	Size      int64     `json:"size"`
+	ETag      string    `json:"etag,omitempty"`
+	DestSize  int64     `json:"destSize,omitempty"`
+	DestETag  string    `json:"destEtag,omitempty"`
+}
+
+// decomVerifyReport - outcome of the verification of a drained pool,
+// written to .minio.sys/decommission/ for audit.
+type decomVerifyReport struct {
+	Pool     string    `json:"pool"`
+	Started  time.Time `json:"started"`
+	Finished time.Time `json:"finished"`
+
+	// Versions still on the pool which are also on the other pools.
+	Verified int64 `json:"verified"`
+
+	// Versions which are not or differently on the other pools, the
+	// decommission cannot complete while there are any.
+	MissingCount  int64              `json:"missingCount"`
+	MismatchCount int64              `json:"mismatchCount"`
+	Divergent     []decomVerifyEntry `json:"divergent,omitempty"`
+
+	// Versions intentionally not moved by the decommission.
+	SkippedCount int64              `json:"skippedCount"`
+	Skipped      []decomVerifyEntry `json:"skipped,omitempty"`
+}
+
+// diverged returns true if versions were missed or mismatched.
+func (r *decomVerifyReport) diverged() bool {
+	return r.MissingCount > 0 || r.MismatchCount > 0
+}
+
+func (r *decomVerifyReport) add(e decomVerifyEntry) {
+	switch e.Reason {
+	case decomVerifyMissing, decomVerifyMismatch:
+		if e.Reason == decomVerifyMissing {
+			r.MissingCount++
+		} else {
+			r.MismatchCount++
+		}
+		if len(r.Divergent) < decomVerifyMaxEntries {
+			r.Divergent = append(r.Divergent, e)
+		}
+	default:
+		r.SkippedCount++
+		if len(r.Skipped) < decomVerifyMaxEntries {
+			r.Skipped = append(r.Skipped, e)
+		}
+	}
+}
+
+// decomVerifyReportPath returns the path of the verification report of a
+// pool in the meta bucket.
+func decomVerifyReportPath(idx int) string {
+	return pathJoin("decommission", fmt.Sprintf("verify-pool-%d.json", idx+1))
+}
+
+// checkDecommissionedVersion compares a version left on the drained pool
+// with the same version on the other pools, reason is empty if it was
+// moved alright or was superseded since.
+func checkDecommissionedVersion(src, dst ObjectInfo, dstErr error) (reason string) {
+	if src.VersionID == "" && dst.ModTime.After(src.ModTime) {
+		// The null version was overwritten after it was moved.
+		return ""
+	}
+	if dstErr != nil {
+		if dst.DeleteMarker && src.DeleteMarker && dst.VersionID == src.VersionID {
+			return ""
+		}
+		return decomVerifyMissing
+	}
+	if dst.Size != src.Size || canonicalizeETag(dst.ETag) != canonicalizeETag(src.ETag) {
+		return decomVerifyMismatch
+	}
+	return ""
+}
+
+// verifyDecommission re-lists the decommissioned buckets of the drained
+// pool idx and looks up every version still present on it on the other
+// pools, versions missing or mismatching there are reported.
+func (z *erasureServerPools) verifyDecommission(ctx context.Context, idx int) (*decomVerifyReport, error) {
+	z.poolMetaMutex.RLock()
+	pool := z.poolMeta.Pools[idx]
+	var buckets []string
+	if pool.Decommission != nil {
+		buckets = append(buckets, pool.Decommission.DecommissionedBuckets...)
+	}
+	z.poolMetaMutex.RUnlock()
+
+	report := &decomVerifyReport{
+		Pool:    pool.CmdLine,
+		Started: UTCNow(),
+	}
+	var mu sync.Mutex
+
+	for _, b := range buckets {
+		bucket, prefix := path2BucketObject(b)
+		vc, _ := globalBucketVersioningSys.Get(bucket)
+		lc, _ := globalLifecycleSys.Get(bucket)
+		lr, _ := globalBucketObjectLockSys.Get(bucket)
+
+		verifyEntry := func(entry metaCacheEntry) {
+			if entry.isDir() {
+				return
+			}
+			fivs, err := entry.fileInfoVersions(bucket)
+			if err != nil {
+				return
+			}
+			for _, version := range fivs.Versions {
+				versioned := vc != nil && vc.Versioned(version.Name)
+				src := version.ToObjectInfo(bucket, version.Name, versioned)
+				e := decomVerifyEntry{
+					Bucket:    bucket,
+					Object:    version.Name,
+					VersionID: version.VersionID,
+					ModTime:   version.ModTime,
+					Size:      src.Size,
+					ETag:      src.ETag,
+				}
+				switch {
+				case version.IsRemote():
+					e.Reason = decomVerifyTransitioned
+				case lc != nil && evalActionFromLifecycle(ctx, *lc, lr, src, false) != lifecycle.NoneAction:
+					e.Reason = decomVerifyExpired
+				case version.Deleted && len(fivs.Versions) == 1:
+					e.Reason = decomVerifyDeleteMarker
+				default:
+					dst, dstErr := z.getDecommissionedVersion(ctx, idx, bucket, version)
+					if e.Reason = checkDecommissionedVersion(src, dst, dstErr); e.Reason == decomVerifyMismatch {
+						e.DestSize, e.DestETag = dst.Size, dst.ETag
+					}
+				}
+
+				mu.Lock()
+				if e.Reason == "" {
+					report.Verified++
+				} else {
+					report.add(e)
+				}
+				mu.Unlock()
+			}
+		}
+
+		for _, set := range z.serverPools[idx].sets {
+			disks := set.getOnlineDisks()
+			if len(disks) == 0 {
+				return nil, fmt.Errorf("no online drives found for set with endpoints %s", set.getEndpoints())
+			}
+			resolver := metadataResolutionParams{
+				dirQuorum: len(disks) / 2,
+				objQuorum: len(disks) / 2,
+				bucket:    bucket,
+			}
+			err := listPathRaw(ctx, listPathRawOptions{
+				disks:          disks,
+				bucket:         bucket,
+				path:           prefix,
+				recursive:      true,
+				minDisks:       len(disks) / 2,
+				reportNotFound: false,
+				agreed:         verifyEntry,
+				partial: func(entries metaCacheEntries, _ []error) {
+					if entry, ok := entries.resolve(&resolver); ok {
+						verifyEntry(*entry)
+					}
+				},
+			})
+			if err != nil && !isErrBucketNotFound(err) {
+				return nil, err
+			}
+		}
+	}
+
+	report.Finished = UTCNow()
+	return report, nil
+}
+
+// getDecommissionedVersion looks up version on the pools other than idx.
+func (z *erasureServerPools) getDecommissionedVersion(ctx context.Context, idx int, bucket string, version FileInfo) (ObjectInfo, error) {
+	opts := ObjectOptions{
+		VersionID:    version.VersionID,
+		NoDecryption: true,
+		NoLock:       true,
+	}
+	err := error(ObjectNotFound{Bucket: bucket, Object: version.Name})
+	var oi ObjectInfo
+	for i, pool := range z.serverPools {
+		if i == idx {
+			continue
+		}
+		oi, err = pool.GetObjectInfo(ctx, bucket, encodeDirObject(version.Name), opts)
+		if err == nil || oi.DeleteMarker {
+			return oi, err
+		}
+	}
+	return oi, err
+}
+
+// saveDecommissionVerifyReport writes the verification report of pool idx
+// to the meta bucket.
+func (z *erasureServerPools) saveDecommissionVerifyReport(ctx context.Context, idx int, report *decomVerifyReport) error {
+	data, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+	return saveConfig(ctx, z, decomVerifyReportPath(idx), data)
+}
+
+// verifyDecommissionInRoutine verifies the drained pool idx and saves the
+// report, returns false if the decommission must not complete.
+func (z *erasureServerPools) verifyDecommissionInRoutine(ctx context.Context, idx int) bool {
+	report, err := z.verifyDecommission(ctx, idx)
+	if err != nil {
+		logger.LogIf(ctx, fmt.Errorf("decommission: unable to verify pool %d: %w", idx+1, err))
+		return false
+	}
+	logger.LogIf(ctx, z.saveDecommissionVerifyReport(ctx, idx, report))
+	if report.diverged() {
+		logger.LogIf(ctx, fmt.Errorf("decommission: pool %d has %d missing and %d mismatched versions, see %s/%s",
+			idx+1, report.MissingCount, report.MismatchCount, minioMetaBucket, decomVerifyReportPath(idx)))
+		return false
+	}
+	return true
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCheckDecommissionedVersion(t *testing.T) {
+	now := time.Now()
+	src := ObjectInfo{Size: 10, ETag: "abc", ModTime: now}
+	errNotFound := errors.New("not found")
+	testCases := []struct {
+		src    ObjectInfo
+		dst    ObjectInfo
+		dstErr error
+		reason string
+	}{
+		{src, ObjectInfo{Size: 10, ETag: "\"abc\"", ModTime: now}, nil, ""},
+		{src, ObjectInfo{Size: 11, ETag: "abc", ModTime: now}, nil, decomVerifyMismatch},
+		{src, ObjectInfo{Size: 10, ETag: "abd", ModTime: now}, nil, decomVerifyMismatch},
+		{src, ObjectInfo{}, errNotFound, decomVerifyMissing},
+		// Overwritten null version.
+		{src, ObjectInfo{Size: 1, ETag: "xyz", ModTime: now.Add(time.Second)}, nil, ""},
+		// Versions are never superseded.
+		{ObjectInfo{VersionID: "v1", Size: 10, ETag: "abc"}, ObjectInfo{VersionID: "v1", Size: 1, ModTime: now}, nil, decomVerifyMismatch},
+		{
+			ObjectInfo{VersionID: "v1", DeleteMarker: true},
+			ObjectInfo{VersionID: "v1", DeleteMarker: true},
+			errNotFound, "",
+		},
+	}
+	for i, tc := range testCases {
+		if reason := checkDecommissionedVersion(tc.src, tc.dst, tc.dstErr); reason != tc.reason {
+			t.Errorf("case %d: expected %q, got %q", i+1, tc.reason, reason)
+		}
+	}
+}
+
+func TestVerifyDecommission(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	fsDirs, err := getRandomDisks(32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+
+	pools := mustGetPoolEndpoints(fsDirs[:16]...)
+	pools = append(pools, mustGetPoolEndpoints(fsDirs[16:]...)...)
+	objLayer, _, err := initObjectLayer(ctx, pools)
+	if err != nil {
+		t.Fatal(err)
+	}
+	setObjectLayer(objLayer)
+	defer setObjectLayer(nil)
+
+	bucket := getRandomBucketName()
+	if err = objLayer.MakeBucketWithLocation(ctx, bucket, MakeBucketOptions{}); err != nil {
+		t.Fatalf("Failed to make a bucket - %v", err)
+	}
+	defer func(sys *BucketMetadataSys) { globalBucketMetadataSys = sys }(globalBucketMetadataSys)
+	globalBucketMetadataSys = NewBucketMetadataSys()
+	globalBucketMetadataSys.Set(bucket, newBucketMetadata(bucket))
+
+	z := objLayer.(*erasureServerPools)
+	put := func(pool int, object string, data []byte) {
+		t.Helper()
+		if _, err := z.serverPools[pool].PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+			t.Fatalf("Failed to put %s - %v", object, err)
+		}
+	}
+	// Moved but not removed from the drained pool.
+	put(1, "moved", []byte("same"))
+	put(0, "moved", []byte("same"))
+	// Never moved.
+	put(1, "missing", []byte("lost"))
+	// Differs on the other pool, which is not newer.
+	put(0, "mismatch", []byte("two"))
+	put(1, "mismatch", []byte("one"))
+	// Overwritten since moved.
+	put(1, "overwritten", []byte("old"))
+	put(0, "overwritten", []byte("new"))
+
+	z.poolMeta = poolMeta{Pools: []PoolStatus{{}, {
+		CmdLine:      "pool2",
+		Decommission: &PoolDecommissionInfo{DecommissionedBuckets: []string{bucket}},
+	}}}
+
+	report, err := z.verifyDecommission(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.diverged() || report.Verified != 2 || report.MissingCount != 1 || report.MismatchCount != 1 {
+		t.Fatalf("unexpected report %+v", report)
+	}
+	for _, e := range report.Divergent {
+		if e.Object != e.Reason {
+			t.Errorf("expected %s to be reported as %s", e.Object, e.Reason)
+		}
+	}
+
+	if err = z.saveDecommissionVerifyReport(ctx, 1, report); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = readConfig(ctx, z, decomVerifyReportPath(1)); err != nil {
+		t.Fatalf("expected report to be saved - %v", err)
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"io"
 	"math/rand"
 	"net/http"
 	"sort"
@@ -116,6 +117,33 @@ type PoolStatus struct {
 	CmdLine      string                `json:"cmdline" msg:"cl"`
 	LastUpdate   time.Time             `json:"lastUpdate" msg:"lu"`
 	Decommission *PoolDecommissionInfo `json:"decommissionInfo,omitempty" msg:"dec"`
+	State        string                `json:"state,omitempty" msg:"stt"`
+}
+
+// Pool states settable by the administrator, an empty
+// state is the same as poolStateActive.
+const (
+	// poolStateActive accepts new writes.
+	poolStateActive = "active"
+	// poolStateReadOnly does not accept new object versions, overwrites
+	// of existing objects are placed on other pools.
+	poolStateReadOnly = "readonly"
+	// poolStateDraining is same as poolStateReadOnly, indicates that
+	// the pool is slated for decommission.
+	poolStateDraining = "draining"
+	// poolStateMaintenance is same as poolStateReadOnly, additionally
+	// deletes of objects present on the pool are refused.
+	poolStateMaintenance = "maintenance"
+)
+
+var errPoolUnderMaintenance = errors.New("pool is under maintenance")
+
+func isValidPoolState(state string) bool {
+	switch state {
+	case poolStateActive, poolStateReadOnly, poolStateDraining, poolStateMaintenance:
+		return true
+	}
+	return false
 }
 
 //go:generate msgp -file $GOFILE -unexported
@@ -287,6 +315,39 @@ func (p poolMeta) IsSuspended(idx int) bool {
 	return p.Pools[idx].Decommission != nil
 }
 
+// AcceptsWrites returns true if new object versions
+// may be written to the pool.
+func (p poolMeta) AcceptsWrites(idx int) bool {
+	if idx >= len(p.Pools) {
+		// poolMeta is not initialized for single pool setups.
+		return true
+	}
+	if p.IsSuspended(idx) {
+		return false
+	}
+	state := p.Pools[idx].State
+	return state == "" || state == poolStateActive
+}
+
+// InMaintenance returns true if the pool is under maintenance.
+func (p poolMeta) InMaintenance(idx int) bool {
+	return idx < len(p.Pools) && p.Pools[idx].State == poolStateMaintenance
+}
+
+// SetState sets the administrative state of the pool, returns
+// true if the state was changed.
+func (p *poolMeta) SetState(idx int, state string) bool {
+	if state == poolStateActive {
+		state = ""
+	}
+	if p.Pools[idx].State == state {
+		return false
+	}
+	p.Pools[idx].State = state
+	p.Pools[idx].LastUpdate = UTCNow()
+	return true
+}
+
 func (p *poolMeta) validate(pools []*erasureSets) (bool, error) {
 	type poolInfo struct {
 		position     int
@@ -533,6 +594,7 @@ func (z *erasureServerPools) Init(ctx context.Context) error {
 							// we already started decommission
 							case errDecommissionAlreadyRunning:
 								// A previous decommission running found restart it.
+								registerDecommissionJob(z, idx)
 								z.doDecommissionInRoutine(ctx, idx)
 								return
 							default:
@@ -545,6 +607,7 @@ func (z *erasureServerPools) Init(ctx context.Context) error {
 								return
 							}
 						}
+						registerDecommissionJob(z, idx)
 						break
 					}
 				}(pool)
@@ -554,6 +617,13 @@ func (z *erasureServerPools) Init(ctx context.Context) error {
 		return nil
 	}
 
+	// Remember administrative pool states across
+	// pool additions and removals.
+	states := make(map[string]string, len(meta.Pools))
+	for _, pool := range meta.Pools {
+		states[pool.CmdLine] = pool.State
+	}
+
 	meta = poolMeta{} // to update write poolMeta fresh.
 	// looks like new pool was added we need to update,
 	// or this is a fresh installation (or an existing
@@ -564,6 +634,7 @@ func (z *erasureServerPools) Init(ctx context.Context) error {
 			CmdLine:    pool.endpoints.CmdLine,
 			ID:         idx,
 			LastUpdate: UTCNow(),
+			State:      states[pool.endpoints.CmdLine],
 		})
 	}
 	if err = meta.save(ctx, z.serverPools); err != nil {
@@ -573,7 +644,7 @@ func (z *erasureServerPools) Init(ctx context.Context) error {
 	return nil
 }
 
-func (z *erasureServerPools) decommissionObject(ctx context.Context, bucket string, gr *GetObjectReader) (err error) {
+func (z *erasureServerPools) decommissionObject(ctx context.Context, set *erasureObjects, bucket string, gr *GetObjectReader) (err error) {
 	objInfo := gr.ObjInfo
 
 	defer func() {
@@ -596,11 +667,40 @@ func (z *erasureServerPools) decommissionObject(ctx context.Context, bucket stri
 			return fmt.Errorf("decommissionObject: NewMultipartUpload() %w", err)
 		}
 		defer z.AbortMultipartUpload(ctx, bucket, objInfo.Name, res.UploadID, ObjectOptions{})
+		// Parts are copied in parallel, the first one is read from gr
+		// and the others over their own streams.
+		offsets := make([]int64, len(objInfo.Parts))
+		for i := 1; i < len(objInfo.Parts); i++ {
+			offsets[i] = offsets[i-1] + objInfo.Parts[i-1].Size
+		}
 		parts := make([]CompletePart, len(objInfo.Parts))
-		for i, part := range objInfo.Parts {
-			hr, err := hash.NewReader(gr, part.Size, "", "", part.ActualSize)
+		err = globalTransferManager.transfer(ctx, len(objInfo.Parts), func(ctx context.Context, i int) (int64, error) {
+			part := objInfo.Parts[i]
+			r := io.Reader(gr)
+			switch {
+			case i == 0:
+			case part.Size == 0:
+				r = strings.NewReader("")
+			default:
+				pr, err := set.GetObjectNInfo(ctx,
+					bucket,
+					encodeDirObject(objInfo.Name),
+					&HTTPRangeSpec{Start: offsets[i], End: offsets[i] + part.Size - 1},
+					http.Header{},
+					noLock, // all mutations are blocked reads are safe without locks.
+					ObjectOptions{
+						VersionID:    objInfo.VersionID,
+						NoDecryption: true,
+					})
+				if err != nil {
+					return 0, fmt.Errorf("decommissionObject: GetObjectNInfo() %w", err)
+				}
+				defer pr.Close()
+				r = pr
+			}
+			hr, err := hash.NewReader(r, part.Size, "", "", part.ActualSize)
 			if err != nil {
-				return fmt.Errorf("decommissionObject: hash.NewReader() %w", err)
+				return 0, fmt.Errorf("decommissionObject: hash.NewReader() %w", err)
 			}
 			pi, err := z.PutObjectPart(ctx, bucket, objInfo.Name, res.UploadID,
 				part.Number,
@@ -612,7 +712,7 @@ func (z *erasureServerPools) decommissionObject(ctx context.Context, bucket stri
 					},
 				})
 			if err != nil {
-				return fmt.Errorf("decommissionObject: PutObjectPart() %w", err)
+				return 0, fmt.Errorf("decommissionObject: PutObjectPart() %w", err)
 			}
 			parts[i] = CompletePart{
 				ETag:           pi.ETag,
@@ -622,6 +722,10 @@ func (z *erasureServerPools) decommissionObject(ctx context.Context, bucket stri
 				ChecksumSHA256: pi.ChecksumSHA256,
 				ChecksumSHA1:   pi.ChecksumSHA1,
 			}
+			return part.Size, nil
+		})
+		if err != nil {
+			return err
 		}
 		_, err = z.CompleteMultipartUpload(ctx, bucket, objInfo.Name, res.UploadID, parts, ObjectOptions{
 			MTime: objInfo.ModTime,
@@ -806,7 +910,7 @@ func (z *erasureServerPools) decommissionPool(ctx context.Context, idx int, pool
 						continue
 					}
 					stopFn := globalDecommissionMetrics.log(decomMetricDecommissionObject, idx, bi.Name, version.Name, version.VersionID)
-					if err = z.decommissionObject(ctx, bi.Name, gr); err != nil {
+					if err = z.decommissionObject(ctx, set, bi.Name, gr); err != nil {
 						stopFn(err)
 						failure = true
 						logger.LogIf(ctx, err)
@@ -853,9 +957,10 @@ func (z *erasureServerPools) decommissionPool(ctx context.Context, idx int, pool
 
 		// How to resolve partial results.
 		resolver := metadataResolutionParams{
-			dirQuorum: len(disks) / 2, // make sure to capture all quorum ratios
-			objQuorum: len(disks) / 2, // make sure to capture all quorum ratios
-			bucket:    bi.Name,
+			dirQuorum:        len(disks) / 2, // make sure to capture all quorum ratios
+			objQuorum:        len(disks) / 2, // make sure to capture all quorum ratios
+			bucket:           bi.Name,
+			divergenceSource: divergenceSourceDecom,
 		}
 
 		wg.Add(1)
@@ -983,6 +1088,11 @@ func (z *erasureServerPools) doDecommissionInRoutine(ctx context.Context, idx in
 	failed := z.poolMeta.Pools[idx].Decommission.ItemsDecommissionFailed > 0
 	z.poolMetaMutex.Unlock()
 
+	if !failed {
+		// Verify nothing was left behind before the final cutoff.
+		failed = !z.verifyDecommissionInRoutine(dctx, idx)
+	}
+
 	if failed {
 		// Decommission failed indicate as such.
 		logger.LogIf(GlobalContext, z.DecommissionFailed(dctx, idx))
@@ -998,6 +1108,45 @@ func (z *erasureServerPools) IsSuspended(idx int) bool {
 	return z.poolMeta.IsSuspended(idx)
 }
 
+// AcceptsWrites returns true if new object versions may be
+// placed on the pool, i.e the pool is neither being decommissioned
+// nor set to a read-only state by the administrator.
+func (z *erasureServerPools) AcceptsWrites(idx int) bool {
+	z.poolMetaMutex.RLock()
+	defer z.poolMetaMutex.RUnlock()
+	return z.poolMeta.AcceptsWrites(idx)
+}
+
+// InMaintenance returns true if the pool is under maintenance.
+func (z *erasureServerPools) InMaintenance(idx int) bool {
+	z.poolMetaMutex.RLock()
+	defer z.poolMetaMutex.RUnlock()
+	return z.poolMeta.InMaintenance(idx)
+}
+
+// SetPoolState - sets the administrative state of a pool.
+func (z *erasureServerPools) SetPoolState(ctx context.Context, idx int, state string) (err error) {
+	if idx < 0 || idx >= len(z.serverPools) || !isValidPoolState(state) {
+		return errInvalidArgument
+	}
+
+	if z.SinglePool() && state != poolStateActive {
+		// A single pool cannot stop accepting writes.
+		return errInvalidArgument
+	}
+
+	z.poolMetaMutex.Lock()
+	defer z.poolMetaMutex.Unlock()
+
+	if z.poolMeta.SetState(idx, state) {
+		if err = z.poolMeta.save(ctx, z.serverPools); err != nil {
+			return err
+		}
+		globalNotificationSys.ReloadPoolMeta(ctx)
+	}
+	return nil
+}
+
 // Decommission - start decommission session.
 func (z *erasureServerPools) Decommission(ctx context.Context, idx int) error {
 	if idx < 0 {
@@ -618,6 +618,12 @@ func (z *PoolStatus) DecodeMsg(dc *msgp.Reader) (err error) {
 					return
 				}
 			}
+		case "stt":
+			z.State, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "State")
+				return
+			}
 		default:
 			err = dc.Skip()
 			if err != nil {
@@ -631,9 +637,9 @@ func (z *PoolStatus) DecodeMsg(dc *msgp.Reader) (err error) {
 
 // EncodeMsg implements msgp.Encodable
 func (z *PoolStatus) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 4
+	// map header, size 5
 	// write "id"
-	err = en.Append(0x84, 0xa2, 0x69, 0x64)
+	err = en.Append(0x85, 0xa2, 0x69, 0x64)
 	if err != nil {
 		return
 	}
@@ -679,15 +685,25 @@ func (z *PoolStatus) EncodeMsg(en *msgp.Writer) (err error) {
 			return
 		}
 	}
+	// write "stt"
+	err = en.Append(0xa3, 0x73, 0x74, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.State)
+	if err != nil {
+		err = msgp.WrapError(err, "State")
+		return
+	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
 func (z *PoolStatus) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 4
+	// map header, size 5
 	// string "id"
-	o = append(o, 0x84, 0xa2, 0x69, 0x64)
+	o = append(o, 0x85, 0xa2, 0x69, 0x64)
 	o = msgp.AppendInt(o, z.ID)
 	// string "cl"
 	o = append(o, 0xa2, 0x63, 0x6c)
@@ -706,6 +722,9 @@ func (z *PoolStatus) MarshalMsg(b []byte) (o []byte, err error) {
 			return
 		}
 	}
+	// string "stt"
+	o = append(o, 0xa3, 0x73, 0x74, 0x74)
+	o = msgp.AppendString(o, z.State)
 	return
 }
 
@@ -762,6 +781,12 @@ func (z *PoolStatus) UnmarshalMsg(bts []byte) (o []byte, err error) {
 					return
 				}
 			}
+		case "stt":
+			z.State, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "State")
+				return
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -782,6 +807,7 @@ func (z *PoolStatus) Msgsize() (s int) {
 	} else {
 		s += z.Decommission.Msgsize()
 	}
+	s += 4 + msgp.StringPrefixSize + len(z.State)
 	return
 }
 
@@ -194,3 +194,48 @@ func TestPoolMetaValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestPoolMetaState(t *testing.T) {
+	meta := poolMeta{
+		Version: poolMetaVersion,
+		Pools: []PoolStatus{
+			{CmdLine: "http://localhost:9000/data{1...4}", ID: 0},
+			{CmdLine: "http://localhost:9001/data{1...4}", ID: 1},
+		},
+	}
+
+	if !meta.AcceptsWrites(0) || !meta.AcceptsWrites(1) {
+		t.Fatal("expected all pools to accept writes")
+	}
+
+	if !meta.SetState(0, poolStateReadOnly) {
+		t.Fatal("expected state change")
+	}
+	if meta.SetState(0, poolStateReadOnly) {
+		t.Fatal("expected no state change")
+	}
+	if meta.AcceptsWrites(0) || meta.InMaintenance(0) {
+		t.Fatal("readonly pool must not accept writes")
+	}
+
+	meta.SetState(1, poolStateMaintenance)
+	if meta.AcceptsWrites(1) || !meta.InMaintenance(1) {
+		t.Fatal("pool under maintenance must not accept writes")
+	}
+
+	meta.SetState(0, poolStateActive)
+	if !meta.AcceptsWrites(0) || meta.Pools[0].State != "" {
+		t.Fatal("expected pool to accept writes after reactivation")
+	}
+
+	meta.Pools[0].Decommission = &PoolDecommissionInfo{}
+	if meta.AcceptsWrites(0) {
+		t.Fatal("decommissioning pool must not accept writes")
+	}
+
+	for _, state := range []string{"", "unknown", "READONLY"} {
+		if isValidPoolState(state) {
+			t.Fatalf("unexpected valid state %q", state)
+		}
+	}
+}
@@ -106,6 +106,15 @@ func newErasureServerPools(ctx context.Context, endpointServerPools EndpointServ
 			return nil, fmt.Errorf("All current serverPools should have same parity ratio - expected %d, got %d", commonParityDrives, ecDrivesNoConfig(ep.DrivesPerSet))
 		}
 
+		// Spread the drives of pools with topology labels across their failure
+		// domains, formatted pools keep the drive order they were formatted with.
+		if err = endpointServerPools.SpreadFailureDomains(i, commonParityDrives, func(spread Endpoints) (Endpoints, error) {
+			return waitForStoredDriveOrder(ep.Endpoints, spread)
+		}); err != nil {
+			return nil, err
+		}
+		ep = endpointServerPools[i]
+
 		storageDisks[i], formats[i], err = waitForFormatErasure(local, ep.Endpoints, i+1,
 			ep.SetCount, ep.DrivesPerSet, deploymentID, distributionAlgo)
 		if err != nil {
@@ -298,7 +307,7 @@ func (p serverPoolsAvailableSpace) FilterMaxUsed(max int) {
 // -1 is returned if no serverPools have available space for the size given.
 func (z *erasureServerPools) getAvailablePoolIdx(ctx context.Context, bucket, object string, size int64) int {
 	serverPools := z.getServerPoolsAvailableSpace(ctx, bucket, object, size)
-	serverPools.FilterMaxUsed(100 - (100 * diskReserveFraction))
+	serverPools.FilterMaxUsed(int(100 - globalAPIConfig.getDriveThresholds().ReservePercent))
 	total := serverPools.TotalAvailable()
 	if total == 0 {
 		return -1
@@ -329,8 +338,8 @@ func (z *erasureServerPools) getServerPoolsAvailableSpace(ctx context.Context, b
 	g := errgroup.WithNErrs(len(z.serverPools))
 	for index := range z.serverPools {
 		index := index
-		// skip suspended pools for any new I/O.
-		if z.IsSuspended(index) {
+		// skip suspended and read-only pools for any new I/O.
+		if !z.AcceptsWrites(index) {
 			continue
 		}
 		pool := z.serverPools[index]
@@ -347,7 +356,7 @@ func (z *erasureServerPools) getServerPoolsAvailableSpace(ctx context.Context, b
 
 	for i, zinfo := range storageInfos {
 		var available uint64
-		if !isMinioMetaBucketName(bucket) && !hasSpaceFor(zinfo, size) {
+		if !isMinioMetaBucketName(bucket) && checkSpaceFor(zinfo, size) != nil {
 			serverPools[i] = poolAvailableSpace{Index: i}
 			continue
 		}
@@ -423,9 +432,9 @@ func (z *erasureServerPools) getPoolInfoExistingWithOpts(ctx context.Context, bu
 	})
 
 	for _, pinfo := range poolObjInfos {
-		// skip all objects from suspended pools if asked by the
-		// caller.
-		if z.IsSuspended(pinfo.Index) && opts.SkipDecommissioned {
+		// skip all objects from suspended and read-only pools
+		// if asked by the caller.
+		if opts.SkipDecommissioned && !z.AcceptsWrites(pinfo.Index) {
 			continue
 		}
 
@@ -480,7 +489,9 @@ func (z *erasureServerPools) getPoolIdxNoLock(ctx context.Context, bucket, objec
 	if isErrObjectNotFound(err) {
 		idx = z.getAvailablePoolIdx(ctx, bucket, object, size)
 		if idx < 0 {
-			return -1, toObjectErr(errDiskFull)
+			err = StorageFull{Reason: "no pool has enough free space"}
+			sendStorageFullEvent(ctx, bucket, object, size, err)
+			return -1, err
 		}
 	}
 
@@ -499,7 +510,9 @@ func (z *erasureServerPools) getPoolIdx(ctx context.Context, bucket, object stri
 	if isErrObjectNotFound(err) {
 		idx = z.getAvailablePoolIdx(ctx, bucket, object, size)
 		if idx < 0 {
-			return -1, toObjectErr(errDiskFull)
+			err = StorageFull{Reason: "no pool has enough free space"}
+			sendStorageFullEvent(ctx, bucket, object, size, err)
+			return -1, err
 		}
 	}
 
@@ -958,8 +971,11 @@ func (z *erasureServerPools) PutObject(ctx context.Context, bucket string, objec
 	object = encodeDirObject(object)
 
 	if z.SinglePool() {
-		if !isMinioMetaBucketName(bucket) && !hasSpaceFor(getDiskInfos(ctx, z.serverPools[0].getHashedSet(object).getDisks()...), data.Size()) {
-			return ObjectInfo{}, toObjectErr(errDiskFull)
+		if !isMinioMetaBucketName(bucket) {
+			if err := checkSpaceFor(getDiskInfos(ctx, z.serverPools[0].getHashedSet(object).getDisks()...), data.Size()); err != nil {
+				sendStorageFullEvent(ctx, bucket, object, data.Size(), err)
+				return ObjectInfo{}, err
+			}
 		}
 		return z.serverPools[0].PutObject(ctx, bucket, object, data, opts)
 	}
@@ -974,6 +990,41 @@ func (z *erasureServerPools) PutObject(ctx context.Context, bucket string, objec
 		opts.NoLock = true
 	}
 
+	if opts.Append {
+		// Appends must land on the pool holding the object.
+		idx, err := z.getPoolIdxExistingWithOpts(ctx, bucket, object, ObjectOptions{NoLock: true})
+		if err == nil {
+			if !z.AcceptsWrites(idx) {
+				return ObjectInfo{}, NotImplemented{Message: "Append is not supported for objects on pools that do not accept writes"}
+			}
+			return z.serverPools[idx].PutObject(ctx, bucket, object, data, opts)
+		}
+		if !isErrObjectNotFound(err) {
+			return ObjectInfo{}, err
+		}
+	}
+
+	if opts.PlacementPool > 0 {
+		idx := opts.PlacementPool - 1
+		if idx >= len(z.serverPools) || !z.AcceptsWrites(idx) {
+			return ObjectInfo{}, fmt.Errorf("pool %d does not accept writes", opts.PlacementPool)
+		}
+		// Older versions on other pools would otherwise shadow the new one.
+		if pidx, err := z.getPoolIdxExistingWithOpts(ctx, bucket, object, ObjectOptions{NoLock: true}); err == nil && pidx != idx {
+			return ObjectInfo{}, fmt.Errorf("object already exists on pool %d", pidx+1)
+		}
+		// The placement pool is held to the same free space thresholds
+		// as pools selected by getAvailablePoolIdx.
+		serverPools := z.getServerPoolsAvailableSpace(ctx, bucket, object, data.Size())
+		serverPools.FilterMaxUsed(int(100 - globalAPIConfig.getDriveThresholds().ReservePercent))
+		if serverPools[idx].Available == 0 {
+			err := StorageFull{Reason: fmt.Sprintf("pool %d does not have enough free space", opts.PlacementPool)}
+			sendStorageFullEvent(ctx, bucket, object, data.Size(), err)
+			return ObjectInfo{}, err
+		}
+		return z.serverPools[idx].PutObject(ctx, bucket, object, data, opts)
+	}
+
 	idx, err := z.getPoolIdxNoLock(ctx, bucket, object, data.Size())
 	if err != nil {
 		return ObjectInfo{}, err
@@ -1005,13 +1056,15 @@ func (z *erasureServerPools) DeleteObject(ctx context.Context, bucket string, ob
 	object = encodeDirObject(object)
 
 	// Acquire a write lock before deleting the object.
-	lk := z.NewNSLock(bucket, object)
-	lkctx, err := lk.GetLock(ctx, globalDeleteOperationTimeout)
-	if err != nil {
-		return ObjectInfo{}, err
+	if !opts.NoLock {
+		lk := z.NewNSLock(bucket, object)
+		lkctx, err := lk.GetLock(ctx, globalDeleteOperationTimeout)
+		if err != nil {
+			return ObjectInfo{}, err
+		}
+		ctx = lkctx.Context()
+		defer lk.Unlock(lkctx.Cancel)
 	}
-	ctx = lkctx.Context()
-	defer lk.Unlock(lkctx.Cancel)
 
 	gopts := opts
 	gopts.NoLock = true
@@ -1030,6 +1083,10 @@ func (z *erasureServerPools) DeleteObject(ctx context.Context, bucket string, ob
 		return pinfo.ObjInfo, nil
 	}
 
+	if z.InMaintenance(pinfo.Index) {
+		return objInfo, errPoolUnderMaintenance
+	}
+
 	objInfo, err = z.serverPools[pinfo.Index].DeleteObject(ctx, bucket, object, opts)
 	objInfo.Name = decodeDirObject(object)
 	return objInfo, err
@@ -1099,6 +1156,13 @@ func (z *erasureServerPools) DeleteObjects(ctx context.Context, bucket string, o
 			}
 
 			idx := pinfo.Index
+			if z.InMaintenance(idx) {
+				derrs[j] = errPoolUnderMaintenance
+				dobjects[j] = DeletedObject{
+					ObjectName: obj.ObjectName,
+				}
+				return nil
+			}
 
 			mu.Lock()
 			defer mu.Unlock()
@@ -1247,7 +1311,7 @@ func (z *erasureServerPools) ListObjectVersions(ctx context.Context, bucket, pre
 		o.parseMarker()
 		merged.forwardPast(o.Marker)
 	}
-	objects := merged.fileInfoVersions(bucket, prefix, delimiter, versionMarker)
+	objects := merged.fileInfoVersions(bucket, prefix, delimiter, opts.Marker, versionMarker, opts.VersionMarkerTime)
 	loi.IsTruncated = err == nil && len(objects) > 0
 	if maxKeys > 0 && len(objects) > maxKeys {
 		objects = objects[:maxKeys]
@@ -1256,12 +1320,14 @@ func (z *erasureServerPools) ListObjectVersions(ctx context.Context, bucket, pre
 	for _, obj := range objects {
 		if obj.IsDir && obj.ModTime.IsZero() && delimiter != "" {
 			loi.Prefixes = append(loi.Prefixes, obj.Name)
-		} else {
+		} else if opts.Snapshot.IsZero() || !objectCreated(obj.UserDefined, obj.ModTime).After(opts.Snapshot) {
+			// Versions created after the listing started are not returned.
 			loi.Objects = append(loi.Objects, obj)
 		}
 	}
 	if loi.IsTruncated {
 		last := objects[len(objects)-1]
+		opts.VersionMarkerTime = last.ModTime
 		loi.NextMarker = opts.encodeMarker(last.Name)
 		loi.NextVersionIDMarker = last.VersionID
 	}
@@ -1326,6 +1392,9 @@ func (z *erasureServerPools) ListObjects(ctx context.Context, bucket, prefix, ma
 	merged.forwardPast(opts.Marker)
 	defer merged.truncate(0) // Release when returning
 
+	// Objects created after the listing started are not returned.
+	created := merged.createdAfter(opts.Snapshot)
+
 	// Default is recursive, if delimiter is set then list non recursive.
 	objects := merged.fileInfos(bucket, prefix, delimiter)
 	loi.IsTruncated = err == nil && len(objects) > 0
@@ -1336,7 +1405,7 @@ func (z *erasureServerPools) ListObjects(ctx context.Context, bucket, prefix, ma
 	for _, obj := range objects {
 		if obj.IsDir && obj.ModTime.IsZero() && delimiter != "" {
 			loi.Prefixes = append(loi.Prefixes, obj.Name)
-		} else {
+		} else if _, ok := created[obj.Name]; !ok {
 			loi.Objects = append(loi.Objects, obj)
 		}
 	}
@@ -1382,8 +1451,11 @@ func (z *erasureServerPools) NewMultipartUpload(ctx context.Context, bucket, obj
 	}
 
 	if z.SinglePool() {
-		if !isMinioMetaBucketName(bucket) && !hasSpaceFor(getDiskInfos(ctx, z.serverPools[0].getHashedSet(object).getDisks()...), -1) {
-			return nil, toObjectErr(errDiskFull)
+		if !isMinioMetaBucketName(bucket) {
+			if err := checkSpaceFor(getDiskInfos(ctx, z.serverPools[0].getHashedSet(object).getDisks()...), -1); err != nil {
+				sendStorageFullEvent(ctx, bucket, object, 0, err)
+				return nil, err
+			}
 		}
 		return z.serverPools[0].NewMultipartUpload(ctx, bucket, object, opts)
 	}
@@ -1805,10 +1877,16 @@ func (z *erasureServerPools) HealBucket(ctx context.Context, bucket string, opts
 		Bucket: bucket,
 	}
 
-	// Attempt heal on the bucket metadata, ignore any failures
-	hopts := opts
-	hopts.Recreate = false
-	defer z.HealObject(ctx, minioMetaBucket, pathJoin(bucketMetaPrefix, bucket, bucketMetadataFile), "", hopts)
+	// Attempt heal on the bucket config objects, reload the metadata
+	// everywhere when a stale copy was repaired since nodes might have
+	// loaded it.
+	defer func() {
+		repaired, err := healBucketMetadata(ctx, z, bucket, opts)
+		logger.LogIf(ctx, err)
+		if _, lerr := globalBucketMetadataSys.Get(bucket); repaired && lerr == nil {
+			reloadBucketMetadata(ctx, z, bucket)
+		}
+	}()
 
 	for _, pool := range z.serverPools {
 		result, err := pool.HealBucket(ctx, bucket, opts)
@@ -1887,9 +1965,10 @@ func (z *erasureServerPools) Walk(ctx context.Context, bucket, prefix string, re
 
 					// How to resolve partial results.
 					resolver := metadataResolutionParams{
-						dirQuorum: 1,
-						objQuorum: 1,
-						bucket:    bucket,
+						dirQuorum:        1,
+						objQuorum:        1,
+						bucket:           bucket,
+						divergenceSource: divergenceSourceListing,
 					}
 
 					path := baseDirFromPrefix(prefix)
@@ -1947,12 +2026,22 @@ func listAndHeal(ctx context.Context, bucket, prefix string, set *erasureObjects
 		return errors.New("listAndHeal: No non-healing drives found")
 	}
 
+	return listDisksAndHeal(ctx, bucket, prefix, disks, healEntry)
+}
+
+// listDisksAndHeal lists bucket/prefix recursively on disks and calls
+// healEntry for every entry found.
+func listDisksAndHeal(ctx context.Context, bucket, prefix string, disks []StorageAPI, healEntry func(metaCacheEntry) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
 	// How to resolve partial results.
 	resolver := metadataResolutionParams{
-		dirQuorum: 1,
-		objQuorum: 1,
-		bucket:    bucket,
-		strict:    false, // Allow less strict matching.
+		dirQuorum:        1,
+		objQuorum:        1,
+		bucket:           bucket,
+		strict:           false, // Allow less strict matching.
+		divergenceSource: divergenceSourceHeal,
 	}
 
 	path := baseDirFromPrefix(prefix)
@@ -2000,8 +2089,10 @@ func listAndHeal(ctx context.Context, bucket, prefix string, set *erasureObjects
 	return nil
 }
 
-func (z *erasureServerPools) HealObjects(ctx context.Context, bucket, prefix string, opts madmin.HealOpts, healObjectFn HealObjectFn) error {
-	healEntry := func(entry metaCacheEntry) error {
+// healObjectsEntry returns a listing callback that calls healObjectFn
+// for all versions of each object entry found in bucket.
+func healObjectsEntry(bucket string, healObjectFn HealObjectFn) func(metaCacheEntry) error {
+	return func(entry metaCacheEntry) error {
 		if entry.isDir() {
 			return nil
 		}
@@ -2036,6 +2127,10 @@ func (z *erasureServerPools) HealObjects(ctx context.Context, bucket, prefix str
 
 		return nil
 	}
+}
+
+func (z *erasureServerPools) HealObjects(ctx context.Context, bucket, prefix string, opts madmin.HealOpts, healObjectFn HealObjectFn) error {
+	healEntry := healObjectsEntry(bucket, healObjectFn)
 
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
@@ -2384,3 +2479,40 @@ func (z *erasureServerPools) RestoreTransitionedObject(ctx context.Context, buck
 
 	return z.serverPools[idx].RestoreTransitionedObject(ctx, bucket, object, opts)
 }
+
+// renameObject moves srcObject to dstObject without copying its data,
+// both objects need to be placed on the same erasure set.
+func (z *erasureServerPools) renameObject(ctx context.Context, bucket, srcObject, dstObject string) (ObjectInfo, error) {
+	if !z.SinglePool() {
+		return ObjectInfo{}, errRenameNotSupported
+	}
+	sets := z.serverPools[0]
+	src, dst := encodeDirObject(srcObject), encodeDirObject(dstObject)
+	if sets.getHashedSetIndex(src) != sets.getHashedSetIndex(dst) {
+		return ObjectInfo{}, errRenameNotSupported
+	}
+
+	lk := z.NewNSLock(bucket, src, dst)
+	lkctx, err := lk.GetLock(ctx, globalOperationTimeout)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	ctx = lkctx.Context()
+	defer lk.Unlock(lkctx.Cancel)
+
+	oi, err := z.GetObjectInfo(ctx, bucket, srcObject, ObjectOptions{NoLock: true})
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	if !canRenameObjectDir(oi) {
+		return ObjectInfo{}, errRenameNotSupported
+	}
+	if err = sets.getHashedSet(src).renameObjectDir(ctx, bucket, srcObject, dstObject); err != nil {
+		return ObjectInfo{}, err
+	}
+	NSUpdated(bucket, srcObject)
+	NSUpdated(bucket, dstObject)
+
+	oi.Name = dstObject
+	return oi, nil
+}
@@ -0,0 +1,266 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/klauspost/cpuid/v2"
+	"github.com/klauspost/reedsolomon"
+	iampolicy "github.com/minio/pkg/iam/policy"
+	"github.com/qkbyte/minio/internal/logger"
+)
+
+// Reed-Solomon acceleration paths.
+const (
+	erasureSIMDAuto    = "auto"
+	erasureSIMDAVX512  = "avx512"
+	erasureSIMDAVX2    = "avx2"
+	erasureSIMDSSSE3   = "ssse3"
+	erasureSIMDSSE2    = "sse2"
+	erasureSIMDNEON    = "neon"
+	erasureSIMDVSX     = "vsx"
+	erasureSIMDGeneric = "generic"
+)
+
+// amd64 acceleration paths, fastest first. Every CPU supporting a path
+// supports all paths after it.
+var erasureSIMDAMD64 = []string{
+	erasureSIMDAVX512,
+	erasureSIMDAVX2,
+	erasureSIMDSSSE3,
+	erasureSIMDSSE2,
+	erasureSIMDGeneric,
+}
+
+// erasureSIMDInfo is the Reed-Solomon acceleration path of a node.
+type erasureSIMDInfo struct {
+	Node     string `json:"node"`
+	Arch     string `json:"arch"`
+	CPU      string `json:"cpu,omitempty"`
+	Detected string `json:"detected"`
+	Active   string `json:"active"`
+	SlowPath bool   `json:"slowPath"`
+	Error    string `json:"error,omitempty"`
+}
+
+// detectErasureSIMD returns the fastest acceleration path supported
+// by the CPU on arch.
+func detectErasureSIMD(arch string, cpu cpuid.CPUInfo) string {
+	switch arch {
+	case "amd64":
+		switch {
+		case cpu.Supports(cpuid.AVX512F, cpuid.AVX512BW):
+			return erasureSIMDAVX512
+		case cpu.Supports(cpuid.AVX2):
+			return erasureSIMDAVX2
+		case cpu.Supports(cpuid.SSSE3):
+			return erasureSIMDSSSE3
+		case cpu.Supports(cpuid.SSE2):
+			return erasureSIMDSSE2
+		}
+	case "arm64":
+		return erasureSIMDNEON
+	case "ppc64le":
+		return erasureSIMDVSX
+	}
+	return erasureSIMDGeneric
+}
+
+func erasureSIMDRank(path string) int {
+	for i, p := range erasureSIMDAMD64 {
+		if p == path {
+			return i
+		}
+	}
+	return -1
+}
+
+// resolveErasureSIMD returns the path to use when override is
+// requested, paths can only be turned down from the detected one.
+// Only amd64 paths can be turned down, the encoder always uses the
+// NEON and VSX paths on arm64 and ppc64le.
+func resolveErasureSIMD(arch, detected, override string) (string, error) {
+	override = strings.ToLower(strings.TrimSpace(override))
+	switch override {
+	case "", erasureSIMDAuto, detected:
+		return detected, nil
+	}
+	if arch != "amd64" {
+		return "", fmt.Errorf("unknown erasure acceleration '%s' for %s, expected auto or %s", override, arch, detected)
+	}
+	rank := erasureSIMDRank(override)
+	if rank < 0 {
+		return "", fmt.Errorf("unknown erasure acceleration '%s' for %s, expected one of auto, %s", override, arch, strings.Join(erasureSIMDAMD64, ", "))
+	}
+	if rank < erasureSIMDRank(detected) {
+		return "", fmt.Errorf("erasure acceleration '%s' is not supported by this CPU, the fastest supported is '%s'", override, detected)
+	}
+	return override, nil
+}
+
+// erasureSIMDSlowPath returns true if path is considerably slower than
+// the paths used by current server CPUs.
+func erasureSIMDSlowPath(path string) bool {
+	if rank := erasureSIMDRank(path); rank >= 0 {
+		return rank > erasureSIMDRank(erasureSIMDAVX2)
+	}
+	return false
+}
+
+// newErasureSIMDInfo detects the acceleration path of this node and
+// applies the override from MINIO_ERASURE_SIMD.
+func newErasureSIMDInfo(override string) (erasureSIMDInfo, error) {
+	info := erasureSIMDInfo{
+		Arch:     runtime.GOARCH,
+		CPU:      cpuid.CPU.BrandName,
+		Detected: detectErasureSIMD(runtime.GOARCH, cpuid.CPU),
+	}
+	active, err := resolveErasureSIMD(info.Arch, info.Detected, override)
+	if err != nil {
+		return info, err
+	}
+	info.Active = active
+	info.SlowPath = erasureSIMDSlowPath(active)
+	return info, nil
+}
+
+// erasureSIMDOptions returns the encoder options restricting amd64
+// encoders to path, other architectures use a single path.
+func erasureSIMDOptions(path string) []reedsolomon.Option {
+	if runtime.GOARCH != "amd64" {
+		return nil
+	}
+	rank := erasureSIMDRank(path)
+	if rank < 0 {
+		return nil
+	}
+	enabled := func(p string) bool {
+		return erasureSIMDRank(p) >= rank
+	}
+	return []reedsolomon.Option{
+		reedsolomon.WithAVX512(enabled(erasureSIMDAVX512)),
+		reedsolomon.WithAVX2(enabled(erasureSIMDAVX2)),
+		reedsolomon.WithSSSE3(enabled(erasureSIMDSSSE3)),
+		reedsolomon.WithSSE2(enabled(erasureSIMDSSE2)),
+	}
+}
+
+// localErasureSIMD returns the acceleration path of this node.
+func localErasureSIMD() erasureSIMDInfo {
+	info := globalErasureSIMD
+	info.Node = globalLocalNodeName
+	return info
+}
+
+// erasureSIMDReport lists the acceleration path of every node.
+type erasureSIMDReport struct {
+	Nodes []erasureSIMDInfo `json:"nodes"`
+	// Mixed is true if nodes use different paths, erasure coding
+	// performance then depends on the node serving the request.
+	Mixed bool `json:"mixed"`
+	// SlowNodes is the number of nodes using a slow path.
+	SlowNodes int `json:"slowNodes"`
+}
+
+func newErasureSIMDReport(nodes []erasureSIMDInfo) erasureSIMDReport {
+	report := erasureSIMDReport{Nodes: nodes}
+	sort.Slice(report.Nodes, func(i, j int) bool {
+		return report.Nodes[i].Node < report.Nodes[j].Node
+	})
+	var active string
+	for _, node := range report.Nodes {
+		if node.Error != "" {
+			continue
+		}
+		if node.SlowPath {
+			report.SlowNodes++
+		}
+		if active != "" && node.Active != active {
+			report.Mixed = true
+		}
+		active = node.Active
+	}
+	return report
+}
+
+// GetErasureSIMD fetches the acceleration path of all peers, errors
+// are reported per peer.
+func (sys *NotificationSys) GetErasureSIMD(ctx context.Context) []erasureSIMDInfo {
+	infos := make([]erasureSIMDInfo, len(sys.peerClients))
+	var wg sync.WaitGroup
+	for index, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		wg.Add(1)
+		go func(index int, client *peerRESTClient) {
+			defer wg.Done()
+			info, err := client.GetErasureSIMD(ctx)
+			if err != nil {
+				info.Error = err.Error()
+			}
+			info.Node = client.host.String()
+			infos[index] = info
+		}(index, client)
+	}
+	wg.Wait()
+
+	result := infos[:0]
+	for _, info := range infos {
+		if info.Node != "" {
+			result = append(result, info)
+		}
+	}
+	return result
+}
+
+// ErasureSIMDHandler - GET /minio/admin/v3/erasure-simd
+// ----------
+// Returns the Reed-Solomon acceleration path used by every node, nodes
+// falling back to a slow path decode several times slower than others.
+func (a adminAPIHandlers) ErasureSIMDHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ErasureSIMD")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServerInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	nodes := []erasureSIMDInfo{localErasureSIMD()}
+	if globalNotificationSys != nil {
+		nodes = append(nodes, globalNotificationSys.GetErasureSIMD(ctx)...)
+	}
+
+	data, err := json.Marshal(newErasureSIMDReport(nodes))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/klauspost/cpuid/v2"
+)
+
+func TestDetectErasureSIMD(t *testing.T) {
+	var avx2 cpuid.CPUInfo
+	avx2.Enable(cpuid.SSE2, cpuid.SSSE3, cpuid.AVX2)
+	var avx512 cpuid.CPUInfo
+	avx512.Enable(cpuid.SSE2, cpuid.SSSE3, cpuid.AVX2, cpuid.AVX512F, cpuid.AVX512BW)
+	var sse2 cpuid.CPUInfo
+	sse2.Enable(cpuid.SSE2)
+
+	testCases := []struct {
+		arch string
+		cpu  cpuid.CPUInfo
+		want string
+	}{
+		{"amd64", avx512, erasureSIMDAVX512},
+		{"amd64", avx2, erasureSIMDAVX2},
+		{"amd64", sse2, erasureSIMDSSE2},
+		{"amd64", cpuid.CPUInfo{}, erasureSIMDGeneric},
+		{"arm64", cpuid.CPUInfo{}, erasureSIMDNEON},
+		{"s390x", cpuid.CPUInfo{}, erasureSIMDGeneric},
+	}
+	for i, tc := range testCases {
+		if got := detectErasureSIMD(tc.arch, tc.cpu); got != tc.want {
+			t.Errorf("Test %d: expected %s, got %s", i+1, tc.want, got)
+		}
+	}
+}
+
+func TestResolveErasureSIMD(t *testing.T) {
+	testCases := []struct {
+		arch, detected, override string
+		want                     string
+		slow                     bool
+		wantErr                  bool
+	}{
+		{"amd64", erasureSIMDAVX512, "", erasureSIMDAVX512, false, false},
+		{"amd64", erasureSIMDAVX512, "AVX2", erasureSIMDAVX2, false, false},
+		{"amd64", erasureSIMDAVX512, "ssse3", erasureSIMDSSSE3, true, false},
+		{"amd64", erasureSIMDAVX2, "avx512", "", false, true},
+		{"amd64", erasureSIMDAVX2, "neon", "", false, true},
+		{"amd64", erasureSIMDSSE2, "auto", erasureSIMDSSE2, true, false},
+		{"amd64", erasureSIMDAVX2, "generic", erasureSIMDGeneric, true, false},
+		{"arm64", erasureSIMDNEON, "neon", erasureSIMDNEON, false, false},
+		{"arm64", erasureSIMDNEON, "generic", "", false, true},
+		{"arm64", erasureSIMDNEON, "avx2", "", false, true},
+	}
+	for i, tc := range testCases {
+		got, err := resolveErasureSIMD(tc.arch, tc.detected, tc.override)
+		if (err != nil) != tc.wantErr {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if got != tc.want {
+			t.Errorf("Test %d: expected %s, got %s", i+1, tc.want, got)
+		}
+		if err == nil && erasureSIMDSlowPath(got) != tc.slow {
+			t.Errorf("Test %d: expected slow path %v for %s", i+1, tc.slow, got)
+		}
+	}
+}
+
+func TestErasureSIMDReport(t *testing.T) {
+	report := newErasureSIMDReport([]erasureSIMDInfo{
+		{Node: "node2", Active: erasureSIMDSSE2, SlowPath: true},
+		{Node: "node1", Active: erasureSIMDAVX2},
+		{Node: "node3", Error: "offline"},
+	})
+	if !report.Mixed || report.SlowNodes != 1 {
+		t.Fatalf("unexpected report %+v", report)
+	}
+	if report.Nodes[0].Node != "node1" {
+		t.Fatalf("expected nodes sorted, got %+v", report.Nodes)
+	}
+}
@@ -260,13 +260,7 @@ func (es *erasureSingle) LocalStorageInfo(ctx context.Context) (StorageInfo, []e
 
 // Clean-up previously deleted objects. from .minio.sys/tmp/.trash/
 func (es *erasureSingle) cleanupDeletedObjectsInner(ctx context.Context) {
-	diskPath := es.disk.Endpoint().Path
-	readDirFn(pathJoin(diskPath, minioMetaTmpDeletedBucket), func(ddir string, typ os.FileMode) error {
-		wait := es.deletedCleanupSleeper.Timer(ctx)
-		removeAll(pathJoin(diskPath, minioMetaTmpDeletedBucket, ddir))
-		wait()
-		return nil
-	})
+	globalTrashJanitor.cleanup(ctx, es.disk.Endpoint().Path, es.deletedCleanupSleeper)
 }
 
 func (es *erasureSingle) renameAll(ctx context.Context, bucket, prefix string) {
@@ -602,6 +596,26 @@ func (es *erasureSingle) GetObjectNInfo(ctx context.Context, bucket, object stri
 	if err != nil {
 		return nil, err
 	}
+
+	if opts.ZeroCopy {
+		decode := func(offset, length int64) io.ReadCloser {
+			pr, pw := xioutil.WaitPipe()
+			go func() {
+				pw.CloseWithError(es.getObjectWithFileInfo(ctx, bucket, object, offset, length, pw, fi, metaArr, onlineDisks))
+			}()
+			return pr
+		}
+		if raw := rawErasureReader(bucket, object, off, length, objInfo, fi, metaArr, onlineDisks, decode); raw != nil {
+			gr, err := fn(raw, h, func() { raw.Close() }, nsUnlocker)
+			if err != nil {
+				raw.Close()
+				return nil, err
+			}
+			unlockOnDefer = false
+			gr.raw = raw
+			return gr, nil
+		}
+	}
 	unlockOnDefer = false
 
 	pr, pw := xioutil.WaitPipe()
@@ -705,6 +719,13 @@ func (es *erasureSingle) getObjectWithFileInfo(ctx context.Context, bucket, obje
 		// we return from this function.
 		closeBitrotReaders(readers)
 		if err != nil {
+			// Without parity a missing or corrupted shard can not be
+			// recovered and is only reported as a read quorum error,
+			// queue the object for a verification so that the
+			// inconsistency is reported by the background heal.
+			if errors.Is(err, errErasureReadQuorum) || errors.Is(err, errFileNotFound) || errors.Is(err, errFileCorrupt) {
+				es.addPartial(bucket, object, fi.VersionID, fi.Size, madmin.HealDeepScan)
+			}
 			return toObjectErr(err, bucket, object)
 		}
 		for i, r := range readers {
@@ -960,6 +981,10 @@ func (es *erasureSingle) putMetacacheObject(ctx context.Context, key string, r *
 // writes `xl.meta` which carries the necessary metadata for future
 // object operations.
 func (es *erasureSingle) PutObject(ctx context.Context, bucket string, object string, data *PutObjReader, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	if opts.Append {
+		return ObjectInfo{}, NotImplemented{Message: "Append is not supported on single drive deployments"}
+	}
+
 	// Validate put object input args.
 	if err := checkPutObjectArgs(ctx, bucket, object, es); err != nil {
 		return ObjectInfo{}, err
@@ -967,8 +992,11 @@ func (es *erasureSingle) PutObject(ctx context.Context, bucket string, object st
 
 	object = encodeDirObject(object)
 
-	if !isMinioMetaBucketName(bucket) && !hasSpaceFor(getDiskInfos(ctx, es.disk), data.Size()) {
-		return ObjectInfo{}, toObjectErr(errDiskFull)
+	if !isMinioMetaBucketName(bucket) {
+		if err := checkSpaceFor(getDiskInfos(ctx, es.disk), data.Size()); err != nil {
+			sendStorageFullEvent(ctx, bucket, object, data.Size(), err)
+			return ObjectInfo{}, err
+		}
 	}
 
 	return es.putObject(ctx, bucket, object, data, opts)
@@ -1067,20 +1095,15 @@ func (es *erasureSingle) putObject(ctx context.Context, bucket string, object st
 	shardFileSize := erasure.ShardFileSize(data.Size())
 	writers := make([]io.Writer, len(onlineDisks))
 	var inlineBuffers []*bytes.Buffer
+	threshold := inlineThreshold(bucket, opts.UserDefined[xhttp.AmzStorageClass])
 	if shardFileSize >= 0 {
-		if !opts.Versioned && shardFileSize < smallFileThreshold {
-			inlineBuffers = make([]*bytes.Buffer, len(onlineDisks))
-		} else if shardFileSize < smallFileThreshold/8 {
+		if shouldInline(shardFileSize, threshold, opts.Versioned) {
 			inlineBuffers = make([]*bytes.Buffer, len(onlineDisks))
 		}
 	} else {
 		// If compressed, use actual size to determine.
-		if sz := erasure.ShardFileSize(data.ActualSize()); sz > 0 {
-			if !opts.Versioned && sz < smallFileThreshold {
-				inlineBuffers = make([]*bytes.Buffer, len(onlineDisks))
-			} else if sz < smallFileThreshold/8 {
-				inlineBuffers = make([]*bytes.Buffer, len(onlineDisks))
-			}
+		if sz := erasure.ShardFileSize(data.ActualSize()); sz > 0 && shouldInline(sz, threshold, opts.Versioned) {
+			inlineBuffers = make([]*bytes.Buffer, len(onlineDisks))
 		}
 	}
 	for i, disk := range onlineDisks {
@@ -1176,6 +1199,7 @@ func (es *erasureSingle) putObject(ctx context.Context, bucket string, object st
 	if opts.UserDefined["content-type"] == "" {
 		opts.UserDefined["content-type"] = mimedb.TypeByExtension(path.Ext(object))
 	}
+	setObjectCreated(opts.UserDefined, UTCNow())
 
 	// Fill all the necessary metadata.
 	// Update `xl.meta` content on each disks.
@@ -1435,13 +1459,15 @@ func (es *erasureSingle) DeleteObject(ctx context.Context, bucket, object string
 	}
 
 	// Acquire a write lock before deleting the object.
-	lk := es.NewNSLock(bucket, object)
-	lkctx, err := lk.GetLock(ctx, globalDeleteOperationTimeout)
-	if err != nil {
-		return ObjectInfo{}, err
+	if !opts.NoLock {
+		lk := es.NewNSLock(bucket, object)
+		lkctx, err := lk.GetLock(ctx, globalDeleteOperationTimeout)
+		if err != nil {
+			return ObjectInfo{}, err
+		}
+		ctx = lkctx.Context()
+		defer lk.Unlock(lkctx.Cancel)
 	}
-	ctx = lkctx.Context()
-	defer lk.Unlock(lkctx.Cancel)
 
 	versionFound := true
 	objInfo = ObjectInfo{VersionID: opts.VersionID} // version id needed in Delete API response.
@@ -2773,6 +2799,7 @@ func (es *erasureSingle) CompleteMultipartUpload(ctx context.Context, bucket str
 	if fi.Metadata["etag"] == "" {
 		fi.Metadata["etag"] = getCompleteMultipartMD5(parts)
 	}
+	setObjectCreated(fi.Metadata, UTCNow())
 
 	// Save the consolidated actual size.
 	fi.Metadata[ReservedMetadataPrefix+"actual-size"] = strconv.FormatInt(objectActualSize, 10)
@@ -2816,12 +2843,33 @@ func (es *erasureSingle) CompleteMultipartUpload(ctx context.Context, bucket str
 		}
 	}
 
+	// All parts but the last are at least 5MiB, so only uploads of a
+	// single part may be small enough to be inlined in xl.meta.
+	var inlined bool
+	threshold := inlineThreshold(bucket, fi.Metadata[xhttp.AmzStorageClass])
+	if len(fi.Parts) == 1 && shouldInline(fi.Erasure.ShardFileSize(fi.Parts[0].Size), threshold, opts.Versioned) {
+		if data := readMultipartInlineData(ctx, onlineDisks, uploadIDPath, fi); data != nil {
+			for index := range partsMetadata {
+				if onlineDisks[index] != nil && partsMetadata[index].IsValid() {
+					partsMetadata[index].Data = data[index]
+					partsMetadata[index].SetInlineData()
+				}
+			}
+			inlined = true
+		}
+	}
+
 	// Rename the multipart object to final location.
 	if onlineDisks, err = renameData(ctx, onlineDisks, minioMetaMultipartBucket, uploadIDPath,
 		partsMetadata, bucket, object, writeQuorum); err != nil {
 		return oi, toObjectErr(err, bucket, object)
 	}
 
+	if inlined {
+		// The part files are left behind by renameData().
+		es.renameAll(ctx, minioMetaMultipartBucket, uploadIDPath)
+	}
+
 	defer NSUpdated(bucket, object)
 
 	for i := 0; i < len(onlineDisks); i++ {
@@ -2921,6 +2969,9 @@ func (es *erasureSingle) ListObjects(ctx context.Context, bucket, prefix, marker
 	merged.forwardPast(opts.Marker)
 	defer merged.truncate(0) // Release when returning
 
+	// Objects created after the listing started are not returned.
+	created := merged.createdAfter(opts.Snapshot)
+
 	// Default is recursive, if delimiter is set then list non recursive.
 	objects := merged.fileInfos(bucket, prefix, delimiter)
 	loi.IsTruncated = err == nil && len(objects) > 0
@@ -2931,7 +2982,7 @@ func (es *erasureSingle) ListObjects(ctx context.Context, bucket, prefix, marker
 	for _, obj := range objects {
 		if obj.IsDir && obj.ModTime.IsZero() && delimiter != "" {
 			loi.Prefixes = append(loi.Prefixes, obj.Name)
-		} else {
+		} else if _, ok := created[obj.Name]; !ok {
 			loi.Objects = append(loi.Objects, obj)
 		}
 	}
@@ -2992,7 +3043,7 @@ func (es *erasureSingle) ListObjectVersions(ctx context.Context, bucket, prefix,
 		o.parseMarker()
 		merged.forwardPast(o.Marker)
 	}
-	objects := merged.fileInfoVersions(bucket, prefix, delimiter, versionMarker)
+	objects := merged.fileInfoVersions(bucket, prefix, delimiter, opts.Marker, versionMarker, opts.VersionMarkerTime)
 	loi.IsTruncated = err == nil && len(objects) > 0
 	if maxKeys > 0 && len(objects) > maxKeys {
 		objects = objects[:maxKeys]
@@ -3001,12 +3052,14 @@ func (es *erasureSingle) ListObjectVersions(ctx context.Context, bucket, prefix,
 	for _, obj := range objects {
 		if obj.IsDir && obj.ModTime.IsZero() && delimiter != "" {
 			loi.Prefixes = append(loi.Prefixes, obj.Name)
-		} else {
+		} else if opts.Snapshot.IsZero() || !objectCreated(obj.UserDefined, obj.ModTime).After(opts.Snapshot) {
+			// Versions created after the listing started are not returned.
 			loi.Objects = append(loi.Objects, obj)
 		}
 	}
 	if loi.IsTruncated {
 		last := objects[len(objects)-1]
+		opts.VersionMarkerTime = last.ModTime
 		loi.NextMarker = opts.encodeMarker(last.Name)
 		loi.NextVersionIDMarker = last.VersionID
 	}
@@ -3249,7 +3302,7 @@ func (es *erasureSingle) nsScanner(ctx context.Context, buckets []BucketInfo, bf
 					cache.Info.Name = bucket.Name
 				}
 				cache.Info.BloomFilter = bloom
-				cache.Info.SkipHealing = true
+				cache.Info.SkipHealing = false
 				cache.Info.NextCycle = wantCycle
 				if cache.Info.Name != bucket.Name {
 					logger.LogIf(ctx, fmt.Errorf("cache name mismatch: %s != %s", cache.Info.Name, bucket.Name))
@@ -3463,3 +3516,41 @@ func (es *erasureSingle) GetRawData(ctx context.Context, volume, file string, fn
 
 	return nil
 }
+
+// renameObjectDir moves the directory holding srcObject to dstObject,
+// no data is copied. The caller must hold the namespace locks of both
+// objects.
+func (es *erasureSingle) renameObjectDir(ctx context.Context, bucket, srcObject, dstObject string) error {
+	src, dst := encodeDirObject(srcObject), encodeDirObject(dstObject)
+	if err := es.disk.RenameFile(ctx, bucket, retainSlash(src), bucket, retainSlash(dst)); err != nil {
+		return toObjectErr(err, bucket, srcObject)
+	}
+	return nil
+}
+
+// renameObject moves srcObject to dstObject without copying its data.
+func (es *erasureSingle) renameObject(ctx context.Context, bucket, srcObject, dstObject string) (ObjectInfo, error) {
+	lk := es.NewNSLock(bucket, encodeDirObject(srcObject), encodeDirObject(dstObject))
+	lkctx, err := lk.GetLock(ctx, globalOperationTimeout)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	ctx = lkctx.Context()
+	defer lk.Unlock(lkctx.Cancel)
+
+	oi, err := es.GetObjectInfo(ctx, bucket, srcObject, ObjectOptions{NoLock: true})
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	if !canRenameObjectDir(oi) {
+		return ObjectInfo{}, errRenameNotSupported
+	}
+	if err = es.renameObjectDir(ctx, bucket, srcObject, dstObject); err != nil {
+		return ObjectInfo{}, err
+	}
+	NSUpdated(bucket, srcObject)
+	NSUpdated(bucket, dstObject)
+
+	oi.Name = dstObject
+	return oi, nil
+}
@@ -0,0 +1,156 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+
+	"github.com/minio/madmin-go"
+)
+
+// A single drive has no redundancy, healing in this mode verifies the
+// integrity of the namespace and reports any inconsistency found; the
+// data itself can not be reconstructed and is never modified.
+
+// HealFormat - there is only one format.json, nothing to heal.
+func (es *erasureSingle) HealFormat(ctx context.Context, dryRun bool) (madmin.HealResultItem, error) {
+	return madmin.HealResultItem{
+		Type:      madmin.HealItemMetadata,
+		Detail:    "disk-format",
+		DiskCount: 1,
+		SetCount:  1,
+	}, errNoHealRequired
+}
+
+// HealBucket - verifies that the bucket is present on the drive.
+func (es *erasureSingle) HealBucket(ctx context.Context, bucket string, opts madmin.HealOpts) (madmin.HealResultItem, error) {
+	result := madmin.HealResultItem{
+		Type:      madmin.HealItemBucket,
+		Bucket:    bucket,
+		DiskCount: 1,
+		SetCount:  1,
+	}
+
+	state := madmin.DriveStateOk
+	_, err := es.disk.StatVol(ctx, bucket)
+	switch {
+	case err == nil:
+	case errors.Is(err, errVolumeNotFound):
+		state = madmin.DriveStateMissing
+	case errors.Is(err, errDiskNotFound):
+		state = madmin.DriveStateOffline
+	default:
+		state = madmin.DriveStateCorrupt
+	}
+	drive := madmin.HealDriveInfo{Endpoint: es.endpoint.String(), State: state}
+	result.Before.Drives = []madmin.HealDriveInfo{drive}
+	result.After.Drives = []madmin.HealDriveInfo{drive}
+
+	return result, toObjectErr(err, bucket)
+}
+
+// HealObjects - verifies all objects under bucket/prefix by calling
+// healObjectFn on every object version found on the drive.
+func (es *erasureSingle) HealObjects(ctx context.Context, bucket, prefix string, opts madmin.HealOpts, healObjectFn HealObjectFn) error {
+	if _, err := es.disk.StatVol(ctx, bucket); err != nil {
+		return toObjectErr(err, bucket)
+	}
+	return listDisksAndHeal(ctx, bucket, prefix, []StorageAPI{es.disk}, healObjectsEntry(bucket, healObjectFn))
+}
+
+// HealObject - verifies the metadata and the parts of an object version,
+// with madmin.HealDeepScan the content of all parts is verified against
+// its bitrot checksums. An error is returned when the object is found
+// to be inconsistent.
+func (es *erasureSingle) HealObject(ctx context.Context, bucket, object, versionID string, opts madmin.HealOpts) (hr madmin.HealResultItem, err error) {
+	object = encodeDirObject(object)
+
+	hr = madmin.HealResultItem{
+		Type:      madmin.HealItemObject,
+		Bucket:    bucket,
+		Object:    decodeDirObject(object),
+		VersionID: versionID,
+		DiskCount: 1,
+		SetCount:  1,
+	}
+
+	if HasSuffix(object, SlashSeparator) {
+		hr, err = es.HealBucket(ctx, bucket, opts)
+		hr.Type = madmin.HealItemObject
+		hr.Object = decodeDirObject(object)
+		return hr, err
+	}
+
+	// When versionID is empty, we read directly from the `null` versionID for healing.
+	if versionID == "" {
+		versionID = nullVersionID
+	}
+
+	if !opts.NoLock {
+		lk := es.NewNSLock(bucket, object)
+		lkctx, err := lk.GetRLock(ctx, globalOperationTimeout)
+		if err != nil {
+			return hr, err
+		}
+		ctx = lkctx.Context()
+		defer lk.RUnlock(lkctx.Cancel)
+	}
+
+	disks := []StorageAPI{es.disk}
+	metaArr, errs := readAllFileInfo(ctx, disks, bucket, object, versionID, true)
+	dataErrs := errs
+	if errs[0] == nil {
+		fi := metaArr[0]
+		hr.ObjectSize = fi.Size
+		hr.DataBlocks = fi.Erasure.DataBlocks
+		hr.ParityBlocks = fi.Erasure.ParityBlocks
+		_, dataErrs, _ = disksWithAllParts(ctx, disks, metaArr, errs, fi, bucket, object, opts.ScanMode)
+	}
+
+	state := madmin.DriveStateOk
+	switch err = dataErrs[0]; {
+	case err == nil:
+	case errors.Is(err, errDiskNotFound):
+		state = madmin.DriveStateOffline
+	case errors.Is(err, errFileNotFound), errors.Is(err, errFileVersionNotFound), errors.Is(err, errVolumeNotFound):
+		if errs[0] != nil {
+			// Nothing to do, the object is already gone.
+			return hr, toObjectErr(err, bucket, object, versionID)
+		}
+		state = madmin.DriveStateMissing
+	default:
+		state = madmin.DriveStateCorrupt
+	}
+	drive := madmin.HealDriveInfo{Endpoint: es.endpoint.String(), State: state}
+	hr.Before.Drives = []madmin.HealDriveInfo{drive}
+	hr.After.Drives = []madmin.HealDriveInfo{drive}
+
+	return hr, toObjectErr(err, bucket, object, versionID)
+}
+
+// addPartial queues the object for a deferred integrity verification.
+func (es *erasureSingle) addPartial(bucket, object, versionID string, size int64, scanMode madmin.HealScanMode) {
+	globalMRFState.addPartialOp(partialOperation{
+		bucket:    bucket,
+		object:    object,
+		versionID: versionID,
+		size:      size,
+		scanMode:  scanMode,
+	})
+}
@@ -0,0 +1,148 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/minio/madmin-go"
+)
+
+func TestErasureSingleHealObject(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots([]string{fsDir})
+
+	if _, ok := obj.(*erasureSingle); !ok {
+		t.Skipf("expected a single drive object layer, got %T", obj)
+	}
+	initHealMRF(ctx, obj)
+
+	bucket, object := "bucket", "object"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, MakeBucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	// Large enough to not be inlined in xl.meta.
+	data := bytes.Repeat([]byte("a"), 1<<20)
+	if _, err = obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, mode := range []madmin.HealScanMode{madmin.HealNormalScan, madmin.HealDeepScan} {
+		hr, err := obj.HealObject(ctx, bucket, object, "", madmin.HealOpts{ScanMode: mode})
+		if err != nil {
+			t.Fatalf("scan mode %d: unexpected error %v", mode, err)
+		}
+		if len(hr.After.Drives) != 1 || hr.After.Drives[0].State != madmin.DriveStateOk {
+			t.Fatalf("scan mode %d: unexpected drives %#v", mode, hr.After.Drives)
+		}
+		if hr.ObjectSize != int64(len(data)) {
+			t.Fatalf("scan mode %d: expected size %d, got %d", mode, len(data), hr.ObjectSize)
+		}
+	}
+
+	if _, err = obj.HealObject(ctx, bucket, "missing", "", madmin.HealOpts{}); !isErrObjectNotFound(err) && !isErrVersionNotFound(err) {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+	if _, err = obj.HealBucket(ctx, bucket, madmin.HealOpts{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = obj.HealFormat(ctx, false); err != errNoHealRequired {
+		t.Fatalf("expected %v, got %v", errNoHealRequired, err)
+	}
+
+	var healed []string
+	err = obj.HealObjects(ctx, bucket, "", madmin.HealOpts{}, func(bucket, object, versionID string) error {
+		healed = append(healed, object)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(healed) != 1 || healed[0] != object {
+		t.Fatalf("unexpected objects listed for healing %v", healed)
+	}
+
+	// Flip the content of the part, only a deep scan detects it.
+	parts, err := filepath.Glob(filepath.Join(fsDir, bucket, object, "*", "part.1"))
+	if err != nil || len(parts) != 1 {
+		t.Fatalf("unable to find part file: %v %v", parts, err)
+	}
+	corrupt := bytes.Repeat([]byte("b"), 1<<20)
+	f, err := os.OpenFile(parts[0], os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = f.WriteAt(corrupt[:1024], 64); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	if _, err = obj.HealObject(ctx, bucket, object, "", madmin.HealOpts{ScanMode: madmin.HealNormalScan}); err != nil {
+		t.Fatalf("normal scan should not verify content, got %v", err)
+	}
+	hr, err := obj.HealObject(ctx, bucket, object, "", madmin.HealOpts{ScanMode: madmin.HealDeepScan})
+	if !errors.Is(err, errFileCorrupt) {
+		t.Fatalf("expected %v, got %v", errFileCorrupt, err)
+	}
+	if hr.After.Drives[0].State != madmin.DriveStateCorrupt {
+		t.Fatalf("expected corrupt drive state, got %s", hr.After.Drives[0].State)
+	}
+
+	// Reading the corrupted object queues it for verification.
+	r, err := obj.GetObjectNInfo(ctx, bucket, object, nil, nil, readLock, ObjectOptions{})
+	if err == nil {
+		_, err = io.Copy(io.Discard, r)
+		r.Close()
+	}
+	if err == nil {
+		t.Fatal("expected reading the corrupted object to fail")
+	}
+	op := partialOperation{
+		bucket:    bucket,
+		object:    object,
+		versionID: "",
+		size:      int64(len(data)),
+		scanMode:  madmin.HealDeepScan,
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		globalMRFState.mu.Lock()
+		_, found := globalMRFState.pendingOps[op]
+		globalMRFState.mu.Unlock()
+		if found {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected %#v to be queued in MRF", op)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
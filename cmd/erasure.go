@@ -22,7 +22,6 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
-	"os"
 	"runtime"
 	"sort"
 	"sync"
@@ -335,13 +334,7 @@ func (er erasureObjects) cleanupDeletedObjects(ctx context.Context) {
 			wg.Add(1)
 			go func(disk StorageAPI) {
 				defer wg.Done()
-				diskPath := disk.Endpoint().Path
-				readDirFn(pathJoin(diskPath, minioMetaTmpDeletedBucket), func(ddir string, typ os.FileMode) error {
-					wait := er.deletedCleanupSleeper.Timer(ctx)
-					removeAll(pathJoin(diskPath, minioMetaTmpDeletedBucket, ddir))
-					wait()
-					return nil
-				})
+				globalTrashJanitor.cleanup(ctx, disk.Endpoint().Path, er.deletedCleanupSleeper)
 			}(disk)
 		}
 	}
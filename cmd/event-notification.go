@@ -25,11 +25,13 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/minio/minio-go/v7/pkg/tags"
 	"github.com/minio/pkg/bucket/policy"
 	"github.com/qkbyte/minio/internal/crypto"
 	"github.com/qkbyte/minio/internal/event"
 	xhttp "github.com/qkbyte/minio/internal/http"
 	"github.com/qkbyte/minio/internal/logger"
+	"github.com/qkbyte/minio/internal/sync/errgroup"
 )
 
 // EventNotifier - notifies external systems about events in MinIO.
@@ -108,6 +110,9 @@ func (evnot *EventNotifier) InitBucketTargets(ctx context.Context, objAPI Object
 	if err := evnot.targetList.Add(globalConfigTargetList.Targets()...); err != nil {
 		return err
 	}
+	for _, target := range globalConfigTargetList.Targets() {
+		evnot.targetList.SetPayloadVersion(target.ID(), globalConfigTargetList.PayloadVersion(target.ID()))
+	}
 
 	go func() {
 		for e := range evnot.eventsQueue {
@@ -223,6 +228,46 @@ func (evnot *EventNotifier) Send(args eventArgs) {
 	}
 }
 
+// SendSync - delivers the event directly to the targets of the synchronous
+// queue configurations of the bucket and waits for the delivery, an error
+// is returned if the delivery to a target with the fail policy did not
+// succeed. Deliveries still pending are abandoned once ctx is canceled.
+func (evnot *EventNotifier) SendSync(ctx context.Context, args eventArgs) error {
+	meta, err := globalBucketMetadataSys.Get(args.BucketName)
+	if err != nil || meta.notificationConfig == nil {
+		return nil
+	}
+	queues := meta.notificationConfig.SyncQueues(args.EventName, args.Object.Name)
+	if len(queues) == 0 {
+		return nil
+	}
+
+	ev := args.ToEvent(true)
+	g := errgroup.WithNErrs(len(queues))
+	for idx, queue := range queues {
+		queue := queue
+		g.Go(func() error {
+			err := evnot.targetList.SendSync(ctx, ev, queue.ARN.TargetID, queue.Sync.TimeoutDuration())
+			if err == nil {
+				return nil
+			}
+			reqInfo := &logger.ReqInfo{}
+			reqInfo.AppendTags("targetID", queue.ARN.TargetID.Name)
+			logger.LogOnceIf(logger.SetReqInfo(GlobalContext, reqInfo), err, queue.ARN.TargetID.String())
+			if queue.Sync.FailRequest() {
+				return err
+			}
+			return nil
+		}, idx)
+	}
+	for _, err := range g.Wait() {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (evnot *EventNotifier) send(args eventArgs) {
 	evnot.RLock()
 	targetIDSet := evnot.bucketRulesMap[args.BucketName].Match(args.EventName, args.Object.Name)
@@ -310,28 +355,59 @@ func (args eventArgs) ToEvent(escape bool) event.Event {
 			}
 			newEvent.S3.Object.UserMetadata[k] = v
 		}
+		// Enriched fields, only delivered to targets configured
+		// with payload version '2'.
+		if t, err := tags.ParseObjectTags(args.Object.UserTags); err == nil && args.Object.UserTags != "" {
+			newEvent.S3.Object.Tags = t.ToMap()
+		}
+		newEvent.S3.Object.StorageClass = args.Object.StorageClass
+		newEvent.S3.Object.ReplicationStatus = string(args.Object.ReplicationStatus)
 	}
 
 	return newEvent
 }
 
-func sendEvent(args eventArgs) {
+// prepare prepares the event arguments to be sent, it
+// returns false if no event is to be sent.
+func (args *eventArgs) prepare() bool {
 	args.Object.Size, _ = args.Object.GetActualSize()
 
 	// avoid generating a notification for REPLICA creation event.
 	if _, ok := args.ReqParams[xhttp.MinIOSourceReplicationRequest]; ok {
-		return
+		return false
 	}
 	// remove sensitive encryption entries in metadata.
 	crypto.RemoveSensitiveEntries(args.Object.UserDefined)
 	crypto.RemoveInternalEntries(args.Object.UserDefined)
 
 	// globalNotificationSys is not initialized in gateway mode.
-	if globalNotificationSys == nil {
+	return globalNotificationSys != nil
+}
+
+// sendEventSync delivers the event to the synchronous notification
+// targets of the bucket before the response to the request is written.
+// The object is already committed at this point, an error only means
+// the request is answered with ErrSyncNotificationFailed while the
+// object persists.
+func sendEventSync(ctx context.Context, args eventArgs) error {
+	if !args.prepare() {
+		return nil
+	}
+	return globalEventNotifier.SendSync(ctx, args)
+}
+
+func sendEvent(args eventArgs) {
+	// Replicas are indexed as well, hence before prepare.
+	globalMetadataIndexer.enqueue(args)
+	globalTagIndexer.record(args)
+	globalChangesFeed.record(args)
+	globalAuditManifest.record(args)
+
+	if !args.prepare() {
 		return
 	}
 	if globalHTTPListen.NumSubscribers(args.EventName) > 0 {
-		globalHTTPListen.Publish(args.ToEvent(false))
+		globalHTTPListen.Publish(args.ToEvent(false).WithPayloadVersion(event.PayloadV1))
 	}
 
 	globalEventNotifier.Send(args)
@@ -26,6 +26,7 @@ import (
 	"os"
 	"reflect"
 	"sync"
+	"time"
 
 	"github.com/dustin/go-humanize"
 	"github.com/qkbyte/minio/internal/color"
@@ -123,6 +124,17 @@ type formatErasureV3 struct {
 		// to pick the right set index for an object.
 		DistributionAlgo string `json:"distributionAlgo"`
 	} `json:"xl"`
+	// Drive carries the identity of the physical drive this
+	// format.json was first used on, this field is specific
+	// to each drive and is never carried over to other drives.
+	Drive *formatDriveIdentity `json:"drive,omitempty"`
+}
+
+// formatDriveIdentity identifies the physical drive a format.json
+// was recorded on.
+type formatDriveIdentity struct {
+	Serial   string    `json:"serial"`
+	Recorded time.Time `json:"recorded"`
 }
 
 func (f *formatErasureV3) Drives() (drives int) {
@@ -591,6 +603,7 @@ func getFormatErasureInQuorum(formats []*formatErasureV3) (*formatErasureV3, err
 		if format.Drives() == maxDrives {
 			format := formats[i].Clone()
 			format.Erasure.This = ""
+			format.Drive = nil
 			return format, nil
 		}
 	}
@@ -325,6 +325,11 @@ func fsCreateFile(ctx context.Context, filePath string, reader io.Reader, falloc
 		return 0, err
 	}
 
+	if w, err := fsTmpFile.open(pathutil.Dir(filePath), false); err == nil {
+		defer w.Close()
+		return fsCreateTmpFile(ctx, w, filePath, reader)
+	}
+
 	flags := os.O_CREATE | os.O_WRONLY
 	if globalFSOSync {
 		flags |= os.O_SYNC
@@ -344,6 +349,33 @@ func fsCreateFile(ctx context.Context, filePath string, reader io.Reader, falloc
 	return bytesWritten, nil
 }
 
+// fsTmpFile writes the files created by fsCreateFile unnamed
+// when the backend filesystem supports it.
+var fsTmpFile tmpFile
+
+// fsCreateTmpFile copies the incoming reader into the unnamed file w
+// and links it at filePath once all the data is written.
+func fsCreateTmpFile(ctx context.Context, w *os.File, filePath string, reader io.Reader) (int64, error) {
+	bytesWritten, err := xioutil.Copy(w, reader)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return 0, err
+	}
+
+	if globalFSOSync {
+		if err = w.Sync(); err != nil {
+			logger.LogIf(ctx, err)
+			return 0, err
+		}
+	}
+
+	if err = fsTmpFile.link(w, filePath, true); err != nil {
+		logger.LogIf(ctx, err)
+		return 0, err
+	}
+	return bytesWritten, nil
+}
+
 // Renames source path to destination path, creates all the
 // missing parents if they don't exist.
 func fsRenameFile(ctx context.Context, sourcePath, destPath string) error {
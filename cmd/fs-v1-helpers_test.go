@@ -23,6 +23,7 @@ import (
 	"os"
 	"path"
 	"testing"
+	"testing/iotest"
 
 	"github.com/qkbyte/minio/internal/lock"
 )
@@ -547,3 +548,47 @@ func TestFSIsFile(t *testing.T) {
 		t.Fatalf("Expected %s to be a file", filePath)
 	}
 }
+
+func TestFSCreateFileUnnamed(t *testing.T) {
+	_, path, err := newXLStorageTestSetup(t)
+	if err != nil {
+		t.Fatalf("Unable to create xlStorage test setup, %s", err)
+	}
+
+	f, err := fsTmpFile.open(path, false)
+	if err != nil {
+		t.Skip("O_TMPFILE is not supported:", err)
+	}
+	f.Close()
+
+	filePath := pathJoin(path, "vol", "file")
+	for _, content := range []string{"Hello, world", "Bye"} {
+		if _, err = fsCreateFile(GlobalContext, filePath, bytes.NewReader([]byte(content)), 0); err != nil {
+			t.Fatalf("Unable to create file, %s", err)
+		}
+		b, err := os.ReadFile(filePath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != content {
+			t.Fatalf("Expected %q, got %q", content, string(b))
+		}
+	}
+
+	// A failed write must not leave a partial file behind.
+	partialPath := pathJoin(path, "vol", "partial")
+	reader := io.MultiReader(bytes.NewReader([]byte("partial")), iotest.ErrReader(io.ErrUnexpectedEOF))
+	if _, err = fsCreateFile(GlobalContext, partialPath, reader, 0); err == nil {
+		t.Fatal("Expected an error on a failed reader")
+	}
+	if _, err = os.Stat(partialPath); !os.IsNotExist(err) {
+		t.Fatalf("Expected no file to be created, got %v", err)
+	}
+	entries, err := os.ReadDir(pathJoin(path, "vol"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("Expected only the created file, got %d entries", len(entries))
+	}
+}
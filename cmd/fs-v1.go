@@ -39,6 +39,7 @@ import (
 	"github.com/minio/pkg/mimedb"
 	"github.com/qkbyte/minio/internal/color"
 	"github.com/qkbyte/minio/internal/config"
+	"github.com/qkbyte/minio/internal/crypto"
 	xhttp "github.com/qkbyte/minio/internal/http"
 	xioutil "github.com/qkbyte/minio/internal/ioutil"
 	"github.com/qkbyte/minio/internal/lock"
@@ -813,7 +814,19 @@ func (fs *FSObjects) GetObjectNInfo(ctx context.Context, bucket, object string,
 		return nil, err
 	}
 
-	return objReaderFn(reader, h, closeFn, rwPoolUnlocker, nsUnlocker)
+	gr, err = objReaderFn(reader, h, closeFn, rwPoolUnlocker, nsUnlocker)
+	if err != nil {
+		return nil, err
+	}
+
+	// Plain objects are stored verbatim, allow the caller to
+	// serve them straight from the file.
+	if _, encrypted := crypto.IsEncrypted(objInfo.UserDefined); !encrypted && !objInfo.IsCompressed() {
+		if f, ok := readCloser.(*os.File); ok {
+			gr.raw = newRawFileReader(f, off, length)
+		}
+	}
+	return gr, nil
 }
 
 // Create a new fs.json file, if the existing one is corrupt. Should happen very rarely.
@@ -1013,7 +1026,7 @@ func (fs *FSObjects) GetObjectInfo(ctx context.Context, bucket, object string, o
 // Additionally writes `fs.json` which carries the necessary metadata
 // for future object operations.
 func (fs *FSObjects) PutObject(ctx context.Context, bucket string, object string, r *PutObjReader, opts ObjectOptions) (objInfo ObjectInfo, err error) {
-	if opts.Versioned {
+	if opts.Versioned || opts.Append {
 		return objInfo, NotImplemented{}
 	}
 
@@ -1188,13 +1201,15 @@ func (fs *FSObjects) DeleteObject(ctx context.Context, bucket, object string, op
 	defer NSUpdated(bucket, object)
 
 	// Acquire a write lock before deleting the object.
-	lk := fs.NewNSLock(bucket, object)
-	lkctx, err := lk.GetLock(ctx, globalOperationTimeout)
-	if err != nil {
-		return objInfo, err
+	if !opts.NoLock {
+		lk := fs.NewNSLock(bucket, object)
+		lkctx, err := lk.GetLock(ctx, globalOperationTimeout)
+		if err != nil {
+			return objInfo, err
+		}
+		ctx = lkctx.Context()
+		defer lk.Unlock(lkctx.Cancel)
 	}
-	ctx = lkctx.Context()
-	defer lk.Unlock(lkctx.Cancel)
 
 	if err = checkDelObjArgs(ctx, bucket, object); err != nil {
 		return objInfo, err
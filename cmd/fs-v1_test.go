@@ -19,6 +19,8 @@ package cmd
 
 import (
 	"bytes"
+	"context"
+	"io"
 	"os"
 	"path/filepath"
 	"testing"
@@ -167,6 +169,67 @@ func TestFSPutObject(t *testing.T) {
 	}
 }
 
+// TestFSGetObjectNInfoRawReader - plain objects expose the backing file.
+func TestFSGetObjectNInfoRawReader(t *testing.T) {
+	disk := filepath.Join(globalTestTmpDir, "minio-"+nextSuffix())
+	defer os.RemoveAll(disk)
+
+	// A fresh drive is formatted as erasure, create an FS format.json upfront.
+	if err := os.MkdirAll(pathJoin(disk, minioMetaBucket), 0o777); err != nil {
+		t.Fatal(err)
+	}
+	if err := createFormatFS(pathJoin(disk, minioMetaBucket, formatConfigFile)); err != nil {
+		t.Fatal(err)
+	}
+	obj, err := NewFSObjectLayer(disk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	newTestConfig(globalMinioDefaultRegion, obj)
+	initAllSubsystems()
+
+	bucketName := "bucket"
+	objectName := "object"
+
+	if err := obj.MakeBucketWithLocation(GlobalContext, bucketName, MakeBucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	data := []byte("abcdefgh")
+	_, err = obj.PutObject(GlobalContext, bucketName, objectName, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		rs       *HTTPRangeSpec
+		expected string
+	}{
+		{nil, "abcdefgh"},
+		{&HTTPRangeSpec{Start: 2, End: 4}, "cde"},
+		{&HTTPRangeSpec{IsSuffixLength: true, Start: -2}, "gh"},
+	}
+	for i, testCase := range testCases {
+		gr, err := obj.GetObjectNInfo(GlobalContext, bucketName, objectName, testCase.rs, nil, readLock, ObjectOptions{})
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		rr := gr.RawReader()
+		if rr == nil {
+			gr.Close()
+			t.Fatalf("Test %d: expected a raw reader for a plain object", i+1)
+		}
+		got, err := io.ReadAll(rr)
+		gr.Close()
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		if string(got) != testCase.expected {
+			t.Fatalf("Test %d: expected %q, got %q", i+1, testCase.expected, string(got))
+		}
+	}
+}
+
 // TestFSDeleteObject - test fs.DeleteObject() with healthy and corrupted disks
 func TestFSDeleteObject(t *testing.T) {
 	t.Skip()
@@ -303,19 +366,19 @@ func TestFSHealObject(t *testing.T) {
 
 	obj := initFSObjects(disk, t)
 	_, err := obj.HealObject(GlobalContext, "bucket", "object", "", madmin.HealOpts{})
-	if err == nil || !isSameType(err, NotImplemented{}) {
-		t.Fatalf("Heal Object should return NotImplemented error ")
+	if err == nil || isSameType(err, NotImplemented{}) {
+		t.Fatalf("Heal Object should return an error for a missing object, got %v", err)
 	}
 }
 
-// TestFSHealObjects - tests for fs HealObjects to return not implemented.
+// TestFSHealObjects - tests for fs HealObjects on a missing bucket.
 func TestFSHealObjects(t *testing.T) {
 	disk := filepath.Join(globalTestTmpDir, "minio-"+nextSuffix())
 	defer os.RemoveAll(disk)
 
 	obj := initFSObjects(disk, t)
 	err := obj.HealObjects(GlobalContext, "bucket", "prefix", madmin.HealOpts{}, nil)
-	if err == nil || !isSameType(err, NotImplemented{}) {
-		t.Fatalf("Heal Object should return NotImplemented error ")
+	if err == nil || isSameType(err, NotImplemented{}) {
+		t.Fatalf("Heal Objects should return an error for a missing bucket, got %v", err)
 	}
 }
@@ -259,7 +259,7 @@ func StartGateway(ctx *cli.Context, gw Gateway) {
 	}
 
 	httpServer := xhttp.NewServer(addrs).
-		UseHandler(setCriticalErrorHandler(corsHandler(router))).
+		UseHandler(setCriticalErrorHandler(corsHandler(bucketWebsiteHandler(router)))).
 		UseTLSConfig(newTLSConfig(getCert)).
 		UseShutdownTimeout(ctx.Duration("shutdown-timeout")).
 		UseBaseContext(GlobalContext).
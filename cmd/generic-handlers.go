@@ -251,6 +251,12 @@ func isKMSReq(r *http.Request) bool {
 	return strings.HasPrefix(r.URL.Path, kmsPathPrefix)
 }
 
+// Check to allow access to the reserved "bucket" `/minio` for SCIM
+// provisioning API requests.
+func isSCIMReq(r *http.Request) bool {
+	return strings.HasPrefix(r.URL.Path, scimPathPrefix+SlashSeparator)
+}
+
 // Supported Amz date headers.
 var amzDateHeaders = []string{
 	// Do not chane this order, x-amz-date value should be
@@ -434,7 +440,7 @@ func setRequestValidityHandler(h http.Handler) http.Handler {
 		// For all other requests reject access to reserved buckets
 		bucketName, _ := request2BucketObjectName(r)
 		if isMinioReservedBucket(bucketName) || isMinioMetaBucket(bucketName) {
-			if !guessIsRPCReq(r) && !guessIsBrowserReq(r) && !guessIsHealthCheckReq(r) && !guessIsMetricsReq(r) && !isAdminReq(r) && !isKMSReq(r) {
+			if !guessIsRPCReq(r) && !guessIsBrowserReq(r) && !guessIsHealthCheckReq(r) && !guessIsMetricsReq(r) && !isAdminReq(r) && !isKMSReq(r) && !isSCIMReq(r) {
 				if ok {
 					tc.funcName = "handler.ValidRequest"
 					tc.responseRecorder.LogErrBody = true
@@ -466,6 +472,53 @@ func setRequestValidityHandler(h http.Handler) http.Handler {
 	})
 }
 
+// isS3WriteRequest returns true for S3 API requests that modify data,
+// SelectObjectContent is the only POST request that does not.
+func isS3WriteRequest(r *http.Request) bool {
+	switch r.Method {
+	case http.MethodPut, http.MethodDelete:
+		return true
+	case http.MethodPost:
+		_, isSelect := r.URL.Query()["select"]
+		return !isSelect
+	}
+	return false
+}
+
+// setSiteReplicationReadOnlyHandler rejects S3 writes when this site is a
+// read-only site replication follower, except for the changes replicated
+// by its peer sites.
+func setSiteReplicationReadOnlyHandler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !isS3WriteRequest(r) || guessIsHealthCheckReq(r) || guessIsMetricsReq(r) ||
+			guessIsRPCReq(r) || guessIsLoginSTSReq(r) || isAdminReq(r) || isKMSReq(r) || isSCIMReq(r) {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		// STS requests are served at '/'.
+		if bucket, _ := request2BucketObjectName(r); bucket == "" {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		status := globalSiteReplicationSys.followerStatus()
+		if !status.ReadOnly || globalSiteReplicationSys.isReplicatorRequest(r) {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		if tc, ok := r.Context().Value(contextTraceReqKey).(*traceCtxt); ok {
+			tc.funcName = "handler.SiteReplicationReadOnly"
+			tc.responseRecorder.LogErrBody = true
+		}
+		if status.PrimaryEndpoint != "" {
+			w.Header().Set(xhttp.MinIOSiteReplicationPrimary, status.PrimaryEndpoint)
+		}
+		writeErrorResponse(r.Context(), w, errorCodes.ToAPIErr(ErrSiteReplicationReadOnly), r.URL)
+	})
+}
+
 // setBucketForwardingHandler middleware forwards the path style requests
 // on a bucket to the right bucket location, bucket to IP configuration
 // is obtained from centralized etcd configuration service.
@@ -24,6 +24,7 @@ import (
 	"strconv"
 	"testing"
 
+	"github.com/minio/madmin-go"
 	"github.com/qkbyte/minio/internal/crypto"
 	xhttp "github.com/qkbyte/minio/internal/http"
 )
@@ -166,3 +167,76 @@ func TestSSETLSHandler(t *testing.T) {
 		}
 	}
 }
+
+func TestSiteReplicationReadOnlyHandler(t *testing.T) {
+	globalSiteReplicationSys.Lock()
+	savedEnabled, savedState := globalSiteReplicationSys.enabled, globalSiteReplicationSys.state
+	globalSiteReplicationSys.enabled = true
+	globalSiteReplicationSys.state = srState{
+		Name: "dr",
+		Peers: map[string]madmin.PeerInfo{
+			"primary-id": {Name: "primary", Endpoint: "https://primary:9000", DeploymentID: "primary-id"},
+		},
+		ServiceAccountAccessKey: siteReplicatorSvcAcc,
+		Follower:                &srFollower{Primary: "primary-id"},
+	}
+	globalSiteReplicationSys.Unlock()
+	defer func() {
+		globalSiteReplicationSys.Lock()
+		globalSiteReplicationSys.enabled, globalSiteReplicationSys.state = savedEnabled, savedState
+		globalSiteReplicationSys.Unlock()
+	}()
+
+	var okHandler http.HandlerFunc = func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}
+	replicatorAuth := signV4Algorithm + " Credential=" + siteReplicatorSvcAcc + "/20220101/" + globalSite.Region +
+		"/s3/aws4_request, SignedHeaders=host, Signature=0"
+
+	testCases := []struct {
+		method     string
+		target     string
+		header     http.Header
+		shouldFail bool
+	}{
+		{method: http.MethodGet, target: "/bucket/object"},
+		{method: http.MethodPost, target: "/bucket/object?select&select-type=2"},
+		{method: http.MethodPost, target: "/"},
+		{method: http.MethodPut, target: "/bucket/object", shouldFail: true},
+		{method: http.MethodDelete, target: "/bucket", shouldFail: true},
+		{method: http.MethodPost, target: "/bucket?delete", shouldFail: true},
+		// Replication header alone is not enough.
+		{
+			method:     http.MethodPut,
+			target:     "/bucket/object",
+			header:     http.Header{xhttp.MinIOSourceReplicationRequest: []string{"true"}},
+			shouldFail: true,
+		},
+		{
+			method: http.MethodPut,
+			target: "/bucket/object",
+			header: http.Header{
+				xhttp.MinIOSourceReplicationRequest: []string{"true"},
+				xhttp.Authorization:                 []string{replicatorAuth},
+			},
+		},
+	}
+	for i, test := range testCases {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(test.method, test.target, nil)
+		for k, v := range test.header {
+			r.Header[k] = v
+		}
+
+		setSiteReplicationReadOnlyHandler(okHandler).ServeHTTP(w, r)
+
+		switch {
+		case test.shouldFail && w.Code != http.StatusForbidden:
+			t.Errorf("Test %d: expected HTTP 403, got HTTP %d", i+1, w.Code)
+		case test.shouldFail && w.Header().Get(xhttp.MinIOSiteReplicationPrimary) != "https://primary:9000":
+			t.Errorf("Test %d: expected primary endpoint header, got %q", i+1, w.Header().Get(xhttp.MinIOSiteReplicationPrimary))
+		case !test.shouldFail && w.Code != http.StatusOK:
+			t.Errorf("Test %d: expected HTTP 200, got HTTP %d", i+1, w.Code)
+		}
+	}
+}
@@ -20,6 +20,7 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"math"
 	"sort"
 	"strconv"
 	"time"
@@ -108,8 +109,14 @@ func getBackgroundHealStatus(ctx context.Context, o ObjectLayer) (madmin.BgHealS
 	// ignores any errors here.
 	si, _ := o.StorageInfo(ctx)
 
+	healing := globalBackgroundHealState.getLocalHealingDisks()
+
 	indexed := make(map[string][]madmin.Disk)
 	for _, disk := range si.Disks {
+		if hd, ok := healing[disk.Endpoint]; ok {
+			hd := hd
+			disk.HealInfo = &hd
+		}
 		setIdx := fmt.Sprintf("%d-%d", disk.PoolIndex, disk.SetIndex)
 		indexed[setIdx] = append(indexed[setIdx], disk)
 	}
@@ -129,6 +136,8 @@ func getBackgroundHealStatus(ctx context.Context, o ObjectLayer) (madmin.BgHealS
 			}
 		}
 		sortDisks(ss.Disks)
+		// TotalObjects reports the objects left to heal in this set.
+		ss.TotalObjects = estimateHealBacklog(ss.Disks)
 		status.Sets = append(status.Sets, ss)
 	}
 	sort.Slice(status.Sets, func(i, j int) bool {
@@ -143,6 +152,29 @@ func getBackgroundHealStatus(ctx context.Context, o ObjectLayer) (madmin.BgHealS
 	return status, true
 }
 
+// estimateHealBacklog returns the estimated number of objects of an
+// erasure set that are not yet verified by the healing of its drives.
+// It diffs the object count known from the last scanner listing with
+// the items the heal listing has already processed, healing drives of
+// a set traverse the same namespace so the largest backlog is used.
+func estimateHealBacklog(disks []madmin.Disk) int {
+	var backlog uint64
+	for _, disk := range disks {
+		if !disk.Healing || disk.HealInfo == nil {
+			continue
+		}
+		hi := disk.HealInfo
+		done := hi.ItemsHealed + hi.ItemsFailed
+		if hi.ObjectsTotalCount > done && hi.ObjectsTotalCount-done > backlog {
+			backlog = hi.ObjectsTotalCount - done
+		}
+	}
+	if backlog > math.MaxInt32 {
+		return math.MaxInt32
+	}
+	return int(backlog)
+}
+
 func mustGetHealSequence(ctx context.Context) *healSequence {
 	// Get background heal sequence to send elements to heal
 	for {
@@ -168,6 +200,34 @@ const envHealWorkers = "_MINIO_HEAL_WORKERS"
 
 // healErasureSet lists and heals all objects in a specific erasure set
 func (er *erasureObjects) healErasureSet(ctx context.Context, buckets []string, tracker *healingTracker) error {
+	shutdownDone, ok := globalShutdownCoordinator.register("heal")
+	if !ok {
+		return errShuttingDown
+	}
+	defer shutdownDone()
+
+	// Stop healing when the server shuts down, progress is saved
+	// to the tracker so healing resumes from the last healed object.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-globalShutdownCoordinator.Done():
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	checkpoint := func() bool {
+		select {
+		case <-globalShutdownCoordinator.Done():
+			// GlobalContext is still valid during the grace period.
+			logger.LogIf(GlobalContext, tracker.update(GlobalContext))
+			return true
+		default:
+			return false
+		}
+	}
+
 	bgSeq := mustGetHealSequence(ctx)
 	scanMode := madmin.HealNormalScan
 
@@ -199,6 +259,9 @@ func (er *erasureObjects) healErasureSet(ctx context.Context, buckets []string,
 	var retErr error
 	// Heal all buckets with all objects
 	for _, bucket := range healBuckets {
+		if checkpoint() {
+			return errShuttingDown
+		}
 		if tracker.isHealed(bucket) {
 			continue
 		}
@@ -269,7 +332,9 @@ func (er *erasureObjects) healErasureSet(ctx context.Context, buckets []string,
 
 		// Collect updates to tracker from concurrent healEntry calls
 		results := make(chan healEntryResult)
+		resultsDone := make(chan struct{})
 		go func() {
+			defer close(resultsDone)
 			for res := range results {
 				if res.entryDone {
 					tracker.Object = res.name
@@ -379,9 +444,10 @@ func (er *erasureObjects) healErasureSet(ctx context.Context, buckets []string,
 
 		// How to resolve partial results.
 		resolver := metadataResolutionParams{
-			dirQuorum: 1,
-			objQuorum: 1,
-			bucket:    bucket,
+			dirQuorum:        1,
+			objQuorum:        1,
+			bucket:           bucket,
+			divergenceSource: divergenceSourceHeal,
 		}
 
 		err = listPathRaw(ctx, listPathRawOptions{
@@ -409,6 +475,11 @@ func (er *erasureObjects) healErasureSet(ctx context.Context, buckets []string,
 		})
 		jt.Wait() // synchronize all the concurrent heal jobs
 		close(results)
+		<-resultsDone
+		if checkpoint() {
+			return errShuttingDown
+		}
+
 		if err != nil {
 			// Set this such that when we return this function
 			// we let the caller retry this disk again for the
@@ -427,6 +498,9 @@ func (er *erasureObjects) healErasureSet(ctx context.Context, buckets []string,
 			logger.LogIf(ctx, tracker.update(ctx))
 		}
 	}
+	if checkpoint() {
+		return errShuttingDown
+	}
 	tracker.Object = ""
 	tracker.Bucket = ""
 
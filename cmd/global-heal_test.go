@@ -0,0 +1,54 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/minio/madmin-go"
+)
+
+func TestEstimateHealBacklog(t *testing.T) {
+	testCases := []struct {
+		disks   []madmin.Disk
+		backlog int
+	}{
+		// No healing drives.
+		{disks: []madmin.Disk{{}, {}}, backlog: 0},
+		// Healing drive without progress reported by this node.
+		{disks: []madmin.Disk{{Healing: true}}, backlog: 0},
+		{
+			disks: []madmin.Disk{
+				{},
+				{Healing: true, HealInfo: &madmin.HealingDisk{ObjectsTotalCount: 1000, ItemsHealed: 300, ItemsFailed: 100}},
+				{Healing: true, HealInfo: &madmin.HealingDisk{ObjectsTotalCount: 1000, ItemsHealed: 900}},
+			},
+			backlog: 600,
+		},
+		// More items processed than counted by the scanner.
+		{
+			disks:   []madmin.Disk{{Healing: true, HealInfo: &madmin.HealingDisk{ObjectsTotalCount: 10, ItemsHealed: 20}}},
+			backlog: 0,
+		},
+	}
+	for i, tc := range testCases {
+		if got := estimateHealBacklog(tc.disks); got != tc.backlog {
+			t.Errorf("case %d: expected backlog %d, got %d", i+1, tc.backlog, got)
+		}
+	}
+}
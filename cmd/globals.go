@@ -36,6 +36,7 @@ import (
 	"github.com/rs/dnscache"
 
 	"github.com/dustin/go-humanize"
+	"github.com/klauspost/reedsolomon"
 	"github.com/qkbyte/minio/internal/auth"
 	"github.com/qkbyte/minio/internal/config/cache"
 	"github.com/qkbyte/minio/internal/config/callhome"
@@ -55,6 +56,7 @@ import (
 	xnet "github.com/minio/pkg/net"
 	"github.com/qkbyte/minio/internal/event"
 	"github.com/qkbyte/minio/internal/pubsub"
+	"github.com/quic-go/quic-go/http3"
 )
 
 // minio configuration related constants.
@@ -96,7 +98,7 @@ const (
 
 	// The maximum allowed time difference between the incoming request
 	// date and server date during signature verification.
-	globalMaxSkewTime = 15 * time.Minute // 15 minutes skew allowed.
+	globalMaxSkewTime = 15 * time.Minute // 15 minutes skew allowed by default.
 
 	// GlobalStaleUploadsExpiry - Expiry duration after which the uploads in multipart,
 	// tmp directory are deemed stale.
@@ -114,19 +116,9 @@ const (
 	// Maximum size of default bucket encryption configuration allowed
 	maxBucketSSEConfigSize = 1 * humanize.MiByte
 
-	// diskFillFraction is the fraction of a disk we allow to be filled.
-	diskFillFraction = 0.99
-
-	// diskReserveFraction is the fraction of a disk where we will fill other server pools first.
-	// If all pools reach this, we will use all pools with regular placement.
-	diskReserveFraction = 0.15
-
 	// diskAssumeUnknownSize is the size to assume when an unknown size upload is requested.
 	diskAssumeUnknownSize = 1 << 30
 
-	// diskMinInodes is the minimum number of inodes we want free on a disk to perform writes.
-	diskMinInodes = 1000
-
 	// tlsClientSessionCacheSize is the cache size for client sessions.
 	tlsClientSessionCacheSize = 100
 )
@@ -225,6 +217,16 @@ var (
 	globalHTTPServerErrorCh = make(chan error)
 	globalOSSignalCh        = make(chan os.Signal, 1)
 
+	// Listener addresses per role and the internode address of each
+	// node, see MINIO_BIND and MINIO_INTERNODE_HOSTS.
+	globalServerBindings      serverBindings
+	globalInternodeHosts      map[string]string
+	globalInternodeHTTPServer *xhttp.Server
+
+	// Experimental HTTP/3 listener of the S3 API, see MINIO_HTTP3.
+	globalHTTP3Enabled bool
+	globalHTTP3Server  *http3.Server
+
 	// global Trace system to send HTTP request/response
 	// and Storage/OS calls info to registered listeners.
 	globalTrace = pubsub.New(8)
@@ -233,6 +235,10 @@ var (
 	// Objects are expected to be event.Event
 	globalHTTPListen = pubsub.New(0)
 
+	// global heal status stream to send drive heal progress
+	// updates to registered listeners
+	globalHealStatusStream = pubsub.New(0)
+
 	// global console system to send console logs to
 	// registered listeners
 	globalConsoleSys *HTTPConsoleLoggerSys
@@ -256,12 +262,39 @@ var (
 	// Global HTTP request statisitics
 	globalHTTPStats = newHTTPStats()
 
+	// Tracks API error rates and latencies for anomaly alerts.
+	globalAPIAnomalyDetector = newAPIAnomalyDetector()
+
+	// Samples object requests to report hot and slow objects.
+	globalHotObjects = newHotObjectsCollector()
+
+	// Hourly requests and bytes per access key for billing.
+	globalUsageAccounting = newUsageAccounting()
+
+	// Requests and bytes charged to requesters of requester pays buckets.
+	globalRequesterPaysUsage = newRequesterPaysUsage()
+
+	// Bytes served and rate limiters per bucket share link.
+	globalShareLinkUsage = newShareLinkUsage()
+
+	// Tracks requests rejected by bucket encryption compliance mode.
+	globalSSEComplianceReporter = newSSEComplianceReporter()
+
+	// Counts GetObject requests served from the raw file.
+	globalGetObjectFastPath getObjectFastPathStats
+
 	// Global bucket network statistics
 	globalBucketConnStats = newBucketConnStats()
 
 	// Time when the server is started
 	globalBootTime = UTCNow()
 
+	// Membership and health view of the cluster, maintained by gossip.
+	globalGossip = newGossipState()
+
+	// Rolling restart orchestrated by this node.
+	globalRollingRestart = &rollingRestart{}
+
 	globalActiveCred auth.Credentials
 
 	globalPublicCerts []*x509.Certificate
@@ -276,6 +309,9 @@ var (
 	globalBucketQuotaSys      *BucketQuotaSys
 	globalBucketVersioningSys *BucketVersioningSys
 
+	// Tenants grouping buckets under aggregate quotas
+	globalTenantSys *TenantSys
+
 	// Disk cache drives
 	globalCacheConfig cache.Config
 
@@ -368,6 +404,26 @@ var (
 
 	globalRootDiskThreshold uint64
 
+	// Deny-by-default hardening, see MINIO_IAM_DENY_BY_DEFAULT.
+	globalIAMDenyByDefault       bool
+	globalIAMBreakGlassAccessKey string
+
+	// Add config changes to audit logs, see MINIO_CONFIG_JOURNAL_AUDIT.
+	globalConfigJournalAudit bool
+
+	// Cached listing of the config journal, used to prune it.
+	globalConfigJournalFiles = &configJournalFiles{}
+
+	// Reed-Solomon acceleration path of this node, see MINIO_ERASURE_SIMD.
+	globalErasureSIMD        erasureSIMDInfo
+	globalErasureSIMDOptions []reedsolomon.Option
+
+	// Moves object parts between pools in parallel, see MINIO_TRANSFER_STREAMS.
+	globalTransferManager = newTransferManager(transferDefaultStreams)
+
+	// Samples the network quality to all peers, see MINIO_NET_MONITOR_*.
+	globalNetMonitor = newNetMonitor(defaultNetMonitorConfig())
+
 	// Used for collecting stats for netperf
 	globalNetPerfMinDuration     = time.Second * 10
 	globalNetPerfRX              netPerfRX
@@ -0,0 +1,350 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/minio/madmin-go"
+	iampolicy "github.com/minio/pkg/iam/policy"
+	"github.com/qkbyte/minio/internal/logger"
+)
+
+const (
+	// gossipInterval is the time between two gossip rounds of a node.
+	gossipInterval = 2 * time.Second
+
+	// gossipFanout is the number of random peers contacted per round.
+	gossipFanout = 3
+
+	// A member whose heartbeat did not advance for gossipSuspectAfter is
+	// suspect, after gossipOfflineAfter it is considered offline.
+	gossipSuspectAfter = 5 * gossipInterval
+	gossipOfflineAfter = 15 * gossipInterval
+)
+
+// Membership states of a node as seen by the local node.
+const (
+	memberStateOnline  = "online"
+	memberStateSuspect = "suspect"
+	memberStateOffline = "offline"
+)
+
+// gossipMember is the state a node publishes about itself, exchanged
+// between peers as is.
+type gossipMember struct {
+	Node          string
+	Version       string
+	CommitID      string
+	Started       time.Time
+	Heartbeat     uint64
+	DrivesOnline  int
+	DrivesOffline int
+}
+
+// newerThan returns true if m is more recent than o, a restarted node
+// starts counting heartbeats from zero again with a later start time.
+func (m gossipMember) newerThan(o gossipMember) bool {
+	if !m.Started.Equal(o.Started) {
+		return m.Started.After(o.Started)
+	}
+	return m.Heartbeat > o.Heartbeat
+}
+
+type gossipEntry struct {
+	gossipMember
+
+	// local time the heartbeat of the member last advanced.
+	seen time.Time
+	// round trip time of the last direct exchange with the member.
+	latency time.Duration
+}
+
+// gossipState is the membership and health view of the local node,
+// kept up to date by periodically exchanging it with random peers.
+type gossipState struct {
+	mu      sync.RWMutex
+	members map[string]*gossipEntry
+}
+
+func newGossipState() *gossipState {
+	return &gossipState{members: make(map[string]*gossipEntry)}
+}
+
+// addPeers makes nodes part of the view before anything was heard from
+// them, so that unreachable nodes are reported as offline.
+func (g *gossipState) addPeers(nodes ...string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for _, node := range nodes {
+		if _, ok := g.members[node]; !ok {
+			g.members[node] = &gossipEntry{gossipMember: gossipMember{Node: node}}
+		}
+	}
+}
+
+// heartbeat advances the heartbeat of the local node and refreshes the
+// state it publishes.
+func (g *gossipState) heartbeat(local gossipMember, now time.Time) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	e, ok := g.members[local.Node]
+	if !ok {
+		e = &gossipEntry{}
+		g.members[local.Node] = e
+	}
+	local.Heartbeat = e.Heartbeat + 1
+	e.gossipMember = local
+	e.seen = now
+}
+
+// digest returns the known state of all members.
+func (g *gossipState) digest() []gossipMember {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	members := make([]gossipMember, 0, len(g.members))
+	for _, e := range g.members {
+		if !e.Started.IsZero() {
+			members = append(members, e.gossipMember)
+		}
+	}
+	return members
+}
+
+// merge updates the view with the members received from a peer, keeping
+// the most recent state of every member.
+func (g *gossipState) merge(members []gossipMember, now time.Time) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for _, m := range members {
+		if m.Node == "" {
+			continue
+		}
+		e, ok := g.members[m.Node]
+		if !ok {
+			e = &gossipEntry{}
+			g.members[m.Node] = e
+		}
+		if m.newerThan(e.gossipMember) {
+			e.gossipMember = m
+			e.seen = now
+		}
+	}
+}
+
+// setLatency records the round trip time of a direct exchange with node.
+func (g *gossipState) setLatency(node string, rtt time.Duration) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if e, ok := g.members[node]; ok {
+		e.latency = rtt
+	}
+}
+
+// memberStatus is the state of a member as reported by the admin API.
+type memberStatus struct {
+	Node          string        `json:"node"`
+	State         string        `json:"state"`
+	Version       string        `json:"version,omitempty"`
+	CommitID      string        `json:"commitID,omitempty"`
+	Started       time.Time     `json:"started,omitempty"`
+	Heartbeat     uint64        `json:"heartbeat"`
+	LastSeen      time.Time     `json:"lastSeen,omitempty"`
+	Latency       time.Duration `json:"latency,omitempty"`
+	DrivesOnline  int           `json:"drivesOnline"`
+	DrivesOffline int           `json:"drivesOffline"`
+}
+
+// membershipView is the membership and health of all nodes as seen by
+// a single node.
+type membershipView struct {
+	Node    string         `json:"node"`
+	Members []memberStatus `json:"members"`
+}
+
+// view returns the membership as seen by the local node at now.
+func (g *gossipState) view(local string, now time.Time) membershipView {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	view := membershipView{Node: local, Members: make([]memberStatus, 0, len(g.members))}
+	for node, e := range g.members {
+		state := memberStateOnline
+		switch since := now.Sub(e.seen); {
+		case node == local:
+		case e.seen.IsZero(), since > gossipOfflineAfter:
+			state = memberStateOffline
+		case since > gossipSuspectAfter:
+			state = memberStateSuspect
+		}
+		view.Members = append(view.Members, memberStatus{
+			Node:          node,
+			State:         state,
+			Version:       e.Version,
+			CommitID:      e.CommitID,
+			Started:       e.Started,
+			Heartbeat:     e.Heartbeat,
+			LastSeen:      e.seen,
+			Latency:       e.latency,
+			DrivesOnline:  e.DrivesOnline,
+			DrivesOffline: e.DrivesOffline,
+		})
+	}
+	sort.Slice(view.Members, func(i, j int) bool {
+		return view.Members[i].Node < view.Members[j].Node
+	})
+	return view
+}
+
+// gossipNetworkStates returns the network state of the nodes heard of
+// through the gossip, nodes without any news yet are left out.
+func gossipNetworkStates(now time.Time) map[string]string {
+	if !globalIsDistErasure {
+		return nil
+	}
+	view := globalGossip.view(globalLocalNodeName, now)
+	states := make(map[string]string, len(view.Members))
+	for _, m := range view.Members {
+		switch {
+		case m.LastSeen.IsZero():
+		case m.State == memberStateOffline:
+			states[m.Node] = string(madmin.ItemOffline)
+		default:
+			states[m.Node] = string(madmin.ItemOnline)
+		}
+	}
+	return states
+}
+
+// localGossipMember returns the state published by this node.
+func localGossipMember() gossipMember {
+	m := gossipMember{
+		Node:     globalLocalNodeName,
+		Version:  Version,
+		CommitID: CommitID,
+		Started:  globalBootTime,
+	}
+	for _, disk := range globalLocalDrives {
+		if disk != nil && disk.IsOnline() {
+			m.DrivesOnline++
+		} else {
+			m.DrivesOffline++
+		}
+	}
+	return m
+}
+
+// initGossip starts exchanging the membership view with random peers
+// every gossipInterval.
+func initGossip(ctx context.Context) {
+	if globalNotificationSys == nil {
+		return
+	}
+	peers := make([]*peerRESTClient, 0, len(globalNotificationSys.peerClients))
+	for _, client := range globalNotificationSys.peerClients {
+		if client != nil {
+			peers = append(peers, client)
+			globalGossip.addPeers(client.host.String())
+		}
+	}
+	globalGossip.heartbeat(localGossipMember(), UTCNow())
+
+	go func() {
+		r := rand.New(rand.NewSource(time.Now().UnixNano()))
+		for {
+			// Add some jitter so that rounds of all nodes spread out.
+			duration := gossipInterval/2 + time.Duration(r.Int63n(int64(gossipInterval)))
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(duration):
+			}
+
+			globalGossip.heartbeat(localGossipMember(), UTCNow())
+			digest := globalGossip.digest()
+
+			r.Shuffle(len(peers), func(i, j int) { peers[i], peers[j] = peers[j], peers[i] })
+			n := gossipFanout
+			if n > len(peers) {
+				n = len(peers)
+			}
+			var wg sync.WaitGroup
+			for _, client := range peers[:n] {
+				wg.Add(1)
+				go func(client *peerRESTClient) {
+					defer wg.Done()
+					gossipWith(ctx, client, digest)
+				}(client)
+			}
+			wg.Wait()
+		}
+	}()
+}
+
+// gossipWith exchanges the membership view with a single peer.
+func gossipWith(ctx context.Context, client *peerRESTClient, digest []gossipMember) {
+	ctx, cancel := context.WithTimeout(ctx, gossipInterval)
+	defer cancel()
+
+	sent := time.Now()
+	members, err := client.Gossip(ctx, digest)
+	if err != nil {
+		return
+	}
+	globalGossip.merge(members, UTCNow())
+	globalGossip.setLatency(client.host.String(), time.Since(sent))
+}
+
+// MembershipHandler - GET /minio/admin/v3/membership
+// ----------
+// Returns the membership and health of all nodes as seen by this node,
+// kept up to date by the gossip between nodes instead of probing every
+// node on each call.
+func (a adminAPIHandlers) MembershipHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "Membership")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServerInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	if !globalIsDistErasure {
+		globalGossip.heartbeat(localGossipMember(), UTCNow())
+	}
+
+	data, err := json.Marshal(globalGossip.view(globalLocalNodeName, UTCNow()))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
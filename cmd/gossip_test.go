@@ -0,0 +1,119 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/minio/madmin-go"
+)
+
+func TestGossipStateMerge(t *testing.T) {
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	started := now.Add(-time.Hour)
+
+	g := newGossipState()
+	g.addPeers("node2:9000", "node3:9000")
+	g.heartbeat(gossipMember{Node: "node1:9000", Started: started, DrivesOnline: 4}, now)
+	g.heartbeat(gossipMember{Node: "node1:9000", Started: started, DrivesOnline: 4}, now)
+
+	// Peers not heard from yet are not gossiped.
+	if digest := g.digest(); len(digest) != 1 || digest[0].Heartbeat != 2 {
+		t.Fatalf("unexpected digest %+v", digest)
+	}
+
+	g.merge([]gossipMember{{Node: "node2:9000", Started: started, Heartbeat: 10, DrivesOnline: 3, DrivesOffline: 1}}, now)
+	// Stale state received through another peer must be ignored.
+	g.merge([]gossipMember{{Node: "node2:9000", Started: started, Heartbeat: 5}}, now.Add(time.Second))
+	g.setLatency("node2:9000", 5*time.Millisecond)
+
+	view := g.view("node1:9000", now.Add(time.Second))
+	if len(view.Members) != 3 {
+		t.Fatalf("expected 3 members, got %d", len(view.Members))
+	}
+	node2 := view.Members[1]
+	if node2.Node != "node2:9000" || node2.State != memberStateOnline || node2.Heartbeat != 10 ||
+		node2.DrivesOffline != 1 || node2.Latency != 5*time.Millisecond || !node2.LastSeen.Equal(now) {
+		t.Fatalf("unexpected state of node2 %+v", node2)
+	}
+	if view.Members[2].State != memberStateOffline {
+		t.Fatalf("expected node3 to be offline, got %s", view.Members[2].State)
+	}
+
+	// A restarted node counts heartbeats from zero again.
+	g.merge([]gossipMember{{Node: "node2:9000", Started: now, Heartbeat: 1}}, now.Add(2*time.Second))
+	if view = g.view("node1:9000", now.Add(2*time.Second)); view.Members[1].Heartbeat != 1 {
+		t.Fatalf("expected restarted node2 to replace the previous state, got %+v", view.Members[1])
+	}
+}
+
+func TestGossipStateView(t *testing.T) {
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	g := newGossipState()
+	g.heartbeat(gossipMember{Node: "node1:9000", Started: now}, now)
+	g.merge([]gossipMember{
+		{Node: "node2:9000", Started: now, Heartbeat: 1},
+		{Node: "node3:9000", Started: now, Heartbeat: 1},
+	}, now)
+	g.merge([]gossipMember{{Node: "node3:9000", Started: now, Heartbeat: 2}}, now.Add(gossipSuspectAfter))
+
+	testCases := []struct {
+		at       time.Time
+		expected []string
+	}{
+		{now, []string{memberStateOnline, memberStateOnline, memberStateOnline}},
+		{now.Add(gossipSuspectAfter + time.Second), []string{memberStateOnline, memberStateSuspect, memberStateOnline}},
+		{now.Add(gossipOfflineAfter + time.Second), []string{memberStateOnline, memberStateOffline, memberStateSuspect}},
+		{now.Add(gossipSuspectAfter + gossipOfflineAfter + time.Second), []string{memberStateOnline, memberStateOffline, memberStateOffline}},
+	}
+	for i, testCase := range testCases {
+		view := g.view("node1:9000", testCase.at)
+		for j, member := range view.Members {
+			if member.State != testCase.expected[j] {
+				t.Errorf("Test %d: expected %s to be %s, got %s", i+1, member.Node, testCase.expected[j], member.State)
+			}
+		}
+	}
+}
+
+func TestGossipNetworkStates(t *testing.T) {
+	defer func(g *gossipState, dist bool, local string) {
+		globalGossip, globalIsDistErasure, globalLocalNodeName = g, dist, local
+	}(globalGossip, globalIsDistErasure, globalLocalNodeName)
+
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	globalGossip = newGossipState()
+	globalIsDistErasure, globalLocalNodeName = true, "node1:9000"
+	globalGossip.addPeers("node2:9000", "node3:9000", "node4:9000")
+	globalGossip.heartbeat(gossipMember{Node: "node1:9000", Started: now}, now)
+	globalGossip.merge([]gossipMember{{Node: "node2:9000", Started: now, Heartbeat: 1}}, now)
+	globalGossip.merge([]gossipMember{{Node: "node3:9000", Started: now, Heartbeat: 1}}, now.Add(-gossipOfflineAfter-time.Second))
+
+	states := gossipNetworkStates(now)
+	expected := map[string]string{
+		"node1:9000": string(madmin.ItemOnline),
+		"node2:9000": string(madmin.ItemOnline),
+		"node3:9000": string(madmin.ItemOffline),
+	}
+	if !reflect.DeepEqual(states, expected) {
+		t.Fatalf("expected %v, got %v", expected, states)
+	}
+}
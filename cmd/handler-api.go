@@ -23,11 +23,13 @@ import (
 	"runtime"
 	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/shirou/gopsutil/v3/mem"
 
 	"github.com/qkbyte/minio/internal/config/api"
+	xhttp "github.com/qkbyte/minio/internal/http"
 	xioutil "github.com/qkbyte/minio/internal/ioutil"
 	"github.com/qkbyte/minio/internal/logger"
 )
@@ -44,12 +46,34 @@ type apiConfig struct {
 	totalDriveCount     int
 	replicationPriority string
 	transitionWorkers   int
+	expiryWorkers       int
+	expiryQueueWait     time.Duration
 
 	staleUploadsExpiry          time.Duration
 	staleUploadsCleanupInterval time.Duration
 	deleteCleanupInterval       time.Duration
+	deleteCleanupQuarantine     time.Duration
+	deleteCleanupMaxSize        uint64
 	disableODirect              bool
 	gzipObjects                 bool
+	requestTimeSkew             time.Duration
+	perAPIDeadline              map[string]time.Duration
+	credentialLimits            credentialLimits
+	driveThresholds             *api.DriveThresholds
+	listConflictPolicy          resolveConflictPolicy
+	divergenceLog               bool
+	rejectVersionSkew           bool
+}
+
+// defaultDriveThresholds apply until the API configuration is loaded.
+var defaultDriveThresholds = api.DriveThresholds{
+	// Allow drives to be filled up to 99%.
+	MinFreePercent: 1,
+	// Keep a minimum number of inodes free to perform writes.
+	MinFreeInodes: 1000,
+	// Fill other server pools first once a drive is 85% full,
+	// if all pools reach this, use all pools with regular placement.
+	ReservePercent: 15,
 }
 
 const cgroupLimitFile = "/sys/fs/cgroup/memory/memory.limit_in_bytes"
@@ -146,11 +170,81 @@ func (t *apiConfig) init(cfg api.Config, setDriveCounts []int) {
 	}
 	t.transitionWorkers = cfg.TransitionWorkers
 
+	if globalExpiryState != nil && cfg.ExpiryWorkers != t.expiryWorkers {
+		globalExpiryState.UpdateWorkers(cfg.ExpiryWorkers)
+	}
+	t.expiryWorkers = cfg.ExpiryWorkers
+	t.expiryQueueWait = cfg.ExpiryQueueWait
+
 	t.staleUploadsExpiry = cfg.StaleUploadsExpiry
 	t.staleUploadsCleanupInterval = cfg.StaleUploadsCleanupInterval
 	t.deleteCleanupInterval = cfg.DeleteCleanupInterval
+	t.deleteCleanupQuarantine = cfg.DeleteCleanupQuarantine
+	t.deleteCleanupMaxSize = cfg.DeleteCleanupMaxSize
 	t.disableODirect = cfg.DisableODirect
 	t.gzipObjects = cfg.GzipObjects
+	t.requestTimeSkew = cfg.RequestTimeSkew
+	t.perAPIDeadline = cfg.PerAPIDeadline
+
+	globalRangeCache.setLimits(int64(cfg.RangeCacheSize), int64(cfg.RangeCacheMaxRange))
+	globalTLSPolicy.update(cfg.TLSPolicy)
+	globalVhostDomains.update(cfg.VhostDomains)
+
+	driveThresholds := cfg.DriveThresholds
+	t.driveThresholds = &driveThresholds
+
+	t.listConflictPolicy = resolveConflictMerge
+	if cfg.ListConflictPolicy == "skip" {
+		t.listConflictPolicy = resolveConflictSkip
+	}
+	t.divergenceLog = cfg.DivergenceLog
+	t.rejectVersionSkew = cfg.VersionSkew == "reject"
+
+	t.credentialLimits = credentialLimits{
+		serviceAccountsMax:      cfg.ServiceAccountsMax,
+		serviceAccountsGroupMax: cfg.ServiceAccountsGroupMax,
+		sessionMaxDuration:      cfg.SessionMaxDuration,
+		sessionPolicyMaxSize:    int(cfg.SessionPolicyMaxSize),
+	}
+}
+
+func (t *apiConfig) getCredentialLimits() credentialLimits {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.credentialLimits
+}
+
+func (t *apiConfig) getDriveThresholds() api.DriveThresholds {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.driveThresholds == nil {
+		return defaultDriveThresholds
+	}
+
+	return *t.driveThresholds
+}
+
+func (t *apiConfig) getListConflictPolicy() resolveConflictPolicy {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.listConflictPolicy
+}
+
+func (t *apiConfig) isDivergenceLogEnabled() bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.divergenceLog
+}
+
+func (t *apiConfig) isVersionSkewRejected() bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.rejectVersionSkew
 }
 
 func (t *apiConfig) isDisableODirect() bool {
@@ -167,6 +261,29 @@ func (t *apiConfig) shouldGzipObjects() bool {
 	return t.gzipObjects
 }
 
+func (t *apiConfig) getRequestTimeSkew() time.Duration {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.requestTimeSkew == 0 {
+		return globalMaxSkewTime
+	}
+
+	return t.requestTimeSkew
+}
+
+// getAPIDeadline returns the deadline configured for api, zero
+// if requests to api are not bounded.
+func (t *apiConfig) getAPIDeadline(api string) time.Duration {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if d, ok := t.perAPIDeadline[api]; ok {
+		return d
+	}
+	return t.perAPIDeadline["*"]
+}
+
 func (t *apiConfig) getListQuorum() string {
 	t.mu.RLock()
 	defer t.mu.RUnlock()
@@ -216,6 +333,13 @@ func (t *apiConfig) getDeleteCleanupInterval() time.Duration {
 	return t.deleteCleanupInterval
 }
 
+func (t *apiConfig) getDeleteCleanupBudget() (quarantine time.Duration, maxSize uint64) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.deleteCleanupQuarantine, t.deleteCleanupMaxSize
+}
+
 func (t *apiConfig) getClusterDeadline() time.Duration {
 	t.mu.RLock()
 	defer t.mu.RUnlock()
@@ -263,6 +387,17 @@ func maxClients(f http.HandlerFunc) http.HandlerFunc {
 			return
 		}
 
+		// Clients ignoring the Retry-After of throttled requests
+		// are rejected without waiting in the queue.
+		client := backoffClient(r)
+		if wait, ok := globalRequestBackoff.admit(client); !ok {
+			w.Header().Set(xhttp.RetryAfter, retryAfterHeader(wait))
+			writeErrorResponse(r.Context(), w,
+				errorCodes.ToAPIErr(ErrOperationMaxedOut),
+				r.URL)
+			return
+		}
+
 		globalHTTPStats.addRequestsInQueue(1)
 
 		deadlineTimer := time.NewTimer(deadline)
@@ -270,11 +405,18 @@ func maxClients(f http.HandlerFunc) http.HandlerFunc {
 
 		select {
 		case pool <- struct{}{}:
-			defer func() { <-pool }()
+			defer func() {
+				<-pool
+				globalRequestBackoff.done()
+			}()
 			globalHTTPStats.addRequestsInQueue(-1)
 			f.ServeHTTP(w, r)
 		case <-deadlineTimer.C:
-			// Send a http timeout message
+			// Send a http timeout message with a hint of when the
+			// queued requests are expected to be drained.
+			queued := int(atomic.LoadInt32(&globalHTTPStats.s3RequestsInQueue))
+			wait := globalRequestBackoff.reject(client, queued, deadline)
+			w.Header().Set(xhttp.RetryAfter, retryAfterHeader(wait))
 			writeErrorResponse(r.Context(), w,
 				errorCodes.ToAPIErr(ErrOperationMaxedOut),
 				r.URL)
@@ -300,3 +442,17 @@ func (t *apiConfig) getTransitionWorkers() int {
 
 	return t.transitionWorkers
 }
+
+func (t *apiConfig) getExpiryWorkers() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.expiryWorkers
+}
+
+func (t *apiConfig) getExpiryQueueWait() time.Duration {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.expiryQueueWait
+}
@@ -20,14 +20,19 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
+	"net"
 	"net/http"
 	"net/textproto"
+	"os"
 	"regexp"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/minio/madmin-go"
 	xnet "github.com/minio/pkg/net"
@@ -247,6 +252,17 @@ func extractRespElements(w http.ResponseWriter) map[string]string {
 	}
 }
 
+// getRemoteHost returns the host of the peer connected to the server
+// for r, unlike handlers.GetSourceIP forwarded headers are ignored as
+// any client can set them.
+func getRemoteHost(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
 // Trims away `aws-chunked` from the content-encoding header if present.
 // Streaming signature clients can have custom content-encoding such as
 // `aws-chunked,gzip` here we need to only save `gzip`.
@@ -279,68 +295,126 @@ func validateFormFieldSize(ctx context.Context, formValues http.Header) error {
 	return nil
 }
 
-// Extract form fields and file data from a HTTP POST Policy
-func extractPostPolicyFormValues(ctx context.Context, form *multipart.Form) (filePart io.ReadCloser, fileName string, fileSize int64, formValues http.Header, err error) {
-	// HTML Form values
-	fileName = ""
+// postPolicyTmpFile is an uploaded file spooled to
+// a temporary file, it is removed upon Close.
+type postPolicyTmpFile struct {
+	*os.File
+}
 
-	// Canonicalize the form values into http.Header.
-	formValues = make(http.Header)
-	for k, v := range form.Value {
-		formValues[http.CanonicalHeaderKey(k)] = v
+func (f postPolicyTmpFile) Close() error {
+	f.File.Close()
+	return os.Remove(f.Name())
+}
+
+// postPolicyMaxFileSize returns the largest acceptable file size for the
+// policy found in the form values, the policy is only used as a hint to
+// stop reading early and is validated later along with the signature.
+func postPolicyMaxFileSize(formValues http.Header) int64 {
+	maxSize := int64(globalMaxObjectSize)
+	policyBytes, err := base64.StdEncoding.DecodeString(formValues.Get("Policy"))
+	if err != nil || len(policyBytes) == 0 {
+		return maxSize
+	}
+	postPolicyForm, err := parsePostPolicyForm(bytes.NewReader(policyBytes))
+	if err != nil {
+		return maxSize
+	}
+	if lengthRange := postPolicyForm.Conditions.ContentLengthRange; lengthRange.Valid && lengthRange.Max < maxSize {
+		return lengthRange.Max
 	}
+	return maxSize
+}
 
-	// Validate form values.
-	if err = validateFormFieldSize(ctx, formValues); err != nil {
-		return nil, "", 0, nil, err
+// spoolPostPolicyFile reads the uploaded file into memory, spilling over to
+// a temporary file beyond the remaining form memory. errDataTooLarge is
+// returned as soon as more than maxSize bytes are read.
+func spoolPostPolicyFile(r io.Reader, memory *int64, maxSize int64) (io.ReadCloser, int64, error) {
+	var b bytes.Buffer
+	n, err := io.CopyN(&b, r, *memory+1)
+	if err != nil && err != io.EOF {
+		return nil, 0, err
+	}
+	if n > maxSize {
+		return nil, 0, errDataTooLarge
+	}
+	if n <= *memory {
+		*memory -= n
+		return io.NopCloser(&b), n, nil
 	}
 
-	// this means that filename="" was not specified for file key and Go has
-	// an ugly way of handling this situation. Refer here
-	// https://golang.org/src/mime/multipart/formdata.go#L61
-	if len(form.File) == 0 {
-		b := &bytes.Buffer{}
-		for _, v := range formValues["File"] {
-			b.WriteString(v)
+	f, err := os.CreateTemp("", "multipart-")
+	if err != nil {
+		return nil, 0, err
+	}
+	tmpFile := postPolicyTmpFile{f}
+	size, err := io.Copy(f, io.MultiReader(&b, io.LimitReader(r, maxSize-n+1)))
+	if err == nil && size > maxSize {
+		err = errDataTooLarge
+	}
+	if err == nil {
+		_, err = f.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		tmpFile.Close()
+		return nil, 0, err
+	}
+	return tmpFile, size, nil
+}
+
+// Extract form fields and file data from a HTTP POST Policy, the file is
+// kept in memory or in a temporary file if it is too large. When the
+// policy precedes the file, as required by S3, files larger than the
+// content-length-range of the policy are rejected while being read.
+func extractPostPolicyFormValues(ctx context.Context, reader *multipart.Reader) (filePart io.ReadCloser, fileName string, fileSize int64, formValues http.Header, err error) {
+	defer func() {
+		if err != nil && filePart != nil {
+			filePart.Close()
 		}
-		fileSize = int64(b.Len())
-		filePart = io.NopCloser(b)
-		return filePart, fileName, fileSize, formValues, nil
-	}
-
-	// Iterator until we find a valid File field and break
-	for k, v := range form.File {
-		canonicalFormName := http.CanonicalHeaderKey(k)
-		if canonicalFormName == "File" {
-			if len(v) == 0 {
-				logger.LogIf(ctx, errInvalidArgument)
-				return nil, "", 0, nil, errInvalidArgument
-			}
-			// Fetch fileHeader which has the uploaded file information
-			fileHeader := v[0]
-			// Set filename
-			fileName = fileHeader.Filename
-			// Open the uploaded part
-			filePart, err = fileHeader.Open()
-			if err != nil {
-				logger.LogIf(ctx, err)
-				return nil, "", 0, nil, err
-			}
-			// Compute file size
-			fileSize, err = filePart.(io.Seeker).Seek(0, 2)
-			if err != nil {
-				logger.LogIf(ctx, err)
-				return nil, "", 0, nil, err
-			}
-			// Reset Seek to the beginning
-			_, err = filePart.(io.Seeker).Seek(0, 0)
+	}()
+
+	formValues = make(http.Header)
+	memory := maxFormMemory
+	for {
+		part, perr := reader.NextPart()
+		if perr == io.EOF {
+			break
+		}
+		if perr != nil {
+			return filePart, "", 0, nil, perr
+		}
+
+		name := http.CanonicalHeaderKey(part.FormName())
+		if name == "" {
+			part.Close()
+			continue
+		}
+
+		// Only the first File field is the uploaded file.
+		if name == "File" && filePart == nil {
+			fileName = part.FileName()
+			filePart, fileSize, err = spoolPostPolicyFile(part, &memory, postPolicyMaxFileSize(formValues))
+			part.Close()
 			if err != nil {
-				logger.LogIf(ctx, err)
-				return nil, "", 0, nil, err
+				return filePart, "", 0, nil, err
 			}
-			// File found and ready for reading
-			break
+			continue
 		}
+
+		var b bytes.Buffer
+		n, cerr := io.CopyN(&b, part, maxFormFieldSize+1)
+		part.Close()
+		if cerr != nil && cerr != io.EOF {
+			return filePart, "", 0, nil, cerr
+		}
+		if memory -= n; memory < 0 {
+			return filePart, "", 0, nil, multipart.ErrMessageTooLarge
+		}
+		formValues.Add(name, b.String())
+	}
+
+	// Validate form values.
+	if err = validateFormFieldSize(ctx, formValues); err != nil {
+		return filePart, "", 0, nil, err
 	}
 	return filePart, fileName, fileSize, formValues, nil
 }
@@ -350,16 +424,38 @@ func collectAPIStats(api string, f http.HandlerFunc) http.HandlerFunc {
 		globalHTTPStats.currentS3Requests.Inc(api)
 		defer globalHTTPStats.currentS3Requests.Dec(api)
 
+		body := &countingReadCloser{ReadCloser: r.Body}
+		if r.Body != nil {
+			r.Body = body
+		}
 		statsWriter := logger.NewResponseWriter(w)
 
+		if deadline := globalAPIConfig.getAPIDeadline(api); deadline > 0 {
+			ctx, cancel := context.WithTimeout(r.Context(), deadline)
+			defer cancel()
+			r = r.WithContext(ctx)
+		}
+
+		// Usage is accounted to the access key the request was
+		// authenticated with, requests failing authentication are not.
+		ctx, usage := withUsageAccessKey(r.Context())
+		r = r.WithContext(ctx)
+
 		f.ServeHTTP(statsWriter, r)
 
 		globalHTTPStats.updateStats(api, r, statsWriter)
+		if usage.authenticated && !strings.HasSuffix(r.URL.Path, minioReservedBucketPathWithSlash) {
+			globalUsageAccounting.record(usage.accessKey, atomic.LoadUint64(&body.n), uint64(statsWriter.Size()),
+				statsWriter.StatusCode >= http.StatusBadRequest, time.Now())
+		}
 	}
 }
 
 // Returns "/bucketName/objectName" for path-style or virtual-host-style requests.
 func getResource(path string, host string, domains []string) (string, error) {
+	if bucket, ok := vhostBucket(host); ok {
+		return SlashSeparator + pathJoin(bucket, path), nil
+	}
 	if len(domains) == 0 {
 		return path, nil
 	}
@@ -20,8 +20,11 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/xml"
+	"errors"
 	"io"
+	"mime/multipart"
 	"net/http"
 	"net/textproto"
 	"os"
@@ -237,3 +240,39 @@ func TestGetResource(t *testing.T) {
 		}
 	}
 }
+
+func TestExtractPostPolicyFormValues(t *testing.T) {
+	policy := `{"expiration":"2100-01-01T00:00:00.000Z","conditions":[["content-length-range",1,10]]}`
+	newReader := func(file []byte) *multipart.Reader {
+		var body bytes.Buffer
+		w := multipart.NewWriter(&body)
+		w.WriteField("key", "object")
+		w.WriteField("policy", base64.StdEncoding.EncodeToString([]byte(policy)))
+		fw, err := w.CreateFormFile("file", "upload.txt")
+		if err != nil {
+			t.Fatal(err)
+		}
+		fw.Write(file)
+		w.Close()
+		return multipart.NewReader(&body, w.Boundary())
+	}
+
+	fileBody, fileName, fileSize, formValues, err := extractPostPolicyFormValues(context.Background(), newReader([]byte("hello")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fileBody.Close()
+	data, err := io.ReadAll(fileBody)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "hello" || fileSize != 5 || fileName != "upload.txt" || formValues.Get("Key") != "object" {
+		t.Fatalf("unexpected form values %v, file %s (%d bytes) %q", formValues, fileName, fileSize, data)
+	}
+
+	// Files exceeding the content-length-range are rejected while reading.
+	_, _, _, _, err = extractPostPolicyFormValues(context.Background(), newReader(bytes.Repeat([]byte("a"), 11)))
+	if !errors.Is(err, errDataTooLarge) {
+		t.Fatalf("expected %v, got %v", errDataTooLarge, err)
+	}
+}
@@ -0,0 +1,187 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"io"
+	"math"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+// healDriveThrottle rate limits the heal traffic of each drive to the
+// configured heal max_drive_rate, reads and writes share the budget.
+type healDriveThrottle struct {
+	mu       sync.Mutex
+	limiters map[string]*healDriveLimiter
+}
+
+var globalHealDriveThrottle = &healDriveThrottle{
+	limiters: make(map[string]*healDriveLimiter),
+}
+
+// healDriveLimiter is the limiter of a drive, mu makes changing the rate
+// and burst atomic for waiters, which size their waits by the burst.
+type healDriveLimiter struct {
+	mu sync.RWMutex
+	l  *rate.Limiter
+}
+
+func newHealDriveLimiter(limit rate.Limit, burst int) *healDriveLimiter {
+	return &healDriveLimiter{l: rate.NewLimiter(limit, burst)}
+}
+
+// set changes the rate and burst of the limiter.
+func (h *healDriveLimiter) set(limit rate.Limit, burst int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.l.Limit() != limit || h.l.Burst() != burst {
+		h.l.SetLimit(limit)
+		h.l.SetBurst(burst)
+	}
+}
+
+// Limit returns the current rate of the limiter.
+func (h *healDriveLimiter) Limit() rate.Limit {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.l.Limit()
+}
+
+// Burst returns the current burst of the limiter.
+func (h *healDriveLimiter) Burst() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.l.Burst()
+}
+
+// wait blocks until at most n bytes may be transferred, returns the
+// number of bytes granted.
+func (h *healDriveLimiter) wait(ctx context.Context, n int) (int, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	if burst := h.l.Burst(); n > burst {
+		n = burst
+	}
+	return n, h.l.WaitN(ctx, n)
+}
+
+// limiter returns the limiter of drive, nil if heal is not rate limited.
+func (t *healDriveThrottle) limiter(drive string) *healDriveLimiter {
+	bps := globalHealConfig.MaxDriveRate()
+	if bps == 0 {
+		return nil
+	}
+	burst := int(bps)
+	if bps > math.MaxInt32 {
+		burst = math.MaxInt32
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	l, ok := t.limiters[drive]
+	if !ok {
+		l = newHealDriveLimiter(rate.Limit(bps), burst)
+		t.limiters[drive] = l
+	} else {
+		// The rate may have been changed at runtime.
+		l.set(rate.Limit(bps), burst)
+	}
+	return l
+}
+
+// healThrottleWait blocks until n bytes may be transferred from or to the drive.
+func healThrottleWait(ctx context.Context, l *healDriveLimiter, n int) error {
+	for n > 0 {
+		granted, err := l.wait(ctx, n)
+		if err != nil {
+			return err
+		}
+		n -= granted
+	}
+	return nil
+}
+
+// healThrottledReader rate limits the reads of a bitrot reader.
+type healThrottledReader struct {
+	io.ReaderAt
+	ctx context.Context
+	l   *healDriveLimiter
+}
+
+func (r *healThrottledReader) ReadAt(p []byte, off int64) (int, error) {
+	n, err := r.ReaderAt.ReadAt(p, off)
+	if werr := healThrottleWait(r.ctx, r.l, n); err == nil {
+		err = werr
+	}
+	return n, err
+}
+
+func (r *healThrottledReader) Close() error {
+	if c, ok := r.ReaderAt.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// healThrottledWriter rate limits the writes of a bitrot writer.
+type healThrottledWriter struct {
+	io.Writer
+	ctx context.Context
+	l   *healDriveLimiter
+}
+
+func (w *healThrottledWriter) Write(p []byte) (int, error) {
+	if err := healThrottleWait(w.ctx, w.l, len(p)); err != nil {
+		return 0, err
+	}
+	return w.Writer.Write(p)
+}
+
+func (w *healThrottledWriter) Close() error {
+	if c, ok := w.Writer.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// throttleHealReaders rate limits the readers of the heal sources per drive.
+func throttleHealReaders(ctx context.Context, readers []io.ReaderAt, disks []StorageAPI) {
+	for i, r := range readers {
+		if r == nil || disks[i] == nil {
+			continue
+		}
+		if l := globalHealDriveThrottle.limiter(disks[i].String()); l != nil {
+			readers[i] = &healThrottledReader{ReaderAt: r, ctx: ctx, l: l}
+		}
+	}
+}
+
+// throttleHealWriters rate limits the writers of the healed drives.
+func throttleHealWriters(ctx context.Context, writers []io.Writer, disks []StorageAPI) {
+	for i, w := range writers {
+		if w == nil || disks[i] == nil {
+			continue
+		}
+		if l := globalHealDriveThrottle.limiter(disks[i].String()); l != nil {
+			writers[i] = &healThrottledWriter{Writer: w, ctx: ctx, l: l}
+		}
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/qkbyte/minio/internal/config/heal"
+	"golang.org/x/time/rate"
+)
+
+func TestHealDriveThrottleLimiter(t *testing.T) {
+	defer globalHealConfig.Update(heal.Config{})
+
+	throttle := &healDriveThrottle{limiters: make(map[string]*healDriveLimiter)}
+	globalHealConfig.Update(heal.Config{})
+	if l := throttle.limiter("/drive1"); l != nil {
+		t.Fatal("expected no limiter when heal is not rate limited")
+	}
+
+	globalHealConfig.Update(heal.Config{DriveRate: 1 << 20})
+	l := throttle.limiter("/drive1")
+	if l == nil || l.Limit() != rate.Limit(1<<20) || l.Burst() != 1<<20 {
+		t.Fatalf("unexpected limiter %v", l)
+	}
+	if throttle.limiter("/drive1") != l {
+		t.Fatal("expected the limiter of a drive to be reused")
+	}
+	if throttle.limiter("/drive2") == l {
+		t.Fatal("expected a limiter per drive")
+	}
+
+	globalHealConfig.Update(heal.Config{DriveRate: 2 << 20})
+	if l2 := throttle.limiter("/drive1"); l2 != l || l.Limit() != rate.Limit(2<<20) {
+		t.Fatal("expected the limiter to follow the configured rate")
+	}
+}
+
+func TestHealThrottledReadWrite(t *testing.T) {
+	l := newHealDriveLimiter(rate.Inf, 4)
+	var buf bytes.Buffer
+	w := &healThrottledWriter{Writer: &buf, ctx: context.Background(), l: l}
+	if _, err := w.Write([]byte("0123456789")); err != nil {
+		t.Fatal(err)
+	}
+	r := &healThrottledReader{ReaderAt: bytes.NewReader(buf.Bytes()), ctx: context.Background(), l: l}
+	p := make([]byte, 10)
+	if n, err := r.ReadAt(p, 0); err != nil && err != io.EOF || n != 10 || string(p) != "0123456789" {
+		t.Fatalf("unexpected read %q, %d, %v", p, n, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	w = &healThrottledWriter{Writer: &buf, ctx: ctx, l: newHealDriveLimiter(1, 1)}
+	if _, err := w.Write([]byte("0123")); err == nil {
+		t.Fatal("expected canceled write to fail")
+	}
+	if bitrotWriterSum(w) != nil {
+		t.Fatal("expected no sum for a non whole bitrot writer")
+	}
+}
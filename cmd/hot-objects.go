@@ -0,0 +1,214 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// One in hotObjectsSampleRate object requests is sampled.
+	hotObjectsSampleRate = 10
+
+	// Samples are kept in one minute slots for the last hour.
+	hotObjectsSlotDuration = time.Minute
+	hotObjectsSlots        = 60
+
+	// Maximum number of distinct objects sampled per slot, samples of
+	// other objects are dropped once reached.
+	hotObjectsMaxKeys = 10000
+
+	// Objects with fewer samples are not ranked by latency.
+	hotObjectsMinLatencySamples = 3
+
+	// Default and maximum number of objects reported.
+	hotObjectsDefaultCount = 10
+	hotObjectsMaxCount     = 1000
+)
+
+// hotObjectStat is the sampled activity of an object, Requests is the
+// estimated number of requests served for the object.
+type hotObjectStat struct {
+	Bucket       string        `json:"bucket"`
+	Object       string        `json:"object"`
+	Requests     uint64        `json:"requests"`
+	AvgLatency   time.Duration `json:"avgLatency"`
+	Samples      uint64        `json:"-"`
+	TotalLatency time.Duration `json:"-"`
+}
+
+func (s *hotObjectStat) merge(o hotObjectStat) {
+	s.Samples += o.Samples
+	s.TotalLatency += o.TotalLatency
+	s.Requests = s.Samples * hotObjectsSampleRate
+	s.AvgLatency = s.TotalLatency / time.Duration(s.Samples)
+}
+
+// hotObjectsReport reports the objects with the most requests and with
+// the highest average latency over the last hour.
+type hotObjectsReport struct {
+	Window     time.Duration     `json:"window"`
+	SampleRate int               `json:"sampleRate"`
+	ByRequests []hotObjectStat   `json:"byRequests"`
+	ByLatency  []hotObjectStat   `json:"byLatency"`
+	NodeErrors map[string]string `json:"nodeErrors,omitempty"`
+}
+
+type hotObjectsSlot struct {
+	start   int64
+	objects map[string]*hotObjectStat
+}
+
+// hotObjectsCollector samples object requests of this node.
+type hotObjectsCollector struct {
+	requests uint64 // atomic, used for sampling
+
+	mu    sync.Mutex
+	slots [hotObjectsSlots]hotObjectsSlot
+}
+
+func newHotObjectsCollector() *hotObjectsCollector {
+	return &hotObjectsCollector{}
+}
+
+// record samples a served request of bucket/object.
+func (c *hotObjectsCollector) record(bucket, object string, latency time.Duration, now time.Time) {
+	if bucket == "" || object == "" {
+		return
+	}
+	if atomic.AddUint64(&c.requests, 1)%hotObjectsSampleRate != 0 {
+		return
+	}
+
+	start := now.Truncate(hotObjectsSlotDuration).Unix()
+	key := pathJoin(bucket, object)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	slot := &c.slots[(start/int64(hotObjectsSlotDuration.Seconds()))%hotObjectsSlots]
+	if slot.start != start || slot.objects == nil {
+		slot.start = start
+		slot.objects = make(map[string]*hotObjectStat)
+	}
+	s, ok := slot.objects[key]
+	if !ok {
+		if len(slot.objects) >= hotObjectsMaxKeys {
+			return
+		}
+		s = &hotObjectStat{Bucket: bucket, Object: object}
+		slot.objects[key] = s
+	}
+	s.merge(hotObjectStat{Samples: 1, TotalLatency: latency})
+}
+
+// aggregate returns the samples of the last hour per object.
+func (c *hotObjectsCollector) aggregate(now time.Time) map[string]*hotObjectStat {
+	oldest := now.Add(-hotObjectsSlotDuration * hotObjectsSlots).Unix()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	objects := make(map[string]*hotObjectStat)
+	for i := range c.slots {
+		slot := &c.slots[i]
+		if slot.start <= oldest {
+			continue
+		}
+		for key, s := range slot.objects {
+			mergeHotObjectStat(objects, key, *s)
+		}
+	}
+	return objects
+}
+
+func mergeHotObjectStat(objects map[string]*hotObjectStat, key string, s hotObjectStat) {
+	t, ok := objects[key]
+	if !ok {
+		t = &hotObjectStat{Bucket: s.Bucket, Object: s.Object}
+		objects[key] = t
+	}
+	t.merge(s)
+}
+
+// report returns the top n objects of this node by requests and by
+// average latency.
+func (c *hotObjectsCollector) report(n int, now time.Time) hotObjectsReport {
+	return newHotObjectsReport(c.aggregate(now), n)
+}
+
+// newHotObjectsReport ranks objects by requests and by average latency.
+func newHotObjectsReport(objects map[string]*hotObjectStat, n int) hotObjectsReport {
+	report := hotObjectsReport{
+		Window:     hotObjectsSlotDuration * hotObjectsSlots,
+		SampleRate: hotObjectsSampleRate,
+		ByRequests: []hotObjectStat{},
+		ByLatency:  []hotObjectStat{},
+	}
+	for _, s := range objects {
+		report.ByRequests = append(report.ByRequests, *s)
+		if s.Samples >= hotObjectsMinLatencySamples {
+			report.ByLatency = append(report.ByLatency, *s)
+		}
+	}
+	sort.Slice(report.ByRequests, func(i, j int) bool {
+		a, b := report.ByRequests[i], report.ByRequests[j]
+		if a.Samples != b.Samples {
+			return a.Samples > b.Samples
+		}
+		return pathJoin(a.Bucket, a.Object) < pathJoin(b.Bucket, b.Object)
+	})
+	sort.Slice(report.ByLatency, func(i, j int) bool {
+		a, b := report.ByLatency[i], report.ByLatency[j]
+		if a.AvgLatency != b.AvgLatency {
+			return a.AvgLatency > b.AvgLatency
+		}
+		return pathJoin(a.Bucket, a.Object) < pathJoin(b.Bucket, b.Object)
+	})
+	if len(report.ByRequests) > n {
+		report.ByRequests = report.ByRequests[:n]
+	}
+	if len(report.ByLatency) > n {
+		report.ByLatency = report.ByLatency[:n]
+	}
+	return report
+}
+
+// mergeHotObjectsReports merges the per node reports into a cluster
+// report. Since nodes only report their own top objects the result is
+// an approximation for objects that are not hot on all nodes.
+func mergeHotObjectsReports(reports []hotObjectsReport, n int) hotObjectsReport {
+	objects := make(map[string]*hotObjectStat)
+	for _, report := range reports {
+		seen := make(map[string]struct{}, len(report.ByRequests)+len(report.ByLatency))
+		for _, list := range [][]hotObjectStat{report.ByRequests, report.ByLatency} {
+			for _, s := range list {
+				key := pathJoin(s.Bucket, s.Object)
+				if _, ok := seen[key]; ok {
+					continue
+				}
+				seen[key] = struct{}{}
+				mergeHotObjectStat(objects, key, s)
+			}
+		}
+	}
+	return newHotObjectsReport(objects, n)
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHotObjectsCollector(t *testing.T) {
+	c := newHotObjectsCollector()
+	now := time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// Every hotObjectsSampleRate-th request is sampled, so
+	// record each request of an object that many times.
+	record := func(bucket, object string, latency time.Duration, at time.Time, requests int) {
+		for i := 0; i < requests*hotObjectsSampleRate; i++ {
+			c.record(bucket, object, latency, at)
+		}
+	}
+	// Samples older than an hour are dropped.
+	record("bucket", "old", time.Millisecond, now.Add(-90*time.Minute), 10)
+	record("bucket", "hot", 10*time.Millisecond, now, 5)
+	record("bucket", "slow", time.Second, now.Add(time.Minute), 3)
+	record("bucket", "rare", 5*time.Second, now, 1)
+
+	report := c.report(10, now.Add(2*time.Minute))
+	if len(report.ByRequests) != 3 {
+		t.Fatalf("expected 3 objects by requests, got %v", report.ByRequests)
+	}
+	top := report.ByRequests[0]
+	if top.Object != "hot" || top.Requests != 5*hotObjectsSampleRate {
+		t.Fatalf("expected hot object with %d requests, got %+v", 5*hotObjectsSampleRate, top)
+	}
+	// rare has too few samples to be ranked by latency.
+	if len(report.ByLatency) != 2 || report.ByLatency[0].Object != "slow" || report.ByLatency[0].AvgLatency != time.Second {
+		t.Fatalf("expected slow object first by latency, got %v", report.ByLatency)
+	}
+
+	if report = c.report(1, now.Add(2*time.Minute)); len(report.ByRequests) != 1 || len(report.ByLatency) != 1 {
+		t.Fatalf("expected a single object per ranking, got %v and %v", report.ByRequests, report.ByLatency)
+	}
+
+	// Ignore requests without an object.
+	record("bucket", "", time.Millisecond, now, 10)
+	if report = c.report(10, now.Add(2*time.Minute)); len(report.ByRequests) != 3 {
+		t.Fatalf("expected 3 objects by requests, got %v", report.ByRequests)
+	}
+}
+
+func TestMergeHotObjectsReports(t *testing.T) {
+	stat := func(object string, samples uint64, latency time.Duration) hotObjectStat {
+		s := hotObjectStat{Bucket: "bucket", Object: object}
+		s.merge(hotObjectStat{Samples: samples, TotalLatency: latency * time.Duration(samples)})
+		return s
+	}
+	a := newHotObjectsReport(map[string]*hotObjectStat{
+		"bucket/x": ptrHotObjectStat(stat("x", 10, 10*time.Millisecond)),
+		"bucket/y": ptrHotObjectStat(stat("y", 4, 100*time.Millisecond)),
+	}, 10)
+	b := newHotObjectsReport(map[string]*hotObjectStat{
+		"bucket/y": ptrHotObjectStat(stat("y", 8, 400*time.Millisecond)),
+	}, 10)
+
+	merged := mergeHotObjectsReports([]hotObjectsReport{a, b}, 10)
+	if len(merged.ByRequests) != 2 {
+		t.Fatalf("expected 2 objects, got %v", merged.ByRequests)
+	}
+	y := merged.ByRequests[0]
+	// Objects listed in both rankings of a node are only counted once.
+	if y.Object != "y" || y.Samples != 12 || y.Requests != 12*hotObjectsSampleRate {
+		t.Fatalf("expected y with 12 samples, got %+v", y)
+	}
+	if y.AvgLatency != 300*time.Millisecond {
+		t.Fatalf("expected y average latency 300ms, got %v", y.AvgLatency)
+	}
+	if merged.ByLatency[0].Object != "y" || merged.ByLatency[1].Object != "x" {
+		t.Fatalf("unexpected latency ranking %v", merged.ByLatency)
+	}
+}
+
+func ptrHotObjectStat(s hotObjectStat) *hotObjectStat {
+	return &s
+}
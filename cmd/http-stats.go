@@ -22,7 +22,9 @@ import (
 	"strings"
 	"sync"
 	"sync/atomic"
+	"time"
 
+	"github.com/gorilla/mux"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/qkbyte/minio/internal/logger"
 )
@@ -247,6 +249,8 @@ type HTTPStats struct {
 	rejectedRequestsTime    uint64
 	rejectedRequestsHeader  uint64
 	rejectedRequestsInvalid uint64
+	rejectedRequestsSkew    uint64
+	rejectedRequestsCreds   uint64
 	currentS3Requests       HTTPAPIStats
 	totalS3Requests         HTTPAPIStats
 	totalS3Errors           HTTPAPIStats
@@ -273,6 +277,8 @@ func (st *HTTPStats) toServerHTTPStats() ServerHTTPStats {
 	serverStats.TotalS3RejectedTime = atomic.LoadUint64(&st.rejectedRequestsTime)
 	serverStats.TotalS3RejectedHeader = atomic.LoadUint64(&st.rejectedRequestsHeader)
 	serverStats.TotalS3RejectedInvalid = atomic.LoadUint64(&st.rejectedRequestsInvalid)
+	serverStats.TotalS3RejectedSkew = atomic.LoadUint64(&st.rejectedRequestsSkew)
+	serverStats.TotalS3RejectedCreds = atomic.LoadUint64(&st.rejectedRequestsCreds)
 	serverStats.CurrentS3Requests = ServerHTTPAPIStats{
 		APIStats: st.currentS3Requests.Load(),
 	}
@@ -307,6 +313,12 @@ func (st *HTTPStats) updateStats(api string, r *http.Request, w *logger.Response
 	httpRequestsDuration.With(prometheus.Labels{"api": api}).Observe(w.TimeToFirstByte.Seconds())
 
 	code := w.StatusCode
+	globalAPIAnomalyDetector.record(api, code, time.Since(w.StartTime), time.Now())
+
+	vars := mux.Vars(r)
+	if object, err := unescapePath(vars["object"]); err == nil {
+		globalHotObjects.record(vars["bucket"], object, time.Since(w.StartTime), time.Now())
+	}
 
 	switch {
 	case code == 0:
@@ -0,0 +1,78 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+
+	xhttp "github.com/qkbyte/minio/internal/http"
+	"github.com/qkbyte/minio/internal/logger"
+	"github.com/quic-go/quic-go/http3"
+)
+
+// newHTTP3Server returns the experimental HTTP/3 server of the S3 API,
+// it shares the TLS configuration, certificates and TLS policy included,
+// with the TCP listeners. addr is advertised when no socket is open.
+func newHTTP3Server(addr string, handler http.Handler, tlsConfig *tls.Config) *http3.Server {
+	return &http3.Server{
+		Addr:      addr,
+		Handler:   handler,
+		TLSConfig: tlsConfig,
+	}
+}
+
+// startHTTP3Server serves srv on the UDP port of every address until
+// it is closed, a failure to listen only disables HTTP/3, clients keep
+// using the TCP listeners.
+func startHTTP3Server(ctx context.Context, srv *http3.Server, addrs []string) {
+	conns, err := xhttp.ListenPacket(ctx, addrs)
+	if err != nil {
+		logger.LogIf(ctx, fmt.Errorf("unable to listen for HTTP/3: %w", err))
+		return
+	}
+	var wg sync.WaitGroup
+	for _, conn := range conns {
+		wg.Add(1)
+		go func(conn net.PacketConn) {
+			defer wg.Done()
+			if err := srv.Serve(conn); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				logger.LogIf(ctx, err)
+			}
+		}(conn)
+	}
+	wg.Wait()
+}
+
+// http3AltSvcHandler advertises the HTTP/3 listener with an Alt-Svc
+// header on responses sent over HTTP/1.1 and HTTP/2, clients that
+// support HTTP/3 switch to it for subsequent requests.
+func http3AltSvcHandler(srv *http3.Server, h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ProtoMajor < 3 {
+			// Nothing is advertised until the listener is up.
+			srv.SetQuicHeaders(w.Header())
+		}
+		h.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	xhttp "github.com/qkbyte/minio/internal/http"
+	"github.com/quic-go/quic-go/http3"
+)
+
+func TestHTTP3Server(t *testing.T) {
+	certPEM, keyPEM, err := generateTLSCertKey("127.0.0.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootCAs := x509.NewCertPool()
+	rootCAs.AppendCertsFromPEM(certPEM)
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("UDP not available: %v", err)
+	}
+	defer conn.Close()
+
+	// HTTP/3 requests are counted by the API server.
+	httpServer := xhttp.NewServer(nil)
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if n := httpServer.GetRequestCount(); n != 1 {
+			t.Errorf("expected the request to be counted, got %d", n)
+		}
+		io.WriteString(w, r.Proto)
+	})
+	getCert := func(*tls.ClientHelloInfo) (*tls.Certificate, error) { return &cert, nil }
+	srv := newHTTP3Server(conn.LocalAddr().String(), httpServer.WrapHandler(handler), newTLSConfig(getCert))
+	defer srv.Close()
+	go srv.Serve(conn)
+
+	rt := &http3.RoundTripper{TLSClientConfig: &tls.Config{RootCAs: rootCAs}}
+	defer rt.Close()
+	resp, err := (&http.Client{Transport: rt}).Get("https://" + conn.LocalAddr().String() + "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.ProtoMajor != 3 || string(body) != "HTTP/3.0" {
+		t.Fatalf("expected an HTTP/3 response, got %s %q", resp.Proto, body)
+	}
+
+	// Requests over TCP advertise the HTTP/3 listener.
+	altSvc := http3AltSvcHandler(srv, http.NotFoundHandler())
+	w := httptest.NewRecorder()
+	altSvc.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	_, port, _ := net.SplitHostPort(conn.LocalAddr().String())
+	if h := w.Header().Get("Alt-Svc"); !strings.Contains(h, `h3=":`+port+`"`) {
+		t.Fatalf("unexpected Alt-Svc header %q", h)
+	}
+}
+
+func TestHTTP3AltSvcNotListening(t *testing.T) {
+	srv := newHTTP3Server("127.0.0.1:9000", http.NotFoundHandler(), nil)
+	w := httptest.NewRecorder()
+	http3AltSvcHandler(srv, http.NotFoundHandler()).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if h := w.Header().Get("Alt-Svc"); h != "" {
+		t.Fatalf("expected no Alt-Svc header, got %q", h)
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/qkbyte/minio/internal/auth"
+)
+
+// Built-in session policy size limits.
+const (
+	stsSessionPolicyMaxSize    = 2048
+	svcAcctSessionPolicyMaxLen = 16 << 10
+)
+
+// credentialLimits bounds the credentials users may create
+// for themselves, they do not apply to the root user.
+type credentialLimits struct {
+	serviceAccountsMax      int
+	serviceAccountsGroupMax map[string]int
+	sessionMaxDuration      time.Duration
+	sessionPolicyMaxSize    int
+}
+
+// maxServiceAccounts returns the maximum number of service accounts
+// a member of groups may own, the strictest applicable limit wins and
+// zero means unlimited.
+func (l credentialLimits) maxServiceAccounts(groups []string) int {
+	limit := l.serviceAccountsMax
+	for _, group := range groups {
+		if groupLimit, ok := l.serviceAccountsGroupMax[group]; ok && groupLimit > 0 {
+			if limit == 0 || groupLimit < limit {
+				limit = groupLimit
+			}
+		}
+	}
+	return limit
+}
+
+// policyMaxSize returns the maximum session policy size,
+// the configured limit may only lower the built-in limit.
+func (l credentialLimits) policyMaxSize(builtin int) int {
+	if l.sessionPolicyMaxSize > 0 && l.sessionPolicyMaxSize < builtin {
+		return l.sessionPolicyMaxSize
+	}
+	return builtin
+}
+
+// limitSessionExpiry enforces the maximum session duration on the
+// expiry claim of temporary credentials, explicitly requesting a
+// longer duration is an error while longer defaults are shortened.
+func (l credentialLimits) limitSessionExpiry(claims map[string]interface{}, requestedDuration string) error {
+	if l.sessionMaxDuration <= 0 {
+		return nil
+	}
+	if requestedDuration != "" {
+		secs, err := strconv.ParseInt(requestedDuration, 10, 64)
+		if err == nil && time.Duration(secs)*time.Second > l.sessionMaxDuration {
+			return fmt.Errorf("requested duration %ss exceeds the maximum session duration of %v", requestedDuration, l.sessionMaxDuration)
+		}
+	}
+	maxExpiry := UTCNow().Add(l.sessionMaxDuration).Unix()
+	if expiry, err := auth.ExpToInt64(claims[expClaim]); err == nil && expiry > maxExpiry {
+		claims[expClaim] = maxExpiry
+	}
+	return nil
+}
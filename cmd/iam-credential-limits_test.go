@@ -0,0 +1,79 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCredentialLimits(t *testing.T) {
+	limits := credentialLimits{
+		serviceAccountsMax:      10,
+		serviceAccountsGroupMax: map[string]int{"devs": 5, "ci": 20, "ops": 0},
+		sessionMaxDuration:      time.Hour,
+		sessionPolicyMaxSize:    1024,
+	}
+
+	testCases := []struct {
+		groups []string
+		limit  int
+	}{
+		{groups: nil, limit: 10},
+		{groups: []string{"devs"}, limit: 5},
+		{groups: []string{"ci"}, limit: 10},
+		{groups: []string{"ci", "devs"}, limit: 5},
+		{groups: []string{"ops"}, limit: 10},
+	}
+	for i, testCase := range testCases {
+		if limit := limits.maxServiceAccounts(testCase.groups); limit != testCase.limit {
+			t.Errorf("Test %d: expected limit %d, got %d", i+1, testCase.limit, limit)
+		}
+	}
+	if limit := (credentialLimits{serviceAccountsGroupMax: map[string]int{"devs": 5}}).maxServiceAccounts([]string{"devs"}); limit != 5 {
+		t.Errorf("expected group limit without user limit, got %d", limit)
+	}
+
+	if size := limits.policyMaxSize(stsSessionPolicyMaxSize); size != 1024 {
+		t.Errorf("expected configured policy size, got %d", size)
+	}
+	if size := (credentialLimits{sessionPolicyMaxSize: 1 << 20}).policyMaxSize(stsSessionPolicyMaxSize); size != stsSessionPolicyMaxSize {
+		t.Errorf("expected built-in policy size, got %d", size)
+	}
+
+	claims := map[string]interface{}{expClaim: UTCNow().Add(12 * time.Hour).Unix()}
+	if err := limits.limitSessionExpiry(claims, ""); err != nil {
+		t.Fatal(err)
+	}
+	if expiry := claims[expClaim].(int64); expiry > UTCNow().Add(time.Hour).Unix() {
+		t.Errorf("expected expiry to be limited, got %d", expiry)
+	}
+	claims = map[string]interface{}{expClaim: float64(UTCNow().Add(12 * time.Hour).Unix())}
+	if err := limits.limitSessionExpiry(claims, ""); err != nil {
+		t.Fatal(err)
+	}
+	if expiry, ok := claims[expClaim].(int64); !ok || expiry > UTCNow().Add(time.Hour).Unix() {
+		t.Errorf("expected float64 expiry to be limited, got %v", claims[expClaim])
+	}
+	if err := limits.limitSessionExpiry(claims, "7200"); err == nil {
+		t.Error("expected error for requested duration beyond the limit")
+	}
+	if err := limits.limitSessionExpiry(claims, "1800"); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+}
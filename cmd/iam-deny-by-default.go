@@ -0,0 +1,114 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	iampolicy "github.com/minio/pkg/iam/policy"
+	"github.com/qkbyte/minio/internal/logger"
+)
+
+// isBreakGlassAccount returns true if accessKey is the configured
+// break-glass credential of a cluster running in deny-by-default mode.
+func isBreakGlassAccount(accessKey string) bool {
+	return globalIAMDenyByDefault && globalIAMBreakGlassAccessKey != "" &&
+		accessKey == globalIAMBreakGlassAccessKey
+}
+
+// isOwnerPrivileged returns true if the owner credentials, or credentials
+// derived from them, bypass policy evaluation. In deny-by-default mode
+// the owner is treated like any other account, unless it is also the
+// break-glass credential.
+func isOwnerPrivileged(accessKey string) bool {
+	return !globalIAMDenyByDefault || isBreakGlassAccount(accessKey)
+}
+
+// privilegedAccessAlertInterval is the minimum interval between two
+// alerts for the same kind of privileged credential.
+const privilegedAccessAlertInterval = time.Minute
+
+// privilegedAccessAlerts rate limits privileged access alerts, uses
+// within the interval are counted and reported with the next alert.
+var privilegedAccessAlerts = &privilegedAccessLimiter{
+	alerts: make(map[string]privilegedAccessAlert),
+}
+
+type privilegedAccessAlert struct {
+	last       time.Time
+	suppressed int
+}
+
+type privilegedAccessLimiter struct {
+	sync.Mutex
+	alerts map[string]privilegedAccessAlert
+}
+
+// allow returns true if an alert for kind may be raised at now, along
+// with the number of uses suppressed since the previous alert.
+func (l *privilegedAccessLimiter) allow(kind string, now time.Time) (int, bool) {
+	l.Lock()
+	defer l.Unlock()
+
+	a := l.alerts[kind]
+	if now.Sub(a.last) < privilegedAccessAlertInterval {
+		a.suppressed++
+		l.alerts[kind] = a
+		return 0, false
+	}
+	l.alerts[kind] = privilegedAccessAlert{last: now}
+	return a.suppressed, true
+}
+
+// alertPrivilegedAccess raises an alert for the use of the root or
+// break-glass credential while the cluster runs in deny-by-default mode.
+// Alerts are logged and sent to the audit targets, at most once per
+// privilegedAccessAlertInterval for each kind of credential.
+func alertPrivilegedAccess(args iampolicy.Args) {
+	if !globalIAMDenyByDefault {
+		return
+	}
+	var kind string
+	switch {
+	case isBreakGlassAccount(args.AccountName):
+		kind = "break-glass"
+	case args.IsOwner || args.AccountName == globalActiveCred.AccessKey:
+		kind = "root"
+	default:
+		return
+	}
+	suppressed, ok := privilegedAccessAlerts.allow(kind, UTCNow())
+	if !ok {
+		return
+	}
+
+	err := fmt.Errorf("%s credential '%s' used for action '%s' on '%s/%s'",
+		kind, args.AccountName, args.Action, args.BucketName, args.ObjectName)
+	if suppressed > 0 {
+		err = fmt.Errorf("%w, %d more uses since the last alert", err, suppressed)
+	}
+	logger.LogAlwaysIf(GlobalContext, err)
+	auditLogInternal(GlobalContext, args.BucketName, args.ObjectName, AuditLogOptions{
+		Event:   "privileged-access",
+		APIName: string(args.Action),
+		Status:  kind,
+		Error:   err.Error(),
+	})
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIsOwnerPrivileged(t *testing.T) {
+	defer func(deny bool, breakGlass string) {
+		globalIAMDenyByDefault, globalIAMBreakGlassAccessKey = deny, breakGlass
+	}(globalIAMDenyByDefault, globalIAMBreakGlassAccessKey)
+
+	testCases := []struct {
+		deny       bool
+		breakGlass string
+		accessKey  string
+		privileged bool
+		isBreak    bool
+	}{
+		{false, "", "minio", true, false},
+		{false, "minio", "minio", true, false},
+		{true, "", "minio", false, false},
+		{true, "emergency", "minio", false, false},
+		{true, "minio", "minio", true, true},
+		{true, "emergency", "emergency", true, true},
+	}
+	for i, tc := range testCases {
+		globalIAMDenyByDefault, globalIAMBreakGlassAccessKey = tc.deny, tc.breakGlass
+		if got := isOwnerPrivileged(tc.accessKey); got != tc.privileged {
+			t.Errorf("Test %d: expected privileged %v, got %v", i+1, tc.privileged, got)
+		}
+		if got := isBreakGlassAccount(tc.accessKey); got != tc.isBreak {
+			t.Errorf("Test %d: expected break-glass %v, got %v", i+1, tc.isBreak, got)
+		}
+	}
+}
+
+func TestPrivilegedAccessLimiter(t *testing.T) {
+	l := &privilegedAccessLimiter{alerts: make(map[string]privilegedAccessAlert)}
+	now := time.Now()
+
+	if _, ok := l.allow("root", now); !ok {
+		t.Fatal("expected first alert to be raised")
+	}
+	for i := 0; i < 3; i++ {
+		if _, ok := l.allow("root", now.Add(time.Second)); ok {
+			t.Fatal("expected alert to be rate limited")
+		}
+	}
+	if _, ok := l.allow("break-glass", now.Add(time.Second)); !ok {
+		t.Fatal("expected alerts to be limited per kind")
+	}
+	suppressed, ok := l.allow("root", now.Add(privilegedAccessAlertInterval))
+	if !ok || suppressed != 3 {
+		t.Fatalf("expected alert with 3 suppressed uses, got %v %d", ok, suppressed)
+	}
+	if _, ok = l.allow("root", now.Add(privilegedAccessAlertInterval+time.Second)); ok {
+		t.Fatal("expected alert to be rate limited")
+	}
+}
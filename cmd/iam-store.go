@@ -1675,8 +1675,9 @@ func (store *IAMStoreSys) SetUserStatus(ctx context.Context, accessKey string, s
 	return uinfo.UpdatedAt, nil
 }
 
-// AddServiceAccount - add a new service account
-func (store *IAMStoreSys) AddServiceAccount(ctx context.Context, cred auth.Credentials) (updatedAt time.Time, err error) {
+// AddServiceAccount - add a new service account, a positive limit bounds
+// the number of service accounts the parent user may own.
+func (store *IAMStoreSys) AddServiceAccount(ctx context.Context, cred auth.Credentials, limit int) (updatedAt time.Time, err error) {
 	cache := store.lock()
 	defer store.unlock()
 
@@ -1699,6 +1700,19 @@ func (store *IAMStoreSys) AddServiceAccount(ctx context.Context, cred auth.Crede
 		return updatedAt, errIAMServiceAccount
 	}
 
+	// Count under the lock so concurrent creates cannot exceed the limit.
+	if limit > 0 {
+		var count int
+		for _, u := range cache.iamUsersMap {
+			if u.Credentials.IsServiceAccount() && u.Credentials.ParentUser == parentUser {
+				count++
+			}
+		}
+		if count >= limit {
+			return updatedAt, errServiceAccountLimitExceeded
+		}
+	}
+
 	u := newUserIdentity(cred)
 	err = store.saveUserIdentity(ctx, u.Credentials.AccessKey, svcUser, u)
 	if err != nil {
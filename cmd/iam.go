@@ -886,6 +886,10 @@ type newServiceAccountOpts struct {
 	secretKey     string
 
 	claims map[string]interface{}
+
+	// maxServiceAccounts limits the number of service accounts
+	// of the parent user, zero means unlimited.
+	maxServiceAccounts int
 }
 
 // NewServiceAccount - create a new service account
@@ -955,7 +959,7 @@ func (sys *IAMSys) NewServiceAccount(ctx context.Context, parentUser string, gro
 	cred.Groups = groups
 	cred.Status = string(auth.AccountOn)
 
-	updatedAt, err := sys.store.AddServiceAccount(ctx, cred)
+	updatedAt, err := sys.store.AddServiceAccount(ctx, cred, opts.maxServiceAccounts)
 	if err != nil {
 		return auth.Credentials{}, time.Time{}, err
 	}
@@ -1494,7 +1498,7 @@ func (sys *IAMSys) IsAllowedServiceAccount(args iampolicy.Args, parentUser strin
 		return false
 	}
 
-	isOwnerDerived := parentUser == globalActiveCred.AccessKey
+	isOwnerDerived := parentUser == globalActiveCred.AccessKey && isOwnerPrivileged(parentUser)
 
 	var err error
 	var svcPolicies []string
@@ -1605,7 +1609,7 @@ func (sys *IAMSys) IsAllowedServiceAccount(args iampolicy.Args, parentUser strin
 func (sys *IAMSys) IsAllowedSTS(args iampolicy.Args, parentUser string) bool {
 	// 1. Determine mapped policies
 
-	isOwnerDerived := parentUser == globalActiveCred.AccessKey
+	isOwnerDerived := parentUser == globalActiveCred.AccessKey && isOwnerPrivileged(parentUser)
 	var policies []string
 	roleArn := args.GetRoleArn()
 
@@ -1743,8 +1747,11 @@ func (sys *IAMSys) IsAllowed(args iampolicy.Args) bool {
 		return ok
 	}
 
-	// Policies don't apply to the owner.
-	if args.IsOwner {
+	alertPrivilegedAccess(args)
+
+	// Policies don't apply to the owner, in deny-by-default mode they
+	// are only bypassed by the break-glass credential.
+	if (args.IsOwner && isOwnerPrivileged(args.AccountName)) || isBreakGlassAccount(args.AccountName) {
 		return true
 	}
 
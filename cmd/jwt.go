@@ -154,7 +154,7 @@ func metricsRequestAuthenticate(req *http.Request) (*xjwt.MapClaims, []string, b
 	}); err != nil {
 		return claims, nil, false, errAuthentication
 	}
-	owner := true
+	owner := isOwnerPrivileged(claims.AccessKey)
 	var groups []string
 	if globalActiveCred.AccessKey != claims.AccessKey {
 		// Check if the access key is part of users credentials.
@@ -177,7 +177,7 @@ func metricsRequestAuthenticate(req *http.Request) (*xjwt.MapClaims, []string, b
 		if _, ok = eclaims[iampolicy.SessionPolicyName]; ok {
 			owner = false
 		} else {
-			owner = globalActiveCred.AccessKey == ucred.ParentUser
+			owner = globalActiveCred.AccessKey == ucred.ParentUser && isOwnerPrivileged(ucred.ParentUser)
 		}
 
 		groups = ucred.Groups
@@ -0,0 +1,180 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/minio/kes"
+	"github.com/qkbyte/minio/internal/kms"
+	"github.com/qkbyte/minio/internal/logger"
+)
+
+// kmsKeyUsage - objects, versions and bytes encrypted with a KMS master
+// key as of the last scanner cycle.
+type kmsKeyUsage struct {
+	Objects  int    `json:"objects"`
+	Versions int    `json:"versions"`
+	Size     uint64 `json:"size"`
+}
+
+func (u kmsKeyUsage) add(st tierStats) kmsKeyUsage {
+	u.Objects += st.NumObjects
+	u.Versions += st.NumVersions
+	u.Size += st.TotalSize
+	return u
+}
+
+// kmsKeyReport - usage of a KMS master key. Listed is set for keys
+// known to the KMS when it supports listing keys, a key used by objects
+// but not listed may have been deleted.
+type kmsKeyReport struct {
+	KeyID          string                 `json:"keyId"`
+	Default        bool                   `json:"default,omitempty"`
+	Listed         bool                   `json:"listed,omitempty"`
+	BucketDefaults []string               `json:"bucketDefaults,omitempty"`
+	Usage          kmsKeyUsage            `json:"usage"`
+	Buckets        map[string]kmsKeyUsage `json:"buckets,omitempty"`
+}
+
+// kmsReport - KMS health along with the master keys in use, Latency is
+// the time taken to generate and decrypt a data key.
+type kmsReport struct {
+	Name         string         `json:"name"`
+	Endpoints    []string       `json:"endpoints,omitempty"`
+	DefaultKeyID string         `json:"defaultKeyId,omitempty"`
+	Online       bool           `json:"online"`
+	Latency      string         `json:"latency,omitempty"`
+	Error        string         `json:"error,omitempty"`
+	UsageUpdated time.Time      `json:"usageUpdated"`
+	Keys         []kmsKeyReport `json:"keys"`
+}
+
+// checkKMS returns the time taken to generate a data key with keyID and
+// to decrypt it again.
+func checkKMS(ctx context.Context, k kms.KMS, keyID string) (time.Duration, error) {
+	kmsContext := kms.Context{"MinIO admin API": "KMSReportHandler"} // Context for a test key operation
+
+	start := time.Now()
+	key, err := k.GenerateKey(ctx, keyID, kmsContext)
+	if err != nil {
+		return 0, err
+	}
+	decryptedKey, err := k.DecryptKey(key.KeyID, key.Ciphertext, kmsContext)
+	if err != nil {
+		return 0, err
+	}
+	if subtle.ConstantTimeCompare(key.Plaintext, decryptedKey) != 1 {
+		return 0, errors.New("The generated and the decrypted data key do not match")
+	}
+	return time.Since(start), nil
+}
+
+// buildKMSReport reports the health of the KMS and maps the master keys
+// to the buckets using them, either by default or for stored objects.
+func buildKMSReport(ctx context.Context, objAPI ObjectLayer, k kms.KMS) (kmsReport, error) {
+	var report kmsReport
+	keys := make(map[string]*kmsKeyReport)
+	key := func(keyID string) *kmsKeyReport {
+		r, ok := keys[keyID]
+		if !ok {
+			r = &kmsKeyReport{KeyID: keyID}
+			keys[keyID] = r
+		}
+		return r
+	}
+
+	stat, err := k.Stat(ctx)
+	if err != nil {
+		report.Error = err.Error()
+	} else {
+		report.Name = stat.Name
+		report.Endpoints = stat.Endpoints
+		report.DefaultKeyID = stat.DefaultKey
+		if stat.DefaultKey != "" {
+			key(stat.DefaultKey).Default = true
+		}
+		if latency, err := checkKMS(ctx, k, stat.DefaultKey); err != nil {
+			report.Error = err.Error()
+		} else {
+			report.Online = true
+			report.Latency = latency.String()
+		}
+	}
+
+	if manager, ok := k.(kms.KeyManager); ok && report.Online {
+		it, err := manager.ListKeys(ctx, "*")
+		if err == nil {
+			var infos []kes.KeyInfo
+			if infos, err = it.Values(0); err == nil {
+				for _, info := range infos {
+					key(info.Name).Listed = true
+				}
+			}
+		}
+		logger.LogIf(ctx, err)
+	}
+
+	buckets, err := objAPI.ListBuckets(ctx, BucketOptions{})
+	if err != nil {
+		return report, err
+	}
+	for _, bucket := range buckets {
+		sseConfig, err := globalBucketSSEConfigSys.Get(bucket.Name)
+		if err != nil || sseConfig.Algo() == "" {
+			continue
+		}
+		keyID := sseConfig.KeyID()
+		if keyID == "" {
+			keyID = report.DefaultKeyID
+		}
+		r := key(keyID)
+		r.BucketDefaults = append(r.BucketDefaults, bucket.Name)
+	}
+
+	dataUsageInfo, err := loadDataUsageFromBackend(ctx, objAPI)
+	if err != nil {
+		return report, err
+	}
+	report.UsageUpdated = dataUsageInfo.LastUpdate
+	for bucket, bui := range dataUsageInfo.BucketsUsage {
+		if bui.KMSKeyStats == nil {
+			continue
+		}
+		for keyID, st := range bui.KMSKeyStats.Tiers {
+			r := key(keyID)
+			if r.Buckets == nil {
+				r.Buckets = make(map[string]kmsKeyUsage)
+			}
+			r.Buckets[bucket] = r.Buckets[bucket].add(st)
+			r.Usage = r.Usage.add(st)
+		}
+	}
+
+	report.Keys = make([]kmsKeyReport, 0, len(keys))
+	for _, r := range keys {
+		sort.Strings(r.BucketDefaults)
+		report.Keys = append(report.Keys, *r)
+	}
+	sort.Slice(report.Keys, func(i, j int) bool { return report.Keys[i].KeyID < report.Keys[j].KeyID })
+	return report, nil
+}
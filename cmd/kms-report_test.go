@@ -0,0 +1,91 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/qkbyte/minio/internal/kms"
+)
+
+func TestBuildKMSReport(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+	setObjectLayer(objLayer)
+	defer setObjectLayer(nil)
+
+	if err = newTestConfig(globalMinioDefaultRegion, objLayer); err != nil {
+		t.Fatal(err)
+	}
+
+	initAllSubsystems()
+
+	const bucket = "bucket"
+	if err = objLayer.MakeBucketWithLocation(ctx, bucket, MakeBucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	dui := DataUsageInfo{
+		LastUpdate: UTCNow(),
+		BucketsUsage: map[string]BucketUsageInfo{
+			bucket: {
+				KMSKeyStats: &allTierStats{Tiers: map[string]tierStats{
+					"my-key":  {TotalSize: 100, NumVersions: 3, NumObjects: 2},
+					"old-key": {TotalSize: 10, NumVersions: 1, NumObjects: 1},
+				}},
+			},
+		},
+	}
+	data, err := json.Marshal(dui)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = saveConfig(ctx, objLayer, dataUsageObjNamePath, data); err != nil {
+		t.Fatal(err)
+	}
+
+	k, err := kms.New("my-key", make([]byte, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	report, err := buildKMSReport(ctx, objLayer, k)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.Online || report.Latency == "" || report.DefaultKeyID != "my-key" {
+		t.Fatalf("unexpected KMS status %+v", report)
+	}
+	if len(report.Keys) != 2 {
+		t.Fatalf("expected 2 keys, got %+v", report.Keys)
+	}
+	if key := report.Keys[0]; key.KeyID != "my-key" || !key.Default || key.Usage.Versions != 3 || key.Buckets[bucket].Objects != 2 {
+		t.Fatalf("unexpected key report %+v", key)
+	}
+	if key := report.Keys[1]; key.KeyID != "old-key" || key.Default || key.Usage.Size != 10 {
+		t.Fatalf("unexpected key report %+v", key)
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	xhttp "github.com/qkbyte/minio/internal/http"
+)
+
+// listBarrierCtxKey carries the read-after-write barrier of a listing
+// request down to the object layer.
+type listBarrierCtxKey struct{}
+
+// withListBarrier returns a context requiring listings to reflect all
+// writes acknowledged before barrier.
+func withListBarrier(ctx context.Context, barrier time.Time) context.Context {
+	return context.WithValue(ctx, listBarrierCtxKey{}, barrier)
+}
+
+// listBarrierFromContext returns the barrier set with withListBarrier,
+// zero if the listing has none.
+func listBarrierFromContext(ctx context.Context) time.Time {
+	barrier, _ := ctx.Value(listBarrierCtxKey{}).(time.Time)
+	return barrier
+}
+
+// parseListBarrier returns the read-after-write barrier requested for a
+// listing of prefix in bucket, zero if none was requested. The barrier
+// is the time given in X-Minio-List-Barrier or the modification time of
+// the object given in X-Minio-List-Barrier-Object, whichever is later.
+// When X-Minio-List-Barrier-Etag is set the object must still have that
+// ETag, so that a client gets an error instead of a listing that does
+// not reflect its own write.
+func parseListBarrier(ctx context.Context, objectAPI ObjectLayer, bucket, prefix string, h http.Header) (barrier time.Time, errCode APIErrorCode) {
+	if v := h.Get(xhttp.MinIOListBarrier); v != "" {
+		t, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			return barrier, ErrMalformedDate
+		}
+		barrier = t.UTC()
+	}
+
+	object := h.Get(xhttp.MinIOListBarrierObject)
+	etag := h.Get(xhttp.MinIOListBarrierETag)
+	switch {
+	case object == "" && etag != "":
+		return barrier, ErrInvalidRequest
+	case object != "":
+		// The object must be part of the listing, this also keeps
+		// the barrier from probing objects outside of the prefix
+		// the client is allowed to list.
+		if !HasPrefix(object, prefix) {
+			return barrier, ErrInvalidRequest
+		}
+		objInfo, err := objectAPI.GetObjectInfo(ctx, bucket, object, ObjectOptions{})
+		if err != nil {
+			if isErrObjectNotFound(err) || isErrVersionNotFound(err) || isErrMethodNotAllowed(err) {
+				return barrier, ErrPreconditionFailed
+			}
+			return barrier, toAPIErrorCode(ctx, err)
+		}
+		if etag != "" {
+			objects := []ObjectInfo{objInfo}
+			if err = DecryptETags(ctx, GlobalKMS, objects); err != nil {
+				return barrier, toAPIErrorCode(ctx, err)
+			}
+			if !isETagEqual(objects[0].ETag, etag) {
+				return barrier, ErrPreconditionFailed
+			}
+		}
+		if objInfo.ModTime.After(barrier) {
+			barrier = objInfo.ModTime
+		}
+	}
+
+	// Nothing can have been acknowledged after now, a barrier far in
+	// the future is most likely a client clock problem.
+	if now := UTCNow(); barrier.After(now) {
+		if barrier.Sub(now) > globalMaxSkewTime {
+			return barrier, ErrRequestTimeTooSkewed
+		}
+		barrier = now
+	}
+	return barrier, ErrNone
+}
+
+// listBarrierContext returns ctx carrying the barrier requested by the
+// headers of a listing request, see parseListBarrier.
+func listBarrierContext(ctx context.Context, objectAPI ObjectLayer, bucket, prefix string, h http.Header) (context.Context, APIErrorCode) {
+	barrier, errCode := parseListBarrier(ctx, objectAPI, bucket, prefix, h)
+	if errCode != ErrNone || barrier.IsZero() {
+		return ctx, errCode
+	}
+	return withListBarrier(ctx, barrier), ErrNone
+}
+
+// applyListBarrier makes the listing reflect all writes acknowledged
+// before barrier: all drives are asked, so that a write that only made
+// it to its write quorum is seen, and objects up to the barrier are
+// returned even if the listing session started earlier.
+func (o *listPathOptions) applyListBarrier(barrier time.Time) {
+	if barrier.IsZero() {
+		return
+	}
+	o.AskDisks = "strict"
+	if o.Snapshot.Before(barrier) {
+		o.Snapshot = barrier
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	xhttp "github.com/qkbyte/minio/internal/http"
+)
+
+func TestParseListBarrier(t *testing.T) {
+	ExecObjectLayerTest(t, testParseListBarrier)
+}
+
+func testParseListBarrier(obj ObjectLayer, instanceType string, t1 TestErrHandler) {
+	t, _ := t1.(*testing.T)
+	ctx := context.Background()
+	bucket := "barrier-bucket"
+	if err := obj.MakeBucketWithLocation(ctx, bucket, MakeBucketOptions{}); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	objInfo, err := obj.PutObject(ctx, bucket, "dir/obj", mustGetPutObjReader(t, bytes.NewReader([]byte("x")), 1, "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+
+	past := objInfo.ModTime.Add(-time.Hour)
+	testCases := []struct {
+		headers map[string]string
+		prefix  string
+		barrier time.Time
+		errCode APIErrorCode
+	}{
+		{nil, "", time.Time{}, ErrNone},
+		{map[string]string{xhttp.MinIOListBarrier: past.Format(time.RFC3339Nano)}, "", past, ErrNone},
+		{map[string]string{xhttp.MinIOListBarrier: "yesterday"}, "", time.Time{}, ErrMalformedDate},
+		{map[string]string{xhttp.MinIOListBarrier: UTCNow().Add(time.Hour).Format(time.RFC3339Nano)}, "", time.Time{}, ErrRequestTimeTooSkewed},
+		{map[string]string{xhttp.MinIOListBarrierObject: "dir/obj"}, "dir/", objInfo.ModTime, ErrNone},
+		{map[string]string{xhttp.MinIOListBarrierObject: "dir/obj", xhttp.MinIOListBarrierETag: `"` + objInfo.ETag + `"`}, "", objInfo.ModTime, ErrNone},
+		{map[string]string{xhttp.MinIOListBarrierObject: "dir/obj", xhttp.MinIOListBarrier: past.Format(time.RFC3339Nano)}, "", objInfo.ModTime, ErrNone},
+		{map[string]string{xhttp.MinIOListBarrierObject: "dir/obj", xhttp.MinIOListBarrierETag: "deadbeef"}, "", time.Time{}, ErrPreconditionFailed},
+		{map[string]string{xhttp.MinIOListBarrierObject: "dir/missing"}, "", time.Time{}, ErrPreconditionFailed},
+		{map[string]string{xhttp.MinIOListBarrierObject: "dir/obj"}, "other/", time.Time{}, ErrInvalidRequest},
+		{map[string]string{xhttp.MinIOListBarrierETag: objInfo.ETag}, "", time.Time{}, ErrInvalidRequest},
+	}
+	for i, tc := range testCases {
+		h := make(http.Header)
+		for k, v := range tc.headers {
+			h.Set(k, v)
+		}
+		barrier, errCode := parseListBarrier(ctx, obj, bucket, tc.prefix, h)
+		if errCode != tc.errCode {
+			t.Fatalf("%s: test %d: expected error code %v, got %v", instanceType, i+1, tc.errCode, errCode)
+		}
+		if errCode == ErrNone && !barrier.Equal(tc.barrier) {
+			t.Fatalf("%s: test %d: expected barrier %v, got %v", instanceType, i+1, tc.barrier, barrier)
+		}
+	}
+
+	// A barrier slightly ahead of this clock is capped to now.
+	h := make(http.Header)
+	h.Set(xhttp.MinIOListBarrier, UTCNow().Add(time.Minute).Format(time.RFC3339Nano))
+	barrier, errCode := parseListBarrier(ctx, obj, bucket, "", h)
+	if errCode != ErrNone || barrier.After(UTCNow()) {
+		t.Fatalf("%s: expected barrier capped to now, got %v %v", instanceType, barrier, errCode)
+	}
+}
+
+func TestListObjectsBarrier(t *testing.T) {
+	ExecObjectLayerTest(t, testListObjectsBarrier)
+}
+
+// Objects created after a listing started are returned by the following
+// pages when they were acknowledged before the barrier of the request,
+// even when the pages are otherwise served from the listing cache.
+func testListObjectsBarrier(obj ObjectLayer, instanceType string, t1 TestErrHandler) {
+	t, _ := t1.(*testing.T)
+	ctx := context.Background()
+	bucket := "barrier-bucket"
+	if err := obj.MakeBucketWithLocation(ctx, bucket, MakeBucketOptions{}); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	put := func(object string) {
+		if _, err := obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader([]byte("x")), 1, "", ""), ObjectOptions{}); err != nil {
+			t.Fatalf("%s: %v", instanceType, err)
+		}
+	}
+	for _, object := range []string{"a", "b", "c", "d"} {
+		put(object)
+	}
+
+	// The second page starts the listing cache.
+	var marker string
+	for i := 0; i < 2; i++ {
+		res, err := obj.ListObjects(ctx, bucket, "", marker, "", 1)
+		if err != nil {
+			t.Fatalf("%s: %v", instanceType, err)
+		}
+		if !res.IsTruncated {
+			t.Fatalf("%s: unexpected page %+v", instanceType, res)
+		}
+		marker = res.NextMarker
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	put("b2")
+	put("e")
+
+	barrierCtx := withListBarrier(ctx, UTCNow())
+	var names []string
+	for marker != "" {
+		res, err := obj.ListObjects(barrierCtx, bucket, "", marker, "", 1)
+		if err != nil {
+			t.Fatalf("%s: %v", instanceType, err)
+		}
+		for _, oi := range res.Objects {
+			names = append(names, oi.Name)
+		}
+		marker = res.NextMarker
+		if !res.IsTruncated {
+			break
+		}
+	}
+	if strings.Join(names, ",") != "b2,c,d,e" {
+		t.Fatalf("%s: expected b2,c,d,e in the remaining pages, got %v", instanceType, names)
+	}
+}
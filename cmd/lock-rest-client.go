@@ -152,9 +152,11 @@ func newlockRESTClient(endpoint Endpoint) *lockRESTClient {
 	}
 
 	restClient := rest.NewClient(serverURL, globalInternodeTransport, newCachedAuthToken())
+	restClient.HandshakeFn = globalPeerHandshakes.observe
 	restClient.ExpectTimeouts = true
 	// Use a separate client to avoid recursive calls.
 	healthClient := rest.NewClient(serverURL, globalInternodeTransport, newCachedAuthToken())
+	healthClient.HandshakeFn = globalPeerHandshakes.observe
 	healthClient.ExpectTimeouts = true
 	healthClient.NoMetrics = true
 	restClient.HealthCheckFn = func() bool {
@@ -49,6 +49,7 @@ func (l *lockRESTServer) writeErrorResponse(w http.ResponseWriter, err error) {
 
 // IsValid - To authenticate and verify the time difference.
 func (l *lockRESTServer) IsValid(w http.ResponseWriter, r *http.Request) bool {
+	setPeerHandshakeHeaders(w)
 	if l.ll == nil {
 		l.writeErrorResponse(w, errLockNotInitialized)
 		return false
@@ -0,0 +1,254 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// listCoalesceMaxAge is how long a listing cache started by one client
+// is handed to other clients listing the same path.
+const listCoalesceMaxAge = metacacheMaxRunningAge
+
+// coalescedWalk is a raw listing in flight, the clients that requested
+// the same page meanwhile wait for its result instead of walking the
+// drives again.
+type coalescedWalk struct {
+	started time.Time
+	waiters int
+	done    chan struct{}
+
+	// Set before done is closed.
+	entries  metaCacheEntriesSorted
+	err      error
+	canceled bool
+}
+
+// coalescedCache is a listing cache being saved that other clients can
+// resume from, starting at marker or later.
+type coalescedCache struct {
+	id        string
+	pool, set int
+	marker    string
+	started   time.Time
+}
+
+// listCoalescer lets concurrent identical listings on this node share a
+// single walk of the drives. Identical raw listings in flight return the
+// same entries, and listings that need a cache resume from one another
+// client started instead of each spawning listAndSave.
+type listCoalescer struct {
+	mu     sync.Mutex
+	walks  map[string]*coalescedWalk
+	caches map[string][]coalescedCache
+
+	requests    uint64
+	sharedWalks uint64
+	sharedCache uint64
+}
+
+// globalListCoalescer coalesces the listings served by this node.
+var globalListCoalescer = newListCoalescer()
+
+func newListCoalescer() *listCoalescer {
+	return &listCoalescer{
+		walks:  make(map[string]*coalescedWalk),
+		caches: make(map[string][]coalescedCache),
+	}
+}
+
+// cacheKey identifies listings producing the same cache content.
+// Resumed listings only share with the pages of the same snapshot,
+// first pages take their snapshot when they start and share the
+// walks and caches started no earlier.
+func (o listPathOptions) cacheKey() string {
+	var snapshot int64
+	if o.resumed {
+		snapshot = o.Snapshot.UnixNano()
+	}
+	return fmt.Sprintf("%s/%s|%s|%t|%t|%t|%t|%s|%t|%d|%d", o.Bucket, o.Prefix, o.Separator,
+		o.Recursive, o.IncludeDirectories, o.Versioned, o.InclDeleted, o.AskDisks,
+		o.StopDiskAtLimit, snapshot, o.VersionMarkerTime.UnixNano())
+}
+
+// walkKey identifies raw listings returning the same page.
+func (o listPathOptions) walkKey() string {
+	return fmt.Sprintf("%s|%s|%d", o.cacheKey(), o.Marker, o.Limit)
+}
+
+// walk returns the result of list, or that of an identical listing in
+// flight that started no earlier than notBefore. The entries of a shared
+// result are never handed back to the buffer pool.
+func (c *listCoalescer) walk(ctx context.Context, o listPathOptions, notBefore time.Time, list func() (metaCacheEntriesSorted, error)) (metaCacheEntriesSorted, error) {
+	key := o.walkKey()
+
+	c.mu.Lock()
+	atomic.AddUint64(&c.requests, 1)
+	if w, ok := c.walks[key]; ok && !w.started.Before(notBefore) {
+		w.waiters++
+		c.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return metaCacheEntriesSorted{}, ctx.Err()
+		case <-w.done:
+		}
+		if w.canceled {
+			// The client that walked went away, its result may
+			// be incomplete.
+			return list()
+		}
+		atomic.AddUint64(&c.sharedWalks, 1)
+		return w.entries.shallowClone(), w.err
+	}
+	w := &coalescedWalk{started: UTCNow(), done: make(chan struct{})}
+	c.walks[key] = w
+	c.mu.Unlock()
+
+	entries, err := list()
+
+	c.mu.Lock()
+	delete(c.walks, key)
+	shared := w.waiters > 0
+	c.mu.Unlock()
+
+	if shared {
+		entries.reuse = false
+		w.entries = entries.shallowClone()
+		w.err = err
+		w.canceled = ctx.Err() != nil
+	}
+	close(w.done)
+	return entries, err
+}
+
+// findCache returns a cache another client started for the listing in o
+// that holds all entries from its marker on and all objects created
+// before notBefore.
+func (c *listCoalescer) findCache(o listPathOptions, notBefore time.Time) (coalescedCache, bool) {
+	key := o.cacheKey()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	atomic.AddUint64(&c.requests, 1)
+	caches := c.expire(key, UTCNow())
+	for i := len(caches) - 1; i >= 0; i-- {
+		cache := caches[i]
+		if cache.marker <= o.Marker && !cache.started.Before(notBefore) {
+			atomic.AddUint64(&c.sharedCache, 1)
+			return cache, true
+		}
+	}
+	return coalescedCache{}, false
+}
+
+// addCache makes the cache just started for the listing in o available
+// to other clients.
+func (c *listCoalescer) addCache(o listPathOptions, started time.Time) {
+	key := o.cacheKey()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.caches[key] = append(c.expire(key, UTCNow()), coalescedCache{
+		id:      o.ID,
+		pool:    o.pool,
+		set:     o.set,
+		marker:  o.Marker,
+		started: started,
+	})
+}
+
+// forgetCache stops handing out the cache of the listing in o after it
+// turned out to be unusable.
+func (c *listCoalescer) forgetCache(o listPathOptions) {
+	key := o.cacheKey()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	caches := c.caches[key]
+	for i, cache := range caches {
+		if cache.id == o.ID {
+			c.caches[key] = append(caches[:i:i], caches[i+1:]...)
+			break
+		}
+	}
+	if len(c.caches[key]) == 0 {
+		delete(c.caches, key)
+	}
+}
+
+// expire drops the caches of key that are too old to be shared, must be
+// called with the lock held.
+func (c *listCoalescer) expire(key string, now time.Time) []coalescedCache {
+	caches := c.caches[key]
+	n := 0
+	for _, cache := range caches {
+		if now.Sub(cache.started) < listCoalesceMaxAge {
+			caches[n] = cache
+			n++
+		}
+	}
+	if n == 0 {
+		delete(c.caches, key)
+		return nil
+	}
+	c.caches[key] = caches[:n]
+	return caches[:n]
+}
+
+// sweep drops the caches of listings nobody repeated, the lock is
+// only held to expire one listing at a time.
+func (c *listCoalescer) sweep() {
+	c.mu.Lock()
+	keys := make([]string, 0, len(c.caches))
+	for key := range c.caches {
+		keys = append(keys, key)
+	}
+	c.mu.Unlock()
+
+	for _, key := range keys {
+		c.mu.Lock()
+		c.expire(key, UTCNow())
+		c.mu.Unlock()
+	}
+}
+
+// Requests returns the number of listings that looked for a walk or a
+// cache to share.
+func (c *listCoalescer) Requests() uint64 {
+	return atomic.LoadUint64(&c.requests)
+}
+
+// SharedWalks returns the number of listings served by the walk of an
+// identical listing in flight.
+func (c *listCoalescer) SharedWalks() uint64 {
+	return atomic.LoadUint64(&c.sharedWalks)
+}
+
+// SharedCaches returns the number of listings resumed from a cache
+// another client started.
+func (c *listCoalescer) SharedCaches() uint64 {
+	return atomic.LoadUint64(&c.sharedCache)
+}
@@ -0,0 +1,265 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestListCoalescerWalk(t *testing.T) {
+	c := newListCoalescer()
+	o := listPathOptions{Bucket: "bucket", Prefix: "prefix/", Limit: 10}
+	want := metaCacheEntriesSorted{o: metaCacheEntries{{name: "prefix/a"}, {name: "prefix/b"}}, reuse: true}
+
+	var walks int32
+	release := make(chan struct{})
+	list := func() (metaCacheEntriesSorted, error) {
+		atomic.AddInt32(&walks, 1)
+		<-release
+		return want.shallowClone(), io.EOF
+	}
+
+	const clients = 5
+	results := make([]metaCacheEntriesSorted, clients)
+	errs := make([]error, clients)
+	var wg sync.WaitGroup
+	for i := 0; i < clients; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = c.walk(context.Background(), o, time.Time{}, list)
+		}(i)
+		// Wait for the first client to start walking.
+		for i == 0 && atomic.LoadInt32(&walks) == 0 {
+			time.Sleep(time.Millisecond)
+		}
+	}
+	for c.Requests() < clients {
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	wg.Wait()
+
+	if walks != 1 {
+		t.Fatalf("expected a single walk, got %d", walks)
+	}
+	for i := range results {
+		if errs[i] != io.EOF || results[i].reuse || strings.Join(results[i].entries().names(), ",") != "prefix/a,prefix/b" {
+			t.Fatalf("client %d: unexpected result %+v, %v", i, results[i], errs[i])
+		}
+	}
+	if c.SharedWalks() != clients-1 {
+		t.Fatalf("expected %d shared walks, got %d", clients-1, c.SharedWalks())
+	}
+
+	// A different page is walked on its own.
+	o.Marker = "prefix/a"
+	if _, err := c.walk(context.Background(), o, time.Time{}, list); err != io.EOF || walks != 2 {
+		t.Fatalf("expected a second walk, got %d walks and %v", walks, err)
+	}
+}
+
+func TestListCoalescerWalkBarrier(t *testing.T) {
+	c := newListCoalescer()
+	o := listPathOptions{Bucket: "bucket", Limit: 10}
+
+	var walks int32
+	started := make(chan struct{})
+	release := make(chan struct{})
+	leaderCtx, cancelLeader := context.WithCancel(context.Background())
+	go c.walk(leaderCtx, o, time.Time{}, func() (metaCacheEntriesSorted, error) {
+		atomic.AddInt32(&walks, 1)
+		close(started)
+		<-release
+		return metaCacheEntriesSorted{}, nil
+	})
+	<-started
+
+	own := func() (metaCacheEntriesSorted, error) {
+		atomic.AddInt32(&walks, 1)
+		return metaCacheEntriesSorted{}, io.EOF
+	}
+
+	// A walk that started before the barrier is not shared.
+	if _, err := c.walk(context.Background(), o, UTCNow().Add(time.Second), own); err != io.EOF {
+		t.Fatalf("expected own walk, got %v", err)
+	}
+
+	// Followers of a walk whose client went away walk themselves.
+	done := make(chan error)
+	go func() {
+		_, err := c.walk(context.Background(), o, time.Time{}, own)
+		done <- err
+	}()
+	for c.Requests() < 3 {
+		time.Sleep(time.Millisecond)
+	}
+	cancelLeader()
+	close(release)
+	if err := <-done; err != io.EOF {
+		t.Fatalf("expected own walk after the leader was canceled, got %v", err)
+	}
+	if walks != 3 || c.SharedWalks() != 0 {
+		t.Fatalf("expected 3 walks and none shared, got %d and %d", walks, c.SharedWalks())
+	}
+}
+
+func TestListCoalescerCache(t *testing.T) {
+	c := newListCoalescer()
+	now := UTCNow()
+	o := listPathOptions{Bucket: "bucket", Prefix: "prefix/", Recursive: true, Marker: "prefix/m", ID: "cache-id", pool: 1, set: 2}
+	c.addCache(o, now)
+
+	testCases := []struct {
+		marker    string
+		prefix    string
+		notBefore time.Time
+		found     bool
+	}{
+		{"prefix/m", "prefix/", now, true},
+		{"prefix/z", "prefix/", now.Add(-time.Minute), true},
+		{"prefix/a", "prefix/", now, false},
+		{"prefix/m", "prefix/", now.Add(time.Second), false},
+		{"prefix/m", "other/", now, false},
+	}
+	for i, tc := range testCases {
+		q := listPathOptions{Bucket: "bucket", Prefix: tc.prefix, Recursive: true, Marker: tc.marker}
+		cache, found := c.findCache(q, tc.notBefore)
+		if found != tc.found {
+			t.Fatalf("test %d: expected found=%v", i+1, tc.found)
+		}
+		if found && (cache.id != o.ID || cache.pool != 1 || cache.set != 2) {
+			t.Fatalf("test %d: unexpected cache %+v", i+1, cache)
+		}
+	}
+	if c.SharedCaches() != 2 {
+		t.Fatalf("expected 2 shared caches, got %d", c.SharedCaches())
+	}
+
+	c.forgetCache(o)
+	if _, found := c.findCache(o, now); found {
+		t.Fatal("expected forgotten cache not to be shared")
+	}
+
+	o.ID = "expired"
+	c.addCache(o, now.Add(-listCoalesceMaxAge))
+	if _, found := c.findCache(o, time.Time{}); found {
+		t.Fatal("expected expired cache not to be shared")
+	}
+	if len(c.caches) != 0 {
+		t.Fatalf("expected no caches left, got %v", c.caches)
+	}
+
+	// Resumed listings only share with the same snapshot.
+	o.ID = "resumed"
+	o.Snapshot, o.resumed = now.Add(-time.Minute), true
+	c.addCache(o, now)
+	fresh := o
+	fresh.Snapshot, fresh.resumed = now, false
+	if _, found := c.findCache(fresh, now); found {
+		t.Fatal("expected the cache of a resumed listing not to be shared with a first page")
+	}
+	if _, found := c.findCache(o, now); !found {
+		t.Fatal("expected the cache to be shared with the same snapshot")
+	}
+
+	o.ID = "unused"
+	c.caches["unused"] = []coalescedCache{{id: o.ID, started: now.Add(-listCoalesceMaxAge)}}
+	c.sweep()
+	if _, ok := c.caches["unused"]; ok || len(c.caches) != 1 {
+		t.Fatalf("expected only the expired caches to be swept, got %v", c.caches)
+	}
+}
+
+func TestListObjectsCoalesced(t *testing.T) {
+	ExecObjectLayerTest(t, testListObjectsCoalesced)
+}
+
+// Two clients paging through the same listing session share the cache
+// the first one started.
+func testListObjectsCoalesced(obj ObjectLayer, instanceType string, t1 TestErrHandler) {
+	t, _ := t1.(*testing.T)
+	ctx := context.Background()
+	bucket := "coalesced-bucket"
+	if err := obj.MakeBucketWithLocation(ctx, bucket, MakeBucketOptions{}); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	var want []string
+	for i := 0; i < 10; i++ {
+		object := fmt.Sprintf("obj-%02d", i)
+		if _, err := obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader([]byte("x")), 1, "", ""), ObjectOptions{}); err != nil {
+			t.Fatalf("%s: %v", instanceType, err)
+		}
+		want = append(want, object)
+	}
+
+	type client struct {
+		marker string
+		names  []string
+		done   bool
+	}
+	page := func(c *client) {
+		res, err := obj.ListObjects(ctx, bucket, "", c.marker, "", 3)
+		if err != nil {
+			t.Fatalf("%s: %v", instanceType, err)
+		}
+		for _, oi := range res.Objects {
+			c.names = append(c.names, oi.Name)
+		}
+		c.marker = res.NextMarker
+		c.done = !res.IsTruncated
+	}
+
+	var a client
+	page(&a)
+	// The second client resumes the same listing session.
+	b := client{marker: a.marker, names: append([]string(nil), a.names...)}
+	page(&a) // starts the cache
+
+	shared := globalListCoalescer.SharedCaches()
+	page(&b)
+	if globalListCoalescer.SharedCaches() != shared+1 {
+		t.Fatalf("%s: expected the second client to share the cache", instanceType)
+	}
+	for _, c := range []*client{&a, &b} {
+		for !c.done {
+			page(c)
+		}
+		if strings.Join(c.names, ",") != strings.Join(want, ",") {
+			t.Fatalf("%s: expected %v, got %v", instanceType, want, c.names)
+		}
+	}
+
+	// A new listing session does not resume from the cache of a session
+	// that started before it.
+	shared = globalListCoalescer.SharedCaches()
+	c := client{}
+	page(&c)
+	page(&c)
+	if globalListCoalescer.SharedCaches() != shared {
+		t.Fatalf("%s: expected a new session not to share an older cache", instanceType)
+	}
+}
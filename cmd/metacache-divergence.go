@@ -0,0 +1,190 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Sources of metadata divergences, the operation resolving the entry.
+const (
+	divergenceSourceListing = "listing"
+	divergenceSourceHeal    = "heal"
+	divergenceSourceScanner = "scanner"
+	divergenceSourceDecom   = "decommission"
+)
+
+const (
+	// metaDivergenceLogSize is the number of divergences kept per node.
+	metaDivergenceLogSize = 1000
+
+	// metaDivergenceDefaultMax is the number of divergences returned
+	// by default.
+	metaDivergenceDefaultMax = 100
+)
+
+// resolveConflictPolicy decides how entries are resolved when the
+// drives disagree on their metadata.
+type resolveConflictPolicy uint8
+
+const (
+	// resolveConflictMerge merges the versions agreed upon by
+	// objQuorum drives.
+	resolveConflictMerge resolveConflictPolicy = iota
+	// resolveConflictSkip skips the entry until it is healed.
+	resolveConflictSkip
+)
+
+// metaDivergence is an entry on which drives disagreed while it was
+// resolved.
+type metaDivergence struct {
+	Time     time.Time `json:"time"`
+	Node     string    `json:"node"`
+	Source   string    `json:"source"`
+	Bucket   string    `json:"bucket"`
+	Object   string    `json:"object"`
+	Drives   int       `json:"drives"`   // Drives with valid metadata.
+	Agreeing int       `json:"agreeing"` // Drives agreeing with the first one.
+	Resolved bool      `json:"resolved"` // Whether a merged entry was returned.
+}
+
+// metaDivergenceLog counts the divergences by source and keeps the
+// most recent ones when enabled.
+type metaDivergenceLog struct {
+	mu      sync.Mutex
+	entries []metaDivergence
+	next    int
+	total   map[string]uint64
+}
+
+var globalMetaDivergence = newMetaDivergenceLog(metaDivergenceLogSize)
+
+func newMetaDivergenceLog(size int) *metaDivergenceLog {
+	return &metaDivergenceLog{
+		entries: make([]metaDivergence, 0, size),
+		total:   make(map[string]uint64),
+	}
+}
+
+// add counts the divergence and, if keep is set, logs it, replacing
+// the oldest entry once the log is full.
+func (l *metaDivergenceLog) add(d metaDivergence, keep bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.total[d.Source]++
+	if !keep {
+		return
+	}
+	if len(l.entries) < cap(l.entries) {
+		l.entries = append(l.entries, d)
+		return
+	}
+	l.entries[l.next] = d
+	l.next = (l.next + 1) % len(l.entries)
+}
+
+// recent returns up to n logged divergences, newest first.
+func (l *metaDivergenceLog) recent(n int) []metaDivergence {
+	l.mu.Lock()
+	res := make([]metaDivergence, len(l.entries))
+	copy(res, l.entries)
+	l.mu.Unlock()
+
+	return newestDivergences(res, n)
+}
+
+// counts returns the number of divergences seen per source.
+func (l *metaDivergenceLog) counts() map[string]uint64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	res := make(map[string]uint64, len(l.total))
+	for source, n := range l.total {
+		res[source] = n
+	}
+	return res
+}
+
+// newestDivergences sorts the divergences newest first and returns
+// at most n of them.
+func newestDivergences(d []metaDivergence, n int) []metaDivergence {
+	sort.Slice(d, func(i, j int) bool {
+		return d[i].Time.After(d[j].Time)
+	})
+	if len(d) > n {
+		d = d[:n]
+	}
+	return d
+}
+
+// reportDivergence records that the drives disagreed on the metadata
+// of object, if a source is set.
+func (r *metadataResolutionParams) reportDivergence(object string, agreeing, drives int, resolved bool) {
+	if r.divergenceSource == "" {
+		return
+	}
+	globalMetaDivergence.add(metaDivergence{
+		Time:     UTCNow(),
+		Node:     globalLocalNodeName,
+		Source:   r.divergenceSource,
+		Bucket:   r.bucket,
+		Object:   object,
+		Drives:   drives,
+		Agreeing: agreeing,
+		Resolved: resolved,
+	}, globalAPIConfig.isDivergenceLogEnabled())
+}
+
+// metaDivergenceReport is the number of divergences per source and the
+// most recent logged divergences of one or all nodes.
+type metaDivergenceReport struct {
+	Counts      map[string]uint64 `json:"counts"`
+	Divergences []metaDivergence  `json:"divergences"`
+	NodeErrors  map[string]string `json:"nodeErrors,omitempty"`
+}
+
+// localMetaDivergences returns the divergences of this node.
+func localMetaDivergences(n int) metaDivergenceReport {
+	return metaDivergenceReport{
+		Counts:      globalMetaDivergence.counts(),
+		Divergences: globalMetaDivergence.recent(n),
+	}
+}
+
+// getMetaDivergences returns the divergences of all nodes.
+func getMetaDivergences(ctx context.Context, n int) metaDivergenceReport {
+	report := localMetaDivergences(n)
+	if globalNotificationSys == nil {
+		return report
+	}
+
+	var peers []metaDivergenceReport
+	peers, report.NodeErrors = globalNotificationSys.GetMetaDivergences(ctx, n)
+	for _, peer := range peers {
+		for source, count := range peer.Counts {
+			report.Counts[source] += count
+		}
+		report.Divergences = append(report.Divergences, peer.Divergences...)
+	}
+	report.Divergences = newestDivergences(report.Divergences, n)
+	return report
+}
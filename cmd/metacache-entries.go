@@ -25,6 +25,7 @@ import (
 	"path"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/minio/pkg/console"
 	"github.com/qkbyte/minio/internal/logger"
@@ -302,6 +303,13 @@ type metadataResolutionParams struct {
 	bucket string // Name of the bucket. Used for generating cached fileinfo.
 	strict bool   // Versions must match exactly, including all metadata.
 
+	// How to resolve entries the drives disagree on, merged by default.
+	conflict resolveConflictPolicy
+
+	// When set, entries the drives disagree on are reported
+	// to the divergence log with this source.
+	divergenceSource string
+
 	// Reusable slice for resolution
 	candidates [][]xlMetaV2ShallowVersion
 }
@@ -383,6 +391,17 @@ func (m metaCacheEntries) resolve(r *metadataResolutionParams) (selected *metaCa
 		return nil, false
 	}
 
+	// The drives disagree, only select metadata agreed upon by
+	// objQuorum drives and skip the entry otherwise if requested.
+	if r.conflict == resolveConflictSkip {
+		if quorum, agree := m.quorumEntry(r); quorum != nil {
+			r.reportDivergence(quorum.name, agree, objsValid, true)
+			return quorum, true
+		}
+		r.reportDivergence(selected.name, objsAgree, objsValid, false)
+		return nil, false
+	}
+
 	// Merge if we have disagreement.
 	// Create a new merged result.
 	selected = &metaCacheEntry{
@@ -392,6 +411,7 @@ func (m metaCacheEntries) resolve(r *metadataResolutionParams) (selected *metaCa
 	}
 	selected.cached.versions = mergeXLV2Versions(r.objQuorum, r.strict, r.requestedVersions, r.candidates...)
 	if len(selected.cached.versions) == 0 {
+		r.reportDivergence(selected.name, objsAgree, objsValid, false)
 		return nil, false
 	}
 
@@ -402,9 +422,38 @@ func (m metaCacheEntries) resolve(r *metadataResolutionParams) (selected *metaCa
 		logger.LogIf(context.Background(), err)
 		return nil, false
 	}
+	r.reportDivergence(selected.name, objsAgree, objsValid, true)
 	return selected, true
 }
 
+// quorumEntry returns the object entry whose metadata is matched by the
+// most drives, provided they reach objQuorum, and the number of them.
+func (m metaCacheEntries) quorumEntry(r *metadataResolutionParams) (quorum *metaCacheEntry, agree int) {
+	for i := range m {
+		entry := &m[i]
+		if entry.name == "" || entry.isDir() {
+			continue
+		}
+		n := 0
+		for j := range m {
+			other := &m[j]
+			if other.name == "" || other.isDir() {
+				continue
+			}
+			if _, ok := entry.matches(other, r.strict); ok {
+				n++
+			}
+		}
+		if n > agree {
+			quorum, agree = entry, n
+		}
+	}
+	if agree < r.objQuorum {
+		return nil, agree
+	}
+	return quorum, agree
+}
+
 // firstFound returns the first found and the number of set entries.
 func (m metaCacheEntries) firstFound() (first *metaCacheEntry, n int) {
 	for i, entry := range m {
@@ -446,8 +495,11 @@ func (m metaCacheEntriesSorted) shallowClone() metaCacheEntriesSorted {
 }
 
 // fileInfoVersions converts the metadata to FileInfoVersions where possible.
-// Metadata that cannot be decoded is skipped.
-func (m *metaCacheEntriesSorted) fileInfoVersions(bucket, prefix, delimiter, afterV string) (versions []ObjectInfo) {
+// Metadata that cannot be decoded is skipped. The versions of the object
+// afterName up to version afterV are skipped, if afterV no longer exists
+// the versions not older than afterVTime are skipped instead so that
+// versions of the previous page are not returned again.
+func (m *metaCacheEntriesSorted) fileInfoVersions(bucket, prefix, delimiter, afterName, afterV string, afterVTime time.Time) (versions []ObjectInfo) {
 	versions = make([]ObjectInfo, 0, m.len())
 	prevPrefix := ""
 	vcfg, _ := globalBucketVersioningSys.Get(bucket)
@@ -478,10 +530,18 @@ func (m *metaCacheEntriesSorted) fileInfoVersions(bucket, prefix, delimiter, aft
 			}
 
 			fiVersions := fiv.Versions
-			if afterV != "" {
+			if afterV != "" && entry.name == afterName {
 				vidMarkerIdx := fiv.findVersionIndex(afterV)
-				if vidMarkerIdx >= 0 {
+				switch {
+				case vidMarkerIdx >= 0:
 					fiVersions = fiVersions[vidMarkerIdx+1:]
+				case !afterVTime.IsZero():
+					// Versions are sorted newest first.
+					n := 0
+					for n < len(fiVersions) && !fiVersions[n].ModTime.Before(afterVTime) {
+						n++
+					}
+					fiVersions = fiVersions[n:]
 				}
 				afterV = ""
 			}
@@ -597,6 +657,54 @@ func (m *metaCacheEntriesSorted) forwardTo(s string) {
 	m.o = m.o[idx:]
 }
 
+// objectCreatedKey holds the time an object version was written to this
+// cluster, unlike its modtime it is not preserved by replication and it
+// survives overwrites of the null version.
+const objectCreatedKey = ReservedMetadataPrefixLower + "created"
+
+// setObjectCreated records the write time of a new object version,
+// versions moved between pools keep the recorded one.
+func setObjectCreated(metadata map[string]string, t time.Time) {
+	if _, ok := metadata[objectCreatedKey]; !ok {
+		metadata[objectCreatedKey] = t.UTC().Format(time.RFC3339Nano)
+	}
+}
+
+// objectCreated returns the write time recorded in the metadata of a
+// version, or modTime for versions written before it was recorded.
+func objectCreated(metadata map[string]string, modTime time.Time) time.Time {
+	if t, err := time.Parse(time.RFC3339Nano, metadata[objectCreatedKey]); err == nil {
+		return t
+	}
+	return modTime
+}
+
+// createdAfter returns the names of the objects whose oldest version was
+// written after t, they did not exist when a listing snapshot was taken.
+func (m *metaCacheEntriesSorted) createdAfter(t time.Time) map[string]struct{} {
+	if t.IsZero() {
+		return nil
+	}
+	var names map[string]struct{}
+	for i := range m.o {
+		entry := &m.o[i]
+		if !entry.isObject() {
+			continue
+		}
+		meta, err := entry.xlmeta()
+		if err != nil || len(meta.versions) == 0 {
+			continue
+		}
+		if meta.versionCreated(len(meta.versions) - 1).After(t) {
+			if names == nil {
+				names = make(map[string]struct{})
+			}
+			names[entry.name] = struct{}{}
+		}
+	}
+	return names
+}
+
 // forwardPast will truncate m so only entries that are after s is in the list.
 func (m *metaCacheEntriesSorted) forwardPast(s string) {
 	if s == "" {
@@ -18,6 +18,7 @@
 package cmd
 
 import (
+	"bytes"
 	"fmt"
 	"math/rand"
 	"reflect"
@@ -657,3 +658,84 @@ func Test_metaCacheEntries_resolve(t *testing.T) {
 		}
 	}
 }
+
+func Test_metaCacheEntries_resolveDivergence(t *testing.T) {
+	defer func(l *metaDivergenceLog) { globalMetaDivergence = l }(globalMetaDivergence)
+	globalMetaDivergence = newMetaDivergenceLog(metaDivergenceLogSize)
+
+	baseTime := time.Now()
+	version := func(id byte, modTime time.Duration) xlMetaV2ShallowVersion {
+		return xlMetaV2ShallowVersion{header: xlMetaV2VersionHeader{
+			VersionID: [16]byte{id},
+			ModTime:   baseTime.Add(modTime).UnixNano(),
+			Signature: [4]byte{id},
+			Type:      ObjectType,
+		}}
+	}
+	entry := func(versions ...xlMetaV2ShallowVersion) metaCacheEntry {
+		xl := xlMetaV2{versions: versions}
+		xl.sortByModTime()
+		e := metaCacheEntry{name: "testobject"}
+		var err error
+		if e.metadata, err = xl.AppendTo(nil); err != nil {
+			t.Fatal(err)
+		}
+		return e
+	}
+	agreed := entry(version(1, 0))
+	diverged := entry(version(2, time.Minute), version(1, 0))
+
+	// Entries all drives agree on are never reported.
+	r := metadataResolutionParams{dirQuorum: 1, objQuorum: 1, bucket: "bucket", divergenceSource: divergenceSourceListing}
+	if _, ok := (metaCacheEntries{agreed, agreed}).resolve(&r); !ok {
+		t.Fatal("expected agreed entry to resolve")
+	}
+	if n := globalMetaDivergence.counts()[divergenceSourceListing]; n != 0 {
+		t.Fatalf("expected no divergences, got %d", n)
+	}
+
+	// Merged by default.
+	if _, ok := (metaCacheEntries{agreed, diverged}).resolve(&r); !ok {
+		t.Fatal("expected diverged entry to be merged")
+	}
+
+	// Skipped with the skip policy, unless objQuorum drives agree.
+	r.conflict = resolveConflictSkip
+	r.objQuorum = 2
+	if _, ok := (metaCacheEntries{agreed, diverged}).resolve(&r); ok {
+		t.Fatal("expected diverged entry to be skipped")
+	}
+	got, ok := (metaCacheEntries{agreed, diverged, agreed}).resolve(&r)
+	if !ok || !bytes.Equal(got.metadata, agreed.metadata) {
+		t.Fatal("expected entry agreed by quorum to be selected")
+	}
+
+	// Not reported without a source.
+	r.divergenceSource = ""
+	(metaCacheEntries{agreed, diverged}).resolve(&r)
+
+	if n := globalMetaDivergence.counts()[divergenceSourceListing]; n != 3 {
+		t.Fatalf("expected 3 divergences, got %d", n)
+	}
+	if d := globalMetaDivergence.recent(10); len(d) != 0 {
+		t.Fatalf("expected no logged divergences with the log disabled, got %v", d)
+	}
+
+	globalMetaDivergence.add(metaDivergence{Time: baseTime, Source: divergenceSourceHeal, Bucket: "bucket", Object: "testobject"}, true)
+	d := globalMetaDivergence.recent(10)
+	if len(d) != 1 || d[0].Object != "testobject" || d[0].Source != divergenceSourceHeal {
+		t.Fatalf("unexpected logged divergences %v", d)
+	}
+}
+
+func Test_metaDivergenceLogWraps(t *testing.T) {
+	l := newMetaDivergenceLog(3)
+	baseTime := time.Now()
+	for i := 0; i < 5; i++ {
+		l.add(metaDivergence{Time: baseTime.Add(time.Duration(i) * time.Second), Object: fmt.Sprint(i)}, true)
+	}
+	d := l.recent(10)
+	if len(d) != 3 || d[0].Object != "4" || d[2].Object != "2" {
+		t.Fatalf("unexpected divergences %v", d)
+	}
+}
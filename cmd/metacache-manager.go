@@ -85,6 +85,7 @@ func (m *metacacheManager) initManager() {
 				}
 			}
 			m.mu.Unlock()
+			globalListCoalescer.sweep()
 		}
 	}()
 }
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/qkbyte/minio/internal/logger"
 )
@@ -72,6 +73,14 @@ func (o *listPathOptions) parseMarker() {
 				continue
 			}
 			o.set = int(v)
+		case "t": // snapshot
+			if v, err := strconv.ParseInt(kv[1], 10, 64); err == nil {
+				o.Snapshot = time.Unix(0, v).UTC()
+			}
+		case "vt": // version marker modtime
+			if v, err := strconv.ParseInt(kv[1], 10, 64); err == nil {
+				o.VersionMarkerTime = time.Unix(0, v).UTC()
+			}
 		default:
 			// Ignore unknown
 		}
@@ -81,12 +90,19 @@ func (o *listPathOptions) parseMarker() {
 // encodeMarker will encode a uuid and return it as a marker.
 // uuid cannot contain '[', ':' or ','.
 func (o listPathOptions) encodeMarker(marker string) string {
+	var snapshot string
+	if !o.Snapshot.IsZero() {
+		snapshot = fmt.Sprintf(",t:%d", o.Snapshot.UnixNano())
+	}
+	if !o.VersionMarkerTime.IsZero() {
+		snapshot += fmt.Sprintf(",vt:%d", o.VersionMarkerTime.UnixNano())
+	}
 	if o.ID == "" {
 		// Mark as returning listing...
-		return fmt.Sprintf("%s[minio_cache:%s,return:]", marker, markerTagVersion)
+		return fmt.Sprintf("%s[minio_cache:%s,return:%s]", marker, markerTagVersion, snapshot)
 	}
 	if strings.ContainsAny(o.ID, "[:,") {
 		logger.LogIf(context.Background(), fmt.Errorf("encodeMarker: uuid %s contained invalid characters", o.ID))
 	}
-	return fmt.Sprintf("%s[minio_cache:%s,id:%s,p:%d,s:%d]", marker, markerTagVersion, o.ID, o.pool, o.set)
+	return fmt.Sprintf("%s[minio_cache:%s,id:%s,p:%d,s:%d%s]", marker, markerTagVersion, o.ID, o.pool, o.set, snapshot)
 }
@@ -0,0 +1,270 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	// metacacheRPCTimeout is the maximum time a listing waits
+	// for the metacache of a continued listing.
+	metacacheRPCTimeout = 5 * time.Second
+
+	// metacacheRPCHedgeDelay is the time after which a metacache
+	// request is also sent to the secondary peer.
+	metacacheRPCHedgeDelay = 250 * time.Millisecond
+
+	// Consecutive failures after which metacache RPCs to a peer are
+	// skipped, until peerBreakerCooldown has passed.
+	peerBreakerThreshold = 3
+	peerBreakerCooldown  = 30 * time.Second
+)
+
+var (
+	errPeerCircuitOpen  = errors.New("peer circuit breaker is open")
+	errPeerRPCOvertaken = errors.New("peer RPC overtaken by hedged request")
+)
+
+// peerCircuitBreaker skips requests to a peer that failed
+// repeatedly, one request is let through after the cooldown
+// to probe whether the peer has recovered.
+type peerCircuitBreaker struct {
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+}
+
+// allow returns whether a request may be sent to the peer.
+func (b *peerCircuitBreaker) allow(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.failures < peerBreakerThreshold {
+		return true
+	}
+	if now.Before(b.openUntil) {
+		return false
+	}
+	// Half-open, let a single request probe the peer.
+	b.openUntil = now.Add(peerBreakerCooldown)
+	return true
+}
+
+// done records the outcome of a request to the peer, requests
+// canceled by the caller are not held against the peer.
+func (b *peerCircuitBreaker) done(err error, now time.Time) {
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err == nil {
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.failures >= peerBreakerThreshold {
+		b.openUntil = now.Add(peerBreakerCooldown)
+	}
+}
+
+// getMetacacheListingHedged requests the metacache from the primary peer
+// and, if it has not answered after metacacheRPCHedgeDelay, failed or its
+// circuit breaker is open, from the secondary peer as well. The first
+// successful answer is returned along with the peer that sent it, answers
+// of a peer that does not know the cache only if no peer knows it.
+//
+// The secondary peer is never asked to create a cache, since the cache
+// would not be found by listings routed to the primary. It only knows the
+// cache if it owned it before the set of online peers changed, otherwise
+// the listing falls back to listing without a cache instead of stalling.
+func getMetacacheListingHedged(ctx context.Context, o listPathOptions, primary, secondary *peerRESTClient) (*metacache, *peerRESTClient, error) {
+	ctx, cancel := context.WithTimeout(ctx, metacacheRPCTimeout)
+	defer cancel()
+
+	if primary == nil || secondary == nil {
+		c, err := primary.GetMetacacheListing(ctx, o)
+		return c, primary, err
+	}
+
+	type result struct {
+		c      *metacache
+		client *peerRESTClient
+		err    error
+	}
+	results := make(chan result, 2)
+	send := func(client *peerRESTClient, o listPathOptions) {
+		go func() {
+			c, err := client.GetMetacacheListing(ctx, o)
+			results <- result{c: c, client: client, err: err}
+		}()
+	}
+
+	hedged := o
+	hedged.Create = false
+
+	var primaryPending, hedgeSent bool
+	if primary.metacacheBreaker.allow(time.Now()) {
+		send(primary, o)
+		primaryPending = true
+	} else {
+		send(secondary, hedged)
+		hedgeSent = true
+	}
+
+	timer := time.NewTimer(metacacheRPCHedgeDelay)
+	defer timer.Stop()
+
+	var (
+		err error
+		// An answer from a peer that does not know the cache,
+		// used if no peer knows it.
+		unknown *result
+	)
+	for pending := 1; pending > 0; {
+		select {
+		case <-timer.C:
+			if !hedgeSent {
+				send(secondary, hedged)
+				hedgeSent = true
+				pending++
+			}
+		case r := <-results:
+			pending--
+			if r.client == primary {
+				primaryPending = false
+			}
+			if r.err == nil && r.c.status == scanStateNone && (pending > 0 || !hedgeSent) {
+				if unknown == nil || r.client == primary {
+					unknown = &r
+				}
+				if !hedgeSent {
+					send(secondary, hedged)
+					hedgeSent = true
+					pending++
+				}
+				continue
+			}
+			if r.err == nil {
+				if primaryPending {
+					// The primary is too slow, it will be
+					// canceled once we return.
+					primary.metacacheBreaker.done(errPeerRPCOvertaken, time.Now())
+				}
+				return r.c, r.client, nil
+			}
+			err = r.err
+			if !hedgeSent {
+				send(secondary, hedged)
+				hedgeSent = true
+				pending++
+			}
+		}
+	}
+	if unknown != nil {
+		return unknown.c, unknown.client, nil
+	}
+	return nil, nil, err
+}
+
+// listPathOptionsRPC are the listPathOptions sent to peers.
+type listPathOptionsRPC struct {
+	ID                 string
+	Bucket             string
+	BaseDir            string
+	Prefix             string
+	FilterPrefix       string
+	Marker             string
+	Limit              int
+	AskDisks           string
+	InclDeleted        bool
+	Recursive          bool
+	Separator          string
+	Create             bool
+	IncludeDirectories bool
+	Transient          bool
+	Versioned          bool
+	StopDiskAtLimit    bool
+}
+
+// listPathOptionsRPCVersion is the version of the encoding of the
+// options sent to peers, it must be bumped when listPathOptionsRPC
+// changes.
+const listPathOptionsRPCVersion = 1
+
+// GobEncode encodes the options sent to peers, the lifecycle, retention
+// and replication configurations are not transferred, since the lifecycle
+// can not be encoded by gob.
+func (o listPathOptions) GobEncode() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte(listPathOptionsRPCVersion)
+	err := gob.NewEncoder(&buf).Encode(listPathOptionsRPC{
+		ID:                 o.ID,
+		Bucket:             o.Bucket,
+		BaseDir:            o.BaseDir,
+		Prefix:             o.Prefix,
+		FilterPrefix:       o.FilterPrefix,
+		Marker:             o.Marker,
+		Limit:              o.Limit,
+		AskDisks:           o.AskDisks,
+		InclDeleted:        o.InclDeleted,
+		Recursive:          o.Recursive,
+		Separator:          o.Separator,
+		Create:             o.Create,
+		IncludeDirectories: o.IncludeDirectories,
+		Transient:          o.Transient,
+		Versioned:          o.Versioned,
+		StopDiskAtLimit:    o.StopDiskAtLimit,
+	})
+	return buf.Bytes(), err
+}
+
+// GobDecode decodes options encoded by GobEncode.
+func (o *listPathOptions) GobDecode(data []byte) error {
+	if len(data) == 0 || data[0] != listPathOptionsRPCVersion {
+		return errors.New("unsupported list path options encoding")
+	}
+	var v listPathOptionsRPC
+	if err := gob.NewDecoder(bytes.NewReader(data[1:])).Decode(&v); err != nil {
+		return err
+	}
+	*o = listPathOptions{
+		ID:                 v.ID,
+		Bucket:             v.Bucket,
+		BaseDir:            v.BaseDir,
+		Prefix:             v.Prefix,
+		FilterPrefix:       v.FilterPrefix,
+		Marker:             v.Marker,
+		Limit:              v.Limit,
+		AskDisks:           v.AskDisks,
+		InclDeleted:        v.InclDeleted,
+		Recursive:          v.Recursive,
+		Separator:          v.Separator,
+		Create:             v.Create,
+		IncludeDirectories: v.IncludeDirectories,
+		Transient:          v.Transient,
+		Versioned:          v.Versioned,
+		StopDiskAtLimit:    v.StopDiskAtLimit,
+	}
+	return nil
+}
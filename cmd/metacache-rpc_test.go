@@ -0,0 +1,148 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/qkbyte/minio/internal/rest"
+	"github.com/tinylib/msgp/msgp"
+)
+
+func TestListPathOptionsGobVersion(t *testing.T) {
+	b, err := listPathOptions{ID: "id", Bucket: "bucket", Limit: 10}.GobEncode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var o listPathOptions
+	if err = o.GobDecode(b); err != nil || o.ID != "id" || o.Bucket != "bucket" || o.Limit != 10 {
+		t.Fatalf("unexpected decoded options %+v, %v", o, err)
+	}
+	b[0]++
+	if err = o.GobDecode(b); err == nil {
+		t.Fatal("expected an unknown encoding version to be rejected")
+	}
+}
+
+func TestPeerCircuitBreaker(t *testing.T) {
+	var b peerCircuitBreaker
+	now := time.Now()
+	errFailed := errors.New("failed")
+
+	for i := 0; i < peerBreakerThreshold; i++ {
+		if !b.allow(now) {
+			t.Fatalf("breaker opened after %d failures", i)
+		}
+		b.done(errFailed, now)
+	}
+	if b.allow(now) {
+		t.Fatal("expected breaker to be open")
+	}
+
+	// Canceled requests are not counted.
+	b.done(context.Canceled, now)
+
+	// A single probe is let through after the cooldown.
+	now = now.Add(peerBreakerCooldown)
+	if !b.allow(now) {
+		t.Fatal("expected a probe after the cooldown")
+	}
+	if b.allow(now) {
+		t.Fatal("expected a single probe")
+	}
+	b.done(nil, now)
+	if !b.allow(now) {
+		t.Fatal("expected breaker to be closed after a success")
+	}
+}
+
+func TestGetMetacacheListingHedged(t *testing.T) {
+	newPeer := func(id string, delay time.Duration, status scanStatus) (*peerRESTClient, func()) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-time.After(delay):
+			case <-r.Context().Done():
+				return
+			}
+			msgp.Encode(w, &metacache{id: id, status: status})
+		}))
+		u, err := url.Parse(srv.URL + peerRESTPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		client := rest.NewClient(u, http.DefaultTransport, func(string) string { return "" })
+		return &peerRESTClient{restClient: client}, srv.Close
+	}
+
+	fast, closeFast := newPeer("fast", 0, scanStateStarted)
+	defer closeFast()
+	slow, closeSlow := newPeer("slow", time.Second, scanStateStarted)
+	defer closeSlow()
+	unknown, closeUnknown := newPeer("unknown", 0, scanStateNone)
+	defer closeUnknown()
+	late, closeLate := newPeer("late", 2*metacacheRPCHedgeDelay, scanStateStarted)
+	defer closeLate()
+
+	o := listPathOptions{ID: "id", Bucket: "bucket"}
+
+	// A fast primary answers without hedging.
+	c, client, err := getMetacacheListingHedged(context.Background(), o, fast, slow)
+	if err != nil || client != fast || c.id != "fast" {
+		t.Fatalf("expected answer from primary, got %v, %v", c, err)
+	}
+
+	// A slow primary is overtaken by the secondary.
+	start := time.Now()
+	c, client, err = getMetacacheListingHedged(context.Background(), o, slow, fast)
+	if err != nil || client != fast || c.id != "fast" {
+		t.Fatalf("expected answer from secondary, got %v, %v", c, err)
+	}
+	if time.Since(start) >= time.Second {
+		t.Fatal("hedged request waited for the slow primary")
+	}
+
+	// A secondary not knowing the cache does not overtake the primary.
+	c, client, err = getMetacacheListingHedged(context.Background(), o, late, unknown)
+	if err != nil || client != late || c.id != "late" {
+		t.Fatalf("expected answer from primary, got %v, %v", c, err)
+	}
+
+	// Nor is its answer preferred to the one of a primary knowing it.
+	c, client, err = getMetacacheListingHedged(context.Background(), o, unknown, late)
+	if err != nil || client != late || c.id != "late" {
+		t.Fatalf("expected answer from secondary, got %v, %v", c, err)
+	}
+
+	// Once the breaker of the primary is open, it is skipped.
+	for i := 0; i < peerBreakerThreshold; i++ {
+		slow.metacacheBreaker.done(errPeerRPCOvertaken, time.Now())
+	}
+	if _, err = slow.GetMetacacheListing(context.Background(), o); err != errPeerCircuitOpen {
+		t.Fatalf("expected %v, got %v", errPeerCircuitOpen, err)
+	}
+	c, client, err = getMetacacheListingHedged(context.Background(), o, slow, fast)
+	if err != nil || client != fast || c.id != "fast" {
+		t.Fatalf("expected answer from secondary, got %v, %v", c, err)
+	}
+}
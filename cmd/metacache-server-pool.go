@@ -96,6 +96,13 @@ func (z *erasureServerPools) listPath(ctx context.Context, o *listPathOptions) (
 
 	// Decode and get the optional list id from the marker.
 	o.parseMarker()
+	o.resumed = !o.Snapshot.IsZero()
+	if !o.resumed {
+		// First page of the listing session.
+		o.Snapshot = UTCNow()
+	}
+	barrier := listBarrierFromContext(ctx)
+	o.applyListBarrier(barrier)
 	o.BaseDir = baseDirFromPrefix(o.Prefix)
 	o.Transient = o.Transient || isReservedOrInvalidBucket(o.Bucket, false)
 	o.SetFilter()
@@ -103,6 +110,16 @@ func (z *erasureServerPools) listPath(ctx context.Context, o *listPathOptions) (
 		o.Create = false
 	}
 
+	// Resume from a cache another client started for the same listing
+	// instead of walking the drives again.
+	if o.Create {
+		if cache, ok := globalListCoalescer.findCache(*o, o.Snapshot); ok {
+			o.debugln("listPath: sharing cache", cache.id)
+			o.ID, o.pool, o.set = cache.id, cache.pool, cache.set
+			o.Create = false
+		}
+	}
+
 	// We have 2 cases:
 	// 1) Cold listing, just list.
 	// 2) Returning, but with no id. Start async listing.
@@ -111,15 +128,14 @@ func (z *erasureServerPools) listPath(ctx context.Context, o *listPathOptions) (
 	// If we don't have a list id we must ask the server if it has a cache or create a new.
 	if o.ID != "" && !o.Transient {
 		// Create or ping with handout...
-		rpc := globalNotificationSys.restClientFromHash(pathJoin(o.Bucket, o.Prefix))
+		rpc, secondary := globalNotificationSys.restClientsFromHash(pathJoin(o.Bucket, o.Prefix))
 		var c *metacache
 		if rpc == nil {
 			resp := localMetacacheMgr.getBucket(ctx, o.Bucket).findCache(*o)
 			c = &resp
 		} else {
-			rctx, cancel := context.WithTimeout(ctx, 5*time.Second)
-			c, err = rpc.GetMetacacheListing(rctx, *o)
-			cancel()
+			// Further updates go to the peer that answered.
+			c, rpc, err = getMetacacheListingHedged(ctx, *o, rpc, secondary)
 		}
 		if err != nil {
 			if errors.Is(err, context.Canceled) {
@@ -139,9 +155,16 @@ func (z *erasureServerPools) listPath(ctx context.Context, o *listPathOptions) (
 				return entries, io.EOF
 			}
 			if c.status == scanStateError || c.status == scanStateNone {
+				globalListCoalescer.forgetCache(*o)
 				o.ID = ""
 				o.Create = false
 				o.debugln("scan status", c.status, " - waiting a roundtrip to create")
+			} else if c.started.Before(barrier) {
+				// The cache may miss writes the client requires to be
+				// listed, list from the marker into a new cache.
+				o.debugln("cache", c.id, "started before barrier", barrier, "- recreating")
+				o.ID = mustGetUUID()
+				o.Create = true
 			} else {
 				// Continue listing
 				o.ID = c.id
@@ -169,8 +192,10 @@ func (z *erasureServerPools) listPath(ctx context.Context, o *listPathOptions) (
 		// We have an existing list ID, continue streaming.
 		if o.Create {
 			o.debugln("Creating", o)
+			started := UTCNow()
 			entries, err = z.listAndSave(ctx, o)
 			if err == nil || err == io.EOF {
+				globalListCoalescer.addCache(*o, started)
 				return entries, err
 			}
 			entries.truncate(0)
@@ -201,7 +226,7 @@ func (z *erasureServerPools) listPath(ctx context.Context, o *listPathOptions) (
 		go func() {
 			rpc := globalNotificationSys.restClientFromHash(pathJoin(o.Bucket, o.Prefix))
 			if rpc != nil {
-				ctx, cancel := context.WithTimeout(GlobalContext, 5*time.Second)
+				ctx, cancel := context.WithTimeout(GlobalContext, metacacheRPCTimeout)
 				defer cancel()
 				c, err := rpc.GetMetacacheListing(ctx, *o)
 				if err == nil {
@@ -211,6 +236,7 @@ func (z *erasureServerPools) listPath(ctx context.Context, o *listPathOptions) (
 				}
 			}
 		}()
+		globalListCoalescer.forgetCache(*o)
 		o.ID = ""
 
 		if err != nil {
@@ -218,6 +244,17 @@ func (z *erasureServerPools) listPath(ctx context.Context, o *listPathOptions) (
 		}
 	}
 
+	if o.ID == "" {
+		// Identical listings in flight share a single walk.
+		return globalListCoalescer.walk(ctx, *o, o.Snapshot, func() (metaCacheEntriesSorted, error) {
+			return z.listRaw(ctx, o)
+		})
+	}
+	return z.listRaw(ctx, o)
+}
+
+// listRaw lists the requested entries directly from the drives.
+func (z *erasureServerPools) listRaw(ctx context.Context, o *listPathOptions) (entries metaCacheEntriesSorted, err error) {
 	// Do listing in-place.
 	// Create output for our results.
 	// Create filter for results.
@@ -304,6 +341,13 @@ func (es *erasureSingle) listPath(ctx context.Context, o *listPathOptions) (entr
 
 	// Decode and get the optional list id from the marker.
 	o.parseMarker()
+	o.resumed = !o.Snapshot.IsZero()
+	if !o.resumed {
+		// First page of the listing session.
+		o.Snapshot = UTCNow()
+	}
+	barrier := listBarrierFromContext(ctx)
+	o.applyListBarrier(barrier)
 	o.BaseDir = baseDirFromPrefix(o.Prefix)
 	o.Transient = o.Transient || isReservedOrInvalidBucket(o.Bucket, false)
 	o.SetFilter()
@@ -311,6 +355,16 @@ func (es *erasureSingle) listPath(ctx context.Context, o *listPathOptions) (entr
 		o.Create = false
 	}
 
+	// Resume from a cache another client started for the same listing
+	// instead of walking the drives again.
+	if o.Create {
+		if cache, ok := globalListCoalescer.findCache(*o, o.Snapshot); ok {
+			o.debugln("listPath: sharing cache", cache.id)
+			o.ID, o.pool, o.set = cache.id, cache.pool, cache.set
+			o.Create = false
+		}
+	}
+
 	// We have 2 cases:
 	// 1) Cold listing, just list.
 	// 2) Returning, but with no id. Start async listing.
@@ -325,9 +379,16 @@ func (es *erasureSingle) listPath(ctx context.Context, o *listPathOptions) (entr
 			return entries, io.EOF
 		}
 		if c.status == scanStateError || c.status == scanStateNone {
+			globalListCoalescer.forgetCache(*o)
 			o.ID = ""
 			o.Create = false
 			o.debugln("scan status", c.status, " - waiting a roundtrip to create")
+		} else if c.started.Before(barrier) {
+			// The cache may miss writes the client requires to be
+			// listed, list from the marker into a new cache.
+			o.debugln("cache", c.id, "started before barrier", barrier, "- recreating")
+			o.ID = mustGetUUID()
+			o.Create = true
 		} else {
 			// Continue listing
 			o.ID = c.id
@@ -349,8 +410,10 @@ func (es *erasureSingle) listPath(ctx context.Context, o *listPathOptions) (entr
 		// We have an existing list ID, continue streaming.
 		if o.Create {
 			o.debugln("Creating", o)
+			started := UTCNow()
 			entries, err = es.listAndSave(ctx, o)
 			if err == nil || err == io.EOF {
+				globalListCoalescer.addCache(*o, started)
 				return entries, err
 			}
 			entries.truncate(0)
@@ -373,12 +436,24 @@ func (es *erasureSingle) listPath(ctx context.Context, o *listPathOptions) (entr
 			return entries, err
 		}
 		entries.truncate(0)
+		globalListCoalescer.forgetCache(*o)
 		o.ID = ""
 		if err != nil {
 			logger.LogIf(ctx, fmt.Errorf("Resuming listing from drives failed %w, proceeding to do raw listing", err))
 		}
 	}
 
+	if o.ID == "" {
+		// Identical listings in flight share a single walk.
+		return globalListCoalescer.walk(ctx, *o, o.Snapshot, func() (metaCacheEntriesSorted, error) {
+			return es.listRaw(ctx, o)
+		})
+	}
+	return es.listRaw(ctx, o)
+}
+
+// listRaw lists the requested entries directly from the drives.
+func (es *erasureSingle) listRaw(ctx context.Context, o *listPathOptions) (entries metaCacheEntriesSorted, err error) {
 	// Do listing in-place.
 	// Create output for our results.
 	// Create filter for results.
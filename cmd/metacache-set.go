@@ -108,8 +108,21 @@ type listPathOptions struct {
 	// StopDiskAtLimit will stop listing on each disk when limit number off objects has been returned.
 	StopDiskAtLimit bool
 
+	// Snapshot is the time the first page of the listing session was
+	// served, objects and versions created later are not returned.
+	Snapshot time.Time
+
+	// VersionMarkerTime is the modtime of the last version returned by the
+	// previous page of a versioned listing, versions at least as recent are
+	// skipped if the version marker no longer exists.
+	VersionMarkerTime time.Time
+
 	// pool and set of where the cache is located.
 	pool, set int
+
+	// resumed is set when Snapshot was decoded from the marker of a
+	// previous page of the listing session.
+	resumed bool
 }
 
 func init() {
@@ -754,9 +767,11 @@ func (es *erasureSingle) listPathInner(ctx context.Context, o listPathOptions, r
 
 	// How to resolve results.
 	resolver := metadataResolutionParams{
-		dirQuorum: 1,
-		objQuorum: 1,
-		bucket:    o.Bucket,
+		dirQuorum:        1,
+		objQuorum:        1,
+		bucket:           o.Bucket,
+		conflict:         globalAPIConfig.getListConflictPolicy(),
+		divergenceSource: divergenceSourceListing,
 	}
 
 	// Maximum versions requested for "latest" object
@@ -829,9 +844,11 @@ func (er *erasureObjects) listPath(ctx context.Context, o listPathOptions, resul
 
 	// How to resolve results.
 	resolver := metadataResolutionParams{
-		dirQuorum: listingQuorum,
-		objQuorum: listingQuorum,
-		bucket:    o.Bucket,
+		dirQuorum:        listingQuorum,
+		objQuorum:        listingQuorum,
+		bucket:           o.Bucket,
+		conflict:         globalAPIConfig.getListConflictPolicy(),
+		divergenceSource: divergenceSourceListing,
 	}
 
 	// Maximum versions requested for "latest" object
@@ -915,21 +932,36 @@ func (es *erasureSingle) saveMetaCacheStream(ctx context.Context, mc *metaCacheR
 	}()
 
 	defer cancel()
+
+	// Let shutdown wait for the listing state to be persisted.
+	shutdownDone, _ := globalShutdownCoordinator.register("listing")
+	defer shutdownDone()
+
 	// Save continuous updates
 	go func() {
 		var err error
 		ticker := time.NewTicker(10 * time.Second)
 		defer ticker.Stop()
-		var exit bool
+		var exit, shutdown bool
 		for !exit {
 			select {
 			case <-ticker.C:
 			case <-ctx.Done():
 				exit = true
+			case <-globalShutdownCoordinator.Done():
+				exit, shutdown = true, true
 			}
 			metaMu.Lock()
 			meta := *mc.meta
 			meta, err = o.updateMetacacheListing(meta, rpc)
+			if err == nil && shutdown && meta.status == scanStateStarted {
+				// Mark the listing as aborted so that clients restart
+				// it instead of waiting on a listing that never ends.
+				meta.status = scanStateError
+				meta.error = "listing aborted by server shutdown"
+				o.debugln(color.Green("saveMetaCacheStream: ") + meta.error)
+				meta, err = o.updateMetacacheListing(meta, rpc)
+			}
 			if err == nil && time.Since(meta.lastHandout) > metacacheMaxClientWait {
 				cancel()
 				exit = true
@@ -1042,21 +1074,36 @@ func (er *erasureObjects) saveMetaCacheStream(ctx context.Context, mc *metaCache
 	}()
 
 	defer cancel()
+
+	// Let shutdown wait for the listing state to be persisted.
+	shutdownDone, _ := globalShutdownCoordinator.register("listing")
+	defer shutdownDone()
+
 	// Save continuous updates
 	go func() {
 		var err error
 		ticker := time.NewTicker(10 * time.Second)
 		defer ticker.Stop()
-		var exit bool
+		var exit, shutdown bool
 		for !exit {
 			select {
 			case <-ticker.C:
 			case <-ctx.Done():
 				exit = true
+			case <-globalShutdownCoordinator.Done():
+				exit, shutdown = true, true
 			}
 			metaMu.Lock()
 			meta := *mc.meta
 			meta, err = o.updateMetacacheListing(meta, rpc)
+			if err == nil && shutdown && meta.status == scanStateStarted {
+				// Mark the listing as aborted so that clients restart
+				// it instead of waiting on a listing that never ends.
+				meta.status = scanStateError
+				meta.error = "listing aborted by server shutdown"
+				o.debugln(color.Green("saveMetaCacheStream: ") + meta.error)
+				meta, err = o.updateMetacacheListing(meta, rpc)
+			}
 			if err == nil && time.Since(meta.lastHandout) > metacacheMaxClientWait {
 				cancel()
 				exit = true
@@ -34,6 +34,7 @@ import (
 	"github.com/prometheus/common/expfmt"
 	"github.com/prometheus/procfs"
 	"github.com/qkbyte/minio/internal/bucket/lifecycle"
+	"github.com/qkbyte/minio/internal/config/identity/openid"
 	"github.com/qkbyte/minio/internal/logger"
 	"github.com/qkbyte/minio/internal/rest"
 )
@@ -67,6 +68,14 @@ func init() {
 		getScannerNodeMetrics(),
 		getIAMNodeMetrics(),
 		getKMSNodeMetrics(),
+		getRangeCacheMetrics(),
+		getGetObjectFastPathMetrics(),
+		getOSMetrics(),
+		getRequesterPaysMetrics(),
+		getTrashMetrics(),
+		getMetaDivergenceMetrics(),
+		getReplicationHealMetrics(),
+		getListCoalesceMetrics(),
 	}
 
 	allMetricsGroups := func() (allMetrics []*MetricsGroup) {
@@ -83,6 +92,7 @@ func init() {
 		getNetworkMetrics(),
 		getMinioVersionMetrics(),
 		getS3TTFBMetric(),
+		getOSMetrics(),
 	})
 	clusterCollector = newMinioClusterCollector(allMetricsGroups)
 }
@@ -127,6 +137,13 @@ const (
 	scannerSubsystem          MetricSubsystem = "scanner"
 	iamSubsystem              MetricSubsystem = "iam"
 	kmsSubsystem              MetricSubsystem = "kms"
+	rangeCacheSubsystem       MetricSubsystem = "range_cache"
+	getFastPathSubsystem      MetricSubsystem = "get_fast_path"
+	requesterPaysSubsystem    MetricSubsystem = "requester_pays"
+	osSubsystem               MetricSubsystem = "os"
+	trashSubsystem            MetricSubsystem = "trash"
+	metadataSubsystem         MetricSubsystem = "metadata"
+	listSubsystem             MetricSubsystem = "list"
 )
 
 // MetricName are the individual names for the metric.
@@ -135,6 +152,8 @@ type MetricName string
 const (
 	authTotal      MetricName = "auth_total"
 	canceledTotal  MetricName = "canceled_total"
+	clockSkewTotal MetricName = "clock_skew_total"
+	credsTotal     MetricName = "credentials_total"
 	errorsTotal    MetricName = "errors_total"
 	headerTotal    MetricName = "header_total"
 	healTotal      MetricName = "heal_total"
@@ -149,10 +168,13 @@ const (
 	offlineTotal   MetricName = "offline_total"
 	onlineTotal    MetricName = "online_total"
 	openTotal      MetricName = "open_total"
+	penalizedTotal MetricName = "penalized_total"
 	readTotal      MetricName = "read_total"
+	throttledTotal MetricName = "throttled_total"
 	timestampTotal MetricName = "timestamp_total"
 	writeTotal     MetricName = "write_total"
 	total          MetricName = "total"
+	requestsTotal  MetricName = "requests_total"
 	freeInodes     MetricName = "free_inodes"
 
 	failedCount     MetricName = "failed_count"
@@ -168,6 +190,11 @@ const (
 	writeBytes      MetricName = "write_bytes"
 	wcharBytes      MetricName = "wchar_bytes"
 
+	pendingCount        MetricName = "pending_count"
+	pendingBytes        MetricName = "pending_bytes"
+	avgLatencyMilliSec  MetricName = "average_latency_ms"
+	oldestPendingAgeSec MetricName = "oldest_pending_age_seconds"
+
 	latencyMicroSec MetricName = "latency_us"
 	latencyNanoSec  MetricName = "latency_ns"
 
@@ -187,6 +214,11 @@ const (
 	cpu              = "cpu_total_seconds"
 
 	expiryPendingTasks     MetricName = "expiry_pending_tasks"
+	expiryWorkers          MetricName = "expiry_workers"
+	expiryExpiredVersions  MetricName = "expiry_expired_versions_total"
+	expiryShedTasks        MetricName = "expiry_shed_tasks_total"
+	expiryQueueWait        MetricName = "expiry_queue_wait_seconds"
+	expiryObjectAge        MetricName = "expiry_object_age_distribution"
 	transitionPendingTasks MetricName = "transition_pending_tasks"
 	transitionActiveTasks  MetricName = "transition_active_tasks"
 
@@ -519,6 +551,46 @@ func getBucketRepFailedOperationsMD() MetricDescription {
 	}
 }
 
+func getBucketRepPendingOperationsMD() MetricDescription {
+	return MetricDescription{
+		Namespace: bucketMetricNamespace,
+		Subsystem: replicationSubsystem,
+		Name:      pendingCount,
+		Help:      "Total number of objects pending replication",
+		Type:      gaugeMetric,
+	}
+}
+
+func getBucketRepPendingBytesMD() MetricDescription {
+	return MetricDescription{
+		Namespace: bucketMetricNamespace,
+		Subsystem: replicationSubsystem,
+		Name:      pendingBytes,
+		Help:      "Total number of bytes pending replication",
+		Type:      gaugeMetric,
+	}
+}
+
+func getBucketRepAvgLatencyMD() MetricDescription {
+	return MetricDescription{
+		Namespace: bucketMetricNamespace,
+		Subsystem: replicationSubsystem,
+		Name:      avgLatencyMilliSec,
+		Help:      "Average replication latency in milliseconds over the last minute",
+		Type:      gaugeMetric,
+	}
+}
+
+func getBucketRepOldestPendingAgeMD() MetricDescription {
+	return MetricDescription{
+		Namespace: bucketMetricNamespace,
+		Subsystem: replicationSubsystem,
+		Name:      oldestPendingAgeSec,
+		Help:      "Age in seconds of the oldest object pending replication",
+		Type:      gaugeMetric,
+	}
+}
+
 func getBucketObjectDistributionMD() MetricDescription {
 	return MetricDescription{
 		Namespace: bucketMetricNamespace,
@@ -709,6 +781,46 @@ func getS3RejectedTimestampRequestsTotalMD() MetricDescription {
 	}
 }
 
+func getS3RejectedClockSkewRequestsTotalMD() MetricDescription {
+	return MetricDescription{
+		Namespace: s3MetricNamespace,
+		Subsystem: requestsRejectedSubsystem,
+		Name:      clockSkewTotal,
+		Help:      "Total number S3 requests rejected because the client clock is outside the allowed request time skew",
+		Type:      counterMetric,
+	}
+}
+
+func getS3RejectedCredentialsRequestsTotalMD() MetricDescription {
+	return MetricDescription{
+		Namespace: s3MetricNamespace,
+		Subsystem: requestsRejectedSubsystem,
+		Name:      credsTotal,
+		Help:      "Total number S3 requests rejected for invalid credentials or signature mismatch",
+		Type:      counterMetric,
+	}
+}
+
+func getS3RejectedThrottledRequestsTotalMD() MetricDescription {
+	return MetricDescription{
+		Namespace: s3MetricNamespace,
+		Subsystem: requestsRejectedSubsystem,
+		Name:      throttledTotal,
+		Help:      "Total number S3 requests rejected after waiting in a full request queue",
+		Type:      counterMetric,
+	}
+}
+
+func getS3RejectedPenalizedRequestsTotalMD() MetricDescription {
+	return MetricDescription{
+		Namespace: s3MetricNamespace,
+		Subsystem: requestsRejectedSubsystem,
+		Name:      penalizedTotal,
+		Help:      "Total number S3 requests rejected for retrying before the Retry-After hint expired",
+		Type:      counterMetric,
+	}
+}
+
 func getS3RejectedInvalidRequestsTotalMD() MetricDescription {
 	return MetricDescription{
 		Namespace: s3MetricNamespace,
@@ -1214,6 +1326,56 @@ func getExpiryPendingTasksMD() MetricDescription {
 	}
 }
 
+func getExpiryWorkersMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: ilmSubsystem,
+		Name:      expiryWorkers,
+		Help:      "Number of ILM expiry workers",
+		Type:      gaugeMetric,
+	}
+}
+
+func getExpiryExpiredVersionsMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: ilmSubsystem,
+		Name:      expiryExpiredVersions,
+		Help:      "Total number of object versions expired by ILM since server start",
+		Type:      counterMetric,
+	}
+}
+
+func getExpiryShedTasksMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: ilmSubsystem,
+		Name:      expiryShedTasks,
+		Help:      "Total number of ILM expiries left to the next scanner cycle because the queue was full",
+		Type:      counterMetric,
+	}
+}
+
+func getExpiryQueueWaitMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: ilmSubsystem,
+		Name:      expiryQueueWait,
+		Help:      "Time the last ILM expiry task picked up by a worker waited in the queue",
+		Type:      gaugeMetric,
+	}
+}
+
+func getExpiryObjectAgeMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: ilmSubsystem,
+		Name:      expiryObjectAge,
+		Help:      "Distribution of the age of object versions when expired by ILM",
+		Type:      histogramMetric,
+	}
+}
+
 func getILMNodeMetrics() *MetricsGroup {
 	mg := &MetricsGroup{}
 	mg.RegisterRead(func(_ context.Context) []Metric {
@@ -1226,18 +1388,41 @@ func getILMNodeMetrics() *MetricsGroup {
 		trActiveTasks := Metric{
 			Description: getTransitionActiveTasksMD(),
 		}
-		if globalExpiryState != nil {
-			expPendingTasks.Value = float64(globalExpiryState.PendingTasks())
-		}
 		if globalTransitionState != nil {
 			trPendingTasks.Value = float64(globalTransitionState.PendingTasks())
 			trActiveTasks.Value = float64(globalTransitionState.ActiveTasks())
 		}
-		return []Metric{
+		metrics := []Metric{
 			expPendingTasks,
 			trPendingTasks,
 			trActiveTasks,
 		}
+		if globalExpiryState != nil {
+			metrics[0].Value = float64(globalExpiryState.PendingTasks())
+			metrics = append(metrics,
+				Metric{
+					Description: getExpiryWorkersMD(),
+					Value:       float64(globalExpiryState.Workers()),
+				},
+				Metric{
+					Description: getExpiryExpiredVersionsMD(),
+					Value:       float64(globalExpiryState.Expired()),
+				},
+				Metric{
+					Description: getExpiryShedTasksMD(),
+					Value:       float64(globalExpiryState.Shed()),
+				},
+				Metric{
+					Description: getExpiryQueueWaitMD(),
+					Value:       globalExpiryState.QueueWait().Seconds(),
+				},
+				Metric{
+					Description:          getExpiryObjectAgeMD(),
+					HistogramBucketLabel: "range",
+					Histogram:            globalExpiryState.AgeHistogram(),
+				})
+		}
+		return metrics
 	})
 	return mg
 }
@@ -1307,6 +1492,55 @@ func getScannerNodeMetrics() *MetricsGroup {
 				Value: float64(globalScannerMetrics.lifetime(scannerMetricILM)),
 			},
 		}
+		for _, pool := range []*scannerWorkerPool{scannerLifecyclePool, scannerReplicationPool} {
+			st := pool.stats()
+			labels := map[string]string{"pool": st.Name}
+			metrics = append(metrics,
+				Metric{
+					Description: MetricDescription{
+						Namespace: nodeMetricNamespace,
+						Subsystem: scannerSubsystem,
+						Name:      "pool_workers",
+						Help:      "Number of workers of the scanner worker pool",
+						Type:      gaugeMetric,
+					},
+					VariableLabels: labels,
+					Value:          float64(st.Workers),
+				},
+				Metric{
+					Description: MetricDescription{
+						Namespace: nodeMetricNamespace,
+						Subsystem: scannerSubsystem,
+						Name:      "pool_queued_tasks",
+						Help:      "Number of tasks waiting for a worker of the scanner worker pool",
+						Type:      gaugeMetric,
+					},
+					VariableLabels: labels,
+					Value:          float64(st.Queued),
+				},
+				Metric{
+					Description: MetricDescription{
+						Namespace: nodeMetricNamespace,
+						Subsystem: scannerSubsystem,
+						Name:      "pool_completed_tasks",
+						Help:      "Total number of tasks completed by the scanner worker pool since server start",
+						Type:      counterMetric,
+					},
+					VariableLabels: labels,
+					Value:          float64(st.Completed),
+				},
+				Metric{
+					Description: MetricDescription{
+						Namespace: nodeMetricNamespace,
+						Subsystem: scannerSubsystem,
+						Name:      "pool_full_waits",
+						Help:      "Total number of times the scanner waited for the workers because the scanner worker pool queue was full",
+						Type:      counterMetric,
+					},
+					VariableLabels: labels,
+					Value:          float64(st.Waited),
+				})
+		}
 		for i := range globalScannerMetrics.actions {
 			action := lifecycle.Action(i)
 			v := globalScannerMetrics.lifetimeActions(action)
@@ -1380,6 +1614,77 @@ func getIAMNodeMetrics() *MetricsGroup {
 				Value: float64(atomic.LoadUint64(&globalIAMSys.TotalRefreshFailures)),
 			},
 		}
+
+		if !globalOpenIDConfig.Enabled {
+			return metrics
+		}
+		openIDMetrics := openid.GetMetrics()
+		var sinceLastJWKSRefreshMillis uint64
+		if !openIDMetrics.LastJWKSRefresh.IsZero() {
+			sinceLastJWKSRefreshMillis = uint64(time.Since(openIDMetrics.LastJWKSRefresh) / time.Millisecond)
+		}
+		metrics = append(metrics, []Metric{
+			{
+				Description: MetricDescription{
+					Namespace: nodeMetricNamespace,
+					Subsystem: iamSubsystem,
+					Name:      "openid_validations",
+					Help:      "Number of OpenID tokens validated since server start.",
+					Type:      counterMetric,
+				},
+				Value: float64(openIDMetrics.Validations),
+			},
+			{
+				Description: MetricDescription{
+					Namespace: nodeMetricNamespace,
+					Subsystem: iamSubsystem,
+					Name:      "openid_validation_failures",
+					Help:      "Number of OpenID tokens that failed validation since server start.",
+					Type:      counterMetric,
+				},
+				Value: float64(openIDMetrics.ValidationFailures),
+			},
+			{
+				Description: MetricDescription{
+					Namespace: nodeMetricNamespace,
+					Subsystem: iamSubsystem,
+					Name:      "openid_validation_seconds",
+					Help:      "Total time spent validating OpenID tokens since server start.",
+					Type:      counterMetric,
+				},
+				Value: openIDMetrics.ValidationTime.Seconds(),
+			},
+			{
+				Description: MetricDescription{
+					Namespace: nodeMetricNamespace,
+					Subsystem: iamSubsystem,
+					Name:      "openid_jwks_refreshes",
+					Help:      "Number of OpenID JWKS refresh attempts since server start.",
+					Type:      counterMetric,
+				},
+				Value: float64(openIDMetrics.JWKSRefreshes),
+			},
+			{
+				Description: MetricDescription{
+					Namespace: nodeMetricNamespace,
+					Subsystem: iamSubsystem,
+					Name:      "openid_jwks_refresh_failures",
+					Help:      "Number of failed OpenID JWKS refresh attempts since server start.",
+					Type:      counterMetric,
+				},
+				Value: float64(openIDMetrics.JWKSRefreshFailures),
+			},
+			{
+				Description: MetricDescription{
+					Namespace: nodeMetricNamespace,
+					Subsystem: iamSubsystem,
+					Name:      "openid_since_last_jwks_refresh_millis",
+					Help:      "Time (in milliseconds) since the last successful OpenID JWKS refresh. This is set to 0 until the first refresh.",
+					Type:      gaugeMetric,
+				},
+				Value: float64(sinceLastJWKSRefreshMillis),
+			},
+		}...)
 		return metrics
 	})
 	return mg
@@ -1542,6 +1847,401 @@ func getCacheMetrics() *MetricsGroup {
 	return mg
 }
 
+func getRequesterPaysMetrics() *MetricsGroup {
+	mg := &MetricsGroup{}
+	mg.RegisterRead(func(_ context.Context) (metrics []Metric) {
+		for bucket, requesters := range globalRequesterPaysUsage.snapshot() {
+			for accessKey, st := range requesters {
+				labels := map[string]string{"bucket": bucket, "accessKey": accessKey}
+				metrics = append(metrics, Metric{
+					Description: MetricDescription{
+						Namespace: bucketMetricNamespace,
+						Subsystem: requesterPaysSubsystem,
+						Name:      requestsTotal,
+						Help:      "Total number of requests charged to the requester",
+						Type:      counterMetric,
+					},
+					Value:          float64(st.Requests),
+					VariableLabels: labels,
+				})
+				metrics = append(metrics, Metric{
+					Description: MetricDescription{
+						Namespace: bucketMetricNamespace,
+						Subsystem: requesterPaysSubsystem,
+						Name:      receivedBytes,
+						Help:      "Total number of bytes received from the requester",
+						Type:      counterMetric,
+					},
+					Value:          float64(st.ReceivedBytes),
+					VariableLabels: labels,
+				})
+				metrics = append(metrics, Metric{
+					Description: MetricDescription{
+						Namespace: bucketMetricNamespace,
+						Subsystem: requesterPaysSubsystem,
+						Name:      sentBytes,
+						Help:      "Total number of bytes sent to the requester",
+						Type:      counterMetric,
+					},
+					Value:          float64(st.SentBytes),
+					VariableLabels: labels,
+				})
+			}
+		}
+		return metrics
+	})
+	return mg
+}
+
+func getRangeCacheMetrics() *MetricsGroup {
+	mg := &MetricsGroup{}
+	mg.RegisterRead(func(_ context.Context) []Metric {
+		st := globalRangeCache.stats()
+		return []Metric{
+			{
+				Description: MetricDescription{
+					Namespace: nodeMetricNamespace,
+					Subsystem: rangeCacheSubsystem,
+					Name:      hitsTotal,
+					Help:      "Total number of range cache block hits",
+					Type:      counterMetric,
+				},
+				Value: float64(st.Hits),
+			},
+			{
+				Description: MetricDescription{
+					Namespace: nodeMetricNamespace,
+					Subsystem: rangeCacheSubsystem,
+					Name:      missedTotal,
+					Help:      "Total number of range cache block misses",
+					Type:      counterMetric,
+				},
+				Value: float64(st.Misses),
+			},
+			{
+				Description: MetricDescription{
+					Namespace: nodeMetricNamespace,
+					Subsystem: rangeCacheSubsystem,
+					Name:      "evictions_total",
+					Help:      "Total number of blocks evicted from the range cache",
+					Type:      counterMetric,
+				},
+				Value: float64(st.Evictions),
+			},
+			{
+				Description: MetricDescription{
+					Namespace: nodeMetricNamespace,
+					Subsystem: rangeCacheSubsystem,
+					Name:      usedBytes,
+					Help:      "Total memory used by the range cache",
+					Type:      gaugeMetric,
+				},
+				Value: float64(st.Size),
+			},
+			{
+				Description: MetricDescription{
+					Namespace: nodeMetricNamespace,
+					Subsystem: rangeCacheSubsystem,
+					Name:      totalBytes,
+					Help:      "Maximum memory configured for the range cache",
+					Type:      gaugeMetric,
+				},
+				Value: float64(st.MaxSize),
+			},
+		}
+	})
+	return mg
+}
+
+func getTrashMetrics() *MetricsGroup {
+	mg := &MetricsGroup{}
+	mg.RegisterRead(func(_ context.Context) []Metric {
+		st := globalTrashJanitor.stats()
+		return []Metric{
+			{
+				Description: MetricDescription{
+					Namespace: nodeMetricNamespace,
+					Subsystem: trashSubsystem,
+					Name:      "backlog_bytes",
+					Help:      "Total size of deleted data waiting in the .trash folder of the local drives",
+					Type:      gaugeMetric,
+				},
+				Value: float64(st.BacklogBytes),
+			},
+			{
+				Description: MetricDescription{
+					Namespace: nodeMetricNamespace,
+					Subsystem: trashSubsystem,
+					Name:      "backlog_entries",
+					Help:      "Total number of entries waiting in the .trash folder of the local drives",
+					Type:      gaugeMetric,
+				},
+				Value: float64(st.BacklogEntries),
+			},
+			{
+				Description: MetricDescription{
+					Namespace: nodeMetricNamespace,
+					Subsystem: trashSubsystem,
+					Name:      "reclaimed_bytes_total",
+					Help:      "Total number of bytes reclaimed by permanently deleting .trash entries",
+					Type:      counterMetric,
+				},
+				Value: float64(st.ReclaimedBytes),
+			},
+			{
+				Description: MetricDescription{
+					Namespace: nodeMetricNamespace,
+					Subsystem: trashSubsystem,
+					Name:      "reclaimed_entries_total",
+					Help:      "Total number of .trash entries permanently deleted",
+					Type:      counterMetric,
+				},
+				Value: float64(st.ReclaimedEntries),
+			},
+		}
+	})
+	return mg
+}
+
+func getMetaDivergenceMetrics() *MetricsGroup {
+	mg := &MetricsGroup{}
+	mg.RegisterRead(func(_ context.Context) (metrics []Metric) {
+		for source, n := range globalMetaDivergence.counts() {
+			metrics = append(metrics, Metric{
+				Description: MetricDescription{
+					Namespace: nodeMetricNamespace,
+					Subsystem: metadataSubsystem,
+					Name:      "divergences_total",
+					Help:      "Total number of objects the drives disagreed on while resolving them, by source",
+					Type:      counterMetric,
+				},
+				VariableLabels: map[string]string{"source": source},
+				Value:          float64(n),
+			})
+		}
+		return metrics
+	})
+	return mg
+}
+
+func getReplicationHealMetrics() *MetricsGroup {
+	mg := &MetricsGroup{}
+	mg.RegisterRead(func(_ context.Context) []Metric {
+		return []Metric{
+			{
+				Description: MetricDescription{
+					Namespace: nodeMetricNamespace,
+					Subsystem: replicationSubsystem,
+					Name:      "heal_queued_total",
+					Help:      "Total number of versions queued for replication heal by listings and scanner passes",
+					Type:      counterMetric,
+				},
+				Value: float64(globalReplicationHealDedup.Queued()),
+			},
+			{
+				Description: MetricDescription{
+					Namespace: nodeMetricNamespace,
+					Subsystem: replicationSubsystem,
+					Name:      "heal_suppressed_total",
+					Help:      "Total number of replication heals not queued because the version was queued recently",
+					Type:      counterMetric,
+				},
+				Value: float64(globalReplicationHealDedup.Suppressed()),
+			},
+		}
+	})
+	return mg
+}
+
+func getListCoalesceMetrics() *MetricsGroup {
+	mg := &MetricsGroup{}
+	mg.RegisterRead(func(_ context.Context) []Metric {
+		coalescedMD := MetricDescription{
+			Namespace: nodeMetricNamespace,
+			Subsystem: listSubsystem,
+			Name:      "coalesced_total",
+			Help:      "Total number of listings served by the drive walk or the listing cache of a concurrent identical listing, by type",
+			Type:      counterMetric,
+		}
+		return []Metric{
+			{
+				Description: MetricDescription{
+					Namespace: nodeMetricNamespace,
+					Subsystem: listSubsystem,
+					Name:      "coalesce_requests_total",
+					Help:      "Total number of listings that looked for a concurrent identical listing to share",
+					Type:      counterMetric,
+				},
+				Value: float64(globalListCoalescer.Requests()),
+			},
+			{
+				Description:    coalescedMD,
+				VariableLabels: map[string]string{"type": "walk"},
+				Value:          float64(globalListCoalescer.SharedWalks()),
+			},
+			{
+				Description:    coalescedMD,
+				VariableLabels: map[string]string{"type": "cache"},
+				Value:          float64(globalListCoalescer.SharedCaches()),
+			},
+		}
+	})
+	return mg
+}
+
+func getGetObjectFastPathMetrics() *MetricsGroup {
+	mg := &MetricsGroup{}
+	mg.RegisterRead(func(_ context.Context) []Metric {
+		return []Metric{
+			{
+				Description: MetricDescription{
+					Namespace: nodeMetricNamespace,
+					Subsystem: getFastPathSubsystem,
+					Name:      hitsTotal,
+					Help:      "Total number of GetObject requests served from the raw file via zero-copy",
+					Type:      counterMetric,
+				},
+				Value: float64(atomic.LoadUint64(&globalGetObjectFastPath.hits)),
+			},
+			{
+				Description: MetricDescription{
+					Namespace: nodeMetricNamespace,
+					Subsystem: getFastPathSubsystem,
+					Name:      "requests_total",
+					Help:      "Total number of GetObject requests served by this node",
+					Type:      counterMetric,
+				},
+				Value: float64(atomic.LoadUint64(&globalGetObjectFastPath.total)),
+			},
+		}
+	})
+	return mg
+}
+
+func getOSOperationsMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: osSubsystem,
+		Name:      "operations_total",
+		Help:      "Total number of operating system calls made by drive I/O",
+		Type:      counterMetric,
+	}
+}
+
+func getOSLastMinuteOperationsMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: osSubsystem,
+		Name:      "last_minute_operations",
+		Help:      "Number of operating system calls made by drive I/O in the last minute",
+		Type:      gaugeMetric,
+	}
+}
+
+func getOSLatencyMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: osSubsystem,
+		Name:      latencyMicroSec,
+		Help:      "Average last minute latency in µs of operating system calls made by drive I/O",
+		Type:      gaugeMetric,
+	}
+}
+
+func getOSBytesMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: osSubsystem,
+		Name:      "bytes_total",
+		Help:      "Total number of bytes transferred by operating system reads, writes and directory reads",
+		Type:      counterMetric,
+	}
+}
+
+func getOSIOSizeDistributionMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: osSubsystem,
+		Name:      "io_size_distribution",
+		Help:      "Distribution of the size of operating system reads, writes and directory reads",
+		Type:      counterMetric,
+	}
+}
+
+func getOSReadDirEntriesDistributionMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: osSubsystem,
+		Name:      "readdir_entries_distribution",
+		Help:      "Distribution of the number of entries of fully enumerated directories",
+		Type:      counterMetric,
+	}
+}
+
+func getOSReadDirSlowMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: osSubsystem,
+		Name:      "readdir_slow_total",
+		Help:      "Total number of directory enumerations that exceeded the slow duration or entry count",
+		Type:      counterMetric,
+	}
+}
+
+func getOSMetrics() *MetricsGroup {
+	mg := &MetricsGroup{}
+	mg.RegisterRead(func(_ context.Context) (metrics []Metric) {
+		m := globalOSMetrics.report()
+		metrics = make([]Metric, 0, len(m.LifeTimeOps)+2*len(m.LastMinute.Operations))
+		for op, n := range m.LifeTimeOps {
+			metrics = append(metrics, Metric{
+				Description:    getOSOperationsMD(),
+				Value:          float64(n),
+				VariableLabels: map[string]string{"op": op},
+			})
+		}
+		for op, action := range m.LastMinute.Operations {
+			metrics = append(metrics, Metric{
+				Description:    getOSLastMinuteOperationsMD(),
+				Value:          float64(action.Count),
+				VariableLabels: map[string]string{"op": op},
+			})
+			metrics = append(metrics, Metric{
+				Description:    getOSLatencyMD(),
+				Value:          float64(action.Avg().Microseconds()),
+				VariableLabels: map[string]string{"op": op},
+			})
+		}
+		for op, st := range globalOSMetrics.ioReport() {
+			metrics = append(metrics, Metric{
+				Description:    getOSBytesMD(),
+				Value:          float64(st.Bytes),
+				VariableLabels: map[string]string{"op": op.String()},
+			})
+			for i, interval := range osIOSizeIntervals {
+				metrics = append(metrics, Metric{
+					Description:    getOSIOSizeDistributionMD(),
+					Value:          float64(st.Sizes[i]),
+					VariableLabels: map[string]string{"op": op.String(), "range": interval.name},
+				})
+			}
+		}
+		for i, n := range globalReadDirStats.entriesDistribution() {
+			metrics = append(metrics, Metric{
+				Description:    getOSReadDirEntriesDistributionMD(),
+				Value:          float64(n),
+				VariableLabels: map[string]string{"range": readDirEntriesIntervals[i].name},
+			})
+		}
+		metrics = append(metrics, Metric{
+			Description: getOSReadDirSlowMD(),
+			Value:       float64(globalReadDirStats.report().Slow),
+		})
+		return
+	})
+	return mg
+}
+
 func getHTTPMetrics() *MetricsGroup {
 	mg := &MetricsGroup{}
 	mg.RegisterRead(func(ctx context.Context) (metrics []Metric) {
@@ -1560,6 +2260,14 @@ func getHTTPMetrics() *MetricsGroup {
 			Description: getS3RejectedTimestampRequestsTotalMD(),
 			Value:       float64(httpStats.TotalS3RejectedTime),
 		})
+		metrics = append(metrics, Metric{
+			Description: getS3RejectedClockSkewRequestsTotalMD(),
+			Value:       float64(httpStats.TotalS3RejectedSkew),
+		})
+		metrics = append(metrics, Metric{
+			Description: getS3RejectedCredentialsRequestsTotalMD(),
+			Value:       float64(httpStats.TotalS3RejectedCreds),
+		})
 		metrics = append(metrics, Metric{
 			Description: getS3RejectedHeaderRequestsTotalMD(),
 			Value:       float64(httpStats.TotalS3RejectedHeader),
@@ -1568,6 +2276,14 @@ func getHTTPMetrics() *MetricsGroup {
 			Description: getS3RejectedInvalidRequestsTotalMD(),
 			Value:       float64(httpStats.TotalS3RejectedInvalid),
 		})
+		metrics = append(metrics, Metric{
+			Description: getS3RejectedThrottledRequestsTotalMD(),
+			Value:       float64(atomic.LoadUint64(&globalRequestBackoff.throttled)),
+		})
+		metrics = append(metrics, Metric{
+			Description: getS3RejectedPenalizedRequestsTotalMD(),
+			Value:       float64(atomic.LoadUint64(&globalRequestBackoff.penalized)),
+		})
 		metrics = append(metrics, Metric{
 			Description: getS3RequestsInQueueMD(),
 			Value:       float64(httpStats.S3RequestsInQueue),
@@ -1759,13 +2475,36 @@ func getBucketUsageMetrics() *MetricsGroup {
 						Value:          float64(stat.FailedCount),
 						VariableLabels: map[string]string{"bucket": bucket, "targetArn": arn},
 					})
+					metrics = append(metrics, Metric{
+						Description:    getBucketRepPendingBytesMD(),
+						Value:          float64(stat.PendingSize),
+						VariableLabels: map[string]string{"bucket": bucket, "targetArn": arn},
+					})
+					metrics = append(metrics, Metric{
+						Description:    getBucketRepPendingOperationsMD(),
+						Value:          float64(stat.PendingCount),
+						VariableLabels: map[string]string{"bucket": bucket, "targetArn": arn},
+					})
 					metrics = append(metrics, Metric{
 						Description:          getBucketRepLatencyMD(),
 						HistogramBucketLabel: "range",
 						Histogram:            stat.Latency.getUploadLatency(),
 						VariableLabels:       map[string]string{"bucket": bucket, "operation": "upload", "targetArn": arn},
 					})
-
+					metrics = append(metrics, Metric{
+						Description:    getBucketRepAvgLatencyMD(),
+						Value:          float64(stat.Latency.getAvgUploadLatency() / time.Millisecond),
+						VariableLabels: map[string]string{"bucket": bucket, "operation": "upload", "targetArn": arn},
+					})
+					var oldestAge time.Duration
+					if !stat.OldestPending.IsZero() {
+						oldestAge = time.Since(stat.OldestPending)
+					}
+					metrics = append(metrics, Metric{
+						Description:    getBucketRepOldestPendingAgeMD(),
+						Value:          oldestAge.Seconds(),
+						VariableLabels: map[string]string{"bucket": bucket, "targetArn": arn},
+					})
 				}
 			}
 
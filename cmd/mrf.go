@@ -33,7 +33,8 @@ const (
 )
 
 // partialOperation is a successful upload/delete of an object
-// but not written in all disks (having quorum)
+// but not written in all disks (having quorum), or an object
+// whose integrity needs to be re-verified.
 type partialOperation struct {
 	bucket    string
 	object    string
@@ -41,6 +42,7 @@ type partialOperation struct {
 	size      int64
 	setIndex  int
 	poolIndex int
+	scanMode  madmin.HealScanMode
 }
 
 type setInfo struct {
@@ -184,60 +186,75 @@ func (m *mrfState) healRoutine() {
 	idler := time.NewTimer(mrfInfoResetInterval)
 	defer idler.Stop()
 
-	mrfHealingOpts := madmin.HealOpts{
-		ScanMode: madmin.HealNormalScan,
-		Remove:   healDeleteDangling,
-	}
-
 	for {
 		select {
 		case <-m.ctx.Done():
 			return
 		case <-idler.C:
 			m.resetMRFInfoIfNoPendingOps()
+			if globalIsErasureSD {
+				// A single drive never reconnects as a set, drain
+				// the queued operations periodically instead.
+				m.healSet(setInfo{})
+			}
 			idler.Reset(mrfInfoResetInterval)
 		case setInfo := <-m.setReconnectEvent:
-			// Get the list of objects related the er.set
-			// to which the connected disk belongs.
-			var mrfOperations []partialOperation
-			m.mu.Lock()
-			for k, v := range m.pendingOps {
-				if v == setInfo {
-					mrfOperations = append(mrfOperations, k)
-				}
-			}
-			m.mu.Unlock()
+			m.healSet(setInfo)
+		}
+	}
+}
 
-			if len(mrfOperations) == 0 {
-				continue
-			}
+// healSet issues healing requests for the queued objects belonging
+// to the given erasure set.
+func (m *mrfState) healSet(setInfo setInfo) {
+	mrfHealingOpts := madmin.HealOpts{
+		ScanMode: madmin.HealNormalScan,
+		Remove:   healDeleteDangling,
+	}
 
-			m.mu.Lock()
-			m.triggeredAt = time.Now().UTC()
-			m.mu.Unlock()
+	// Get the list of objects related the er.set
+	// to which the connected disk belongs.
+	var mrfOperations []partialOperation
+	m.mu.Lock()
+	for k, v := range m.pendingOps {
+		if v == setInfo {
+			mrfOperations = append(mrfOperations, k)
+		}
+	}
+	m.mu.Unlock()
 
-			// Heal objects
-			for _, u := range mrfOperations {
-				_, err := m.objectAPI.HealObject(m.ctx, u.bucket, u.object, u.versionID, mrfHealingOpts)
-				m.mu.Lock()
-				if err == nil {
-					m.itemsHealed++
-					m.bytesHealed += uint64(u.size)
-				}
-				m.pendingItems--
-				m.pendingBytes -= uint64(u.size)
-				delete(m.pendingOps, u)
-				m.mu.Unlock()
-
-				if !isErrObjectNotFound(err) && !isErrVersionNotFound(err) {
-					// Log healing error if any
-					logger.LogIf(m.ctx, err)
-				}
-			}
+	if len(mrfOperations) == 0 {
+		return
+	}
+
+	m.mu.Lock()
+	m.triggeredAt = time.Now().UTC()
+	m.mu.Unlock()
+
+	// Heal objects
+	for _, u := range mrfOperations {
+		opts := mrfHealingOpts
+		if u.scanMode != madmin.HealUnknownScan {
+			opts.ScanMode = u.scanMode
+		}
+		_, err := m.objectAPI.HealObject(m.ctx, u.bucket, u.object, u.versionID, opts)
+		m.mu.Lock()
+		if err == nil {
+			m.itemsHealed++
+			m.bytesHealed += uint64(u.size)
+		}
+		m.pendingItems--
+		m.pendingBytes -= uint64(u.size)
+		delete(m.pendingOps, u)
+		m.mu.Unlock()
 
-			waitForLowHTTPReq()
+		if !isErrObjectNotFound(err) && !isErrVersionNotFound(err) {
+			// Log healing error if any
+			logger.LogIf(m.ctx, err)
 		}
 	}
+
+	waitForLowHTTPReq()
 }
 
 // Initialize healing MRF
@@ -0,0 +1,424 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/minio/pkg/env"
+	iampolicy "github.com/minio/pkg/iam/policy"
+	"github.com/qkbyte/minio/internal/config"
+	"github.com/qkbyte/minio/internal/logger"
+)
+
+const (
+	// netMonitorPings is the number of pings sent to every peer per
+	// round, the share that failed is reported as loss.
+	netMonitorPings = 5
+
+	// netMonitorPingTimeout bounds a single ping, a slower answer is
+	// counted as lost.
+	netMonitorPingTimeout = 2 * time.Second
+
+	// netMonitorPayloadTimeout bounds a single throughput sample.
+	netMonitorPayloadTimeout = 10 * time.Second
+
+	// An alert is cleared after netMonitorClearAfter consecutive samples
+	// within the thresholds, so that a flapping link stays flagged.
+	netMonitorClearAfter = 3
+)
+
+// netMonitorConfig controls the background network monitor, see the
+// MINIO_NET_MONITOR_* environment variables.
+type netMonitorConfig struct {
+	// interval between two probe rounds, zero disables the monitor.
+	interval time.Duration
+	// retention of the sample history per peer.
+	retention time.Duration
+	// size of the payload sent to measure throughput, zero disables
+	// throughput samples.
+	payload int64
+	// mean round trip time above which a peer is alerted on, zero
+	// disables the latency alert.
+	latency time.Duration
+	// percentage of lost pings above which a peer is alerted on, zero
+	// disables the loss alert.
+	loss float64
+}
+
+func defaultNetMonitorConfig() netMonitorConfig {
+	return netMonitorConfig{
+		interval:  time.Minute,
+		retention: 24 * time.Hour,
+		payload:   64 * humanize.KiByte,
+		latency:   100 * time.Millisecond,
+		loss:      5,
+	}
+}
+
+// lookupNetMonitorConfig reads the network monitor settings from the
+// environment.
+func lookupNetMonitorConfig() (cfg netMonitorConfig, err error) {
+	cfg = defaultNetMonitorConfig()
+
+	parseDuration := func(key string, d *time.Duration) error {
+		v := env.Get(key, "")
+		if v == "" {
+			return nil
+		}
+		if *d, err = time.ParseDuration(v); err != nil || *d < 0 {
+			return fmt.Errorf("invalid %s value %q", key, v)
+		}
+		return nil
+	}
+	if err = parseDuration(config.EnvNetMonitorInterval, &cfg.interval); err != nil {
+		return cfg, err
+	}
+	if err = parseDuration(config.EnvNetMonitorRetention, &cfg.retention); err != nil {
+		return cfg, err
+	}
+	if err = parseDuration(config.EnvNetMonitorLatency, &cfg.latency); err != nil {
+		return cfg, err
+	}
+	if v := env.Get(config.EnvNetMonitorPayload, ""); v != "" {
+		size, err := humanize.ParseBytes(v)
+		if err != nil || size > 64*humanize.MiByte {
+			return cfg, fmt.Errorf("invalid %s value %q", config.EnvNetMonitorPayload, v)
+		}
+		cfg.payload = int64(size)
+	}
+	if v := env.Get(config.EnvNetMonitorLoss, ""); v != "" {
+		loss, err := strconv.ParseFloat(v, 64)
+		if err != nil || loss < 0 || loss > 100 {
+			return cfg, fmt.Errorf("invalid %s value %q", config.EnvNetMonitorLoss, v)
+		}
+		cfg.loss = loss
+	}
+	if cfg.interval > 0 && cfg.retention < cfg.interval {
+		return cfg, fmt.Errorf("%s must not be shorter than %s", config.EnvNetMonitorRetention, config.EnvNetMonitorInterval)
+	}
+	return cfg, nil
+}
+
+// netSample is the network quality to a peer measured in one round.
+type netSample struct {
+	Time time.Time `json:"time"`
+	// Mean and maximum round trip time of the pings answered.
+	Latency    time.Duration `json:"latency"`
+	MaxLatency time.Duration `json:"maxLatency"`
+	// Percentage of pings not answered in time.
+	Loss float64 `json:"loss"`
+	// Bytes per second sent to the peer, zero when not measured.
+	Throughput uint64 `json:"throughput,omitempty"`
+}
+
+// netPeerReport is the sample history to a peer and its alert state.
+type netPeerReport struct {
+	Peer       string      `json:"peer"`
+	Alerting   bool        `json:"alerting"`
+	AlertSince time.Time   `json:"alertSince,omitempty"`
+	Alerts     uint64      `json:"alerts"`
+	Samples    []netSample `json:"samples"`
+}
+
+// netMonitorReport is the network quality to all peers as measured by
+// a single node.
+type netMonitorReport struct {
+	Node  string          `json:"node"`
+	Peers []netPeerReport `json:"peers"`
+}
+
+type netPeerHistory struct {
+	samples    []netSample // oldest first
+	alerting   bool
+	alertSince time.Time
+	alerts     uint64
+	healthy    int // consecutive samples within thresholds
+}
+
+// netMonitor keeps the history of the network quality to every peer,
+// sampled at a low rate in the background so that intermittent issues
+// are recorded when they happen instead of only on demand.
+type netMonitor struct {
+	cfg netMonitorConfig
+
+	mu    sync.RWMutex
+	peers map[string]*netPeerHistory
+}
+
+func newNetMonitor(cfg netMonitorConfig) *netMonitor {
+	return &netMonitor{cfg: cfg, peers: make(map[string]*netPeerHistory)}
+}
+
+// breached returns true if s is outside of the configured thresholds.
+func (m *netMonitor) breached(s netSample) bool {
+	if m.cfg.loss > 0 && s.Loss > m.cfg.loss {
+		return true
+	}
+	return m.cfg.latency > 0 && s.Loss < 100 && s.Latency > m.cfg.latency
+}
+
+// record adds a sample to the history of peer, dropping the samples
+// older than the retention. It returns whether the sample raised or
+// cleared the alert of the peer.
+func (m *netMonitor) record(peer string, s netSample) (raised, cleared bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	h, ok := m.peers[peer]
+	if !ok {
+		h = &netPeerHistory{}
+		m.peers[peer] = h
+	}
+
+	cutoff := s.Time.Add(-m.cfg.retention)
+	i := sort.Search(len(h.samples), func(i int) bool {
+		return h.samples[i].Time.After(cutoff)
+	})
+	h.samples = append(h.samples[i:], s)
+
+	if m.breached(s) {
+		h.healthy = 0
+		if !h.alerting {
+			h.alerting = true
+			h.alertSince = s.Time
+			h.alerts++
+			return true, false
+		}
+		return false, false
+	}
+	h.healthy++
+	if h.alerting && h.healthy >= netMonitorClearAfter {
+		h.alerting = false
+		h.alertSince = time.Time{}
+		return false, true
+	}
+	return false, false
+}
+
+// report returns the samples taken after since to all peers.
+func (m *netMonitor) report(node string, since time.Time) netMonitorReport {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	report := netMonitorReport{Node: node, Peers: make([]netPeerReport, 0, len(m.peers))}
+	for peer, h := range m.peers {
+		i := sort.Search(len(h.samples), func(i int) bool {
+			return h.samples[i].Time.After(since)
+		})
+		report.Peers = append(report.Peers, netPeerReport{
+			Peer:       peer,
+			Alerting:   h.alerting,
+			AlertSince: h.alertSince,
+			Alerts:     h.alerts,
+			Samples:    append([]netSample(nil), h.samples[i:]...),
+		})
+	}
+	sort.Slice(report.Peers, func(i, j int) bool {
+		return report.Peers[i].Peer < report.Peers[j].Peer
+	})
+	return report
+}
+
+// netProber is the part of a peer client used to probe the network.
+type netProber interface {
+	NetProbe(ctx context.Context, payload []byte) error
+}
+
+// probe measures the network quality to a peer, the throughput is only
+// sampled if the peer answered at least one ping.
+func (m *netMonitor) probe(ctx context.Context, client netProber, payload []byte) netSample {
+	s := netSample{Time: UTCNow()}
+
+	var answered int
+	var total time.Duration
+	for i := 0; i < netMonitorPings; i++ {
+		pctx, cancel := context.WithTimeout(ctx, netMonitorPingTimeout)
+		sent := time.Now()
+		err := client.NetProbe(pctx, nil)
+		rtt := time.Since(sent)
+		cancel()
+		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			continue
+		}
+		answered++
+		total += rtt
+		if rtt > s.MaxLatency {
+			s.MaxLatency = rtt
+		}
+	}
+	s.Loss = float64(netMonitorPings-answered) * 100 / netMonitorPings
+	if answered == 0 {
+		return s
+	}
+	s.Latency = total / time.Duration(answered)
+
+	if len(payload) > 0 {
+		pctx, cancel := context.WithTimeout(ctx, netMonitorPayloadTimeout)
+		sent := time.Now()
+		err := client.NetProbe(pctx, payload)
+		elapsed := time.Since(sent)
+		cancel()
+		if err == nil && elapsed > 0 {
+			s.Throughput = uint64(float64(len(payload)) / elapsed.Seconds())
+		}
+	}
+	return s
+}
+
+// monitor probes peer and records the result, logging the alerts
+// raised and cleared.
+func (m *netMonitor) monitor(ctx context.Context, peer string, client netProber, payload []byte) {
+	s := m.probe(ctx, client, payload)
+	if ctx.Err() != nil {
+		return
+	}
+
+	raised, cleared := m.record(peer, s)
+	switch {
+	case raised:
+		logger.LogIf(ctx, fmt.Errorf("Network quality to %s degraded: latency %s (max %s), %.0f percent of pings lost",
+			peer, s.Latency, s.MaxLatency, s.Loss))
+	case cleared:
+		logger.Info("Network quality to %s is back within thresholds", peer)
+	}
+}
+
+// initNetMonitor starts probing all peers every interval, unless the
+// monitor is disabled.
+func initNetMonitor(ctx context.Context) {
+	m := globalNetMonitor
+	if m == nil || m.cfg.interval <= 0 || globalNotificationSys == nil {
+		return
+	}
+
+	peers := make([]*peerRESTClient, 0, len(globalNotificationSys.peerClients))
+	for _, client := range globalNotificationSys.peerClients {
+		if client != nil {
+			peers = append(peers, client)
+		}
+	}
+	if len(peers) == 0 {
+		return
+	}
+	payload := make([]byte, m.cfg.payload)
+
+	go func() {
+		r := rand.New(rand.NewSource(time.Now().UnixNano()))
+		for {
+			// Add some jitter so that the rounds of all nodes spread out.
+			duration := m.cfg.interval/2 + time.Duration(r.Int63n(int64(m.cfg.interval)))
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(duration):
+			}
+
+			var wg sync.WaitGroup
+			for _, client := range peers {
+				wg.Add(1)
+				go func(client *peerRESTClient) {
+					defer wg.Done()
+					m.monitor(ctx, client.host.String(), client, payload)
+				}(client)
+			}
+			wg.Wait()
+		}
+	}()
+}
+
+// localNetMonitor returns the samples this node took within the last
+// window, zero returns the whole retained history. The window is
+// applied by every node with its own clock.
+func localNetMonitor(window time.Duration) netMonitorReport {
+	var since time.Time
+	if window > 0 {
+		since = UTCNow().Add(-window)
+	}
+	return globalNetMonitor.report(globalLocalNodeName, since)
+}
+
+// getNetMonitorReports returns the network quality measured by all
+// nodes within the last window, along with the nodes that could not be
+// reached.
+func getNetMonitorReports(ctx context.Context, window time.Duration) ([]netMonitorReport, map[string]string) {
+	reports := []netMonitorReport{localNetMonitor(window)}
+	if globalNotificationSys == nil {
+		return reports, nil
+	}
+	peers, nodeErrs := globalNotificationSys.GetNetMonitor(ctx, window)
+	reports = append(reports, peers...)
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].Node < reports[j].Node
+	})
+	return reports, nodeErrs
+}
+
+// NetMonitorHandler - GET /minio/admin/v3/net-monitor?since=1h
+// ----------
+// Returns the latency, loss and throughput samples every node took to
+// its peers in the background, along with the peers currently alerted
+// on. Without since the whole retained history is returned.
+func (a adminAPIHandlers) NetMonitorHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "NetMonitor")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.HealthInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	if !globalIsDistErasure {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	var window time.Duration
+	if v := r.Form.Get("since"); v != "" {
+		var err error
+		window, err = time.ParseDuration(v)
+		if err != nil || window <= 0 {
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+			return
+		}
+	}
+
+	nodes, nodeErrs := getNetMonitorReports(ctx, window)
+	resp, err := json.Marshal(struct {
+		Nodes      []netMonitorReport `json:"nodes"`
+		NodeErrors map[string]string  `json:"nodeErrors,omitempty"`
+	}{nodes, nodeErrs})
+	if err != nil {
+		writeCustomErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInternalError), err.Error(), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, resp)
+}
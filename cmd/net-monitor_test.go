@@ -0,0 +1,156 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/qkbyte/minio/internal/config"
+)
+
+func TestNetMonitorRecord(t *testing.T) {
+	cfg := defaultNetMonitorConfig()
+	cfg.retention = 10 * time.Minute
+	m := newNetMonitor(cfg)
+
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	good := netSample{Latency: time.Millisecond}
+	lossy := netSample{Latency: time.Millisecond, Loss: 40}
+	slow := netSample{Latency: time.Second}
+	down := netSample{Loss: 100}
+
+	testCases := []struct {
+		sample  netSample
+		raised  bool
+		cleared bool
+	}{
+		{good, false, false},
+		{lossy, true, false},
+		{slow, false, false},
+		{good, false, false},
+		{good, false, false},
+		{good, false, true},
+		{down, true, false},
+		{good, false, false},
+	}
+	for i, tc := range testCases {
+		tc.sample.Time = start.Add(time.Duration(i) * time.Minute)
+		raised, cleared := m.record("peer", tc.sample)
+		if raised != tc.raised || cleared != tc.cleared {
+			t.Fatalf("Test %d: expected raised=%v cleared=%v, got %v %v", i+1, tc.raised, tc.cleared, raised, cleared)
+		}
+	}
+
+	report := m.report("node", time.Time{})
+	if len(report.Peers) != 1 {
+		t.Fatalf("expected one peer, got %d", len(report.Peers))
+	}
+	peer := report.Peers[0]
+	if !peer.Alerting || peer.Alerts != 2 || !peer.AlertSince.Equal(start.Add(6*time.Minute)) {
+		t.Fatalf("unexpected alert state %+v", peer)
+	}
+	if len(peer.Samples) != len(testCases) {
+		t.Fatalf("expected %d samples, got %d", len(testCases), len(peer.Samples))
+	}
+
+	// Samples past the retention are dropped on the next record.
+	m.record("peer", netSample{Time: start.Add(15 * time.Minute)})
+	report = m.report("node", time.Time{})
+	if n := len(report.Peers[0].Samples); n != 3 {
+		t.Fatalf("expected 3 retained samples, got %d", n)
+	}
+	report = m.report("node", start.Add(10*time.Minute))
+	if n := len(report.Peers[0].Samples); n != 1 {
+		t.Fatalf("expected 1 sample since 10m, got %d", n)
+	}
+}
+
+type testNetProber struct {
+	calls int32
+	fail  func(call int32, payload []byte) bool
+}
+
+func (p *testNetProber) NetProbe(ctx context.Context, payload []byte) error {
+	call := atomic.AddInt32(&p.calls, 1)
+	if p.fail != nil && p.fail(call, payload) {
+		return errors.New("probe failed")
+	}
+	return nil
+}
+
+func TestNetMonitorProbe(t *testing.T) {
+	m := newNetMonitor(defaultNetMonitorConfig())
+	payload := make([]byte, 1024)
+
+	p := &testNetProber{}
+	s := m.probe(context.Background(), p, payload)
+	if s.Loss != 0 || s.Throughput == 0 || s.MaxLatency < s.Latency {
+		t.Fatalf("unexpected sample %+v", s)
+	}
+	if p.calls != netMonitorPings+1 {
+		t.Fatalf("expected %d calls, got %d", netMonitorPings+1, p.calls)
+	}
+
+	// Every other ping is lost.
+	p = &testNetProber{fail: func(call int32, payload []byte) bool {
+		return payload == nil && call%2 == 0
+	}}
+	s = m.probe(context.Background(), p, payload)
+	if s.Loss != 40 || s.Throughput == 0 {
+		t.Fatalf("unexpected sample %+v", s)
+	}
+
+	// No throughput sample once the peer is unreachable.
+	p = &testNetProber{fail: func(int32, []byte) bool { return true }}
+	s = m.probe(context.Background(), p, payload)
+	if s.Loss != 100 || s.Throughput != 0 || p.calls != netMonitorPings {
+		t.Fatalf("unexpected sample %+v after %d calls", s, p.calls)
+	}
+}
+
+func TestLookupNetMonitorConfig(t *testing.T) {
+	t.Setenv(config.EnvNetMonitorInterval, "30s")
+	t.Setenv(config.EnvNetMonitorPayload, "256KiB")
+	t.Setenv(config.EnvNetMonitorLoss, "2.5")
+	cfg, err := lookupNetMonitorConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.interval != 30*time.Second || cfg.payload != 256<<10 || cfg.loss != 2.5 || cfg.retention != 24*time.Hour {
+		t.Fatalf("unexpected config %+v", cfg)
+	}
+
+	for env, value := range map[string]string{
+		config.EnvNetMonitorInterval:  "soon",
+		config.EnvNetMonitorRetention: "10s",
+		config.EnvNetMonitorPayload:   "1GiB",
+		config.EnvNetMonitorLoss:      "101",
+		config.EnvNetMonitorLatency:   "-1s",
+	} {
+		t.Run(env, func(t *testing.T) {
+			t.Setenv(env, value)
+			if _, err := lookupNetMonitorConfig(); err == nil {
+				t.Fatalf("expected %s=%s to be rejected", env, value)
+			}
+		})
+	}
+}
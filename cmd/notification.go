@@ -26,11 +26,11 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/bits-and-blooms/bloom/v3"
-	"github.com/cespare/xxhash/v2"
 	"github.com/klauspost/compress/zip"
 	"github.com/minio/madmin-go"
 	xnet "github.com/minio/pkg/net"
@@ -46,6 +46,7 @@ import (
 type NotificationSys struct {
 	peerClients    []*peerRESTClient // Excludes self
 	allPeerClients []*peerRESTClient // Includes nil client for self
+	peerRing       *peerHashRing     // Ring of allPeerClients
 }
 
 // NotificationPeerErr returns error associated for a remote peer.
@@ -453,6 +454,53 @@ func (sys *NotificationSys) updateBloomFilter(ctx context.Context, current uint6
 	return bf, nil
 }
 
+// changedSinceBloomFilter returns the merged bloom filter of all servers
+// from index oldest up to the current index without cycling it, nil is
+// returned unless every server has complete information.
+func (sys *NotificationSys) changedSinceBloomFilter(ctx context.Context, oldest uint64) *bloomFilter {
+	req := bloomFilterRequest{Oldest: oldest}
+
+	filters := make([]*bloomFilterResponse, len(sys.peerClients)+1)
+	var err error
+	filters[0], err = intDataUpdateTracker.cycleFilter(ctx, req)
+	logger.LogIf(ctx, err)
+
+	g := errgroup.WithNErrs(len(sys.peerClients))
+	for idx, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		idx, client := idx, client
+		g.Go(func() error {
+			bfr, err := client.cycleServerBloomFilter(ctx, req)
+			if err != nil {
+				logger.LogOnceIf(ctx, err, client.host.String(), client.cycleServerBloomFilter)
+				return nil
+			}
+			filters[idx+1] = bfr
+			return nil
+		}, idx)
+	}
+	g.Wait()
+
+	bf := intDataUpdateTracker.newBloomFilter()
+	for _, bfr := range filters {
+		if bfr == nil || !bfr.Complete || bfr.OldestIdx > oldest {
+			return nil
+		}
+		var tmp bloom.BloomFilter
+		if _, err = tmp.ReadFrom(bytes.NewReader(bfr.Filter)); err != nil {
+			logger.LogIf(ctx, err)
+			return nil
+		}
+		if err = bf.Merge(&tmp); err != nil {
+			logger.LogIf(ctx, err)
+			return nil
+		}
+	}
+	return &bf
+}
+
 var errPeerNotReachable = errors.New("peer is not reachable")
 
 // GetLocks - makes GetLocks RPC call on all peers.
@@ -520,6 +568,7 @@ func (sys *NotificationSys) DeleteBucketMetadata(ctx context.Context, bucketName
 	globalBucketTargetSys.Delete(bucketName)
 	globalEventNotifier.RemoveNotification(bucketName)
 	globalBucketConnStats.delete(bucketName)
+	globalSSEComplianceReporter.delete(bucketName)
 	if localMetacacheMgr != nil {
 		localMetacacheMgr.deleteBucketCache(bucketName)
 	}
@@ -614,6 +663,26 @@ func (sys *NotificationSys) GetClusterBucketStats(ctx context.Context, bucketNam
 	return bucketStats
 }
 
+// LoadTenants makes all peers reload the tenants.
+func (sys *NotificationSys) LoadTenants(ctx context.Context) {
+	ng := WithNPeers(len(sys.peerClients))
+	for idx, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		client := client
+		ng.Go(ctx, func() error {
+			return client.LoadTenants(ctx)
+		}, idx, *client.host)
+	}
+	for _, nErr := range ng.Wait() {
+		reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", nErr.Host.String())
+		if nErr.Err != nil {
+			logger.LogIf(logger.SetReqInfo(ctx, reqInfo), nErr.Err)
+		}
+	}
+}
+
 // ReloadPoolMeta reloads on disk updates on pool metadata
 func (sys *NotificationSys) ReloadPoolMeta(ctx context.Context) {
 	ng := WithNPeers(len(sys.peerClients))
@@ -916,6 +985,7 @@ func getOfflineDisks(offlineHost string, endpoints EndpointServerPools) []madmin
 // ServerInfo - calls ServerInfo RPC call on all peers.
 func (sys *NotificationSys) ServerInfo() []madmin.ServerProperties {
 	reply := make([]madmin.ServerProperties, len(sys.peerClients))
+	members := gossipNetworkStates(UTCNow())
 	var wg sync.WaitGroup
 	for i, client := range sys.peerClients {
 		if client == nil {
@@ -924,8 +994,17 @@ func (sys *NotificationSys) ServerInfo() []madmin.ServerProperties {
 		wg.Add(1)
 		go func(client *peerRESTClient, idx int) {
 			defer wg.Done()
-			info, err := client.ServerInfo()
-			if err != nil {
+			// Peers the membership view knows to be offline are not
+			// waited for.
+			var (
+				info   madmin.ServerProperties
+				err    error
+				online = members[client.host.String()] != string(madmin.ItemOffline)
+			)
+			if online {
+				info, err = client.ServerInfo()
+			}
+			if !online || err != nil {
 				info.Endpoint = client.host.String()
 				info.State = string(madmin.ItemOffline)
 				info.Disks = getOfflineDisks(info.Endpoint, globalEndpoints)
@@ -958,29 +1037,34 @@ func (sys *NotificationSys) GetLocalDiskIDs(ctx context.Context) (localDiskIDs [
 	return localDiskIDs
 }
 
-// returns all the peers that are currently online.
-func (sys *NotificationSys) getOnlinePeers() []*peerRESTClient {
-	var peerClients []*peerRESTClient
-	for _, peerClient := range sys.allPeerClients {
-		if peerClient != nil && peerClient.IsOnline() {
-			peerClients = append(peerClients, peerClient)
-		}
-	}
-	return peerClients
-}
-
-// restClientFromHash will return a deterministic peerRESTClient based on s.
+// restClientFromHash will return a deterministic peerRESTClient based on s,
+// the owner is picked from a consistent hash ring of all online peers.
 // Will return nil if client is local.
 func (sys *NotificationSys) restClientFromHash(s string) (client *peerRESTClient) {
+	client, _ = sys.restClientsFromHash(s)
+	return client
+}
+
+// restClientsFromHash will return the deterministic peerRESTClient based on s
+// along with the next online peer on the ring, which takes over ownership if
+// the primary goes offline and is used for hedged requests. A nil primary is
+// the local node, the secondary client is nil if it is the local node or
+// there is no other online peer.
+func (sys *NotificationSys) restClientsFromHash(s string) (primary, secondary *peerRESTClient) {
 	if len(sys.peerClients) == 0 {
-		return nil
+		return nil, nil
 	}
-	peerClients := sys.getOnlinePeers()
-	if len(peerClients) == 0 {
-		return nil
+	peers := sys.peerRing.lookup(s, 2, func(peer int) bool {
+		client := sys.allPeerClients[peer]
+		return client == nil || client.IsOnline()
+	})
+	if len(peers) > 0 {
+		primary = sys.allPeerClients[peers[0]]
 	}
-	idx := xxhash.Sum64String(s) % uint64(len(peerClients))
-	return peerClients[idx]
+	if len(peers) > 1 {
+		secondary = sys.allPeerClients[peers[1]]
+	}
+	return primary, secondary
 }
 
 // GetPeerOnlineCount gets the count of online and offline nodes.
@@ -1016,10 +1100,17 @@ func (sys *NotificationSys) GetPeerOnlineCount() (nodesOnline, nodesOffline int)
 func NewNotificationSys(endpoints EndpointServerPools) *NotificationSys {
 	// targetList/bucketRulesMap/bucketRemoteTargetRulesMap are populated by NotificationSys.Init()
 	remote, all := newPeerRestClients(endpoints)
-	return &NotificationSys{
+	sys := &NotificationSys{
 		peerClients:    remote,
 		allPeerClients: all,
 	}
+	if len(all) > 0 {
+		// Same order as allPeerClients.
+		peers, _ := endpoints.peers()
+		sort.Strings(peers)
+		sys.peerRing = newPeerHashRing(peers)
+	}
+	return sys
 }
 
 // GetBandwidthReports - gets the bandwidth report from all nodes including self.
@@ -1328,6 +1419,339 @@ func (sys *NotificationSys) ReloadSiteReplicationConfig(ctx context.Context) []e
 	return errs
 }
 
+// GetAPIAnomalies fetches the API anomaly reports of all peers, peers
+// that could not be reached are reported with an error.
+func (sys *NotificationSys) GetAPIAnomalies(ctx context.Context) []apiAnomalyReport {
+	reports := make([]apiAnomalyReport, len(sys.peerClients))
+	var wg sync.WaitGroup
+	for index, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		wg.Add(1)
+		go func(index int, client *peerRESTClient) {
+			defer wg.Done()
+			report, err := client.GetAPIAnomalies(ctx)
+			if err != nil {
+				report = apiAnomalyReport{Node: client.host.String(), Error: err.Error()}
+			}
+			reports[index] = report
+		}(index, client)
+	}
+	wg.Wait()
+
+	result := reports[:0]
+	for _, report := range reports {
+		if report.Node != "" {
+			result = append(result, report)
+		}
+	}
+	return result
+}
+
+// GetSSEComplianceReports fetches the bucket encryption compliance
+// reports of all peers, peers that could not be reached are reported
+// with an error.
+func (sys *NotificationSys) GetSSEComplianceReports(ctx context.Context, bucket string) []sseComplianceReport {
+	reports := make([]sseComplianceReport, len(sys.peerClients))
+	var wg sync.WaitGroup
+	for index, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		wg.Add(1)
+		go func(index int, client *peerRESTClient) {
+			defer wg.Done()
+			report, err := client.GetSSEComplianceReport(ctx, bucket)
+			if err != nil {
+				report = sseComplianceReport{Node: client.host.String(), Bucket: bucket, Error: err.Error()}
+			}
+			reports[index] = report
+		}(index, client)
+	}
+	wg.Wait()
+
+	result := reports[:0]
+	for _, report := range reports {
+		if report.Node != "" {
+			result = append(result, report)
+		}
+	}
+	return result
+}
+
+// GetSlowDirs fetches the slow directories seen by all peers, peers
+// that could not be reached are reported with an error.
+func (sys *NotificationSys) GetSlowDirs(ctx context.Context) []slowDirsReport {
+	reports := make([]slowDirsReport, len(sys.peerClients))
+	var wg sync.WaitGroup
+	for index, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		wg.Add(1)
+		go func(index int, client *peerRESTClient) {
+			defer wg.Done()
+			report, err := client.GetSlowDirs(ctx)
+			if err != nil {
+				report = slowDirsReport{Node: client.host.String(), Error: err.Error()}
+			}
+			reports[index] = report
+		}(index, client)
+	}
+	wg.Wait()
+
+	result := reports[:0]
+	for _, report := range reports {
+		if report.Node != "" {
+			result = append(result, report)
+		}
+	}
+	return result
+}
+
+// GetHotObjects fetches the top n objects by requests and by latency of
+// all peers, errors are returned per peer.
+func (sys *NotificationSys) GetHotObjects(ctx context.Context, n int) ([]hotObjectsReport, map[string]string) {
+	reports := make([]hotObjectsReport, len(sys.peerClients))
+	errs := make([]error, len(sys.peerClients))
+	var wg sync.WaitGroup
+	for index, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		wg.Add(1)
+		go func(index int, client *peerRESTClient) {
+			defer wg.Done()
+			reports[index], errs[index] = client.GetHotObjects(ctx, n)
+		}(index, client)
+	}
+	wg.Wait()
+
+	var nodeErrs map[string]string
+	result := reports[:0]
+	for index, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		if errs[index] != nil {
+			if nodeErrs == nil {
+				nodeErrs = make(map[string]string)
+			}
+			nodeErrs[client.host.String()] = errs[index].Error()
+			continue
+		}
+		result = append(result, reports[index])
+	}
+	return result, nodeErrs
+}
+
+// GetRecentErrors fetches the n most recent errors logged by each peer,
+// along with the peers that could not be reached.
+func (sys *NotificationSys) GetRecentErrors(ctx context.Context, n int) ([]dashboardError, map[string]string) {
+	peerErrs := make([][]dashboardError, len(sys.peerClients))
+	errs := make([]error, len(sys.peerClients))
+	var wg sync.WaitGroup
+	for index, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		wg.Add(1)
+		go func(index int, client *peerRESTClient) {
+			defer wg.Done()
+			peerErrs[index], errs[index] = client.GetRecentErrors(ctx, n)
+		}(index, client)
+	}
+	wg.Wait()
+
+	var nodeErrs map[string]string
+	var result []dashboardError
+	for index, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		if errs[index] != nil {
+			if nodeErrs == nil {
+				nodeErrs = make(map[string]string)
+			}
+			nodeErrs[client.host.String()] = errs[index].Error()
+			continue
+		}
+		result = append(result, peerErrs[index]...)
+	}
+	return result, nodeErrs
+}
+
+// GetRecentLogs fetches the n most recent console log entries of each
+// peer, along with the peers that could not be reached.
+func (sys *NotificationSys) GetRecentLogs(ctx context.Context, n int) ([]diagLogEntry, map[string]string) {
+	peerLogs := make([][]diagLogEntry, len(sys.peerClients))
+	errs := make([]error, len(sys.peerClients))
+	var wg sync.WaitGroup
+	for index, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		wg.Add(1)
+		go func(index int, client *peerRESTClient) {
+			defer wg.Done()
+			peerLogs[index], errs[index] = client.GetRecentLogs(ctx, n)
+		}(index, client)
+	}
+	wg.Wait()
+
+	var nodeErrs map[string]string
+	var result []diagLogEntry
+	for index, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		if errs[index] != nil {
+			if nodeErrs == nil {
+				nodeErrs = make(map[string]string)
+			}
+			nodeErrs[client.host.String()] = errs[index].Error()
+			continue
+		}
+		result = append(result, peerLogs[index]...)
+	}
+	return result, nodeErrs
+}
+
+// GetNetMonitor fetches the network quality samples each peer took
+// within the last window, along with the peers that could not be
+// reached.
+func (sys *NotificationSys) GetNetMonitor(ctx context.Context, window time.Duration) ([]netMonitorReport, map[string]string) {
+	reports := make([]netMonitorReport, len(sys.peerClients))
+	errs := make([]error, len(sys.peerClients))
+	var wg sync.WaitGroup
+	for index, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		wg.Add(1)
+		go func(index int, client *peerRESTClient) {
+			defer wg.Done()
+			reports[index], errs[index] = client.GetNetMonitor(ctx, window)
+		}(index, client)
+	}
+	wg.Wait()
+
+	var nodeErrs map[string]string
+	var result []netMonitorReport
+	for index, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		if errs[index] != nil {
+			if nodeErrs == nil {
+				nodeErrs = make(map[string]string)
+			}
+			nodeErrs[client.host.String()] = errs[index].Error()
+			continue
+		}
+		result = append(result, reports[index])
+	}
+	return result, nodeErrs
+}
+
+// GetMetaDivergences fetches the metadata divergences counted and the n
+// most recent ones logged by each peer, along with the peers that could
+// not be reached.
+func (sys *NotificationSys) GetMetaDivergences(ctx context.Context, n int) ([]metaDivergenceReport, map[string]string) {
+	reports := make([]metaDivergenceReport, len(sys.peerClients))
+	errs := make([]error, len(sys.peerClients))
+	var wg sync.WaitGroup
+	for index, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		wg.Add(1)
+		go func(index int, client *peerRESTClient) {
+			defer wg.Done()
+			reports[index], errs[index] = client.GetMetaDivergences(ctx, n)
+		}(index, client)
+	}
+	wg.Wait()
+
+	var nodeErrs map[string]string
+	var result []metaDivergenceReport
+	for index, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		if errs[index] != nil {
+			if nodeErrs == nil {
+				nodeErrs = make(map[string]string)
+			}
+			nodeErrs[client.host.String()] = errs[index].Error()
+			continue
+		}
+		result = append(result, reports[index])
+	}
+	return result, nodeErrs
+}
+
+// GetShareLinkUsage fetches the bytes served per share link by all peers,
+// summed up. Unreachable peers are logged and skipped.
+func (sys *NotificationSys) GetShareLinkUsage(ctx context.Context) map[string]int64 {
+	usages := make([]map[string]int64, len(sys.peerClients))
+	var wg sync.WaitGroup
+	for index, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		wg.Add(1)
+		go func(index int, client *peerRESTClient) {
+			defer wg.Done()
+			var err error
+			usages[index], err = client.GetShareLinkUsage(ctx)
+			if err != nil {
+				reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", client.host.String())
+				logger.LogOnceIf(logger.SetReqInfo(ctx, reqInfo), err, client.host.String())
+			}
+		}(index, client)
+	}
+	wg.Wait()
+
+	total := make(map[string]int64)
+	for _, usage := range usages {
+		for key, n := range usage {
+			total[key] += n
+		}
+	}
+	return total
+}
+
+// ListAdminJobs fetches the running and finished admin jobs of all peers.
+// Unreachable peers are logged and skipped.
+func (sys *NotificationSys) ListAdminJobs(ctx context.Context) []adminJobInfo {
+	jobs := make([][]adminJobInfo, len(sys.peerClients))
+	var wg sync.WaitGroup
+	for index, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		wg.Add(1)
+		go func(index int, client *peerRESTClient) {
+			defer wg.Done()
+			var err error
+			jobs[index], err = client.ListAdminJobs(ctx)
+			if err != nil {
+				reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", client.host.String())
+				logger.LogOnceIf(logger.SetReqInfo(ctx, reqInfo), err, client.host.String())
+			}
+		}(index, client)
+	}
+	wg.Wait()
+
+	var all []adminJobInfo
+	for _, j := range jobs {
+		all = append(all, j...)
+	}
+	return all
+}
+
 // GetLastDayTierStats fetches per-tier stats of the last 24hrs from all peers
 func (sys *NotificationSys) GetLastDayTierStats(ctx context.Context) DailyAllTierStats {
 	errs := make([]error, len(sys.allPeerClients))
@@ -182,10 +182,19 @@ func (e SignatureDoesNotMatch) Error() string {
 	return "The request signature we calculated does not match the signature you provided. Check your key and signing method."
 }
 
-// StorageFull storage ran out of space.
-type StorageFull struct{}
+// StorageFull storage ran out of space, Reason names the
+// drive threshold that was crossed when known. Drive is the
+// endpoint of the drive that crossed it, which is only logged
+// and never returned to clients.
+type StorageFull struct {
+	Reason string
+	Drive  string
+}
 
 func (e StorageFull) Error() string {
+	if e.Reason != "" {
+		return "Storage reached its minimum free drive threshold: " + e.Reason
+	}
 	return "Storage reached its minimum free drive threshold."
 }
 
@@ -380,6 +389,27 @@ func (e BucketTaggingNotFound) Error() string {
 	return "No bucket tags found for bucket: " + e.Bucket
 }
 
+// BucketCorsNotFound - no bucket CORS config found
+type BucketCorsNotFound GenericError
+
+func (e BucketCorsNotFound) Error() string {
+	return "The CORS configuration does not exist for bucket: " + e.Bucket
+}
+
+// BucketLoggingNotFound - no bucket access logging config found
+type BucketLoggingNotFound GenericError
+
+func (e BucketLoggingNotFound) Error() string {
+	return "The access logging configuration does not exist for bucket: " + e.Bucket
+}
+
+// BucketWebsiteNotFound - no bucket website config found
+type BucketWebsiteNotFound GenericError
+
+func (e BucketWebsiteNotFound) Error() string {
+	return "The website configuration does not exist for bucket: " + e.Bucket
+}
+
 // BucketObjectLockConfigNotFound - no bucket object lock config found
 type BucketObjectLockConfigNotFound GenericError
 
@@ -401,6 +431,16 @@ func (e BucketQuotaExceeded) Error() string {
 	return "Bucket quota exceeded for bucket: " + e.Bucket
 }
 
+// TenantQuotaExceeded - aggregate quota of the tenant owning the bucket exceeded.
+type TenantQuotaExceeded struct {
+	Bucket string
+	Tenant string
+}
+
+func (e TenantQuotaExceeded) Error() string {
+	return "Tenant quota exceeded for tenant: " + e.Tenant + " bucket: " + e.Bucket
+}
+
 // BucketReplicationConfigNotFound - no bucket replication config found
 type BucketReplicationConfigNotFound GenericError
 
@@ -90,6 +90,19 @@ type ObjectOptions struct {
 	// IndexCB will return any index created but the compression.
 	// Object must have been read at this point.
 	IndexCB func() []byte
+
+	// Append set to 'true' if the data must be appended to the latest
+	// version of the object instead of replacing it.
+	Append bool
+
+	// ZeroCopy set to 'true' lets erasure backends serve plain objects
+	// through GetObjectReader.RawReader, straight from the data shards
+	// after verifying their bitrot hashes, only set by GetObject.
+	ZeroCopy bool
+
+	// PlacementPool when > 0 places new objects on the pool with this
+	// 1-based index instead of the pool with most available space.
+	PlacementPool int
 }
 
 // ExpirationOptions represents object options for object expiration at objectLayer.
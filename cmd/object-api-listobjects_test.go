@@ -26,6 +26,7 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestListObjectsVersionedFolders(t *testing.T) {
@@ -1868,6 +1869,90 @@ func testListObjectsContinuation(obj ObjectLayer, instanceType string, t1 TestEr
 	}
 }
 
+func TestListObjectsSnapshot(t *testing.T) {
+	ExecObjectLayerTest(t, testListObjectsSnapshot)
+}
+
+// Objects created after the first page of a listing are not returned by
+// the following pages of the same listing.
+func testListObjectsSnapshot(obj ObjectLayer, instanceType string, t1 TestErrHandler) {
+	t, _ := t1.(*testing.T)
+	ctx := context.Background()
+	bucket := "snapshot-bucket"
+	if err := obj.MakeBucketWithLocation(ctx, bucket, MakeBucketOptions{}); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	putAt := func(object string, mtime time.Time) {
+		if _, err := obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader([]byte("x")), 1, "", ""), ObjectOptions{MTime: mtime}); err != nil {
+			t.Fatalf("%s: %v", instanceType, err)
+		}
+	}
+	put := func(object string) {
+		putAt(object, time.Time{})
+	}
+	for _, object := range []string{"a", "b", "c", "d"} {
+		put(object)
+	}
+
+	res, err := obj.ListObjects(ctx, bucket, "", "", "", 2)
+	if err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if !res.IsTruncated || len(res.Objects) != 2 {
+		t.Fatalf("%s: unexpected first page %+v", instanceType, res)
+	}
+
+	// Created once the listing started, c2 with the preserved modtime
+	// of a replica. d is only overwritten and is still returned.
+	time.Sleep(10 * time.Millisecond)
+	put("b2")
+	put("e")
+	putAt("c2", time.Now().Add(-time.Hour))
+	put("d")
+
+	var names []string
+	marker := res.NextMarker
+	for marker != "" {
+		res, err = obj.ListObjects(ctx, bucket, "", marker, "", 2)
+		if err != nil {
+			t.Fatalf("%s: %v", instanceType, err)
+		}
+		for _, oi := range res.Objects {
+			names = append(names, oi.Name)
+		}
+		marker = res.NextMarker
+		if !res.IsTruncated {
+			break
+		}
+	}
+	if strings.Join(names, ",") != "c,d" {
+		t.Fatalf("%s: expected c,d in the remaining pages, got %v", instanceType, names)
+	}
+
+	// A new listing sees them.
+	res, err = obj.ListObjects(ctx, bucket, "", "", "", 10)
+	if err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if len(res.Objects) != 7 {
+		t.Fatalf("%s: expected 7 objects, got %d", instanceType, len(res.Objects))
+	}
+}
+
+func TestListPathOptionsMarkerSnapshot(t *testing.T) {
+	snapshot := time.Unix(0, 1666000000123456789).UTC()
+	o := listPathOptions{ID: "id", pool: 1, set: 2, Snapshot: snapshot, VersionMarkerTime: snapshot.Add(-time.Second)}
+	var got listPathOptions
+	got.Marker = o.encodeMarker("obj")
+	got.parseMarker()
+	if got.Marker != "obj" || got.ID != "id" || got.pool != 1 || got.set != 2 {
+		t.Fatalf("unexpected marker %+v", got)
+	}
+	if !got.Snapshot.Equal(snapshot) || !got.VersionMarkerTime.Equal(o.VersionMarkerTime) {
+		t.Fatalf("expected snapshot %v/%v, got %v/%v", snapshot, o.VersionMarkerTime, got.Snapshot, got.VersionMarkerTime)
+	}
+}
+
 // Initialize FS backend for the benchmark.
 func initFSObjectsB(disk string, t *testing.B) (obj ObjectLayer) {
 	obj, _, err := initObjectLayer(context.Background(), mustGetPoolEndpoints(disk))
@@ -27,6 +27,7 @@ import (
 	"math/rand"
 	"net"
 	"net/http"
+	"os"
 	"path"
 	"runtime"
 	"strconv"
@@ -35,6 +36,7 @@ import (
 	"time"
 	"unicode/utf8"
 
+	"github.com/dustin/go-humanize"
 	"github.com/google/uuid"
 	"github.com/klauspost/compress/s2"
 	"github.com/klauspost/readahead"
@@ -45,6 +47,7 @@ import (
 	"github.com/qkbyte/minio/internal/config/dns"
 	"github.com/qkbyte/minio/internal/config/storageclass"
 	"github.com/qkbyte/minio/internal/crypto"
+	"github.com/qkbyte/minio/internal/event"
 	"github.com/qkbyte/minio/internal/hash"
 	xhttp "github.com/qkbyte/minio/internal/http"
 	"github.com/qkbyte/minio/internal/ioutil"
@@ -589,6 +592,72 @@ type GetObjectReader struct {
 	cleanUpFns []func()
 	opts       ObjectOptions
 	once       sync.Once
+
+	// raw is set by backends that can serve the requested range
+	// straight from the files stored on local drives.
+	raw io.Reader
+}
+
+// RawReader returns a reader over the on-disk files backing this
+// object, suitable for a zero-copy transfer, or nil when the content
+// has to go through the regular Reader.
+func (g *GetObjectReader) RawReader() io.Reader {
+	return g.raw
+}
+
+// rawFileReader serves a section of a file, WriteTo copies it as a
+// limited *os.File which lets w use sendfile when it supports it.
+type rawFileReader struct {
+	f   *os.File
+	off int64
+	n   int64
+}
+
+// newRawFileReader returns a rawFileReader serving length bytes of
+// f starting at offset.
+func newRawFileReader(f *os.File, offset, length int64) *rawFileReader {
+	return &rawFileReader{f: f, off: offset, n: length}
+}
+
+// Read implements io.Reader.
+func (r *rawFileReader) Read(p []byte) (int, error) {
+	if r.n == 0 {
+		return 0, io.EOF
+	}
+	if int64(len(p)) > r.n {
+		p = p[:r.n]
+	}
+	n, err := r.f.ReadAt(p, r.off)
+	r.off += int64(n)
+	r.n -= int64(n)
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	if n > 0 {
+		err = nil
+	}
+	return n, err
+}
+
+// WriteTo implements io.WriterTo.
+func (r *rawFileReader) WriteTo(w io.Writer) (int64, error) {
+	if _, err := r.f.Seek(r.off, io.SeekStart); err != nil {
+		return 0, err
+	}
+	n, err := io.Copy(w, io.LimitReader(r.f, r.n))
+	r.off += n
+	r.n -= n
+	if err == nil && r.n > 0 {
+		err = io.ErrUnexpectedEOF
+	}
+	return n, err
+}
+
+// getObjectFastPathStats counts GetObject requests and how many of
+// them were served through GetObjectReader.RawReader.
+type getObjectFastPathStats struct {
+	hits  uint64
+	total uint64
 }
 
 // WithCleanupFuncs sets additional cleanup functions to be called when closing
@@ -1070,8 +1139,12 @@ func getDiskInfos(ctx context.Context, disks ...StorageAPI) []*DiskInfo {
 	return res
 }
 
-// hasSpaceFor returns whether the disks in `di` have space for and object of a given size.
-func hasSpaceFor(di []*DiskInfo, size int64) bool {
+// checkSpaceFor returns a StorageFull error naming the crossed drive
+// threshold when the disks in `di` have no space for an object of a
+// given size.
+func checkSpaceFor(di []*DiskInfo, size int64) error {
+	th := globalAPIConfig.getDriveThresholds()
+
 	// We multiply the size by 2 to account for erasure coding.
 	size *= 2
 	if size < 0 {
@@ -1083,8 +1156,8 @@ func hasSpaceFor(di []*DiskInfo, size int64) bool {
 	var total uint64
 	var nDisks int
 	for _, disk := range di {
-		if disk == nil || disk.Total == 0 || (disk.FreeInodes < diskMinInodes && disk.UsedInodes > 0) {
-			// Disk offline, no inodes or something else is wrong.
+		if disk == nil || disk.Total == 0 {
+			// Disk offline or something else is wrong.
 			continue
 		}
 		nDisks++
@@ -1093,29 +1166,72 @@ func hasSpaceFor(di []*DiskInfo, size int64) bool {
 	}
 
 	if nDisks == 0 {
-		return false
+		return StorageFull{Reason: "no drive is online"}
 	}
 
-	// Check we have enough on each disk, ignoring diskFillFraction.
+	// Check we have enough on each disk, ignoring MinFreePercent.
 	perDisk := size / int64(nDisks)
 	for _, disk := range di {
-		if disk == nil || disk.Total == 0 || (disk.FreeInodes < diskMinInodes && disk.UsedInodes > 0) {
+		if disk == nil || disk.Total == 0 {
 			continue
 		}
+		if disk.UsedInodes > 0 && disk.FreeInodes < th.MinFreeInodes {
+			return StorageFull{Reason: fmt.Sprintf("a drive has %d free inodes, the minimum is %d",
+				disk.FreeInodes, th.MinFreeInodes), Drive: disk.Endpoint}
+		}
 		if int64(disk.Free) <= perDisk {
-			return false
+			return StorageFull{Reason: fmt.Sprintf("a drive has %s free, %s is needed",
+				humanize.IBytes(disk.Free), humanize.IBytes(uint64(perDisk))), Drive: disk.Endpoint}
+		}
+		if left := disk.Free - uint64(perDisk); left < th.MinFreeBytes {
+			return StorageFull{Reason: fmt.Sprintf("a drive would have %s free, the minimum is %s",
+				humanize.IBytes(left), humanize.IBytes(th.MinFreeBytes)), Drive: disk.Endpoint}
 		}
 	}
 
-	// Make sure we can fit "size" on to the disk without getting above the diskFillFraction
+	// Make sure we can fit "size" on to the disk without getting below MinFreePercent.
 	if available < uint64(size) {
-		return false
+		return StorageFull{Reason: fmt.Sprintf("%s is available, %s is needed",
+			humanize.IBytes(available), humanize.IBytes(uint64(size)))}
 	}
 
 	// How much will be left after adding the file.
 	available -= uint64(size)
 
 	// wantLeft is how much space there at least must be left.
-	wantLeft := uint64(float64(total) * (1.0 - diskFillFraction))
-	return available > wantLeft
+	wantLeft := uint64(float64(total) * th.MinFreePercent / 100)
+	if th.MinFreePercent > 0 && available <= wantLeft {
+		return StorageFull{Reason: fmt.Sprintf("free space would drop below %v%% of the drive capacity", th.MinFreePercent)}
+	}
+	return nil
+}
+
+// sendStorageFullEvent notifies about a write rejected because a
+// drive threshold was crossed.
+func sendStorageFullEvent(ctx context.Context, bucket, object string, size int64, err error) {
+	sf, ok := err.(StorageFull)
+	if !ok {
+		return
+	}
+	if sf.Drive != "" {
+		logger.LogOnceIf(ctx, fmt.Errorf("drive %s: %s", sf.Drive, sf.Reason), "storage-full-"+sf.Drive)
+	}
+	reqInfo := logger.GetReqInfo(ctx)
+	sendEvent(eventArgs{
+		EventName:  event.ObjectRejectedStorageFull,
+		BucketName: bucket,
+		Object: ObjectInfo{
+			Bucket: bucket,
+			Name:   object,
+			Size:   size,
+		},
+		ReqParams: map[string]string{
+			"region":          reqInfo.Region,
+			"principalId":     reqInfo.Cred.AccessKey,
+			"sourceIPAddress": reqInfo.RemoteHost,
+			"reason":          sf.Reason,
+		},
+		Host:      reqInfo.RemoteHost,
+		UserAgent: reqInfo.UserAgent,
+	})
 }
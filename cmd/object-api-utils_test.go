@@ -24,10 +24,13 @@ import (
 	"net/http"
 	"reflect"
 	"strconv"
+	"strings"
 	"testing"
 
+	"github.com/dustin/go-humanize"
 	"github.com/klauspost/compress/s2"
 	"github.com/minio/pkg/trie"
+	"github.com/qkbyte/minio/internal/config/api"
 	"github.com/qkbyte/minio/internal/config/compress"
 	"github.com/qkbyte/minio/internal/crypto"
 )
@@ -684,3 +687,72 @@ func TestS2CompressReader(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckSpaceFor(t *testing.T) {
+	defer func(th *api.DriveThresholds) {
+		globalAPIConfig.mu.Lock()
+		globalAPIConfig.driveThresholds = th
+		globalAPIConfig.mu.Unlock()
+	}(globalAPIConfig.driveThresholds)
+
+	disk := func(total, free, freeInodes uint64) *DiskInfo {
+		return &DiskInfo{
+			Total:      total,
+			Free:       free,
+			Used:       total - free,
+			UsedInodes: 1,
+			FreeInodes: freeInodes,
+			Endpoint:   "/mnt/drive",
+		}
+	}
+
+	testCases := []struct {
+		th     api.DriveThresholds
+		disks  []*DiskInfo
+		size   int64
+		reason string
+	}{
+		{defaultDriveThresholds, []*DiskInfo{disk(100*humanize.GiByte, 50*humanize.GiByte, 10000)}, humanize.MiByte, ""},
+		{defaultDriveThresholds, []*DiskInfo{nil, {}}, humanize.MiByte, "no drive is online"},
+		{defaultDriveThresholds, []*DiskInfo{disk(100*humanize.GiByte, 50*humanize.GiByte, 10)}, humanize.MiByte, "free inodes"},
+		{defaultDriveThresholds, []*DiskInfo{disk(100*humanize.GiByte, humanize.GiByte, 10000)}, humanize.MiByte, "below 1%"},
+		{defaultDriveThresholds, []*DiskInfo{disk(100*humanize.GiByte, humanize.MiByte, 10000)}, humanize.MiByte, "is needed"},
+		{
+			api.DriveThresholds{MinFreeBytes: 60 * humanize.GiByte},
+			[]*DiskInfo{disk(100*humanize.GiByte, 50*humanize.GiByte, 10000)},
+			humanize.MiByte, "the minimum is 60 GiB",
+		},
+		{
+			api.DriveThresholds{MinFreePercent: 60},
+			[]*DiskInfo{disk(100*humanize.GiByte, 50*humanize.GiByte, 10000)},
+			humanize.MiByte, "below 60%",
+		},
+		{api.DriveThresholds{}, []*DiskInfo{disk(100*humanize.GiByte, humanize.GiByte, 10)}, humanize.MiByte, ""},
+	}
+
+	for i, testCase := range testCases {
+		th := testCase.th
+		globalAPIConfig.mu.Lock()
+		globalAPIConfig.driveThresholds = &th
+		globalAPIConfig.mu.Unlock()
+
+		err := checkSpaceFor(testCase.disks, testCase.size)
+		if testCase.reason == "" {
+			if err != nil {
+				t.Errorf("Test %d: expected no error, got %v", i+1, err)
+			}
+			continue
+		}
+		sf, ok := err.(StorageFull)
+		if !ok {
+			t.Errorf("Test %d: expected StorageFull, got %v", i+1, err)
+			continue
+		}
+		if !strings.Contains(sf.Reason, testCase.reason) {
+			t.Errorf("Test %d: expected reason containing %q, got %q", i+1, testCase.reason, sf.Reason)
+		}
+		if strings.Contains(sf.Reason, "/mnt/drive") {
+			t.Errorf("Test %d: expected reason not naming the drive, got %q", i+1, sf.Reason)
+		}
+	}
+}
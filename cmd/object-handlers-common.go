@@ -24,6 +24,8 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/minio/minio-go/v7/pkg/tags"
+	"github.com/minio/pkg/bucket/policy"
 	"github.com/qkbyte/minio/internal/amztime"
 	"github.com/qkbyte/minio/internal/event"
 	"github.com/qkbyte/minio/internal/hash"
@@ -185,6 +187,7 @@ func checkPreconditionsPUT(ctx context.Context, w http.ResponseWriter, r *http.R
 //	If-Unmodified-Since
 //	If-Match
 //	If-None-Match
+//	X-Minio-If-Tags
 func checkPreconditions(ctx context.Context, w http.ResponseWriter, r *http.Request, objInfo ObjectInfo, opts ObjectOptions) bool {
 	// Return false for methods other than GET and HEAD.
 	if r.Method != http.MethodGet && r.Method != http.MethodHead {
@@ -268,10 +271,52 @@ func checkPreconditions(ctx context.Context, w http.ResponseWriter, r *http.Requ
 			return true
 		}
 	}
+
+	// X-Minio-If-Tags : Return the object only if it carries all the specified tags,
+	// otherwise return a 412 (precondition failed). Since the outcome reveals the
+	// object tags the request must be allowed to read them.
+	ifTagsHeader := r.Header.Get(xhttp.MinIOIfTags)
+	if ifTagsHeader != "" {
+		if s3Error := authorizeRequest(ctx, r, policy.GetObjectTaggingAction); s3Error != ErrNone {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+			return true
+		}
+		match, err := objectTagsMatch(objInfo.UserTags, ifTagsHeader)
+		if err != nil {
+			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+			return true
+		}
+		if !match {
+			// If the object does not carry the specified tags.
+			writeHeaders()
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrPreconditionFailed), r.URL)
+			return true
+		}
+	}
 	// Object content should be written to http.ResponseWriter
 	return false
 }
 
+// objectTagsMatch returns true if the object tags carry all the tags of
+// cond, both are URL encoded tag sets.
+func objectTagsMatch(objTags, cond string) (bool, error) {
+	want, err := tags.ParseObjectTags(cond)
+	if err != nil {
+		return false, err
+	}
+	have, err := tags.ParseObjectTags(objTags)
+	if err != nil {
+		return false, err
+	}
+	haveMap := have.ToMap()
+	for k, v := range want.ToMap() {
+		if hv, ok := haveMap[k]; !ok || hv != v {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
 // returns true if object was modified after givenTime.
 func ifModifiedSince(objTime time.Time, givenTime time.Time) bool {
 	// The Date-Modified header truncates sub-second precision, so
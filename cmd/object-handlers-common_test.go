@@ -51,3 +51,31 @@ func TestCanonicalizeETag(t *testing.T) {
 		}
 	}
 }
+
+// Tests - objectTagsMatch()
+func TestObjectTagsMatch(t *testing.T) {
+	testCases := []struct {
+		objTags string
+		cond    string
+		match   bool
+		wantErr bool
+	}{
+		{objTags: "status=published", cond: "status=published", match: true},
+		{objTags: "status=published&tier=gold", cond: "status=published", match: true},
+		{objTags: "status=published&tier=gold", cond: "tier=gold&status=published", match: true},
+		{objTags: "status=draft", cond: "status=published", match: false},
+		{objTags: "", cond: "status=published", match: false},
+		{objTags: "status=published", cond: "status=published&tier=gold", match: false},
+		{objTags: "status=", cond: "status=", match: true},
+		{objTags: "status=published", cond: "status=published&status=draft", wantErr: true},
+	}
+	for i, testCase := range testCases {
+		match, err := objectTagsMatch(testCase.objTags, testCase.cond)
+		if (err != nil) != testCase.wantErr {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if match != testCase.match {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.match, match)
+		}
+	}
+}
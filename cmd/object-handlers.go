@@ -31,6 +31,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
@@ -383,6 +384,10 @@ func (api objectAPIHandlers) getObjectHandler(ctx context.Context, objectAPI Obj
 	getObjectNInfo := objectAPI.GetObjectNInfo
 	if api.CacheAPI() != nil {
 		getObjectNInfo = api.CacheAPI().GetObjectNInfo
+	} else {
+		// Content is written straight to the client, allow
+		// erasure backends to skip the decode for plain objects.
+		opts.ZeroCopy = true
 	}
 
 	// Get request range.
@@ -520,6 +525,7 @@ func (api objectAPIHandlers) getObjectHandler(ctx context.Context, objectAPI Obj
 
 	// filter object lock metadata if permission does not permit
 	objInfo.UserDefined = objectlock.FilterObjectLockMetadata(objInfo.UserDefined, getRetPerms != ErrNone, legalHoldPerms != ErrNone)
+	setRetentionSourceHeader(w, r, objInfo.UserDefined)
 
 	// Set encryption response headers
 	if objectAPI.IsEncryptionSupported() {
@@ -561,8 +567,17 @@ func (api objectAPIHandlers) getObjectHandler(ctx context.Context, objectAPI Obj
 		w.WriteHeader(http.StatusPartialContent)
 	}
 
-	// Write object content to response body
-	if _, err = xioutil.Copy(httpWriter, gr); err != nil {
+	// Write object content to response body, plain objects served
+	// from the files on local drives are handed to the writer as is,
+	// which lets net/http use sendfile.
+	atomic.AddUint64(&globalGetObjectFastPath.total, 1)
+	if rr := gr.RawReader(); rr != nil {
+		atomic.AddUint64(&globalGetObjectFastPath.hits, 1)
+		_, err = io.Copy(httpWriter, rr)
+	} else {
+		_, err = xioutil.Copy(httpWriter, gr)
+	}
+	if err != nil {
 		if !httpWriter.HasWritten() && !statusCodeWritten {
 			// write error response only if no data or headers has been written to client yet
 			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
@@ -759,6 +774,7 @@ func (api objectAPIHandlers) headObjectHandler(ctx context.Context, objectAPI Ob
 
 	// filter object lock metadata if permission does not permit
 	objInfo.UserDefined = objectlock.FilterObjectLockMetadata(objInfo.UserDefined, getRetPerms != ErrNone, legalHoldPerms != ErrNone)
+	setRetentionSourceHeader(w, r, objInfo.UserDefined)
 
 	if objectAPI.IsEncryptionSupported() {
 		if _, err = DecryptObjectInfo(&objInfo, r); err != nil {
@@ -1072,6 +1088,10 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 
 	// Check if bucket encryption is enabled
 	sseConfig, _ := globalBucketSSEConfigSys.Get(dstBucket)
+	if s3Err := enforceBucketSSECompliance(ctx, r, r.Header, dstBucket, dstObject, sseConfig); s3Err != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Err), r.URL)
+		return
+	}
 	sseConfig.Apply(r.Header, sse.ApplyOptions{
 		AutoEncrypt: globalAutoEncryption,
 		Passthrough: globalIsGateway && globalGatewayName == S3BackendGateway,
@@ -1429,6 +1449,8 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 			srcInfo.UserDefined[strings.ToLower(xhttp.AmzObjectLockMode)] = string(retentionMode)
 			srcInfo.UserDefined[strings.ToLower(xhttp.AmzObjectLockRetainUntilDate)] = retentionDate.UTC().Format(iso8601TimeFormat)
 			srcInfo.UserDefined[ReservedMetadataPrefixLower+ObjectLockRetentionTimestamp] = UTCNow().Format(time.RFC3339Nano)
+			delete(srcInfo.UserDefined, ReservedMetadataPrefixLower+ObjectLockRetentionInherited)
+			setRetentionSource(srcInfo.UserDefined, r, dstBucket, retentionMode)
 		}
 	}
 
@@ -1480,6 +1502,11 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 		srcInfo.metadataOnly = false
 	}
 
+	// The copy is a new object unless only the metadata is updated.
+	if !srcInfo.metadataOnly {
+		delete(srcInfo.UserDefined, objectCreatedKey)
+	}
+
 	// Check if x-amz-metadata-directive or x-amz-tagging-directive was not set to REPLACE and source,
 	// destination are same objects. Apply this restriction also when
 	// metadataOnly is true indicating that we are not overwriting the object.
@@ -1574,11 +1601,9 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 		w.Header()[strings.ToLower(xhttp.AmzCopySourceVersionID)] = []string{srcOpts.VersionID}
 	}
 
-	// Write success response.
-	writeSuccessResponseXML(w, encodedSuccessResponse)
-
-	// Notify object created event.
-	sendEvent(eventArgs{
+	// Deliver the event to the synchronous notification targets
+	// before the response is written.
+	evArgs := eventArgs{
 		EventName:    event.ObjectCreatedCopy,
 		BucketName:   dstBucket,
 		Object:       objInfo,
@@ -1586,7 +1611,18 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 		RespElements: extractRespElements(w),
 		UserAgent:    r.UserAgent(),
 		Host:         handlers.GetSourceIP(r),
-	})
+	}
+	if err := sendEventSync(ctx, evArgs); err != nil {
+		sendEvent(evArgs)
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrSyncNotificationFailed), r.URL)
+		return
+	}
+
+	// Write success response.
+	writeSuccessResponseXML(w, encodedSuccessResponse)
+
+	// Notify object created event.
+	sendEvent(evArgs)
 
 	if !remoteCallRequired && !globalTierConfigMgr.Empty() {
 		// Schedule object for immediate transition if eligible.
@@ -1758,14 +1794,32 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 
 	// Check if bucket encryption is enabled
 	sseConfig, _ := globalBucketSSEConfigSys.Get(bucket)
+	if s3Err := enforceBucketSSECompliance(ctx, r, r.Header, bucket, object, sseConfig); s3Err != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Err), r.URL)
+		return
+	}
 	sseConfig.Apply(r.Header, sse.ApplyOptions{
 		AutoEncrypt: globalAutoEncryption,
 		Passthrough: globalIsGateway && globalGatewayName == S3BackendGateway,
 	})
 
+	// Appends are an extension supported only for unencrypted and
+	// uncompressed objects in buckets without object locking.
+	appendObject := r.Header.Get(xhttp.MinIOAppend) == "true"
+	if appendObject {
+		if globalIsGateway || crypto.Requested(r.Header) {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+			return
+		}
+		if rcfg, _ := globalBucketObjectLockSys.Get(bucket); rcfg.LockEnabled {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+			return
+		}
+	}
+
 	actualSize := size
 	var idxCb func() []byte
-	if objectAPI.IsCompressionSupported() && isCompressible(r.Header, object) && size > minCompressibleSize {
+	if !appendObject && objectAPI.IsCompressionSupported() && isCompressible(r.Header, object) && size > minCompressibleSize {
 		// Storing the compression metadata.
 		metadata[ReservedMetadataPrefix+"compression"] = compressionAlgorithmV2
 		metadata[ReservedMetadataPrefix+"actual-size"] = strconv.FormatInt(size, 10)
@@ -1812,6 +1866,7 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		return
 	}
 	opts.IndexCB = idxCb
+	opts.Append = appendObject
 
 	if !opts.MTime.IsZero() && opts.PreserveETag != "" {
 		opts.CheckPrecondFn = func(oi ObjectInfo) bool {
@@ -1825,7 +1880,7 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		}
 	}
 
-	if api.CacheAPI() != nil {
+	if api.CacheAPI() != nil && !opts.Append {
 		putObject = api.CacheAPI().PutObject
 	}
 
@@ -1841,6 +1896,7 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 	if s3Err == ErrNone && retentionMode.Valid() {
 		metadata[strings.ToLower(xhttp.AmzObjectLockMode)] = string(retentionMode)
 		metadata[strings.ToLower(xhttp.AmzObjectLockRetainUntilDate)] = retentionDate.UTC().Format(iso8601TimeFormat)
+		setRetentionSource(metadata, r, bucket, retentionMode)
 	}
 	if s3Err == ErrNone && legalHold.Status.Valid() {
 		metadata[strings.ToLower(xhttp.AmzObjectLockLegalHold)] = string(legalHold.Status)
@@ -1950,10 +2006,9 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 	}
 
 	setPutObjHeaders(w, objInfo, false)
-	writeSuccessResponseHeadersOnly(w)
-
-	// Notify object created event.
-	sendEvent(eventArgs{
+	// Deliver the event to the synchronous notification targets
+	// before the response is written.
+	evArgs := eventArgs{
 		EventName:    event.ObjectCreatedPut,
 		BucketName:   bucket,
 		Object:       objInfo,
@@ -1961,7 +2016,17 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		RespElements: extractRespElements(w),
 		UserAgent:    r.UserAgent(),
 		Host:         handlers.GetSourceIP(r),
-	})
+	}
+	if err := sendEventSync(ctx, evArgs); err != nil {
+		sendEvent(evArgs)
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrSyncNotificationFailed), r.URL)
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+
+	// Notify object created event.
+	sendEvent(evArgs)
 
 	// Remove the transitioned object whose object version is being overwritten.
 	if !globalTierConfigMgr.Empty() {
@@ -2119,6 +2184,10 @@ func (api objectAPIHandlers) PutObjectExtractHandler(w http.ResponseWriter, r *h
 
 	// Check if bucket encryption is enabled
 	sseConfig, _ := globalBucketSSEConfigSys.Get(bucket)
+	if s3Err := enforceBucketSSECompliance(ctx, r, r.Header, bucket, object, sseConfig); s3Err != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Err), r.URL)
+		return
+	}
 	sseConfig.Apply(r.Header, sse.ApplyOptions{
 		AutoEncrypt: globalAutoEncryption,
 		Passthrough: globalIsGateway && globalGatewayName == S3BackendGateway,
@@ -2191,6 +2260,7 @@ func (api objectAPIHandlers) PutObjectExtractHandler(w http.ResponseWriter, r *h
 		if s3err == ErrNone && retentionMode.Valid() {
 			metadata[strings.ToLower(xhttp.AmzObjectLockMode)] = string(retentionMode)
 			metadata[strings.ToLower(xhttp.AmzObjectLockRetainUntilDate)] = retentionDate.UTC().Format(iso8601TimeFormat)
+			setRetentionSource(metadata, r, bucket, retentionMode)
 		}
 
 		if s3err == ErrNone && legalHold.Status.Valid() {
@@ -2737,17 +2807,32 @@ func (api objectAPIHandlers) DeleteObjectHandler(w http.ResponseWriter, r *http.
 		deleteObject = api.CacheAPI().DeleteObject
 	}
 
-	// http://docs.aws.amazon.com/AmazonS3/latest/API/RESTObjectDELETE.html
-	objInfo, err := deleteObject(ctx, bucket, object, opts)
-	if err != nil {
-		switch err.(type) {
-		case BucketNotFound:
-			// When bucket doesn't exist specially handle it.
+	var (
+		objInfo ObjectInfo
+		trashed bool
+	)
+	// Keep a copy of the object in the recycle bin of non-versioned buckets.
+	if gerr == nil && isBucketTrashEnabled(bucket, opts) {
+		objInfo, trashed, err = moveObjectToTrash(ctx, objectAPI, bucket, object, opts)
+		if err != nil {
 			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
 			return
 		}
 	}
 
+	if !trashed {
+		// http://docs.aws.amazon.com/AmazonS3/latest/API/RESTObjectDELETE.html
+		objInfo, err = deleteObject(ctx, bucket, object, opts)
+		if err != nil {
+			switch err.(type) {
+			case BucketNotFound:
+				// When bucket doesn't exist specially handle it.
+				writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+				return
+			}
+		}
+	}
+
 	if objInfo.Name == "" {
 		writeSuccessNoContent(w)
 		return
@@ -3031,6 +3116,10 @@ func (api objectAPIHandlers) PutObjectRetentionHandler(w http.ResponseWriter, r
 				oi.UserDefined[strings.ToLower(xhttp.AmzObjectLockRetainUntilDate)] = ""
 			}
 			oi.UserDefined[ReservedMetadataPrefixLower+ObjectLockRetentionTimestamp] = UTCNow().Format(time.RFC3339Nano)
+			if _, ok := oi.UserDefined[ReservedMetadataPrefixLower+ObjectLockRetentionInherited]; ok {
+				// Retention is explicit from now on.
+				oi.UserDefined[ReservedMetadataPrefixLower+ObjectLockRetentionInherited] = ""
+			}
 			dsc := mustReplicate(ctx, bucket, object, getMustReplicateOptions(oi, replication.MetadataReplicationType, opts))
 			if dsc.ReplicateAny() {
 				oi.UserDefined[ReservedMetadataPrefixLower+ReplicationTimestamp] = UTCNow().Format(time.RFC3339Nano)
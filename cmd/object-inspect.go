@@ -0,0 +1,250 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+)
+
+// Per drive states of an inspected object.
+const (
+	objectInspectOK             = "ok"
+	objectInspectOffline        = "offline"
+	objectInspectMissing        = "missing"
+	objectInspectVersionMissing = "version-missing"
+	objectInspectCorrupt        = "corrupt"
+	objectInspectError          = "error"
+)
+
+// Shard states of an inspected object version on a drive.
+const (
+	objectShardsPresent = "present"
+	objectShardsMissing = "missing"
+	objectShardsCorrupt = "corrupt"
+	objectShardsInline  = "inline"
+)
+
+// objectInspectVersion summarizes a version recorded in an xl.meta.
+type objectInspectVersion struct {
+	VersionID string    `json:"versionId"`
+	ModTime   time.Time `json:"modTime"`
+	Deleted   bool      `json:"deleted,omitempty"`
+	DataDir   string    `json:"dataDir,omitempty"`
+	Size      int64     `json:"size"`
+	Inline    bool      `json:"inline,omitempty"`
+}
+
+// objectInspectPart is a part of the inspected version and the bitrot
+// checksum of its shard on a drive.
+type objectInspectPart struct {
+	Number     int    `json:"number"`
+	Size       int64  `json:"size"`
+	ActualSize int64  `json:"actualSize"`
+	Algorithm  string `json:"algorithm,omitempty"`
+	Checksum   string `json:"checksum,omitempty"`
+}
+
+// objectInspectDrive is what one drive of the erasure set holds for the object.
+type objectInspectDrive struct {
+	Pool     int    `json:"pool"`
+	Set      int    `json:"set"`
+	Index    int    `json:"index"`
+	Endpoint string `json:"endpoint"`
+	State    string `json:"state"`
+	Error    string `json:"error,omitempty"`
+
+	MetaSize int                    `json:"metaSize,omitempty"`
+	Versions []objectInspectVersion `json:"versions,omitempty"`
+
+	// Fields below describe the requested version.
+	VersionID    string              `json:"versionId,omitempty"`
+	ModTime      time.Time           `json:"modTime,omitempty"`
+	Deleted      bool                `json:"deleted,omitempty"`
+	DataDir      string              `json:"dataDir,omitempty"`
+	Size         int64               `json:"size,omitempty"`
+	DataBlocks   int                 `json:"dataBlocks,omitempty"`
+	ParityBlocks int                 `json:"parityBlocks,omitempty"`
+	BlockSize    int64               `json:"blockSize,omitempty"`
+	ShardIndex   int                 `json:"shardIndex,omitempty"`
+	Distribution []int               `json:"distribution,omitempty"`
+	Parts        []objectInspectPart `json:"parts,omitempty"`
+	Inline       bool                `json:"inline,omitempty"`
+	InlineSize   int                 `json:"inlineSize,omitempty"`
+	Shards       string              `json:"shards,omitempty"`
+	ShardsError  string              `json:"shardsError,omitempty"`
+}
+
+// objectInspectReport is the per drive view of an object version.
+type objectInspectReport struct {
+	Bucket    string               `json:"bucket"`
+	Object    string               `json:"object"`
+	VersionID string               `json:"versionId,omitempty"`
+	Verified  bool                 `json:"verified"`
+	Drives    []objectInspectDrive `json:"drives"`
+}
+
+// inspectObject reads the xl.meta of object on every drive of the sets
+// the object hashes to and reports the versions each drive knows of and,
+// for the requested version, its erasure layout, checksums and whether
+// its shards are present. When verify is set the shards are read back
+// and their bitrot checksums validated.
+func (z *erasureServerPools) inspectObject(ctx context.Context, bucket, object, versionID string, verify bool) objectInspectReport {
+	report := objectInspectReport{
+		Bucket:    bucket,
+		Object:    object,
+		VersionID: versionID,
+		Verified:  verify,
+	}
+	for poolIdx, pool := range z.serverPools {
+		setIdx := pool.getHashedSetIndex(object)
+		set := pool.sets[setIdx]
+		disks := set.getDisks()
+		endpoints := set.getEndpoints()
+
+		drives := make([]objectInspectDrive, len(disks))
+		var wg sync.WaitGroup
+		for i := range disks {
+			drives[i] = objectInspectDrive{Pool: poolIdx, Set: setIdx, Index: i}
+			if i < len(endpoints) {
+				drives[i].Endpoint = endpoints[i].String()
+			}
+			wg.Add(1)
+			go func(d *objectInspectDrive, disk StorageAPI) {
+				defer wg.Done()
+				inspectObjectOnDrive(ctx, d, disk, bucket, object, versionID, verify)
+			}(&drives[i], disks[i])
+		}
+		wg.Wait()
+		report.Drives = append(report.Drives, drives...)
+	}
+	return report
+}
+
+func inspectObjectOnDrive(ctx context.Context, d *objectInspectDrive, disk StorageAPI, bucket, object, versionID string, verify bool) {
+	if disk == nil || !disk.IsOnline() {
+		d.State = objectInspectOffline
+		return
+	}
+
+	buf, err := disk.ReadAll(ctx, bucket, pathJoin(encodeDirObject(object), xlStorageFormatFile))
+	if err != nil {
+		d.State = objectInspectError
+		if errors.Is(err, errFileNotFound) || errors.Is(err, errVolumeNotFound) {
+			d.State = objectInspectMissing
+		}
+		d.Error = err.Error()
+		return
+	}
+	d.MetaSize = len(buf)
+
+	var x xlMetaV2
+	if err = x.LoadOrConvert(buf); err != nil {
+		d.State = objectInspectCorrupt
+		d.Error = err.Error()
+		return
+	}
+
+	versions, err := x.ListVersions(bucket, object)
+	if err != nil {
+		d.State = objectInspectCorrupt
+		d.Error = err.Error()
+		return
+	}
+	for _, v := range versions {
+		d.Versions = append(d.Versions, objectInspectVersion{
+			VersionID: v.VersionID,
+			ModTime:   v.ModTime,
+			Deleted:   v.Deleted,
+			DataDir:   v.DataDir,
+			Size:      v.Size,
+			Inline:    v.InlineData(),
+		})
+	}
+
+	fi, err := x.ToFileInfo(bucket, object, versionID)
+	if err != nil {
+		d.State = objectInspectCorrupt
+		if errors.Is(err, errFileNotFound) || errors.Is(err, errFileVersionNotFound) {
+			d.State = objectInspectVersionMissing
+		}
+		d.Error = err.Error()
+		return
+	}
+
+	d.State = objectInspectOK
+	d.VersionID = fi.VersionID
+	d.ModTime = fi.ModTime
+	d.Deleted = fi.Deleted
+	d.DataDir = fi.DataDir
+	d.Size = fi.Size
+	d.DataBlocks = fi.Erasure.DataBlocks
+	d.ParityBlocks = fi.Erasure.ParityBlocks
+	d.BlockSize = fi.Erasure.BlockSize
+	d.ShardIndex = fi.Erasure.Index
+	d.Distribution = fi.Erasure.Distribution
+	for _, part := range fi.Parts {
+		p := objectInspectPart{
+			Number:     part.Number,
+			Size:       part.Size,
+			ActualSize: part.ActualSize,
+		}
+		if sum := fi.Erasure.GetChecksumInfo(part.Number); sum.Algorithm.Available() {
+			p.Algorithm = sum.Algorithm.String()
+			p.Checksum = hex.EncodeToString(sum.Hash)
+		}
+		d.Parts = append(d.Parts, p)
+	}
+	if fi.Deleted {
+		return
+	}
+
+	if fi.InlineData() {
+		key := fi.VersionID
+		if key == "" {
+			key = nullVersionID
+		}
+		d.Inline = true
+		d.InlineSize = len(x.data.find(key))
+		d.Shards = objectShardsInline
+		if d.InlineSize == 0 {
+			d.Shards = objectShardsMissing
+		}
+		return
+	}
+
+	if verify {
+		err = disk.VerifyFile(ctx, bucket, object, fi)
+	} else {
+		err = disk.CheckParts(ctx, bucket, object, fi)
+	}
+	switch {
+	case err == nil:
+		d.Shards = objectShardsPresent
+	case errors.Is(err, errFileCorrupt):
+		d.Shards = objectShardsCorrupt
+	default:
+		d.Shards = objectShardsMissing
+	}
+	if err != nil {
+		d.ShardsError = err.Error()
+	}
+}
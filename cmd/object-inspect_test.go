@@ -0,0 +1,113 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/qkbyte/minio/internal/config/storageclass"
+)
+
+func TestInspectObject(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	fsDirs, err := getRandomDisks(16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+
+	objLayer, _, err := initObjectLayer(ctx, mustGetPoolEndpoints(fsDirs...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	z := objLayer.(*erasureServerPools)
+
+	defer func(sc storageclass.Config) { globalStorageClass = sc }(globalStorageClass)
+	globalStorageClass = storageclass.Config{Standard: storageclass.StorageClass{Parity: 4}}
+
+	bucket := getRandomBucketName()
+	if err = objLayer.MakeBucketWithLocation(ctx, bucket, MakeBucketOptions{}); err != nil {
+		t.Fatalf("Failed to make a bucket - %v", err)
+	}
+	small := []byte("inline data")
+	if _, err = objLayer.PutObject(ctx, bucket, "small", mustGetPutObjReader(t, bytes.NewReader(small), int64(len(small)), "", ""), ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	large := bytes.Repeat([]byte("a"), 8<<20)
+	oi, err := objLayer.PutObject(ctx, bucket, "large", mustGetPutObjReader(t, bytes.NewReader(large), int64(len(large)), "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report := z.inspectObject(ctx, bucket, "small", "", false)
+	if len(report.Drives) != 16 {
+		t.Fatalf("expected 16 drives, got %d", len(report.Drives))
+	}
+	for _, d := range report.Drives {
+		if d.State != objectInspectOK || !d.Inline || d.Shards != objectShardsInline || d.InlineSize == 0 {
+			t.Fatalf("unexpected inline drive report %+v", d)
+		}
+	}
+
+	// Lose the shard on one drive and the xl.meta on another.
+	disks := z.serverPools[0].sets[0].getDisks()
+	report = z.inspectObject(ctx, bucket, "large", "", false)
+	dataDir := report.Drives[0].DataDir
+	if err = disks[0].Delete(ctx, bucket, pathJoin("large", dataDir, "part.1"), DeleteOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err = disks[1].Delete(ctx, bucket, pathJoin("large", xlStorageFormatFile), DeleteOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	report = z.inspectObject(ctx, bucket, "large", oi.VersionID, true)
+	shardIndexes := make(map[int]bool)
+	for i, d := range report.Drives {
+		switch i {
+		case 0:
+			if d.State != objectInspectOK || d.Shards != objectShardsMissing {
+				t.Fatalf("expected missing shard, got %+v", d)
+			}
+		case 1:
+			if d.State != objectInspectMissing {
+				t.Fatalf("expected missing xl.meta, got %+v", d)
+			}
+			continue
+		default:
+			if d.State != objectInspectOK || d.Shards != objectShardsPresent {
+				t.Fatalf("expected present shard, got %+v", d)
+			}
+		}
+		if len(d.Parts) != 1 || d.Parts[0].Algorithm == "" || d.Inline {
+			t.Fatalf("unexpected parts %+v", d.Parts)
+		}
+		shardIndexes[d.ShardIndex] = true
+	}
+	if len(shardIndexes) != 15 {
+		t.Fatalf("expected 15 distinct shard indexes, got %d", len(shardIndexes))
+	}
+
+	report = z.inspectObject(ctx, bucket, "large", mustGetUUID(), false)
+	if report.Drives[2].State != objectInspectVersionMissing {
+		t.Fatalf("expected missing version, got %+v", report.Drives[2])
+	}
+}
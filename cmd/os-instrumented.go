@@ -18,11 +18,14 @@
 package cmd
 
 import (
+	"io"
+	"math"
 	"os"
 	"strings"
 	"sync/atomic"
 	"time"
 
+	"github.com/dustin/go-humanize"
 	"github.com/minio/madmin-go"
 	"github.com/qkbyte/minio/internal/disk"
 	ioutilx "github.com/qkbyte/minio/internal/ioutil"
@@ -49,6 +52,8 @@ const (
 	osMetricReadDirent
 	osMetricFdatasync
 	osMetricSync
+	osMetricRead
+	osMetricWrite
 	// .... add more
 
 	osMetricLast
@@ -56,6 +61,17 @@ const (
 
 var globalOSMetrics osMetrics
 
+// osIOSizeIntervals is the list of intervals of the size of a
+// single read, write or directory read for the I/O size histogram.
+var osIOSizeIntervals = [...]objectHistogramInterval{
+	{"LESS_THAN_4_KiB", 0, 4*humanize.KiByte - 1},
+	{"BETWEEN_4_KiB_AND_16_KiB", 4 * humanize.KiByte, 16*humanize.KiByte - 1},
+	{"BETWEEN_16_KiB_AND_64_KiB", 16 * humanize.KiByte, 64*humanize.KiByte - 1},
+	{"BETWEEN_64_KiB_AND_256_KiB", 64 * humanize.KiByte, 256*humanize.KiByte - 1},
+	{"BETWEEN_256_KiB_AND_1_MiB", 256 * humanize.KiByte, humanize.MiByte - 1},
+	{"GREATER_THAN_1_MiB", humanize.MiByte, math.MaxInt64},
+}
+
 func init() {
 	// Inject metrics.
 	ioutilx.OsOpenFile = OpenFile
@@ -66,9 +82,18 @@ func init() {
 type osMetrics struct {
 	// All fields must be accessed atomically and aligned.
 	operations [osMetricLast]uint64
+	bytes      [osMetricLast]uint64
+	sizes      [osMetricLast][len(osIOSizeIntervals)]uint64
 	latency    [osMetricLast]lockedLastMinuteLatency
 }
 
+// osIOStats is the lifetime number of bytes transferred and the
+// distribution of transfer sizes of an operation.
+type osIOStats struct {
+	Bytes uint64
+	Sizes [len(osIOSizeIntervals)]uint64
+}
+
 // time an os action.
 func (o *osMetrics) time(s osMetric) func() {
 	startTime := time.Now()
@@ -86,6 +111,96 @@ func (o *osMetrics) incTime(s osMetric, d time.Duration) {
 	o.latency[s].add(d)
 }
 
+// timeIO times an os action transferring data, the returned
+// function must be called with the number of bytes transferred.
+func (o *osMetrics) timeIO(s osMetric) func(n int) {
+	startTime := time.Now()
+	return func(n int) {
+		o.addIO(s, startTime, n)
+	}
+}
+
+// addIO records an operation started at startTime that transferred n bytes.
+func (o *osMetrics) addIO(s osMetric, startTime time.Time, n int) {
+	duration := time.Since(startTime)
+	atomic.AddUint64(&o.operations[s], 1)
+	if n < 0 {
+		n = 0
+	}
+	atomic.AddUint64(&o.bytes[s], uint64(n))
+	for i, interval := range osIOSizeIntervals {
+		if int64(n) >= interval.start && int64(n) <= interval.end {
+			atomic.AddUint64(&o.sizes[s][i], 1)
+			break
+		}
+	}
+	o.latency[s].addSize(duration, int64(n))
+}
+
+// ioReport returns the lifetime I/O statistics of the operations
+// that transferred data.
+func (o *osMetrics) ioReport() map[osMetric]osIOStats {
+	m := make(map[osMetric]osIOStats)
+	for i := osMetric(0); i < osMetricLast; i++ {
+		var st osIOStats
+		var ops uint64
+		for j := range osIOSizeIntervals {
+			st.Sizes[j] = atomic.LoadUint64(&o.sizes[i][j])
+			ops += st.Sizes[j]
+		}
+		if ops == 0 {
+			continue
+		}
+		st.Bytes = atomic.LoadUint64(&o.bytes[i])
+		m[i] = st
+	}
+	return m
+}
+
+// osReader records every Read of the underlying reader.
+type osReader struct {
+	r io.Reader
+}
+
+func (r *osReader) Read(p []byte) (int, error) {
+	stop := globalOSMetrics.timeIO(osMetricRead)
+	n, err := r.r.Read(p)
+	stop(n)
+	return n, err
+}
+
+// osWriter records every Write to the underlying writer.
+type osWriter struct {
+	w io.Writer
+}
+
+func (w *osWriter) Write(p []byte) (int, error) {
+	stop := globalOSMetrics.timeIO(osMetricWrite)
+	n, err := w.w.Write(p)
+	stop(n)
+	return n, err
+}
+
+// newOSReader returns a reader recording bytes read and read sizes,
+// this should only be used directly at the os level.
+func newOSReader(r io.Reader) io.Reader {
+	return &osReader{r: r}
+}
+
+// newOSWriter returns a writer recording bytes written and write sizes,
+// this should only be used directly at the os level.
+func newOSWriter(w io.Writer) io.Writer {
+	return &osWriter{w: w}
+}
+
+// ReadAt captures time taken and bytes read by f.ReadAt
+func ReadAt(f *os.File, b []byte, off int64) (int, error) {
+	stop := globalOSMetrics.timeIO(osMetricRead)
+	n, err := f.ReadAt(b, off)
+	stop(n)
+	return n, err
+}
+
 func osTrace(s osMetric, startTime time.Time, duration time.Duration, path string) madmin.TraceInfo {
 	return madmin.TraceInfo{
 		TraceType: madmin.TraceOS,
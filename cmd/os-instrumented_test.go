@@ -0,0 +1,74 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOSMetricsPrometheus(t *testing.T) {
+	var o osMetrics
+	o.incTime(osMetricStat, 2*time.Millisecond)
+	o.incTime(osMetricStat, 4*time.Millisecond)
+
+	m := o.report()
+	if m.LifeTimeOps[osMetricStat.String()] != 2 {
+		t.Fatalf("expected 2 lifetime stat operations, got %d", m.LifeTimeOps[osMetricStat.String()])
+	}
+	if got := m.LastMinute.Operations[osMetricStat.String()].Avg(); got != 3*time.Millisecond {
+		t.Fatalf("expected 3ms average latency, got %v", got)
+	}
+
+	globalOSMetrics.incTime(osMetricStat, time.Millisecond)
+	var found bool
+	for _, metric := range getOSMetrics().Get() {
+		if metric.VariableLabels["op"] == osMetricStat.String() && metric.Description.Name == "operations_total" {
+			found = metric.Value > 0
+		}
+	}
+	if !found {
+		t.Fatal("expected an operations_total metric for Stat")
+	}
+}
+
+func TestOSMetricsIO(t *testing.T) {
+	var o osMetrics
+	stop := o.timeIO(osMetricRead)
+	stop(100)
+	o.timeIO(osMetricRead)(8 << 10)
+	o.timeIO(osMetricRead)(2 << 20)
+
+	st, ok := o.ioReport()[osMetricRead]
+	if !ok {
+		t.Fatal("expected I/O statistics for Read")
+	}
+	if want := uint64(100 + 8<<10 + 2<<20); st.Bytes != want {
+		t.Fatalf("expected %d bytes, got %d", want, st.Bytes)
+	}
+	want := [len(osIOSizeIntervals)]uint64{1, 1, 0, 0, 0, 1}
+	if st.Sizes != want {
+		t.Fatalf("expected size distribution %v, got %v", want, st.Sizes)
+	}
+	if _, ok = o.ioReport()[osMetricWrite]; ok {
+		t.Fatal("unexpected I/O statistics for Write")
+	}
+	if lm := o.report().LastMinute.Operations[osMetricRead.String()]; lm.Bytes != st.Bytes {
+		t.Fatalf("expected %d last minute bytes, got %d", st.Bytes, lm.Bytes)
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// defaultReadDirSlowDuration is the enumeration time above which
+	// a directory is flagged as slow.
+	defaultReadDirSlowDuration = 2 * time.Second
+
+	// defaultReadDirLargeEntries is the number of entries above which
+	// a directory is flagged as slow.
+	defaultReadDirLargeEntries = 100000
+
+	// readDirMaxSlowDirs is the number of slow directories remembered.
+	readDirMaxSlowDirs = 100
+)
+
+// readDirEntriesIntervals is the list of intervals of the number of
+// entries of a fully enumerated directory.
+var readDirEntriesIntervals = [...]objectHistogramInterval{
+	{"LESS_THAN_10", 0, 9},
+	{"BETWEEN_10_AND_100", 10, 99},
+	{"BETWEEN_100_AND_1000", 100, 999},
+	{"BETWEEN_1000_AND_10000", 1000, 9999},
+	{"BETWEEN_10000_AND_100000", 10000, 99999},
+	{"BETWEEN_100000_AND_1000000", 100000, 999999},
+	{"GREATER_THAN_1000000", 1000000, math.MaxInt64},
+}
+
+// slowDir describes a directory whose enumeration exceeded the
+// configured duration or entry count.
+type slowDir struct {
+	Path     string        `json:"path"`
+	Entries  int           `json:"entries"`
+	Duration time.Duration `json:"duration"`
+	Complete bool          `json:"complete"`
+	Count    uint64        `json:"count"`
+	LastSeen time.Time     `json:"lastSeen"`
+}
+
+// slowDirsReport is the per node report of slow directories.
+type slowDirsReport struct {
+	Node  string    `json:"node"`
+	Slow  uint64    `json:"slow"`
+	Dirs  []slowDir `json:"dirs,omitempty"`
+	Error string    `json:"error,omitempty"`
+}
+
+// readDirStats keeps the distribution of the number of entries per
+// directory and the most recent slow directories.
+type readDirStats struct {
+	// All fields must be accessed atomically and aligned.
+	entries [len(readDirEntriesIntervals)]uint64
+	slow    uint64
+
+	slowDuration int64
+	largeEntries int64
+
+	mu   sync.Mutex
+	dirs map[string]*slowDir
+}
+
+var globalReadDirStats = newReadDirStats()
+
+func newReadDirStats() *readDirStats {
+	return &readDirStats{
+		slowDuration: int64(defaultReadDirSlowDuration),
+		largeEntries: defaultReadDirLargeEntries,
+		dirs:         make(map[string]*slowDir),
+	}
+}
+
+// setThresholds updates the thresholds above which directories are slow.
+func (r *readDirStats) setThresholds(d time.Duration, entries int) {
+	atomic.StoreInt64(&r.slowDuration, int64(d))
+	atomic.StoreInt64(&r.largeEntries, int64(entries))
+}
+
+// timeDir times the enumeration of dirPath, the returned function
+// must be called with the number of entries read and whether the
+// whole directory was read.
+func (r *readDirStats) timeDir(dirPath string) func(entries int, complete bool) {
+	startTime := time.Now()
+	return func(entries int, complete bool) {
+		r.record(dirPath, entries, time.Since(startTime), complete)
+	}
+}
+
+func (r *readDirStats) record(dirPath string, entries int, d time.Duration, complete bool) {
+	if complete {
+		for i, interval := range readDirEntriesIntervals {
+			if int64(entries) >= interval.start && int64(entries) <= interval.end {
+				atomic.AddUint64(&r.entries[i], 1)
+				break
+			}
+		}
+	}
+	if d < time.Duration(atomic.LoadInt64(&r.slowDuration)) &&
+		int64(entries) < atomic.LoadInt64(&r.largeEntries) {
+		return
+	}
+	atomic.AddUint64(&r.slow, 1)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	sd, ok := r.dirs[dirPath]
+	if !ok {
+		if len(r.dirs) >= readDirMaxSlowDirs {
+			r.evictOldest()
+		}
+		sd = &slowDir{Path: dirPath}
+		r.dirs[dirPath] = sd
+	}
+	sd.Entries = entries
+	sd.Duration = d
+	sd.Complete = complete
+	sd.Count++
+	sd.LastSeen = UTCNow()
+}
+
+func (r *readDirStats) evictOldest() {
+	var oldest *slowDir
+	for _, sd := range r.dirs {
+		if oldest == nil || sd.LastSeen.Before(oldest.LastSeen) {
+			oldest = sd
+		}
+	}
+	if oldest != nil {
+		delete(r.dirs, oldest.Path)
+	}
+}
+
+// entriesDistribution returns the number of fully enumerated
+// directories for each interval of readDirEntriesIntervals.
+func (r *readDirStats) entriesDistribution() (dist [len(readDirEntriesIntervals)]uint64) {
+	for i := range dist {
+		dist[i] = atomic.LoadUint64(&r.entries[i])
+	}
+	return dist
+}
+
+// report returns the slow directories seen by this node, slowest first.
+func (r *readDirStats) report() slowDirsReport {
+	report := slowDirsReport{Node: globalLocalNodeName, Slow: atomic.LoadUint64(&r.slow)}
+
+	r.mu.Lock()
+	for _, sd := range r.dirs {
+		report.Dirs = append(report.Dirs, *sd)
+	}
+	r.mu.Unlock()
+
+	sort.Slice(report.Dirs, func(i, j int) bool {
+		return report.Dirs[i].Duration > report.Dirs[j].Duration
+	})
+	return report
+}
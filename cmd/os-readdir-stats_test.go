@@ -0,0 +1,110 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestReadDirStatsRecord(t *testing.T) {
+	r := newReadDirStats()
+	r.setThresholds(time.Second, 100)
+
+	r.record("/fast", 5, time.Millisecond, true)
+	r.record("/large", 150, time.Millisecond, true)
+	r.record("/slow", 10, 2*time.Second, false)
+	r.record("/slow", 20, 3*time.Second, false)
+
+	dist := r.entriesDistribution()
+	if dist[0] != 1 || dist[2] != 1 {
+		t.Fatalf("unexpected entries distribution %v", dist)
+	}
+	var total uint64
+	for _, n := range dist {
+		total += n
+	}
+	if total != 2 {
+		t.Fatalf("incomplete enumerations must not be counted, got %d", total)
+	}
+
+	report := r.report()
+	if report.Slow != 3 {
+		t.Fatalf("expected 3 slow enumerations, got %d", report.Slow)
+	}
+	if len(report.Dirs) != 2 {
+		t.Fatalf("expected 2 slow directories, got %d", len(report.Dirs))
+	}
+	if sd := report.Dirs[0]; sd.Path != "/slow" || sd.Count != 2 || sd.Entries != 20 || sd.Complete {
+		t.Fatalf("unexpected slowest directory %+v", sd)
+	}
+	if sd := report.Dirs[1]; sd.Path != "/large" || !sd.Complete {
+		t.Fatalf("unexpected second directory %+v", sd)
+	}
+}
+
+func TestReadDirStatsEvict(t *testing.T) {
+	r := newReadDirStats()
+	r.setThresholds(time.Second, 1)
+	for i := 0; i < readDirMaxSlowDirs+10; i++ {
+		r.record("/dir"+strconv.Itoa(i), 2, time.Millisecond, true)
+	}
+	if report := r.report(); len(report.Dirs) != readDirMaxSlowDirs {
+		t.Fatalf("expected %d slow directories, got %d", readDirMaxSlowDirs, len(report.Dirs))
+	}
+}
+
+func TestReadDirStatsReadDir(t *testing.T) {
+	saved := globalReadDirStats
+	defer func() { globalReadDirStats = saved }()
+	globalReadDirStats = newReadDirStats()
+	globalReadDirStats.setThresholds(time.Hour, 3)
+
+	dir := t.TempDir()
+	for i := 0; i < 5; i++ {
+		if err := os.WriteFile(filepath.Join(dir, strconv.Itoa(i)), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// A partial listing below the thresholds is neither counted nor slow.
+	if _, err := readDirWithOpts(dir, readDirOpts{count: 2}); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := readDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 5 {
+		t.Fatalf("expected 5 entries, got %d", len(entries))
+	}
+
+	if dist := globalReadDirStats.entriesDistribution(); dist[0] != 1 {
+		t.Fatalf("expected one complete enumeration, got %v", dist)
+	}
+	report := globalReadDirStats.report()
+	if report.Slow != 1 || len(report.Dirs) != 1 {
+		t.Fatalf("unexpected report %+v", report)
+	}
+	if sd := report.Dirs[0]; sd.Path != dir || sd.Entries != 5 || !sd.Complete {
+		t.Fatalf("unexpected slow directory %+v", sd)
+	}
+}
@@ -0,0 +1,95 @@
+//go:build linux
+// +build linux
+
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"sync/atomic"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// tmpFile creates anonymous files with O_TMPFILE that only become
+// visible in the namespace once their content is complete, a crash
+// while writing never leaves a partially written file behind.
+// The zero value is ready to use, support is tracked per instance
+// since it depends on the filesystem.
+type tmpFile struct {
+	unsupported int32
+}
+
+// open returns an unnamed file in dir, errTmpFileUnsupported is
+// returned when the underlying filesystem does not support it.
+func (t *tmpFile) open(dir string, direct bool) (*os.File, error) {
+	if atomic.LoadInt32(&t.unsupported) == 1 || !procSelfFD {
+		return nil, errTmpFileUnsupported
+	}
+
+	var f *os.File
+	var err error
+	flags := unix.O_TMPFILE | os.O_WRONLY
+	if direct {
+		f, err = OpenFileDirectIO(dir, flags, 0o666)
+	} else {
+		f, err = OpenFile(dir, flags, 0o666)
+	}
+	if err != nil {
+		// Kernels or filesystems without O_TMPFILE report
+		// one of these, remember it to not try again.
+		if errors.Is(err, syscall.EOPNOTSUPP) || errors.Is(err, syscall.EISDIR) || errors.Is(err, syscall.EINVAL) {
+			atomic.StoreInt32(&t.unsupported, 1)
+			return nil, errTmpFileUnsupported
+		}
+		return nil, osErrToFileErr(err)
+	}
+	return f, nil
+}
+
+// link gives the unnamed file f the name filePath, with replace
+// an existing file at filePath is atomically replaced.
+func (t *tmpFile) link(f *os.File, filePath string, replace bool) error {
+	src := "/proc/self/fd/" + strconv.Itoa(int(f.Fd()))
+	err := unix.Linkat(unix.AT_FDCWD, src, unix.AT_FDCWD, filePath, unix.AT_SYMLINK_FOLLOW)
+	if replace && errors.Is(err, syscall.EEXIST) {
+		// linkat never overwrites, link next to the
+		// destination and rename it over.
+		tmpPath := filePath + "." + mustGetUUID()
+		if err = unix.Linkat(unix.AT_FDCWD, src, unix.AT_FDCWD, tmpPath, unix.AT_SYMLINK_FOLLOW); err == nil {
+			if err = Rename(tmpPath, filePath); err != nil {
+				Remove(tmpPath)
+			}
+		}
+	}
+	if err != nil {
+		return osErrToFileErr(&os.LinkError{Op: "linkat", Old: src, New: filePath, Err: err})
+	}
+	return nil
+}
+
+// procSelfFD is true when unnamed files can be linked by their
+// /proc/self/fd entry, which does not need CAP_DAC_READ_SEARCH.
+var procSelfFD = func() bool {
+	_, err := os.Stat("/proc/self/fd")
+	return err == nil
+}()
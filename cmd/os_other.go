@@ -48,12 +48,18 @@ func readDirFn(dirPath string, filter func(name string, typ os.FileMode) error)
 	}
 	defer d.Close()
 
+	var count int
+	var complete bool
+	stop := globalReadDirStats.timeDir(dirPath)
+	defer func() { stop(count, complete) }()
+
 	maxEntries := 1000
 	for {
 		// Read up to max number of entries.
 		fis, err := d.Readdir(maxEntries)
 		if err != nil {
 			if err == io.EOF {
+				complete = true
 				break
 			}
 			err = osErrToFileErr(err)
@@ -81,6 +87,7 @@ func readDirFn(dirPath string, filter func(name string, typ os.FileMode) error)
 					continue
 				}
 			}
+			count++
 			if err = filter(fi.Name(), fi.Mode()); err == errDoneForNow {
 				// filtering requested to return by caller.
 				return nil
@@ -98,6 +105,10 @@ func readDirWithOpts(dirPath string, opts readDirOpts) (entries []string, err er
 	}
 	defer d.Close()
 
+	var complete bool
+	stop := globalReadDirStats.timeDir(dirPath)
+	defer func() { stop(len(entries), complete) }()
+
 	maxEntries := 1000
 	if opts.count > 0 && opts.count < maxEntries {
 		maxEntries = opts.count
@@ -111,6 +122,7 @@ func readDirWithOpts(dirPath string, opts readDirOpts) (entries []string, err er
 		fis, err := d.Readdir(maxEntries)
 		if err != nil {
 			if err == io.EOF {
+				complete = true
 				break
 			}
 			return nil, osErrToFileErr(err)
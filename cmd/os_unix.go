@@ -173,6 +173,11 @@ func readDirFn(dirPath string, fn func(name string, typ os.FileMode) error) erro
 	}
 	defer f.Close()
 
+	var count int
+	var complete bool
+	stop := globalReadDirStats.timeDir(dirPath)
+	defer func() { stop(count, complete) }()
+
 	bufp := direntPool.Get().(*[]byte)
 	defer direntPool.Put(bufp)
 	buf := *bufp
@@ -183,9 +188,9 @@ func readDirFn(dirPath string, fn func(name string, typ os.FileMode) error) erro
 	for {
 		if boff >= nbuf {
 			boff = 0
-			stop := globalOSMetrics.time(osMetricReadDirent)
+			stop := globalOSMetrics.timeIO(osMetricReadDirent)
 			nbuf, err = syscall.ReadDirent(int(f.Fd()), buf)
-			stop()
+			stop(nbuf)
 			if err != nil {
 				if isSysErrNotDir(err) {
 					return nil
@@ -197,6 +202,7 @@ func readDirFn(dirPath string, fn func(name string, typ os.FileMode) error) erro
 				return err
 			}
 			if nbuf <= 0 {
+				complete = true
 				break // EOF
 			}
 		}
@@ -232,6 +238,7 @@ func readDirFn(dirPath string, fn func(name string, typ os.FileMode) error) erro
 
 			typ = fi.Mode() & os.ModeType
 		}
+		count++
 		if err = fn(string(name), typ); err == errDoneForNow {
 			// fn() requested to return by caller.
 			return nil
@@ -259,6 +266,10 @@ func readDirWithOpts(dirPath string, opts readDirOpts) (entries []string, err er
 	}
 	defer f.Close()
 
+	var complete bool
+	stop := globalReadDirStats.timeDir(dirPath)
+	defer func() { stop(len(entries), complete) }()
+
 	bufp := direntPool.Get().(*[]byte)
 	defer direntPool.Put(bufp)
 	buf := *bufp
@@ -275,9 +286,9 @@ func readDirWithOpts(dirPath string, opts readDirOpts) (entries []string, err er
 	for count != 0 {
 		if boff >= nbuf {
 			boff = 0
-			stop := globalOSMetrics.time(osMetricReadDirent)
+			stop := globalOSMetrics.timeIO(osMetricReadDirent)
 			nbuf, err = syscall.ReadDirent(int(f.Fd()), buf)
-			stop()
+			stop(nbuf)
 			if err != nil {
 				if isSysErrNotDir(err) {
 					return nil, errFileNotFound
@@ -285,6 +296,7 @@ func readDirWithOpts(dirPath string, opts readDirOpts) (entries []string, err er
 				return nil, osErrToFileErr(err)
 			}
 			if nbuf <= 0 {
+				complete = true
 				break
 			}
 		}
@@ -0,0 +1,142 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	xhttp "github.com/qkbyte/minio/internal/http"
+)
+
+var (
+	errPeerDeploymentIDMismatch = errors.New("peer belongs to a different deployment")
+	errPeerVersionSkew          = errors.New("peer runs a different release")
+)
+
+// peerHandshake is what this node last learned about a peer from
+// the identity headers on its internode responses.
+type peerHandshake struct {
+	Host         string    `json:"host"`
+	DeploymentID string    `json:"deploymentID,omitempty"`
+	Version      string    `json:"version,omitempty"`
+	LastSeen     time.Time `json:"lastSeen"`
+	Skewed       bool      `json:"skewed"`
+	Rejected     string    `json:"rejected,omitempty"`
+}
+
+// peerHandshakes keeps the last handshake seen per peer host.
+type peerHandshakes struct {
+	mu    sync.Mutex
+	peers map[string]peerHandshake
+}
+
+var globalPeerHandshakes = &peerHandshakes{peers: make(map[string]peerHandshake)}
+
+// checkPeerIdentity validates the deployment ID and release a peer
+// announced. Either side may not know its deployment ID yet while the
+// drives are being formatted, in which case only the release is checked.
+func checkPeerIdentity(deploymentID, version string) error {
+	if deploymentID != "" && globalDeploymentID != "" && deploymentID != globalDeploymentID {
+		return fmt.Errorf("%w: expected %s, found %s", errPeerDeploymentIDMismatch, globalDeploymentID, deploymentID)
+	}
+	if version != "" && version != Version && globalAPIConfig.isVersionSkewRejected() {
+		return fmt.Errorf("%w: expected %s, found %s", errPeerVersionSkew, Version, version)
+	}
+	return nil
+}
+
+// observe records the identity headers returned by host and
+// validates them, it is used as the internode REST clients' HandshakeFn.
+func (p *peerHandshakes) observe(host string, h http.Header) error {
+	hs := peerHandshake{
+		Host:         host,
+		DeploymentID: h.Get(xhttp.MinioDeploymentID),
+		Version:      h.Get(xhttp.MinIOVersion),
+		LastSeen:     UTCNow(),
+	}
+	hs.Skewed = hs.Version != "" && hs.Version != Version
+	err := checkPeerIdentity(hs.DeploymentID, hs.Version)
+	if err != nil {
+		hs.Rejected = err.Error()
+	}
+
+	p.mu.Lock()
+	p.peers[host] = hs
+	p.mu.Unlock()
+	return err
+}
+
+// list returns the recorded peers sorted by host.
+func (p *peerHandshakes) list() []peerHandshake {
+	p.mu.Lock()
+	peers := make([]peerHandshake, 0, len(p.peers))
+	for _, hs := range p.peers {
+		peers = append(peers, hs)
+	}
+	p.mu.Unlock()
+
+	sort.Slice(peers, func(i, j int) bool {
+		return peers[i].Host < peers[j].Host
+	})
+	return peers
+}
+
+// setPeerHandshakeHeaders announces this node's deployment ID and
+// release on an internode response, before the request is validated
+// so that a rejected caller also learns why.
+func setPeerHandshakeHeaders(w http.ResponseWriter) {
+	if globalDeploymentID != "" {
+		w.Header().Set(xhttp.MinioDeploymentID, globalDeploymentID)
+	}
+	w.Header().Set(xhttp.MinIOVersion, Version)
+}
+
+// validatePeerHandshake checks the identity headers of an internode request.
+func validatePeerHandshake(r *http.Request) error {
+	return checkPeerIdentity(r.Header.Get(xhttp.MinioDeploymentID), r.Header.Get(xhttp.MinIOVersion))
+}
+
+// versionSkewReport lists the release and deployment ID of every peer
+// this node talks to.
+type versionSkewReport struct {
+	Node         string          `json:"node"`
+	DeploymentID string          `json:"deploymentID"`
+	Version      string          `json:"version"`
+	Skewed       bool            `json:"skewed"`
+	Peers        []peerHandshake `json:"peers"`
+}
+
+func getVersionSkewReport() versionSkewReport {
+	report := versionSkewReport{
+		Node:         globalLocalNodeName,
+		DeploymentID: globalDeploymentID,
+		Version:      Version,
+		Peers:        globalPeerHandshakes.list(),
+	}
+	for _, hs := range report.Peers {
+		if hs.Skewed || hs.Rejected != "" {
+			report.Skewed = true
+		}
+	}
+	return report
+}
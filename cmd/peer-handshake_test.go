@@ -0,0 +1,88 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	xhttp "github.com/qkbyte/minio/internal/http"
+)
+
+func TestPeerHandshakeObserve(t *testing.T) {
+	savedID := globalDeploymentID
+	globalDeploymentID = "a1b2c3"
+	defer func() {
+		globalDeploymentID = savedID
+		globalAPIConfig.mu.Lock()
+		globalAPIConfig.rejectVersionSkew = false
+		globalAPIConfig.mu.Unlock()
+	}()
+
+	header := func(id, version string) http.Header {
+		h := make(http.Header)
+		if id != "" {
+			h.Set(xhttp.MinioDeploymentID, id)
+		}
+		h.Set(xhttp.MinIOVersion, version)
+		return h
+	}
+
+	p := &peerHandshakes{peers: make(map[string]peerHandshake)}
+	if err := p.observe("node1:9000", header("a1b2c3", Version)); err != nil {
+		t.Fatal(err)
+	}
+	// A peer still formatting its drives has no deployment ID yet.
+	if err := p.observe("node2:9000", header("", Version)); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.observe("node3:9000", header("ffffff", Version)); !errors.Is(err, errPeerDeploymentIDMismatch) {
+		t.Fatalf("expected %v, got %v", errPeerDeploymentIDMismatch, err)
+	}
+	if err := p.observe("node4:9000", header("a1b2c3", "RELEASE.old")); err != nil {
+		t.Fatalf("skew should only be flagged by default, got %v", err)
+	}
+
+	globalAPIConfig.mu.Lock()
+	globalAPIConfig.rejectVersionSkew = true
+	globalAPIConfig.mu.Unlock()
+	if err := p.observe("node4:9000", header("a1b2c3", "RELEASE.old")); !errors.Is(err, errPeerVersionSkew) {
+		t.Fatalf("expected %v, got %v", errPeerVersionSkew, err)
+	}
+
+	peers := p.list()
+	if len(peers) != 4 {
+		t.Fatalf("expected 4 peers, got %d", len(peers))
+	}
+	for i, want := range []struct {
+		host     string
+		skewed   bool
+		rejected bool
+	}{
+		{"node1:9000", false, false},
+		{"node2:9000", false, false},
+		{"node3:9000", false, true},
+		{"node4:9000", true, true},
+	} {
+		hs := peers[i]
+		if hs.Host != want.host || hs.Skewed != want.skewed || (hs.Rejected != "") != want.rejected {
+			t.Errorf("peer %d: got %+v, want %+v", i, hs, want)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/cespare/xxhash/v2"
+)
+
+// peerHashRingReplicas is the number of points of each peer on the
+// ring, spreading the keys of a failed peer over the remaining peers.
+const peerHashRingReplicas = 64
+
+// peerHashRing is a consistent hash ring of all peers, including the
+// local node. Keys are owned by the first online peer found walking the
+// ring clockwise, so when a peer goes offline only its keys move, to the
+// next peers on the ring, and return once it is back online. Since the
+// ring is built from the sorted host names it is the same on all nodes.
+type peerHashRing struct {
+	points []peerHashRingPoint
+}
+
+type peerHashRingPoint struct {
+	hash uint64
+	// peer is the index of the peer in the list the ring was built from.
+	peer int
+}
+
+// newPeerHashRing returns a ring of the given peer host names.
+func newPeerHashRing(hosts []string) *peerHashRing {
+	r := &peerHashRing{
+		points: make([]peerHashRingPoint, 0, len(hosts)*peerHashRingReplicas),
+	}
+	for i, host := range hosts {
+		for j := 0; j < peerHashRingReplicas; j++ {
+			r.points = append(r.points, peerHashRingPoint{
+				hash: xxhash.Sum64String(host + "#" + strconv.Itoa(j)),
+				peer: i,
+			})
+		}
+	}
+	sort.Slice(r.points, func(i, j int) bool {
+		if r.points[i].hash == r.points[j].hash {
+			return r.points[i].peer < r.points[j].peer
+		}
+		return r.points[i].hash < r.points[j].hash
+	})
+	return r
+}
+
+// lookup returns the indexes of up to n distinct online peers
+// owning key, in the order of preference.
+func (r *peerHashRing) lookup(key string, n int, online func(peer int) bool) []int {
+	if r == nil || len(r.points) == 0 || n <= 0 {
+		return nil
+	}
+	h := xxhash.Sum64String(key)
+	start := sort.Search(len(r.points), func(i int) bool {
+		return r.points[i].hash >= h
+	})
+
+	peers := make([]int, 0, n)
+	seen := make(map[int]struct{}, n)
+	for i := 0; i < len(r.points) && len(peers) < n; i++ {
+		p := r.points[(start+i)%len(r.points)].peer
+		if _, ok := seen[p]; ok {
+			continue
+		}
+		seen[p] = struct{}{}
+		if online(p) {
+			peers = append(peers, p)
+		}
+	}
+	return peers
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestPeerHashRing(t *testing.T) {
+	hosts := []string{"node1:9000", "node2:9000", "node3:9000", "node4:9000"}
+	r := newPeerHashRing(hosts)
+
+	allOnline := func(int) bool { return true }
+	owners := make(map[string]int)
+	counts := make([]int, len(hosts))
+	for i := 0; i < 1000; i++ {
+		key := "bucket/prefix-" + strconv.Itoa(i)
+		peers := r.lookup(key, 2, allOnline)
+		if len(peers) != 2 || peers[0] == peers[1] {
+			t.Fatalf("expected 2 distinct peers, got %v", peers)
+		}
+		owners[key] = peers[0]
+		counts[peers[0]]++
+	}
+	for i, n := range counts {
+		if n == 0 {
+			t.Fatalf("peer %d owns no keys", i)
+		}
+	}
+
+	// The ring only depends on the host names.
+	other := newPeerHashRing(hosts)
+	for key, owner := range owners {
+		if got := other.lookup(key, 1, allOnline)[0]; got != owner {
+			t.Fatalf("expected owner %d for %s, got %d", owner, key, got)
+		}
+	}
+
+	// Only keys of an offline peer move, to their secondary.
+	const offline = 2
+	for key, owner := range owners {
+		peers := r.lookup(key, 2, func(peer int) bool { return peer != offline })
+		if owner != offline && peers[0] != owner {
+			t.Fatalf("key %s moved from %d to %d", key, owner, peers[0])
+		}
+		if owner == offline {
+			if peers[0] == offline {
+				t.Fatalf("key %s is owned by an offline peer", key)
+			}
+			if all := r.lookup(key, 2, allOnline); all[1] != peers[0] {
+				t.Fatalf("key %s moved to %d instead of its secondary %d", key, peers[0], all[1])
+			}
+		}
+	}
+
+	if peers := r.lookup("key", 2, func(int) bool { return false }); len(peers) != 0 {
+		t.Fatalf("expected no peers, got %v", peers)
+	}
+	var nilRing *peerHashRing
+	if peers := nilRing.lookup("key", 1, allOnline); peers != nil {
+		t.Fatalf("expected no peers, got %v", peers)
+	}
+}
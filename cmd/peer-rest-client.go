@@ -44,6 +44,9 @@ import (
 type peerRESTClient struct {
 	host       *xnet.Host
 	restClient *rest.Client
+
+	// Tracks failing metacache RPCs to the peer.
+	metacacheBreaker peerCircuitBreaker
 }
 
 // Wrapper to restClient.Call to handle network errors, in case of network error the connection is marked disconnected
@@ -506,12 +509,17 @@ func (client *peerRESTClient) GetMetacacheListing(ctx context.Context, o listPat
 		return &resp, nil
 	}
 
+	if !client.metacacheBreaker.allow(time.Now()) {
+		return nil, errPeerCircuitOpen
+	}
+
 	var reader bytes.Buffer
 	err := gob.NewEncoder(&reader).Encode(o)
 	if err != nil {
 		return nil, err
 	}
 	respBody, err := client.callWithContext(ctx, peerRESTMethodGetMetacacheListing, nil, &reader, int64(reader.Len()))
+	client.metacacheBreaker.done(err, time.Now())
 	if err != nil {
 		logger.LogIf(ctx, err)
 		return nil, err
@@ -550,6 +558,16 @@ func (client *peerRESTClient) ReloadPoolMeta(ctx context.Context) error {
 	return nil
 }
 
+// LoadTenants - reloads the tenants on the peer.
+func (client *peerRESTClient) LoadTenants(ctx context.Context) error {
+	respBody, err := client.callWithContext(ctx, peerRESTMethodLoadTenants, nil, nil, 0)
+	if err != nil {
+		return err
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
 func (client *peerRESTClient) LoadTransitionTierConfig(ctx context.Context) error {
 	respBody, err := client.callWithContext(ctx, peerRESTMethodLoadTransitionTierConfig, nil, nil, 0)
 	if err != nil {
@@ -708,6 +726,59 @@ func (client *peerRESTClient) doConsoleLog(logCh chan pubsub.Maskable, doneCh <-
 	}
 }
 
+func (client *peerRESTClient) doHealStatusStream(healCh chan<- pubsub.Maskable, doneCh <-chan struct{}) {
+	// To cancel the REST request in case doneCh gets closed.
+	ctx, cancel := context.WithCancel(GlobalContext)
+
+	cancelCh := make(chan struct{})
+	defer close(cancelCh)
+	go func() {
+		select {
+		case <-doneCh:
+		case <-cancelCh:
+			// There was an error in the REST request.
+		}
+		cancel()
+	}()
+
+	respBody, err := client.callWithContext(ctx, peerRESTMethodHealStatusStream, nil, nil, -1)
+	defer http.DrainBody(respBody)
+	if err != nil {
+		return
+	}
+
+	dec := gob.NewDecoder(respBody)
+	for {
+		var u healStatusUpdate
+		if err = dec.Decode(&u); err != nil {
+			return
+		}
+		if u.Node != "" {
+			select {
+			case healCh <- u:
+			default:
+				// Do not block on slow receivers.
+			}
+		}
+	}
+}
+
+// HealStatusStream - streams heal status updates of drives healing on the peer.
+func (client *peerRESTClient) HealStatusStream(healCh chan<- pubsub.Maskable, doneCh <-chan struct{}) {
+	go func() {
+		for {
+			client.doHealStatusStream(healCh, doneCh)
+			select {
+			case <-doneCh:
+				return
+			default:
+				// There was error in the REST request, retry after sometime as probably the peer is down.
+				time.Sleep(5 * time.Second)
+			}
+		}
+	}()
+}
+
 // ConsoleLog - sends request to peer nodes to get console logs
 func (client *peerRESTClient) ConsoleLog(logCh chan pubsub.Maskable, doneCh <-chan struct{}) {
 	go func() {
@@ -763,8 +834,10 @@ func newPeerRESTClient(peer *xnet.Host) *peerRESTClient {
 	}
 
 	restClient := rest.NewClient(serverURL, globalInternodeTransport, newCachedAuthToken())
+	restClient.HandshakeFn = globalPeerHandshakes.observe
 	// Use a separate client to avoid recursive calls.
 	healthClient := rest.NewClient(serverURL, globalInternodeTransport, newCachedAuthToken())
+	healthClient.HandshakeFn = globalPeerHandshakes.observe
 	healthClient.ExpectTimeouts = true
 	healthClient.NoMetrics = true
 
@@ -899,6 +972,205 @@ func (client *peerRESTClient) GetLastDayTierStats(ctx context.Context) (DailyAll
 	return DailyAllTierStats(result), nil
 }
 
+// GetAPIAnomalies - returns the API anomaly report of the peer
+func (client *peerRESTClient) GetAPIAnomalies(ctx context.Context) (apiAnomalyReport, error) {
+	var report apiAnomalyReport
+	respBody, err := client.callWithContext(ctx, peerRESTMethodGetAPIAnomalies, nil, nil, -1)
+	if err != nil {
+		return report, err
+	}
+	defer http.DrainBody(respBody)
+
+	err = gob.NewDecoder(respBody).Decode(&report)
+	return report, err
+}
+
+// GetSSEComplianceReport - returns the bucket encryption compliance report of the peer
+func (client *peerRESTClient) GetSSEComplianceReport(ctx context.Context, bucket string) (sseComplianceReport, error) {
+	var report sseComplianceReport
+	values := make(url.Values)
+	values.Set(peerRESTBucket, bucket)
+	respBody, err := client.callWithContext(ctx, peerRESTMethodGetSSEComplianceReport, values, nil, -1)
+	if err != nil {
+		return report, err
+	}
+	defer http.DrainBody(respBody)
+
+	err = gob.NewDecoder(respBody).Decode(&report)
+	return report, err
+}
+
+// GetSlowDirs - returns the slow directories seen by the peer
+func (client *peerRESTClient) GetSlowDirs(ctx context.Context) (slowDirsReport, error) {
+	var report slowDirsReport
+	respBody, err := client.callWithContext(ctx, peerRESTMethodGetSlowDirs, nil, nil, -1)
+	if err != nil {
+		return report, err
+	}
+	defer http.DrainBody(respBody)
+
+	err = gob.NewDecoder(respBody).Decode(&report)
+	return report, err
+}
+
+// GetLocalTime - returns the current time of the peer
+func (client *peerRESTClient) GetLocalTime(ctx context.Context) (time.Time, error) {
+	var t time.Time
+	respBody, err := client.callWithContext(ctx, peerRESTMethodGetLocalTime, nil, nil, -1)
+	if err != nil {
+		return t, err
+	}
+	defer http.DrainBody(respBody)
+
+	err = gob.NewDecoder(respBody).Decode(&t)
+	return t, err
+}
+
+// Gossip - exchanges the membership view with the peer, returns the
+// view of the peer.
+func (client *peerRESTClient) Gossip(ctx context.Context, digest []gossipMember) ([]gossipMember, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(digest); err != nil {
+		return nil, err
+	}
+	respBody, err := client.callWithContext(ctx, peerRESTMethodGossip, nil, &buf, int64(buf.Len()))
+	if err != nil {
+		return nil, err
+	}
+	defer http.DrainBody(respBody)
+
+	var members []gossipMember
+	err = gob.NewDecoder(respBody).Decode(&members)
+	return members, err
+}
+
+// GetHotObjects - returns the top n objects by requests and by latency of the peer
+func (client *peerRESTClient) GetHotObjects(ctx context.Context, n int) (hotObjectsReport, error) {
+	var report hotObjectsReport
+	values := make(url.Values)
+	values.Set(peerRESTCount, strconv.Itoa(n))
+	respBody, err := client.callWithContext(ctx, peerRESTMethodGetHotObjects, values, nil, -1)
+	if err != nil {
+		return report, err
+	}
+	defer http.DrainBody(respBody)
+
+	err = gob.NewDecoder(respBody).Decode(&report)
+	return report, err
+}
+
+// GetRecentErrors - returns the most recent errors logged by the peer
+func (client *peerRESTClient) GetRecentErrors(ctx context.Context, n int) ([]dashboardError, error) {
+	var errs []dashboardError
+	values := make(url.Values)
+	values.Set(peerRESTCount, strconv.Itoa(n))
+	respBody, err := client.callWithContext(ctx, peerRESTMethodGetRecentErrors, values, nil, -1)
+	if err != nil {
+		return errs, err
+	}
+	defer http.DrainBody(respBody)
+
+	err = gob.NewDecoder(respBody).Decode(&errs)
+	return errs, err
+}
+
+// GetRecentLogs - returns the most recent console log entries of the peer
+func (client *peerRESTClient) GetRecentLogs(ctx context.Context, n int) ([]diagLogEntry, error) {
+	var entries []diagLogEntry
+	values := make(url.Values)
+	values.Set(peerRESTCount, strconv.Itoa(n))
+	respBody, err := client.callWithContext(ctx, peerRESTMethodGetRecentLogs, values, nil, -1)
+	if err != nil {
+		return entries, err
+	}
+	defer http.DrainBody(respBody)
+
+	err = gob.NewDecoder(respBody).Decode(&entries)
+	return entries, err
+}
+
+// NetProbe - sends payload to the peer, which discards it, to sample
+// the network quality without touching the netperf counters
+func (client *peerRESTClient) NetProbe(ctx context.Context, payload []byte) error {
+	respBody, err := client.callWithContext(ctx, peerRESTMethodNetProbe, nil, bytes.NewReader(payload), int64(len(payload)))
+	if err != nil {
+		return err
+	}
+	http.DrainBody(respBody)
+	return nil
+}
+
+// GetNetMonitor - returns the network quality samples the peer took
+// within the last window
+func (client *peerRESTClient) GetNetMonitor(ctx context.Context, window time.Duration) (netMonitorReport, error) {
+	var report netMonitorReport
+	values := make(url.Values)
+	values.Set(peerRESTDuration, window.String())
+	respBody, err := client.callWithContext(ctx, peerRESTMethodGetNetMonitor, values, nil, -1)
+	if err != nil {
+		return report, err
+	}
+	defer http.DrainBody(respBody)
+
+	err = gob.NewDecoder(respBody).Decode(&report)
+	return report, err
+}
+
+// GetMetaDivergences - returns the metadata divergences counted and
+// logged by the peer
+func (client *peerRESTClient) GetMetaDivergences(ctx context.Context, n int) (metaDivergenceReport, error) {
+	var report metaDivergenceReport
+	values := make(url.Values)
+	values.Set(peerRESTCount, strconv.Itoa(n))
+	respBody, err := client.callWithContext(ctx, peerRESTMethodGetMetaDivergences, values, nil, -1)
+	if err != nil {
+		return report, err
+	}
+	defer http.DrainBody(respBody)
+
+	err = gob.NewDecoder(respBody).Decode(&report)
+	return report, err
+}
+
+// GetErasureSIMD - returns the Reed-Solomon acceleration path of the peer
+func (client *peerRESTClient) GetErasureSIMD(ctx context.Context) (erasureSIMDInfo, error) {
+	var info erasureSIMDInfo
+	respBody, err := client.callWithContext(ctx, peerRESTMethodGetErasureSIMD, nil, nil, -1)
+	if err != nil {
+		return info, err
+	}
+	defer http.DrainBody(respBody)
+
+	err = gob.NewDecoder(respBody).Decode(&info)
+	return info, err
+}
+
+// GetShareLinkUsage - returns the bytes served per share link by the peer
+func (client *peerRESTClient) GetShareLinkUsage(ctx context.Context) (map[string]int64, error) {
+	var usage map[string]int64
+	respBody, err := client.callWithContext(ctx, peerRESTMethodGetShareLinkUsage, nil, nil, -1)
+	if err != nil {
+		return usage, err
+	}
+	defer http.DrainBody(respBody)
+
+	err = gob.NewDecoder(respBody).Decode(&usage)
+	return usage, err
+}
+
+// ListAdminJobs - returns the running and finished admin jobs of the peer
+func (client *peerRESTClient) ListAdminJobs(ctx context.Context) ([]adminJobInfo, error) {
+	var jobs []adminJobInfo
+	respBody, err := client.callWithContext(ctx, peerRESTMethodListAdminJobs, nil, nil, -1)
+	if err != nil {
+		return jobs, err
+	}
+	defer http.DrainBody(respBody)
+
+	err = gob.NewDecoder(respBody).Decode(&jobs)
+	return jobs, err
+}
+
 // DevNull - Used by netperf to pump data to peer
 func (client *peerRESTClient) DevNull(ctx context.Context, r io.Reader) error {
 	respBody, err := client.callWithContext(ctx, peerRESTMethodDevNull, nil, r, -1)
@@ -18,7 +18,7 @@
 package cmd
 
 const (
-	peerRESTVersion       = "v27" // change in GetAllBucketStats response.
+	peerRESTVersion       = "v43" // Added GetErasureSIMD
 	peerRESTVersionPrefix = SlashSeparator + peerRESTVersion
 	peerRESTPrefix        = minioReservedBucketPath + "/peer"
 	peerRESTPath          = peerRESTPrefix + peerRESTVersionPrefix
@@ -58,6 +58,7 @@ const (
 	peerRESTMethodTrace                       = "/trace"
 	peerRESTMethodListen                      = "/listen"
 	peerRESTMethodLog                         = "/log"
+	peerRESTMethodHealStatusStream            = "/healstatusstream"
 	peerRESTMethodGetLocalDiskIDs             = "/getlocaldiskids"
 	peerRESTMethodGetBandwidth                = "/bandwidth"
 	peerRESTMethodGetMetacacheListing         = "/getmetacache"
@@ -72,6 +73,21 @@ const (
 	peerRESTMethodDevNull                     = "/devnull"
 	peerRESTMethodNetperf                     = "/netperf"
 	peerRESTMethodMetrics                     = "/metrics"
+	peerRESTMethodGetAPIAnomalies             = "/apianomalies"
+	peerRESTMethodGetSSEComplianceReport      = "/ssecompliancereport"
+	peerRESTMethodGetSlowDirs                 = "/slowdirs"
+	peerRESTMethodGetHotObjects               = "/hotobjects"
+	peerRESTMethodGetShareLinkUsage           = "/sharelinkusage"
+	peerRESTMethodLoadTenants                 = "/loadtenants"
+	peerRESTMethodListAdminJobs               = "/adminjobs"
+	peerRESTMethodGetLocalTime                = "/localtime"
+	peerRESTMethodGossip                      = "/gossip"
+	peerRESTMethodGetRecentErrors             = "/recenterrors"
+	peerRESTMethodGetErasureSIMD              = "/erasuresimd"
+	peerRESTMethodGetMetaDivergences          = "/metadivergences"
+	peerRESTMethodGetRecentLogs               = "/recentlogs"
+	peerRESTMethodNetProbe                    = "/netprobe"
+	peerRESTMethodGetNetMonitor               = "/netmonitor"
 )
 
 const (
@@ -93,6 +109,7 @@ const (
 	peerRESTStorageClass = "storage-class"
 	peerRESTTypes        = "types"
 	peerRESTDisk         = "disk"
+	peerRESTCount        = "count"
 
 	peerRESTListenBucket = "bucket"
 	peerRESTListenPrefix = "prefix"
@@ -512,6 +512,7 @@ func (s *peerRESTServer) DeleteBucketMetadataHandler(w http.ResponseWriter, r *h
 	globalBucketTargetSys.Delete(bucketName)
 	globalEventNotifier.RemoveNotification(bucketName)
 	globalBucketConnStats.delete(bucketName)
+	globalSSEComplianceReporter.delete(bucketName)
 	if localMetacacheMgr != nil {
 		localMetacacheMgr.deleteBucketCache(bucketName)
 	}
@@ -1046,6 +1047,23 @@ func (s *peerRESTServer) ReloadPoolMetaHandler(w http.ResponseWriter, r *http.Re
 	}
 }
 
+// LoadTenantsHandler - reloads the tenants from the backend.
+func (s *peerRESTServer) LoadTenantsHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("invalid request"))
+		return
+	}
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		s.writeErrorResponse(w, errServerNotInitialized)
+		return
+	}
+	if err := globalTenantSys.Load(r.Context(), objAPI); err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+}
+
 func (s *peerRESTServer) LoadTransitionTierConfigHandler(w http.ResponseWriter, r *http.Request) {
 	if !s.IsValid(w, r) {
 		s.writeErrorResponse(w, errors.New("invalid request"))
@@ -1097,6 +1115,43 @@ func (s *peerRESTServer) ConsoleLogHandler(w http.ResponseWriter, r *http.Reques
 	}
 }
 
+// HealStatusStreamHandler sends local heal status updates to the peer rest client.
+func (s *peerRESTServer) HealStatusStreamHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("Invalid request"))
+		return
+	}
+
+	ch := make(chan pubsub.Maskable, 1000)
+	if err := globalHealStatusStream.Subscribe(pubsub.MaskAll, ch, r.Context().Done(), nil); err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+	keepAliveTicker := time.NewTicker(500 * time.Millisecond)
+	defer keepAliveTicker.Stop()
+
+	enc := gob.NewEncoder(w)
+	for {
+		select {
+		case entry := <-ch:
+			if err := enc.Encode(entry); err != nil {
+				return
+			}
+			if len(ch) == 0 {
+				// Flush if nothing is queued
+				w.(http.Flusher).Flush()
+			}
+		case <-keepAliveTicker.C:
+			if err := enc.Encode(&healStatusUpdate{}); err != nil {
+				return
+			}
+			w.(http.Flusher).Flush()
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
 func (s *peerRESTServer) writeErrorResponse(w http.ResponseWriter, err error) {
 	w.WriteHeader(http.StatusForbidden)
 	w.Write([]byte(err.Error()))
@@ -1104,6 +1159,7 @@ func (s *peerRESTServer) writeErrorResponse(w http.ResponseWriter, err error) {
 
 // IsValid - To authenticate and verify the time difference.
 func (s *peerRESTServer) IsValid(w http.ResponseWriter, r *http.Request) bool {
+	setPeerHandshakeHeaders(w)
 	if err := storageServerRequestValidate(r); err != nil {
 		s.writeErrorResponse(w, err)
 		return false
@@ -1216,6 +1272,217 @@ func (s *peerRESTServer) GetLastDayTierStatsHandler(w http.ResponseWriter, r *ht
 	logger.LogIf(ctx, gob.NewEncoder(w).Encode(result))
 }
 
+// GetAPIAnomaliesHandler - returns the API anomaly report of this server
+func (s *peerRESTServer) GetAPIAnomaliesHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("invalid request"))
+		return
+	}
+
+	ctx := newContext(r, w, "GetAPIAnomalies")
+	report := globalAPIAnomalyDetector.report(time.Now())
+	logger.LogIf(ctx, gob.NewEncoder(w).Encode(report))
+}
+
+// GetSSEComplianceReportHandler - returns the bucket encryption compliance report of this server
+func (s *peerRESTServer) GetSSEComplianceReportHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("invalid request"))
+		return
+	}
+
+	bucketName := mux.Vars(r)[peerRESTBucket]
+	if bucketName == "" {
+		s.writeErrorResponse(w, errors.New("Bucket name is missing"))
+		return
+	}
+
+	ctx := newContext(r, w, "GetSSEComplianceReport")
+	report := globalSSEComplianceReporter.report(bucketName)
+	logger.LogIf(ctx, gob.NewEncoder(w).Encode(report))
+}
+
+// GetSlowDirsHandler - returns the slow directories seen by this server
+func (s *peerRESTServer) GetSlowDirsHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("invalid request"))
+		return
+	}
+
+	ctx := newContext(r, w, "GetSlowDirs")
+	logger.LogIf(ctx, gob.NewEncoder(w).Encode(globalReadDirStats.report()))
+}
+
+// GetLocalTimeHandler - returns the current time of this server
+func (s *peerRESTServer) GetLocalTimeHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("invalid request"))
+		return
+	}
+
+	ctx := newContext(r, w, "GetLocalTime")
+	logger.LogIf(ctx, gob.NewEncoder(w).Encode(UTCNow()))
+}
+
+// GossipHandler - merges the membership view of the calling peer and
+// returns the view of this server.
+func (s *peerRESTServer) GossipHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("invalid request"))
+		return
+	}
+
+	var members []gossipMember
+	if err := gob.NewDecoder(r.Body).Decode(&members); err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+	globalGossip.merge(members, UTCNow())
+
+	ctx := newContext(r, w, "Gossip")
+	logger.LogIf(ctx, gob.NewEncoder(w).Encode(globalGossip.digest()))
+}
+
+// GetRecentErrorsHandler - returns the most recent errors logged by
+// this server
+func (s *peerRESTServer) GetRecentErrorsHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("invalid request"))
+		return
+	}
+
+	n, err := strconv.Atoi(r.Form.Get(peerRESTCount))
+	if err != nil || n <= 0 {
+		s.writeErrorResponse(w, errors.New("invalid error count"))
+		return
+	}
+
+	ctx := newContext(r, w, "GetRecentErrors")
+	logger.LogIf(ctx, gob.NewEncoder(w).Encode(localRecentErrors(n)))
+}
+
+// GetRecentLogsHandler - returns the most recent console log entries
+// of this server
+func (s *peerRESTServer) GetRecentLogsHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("invalid request"))
+		return
+	}
+
+	n, err := strconv.Atoi(r.Form.Get(peerRESTCount))
+	if err != nil || n <= 0 {
+		s.writeErrorResponse(w, errors.New("invalid log count"))
+		return
+	}
+
+	ctx := newContext(r, w, "GetRecentLogs")
+	logger.LogIf(ctx, gob.NewEncoder(w).Encode(localRecentLogs(n)))
+}
+
+// NetProbeHandler - discards the payload sent by the network monitor
+// of a peer
+func (s *peerRESTServer) NetProbeHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("invalid request"))
+		return
+	}
+
+	if _, err := io.Copy(io.Discard, r.Body); err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+}
+
+// GetNetMonitorHandler - returns the network quality samples this
+// server took to its peers
+func (s *peerRESTServer) GetNetMonitorHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("invalid request"))
+		return
+	}
+
+	window, err := time.ParseDuration(r.Form.Get(peerRESTDuration))
+	if err != nil || window < 0 {
+		s.writeErrorResponse(w, errors.New("invalid window"))
+		return
+	}
+
+	ctx := newContext(r, w, "GetNetMonitor")
+	logger.LogIf(ctx, gob.NewEncoder(w).Encode(localNetMonitor(window)))
+}
+
+// GetMetaDivergencesHandler - returns the metadata divergences counted
+// and logged by this server
+func (s *peerRESTServer) GetMetaDivergencesHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("invalid request"))
+		return
+	}
+
+	n, err := strconv.Atoi(r.Form.Get(peerRESTCount))
+	if err != nil || n <= 0 {
+		s.writeErrorResponse(w, errors.New("invalid divergence count"))
+		return
+	}
+
+	ctx := newContext(r, w, "GetMetaDivergences")
+	logger.LogIf(ctx, gob.NewEncoder(w).Encode(localMetaDivergences(n)))
+}
+
+// GetErasureSIMDHandler - returns the Reed-Solomon acceleration path
+// of this server
+func (s *peerRESTServer) GetErasureSIMDHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("invalid request"))
+		return
+	}
+
+	ctx := newContext(r, w, "GetErasureSIMD")
+	logger.LogIf(ctx, gob.NewEncoder(w).Encode(localErasureSIMD()))
+}
+
+// GetHotObjectsHandler - returns the top objects by requests and by
+// latency sampled by this server
+func (s *peerRESTServer) GetHotObjectsHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("invalid request"))
+		return
+	}
+
+	n, err := strconv.Atoi(r.Form.Get(peerRESTCount))
+	if err != nil || n <= 0 {
+		s.writeErrorResponse(w, errors.New("invalid object count"))
+		return
+	}
+
+	ctx := newContext(r, w, "GetHotObjects")
+	logger.LogIf(ctx, gob.NewEncoder(w).Encode(globalHotObjects.report(n, time.Now())))
+}
+
+// GetShareLinkUsageHandler - returns the bytes served per share link
+// by this server
+func (s *peerRESTServer) GetShareLinkUsageHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("invalid request"))
+		return
+	}
+
+	ctx := newContext(r, w, "GetShareLinkUsage")
+	logger.LogIf(ctx, gob.NewEncoder(w).Encode(globalShareLinkUsage.localUsage()))
+}
+
+// ListAdminJobsHandler - returns the running and finished admin jobs
+// of this server
+func (s *peerRESTServer) ListAdminJobsHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("invalid request"))
+		return
+	}
+
+	ctx := newContext(r, w, "ListAdminJobs")
+	logger.LogIf(ctx, gob.NewEncoder(w).Encode(globalAdminJobs.list()))
+}
+
 func (s *peerRESTServer) DriveSpeedTestHandler(w http.ResponseWriter, r *http.Request) {
 	if !s.IsValid(w, r) {
 		s.writeErrorResponse(w, errors.New("invalid request"))
@@ -1338,6 +1605,7 @@ func registerPeerRESTHandlers(router *mux.Router) {
 	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodListen).HandlerFunc(httpTraceHdrs(server.ListenHandler))
 	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodBackgroundHealStatus).HandlerFunc(server.BackgroundHealStatusHandler)
 	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodLog).HandlerFunc(server.ConsoleLogHandler)
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodHealStatusStream).HandlerFunc(server.HealStatusStreamHandler)
 	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodGetLocalDiskIDs).HandlerFunc(httpTraceHdrs(server.GetLocalDiskIDs))
 	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodGetBandwidth).HandlerFunc(httpTraceHdrs(server.GetBandwidth))
 	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodGetMetacacheListing).HandlerFunc(httpTraceHdrs(server.GetMetacacheListingHandler))
@@ -1351,4 +1619,19 @@ func registerPeerRESTHandlers(router *mux.Router) {
 	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodReloadSiteReplicationConfig).HandlerFunc(httpTraceHdrs(server.ReloadSiteReplicationConfigHandler))
 	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodReloadPoolMeta).HandlerFunc(httpTraceHdrs(server.ReloadPoolMetaHandler))
 	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodGetLastDayTierStats).HandlerFunc(httpTraceHdrs(server.GetLastDayTierStatsHandler))
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodGetAPIAnomalies).HandlerFunc(httpTraceHdrs(server.GetAPIAnomaliesHandler))
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodGetSSEComplianceReport).HandlerFunc(httpTraceHdrs(server.GetSSEComplianceReportHandler)).Queries(restQueries(peerRESTBucket)...)
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodGetSlowDirs).HandlerFunc(httpTraceHdrs(server.GetSlowDirsHandler))
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodGetHotObjects).HandlerFunc(httpTraceHdrs(server.GetHotObjectsHandler)).Queries(restQueries(peerRESTCount)...)
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodGetShareLinkUsage).HandlerFunc(httpTraceHdrs(server.GetShareLinkUsageHandler))
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodLoadTenants).HandlerFunc(httpTraceHdrs(server.LoadTenantsHandler))
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodListAdminJobs).HandlerFunc(httpTraceHdrs(server.ListAdminJobsHandler))
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodGetLocalTime).HandlerFunc(httpTraceHdrs(server.GetLocalTimeHandler))
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodGossip).HandlerFunc(httpTraceHdrs(server.GossipHandler))
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodGetRecentErrors).HandlerFunc(httpTraceHdrs(server.GetRecentErrorsHandler)).Queries(restQueries(peerRESTCount)...)
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodGetRecentLogs).HandlerFunc(httpTraceHdrs(server.GetRecentLogsHandler)).Queries(restQueries(peerRESTCount)...)
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodGetMetaDivergences).HandlerFunc(httpTraceHdrs(server.GetMetaDivergencesHandler)).Queries(restQueries(peerRESTCount)...)
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodGetErasureSIMD).HandlerFunc(httpTraceHdrs(server.GetErasureSIMDHandler))
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodNetProbe).HandlerFunc(httpTraceHdrs(server.NetProbeHandler))
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodGetNetMonitor).HandlerFunc(httpTraceHdrs(server.GetNetMonitorHandler)).Queries(restQueries(peerRESTDuration)...)
 }
@@ -246,6 +246,18 @@ func parsePostPolicyForm(r io.Reader) (PostPolicyForm, error) {
 	return parsedPolicy, nil
 }
 
+// isPostPolicyMetadataCond returns true if the condition applies
+// to a metadata field saved along with the object.
+func isPostPolicyMetadataCond(key string) bool {
+	key = strings.TrimPrefix(key, "$")
+	for _, header := range supportedHeaders {
+		if key == header {
+			return true
+		}
+	}
+	return false
+}
+
 // checkPolicyCond returns a boolean to indicate if a condition is satisified according
 // to the passed operator
 func checkPolicyCond(op string, input1, input2 string) bool {
@@ -303,8 +315,9 @@ func checkPostPolicy(formValues http.Header, postPolicyForm PostPolicyForm) erro
 			if !condPassed {
 				return fmt.Errorf("Invalid according to Policy: Policy Condition failed")
 			}
-		} else if strings.HasPrefix(policy.Key, "$x-amz-meta-") || strings.HasPrefix(policy.Key, "$x-amz-") {
-			// This covers all conditions X-Amz-Meta-* and X-Amz-*
+		} else if strings.HasPrefix(policy.Key, "$x-amz-") || isPostPolicyMetadataCond(policy.Key) {
+			// This covers all conditions X-Amz-Meta-*, X-Amz-* and
+			// the other user metadata such as Content-Language
 			// Check if policy condition is satisfied
 			condPassed = checkPolicyCond(op, formValues.Get(formCanonicalName), policy.Value)
 			if !condPassed {
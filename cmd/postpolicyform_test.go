@@ -158,3 +158,28 @@ func TestPostPolicyForm(t *testing.T) {
 		}
 	}
 }
+
+func TestPostPolicyMetadataCond(t *testing.T) {
+	policy := `{"expiration":"2100-01-01T00:00:00.000Z","conditions":[["starts-with","$content-language","en"],["starts-with","$x-amz-meta-team",""]]}`
+	postPolicyForm, err := parsePostPolicyForm(strings.NewReader(policy))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		language string
+		success  bool
+	}{
+		{language: "en-US", success: true},
+		{language: "de-DE", success: false},
+		{language: "", success: false},
+	}
+	for i, testCase := range testCases {
+		formValues := make(http.Header)
+		formValues.Set("Content-Language", testCase.language)
+		formValues.Set("X-Amz-Meta-Team", "storage")
+		if err = checkPostPolicy(formValues, postPolicyForm); (err == nil) != testCase.success {
+			t.Errorf("Test %d: expected success %t, got %v", i+1, testCase.success, err)
+		}
+	}
+}
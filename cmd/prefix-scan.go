@@ -0,0 +1,242 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/minio/madmin-go"
+	"github.com/qkbyte/minio/internal/bucket/lifecycle"
+	"github.com/qkbyte/minio/internal/logger"
+)
+
+const (
+	// Maximum number of prefix scans running at once on a node.
+	prefixScanMaxRunning = 4
+
+	// Finished prefix scans are reported for this long.
+	prefixScanRetention = 24 * time.Hour
+)
+
+// Status of a prefix scan.
+const (
+	prefixScanRunning  = "running"
+	prefixScanDone     = "done"
+	prefixScanFailed   = "failed"
+	prefixScanCanceled = "canceled"
+)
+
+var (
+	errPrefixScanNotFound = errors.New("prefix scan not found")
+	errPrefixScanTooMany  = errors.New("too many prefix scans running, retry later")
+)
+
+// prefixScanStatus - progress and outcome of an on-demand deep scan of
+// a bucket prefix.
+type prefixScanStatus struct {
+	ID       string    `json:"id"`
+	Bucket   string    `json:"bucket"`
+	Prefix   string    `json:"prefix,omitempty"`
+	Status   string    `json:"status"`
+	Error    string    `json:"error,omitempty"`
+	Started  time.Time `json:"started"`
+	Finished time.Time `json:"finished,omitempty"`
+	// Object last scanned.
+	LastObject string `json:"lastObject,omitempty"`
+
+	// Usage of the prefix, versions removed by lifecycle are not counted.
+	Objects       uint64 `json:"objects"`
+	Versions      uint64 `json:"versions"`
+	DeleteMarkers uint64 `json:"deleteMarkers"`
+	Size          int64  `json:"size"`
+
+	// Lifecycle actions applied.
+	Expired      uint64 `json:"expired"`
+	Transitioned uint64 `json:"transitioned"`
+
+	// Versions repaired by the deep heal and versions which failed to heal.
+	Healed     uint64 `json:"healed"`
+	HealFailed uint64 `json:"healFailed"`
+}
+
+type prefixScanJob struct {
+	mu     sync.Mutex
+	status prefixScanStatus
+	cancel context.CancelFunc
+}
+
+func (j *prefixScanJob) getStatus() prefixScanStatus {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.status
+}
+
+func (j *prefixScanJob) update(f func(s *prefixScanStatus)) {
+	j.mu.Lock()
+	f(&j.status)
+	j.mu.Unlock()
+}
+
+// prefixScans tracks the prefix scans started on this node.
+type prefixScans struct {
+	mu   sync.Mutex
+	jobs map[string]*prefixScanJob
+}
+
+var globalPrefixScans = &prefixScans{jobs: make(map[string]*prefixScanJob)}
+
+// start starts a deep scan of bucket/prefix unless one is already running,
+// the id of the running or started scan is returned.
+func (p *prefixScans) start(objAPI ObjectLayer, bucket, prefix string) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := UTCNow()
+	running := 0
+	for id, job := range p.jobs {
+		s := job.getStatus()
+		if s.Status == prefixScanRunning {
+			if s.Bucket == bucket && s.Prefix == prefix {
+				return id, nil
+			}
+			running++
+		} else if now.Sub(s.Finished) > prefixScanRetention {
+			delete(p.jobs, id)
+		}
+	}
+	if running >= prefixScanMaxRunning {
+		return "", errPrefixScanTooMany
+	}
+
+	ctx, cancel := context.WithCancel(GlobalContext)
+	job := &prefixScanJob{
+		status: prefixScanStatus{
+			ID:      mustGetUUID(),
+			Bucket:  bucket,
+			Prefix:  prefix,
+			Status:  prefixScanRunning,
+			Started: now,
+		},
+		cancel: cancel,
+	}
+	p.jobs[job.status.ID] = job
+	globalAdminJobs.register(adminJobPrefixScan, job.status.ID, now, job.jobState, job.cancelJob)
+	go job.run(ctx, objAPI)
+	return job.status.ID, nil
+}
+
+func (j *prefixScanJob) jobState() adminJobState {
+	s := j.getStatus()
+	return adminJobState{Status: s.Status, Error: s.Error, Finished: s.Finished, Detail: s}
+}
+
+func (j *prefixScanJob) cancelJob() error {
+	j.cancel()
+	return nil
+}
+
+func (p *prefixScans) get(id string) (*prefixScanJob, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	job, ok := p.jobs[id]
+	return job, ok
+}
+
+// run walks all versions under the prefix, accounts their usage, applies
+// the bucket lifecycle and deep heals them.
+func (j *prefixScanJob) run(ctx context.Context, objAPI ObjectLayer) {
+	defer j.cancel()
+
+	bucket, prefix := j.status.Bucket, j.status.Prefix
+	lc, _ := globalLifecycleSys.Get(bucket)
+	rcfg, _ := globalBucketObjectLockSys.Get(bucket)
+	healOpts := madmin.HealOpts{
+		Remove:   healDeleteDangling,
+		ScanMode: madmin.HealDeepScan,
+	}
+
+	results := make(chan ObjectInfo, 100)
+	if err := objAPI.Walk(ctx, bucket, prefix, results, ObjectOptions{}); err != nil {
+		j.finish(err)
+		return
+	}
+
+	for oi := range results {
+		res, err := objAPI.HealObject(ctx, bucket, oi.Name, oi.VersionID, healOpts)
+		if isErrObjectNotFound(err) || isErrVersionNotFound(err) {
+			// Removed since it was listed.
+			err = nil
+		}
+		if err != nil {
+			logger.LogIf(ctx, fmt.Errorf("prefix scan: unable to heal %s/%s (%s): %w", bucket, oi.Name, oi.VersionID, err))
+		}
+
+		var action lifecycle.Action
+		if lc != nil {
+			action = evalActionFromLifecycle(ctx, *lc, rcfg, oi, false)
+		}
+		applied := action != lifecycle.NoneAction && applyLifecycleAction(action, oi)
+		size, _ := oi.GetActualSize()
+
+		j.update(func(s *prefixScanStatus) {
+			s.LastObject = oi.Name
+			switch {
+			case err != nil:
+				s.HealFailed++
+			case healResultRepaired(res):
+				s.Healed++
+			}
+			switch {
+			case applied && (action == lifecycle.TransitionAction || action == lifecycle.TransitionVersionAction):
+				s.Transitioned++
+			case applied:
+				s.Expired++
+				return
+			}
+			if oi.DeleteMarker {
+				s.DeleteMarkers++
+				return
+			}
+			s.Versions++
+			if oi.IsLatest {
+				s.Objects++
+			}
+			s.Size += size
+		})
+	}
+	j.finish(ctx.Err())
+}
+
+func (j *prefixScanJob) finish(err error) {
+	j.update(func(s *prefixScanStatus) {
+		s.Finished = UTCNow()
+		switch {
+		case errors.Is(err, context.Canceled):
+			s.Status = prefixScanCanceled
+		case err != nil:
+			s.Status = prefixScanFailed
+			s.Error = err.Error()
+		default:
+			s.Status = prefixScanDone
+		}
+	})
+}
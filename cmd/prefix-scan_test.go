@@ -0,0 +1,109 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/qkbyte/minio/internal/config/storageclass"
+)
+
+func TestPrefixScan(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	resetGlobalHealState()
+	defer resetGlobalHealState()
+
+	fsDirs, err := getRandomDisks(16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+
+	objLayer, _, err := initObjectLayer(ctx, mustGetPoolEndpoints(fsDirs...))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bucket := getRandomBucketName()
+	if err = objLayer.MakeBucketWithLocation(ctx, bucket, MakeBucketOptions{}); err != nil {
+		t.Fatalf("Failed to make a bucket - %v", err)
+	}
+	setObjectLayer(objLayer)
+	defer setObjectLayer(nil)
+
+	defer func(sys *BucketMetadataSys) { globalBucketMetadataSys = sys }(globalBucketMetadataSys)
+	globalBucketMetadataSys = NewBucketMetadataSys()
+	globalBucketMetadataSys.Set(bucket, newBucketMetadata(bucket))
+
+	defer func(sc storageclass.Config) { globalStorageClass = sc }(globalStorageClass)
+	globalStorageClass = storageclass.Config{Standard: storageclass.StorageClass{Parity: 4}}
+
+	data := bytes.Repeat([]byte("a"), 1024)
+	for _, object := range []string{"data/a", "data/b", "data/c/d", "other/e"} {
+		if _, err = objLayer.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+			t.Fatalf("Failed to put %s - %v", object, err)
+		}
+	}
+
+	// Remove a copy of an object under the prefix.
+	disk := objLayer.(*erasureServerPools).serverPools[0].sets[0].getDisks()[0]
+	if err = disk.Delete(ctx, bucket, pathJoin("data/a", xlStorageFormatFile), DeleteOptions{}); err != nil {
+		t.Fatalf("Failed to delete a file - %v", err)
+	}
+
+	scans := &prefixScans{jobs: make(map[string]*prefixScanJob)}
+	id, err := scans.start(objLayer, bucket, "data/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	job, ok := scans.get(id)
+	if !ok {
+		t.Fatal("expected prefix scan to be tracked")
+	}
+
+	deadline := time.Now().Add(30 * time.Second)
+	for job.getStatus().Status == prefixScanRunning {
+		if time.Now().After(deadline) {
+			t.Fatal("prefix scan did not finish")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	s := job.getStatus()
+	if s.Status != prefixScanDone {
+		t.Fatalf("expected scan to be done, got %s (%s)", s.Status, s.Error)
+	}
+	if s.Objects != 3 || s.Versions != 3 || s.Size != 3*int64(len(data)) {
+		t.Fatalf("unexpected usage: %d objects, %d versions, %d bytes", s.Objects, s.Versions, s.Size)
+	}
+	if s.Healed != 1 || s.HealFailed != 0 {
+		t.Fatalf("expected 1 healed object, got %d healed and %d failed", s.Healed, s.HealFailed)
+	}
+	if _, err = disk.StatInfoFile(ctx, bucket, "data/a/"+xlStorageFormatFile, false); err != nil {
+		t.Fatalf("expected xl.meta to be healed - %v", err)
+	}
+
+	if _, ok = scans.get("unknown"); ok {
+		t.Fatal("expected unknown prefix scan not to be found")
+	}
+}
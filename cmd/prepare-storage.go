@@ -264,6 +264,118 @@ func connectLoadInitFormats(verboseLogging bool, firstDisk bool, endpoints Endpo
 	return storageDisks, format, nil
 }
 
+// storedDriveOrder returns the drive order a pool was formatted with,
+// either endpoints or spread, based on the positions recorded in the
+// format.json of its drives. A nil order is returned when a quorum of
+// drives is unformatted, ok is false until enough drives are online.
+func storedDriveOrder(endpoints, spread Endpoints) (order Endpoints, ok bool, err error) {
+	storageDisks, _ := initStorageDisksWithErrors(endpoints)
+	defer closeStorageDisks(storageDisks...)
+
+	formats, sErrs := loadFormatErasureAll(storageDisks, false)
+	return driveOrderFromFormats(endpoints, spread, formats, sErrs)
+}
+
+// driveOrderFromFormats returns the drive order recorded in the formats
+// of the drives of endpoints. An order is returned once a quorum of the
+// drives of the deployment agree on it, drives holding the same position
+// in both orders do not tell them apart. An error is returned when the
+// drives disagree.
+func driveOrderFromFormats(endpoints, spread Endpoints, formats []*formatErasureV3, errs []error) (order Endpoints, ok bool, err error) {
+	quorum := len(endpoints)/2 + 1
+
+	// Only the formats of the deployment most drives belong to count.
+	ids := make(map[string]int)
+	var id string
+	for _, format := range formats {
+		if format == nil {
+			continue
+		}
+		if ids[format.ID]++; ids[format.ID] > ids[id] {
+			id = format.ID
+		}
+	}
+
+	sameOrder := true
+	for i := range endpoints {
+		if endpoints[i].String() != spread[i].String() {
+			sameOrder = false
+			break
+		}
+	}
+
+	var unformatted, inOrder, inSpread, onlyOrder, onlySpread int
+	for i, format := range formats {
+		if format == nil {
+			if errors.Is(errs[i], errUnformattedDisk) {
+				unformatted++
+			}
+			continue
+		}
+		if format.ID != id {
+			continue
+		}
+		m, n, err := findDiskIndexByDiskID(format, format.Erasure.This)
+		if err != nil {
+			continue
+		}
+		idx := m*len(format.Erasure.Sets[0]) + n
+		if idx >= len(endpoints) {
+			continue
+		}
+		o := endpoints[idx].String() == endpoints[i].String()
+		s := spread[idx].String() == endpoints[i].String()
+		if o {
+			inOrder++
+		}
+		if s {
+			inSpread++
+		}
+		switch {
+		case o && !s:
+			onlyOrder++
+		case s && !o:
+			onlySpread++
+		}
+	}
+
+	switch {
+	case onlyOrder > 0 && onlySpread > 0:
+		return nil, false, fmt.Errorf("drives disagree on the drive order the pool was formatted with, %d drives are in the given order and %d spread across failure domains", onlyOrder, onlySpread)
+	case inOrder >= quorum && (onlyOrder > 0 || sameOrder):
+		return endpoints, true, nil
+	case inSpread >= quorum && onlySpread > 0:
+		return spread, true, nil
+	case unformatted >= quorum:
+		return nil, true, nil
+	}
+	return nil, false, nil
+}
+
+// waitForStoredDriveOrder waits for enough drives of a pool to be online
+// to tell the drive order the pool was formatted with, nil is returned
+// for pools that are not formatted yet.
+func waitForStoredDriveOrder(endpoints, spread Endpoints) (Endpoints, error) {
+	ticker := time.NewTicker(150 * time.Millisecond)
+	defer ticker.Stop()
+
+	var tries int
+	for {
+		order, ok, err := storedDriveOrder(endpoints, spread)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return order, nil
+		}
+		tries++
+		if tries%10 == 0 {
+			logger.Info("Waiting for a minimum of %d drives to come online to verify the drive order", len(endpoints)/2+1)
+		}
+		<-ticker.C
+	}
+}
+
 // Format disks before initialization of object layer.
 func waitForFormatErasure(firstDisk bool, endpoints Endpoints, poolCount, setCount, setDriveCount int, deploymentID, distributionAlgo string) ([]StorageAPI, *formatErasureV3, error) {
 	if len(endpoints) == 0 || setCount == 0 || setDriveCount == 0 {
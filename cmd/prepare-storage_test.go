@@ -0,0 +1,99 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+)
+
+func TestDriveOrderFromFormats(t *testing.T) {
+	var endpoints Endpoints
+	for i := 1; i <= 2; i++ {
+		for d := 1; d <= 4; d++ {
+			u, err := url.Parse(fmt.Sprintf("http://host%d:9000/disk%d", i, d))
+			if err != nil {
+				t.Fatal(err)
+			}
+			endpoints = append(endpoints, Endpoint{URL: u})
+		}
+	}
+	// Drives 0 and 7 hold the same position in both orders.
+	spread := Endpoints{endpoints[0], endpoints[4], endpoints[1], endpoints[5], endpoints[2], endpoints[6], endpoints[3], endpoints[7]}
+
+	ref := newFormatErasureV3(1, len(endpoints))
+	// formatted returns the formats of the given drives formatted in order.
+	formatted := func(order Endpoints, drives ...int) []*formatErasureV3 {
+		formats := make([]*formatErasureV3, len(endpoints))
+		for _, i := range drives {
+			for pos, ep := range order {
+				if ep.String() == endpoints[i].String() {
+					formats[i] = ref.Clone()
+					formats[i].Erasure.This = ref.Erasure.Sets[0][pos]
+				}
+			}
+		}
+		return formats
+	}
+	errs := make([]error, len(endpoints))
+
+	testCases := []struct {
+		formats []*formatErasureV3
+		order   Endpoints
+		ok      bool
+		err     bool
+	}{
+		// Less than a quorum of drives online.
+		{formatted(endpoints, 1, 2, 3), nil, false, false},
+		{formatted(endpoints, 1, 2, 3, 4, 5), endpoints, true, false},
+		{formatted(spread, 1, 2, 3, 4, 5), spread, true, false},
+		// Drives at the same position in both orders are a tie.
+		{formatted(spread, 0, 7), nil, false, false},
+		// Drives disagreeing on the order.
+		{append(formatted(endpoints, 1, 2, 3)[:4], formatted(spread, 4, 5, 6, 7)[4:]...), nil, false, true},
+	}
+	for i, tc := range testCases {
+		order, ok, err := driveOrderFromFormats(endpoints, spread, tc.formats, errs)
+		if (err != nil) != tc.err || ok != tc.ok {
+			t.Fatalf("Test %d: expected ok %v, error %v, got %v, %v", i+1, tc.ok, tc.err, ok, err)
+		}
+		if len(order) != len(tc.order) || (order != nil && order[1].String() != tc.order[1].String()) {
+			t.Fatalf("Test %d: unexpected order %v", i+1, order)
+		}
+	}
+
+	// Formats of another deployment do not count.
+	formats := formatted(endpoints, 1, 2, 3, 4, 5)
+	other := newFormatErasureV3(1, len(endpoints))
+	for _, i := range []int{1, 2} {
+		formats[i] = other.Clone()
+		formats[i].Erasure.This = other.Erasure.Sets[0][i]
+	}
+	if _, ok, err := driveOrderFromFormats(endpoints, spread, formats, errs); ok || err != nil {
+		t.Fatalf("expected no quorum, got %v, %v", ok, err)
+	}
+
+	// A pool mostly unformatted is not formatted yet.
+	for i := range errs {
+		errs[i] = errUnformattedDisk
+	}
+	if order, ok, err := driveOrderFromFormats(endpoints, spread, make([]*formatErasureV3, len(endpoints)), errs); !ok || order != nil || err != nil {
+		t.Fatalf("expected an unformatted pool, got %v, %v, %v", order, ok, err)
+	}
+}
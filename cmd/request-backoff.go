@@ -0,0 +1,194 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// Bounds of the Retry-After hint sent with throttled requests.
+	minRetryAfter = time.Second
+	maxRetryAfter = 120 * time.Second
+
+	// Clients retrying this many times before their Retry-After expired
+	// are rejected without queueing until their penalty expires.
+	penaltyStrikes = 2
+
+	// Penalty entries are swept once the table grows beyond this size.
+	maxPenaltyEntries = 10000
+)
+
+// requestBackoff computes the Retry-After hints of requests throttled
+// by maxClients from the queue depth and the rate at which the queue is
+// drained, and keeps clients ignoring those hints in a penalty box.
+type requestBackoff struct {
+	completed uint64 // requests that left the requests pool.
+	throttled uint64 // requests rejected because the queue was full.
+	penalized uint64 // requests rejected from the penalty box.
+	entries   int32  // number of clients in the penalty box.
+
+	mu       sync.Mutex
+	rate     float64 // EWMA of requests drained per second.
+	lastTick time.Time
+	lastDone uint64
+	clients  map[string]*clientBackoff
+}
+
+// clientBackoff tracks the Retry-After last sent to a client.
+type clientBackoff struct {
+	strikes int
+	wait    time.Duration
+	until   time.Time
+}
+
+var globalRequestBackoff = newRequestBackoff()
+
+func newRequestBackoff() *requestBackoff {
+	return &requestBackoff{
+		lastTick: time.Now(),
+		clients:  make(map[string]*clientBackoff),
+	}
+}
+
+// done must be called when a request leaves the requests pool.
+func (b *requestBackoff) done() {
+	atomic.AddUint64(&b.completed, 1)
+}
+
+// drainRate returns the number of requests drained per second, callers
+// must hold the lock.
+func (b *requestBackoff) drainRate(now time.Time) float64 {
+	elapsed := now.Sub(b.lastTick).Seconds()
+	if elapsed < 1 {
+		return b.rate
+	}
+	completed := atomic.LoadUint64(&b.completed)
+	rate := float64(completed-b.lastDone) / elapsed
+	if b.rate == 0 {
+		b.rate = rate
+	} else {
+		b.rate = 0.7*b.rate + 0.3*rate
+	}
+	b.lastTick, b.lastDone = now, completed
+	return b.rate
+}
+
+// retryAfter returns the time it takes to drain queued requests, if the
+// drain rate is unknown the time a request waits in the queue is used.
+func (b *requestBackoff) retryAfter(now time.Time, queued int, deadline time.Duration) time.Duration {
+	wait := deadline
+	if rate := b.drainRate(now); rate > 0 {
+		wait = time.Duration(float64(queued) / rate * float64(time.Second))
+	}
+	return clampRetryAfter(wait)
+}
+
+func clampRetryAfter(wait time.Duration) time.Duration {
+	if wait < minRetryAfter {
+		return minRetryAfter
+	}
+	if wait > maxRetryAfter {
+		return maxRetryAfter
+	}
+	return wait.Round(time.Second)
+}
+
+// backoffClient returns the client r is accounted to in the penalty box,
+// the peer address of the connection since forwarded headers can be set
+// by any client to evade the penalty or to lock others out.
+func backoffClient(r *http.Request) string {
+	return getRemoteHost(r)
+}
+
+// reject records that a request of client was throttled with queued
+// requests waiting and returns the Retry-After hint to send.
+func (b *requestBackoff) reject(client string, queued int, deadline time.Duration) time.Duration {
+	atomic.AddUint64(&b.throttled, 1)
+
+	now := time.Now()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	wait := b.retryAfter(now, queued, deadline)
+	if cb, ok := b.clients[client]; ok {
+		if wait < cb.wait {
+			wait = cb.wait
+		}
+		cb.wait, cb.until = wait, now.Add(wait)
+		return wait
+	}
+	if len(b.clients) >= maxPenaltyEntries {
+		b.sweep(now)
+	}
+	b.clients[client] = &clientBackoff{wait: wait, until: now.Add(wait)}
+	atomic.StoreInt32(&b.entries, int32(len(b.clients)))
+	return wait
+}
+
+// admit returns false and the Retry-After hint to send if client is in
+// the penalty box. Retrying before the last hint expired doubles it.
+func (b *requestBackoff) admit(client string) (time.Duration, bool) {
+	if atomic.LoadInt32(&b.entries) == 0 {
+		return 0, true
+	}
+
+	now := time.Now()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	cb, ok := b.clients[client]
+	if !ok {
+		return 0, true
+	}
+	if !now.Before(cb.until) {
+		// Client honored the backoff.
+		delete(b.clients, client)
+		atomic.StoreInt32(&b.entries, int32(len(b.clients)))
+		return 0, true
+	}
+
+	cb.strikes++
+	if cb.strikes < penaltyStrikes {
+		return 0, true
+	}
+	cb.wait = clampRetryAfter(time.Duration(math.Min(float64(cb.wait)*2, float64(maxRetryAfter))))
+	cb.until = now.Add(cb.wait)
+	atomic.AddUint64(&b.penalized, 1)
+	return cb.wait, false
+}
+
+// sweep removes the clients whose penalty expired, callers must hold
+// the lock.
+func (b *requestBackoff) sweep(now time.Time) {
+	for client, cb := range b.clients {
+		if !now.Before(cb.until) {
+			delete(b.clients, client)
+		}
+	}
+}
+
+// retryAfterHeader formats wait as the value of a Retry-After header.
+func retryAfterHeader(wait time.Duration) string {
+	return strconv.Itoa(int(wait / time.Second))
+}
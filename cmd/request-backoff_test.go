@@ -0,0 +1,98 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRequestBackoffRetryAfter(t *testing.T) {
+	b := newRequestBackoff()
+	now := b.lastTick
+
+	// Drain rate is unknown, the queue deadline is used.
+	if wait := b.retryAfter(now, 100, 10*time.Second); wait != 10*time.Second {
+		t.Fatalf("expected 10s, got %s", wait)
+	}
+
+	// 50 requests drained in 2s, 100 queued requests take 4s.
+	b.completed = 50
+	if wait := b.retryAfter(now.Add(2*time.Second), 100, 10*time.Second); wait != 4*time.Second {
+		t.Fatalf("expected 4s, got %s", wait)
+	}
+
+	if wait := clampRetryAfter(time.Millisecond); wait != minRetryAfter {
+		t.Fatalf("expected %s, got %s", minRetryAfter, wait)
+	}
+	if wait := clampRetryAfter(time.Hour); wait != maxRetryAfter {
+		t.Fatalf("expected %s, got %s", maxRetryAfter, wait)
+	}
+}
+
+func TestRequestBackoffPenaltyBox(t *testing.T) {
+	b := newRequestBackoff()
+
+	if _, ok := b.admit("client"); !ok {
+		t.Fatal("expected client to be admitted")
+	}
+
+	wait := b.reject("client", 10, 5*time.Second)
+	if wait != 5*time.Second {
+		t.Fatalf("expected 5s, got %s", wait)
+	}
+
+	// A single early retry is tolerated.
+	if _, ok := b.admit("client"); !ok {
+		t.Fatal("expected first early retry to be admitted")
+	}
+	penalty, ok := b.admit("client")
+	if ok {
+		t.Fatal("expected client to be penalized")
+	}
+	if penalty != 2*wait {
+		t.Fatalf("expected penalty of %s, got %s", 2*wait, penalty)
+	}
+	if _, ok = b.admit("other"); !ok {
+		t.Fatal("expected other clients to be admitted")
+	}
+
+	// Once the penalty expired the client is admitted again.
+	b.clients["client"].until = time.Now().Add(-time.Second)
+	if _, ok = b.admit("client"); !ok {
+		t.Fatal("expected client to be admitted after its penalty expired")
+	}
+	if len(b.clients) != 0 || b.entries != 0 {
+		t.Fatalf("expected empty penalty box, got %d entries", len(b.clients))
+	}
+	if b.throttled != 1 || b.penalized != 1 {
+		t.Fatalf("unexpected counters throttled=%d penalized=%d", b.throttled, b.penalized)
+	}
+}
+
+func TestBackoffClientIgnoresForwardedHeaders(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/bucket/object", nil)
+	r.RemoteAddr = "10.0.0.1:54321"
+	r.Header.Set("X-Forwarded-For", "192.168.1.1")
+	r.Header.Set("X-Real-Ip", "192.168.1.2")
+	if client := backoffClient(r); client != "10.0.0.1" {
+		t.Fatalf("expected the peer address, got %s", client)
+	}
+}
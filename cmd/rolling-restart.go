@@ -0,0 +1,426 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/minio/madmin-go"
+	iampolicy "github.com/minio/pkg/iam/policy"
+	"github.com/qkbyte/minio/internal/logger"
+)
+
+const (
+	// rollingRestartPollInterval is the interval between two checks of a
+	// restarting node and of the cluster health.
+	rollingRestartPollInterval = 2 * time.Second
+
+	// rollingRestartStepTimeout bounds the time a step may take until the
+	// restarted nodes are back and the cluster is healthy again.
+	rollingRestartStepTimeout = 10 * time.Minute
+)
+
+// States of a rolling restart and of its steps.
+const (
+	rollingRestartPending   = "pending"
+	rollingRestartRunning   = "running"
+	rollingRestartCompleted = "completed"
+	rollingRestartFailed    = "failed"
+	rollingRestartAborted   = "aborted"
+)
+
+var (
+	errRollingRestartInProgress = errors.New("a rolling restart is already in progress")
+	errNoRollingRestart         = errors.New("no rolling restart was started on this node")
+	errNoRollingRestartRunning  = errors.New("no rolling restart is running")
+)
+
+// rollingRestartStep restarts nodes which share no erasure set.
+type rollingRestartStep struct {
+	Nodes   []string  `json:"nodes"`
+	State   string    `json:"state"`
+	Started time.Time `json:"started,omitempty"`
+	Ended   time.Time `json:"ended,omitempty"`
+	Error   string    `json:"error,omitempty"`
+}
+
+// rollingRestartStatus is the progress of a rolling restart, tracked by
+// the node orchestrating it.
+type rollingRestartStatus struct {
+	Reason  string               `json:"reason"`
+	State   string               `json:"state"`
+	Started time.Time            `json:"started"`
+	Ended   time.Time            `json:"ended,omitempty"`
+	Steps   []rollingRestartStep `json:"steps"`
+}
+
+// planRollingRestart groups the nodes of the deployment into restart
+// steps. Nodes of a step never share an erasure set, so that a single
+// node per erasure set is down at any time, nodes of different pools are
+// restarted together. The local node is restarted last, on its own.
+func planRollingRestart(endpointServerPools EndpointServerPools, local string) [][]string {
+	var nodes []string
+	nodeSets := make(map[string]map[string]struct{})
+	for poolIdx, ep := range endpointServerPools {
+		drivesPerSet := ep.DrivesPerSet
+		if drivesPerSet <= 0 {
+			drivesPerSet = len(ep.Endpoints)
+		}
+		for i, endpoint := range ep.Endpoints {
+			node := endpoint.Host
+			if node == "" {
+				continue
+			}
+			sets, ok := nodeSets[node]
+			if !ok {
+				sets = make(map[string]struct{})
+				nodeSets[node] = sets
+				nodes = append(nodes, node)
+			}
+			sets[fmt.Sprintf("%d/%d", poolIdx, i/drivesPerSet)] = struct{}{}
+		}
+	}
+
+	var steps [][]string
+	var stepSets []map[string]struct{}
+	for _, node := range nodes {
+		if node == local {
+			continue
+		}
+		placed := false
+		for i := range steps {
+			overlaps := false
+			for set := range nodeSets[node] {
+				if _, ok := stepSets[i][set]; ok {
+					overlaps = true
+					break
+				}
+			}
+			if overlaps {
+				continue
+			}
+			steps[i] = append(steps[i], node)
+			for set := range nodeSets[node] {
+				stepSets[i][set] = struct{}{}
+			}
+			placed = true
+			break
+		}
+		if !placed {
+			sets := make(map[string]struct{}, len(nodeSets[node]))
+			for set := range nodeSets[node] {
+				sets[set] = struct{}{}
+			}
+			steps = append(steps, []string{node})
+			stepSets = append(stepSets, sets)
+		}
+	}
+	if _, ok := nodeSets[local]; ok {
+		steps = append(steps, []string{local})
+	}
+	return steps
+}
+
+// rollingRestart orchestrates restarting the nodes of the deployment
+// one step at a time, at most one rolling restart runs at any time.
+type rollingRestart struct {
+	mu     sync.Mutex
+	status *rollingRestartStatus
+	cancel context.CancelFunc
+}
+
+// prepare plans a rolling restart and reserves it until it is started
+// or released, conflicts are reported before the deployment is changed.
+func (rr *rollingRestart) prepare(reason string) error {
+	rr.mu.Lock()
+	defer rr.mu.Unlock()
+
+	if rr.status != nil && (rr.status.State == rollingRestartPending || rr.status.State == rollingRestartRunning) {
+		return errRollingRestartInProgress
+	}
+
+	status := &rollingRestartStatus{
+		Reason:  reason,
+		State:   rollingRestartPending,
+		Started: UTCNow(),
+	}
+	for _, nodes := range planRollingRestart(globalEndpoints, globalLocalNodeName) {
+		status.Steps = append(status.Steps, rollingRestartStep{Nodes: nodes, State: rollingRestartPending})
+	}
+	rr.status = status
+	return nil
+}
+
+// start starts the prepared rolling restart in the background.
+func (rr *rollingRestart) start(objAPI ObjectLayer) rollingRestartStatus {
+	rr.mu.Lock()
+	defer rr.mu.Unlock()
+
+	rr.status.State = rollingRestartRunning
+	ctx, cancel := context.WithCancel(GlobalContext)
+	rr.cancel = cancel
+	go rr.run(ctx, objAPI)
+
+	return rr.copyStatus()
+}
+
+// release gives up the prepared rolling restart if it was not started.
+func (rr *rollingRestart) release() {
+	rr.mu.Lock()
+	defer rr.mu.Unlock()
+
+	if rr.status != nil && rr.status.State == rollingRestartPending {
+		rr.status.State = rollingRestartAborted
+		rr.status.Ended = UTCNow()
+	}
+}
+
+// abort stops the rolling restart before its next step, nodes being
+// restarted are still waited for.
+func (rr *rollingRestart) abort() bool {
+	rr.mu.Lock()
+	defer rr.mu.Unlock()
+
+	if rr.status == nil || rr.status.State != rollingRestartRunning {
+		return false
+	}
+	rr.cancel()
+	return true
+}
+
+// getStatus returns the progress of the last rolling restart.
+func (rr *rollingRestart) getStatus() (rollingRestartStatus, bool) {
+	rr.mu.Lock()
+	defer rr.mu.Unlock()
+
+	if rr.status == nil {
+		return rollingRestartStatus{}, false
+	}
+	return rr.copyStatus(), true
+}
+
+func (rr *rollingRestart) copyStatus() rollingRestartStatus {
+	status := *rr.status
+	status.Steps = make([]rollingRestartStep, len(rr.status.Steps))
+	copy(status.Steps, rr.status.Steps)
+	return status
+}
+
+// updateStep updates the state of step i, and of the rolling restart
+// once it ended.
+func (rr *rollingRestart) updateStep(i int, state string, err error) {
+	rr.mu.Lock()
+	defer rr.mu.Unlock()
+
+	step := &rr.status.Steps[i]
+	step.State = state
+	switch state {
+	case rollingRestartRunning:
+		step.Started = UTCNow()
+	default:
+		step.Ended = UTCNow()
+	}
+	if err != nil {
+		step.Error = err.Error()
+	}
+}
+
+func (rr *rollingRestart) finish(state string) {
+	rr.mu.Lock()
+	defer rr.mu.Unlock()
+
+	rr.status.State = state
+	rr.status.Ended = UTCNow()
+	rr.cancel()
+}
+
+func (rr *rollingRestart) run(ctx context.Context, objAPI ObjectLayer) {
+	status, _ := rr.getStatus()
+	for i, step := range status.Steps {
+		if ctx.Err() != nil {
+			rr.finish(rollingRestartAborted)
+			return
+		}
+
+		if len(step.Nodes) == 1 && step.Nodes[0] == globalLocalNodeName {
+			// The local node goes last, the rolling restart is complete
+			// as soon as it restarts.
+			rr.updateStep(i, rollingRestartCompleted, nil)
+			rr.finish(rollingRestartCompleted)
+			globalServiceSignalCh <- serviceRestart
+			return
+		}
+
+		rr.updateStep(i, rollingRestartRunning, nil)
+		if err := restartNodesAndWait(objAPI, step.Nodes); err != nil {
+			logger.LogIf(GlobalContext, fmt.Errorf("rolling restart stopped: %w", err))
+			rr.updateStep(i, rollingRestartFailed, err)
+			rr.finish(rollingRestartFailed)
+			return
+		}
+		rr.updateStep(i, rollingRestartCompleted, nil)
+	}
+	rr.finish(rollingRestartCompleted)
+}
+
+// restartNodesAndWait restarts the nodes and waits until all of them run
+// again and the cluster regained write quorum on all erasure sets.
+func restartNodesAndWait(objAPI ObjectLayer, nodes []string) error {
+	ctx, cancel := context.WithTimeout(GlobalContext, rollingRestartStepTimeout)
+	defer cancel()
+
+	clients := make(map[string]*peerRESTClient, len(nodes))
+	for _, client := range globalNotificationSys.peerClients {
+		if client != nil {
+			clients[client.host.String()] = client
+		}
+	}
+
+	errs := make([]error, len(nodes))
+	var wg sync.WaitGroup
+	for i, node := range nodes {
+		client, ok := clients[node]
+		if !ok {
+			return fmt.Errorf("no peer connection to %s", node)
+		}
+		wg.Add(1)
+		go func(i int, node string, client *peerRESTClient) {
+			defer wg.Done()
+			if errs[i] = restartPeerAndWait(ctx, client); errs[i] != nil {
+				errs[i] = fmt.Errorf("%s: %w", node, errs[i])
+			}
+		}(i, node, client)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	for {
+		if objAPI.Health(ctx, HealthOptions{}).Healthy {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return errors.New("cluster did not regain write quorum in time")
+		case <-time.After(rollingRestartPollInterval):
+		}
+	}
+}
+
+// restartPeerAndWait restarts a peer and waits until it is back, which
+// is detected by an uptime lower than the time since the restart.
+func restartPeerAndWait(ctx context.Context, client *peerRESTClient) error {
+	restarted := time.Now()
+	if err := client.SignalService(serviceRestart, ""); err != nil {
+		return err
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return errors.New("node did not come back in time")
+		case <-time.After(rollingRestartPollInterval):
+		}
+		info, err := client.ServerInfo()
+		if err == nil && info.State == string(madmin.ItemOnline) &&
+			time.Duration(info.Uptime)*time.Second <= time.Since(restarted) {
+			return nil
+		}
+	}
+}
+
+// prepareRollingRestart prepares a rolling restart, replying with a
+// conflict if one is already in progress.
+func prepareRollingRestart(ctx context.Context, w http.ResponseWriter, r *http.Request, reason string) bool {
+	if err := globalRollingRestart.prepare(reason); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, AdminError{
+			Code:       "XMinioAdminRollingRestartInProgress",
+			Message:    err.Error(),
+			StatusCode: http.StatusConflict,
+		}), r.URL)
+		return false
+	}
+	return true
+}
+
+// startRollingRestart starts the prepared rolling restart and replies
+// with its plan.
+func startRollingRestart(ctx context.Context, w http.ResponseWriter, r *http.Request, objAPI ObjectLayer) {
+	data, err := json.Marshal(globalRollingRestart.start(objAPI))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, data)
+}
+
+// RollingRestartStatusHandler - GET /minio/admin/v3/rolling-restart/status
+// ----------
+// Returns the progress of the last rolling restart orchestrated by this
+// node.
+func (a adminAPIHandlers) RollingRestartStatusHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "RollingRestartStatus")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServerInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	status, ok := globalRollingRestart.getStatus()
+	if !ok {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrInvalidRequest, errNoRollingRestart), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(status)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, data)
+}
+
+// RollingRestartAbortHandler - POST /minio/admin/v3/rolling-restart/abort
+// ----------
+// Stops the running rolling restart before its next step.
+func (a adminAPIHandlers) RollingRestartAbortHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "RollingRestartAbort")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServiceRestartAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	if !globalRollingRestart.abort() {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrInvalidRequest, errNoRollingRestartRunning), r.URL)
+		return
+	}
+	writeSuccessNoContent(w)
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestPlanRollingRestart(t *testing.T) {
+	newPool := func(setCount, drivesPerSet int, nodes ...string) PoolEndpoints {
+		ep := PoolEndpoints{SetCount: setCount, DrivesPerSet: drivesPerSet}
+		for drive := 0; len(ep.Endpoints) < setCount*drivesPerSet; drive++ {
+			for _, node := range nodes {
+				ep.Endpoints = append(ep.Endpoints, Endpoint{URL: &url.URL{
+					Scheme: "http",
+					Host:   node,
+					Path:   fmt.Sprintf("/data%d", drive),
+				}})
+			}
+		}
+		return ep
+	}
+
+	testCases := []struct {
+		pools    EndpointServerPools
+		local    string
+		expected [][]string
+	}{
+		// (1) Single pool, every node is part of the only erasure set.
+		{
+			pools:    EndpointServerPools{newPool(1, 8, "node1:9000", "node2:9000", "node3:9000", "node4:9000")},
+			local:    "node2:9000",
+			expected: [][]string{{"node1:9000"}, {"node3:9000"}, {"node4:9000"}, {"node2:9000"}},
+		},
+		// (2) Nodes of different pools are restarted together.
+		{
+			pools: EndpointServerPools{
+				newPool(1, 8, "node1:9000", "node2:9000", "node3:9000", "node4:9000"),
+				newPool(1, 4, "node5:9000", "node6:9000"),
+			},
+			local:    "node1:9000",
+			expected: [][]string{{"node2:9000", "node5:9000"}, {"node3:9000", "node6:9000"}, {"node4:9000"}, {"node1:9000"}},
+		},
+		// (3) Erasure sets spanning half of the nodes each.
+		{
+			pools: EndpointServerPools{
+				{
+					SetCount:     2,
+					DrivesPerSet: 2,
+					Endpoints: Endpoints{
+						{URL: &url.URL{Host: "node1:9000", Path: "/data"}},
+						{URL: &url.URL{Host: "node2:9000", Path: "/data"}},
+						{URL: &url.URL{Host: "node3:9000", Path: "/data"}},
+						{URL: &url.URL{Host: "node4:9000", Path: "/data"}},
+					},
+				},
+			},
+			local:    "node4:9000",
+			expected: [][]string{{"node1:9000", "node3:9000"}, {"node2:9000"}, {"node4:9000"}},
+		},
+	}
+
+	for i, testCase := range testCases {
+		steps := planRollingRestart(testCase.pools, testCase.local)
+		if !reflect.DeepEqual(steps, testCase.expected) {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, steps)
+		}
+	}
+}
+
+func TestRollingRestartAbort(t *testing.T) {
+	rr := &rollingRestart{}
+	if _, ok := rr.getStatus(); ok {
+		t.Fatal("expected no status before any rolling restart")
+	}
+	if rr.abort() {
+		t.Fatal("expected abort to fail without a running rolling restart")
+	}
+}
+
+func TestRollingRestartPrepare(t *testing.T) {
+	rr := &rollingRestart{}
+	if err := rr.prepare("update"); err != nil {
+		t.Fatal(err)
+	}
+	if err := rr.prepare("update"); err != errRollingRestartInProgress {
+		t.Fatalf("expected a prepared rolling restart to conflict, got %v", err)
+	}
+	rr.release()
+	if status, _ := rr.getStatus(); status.State != rollingRestartAborted {
+		t.Fatalf("expected released rolling restart to be aborted, got %s", status.State)
+	}
+	if err := rr.prepare("update"); err != nil {
+		t.Fatalf("expected a rolling restart to be prepared after release, got %v", err)
+	}
+}
@@ -61,6 +61,14 @@ var globalHandlers = []mux.MiddlewareFunc{
 	setRequestValidityHandler,
 	// set x-amz-request-id header.
 	addCustomHeaders,
+	// Reject writes on read-only site replication followers
+	setSiteReplicationReadOnlyHandler,
+	// Reject requests to suspended buckets
+	setBucketSuspendHandler,
+	// Enforce and account requester pays buckets
+	setRequesterPaysHandler,
+	// Throttle and account bucket share link downloads
+	setShareLinkHandler,
 	// Add bucket forwarding handler
 	setBucketForwardingHandler,
 	// Add new handlers here.
@@ -92,6 +100,9 @@ func configureServerHandler(endpointServerPools EndpointServerPools) (http.Handl
 	// Add KMS router
 	registerKMSRouter(router)
 
+	// Add SCIM provisioning router
+	registerSCIMRouter(router)
+
 	// Add API router
 	registerAPIRouter(router)
 
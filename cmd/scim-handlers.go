@@ -0,0 +1,1020 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/minio/madmin-go"
+	"github.com/minio/minio-go/v7/pkg/set"
+	"github.com/qkbyte/minio/internal/auth"
+	xhttp "github.com/qkbyte/minio/internal/http"
+	"github.com/qkbyte/minio/internal/logger"
+)
+
+const (
+	scimSchemaUser                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	scimSchemaGroup                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	scimSchemaResourceType          = "urn:ietf:params:scim:schemas:core:2.0:ResourceType"
+	scimSchemaServiceProviderConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	scimSchemaListResponse          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	scimSchemaPatchOp               = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	scimSchemaError                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+
+	mimeSCIM mimeType = "application/scim+json"
+
+	// maxSCIMRequestSize is the largest SCIM request body accepted,
+	// group replacements carry the full member list.
+	maxSCIMRequestSize = 4 << 20
+)
+
+// scimError is a SCIM error, returned as defined in RFC 7644 section 3.12.
+type scimError struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+
+	code int
+}
+
+func (e scimError) Error() string {
+	return e.Detail
+}
+
+func newSCIMError(code int, scimType, format string, a ...interface{}) scimError {
+	return scimError{
+		Schemas:  []string{scimSchemaError},
+		Status:   strconv.Itoa(code),
+		ScimType: scimType,
+		Detail:   fmt.Sprintf(format, a...),
+		code:     code,
+	}
+}
+
+type scimMeta struct {
+	ResourceType string `json:"resourceType"`
+	Location     string `json:"location,omitempty"`
+}
+
+// scimRef references a user or group by id.
+type scimRef struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+}
+
+type scimUser struct {
+	Schemas  []string  `json:"schemas"`
+	ID       string    `json:"id,omitempty"`
+	UserName string    `json:"userName"`
+	Password string    `json:"password,omitempty"`
+	Active   *bool     `json:"active,omitempty"`
+	Groups   []scimRef `json:"groups,omitempty"`
+	Meta     *scimMeta `json:"meta,omitempty"`
+}
+
+type scimGroup struct {
+	Schemas     []string  `json:"schemas"`
+	ID          string    `json:"id,omitempty"`
+	DisplayName string    `json:"displayName"`
+	Members     []scimRef `json:"members"`
+	Meta        *scimMeta `json:"meta,omitempty"`
+}
+
+type scimListResponse struct {
+	Schemas      []string      `json:"schemas"`
+	TotalResults int           `json:"totalResults"`
+	StartIndex   int           `json:"startIndex"`
+	ItemsPerPage int           `json:"itemsPerPage"`
+	Resources    []interface{} `json:"Resources"`
+}
+
+type scimPatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value"`
+}
+
+type scimPatchRequest struct {
+	Schemas    []string             `json:"schemas"`
+	Operations []scimPatchOperation `json:"Operations"`
+}
+
+// users and groups are identified by their access key and name.
+func scimUserLocation(id string) string {
+	return scimPathPrefix + scimAPIVersionPrefix + "/Users/" + url.PathEscape(id)
+}
+
+func scimGroupLocation(id string) string {
+	return scimPathPrefix + scimAPIVersionPrefix + "/Groups/" + url.PathEscape(id)
+}
+
+func newSCIMUser(name string, info madmin.UserInfo) scimUser {
+	active := info.Status == madmin.AccountEnabled
+	u := scimUser{
+		Schemas:  []string{scimSchemaUser},
+		ID:       name,
+		UserName: name,
+		Active:   &active,
+		Meta:     &scimMeta{ResourceType: "User", Location: scimUserLocation(name)},
+	}
+	for _, g := range info.MemberOf {
+		u.Groups = append(u.Groups, scimRef{Value: g, Display: g})
+	}
+	return u
+}
+
+func newSCIMGroup(gd madmin.GroupDesc) scimGroup {
+	g := scimGroup{
+		Schemas:     []string{scimSchemaGroup},
+		ID:          gd.Name,
+		DisplayName: gd.Name,
+		Members:     []scimRef{},
+		Meta:        &scimMeta{ResourceType: "Group", Location: scimGroupLocation(gd.Name)},
+	}
+	for _, m := range gd.Members {
+		g.Members = append(g.Members, scimRef{Value: m, Display: m})
+	}
+	return g
+}
+
+var scimEqFilterRegex = regexp.MustCompile(`^\s*([A-Za-z.]+)\s+(?i:eq)\s+"((?:[^"\\]|\\.)*)"\s*$`)
+
+// parseSCIMFilter parses the `<attr> eq "<value>"` filters identity
+// providers use to look up resources before provisioning them, other
+// filters are not supported.
+func parseSCIMFilter(filter, attr string) (string, error) {
+	if filter == "" {
+		return "", nil
+	}
+	m := scimEqFilterRegex.FindStringSubmatch(filter)
+	if m == nil || !strings.EqualFold(m[1], attr) {
+		return "", newSCIMError(http.StatusBadRequest, "invalidFilter", "unsupported filter %q, only '%s eq \"value\"' is supported", filter, attr)
+	}
+	var v string
+	if err := json.Unmarshal([]byte(`"`+m[2]+`"`), &v); err != nil {
+		return "", newSCIMError(http.StatusBadRequest, "invalidFilter", "invalid filter value %q", m[2])
+	}
+	return v, nil
+}
+
+// scimPage returns the 1-based startIndex and count pagination
+// parameters applied to the sorted names.
+func scimPage(r *http.Request, names []string) ([]string, int) {
+	start, _ := strconv.Atoi(r.Form.Get("startIndex"))
+	if start < 1 {
+		start = 1
+	}
+	count := len(names)
+	if v := r.Form.Get("count"); v != "" {
+		if c, err := strconv.Atoi(v); err == nil && c >= 0 && c < count {
+			count = c
+		}
+	}
+	sort.Strings(names)
+	if start > len(names) {
+		return nil, start
+	}
+	names = names[start-1:]
+	if count < len(names) {
+		names = names[:count]
+	}
+	return names, start
+}
+
+// parseSCIMBool parses booleans, some identity providers send them as
+// strings e.g. "False".
+func parseSCIMBool(v json.RawMessage) (bool, error) {
+	var b bool
+	if err := json.Unmarshal(v, &b); err == nil {
+		return b, nil
+	}
+	var s string
+	if err := json.Unmarshal(v, &s); err != nil {
+		return false, err
+	}
+	return strconv.ParseBool(s)
+}
+
+// parseSCIMMembers parses a patch value holding a list of member
+// references.
+func parseSCIMMembers(v json.RawMessage) ([]string, error) {
+	if len(v) == 0 {
+		return nil, nil
+	}
+	var refs []scimRef
+	if err := json.Unmarshal(v, &refs); err != nil {
+		return nil, newSCIMError(http.StatusBadRequest, "invalidValue", "invalid members value")
+	}
+	members := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		members = append(members, ref.Value)
+	}
+	return members, nil
+}
+
+var scimMemberPathRegex = regexp.MustCompile(`^members\[\s*value\s+(?i:eq)\s+"((?:[^"\\]|\\.)*)"\s*\]$`)
+
+func toSCIMError(err error) scimError {
+	var serr scimError
+	if errors.As(err, &serr) {
+		return serr
+	}
+	switch {
+	case errors.Is(err, errNoSuchUser), errors.Is(err, errNoSuchGroup):
+		return newSCIMError(http.StatusNotFound, "", err.Error())
+	case errors.Is(err, errServerNotInitialized), errors.Is(err, errIAMNotInitialized):
+		return newSCIMError(http.StatusServiceUnavailable, "", err.Error())
+	case errors.Is(err, errIAMActionNotAllowed):
+		return newSCIMError(http.StatusForbidden, "", err.Error())
+	case errors.Is(err, errGroupNotEmpty):
+		return newSCIMError(http.StatusConflict, "", err.Error())
+	case errors.Is(err, errNoSuchPolicy), errors.Is(err, errInvalidArgument),
+		errors.Is(err, auth.ErrInvalidAccessKeyLength), errors.Is(err, auth.ErrInvalidSecretKeyLength):
+		return newSCIMError(http.StatusBadRequest, "invalidValue", err.Error())
+	}
+	return newSCIMError(http.StatusInternalServerError, "", err.Error())
+}
+
+func writeSCIMResponse(w http.ResponseWriter, statusCode int, v interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		statusCode = http.StatusInternalServerError
+		data, _ = json.Marshal(newSCIMError(statusCode, "", err.Error()))
+	}
+	writeResponse(w, statusCode, data, mimeSCIM)
+}
+
+func writeSCIMError(ctx context.Context, w http.ResponseWriter, err error) {
+	serr := toSCIMError(err)
+	if serr.code == http.StatusInternalServerError {
+		logger.LogIf(ctx, err)
+	}
+	writeSCIMResponse(w, serr.code, serr)
+}
+
+func readSCIMRequest(r *http.Request, v interface{}) error {
+	data, err := io.ReadAll(io.LimitReader(r.Body, maxSCIMRequestSize))
+	if err != nil {
+		return newSCIMError(http.StatusBadRequest, "invalidSyntax", "unable to read request: %v", err)
+	}
+	if err = json.Unmarshal(data, v); err != nil {
+		return newSCIMError(http.StatusBadRequest, "invalidSyntax", "invalid request: %v", err)
+	}
+	return nil
+}
+
+// authenticate checks the bearer token of the identity provider and
+// parses the request form, it writes the error response on failure.
+func (h scimAPIHandlers) authenticate(ctx context.Context, w http.ResponseWriter, r *http.Request) bool {
+	token := r.Header.Get("Authorization")
+	if len(token) < len("Bearer ") || !strings.EqualFold(token[:len("Bearer ")], "Bearer ") ||
+		subtle.ConstantTimeCompare([]byte(token[len("Bearer "):]), []byte(h.cfg.Token)) != 1 {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="minio"`)
+		writeSCIMError(ctx, w, newSCIMError(http.StatusUnauthorized, "", "invalid or missing bearer token"))
+		return false
+	}
+	if err := r.ParseForm(); err != nil {
+		writeSCIMError(ctx, w, newSCIMError(http.StatusBadRequest, "invalidSyntax", err.Error()))
+		return false
+	}
+	return true
+}
+
+func (h scimAPIHandlers) notFoundHandler(w http.ResponseWriter, r *http.Request) {
+	writeSCIMError(r.Context(), w, newSCIMError(http.StatusNotFound, "", "unsupported SCIM resource %s %s", r.Method, r.URL.Path))
+}
+
+// ServiceProviderConfigHandler - GET /minio/scim/v2/ServiceProviderConfig
+func (h scimAPIHandlers) ServiceProviderConfigHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SCIMServiceProviderConfig")
+
+	defer logger.AuditLog(ctx, w, r, nil)
+
+	if !h.authenticate(ctx, w, r) {
+		return
+	}
+
+	supported := func(ok bool) map[string]bool { return map[string]bool{"supported": ok} }
+	writeSCIMResponse(w, http.StatusOK, map[string]interface{}{
+		"schemas":        []string{scimSchemaServiceProviderConfig},
+		"patch":          supported(true),
+		"bulk":           map[string]interface{}{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":         map[string]interface{}{"supported": true, "maxResults": 0},
+		"changePassword": supported(true),
+		"sort":           supported(false),
+		"etag":           supported(false),
+		"authenticationSchemes": []map[string]string{{
+			"type":        "oauthbearertoken",
+			"name":        "OAuth Bearer Token",
+			"description": "Authentication with the token configured in MINIO_IDENTITY_SCIM_TOKEN",
+		}},
+	})
+}
+
+// ResourceTypesHandler - GET /minio/scim/v2/ResourceTypes
+func (h scimAPIHandlers) ResourceTypesHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SCIMResourceTypes")
+
+	defer logger.AuditLog(ctx, w, r, nil)
+
+	if !h.authenticate(ctx, w, r) {
+		return
+	}
+
+	resourceType := func(name, endpoint, schema string) interface{} {
+		return map[string]interface{}{
+			"schemas":  []string{scimSchemaResourceType},
+			"id":       name,
+			"name":     name,
+			"endpoint": endpoint,
+			"schema":   schema,
+		}
+	}
+	writeSCIMResponse(w, http.StatusOK, scimListResponse{
+		Schemas:      []string{scimSchemaListResponse},
+		TotalResults: 2,
+		StartIndex:   1,
+		ItemsPerPage: 2,
+		Resources: []interface{}{
+			resourceType("User", "/Users", scimSchemaUser),
+			resourceType("Group", "/Groups", scimSchemaGroup),
+		},
+	})
+}
+
+// ListUsersHandler - GET /minio/scim/v2/Users?filter=userName eq "name"
+func (h scimAPIHandlers) ListUsersHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SCIMListUsers")
+
+	defer logger.AuditLog(ctx, w, r, nil)
+
+	if !h.authenticate(ctx, w, r) {
+		return
+	}
+
+	userName, err := parseSCIMFilter(r.Form.Get("filter"), "userName")
+	if err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+
+	users, err := globalIAMSys.ListUsers(ctx)
+	if err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+
+	names := make([]string, 0, len(users))
+	for name := range users {
+		if userName == "" || name == userName {
+			names = append(names, name)
+		}
+	}
+	total := len(names)
+	names, start := scimPage(r, names)
+
+	resp := scimListResponse{
+		Schemas:      []string{scimSchemaListResponse},
+		TotalResults: total,
+		StartIndex:   start,
+		ItemsPerPage: len(names),
+		Resources:    make([]interface{}, 0, len(names)),
+	}
+	for _, name := range names {
+		resp.Resources = append(resp.Resources, newSCIMUser(name, users[name]))
+	}
+	writeSCIMResponse(w, http.StatusOK, resp)
+}
+
+// GetUserHandler - GET /minio/scim/v2/Users/{id}
+func (h scimAPIHandlers) GetUserHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SCIMGetUser")
+
+	defer logger.AuditLog(ctx, w, r, nil)
+
+	if !h.authenticate(ctx, w, r) {
+		return
+	}
+
+	id := mux.Vars(r)["id"]
+	info, err := h.getUser(ctx, id)
+	if err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+	writeSCIMResponse(w, http.StatusOK, newSCIMUser(id, info))
+}
+
+// CreateUserHandler - POST /minio/scim/v2/Users
+func (h scimAPIHandlers) CreateUserHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SCIMCreateUser")
+
+	defer logger.AuditLog(ctx, w, r, nil)
+
+	if !h.authenticate(ctx, w, r) {
+		return
+	}
+
+	var req scimUser
+	if err := readSCIMRequest(r, &req); err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+	accessKey := req.UserName
+	if accessKey == "" || hasSpaceBE(accessKey) || accessKey == globalActiveCred.AccessKey {
+		writeSCIMError(ctx, w, newSCIMError(http.StatusBadRequest, "invalidValue", "invalid userName %q", accessKey))
+		return
+	}
+	if _, err := globalIAMSys.GetUserInfo(ctx, accessKey); err == nil {
+		writeSCIMError(ctx, w, newSCIMError(http.StatusConflict, "uniqueness", "user %q already exists", accessKey))
+		return
+	}
+
+	secretKey := req.Password
+	if secretKey == "" {
+		// Users provisioned without a password get a random one, the
+		// administrator or the user resets it before using it.
+		cred, err := auth.GetNewCredentials()
+		if err != nil {
+			writeSCIMError(ctx, w, err)
+			return
+		}
+		secretKey = cred.SecretKey
+	}
+	status := madmin.AccountEnabled
+	if req.Active != nil && !*req.Active {
+		status = madmin.AccountDisabled
+	}
+	if err := h.setUser(ctx, accessKey, madmin.AddOrUpdateUserReq{SecretKey: secretKey, Status: status}); err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+
+	if h.cfg.DefaultPolicy != "" {
+		if err := h.setPolicy(ctx, accessKey, h.cfg.DefaultPolicy, false); err != nil {
+			writeSCIMError(ctx, w, err)
+			return
+		}
+	}
+
+	info, err := h.getUser(ctx, accessKey)
+	if err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+	w.Header().Set(xhttp.Location, scimUserLocation(accessKey))
+	writeSCIMResponse(w, http.StatusCreated, newSCIMUser(accessKey, info))
+}
+
+// ReplaceUserHandler - PUT /minio/scim/v2/Users/{id}
+func (h scimAPIHandlers) ReplaceUserHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SCIMReplaceUser")
+
+	defer logger.AuditLog(ctx, w, r, nil)
+
+	if !h.authenticate(ctx, w, r) {
+		return
+	}
+
+	id := mux.Vars(r)["id"]
+	var req scimUser
+	if err := readSCIMRequest(r, &req); err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+	if req.UserName != "" && req.UserName != id {
+		writeSCIMError(ctx, w, newSCIMError(http.StatusBadRequest, "mutability", "userName cannot be changed"))
+		return
+	}
+	h.updateUser(ctx, w, id, req.Password, req.Active)
+}
+
+// PatchUserHandler - PATCH /minio/scim/v2/Users/{id}
+func (h scimAPIHandlers) PatchUserHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SCIMPatchUser")
+
+	defer logger.AuditLog(ctx, w, r, nil)
+
+	if !h.authenticate(ctx, w, r) {
+		return
+	}
+
+	id := mux.Vars(r)["id"]
+	var req scimPatchRequest
+	if err := readSCIMRequest(r, &req); err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+
+	var (
+		password string
+		active   *bool
+	)
+	for _, op := range req.Operations {
+		switch strings.ToLower(op.Op) {
+		case "add", "replace":
+		default:
+			writeSCIMError(ctx, w, newSCIMError(http.StatusBadRequest, "invalidValue", "unsupported user patch operation %q", op.Op))
+			return
+		}
+		attrs := map[string]json.RawMessage{}
+		if op.Path == "" {
+			if err := json.Unmarshal(op.Value, &attrs); err != nil {
+				writeSCIMError(ctx, w, newSCIMError(http.StatusBadRequest, "invalidValue", "invalid patch value"))
+				return
+			}
+		} else {
+			attrs[op.Path] = op.Value
+		}
+		// Only the attributes MinIO stores are applied, identity
+		// providers also send profile attributes such as emails.
+		for attr, v := range attrs {
+			switch strings.ToLower(attr) {
+			case "active":
+				b, err := parseSCIMBool(v)
+				if err != nil {
+					writeSCIMError(ctx, w, newSCIMError(http.StatusBadRequest, "invalidValue", "invalid active value"))
+					return
+				}
+				active = &b
+			case "password":
+				if err := json.Unmarshal(v, &password); err != nil {
+					writeSCIMError(ctx, w, newSCIMError(http.StatusBadRequest, "invalidValue", "invalid password value"))
+					return
+				}
+			}
+		}
+	}
+	h.updateUser(ctx, w, id, password, active)
+}
+
+// DeleteUserHandler - DELETE /minio/scim/v2/Users/{id}
+func (h scimAPIHandlers) DeleteUserHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SCIMDeleteUser")
+
+	defer logger.AuditLog(ctx, w, r, nil)
+
+	if !h.authenticate(ctx, w, r) {
+		return
+	}
+
+	id := mux.Vars(r)["id"]
+	if _, err := h.getUser(ctx, id); err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+	if err := globalIAMSys.DeleteUser(ctx, id, true); err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+
+	if err := globalSiteReplicationSys.IAMChangeHook(ctx, madmin.SRIAMItem{
+		Type: madmin.SRIAMItemIAMUser,
+		IAMUser: &madmin.SRIAMUser{
+			AccessKey:   id,
+			IsDeleteReq: true,
+		},
+		UpdatedAt: UTCNow(),
+	}); err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+	writeSuccessNoContent(w)
+}
+
+// ListGroupsHandler - GET /minio/scim/v2/Groups?filter=displayName eq "name"
+func (h scimAPIHandlers) ListGroupsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SCIMListGroups")
+
+	defer logger.AuditLog(ctx, w, r, nil)
+
+	if !h.authenticate(ctx, w, r) {
+		return
+	}
+
+	displayName, err := parseSCIMFilter(r.Form.Get("filter"), "displayName")
+	if err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+
+	groups, err := globalIAMSys.ListGroups(ctx)
+	if err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+
+	names := groups[:0]
+	for _, name := range groups {
+		if displayName == "" || name == displayName {
+			names = append(names, name)
+		}
+	}
+	total := len(names)
+	names, start := scimPage(r, names)
+
+	resp := scimListResponse{
+		Schemas:      []string{scimSchemaListResponse},
+		TotalResults: total,
+		StartIndex:   start,
+		Resources:    make([]interface{}, 0, len(names)),
+	}
+	for _, name := range names {
+		gd, err := globalIAMSys.GetGroupDescription(name)
+		if err != nil {
+			// Deleted since listed.
+			continue
+		}
+		resp.Resources = append(resp.Resources, newSCIMGroup(gd))
+	}
+	resp.ItemsPerPage = len(resp.Resources)
+	writeSCIMResponse(w, http.StatusOK, resp)
+}
+
+// GetGroupHandler - GET /minio/scim/v2/Groups/{id}
+func (h scimAPIHandlers) GetGroupHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SCIMGetGroup")
+
+	defer logger.AuditLog(ctx, w, r, nil)
+
+	if !h.authenticate(ctx, w, r) {
+		return
+	}
+
+	gd, err := globalIAMSys.GetGroupDescription(mux.Vars(r)["id"])
+	if err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+	writeSCIMResponse(w, http.StatusOK, newSCIMGroup(gd))
+}
+
+// CreateGroupHandler - POST /minio/scim/v2/Groups
+func (h scimAPIHandlers) CreateGroupHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SCIMCreateGroup")
+
+	defer logger.AuditLog(ctx, w, r, nil)
+
+	if !h.authenticate(ctx, w, r) {
+		return
+	}
+
+	var req scimGroup
+	if err := readSCIMRequest(r, &req); err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+	group := req.DisplayName
+	if group == "" || hasSpaceBE(group) {
+		writeSCIMError(ctx, w, newSCIMError(http.StatusBadRequest, "invalidValue", "invalid displayName %q", group))
+		return
+	}
+	if _, err := globalIAMSys.GetGroupDescription(group); err == nil {
+		writeSCIMError(ctx, w, newSCIMError(http.StatusConflict, "uniqueness", "group %q already exists", group))
+		return
+	}
+
+	members := make([]string, 0, len(req.Members))
+	for _, m := range req.Members {
+		members = append(members, m.Value)
+	}
+	if err := h.updateGroupMembers(ctx, group, members, false); err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+
+	// Policies are mapped when the group is provisioned, later changes
+	// by administrators are left untouched.
+	if policies := h.cfg.GroupPolicies(group); len(policies) > 0 {
+		if err := h.setPolicy(ctx, group, strings.Join(policies, ","), true); err != nil {
+			writeSCIMError(ctx, w, err)
+			return
+		}
+	}
+
+	gd, err := globalIAMSys.GetGroupDescription(group)
+	if err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+	w.Header().Set(xhttp.Location, scimGroupLocation(group))
+	writeSCIMResponse(w, http.StatusCreated, newSCIMGroup(gd))
+}
+
+// ReplaceGroupHandler - PUT /minio/scim/v2/Groups/{id}
+func (h scimAPIHandlers) ReplaceGroupHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SCIMReplaceGroup")
+
+	defer logger.AuditLog(ctx, w, r, nil)
+
+	if !h.authenticate(ctx, w, r) {
+		return
+	}
+
+	id := mux.Vars(r)["id"]
+	var req scimGroup
+	if err := readSCIMRequest(r, &req); err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+	if req.DisplayName != "" && req.DisplayName != id {
+		writeSCIMError(ctx, w, newSCIMError(http.StatusBadRequest, "mutability", "groups cannot be renamed"))
+		return
+	}
+	members := make([]string, 0, len(req.Members))
+	for _, m := range req.Members {
+		members = append(members, m.Value)
+	}
+	h.patchGroup(ctx, w, id, nil, nil, members)
+}
+
+// PatchGroupHandler - PATCH /minio/scim/v2/Groups/{id}
+func (h scimAPIHandlers) PatchGroupHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SCIMPatchGroup")
+
+	defer logger.AuditLog(ctx, w, r, nil)
+
+	if !h.authenticate(ctx, w, r) {
+		return
+	}
+
+	id := mux.Vars(r)["id"]
+	var req scimPatchRequest
+	if err := readSCIMRequest(r, &req); err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+
+	var add, remove, replace []string
+	for _, op := range req.Operations {
+		path := strings.TrimSpace(op.Path)
+		if m := scimMemberPathRegex.FindStringSubmatch(path); m != nil && strings.EqualFold(op.Op, "remove") {
+			remove = append(remove, m[1])
+			continue
+		}
+		if path == "" && !strings.EqualFold(op.Op, "remove") {
+			// Attributes given as an object e.g. by Okta.
+			var attrs struct {
+				DisplayName *string         `json:"displayName"`
+				Members     json.RawMessage `json:"members"`
+			}
+			if err := json.Unmarshal(op.Value, &attrs); err != nil {
+				writeSCIMError(ctx, w, newSCIMError(http.StatusBadRequest, "invalidValue", "invalid patch value"))
+				return
+			}
+			if attrs.DisplayName != nil && *attrs.DisplayName != id {
+				writeSCIMError(ctx, w, newSCIMError(http.StatusBadRequest, "mutability", "groups cannot be renamed"))
+				return
+			}
+			if attrs.Members == nil {
+				continue
+			}
+			path, op.Value = "members", attrs.Members
+		}
+		if !strings.EqualFold(path, "members") {
+			if strings.EqualFold(path, "displayName") {
+				var name string
+				if json.Unmarshal(op.Value, &name) != nil || name != id {
+					writeSCIMError(ctx, w, newSCIMError(http.StatusBadRequest, "mutability", "groups cannot be renamed"))
+					return
+				}
+			}
+			continue
+		}
+		members, err := parseSCIMMembers(op.Value)
+		if err != nil {
+			writeSCIMError(ctx, w, err)
+			return
+		}
+		switch strings.ToLower(op.Op) {
+		case "add":
+			add = append(add, members...)
+		case "remove":
+			if len(op.Value) == 0 {
+				// Removes all members.
+				replace = []string{}
+				continue
+			}
+			remove = append(remove, members...)
+		case "replace":
+			replace = members
+		default:
+			writeSCIMError(ctx, w, newSCIMError(http.StatusBadRequest, "invalidValue", "unsupported group patch operation %q", op.Op))
+			return
+		}
+	}
+	h.patchGroup(ctx, w, id, add, remove, replace)
+}
+
+// DeleteGroupHandler - DELETE /minio/scim/v2/Groups/{id}
+func (h scimAPIHandlers) DeleteGroupHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SCIMDeleteGroup")
+
+	defer logger.AuditLog(ctx, w, r, nil)
+
+	if !h.authenticate(ctx, w, r) {
+		return
+	}
+
+	id := mux.Vars(r)["id"]
+	gd, err := globalIAMSys.GetGroupDescription(id)
+	if err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+	// Only empty groups can be removed.
+	if len(gd.Members) > 0 {
+		if err = h.updateGroupMembers(ctx, id, gd.Members, true); err != nil {
+			writeSCIMError(ctx, w, err)
+			return
+		}
+	}
+	if err = h.updateGroupMembers(ctx, id, nil, true); err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+	writeSuccessNoContent(w)
+}
+
+// getUser returns the regular user id, temporary credentials and
+// service accounts are not managed through SCIM.
+func (h scimAPIHandlers) getUser(ctx context.Context, id string) (madmin.UserInfo, error) {
+	if id == globalActiveCred.AccessKey {
+		return madmin.UserInfo{}, errNoSuchUser
+	}
+	if ok, _, err := globalIAMSys.IsTempUser(id); err != nil || ok {
+		return madmin.UserInfo{}, errNoSuchUser
+	}
+	if ok, _, err := globalIAMSys.IsServiceAccount(id); err != nil || ok {
+		return madmin.UserInfo{}, errNoSuchUser
+	}
+	return globalIAMSys.GetUserInfo(ctx, id)
+}
+
+// updateUser applies a new password and status to the user id and
+// writes the updated user.
+func (h scimAPIHandlers) updateUser(ctx context.Context, w http.ResponseWriter, id, password string, active *bool) {
+	info, err := h.getUser(ctx, id)
+	if err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+	status := info.Status
+	if active != nil {
+		status = madmin.AccountDisabled
+		if *active {
+			status = madmin.AccountEnabled
+		}
+	}
+
+	switch {
+	case password != "":
+		err = h.setUser(ctx, id, madmin.AddOrUpdateUserReq{SecretKey: password, Status: status})
+	case status != info.Status:
+		var updatedAt time.Time
+		updatedAt, err = globalIAMSys.SetUserStatus(ctx, id, status)
+		if err == nil {
+			err = globalSiteReplicationSys.IAMChangeHook(ctx, madmin.SRIAMItem{
+				Type: madmin.SRIAMItemIAMUser,
+				IAMUser: &madmin.SRIAMUser{
+					AccessKey: id,
+					UserReq:   &madmin.AddOrUpdateUserReq{Status: status},
+				},
+				UpdatedAt: updatedAt,
+			})
+		}
+	}
+	if err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+
+	if info, err = h.getUser(ctx, id); err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+	writeSCIMResponse(w, http.StatusOK, newSCIMUser(id, info))
+}
+
+// patchGroup adds and removes members of the group id, or replaces
+// them when replace is non-nil, and writes the updated group.
+func (h scimAPIHandlers) patchGroup(ctx context.Context, w http.ResponseWriter, id string, add, remove, replace []string) {
+	gd, err := globalIAMSys.GetGroupDescription(id)
+	if err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+	if replace != nil {
+		current := set.CreateStringSet(gd.Members...)
+		desired := set.CreateStringSet(replace...)
+		add = append(add, desired.Difference(current).ToSlice()...)
+		remove = append(remove, current.Difference(desired).ToSlice()...)
+	}
+	if len(add) > 0 {
+		if err = h.updateGroupMembers(ctx, id, add, false); err != nil {
+			writeSCIMError(ctx, w, err)
+			return
+		}
+	}
+	if len(remove) > 0 {
+		if err = h.updateGroupMembers(ctx, id, remove, true); err != nil {
+			writeSCIMError(ctx, w, err)
+			return
+		}
+	}
+
+	if gd, err = globalIAMSys.GetGroupDescription(id); err != nil {
+		writeSCIMError(ctx, w, err)
+		return
+	}
+	writeSCIMResponse(w, http.StatusOK, newSCIMGroup(gd))
+}
+
+func (h scimAPIHandlers) setUser(ctx context.Context, accessKey string, ureq madmin.AddOrUpdateUserReq) error {
+	updatedAt, err := globalIAMSys.CreateUser(ctx, accessKey, ureq)
+	if err != nil {
+		return err
+	}
+	return globalSiteReplicationSys.IAMChangeHook(ctx, madmin.SRIAMItem{
+		Type: madmin.SRIAMItemIAMUser,
+		IAMUser: &madmin.SRIAMUser{
+			AccessKey: accessKey,
+			UserReq:   &ureq,
+		},
+		UpdatedAt: updatedAt,
+	})
+}
+
+func (h scimAPIHandlers) setPolicy(ctx context.Context, name, policy string, isGroup bool) error {
+	updatedAt, err := globalIAMSys.PolicyDBSet(ctx, name, policy, regUser, isGroup)
+	if err != nil {
+		return err
+	}
+	return globalSiteReplicationSys.IAMChangeHook(ctx, madmin.SRIAMItem{
+		Type: madmin.SRIAMItemPolicyMapping,
+		PolicyMapping: &madmin.SRPolicyMapping{
+			UserOrGroup: name,
+			UserType:    int(regUser),
+			IsGroup:     isGroup,
+			Policy:      policy,
+		},
+		UpdatedAt: updatedAt,
+	})
+}
+
+// updateGroupMembers adds or removes members of group, removing no
+// members deletes the empty group.
+func (h scimAPIHandlers) updateGroupMembers(ctx context.Context, group string, members []string, isRemove bool) error {
+	var (
+		updatedAt time.Time
+		err       error
+	)
+	if isRemove {
+		updatedAt, err = globalIAMSys.RemoveUsersFromGroup(ctx, group, members)
+	} else {
+		updatedAt, err = globalIAMSys.AddUsersToGroup(ctx, group, members)
+	}
+	if err != nil {
+		return err
+	}
+	return globalSiteReplicationSys.IAMChangeHook(ctx, madmin.SRIAMItem{
+		Type: madmin.SRIAMItemGroupInfo,
+		GroupInfo: &madmin.SRGroupInfo{
+			UpdateReq: madmin.GroupAddRemove{
+				Group:    group,
+				Members:  members,
+				IsRemove: isRemove,
+			},
+		},
+		UpdatedAt: updatedAt,
+	})
+}
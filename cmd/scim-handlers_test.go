@@ -0,0 +1,141 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/qkbyte/minio/internal/config/identity/scim"
+)
+
+func TestParseSCIMFilter(t *testing.T) {
+	testCases := []struct {
+		filter  string
+		attr    string
+		value   string
+		success bool
+	}{
+		{"", "userName", "", true},
+		{`userName eq "alice"`, "userName", "alice", true},
+		{`username EQ "alice@example.com"`, "userName", "alice@example.com", true},
+		{`displayName eq "dev \"ops\""`, "displayName", `dev "ops"`, true},
+		{`userName eq "alice"`, "displayName", "", false},
+		{`userName sw "al"`, "userName", "", false},
+		{`userName eq "a" or userName eq "b"`, "userName", "", false},
+		{`userName eq alice`, "userName", "", false},
+	}
+	for i, testCase := range testCases {
+		value, err := parseSCIMFilter(testCase.filter, testCase.attr)
+		if (err == nil) != testCase.success {
+			t.Fatalf("Test %d: expected success %v, got %v", i+1, testCase.success, err)
+		}
+		if value != testCase.value {
+			t.Fatalf("Test %d: expected %q, got %q", i+1, testCase.value, value)
+		}
+	}
+}
+
+func TestSCIMPage(t *testing.T) {
+	names := []string{"e", "d", "c", "b", "a"}
+	testCases := []struct {
+		startIndex, count string
+		expected          []string
+		start             int
+	}{
+		{"", "", []string{"a", "b", "c", "d", "e"}, 1},
+		{"0", "2", []string{"a", "b"}, 1},
+		{"2", "2", []string{"b", "c"}, 2},
+		{"4", "10", []string{"d", "e"}, 4},
+		{"6", "", nil, 6},
+		{"1", "0", []string{}, 1},
+	}
+	for i, testCase := range testCases {
+		r := httptest.NewRequest(http.MethodGet, "/Users", nil)
+		r.Form = url.Values{"startIndex": {testCase.startIndex}, "count": {testCase.count}}
+		page, start := scimPage(r, append([]string{}, names...))
+		if start != testCase.start {
+			t.Fatalf("Test %d: expected start %d, got %d", i+1, testCase.start, start)
+		}
+		if !reflect.DeepEqual(page, testCase.expected) {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.expected, page)
+		}
+	}
+}
+
+func TestParseSCIMBool(t *testing.T) {
+	for i, testCase := range []struct {
+		value    string
+		expected bool
+		success  bool
+	}{
+		{`true`, true, true},
+		{`false`, false, true},
+		{`"False"`, false, true},
+		{`"True"`, true, true},
+		{`"yes"`, false, false},
+		{`1`, false, false},
+	} {
+		b, err := parseSCIMBool([]byte(testCase.value))
+		if (err == nil) != testCase.success || b != testCase.expected {
+			t.Fatalf("Test %d: expected %v (success %v), got %v (%v)", i+1, testCase.expected, testCase.success, b, err)
+		}
+	}
+}
+
+func TestSCIMAuthentication(t *testing.T) {
+	h := scimAPIHandlers{cfg: scim.Config{Enabled: true, Token: "0123456789abcdef"}}
+	router := mux.NewRouter()
+	router.Methods(http.MethodGet).Path(scimPathPrefix + scimAPIVersionPrefix + "/ServiceProviderConfig").HandlerFunc(h.ServiceProviderConfigHandler)
+
+	for i, testCase := range []struct {
+		authorization string
+		status        int
+	}{
+		{"", http.StatusUnauthorized},
+		{"Bearer", http.StatusUnauthorized},
+		{"Bearer 0123456789abcdeX", http.StatusUnauthorized},
+		{"Basic 0123456789abcdef", http.StatusUnauthorized},
+		{"Bearer 0123456789abcdef", http.StatusOK},
+		{"bearer 0123456789abcdef", http.StatusOK},
+	} {
+		r := httptest.NewRequest(http.MethodGet, scimPathPrefix+scimAPIVersionPrefix+"/ServiceProviderConfig", nil)
+		if testCase.authorization != "" {
+			r.Header.Set("Authorization", testCase.authorization)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		if w.Code != testCase.status {
+			t.Fatalf("Test %d: expected status %d, got %d", i+1, testCase.status, w.Code)
+		}
+		if ct := w.Header().Get("Content-Type"); ct != string(mimeSCIM) {
+			t.Fatalf("Test %d: expected content type %s, got %s", i+1, mimeSCIM, ct)
+		}
+		if w.Code != http.StatusOK && w.Body.Len() > 0 {
+			if status := strconv.Itoa(w.Code); !strings.Contains(w.Body.String(), `"status":"`+status+`"`) {
+				t.Fatalf("Test %d: expected SCIM error, got %s", i+1, w.Body.String())
+			}
+		}
+	}
+}
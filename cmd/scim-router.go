@@ -0,0 +1,71 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/qkbyte/minio/internal/config/identity/scim"
+	"github.com/qkbyte/minio/internal/logger"
+)
+
+const (
+	scimPathPrefix       = minioReservedBucketPath + "/scim"
+	scimAPIVersionPrefix = SlashSeparator + "v2"
+)
+
+// scimAPIHandlers implements the SCIM 2.0 provisioning API.
+type scimAPIHandlers struct {
+	cfg scim.Config
+}
+
+// registerSCIMRouter - registers the SCIM 2.0 provisioning API when
+// enabled in the environment.
+func registerSCIMRouter(router *mux.Router) {
+	cfg, err := scim.LookupConfig()
+	logger.FatalIf(err, "Unable to initialize SCIM provisioning API")
+	if !cfg.Enabled {
+		return
+	}
+
+	scimAPI := scimAPIHandlers{cfg: cfg}
+	scimRouter := router.PathPrefix(scimPathPrefix + scimAPIVersionPrefix).Subrouter()
+
+	scimRouter.Methods(http.MethodGet).Path("/ServiceProviderConfig").HandlerFunc(httpTraceHdrs(scimAPI.ServiceProviderConfigHandler))
+	scimRouter.Methods(http.MethodGet).Path("/ResourceTypes").HandlerFunc(httpTraceHdrs(scimAPI.ResourceTypesHandler))
+
+	// SCIM user APIs
+	scimRouter.Methods(http.MethodGet).Path("/Users").HandlerFunc(httpTraceHdrs(scimAPI.ListUsersHandler))
+	scimRouter.Methods(http.MethodPost).Path("/Users").HandlerFunc(httpTraceHdrs(scimAPI.CreateUserHandler))
+	scimRouter.Methods(http.MethodGet).Path("/Users/{id}").HandlerFunc(httpTraceHdrs(scimAPI.GetUserHandler))
+	scimRouter.Methods(http.MethodPut).Path("/Users/{id}").HandlerFunc(httpTraceHdrs(scimAPI.ReplaceUserHandler))
+	scimRouter.Methods(http.MethodPatch).Path("/Users/{id}").HandlerFunc(httpTraceHdrs(scimAPI.PatchUserHandler))
+	scimRouter.Methods(http.MethodDelete).Path("/Users/{id}").HandlerFunc(httpTraceHdrs(scimAPI.DeleteUserHandler))
+
+	// SCIM group APIs
+	scimRouter.Methods(http.MethodGet).Path("/Groups").HandlerFunc(httpTraceHdrs(scimAPI.ListGroupsHandler))
+	scimRouter.Methods(http.MethodPost).Path("/Groups").HandlerFunc(httpTraceHdrs(scimAPI.CreateGroupHandler))
+	scimRouter.Methods(http.MethodGet).Path("/Groups/{id}").HandlerFunc(httpTraceHdrs(scimAPI.GetGroupHandler))
+	scimRouter.Methods(http.MethodPut).Path("/Groups/{id}").HandlerFunc(httpTraceHdrs(scimAPI.ReplaceGroupHandler))
+	scimRouter.Methods(http.MethodPatch).Path("/Groups/{id}").HandlerFunc(httpTraceHdrs(scimAPI.PatchGroupHandler))
+	scimRouter.Methods(http.MethodDelete).Path("/Groups/{id}").HandlerFunc(httpTraceHdrs(scimAPI.DeleteGroupHandler))
+
+	scimRouter.NotFoundHandler = httpTraceAll(scimAPI.notFoundHandler)
+	scimRouter.MethodNotAllowedHandler = httpTraceAll(scimAPI.notFoundHandler)
+}
@@ -0,0 +1,213 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	xhttp "github.com/qkbyte/minio/internal/http"
+)
+
+// serverBindings are the addresses the listeners of each role bind to,
+// an empty role uses the defaults: the S3 API binds to --address and
+// internode traffic is served by the S3 API listeners.
+type serverBindings struct {
+	API       []string
+	Internode []string
+}
+
+// parseBindAddr validates an ADDRESS:PORT value, ADDRESS can be empty
+// to bind to all interfaces.
+func parseBindAddr(addr string) (host, port string, err error) {
+	host, port, err = net.SplitHostPort(addr)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid address '%s': %w", addr, err)
+	}
+	if p, err := strconv.Atoi(port); err != nil || p <= 0 || p > 65535 {
+		return "", "", fmt.Errorf("invalid port in address '%s'", addr)
+	}
+	return host, port, nil
+}
+
+// parseServerBindings parses comma separated role=ADDRESS:PORT entries,
+// e.g. "api=10.0.0.5:9000,api=[fd00::5]:9000,internode=192.168.10.5:9100".
+// The S3 API must keep the port of --address, peers find each other
+// through it unless MINIO_INTERNODE_HOSTS says otherwise.
+func parseServerBindings(v, apiPort string) (b serverBindings, err error) {
+	if v == "" {
+		return b, nil
+	}
+	seen := make(map[string]string)
+	for _, entry := range strings.Split(v, ",") {
+		role, addr, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok {
+			return b, fmt.Errorf("invalid binding '%s', expected role=ADDRESS:PORT", entry)
+		}
+		_, port, err := parseBindAddr(addr)
+		if err != nil {
+			return b, err
+		}
+		if other, ok := seen[addr]; ok {
+			return b, fmt.Errorf("address '%s' is bound by both %s and %s", addr, other, role)
+		}
+		seen[addr] = role
+		switch role {
+		case "api":
+			if port != apiPort {
+				return b, fmt.Errorf("api address '%s' must use the port of --address %s", addr, apiPort)
+			}
+			b.API = append(b.API, addr)
+		case "internode":
+			if port == apiPort {
+				return b, fmt.Errorf("internode address '%s' cannot use the port of --address %s", addr, apiPort)
+			}
+			b.Internode = append(b.Internode, addr)
+		default:
+			return b, fmt.Errorf("unknown role '%s', expected api or internode", role)
+		}
+	}
+	return b, nil
+}
+
+// parseInternodeHosts parses comma separated HOST:PORT=ADDRESS:PORT
+// entries mapping the host of each node, as given in the endpoints, to
+// the address its internode listener is reachable at.
+func parseInternodeHosts(v string) (map[string]string, error) {
+	if v == "" {
+		return nil, nil
+	}
+	hosts := make(map[string]string)
+	for _, entry := range strings.Split(v, ",") {
+		host, addr, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid internode host '%s', expected HOST:PORT=ADDRESS:PORT", entry)
+		}
+		if _, _, err := parseBindAddr(host); err != nil {
+			return nil, err
+		}
+		if h, _, err := parseBindAddr(addr); err != nil {
+			return nil, err
+		} else if h == "" {
+			return nil, fmt.Errorf("internode address '%s' needs a host", addr)
+		}
+		if _, ok := hosts[host]; ok {
+			return nil, fmt.Errorf("duplicate internode host '%s'", host)
+		}
+		hosts[host] = addr
+	}
+	return hosts, nil
+}
+
+// validateInternodeHosts checks that the internode hosts cover known
+// nodes only and agree with the internode bindings of this node.
+func validateInternodeHosts(b serverBindings, hosts map[string]string, nodes map[string]Endpoint, localNode string) error {
+	for host := range hosts {
+		if _, ok := nodes[host]; !ok {
+			return fmt.Errorf("internode host '%s' is not part of the endpoints", host)
+		}
+	}
+	addr, ok := hosts[localNode]
+	switch {
+	case len(b.Internode) == 0 && ok:
+		return fmt.Errorf("internode host '%s' is set for this node without an internode binding", localNode)
+	case len(b.Internode) == 0:
+		return nil
+	case !ok:
+		return fmt.Errorf("internode binding requires an internode host for this node '%s'", localNode)
+	}
+	_, port, _ := net.SplitHostPort(addr)
+	for _, bind := range b.Internode {
+		if _, p, _ := net.SplitHostPort(bind); p == port {
+			return nil
+		}
+	}
+	return fmt.Errorf("internode host '%s' of this node does not match any internode binding", addr)
+}
+
+// internodeDialContext dials peers at their internode address.
+func internodeDialContext(hosts map[string]string, dial xhttp.DialContext) xhttp.DialContext {
+	if len(hosts) == 0 {
+		return dial
+	}
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		if internodeAddr, ok := hosts[addr]; ok {
+			addr = internodeAddr
+		}
+		return dial(ctx, network, addr)
+	}
+}
+
+var internodePrefixes = []string{
+	storageRESTPrefix,
+	peerRESTPrefix,
+	bootstrapRESTPrefix,
+	lockRESTPrefix,
+}
+
+func isInternodeReq(r *http.Request) bool {
+	for _, prefix := range internodePrefixes {
+		if strings.HasPrefix(r.URL.Path, prefix+SlashSeparator) {
+			return true
+		}
+	}
+	return false
+}
+
+func isHealthCheckReq(r *http.Request) bool {
+	return strings.HasPrefix(r.URL.Path, healthCheckPathPrefix+SlashSeparator)
+}
+
+// splitInternodeHandler returns the handlers of the S3 API and internode
+// listeners, each rejects the requests meant for the other one. Health
+// checks are served by both.
+func splitInternodeHandler(h http.Handler) (api, internode http.Handler) {
+	reject := func(w http.ResponseWriter, r *http.Request) {
+		writeErrorResponse(r.Context(), w, errorCodes.ToAPIErr(ErrAccessDenied), r.URL)
+	}
+	api = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isInternodeReq(r) {
+			reject(w, r)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+	internode = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !isInternodeReq(r) && !isHealthCheckReq(r) {
+			reject(w, r)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+	return api, internode
+}
+
+// listenerAddrs repeats each address for the number of listeners.
+func listenerAddrs(addrs []string, listeners int) []string {
+	out := make([]string, 0, len(addrs)*listeners)
+	for _, addr := range addrs {
+		for i := 0; i < listeners; i++ {
+			out = append(out, addr)
+		}
+	}
+	return out
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseServerBindings(t *testing.T) {
+	b, err := parseServerBindings("api=10.0.0.5:9000, api=[fd00::5]:9000,internode=192.168.10.5:9100", "9000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(b.API) != 2 || b.API[1] != "[fd00::5]:9000" || len(b.Internode) != 1 || b.Internode[0] != "192.168.10.5:9100" {
+		t.Fatalf("unexpected bindings %+v", b)
+	}
+	if b, err = parseServerBindings("", "9000"); err != nil || len(b.API)+len(b.Internode) != 0 {
+		t.Fatalf("unexpected bindings %+v: %v", b, err)
+	}
+
+	for _, v := range []string{
+		"10.0.0.5:9000",
+		"api=10.0.0.5",
+		"api=10.0.0.5:9001",
+		"internode=:9000",
+		"console=:9001",
+		"api=:9000,api=:9000",
+		"internode=:0",
+	} {
+		if _, err := parseServerBindings(v, "9000"); err == nil {
+			t.Errorf("%q: expected an error", v)
+		}
+	}
+}
+
+func TestInternodeHosts(t *testing.T) {
+	hosts, err := parseInternodeHosts("node1:9000=192.168.10.1:9100,node2:9000=192.168.10.2:9100")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hosts) != 2 || hosts["node2:9000"] != "192.168.10.2:9100" {
+		t.Fatalf("unexpected hosts %v", hosts)
+	}
+	for _, v := range []string{
+		"node1:9000",
+		"node1=192.168.10.1:9100",
+		"node1:9000=:9100",
+		"node1:9000=192.168.10.1:9100,node1:9000=192.168.10.2:9100",
+	} {
+		if _, err := parseInternodeHosts(v); err == nil {
+			t.Errorf("%q: expected an error", v)
+		}
+	}
+
+	nodes := map[string]Endpoint{"node1:9000": {}, "node2:9000": {}}
+	bindings := serverBindings{Internode: []string{"192.168.10.1:9100"}}
+	if err = validateInternodeHosts(bindings, hosts, nodes, "node1:9000"); err != nil {
+		t.Fatal(err)
+	}
+	if err = validateInternodeHosts(serverBindings{}, hosts, nodes, "node1:9000"); err == nil {
+		t.Error("expected an error for an internode host without binding")
+	}
+	if err = validateInternodeHosts(bindings, map[string]string{"node2:9000": "192.168.10.2:9100"}, nodes, "node1:9000"); err == nil {
+		t.Error("expected an error for a binding without internode host")
+	}
+	if err = validateInternodeHosts(serverBindings{Internode: []string{":9200"}}, hosts, nodes, "node1:9000"); err == nil {
+		t.Error("expected an error for mismatching ports")
+	}
+	if err = validateInternodeHosts(bindings, map[string]string{"node1:9000": "192.168.10.1:9100", "node3:9000": "192.168.10.3:9100"}, nodes, "node1:9000"); err == nil {
+		t.Error("expected an error for an unknown node")
+	}
+
+	var dialed string
+	dial := internodeDialContext(hosts, func(ctx context.Context, network, addr string) (net.Conn, error) {
+		dialed = addr
+		return nil, nil
+	})
+	dial(context.Background(), "tcp", "node2:9000")
+	if dialed != "192.168.10.2:9100" {
+		t.Errorf("expected internode address to be dialed, got %s", dialed)
+	}
+	dial(context.Background(), "tcp", "node3:9000")
+	if dialed != "node3:9000" {
+		t.Errorf("expected unmapped address to be dialed as is, got %s", dialed)
+	}
+}
+
+func TestSplitInternodeHandler(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	api, internode := splitInternodeHandler(ok)
+	for _, test := range []struct {
+		path      string
+		api       int
+		internode int
+	}{
+		{"/bucket/object", http.StatusOK, http.StatusForbidden},
+		{peerRESTPrefix + "/v1/serverinfo", http.StatusForbidden, http.StatusOK},
+		{storageRESTPrefix + "/v1/readall", http.StatusForbidden, http.StatusOK},
+		{healthCheckPathPrefix + "/live", http.StatusOK, http.StatusOK},
+	} {
+		for _, h := range []struct {
+			handler http.Handler
+			status  int
+		}{{api, test.api}, {internode, test.internode}} {
+			w := httptest.NewRecorder()
+			h.handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, test.path, nil))
+			if w.Code != h.status {
+				t.Errorf("%s: expected %d, got %d", test.path, h.status, w.Code)
+			}
+		}
+	}
+}
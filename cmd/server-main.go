@@ -25,6 +25,8 @@ import (
 	"io"
 	"log"
 	"math/rand"
+	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"runtime"
@@ -42,6 +44,7 @@ import (
 	"github.com/qkbyte/minio/internal/bucket/bandwidth"
 	"github.com/qkbyte/minio/internal/color"
 	"github.com/qkbyte/minio/internal/config"
+	"github.com/qkbyte/minio/internal/event/target"
 	"github.com/qkbyte/minio/internal/fips"
 	xhttp "github.com/qkbyte/minio/internal/http"
 	"github.com/qkbyte/minio/internal/logger"
@@ -56,6 +59,16 @@ var ServerFlags = []cli.Flag{
 		Usage:  "bind to a specific ADDRESS:PORT, ADDRESS can be an IP or hostname",
 		EnvVar: "MINIO_ADDRESS",
 	},
+	cli.StringFlag{
+		Name:   "bind",
+		Usage:  "bind the S3 API and internode traffic to specific interfaces, e.g. \"api=10.0.0.5:9000,internode=192.168.10.5:9100\"",
+		EnvVar: "MINIO_BIND",
+	},
+	cli.StringFlag{
+		Name:   "internode-hosts",
+		Usage:  "reach each node at its internode address, e.g. \"node1:9000=192.168.10.1:9100,node2:9000=192.168.10.2:9100\"",
+		EnvVar: "MINIO_INTERNODE_HOSTS",
+	},
 	cli.IntFlag{
 		Name:   "listeners",
 		Value:  1,
@@ -88,6 +101,16 @@ var ServerFlags = []cli.Flag{
 		EnvVar: "MINIO_READ_HEADER_TIMEOUT",
 		Hidden: true,
 	},
+	cli.BoolFlag{
+		Name:   "preflight",
+		Usage:  "validate drives, clock sync, resource limits, peers, KMS and identity providers, print a report and exit",
+		EnvVar: "MINIO_PREFLIGHT",
+	},
+	cli.BoolFlag{
+		Name:   "preflight-strict",
+		Usage:  "refuse to start the server if any preflight check fails",
+		EnvVar: "MINIO_PREFLIGHT_STRICT",
+	},
 }
 
 var serverCmd = cli.Command{
@@ -191,7 +214,12 @@ func serverHandleCmdArgs(ctx *cli.Context) {
 	globalEndpoints, setupType, err = createServerEndpoints(globalMinioAddr, serverCmdArgs(ctx)...)
 	logger.FatalIf(err, "Invalid command line arguments")
 
+	topology, err := parseTopologyLabels(env.Get(config.EnvTopology, ""))
+	logger.FatalIf(err, "Invalid topology labels")
+	globalEndpoints.SetTopology(topology)
+
 	globalLocalNodeName = GetLocalPeer(globalEndpoints, globalMinioHost, globalMinioPort)
+	target.SetMQTTNodeName(globalLocalNodeName)
 
 	globalRemoteEndpoints = make(map[string]Endpoint)
 	for _, z := range globalEndpoints {
@@ -204,6 +232,16 @@ func serverHandleCmdArgs(ctx *cli.Context) {
 		}
 	}
 
+	globalServerBindings, err = parseServerBindings(ctx.String("bind"), globalMinioPort)
+	logger.FatalIf(err, "Invalid --bind value")
+	globalInternodeHosts, err = parseInternodeHosts(ctx.String("internode-hosts"))
+	logger.FatalIf(err, "Invalid --internode-hosts value")
+	if setupType != DistErasureSetupType && (len(globalServerBindings.Internode) > 0 || len(globalInternodeHosts) > 0) {
+		logger.FatalIf(errors.New("internode bindings require a distributed setup"), "Invalid --bind value")
+	}
+	logger.FatalIf(validateInternodeHosts(globalServerBindings, globalInternodeHosts, globalRemoteEndpoints, globalLocalNodeName),
+		"Invalid --internode-hosts value")
+
 	// allow transport to be HTTP/1.1 for proxying.
 	globalProxyTransport = newCustomHTTPProxyTransport(&tls.Config{
 		RootCAs:            globalRootCAs,
@@ -225,6 +263,10 @@ func serverHandleCmdArgs(ctx *cli.Context) {
 	// (non-)minio process is listening on IPv4 of given port.
 	// To avoid this error situation we check for port availability.
 	logger.FatalIf(checkPortAvailability(globalMinioHost, globalMinioPort), "Unable to start the server")
+	for _, addr := range append(globalServerBindings.API, globalServerBindings.Internode...) {
+		host, port, _ := net.SplitHostPort(addr)
+		logger.FatalIf(checkPortAvailability(host, port), "Unable to start the server")
+	}
 
 	globalIsErasure = (setupType == ErasureSetupType)
 	globalIsDistErasure = (setupType == DistErasureSetupType)
@@ -286,6 +328,9 @@ func initAllSubsystems() {
 	// Create new bucket quota subsystem
 	globalBucketQuotaSys = NewBucketQuotaSys()
 
+	// Create new tenant subsystem
+	globalTenantSys = NewTenantSys()
+
 	// Create new bucket versioning subsystem
 	if globalBucketVersioningSys == nil {
 		globalBucketVersioningSys = NewBucketVersioningSys()
@@ -488,6 +533,13 @@ func serverMain(ctx *cli.Context) {
 		logger.Info(color.RedBold("WARNING: Detected GOMAXPROCS(%d) < NumCPU(%d), please make sure to provide all PROCS to MinIO for optimal performance", maxProcs, cpuProcs))
 	}
 
+	if globalErasureSIMD.SlowPath && (globalIsErasure || globalIsDistErasure) {
+		logger.Info(color.RedBold("WARNING: Erasure coding uses the slow '%s' path on this node (detected '%s'), decoding can be several times slower than on nodes with AVX2, AVX512 or NEON", globalErasureSIMD.Active, globalErasureSIMD.Detected))
+	}
+
+	// Validate the node before it starts listening.
+	handlePreflight(GlobalContext, ctx.Bool("preflight"), ctx.Bool("preflight-strict"))
+
 	// Configure server.
 	handler, err := configureServerHandler(globalEndpoints)
 	if err != nil {
@@ -503,20 +555,50 @@ func serverMain(ctx *cli.Context) {
 	if listeners == 0 {
 		listeners = 1
 	}
-	addrs := make([]string, 0, listeners)
-	for i := 0; i < listeners; i++ {
-		addrs = append(addrs, globalMinioAddr)
+	apiAddrs := globalServerBindings.API
+	if len(apiAddrs) == 0 {
+		apiAddrs = []string{globalMinioAddr}
 	}
 
-	httpServer := xhttp.NewServer(addrs).
-		UseHandler(setCriticalErrorHandler(corsHandler(handler))).
-		UseTLSConfig(newTLSConfig(getCert)).
+	tlsConfig := newTLSConfig(getCert)
+	apiHandler := setCriticalErrorHandler(corsHandler(bucketWebsiteHandler(handler)))
+	if len(globalServerBindings.Internode) > 0 {
+		var internodeHandler http.Handler
+		apiHandler, internodeHandler = splitInternodeHandler(apiHandler)
+		internodeServer := xhttp.NewServer(listenerAddrs(globalServerBindings.Internode, listeners)).
+			UseHandler(internodeHandler).
+			UseTLSConfig(tlsConfig).
+			UseShutdownTimeout(ctx.Duration("shutdown-timeout")).
+			UseIdleTimeout(ctx.Duration("idle-timeout")).
+			UseReadHeaderTimeout(ctx.Duration("read-header-timeout")).
+			UseBaseContext(GlobalContext).
+			UseCustomLogger(log.New(io.Discard, "", 0))
+		go func() {
+			globalHTTPServerErrorCh <- internodeServer.Start(GlobalContext)
+		}()
+		setInternodeHTTPServer(internodeServer)
+	}
+	httpServer := xhttp.NewServer(listenerAddrs(apiAddrs, listeners)).
+		UseHandler(apiHandler).
+		UseTLSConfig(tlsConfig).
 		UseShutdownTimeout(ctx.Duration("shutdown-timeout")).
 		UseIdleTimeout(ctx.Duration("idle-timeout")).
 		UseReadHeaderTimeout(ctx.Duration("read-header-timeout")).
 		UseBaseContext(GlobalContext).
 		UseCustomLogger(log.New(io.Discard, "", 0)) // Turn-off random logging by Go stdlib
 
+	if globalHTTP3Enabled {
+		if globalIsTLS {
+			// HTTP/3 requests are drained along with the API server.
+			http3Server := newHTTP3Server(apiAddrs[0], httpServer.WrapHandler(apiHandler), tlsConfig)
+			httpServer.UseHandler(http3AltSvcHandler(http3Server, apiHandler))
+			setHTTP3Server(http3Server)
+			go startHTTP3Server(GlobalContext, http3Server, listenerAddrs(apiAddrs, listeners))
+		} else {
+			logger.Info(color.RedBold("WARNING: HTTP/3 requires TLS, ignoring %s", config.EnvHTTP3))
+		}
+	}
+
 	go func() {
 		globalHTTPServerErrorCh <- httpServer.Start(GlobalContext)
 	}()
@@ -543,6 +625,12 @@ func serverMain(ctx *cli.Context) {
 	initHealMRF(GlobalContext, newObject)
 	initBackgroundExpiry(GlobalContext, newObject)
 
+	// Formatted pools keep their drive order, warn about the sets
+	// that do not span enough failure domains.
+	for _, warning := range globalEndpoints.TopologyWarnings(ecDrivesNoConfig) {
+		logger.Info(color.Yellow("WARNING:") + " " + warning)
+	}
+
 	if !globalCLIContext.StrictS3Compat {
 		logger.Info(color.RedBold("WARNING: Strict AWS S3 compatible incoming PUT, POST content payload validation is turned off, caution is advised do not use in production"))
 	}
@@ -563,6 +651,10 @@ func serverMain(ctx *cli.Context) {
 		logger.LogIf(GlobalContext, err)
 	}
 
+	// Validate the peers and the identity providers once the
+	// server config is loaded.
+	handleDeploymentPreflight(GlobalContext, ctx.Bool("preflight-strict"))
+
 	if globalActiveCred.Equal(auth.DefaultCredentials) {
 		msg := fmt.Sprintf("WARNING: Detected default credentials '%s', we recommend that you change these values with 'MINIO_ROOT_USER' and 'MINIO_ROOT_PASSWORD' environment variables",
 			globalActiveCred)
@@ -613,8 +705,20 @@ func serverMain(ctx *cli.Context) {
 			}
 		}()
 
+		// Exchange node health and membership with peers and sample
+		// the network quality to them.
+		if globalIsDistErasure {
+			initGossip(GlobalContext)
+			initNetMonitor(GlobalContext)
+		}
+
 		// Initialize quota manager.
 		globalBucketQuotaSys.Init(newObject)
+		globalAdminJobs.init(GlobalContext, newObject)
+		globalUsageAccounting.init(GlobalContext, newObject)
+		if err := globalTenantSys.Load(GlobalContext, newObject); err != nil {
+			logger.LogIf(GlobalContext, fmt.Errorf("Unable to load tenants: %w", err))
+		}
 
 		initDataScanner(GlobalContext, newObject)
 
@@ -635,6 +739,20 @@ func serverMain(ctx *cli.Context) {
 		// Initialize bucket metadata sub-system.
 		globalBucketMetadataSys.Init(GlobalContext, buckets, newObject)
 
+		// Initialize server access logging of buckets.
+		logger.LogIf(GlobalContext, initBucketAccessLog(newObject))
+
+		// Purge expired objects from bucket recycle bins.
+		initBucketTrashPurge(GlobalContext, newObject)
+
+		// Roll back or forward interrupted prefix renames.
+		initRenamePrefixRecovery(GlobalContext, newObject)
+
+		// Periodically verify and repair bucket config objects.
+		if globalIsErasure {
+			initBucketMetadataHeal(GlobalContext, newObject)
+		}
+
 		// Initialize site replication manager.
 		globalSiteReplicationSys.Init(GlobalContext, newObject)
 
@@ -0,0 +1,543 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/minio/pkg/env"
+	"github.com/minio/pkg/sys"
+	"github.com/qkbyte/minio/internal/config"
+	"github.com/qkbyte/minio/internal/config/identity/ldap"
+	"github.com/qkbyte/minio/internal/config/identity/openid"
+	xhttp "github.com/qkbyte/minio/internal/http"
+	"github.com/qkbyte/minio/internal/kms"
+	"github.com/qkbyte/minio/internal/logger"
+	"github.com/shirou/gopsutil/v3/mem"
+)
+
+const (
+	preflightPass = "pass"
+	preflightWarn = "warn"
+	preflightFail = "fail"
+
+	// preflightDriveTestSize is the amount of data written, synced and
+	// read back from every local drive.
+	preflightDriveTestSize = 1 * humanize.MiByte
+
+	// preflightMinMemory is the least amount of memory a node must be
+	// allowed to use.
+	preflightMinMemory = 1 * humanize.GiByte
+
+	// preflightMinOpenFiles is the least number of file descriptors a
+	// node must be allowed to open.
+	preflightMinOpenFiles = 4096
+
+	// Clock skew between this node and a peer above which the check
+	// warns or fails respectively.
+	preflightClockSkewWarn = 2 * time.Second
+	preflightClockSkewFail = time.Minute
+
+	// preflightPeerTimeout is how long peers are given to come up,
+	// all nodes of a deployment are usually started at the same time.
+	preflightPeerTimeout = 30 * time.Second
+
+	// preflightTimeout bounds every single external check.
+	preflightTimeout = 5 * time.Second
+)
+
+// preflightCheck is the result of one startup check.
+type preflightCheck struct {
+	Name     string        `json:"name"`
+	Target   string        `json:"target,omitempty"`
+	Status   string        `json:"status"`
+	Message  string        `json:"message,omitempty"`
+	Duration time.Duration `json:"duration"`
+}
+
+// preflightReport is the structured result of all startup checks
+// of this node.
+type preflightReport struct {
+	Node   string           `json:"node"`
+	Time   time.Time        `json:"time"`
+	Passed bool             `json:"passed"`
+	Checks []preflightCheck `json:"checks"`
+}
+
+func (r *preflightReport) add(checks ...preflightCheck) {
+	r.Checks = append(r.Checks, checks...)
+}
+
+// finish sorts the checks and computes the overall result, a report
+// passes as long as none of its checks failed.
+func (r *preflightReport) finish() {
+	sort.SliceStable(r.Checks, func(i, j int) bool {
+		return r.Checks[i].Name < r.Checks[j].Name
+	})
+	r.Passed = true
+	for _, c := range r.Checks {
+		if c.Status == preflightFail {
+			r.Passed = false
+		}
+	}
+}
+
+// Failed returns all failed checks.
+func (r preflightReport) Failed() (failed []preflightCheck) {
+	for _, c := range r.Checks {
+		if c.Status == preflightFail {
+			failed = append(failed, c)
+		}
+	}
+	return failed
+}
+
+// String renders the report as human readable text, one check per line.
+func (r preflightReport) String() string {
+	var s strings.Builder
+	for _, c := range r.Checks {
+		fmt.Fprintf(&s, "%-4s  %-8s  %s", strings.ToUpper(c.Status), c.Name, c.Target)
+		if c.Message != "" {
+			fmt.Fprintf(&s, ": %s", c.Message)
+		}
+		s.WriteString("\n")
+	}
+	if r.Passed {
+		s.WriteString("Preflight checks passed\n")
+	} else {
+		fmt.Fprintf(&s, "Preflight checks failed (%d of %d)\n", len(r.Failed()), len(r.Checks))
+	}
+	return s.String()
+}
+
+func newPreflightCheck(name, target string, start time.Time, err error) preflightCheck {
+	c := preflightCheck{
+		Name:     name,
+		Target:   target,
+		Status:   preflightPass,
+		Duration: time.Since(start),
+	}
+	if err != nil {
+		c.Status = preflightFail
+		c.Message = err.Error()
+	}
+	return c
+}
+
+// runPreflightChecks runs the given checks concurrently and collects
+// their results into a single report.
+func runPreflightChecks(checks ...func() []preflightCheck) preflightReport {
+	report := preflightReport{
+		Node: globalLocalNodeName,
+		Time: UTCNow(),
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, fn := range checks {
+		wg.Add(1)
+		go func(fn func() []preflightCheck) {
+			defer wg.Done()
+			checks := fn()
+			mu.Lock()
+			report.add(checks...)
+			mu.Unlock()
+		}(fn)
+	}
+	wg.Wait()
+
+	report.finish()
+	return report
+}
+
+// localPreflightChecks validate that this node is able to run on its
+// own, they do not need the server to be listening.
+func localPreflightChecks(ctx context.Context) []func() []preflightCheck {
+	checks := []func() []preflightCheck{
+		func() []preflightCheck {
+			return checkPreflightDrives(globalEndpoints.LocalDisksPaths())
+		},
+		func() []preflightCheck {
+			return []preflightCheck{checkPreflightMemory(), checkPreflightOpenFiles()}
+		},
+	}
+	if GlobalKMS != nil {
+		checks = append(checks, func() []preflightCheck {
+			return []preflightCheck{checkPreflightKMS(ctx, GlobalKMS)}
+		})
+	}
+	return checks
+}
+
+// deploymentPreflightChecks validate that this node is able to reach
+// the other nodes of its deployment and the identity providers of the
+// given server config, environment variables take precedence over it.
+func deploymentPreflightChecks(ctx context.Context, s config.Config) []func() []preflightCheck {
+	checks := []func() []preflightCheck{
+		func() []preflightCheck {
+			return checkPreflightIDP(ctx, s)
+		},
+	}
+	if globalIsDistErasure {
+		checks = append(checks, func() []preflightCheck {
+			var remote []string
+			peers, local := globalEndpoints.peers()
+			for _, peer := range peers {
+				if peer != local {
+					remote = append(remote, peer)
+				}
+			}
+			return checkPreflightPeers(ctx, getURLScheme(globalIsTLS), remote, preflightPeerTimeout)
+		})
+	}
+	return checks
+}
+
+// checkPreflightDrives writes, syncs and reads back a test file on
+// every local drive.
+func checkPreflightDrives(drives []string) []preflightCheck {
+	checks := make([]preflightCheck, len(drives))
+	var wg sync.WaitGroup
+	for i, drive := range drives {
+		wg.Add(1)
+		go func(i int, drive string) {
+			defer wg.Done()
+			start := time.Now()
+			checks[i] = newPreflightCheck("drive", drive, start, checkPreflightDrive(drive))
+			if checks[i].Status == preflightPass {
+				checks[i].Message = fmt.Sprintf("wrote, synced and read back %s in %s",
+					humanize.IBytes(preflightDriveTestSize), checks[i].Duration.Round(time.Millisecond))
+			}
+		}(i, drive)
+	}
+	wg.Wait()
+	return checks
+}
+
+func checkPreflightDrive(drive string) (err error) {
+	if err = os.MkdirAll(drive, 0o777); err != nil {
+		return err
+	}
+
+	data := make([]byte, preflightDriveTestSize)
+	if _, err = io.ReadFull(rand.Reader, data); err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(drive, ".minio-preflight-*")
+	if err != nil {
+		return err
+	}
+	name := f.Name()
+	defer os.Remove(name)
+
+	if _, err = f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err = f.Sync(); err != nil {
+		f.Close()
+		return fmt.Errorf("fsync: %w", err)
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+
+	got, err := os.ReadFile(filepath.Clean(name))
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(got, data) {
+		return fmt.Errorf("data read back differs from the data written")
+	}
+	return nil
+}
+
+// checkPreflightMemory verifies the memory this node may use, the
+// smallest of physical memory, cgroup and process limits.
+func checkPreflightMemory() preflightCheck {
+	start := time.Now()
+	vm, err := mem.VirtualMemory()
+	if err != nil {
+		return preflightCheck{Name: "memory", Status: preflightWarn, Message: err.Error()}
+	}
+	limit := vm.Total
+	if runtime.GOOS == "linux" {
+		if l := cgroupLimit(cgroupLimitFile); l < limit {
+			limit = l
+		}
+	}
+	if _, l, err := sys.GetMaxMemoryLimit(); err == nil && l > 0 && l < limit {
+		limit = l
+	}
+
+	if limit < preflightMinMemory {
+		err = fmt.Errorf("%s usable memory is less than the required %s",
+			humanize.IBytes(limit), humanize.IBytes(preflightMinMemory))
+	}
+	c := newPreflightCheck("memory", "", start, err)
+	if err == nil {
+		c.Message = fmt.Sprintf("%s usable memory", humanize.IBytes(limit))
+	}
+	return c
+}
+
+// checkPreflightOpenFiles verifies the file descriptor limit.
+func checkPreflightOpenFiles() preflightCheck {
+	start := time.Now()
+	if runtime.GOOS == globalWindowsOSName {
+		return newPreflightCheck("ulimit", "nofile", start, nil)
+	}
+	_, limit, err := sys.GetMaxOpenFileLimit()
+	if err == nil && limit < preflightMinOpenFiles {
+		err = fmt.Errorf("maximum file descriptor limit %d is less than the required %d, fix with \"ulimit -n %d\"",
+			limit, preflightMinOpenFiles, preflightMinOpenFiles)
+	}
+	c := newPreflightCheck("ulimit", "nofile", start, err)
+	if err == nil {
+		c.Message = fmt.Sprintf("%d open files", limit)
+	}
+	return c
+}
+
+// checkPreflightPeers verifies that all peers are reachable and that
+// their clocks agree with the local clock. Peers are retried until
+// the timeout expires since they may still be starting up.
+func checkPreflightPeers(ctx context.Context, scheme string, peers []string, timeout time.Duration) []preflightCheck {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	checks := make([]preflightCheck, len(peers))
+	var wg sync.WaitGroup
+	for i, peer := range peers {
+		wg.Add(1)
+		go func(i int, peer string) {
+			defer wg.Done()
+			start := time.Now()
+			var skew time.Duration
+			var err error
+			for {
+				if skew, err = probePreflightPeer(ctx, scheme, peer); err == nil {
+					break
+				}
+				select {
+				case <-ctx.Done():
+				case <-time.After(time.Second):
+					continue
+				}
+				break
+			}
+			checks[i] = newPreflightCheck("peer", peer, start, err)
+			if err != nil {
+				return
+			}
+			checks[i].Message = fmt.Sprintf("reachable, clock skew %s", skew)
+			switch {
+			case skew > preflightClockSkewFail:
+				checks[i].Status = preflightFail
+			case skew > preflightClockSkewWarn:
+				checks[i].Status = preflightWarn
+			}
+		}(i, peer)
+	}
+	wg.Wait()
+	return checks
+}
+
+// probePreflightPeer sends a liveness request to the peer and returns
+// the difference between its clock, as reported by the HTTP Date
+// header, and the local clock.
+func probePreflightPeer(ctx context.Context, scheme, peer string) (time.Duration, error) {
+	serverURL := &url.URL{
+		Scheme: scheme,
+		Host:   peer,
+		Path:   pathJoin(healthCheckPathPrefix, healthCheckLivenessPath),
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, preflightTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, serverURL.String(), nil)
+	if err != nil {
+		return 0, err
+	}
+
+	httpClient := &http.Client{Transport: globalInternodeTransport}
+	sent := time.Now()
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer xhttp.DrainBody(resp.Body)
+	received := time.Now()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("unexpected response %s", resp.Status)
+	}
+	date, err := http.ParseTime(resp.Header.Get(xhttp.Date))
+	if err != nil {
+		return 0, fmt.Errorf("unable to read the peer clock: %w", err)
+	}
+
+	// The Date header has second precision, compare it against
+	// the middle of the request.
+	local := sent.Add(received.Sub(sent) / 2).Truncate(time.Second)
+	skew := date.Sub(local)
+	if skew < 0 {
+		skew = -skew
+	}
+	return skew, nil
+}
+
+// checkPreflightKMS verifies that the KMS is reachable and able to
+// generate and decrypt data keys with its default key.
+func checkPreflightKMS(ctx context.Context, k kms.KMS) preflightCheck {
+	ctx, cancel := context.WithTimeout(ctx, preflightTimeout)
+	defer cancel()
+
+	start := time.Now()
+	stat, err := k.Stat(ctx)
+	if err == nil {
+		_, err = checkKMS(ctx, k, stat.DefaultKey)
+	}
+	c := newPreflightCheck("kms", stat.Name, start, err)
+	if err == nil {
+		c.Message = fmt.Sprintf("default key %s available", stat.DefaultKey)
+	}
+	return c
+}
+
+// checkPreflightIDP verifies that the OpenID providers and the LDAP
+// server configured in the server config or through the environment
+// are reachable.
+func checkPreflightIDP(ctx context.Context, s config.Config) (checks []preflightCheck) {
+	targets, err := s.GetAvailableTargets(config.IdentityOpenIDSubSys)
+	if err != nil {
+		return []preflightCheck{newPreflightCheck("openid", "", time.Now(), err)}
+	}
+	for _, target := range targets {
+		configURL, _ := s.ResolveConfigParam(config.IdentityOpenIDSubSys, target, openid.ConfigURL)
+		if configURL == "" {
+			continue
+		}
+		start := time.Now()
+		checks = append(checks, newPreflightCheck("openid", configURL, start, probePreflightURL(ctx, configURL)))
+	}
+	if addr := env.Get(ldap.EnvServerAddr, s[config.IdentityLDAPSubSys][config.Default].Get(ldap.ServerAddr)); addr != "" {
+		start := time.Now()
+		dialer := &net.Dialer{Timeout: preflightTimeout}
+		conn, err := dialer.DialContext(ctx, "tcp", addr)
+		if err == nil {
+			conn.Close()
+		}
+		checks = append(checks, newPreflightCheck("ldap", addr, start, err))
+	}
+	return checks
+}
+
+func probePreflightURL(ctx context.Context, u string) error {
+	ctx, cancel := context.WithTimeout(ctx, preflightTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	httpClient := &http.Client{Transport: NewGatewayHTTPTransport()}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer xhttp.DrainBody(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected response %s", resp.Status)
+	}
+	return nil
+}
+
+// handlePreflight runs the startup checks before the server starts
+// listening. With '--preflight' all checks run, the identity providers
+// only from the environment since the server config is not loaded yet,
+// the report is printed and the process exits. With '--preflight-strict'
+// the server refuses to start when a local check failed, the remaining
+// checks follow in handleDeploymentPreflight.
+func handlePreflight(ctx context.Context, reportOnly, strict bool) {
+	if reportOnly {
+		report := runPreflightChecks(append(localPreflightChecks(ctx), deploymentPreflightChecks(ctx, nil)...)...)
+		if globalCLIContext.JSON {
+			buf, err := json.Marshal(report)
+			logger.FatalIf(err, "Unable to marshal the preflight report")
+			fmt.Println(string(buf))
+		} else {
+			fmt.Print(report.String())
+		}
+		if !report.Passed {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	if strict {
+		failPreflight(runPreflightChecks(localPreflightChecks(ctx)...))
+	}
+}
+
+// handleDeploymentPreflight runs the checks against the peers and the
+// identity providers of the loaded server config with '--preflight-strict'.
+// The peers may be running the same checks, so they only run once this
+// node is listening.
+func handleDeploymentPreflight(ctx context.Context, strict bool) {
+	if !strict {
+		return
+	}
+
+	globalServerConfigMu.RLock()
+	s := globalServerConfig.Clone()
+	globalServerConfigMu.RUnlock()
+
+	failPreflight(runPreflightChecks(deploymentPreflightChecks(ctx, s)...))
+}
+
+// failPreflight logs the checks that did not pass and refuses to start
+// the server when any of them failed.
+func failPreflight(report preflightReport) {
+	for _, c := range report.Checks {
+		if c.Status != preflightPass {
+			logger.Info("Preflight check %s %s %s: %s", c.Status, c.Name, c.Target, c.Message)
+		}
+	}
+	if !report.Passed {
+		logger.Fatal(fmt.Errorf("%d of %d preflight checks failed", len(report.Failed()), len(report.Checks)),
+			"Unable to start the server")
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/qkbyte/minio/internal/config"
+	"github.com/qkbyte/minio/internal/config/identity/ldap"
+	"github.com/qkbyte/minio/internal/config/identity/openid"
+	xhttp "github.com/qkbyte/minio/internal/http"
+)
+
+func TestPreflightReport(t *testing.T) {
+	var report preflightReport
+	report.add(
+		preflightCheck{Name: "ulimit", Status: preflightPass},
+		preflightCheck{Name: "drive", Target: "/mnt/data1", Status: preflightWarn},
+	)
+	report.finish()
+	if !report.Passed {
+		t.Fatal("expected report with warnings to pass")
+	}
+	if report.Checks[0].Name != "drive" {
+		t.Fatalf("expected checks to be sorted, got %v", report.Checks)
+	}
+
+	report.add(preflightCheck{Name: "kms", Status: preflightFail, Message: "unreachable"})
+	report.finish()
+	if report.Passed {
+		t.Fatal("expected report with a failed check to fail")
+	}
+	if failed := report.Failed(); len(failed) != 1 || failed[0].Name != "kms" {
+		t.Fatalf("unexpected failed checks %v", failed)
+	}
+	if s := report.String(); !strings.Contains(s, "FAIL  kms") || !strings.Contains(s, "failed (1 of 3)") {
+		t.Fatalf("unexpected report %q", s)
+	}
+}
+
+func TestCheckPreflightDrives(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file")
+	if err := os.WriteFile(file, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	checks := checkPreflightDrives([]string{filepath.Join(dir, "drive"), filepath.Join(file, "drive")})
+	if checks[0].Status != preflightPass {
+		t.Fatalf("expected writable drive to pass, got %v", checks[0])
+	}
+	if checks[1].Status != preflightFail {
+		t.Fatalf("expected drive below a file to fail, got %v", checks[1])
+	}
+
+	entries, err := os.ReadDir(filepath.Join(dir, "drive"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("expected the test file to be removed, got %d entries", len(entries))
+	}
+}
+
+func TestCheckPreflightPeers(t *testing.T) {
+	var skew time.Duration
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != healthCheckPathPrefix+healthCheckLivenessPath {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set(xhttp.Date, time.Now().Add(skew).UTC().Format(http.TimeFormat))
+	}))
+	defer srv.Close()
+	peer := strings.TrimPrefix(srv.URL, "http://")
+
+	testCases := []struct {
+		skew   time.Duration
+		status string
+	}{
+		{0, preflightPass},
+		{10 * time.Second, preflightWarn},
+		{-time.Hour, preflightFail},
+	}
+	for i, testCase := range testCases {
+		skew = testCase.skew
+		checks := checkPreflightPeers(context.Background(), "http", []string{peer}, time.Second)
+		if len(checks) != 1 || checks[0].Status != testCase.status {
+			t.Errorf("Test %d: expected %s, got %v", i+1, testCase.status, checks)
+		}
+	}
+
+	srv.Close()
+	checks := checkPreflightPeers(context.Background(), "http", []string{peer}, time.Second)
+	if checks[0].Status != preflightFail {
+		t.Errorf("expected unreachable peer to fail, got %v", checks[0])
+	}
+}
+
+func TestCheckPreflightIDP(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	if checks := checkPreflightIDP(context.Background(), nil); len(checks) != 0 {
+		t.Fatalf("expected no checks without identity providers, got %v", checks)
+	}
+
+	s := config.New()
+	s[config.IdentityOpenIDSubSys] = map[string]config.KVS{
+		config.Default: {{Key: openid.ConfigURL, Value: srv.URL}},
+	}
+	s[config.IdentityLDAPSubSys] = map[string]config.KVS{
+		config.Default: {{Key: ldap.ServerAddr, Value: "127.0.0.1:1"}},
+	}
+	checks := checkPreflightIDP(context.Background(), s)
+	if len(checks) != 2 {
+		t.Fatalf("expected 2 checks, got %v", checks)
+	}
+	for _, c := range checks {
+		switch c.Name {
+		case "openid":
+			if c.Status != preflightPass || c.Target != srv.URL {
+				t.Errorf("expected stored OpenID provider to pass, got %v", c)
+			}
+		case "ldap":
+			if c.Status != preflightFail {
+				t.Errorf("expected unreachable LDAP server to fail, got %v", c)
+			}
+		}
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// defaultShutdownGracePeriod is the time long-running operations are
+// given to checkpoint their state before the global context is canceled.
+const defaultShutdownGracePeriod = 10 * time.Second
+
+// errShuttingDown is returned by operations aborted by a server shutdown.
+var errShuttingDown = errors.New("server is shutting down")
+
+// shutdownCoordinator tracks long-running operations, such as listings
+// being saved and drive heals, so that on shutdown they can checkpoint
+// their progress instead of losing it when GlobalContext is canceled.
+type shutdownCoordinator struct {
+	mu      sync.Mutex
+	closing bool
+	doneCh  chan struct{}
+	active  sync.WaitGroup
+	grace   time.Duration
+	pending map[string]int
+}
+
+var globalShutdownCoordinator = newShutdownCoordinator()
+
+func newShutdownCoordinator() *shutdownCoordinator {
+	return &shutdownCoordinator{
+		doneCh:  make(chan struct{}),
+		grace:   defaultShutdownGracePeriod,
+		pending: make(map[string]int),
+	}
+}
+
+// setGracePeriod sets the time shutdown waits for registered operations.
+func (s *shutdownCoordinator) setGracePeriod(d time.Duration) {
+	s.mu.Lock()
+	s.grace = d
+	s.mu.Unlock()
+}
+
+// register records a long-running operation of the given kind, the
+// returned function must be called once the operation has returned.
+// When the server is already shutting down false is returned and the
+// operation should not be started.
+func (s *shutdownCoordinator) register(kind string) (done func(), ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closing {
+		return func() {}, false
+	}
+	s.active.Add(1)
+	s.pending[kind]++
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			s.mu.Lock()
+			s.pending[kind]--
+			if s.pending[kind] == 0 {
+				delete(s.pending, kind)
+			}
+			s.mu.Unlock()
+			s.active.Done()
+		})
+	}, true
+}
+
+// Done returns a channel that is closed when shutdown begins, registered
+// operations must checkpoint their state and return once it is closed.
+func (s *shutdownCoordinator) Done() <-chan struct{} {
+	return s.doneCh
+}
+
+// shutdown signals all registered operations to checkpoint and waits for
+// them to return for at most the grace period. It returns the number of
+// operations of each kind that were still running when the wait ended.
+func (s *shutdownCoordinator) shutdown() map[string]int {
+	s.mu.Lock()
+	if s.closing {
+		s.mu.Unlock()
+		return nil
+	}
+	s.closing = true
+	grace := s.grace
+	close(s.doneCh)
+	s.mu.Unlock()
+
+	finished := make(chan struct{})
+	go func() {
+		s.active.Wait()
+		close(finished)
+	}()
+
+	timer := time.NewTimer(grace)
+	defer timer.Stop()
+	select {
+	case <-finished:
+		return nil
+	case <-timer.C:
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	pending := make(map[string]int, len(s.pending))
+	for kind, n := range s.pending {
+		pending[kind] = n
+	}
+	return pending
+}
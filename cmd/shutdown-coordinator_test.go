@@ -0,0 +1,68 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestShutdownCoordinator(t *testing.T) {
+	s := newShutdownCoordinator()
+	s.setGracePeriod(time.Minute)
+
+	done, ok := s.register("listing")
+	if !ok {
+		t.Fatal("expected registration to succeed")
+	}
+	go func() {
+		// Checkpoint once shutdown is signaled.
+		<-s.Done()
+		done()
+		done() // calling done twice must be harmless
+	}()
+
+	start := time.Now()
+	if pending := s.shutdown(); len(pending) != 0 {
+		t.Fatalf("expected no pending operations, got %v", pending)
+	}
+	if time.Since(start) > 10*time.Second {
+		t.Fatal("shutdown did not return once operations finished")
+	}
+
+	if _, ok := s.register("heal"); ok {
+		t.Fatal("expected registration to fail after shutdown")
+	}
+	if pending := s.shutdown(); pending != nil {
+		t.Fatalf("expected repeated shutdown to be a no-op, got %v", pending)
+	}
+}
+
+func TestShutdownCoordinatorGrace(t *testing.T) {
+	s := newShutdownCoordinator()
+	s.setGracePeriod(50 * time.Millisecond)
+
+	done, _ := s.register("heal")
+	defer done()
+	s.register("listing")
+
+	pending := s.shutdown()
+	if pending["heal"] != 1 || pending["listing"] != 1 {
+		t.Fatalf("expected heal and listing to be pending, got %v", pending)
+	}
+}
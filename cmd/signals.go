@@ -47,6 +47,12 @@ func handleSignals() {
 	stopProcess := func() bool {
 		var err, oerr error
 
+		// give long-running operations a chance to checkpoint their
+		// progress before they are canceled.
+		if pending := globalShutdownCoordinator.shutdown(); len(pending) > 0 {
+			logger.Info("Shutdown grace period expired with operations still running: %v", pending)
+		}
+
 		// send signal to various go-routines that they need to quit.
 		cancelGlobalContext()
 
@@ -54,6 +60,12 @@ func handleSignals() {
 			globalEventNotifier.RemoveAllRemoteTargets()
 		}
 
+		// Deregister from the DNS store, e.g. the health checked
+		// Consul service instances of this node.
+		if globalDNSConfig != nil {
+			logger.LogIf(context.Background(), globalDNSConfig.Close())
+		}
+
 		if httpServer := newHTTPServerFn(); httpServer != nil {
 			err = httpServer.Shutdown()
 			if !errors.Is(err, http.ErrServerClosed) {
@@ -61,6 +73,17 @@ func handleSignals() {
 			}
 		}
 
+		if srv := newInternodeHTTPServerFn(); srv != nil {
+			if serr := srv.Shutdown(); !errors.Is(serr, http.ErrServerClosed) {
+				logger.LogIf(context.Background(), serr)
+			}
+		}
+
+		// HTTP/3 requests were drained by the API server shutdown above.
+		if srv := newHTTP3ServerFn(); srv != nil {
+			logger.LogIf(context.Background(), srv.Close())
+		}
+
 		if objAPI := newObjectLayerFn(); objAPI != nil {
 			oerr = objAPI.Shutdown(context.Background())
 			logger.LogIf(context.Background(), oerr)
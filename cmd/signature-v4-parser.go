@@ -50,20 +50,30 @@ func (c credentialHeader) getScope() string {
 }
 
 func getReqAccessKeyV4(r *http.Request, region string, stype serviceType) (auth.Credentials, bool, APIErrorCode) {
+	ch, s3Err := getReqCredentialHeaderV4(r, region, stype)
+	if s3Err != ErrNone {
+		return auth.Credentials{}, false, s3Err
+	}
+	return checkKeyValid(r, ch.accessKey)
+}
+
+// getReqCredentialHeaderV4 returns the credential of a presigned or signed
+// request without checking the access key or the signature.
+func getReqCredentialHeaderV4(r *http.Request, region string, stype serviceType) (credentialHeader, APIErrorCode) {
+	if isRequestSignatureV4A(r) || isRequestPresignedSignatureV4A(r) {
+		return getReqCredentialHeaderV4A(r, region, stype)
+	}
 	ch, s3Err := parseCredentialHeader("Credential="+r.Form.Get(xhttp.AmzCredential), region, stype)
 	if s3Err != ErrNone {
 		// Strip off the Algorithm prefix.
 		v4Auth := strings.TrimPrefix(r.Header.Get("Authorization"), signV4Algorithm)
 		authFields := strings.Split(strings.TrimSpace(v4Auth), ",")
 		if len(authFields) != 3 {
-			return auth.Credentials{}, false, ErrMissingFields
+			return ch, ErrMissingFields
 		}
 		ch, s3Err = parseCredentialHeader(authFields[0], region, stype)
-		if s3Err != ErrNone {
-			return auth.Credentials{}, false, s3Err
-		}
 	}
-	return checkKeyValid(r, ch.accessKey)
+	return ch, s3Err
 }
 
 // parse credentialHeader string into its structured form.
@@ -198,15 +208,20 @@ func parsePreSignV4(query url.Values, region string, stype serviceType) (psv pre
 	}
 
 	// Verify if the query algorithm is supported or not.
-	if query.Get(xhttp.AmzAlgorithm) != signV4Algorithm {
+	algorithm := query.Get(xhttp.AmzAlgorithm)
+	if algorithm != signV4Algorithm && algorithm != signV4AAlgorithm {
 		return psv, ErrInvalidQuerySignatureAlgo
 	}
 
 	// Initialize signature version '4' structured header.
 	preSignV4Values := preSignValues{}
 
-	// Save credential.
-	preSignV4Values.Credential, aec = parseCredentialHeader("Credential="+query.Get(xhttp.AmzCredential), region, stype)
+	// Save credential, SigV4A credentials have no region in the scope.
+	if algorithm == signV4AAlgorithm {
+		preSignV4Values.Credential, aec = parseCredentialHeaderV4A("Credential="+query.Get(xhttp.AmzCredential), stype)
+	} else {
+		preSignV4Values.Credential, aec = parseCredentialHeader("Credential="+query.Get(xhttp.AmzCredential), region, stype)
+	}
 	if aec != ErrNone {
 		return psv, aec
 	}
@@ -169,7 +169,7 @@ func checkKeyValid(r *http.Request, accessKey string) (auth.Credentials, bool, A
 	}
 	cred.Claims = claims
 
-	owner := cred.AccessKey == globalActiveCred.AccessKey
+	owner := cred.AccessKey == globalActiveCred.AccessKey && isOwnerPrivileged(cred.AccessKey)
 	return cred, owner, ErrNone
 }
 
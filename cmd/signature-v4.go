@@ -228,9 +228,9 @@ func doesPresignedSignatureMatch(hashedPayload string, r *http.Request, region s
 		return errCode
 	}
 
-	// If the host which signed the request is slightly ahead in time (by less than globalMaxSkewTime) the
+	// If the host which signed the request is slightly ahead in time (by less than the allowed request time skew) the
 	// request should still be allowed.
-	if pSignValues.Date.After(UTCNow().Add(globalMaxSkewTime)) {
+	if pSignValues.Date.After(UTCNow().Add(globalAPIConfig.getRequestTimeSkew())) {
 		return ErrRequestNotReadyYet
 	}
 
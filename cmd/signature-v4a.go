@@ -0,0 +1,345 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/subtle"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/minio/pkg/wildcard"
+	"github.com/qkbyte/minio/internal/auth"
+	"github.com/qkbyte/minio/internal/hash/sha256"
+	xhttp "github.com/qkbyte/minio/internal/http"
+)
+
+// AWS Signature Version '4A' constants, SigV4A is the ECDSA based
+// signature used by multi-region access points, the credential scope
+// carries no region and the regions a signature is valid for are
+// listed in the 'X-Amz-Region-Set' header or query parameter.
+const (
+	signV4AAlgorithm = "AWS4-ECDSA-P256-SHA256"
+)
+
+// Verify if request has AWS Signature Version '4A'.
+func isRequestSignatureV4A(r *http.Request) bool {
+	return strings.HasPrefix(r.Header.Get(xhttp.Authorization), signV4AAlgorithm)
+}
+
+// Verify if request has AWS PreSign Version '4A'.
+func isRequestPresignedSignatureV4A(r *http.Request) bool {
+	return r.Form.Get(xhttp.AmzAlgorithm) == signV4AAlgorithm
+}
+
+// getScopeV4A returns the SigV4A credential scope, i.e the scope
+// without region.
+func (c credentialHeader) getScopeV4A() string {
+	return strings.Join([]string{
+		c.scope.date.Format(yyyymmdd),
+		c.scope.service,
+		c.scope.request,
+	}, SlashSeparator)
+}
+
+// parse SigV4A credentialHeader string of the form
+// 'Credential=accessKey/yyyymmdd/service/aws4_request'.
+func parseCredentialHeaderV4A(credElement string, stype serviceType) (ch credentialHeader, aec APIErrorCode) {
+	creds := strings.SplitN(strings.TrimSpace(credElement), "=", 2)
+	if len(creds) != 2 {
+		return ch, ErrMissingFields
+	}
+	if creds[0] != "Credential" {
+		return ch, ErrMissingCredTag
+	}
+	credElements := strings.Split(strings.TrimSpace(creds[1]), SlashSeparator)
+	if len(credElements) < 4 {
+		return ch, ErrCredMalformed
+	}
+	accessKey := strings.Join(credElements[:len(credElements)-3], SlashSeparator) // The access key may contain one or more `/`
+	if !auth.IsAccessKeyValid(accessKey) {
+		return ch, ErrInvalidAccessKeyID
+	}
+	cred := credentialHeader{
+		accessKey: accessKey,
+	}
+	credElements = credElements[len(credElements)-3:]
+	var e error
+	cred.scope.date, e = time.Parse(yyyymmdd, credElements[0])
+	if e != nil {
+		return ch, ErrMalformedCredentialDate
+	}
+	if credElements[1] != string(stype) {
+		switch stype {
+		case serviceSTS:
+			return ch, ErrInvalidServiceSTS
+		}
+		return ch, ErrInvalidServiceS3
+	}
+	cred.scope.service = credElements[1]
+	if credElements[2] != "aws4_request" {
+		return ch, ErrInvalidRequestVersion
+	}
+	cred.scope.request = credElements[2]
+	return cred, ErrNone
+}
+
+// Parses signature version '4A' header of the following form.
+//
+//	Authorization: AWS4-ECDSA-P256-SHA256 Credential=accessKeyID/credScope, \
+//	        SignedHeaders=signedHeaders, Signature=signature
+func parseSignV4A(v4aAuth string, stype serviceType) (sv signValues, aec APIErrorCode) {
+	if !strings.HasPrefix(v4aAuth, signV4AAlgorithm) {
+		return sv, ErrSignatureVersionNotSupported
+	}
+	authFields := strings.Split(strings.TrimPrefix(v4aAuth, signV4AAlgorithm), ",")
+	if len(authFields) != 3 {
+		return sv, ErrMissingFields
+	}
+
+	var s3Err APIErrorCode
+	sv.Credential, s3Err = parseCredentialHeaderV4A(authFields[0], stype)
+	if s3Err != ErrNone {
+		return sv, s3Err
+	}
+	sv.SignedHeaders, s3Err = parseSignedHeader(strings.ReplaceAll(authFields[1], " ", ""))
+	if s3Err != ErrNone {
+		return sv, s3Err
+	}
+	sv.Signature, s3Err = parseSignature(strings.ReplaceAll(authFields[2], " ", ""))
+	if s3Err != ErrNone {
+		return sv, s3Err
+	}
+	return sv, ErrNone
+}
+
+// isRegionInSetV4A returns true if the comma separated region set of a
+// SigV4A signature includes the region, entries may use wildcards such
+// as '*' or 'us-*'.
+func isRegionInSetV4A(regionSet string, region string) bool {
+	if region == "" {
+		return true
+	}
+	for _, r := range strings.Split(regionSet, ",") {
+		r = strings.TrimSpace(r)
+		if r == "" {
+			continue
+		}
+		if wildcard.MatchSimple(r, region) || isValidRegion(r, region) {
+			return true
+		}
+	}
+	return false
+}
+
+// getReqCredentialHeaderV4A returns the credential of a presigned or
+// signed SigV4A request without checking the access key or the signature.
+func getReqCredentialHeaderV4A(r *http.Request, region string, stype serviceType) (credentialHeader, APIErrorCode) {
+	if isRequestPresignedSignatureV4A(r) {
+		if !isRegionInSetV4A(r.Form.Get(xhttp.AmzRegionSet), region) {
+			return credentialHeader{}, ErrInvalidRegion
+		}
+		return parseCredentialHeaderV4A("Credential="+r.Form.Get(xhttp.AmzCredential), stype)
+	}
+	if !isRegionInSetV4A(r.Header.Get(xhttp.AmzRegionSet), region) {
+		return credentialHeader{}, ErrInvalidRegion
+	}
+	sv, s3Err := parseSignV4A(r.Header.Get(xhttp.Authorization), stype)
+	return sv.Credential, s3Err
+}
+
+var (
+	errSigV4AKeyDerivation = errors.New("sigv4a: exhausted key derivation counter")
+
+	// N-2 of the P-256 curve order, derived keys must be below.
+	sigV4AP256NMinusTwo = new(big.Int).Sub(elliptic.P256().Params().N, big.NewInt(2))
+)
+
+// deriveSigV4AKey derives the ECDSA P-256 key pair of a credential as
+// specified by SigV4A, a NIST SP 800-108 HMAC-SHA256 KDF in counter mode
+// keyed with "AWS4A" + secretKey, retried with an external counter until
+// a candidate below N-2 is found.
+func deriveSigV4AKey(accessKey, secretKey string) (*ecdsa.PrivateKey, error) {
+	curve := elliptic.P256()
+	bitLen := curve.Params().BitSize
+
+	var lenEncoding [4]byte
+	binary.BigEndian.PutUint32(lenEncoding[:], uint32(bitLen))
+
+	mac := hmac.New(sha256.New, []byte("AWS4A"+secretKey))
+	for counter := 1; counter <= 0xFF; counter++ {
+		// A single KDF iteration yields the 256 bits needed.
+		mac.Reset()
+		mac.Write([]byte{0x00, 0x00, 0x00, 0x01})
+		mac.Write([]byte(signV4AAlgorithm))
+		mac.Write([]byte{0x00})
+		mac.Write([]byte(accessKey))
+		mac.Write([]byte{byte(counter)})
+		mac.Write(lenEncoding[:])
+		candidate := new(big.Int).SetBytes(mac.Sum(nil))
+		if candidate.Cmp(sigV4AP256NMinusTwo) > 0 {
+			continue
+		}
+		d := candidate.Add(candidate, big.NewInt(1))
+		priv := &ecdsa.PrivateKey{D: d}
+		priv.PublicKey.Curve = curve
+		priv.PublicKey.X, priv.PublicKey.Y = curve.ScalarBaseMult(d.Bytes())
+		return priv, nil
+	}
+	return nil, errSigV4AKeyDerivation
+}
+
+// getStringToSignV4A - get the string to sign of a SigV4A request.
+func getStringToSignV4A(canonicalRequest string, t time.Time, scope string) string {
+	canonicalRequestBytes := sha256.Sum256([]byte(canonicalRequest))
+	return signV4AAlgorithm + "\n" + t.Format(iso8601Format) + "\n" +
+		scope + "\n" + hex.EncodeToString(canonicalRequestBytes[:])
+}
+
+// verifySignatureV4A verifies the hex encoded ASN.1 ECDSA signature of
+// stringToSign against the key derived from the credentials.
+func verifySignatureV4A(cred auth.Credentials, stringToSign, signature string) bool {
+	sig, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	priv, err := deriveSigV4AKey(cred.AccessKey, cred.SecretKey)
+	if err != nil {
+		return false
+	}
+	digest := sha256.Sum256([]byte(stringToSign))
+	return ecdsa.VerifyASN1(&priv.PublicKey, digest[:], sig)
+}
+
+// doesSignatureV4AMatch - Verify SigV4A authorization header, the
+// canonical request is built exactly as for signature version '4'.
+//
+// returns ErrNone if signature matches.
+func doesSignatureV4AMatch(hashedPayload string, r *http.Request, region string, stype serviceType) APIErrorCode {
+	// Chunked payload signatures are not supported with SigV4A.
+	if strings.HasPrefix(hashedPayload, "STREAMING-") {
+		return ErrSignatureVersionNotSupported
+	}
+
+	sv, s3Err := parseSignV4A(r.Header.Get(xhttp.Authorization), stype)
+	if s3Err != ErrNone {
+		return s3Err
+	}
+
+	regionSet := r.Header.Get(xhttp.AmzRegionSet)
+	if regionSet == "" {
+		return ErrMissingFields
+	}
+	if !contains(sv.SignedHeaders, strings.ToLower(xhttp.AmzRegionSet)) {
+		return ErrUnsignedHeaders
+	}
+	if !isRegionInSetV4A(regionSet, region) {
+		return ErrInvalidRegion
+	}
+
+	extractedSignedHeaders, errCode := extractSignedHeaders(sv.SignedHeaders, r)
+	if errCode != ErrNone {
+		return errCode
+	}
+
+	cred, _, s3Err := checkKeyValid(r, sv.Credential.accessKey)
+	if s3Err != ErrNone {
+		return s3Err
+	}
+
+	// Extract date, if not present throw error.
+	var date string
+	if date = r.Header.Get(xhttp.AmzDate); date == "" {
+		if date = r.Header.Get(xhttp.Date); date == "" {
+			return ErrMissingDateHeader
+		}
+	}
+	t, e := time.Parse(iso8601Format, date)
+	if e != nil {
+		return ErrMalformedDate
+	}
+
+	canonicalRequest := getCanonicalRequest(extractedSignedHeaders, hashedPayload, r.Form.Encode(), r.URL.Path, r.Method)
+	stringToSign := getStringToSignV4A(canonicalRequest, t, sv.Credential.getScopeV4A())
+	if !verifySignatureV4A(cred, stringToSign, sv.Signature) {
+		return ErrSignatureDoesNotMatch
+	}
+	return ErrNone
+}
+
+// doesPresignedSignatureV4AMatch - Verify SigV4A query string signature.
+//
+// returns ErrNone if the signature matches.
+func doesPresignedSignatureV4AMatch(hashedPayload string, r *http.Request, region string, stype serviceType) APIErrorCode {
+	pSignValues, s3Err := parsePreSignV4(r.Form, region, stype)
+	if s3Err != ErrNone {
+		return s3Err
+	}
+	if !isRegionInSetV4A(r.Form.Get(xhttp.AmzRegionSet), region) {
+		return ErrInvalidRegion
+	}
+
+	cred, _, s3Err := checkKeyValid(r, pSignValues.Credential.accessKey)
+	if s3Err != ErrNone {
+		return s3Err
+	}
+
+	extractedSignedHeaders, errCode := extractSignedHeaders(pSignValues.SignedHeaders, r)
+	if errCode != ErrNone {
+		return errCode
+	}
+
+	// If the host which signed the request is slightly ahead in time (by less than the allowed request time skew) the
+	// request should still be allowed.
+	if pSignValues.Date.After(UTCNow().Add(globalAPIConfig.getRequestTimeSkew())) {
+		return ErrRequestNotReadyYet
+	}
+	if UTCNow().Sub(pSignValues.Date) > pSignValues.Expires {
+		return ErrExpiredPresignRequest
+	}
+
+	// Verify if security token is correct.
+	if token := r.Form.Get(xhttp.AmzSecurityToken); token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(cred.SessionToken)) != 1 {
+		return ErrInvalidToken
+	}
+
+	// The canonical query string is made of all query parameters
+	// but the signature itself.
+	query := make(url.Values, len(r.Form))
+	for k, v := range r.Form {
+		if k != xhttp.AmzSignature {
+			query[k] = v
+		}
+	}
+	encodedQuery := query.Encode()
+
+	canonicalRequest := getCanonicalRequest(extractedSignedHeaders, hashedPayload, encodedQuery, r.URL.Path, r.Method)
+	stringToSign := getStringToSignV4A(canonicalRequest, pSignValues.Date, pSignValues.Credential.getScopeV4A())
+	if !verifySignatureV4A(cred, stringToSign, pSignValues.Signature) {
+		return ErrSignatureDoesNotMatch
+	}
+	return ErrNone
+}
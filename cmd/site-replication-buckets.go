@@ -0,0 +1,228 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/minio/madmin-go"
+	"github.com/minio/pkg/wildcard"
+	sreplication "github.com/qkbyte/minio/internal/bucket/replication"
+	xhttp "github.com/qkbyte/minio/internal/http"
+)
+
+const setBucketFilter = "SRPeerSetBucketFilter"
+
+// srBucketFilter selects the buckets kept in sync across sites, bucket
+// names may contain '*' and '?' wildcards.
+type srBucketFilter struct {
+	// Include lists the only buckets replicated, all buckets if empty.
+	Include []string `json:"include,omitempty"`
+	// Exclude lists buckets never replicated, it takes precedence.
+	Exclude []string `json:"exclude,omitempty"`
+}
+
+// isEmpty returns true if the filter replicates all buckets.
+func (f *srBucketFilter) isEmpty() bool {
+	return f == nil || len(f.Include) == 0 && len(f.Exclude) == 0
+}
+
+// validate checks the filter does not contain empty or invalid patterns.
+func (f *srBucketFilter) validate() error {
+	if f == nil {
+		return nil
+	}
+	for _, pattern := range append(append([]string{}, f.Include...), f.Exclude...) {
+		if strings.TrimSpace(pattern) == "" || strings.Contains(pattern, SlashSeparator) {
+			return fmt.Errorf("invalid bucket pattern '%s'", pattern)
+		}
+	}
+	return nil
+}
+
+// match returns true if bucket is replicated to peer sites.
+func (f *srBucketFilter) match(bucket string) bool {
+	if f.isEmpty() {
+		return true
+	}
+	for _, pattern := range f.Exclude {
+		if wildcard.MatchSimple(pattern, bucket) {
+			return false
+		}
+	}
+	if len(f.Include) == 0 {
+		return true
+	}
+	for _, pattern := range f.Include {
+		if wildcard.MatchSimple(pattern, bucket) {
+			return true
+		}
+	}
+	return false
+}
+
+// isBucketReplicated returns true if bucket is kept in sync with peers,
+// callers must hold the lock.
+func (c *SiteReplicationSys) isBucketReplicated(bucket string) bool {
+	return c.state.BucketFilter.match(bucket)
+}
+
+// GetBucketFilter returns the buckets included and excluded from sync.
+func (c *SiteReplicationSys) GetBucketFilter() (srBucketFilter, error) {
+	c.RLock()
+	defer c.RUnlock()
+	if !c.enabled {
+		return srBucketFilter{}, errSRNotEnabled
+	}
+	if c.state.BucketFilter == nil {
+		return srBucketFilter{}, nil
+	}
+	return *c.state.BucketFilter, nil
+}
+
+// SetBucketFilter sets the buckets included and excluded from sync on
+// all sites, an empty filter replicates all buckets again.
+func (c *SiteReplicationSys) SetBucketFilter(ctx context.Context, f srBucketFilter) error {
+	if err := f.validate(); err != nil {
+		return errSRInvalidRequest(err)
+	}
+
+	c.RLock()
+	if !c.enabled {
+		c.RUnlock()
+		return errSRNotEnabled
+	}
+	cerr := c.concDo(nil, func(d string, p madmin.PeerInfo) error {
+		admClient, err := c.getAdminClient(ctx, d)
+		if err != nil {
+			return wrapSRErr(err)
+		}
+		return c.annotatePeerErr(p.Name, setBucketFilter, srPeerSetBucketFilter(ctx, admClient, f))
+	}, setBucketFilter)
+	c.RUnlock()
+
+	if err := errors.Unwrap(cerr); err != nil {
+		return errSRPeerResp(err)
+	}
+	return c.PeerSetBucketFilter(ctx, f)
+}
+
+// srPeerSetBucketFilter sends the bucket filter to a peer site.
+func srPeerSetBucketFilter(ctx context.Context, admClient *madmin.AdminClient, f srBucketFilter) error {
+	b, err := json.Marshal(f)
+	if err != nil {
+		return err
+	}
+	resp, err := admClient.ExecuteMethod(ctx, http.MethodPut, madmin.RequestData{
+		RelPath: "/" + madmin.AdminAPIVersion + "/site-replication/peer/bucket-filter",
+		Content: b,
+	})
+	if err != nil {
+		return err
+	}
+	defer xhttp.DrainBody(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		var errResp madmin.ErrorResponse
+		if err = json.NewDecoder(resp.Body).Decode(&errResp); err != nil || errResp.Code == "" {
+			return fmt.Errorf("unexpected response from peer: %s", resp.Status)
+		}
+		return errResp
+	}
+	return nil
+}
+
+// PeerSetBucketFilter - saves the bucket filter on the local site and
+// removes the site replication rules of buckets that are now excluded.
+func (c *SiteReplicationSys) PeerSetBucketFilter(ctx context.Context, f srBucketFilter) error {
+	c.RLock()
+	if !c.enabled {
+		c.RUnlock()
+		return errSRNotEnabled
+	}
+	state := c.state
+	c.RUnlock()
+
+	state.BucketFilter = nil
+	if !f.isEmpty() {
+		state.BucketFilter = &f
+	}
+	if err := c.saveToDisk(ctx, state); err != nil {
+		return errSRBackendIssue(err)
+	}
+
+	if f.isEmpty() {
+		// The heal routine configures buckets that are included again.
+		return nil
+	}
+
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return errSRObjectLayerNotReady
+	}
+	buckets, err := objAPI.ListBuckets(ctx, BucketOptions{})
+	if err != nil {
+		return errSRBackendIssue(err)
+	}
+	for _, bi := range buckets {
+		if f.match(bi.Name) {
+			continue
+		}
+		if err = removeSiteReplicationRules(ctx, bi.Name); err != nil {
+			return errSRBucketConfigError(err)
+		}
+	}
+	return nil
+}
+
+// removeSiteReplicationRules removes the replication rules created by site
+// replication from bucket, other rules are left untouched.
+func removeSiteReplicationRules(ctx context.Context, bucket string) error {
+	config, _, err := globalBucketMetadataSys.GetReplicationConfig(ctx, bucket)
+	if err != nil {
+		if errors.Is(err, BucketReplicationConfigNotFound{Bucket: bucket}) {
+			return nil
+		}
+		return err
+	}
+	var rules []sreplication.Rule
+	for _, r := range config.Rules {
+		if !strings.HasPrefix(r.ID, "site-repl-") {
+			rules = append(rules, r)
+		}
+	}
+	if len(rules) == len(config.Rules) {
+		return nil
+	}
+	if len(rules) == 0 {
+		_, err = globalBucketMetadataSys.Update(ctx, bucket, bucketReplicationConfig, nil)
+		return err
+	}
+	config.Rules = rules
+	configData, err := xml.Marshal(config)
+	if err != nil {
+		return err
+	}
+	_, err = globalBucketMetadataSys.Update(ctx, bucket, bucketReplicationConfig, configData)
+	return err
+}
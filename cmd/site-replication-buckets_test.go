@@ -0,0 +1,76 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+)
+
+func TestSRBucketFilterMatch(t *testing.T) {
+	testCases := []struct {
+		filter *srBucketFilter
+		bucket string
+		match  bool
+	}{
+		{nil, "bucket", true},
+		{&srBucketFilter{}, "bucket", true},
+		{&srBucketFilter{Exclude: []string{"scratch-*"}}, "scratch-1", false},
+		{&srBucketFilter{Exclude: []string{"scratch-*"}}, "data", true},
+		{&srBucketFilter{Include: []string{"prod-*", "logs"}}, "prod-eu", true},
+		{&srBucketFilter{Include: []string{"prod-*", "logs"}}, "logs", true},
+		{&srBucketFilter{Include: []string{"prod-*", "logs"}}, "dev", false},
+		{&srBucketFilter{Include: []string{"prod-*"}, Exclude: []string{"prod-tmp"}}, "prod-tmp", false},
+	}
+	for i, tc := range testCases {
+		if got := tc.filter.match(tc.bucket); got != tc.match {
+			t.Errorf("Test %d: expected %v for %s, got %v", i+1, tc.match, tc.bucket, got)
+		}
+	}
+}
+
+func TestSRBucketFilterValidate(t *testing.T) {
+	testCases := []struct {
+		filter  srBucketFilter
+		success bool
+	}{
+		{srBucketFilter{}, true},
+		{srBucketFilter{Include: []string{"prod-*"}, Exclude: []string{"scratch"}}, true},
+		{srBucketFilter{Include: []string{""}}, false},
+		{srBucketFilter{Exclude: []string{"a/b"}}, false},
+	}
+	for i, tc := range testCases {
+		if err := tc.filter.validate(); (err == nil) != tc.success {
+			t.Errorf("Test %d: expected success %v, got %v", i+1, tc.success, err)
+		}
+	}
+}
+
+func TestSRIsBucketReplicated(t *testing.T) {
+	c := &SiteReplicationSys{
+		enabled: true,
+		state: srState{
+			BucketFilter: &srBucketFilter{Exclude: []string{"scratch-*"}},
+		},
+	}
+	if !c.isBucketReplicated("data") {
+		t.Fatal("expected bucket 'data' to be replicated")
+	}
+	if c.isBucketReplicated("scratch-1") {
+		t.Fatal("expected bucket 'scratch-1' to be excluded")
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/minio/madmin-go"
+	xhttp "github.com/qkbyte/minio/internal/http"
+)
+
+// srFollower marks a site as a read-only follower of a primary site.
+type srFollower struct {
+	// Primary is the deployment ID of the site writes are sent to,
+	// it may be empty when no primary is advertised to clients.
+	Primary string `json:"primary,omitempty"`
+}
+
+// srFollowerStatus is returned by the follower admin APIs.
+type srFollowerStatus struct {
+	ReadOnly        bool   `json:"readOnly"`
+	Primary         string `json:"primary,omitempty"`
+	PrimaryEndpoint string `json:"primaryEndpoint,omitempty"`
+}
+
+// keepFollower returns the follower state to keep when the peers of
+// the site replication setup change, the primary is cleared if it is
+// no longer a peer.
+func (c *SiteReplicationSys) keepFollower(peers map[string]madmin.PeerInfo) *srFollower {
+	if c.state.Follower == nil {
+		return nil
+	}
+	f := *c.state.Follower
+	if _, ok := peers[f.Primary]; !ok {
+		f.Primary = ""
+	}
+	return &f
+}
+
+// followerStatus returns whether this site is read-only and the primary
+// site writes should be sent to.
+func (c *SiteReplicationSys) followerStatus() (status srFollowerStatus) {
+	c.RLock()
+	defer c.RUnlock()
+	if !c.enabled || c.state.Follower == nil {
+		return status
+	}
+	status.ReadOnly = true
+	if p, ok := c.state.Peers[c.state.Follower.Primary]; ok {
+		status.Primary = p.Name
+		status.PrimaryEndpoint = p.Endpoint
+	}
+	return status
+}
+
+// isReplicatorRequest returns true for requests made by a peer site to
+// replicate changes to this site.
+func (c *SiteReplicationSys) isReplicatorRequest(r *http.Request) bool {
+	if _, ok := r.Header[xhttp.MinIOSourceReplicationRequest]; !ok {
+		return false
+	}
+	c.RLock()
+	accessKey := c.state.ServiceAccountAccessKey
+	c.RUnlock()
+	// The signature is verified by the API handler.
+	ch, s3Err := getReqCredentialHeaderV4(r, globalSite.Region, serviceS3)
+	return s3Err == ErrNone && accessKey != "" && ch.accessKey == accessKey
+}
+
+// SetFollower makes this site a read-only follower, primary is the name
+// or deployment ID of the site writes should be sent to and may be empty.
+func (c *SiteReplicationSys) SetFollower(ctx context.Context, primary string) (srFollowerStatus, error) {
+	if !c.isEnabled() {
+		return srFollowerStatus{}, errSRNotEnabled
+	}
+
+	c.RLock()
+	state := c.state
+	f := &srFollower{}
+	if primary != "" {
+		for dID, p := range state.Peers {
+			if dID == primary || p.Name == primary {
+				f.Primary = dID
+				break
+			}
+		}
+	}
+	c.RUnlock()
+
+	switch {
+	case primary != "" && f.Primary == "":
+		return srFollowerStatus{}, errSRInvalidRequest(fmt.Errorf("unknown primary site %s", primary))
+	case f.Primary == globalDeploymentID:
+		return srFollowerStatus{}, errSRInvalidRequest(errors.New("a site cannot be its own primary"))
+	}
+
+	state.Follower = f
+	if err := c.saveToDisk(ctx, state); err != nil {
+		return srFollowerStatus{}, errSRBackendIssue(err)
+	}
+	return c.followerStatus(), nil
+}
+
+// Promote makes a read-only follower site read-write again.
+func (c *SiteReplicationSys) Promote(ctx context.Context) (srFollowerStatus, error) {
+	if !c.isEnabled() {
+		return srFollowerStatus{}, errSRNotEnabled
+	}
+
+	c.RLock()
+	state := c.state
+	c.RUnlock()
+	if state.Follower == nil {
+		return c.followerStatus(), nil
+	}
+
+	state.Follower = nil
+	if err := c.saveToDisk(ctx, state); err != nil {
+		return srFollowerStatus{}, errSRBackendIssue(err)
+	}
+	return c.followerStatus(), nil
+}
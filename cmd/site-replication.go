@@ -43,6 +43,7 @@ import (
 	iampolicy "github.com/minio/pkg/iam/policy"
 	"github.com/qkbyte/minio/internal/auth"
 	sreplication "github.com/qkbyte/minio/internal/bucket/replication"
+	"github.com/qkbyte/minio/internal/bucket/versioning"
 	"github.com/qkbyte/minio/internal/logger"
 )
 
@@ -184,6 +185,12 @@ type srStateV1 struct {
 	// Peers maps peers by their deploymentID
 	Peers                   map[string]madmin.PeerInfo `json:"peers"`
 	ServiceAccountAccessKey string                     `json:"serviceAccountAccessKey"`
+
+	// Follower is set when this site serves all buckets read-only.
+	Follower *srFollower `json:"follower,omitempty"`
+
+	// BucketFilter limits the buckets kept in sync with peer sites.
+	BucketFilter *srBucketFilter `json:"bucketFilter,omitempty"`
 }
 
 // srStateData represents the format of the current `srStateFile`.
@@ -511,10 +518,16 @@ func (c *SiteReplicationSys) AddPeerClusters(ctx context.Context, psites []madmi
 
 	// Other than handling existing buckets, we can now save the cluster
 	// replication configuration state.
+	c.RLock()
+	bucketFilter := c.state.BucketFilter
+	follower := c.keepFollower(joinReq.Peers)
+	c.RUnlock()
 	state := srState{
 		Name:                    sites[selfIdx].Name,
 		Peers:                   joinReq.Peers,
 		ServiceAccountAccessKey: svcCred.AccessKey,
+		Follower:                follower,
+		BucketFilter:            bucketFilter,
 	}
 
 	if err = c.saveToDisk(ctx, state); err != nil {
@@ -524,6 +537,16 @@ func (c *SiteReplicationSys) AddPeerClusters(ctx context.Context, psites []madmi
 		}, nil
 	}
 
+	// Newly added sites do not know the bucket filter yet.
+	if !bucketFilter.isEmpty() {
+		if err = c.SetBucketFilter(ctx, *bucketFilter); err != nil {
+			return madmin.ReplicateAddStatus{
+				Status:    madmin.ReplicateAddStatusPartial,
+				ErrDetail: fmt.Sprintf("unable to set bucket filter on peers: %v", err),
+			}, nil
+		}
+	}
+
 	result := madmin.ReplicateAddStatus{
 		Success: true,
 		Status:  madmin.ReplicateAddStatusSuccess,
@@ -561,10 +584,16 @@ func (c *SiteReplicationSys) PeerJoinReq(ctx context.Context, arg madmin.SRPeerJ
 		return errSRServiceAccount(fmt.Errorf("unable to create service account on %s: %v", ourName, err))
 	}
 
+	c.RLock()
+	bucketFilter := c.state.BucketFilter
+	follower := c.keepFollower(arg.Peers)
+	c.RUnlock()
 	state := srState{
 		Name:                    ourName,
 		Peers:                   arg.Peers,
 		ServiceAccountAccessKey: arg.SvcAcctAccessKey,
+		Follower:                follower,
+		BucketFilter:            bucketFilter,
 	}
 	if err = c.saveToDisk(ctx, state); err != nil {
 		return errSRBackendIssue(fmt.Errorf("unable to save cluster-replication state to drive on %s: %v", ourName, err))
@@ -657,7 +686,7 @@ func (c *SiteReplicationSys) MakeBucketHook(ctx context.Context, bucket string,
 
 	c.RLock()
 	defer c.RUnlock()
-	if !c.enabled {
+	if !c.enabled || !c.isBucketReplicated(bucket) {
 		return nil
 	}
 
@@ -730,7 +759,7 @@ func (c *SiteReplicationSys) DeleteBucketHook(ctx context.Context, bucket string
 
 	c.RLock()
 	defer c.RUnlock()
-	if !c.enabled {
+	if !c.enabled || !c.isBucketReplicated(bucket) {
 		return nil
 	}
 
@@ -1298,7 +1327,7 @@ func (c *SiteReplicationSys) BucketMetaHook(ctx context.Context, item madmin.SRB
 
 	c.RLock()
 	defer c.RUnlock()
-	if !c.enabled {
+	if !c.enabled || !c.isBucketReplicated(item.Bucket) {
 		return nil
 	}
 
@@ -1316,18 +1345,23 @@ func (c *SiteReplicationSys) BucketMetaHook(ctx context.Context, item madmin.SRB
 }
 
 // PeerBucketVersioningHandler - updates versioning config to local cluster.
-func (c *SiteReplicationSys) PeerBucketVersioningHandler(ctx context.Context, bucket string, versioning *string, updatedAt time.Time) error {
-	if versioning != nil {
+func (c *SiteReplicationSys) PeerBucketVersioningHandler(ctx context.Context, bucket string, vcfg *string, updatedAt time.Time) error {
+	if vcfg != nil {
 		// skip overwrite if local update is newer than peer update.
 		if !updatedAt.IsZero() {
 			if _, updateTm, err := globalBucketMetadataSys.GetVersioningConfig(bucket); err == nil && updateTm.After(updatedAt) {
 				return nil
 			}
 		}
-		configData, err := base64.StdEncoding.DecodeString(*versioning)
+		configData, err := base64.StdEncoding.DecodeString(*vcfg)
 		if err != nil {
 			return wrapSRErr(err)
 		}
+		// Sites running older releases may send configurations which
+		// were not normalized.
+		if configData, err = versioning.NormalizeConfig(configData); err != nil {
+			return wrapSRErr(err)
+		}
 		_, err = globalBucketMetadataSys.Update(ctx, bucket, bucketVersioningConfig, configData)
 		if err != nil {
 			return wrapSRErr(err)
@@ -1530,7 +1564,21 @@ func (c *SiteReplicationSys) listBuckets(ctx context.Context) ([]BucketInfo, err
 	if objAPI == nil {
 		return nil, errSRObjectLayerNotReady
 	}
-	return objAPI.ListBuckets(ctx, BucketOptions{Deleted: true})
+	buckets, err := objAPI.ListBuckets(ctx, BucketOptions{Deleted: true})
+	if err != nil {
+		return nil, err
+	}
+
+	c.RLock()
+	defer c.RUnlock()
+	n := 0
+	for _, bi := range buckets {
+		if c.isBucketReplicated(bi.Name) {
+			buckets[n] = bi
+			n++
+		}
+	}
+	return buckets[:n], nil
 }
 
 // syncToAllPeers is used for syncing local data to all remote peers, it is
@@ -2122,6 +2170,8 @@ func (c *SiteReplicationSys) RemovePeerCluster(ctx context.Context, objectAPI Ob
 			Name:                    info.Name,
 			Peers:                   updatedPeers,
 			ServiceAccountAccessKey: info.ServiceAccountAccessKey,
+			Follower:                c.keepFollower(updatedPeers),
+			BucketFilter:            c.state.BucketFilter,
 		}
 	}
 	if err = c.saveToDisk(ctx, state); err != nil {
@@ -2182,6 +2232,8 @@ func (c *SiteReplicationSys) InternalRemoveReq(ctx context.Context, objectAPI Ob
 			Name:                    c.state.Name,
 			Peers:                   updatedPeers,
 			ServiceAccountAccessKey: c.state.ServiceAccountAccessKey,
+			Follower:                c.keepFollower(updatedPeers),
+			BucketFilter:            c.state.BucketFilter,
 		}
 	}
 
@@ -2777,6 +2829,9 @@ func (c *SiteReplicationSys) siteReplicationStatus(ctx context.Context, objAPI O
 					if err != nil {
 						continue
 					}
+					if normalized, err := versioning.NormalizeConfig(configData); err == nil {
+						configData = normalized
+					}
 					versionCfgCount++
 					if !versionCfgSet.Contains(string(configData)) {
 						versionCfgSet.Add(string(configData))
@@ -3160,6 +3215,9 @@ func (c *SiteReplicationSys) SiteReplicationMetaInfo(ctx context.Context, objAPI
 		info.Buckets = make(map[string]madmin.SRBucketInfo, len(buckets))
 		for _, bucketInfo := range buckets {
 			bucket := bucketInfo.Name
+			if !c.isBucketReplicated(bucket) {
+				continue
+			}
 			bucketExists := bucketInfo.Deleted.IsZero() || (!bucketInfo.Created.IsZero() && bucketInfo.Created.After(bucketInfo.Deleted))
 			bms := madmin.SRBucketInfo{
 				Bucket:    bucket,
@@ -3978,6 +4036,9 @@ func (c *SiteReplicationSys) healVersioningMetadata(ctx context.Context, objAPI
 		if err != nil {
 			return err
 		}
+		if latestVersioningConfigBytes, err = versioning.NormalizeConfig(latestVersioningConfigBytes); err != nil {
+			return err
+		}
 	}
 
 	for dID, bStatus := range bs {
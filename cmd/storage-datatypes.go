@@ -55,6 +55,12 @@ type DiskInfo struct {
 	ID         string
 	Metrics    DiskMetrics
 	Error      string // carries the error over the network
+
+	// Serial is the serial number of the drive currently backing the
+	// disk path, RecordedSerial is the one recorded in format.json.
+	Serial           string
+	RecordedSerial   string
+	IdentityMismatch bool
 }
 
 // DiskMetrics has the information about XL Storage APIs
@@ -39,6 +39,9 @@ var errInconsistentDisk = StorageErr("inconsistent drive found")
 // errUnsupporteDisk - when disk does not support O_DIRECT flag.
 var errUnsupportedDisk = StorageErr("drive does not support O_DIRECT")
 
+// errTmpFileUnsupported - when drive does not support O_TMPFILE.
+var errTmpFileUnsupported = StorageErr("drive does not support O_TMPFILE")
+
 // errDiskFull - cannot create volume or files when disk is full.
 var errDiskFull = StorageErr("drive path full")
 
@@ -54,6 +57,9 @@ var errFaultyRemoteDisk = StorageErr("remote drive is faulty")
 // errFaultyDisk - disk is faulty.
 var errFaultyDisk = StorageErr("drive is faulty")
 
+// errDriveIdentityMismatch - drive is not the one format.json was recorded on.
+var errDriveIdentityMismatch = StorageErr("drive serial number does not match the recorded drive identity")
+
 // errDiskAccessDenied - we don't have write permissions on disk.
 var errDiskAccessDenied = StorageErr("drive access denied")
 
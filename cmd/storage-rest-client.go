@@ -59,6 +59,10 @@ func toStorageErr(err error) error {
 		return nil
 	}
 
+	if errors.Is(err, context.DeadlineExceeded) {
+		return context.DeadlineExceeded
+	}
+
 	if isNetworkError(err) {
 		return errDiskNotFound
 	}
@@ -66,6 +70,8 @@ func toStorageErr(err error) error {
 	switch err.Error() {
 	case errFaultyDisk.Error():
 		return errFaultyDisk
+	case errDriveIdentityMismatch.Error():
+		return errDriveIdentityMismatch
 	case errFileCorrupt.Error():
 		return errFileCorrupt
 	case errUnexpected.Error():
@@ -112,6 +118,8 @@ func toStorageErr(err error) error {
 		return errDiskNotFound
 	case errDiskNotFound.Error():
 		return errDiskNotFound
+	case context.DeadlineExceeded.Error():
+		return context.DeadlineExceeded
 	}
 	return err
 }
@@ -788,10 +796,12 @@ func newStorageRESTClient(endpoint Endpoint, healthcheck bool) *storageRESTClien
 	}
 
 	restClient := rest.NewClient(serverURL, globalInternodeTransport, newCachedAuthToken())
+	restClient.HandshakeFn = globalPeerHandshakes.observe
 
 	if healthcheck {
 		// Use a separate client to avoid recursive calls.
 		healthClient := rest.NewClient(serverURL, globalInternodeTransport, newCachedAuthToken())
+		healthClient.HandshakeFn = globalPeerHandshakes.observe
 		healthClient.ExpectTimeouts = true
 		healthClient.NoMetrics = true
 		restClient.HealthCheckFn = func() bool {
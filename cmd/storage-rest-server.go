@@ -43,6 +43,7 @@ import (
 	xnet "github.com/minio/pkg/net"
 	"github.com/qkbyte/minio/internal/config"
 	xhttp "github.com/qkbyte/minio/internal/http"
+	"github.com/qkbyte/minio/internal/ioprio"
 	xioutil "github.com/qkbyte/minio/internal/ioutil"
 	xjwt "github.com/qkbyte/minio/internal/jwt"
 	"github.com/qkbyte/minio/internal/logger"
@@ -91,6 +92,10 @@ func storageServerRequestValidate(r *http.Request) error {
 		return errAuthentication
 	}
 
+	if err = validatePeerHandshake(r); err != nil {
+		return err
+	}
+
 	requestTimeStr := r.Header.Get("X-Minio-Time")
 	requestTime, err := time.Parse(time.RFC3339, requestTimeStr)
 	if err != nil {
@@ -110,6 +115,7 @@ func storageServerRequestValidate(r *http.Request) error {
 
 // IsValid - To authenticate and verify the time difference.
 func (s *storageRESTServer) IsAuthValid(w http.ResponseWriter, r *http.Request) bool {
+	setPeerHandshakeHeaders(w)
 	if s.storage == nil {
 		s.writeErrorResponse(w, errDiskNotFound)
 		return false
@@ -157,6 +163,34 @@ func (s *storageRESTServer) IsValid(w http.ResponseWriter, r *http.Request) bool
 	return true
 }
 
+// storageRESTDeadlineHandler bounds the request context by the time the
+// caller has left, drive calls are abandoned once the caller gave up.
+func storageRESTDeadlineHandler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if v := r.Header.Get(xhttp.MinIODeadline); v != "" {
+			if d, err := time.ParseDuration(v); err == nil {
+				ctx, cancel := context.WithTimeout(r.Context(), d)
+				defer cancel()
+				r = r.WithContext(ctx)
+			}
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// storageRESTIOClassHandler runs the drive I/O of the request under the
+// I/O class of the caller.
+func storageRESTIOClassHandler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if v := r.Header.Get(xhttp.MinIOIOClass); v != "" {
+			if class, err := ioprio.Parse(v); err == nil {
+				r = r.WithContext(ioprio.WithClass(r.Context(), class))
+			}
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
 // HealthHandler handler checks if disk is stale
 func (s *storageRESTServer) HealthHandler(w http.ResponseWriter, r *http.Request) {
 	s.IsValid(w, r)
@@ -1339,6 +1373,8 @@ func registerStorageRESTHandlers(router *mux.Router, endpointServerPools Endpoin
 			server.storage.SetDiskID(storage.diskID)
 
 			subrouter := router.PathPrefix(path.Join(storageRESTPrefix, endpoint.Path)).Subrouter()
+			subrouter.Use(storageRESTDeadlineHandler)
+			subrouter.Use(storageRESTIOClassHandler)
 
 			subrouter.Methods(http.MethodPost).Path(storageRESTVersionPrefix + storageRESTMethodHealth).HandlerFunc(httpTraceHdrs(server.HealthHandler))
 			subrouter.Methods(http.MethodPost).Path(storageRESTVersionPrefix + storageRESTMethodDiskInfo).HandlerFunc(httpTraceHdrs(server.DiskInfoHandler))
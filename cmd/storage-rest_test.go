@@ -20,6 +20,7 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"net/http"
 	"net/http/httptest"
 	"reflect"
 	"runtime"
@@ -27,6 +28,8 @@ import (
 
 	"github.com/gorilla/mux"
 	xnet "github.com/minio/pkg/net"
+	xhttp "github.com/qkbyte/minio/internal/http"
+	"github.com/qkbyte/minio/internal/ioprio"
 )
 
 // Storage REST server, storageRESTReceiver and StorageRESTClient are
@@ -544,3 +547,30 @@ func TestStorageRESTClientRenameFile(t *testing.T) {
 
 	testStorageAPIRenameFile(t, restClient)
 }
+
+func TestStorageRESTIOClassHandler(t *testing.T) {
+	var got ioprio.Class
+	h := storageRESTIOClassHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = ioprio.FromContext(r.Context())
+	}))
+
+	testCases := []struct {
+		header string
+		class  ioprio.Class
+	}{
+		{"", ioprio.None},
+		{"idle", ioprio.Idle},
+		{"best-effort", ioprio.BestEffort},
+		{"bogus", ioprio.None},
+	}
+	for _, testCase := range testCases {
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		if testCase.header != "" {
+			req.Header.Set(xhttp.MinIOIOClass, testCase.header)
+		}
+		h.ServeHTTP(httptest.NewRecorder(), req)
+		if got != testCase.class {
+			t.Errorf("%q: expected %v, got %v", testCase.header, testCase.class, got)
+		}
+	}
+}
@@ -0,0 +1,291 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/qkbyte/minio/internal/logger"
+)
+
+const tenantsConfigFile = minioConfigPrefix + "/tenants.json"
+
+var (
+	errTenantNotFound    = errors.New("tenant not found")
+	errTenantNoMembers   = errors.New("tenant must have member buckets or a bucket prefix")
+	errTenantInvalidName = errors.New("invalid tenant name")
+)
+
+// tenant groups buckets, listed explicitly or by a common bucket name
+// prefix, whose usage is accounted against aggregate quotas. A zero quota
+// means unlimited.
+type tenant struct {
+	Name         string   `json:"name"`
+	Buckets      []string `json:"buckets,omitempty"`
+	BucketPrefix string   `json:"bucketPrefix,omitempty"`
+	MaxSize      uint64   `json:"maxSize,omitempty"`
+	MaxObjects   uint64   `json:"maxObjects,omitempty"`
+	MaxBuckets   int      `json:"maxBuckets,omitempty"`
+}
+
+// isMember returns true if bucket belongs to the tenant.
+func (t tenant) isMember(bucket string) bool {
+	if t.BucketPrefix != "" && strings.HasPrefix(bucket, t.BucketPrefix) {
+		return true
+	}
+	for _, b := range t.Buckets {
+		if b == bucket {
+			return true
+		}
+	}
+	return false
+}
+
+func (t tenant) validate() error {
+	if t.Name == "" || strings.ContainsAny(t.Name, "/\\") {
+		return errTenantInvalidName
+	}
+	if len(t.Buckets) == 0 && t.BucketPrefix == "" {
+		return errTenantNoMembers
+	}
+	if t.MaxBuckets < 0 {
+		return fmt.Errorf("tenant %s: maxBuckets must not be negative", t.Name)
+	}
+	return nil
+}
+
+// tenantUsage is the aggregate usage of a tenant, as last computed by the
+// data scanner, along with its quotas.
+type tenantUsage struct {
+	tenant
+	MemberBuckets []string `json:"memberBuckets"`
+	Size          uint64   `json:"size"`
+	Objects       uint64   `json:"objects"`
+}
+
+// TenantSys holds the tenants of this deployment.
+type TenantSys struct {
+	sync.RWMutex
+	tenants map[string]tenant
+}
+
+// NewTenantSys returns an initialized TenantSys.
+func NewTenantSys() *TenantSys {
+	return &TenantSys{tenants: make(map[string]tenant)}
+}
+
+// Load reads the tenants from the backend, replacing those in memory.
+func (sys *TenantSys) Load(ctx context.Context, objAPI ObjectLayer) error {
+	data, err := readConfig(ctx, objAPI, tenantsConfigFile)
+	if err != nil && !errors.Is(err, errConfigNotFound) {
+		return err
+	}
+	tenants := make(map[string]tenant)
+	if len(data) > 0 {
+		if err = json.Unmarshal(data, &tenants); err != nil {
+			return err
+		}
+	}
+	sys.Lock()
+	sys.tenants = tenants
+	sys.Unlock()
+	return nil
+}
+
+// save persists tenants and makes peers reload them. The caller must hold
+// the write lock.
+func (sys *TenantSys) save(ctx context.Context, objAPI ObjectLayer, tenants map[string]tenant) error {
+	data, err := json.Marshal(tenants)
+	if err != nil {
+		return err
+	}
+	if err = saveConfig(ctx, objAPI, tenantsConfigFile, data); err != nil {
+		return err
+	}
+	sys.tenants = tenants
+	if globalNotificationSys != nil {
+		globalNotificationSys.LoadTenants(ctx)
+	}
+	return nil
+}
+
+// Set adds or replaces a tenant.
+func (sys *TenantSys) Set(ctx context.Context, objAPI ObjectLayer, t tenant) error {
+	if err := t.validate(); err != nil {
+		return err
+	}
+	sys.Lock()
+	defer sys.Unlock()
+
+	tenants := make(map[string]tenant, len(sys.tenants)+1)
+	for name, v := range sys.tenants {
+		tenants[name] = v
+	}
+	tenants[t.Name] = t
+	return sys.save(ctx, objAPI, tenants)
+}
+
+// Remove deletes a tenant, its buckets are left untouched.
+func (sys *TenantSys) Remove(ctx context.Context, objAPI ObjectLayer, name string) error {
+	sys.Lock()
+	defer sys.Unlock()
+
+	if _, ok := sys.tenants[name]; !ok {
+		return errTenantNotFound
+	}
+	tenants := make(map[string]tenant, len(sys.tenants))
+	for n, v := range sys.tenants {
+		if n != name {
+			tenants[n] = v
+		}
+	}
+	return sys.save(ctx, objAPI, tenants)
+}
+
+// List returns all tenants sorted by name.
+func (sys *TenantSys) List() []tenant {
+	sys.RLock()
+	defer sys.RUnlock()
+
+	tenants := make([]tenant, 0, len(sys.tenants))
+	for _, t := range sys.tenants {
+		tenants = append(tenants, t)
+	}
+	sort.Slice(tenants, func(i, j int) bool { return tenants[i].Name < tenants[j].Name })
+	return tenants
+}
+
+// forBucket returns the tenants bucket belongs to.
+func (sys *TenantSys) forBucket(bucket string) []tenant {
+	sys.RLock()
+	defer sys.RUnlock()
+
+	var tenants []tenant
+	for _, t := range sys.tenants {
+		if t.isMember(bucket) {
+			tenants = append(tenants, t)
+		}
+	}
+	return tenants
+}
+
+// usage aggregates the usage of the member buckets of t.
+func (t tenant) usage(dui DataUsageInfo) tenantUsage {
+	tu := tenantUsage{tenant: t, MemberBuckets: []string{}}
+	for bucket, bui := range dui.BucketsUsage {
+		if !t.isMember(bucket) {
+			continue
+		}
+		tu.MemberBuckets = append(tu.MemberBuckets, bucket)
+		tu.Size += bui.Size
+		tu.Objects += bui.ObjectsCount
+	}
+	sort.Strings(tu.MemberBuckets)
+	return tu
+}
+
+// Usage returns the usage of all tenants.
+func (sys *TenantSys) Usage(dui DataUsageInfo) []tenantUsage {
+	tenants := sys.List()
+	usage := make([]tenantUsage, 0, len(tenants))
+	for _, t := range tenants {
+		usage = append(usage, t.usage(dui))
+	}
+	return usage
+}
+
+// enforceQuota returns TenantQuotaExceeded if writing size more bytes to
+// bucket exceeds the aggregate quota of one of its tenants.
+func (sys *TenantSys) enforceQuota(ctx context.Context, bucket string, size int64) error {
+	tenants := sys.forBucket(bucket)
+	if len(tenants) == 0 || size < 0 {
+		return nil
+	}
+
+	dui, err := globalBucketQuotaSys.getDataUsageInfo()
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return nil
+	}
+
+	for _, t := range tenants {
+		if t.MaxSize == 0 && t.MaxObjects == 0 {
+			continue
+		}
+		tu := t.usage(dui)
+		if t.MaxSize > 0 && tu.Size+uint64(size) > t.MaxSize {
+			return TenantQuotaExceeded{Bucket: bucket, Tenant: t.Name}
+		}
+		if t.MaxObjects > 0 && tu.Objects+1 > t.MaxObjects {
+			return TenantQuotaExceeded{Bucket: bucket, Tenant: t.Name}
+		}
+	}
+	return nil
+}
+
+// enforceBucketCount returns TenantQuotaExceeded if creating bucket
+// exceeds the bucket count quota of one of its tenants.
+func (sys *TenantSys) enforceBucketCount(ctx context.Context, objAPI ObjectLayer, bucket string) error {
+	tenants := sys.forBucket(bucket)
+	if len(tenants) == 0 {
+		return nil
+	}
+
+	var buckets []BucketInfo
+	for _, t := range tenants {
+		if t.MaxBuckets == 0 {
+			continue
+		}
+		if buckets == nil {
+			var err error
+			if buckets, err = objAPI.ListBuckets(ctx, BucketOptions{}); err != nil {
+				return err
+			}
+		}
+		var n int
+		for _, b := range buckets {
+			if t.isMember(b.Name) {
+				n++
+			}
+		}
+		if n+1 > t.MaxBuckets {
+			return TenantQuotaExceeded{Bucket: bucket, Tenant: t.Name}
+		}
+	}
+	return nil
+}
+
+func enforceTenantQuota(ctx context.Context, bucket string, size int64) error {
+	if globalTenantSys == nil {
+		return nil
+	}
+	return globalTenantSys.enforceQuota(ctx, bucket, size)
+}
+
+func enforceTenantBucketCount(ctx context.Context, objAPI ObjectLayer, bucket string) error {
+	if globalTenantSys == nil {
+		return nil
+	}
+	return globalTenantSys.enforceBucketCount(ctx, objAPI, bucket)
+}
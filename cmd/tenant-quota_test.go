@@ -0,0 +1,96 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestTenantQuota(t *testing.T) {
+	ctx := context.Background()
+
+	defer func(sys *BucketQuotaSys) { globalBucketQuotaSys = sys }(globalBucketQuotaSys)
+	globalBucketQuotaSys = NewBucketQuotaSys()
+	globalBucketQuotaSys.bucketStorageCache.Update = func() (interface{}, error) {
+		return DataUsageInfo{
+			BucketsUsage: map[string]BucketUsageInfo{
+				"acme-logs":  {Size: 600, ObjectsCount: 6},
+				"acme-data":  {Size: 300, ObjectsCount: 3},
+				"shared":     {Size: 50, ObjectsCount: 1},
+				"other-data": {Size: 5000, ObjectsCount: 50},
+			},
+		}, nil
+	}
+
+	sys := NewTenantSys()
+	sys.tenants["acme"] = tenant{
+		Name:         "acme",
+		BucketPrefix: "acme-",
+		Buckets:      []string{"shared"},
+		MaxSize:      1000,
+		MaxObjects:   12,
+	}
+
+	testCases := []struct {
+		bucket string
+		size   int64
+		exceed bool
+	}{
+		{"acme-logs", 10, false},
+		{"acme-new", 50, false},
+		{"shared", 50, false},
+		{"shared", 51, true},
+		{"acme-data", 1000, true},
+		{"other-data", 1 << 30, false},
+		{"acme-logs", -1, false},
+	}
+	for i, tc := range testCases {
+		err := sys.enforceQuota(ctx, tc.bucket, tc.size)
+		var qerr TenantQuotaExceeded
+		if got := errors.As(err, &qerr); got != tc.exceed {
+			t.Errorf("Test %d: expected quota exceeded %v, got %v", i+1, tc.exceed, err)
+		}
+	}
+
+	usage := sys.Usage(DataUsageInfo{BucketsUsage: map[string]BucketUsageInfo{
+		"acme-logs": {Size: 600, ObjectsCount: 6},
+		"shared":    {Size: 50, ObjectsCount: 1},
+		"other":     {Size: 5000, ObjectsCount: 50},
+	}})
+	if len(usage) != 1 || usage[0].Size != 650 || usage[0].Objects != 7 || len(usage[0].MemberBuckets) != 2 {
+		t.Fatalf("Unexpected tenant usage %+v", usage)
+	}
+
+	// Object count quota.
+	sys.tenants["acme"] = tenant{Name: "acme", BucketPrefix: "acme-", MaxObjects: 9}
+	if err := sys.enforceQuota(ctx, "acme-logs", 1); err == nil {
+		t.Fatal("Expected object count quota to be exceeded")
+	}
+
+	for _, tn := range []tenant{
+		{Name: "", BucketPrefix: "a"},
+		{Name: "acme"},
+		{Name: "a/b", Buckets: []string{"b"}},
+	} {
+		if err := tn.validate(); err == nil {
+			t.Errorf("Expected %+v to be invalid", tn)
+		}
+	}
+}
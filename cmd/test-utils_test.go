@@ -353,7 +353,7 @@ func initTestServerWithBackend(ctx context.Context, t TestErrHandler, testServer
 	}
 
 	// Run TestServer.
-	testServer.Server = httptest.NewUnstartedServer(setCriticalErrorHandler(corsHandler(httpHandler)))
+	testServer.Server = httptest.NewUnstartedServer(setCriticalErrorHandler(corsHandler(bucketWebsiteHandler(httpHandler))))
 
 	globalObjLayerMutex.Lock()
 	globalObjectAPI = objLayer
@@ -170,6 +170,19 @@ func (config *TierConfigMgr) ListTiers() []madmin.TierConfig {
 	return tierCfgs
 }
 
+// internalTierBucket returns the bucket objects are transitioned to if
+// tierName is an internal tier.
+func (config *TierConfigMgr) internalTierBucket(tierName string) (string, bool) {
+	config.RLock()
+	defer config.RUnlock()
+
+	t, ok := config.Tiers[tierName]
+	if !ok || !isInternalTier(t) {
+		return "", false
+	}
+	return t.MinIO.Bucket, true
+}
+
 // Edit replaces the credentials of the remote tier specified by tierName with creds.
 func (config *TierConfigMgr) Edit(ctx context.Context, tierName string, creds madmin.TierCreds) error {
 	config.Lock()
@@ -206,6 +219,9 @@ func (config *TierConfigMgr) Edit(ctx context.Context, tierName string, creds ma
 		}
 		cfg.GCS.Creds = base64.URLEncoding.EncodeToString(creds.CredsJSON)
 	case madmin.MinIO:
+		if isInternalTier(cfg) {
+			return errTierInternalNoCreds
+		}
 		if creds.AccessKey == "" || creds.SecretKey == "" {
 			return errTierInsufficientCreds
 		}
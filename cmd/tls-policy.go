@@ -0,0 +1,248 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/qkbyte/minio/internal/config/api"
+	xhttp "github.com/qkbyte/minio/internal/http"
+	"github.com/qkbyte/minio/internal/logger"
+	"golang.org/x/crypto/ocsp"
+)
+
+// ocspFetchTimeout bounds a single OCSP responder request.
+const ocspFetchTimeout = 10 * time.Second
+
+// tlsPolicySys holds the TLS policy currently enforced by the server
+// listeners. Every policy change bumps the generation so that listeners
+// rebuild their handshake configuration without a restart.
+type tlsPolicySys struct {
+	mu     sync.RWMutex
+	policy api.TLSPolicy
+	gen    uint64
+	set    bool
+}
+
+var globalTLSPolicy = &tlsPolicySys{}
+
+// update replaces the active policy, changes are logged for auditing.
+func (t *tlsPolicySys) update(p api.TLSPolicy) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.set && t.policy.Equal(p) {
+		return
+	}
+	if t.set {
+		logger.Info("TLS policy changed from '%s' to '%s'", t.policy, p)
+	}
+	t.policy = p
+	t.gen++
+	t.set = true
+}
+
+func (t *tlsPolicySys) get() (api.TLSPolicy, uint64) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.policy, t.gen
+}
+
+// applyTLSPolicy returns a copy of base with the policy overrides applied.
+func applyTLSPolicy(base *tls.Config, p api.TLSPolicy) *tls.Config {
+	c := base.Clone()
+	c.GetConfigForClient = nil
+	if p.MinVersion != 0 {
+		c.MinVersion = p.MinVersion
+	}
+	if len(p.CipherSuites) > 0 {
+		c.CipherSuites = p.CipherSuites
+	}
+	if len(p.CurvePreferences) > 0 {
+		c.CurvePreferences = p.CurvePreferences
+	}
+	if p.OCSPStapling && base.GetCertificate != nil {
+		getCert := base.GetCertificate
+		c.GetCertificate = func(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+			cert, err := getCert(hello)
+			if err != nil || cert == nil {
+				return cert, err
+			}
+			return globalOCSPStapler.staple(cert), nil
+		}
+	}
+	return c
+}
+
+// tlsPolicyConfigForClient returns a GetConfigForClient callback that
+// enforces the active TLS policy on every handshake.
+func tlsPolicyConfigForClient(base *tls.Config) func(*tls.ClientHelloInfo) (*tls.Config, error) {
+	var (
+		mu     sync.Mutex
+		gen    uint64
+		cached *tls.Config
+	)
+	return func(*tls.ClientHelloInfo) (*tls.Config, error) {
+		p, g := globalTLSPolicy.get()
+
+		mu.Lock()
+		defer mu.Unlock()
+		if cached == nil || gen != g {
+			cached = applyTLSPolicy(base, p)
+			gen = g
+		}
+		return cached, nil
+	}
+}
+
+// ocspDefaultValidity is used for responses without a next update time.
+const ocspDefaultValidity = time.Hour
+
+type ocspStaple struct {
+	raw        []byte
+	validUntil time.Time
+	refreshAt  time.Time
+	fetching   bool
+}
+
+// ocspStapler caches OCSP responses per leaf certificate. Responses are
+// fetched in the background so that handshakes never wait on a responder.
+type ocspStapler struct {
+	mu      sync.Mutex
+	staples map[string]*ocspStaple
+	fetch   func(ctx context.Context, leaf, issuer *x509.Certificate) ([]byte, time.Time, error)
+}
+
+var globalOCSPStapler = &ocspStapler{
+	staples: make(map[string]*ocspStaple),
+	fetch:   fetchOCSPResponse,
+}
+
+// staple returns cert with a valid cached OCSP response attached, a
+// refresh is triggered once half of the validity window has passed.
+func (o *ocspStapler) staple(cert *tls.Certificate) *tls.Certificate {
+	if len(cert.Certificate) < 2 {
+		return cert
+	}
+	leaf := cert.Leaf
+	if leaf == nil {
+		var err error
+		if leaf, err = x509.ParseCertificate(cert.Certificate[0]); err != nil {
+			return cert
+		}
+	}
+	if len(leaf.OCSPServer) == 0 {
+		return cert
+	}
+
+	key := string(cert.Certificate[0])
+	now := time.Now()
+
+	o.mu.Lock()
+	s, ok := o.staples[key]
+	if !ok {
+		s = &ocspStaple{}
+		o.staples[key] = s
+	}
+	if !s.fetching && (s.raw == nil || now.After(s.refreshAt)) {
+		s.fetching = true
+		go o.refresh(key, leaf, cert.Certificate[1])
+	}
+	var raw []byte
+	if s.raw != nil && now.Before(s.validUntil) {
+		raw = s.raw
+	}
+	o.mu.Unlock()
+
+	if raw == nil {
+		return cert
+	}
+	stapled := *cert
+	stapled.OCSPStaple = raw
+	return &stapled
+}
+
+func (o *ocspStapler) refresh(key string, leaf *x509.Certificate, issuerDER []byte) {
+	var (
+		raw        []byte
+		nextUpdate time.Time
+	)
+	issuer, err := x509.ParseCertificate(issuerDER)
+	if err == nil {
+		ctx, cancel := context.WithTimeout(GlobalContext, ocspFetchTimeout)
+		raw, nextUpdate, err = o.fetch(ctx, leaf, issuer)
+		cancel()
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	s := o.staples[key]
+	s.fetching = false
+	if err != nil {
+		logger.LogIf(GlobalContext, fmt.Errorf("Unable to fetch OCSP response for %s: %w", leaf.Subject, err))
+		return
+	}
+	now := time.Now()
+	if nextUpdate.IsZero() {
+		nextUpdate = now.Add(ocspDefaultValidity)
+	}
+	s.raw = raw
+	s.validUntil = nextUpdate
+	// Refresh once half of the remaining validity has passed.
+	s.refreshAt = now.Add(nextUpdate.Sub(now) / 2)
+}
+
+func fetchOCSPResponse(ctx context.Context, leaf, issuer *x509.Certificate) ([]byte, time.Time, error) {
+	req, err := ocsp.CreateRequest(leaf, issuer, nil)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, leaf.OCSPServer[0], bytes.NewReader(req))
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	httpReq.Header.Set("Content-Type", "application/ocsp-request")
+	resp, err := http.DefaultClient.Do(httpReq)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	defer xhttp.DrainBody(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return nil, time.Time{}, fmt.Errorf("OCSP responder returned %s", resp.Status)
+	}
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	parsed, err := ocsp.ParseResponseForCert(raw, leaf, issuer)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	if parsed.Status != ocsp.Good {
+		return nil, time.Time{}, fmt.Errorf("OCSP status is not good: %d", parsed.Status)
+	}
+	return raw, parsed.NextUpdate, nil
+}
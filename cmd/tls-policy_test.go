@@ -0,0 +1,126 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/qkbyte/minio/internal/config/api"
+)
+
+func TestTLSPolicyConfigForClient(t *testing.T) {
+	saved := globalTLSPolicy
+	defer func() { globalTLSPolicy = saved }()
+	globalTLSPolicy = &tlsPolicySys{}
+
+	base := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		CipherSuites: []uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256, tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384},
+	}
+	getConfig := tlsPolicyConfigForClient(base)
+
+	globalTLSPolicy.update(api.TLSPolicy{MinVersion: tls.VersionTLS12})
+	c, err := getConfig(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.MinVersion != tls.VersionTLS12 || len(c.CipherSuites) != 2 {
+		t.Fatalf("unexpected default config: %d %v", c.MinVersion, c.CipherSuites)
+	}
+	if c2, _ := getConfig(nil); c2 != c {
+		t.Fatal("expected cached config to be reused")
+	}
+
+	globalTLSPolicy.update(api.TLSPolicy{
+		MinVersion:       tls.VersionTLS13,
+		CipherSuites:     []uint16{tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384},
+		CurvePreferences: []tls.CurveID{tls.CurveP384},
+	})
+	c, err = getConfig(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.MinVersion != tls.VersionTLS13 {
+		t.Fatalf("expected TLS 1.3, got %x", c.MinVersion)
+	}
+	if len(c.CipherSuites) != 1 || c.CipherSuites[0] != tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384 {
+		t.Fatalf("unexpected cipher suites: %v", c.CipherSuites)
+	}
+	if len(c.CurvePreferences) != 1 || c.CurvePreferences[0] != tls.CurveP384 {
+		t.Fatalf("unexpected curves: %v", c.CurvePreferences)
+	}
+	if base.MinVersion != tls.VersionTLS12 || len(base.CipherSuites) != 2 {
+		t.Fatal("base configuration must not be modified")
+	}
+}
+
+func TestOCSPStapler(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		OCSPServer:   []string{"http://ocsp.example.com"},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert := &tls.Certificate{Certificate: [][]byte{der, der}, PrivateKey: key}
+
+	fetched := make(chan struct{}, 1)
+	o := &ocspStapler{
+		staples: make(map[string]*ocspStaple),
+		fetch: func(ctx context.Context, leaf, issuer *x509.Certificate) ([]byte, time.Time, error) {
+			defer func() { fetched <- struct{}{} }()
+			return []byte("staple"), time.Now().Add(time.Hour), nil
+		},
+	}
+
+	// The first handshake triggers a background fetch without waiting.
+	if got := o.staple(cert); got.OCSPStaple != nil {
+		t.Fatal("unexpected staple before fetch completed")
+	}
+	<-fetched
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		got := o.staple(cert)
+		if bytes.Equal(got.OCSPStaple, []byte("staple")) {
+			if cert.OCSPStaple != nil {
+				t.Fatal("original certificate must not be modified")
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("staple was never attached")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,208 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/dustin/go-humanize"
+)
+
+const (
+	// transferDefaultStreams is the default upper bound of concurrent
+	// streams used to move a single object between pools.
+	transferDefaultStreams = 8
+	transferMaxStreams     = 64
+
+	// transferMinStreams is where the adaptive window starts, and the
+	// lowest it shrinks to.
+	transferMinStreams = 2
+
+	// transferSampleBytes is the amount of data moved between two
+	// adjustments of the window.
+	transferSampleBytes = 256 * humanize.MiByte
+)
+
+// transferManager moves the parts of an object over several concurrent
+// streams, this is used by decommission to copy multipart objects.
+//
+// The number of streams, the window, adapts to the observed throughput
+// of all transfers: it grows as long as adding a stream makes the
+// transfers faster and shrinks once it makes them slower.
+//
+// Transitions to remote tiers are not parallelized here, warm backends
+// consume a single stream.
+type transferManager struct {
+	maxStreams int
+
+	mu       sync.Mutex
+	window   int
+	active   int
+	sampled  int64
+	since    time.Time
+	lastRate float64 // bytes per second at the previous window
+}
+
+func newTransferManager(maxStreams int) *transferManager {
+	window := transferMinStreams
+	if window > maxStreams {
+		window = maxStreams
+	}
+	return &transferManager{
+		maxStreams: maxStreams,
+		window:     window,
+	}
+}
+
+// parseTransferStreams parses the MINIO_TRANSFER_STREAMS value, 1
+// disables parallel transfers.
+func parseTransferStreams(s string) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, err
+	}
+	if n < 1 || n > transferMaxStreams {
+		return 0, fmt.Errorf("transfer streams must be between 1 and %d", transferMaxStreams)
+	}
+	return n, nil
+}
+
+// streams returns the current window.
+func (t *transferManager) streams() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.window
+}
+
+func (t *transferManager) begin() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.active == 0 {
+		// Do not account idle time between transfers.
+		t.sampled = 0
+		t.since = time.Now()
+	}
+	t.active++
+}
+
+func (t *transferManager) end() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.active--
+}
+
+// record accounts n bytes moved and adjusts the window once enough
+// data was moved to measure the throughput.
+func (t *transferManager) record(n int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.sampled += n
+	if t.sampled < transferSampleBytes {
+		return
+	}
+	elapsed := time.Since(t.since)
+	if elapsed <= 0 {
+		return
+	}
+	t.adjust(float64(t.sampled) / elapsed.Seconds())
+	t.sampled = 0
+	t.since = time.Now()
+}
+
+// adjust moves the window based on the throughput measured at the
+// current window compared to the previous one.
+func (t *transferManager) adjust(rate float64) {
+	switch {
+	case t.lastRate == 0 || rate > t.lastRate*1.05:
+		if t.window < t.maxStreams {
+			t.window++
+		}
+	case rate < t.lastRate*0.9:
+		if t.window > transferMinStreams {
+			t.window--
+		}
+	}
+	t.lastRate = rate
+}
+
+// transfer calls fn for each of the n parts of an object, running at
+// most streams() of them at a time. fn returns the number of bytes it
+// moved. The first error cancels the parts not yet started and is
+// returned once the running ones are done.
+func (t *transferManager) transfer(ctx context.Context, n int, fn func(ctx context.Context, part int) (int64, error)) error {
+	t.begin()
+	defer t.end()
+
+	tctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+		done     = make(chan struct{}, n)
+		inflight int
+		started  int
+	)
+
+parts:
+	for part := 0; part < n; part++ {
+		for inflight >= t.streams() {
+			select {
+			case <-done:
+				inflight--
+			case <-tctx.Done():
+				break parts
+			}
+		}
+		if tctx.Err() != nil {
+			break
+		}
+		inflight++
+		started++
+		wg.Add(1)
+		go func(part int) {
+			defer wg.Done()
+			defer func() { done <- struct{}{} }()
+			m, err := fn(tctx, part)
+			if err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				mu.Unlock()
+				cancel()
+				return
+			}
+			t.record(m)
+		}(part)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	if started < n {
+		return ctx.Err()
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParseTransferStreams(t *testing.T) {
+	for _, test := range []struct {
+		value   string
+		streams int
+		success bool
+	}{
+		{"1", 1, true},
+		{"16", 16, true},
+		{"0", 0, false},
+		{"65", 0, false},
+		{"many", 0, false},
+	} {
+		streams, err := parseTransferStreams(test.value)
+		if (err == nil) != test.success {
+			t.Errorf("%q: unexpected error %v", test.value, err)
+		}
+		if streams != test.streams {
+			t.Errorf("%q: expected %d streams, got %d", test.value, test.streams, streams)
+		}
+	}
+}
+
+func TestTransferManagerAdjust(t *testing.T) {
+	tm := newTransferManager(4)
+	if tm.streams() != transferMinStreams {
+		t.Fatalf("expected window to start at %d, got %d", transferMinStreams, tm.streams())
+	}
+	// Throughput keeps improving, the window grows up to the limit.
+	for _, rate := range []float64{100, 200, 300, 400} {
+		tm.adjust(rate)
+	}
+	if tm.streams() != 4 {
+		t.Fatalf("expected window of 4, got %d", tm.streams())
+	}
+	// Throughput drops, the window shrinks down to the minimum.
+	for _, rate := range []float64{200, 100, 50, 25} {
+		tm.adjust(rate)
+	}
+	if tm.streams() != transferMinStreams {
+		t.Fatalf("expected window of %d, got %d", transferMinStreams, tm.streams())
+	}
+	// Stable throughput keeps the window.
+	tm.adjust(25)
+	if tm.streams() != transferMinStreams {
+		t.Fatalf("expected window of %d, got %d", transferMinStreams, tm.streams())
+	}
+
+	if tm := newTransferManager(1); tm.streams() != 1 {
+		t.Fatalf("expected window of 1, got %d", tm.streams())
+	}
+}
+
+func TestTransferManagerTransfer(t *testing.T) {
+	tm := newTransferManager(4)
+	var running, maxRunning, total int32
+	err := tm.transfer(context.Background(), 10, func(ctx context.Context, part int) (int64, error) {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			m := atomic.LoadInt32(&maxRunning)
+			if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		atomic.AddInt32(&total, 1)
+		return 1, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 10 {
+		t.Errorf("expected 10 parts, got %d", total)
+	}
+	if maxRunning > int32(tm.streams()) {
+		t.Errorf("expected at most %d concurrent parts, got %d", tm.streams(), maxRunning)
+	}
+
+	errPart := errors.New("part failed")
+	var started int32
+	err = tm.transfer(context.Background(), 100, func(ctx context.Context, part int) (int64, error) {
+		atomic.AddInt32(&started, 1)
+		if part == 1 {
+			return 0, errPart
+		}
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+	if err != errPart {
+		t.Errorf("expected %v, got %v", errPart, err)
+	}
+	if started == 100 {
+		t.Error("expected remaining parts to be canceled")
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// trashJanitor permanently deletes the entries of the ".trash" folder
+// of the local drives. Entries are kept for at least the configured
+// quarantine, unless the folder grows beyond the configured size in
+// which case the oldest entries are deleted first.
+type trashJanitor struct {
+	reclaimedBytes   uint64
+	reclaimedEntries uint64
+
+	mu     sync.Mutex
+	drives map[string]*trashDrive
+}
+
+// trashDrive is the last known ".trash" content of a drive.
+type trashDrive struct {
+	entries map[string]trashEntry
+	backlog int64
+}
+
+type trashEntry struct {
+	// seen is when the entry was first found, renaming into the
+	// ".trash" folder does not change the entry modtime.
+	seen time.Time
+	size int64
+}
+
+// trashStats is a snapshot of the trash backlog of the local drives
+// and of the space reclaimed so far.
+type trashStats struct {
+	BacklogBytes     int64
+	BacklogEntries   int64
+	ReclaimedBytes   uint64
+	ReclaimedEntries uint64
+}
+
+var globalTrashJanitor = &trashJanitor{drives: make(map[string]*trashDrive)}
+
+// cleanup enforces the quarantine and the size budget on the ".trash"
+// folder of the drive at diskPath, sleeper paces the deletes.
+func (j *trashJanitor) cleanup(ctx context.Context, diskPath string, sleeper *dynamicSleeper) {
+	quarantine, maxSize := globalAPIConfig.getDeleteCleanupBudget()
+	trashPath := pathJoin(diskPath, minioMetaTmpDeletedBucket)
+	now := time.Now()
+
+	j.mu.Lock()
+	d, ok := j.drives[diskPath]
+	if !ok {
+		d = &trashDrive{entries: make(map[string]trashEntry)}
+		j.drives[diskPath] = d
+	}
+	known := d.entries
+	j.mu.Unlock()
+
+	var backlog int64
+	current := make(map[string]trashEntry, len(known))
+	readDirFn(trashPath, func(name string, typ os.FileMode) error {
+		e, ok := known[name]
+		if !ok {
+			e = trashEntry{seen: now, size: trashEntrySize(pathJoin(trashPath, name))}
+		}
+		current[name] = e
+		backlog += e.size
+		return nil
+	})
+
+	names := make([]string, 0, len(current))
+	for name := range current {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, k int) bool {
+		ei, ek := current[names[i]], current[names[k]]
+		if ei.seen.Equal(ek.seen) {
+			return names[i] < names[k]
+		}
+		return ei.seen.Before(ek.seen)
+	})
+
+	for _, name := range names {
+		if contextCanceled(ctx) {
+			break
+		}
+		e := current[name]
+		overBudget := maxSize > 0 && backlog > int64(maxSize)
+		if now.Sub(e.seen) < quarantine && !overBudget {
+			// Oldest first, all remaining entries are quarantined.
+			break
+		}
+		wait := sleeper.Timer(ctx)
+		err := removeAll(pathJoin(trashPath, name))
+		wait()
+		if err != nil {
+			continue
+		}
+		delete(current, name)
+		backlog -= e.size
+		atomic.AddUint64(&j.reclaimedBytes, uint64(e.size))
+		atomic.AddUint64(&j.reclaimedEntries, 1)
+	}
+
+	j.mu.Lock()
+	d.entries = current
+	d.backlog = backlog
+	j.mu.Unlock()
+}
+
+// stats returns the trash backlog of the local drives, as of their
+// last cleanup, along with the space reclaimed since startup.
+func (j *trashJanitor) stats() trashStats {
+	st := trashStats{
+		ReclaimedBytes:   atomic.LoadUint64(&j.reclaimedBytes),
+		ReclaimedEntries: atomic.LoadUint64(&j.reclaimedEntries),
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	for _, d := range j.drives {
+		st.BacklogBytes += d.backlog
+		st.BacklogEntries += int64(len(d.entries))
+	}
+	return st
+}
+
+// trashEntrySize returns the size of all the files under path.
+func trashEntrySize(path string) (size int64) {
+	filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		if fi, err := d.Info(); err == nil {
+			size += fi.Size()
+		}
+		return nil
+	})
+	return size
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestTrashJanitorCleanup(t *testing.T) {
+	diskPath := t.TempDir()
+	trashPath := pathJoin(diskPath, minioMetaTmpDeletedBucket)
+
+	addEntry := func(name string, size int) {
+		t.Helper()
+		if err := os.MkdirAll(pathJoin(trashPath, name), 0o777); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(pathJoin(trashPath, name, "part.1"), make([]byte, size), 0o666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	exists := func(name string) bool {
+		_, err := os.Stat(pathJoin(trashPath, name))
+		return err == nil
+	}
+	setBudget := func(quarantine time.Duration, maxSize uint64) {
+		globalAPIConfig.mu.Lock()
+		globalAPIConfig.deleteCleanupQuarantine = quarantine
+		globalAPIConfig.deleteCleanupMaxSize = maxSize
+		globalAPIConfig.mu.Unlock()
+	}
+	defer setBudget(0, 0)
+
+	ctx := context.Background()
+	sleeper := newDynamicSleeper(1, time.Millisecond, false)
+	j := &trashJanitor{drives: make(map[string]*trashDrive)}
+
+	// Everything is quarantined.
+	setBudget(time.Hour, 0)
+	addEntry("a", 100)
+	j.cleanup(ctx, diskPath, sleeper)
+	addEntry("b", 200)
+	addEntry("c", 300)
+	j.cleanup(ctx, diskPath, sleeper)
+	if !exists("a") || !exists("b") || !exists("c") {
+		t.Fatal("quarantined entries must not be deleted")
+	}
+	st := j.stats()
+	if st.BacklogBytes != 600 || st.BacklogEntries != 3 || st.ReclaimedBytes != 0 {
+		t.Fatalf("unexpected stats %+v", st)
+	}
+
+	// Over budget, the oldest entries go first.
+	setBudget(time.Hour, 350)
+	j.cleanup(ctx, diskPath, sleeper)
+	if exists("a") || exists("b") || !exists("c") {
+		t.Fatal("expected the oldest entries to be deleted first")
+	}
+	st = j.stats()
+	if st.BacklogBytes != 300 || st.BacklogEntries != 1 || st.ReclaimedBytes != 300 || st.ReclaimedEntries != 2 {
+		t.Fatalf("unexpected stats %+v", st)
+	}
+
+	// Without quarantine everything is deleted.
+	setBudget(0, 0)
+	j.cleanup(ctx, diskPath, sleeper)
+	if exists("c") {
+		t.Fatal("expected all entries to be deleted")
+	}
+	st = j.stats()
+	if st.BacklogBytes != 0 || st.BacklogEntries != 0 || st.ReclaimedBytes != 600 || st.ReclaimedEntries != 3 {
+		t.Fatalf("unexpected stats %+v", st)
+	}
+}
@@ -97,6 +97,9 @@ var errIAMServiceAccount = errors.New("Specified service account cannot be updat
 // error returned in IAM service account is already used.
 var errIAMServiceAccountUsed = errors.New("Specified service account is used by another user")
 
+// error returned when the parent user already owns the maximum number of service accounts.
+var errServiceAccountLimitExceeded = errors.New("The user has reached the maximum number of service accounts")
+
 // error returned in IAM subsystem when IAM sub-system is still being initialized.
 var errIAMNotInitialized = errors.New("IAM sub-system is being initialized, please try again")
 
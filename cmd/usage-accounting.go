@@ -0,0 +1,396 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/qkbyte/minio/internal/logger"
+)
+
+const (
+	// Usage is rolled up per hour and access key on every node.
+	usageAccountingPrefix     = "usage"
+	usageAccountingHourFormat = "2006-01-02T15"
+
+	usageAccountingFlushInterval = time.Minute
+	usageAccountingRetention     = 90 * 24 * time.Hour
+
+	// Maximum number of access keys accounted per hour on a node,
+	// requests of other keys are accounted as anonymous.
+	usageAccountingMaxKeys = 100000
+
+	// Maximum time range of an export.
+	usageAccountingMaxExport = 31 * 24 * time.Hour
+)
+
+// accessKeyUsage is the usage of an access key during an hour, anonymous
+// requests are accounted with an empty access key.
+type accessKeyUsage struct {
+	AccessKey string `json:"accessKey"`
+	Requests  uint64 `json:"requests"`
+	Errors    uint64 `json:"errors"`
+	RxBytes   uint64 `json:"rxBytes"`
+	TxBytes   uint64 `json:"txBytes"`
+}
+
+func (u *accessKeyUsage) merge(o accessKeyUsage) {
+	u.Requests += o.Requests
+	u.Errors += o.Errors
+	u.RxBytes += o.RxBytes
+	u.TxBytes += o.TxBytes
+}
+
+// usageRollup is the usage of all access keys during an hour on a node.
+type usageRollup struct {
+	Hour time.Time                  `json:"hour"`
+	Keys map[string]*accessKeyUsage `json:"keys"`
+}
+
+func (r *usageRollup) add(u accessKeyUsage) {
+	if r.Keys == nil {
+		r.Keys = make(map[string]*accessKeyUsage)
+	}
+	s, ok := r.Keys[u.AccessKey]
+	if !ok {
+		if len(r.Keys) >= usageAccountingMaxKeys {
+			u.AccessKey = ""
+			if s, ok = r.Keys[""]; ok {
+				s.merge(u)
+				return
+			}
+		}
+		s = &accessKeyUsage{AccessKey: u.AccessKey}
+		r.Keys[u.AccessKey] = s
+	}
+	s.merge(u)
+}
+
+// liveUsageRollup accounts the requests of an hour on this node, the
+// usage of an access key is updated atomically so that requests are
+// not serialized.
+type liveUsageRollup struct {
+	nkeys int64
+	gen   uint64 // incremented by every recorded request
+	hour  time.Time
+	keys  sync.Map // access key -> *accessKeyUsage
+
+	// Guarded by usageAccounting.flushMu.
+	saved  uint64 // gen of the last persisted copy
+	loaded bool   // the persisted rollup was merged
+}
+
+func (r *liveUsageRollup) add(u accessKeyUsage) {
+	v, ok := r.keys.Load(u.AccessKey)
+	if !ok {
+		if atomic.LoadInt64(&r.nkeys) >= usageAccountingMaxKeys {
+			u.AccessKey = ""
+		}
+		var loaded bool
+		v, loaded = r.keys.LoadOrStore(u.AccessKey, &accessKeyUsage{AccessKey: u.AccessKey})
+		if !loaded {
+			atomic.AddInt64(&r.nkeys, 1)
+		}
+	}
+	s := v.(*accessKeyUsage)
+	atomic.AddUint64(&s.Requests, u.Requests)
+	atomic.AddUint64(&s.Errors, u.Errors)
+	atomic.AddUint64(&s.RxBytes, u.RxBytes)
+	atomic.AddUint64(&s.TxBytes, u.TxBytes)
+	atomic.AddUint64(&r.gen, 1)
+}
+
+func (r *liveUsageRollup) snapshot() *usageRollup {
+	c := &usageRollup{Hour: r.hour, Keys: make(map[string]*accessKeyUsage)}
+	r.keys.Range(func(k, v interface{}) bool {
+		s := v.(*accessKeyUsage)
+		c.Keys[k.(string)] = &accessKeyUsage{
+			AccessKey: s.AccessKey,
+			Requests:  atomic.LoadUint64(&s.Requests),
+			Errors:    atomic.LoadUint64(&s.Errors),
+			RxBytes:   atomic.LoadUint64(&s.RxBytes),
+			TxBytes:   atomic.LoadUint64(&s.TxBytes),
+		}
+		return true
+	})
+	return c
+}
+
+// usageAccounting accounts the S3 requests and bytes received and sent
+// per access key on this node, and persists hourly rollups in
+// .minio.sys for billing.
+type usageAccounting struct {
+	rollups sync.Map // hour in unix seconds -> *liveUsageRollup
+
+	flushMu   sync.Mutex
+	lastPurge time.Time
+}
+
+func newUsageAccounting() *usageAccounting {
+	return &usageAccounting{}
+}
+
+// usageRollupFile - the object holding the rollup of an hour of a node.
+func usageRollupFile(hour time.Time, node int) string {
+	return fmt.Sprintf("%s/%s/node-%d.json", usageAccountingPrefix, hour.UTC().Format(usageAccountingHourFormat), node)
+}
+
+// usageAccountingNodes returns the number of nodes and the index of
+// this node.
+func usageAccountingNodes() (nodes, local int) {
+	nodes, local = len(globalProxyEndpoints), GetProxyEndpointLocalIndex(globalProxyEndpoints)
+	if nodes == 0 {
+		nodes = 1
+	}
+	if local < 0 {
+		local = 0
+	}
+	return nodes, local
+}
+
+// usageAccountingCtxKey - the context key of the access key a request
+// was authenticated with, see setUsageAccessKey.
+type usageAccountingCtxKey struct{}
+
+// usageAccessKey holds the access key a request was authenticated
+// with, requests which fail authentication are not accounted.
+type usageAccessKey struct {
+	accessKey     string
+	authenticated bool
+}
+
+// withUsageAccessKey returns a context the authenticated access key of
+// the request is recorded in, an existing holder of ctx is shared.
+func withUsageAccessKey(ctx context.Context) (context.Context, *usageAccessKey) {
+	if k, ok := ctx.Value(usageAccountingCtxKey{}).(*usageAccessKey); ok {
+		return ctx, k
+	}
+	k := &usageAccessKey{}
+	return context.WithValue(ctx, usageAccountingCtxKey{}, k), k
+}
+
+// setUsageAccessKey records the access key the request of ctx was
+// authenticated with, an empty access key for anonymous requests.
+func setUsageAccessKey(ctx context.Context, accessKey string) {
+	if k, ok := ctx.Value(usageAccountingCtxKey{}).(*usageAccessKey); ok {
+		k.accessKey, k.authenticated = accessKey, true
+	}
+}
+
+// record accounts a served request, failed requests are only counted
+// as errors.
+func (u *usageAccounting) record(accessKey string, rx, tx uint64, failed bool, now time.Time) {
+	usage := accessKeyUsage{
+		AccessKey: accessKey,
+		Requests:  1,
+		RxBytes:   rx,
+		TxBytes:   tx,
+	}
+	if failed {
+		usage = accessKeyUsage{AccessKey: accessKey, Errors: 1}
+	}
+
+	hour := now.UTC().Truncate(time.Hour)
+	v, ok := u.rollups.Load(hour.Unix())
+	if !ok {
+		v, _ = u.rollups.LoadOrStore(hour.Unix(), &liveUsageRollup{hour: hour})
+	}
+	v.(*liveUsageRollup).add(usage)
+}
+
+// flush persists the rollups changed since the last flush, rollups of
+// past hours are dropped from memory once persisted. Rollups which
+// expired are purged once an hour.
+func (u *usageAccounting) flush(ctx context.Context, objAPI ObjectLayer, now time.Time) error {
+	u.flushMu.Lock()
+	defer u.flushMu.Unlock()
+
+	_, node := usageAccountingNodes()
+	current := now.UTC().Truncate(time.Hour)
+
+	var firstErr error
+	u.rollups.Range(func(k, v interface{}) bool {
+		r := v.(*liveUsageRollup)
+		gen := atomic.LoadUint64(&r.gen)
+		if gen == r.saved && r.loaded {
+			return true
+		}
+
+		file := usageRollupFile(r.hour, node)
+		if !r.loaded {
+			// Continue the rollup persisted before a restart.
+			data, err := readConfig(ctx, objAPI, file)
+			if err != nil && !errors.Is(err, errConfigNotFound) {
+				if firstErr == nil {
+					firstErr = err
+				}
+				return true
+			}
+			var persisted usageRollup
+			if len(data) > 0 {
+				if err = json.Unmarshal(data, &persisted); err != nil {
+					logger.LogIf(ctx, fmt.Errorf("Unable to parse usage rollup %s: %w", file, err))
+				}
+			}
+			for _, usage := range persisted.Keys {
+				r.add(*usage)
+			}
+			r.loaded = true
+			gen = atomic.LoadUint64(&r.gen)
+		}
+
+		data, err := json.Marshal(r.snapshot())
+		if err == nil {
+			err = saveConfig(ctx, objAPI, file, data)
+		}
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			return true
+		}
+		r.saved = gen
+		if r.hour.Before(current) && atomic.LoadUint64(&r.gen) == gen {
+			u.rollups.Delete(k)
+		}
+		return true
+	})
+
+	if !u.lastPurge.Equal(current) {
+		u.lastPurge = current
+		if err := purgeUsageRollups(ctx, objAPI, node, current.Add(-usageAccountingRetention)); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// purgeUsageRollups removes the rollups of node of the hours before
+// expired, each node removes its own rollups.
+func purgeUsageRollups(ctx context.Context, objAPI ObjectLayer, node int, expired time.Time) error {
+	prefix := usageAccountingPrefix + SlashSeparator
+	marker := ""
+	for {
+		res, err := objAPI.ListObjects(ctx, minioMetaBucket, prefix, marker, SlashSeparator, maxObjectList)
+		if err != nil {
+			return err
+		}
+		for _, p := range res.Prefixes {
+			hour, err := time.Parse(usageAccountingHourFormat, strings.TrimSuffix(strings.TrimPrefix(p, prefix), SlashSeparator))
+			if err != nil || !hour.Before(expired) {
+				continue
+			}
+			if err = deleteConfig(ctx, objAPI, usageRollupFile(hour, node)); err != nil && !errors.Is(err, errConfigNotFound) {
+				return err
+			}
+		}
+		if !res.IsTruncated {
+			return nil
+		}
+		marker = res.NextMarker
+	}
+}
+
+// init periodically persists the rollups of this node.
+func (u *usageAccounting) init(ctx context.Context, objAPI ObjectLayer) {
+	go func() {
+		t := time.NewTicker(usageAccountingFlushInterval)
+		defer t.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-t.C:
+				logger.LogIf(ctx, u.flush(ctx, objAPI, time.Now()))
+			}
+		}
+	}()
+}
+
+// usageRecord is the cluster wide usage of an access key during an hour.
+type usageRecord struct {
+	Hour time.Time `json:"hour"`
+	accessKeyUsage
+}
+
+// readUsageRecords returns the cluster wide usage of the hours between
+// from and to, optionally of a single access key, ordered by hour and
+// access key.
+func readUsageRecords(ctx context.Context, objAPI ObjectLayer, from, to time.Time, accessKey string) ([]usageRecord, error) {
+	nodes, _ := usageAccountingNodes()
+	var records []usageRecord
+	for hour := from.UTC().Truncate(time.Hour); hour.Before(to); hour = hour.Add(time.Hour) {
+		merged := &usageRollup{Hour: hour}
+		for node := 0; node < nodes; node++ {
+			data, err := readConfig(ctx, objAPI, usageRollupFile(hour, node))
+			if errors.Is(err, errConfigNotFound) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			var rollup usageRollup
+			if err = json.Unmarshal(data, &rollup); err != nil {
+				return nil, err
+			}
+			for _, usage := range rollup.Keys {
+				if accessKey == "" || usage.AccessKey == accessKey {
+					merged.add(*usage)
+				}
+			}
+		}
+		start := len(records)
+		for _, usage := range merged.Keys {
+			records = append(records, usageRecord{Hour: hour, accessKeyUsage: *usage})
+		}
+		hourRecords := records[start:]
+		sort.Slice(hourRecords, func(i, j int) bool {
+			return hourRecords[i].AccessKey < hourRecords[j].AccessKey
+		})
+	}
+	return records, nil
+}
+
+// writeUsageCSV writes the usage records as CSV with a header row.
+func writeUsageCSV(w io.Writer, records []usageRecord) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"hour", "accessKey", "requests", "errors", "rxBytes", "txBytes"})
+	for _, r := range records {
+		cw.Write([]string{
+			r.Hour.Format(time.RFC3339),
+			r.AccessKey,
+			strconv.FormatUint(r.Requests, 10),
+			strconv.FormatUint(r.Errors, 10),
+			strconv.FormatUint(r.RxBytes, 10),
+			strconv.FormatUint(r.TxBytes, 10),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
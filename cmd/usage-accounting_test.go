@@ -0,0 +1,110 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUsageAccounting(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	objLayer, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+
+	setObjectLayer(objLayer)
+	defer setObjectLayer(nil)
+	initAllSubsystems()
+
+	hour := time.Date(2022, 10, 1, 10, 0, 0, 0, time.UTC)
+	usage := newUsageAccounting()
+	usage.record("alice", 100, 10, false, hour.Add(5*time.Minute))
+	usage.record("alice", 50, 0, true, hour.Add(10*time.Minute))
+	usage.record("", 0, 200, false, hour.Add(20*time.Minute))
+	if err = usage.flush(ctx, objLayer, hour.Add(30*time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+
+	// A restarted node continues the persisted rollup of the hour.
+	usage = newUsageAccounting()
+	usage.record("alice", 1, 1, false, hour.Add(40*time.Minute))
+	usage.record("bob", 5, 5, false, hour.Add(70*time.Minute))
+	if err = usage.flush(ctx, objLayer, hour.Add(80*time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	var rollups int
+	usage.rollups.Range(func(k, v interface{}) bool {
+		rollups++
+		return true
+	})
+	if rollups != 1 {
+		t.Fatalf("expected the past hour to be dropped from memory, got %d rollups", rollups)
+	}
+
+	records, err := readUsageRecords(ctx, objLayer, hour, hour.Add(2*time.Hour), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []usageRecord{
+		{Hour: hour, accessKeyUsage: accessKeyUsage{AccessKey: "", Requests: 1, TxBytes: 200}},
+		{Hour: hour, accessKeyUsage: accessKeyUsage{AccessKey: "alice", Requests: 2, Errors: 1, RxBytes: 101, TxBytes: 11}},
+		{Hour: hour.Add(time.Hour), accessKeyUsage: accessKeyUsage{AccessKey: "bob", Requests: 1, RxBytes: 5, TxBytes: 5}},
+	}
+	if len(records) != len(expected) {
+		t.Fatalf("expected %d records, got %+v", len(expected), records)
+	}
+	for i := range expected {
+		if !records[i].Hour.Equal(expected[i].Hour) || records[i].accessKeyUsage != expected[i].accessKeyUsage {
+			t.Errorf("record %d: expected %+v, got %+v", i, expected[i], records[i])
+		}
+	}
+
+	records, err = readUsageRecords(ctx, objLayer, hour, hour.Add(2*time.Hour), "bob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 || records[0].AccessKey != "bob" {
+		t.Fatalf("expected only bob's usage, got %+v", records)
+	}
+
+	var buf bytes.Buffer
+	if err = writeUsageCSV(&buf, records); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 || lines[1] != "2022-10-01T11:00:00Z,bob,1,0,5,5" {
+		t.Fatalf("unexpected CSV %q", buf.String())
+	}
+
+	// Rollups older than the retention are purged.
+	usage = newUsageAccounting()
+	if err = usage.flush(ctx, objLayer, hour.Add(usageAccountingRetention+2*time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if records, err = readUsageRecords(ctx, objLayer, hour, hour.Add(2*time.Hour), ""); err != nil || len(records) != 0 {
+		t.Fatalf("expected the expired rollups to be purged, got %+v, %v", records, err)
+	}
+}
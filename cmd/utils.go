@@ -502,7 +502,7 @@ func newInternodeHTTPTransport(tlsConfig *tls.Config, dialTimeout time.Duration)
 	// https://golang.org/pkg/net/http/#Transport documentation
 	tr := &http.Transport{
 		Proxy:                 http.ProxyFromEnvironment,
-		DialContext:           xhttp.DialContextWithDNSCache(globalDNSCache, xhttp.NewInternodeDialContext(dialTimeout)),
+		DialContext:           internodeDialContext(globalInternodeHosts, xhttp.DialContextWithDNSCache(globalDNSCache, xhttp.NewInternodeDialContext(dialTimeout))),
 		MaxIdleConnsPerHost:   1024,
 		WriteBufferSize:       32 << 10, // 32KiB moving up from 4KiB default
 		ReadBufferSize:        32 << 10, // 32KiB moving up from 4KiB default
@@ -1077,6 +1077,10 @@ func newTLSConfig(getCert certs.GetCertificateFunc) *tls.Config {
 		tlsConfig.CipherSuites = fips.TLSCiphersBackwardCompatible()
 	}
 	tlsConfig.CurvePreferences = fips.TLSCurveIDs()
+
+	// Enforce the configurable TLS policy on every handshake, this allows
+	// the policy to change without restarting the listeners.
+	tlsConfig.GetConfigForClient = tlsPolicyConfigForClient(tlsConfig.Clone())
 	return tlsConfig
 }
 
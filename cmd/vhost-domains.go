@@ -0,0 +1,142 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/mux"
+	"github.com/qkbyte/minio/internal/config/api"
+)
+
+// vhostRoute is a domain accepting virtual-host-style requests.
+type vhostRoute struct {
+	labels    []string
+	wildcards int
+	prefix    string
+	static    bool // from MINIO_DOMAIN
+}
+
+// match returns the bucket addressed by the labels of a host.
+func (v vhostRoute) match(host []string) (string, bool) {
+	n := len(host) - len(v.labels)
+	if n < 1 {
+		return "", false
+	}
+	for i, label := range v.labels {
+		if label != "*" && label != host[n+i] {
+			return "", false
+		}
+	}
+	if v.static && n == 1 && host[0] == minioReservedBucket {
+		// minio.<domain> is always path-style.
+		return "", false
+	}
+	return v.prefix + strings.Join(host[:n], "."), true
+}
+
+// vhostDomainsSys resolves virtual-host-style requests to buckets for
+// the domains set with MINIO_DOMAIN and the ones configured at runtime
+// with the api vhost_domains setting, the most specific domain wins.
+type vhostDomainsSys struct {
+	mu      sync.RWMutex
+	routes  []vhostRoute
+	dynamic bool
+}
+
+var globalVhostDomains = &vhostDomainsSys{}
+
+// update replaces the configured domains, the MINIO_DOMAIN domains
+// are always kept.
+func (v *vhostDomainsSys) update(domains []api.VhostDomain) {
+	routes := make([]vhostRoute, 0, len(globalDomainNames)+len(domains))
+	for _, domain := range globalDomainNames {
+		routes = append(routes, vhostRoute{labels: strings.Split(domain, "."), static: true})
+	}
+	for _, d := range domains {
+		r := vhostRoute{labels: d.Labels(), prefix: d.Prefix}
+		for _, label := range r.labels {
+			if label == "*" {
+				r.wildcards++
+			}
+		}
+		routes = append(routes, r)
+	}
+	sort.SliceStable(routes, func(i, j int) bool {
+		a, b := routes[i], routes[j]
+		if len(a.labels) != len(b.labels) {
+			return len(a.labels) > len(b.labels)
+		}
+		if a.wildcards != b.wildcards {
+			return a.wildcards < b.wildcards
+		}
+		return !a.static && b.static
+	})
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.routes = routes
+	v.dynamic = len(domains) > 0
+}
+
+// resolve returns the bucket addressed by host, static is set when the
+// bucket comes from a MINIO_DOMAIN domain.
+func (v *vhostDomainsSys) resolve(host string) (bucket string, static, ok bool) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if !v.dynamic {
+		// MINIO_DOMAIN domains are routed statically.
+		return "", false, false
+	}
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	labels := strings.Split(strings.ToLower(strings.TrimSuffix(host, ".")), ".")
+	for _, r := range v.routes {
+		if bucket, ok = r.match(labels); ok {
+			return bucket, r.static, true
+		}
+	}
+	return "", false, false
+}
+
+// vhostBucket returns the bucket of requests sent to a runtime
+// configured domain.
+func vhostBucket(host string) (string, bool) {
+	bucket, static, ok := globalVhostDomains.resolve(host)
+	return bucket, ok && !static
+}
+
+// vhostMatcher routes requests sent to runtime configured domains, it
+// is registered ahead of the MINIO_DOMAIN routes and only takes the
+// requests for which a configured domain is the most specific match.
+func vhostMatcher(r *http.Request, match *mux.RouteMatch) bool {
+	bucket, ok := vhostBucket(getHost(r))
+	if !ok {
+		return false
+	}
+	if match.Vars == nil {
+		match.Vars = make(map[string]string)
+	}
+	match.Vars["bucket"] = bucket
+	return true
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/qkbyte/minio/internal/config/api"
+)
+
+func TestParseVhostDomains(t *testing.T) {
+	domains, err := api.ParseVhostDomains("Example.com, *.tenant1.example.com=tenant1-,*.s3.*.example.net")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []api.VhostDomain{
+		{Pattern: "example.com"},
+		{Pattern: "*.tenant1.example.com", Prefix: "tenant1-"},
+		{Pattern: "*.s3.*.example.net"},
+	}
+	if len(domains) != len(want) {
+		t.Fatalf("expected %d domains, got %v", len(want), domains)
+	}
+	for i := range want {
+		if domains[i] != want[i] {
+			t.Errorf("expected %v, got %v", want[i], domains[i])
+		}
+	}
+
+	for _, v := range []string{
+		"com",
+		"*.*",
+		"example..com",
+		"-example.com",
+		"exa_mple.com",
+		"example.com=Tenant",
+		"example.com,*.example.com",
+	} {
+		if _, err := api.ParseVhostDomains(v); err == nil {
+			t.Errorf("%q: expected an error", v)
+		}
+	}
+}
+
+func TestVhostDomainsResolve(t *testing.T) {
+	savedDomains, savedVhost := globalDomainNames, globalVhostDomains
+	defer func() { globalDomainNames, globalVhostDomains = savedDomains, savedVhost }()
+
+	globalDomainNames = []string{"example.com"}
+	globalVhostDomains = &vhostDomainsSys{}
+	globalVhostDomains.update(nil)
+	if _, _, ok := globalVhostDomains.resolve("photos.example.com"); ok {
+		t.Fatal("MINIO_DOMAIN alone must be routed statically")
+	}
+
+	domains, err := api.ParseVhostDomains("*.tenant1.example.com=tenant1-,*.s3.*.example.net=shared-,example.org")
+	if err != nil {
+		t.Fatal(err)
+	}
+	globalVhostDomains.update(domains)
+
+	for _, test := range []struct {
+		host   string
+		bucket string
+		static bool
+		ok     bool
+	}{
+		{"photos.tenant1.example.com", "tenant1-photos", false, true},
+		{"Photos.Tenant1.Example.com:9000", "tenant1-photos", false, true},
+		{"photos.example.com", "photos", true, true},
+		{"minio.example.com", "", false, false},
+		{"logs.s3.eu.example.net", "shared-logs", false, true},
+		{"logs.s3.example.net", "", false, false},
+		{"my.bucket.example.org", "my.bucket", false, true},
+		{"tenant1.example.com", "tenant1", true, true},
+		{"example.org", "", false, false},
+		{"bucket.other.com", "", false, false},
+	} {
+		bucket, static, ok := globalVhostDomains.resolve(test.host)
+		if bucket != test.bucket || static != test.static || ok != test.ok {
+			t.Errorf("%s: expected (%q, %v, %v), got (%q, %v, %v)", test.host,
+				test.bucket, test.static, test.ok, bucket, static, ok)
+		}
+	}
+
+	resource, err := getResource("/object", "photos.tenant1.example.com", globalDomainNames)
+	if err != nil || resource != "/tenant1-photos/object" {
+		t.Errorf("unexpected resource %q: %v", resource, err)
+	}
+	resource, err = getResource("/object", "photos.example.com", globalDomainNames)
+	if err != nil || resource != "/photos/object" {
+		t.Errorf("unexpected resource %q: %v", resource, err)
+	}
+}
+
+func TestVhostMatcher(t *testing.T) {
+	savedDomains, savedVhost := globalDomainNames, globalVhostDomains
+	defer func() { globalDomainNames, globalVhostDomains = savedDomains, savedVhost }()
+
+	globalDomainNames = nil
+	globalVhostDomains = &vhostDomainsSys{}
+	domains, err := api.ParseVhostDomains("*.tenant1.example.com=tenant1-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	globalVhostDomains.update(domains)
+
+	var vars map[string]string
+	router := mux.NewRouter()
+	router.MatcherFunc(vhostMatcher).Subrouter().Path("/{object:.+}").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		vars = mux.Vars(r)
+	})
+	router.PathPrefix("/{bucket}").Subrouter().Path("/{object:.+}").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		vars = mux.Vars(r)
+	})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "http://photos.tenant1.example.com/a/b.jpg", nil))
+	if vars["bucket"] != "tenant1-photos" || vars["object"] != "a/b.jpg" {
+		t.Errorf("unexpected vars %v", vars)
+	}
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "http://localhost:9000/photos/a/b.jpg", nil))
+	if vars["bucket"] != "photos" || vars["object"] != "a/b.jpg" {
+		t.Errorf("unexpected vars %v", vars)
+	}
+}
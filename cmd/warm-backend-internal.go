@@ -0,0 +1,174 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/minio/madmin-go"
+	"github.com/qkbyte/minio/internal/hash"
+)
+
+// tierInternalScheme is the endpoint scheme of a MinIO tier which points
+// to a bucket in this deployment, e.g. "internal://?pool=2" places all
+// transitioned objects on the second pool.
+const tierInternalScheme = "internal"
+
+var (
+	errTierInternalSameBucket = errors.New("internal tier bucket cannot be the transition source bucket")
+	errTierInternalLoop       = errors.New("internal tier transitions lead back to the transition source bucket")
+	errTierInternalNoCreds    = errors.New("internal tier has no credentials")
+)
+
+// isInternalTier returns true if tier transitions objects to a bucket in
+// this deployment instead of a remote MinIO.
+func isInternalTier(tier madmin.TierConfig) bool {
+	if tier.Type != madmin.MinIO || tier.MinIO == nil {
+		return false
+	}
+	u, err := url.Parse(tier.MinIO.Endpoint)
+	return err == nil && u.Scheme == tierInternalScheme
+}
+
+// warmBackendInternal implements WarmBackend on top of the local object
+// layer, allowing hot to cold tiering across pools of one deployment.
+type warmBackendInternal struct {
+	Bucket string
+	Prefix string
+	Pool   int // 1-based, 0 for default placement
+}
+
+var _ WarmBackend = (*warmBackendInternal)(nil)
+
+func newWarmBackendInternal(ctx context.Context, conf madmin.TierMinIO) (*warmBackendInternal, error) {
+	u, err := url.Parse(conf.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if isMinioMetaBucketName(conf.Bucket) {
+		return nil, errInvalidArgument
+	}
+
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return nil, errServerNotInitialized
+	}
+
+	var pool int
+	if v := u.Query().Get("pool"); v != "" {
+		pool, err = strconv.Atoi(v)
+		if err != nil || pool <= 0 {
+			return nil, fmt.Errorf("invalid internal tier pool %q", v)
+		}
+		z, ok := objAPI.(*erasureServerPools)
+		if !ok || pool > len(z.serverPools) {
+			return nil, fmt.Errorf("internal tier pool %d does not exist", pool)
+		}
+	}
+
+	if _, err = objAPI.GetBucketInfo(ctx, conf.Bucket, BucketOptions{}); err != nil {
+		return nil, err
+	}
+
+	return &warmBackendInternal{
+		Bucket: conf.Bucket,
+		Prefix: strings.TrimSuffix(conf.Prefix, slashSeparator),
+		Pool:   pool,
+	}, nil
+}
+
+func (in *warmBackendInternal) getDest(object string) string {
+	if in.Prefix != "" {
+		return fmt.Sprintf("%s/%s", in.Prefix, object)
+	}
+	return object
+}
+
+func (in *warmBackendInternal) Put(ctx context.Context, object string, r io.Reader, length int64) (remoteVersionID, error) {
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return "", errServerNotInitialized
+	}
+	hr, err := hash.NewReader(r, length, "", "", length)
+	if err != nil {
+		return "", err
+	}
+	dest := in.getDest(object)
+	oi, err := objAPI.PutObject(ctx, in.Bucket, dest, NewPutObjReader(hr), ObjectOptions{
+		Versioned:        globalBucketVersioningSys.PrefixEnabled(in.Bucket, dest),
+		VersionSuspended: globalBucketVersioningSys.PrefixSuspended(in.Bucket, dest),
+		PlacementPool:    in.Pool,
+	})
+	if err != nil {
+		return "", err
+	}
+	return remoteVersionID(oi.VersionID), nil
+}
+
+func (in *warmBackendInternal) Get(ctx context.Context, object string, rv remoteVersionID, opts WarmBackendGetOpts) (io.ReadCloser, error) {
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return nil, errServerNotInitialized
+	}
+	var rs *HTTPRangeSpec
+	if opts.startOffset >= 0 && opts.length > 0 {
+		rs = &HTTPRangeSpec{Start: opts.startOffset, End: opts.startOffset + opts.length - 1}
+	}
+	return objAPI.GetObjectNInfo(ctx, in.Bucket, in.getDest(object), rs, nil, readLock, ObjectOptions{
+		VersionID: string(rv),
+	})
+}
+
+func (in *warmBackendInternal) Remove(ctx context.Context, object string, rv remoteVersionID) error {
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+	dest := in.getDest(object)
+	_, err := objAPI.DeleteObject(ctx, in.Bucket, dest, ObjectOptions{
+		VersionID:        string(rv),
+		Versioned:        globalBucketVersioningSys.PrefixEnabled(in.Bucket, dest),
+		VersionSuspended: globalBucketVersioningSys.PrefixSuspended(in.Bucket, dest),
+	})
+	if isErrObjectNotFound(err) || isErrVersionNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+func (in *warmBackendInternal) InUse(ctx context.Context) (bool, error) {
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return false, errServerNotInitialized
+	}
+	prefix := in.Prefix
+	if prefix != "" {
+		prefix += slashSeparator
+	}
+	result, err := objAPI.ListObjects(ctx, in.Bucket, prefix, "", slashSeparator, 1)
+	if err != nil {
+		return false, err
+	}
+	return len(result.Prefixes) > 0 || len(result.Objects) > 0, nil
+}
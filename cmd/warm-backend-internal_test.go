@@ -0,0 +1,161 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/minio/madmin-go"
+	"github.com/qkbyte/minio/internal/bucket/lifecycle"
+	"github.com/qkbyte/minio/internal/config/storageclass"
+)
+
+func TestWarmBackendInternal(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	fsDirs, err := getRandomDisks(16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+
+	objLayer, _, err := initObjectLayer(ctx, mustGetPoolEndpoints(fsDirs...))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bucket := getRandomBucketName()
+	if err = objLayer.MakeBucketWithLocation(ctx, bucket, MakeBucketOptions{}); err != nil {
+		t.Fatalf("Failed to make a bucket - %v", err)
+	}
+	setObjectLayer(objLayer)
+	defer setObjectLayer(nil)
+
+	defer func(gateway bool) { globalIsGateway = gateway }(globalIsGateway)
+	globalIsGateway = false
+
+	defer func(sys *BucketMetadataSys) { globalBucketMetadataSys = sys }(globalBucketMetadataSys)
+	globalBucketMetadataSys = NewBucketMetadataSys()
+	globalBucketMetadataSys.Set(bucket, newBucketMetadata(bucket))
+
+	defer func(sc storageclass.Config) { globalStorageClass = sc }(globalStorageClass)
+	globalStorageClass = storageclass.Config{Standard: storageclass.StorageClass{Parity: 4}}
+
+	tier := madmin.TierConfig{
+		Version: madmin.TierConfigVer,
+		Type:    madmin.MinIO,
+		Name:    "COLD",
+		MinIO: &madmin.TierMinIO{
+			Endpoint: "internal://",
+			Bucket:   bucket,
+			Prefix:   "tier/",
+		},
+	}
+	if !isInternalTier(tier) {
+		t.Fatal("Expected tier to be internal")
+	}
+
+	d, err := newWarmBackend(ctx, tier)
+	if err != nil {
+		t.Fatalf("Failed to create internal tier - %v", err)
+	}
+	if inUse, err := d.InUse(ctx); err != nil || inUse {
+		t.Fatalf("Expected tier to be unused, got %v, %v", inUse, err)
+	}
+
+	data := []byte("hello, cold world")
+	rv, err := d.Put(ctx, "obj", bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("Failed to put object - %v", err)
+	}
+	if _, err = objLayer.GetObjectInfo(ctx, bucket, "tier/obj", ObjectOptions{}); err != nil {
+		t.Fatalf("Expected object under the tier prefix - %v", err)
+	}
+	if inUse, err := d.InUse(ctx); err != nil || !inUse {
+		t.Fatalf("Expected tier to be in use, got %v, %v", inUse, err)
+	}
+
+	rc, err := d.Get(ctx, "obj", rv, WarmBackendGetOpts{startOffset: 7, length: 4})
+	if err != nil {
+		t.Fatalf("Failed to get object - %v", err)
+	}
+	got, err := io.ReadAll(rc)
+	rc.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "cold" {
+		t.Fatalf("Expected range %q, got %q", "cold", got)
+	}
+
+	if err = d.Remove(ctx, "obj", rv); err != nil {
+		t.Fatalf("Failed to remove object - %v", err)
+	}
+	if inUse, err := d.InUse(ctx); err != nil || inUse {
+		t.Fatalf("Expected tier to be unused after remove, got %v, %v", inUse, err)
+	}
+
+	// Only one pool is available.
+	tier.MinIO.Endpoint = "internal://?pool=2"
+	if _, err = newWarmBackend(ctx, tier); err == nil {
+		t.Fatal("Expected an error for a non-existent pool")
+	}
+
+	// Transitioning into the source bucket is rejected.
+	globalTierConfigMgr = NewTierConfigMgr()
+	globalTierConfigMgr.Tiers[tier.Name] = tier
+	lc, err := lifecycle.ParseLifecycleConfig(bytes.NewReader([]byte(`<LifecycleConfiguration><Rule><ID>rule</ID><Prefix /><Status>Enabled</Status><Transition><Days>1</Days><StorageClass>COLD</StorageClass></Transition></Rule></LifecycleConfiguration>`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = validateTransitionTier(bucket, lc); err != errTierInternalSameBucket {
+		t.Fatalf("Expected %v, got %v", errTierInternalSameBucket, err)
+	}
+	if err = validateTransitionTier("other", lc); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Transitioning back through another bucket is rejected as well.
+	other := madmin.TierConfig{
+		Version: madmin.TierConfigVer,
+		Type:    madmin.MinIO,
+		Name:    "WARM",
+		MinIO: &madmin.TierMinIO{
+			Endpoint: "internal://",
+			Bucket:   "other",
+		},
+	}
+	globalTierConfigMgr.Tiers[other.Name] = other
+	meta := newBucketMetadata("other")
+	meta.lifecycleConfig = lc
+	globalBucketMetadataSys.Set("other", meta)
+	warmLC, err := lifecycle.ParseLifecycleConfig(bytes.NewReader([]byte(`<LifecycleConfiguration><Rule><ID>rule</ID><Prefix /><Status>Enabled</Status><Transition><Days>1</Days><StorageClass>WARM</StorageClass></Transition></Rule></LifecycleConfiguration>`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = validateTransitionTier(bucket, warmLC); err != errTierInternalLoop {
+		t.Fatalf("Expected %v, got %v", errTierInternalLoop, err)
+	}
+	if err = validateTransitionTier("third", warmLC); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+}
@@ -60,7 +60,10 @@ func checkWarmBackend(ctx context.Context, w WarmBackend) error {
 		}
 	}
 
-	_, err = w.Get(ctx, probeObject, rv, WarmBackendGetOpts{})
+	r, err := w.Get(ctx, probeObject, rv, WarmBackendGetOpts{})
+	if err == nil {
+		r.Close()
+	}
 	if err != nil {
 		switch err.(type) {
 		case BackendDown:
@@ -134,6 +137,18 @@ type remoteVersionID string
 // newWarmBackend instantiates the tier type specific WarmBackend, runs
 // checkWarmBackend on it.
 func newWarmBackend(ctx context.Context, tier madmin.TierConfig) (d WarmBackend, err error) {
+	switch {
+	case isInternalTier(tier):
+		d, err = newWarmBackendInternal(ctx, *tier.MinIO)
+		if err != nil {
+			return nil, err
+		}
+		if err = checkWarmBackend(ctx, d); err != nil {
+			return nil, err
+		}
+		return d, nil
+	}
+
 	switch tier.Type {
 	case madmin.S3:
 		d, err = newWarmBackendS3(*tier.S3)
@@ -0,0 +1,470 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/minio/madmin-go"
+	"github.com/qkbyte/minio/internal/logger"
+	"github.com/tinylib/msgp/msgp"
+)
+
+const (
+	// Objects with more versions are reported as sprawling by default.
+	xlMetaMaintenanceDefaultMaxVersions = 1000
+
+	// Maximum number of sprawling and corrupted objects listed in a
+	// report, all of them are counted.
+	xlMetaMaintenanceMaxEntries = 1000
+)
+
+// Status and retention of admin maintenance jobs, reported like prefix
+// scans.
+const (
+	jobStatusRunning  = prefixScanRunning
+	jobStatusDone     = prefixScanDone
+	jobStatusFailed   = prefixScanFailed
+	jobStatusCanceled = prefixScanCanceled
+
+	finishedJobRetention = prefixScanRetention
+)
+
+var (
+	errXLMetaMaintenanceNotFound = errors.New("xl.meta maintenance job not found")
+	errXLMetaMaintenanceRunning  = errors.New("an xl.meta maintenance job is already running")
+)
+
+// xlMetaCheck - outcome of the check of a single xl.meta file.
+type xlMetaCheck struct {
+	// Legacy is true if the file was not in the current format.
+	Legacy bool
+	// RepairedData is true if corrupted inline data entries were dropped.
+	RepairedData bool
+	// OrphanData is the number of inline data entries no version refers to.
+	OrphanData int
+	// Versions is the number of versions in the file.
+	Versions int
+}
+
+// needsRewrite returns true if the compacted xl.meta must be written back.
+func (c xlMetaCheck) needsRewrite() bool {
+	return c.Legacy || c.RepairedData || c.OrphanData > 0
+}
+
+// compactXLMeta checks the xl.meta in buf and returns it converted to the
+// current format with inline data entries no version refers to removed.
+// An error is returned if buf is corrupted beyond local repair.
+func compactXLMeta(buf []byte, bucket, object string) (out []byte, check xlMetaCheck, err error) {
+	check.Legacy, check.RepairedData = inspectXLMeta(buf)
+
+	var x xlMetaV2
+	if err = x.LoadOrConvert(buf); err != nil {
+		return nil, check, err
+	}
+	fivs, err := x.ListVersions(bucket, object)
+	if err != nil {
+		return nil, check, err
+	}
+	check.Versions = len(fivs)
+
+	if x.data.entries() > 0 {
+		referenced := make(map[string]struct{}, 2*len(fivs)+2)
+		for _, fi := range fivs {
+			if fi.VersionID == "" {
+				referenced[""] = struct{}{}
+				referenced[nullVersionID] = struct{}{}
+			} else {
+				referenced[fi.VersionID] = struct{}{}
+			}
+			if fi.DataDir != "" {
+				referenced[fi.DataDir] = struct{}{}
+			}
+		}
+		keys, err := x.data.list()
+		if err != nil {
+			return nil, check, err
+		}
+		var orphans []string
+		for _, key := range keys {
+			if _, ok := referenced[key]; !ok {
+				orphans = append(orphans, key)
+			}
+		}
+		if len(orphans) > 0 {
+			x.data.remove(orphans...)
+			check.OrphanData = len(orphans)
+		}
+	}
+
+	out, err = x.AppendTo(nil)
+	return out, check, err
+}
+
+// inspectXLMeta returns whether buf is not in the current xl.meta format
+// and whether its inline data is corrupted.
+func inspectXLMeta(buf []byte) (legacy, corruptData bool) {
+	payload, major, minor, err := checkXL2V1(buf)
+	if err != nil || major < xlVersionMajor || minor < xlVersionMinor {
+		return true, false
+	}
+	meta, rest, err := msgp.ReadBytesZC(payload)
+	if err != nil {
+		return false, false
+	}
+	if _, _, metaV, _, err := decodeXLHeaders(meta); err == nil && metaV < xlMetaVersion {
+		legacy = true
+	}
+	if _, rest, err = msgp.ReadUint32Bytes(rest); err != nil {
+		return legacy, false
+	}
+	return legacy, xlMetaInlineData(rest).validate() != nil
+}
+
+// xlMetaMaintenanceEntry - an object reported by a maintenance job.
+type xlMetaMaintenanceEntry struct {
+	Bucket   string `json:"bucket"`
+	Object   string `json:"object"`
+	Drive    string `json:"drive,omitempty"`
+	Versions int    `json:"versions,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// xlMetaMaintenanceStatus - progress and statistics of a job checking
+// and compacting the xl.meta files of a bucket prefix on all drives.
+type xlMetaMaintenanceStatus struct {
+	ID          string    `json:"id"`
+	Bucket      string    `json:"bucket,omitempty"`
+	Prefix      string    `json:"prefix,omitempty"`
+	DryRun      bool      `json:"dryRun"`
+	MaxVersions int       `json:"maxVersions"`
+	Status      string    `json:"status"`
+	Error       string    `json:"error,omitempty"`
+	Started     time.Time `json:"started"`
+	Finished    time.Time `json:"finished,omitempty"`
+	LastObject  string    `json:"lastObject,omitempty"`
+
+	Objects uint64 `json:"objects"`
+	Files   uint64 `json:"files"`
+	Errors  uint64 `json:"errors"`
+
+	// Files in a legacy format, with corrupted inline data entries and
+	// with inline data no version refers to.
+	Legacy       uint64 `json:"legacy"`
+	RepairedData uint64 `json:"repairedData"`
+	OrphanData   uint64 `json:"orphanData"`
+
+	// Files written back compacted, with their size before and after.
+	Rewritten   uint64 `json:"rewritten"`
+	BytesBefore int64  `json:"bytesBefore"`
+	BytesAfter  int64  `json:"bytesAfter"`
+
+	// Files corrupted beyond local repair, their objects are healed
+	// from the other drives.
+	CorruptedCount uint64                   `json:"corruptedCount"`
+	Corrupted      []xlMetaMaintenanceEntry `json:"corrupted,omitempty"`
+	Healed         uint64                   `json:"healed"`
+
+	// Objects with more versions than MaxVersions.
+	SprawlingCount uint64                   `json:"sprawlingCount"`
+	Sprawling      []xlMetaMaintenanceEntry `json:"sprawling,omitempty"`
+}
+
+type xlMetaMaintenanceJob struct {
+	mu     sync.Mutex
+	status xlMetaMaintenanceStatus
+	cancel context.CancelFunc
+}
+
+func (j *xlMetaMaintenanceJob) getStatus() xlMetaMaintenanceStatus {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	s := j.status
+	s.Corrupted = append([]xlMetaMaintenanceEntry(nil), s.Corrupted...)
+	s.Sprawling = append([]xlMetaMaintenanceEntry(nil), s.Sprawling...)
+	return s
+}
+
+func (j *xlMetaMaintenanceJob) update(f func(s *xlMetaMaintenanceStatus)) {
+	j.mu.Lock()
+	f(&j.status)
+	j.mu.Unlock()
+}
+
+// xlMetaMaintenanceJobs tracks the maintenance jobs started on this node.
+type xlMetaMaintenanceJobs struct {
+	mu   sync.Mutex
+	jobs map[string]*xlMetaMaintenanceJob
+}
+
+var globalXLMetaMaintenance = &xlMetaMaintenanceJobs{jobs: make(map[string]*xlMetaMaintenanceJob)}
+
+// start starts a maintenance job of the xl.meta files under bucket/prefix,
+// all buckets are checked if bucket is empty. Only one job may run at once.
+func (m *xlMetaMaintenanceJobs) start(z *erasureServerPools, bucket, prefix string, maxVersions int, dryRun bool) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := UTCNow()
+	for id, job := range m.jobs {
+		s := job.getStatus()
+		if s.Status == jobStatusRunning {
+			return "", errXLMetaMaintenanceRunning
+		}
+		if now.Sub(s.Finished) > finishedJobRetention {
+			delete(m.jobs, id)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(GlobalContext)
+	job := &xlMetaMaintenanceJob{
+		status: xlMetaMaintenanceStatus{
+			ID:          mustGetUUID(),
+			Bucket:      bucket,
+			Prefix:      prefix,
+			DryRun:      dryRun,
+			MaxVersions: maxVersions,
+			Status:      jobStatusRunning,
+			Started:     now,
+		},
+		cancel: cancel,
+	}
+	m.jobs[job.status.ID] = job
+	globalAdminJobs.register(adminJobXLMetaMaintenance, job.status.ID, now, job.jobState, job.cancelJob)
+	go job.run(ctx, z)
+	return job.status.ID, nil
+}
+
+func (j *xlMetaMaintenanceJob) jobState() adminJobState {
+	s := j.getStatus()
+	return adminJobState{Status: s.Status, Error: s.Error, Finished: s.Finished, Detail: s}
+}
+
+func (j *xlMetaMaintenanceJob) cancelJob() error {
+	j.cancel()
+	return nil
+}
+
+func (m *xlMetaMaintenanceJobs) get(id string) (*xlMetaMaintenanceJob, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	job, ok := m.jobs[id]
+	return job, ok
+}
+
+func (j *xlMetaMaintenanceJob) run(ctx context.Context, z *erasureServerPools) {
+	defer j.cancel()
+
+	buckets := []string{j.status.Bucket}
+	if j.status.Bucket == "" {
+		bis, err := z.ListBuckets(ctx, BucketOptions{})
+		if err != nil {
+			j.finish(err)
+			return
+		}
+		buckets = buckets[:0]
+		for _, bi := range bis {
+			buckets = append(buckets, bi.Name)
+		}
+	}
+
+	for _, bucket := range buckets {
+		for _, pool := range z.serverPools {
+			for _, set := range pool.sets {
+				if err := j.runSet(ctx, z, set, bucket); err != nil {
+					j.finish(err)
+					return
+				}
+			}
+		}
+	}
+	j.finish(ctx.Err())
+}
+
+// runSet checks the objects of bucket/prefix on the drives of set.
+func (j *xlMetaMaintenanceJob) runSet(ctx context.Context, z *erasureServerPools, set *erasureObjects, bucket string) error {
+	disks := set.getOnlineDisks()
+	if len(disks) == 0 {
+		return nil
+	}
+	process := func(entry metaCacheEntry) {
+		if !entry.isDir() {
+			j.processObject(ctx, z, disks, bucket, entry.name)
+		}
+	}
+	err := listPathRaw(ctx, listPathRawOptions{
+		disks:          disks,
+		bucket:         bucket,
+		path:           j.status.Prefix,
+		recursive:      true,
+		minDisks:       1,
+		reportNotFound: false,
+		agreed:         process,
+		partial: func(entries metaCacheEntries, _ []error) {
+			// Corrupted copies do not resolve, pick any name.
+			for _, entry := range entries {
+				if entry.name != "" {
+					process(entry)
+					return
+				}
+			}
+		},
+	})
+	if err != nil && !errors.Is(err, errVolumeNotFound) && !isErrBucketNotFound(err) {
+		return err
+	}
+	return ctx.Err()
+}
+
+// processObject checks and compacts the xl.meta of object on all disks,
+// corrupted copies are healed from the other drives.
+func (j *xlMetaMaintenanceJob) processObject(ctx context.Context, z *erasureServerPools, disks []StorageAPI, bucket, object string) {
+	if ctx.Err() != nil {
+		return
+	}
+	if j.compactObject(ctx, z, disks, bucket, object) && !j.status.DryRun {
+		res, err := z.HealObject(ctx, bucket, object, "", madmin.HealOpts{ScanMode: madmin.HealNormalScan})
+		if err == nil && healResultRepaired(res) {
+			j.update(func(s *xlMetaMaintenanceStatus) { s.Healed++ })
+		}
+	}
+}
+
+// compactObject checks and compacts the xl.meta of object on all disks
+// while holding the object lock, returns true if a copy is corrupted.
+func (j *xlMetaMaintenanceJob) compactObject(ctx context.Context, z *erasureServerPools, disks []StorageAPI, bucket, object string) (corrupted bool) {
+	dryRun, maxVersions := j.status.DryRun, j.status.MaxVersions
+	metaPath := pathJoin(encodeDirObject(object), xlStorageFormatFile)
+
+	if !dryRun {
+		lk := z.NewNSLock(bucket, object)
+		lkctx, err := lk.GetLock(ctx, globalOperationTimeout)
+		if err != nil {
+			j.update(func(s *xlMetaMaintenanceStatus) { s.Errors++ })
+			return false
+		}
+		ctx = lkctx.Context()
+		defer lk.Unlock(lkctx.Cancel)
+	}
+
+	var versions int
+	for _, disk := range disks {
+		if disk == nil || !disk.IsOnline() {
+			continue
+		}
+		buf, err := disk.ReadAll(ctx, bucket, metaPath)
+		if errors.Is(err, errFileNotFound) {
+			continue
+		}
+		if err != nil {
+			j.update(func(s *xlMetaMaintenanceStatus) { s.Errors++ })
+			continue
+		}
+		out, check, err := compactXLMeta(buf, bucket, object)
+		if err != nil {
+			corrupted = true
+			entry := xlMetaMaintenanceEntry{Bucket: bucket, Object: object, Drive: disk.String(), Error: err.Error()}
+			j.update(func(s *xlMetaMaintenanceStatus) {
+				s.Files++
+				s.CorruptedCount++
+				if len(s.Corrupted) < xlMetaMaintenanceMaxEntries {
+					s.Corrupted = append(s.Corrupted, entry)
+				}
+			})
+			continue
+		}
+		if check.Versions > versions {
+			versions = check.Versions
+		}
+
+		var rewritten bool
+		if check.needsRewrite() && !dryRun {
+			if err = rewriteXLMeta(ctx, disk, bucket, metaPath, out); err != nil {
+				logger.LogIf(ctx, fmt.Errorf("xl.meta maintenance: unable to rewrite %s/%s on %s: %w", bucket, object, disk, err))
+			}
+			rewritten = err == nil
+		}
+		j.update(func(s *xlMetaMaintenanceStatus) {
+			s.Files++
+			if check.Legacy {
+				s.Legacy++
+			}
+			if check.RepairedData {
+				s.RepairedData++
+			}
+			if check.OrphanData > 0 {
+				s.OrphanData++
+			}
+			if err != nil {
+				s.Errors++
+			}
+			if rewritten {
+				s.Rewritten++
+				s.BytesBefore += int64(len(buf))
+				s.BytesAfter += int64(len(out))
+			}
+		})
+	}
+
+	j.update(func(s *xlMetaMaintenanceStatus) {
+		s.Objects++
+		s.LastObject = pathJoin(bucket, object)
+		if versions > maxVersions {
+			s.SprawlingCount++
+			if len(s.Sprawling) < xlMetaMaintenanceMaxEntries {
+				s.Sprawling = append(s.Sprawling, xlMetaMaintenanceEntry{Bucket: bucket, Object: object, Versions: versions})
+			}
+		}
+	})
+
+	return corrupted
+}
+
+// rewriteXLMeta replaces the xl.meta at metaPath with buf. The file is
+// written to the temporary bucket first and renamed over the original,
+// a crash never leaves a partially written xl.meta behind.
+func rewriteXLMeta(ctx context.Context, disk StorageAPI, bucket, metaPath string, buf []byte) error {
+	tmpPath := mustGetUUID()
+	if err := disk.WriteAll(ctx, minioMetaTmpBucket, tmpPath, buf); err != nil {
+		return err
+	}
+	if err := disk.RenameFile(ctx, minioMetaTmpBucket, tmpPath, bucket, metaPath); err != nil {
+		disk.Delete(ctx, minioMetaTmpBucket, tmpPath, DeleteOptions{})
+		return err
+	}
+	return nil
+}
+
+func (j *xlMetaMaintenanceJob) finish(err error) {
+	j.update(func(s *xlMetaMaintenanceStatus) {
+		s.Finished = UTCNow()
+		switch {
+		case errors.Is(err, context.Canceled):
+			s.Status = jobStatusCanceled
+		case err != nil:
+			s.Status = jobStatusFailed
+			s.Error = err.Error()
+		default:
+			s.Status = jobStatusDone
+		}
+	})
+}
@@ -0,0 +1,190 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/qkbyte/minio/internal/config/storageclass"
+)
+
+func TestCompactXLMeta(t *testing.T) {
+	// Legacy format.
+	data, err := os.ReadFile("testdata/xl.meta-v1.2.zst")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dec, _ := zstd.NewReader(nil)
+	if data, err = dec.DecodeAll(data, nil); err != nil {
+		t.Fatal(err)
+	}
+	out, check, err := compactXLMeta(data, "bucket", "object")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !check.Legacy || check.Versions == 0 || !check.needsRewrite() {
+		t.Fatalf("unexpected check of legacy xl.meta %+v", check)
+	}
+	if legacy, corrupt := inspectXLMeta(out); legacy || corrupt {
+		t.Fatal("expected compacted xl.meta in the current format")
+	}
+
+	// Inline data of a removed version.
+	fi := FileInfo{
+		Volume:    "bucket",
+		Name:      "object",
+		VersionID: mustGetUUID(),
+		ModTime:   time.Now(),
+		Data:      []byte("inline"),
+		Size:      6,
+		Erasure: ErasureInfo{
+			Algorithm:    ReedSolomon.String(),
+			DataBlocks:   2,
+			ParityBlocks: 2,
+			BlockSize:    10000,
+			Index:        1,
+			Distribution: []int{1, 2, 3, 4},
+			Checksums:    []ChecksumInfo{{PartNumber: 1, Algorithm: HighwayHash256S}},
+		},
+		Parts: []ObjectPartInfo{{Number: 1, Size: 6, ActualSize: 6}},
+	}
+	var x xlMetaV2
+	if err = x.AddVersion(fi); err != nil {
+		t.Fatal(err)
+	}
+	x.data.replace(mustGetUUID(), []byte("orphan"))
+	buf, err := x.AppendTo(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, check, err = compactXLMeta(buf, "bucket", "object")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if check.Legacy || check.OrphanData != 1 || check.Versions != 1 || len(out) >= len(buf) {
+		t.Fatalf("unexpected check of xl.meta with orphaned data %+v", check)
+	}
+	var compacted xlMetaV2
+	if err = compacted.Load(out); err != nil {
+		t.Fatal(err)
+	}
+	if compacted.data.entries() != 1 || !bytes.Equal(compacted.data.find(fi.VersionID), fi.Data) {
+		t.Fatal("expected only the inline data of the version to be kept")
+	}
+
+	// Nothing to do.
+	if _, check, err = compactXLMeta(out, "bucket", "object"); err != nil || check.needsRewrite() {
+		t.Fatalf("expected no rewrite of a compacted xl.meta, got %+v, %v", check, err)
+	}
+
+	// Corrupted.
+	if _, _, err = compactXLMeta([]byte("XL2 garbage garbage"), "bucket", "object"); err == nil {
+		t.Fatal("expected corrupted xl.meta to fail")
+	}
+}
+
+func TestXLMetaMaintenanceJob(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	fsDirs, err := getRandomDisks(16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+
+	objLayer, _, err := initObjectLayer(ctx, mustGetPoolEndpoints(fsDirs...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	z := objLayer.(*erasureServerPools)
+
+	defer func(sc storageclass.Config) { globalStorageClass = sc }(globalStorageClass)
+	globalStorageClass = storageclass.Config{Standard: storageclass.StorageClass{Parity: 4}}
+
+	bucket := getRandomBucketName()
+	if err = objLayer.MakeBucketWithLocation(ctx, bucket, MakeBucketOptions{}); err != nil {
+		t.Fatalf("Failed to make a bucket - %v", err)
+	}
+	data := []byte("inline data")
+	if _, err = objLayer.PutObject(ctx, bucket, "object", mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Leave orphaned inline data on the first drive.
+	disk := z.serverPools[0].sets[0].getDisks()[0]
+	metaPath := pathJoin("object", xlStorageFormatFile)
+	buf, err := disk.ReadAll(ctx, bucket, metaPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var x xlMetaV2
+	if err = x.Load(buf); err != nil {
+		t.Fatal(err)
+	}
+	x.data.replace(mustGetUUID(), []byte("orphan"))
+	if buf, err = x.AppendTo(nil); err != nil {
+		t.Fatal(err)
+	}
+	if err = disk.WriteAll(ctx, bucket, metaPath, buf); err != nil {
+		t.Fatal(err)
+	}
+
+	run := func(dryRun bool) xlMetaMaintenanceStatus {
+		t.Helper()
+		jobs := &xlMetaMaintenanceJobs{jobs: make(map[string]*xlMetaMaintenanceJob)}
+		id, err := jobs.start(z, bucket, "", 1, dryRun)
+		if err != nil {
+			t.Fatal(err)
+		}
+		job, _ := jobs.get(id)
+		deadline := time.Now().Add(30 * time.Second)
+		for job.getStatus().Status == jobStatusRunning {
+			if time.Now().After(deadline) {
+				t.Fatal("job did not finish")
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		s := job.getStatus()
+		if s.Status != jobStatusDone {
+			t.Fatalf("expected job to be done, got %s (%s)", s.Status, s.Error)
+		}
+		if s.Objects != 1 || s.Files != 16 || s.OrphanData != 1 {
+			t.Fatalf("unexpected status %+v", s)
+		}
+		return s
+	}
+
+	if s := run(true); s.Rewritten != 0 {
+		t.Fatalf("expected dry run not to rewrite, got %d", s.Rewritten)
+	}
+	if s := run(false); s.Rewritten != 1 || s.BytesAfter >= s.BytesBefore {
+		t.Fatalf("expected the xl.meta to be compacted, got %+v", s)
+	}
+	if buf, err = disk.ReadAll(ctx, bucket, metaPath); err != nil {
+		t.Fatal(err)
+	}
+	if _, check, err := compactXLMeta(buf, bucket, "object"); err != nil || check.OrphanData != 0 {
+		t.Fatalf("expected orphaned data to be removed, got %+v, %v", check, err)
+	}
+}
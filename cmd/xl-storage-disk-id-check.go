@@ -31,6 +31,7 @@ import (
 
 	"github.com/minio/madmin-go"
 	"github.com/minio/pkg/env"
+	"github.com/qkbyte/minio/internal/ioprio"
 	"github.com/qkbyte/minio/internal/logger"
 )
 
@@ -71,6 +72,19 @@ const (
 	storageMetricLast
 )
 
+// isWrite returns true if the storage operation modifies the drive.
+func (s storageMetric) isWrite() bool {
+	switch s {
+	case storageMetricMakeVolBulk, storageMetricMakeVol, storageMetricDeleteVol,
+		storageMetricAppendFile, storageMetricCreateFile, storageMetricRenameFile,
+		storageMetricRenameData, storageMetricDelete, storageMetricDeleteVersions,
+		storageMetricWriteAll, storageMetricDeleteVersion, storageMetricWriteMetadata,
+		storageMetricUpdateMetadata:
+		return true
+	}
+	return false
+}
+
 // Detects change in underlying disk.
 type xlStorageDiskIDCheck struct {
 	// apiCalls should be placed first so alignment is guaranteed for atomic operations.
@@ -179,7 +193,12 @@ func (p *xlStorageDiskIDCheck) NSScanner(ctx context.Context, cache dataUsageCac
 	if err := p.checkDiskStale(); err != nil {
 		return dataUsageCache{}, err
 	}
-	return p.storage.NSScanner(ctx, cache, updates, scanMode)
+	var usage dataUsageCache
+	err := ioprio.Do(ioprio.Class(scannerIOClass.Load()), func() (err error) {
+		usage, err = p.storage.NSScanner(ctx, cache, updates, scanMode)
+		return err
+	})
+	return usage, err
 }
 
 func (p *xlStorageDiskIDCheck) GetDiskLoc() (poolIdx, setIdx, diskIdx int) {
@@ -318,9 +337,27 @@ func (p *xlStorageDiskIDCheck) ReadFile(ctx context.Context, volume string, path
 	if err != nil {
 		return 0, err
 	}
-	defer done(&err)
-
-	return p.storage.ReadFile(ctx, volume, path, offset, buf, verifier)
+	// A read outliving the deadline must not write into buf once the
+	// caller got its buffer back.
+	dst := buf
+	if _, ok := ctx.Deadline(); ok {
+		dst = make([]byte, len(buf))
+	}
+	nCh := make(chan int64, 1)
+	err = runDiskOp(ctx, done, func() error {
+		n, err := p.storage.ReadFile(ctx, volume, path, offset, dst, verifier)
+		nCh <- n
+		return err
+	})
+	select {
+	case n = <-nCh:
+		if len(buf) > 0 && &dst[0] != &buf[0] {
+			copy(buf, dst[:n])
+		}
+	default:
+		// Still reading past the deadline.
+	}
+	return n, err
 }
 
 func (p *xlStorageDiskIDCheck) AppendFile(ctx context.Context, volume string, path string, buf []byte) (err error) {
@@ -330,7 +367,9 @@ func (p *xlStorageDiskIDCheck) AppendFile(ctx context.Context, volume string, pa
 	}
 	defer done(&err)
 
-	return p.storage.AppendFile(ctx, volume, path, buf)
+	return ioprio.DoContext(ctx, func() error {
+		return p.storage.AppendFile(ctx, volume, path, buf)
+	})
 }
 
 func (p *xlStorageDiskIDCheck) CreateFile(ctx context.Context, volume, path string, size int64, reader io.Reader) (err error) {
@@ -338,9 +377,10 @@ func (p *xlStorageDiskIDCheck) CreateFile(ctx context.Context, volume, path stri
 	if err != nil {
 		return err
 	}
-	defer done(&err)
 
-	return p.storage.CreateFile(ctx, volume, path, size, reader)
+	return runDiskOp(ctx, done, func() error {
+		return p.storage.CreateFile(ctx, volume, path, size, reader)
+	})
 }
 
 func (p *xlStorageDiskIDCheck) ReadFileStream(ctx context.Context, volume, path string, offset, length int64) (io.ReadCloser, error) {
@@ -348,9 +388,22 @@ func (p *xlStorageDiskIDCheck) ReadFileStream(ctx context.Context, volume, path
 	if err != nil {
 		return nil, err
 	}
-	defer done(&err)
 
-	return p.storage.ReadFileStream(ctx, volume, path, offset, length)
+	// Streams opened after the deadline passed are closed.
+	rcCh := make(chan io.ReadCloser, 1)
+	if err = runDiskOp(ctx, done, func() error {
+		rc, err := p.storage.ReadFileStream(ctx, volume, path, offset, length)
+		rcCh <- rc
+		return err
+	}); err != nil {
+		go func() {
+			if rc := <-rcCh; rc != nil {
+				rc.Close()
+			}
+		}()
+		return nil, err
+	}
+	return ioprio.ReadCloser(ioprio.FromContext(ctx), <-rcCh), nil
 }
 
 func (p *xlStorageDiskIDCheck) RenameFile(ctx context.Context, srcVolume, srcPath, dstVolume, dstPath string) (err error) {
@@ -368,9 +421,15 @@ func (p *xlStorageDiskIDCheck) RenameData(ctx context.Context, srcVolume, srcPat
 	if err != nil {
 		return 0, err
 	}
-	defer done(&err)
 
-	return p.storage.RenameData(ctx, srcVolume, srcPath, fi, dstVolume, dstPath)
+	var rsign uint64
+	if err = runDiskOp(ctx, done, func() (err error) {
+		rsign, err = p.storage.RenameData(ctx, srcVolume, srcPath, fi, dstVolume, dstPath)
+		return err
+	}); err != nil {
+		return 0, err
+	}
+	return rsign, nil
 }
 
 func (p *xlStorageDiskIDCheck) CheckParts(ctx context.Context, volume string, path string, fi FileInfo) (err error) {
@@ -380,7 +439,9 @@ func (p *xlStorageDiskIDCheck) CheckParts(ctx context.Context, volume string, pa
 	}
 	defer done(&err)
 
-	return p.storage.CheckParts(ctx, volume, path, fi)
+	return ioprio.DoContext(ctx, func() error {
+		return p.storage.CheckParts(ctx, volume, path, fi)
+	})
 }
 
 func (p *xlStorageDiskIDCheck) Delete(ctx context.Context, volume string, path string, deleteOpts DeleteOptions) (err error) {
@@ -428,7 +489,9 @@ func (p *xlStorageDiskIDCheck) VerifyFile(ctx context.Context, volume, path stri
 	}
 	defer done(&err)
 
-	return p.storage.VerifyFile(ctx, volume, path, fi)
+	return ioprio.DoContext(ctx, func() error {
+		return p.storage.VerifyFile(ctx, volume, path, fi)
+	})
 }
 
 func (p *xlStorageDiskIDCheck) WriteAll(ctx context.Context, volume string, path string, b []byte) (err error) {
@@ -468,7 +531,9 @@ func (p *xlStorageDiskIDCheck) WriteMetadata(ctx context.Context, volume, path s
 	}
 	defer done(&err)
 
-	return p.storage.WriteMetadata(ctx, volume, path, fi)
+	return ioprio.DoContext(ctx, func() error {
+		return p.storage.WriteMetadata(ctx, volume, path, fi)
+	})
 }
 
 func (p *xlStorageDiskIDCheck) ReadVersion(ctx context.Context, volume, path, versionID string, readData bool) (fi FileInfo, err error) {
@@ -476,9 +541,15 @@ func (p *xlStorageDiskIDCheck) ReadVersion(ctx context.Context, volume, path, ve
 	if err != nil {
 		return fi, err
 	}
-	defer done(&err)
 
-	return p.storage.ReadVersion(ctx, volume, path, versionID, readData)
+	var rfi FileInfo
+	if err = runDiskOp(ctx, done, func() (err error) {
+		rfi, err = p.storage.ReadVersion(ctx, volume, path, versionID, readData)
+		return err
+	}); err != nil {
+		return fi, err
+	}
+	return rfi, nil
 }
 
 func (p *xlStorageDiskIDCheck) ReadAll(ctx context.Context, volume string, path string) (buf []byte, err error) {
@@ -486,9 +557,15 @@ func (p *xlStorageDiskIDCheck) ReadAll(ctx context.Context, volume string, path
 	if err != nil {
 		return nil, err
 	}
-	defer done(&err)
 
-	return p.storage.ReadAll(ctx, volume, path)
+	var b []byte
+	if err = runDiskOp(ctx, done, func() (err error) {
+		b, err = p.storage.ReadAll(ctx, volume, path)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return b, nil
 }
 
 func (p *xlStorageDiskIDCheck) ReadXL(ctx context.Context, volume string, path string, readData bool) (rf RawFileInfo, err error) {
@@ -496,9 +573,15 @@ func (p *xlStorageDiskIDCheck) ReadXL(ctx context.Context, volume string, path s
 	if err != nil {
 		return RawFileInfo{}, err
 	}
-	defer done(&err)
 
-	return p.storage.ReadXL(ctx, volume, path, readData)
+	var raw RawFileInfo
+	if err = runDiskOp(ctx, done, func() (err error) {
+		raw, err = p.storage.ReadXL(ctx, volume, path, readData)
+		return err
+	}); err != nil {
+		return RawFileInfo{}, err
+	}
+	return raw, nil
 }
 
 func (p *xlStorageDiskIDCheck) StatInfoFile(ctx context.Context, volume, path string, glob bool) (stat []StatInfo, err error) {
@@ -506,9 +589,15 @@ func (p *xlStorageDiskIDCheck) StatInfoFile(ctx context.Context, volume, path st
 	if err != nil {
 		return nil, err
 	}
-	defer done(&err)
 
-	return p.storage.StatInfoFile(ctx, volume, path, glob)
+	var st []StatInfo
+	if err = runDiskOp(ctx, done, func() (err error) {
+		st, err = p.storage.StatInfoFile(ctx, volume, path, glob)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return st, nil
 }
 
 // ReadMultiple will read multiple files and send each back as response.
@@ -639,6 +728,31 @@ func (h *healthDiskCtxValue) logSuccess() {
 // Can be reused.
 var noopDoneFunc = func(_ *error) {}
 
+// runDiskOp runs fn under the I/O class of ctx and reports its outcome
+// to done. When ctx carries a deadline fn runs in the background and
+// ctx.Err() is returned as soon as the deadline passes, a drive that
+// hangs keeps its token until fn eventually returns.
+func runDiskOp(ctx context.Context, done func(*error), fn func() error) error {
+	if _, ok := ctx.Deadline(); !ok {
+		err := ioprio.DoContext(ctx, fn)
+		done(&err)
+		return err
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		err := ioprio.DoContext(ctx, fn)
+		done(&err)
+		errCh <- err
+	}()
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // TrackDiskHealth for this request.
 // When a non-nil error is returned 'done' MUST be called
 // with the status of the response, if it corresponds to disk health.
@@ -664,6 +778,12 @@ func (p *xlStorageDiskIDCheck) TrackDiskHealth(ctx context.Context, s storageMet
 		return ctx, done, err
 	}
 
+	// Refuse writes to a drive that is not the one
+	// format.json was originally recorded on.
+	if s.isWrite() && p.storage.isIdentityMismatch() {
+		return ctx, done, errDriveIdentityMismatch
+	}
+
 	// Disallow recursive tracking to avoid deadlocks.
 	if ctx.Value(healthDiskCtxKey{}) != nil {
 		done = p.updateStorageMetrics(s, paths...)
@@ -0,0 +1,60 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRunDiskOp(t *testing.T) {
+	var calls int
+	done := func(errp *error) { calls++ }
+
+	// Without a deadline fn runs inline.
+	errFn := errors.New("drive error")
+	if err := runDiskOp(context.Background(), done, func() error { return errFn }); err != errFn {
+		t.Fatalf("expected %v, got %v", errFn, err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected done to be called once, got %d", calls)
+	}
+
+	// A drive call outliving the deadline is abandoned, done is only
+	// called once it returns.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	release := make(chan struct{})
+	finished := make(chan struct{})
+	err := runDiskOp(ctx, func(errp *error) { close(finished) }, func() error {
+		<-release
+		return nil
+	})
+	if err != context.DeadlineExceeded {
+		t.Fatalf("expected %v, got %v", context.DeadlineExceeded, err)
+	}
+	select {
+	case <-finished:
+		t.Fatal("done called before the drive call returned")
+	default:
+	}
+	close(release)
+	<-finished
+}
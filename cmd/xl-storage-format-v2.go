@@ -1553,6 +1553,14 @@ func (x *xlMetaV2) AddVersion(fi FileInfo) error {
 		if x.versions[i].header.VersionID != uv {
 			continue
 		}
+		// An overwritten version keeps the time it was created.
+		if ventry.ObjectV2 != nil && x.versions[i].header.Type == ObjectType {
+			if prev, err := x.getIdx(i); err == nil && prev.ObjectV2 != nil {
+				if created, ok := prev.ObjectV2.MetaSys[objectCreatedKey]; ok {
+					ventry.ObjectV2.MetaSys[objectCreatedKey] = created
+				}
+			}
+		}
 		switch x.versions[i].header.Type {
 		case LegacyType:
 			// This would convert legacy type into new ObjectType
@@ -1573,6 +1581,23 @@ func (x *xlMetaV2) AddVersion(fi FileInfo) error {
 	return x.addVersion(ventry)
 }
 
+// versionCreated returns the time the version at idx was written to
+// this cluster, or its modtime if it was not recorded.
+func (x *xlMetaV2) versionCreated(idx int) time.Time {
+	modTime := time.Unix(0, x.versions[idx].header.ModTime)
+	if x.versions[idx].header.Type != ObjectType {
+		return modTime
+	}
+	ver, err := x.getIdx(idx)
+	if err != nil || ver.ObjectV2 == nil {
+		return modTime
+	}
+	if t, err := time.Parse(time.RFC3339Nano, string(ver.ObjectV2.MetaSys[objectCreatedKey])); err == nil {
+		return t
+	}
+	return modTime
+}
+
 func (x *xlMetaV2) SharedDataDirCount(versionID [16]byte, dataDir [16]byte) int {
 	// v2 object is inlined, if it is skip dataDir share check.
 	if x.data.entries() > 0 && x.data.find(uuid.UUID(versionID).String()) != nil {
@@ -41,6 +41,7 @@ import (
 	"github.com/minio/pkg/console"
 	"github.com/qkbyte/minio/internal/bucket/lifecycle"
 	"github.com/qkbyte/minio/internal/color"
+	"github.com/qkbyte/minio/internal/crypto"
 	"github.com/qkbyte/minio/internal/disk"
 	xioutil "github.com/qkbyte/minio/internal/ioutil"
 	"github.com/qkbyte/minio/internal/logger"
@@ -114,9 +115,18 @@ type xlStorage struct {
 
 	formatData []byte
 
+	// serial is the serial number of the physical drive backing
+	// diskPath, recordedSerial is the one recorded in format.json.
+	serial           string
+	recordedSerial   string
+	identityMismatch int32
+
 	// mutex to prevent concurrent read operations overloading walks.
 	walkMu     sync.Mutex
 	walkReadMu sync.Mutex
+
+	// new files are written unnamed when supported.
+	tmpFile tmpFile
 }
 
 // checkPathLength - returns error if given path name length more than 255
@@ -249,6 +259,10 @@ func newXLStorage(ep Endpoint) (s *xlStorage, err error) {
 
 	bgFormatErasureCleanupTmp(s.diskPath) // cleanup any old data.
 
+	// Serial numbers are best effort, not all platforms
+	// and drive types expose them.
+	s.serial, _ = disk.GetSerial(s.diskPath)
+
 	formatData, formatFi, err := formatErasureMigrate(s.diskPath)
 	if err != nil && !errors.Is(err, os.ErrNotExist) {
 		if os.IsPermission(err) {
@@ -287,6 +301,9 @@ func newXLStorage(ep Endpoint) (s *xlStorage, err error) {
 		s.diskID = format.Erasure.This
 		s.formatLastCheck = time.Now()
 		s.formatLegacy = format.Erasure.DistributionAlgo == formatErasureVersionV2DistributionAlgoV1
+		if b := s.checkDriveIdentity(format); b != nil {
+			s.formatData = b
+		}
 	}
 
 	// Success.
@@ -512,10 +529,10 @@ func (s *xlStorage) NSScanner(ctx context.Context, cache dataUsageCache, updates
 			}
 			return sizeSummary{}, errSkipFile
 		}
-		sizeS := sizeSummary{}
-		var noTiers bool
-		if noTiers = globalTierConfigMgr.Empty(); !noTiers {
-			sizeS.tiers = make(map[string]tierStats)
+		sizeS := sizeSummary{
+			tiers:          make(map[string]tierStats),
+			storageClasses: make(map[string]tierStats),
+			kmsKeys:        make(map[string]tierStats),
 		}
 
 		done := globalScannerMetrics.time(scannerMetricApplyAll)
@@ -533,6 +550,9 @@ func (s *xlStorage) NSScanner(ctx context.Context, cache dataUsageCache, updates
 
 		for _, version := range fivs.Versions {
 			oi := version.ToObjectInfo(item.bucket, item.objectPath(), versioned)
+			if oi.IsLatest {
+				globalTagIndexer.scanned(ctx, oi)
+			}
 			done = globalScannerMetrics.time(scannerMetricApplyVersion)
 			sz := item.applyActions(ctx, objAPI, oi, &sizeS)
 			done()
@@ -541,27 +561,35 @@ func (s *xlStorage) NSScanner(ctx context.Context, cache dataUsageCache, updates
 			}
 			sizeS.totalSize += sz
 
-			// Skip tier accounting if,
-			// 1. no tiers configured
-			// 2. object version is a delete-marker or a free-version
-			//    tracking deleted transitioned objects
+			// Skip tier accounting if object version is a delete-marker
+			// or a free-version tracking deleted transitioned objects
 			switch {
-			case noTiers, oi.DeleteMarker, oi.TransitionedObject.FreeVersion:
+			case oi.DeleteMarker, oi.TransitionedObject.FreeVersion:
 				continue
 			}
-			tier := minioHotTier
+			// Versions encrypted with a KMS master key are accounted to the key.
+			if keyID, ok := oi.UserDefined[crypto.MetaKeyID]; ok {
+				sizeS.kmsKeys[keyID] = sizeS.kmsKeys[keyID].add(oi.tierStats())
+			}
+			// Data kept on the drives is accounted to the hot tier and
+			// to its storage class, transitioned data to its tier.
 			if oi.TransitionedObject.Status == lifecycle.TransitionComplete {
-				tier = oi.TransitionedObject.Tier
+				tier := oi.TransitionedObject.Tier
+				sizeS.tiers[tier] = sizeS.tiers[tier].add(oi.tierStats())
+				continue
 			}
-			sizeS.tiers[tier] = sizeS.tiers[tier].add(oi.tierStats())
+			sizeS.tiers[minioHotTier] = sizeS.tiers[minioHotTier].add(oi.tierStats())
+			sizeS.storageClasses[oi.StorageClass] = sizeS.storageClasses[oi.StorageClass].add(oi.tierStats())
 		}
 
 		// apply tier sweep action on free versions
 		for _, freeVersion := range fivs.FreeVersions {
 			oi := freeVersion.ToObjectInfo(item.bucket, item.objectPath(), versioned)
-			done = globalScannerMetrics.time(scannerMetricTierObjSweep)
-			item.applyTierObjSweep(ctx, objAPI, oi)
-			done()
+			scannerLifecyclePool.submit(ctx, func(ctx context.Context) {
+				done := globalScannerMetrics.time(scannerMetricTierObjSweep)
+				item.applyTierObjSweep(ctx, objAPI, oi)
+				done()
+			})
 		}
 		return sizeS, nil
 	}, scanMode)
@@ -603,6 +631,9 @@ func (s *xlStorage) DiskInfo(context.Context) (info DiskInfo, err error) {
 			dcinfo.Healing = errors.Is(err, errUnformattedDisk) || (s.Healing() != nil)
 			dcinfo.Scanning = atomic.LoadInt32(&s.scanning) == 1
 			dcinfo.ID = diskID
+			dcinfo.Serial = s.serial
+			dcinfo.RecordedSerial = s.getRecordedSerial()
+			dcinfo.IdentityMismatch = s.isIdentityMismatch()
 			return dcinfo, err
 		}
 	})
@@ -709,6 +740,10 @@ func (s *xlStorage) GetDiskID() (string, error) {
 		return "", errCorruptedFormat
 	}
 
+	if formatData := s.checkDriveIdentity(format); formatData != nil {
+		b = formatData
+	}
+
 	s.Lock()
 	defer s.Unlock()
 	s.formatData = b
@@ -719,6 +754,72 @@ func (s *xlStorage) GetDiskID() (string, error) {
 	return s.diskID, nil
 }
 
+// checkDriveIdentity compares the drive identity recorded in format.json
+// with the physical drive currently backing the disk path. The identity
+// is recorded on first use, once recorded a drive showing up with a
+// different serial number is flagged and refuses writes until it is
+// replaced with a fresh drive. Returns the new format.json contents if
+// the identity was recorded.
+func (s *xlStorage) checkDriveIdentity(format *formatErasureV3) (formatData []byte) {
+	if s.serial == "" {
+		return nil
+	}
+
+	if format.Drive == nil || format.Drive.Serial == "" {
+		format.Drive = &formatDriveIdentity{
+			Serial:   s.serial,
+			Recorded: UTCNow(),
+		}
+		b, err := s.saveFormat(format)
+		if err != nil {
+			logger.LogIf(GlobalContext, fmt.Errorf("unable to record drive identity on %s: %w", s, err))
+			return nil
+		}
+		formatData = b
+	}
+
+	s.Lock()
+	s.recordedSerial = format.Drive.Serial
+	s.Unlock()
+
+	if format.Drive.Serial == s.serial {
+		atomic.StoreInt32(&s.identityMismatch, 0)
+		return formatData
+	}
+	if atomic.SwapInt32(&s.identityMismatch, 1) == 0 {
+		logger.LogIf(GlobalContext, fmt.Errorf("drive %s was formatted on drive with serial %s but is now backed by drive with serial %s, refusing writes until the drive is replaced: %w",
+			s, format.Drive.Serial, s.serial, errDriveIdentityMismatch))
+	}
+	return formatData
+}
+
+// saveFormat atomically replaces format.json on this drive.
+func (s *xlStorage) saveFormat(format *formatErasureV3) ([]byte, error) {
+	json := jsoniter.ConfigCompatibleWithStandardLibrary
+	b, err := json.Marshal(format)
+	if err != nil {
+		return nil, err
+	}
+	tmpFormat := mustGetUUID()
+	if err = s.writeAll(GlobalContext, minioMetaTmpBucket, tmpFormat, b, true); err != nil {
+		return nil, err
+	}
+	return b, s.RenameFile(GlobalContext, minioMetaTmpBucket, tmpFormat, minioMetaBucket, formatConfigFile)
+}
+
+func (s *xlStorage) getRecordedSerial() string {
+	s.RLock()
+	defer s.RUnlock()
+
+	return s.recordedSerial
+}
+
+// isIdentityMismatch returns true if the drive backing this disk
+// path is not the one format.json was recorded on.
+func (s *xlStorage) isIdentityMismatch() bool {
+	return atomic.LoadInt32(&s.identityMismatch) == 1
+}
+
 // Make a volume entry.
 func (s *xlStorage) SetDiskID(id string) {
 	// NO-OP for xlStorage as it is handled either by xlStorageDiskIDCheck{} for local disks or
@@ -1491,7 +1592,7 @@ func (s *xlStorage) readAllData(ctx context.Context, volumeDir string, filePath
 		buf = make([]byte, sz)
 	}
 	// Read file...
-	_, err = io.ReadFull(diskHealthReader(ctx, r), buf)
+	_, err = io.ReadFull(diskHealthReader(ctx, newOSReader(r)), buf)
 
 	return buf, stat.ModTime().UTC(), osErrToFileErr(err)
 }
@@ -1601,7 +1702,7 @@ func (s *xlStorage) ReadFile(ctx context.Context, volume string, path string, of
 	}
 
 	if verifier == nil {
-		n, err = file.ReadAt(buffer, offset)
+		n, err = ReadAt(file, buffer, offset)
 		return int64(n), err
 	}
 
@@ -1806,7 +1907,7 @@ func (s *xlStorage) ReadFileStream(ctx context.Context, volume, path string, off
 	r := struct {
 		io.Reader
 		io.Closer
-	}{Reader: io.LimitReader(diskHealthReader(ctx, or), length), Closer: closeWrapper(func() error {
+	}{Reader: io.LimitReader(diskHealthReader(ctx, newOSReader(or)), length), Closer: closeWrapper(func() error {
 		if (!alignment || offset+length%xioutil.DirectioAlignSize != 0) && odirectEnabled {
 			// invalidate page-cache for unaligned reads.
 			// skip removing from page-cache only
@@ -1867,10 +1968,19 @@ func (s *xlStorage) writeAllDirect(ctx context.Context, filePath string, fileSiz
 
 	odirectEnabled := s.oDirect
 	var w *os.File
-	if odirectEnabled {
-		w, err = OpenFileDirectIO(filePath, flags, 0o666)
-	} else {
-		w, err = OpenFile(filePath, flags, 0o666)
+	var unnamed bool
+	if flags&os.O_EXCL != 0 {
+		// Write new files unnamed and only link them once
+		// complete, a crash never leaves partial files behind.
+		w, err = s.tmpFile.open(parentFilePath, odirectEnabled)
+		unnamed = err == nil
+	}
+	if !unnamed {
+		if odirectEnabled {
+			w, err = OpenFileDirectIO(filePath, flags, 0o666)
+		} else {
+			w, err = OpenFile(filePath, flags, 0o666)
+		}
 	}
 	if err != nil {
 		return osErrToFileErr(err)
@@ -1892,9 +2002,9 @@ func (s *xlStorage) writeAllDirect(ctx context.Context, filePath string, fileSiz
 
 	var written int64
 	if odirectEnabled {
-		written, err = xioutil.CopyAligned(diskHealthWriter(ctx, w), r, *bufp, fileSize, w)
+		written, err = xioutil.CopyAligned(diskHealthWriter(ctx, newOSWriter(w)), r, *bufp, fileSize, w)
 	} else {
-		written, err = io.CopyBuffer(diskHealthWriter(ctx, w), r, *bufp)
+		written, err = io.CopyBuffer(diskHealthWriter(ctx, newOSWriter(w)), r, *bufp)
 	}
 	if err != nil {
 		return err
@@ -1907,7 +2017,14 @@ func (s *xlStorage) writeAllDirect(ctx context.Context, filePath string, fileSiz
 	}
 
 	// Only interested in flushing the size_t not mtime/atime
-	return Fdatasync(w)
+	if err = Fdatasync(w); err != nil {
+		return err
+	}
+
+	if unnamed {
+		return s.tmpFile.link(w, filePath, false)
+	}
+	return nil
 }
 
 func (s *xlStorage) writeAll(ctx context.Context, volume string, path string, b []byte, sync bool) (err error) {
@@ -1943,7 +2060,7 @@ func (s *xlStorage) writeAll(ctx context.Context, volume string, path string, b
 	}
 	defer w.Close()
 
-	n, err := w.Write(b)
+	n, err := newOSWriter(w).Write(b)
 	if err != nil {
 		return err
 	}
@@ -1993,7 +2110,7 @@ func (s *xlStorage) AppendFile(ctx context.Context, volume string, path string,
 	}
 	defer w.Close()
 
-	n, err := w.Write(buf)
+	n, err := newOSWriter(w).Write(buf)
 	if err != nil {
 		return err
 	}
@@ -1929,3 +1929,45 @@ func TestXLStorageReadMetadata(t *testing.T) {
 		t.Fatalf("Unexpected error from readMetadata - expect %v: got %v", errFileNameTooLong, err)
 	}
 }
+
+// TestXLStorageDriveIdentity - tests recording of the drive identity in
+// format.json and refusal of writes upon identity mismatch.
+func TestXLStorageDriveIdentity(t *testing.T) {
+	disk, _, err := newXLStorageTestSetup(t)
+	if err != nil {
+		t.Fatalf("Unable to create xlStorage test setup, %s", err)
+	}
+
+	xl := disk.storage
+	xl.serial = "serial-1"
+	if _, err = xl.GetDiskID(); err != nil {
+		t.Fatal(err)
+	}
+	if xl.getRecordedSerial() != "serial-1" || xl.isIdentityMismatch() {
+		t.Fatalf("expected drive identity to be recorded, got %s", xl.getRecordedSerial())
+	}
+
+	format, err := loadFormatErasure(xl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if format.Drive == nil || format.Drive.Serial != "serial-1" {
+		t.Fatalf("expected format.json to carry the drive identity, got %#v", format.Drive)
+	}
+	if err = disk.MakeVol(context.Background(), "success-vol"); err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+
+	// Simulate a different drive showing up with the same format.json
+	xl.serial = "serial-2"
+	xl.checkDriveIdentity(format)
+	if !xl.isIdentityMismatch() {
+		t.Fatal("expected drive identity mismatch")
+	}
+	if err = disk.MakeVol(context.Background(), "failure-vol"); err != errDriveIdentityMismatch {
+		t.Fatalf("expected %s, got %v", errDriveIdentityMismatch, err)
+	}
+	if _, err = disk.StatVol(context.Background(), "success-vol"); err != nil {
+		t.Fatalf("expected reads to be allowed, got %s", err)
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cors
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/minio/pkg/wildcard"
+)
+
+// maxRules is the maximum number of CORS rules of a bucket, same as AWS S3.
+const maxRules = 100
+
+var (
+	errNoRules        = Errorf("CORS configuration must have at least one CORSRule")
+	errTooManyRules   = Errorf("CORS configuration must not have more than 100 CORSRules")
+	errNoMethods      = Errorf("CORSRule must have at least one AllowedMethod")
+	errNoOrigins      = Errorf("CORSRule must have at least one AllowedOrigin")
+	errNegativeMaxAge = Errorf("CORSRule MaxAgeSeconds must not be negative")
+)
+
+// Rule - a single CORS rule of a bucket.
+type Rule struct {
+	ID             string   `xml:"ID,omitempty"`
+	AllowedHeaders []string `xml:"AllowedHeader,omitempty"`
+	AllowedMethods []string `xml:"AllowedMethod"`
+	AllowedOrigins []string `xml:"AllowedOrigin"`
+	ExposeHeaders  []string `xml:"ExposeHeader,omitempty"`
+	MaxAgeSeconds  int      `xml:"MaxAgeSeconds,omitempty"`
+}
+
+// Config - CORS configuration of a bucket.
+type Config struct {
+	XMLNS   string   `xml:"xmlns,attr,omitempty"`
+	XMLName xml.Name `xml:"CORSConfiguration"`
+	Rules   []Rule   `xml:"CORSRule"`
+}
+
+// Validate - validates the CORS rule.
+func (r Rule) Validate() error {
+	if len(r.AllowedMethods) == 0 {
+		return errNoMethods
+	}
+	for _, method := range r.AllowedMethods {
+		switch method {
+		case http.MethodGet, http.MethodPut, http.MethodHead, http.MethodPost, http.MethodDelete:
+		default:
+			return Errorf("Found unsupported HTTP method in CORS config. Unsupported method is %s", method)
+		}
+	}
+	if len(r.AllowedOrigins) == 0 {
+		return errNoOrigins
+	}
+	for _, origin := range r.AllowedOrigins {
+		if strings.Count(origin, "*") > 1 {
+			return Errorf("AllowedOrigin '%s' can not have more than one wildcard", origin)
+		}
+	}
+	for _, header := range r.AllowedHeaders {
+		if strings.Count(header, "*") > 1 {
+			return Errorf("AllowedHeader '%s' can not have more than one wildcard", header)
+		}
+	}
+	if r.MaxAgeSeconds < 0 {
+		return errNegativeMaxAge
+	}
+	return nil
+}
+
+// Validate - validates the CORS configuration.
+func (c Config) Validate() error {
+	if len(c.Rules) == 0 {
+		return errNoRules
+	}
+	if len(c.Rules) > maxRules {
+		return errTooManyRules
+	}
+	for _, rule := range c.Rules {
+		if err := rule.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// matchOrigin returns true if the origin is allowed by the rule.
+func (r Rule) matchOrigin(origin string) bool {
+	for _, allowed := range r.AllowedOrigins {
+		if wildcard.MatchSimple(allowed, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchMethod returns true if the method is allowed by the rule.
+func (r Rule) matchMethod(method string) bool {
+	for _, allowed := range r.AllowedMethods {
+		if allowed == method {
+			return true
+		}
+	}
+	return false
+}
+
+// matchHeaders returns true if all the headers are allowed by the rule,
+// header names are matched case-insensitively.
+func (r Rule) matchHeaders(headers []string) bool {
+	for _, header := range headers {
+		header = strings.ToLower(strings.TrimSpace(header))
+		if header == "" {
+			continue
+		}
+		var found bool
+		for _, allowed := range r.AllowedHeaders {
+			if wildcard.MatchSimple(strings.ToLower(allowed), header) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// AllowsAnyOrigin returns true if the rule allows requests
+// from any origin.
+func (r Rule) AllowsAnyOrigin() bool {
+	for _, allowed := range r.AllowedOrigins {
+		if allowed == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// Match returns the first rule allowing a request from origin with
+// method and request headers, nil is returned if no rule matches.
+func (c Config) Match(origin, method string, headers []string) *Rule {
+	for i := range c.Rules {
+		rule := &c.Rules[i]
+		if rule.matchOrigin(origin) && rule.matchMethod(method) && rule.matchHeaders(headers) {
+			return rule
+		}
+	}
+	return nil
+}
+
+// ParseConfig - parses data in given reader to CORSConfiguration.
+func ParseConfig(reader io.Reader) (*Config, error) {
+	var c Config
+	if err := xml.NewDecoder(reader).Decode(&c); err != nil {
+		return nil, err
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cors
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseConfig(t *testing.T) {
+	testCases := []struct {
+		input     string
+		shouldErr bool
+	}{
+		{
+			input: `<CORSConfiguration><CORSRule><AllowedOrigin>https://*.example.com</AllowedOrigin><AllowedMethod>GET</AllowedMethod><AllowedHeader>*</AllowedHeader><MaxAgeSeconds>3000</MaxAgeSeconds></CORSRule></CORSConfiguration>`,
+		},
+		// No rules.
+		{input: `<CORSConfiguration></CORSConfiguration>`, shouldErr: true},
+		// No methods.
+		{input: `<CORSConfiguration><CORSRule><AllowedOrigin>*</AllowedOrigin></CORSRule></CORSConfiguration>`, shouldErr: true},
+		// Unsupported method.
+		{input: `<CORSConfiguration><CORSRule><AllowedOrigin>*</AllowedOrigin><AllowedMethod>PATCH</AllowedMethod></CORSRule></CORSConfiguration>`, shouldErr: true},
+		// No origins.
+		{input: `<CORSConfiguration><CORSRule><AllowedMethod>GET</AllowedMethod></CORSRule></CORSConfiguration>`, shouldErr: true},
+		// Multiple wildcards in origin.
+		{input: `<CORSConfiguration><CORSRule><AllowedOrigin>https://*.*.com</AllowedOrigin><AllowedMethod>GET</AllowedMethod></CORSRule></CORSConfiguration>`, shouldErr: true},
+		// Malformed XML.
+		{input: `<CORSConfiguration><CORSRule>`, shouldErr: true},
+	}
+
+	for i, testCase := range testCases {
+		_, err := ParseConfig(strings.NewReader(testCase.input))
+		if testCase.shouldErr != (err != nil) {
+			t.Errorf("Test %d: expected error %t, got %v", i+1, testCase.shouldErr, err)
+		}
+	}
+}
+
+func TestConfigMatch(t *testing.T) {
+	config, err := ParseConfig(strings.NewReader(`<CORSConfiguration>
+<CORSRule><ID>app</ID><AllowedOrigin>https://*.example.com</AllowedOrigin><AllowedMethod>GET</AllowedMethod><AllowedMethod>PUT</AllowedMethod><AllowedHeader>x-amz-*</AllowedHeader><AllowedHeader>Content-Type</AllowedHeader></CORSRule>
+<CORSRule><ID>public</ID><AllowedOrigin>*</AllowedOrigin><AllowedMethod>GET</AllowedMethod></CORSRule>
+</CORSConfiguration>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		origin  string
+		method  string
+		headers []string
+		ruleID  string
+	}{
+		{origin: "https://app.example.com", method: "PUT", headers: []string{"content-type", " X-Amz-Date"}, ruleID: "app"},
+		{origin: "https://app.example.com", method: "GET", ruleID: "app"},
+		{origin: "https://app.example.com", method: "PUT", headers: []string{"authorization"}},
+		{origin: "https://other.org", method: "GET", ruleID: "public"},
+		{origin: "https://other.org", method: "PUT"},
+		{origin: "https://other.org", method: "GET", headers: []string{"x-amz-date"}},
+	}
+
+	for i, testCase := range testCases {
+		rule := config.Match(testCase.origin, testCase.method, testCase.headers)
+		switch {
+		case rule == nil && testCase.ruleID != "":
+			t.Errorf("Test %d: expected rule %s to match", i+1, testCase.ruleID)
+		case rule != nil && rule.ID != testCase.ruleID:
+			t.Errorf("Test %d: expected rule '%s', got '%s'", i+1, testCase.ruleID, rule.ID)
+		}
+	}
+}
@@ -20,6 +20,7 @@ package sse
 import (
 	"encoding/xml"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"strings"
@@ -80,6 +81,11 @@ type BucketSSEConfig struct {
 	XMLNS   string   `xml:"xmlns,attr,omitempty"`
 	XMLName xml.Name `xml:"ServerSideEncryptionConfiguration"`
 	Rules   []Rule   `xml:"Rule"`
+
+	// ComplianceMode is a MinIO extension, when set requests without
+	// SSE headers matching the rule are rejected instead of being
+	// encrypted transparently.
+	ComplianceMode bool `xml:"ComplianceMode,omitempty"`
 }
 
 // ParseBucketSSEConfig - Decodes given XML to a valid default bucket encryption config
@@ -157,6 +163,33 @@ func (b *BucketSSEConfig) Apply(headers http.Header, opts ApplyOptions) {
 	}
 }
 
+// CheckCompliance returns an error describing why the given HTTP headers
+// do not request the server-side encryption configured for the bucket.
+// It always returns nil when the configuration is not in compliance mode.
+func (b *BucketSSEConfig) CheckCompliance(headers http.Header) error {
+	if b == nil || !b.ComplianceMode {
+		return nil
+	}
+
+	algo := headers.Get(xhttp.AmzServerSideEncryption)
+	switch {
+	case algo == "" && crypto.SSEC.IsRequested(headers):
+		return fmt.Errorf("SSE-C requested, bucket requires %s", b.Algo())
+	case algo == "":
+		return fmt.Errorf("no server-side encryption requested, bucket requires %s", b.Algo())
+	case algo != string(b.Algo()):
+		return fmt.Errorf("server-side encryption %s requested, bucket requires %s", algo, b.Algo())
+	}
+
+	if b.Algo() == AWSKms {
+		keyID := strings.TrimPrefix(headers.Get(xhttp.AmzServerSideEncryptionKmsID), crypto.ARNPrefix)
+		if keyID != b.KeyID() {
+			return fmt.Errorf("KMS key '%s' requested, bucket requires '%s'", keyID, b.KeyID())
+		}
+	}
+	return nil
+}
+
 // Algo returns the SSE algorithm specified by the SSE configuration.
 func (b *BucketSSEConfig) Algo() Algorithm {
 	for _, rule := range b.Rules {
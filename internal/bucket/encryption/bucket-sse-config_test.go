@@ -21,7 +21,11 @@ import (
 	"bytes"
 	"encoding/xml"
 	"errors"
+	"net/http"
 	"testing"
+
+	"github.com/qkbyte/minio/internal/crypto"
+	xhttp "github.com/qkbyte/minio/internal/http"
 )
 
 // TestParseBucketSSEConfig performs basic sanity tests on ParseBucketSSEConfig
@@ -151,3 +155,45 @@ func TestParseBucketSSEConfig(t *testing.T) {
 		}
 	}
 }
+
+func TestCheckCompliance(t *testing.T) {
+	kmsConfig := `<ServerSideEncryptionConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Rule><ApplyServerSideEncryptionByDefault><SSEAlgorithm>aws:kms</SSEAlgorithm><KMSMasterKeyID>my-key</KMSMasterKeyID></ApplyServerSideEncryptionByDefault></Rule><ComplianceMode>true</ComplianceMode></ServerSideEncryptionConfiguration>`
+	config, err := ParseBucketSSEConfig(bytes.NewBufferString(kmsConfig))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !config.ComplianceMode {
+		t.Fatal("expected compliance mode to be parsed")
+	}
+
+	testCases := []struct {
+		headers   http.Header
+		compliant bool
+	}{
+		{headers: http.Header{}, compliant: false},
+		{headers: http.Header{xhttp.AmzServerSideEncryption: []string{xhttp.AmzEncryptionAES}}, compliant: false},
+		{headers: http.Header{xhttp.AmzServerSideEncryption: []string{xhttp.AmzEncryptionKMS}}, compliant: false},
+		{headers: http.Header{
+			xhttp.AmzServerSideEncryption:      []string{xhttp.AmzEncryptionKMS},
+			xhttp.AmzServerSideEncryptionKmsID: []string{"other-key"},
+		}, compliant: false},
+		{headers: http.Header{
+			xhttp.AmzServerSideEncryption:      []string{xhttp.AmzEncryptionKMS},
+			xhttp.AmzServerSideEncryptionKmsID: []string{"my-key"},
+		}, compliant: true},
+		{headers: http.Header{
+			xhttp.AmzServerSideEncryption:      []string{xhttp.AmzEncryptionKMS},
+			xhttp.AmzServerSideEncryptionKmsID: []string{crypto.ARNPrefix + "my-key"},
+		}, compliant: true},
+	}
+	for i, tc := range testCases {
+		if err := config.CheckCompliance(tc.headers); (err == nil) != tc.compliant {
+			t.Errorf("Test %d: expected compliant=%t, got %v", i+1, tc.compliant, err)
+		}
+	}
+
+	config.ComplianceMode = false
+	if err := config.CheckCompliance(http.Header{}); err != nil {
+		t.Fatalf("expected no error without compliance mode, got %v", err)
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package logging
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+)
+
+var (
+	errMissingTargetBucket = Errorf("TargetBucket is required when LoggingEnabled is specified")
+	errGrantsNotSupported  = Errorf("TargetGrants are not supported, use bucket policies to grant access to the log objects")
+)
+
+// TargetGrants - ACL grants on the delivered log objects, accepted
+// only for compatibility and required to be empty.
+type TargetGrants struct {
+	Grants []struct{} `xml:"Grant"`
+}
+
+// LoggingEnabled - describes where access logs of a bucket are stored.
+type LoggingEnabled struct {
+	TargetBucket string        `xml:"TargetBucket"`
+	TargetPrefix string        `xml:"TargetPrefix"`
+	TargetGrants *TargetGrants `xml:"TargetGrants,omitempty"`
+}
+
+// Config - server access logging configuration of a bucket.
+type Config struct {
+	XMLNS          string          `xml:"xmlns,attr,omitempty"`
+	XMLName        xml.Name        `xml:"BucketLoggingStatus"`
+	LoggingEnabled *LoggingEnabled `xml:"LoggingEnabled,omitempty"`
+}
+
+// Enabled returns true if access logging is enabled by the configuration.
+func (c *Config) Enabled() bool {
+	return c != nil && c.LoggingEnabled != nil
+}
+
+// Validate - validates the logging configuration.
+func (c *Config) Validate() error {
+	if !c.Enabled() {
+		return nil
+	}
+	if strings.TrimSpace(c.LoggingEnabled.TargetBucket) == "" {
+		return errMissingTargetBucket
+	}
+	if c.LoggingEnabled.TargetGrants != nil && len(c.LoggingEnabled.TargetGrants.Grants) > 0 {
+		return errGrantsNotSupported
+	}
+	return nil
+}
+
+// ParseConfig - parses data in given reader to Config.
+func ParseConfig(reader io.Reader) (*Config, error) {
+	var c Config
+	if err := xml.NewDecoder(reader).Decode(&c); err != nil {
+		return nil, err
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package logging
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseConfig(t *testing.T) {
+	testCases := []struct {
+		input   string
+		enabled bool
+		wantErr bool
+	}{
+		{`<BucketLoggingStatus xmlns="http://s3.amazonaws.com/doc/2006-03-01/" />`, false, false},
+		{`<BucketLoggingStatus><LoggingEnabled><TargetBucket>logs</TargetBucket><TargetPrefix>access/</TargetPrefix></LoggingEnabled></BucketLoggingStatus>`, true, false},
+		{`<BucketLoggingStatus><LoggingEnabled><TargetPrefix>access/</TargetPrefix></LoggingEnabled></BucketLoggingStatus>`, false, true},
+		{`<BucketLoggingStatus><LoggingEnabled><TargetBucket>logs</TargetBucket><TargetGrants><Grant></Grant></TargetGrants></LoggingEnabled></BucketLoggingStatus>`, false, true},
+		{`<BucketLoggingStatus><LoggingEnabled>`, false, true},
+	}
+	for i, tc := range testCases {
+		c, err := ParseConfig(strings.NewReader(tc.input))
+		if (err != nil) != tc.wantErr {
+			t.Fatalf("Test %d: expected error %v, got %v", i+1, tc.wantErr, err)
+		}
+		if err != nil {
+			continue
+		}
+		if c.Enabled() != tc.enabled {
+			t.Fatalf("Test %d: expected enabled %v, got %v", i+1, tc.enabled, c.Enabled())
+		}
+	}
+}
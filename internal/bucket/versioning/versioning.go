@@ -18,6 +18,7 @@
 package versioning
 
 import (
+	"bytes"
 	"encoding/xml"
 	"io"
 	"strings"
@@ -39,6 +40,8 @@ const (
 var (
 	errExcludedPrefixNotSupported = Errorf("excluded prefixes extension supported only when versioning is enabled")
 	errTooManyExcludedPrefixes    = Errorf("too many excluded prefixes")
+	errInvalidExcludedPrefix      = Errorf("excluded prefix must be a non-empty prefix ending with '/'")
+	errDuplicateExcludedPrefix    = Errorf("duplicate excluded prefix")
 )
 
 // ExcludedPrefix - holds individual prefixes excluded from being versioned.
@@ -60,6 +63,13 @@ type Versioning struct {
 
 // Validate - validates the versioning configuration
 func (v Versioning) Validate() error {
+	if err := v.validateStatus(); err != nil {
+		return err
+	}
+	return v.validateExcludes()
+}
+
+func (v Versioning) validateStatus() error {
 	// Not supported yet
 	// switch v.MFADelete {
 	// case Enabled, Disabled:
@@ -83,6 +93,26 @@ func (v Versioning) Validate() error {
 	return nil
 }
 
+// validateExcludes - excluded prefixes are matched as directories, they
+// must end with '/' and may not repeat. ExcludeFolders, like excluded
+// prefixes, is only meaningful when versioning is enabled.
+func (v Versioning) validateExcludes() error {
+	if v.ExcludeFolders && v.Status != Enabled {
+		return errExcludedPrefixNotSupported
+	}
+	seen := make(map[string]struct{}, len(v.ExcludedPrefixes))
+	for _, sprefix := range v.ExcludedPrefixes {
+		if sprefix.Prefix == "" || sprefix.Prefix == "/" || !strings.HasSuffix(sprefix.Prefix, "/") {
+			return errInvalidExcludedPrefix
+		}
+		if _, ok := seen[sprefix.Prefix]; ok {
+			return errDuplicateExcludedPrefix
+		}
+		seen[sprefix.Prefix] = struct{}{}
+	}
+	return nil
+}
+
 // Enabled - returns true if versioning is enabled
 func (v Versioning) Enabled() bool {
 	return v.Status == Enabled
@@ -154,14 +184,72 @@ func (v Versioning) PrefixesExcluded() bool {
 	return len(v.ExcludedPrefixes) > 0 || v.ExcludeFolders
 }
 
+// OverlappingExcludedPrefixes returns the excluded prefixes which overlap
+// with the given prefix, i.e objects under prefix which are not versioned.
+// Patterns are compared up to their first wildcard, hence may be reported
+// as overlapping even if no object name matches both.
+func (v Versioning) OverlappingExcludedPrefixes(prefix string) (prefixes []string) {
+	if v.Status != Enabled {
+		return nil
+	}
+	for _, sprefix := range v.ExcludedPrefixes {
+		literal := sprefix.Prefix
+		if i := strings.IndexAny(literal, "*?"); i >= 0 {
+			literal = literal[:i]
+		}
+		if strings.HasPrefix(literal, prefix) || strings.HasPrefix(prefix, literal) {
+			prefixes = append(prefixes, sprefix.Prefix)
+		}
+	}
+	return prefixes
+}
+
 // ParseConfig - parses data in given reader to VersioningConfiguration.
 func ParseConfig(reader io.Reader) (*Versioning, error) {
 	var v Versioning
 	if err := xml.NewDecoder(reader).Decode(&v); err != nil {
 		return nil, err
 	}
+	v.normalize()
 	if err := v.Validate(); err != nil {
 		return nil, err
 	}
 	return &v, nil
 }
+
+// normalize - excluded prefixes are matched as directories, patterns such
+// as '*/_temporary' are normalized to '*/_temporary/'.
+func (v *Versioning) normalize() {
+	for i, sprefix := range v.ExcludedPrefixes {
+		if sprefix.Prefix != "" && !strings.HasSuffix(sprefix.Prefix, "/") {
+			v.ExcludedPrefixes[i].Prefix += "/"
+		}
+	}
+}
+
+// NormalizeConfig - returns the normalized encoding of a versioning
+// configuration received from a peer site or another node, so that all
+// sites store the same configuration.
+func NormalizeConfig(data []byte) ([]byte, error) {
+	v, err := ParseStoredConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	v.normalize()
+	return xml.Marshal(v)
+}
+
+// ParseStoredConfig - parses a versioning configuration persisted in the
+// bucket metadata. Excluded prefixes saved before they were normalized
+// and validated are accepted as is, so that existing buckets keep their
+// behavior.
+func ParseStoredConfig(reader io.Reader) (*Versioning, error) {
+	var v Versioning
+	if err := xml.NewDecoder(reader).Decode(&v); err != nil {
+		return nil, err
+	}
+	if err := v.validateStatus(); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
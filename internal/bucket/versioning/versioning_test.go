@@ -19,6 +19,7 @@ package versioning
 
 import (
 	"encoding/xml"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -112,6 +113,44 @@ func TestParseConfig(t *testing.T) {
 			excludedPrefixes: []string{"path/to/my/workload/_staging/", "path/to/my/workload/_temporary/"},
 			excludeFolders:   true,
 		},
+		{
+			input: `<VersioningConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/">
+                                  <Status>Enabled</Status>
+                                  <ExcludedPrefixes>
+                                    <Prefix>path/to/my/workload/_staging</Prefix>
+                                  </ExcludedPrefixes>
+                                </VersioningConfiguration>`,
+			err:              nil,
+			excludedPrefixes: []string{"path/to/my/workload/_staging/"},
+		},
+		{
+			input: `<VersioningConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/">
+                                  <Status>Enabled</Status>
+                                  <ExcludedPrefixes>
+                                    <Prefix></Prefix>
+                                  </ExcludedPrefixes>
+                                </VersioningConfiguration>`,
+			err: errInvalidExcludedPrefix,
+		},
+		{
+			input: `<VersioningConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/">
+                                  <Status>Enabled</Status>
+                                  <ExcludedPrefixes>
+                                    <Prefix>path/to/my/workload/_staging/</Prefix>
+                                  </ExcludedPrefixes>
+                                  <ExcludedPrefixes>
+                                    <Prefix>path/to/my/workload/_staging</Prefix>
+                                  </ExcludedPrefixes>
+                                </VersioningConfiguration>`,
+			err: errDuplicateExcludedPrefix,
+		},
+		{
+			input: `<VersioningConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/">
+                                  <Status>Suspended</Status>
+                                  <ExcludeFolders>true</ExcludeFolders>
+                                </VersioningConfiguration>`,
+			err: errExcludedPrefixNotSupported,
+		},
 	}
 
 	for i, tc := range testcases {
@@ -239,3 +278,80 @@ func TestExcludedPrefixesMatch(t *testing.T) {
 		}
 	}
 }
+
+func TestParseStoredConfig(t *testing.T) {
+	input := `<VersioningConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/">
+                    <Status>Enabled</Status>
+                    <ExcludedPrefixes>
+                      <Prefix>path/to/my/workload/_staging</Prefix>
+                    </ExcludedPrefixes>
+                    <ExcludedPrefixes>
+                      <Prefix>path/to/my/workload/_staging</Prefix>
+                    </ExcludedPrefixes>
+                  </VersioningConfiguration>`
+	if _, err := ParseConfig(strings.NewReader(input)); err != errDuplicateExcludedPrefix {
+		t.Fatalf("expected %v but got %v", errDuplicateExcludedPrefix, err)
+	}
+	v, err := ParseStoredConfig(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.ExcludedPrefixes[0].Prefix != "path/to/my/workload/_staging" || !v.PrefixSuspended("path/to/my/workload/_staging-2022/obj") {
+		t.Fatalf("expected stored config to be loaded as is, got %+v", v)
+	}
+}
+
+func TestOverlappingExcludedPrefixes(t *testing.T) {
+	v := Versioning{
+		Status: Enabled,
+		ExcludedPrefixes: []ExcludedPrefix{
+			{Prefix: "logs/tmp/"},
+			{Prefix: "staging/"},
+			{Prefix: "data/*/_temporary/"},
+		},
+	}
+	testcases := []struct {
+		prefix   string
+		expected []string
+	}{
+		{"", []string{"logs/tmp/", "staging/", "data/*/_temporary/"}},
+		{"logs/", []string{"logs/tmp/"}},
+		{"logs/tmp/2022/", []string{"logs/tmp/"}},
+		{"staging", []string{"staging/"}},
+		{"data/", []string{"data/*/_temporary/"}},
+		{"data/2022/", []string{"data/*/_temporary/"}},
+		{"images/", nil},
+	}
+	for i, tc := range testcases {
+		if got := v.OverlappingExcludedPrefixes(tc.prefix); !reflect.DeepEqual(got, tc.expected) {
+			t.Fatalf("Test %d: expected %v but got %v", i+1, tc.expected, got)
+		}
+	}
+	v.Status = Suspended
+	if got := v.OverlappingExcludedPrefixes(""); got != nil {
+		t.Fatalf("expected no overlaps when versioning is suspended, got %v", got)
+	}
+}
+
+func TestNormalizeConfig(t *testing.T) {
+	stored := `<VersioningConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Status>Enabled</Status><ExcludedPrefixes><Prefix>*/_temporary</Prefix></ExcludedPrefixes><ExcludedPrefixes><Prefix>staging/</Prefix></ExcludedPrefixes></VersioningConfiguration>`
+	normalized, err := NormalizeConfig([]byte(stored))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v, err := ParseConfig(strings.NewReader(stored))
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := xml.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(normalized) != string(parsed) {
+		t.Fatalf("expected %s, got %s", parsed, normalized)
+	}
+	if again, _ := NormalizeConfig(normalized); string(again) != string(normalized) {
+		t.Fatalf("expected normalizing to be idempotent, got %s", again)
+	}
+}
@@ -0,0 +1,204 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package website
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// maxRoutingRules is the maximum number of routing rules, same as AWS S3.
+const maxRoutingRules = 50
+
+var (
+	errMissingIndexDocument  = Errorf("IndexDocument is required unless RedirectAllRequestsTo is specified")
+	errInvalidIndexSuffix    = Errorf("IndexDocument Suffix must not be empty and must not include a slash character")
+	errRedirectAllExclusive  = Errorf("RedirectAllRequestsTo can not be specified along with other website configuration")
+	errMissingRedirectHost   = Errorf("RedirectAllRequestsTo HostName is required")
+	errInvalidProtocol       = Errorf("Protocol must be either http or https")
+	errTooManyRoutingRules   = Errorf("website configuration must not have more than 50 RoutingRules")
+	errReplaceKeyExclusive   = Errorf("ReplaceKeyWith and ReplaceKeyPrefixWith can not be specified together")
+	errInvalidRedirectCode   = Errorf("HttpRedirectCode must be a 3XX HTTP status code")
+	errInvalidErrorCondition = Errorf("HttpErrorCodeReturnedEquals must be a 4XX or 5XX HTTP status code")
+)
+
+// IndexDocument - the document returned for requests to a directory.
+type IndexDocument struct {
+	Suffix string `xml:"Suffix"`
+}
+
+// ErrorDocument - the document returned when an error occurs.
+type ErrorDocument struct {
+	Key string `xml:"Key"`
+}
+
+// RedirectAllRequestsTo - redirects all requests to another host.
+type RedirectAllRequestsTo struct {
+	HostName string `xml:"HostName"`
+	Protocol string `xml:"Protocol,omitempty"`
+}
+
+// Condition - condition of a routing rule, all specified
+// conditions must match for the rule to apply.
+type Condition struct {
+	KeyPrefixEquals             string `xml:"KeyPrefixEquals,omitempty"`
+	HTTPErrorCodeReturnedEquals int    `xml:"HttpErrorCodeReturnedEquals,omitempty"`
+}
+
+// Redirect - describes the redirect of a routing rule.
+type Redirect struct {
+	HostName             string `xml:"HostName,omitempty"`
+	HTTPRedirectCode     int    `xml:"HttpRedirectCode,omitempty"`
+	Protocol             string `xml:"Protocol,omitempty"`
+	ReplaceKeyPrefixWith string `xml:"ReplaceKeyPrefixWith,omitempty"`
+	ReplaceKeyWith       string `xml:"ReplaceKeyWith,omitempty"`
+}
+
+// RoutingRule - redirects requests matching a condition.
+type RoutingRule struct {
+	Condition *Condition `xml:"Condition,omitempty"`
+	Redirect  Redirect   `xml:"Redirect"`
+}
+
+// Config - website configuration of a bucket.
+type Config struct {
+	XMLNS                 string                 `xml:"xmlns,attr,omitempty"`
+	XMLName               xml.Name               `xml:"WebsiteConfiguration"`
+	RedirectAllRequestsTo *RedirectAllRequestsTo `xml:"RedirectAllRequestsTo,omitempty"`
+	IndexDocument         *IndexDocument         `xml:"IndexDocument,omitempty"`
+	ErrorDocument         *ErrorDocument         `xml:"ErrorDocument,omitempty"`
+	RoutingRules          []RoutingRule          `xml:"RoutingRules>RoutingRule,omitempty"`
+}
+
+func validProtocol(protocol string) bool {
+	return protocol == "" || protocol == "http" || protocol == "https"
+}
+
+// Validate - validates the routing rule.
+func (r RoutingRule) Validate() error {
+	if r.Condition != nil && r.Condition.HTTPErrorCodeReturnedEquals != 0 {
+		if code := r.Condition.HTTPErrorCodeReturnedEquals; code < 400 || code > 599 {
+			return errInvalidErrorCondition
+		}
+	}
+	if r.Redirect.ReplaceKeyPrefixWith != "" && r.Redirect.ReplaceKeyWith != "" {
+		return errReplaceKeyExclusive
+	}
+	if code := r.Redirect.HTTPRedirectCode; code != 0 && (code < 300 || code > 399) {
+		return errInvalidRedirectCode
+	}
+	if !validProtocol(r.Redirect.Protocol) {
+		return errInvalidProtocol
+	}
+	return nil
+}
+
+// Validate - validates the website configuration.
+func (c Config) Validate() error {
+	if c.RedirectAllRequestsTo != nil {
+		if c.IndexDocument != nil || c.ErrorDocument != nil || len(c.RoutingRules) > 0 {
+			return errRedirectAllExclusive
+		}
+		if c.RedirectAllRequestsTo.HostName == "" {
+			return errMissingRedirectHost
+		}
+		if !validProtocol(c.RedirectAllRequestsTo.Protocol) {
+			return errInvalidProtocol
+		}
+		return nil
+	}
+	if c.IndexDocument == nil {
+		return errMissingIndexDocument
+	}
+	if c.IndexDocument.Suffix == "" || strings.Contains(c.IndexDocument.Suffix, "/") {
+		return errInvalidIndexSuffix
+	}
+	if len(c.RoutingRules) > maxRoutingRules {
+		return errTooManyRoutingRules
+	}
+	for _, rule := range c.RoutingRules {
+		if err := rule.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// IndexKey returns the object key to be served for key, keys
+// referring to a directory are suffixed with the index document.
+func (c Config) IndexKey(key string) string {
+	if c.IndexDocument != nil && (key == "" || strings.HasSuffix(key, "/")) {
+		return key + c.IndexDocument.Suffix
+	}
+	return key
+}
+
+// MatchRoutingRule returns the first routing rule matching the key
+// and the HTTP error code, statusCode is zero before the request is
+// served in which case rules with an error code condition never match.
+func (c Config) MatchRoutingRule(key string, statusCode int) *RoutingRule {
+	for i := range c.RoutingRules {
+		rule := &c.RoutingRules[i]
+		if cond := rule.Condition; cond != nil {
+			if !strings.HasPrefix(key, cond.KeyPrefixEquals) {
+				continue
+			}
+			if cond.HTTPErrorCodeReturnedEquals != statusCode {
+				continue
+			}
+		} else if statusCode != 0 {
+			continue
+		}
+		return rule
+	}
+	return nil
+}
+
+// Target returns the key, host, protocol and HTTP status code of the
+// redirect of key, empty host and protocol refer to those of the request.
+func (r RoutingRule) Target(key string) (newKey, host, protocol string, code int) {
+	switch {
+	case r.Redirect.ReplaceKeyWith != "":
+		key = r.Redirect.ReplaceKeyWith
+	case r.Redirect.ReplaceKeyPrefixWith != "":
+		prefix := ""
+		if r.Condition != nil {
+			prefix = r.Condition.KeyPrefixEquals
+		}
+		key = r.Redirect.ReplaceKeyPrefixWith + strings.TrimPrefix(key, prefix)
+	}
+	code = r.Redirect.HTTPRedirectCode
+	if code == 0 {
+		code = http.StatusMovedPermanently
+	}
+	return key, r.Redirect.HostName, r.Redirect.Protocol, code
+}
+
+// ParseConfig - parses data in given reader to WebsiteConfiguration.
+func ParseConfig(reader io.Reader) (*Config, error) {
+	var c Config
+	if err := xml.NewDecoder(reader).Decode(&c); err != nil {
+		return nil, err
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package website
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestParseConfig(t *testing.T) {
+	testCases := []struct {
+		input     string
+		shouldErr bool
+	}{
+		{input: `<WebsiteConfiguration><IndexDocument><Suffix>index.html</Suffix></IndexDocument><ErrorDocument><Key>404.html</Key></ErrorDocument></WebsiteConfiguration>`},
+		{input: `<WebsiteConfiguration><RedirectAllRequestsTo><HostName>example.com</HostName><Protocol>https</Protocol></RedirectAllRequestsTo></WebsiteConfiguration>`},
+		// Missing index document.
+		{input: `<WebsiteConfiguration><ErrorDocument><Key>404.html</Key></ErrorDocument></WebsiteConfiguration>`, shouldErr: true},
+		// Index suffix with a slash.
+		{input: `<WebsiteConfiguration><IndexDocument><Suffix>a/index.html</Suffix></IndexDocument></WebsiteConfiguration>`, shouldErr: true},
+		// Redirect all requests along with an index document.
+		{input: `<WebsiteConfiguration><RedirectAllRequestsTo><HostName>example.com</HostName></RedirectAllRequestsTo><IndexDocument><Suffix>index.html</Suffix></IndexDocument></WebsiteConfiguration>`, shouldErr: true},
+		// Invalid redirect code.
+		{input: `<WebsiteConfiguration><IndexDocument><Suffix>index.html</Suffix></IndexDocument><RoutingRules><RoutingRule><Redirect><HttpRedirectCode>200</HttpRedirectCode></Redirect></RoutingRule></RoutingRules></WebsiteConfiguration>`, shouldErr: true},
+		// Both key replacements.
+		{input: `<WebsiteConfiguration><IndexDocument><Suffix>index.html</Suffix></IndexDocument><RoutingRules><RoutingRule><Redirect><ReplaceKeyWith>a</ReplaceKeyWith><ReplaceKeyPrefixWith>b</ReplaceKeyPrefixWith></Redirect></RoutingRule></RoutingRules></WebsiteConfiguration>`, shouldErr: true},
+	}
+
+	for i, testCase := range testCases {
+		_, err := ParseConfig(strings.NewReader(testCase.input))
+		if testCase.shouldErr != (err != nil) {
+			t.Errorf("Test %d: expected error %t, got %v", i+1, testCase.shouldErr, err)
+		}
+	}
+}
+
+func TestRoutingRules(t *testing.T) {
+	config, err := ParseConfig(strings.NewReader(`<WebsiteConfiguration>
+<IndexDocument><Suffix>index.html</Suffix></IndexDocument>
+<RoutingRules>
+<RoutingRule><Condition><KeyPrefixEquals>docs/</KeyPrefixEquals></Condition><Redirect><ReplaceKeyPrefixWith>documents/</ReplaceKeyPrefixWith></Redirect></RoutingRule>
+<RoutingRule><Condition><HttpErrorCodeReturnedEquals>404</HttpErrorCodeReturnedEquals></Condition><Redirect><HostName>fallback.example.com</HostName><HttpRedirectCode>302</HttpRedirectCode></Redirect></RoutingRule>
+</RoutingRules>
+</WebsiteConfiguration>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if key := config.IndexKey("blog/"); key != "blog/index.html" {
+		t.Fatalf("unexpected index key %s", key)
+	}
+	if key := config.IndexKey("blog/post.html"); key != "blog/post.html" {
+		t.Fatalf("unexpected index key %s", key)
+	}
+
+	rule := config.MatchRoutingRule("docs/a.html", 0)
+	if rule == nil {
+		t.Fatal("expected key prefix rule to match")
+	}
+	if key, host, _, code := rule.Target("docs/a.html"); key != "documents/a.html" || host != "" || code != http.StatusMovedPermanently {
+		t.Fatalf("unexpected redirect %s %s %d", key, host, code)
+	}
+
+	if rule = config.MatchRoutingRule("other.html", 0); rule != nil {
+		t.Fatal("error code rules must not match before the request is served")
+	}
+	rule = config.MatchRoutingRule("other.html", http.StatusNotFound)
+	if rule == nil {
+		t.Fatal("expected error code rule to match")
+	}
+	if key, host, _, code := rule.Target("other.html"); key != "other.html" || host != "fallback.example.com" || code != http.StatusFound {
+		t.Fatalf("unexpected redirect %s %s %d", key, host, code)
+	}
+}
@@ -20,11 +20,13 @@ package api
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"runtime"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/dustin/go-humanize"
 	"github.com/minio/pkg/env"
 	"github.com/qkbyte/minio/internal/config"
 )
@@ -42,8 +44,32 @@ const (
 	apiStaleUploadsCleanupInterval = "stale_uploads_cleanup_interval"
 	apiStaleUploadsExpiry          = "stale_uploads_expiry"
 	apiDeleteCleanupInterval       = "delete_cleanup_interval"
+	apiDeleteCleanupQuarantine     = "delete_cleanup_quarantine"
+	apiDeleteCleanupMaxSize        = "delete_cleanup_max_size"
 	apiDisableODirect              = "disable_odirect"
 	apiGzipObjects                 = "gzip_objects"
+	apiRangeCacheSize              = "range_cache_size"
+	apiRangeCacheMaxRange          = "range_cache_max_range"
+	apiServiceAccountsMax          = "service_accounts_max"
+	apiServiceAccountsGroupMax     = "service_accounts_group_max"
+	apiSessionMaxDuration          = "session_max_duration"
+	apiSessionPolicyMaxSize        = "session_policy_max_size"
+	apiRequestTimeSkew             = "request_time_skew"
+	apiPerAPIDeadline              = "per_api_deadline"
+	apiTLSMinVersion               = "tls_min_version"
+	apiTLSCipherSuites             = "tls_cipher_suites"
+	apiTLSCurvePreferences         = "tls_curve_preferences"
+	apiTLSOCSPStapling             = "tls_ocsp_stapling"
+	apiDriveMinFreePercent         = "drive_min_free_percent"
+	apiDriveMinFreeBytes           = "drive_min_free_bytes"
+	apiDriveMinFreeInodes          = "drive_min_free_inodes"
+	apiDriveReservePercent         = "drive_reserve_percent"
+	apiListConflictPolicy          = "list_conflict_policy"
+	apiDivergenceLog               = "divergence_log"
+	apiVersionSkew                 = "version_skew"
+	apiExpiryWorkers               = "expiry_workers"
+	apiExpiryQueueWait             = "expiry_queue_wait"
+	apiVhostDomains                = "vhost_domains"
 
 	EnvAPIRequestsMax             = "MINIO_API_REQUESTS_MAX"
 	EnvAPIRequestsDeadline        = "MINIO_API_REQUESTS_DEADLINE"
@@ -58,8 +84,32 @@ const (
 	EnvAPIStaleUploadsExpiry          = "MINIO_API_STALE_UPLOADS_EXPIRY"
 	EnvAPIDeleteCleanupInterval       = "MINIO_API_DELETE_CLEANUP_INTERVAL"
 	EnvDeleteCleanupInterval          = "MINIO_DELETE_CLEANUP_INTERVAL"
+	EnvAPIDeleteCleanupQuarantine     = "MINIO_API_DELETE_CLEANUP_QUARANTINE"
+	EnvAPIDeleteCleanupMaxSize        = "MINIO_API_DELETE_CLEANUP_MAX_SIZE"
 	EnvAPIDisableODirect              = "MINIO_API_DISABLE_ODIRECT"
 	EnvAPIGzipObjects                 = "MINIO_API_GZIP_OBJECTS"
+	EnvAPIRangeCacheSize              = "MINIO_API_RANGE_CACHE_SIZE"
+	EnvAPIRangeCacheMaxRange          = "MINIO_API_RANGE_CACHE_MAX_RANGE"
+	EnvAPIServiceAccountsMax          = "MINIO_API_SERVICE_ACCOUNTS_MAX"
+	EnvAPIServiceAccountsGroupMax     = "MINIO_API_SERVICE_ACCOUNTS_GROUP_MAX"
+	EnvAPISessionMaxDuration          = "MINIO_API_SESSION_MAX_DURATION"
+	EnvAPISessionPolicyMaxSize        = "MINIO_API_SESSION_POLICY_MAX_SIZE"
+	EnvAPIRequestTimeSkew             = "MINIO_API_REQUEST_TIME_SKEW"
+	EnvAPIPerAPIDeadline              = "MINIO_API_PER_API_DEADLINE"
+	EnvAPITLSMinVersion               = "MINIO_API_TLS_MIN_VERSION"
+	EnvAPITLSCipherSuites             = "MINIO_API_TLS_CIPHER_SUITES"
+	EnvAPITLSCurvePreferences         = "MINIO_API_TLS_CURVE_PREFERENCES"
+	EnvAPITLSOCSPStapling             = "MINIO_API_TLS_OCSP_STAPLING"
+	EnvAPIDriveMinFreePercent         = "MINIO_API_DRIVE_MIN_FREE_PERCENT"
+	EnvAPIDriveMinFreeBytes           = "MINIO_API_DRIVE_MIN_FREE_BYTES"
+	EnvAPIDriveMinFreeInodes          = "MINIO_API_DRIVE_MIN_FREE_INODES"
+	EnvAPIDriveReservePercent         = "MINIO_API_DRIVE_RESERVE_PERCENT"
+	EnvAPIListConflictPolicy          = "MINIO_API_LIST_CONFLICT_POLICY"
+	EnvAPIDivergenceLog               = "MINIO_API_DIVERGENCE_LOG"
+	EnvAPIVersionSkew                 = "MINIO_API_VERSION_SKEW"
+	EnvAPIExpiryWorkers               = "MINIO_API_EXPIRY_WORKERS"
+	EnvAPIExpiryQueueWait             = "MINIO_API_EXPIRY_QUEUE_WAIT"
+	EnvAPIVhostDomains                = "MINIO_API_VHOST_DOMAINS"
 )
 
 // Deprecated key and ENVs
@@ -121,6 +171,14 @@ var (
 			Key:   apiDeleteCleanupInterval,
 			Value: "5m",
 		},
+		config.KV{
+			Key:   apiDeleteCleanupQuarantine,
+			Value: "0s",
+		},
+		config.KV{
+			Key:   apiDeleteCleanupMaxSize,
+			Value: "0",
+		},
 		config.KV{
 			Key:   apiDisableODirect,
 			Value: "off",
@@ -129,24 +187,191 @@ var (
 			Key:   apiGzipObjects,
 			Value: "off",
 		},
+		config.KV{
+			Key:   apiRangeCacheSize,
+			Value: "0",
+		},
+		config.KV{
+			Key:   apiRangeCacheMaxRange,
+			Value: "64KiB",
+		},
+		config.KV{
+			Key:   apiServiceAccountsMax,
+			Value: "0",
+		},
+		config.KV{
+			Key:   apiServiceAccountsGroupMax,
+			Value: "",
+		},
+		config.KV{
+			Key:   apiSessionMaxDuration,
+			Value: "0s",
+		},
+		config.KV{
+			Key:   apiSessionPolicyMaxSize,
+			Value: "0",
+		},
+		config.KV{
+			Key:   apiRequestTimeSkew,
+			Value: "15m",
+		},
+		config.KV{
+			Key:   apiPerAPIDeadline,
+			Value: "",
+		},
+		config.KV{
+			Key:   apiTLSMinVersion,
+			Value: "1.2",
+		},
+		config.KV{
+			Key:   apiTLSCipherSuites,
+			Value: "",
+		},
+		config.KV{
+			Key:   apiTLSCurvePreferences,
+			Value: "",
+		},
+		config.KV{
+			Key:   apiTLSOCSPStapling,
+			Value: "off",
+		},
+		config.KV{
+			Key:   apiDriveMinFreePercent,
+			Value: "1",
+		},
+		config.KV{
+			Key:   apiDriveMinFreeBytes,
+			Value: "0",
+		},
+		config.KV{
+			Key:   apiDriveMinFreeInodes,
+			Value: "1000",
+		},
+		config.KV{
+			Key:   apiDriveReservePercent,
+			Value: "15",
+		},
+		config.KV{
+			Key:   apiListConflictPolicy,
+			Value: "merge",
+		},
+		config.KV{
+			Key:   apiDivergenceLog,
+			Value: "off",
+		},
+		config.KV{
+			Key:   apiVersionSkew,
+			Value: "warn",
+		},
+		config.KV{
+			Key:   apiExpiryWorkers,
+			Value: "4",
+		},
+		config.KV{
+			Key:   apiExpiryQueueWait,
+			Value: "0s",
+		},
+		config.KV{
+			Key:   apiVhostDomains,
+			Value: "",
+		},
 	}
 )
 
 // Config storage class configuration
 type Config struct {
-	RequestsMax                 int           `json:"requests_max"`
-	RequestsDeadline            time.Duration `json:"requests_deadline"`
-	ClusterDeadline             time.Duration `json:"cluster_deadline"`
-	CorsAllowOrigin             []string      `json:"cors_allow_origin"`
-	RemoteTransportDeadline     time.Duration `json:"remote_transport_deadline"`
-	ListQuorum                  string        `json:"list_quorum"`
-	ReplicationPriority         string        `json:"replication_priority"`
-	TransitionWorkers           int           `json:"transition_workers"`
-	StaleUploadsCleanupInterval time.Duration `json:"stale_uploads_cleanup_interval"`
-	StaleUploadsExpiry          time.Duration `json:"stale_uploads_expiry"`
-	DeleteCleanupInterval       time.Duration `json:"delete_cleanup_interval"`
-	DisableODirect              bool          `json:"disable_odirect"`
-	GzipObjects                 bool          `json:"gzip_objects"`
+	RequestsMax                 int                      `json:"requests_max"`
+	RequestsDeadline            time.Duration            `json:"requests_deadline"`
+	ClusterDeadline             time.Duration            `json:"cluster_deadline"`
+	CorsAllowOrigin             []string                 `json:"cors_allow_origin"`
+	RemoteTransportDeadline     time.Duration            `json:"remote_transport_deadline"`
+	ListQuorum                  string                   `json:"list_quorum"`
+	ReplicationPriority         string                   `json:"replication_priority"`
+	TransitionWorkers           int                      `json:"transition_workers"`
+	StaleUploadsCleanupInterval time.Duration            `json:"stale_uploads_cleanup_interval"`
+	StaleUploadsExpiry          time.Duration            `json:"stale_uploads_expiry"`
+	DeleteCleanupInterval       time.Duration            `json:"delete_cleanup_interval"`
+	DeleteCleanupQuarantine     time.Duration            `json:"delete_cleanup_quarantine"`
+	DeleteCleanupMaxSize        uint64                   `json:"delete_cleanup_max_size"`
+	DisableODirect              bool                     `json:"disable_odirect"`
+	GzipObjects                 bool                     `json:"gzip_objects"`
+	RangeCacheSize              uint64                   `json:"range_cache_size"`
+	RangeCacheMaxRange          uint64                   `json:"range_cache_max_range"`
+	ServiceAccountsMax          int                      `json:"service_accounts_max"`
+	ServiceAccountsGroupMax     map[string]int           `json:"service_accounts_group_max"`
+	SessionMaxDuration          time.Duration            `json:"session_max_duration"`
+	SessionPolicyMaxSize        uint64                   `json:"session_policy_max_size"`
+	RequestTimeSkew             time.Duration            `json:"request_time_skew"`
+	PerAPIDeadline              map[string]time.Duration `json:"per_api_deadline"`
+	TLSPolicy                   TLSPolicy                `json:"tls_policy"`
+	DriveThresholds             DriveThresholds          `json:"drive_thresholds"`
+	ListConflictPolicy          string                   `json:"list_conflict_policy"`
+	DivergenceLog               bool                     `json:"divergence_log"`
+	VersionSkew                 string                   `json:"version_skew"`
+	ExpiryWorkers               int                      `json:"expiry_workers"`
+	ExpiryQueueWait             time.Duration            `json:"expiry_queue_wait"`
+	VhostDomains                []VhostDomain            `json:"vhost_domains"`
+}
+
+// DriveThresholds are the limits below which a drive no longer
+// accepts new writes, or is skipped when other pools have room.
+type DriveThresholds struct {
+	MinFreePercent float64 `json:"min_free_percent"`
+	MinFreeBytes   uint64  `json:"min_free_bytes"`
+	MinFreeInodes  uint64  `json:"min_free_inodes"`
+	ReservePercent float64 `json:"reserve_percent"`
+}
+
+// parsePercent parses a percentage between 0 and 100.
+func parsePercent(key, v string) (float64, error) {
+	pct, err := strconv.ParseFloat(v, 64)
+	if err != nil || pct < 0 || pct > 100 {
+		return 0, fmt.Errorf("invalid value '%s' for %s, expected a percentage between 0 and 100", v, key)
+	}
+	return pct, nil
+}
+
+// parseGroupLimits parses comma separated group=limit pairs.
+func parseGroupLimits(v string) (map[string]int, error) {
+	limits := make(map[string]int)
+	for _, kv := range strings.Split(v, ",") {
+		kv = strings.TrimSpace(kv)
+		if kv == "" {
+			continue
+		}
+		i := strings.LastIndex(kv, "=")
+		if i <= 0 {
+			return nil, fmt.Errorf("invalid group limit '%s', expected group=limit", kv)
+		}
+		limit, err := strconv.Atoi(kv[i+1:])
+		if err != nil || limit < 0 {
+			return nil, fmt.Errorf("invalid group limit '%s', expected group=limit", kv)
+		}
+		limits[kv[:i]] = limit
+	}
+	return limits, nil
+}
+
+// parsePerAPIDeadline parses comma separated api=duration pairs, API
+// names are case insensitive and "*" applies to all other APIs.
+func parsePerAPIDeadline(v string) (map[string]time.Duration, error) {
+	deadlines := make(map[string]time.Duration)
+	for _, kv := range strings.Split(v, ",") {
+		kv = strings.TrimSpace(kv)
+		if kv == "" {
+			continue
+		}
+		i := strings.LastIndex(kv, "=")
+		if i <= 0 {
+			return nil, fmt.Errorf("invalid API deadline '%s', expected api=duration", kv)
+		}
+		d, err := time.ParseDuration(kv[i+1:])
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid API deadline '%s', expected api=duration", kv)
+		}
+		deadlines[strings.ToLower(kv[:i])] = d
+	}
+	return deadlines, nil
 }
 
 // UnmarshalJSON - Validate SS and RRS parity when unmarshalling JSON.
@@ -231,6 +456,19 @@ func LookupConfig(kvs config.KVS) (cfg Config, err error) {
 		return cfg, err
 	}
 
+	deleteCleanupQuarantine, err := time.ParseDuration(env.Get(EnvAPIDeleteCleanupQuarantine, kvs.GetWithDefault(apiDeleteCleanupQuarantine, DefaultKVS)))
+	if err != nil {
+		return cfg, err
+	}
+	if deleteCleanupQuarantine < 0 {
+		return cfg, errors.New("invalid value for delete cleanup quarantine")
+	}
+
+	deleteCleanupMaxSize, err := humanize.ParseBytes(env.Get(EnvAPIDeleteCleanupMaxSize, kvs.GetWithDefault(apiDeleteCleanupMaxSize, DefaultKVS)))
+	if err != nil {
+		return cfg, err
+	}
+
 	staleUploadsCleanupInterval, err := time.ParseDuration(env.Get(EnvAPIStaleUploadsCleanupInterval, kvs.GetWithDefault(apiStaleUploadsCleanupInterval, DefaultKVS)))
 	if err != nil {
 		return cfg, err
@@ -245,6 +483,132 @@ func LookupConfig(kvs config.KVS) (cfg Config, err error) {
 
 	gzipObjects := env.Get(EnvAPIGzipObjects, kvs.Get(apiGzipObjects)) == config.EnableOn
 
+	rangeCacheSize, err := humanize.ParseBytes(env.Get(EnvAPIRangeCacheSize, kvs.GetWithDefault(apiRangeCacheSize, DefaultKVS)))
+	if err != nil {
+		return cfg, err
+	}
+
+	rangeCacheMaxRange, err := humanize.ParseBytes(env.Get(EnvAPIRangeCacheMaxRange, kvs.GetWithDefault(apiRangeCacheMaxRange, DefaultKVS)))
+	if err != nil {
+		return cfg, err
+	}
+	if rangeCacheSize > 0 && rangeCacheMaxRange > rangeCacheSize {
+		return cfg, errors.New("invalid value for range cache max range, cannot be larger than range cache size")
+	}
+
+	serviceAccountsMax, err := strconv.Atoi(env.Get(EnvAPIServiceAccountsMax, kvs.GetWithDefault(apiServiceAccountsMax, DefaultKVS)))
+	if err != nil {
+		return cfg, err
+	}
+	if serviceAccountsMax < 0 {
+		return cfg, errors.New("invalid value for service accounts max")
+	}
+
+	serviceAccountsGroupMax, err := parseGroupLimits(env.Get(EnvAPIServiceAccountsGroupMax, kvs.Get(apiServiceAccountsGroupMax)))
+	if err != nil {
+		return cfg, err
+	}
+
+	sessionMaxDuration, err := time.ParseDuration(env.Get(EnvAPISessionMaxDuration, kvs.GetWithDefault(apiSessionMaxDuration, DefaultKVS)))
+	if err != nil {
+		return cfg, err
+	}
+	if sessionMaxDuration < 0 {
+		return cfg, errors.New("invalid value for session max duration")
+	}
+
+	sessionPolicyMaxSize, err := humanize.ParseBytes(env.Get(EnvAPISessionPolicyMaxSize, kvs.GetWithDefault(apiSessionPolicyMaxSize, DefaultKVS)))
+	if err != nil {
+		return cfg, err
+	}
+
+	requestTimeSkew, err := time.ParseDuration(env.Get(EnvAPIRequestTimeSkew, kvs.GetWithDefault(apiRequestTimeSkew, DefaultKVS)))
+	if err != nil {
+		return cfg, err
+	}
+	if requestTimeSkew < time.Second || requestTimeSkew > 7*24*time.Hour {
+		return cfg, errors.New("invalid value for request time skew, must be between 1s and 168h")
+	}
+
+	perAPIDeadline, err := parsePerAPIDeadline(env.Get(EnvAPIPerAPIDeadline, kvs.Get(apiPerAPIDeadline)))
+	if err != nil {
+		return cfg, err
+	}
+
+	tlsMinVersion, err := parseTLSMinVersion(env.Get(EnvAPITLSMinVersion, kvs.GetWithDefault(apiTLSMinVersion, DefaultKVS)))
+	if err != nil {
+		return cfg, err
+	}
+
+	tlsCipherSuites, err := parseTLSCipherSuites(env.Get(EnvAPITLSCipherSuites, kvs.Get(apiTLSCipherSuites)))
+	if err != nil {
+		return cfg, err
+	}
+
+	tlsCurvePreferences, err := parseTLSCurvePreferences(env.Get(EnvAPITLSCurvePreferences, kvs.Get(apiTLSCurvePreferences)))
+	if err != nil {
+		return cfg, err
+	}
+
+	tlsOCSPStapling := env.Get(EnvAPITLSOCSPStapling, kvs.Get(apiTLSOCSPStapling)) == config.EnableOn
+
+	driveMinFreePercent, err := parsePercent(apiDriveMinFreePercent, env.Get(EnvAPIDriveMinFreePercent, kvs.GetWithDefault(apiDriveMinFreePercent, DefaultKVS)))
+	if err != nil {
+		return cfg, err
+	}
+
+	driveMinFreeBytes, err := humanize.ParseBytes(env.Get(EnvAPIDriveMinFreeBytes, kvs.GetWithDefault(apiDriveMinFreeBytes, DefaultKVS)))
+	if err != nil {
+		return cfg, err
+	}
+
+	driveMinFreeInodes, err := strconv.ParseUint(env.Get(EnvAPIDriveMinFreeInodes, kvs.GetWithDefault(apiDriveMinFreeInodes, DefaultKVS)), 10, 64)
+	if err != nil {
+		return cfg, err
+	}
+
+	driveReservePercent, err := parsePercent(apiDriveReservePercent, env.Get(EnvAPIDriveReservePercent, kvs.GetWithDefault(apiDriveReservePercent, DefaultKVS)))
+	if err != nil {
+		return cfg, err
+	}
+
+	listConflictPolicy := env.Get(EnvAPIListConflictPolicy, kvs.GetWithDefault(apiListConflictPolicy, DefaultKVS))
+	switch listConflictPolicy {
+	case "merge", "skip":
+	default:
+		return cfg, errors.New("invalid value for list conflict policy")
+	}
+
+	divergenceLog := env.Get(EnvAPIDivergenceLog, kvs.Get(apiDivergenceLog)) == config.EnableOn
+
+	versionSkew := env.Get(EnvAPIVersionSkew, kvs.GetWithDefault(apiVersionSkew, DefaultKVS))
+	switch versionSkew {
+	case "warn", "reject":
+	default:
+		return cfg, errors.New("invalid value for version skew")
+	}
+
+	expiryWorkers, err := strconv.Atoi(env.Get(EnvAPIExpiryWorkers, kvs.GetWithDefault(apiExpiryWorkers, DefaultKVS)))
+	if err != nil {
+		return cfg, err
+	}
+	if expiryWorkers <= 0 {
+		return cfg, errors.New("invalid value for expiry workers, should be > 0")
+	}
+
+	expiryQueueWait, err := time.ParseDuration(env.Get(EnvAPIExpiryQueueWait, kvs.GetWithDefault(apiExpiryQueueWait, DefaultKVS)))
+	if err != nil {
+		return cfg, err
+	}
+	if expiryQueueWait < 0 {
+		return cfg, errors.New("invalid value for expiry queue wait, cannot be negative")
+	}
+
+	vhostDomains, err := ParseVhostDomains(env.Get(EnvAPIVhostDomains, kvs.Get(apiVhostDomains)))
+	if err != nil {
+		return cfg, err
+	}
+
 	return Config{
 		RequestsMax:                 requestsMax,
 		RequestsDeadline:            requestsDeadline,
@@ -257,7 +621,35 @@ func LookupConfig(kvs config.KVS) (cfg Config, err error) {
 		StaleUploadsCleanupInterval: staleUploadsCleanupInterval,
 		StaleUploadsExpiry:          staleUploadsExpiry,
 		DeleteCleanupInterval:       deleteCleanupInterval,
+		DeleteCleanupQuarantine:     deleteCleanupQuarantine,
+		DeleteCleanupMaxSize:        deleteCleanupMaxSize,
 		DisableODirect:              disableODirect,
 		GzipObjects:                 gzipObjects,
+		RangeCacheSize:              rangeCacheSize,
+		RangeCacheMaxRange:          rangeCacheMaxRange,
+		ServiceAccountsMax:          serviceAccountsMax,
+		ServiceAccountsGroupMax:     serviceAccountsGroupMax,
+		SessionMaxDuration:          sessionMaxDuration,
+		SessionPolicyMaxSize:        sessionPolicyMaxSize,
+		RequestTimeSkew:             requestTimeSkew,
+		PerAPIDeadline:              perAPIDeadline,
+		TLSPolicy: TLSPolicy{
+			MinVersion:       tlsMinVersion,
+			CipherSuites:     tlsCipherSuites,
+			CurvePreferences: tlsCurvePreferences,
+			OCSPStapling:     tlsOCSPStapling,
+		},
+		DriveThresholds: DriveThresholds{
+			MinFreePercent: driveMinFreePercent,
+			MinFreeBytes:   driveMinFreeBytes,
+			MinFreeInodes:  driveMinFreeInodes,
+			ReservePercent: driveReservePercent,
+		},
+		ListConflictPolicy: listConflictPolicy,
+		DivergenceLog:      divergenceLog,
+		VersionSkew:        versionSkew,
+		ExpiryWorkers:      expiryWorkers,
+		ExpiryQueueWait:    expiryQueueWait,
+		VhostDomains:       vhostDomains,
 	}, nil
 }
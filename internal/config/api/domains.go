@@ -0,0 +1,102 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"fmt"
+	"strings"
+)
+
+// VhostDomain routes virtual-host-style requests sent to a domain
+// pattern to buckets, Prefix is prepended to the bucket name taken
+// from the host, this maps the domains of a tenant to its buckets.
+type VhostDomain struct {
+	Pattern string `json:"pattern"`
+	Prefix  string `json:"prefix"`
+}
+
+// Labels returns the labels of the domain, without the bucket part.
+func (d VhostDomain) Labels() []string {
+	return strings.Split(strings.TrimPrefix(d.Pattern, "*."), ".")
+}
+
+// ParseVhostDomains parses a comma separated list of domain patterns,
+// each optionally followed by "=<bucket prefix>", for example
+// "example.com,*.tenant1.example.com=tenant1-". A "*" label matches any
+// single label, the leading "*." standing for the bucket is optional.
+func ParseVhostDomains(v string) (domains []VhostDomain, err error) {
+	if v == "" {
+		return nil, nil
+	}
+	seen := make(map[string]struct{})
+	for _, entry := range strings.Split(v, ",") {
+		pattern, prefix, _ := strings.Cut(strings.TrimSpace(entry), "=")
+		d := VhostDomain{
+			Pattern: strings.ToLower(strings.TrimSpace(pattern)),
+			Prefix:  strings.TrimSpace(prefix),
+		}
+		if err = validateVhostDomain(d); err != nil {
+			return nil, err
+		}
+		key := strings.Join(d.Labels(), ".")
+		if _, ok := seen[key]; ok {
+			return nil, fmt.Errorf("duplicate virtual host domain '%s'", d.Pattern)
+		}
+		seen[key] = struct{}{}
+		domains = append(domains, d)
+	}
+	return domains, nil
+}
+
+func validateVhostDomain(d VhostDomain) error {
+	labels := d.Labels()
+	if len(labels) < 2 {
+		return fmt.Errorf("invalid virtual host domain '%s', expected at least two labels", d.Pattern)
+	}
+	wildcards := 0
+	for _, label := range labels {
+		if label == "*" {
+			wildcards++
+			continue
+		}
+		if label == "" || len(label) > 63 || strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+			return fmt.Errorf("invalid virtual host domain '%s'", d.Pattern)
+		}
+		for _, c := range label {
+			if !isBucketNameChar(c) || c == '.' {
+				return fmt.Errorf("invalid virtual host domain '%s'", d.Pattern)
+			}
+		}
+	}
+	if wildcards == len(labels) {
+		return fmt.Errorf("invalid virtual host domain '%s', cannot match every domain", d.Pattern)
+	}
+	if len(d.Prefix) > 62 {
+		return fmt.Errorf("bucket prefix '%s' is too long", d.Prefix)
+	}
+	for _, c := range d.Prefix {
+		if !isBucketNameChar(c) {
+			return fmt.Errorf("invalid bucket prefix '%s'", d.Prefix)
+		}
+	}
+	return nil
+}
+
+func isBucketNameChar(c rune) bool {
+	return c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '-' || c == '.'
+}
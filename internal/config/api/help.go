@@ -92,11 +92,155 @@ var (
 			Optional:    true,
 			Type:        "duration",
 		},
+		config.HelpKV{
+			Key:         apiDeleteCleanupQuarantine,
+			Description: `set to keep deleted objects in the ".trash" folder for at least this long before they are permanently deleted` + defaultHelpPostfix(apiDeleteCleanupQuarantine),
+			Optional:    true,
+			Type:        "duration",
+		},
+		config.HelpKV{
+			Key:         apiDeleteCleanupMaxSize,
+			Description: `set the maximum size of the ".trash" folder per drive, the oldest quarantined entries are deleted first when exceeded, "0" disables the limit e.g. "100GiB"` + defaultHelpPostfix(apiDeleteCleanupMaxSize),
+			Optional:    true,
+			Type:        "string",
+		},
 		config.HelpKV{
 			Key:         apiDisableODirect,
 			Description: "set to disable O_DIRECT for reads under special conditions. NOTE: it is not recommended to disable O_DIRECT without prior testing." + defaultHelpPostfix(apiDisableODirect),
 			Optional:    true,
 			Type:        "boolean",
 		},
+		config.HelpKV{
+			Key:         apiRangeCacheSize,
+			Description: `set the maximum memory used to cache hot small range reads, "0" disables the cache e.g. "512MiB"` + defaultHelpPostfix(apiRangeCacheSize),
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         apiRangeCacheMaxRange,
+			Description: `set the largest range request eligible for the range cache` + defaultHelpPostfix(apiRangeCacheMaxRange),
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         apiServiceAccountsMax,
+			Description: `set the maximum number of service accounts a user may own, "0" means unlimited` + defaultHelpPostfix(apiServiceAccountsMax),
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         apiServiceAccountsGroupMax,
+			Description: `set the maximum number of service accounts members of a group may own, the strictest limit applies e.g. "devs=5,ci=20"` + defaultHelpPostfix(apiServiceAccountsGroupMax),
+			Optional:    true,
+			Type:        "csv",
+		},
+		config.HelpKV{
+			Key:         apiSessionMaxDuration,
+			Description: `set the maximum duration of temporary credentials, "0s" means no additional limit e.g. "12h"` + defaultHelpPostfix(apiSessionMaxDuration),
+			Optional:    true,
+			Type:        "duration",
+		},
+		config.HelpKV{
+			Key:         apiSessionPolicyMaxSize,
+			Description: `set the maximum size of session policies of service accounts and temporary credentials, "0" uses the built-in limits` + defaultHelpPostfix(apiSessionPolicyMaxSize),
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         apiRequestTimeSkew,
+			Description: `set the maximum allowed difference between the request timestamp and the server clock e.g. "5m"` + defaultHelpPostfix(apiRequestTimeSkew),
+			Optional:    true,
+			Type:        "duration",
+		},
+		config.HelpKV{
+			Key:         apiPerAPIDeadline,
+			Description: `set deadlines for individual S3 APIs as comma separated api=duration pairs, "*" applies to all others e.g. "getobject=5m,*=1m"`,
+			Optional:    true,
+			Type:        "csv",
+		},
+		config.HelpKV{
+			Key:         apiTLSMinVersion,
+			Description: `set the minimum TLS version accepted by the server, one of "1.2" or "1.3"` + defaultHelpPostfix(apiTLSMinVersion),
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         apiTLSCipherSuites,
+			Description: `set comma separated TLS 1.2 cipher suites accepted by the server e.g. "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", empty uses the built-in list`,
+			Optional:    true,
+			Type:        "csv",
+		},
+		config.HelpKV{
+			Key:         apiTLSCurvePreferences,
+			Description: `set comma separated elliptic curves in preference order e.g. "X25519,P256", empty uses the built-in list`,
+			Optional:    true,
+			Type:        "csv",
+		},
+		config.HelpKV{
+			Key:         apiTLSOCSPStapling,
+			Description: `set to "on" to staple OCSP responses to the server certificate` + defaultHelpPostfix(apiTLSOCSPStapling),
+			Optional:    true,
+			Type:        "boolean",
+		},
+		config.HelpKV{
+			Key:         apiDriveMinFreePercent,
+			Description: `set the percentage of drive capacity that must stay free, writes are rejected beyond it` + defaultHelpPostfix(apiDriveMinFreePercent),
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         apiDriveMinFreeBytes,
+			Description: `set the free space every drive must keep after a write e.g. "10GiB"` + defaultHelpPostfix(apiDriveMinFreeBytes),
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         apiDriveMinFreeInodes,
+			Description: `set the number of free inodes every drive must keep to accept writes` + defaultHelpPostfix(apiDriveMinFreeInodes),
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         apiDriveReservePercent,
+			Description: `set the percentage of drive capacity reserved before new objects prefer other pools` + defaultHelpPostfix(apiDriveReservePercent),
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         apiListConflictPolicy,
+			Description: `set how listings resolve objects the drives disagree on, "merge" versions agreed by quorum or "skip" until healed` + defaultHelpPostfix(apiListConflictPolicy),
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         apiDivergenceLog,
+			Description: `set to "on" to log the objects drives disagree on during listing, heal and scanning` + defaultHelpPostfix(apiDivergenceLog),
+			Optional:    true,
+			Type:        "boolean",
+		},
+		config.HelpKV{
+			Key:         apiVersionSkew,
+			Description: `set how peers running a different release are handled, "warn" to report them or "reject" to take them offline` + defaultHelpPostfix(apiVersionSkew),
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         apiExpiryWorkers,
+			Description: `set the number of ILM expiry workers` + defaultHelpPostfix(apiExpiryWorkers),
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         apiExpiryQueueWait,
+			Description: `set how long to wait for room in a full ILM expiry queue before leaving the expiry to the next scanner cycle` + defaultHelpPostfix(apiExpiryQueueWait),
+			Optional:    true,
+			Type:        "duration",
+		},
+		config.HelpKV{
+			Key:         apiVhostDomains,
+			Description: `set comma separated domains for virtual-host-style requests in addition to MINIO_DOMAIN, "*" matches a label and "=prefix" maps buckets to "prefix<bucket>" e.g. "*.tenant1.example.com=tenant1-"` + defaultHelpPostfix(apiVhostDomains),
+			Optional:    true,
+			Type:        "csv",
+		},
 	}
 )
@@ -0,0 +1,142 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"crypto/tls"
+	"fmt"
+	"strings"
+
+	"github.com/qkbyte/minio/internal/fips"
+)
+
+// TLSPolicy - TLS handshake policy enforced by the server listeners.
+// Zero values mean the built-in defaults are used.
+type TLSPolicy struct {
+	MinVersion       uint16        `json:"tls_min_version"`
+	CipherSuites     []uint16      `json:"tls_cipher_suites"`
+	CurvePreferences []tls.CurveID `json:"tls_curve_preferences"`
+	OCSPStapling     bool          `json:"tls_ocsp_stapling"`
+}
+
+// Equal returns true if both policies are identical.
+func (p TLSPolicy) Equal(o TLSPolicy) bool {
+	if p.MinVersion != o.MinVersion || p.OCSPStapling != o.OCSPStapling {
+		return false
+	}
+	if len(p.CipherSuites) != len(o.CipherSuites) || len(p.CurvePreferences) != len(o.CurvePreferences) {
+		return false
+	}
+	for i := range p.CipherSuites {
+		if p.CipherSuites[i] != o.CipherSuites[i] {
+			return false
+		}
+	}
+	for i := range p.CurvePreferences {
+		if p.CurvePreferences[i] != o.CurvePreferences[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns a human readable representation of the policy.
+func (p TLSPolicy) String() string {
+	ciphers := make([]string, 0, len(p.CipherSuites))
+	for _, c := range p.CipherSuites {
+		ciphers = append(ciphers, tls.CipherSuiteName(c))
+	}
+	curves := make([]string, 0, len(p.CurvePreferences))
+	for _, c := range p.CurvePreferences {
+		curves = append(curves, c.String())
+	}
+	return fmt.Sprintf("min_version=%s cipher_suites=[%s] curve_preferences=[%s] ocsp_stapling=%t",
+		tlsVersionName(p.MinVersion), strings.Join(ciphers, ","), strings.Join(curves, ","), p.OCSPStapling)
+}
+
+func tlsVersionName(v uint16) string {
+	switch v {
+	case tls.VersionTLS12:
+		return "1.2"
+	case tls.VersionTLS13:
+		return "1.3"
+	}
+	return "default"
+}
+
+func parseTLSMinVersion(v string) (uint16, error) {
+	switch strings.TrimSpace(v) {
+	case "", "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	}
+	return 0, fmt.Errorf("invalid TLS minimum version '%s', expected '1.2' or '1.3'", v)
+}
+
+// parseTLSCipherSuites parses a comma separated list of TLS 1.2 cipher
+// suite names. Only the ciphers supported by the server (honoring FIPS
+// mode) are accepted, TLS 1.3 cipher suites are not configurable.
+func parseTLSCipherSuites(v string) ([]uint16, error) {
+	supported := make(map[string]uint16)
+	for _, id := range fips.TLSCiphersBackwardCompatible() {
+		supported[tls.CipherSuiteName(id)] = id
+	}
+	var ciphers []uint16
+	for _, name := range strings.Split(v, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		id, ok := supported[name]
+		if !ok {
+			return nil, fmt.Errorf("unsupported TLS cipher suite '%s'", name)
+		}
+		switch id {
+		case tls.TLS_AES_128_GCM_SHA256, tls.TLS_AES_256_GCM_SHA384, tls.TLS_CHACHA20_POLY1305_SHA256:
+			return nil, fmt.Errorf("TLS 1.3 cipher suite '%s' is not configurable", name)
+		}
+		ciphers = append(ciphers, id)
+	}
+	return ciphers, nil
+}
+
+// parseTLSCurvePreferences parses a comma separated list of elliptic
+// curve names, only curves supported by the server are accepted.
+func parseTLSCurvePreferences(v string) ([]tls.CurveID, error) {
+	supported := make(map[string]tls.CurveID)
+	for _, id := range fips.TLSCurveIDs() {
+		supported[strings.ToUpper(id.String())] = id
+	}
+	var curves []tls.CurveID
+	for _, name := range strings.Split(v, ",") {
+		name = strings.ToUpper(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if !strings.HasPrefix(name, "CURVE") && name != "X25519" {
+			name = "CURVE" + name
+		}
+		id, ok := supported[name]
+		if !ok {
+			return nil, fmt.Errorf("unsupported TLS curve '%s'", name)
+		}
+		curves = append(curves, id)
+	}
+	return curves, nil
+}
@@ -414,7 +414,7 @@ func (c Config) RedactSensitiveInfo() Config {
 				for name, kvs := range configVals {
 					for i := range kvs {
 						if kvs[i].Key == helpKV.Key && len(kvs[i].Value) > 0 {
-							kvs[i].Value = "*redacted*"
+							kvs[i].Value = RedactedValue
 						}
 					}
 					configVals[name] = kvs
@@ -51,9 +51,19 @@ const (
 	EnvVolumes    = "MINIO_VOLUMES"
 	EnvDNSWebhook = "MINIO_DNS_WEBHOOK_ENDPOINT"
 
+	// Bucket DNS records kept in Consul KV or exported as a zone file.
+	EnvDNSConsulEndpoint = "MINIO_DNS_CONSUL_ENDPOINT"
+	EnvDNSConsulToken    = "MINIO_DNS_CONSUL_TOKEN"
+	EnvDNSConsulPrefix   = "MINIO_DNS_CONSUL_PREFIX"
+	EnvDNSZoneFile       = "MINIO_DNS_ZONE_FILE"
+
 	EnvSiteName   = "MINIO_SITE_NAME"
 	EnvSiteRegion = "MINIO_SITE_REGION"
 
+	// EnvTopology labels servers with their failure domains, for example
+	// "host1=zone:z1,rack:r1;host2=zone:z1,rack:r2"
+	EnvTopology = "MINIO_TOPOLOGY"
+
 	EnvMinIOSubnetLicense = "MINIO_SUBNET_LICENSE" // Deprecated Dec 2021
 	EnvMinIOSubnetAPIKey  = "MINIO_SUBNET_API_KEY"
 	EnvMinIOSubnetProxy   = "MINIO_SUBNET_PROXY"
@@ -65,6 +75,58 @@ const (
 	EnvMinIOBrowserRedirectURL = "MINIO_BROWSER_REDIRECT_URL"
 	EnvRootDiskThresholdSize   = "MINIO_ROOTDISK_THRESHOLD_SIZE"
 
+	// EnvReadDirSlowDuration and EnvReadDirLargeEntries set the enumeration
+	// time and entry count above which a directory is reported as slow.
+	EnvReadDirSlowDuration = "MINIO_READDIR_SLOW_DURATION"
+	EnvReadDirLargeEntries = "MINIO_READDIR_LARGE_ENTRIES"
+
+	// EnvShutdownGracePeriod is the time long-running listings and heals
+	// are given to checkpoint their progress on shutdown.
+	EnvShutdownGracePeriod = "MINIO_SHUTDOWN_GRACE_PERIOD"
+
+	// EnvScannerTagIndexInterval is the interval at which objects changed
+	// by requests or visited by the scanner are applied to the object tag
+	// index of buckets, tag search is disabled when unset.
+	EnvScannerTagIndexInterval = "MINIO_SCANNER_TAG_INDEX_INTERVAL"
+
+	// EnvHealBucketMetadataInterval is the interval at which the config
+	// objects of all buckets are verified and repaired, zero disables it.
+	EnvHealBucketMetadataInterval = "MINIO_HEAL_BUCKET_METADATA_INTERVAL"
+
+	// EnvIAMDenyByDefault enables the deny-by-default hardening mode,
+	// where even the root credential needs an explicit allow.
+	EnvIAMDenyByDefault = "MINIO_IAM_DENY_BY_DEFAULT"
+	// EnvIAMBreakGlassAccessKey is the only credential allowed to bypass
+	// policy evaluation when deny-by-default is enabled.
+	EnvIAMBreakGlassAccessKey = "MINIO_IAM_BREAK_GLASS_ACCESS_KEY"
+
+	// EnvConfigJournalAudit adds the keys changed by config updates to
+	// the audit log entry of the admin API call.
+	EnvConfigJournalAudit = "MINIO_CONFIG_JOURNAL_AUDIT"
+
+	// EnvErasureSIMD caps the Reed-Solomon acceleration path, "auto"
+	// uses the fastest one supported by the CPU.
+	EnvErasureSIMD = "MINIO_ERASURE_SIMD"
+
+	// EnvTransferStreams caps the number of concurrent streams used to
+	// move a single object between pools, 1 disables parallel transfers.
+	EnvTransferStreams = "MINIO_TRANSFER_STREAMS"
+
+	// EnvHTTP3 enables the experimental HTTP/3 (QUIC) listener of the
+	// S3 API on the UDP port of the server address, requires TLS.
+	EnvHTTP3 = "MINIO_HTTP3"
+
+	// EnvNetMonitorInterval is the time between two rounds of the
+	// background internode network monitor, 0 disables it. The other
+	// EnvNetMonitor* variables set the history retention, the size of
+	// the throughput sample and the latency and loss (in percent)
+	// alert thresholds.
+	EnvNetMonitorInterval  = "MINIO_NET_MONITOR_INTERVAL"
+	EnvNetMonitorRetention = "MINIO_NET_MONITOR_RETENTION"
+	EnvNetMonitorPayload   = "MINIO_NET_MONITOR_PAYLOAD"
+	EnvNetMonitorLatency   = "MINIO_NET_MONITOR_LATENCY"
+	EnvNetMonitorLoss      = "MINIO_NET_MONITOR_LOSS"
+
 	EnvUpdate = "MINIO_UPDATE"
 
 	EnvKMSSecretKey      = "MINIO_KMS_SECRET_KEY"
@@ -0,0 +1,116 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package config
+
+import "sort"
+
+// RedactedValue replaces sensitive values in diffs and redacted configs.
+const RedactedValue = "*redacted*"
+
+// Change is a single key of a sub-system target changed between two
+// configurations, Old is empty for added keys and New for removed keys.
+type Change struct {
+	SubSys string `json:"subSys"`
+	Target string `json:"target,omitempty"`
+	Key    string `json:"key"`
+	Old    string `json:"old,omitempty"`
+	New    string `json:"new,omitempty"`
+}
+
+// isSensitive returns true if the values of key must not be disclosed.
+func isSensitive(subSys, key string) bool {
+	if subSys == CredentialsSubSys {
+		return true
+	}
+	for _, helpKV := range HelpSubSysMap[subSys] {
+		if helpKV.Key == key {
+			return helpKV.Sensitive
+		}
+	}
+	return false
+}
+
+// Diff returns the keys changed from before to after, sorted by
+// sub-system, target and key. Values of sensitive keys are redacted,
+// their changes are still reported.
+func Diff(before, after Config) []Change {
+	var changes []Change
+	seen := make(map[string]map[string]struct{})
+	add := func(subSys, target string) {
+		if seen[subSys] == nil {
+			seen[subSys] = make(map[string]struct{})
+		}
+		seen[subSys][target] = struct{}{}
+	}
+	for subSys, targets := range before {
+		for target := range targets {
+			add(subSys, target)
+		}
+	}
+	for subSys, targets := range after {
+		for target := range targets {
+			add(subSys, target)
+		}
+	}
+
+	for subSys, targets := range seen {
+		for target := range targets {
+			oldKVS, newKVS := before[subSys][target], after[subSys][target]
+			keys := make(map[string]struct{}, len(oldKVS)+len(newKVS))
+			for _, kv := range oldKVS {
+				keys[kv.Key] = struct{}{}
+			}
+			for _, kv := range newKVS {
+				keys[kv.Key] = struct{}{}
+			}
+			for key := range keys {
+				oldValue, _ := oldKVS.Lookup(key)
+				newValue, _ := newKVS.Lookup(key)
+				if oldValue == newValue {
+					continue
+				}
+				if isSensitive(subSys, key) {
+					if oldValue != "" {
+						oldValue = RedactedValue
+					}
+					if newValue != "" {
+						newValue = RedactedValue
+					}
+				}
+				changes = append(changes, Change{
+					SubSys: subSys,
+					Target: target,
+					Key:    key,
+					Old:    oldValue,
+					New:    newValue,
+				})
+			}
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].SubSys != changes[j].SubSys {
+			return changes[i].SubSys < changes[j].SubSys
+		}
+		if changes[i].Target != changes[j].Target {
+			return changes[i].Target < changes[j].Target
+		}
+		return changes[i].Key < changes[j].Key
+	})
+	return changes
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	before := Config{
+		APISubSys: {
+			Default: KVS{{Key: "requests_max", Value: "0"}, {Key: "cors_allow_origin", Value: "*"}},
+		},
+		CredentialsSubSys: {
+			Default: KVS{{Key: "access_key", Value: "minio"}},
+		},
+	}
+	after := Config{
+		APISubSys: {
+			Default: KVS{{Key: "requests_max", Value: "1600"}, {Key: "cors_allow_origin", Value: "*"}},
+		},
+		CredentialsSubSys: {
+			Default: KVS{{Key: "access_key", Value: "admin"}},
+		},
+		RegionSubSys: {
+			Default: KVS{{Key: "name", Value: "eu-west-1"}},
+		},
+	}
+
+	expected := []Change{
+		{SubSys: APISubSys, Target: Default, Key: "requests_max", Old: "0", New: "1600"},
+		{SubSys: CredentialsSubSys, Target: Default, Key: "access_key", Old: RedactedValue, New: RedactedValue},
+		{SubSys: RegionSubSys, Target: Default, Key: "name", New: "eu-west-1"},
+	}
+	if changes := Diff(before, after); !reflect.DeepEqual(changes, expected) {
+		t.Fatalf("expected %v, got %v", expected, changes)
+	}
+
+	// Removed keys are reported with an empty new value.
+	expected = []Change{
+		{SubSys: APISubSys, Target: Default, Key: "requests_max", Old: "1600", New: "0"},
+		{SubSys: CredentialsSubSys, Target: Default, Key: "access_key", Old: RedactedValue, New: RedactedValue},
+		{SubSys: RegionSubSys, Target: Default, Key: "name", Old: "eu-west-1"},
+	}
+	if changes := Diff(after, before); !reflect.DeepEqual(changes, expected) {
+		t.Fatalf("expected %v, got %v", expected, changes)
+	}
+
+	if changes := Diff(before, before.Clone()); len(changes) != 0 {
+		t.Fatalf("expected no changes, got %v", changes)
+	}
+}
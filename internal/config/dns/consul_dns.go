@@ -0,0 +1,467 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dns
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7/pkg/set"
+	xhttp "github.com/qkbyte/minio/internal/http"
+)
+
+// defaultConsulPrefix is the KV prefix of the records when none is set.
+const defaultConsulPrefix = "minio/dns"
+
+const (
+	// consulServiceName is the Consul service the domain IPs are
+	// registered as, each IP is an instance with its own health check.
+	consulServiceName = "minio"
+
+	consulCheckInterval = "10s"
+	consulCheckTimeout  = "5s"
+
+	// consulDeregisterAfter removes instances whose health check keeps
+	// failing, e.g. of a cluster that was shut down without deregistering.
+	consulDeregisterAfter = "10m"
+)
+
+// consulCheck - HTTP health check of a Consul service instance.
+type consulCheck struct {
+	HTTP                           string `json:"HTTP"`
+	Interval                       string `json:"Interval"`
+	Timeout                        string `json:"Timeout"`
+	DeregisterCriticalServiceAfter string `json:"DeregisterCriticalServiceAfter"`
+}
+
+// consulService - a service instance registered with the Consul agent.
+type consulService struct {
+	ID      string      `json:"ID"`
+	Name    string      `json:"Name"`
+	Address string      `json:"Address"`
+	Port    int         `json:"Port"`
+	Tags    []string    `json:"Tags,omitempty"`
+	Check   consulCheck `json:"Check"`
+}
+
+// consulServiceHealth - an entry of the Consul service health listing.
+type consulServiceHealth struct {
+	Service struct {
+		Address string `json:"Address"`
+	} `json:"Service"`
+	Checks []struct {
+		Status string `json:"Status"`
+	} `json:"Checks"`
+}
+
+// consulKV - a single key of a Consul KV listing, values are base64
+// encoded by Consul and decoded by encoding/json into []byte.
+type consulKV struct {
+	Key   string `json:"Key"`
+	Value []byte `json:"Value"`
+}
+
+// ConsulDNS - represents dns records kept in the Consul KV store,
+// one key per bucket and IP at <prefix>/<domain>/<bucket>/<ip>.
+type ConsulDNS struct {
+	httpClient  *http.Client
+	endpoint    *url.URL
+	token       string
+	prefix      string
+	rootCAs     *x509.CertPool
+	domainNames []string
+	domainIPs   set.StringSet
+	domainPort  string
+
+	// checkScheme is the scheme of the health checks of the domain
+	// IPs, empty if they are not registered as service instances.
+	checkScheme string
+}
+
+// ConsulOption - functional options pattern style for ConsulDNS
+type ConsulOption func(*ConsulDNS)
+
+// ConsulToken - ACL token sent with every request to Consul.
+func ConsulToken(token string) ConsulOption {
+	return func(args *ConsulDNS) {
+		args.token = token
+	}
+}
+
+// ConsulPrefix - custom KV prefix of the records, optional and
+// if empty "minio/dns" is used.
+func ConsulPrefix(prefix string) ConsulOption {
+	return func(args *ConsulDNS) {
+		args.prefix = strings.Trim(prefix, "/")
+	}
+}
+
+// ConsulRootCAs - add custom trust certs pool
+func ConsulRootCAs(certPool *x509.CertPool) ConsulOption {
+	return func(args *ConsulDNS) {
+		args.rootCAs = certPool
+	}
+}
+
+// ConsulDomain - domain names, IPs and port the buckets of this
+// deployment resolve to.
+func ConsulDomain(domainNames []string, domainIPs set.StringSet, domainPort string) ConsulOption {
+	return func(args *ConsulDNS) {
+		args.domainNames = domainNames
+		args.domainIPs = domainIPs
+		args.domainPort = domainPort
+	}
+}
+
+// ConsulHealthCheck - register the domain IPs as instances of the
+// "minio" service, health checked through scheme ("http" or "https").
+// Records of IPs whose instances fail their checks are skipped by Get.
+func ConsulHealthCheck(scheme string) ConsulOption {
+	return func(args *ConsulDNS) {
+		args.checkScheme = scheme
+	}
+}
+
+// NewConsulDNS - initialize a new Consul DNS store on the Consul
+// agent at endpoint, e.g. http://localhost:8500.
+func NewConsulDNS(endpoint string, setters ...ConsulOption) (Store, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if u.Host == "" {
+		return nil, errors.New("invalid argument")
+	}
+
+	args := &ConsulDNS{
+		endpoint: u,
+		prefix:   defaultConsulPrefix,
+	}
+	for _, setter := range setters {
+		setter(args)
+	}
+	if len(args.domainNames) == 0 || args.domainIPs.IsEmpty() {
+		return nil, errors.New("invalid argument")
+	}
+	args.domainIPs = stripPorts(args.domainIPs)
+	args.httpClient = &http.Client{
+		Transport: &http.Transport{
+			Proxy: http.ProxyFromEnvironment,
+			DialContext: (&net.Dialer{
+				Timeout:   3 * time.Second,
+				KeepAlive: 5 * time.Second,
+			}).DialContext,
+			ResponseHeaderTimeout: 3 * time.Second,
+			TLSHandshakeTimeout:   3 * time.Second,
+			ExpectContinueTimeout: 3 * time.Second,
+			TLSClientConfig: &tls.Config{
+				RootCAs: args.rootCAs,
+			},
+		},
+	}
+	return args, nil
+}
+
+func (c *ConsulDNS) key(elem ...string) string {
+	return path.Join(append([]string{c.prefix}, elem...)...)
+}
+
+// call sends a request to the Consul HTTP API and returns the response
+// body of a successful request. Not found returns ErrNoEntriesFound.
+func (c *ConsulDNS) call(method, apiPath, rawQuery string, body []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultOperatorContextTimeout)
+	defer cancel()
+
+	u := *c.endpoint
+	u.Path = path.Join(u.Path, apiPath)
+	if strings.HasSuffix(apiPath, "/") {
+		u.Path += "/"
+	}
+	u.RawQuery = rawQuery
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if c.token != "" {
+		req.Header.Set("X-Consul-Token", c.token)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer xhttp.DrainBody(resp.Body)
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, ErrNoEntriesFound
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("consul %s %s failed with status %s", method, apiPath, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// do sends a request for the KV key, recurse applies it to all keys
+// under the key. A missing key returns ErrNoEntriesFound.
+func (c *ConsulDNS) do(method, key string, recurse bool, body []byte) ([]consulKV, error) {
+	apiPath, rawQuery := path.Join("/v1/kv", key), ""
+	if recurse {
+		apiPath += "/"
+		rawQuery = "recurse=true"
+	}
+	data, err := c.call(method, apiPath, rawQuery, body)
+	if err != nil || method != http.MethodGet {
+		return nil, err
+	}
+	var kvs []consulKV
+	if err = json.Unmarshal(data, &kvs); err != nil {
+		return nil, err
+	}
+	return kvs, nil
+}
+
+func (c *ConsulDNS) serviceID(ip string) string {
+	return consulServiceName + "-" + ip
+}
+
+// Register - registers the domain IPs as instances of the "minio"
+// service with the local Consul agent, each with an HTTP health check
+// of the liveness endpoint. Registering again replaces the instances.
+func (c *ConsulDNS) Register() error {
+	if c.checkScheme == "" {
+		return nil
+	}
+	port, err := strconv.Atoi(c.domainPort)
+	if err != nil {
+		return err
+	}
+	for ip := range c.domainIPs {
+		service, err := json.Marshal(consulService{
+			ID:      c.serviceID(ip),
+			Name:    consulServiceName,
+			Address: ip,
+			Port:    port,
+			Tags:    c.domainNames,
+			Check: consulCheck{
+				HTTP:                           c.checkScheme + "://" + net.JoinHostPort(ip, c.domainPort) + "/minio/health/live",
+				Interval:                       consulCheckInterval,
+				Timeout:                        consulCheckTimeout,
+				DeregisterCriticalServiceAfter: consulDeregisterAfter,
+			},
+		})
+		if err != nil {
+			return err
+		}
+		if _, err = c.call(http.MethodPut, "/v1/agent/service/register", "", service); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Deregister - removes the instances added by Register from the local
+// Consul agent, instances of other agents keep being health checked.
+func (c *ConsulDNS) Deregister() error {
+	if c.checkScheme == "" {
+		return nil
+	}
+	var firstErr error
+	for ip := range c.domainIPs {
+		_, err := c.call(http.MethodPut, path.Join("/v1/agent/service/deregister", c.serviceID(ip)), "", nil)
+		if err != nil && err != ErrNoEntriesFound && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// unhealthy returns the registered service addresses of which no
+// instance passes its health checks, nil if they cannot be fetched.
+func (c *ConsulDNS) unhealthy() set.StringSet {
+	data, err := c.call(http.MethodGet, path.Join("/v1/health/service", consulServiceName), "", nil)
+	if err != nil {
+		return nil
+	}
+	var entries []consulServiceHealth
+	if err = json.Unmarshal(data, &entries); err != nil {
+		return nil
+	}
+	registered, passing := set.NewStringSet(), set.NewStringSet()
+	for _, entry := range entries {
+		registered.Add(entry.Service.Address)
+		healthy := true
+		for _, check := range entry.Checks {
+			if check.Status != "passing" {
+				healthy = false
+				break
+			}
+		}
+		if healthy {
+			passing.Add(entry.Service.Address)
+		}
+	}
+	return registered.Difference(passing)
+}
+
+// records decodes the records of a listing, keyed by bucket.
+func (c *ConsulDNS) records(domainName string, kvs []consulKV) (map[string][]SrvRecord, error) {
+	srvRecords := map[string][]SrvRecord{}
+	domainKey := c.key(domainName) + "/"
+	for _, kv := range kvs {
+		bucket, _, ok := strings.Cut(strings.TrimPrefix(kv.Key, domainKey), "/")
+		if !ok || bucket == "" {
+			continue
+		}
+		var srvRecord SrvRecord
+		if err := json.Unmarshal(kv.Value, &srvRecord); err != nil {
+			return nil, err
+		}
+		srvRecord.Key = bucket
+		srvRecords[bucket] = append(srvRecords[bucket], srvRecord)
+	}
+	return srvRecords, nil
+}
+
+// List - Retrieves list of DNS entries for the domain.
+func (c *ConsulDNS) List() (map[string][]SrvRecord, error) {
+	srvRecords := map[string][]SrvRecord{}
+	for _, domainName := range c.domainNames {
+		kvs, err := c.do(http.MethodGet, c.key(domainName), true, nil)
+		if err == ErrNoEntriesFound {
+			return srvRecords, ErrDomainMissing
+		}
+		if err != nil {
+			return srvRecords, err
+		}
+		records, err := c.records(domainName, kvs)
+		if err != nil {
+			return srvRecords, err
+		}
+		for bucket, r := range records {
+			srvRecords[bucket] = append(srvRecords[bucket], r...)
+		}
+	}
+	return srvRecords, nil
+}
+
+// Get - Retrieves DNS records for a bucket.
+func (c *ConsulDNS) Get(bucket string) ([]SrvRecord, error) {
+	var srvRecords []SrvRecord
+	for _, domainName := range c.domainNames {
+		kvs, err := c.do(http.MethodGet, c.key(domainName, bucket), true, nil)
+		if err == ErrNoEntriesFound {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		records, err := c.records(domainName, kvs)
+		if err != nil {
+			return nil, err
+		}
+		srvRecords = append(srvRecords, records[bucket]...)
+	}
+	if len(srvRecords) == 0 {
+		return nil, ErrNoEntriesFound
+	}
+	if c.checkScheme != "" {
+		// Skip the IPs failing their health checks, unless all of them
+		// do, the bucket is still owned by its cluster.
+		if unhealthy := c.unhealthy(); !unhealthy.IsEmpty() {
+			healthy := srvRecords[:0:0]
+			for _, record := range srvRecords {
+				if !unhealthy.Contains(record.Host) {
+					healthy = append(healthy, record)
+				}
+			}
+			if len(healthy) > 0 {
+				srvRecords = healthy
+			}
+		}
+	}
+	sort.Slice(srvRecords, func(i, j int) bool {
+		return srvRecords[i].Host < srvRecords[j].Host
+	})
+	return srvRecords, nil
+}
+
+// Put - Adds DNS entries for the bucket into Consul.
+func (c *ConsulDNS) Put(bucket string) error {
+	c.Delete(bucket) // delete any existing entries.
+
+	t := time.Now().UTC()
+	for ip := range c.domainIPs {
+		bucketMsg, err := newCoreDNSMsg(ip, c.domainPort, defaultTTL, t)
+		if err != nil {
+			return err
+		}
+		for _, domainName := range c.domainNames {
+			if _, err = c.do(http.MethodPut, c.key(domainName, bucket, ip), false, bucketMsg); err != nil {
+				c.Delete(bucket)
+				return newError(bucket, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Delete - Removes DNS entries added in Put().
+func (c *ConsulDNS) Delete(bucket string) error {
+	for _, domainName := range c.domainNames {
+		if _, err := c.do(http.MethodDelete, c.key(domainName, bucket), true, nil); err != nil && err != ErrNoEntriesFound {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteRecord - Removes a specific DNS entry
+func (c *ConsulDNS) DeleteRecord(record SrvRecord) error {
+	for _, domainName := range c.domainNames {
+		if _, err := c.do(http.MethodDelete, c.key(domainName, record.Key, record.Host), false, nil); err != nil && err != ErrNoEntriesFound {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close deregisters the service instances of this node and closes
+// the internal http client
+func (c *ConsulDNS) Close() error {
+	err := c.Deregister()
+	c.httpClient.CloseIdleConnections()
+	return err
+}
+
+// String stringer name for this implementation of dns.Store
+func (c *ConsulDNS) String() string {
+	return "consulDNS"
+}
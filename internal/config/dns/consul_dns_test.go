@@ -0,0 +1,210 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dns
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/minio/minio-go/v7/pkg/set"
+)
+
+// fakeConsulKV serves the subset of the Consul KV, agent and health
+// APIs used by ConsulDNS.
+type fakeConsulKV struct {
+	mu       sync.Mutex
+	kv       map[string][]byte
+	services map[string]consulService
+	failing  set.StringSet
+}
+
+func (f *fakeConsulKV) serveAgent(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.URL.Path == "/v1/agent/service/register":
+		var service consulService
+		if err := json.NewDecoder(r.Body).Decode(&service); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.services[service.ID] = service
+	case strings.HasPrefix(r.URL.Path, "/v1/agent/service/deregister/"):
+		id := strings.TrimPrefix(r.URL.Path, "/v1/agent/service/deregister/")
+		if _, ok := f.services[id]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		delete(f.services, id)
+	case r.URL.Path == "/v1/health/service/"+consulServiceName:
+		var entries []consulServiceHealth
+		for _, service := range f.services {
+			var entry consulServiceHealth
+			entry.Service.Address = service.Address
+			status := "passing"
+			if f.failing.Contains(service.Address) {
+				status = "critical"
+			}
+			entry.Checks = append(entry.Checks, struct {
+				Status string `json:"Status"`
+			}{status})
+			entries = append(entries, entry)
+		}
+		json.NewEncoder(w).Encode(entries)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (f *fakeConsulKV) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !strings.HasPrefix(r.URL.Path, "/v1/kv/") {
+		f.serveAgent(w, r)
+		return
+	}
+	key := strings.TrimPrefix(r.URL.Path, "/v1/kv/")
+	recurse := r.URL.Query().Get("recurse") == "true"
+	match := func(k string) bool {
+		if recurse {
+			return strings.HasPrefix(k, key)
+		}
+		return k == key
+	}
+	switch r.Method {
+	case http.MethodPut:
+		f.kv[key], _ = io.ReadAll(r.Body)
+		w.Write([]byte("true"))
+	case http.MethodDelete:
+		for k := range f.kv {
+			if match(k) {
+				delete(f.kv, k)
+			}
+		}
+		w.Write([]byte("true"))
+	case http.MethodGet:
+		var kvs []consulKV
+		for k, v := range f.kv {
+			if match(k) {
+				kvs = append(kvs, consulKV{Key: k, Value: v})
+			}
+		}
+		if len(kvs) == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		sort.Slice(kvs, func(i, j int) bool { return kvs[i].Key < kvs[j].Key })
+		json.NewEncoder(w).Encode(kvs)
+	}
+}
+
+func TestConsulDNS(t *testing.T) {
+	server := httptest.NewServer(&fakeConsulKV{kv: map[string][]byte{}})
+	defer server.Close()
+
+	store, err := NewConsulDNS(server.URL, ConsulDomain([]string{"example.com"}, set.CreateStringSet("10.0.0.1", "10.0.0.2"), "9000"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = store.List(); err != ErrDomainMissing {
+		t.Fatalf("expected %v, got %v", ErrDomainMissing, err)
+	}
+	if err = store.Put("photos"); err != nil {
+		t.Fatal(err)
+	}
+	records, err := store.Get("photos")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 || records[0].Host != "10.0.0.1" || records[0].Port != "9000" || records[0].Key != "photos" {
+		t.Fatalf("unexpected records %v", records)
+	}
+	if _, err = store.Get("logs"); err != ErrNoEntriesFound {
+		t.Fatalf("expected %v, got %v", ErrNoEntriesFound, err)
+	}
+
+	if err = store.DeleteRecord(SrvRecord{Key: "photos", Host: "10.0.0.2"}); err != nil {
+		t.Fatal(err)
+	}
+	all, err := store.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 1 || len(all["photos"]) != 1 {
+		t.Fatalf("unexpected records %v", all)
+	}
+	if err = store.Delete("photos"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = store.Get("photos"); err != ErrNoEntriesFound {
+		t.Fatalf("expected %v, got %v", ErrNoEntriesFound, err)
+	}
+}
+
+func TestConsulDNSHealthCheck(t *testing.T) {
+	fake := &fakeConsulKV{kv: map[string][]byte{}, services: map[string]consulService{}, failing: set.NewStringSet()}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	store, err := NewConsulDNS(server.URL, ConsulDomain([]string{"example.com"}, set.CreateStringSet("10.0.0.1", "10.0.0.2"), "9000"), ConsulHealthCheck("https"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	consul := store.(*ConsulDNS)
+	if err = consul.Register(); err != nil {
+		t.Fatal(err)
+	}
+	service, ok := fake.services[consulServiceName+"-10.0.0.1"]
+	if !ok || len(fake.services) != 2 {
+		t.Fatalf("expected an instance per domain IP, got %v", fake.services)
+	}
+	if service.Port != 9000 || service.Check.HTTP != "https://10.0.0.1:9000/minio/health/live" || service.Check.DeregisterCriticalServiceAfter == "" {
+		t.Fatalf("unexpected instance %+v", service)
+	}
+
+	if err = store.Put("photos"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Records of failing IPs are skipped.
+	fake.failing.Add("10.0.0.1")
+	records, err := store.Get("photos")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 || records[0].Host != "10.0.0.2" {
+		t.Fatalf("expected only the healthy record, got %v", records)
+	}
+
+	// All IPs failing keeps the bucket owned by its cluster.
+	fake.failing.Add("10.0.0.2")
+	if records, err = store.Get("photos"); err != nil || len(records) != 2 {
+		t.Fatalf("expected all records, got %v, %v", records, err)
+	}
+
+	if err = store.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if len(fake.services) != 0 {
+		t.Fatalf("expected the instances to be deregistered, got %v", fake.services)
+	}
+}
@@ -286,8 +286,17 @@ func NewCoreDNS(cfg clientv3.Config, setters ...EtcdOption) (Store, error) {
 		return nil, errors.New("invalid argument")
 	}
 
-	// strip ports off of domainIPs
-	domainIPsWithoutPorts := args.domainIPs.ApplyFunc(func(ip string) string {
+	args.domainIPs = stripPorts(args.domainIPs)
+
+	return args, nil
+}
+
+// stripPorts strips ports off of domainIPs.
+func stripPorts(domainIPs set.StringSet) set.StringSet {
+	return domainIPs.ApplyFunc(func(ip string) string {
+		if net.ParseIP(ip) != nil {
+			return ip
+		}
 		host, _, err := net.SplitHostPort(ip)
 		if err != nil {
 			if strings.Contains(err.Error(), "missing port in address") {
@@ -296,7 +305,4 @@ func NewCoreDNS(cfg clientv3.Config, setters ...EtcdOption) (Store, error) {
 		}
 		return host
 	})
-	args.domainIPs = domainIPsWithoutPorts
-
-	return args, nil
 }
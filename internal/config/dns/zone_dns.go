@@ -0,0 +1,301 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dns
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	mdns "github.com/miekg/dns"
+	"github.com/minio/minio-go/v7/pkg/set"
+)
+
+// WriteZone writes the records, keyed by bucket, as an RFC 1035 zone
+// file with an A, AAAA or CNAME record per bucket, domain and host.
+func WriteZone(w io.Writer, domainNames []string, srvRecords map[string][]SrvRecord) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "; MinIO bucket DNS records\n$ORIGIN .\n$TTL %d\n", defaultTTL)
+
+	buckets := make([]string, 0, len(srvRecords))
+	for bucket := range srvRecords {
+		buckets = append(buckets, bucket)
+	}
+	sort.Strings(buckets)
+	for _, domainName := range domainNames {
+		for _, bucket := range buckets {
+			records := srvRecords[bucket]
+			sort.Slice(records, func(i, j int) bool {
+				return records[i].Host < records[j].Host
+			})
+			name := mdns.Fqdn(bucket + "." + domainName)
+			for _, record := range records {
+				ttl := record.TTL
+				if ttl == 0 {
+					ttl = defaultTTL
+				}
+				rtype, value := "CNAME", mdns.Fqdn(record.Host)
+				if ip := net.ParseIP(record.Host); ip != nil {
+					rtype, value = "A", ip.String()
+					if ip.To4() == nil {
+						rtype = "AAAA"
+					}
+				}
+				fmt.Fprintf(bw, "%s\t%d\tIN\t%s\t%s\n", name, ttl, rtype, value)
+			}
+		}
+	}
+	return bw.Flush()
+}
+
+// ZoneFileDNS - represents dns records kept in a zone file, to be
+// served by an authoritative DNS server such as BIND or CoreDNS.
+type ZoneFileDNS struct {
+	mu          sync.Mutex
+	path        string
+	modTime     time.Time
+	records     map[string][]SrvRecord
+	domainNames []string
+	domainIPs   set.StringSet
+	domainPort  string
+}
+
+// NewZoneFileDNS - initialize a new zone file DNS store, records
+// already in the zone file at path are kept.
+func NewZoneFileDNS(path string, domainNames []string, domainIPs set.StringSet, domainPort string) (Store, error) {
+	if path == "" || len(domainNames) == 0 || domainIPs.IsEmpty() {
+		return nil, errors.New("invalid argument")
+	}
+	z := &ZoneFileDNS{
+		path:        path,
+		records:     map[string][]SrvRecord{},
+		domainNames: domainNames,
+		domainIPs:   stripPorts(domainIPs),
+		domainPort:  domainPort,
+	}
+	if err := z.load(); err != nil {
+		return nil, err
+	}
+	return z, nil
+}
+
+// load reads the zone file if it changed since it was last read.
+func (z *ZoneFileDNS) load() error {
+	fi, err := os.Stat(z.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if fi.ModTime().Equal(z.modTime) {
+		return nil
+	}
+
+	data, err := os.ReadFile(z.path)
+	if err != nil {
+		return err
+	}
+	records := map[string][]SrvRecord{}
+	zp := mdns.NewZoneParser(bytes.NewReader(data), "", z.path)
+	for rr, ok := zp.Next(); ok; rr, ok = zp.Next() {
+		var host string
+		switch rr := rr.(type) {
+		case *mdns.A:
+			host = rr.A.String()
+		case *mdns.AAAA:
+			host = rr.AAAA.String()
+		case *mdns.CNAME:
+			host = strings.TrimSuffix(rr.Target, ".")
+		default:
+			continue
+		}
+		name := strings.ToLower(rr.Header().Name)
+		for _, domainName := range z.domainNames {
+			bucket := strings.TrimSuffix(name, "."+mdns.Fqdn(strings.ToLower(domainName)))
+			if bucket == name || bucket == "" || strings.Contains(bucket, ".") {
+				continue
+			}
+			if hostRecords(records[bucket]).Contains(host) {
+				break
+			}
+			records[bucket] = append(records[bucket], SrvRecord{
+				Host:         host,
+				Port:         json.Number(z.domainPort),
+				TTL:          rr.Header().Ttl,
+				CreationDate: fi.ModTime().UTC(),
+				Key:          bucket,
+			})
+			break
+		}
+	}
+	if err = zp.Err(); err != nil {
+		return err
+	}
+	z.records = records
+	z.modTime = fi.ModTime()
+	return nil
+}
+
+// save writes the zone file atomically.
+func (z *ZoneFileDNS) save() error {
+	var buf bytes.Buffer
+	if err := WriteZone(&buf, z.domainNames, z.records); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(z.path), filepath.Base(z.path)+".tmp-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(buf.Bytes()); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	if err = os.Rename(tmp.Name(), z.path); err != nil {
+		return err
+	}
+	if fi, err := os.Stat(z.path); err == nil {
+		z.modTime = fi.ModTime()
+	}
+	return nil
+}
+
+// List - Retrieves list of DNS entries for the domain.
+func (z *ZoneFileDNS) List() (map[string][]SrvRecord, error) {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+	if err := z.load(); err != nil {
+		return nil, err
+	}
+	srvRecords := make(map[string][]SrvRecord, len(z.records))
+	for bucket, records := range z.records {
+		srvRecords[bucket] = append([]SrvRecord(nil), records...)
+	}
+	return srvRecords, nil
+}
+
+// Get - Retrieves DNS records for a bucket.
+func (z *ZoneFileDNS) Get(bucket string) ([]SrvRecord, error) {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+	if err := z.load(); err != nil {
+		return nil, err
+	}
+	records := z.records[bucket]
+	if len(records) == 0 {
+		return nil, ErrNoEntriesFound
+	}
+	return append([]SrvRecord(nil), records...), nil
+}
+
+// Put - Adds DNS entries for the bucket into the zone file.
+func (z *ZoneFileDNS) Put(bucket string) error {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+	if err := z.load(); err != nil {
+		return newError(bucket, err)
+	}
+	t := time.Now().UTC()
+	records := make([]SrvRecord, 0, len(z.domainIPs))
+	for ip := range z.domainIPs {
+		records = append(records, SrvRecord{
+			Host:         ip,
+			Port:         json.Number(z.domainPort),
+			TTL:          defaultTTL,
+			CreationDate: t,
+			Key:          bucket,
+		})
+	}
+	z.records[bucket] = records
+	if err := z.save(); err != nil {
+		return newError(bucket, err)
+	}
+	return nil
+}
+
+// Delete - Removes DNS entries added in Put().
+func (z *ZoneFileDNS) Delete(bucket string) error {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+	if err := z.load(); err != nil {
+		return err
+	}
+	if _, ok := z.records[bucket]; !ok {
+		return nil
+	}
+	delete(z.records, bucket)
+	return z.save()
+}
+
+// DeleteRecord - Removes a specific DNS entry
+func (z *ZoneFileDNS) DeleteRecord(record SrvRecord) error {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+	if err := z.load(); err != nil {
+		return err
+	}
+	records := z.records[record.Key][:0]
+	for _, r := range z.records[record.Key] {
+		if r.Host != record.Host {
+			records = append(records, r)
+		}
+	}
+	if len(records) == 0 {
+		delete(z.records, record.Key)
+	} else {
+		z.records[record.Key] = records
+	}
+	return z.save()
+}
+
+// Close is a no-op, the zone file is written on every change.
+func (z *ZoneFileDNS) Close() error {
+	return nil
+}
+
+// String stringer name for this implementation of dns.Store
+func (z *ZoneFileDNS) String() string {
+	return "zoneFileDNS"
+}
+
+// hostRecords returns the hosts of the records.
+func hostRecords(records []SrvRecord) set.StringSet {
+	hosts := set.NewStringSet()
+	for _, record := range records {
+		hosts.Add(record.Host)
+	}
+	return hosts
+}
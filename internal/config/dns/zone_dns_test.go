@@ -0,0 +1,85 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dns
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/minio/minio-go/v7/pkg/set"
+)
+
+func TestZoneFileDNS(t *testing.T) {
+	zonePath := filepath.Join(t.TempDir(), "buckets.zone")
+	store, err := NewZoneFileDNS(zonePath, []string{"example.com"}, set.CreateStringSet("10.0.0.1:9000", "fd00::1"), "9000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = store.Get("photos"); err != ErrNoEntriesFound {
+		t.Fatalf("expected %v, got %v", ErrNoEntriesFound, err)
+	}
+	if err = store.Put("photos"); err != nil {
+		t.Fatal(err)
+	}
+	if err = store.Put("logs"); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(zonePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{
+		"photos.example.com.\t30\tIN\tA\t10.0.0.1",
+		"photos.example.com.\t30\tIN\tAAAA\tfd00::1",
+		"logs.example.com.\t30\tIN\tA\t10.0.0.1",
+	} {
+		if !strings.Contains(string(data), line) {
+			t.Errorf("expected zone file to contain %q, got\n%s", line, data)
+		}
+	}
+
+	// A new store picks up the records of the zone file.
+	store, err = NewZoneFileDNS(zonePath, []string{"example.com"}, set.CreateStringSet("10.0.0.2"), "9000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	records, err := store.Get("photos")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 || !hostRecords(records).Equals(set.CreateStringSet("10.0.0.1", "fd00::1")) {
+		t.Fatalf("unexpected records %v", records)
+	}
+
+	if err = store.DeleteRecord(SrvRecord{Key: "photos", Host: "fd00::1"}); err != nil {
+		t.Fatal(err)
+	}
+	if err = store.Delete("logs"); err != nil {
+		t.Fatal(err)
+	}
+	all, err := store.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 1 || len(all["photos"]) != 1 || all["photos"][0].Host != "10.0.0.1" {
+		t.Fatalf("unexpected records %v", all)
+	}
+}
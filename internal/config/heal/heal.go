@@ -25,19 +25,25 @@ import (
 	"sync"
 	"time"
 
+	"github.com/dustin/go-humanize"
 	"github.com/minio/pkg/env"
 	"github.com/qkbyte/minio/internal/config"
+	"github.com/qkbyte/minio/internal/ioprio"
 )
 
 // Compression environment variables
 const (
-	Bitrot  = "bitrotscan"
-	Sleep   = "max_sleep"
-	IOCount = "max_io"
-
-	EnvBitrot  = "MINIO_HEAL_BITROTSCAN"
-	EnvSleep   = "MINIO_HEAL_MAX_SLEEP"
-	EnvIOCount = "MINIO_HEAL_MAX_IO"
+	Bitrot    = "bitrotscan"
+	Sleep     = "max_sleep"
+	IOCount   = "max_io"
+	DriveRate = "max_drive_rate"
+	IOClass   = "io_class"
+
+	EnvBitrot    = "MINIO_HEAL_BITROTSCAN"
+	EnvSleep     = "MINIO_HEAL_MAX_SLEEP"
+	EnvIOCount   = "MINIO_HEAL_MAX_IO"
+	EnvDriveRate = "MINIO_HEAL_MAX_DRIVE_RATE"
+	EnvIOClass   = "MINIO_HEAL_IO_CLASS"
 )
 
 var configMutex sync.RWMutex
@@ -51,6 +57,13 @@ type Config struct {
 	Sleep   time.Duration `json:"sleep"`
 	IOCount int           `json:"iocount"`
 
+	// maximum bytes per second read from and written to each drive
+	// by heal, 0 for unlimited.
+	DriveRate uint64 `json:"driveRate"`
+
+	// kernel I/O class heal drive I/O runs under.
+	IOClass ioprio.Class `json:"ioClass"`
+
 	// Cached value from Bitrot field
 	cache struct {
 		// -1: bitrot enabled, 0: bitrot disabled, > 0: bitrot cycle
@@ -70,6 +83,21 @@ func (opts Config) BitrotScanCycle() (d time.Duration) {
 	return opts.cache.bitrotCycle
 }
 
+// MaxDriveRate returns the maximum heal traffic per drive in bytes
+// per second, 0 if heal is not rate limited.
+func (opts Config) MaxDriveRate() uint64 {
+	configMutex.RLock()
+	defer configMutex.RUnlock()
+	return opts.DriveRate
+}
+
+// IOPriority returns the I/O class heal drive I/O runs under.
+func (opts Config) IOPriority() ioprio.Class {
+	configMutex.RLock()
+	defer configMutex.RUnlock()
+	return opts.IOClass
+}
+
 // Wait waits for IOCount to go down or max sleep to elapse before returning.
 // usually used in healing paths to wait for specified amount of time to
 // throttle healing.
@@ -113,6 +141,8 @@ func (opts *Config) Update(nopts Config) {
 	opts.Bitrot = nopts.Bitrot
 	opts.IOCount = nopts.IOCount
 	opts.Sleep = nopts.Sleep
+	opts.DriveRate = nopts.DriveRate
+	opts.IOClass = nopts.IOClass
 
 	opts.cache.bitrotCycle, _ = parseBitrotConfig(nopts.Bitrot)
 }
@@ -131,6 +161,14 @@ var DefaultKVS = config.KVS{
 		Key:   IOCount,
 		Value: "100",
 	},
+	config.KV{
+		Key:   DriveRate,
+		Value: "0",
+	},
+	config.KV{
+		Key:   IOClass,
+		Value: "none",
+	},
 }
 
 const minimumBitrotCycleInMonths = 1
@@ -182,5 +220,13 @@ func LookupConfig(kvs config.KVS) (cfg Config, err error) {
 	if err != nil {
 		return cfg, fmt.Errorf("'heal:max_io' value invalid: %w", err)
 	}
+	cfg.DriveRate, err = humanize.ParseBytes(env.Get(EnvDriveRate, kvs.GetWithDefault(DriveRate, DefaultKVS)))
+	if err != nil {
+		return cfg, fmt.Errorf("'heal:max_drive_rate' value invalid: %w", err)
+	}
+	cfg.IOClass, err = ioprio.Parse(env.Get(EnvIOClass, kvs.GetWithDefault(IOClass, DefaultKVS)))
+	if err != nil {
+		return cfg, fmt.Errorf("'heal:io_class' value invalid: %w", err)
+	}
 	return cfg, nil
 }
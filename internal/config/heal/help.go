@@ -45,5 +45,17 @@ var (
 			Optional:    true,
 			Type:        "int",
 		},
+		config.HelpKV{
+			Key:         DriveRate,
+			Description: `maximum heal traffic per drive in bytes per second e.g. "50MiB", 0 for unlimited` + defaultHelpPostfix(DriveRate),
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         IOClass,
+			Description: `kernel I/O class for heal drive I/O on Linux, one of "none", "best-effort", "idle"` + defaultHelpPostfix(IOClass),
+			Optional:    true,
+			Type:        "string",
+		},
 	}
 )
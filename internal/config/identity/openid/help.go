@@ -85,6 +85,18 @@ var (
 			Optional:    true,
 			Type:        "on|off",
 		},
+		config.HelpKV{
+			Key:         ClaimUserinfoCacheTTL,
+			Description: `Duration UserInfo claims are cached per access token, "0s" disables the cache` + defaultHelpPostfix(ClaimUserinfoCacheTTL),
+			Optional:    true,
+			Type:        "duration",
+		},
+		config.HelpKV{
+			Key:         ClaimUserinfoCacheSize,
+			Description: `Maximum number of access tokens with cached UserInfo claims, "0" disables the cache` + defaultHelpPostfix(ClaimUserinfoCacheSize),
+			Optional:    true,
+			Type:        "number",
+		},
 		config.HelpKV{
 			Key:         KeyCloakRealm,
 			Description: `Specify Keycloak 'realm' name, only honored if vendor was set to 'keycloak' as value, if no realm is specified 'master' is default` + defaultHelpPostfix(KeyCloakRealm),
@@ -0,0 +1,217 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package openid
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/qkbyte/minio/internal/arn"
+)
+
+const (
+	// jwksRefreshInterval is the age after which the keys of a
+	// provider are refreshed in the background, validations keep
+	// using the cached keys meanwhile.
+	jwksRefreshInterval = time.Hour
+
+	// jwksMinRefreshInterval rate limits the refreshes triggered by
+	// tokens signed with unknown keys.
+	jwksMinRefreshInterval = 10 * time.Second
+
+	// Failed refreshes are retried with a jittered exponential backoff
+	// between jwksRetryMin and jwksRetryMax.
+	jwksRetryMin = time.Second
+	jwksRetryMax = 5 * time.Minute
+
+	// jwksBackgroundAttempts is the number of attempts of a background
+	// refresh before giving up until the next one is due.
+	jwksBackgroundAttempts = 3
+)
+
+var errJWKSUnknownKey = errors.New("token is signed with an unknown key")
+
+// jwksCache tracks the refreshes of the keys of a provider, the keys
+// themselves live in the shared publicKeys.
+type jwksCache struct {
+	mu          sync.Mutex
+	fetchedAt   time.Time
+	nextAttempt time.Time
+	failures    int
+	lastErr     error
+
+	// refreshing is non-nil while a refresh is in flight and closed
+	// when it completes.
+	refreshing chan struct{}
+}
+
+// jwksBackoff returns the jittered delay before the next attempt after
+// failures consecutive failed refreshes.
+func jwksBackoff(failures int) time.Duration {
+	d := jwksRetryMin
+	for i := 1; i < failures && d < jwksRetryMax; i++ {
+		d *= 2
+	}
+	if d > jwksRetryMax {
+		d = jwksRetryMax
+	}
+	// Full jitter over the upper half keeps nodes of a cluster from
+	// retrying against the IdP in lockstep.
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+func (c *jwksCache) stale(now time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return now.Sub(c.fetchedAt) >= jwksRefreshInterval && !now.Before(c.nextAttempt)
+}
+
+// refreshJWKS refreshes the keys of the provider of arn unless a
+// refresh is in flight or backing off, when wait is set it returns once
+// the keys are refreshed.
+func (r *Config) refreshJWKS(arn arn.ARN, wait bool) error {
+	pCfg, ok := r.arnProviderCfgsMap[arn]
+	if !ok {
+		return fmt.Errorf("Role %s does not exist", arn)
+	}
+	c := pCfg.jwks
+	if c == nil {
+		return r.PopulatePublicKey(arn)
+	}
+
+	c.mu.Lock()
+	if ch := c.refreshing; ch != nil {
+		c.mu.Unlock()
+		if !wait {
+			return nil
+		}
+		<-ch
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		return c.lastErr
+	}
+	if time.Now().Before(c.nextAttempt) {
+		err := c.lastErr
+		c.mu.Unlock()
+		return err
+	}
+	c.refreshing = make(chan struct{})
+	c.mu.Unlock()
+
+	if wait {
+		return r.doRefreshJWKS(arn, c, 1)
+	}
+	go r.doRefreshJWKS(arn, c, jwksBackgroundAttempts)
+	return nil
+}
+
+func (r *Config) doRefreshJWKS(arn arn.ARN, c *jwksCache, attempts int) (err error) {
+	for i := 0; i < attempts; i++ {
+		if i > 0 {
+			time.Sleep(jwksBackoff(i))
+		}
+		atomic.AddUint64(&globalMetrics.jwksRefreshes, 1)
+		if err = r.PopulatePublicKey(arn); err == nil {
+			break
+		}
+		atomic.AddUint64(&globalMetrics.jwksRefreshFailures, 1)
+	}
+
+	now := time.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lastErr = err
+	if err == nil {
+		c.fetchedAt = now
+		c.failures = 0
+		c.nextAttempt = now.Add(jwksMinRefreshInterval)
+		atomic.StoreInt64(&globalMetrics.lastJWKSRefresh, now.UnixNano())
+	} else {
+		c.failures++
+		c.nextAttempt = now.Add(jwksBackoff(c.failures))
+	}
+	close(c.refreshing)
+	c.refreshing = nil
+	return err
+}
+
+// publicKey returns the key kid of the provider of arn, refreshing the
+// keys when the provider may have rotated them.
+func (r *Config) publicKey(arn arn.ARN, kid string) (interface{}, error) {
+	if pk := r.pubKeys.get(kid); pk != nil {
+		return pk, nil
+	}
+	if err := r.refreshJWKS(arn, true); err != nil {
+		return nil, fmt.Errorf("unable to refresh the JWKS of the identity provider: %w", err)
+	}
+	if pk := r.pubKeys.get(kid); pk != nil {
+		return pk, nil
+	}
+	return nil, errJWKSUnknownKey
+}
+
+var globalMetrics struct {
+	validations        uint64
+	validationFailures uint64
+	validationNanos    uint64
+
+	jwksRefreshes       uint64
+	jwksRefreshFailures uint64
+	lastJWKSRefresh     int64
+}
+
+// Metrics - statistics of the OpenID token validations of this node.
+type Metrics struct {
+	Validations        uint64
+	ValidationFailures uint64
+	// ValidationTime is the total time spent validating tokens.
+	ValidationTime time.Duration
+
+	JWKSRefreshes       uint64
+	JWKSRefreshFailures uint64
+	// LastJWKSRefresh is the time of the last successful JWKS refresh,
+	// zero until the first one.
+	LastJWKSRefresh time.Time
+}
+
+// GetMetrics returns the OpenID token validation statistics.
+func GetMetrics() Metrics {
+	m := Metrics{
+		Validations:         atomic.LoadUint64(&globalMetrics.validations),
+		ValidationFailures:  atomic.LoadUint64(&globalMetrics.validationFailures),
+		ValidationTime:      time.Duration(atomic.LoadUint64(&globalMetrics.validationNanos)),
+		JWKSRefreshes:       atomic.LoadUint64(&globalMetrics.jwksRefreshes),
+		JWKSRefreshFailures: atomic.LoadUint64(&globalMetrics.jwksRefreshFailures),
+	}
+	if t := atomic.LoadInt64(&globalMetrics.lastJWKSRefresh); t > 0 {
+		m.LastJWKSRefresh = time.Unix(0, t)
+	}
+	return m
+}
+
+func updateValidationMetrics(start time.Time, err error) {
+	atomic.AddUint64(&globalMetrics.validations, 1)
+	atomic.AddUint64(&globalMetrics.validationNanos, uint64(time.Since(start)))
+	if err != nil {
+		atomic.AddUint64(&globalMetrics.validationFailures, 1)
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package openid
+
+import (
+	"crypto"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	xnet "github.com/minio/pkg/net"
+	"github.com/qkbyte/minio/internal/arn"
+)
+
+const testJWKS = `{"keys":[{"kty":"RSA","alg":"RS256","kid":"2011-04-29","e":"AQAB","n":"0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw"}]}`
+
+func newTestJWKSConfig(t *testing.T, handler http.HandlerFunc) Config {
+	ts := httptest.NewServer(handler)
+	t.Cleanup(ts.Close)
+
+	u, err := xnet.ParseHTTPURL(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	provider := &providerCfg{jwks: &jwksCache{}}
+	provider.JWKS.URL = u
+	return Config{
+		Enabled: true,
+		pubKeys: publicKeys{
+			RWMutex: &sync.RWMutex{},
+			pkMap:   map[string]crypto.PublicKey{},
+		},
+		arnProviderCfgsMap: map[arn.ARN]*providerCfg{DummyRoleARN: provider},
+		transport:          http.DefaultTransport,
+		closeRespFn:        func(rc io.ReadCloser) { rc.Close() },
+	}
+}
+
+func TestJWKSRefreshOnUnknownKey(t *testing.T) {
+	var hits, down int32
+	cfg := newTestJWKSConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		if atomic.LoadInt32(&down) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		io.WriteString(w, testJWKS)
+	})
+
+	// Unknown keys are fetched on demand.
+	if pk, err := cfg.publicKey(DummyRoleARN, "2011-04-29"); err != nil || pk == nil {
+		t.Fatalf("expected the key to be fetched, got %v", err)
+	}
+	if n := atomic.LoadInt32(&hits); n != 1 {
+		t.Fatalf("expected 1 JWKS request, got %d", n)
+	}
+
+	// Cached keys are used while the IdP is unavailable.
+	atomic.StoreInt32(&down, 1)
+	for i := 0; i < 10; i++ {
+		if _, err := cfg.publicKey(DummyRoleARN, "2011-04-29"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := atomic.LoadInt32(&hits); n != 1 {
+		t.Fatalf("expected cached keys to be used, got %d JWKS requests", n)
+	}
+
+	// Refreshes for unknown keys are rate limited.
+	for i := 0; i < 10; i++ {
+		if _, err := cfg.publicKey(DummyRoleARN, "unknown"); err == nil {
+			t.Fatal("expected unknown key to fail")
+		}
+	}
+	if n := atomic.LoadInt32(&hits); n != 1 {
+		t.Fatalf("expected refreshes to be rate limited, got %d JWKS requests", n)
+	}
+
+	// Failed refreshes back off.
+	c := cfg.arnProviderCfgsMap[DummyRoleARN].jwks
+	c.mu.Lock()
+	c.nextAttempt = time.Time{}
+	c.mu.Unlock()
+	for i := 0; i < 10; i++ {
+		if _, err := cfg.publicKey(DummyRoleARN, "unknown"); err == nil {
+			t.Fatal("expected unknown key to fail")
+		}
+	}
+	if n := atomic.LoadInt32(&hits); n != 2 {
+		t.Fatalf("expected failed refreshes to back off, got %d JWKS requests", n)
+	}
+	c.mu.Lock()
+	failures, lastErr := c.failures, c.lastErr
+	c.mu.Unlock()
+	if failures != 1 || lastErr == nil {
+		t.Fatalf("expected 1 failure, got %d (%v)", failures, lastErr)
+	}
+}
+
+func TestJWKSStaleWhileRevalidate(t *testing.T) {
+	refreshed := make(chan struct{}, 1)
+	cfg := newTestJWKSConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, testJWKS)
+		select {
+		case refreshed <- struct{}{}:
+		default:
+		}
+	})
+	if err := cfg.refreshJWKS(DummyRoleARN, true); err != nil {
+		t.Fatal(err)
+	}
+	<-refreshed
+
+	c := cfg.arnProviderCfgsMap[DummyRoleARN].jwks
+	if c.stale(time.Now()) {
+		t.Fatal("expected freshly fetched keys not to be stale")
+	}
+	c.mu.Lock()
+	c.fetchedAt = time.Now().Add(-2 * jwksRefreshInterval)
+	c.nextAttempt = time.Time{}
+	c.mu.Unlock()
+	if !c.stale(time.Now()) {
+		t.Fatal("expected old keys to be stale")
+	}
+
+	// The background refresh does not block the caller.
+	if err := cfg.refreshJWKS(DummyRoleARN, false); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-refreshed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected a background refresh")
+	}
+	// Wait for the refresh to complete.
+	if err := cfg.refreshJWKS(DummyRoleARN, true); err != nil {
+		t.Fatal(err)
+	}
+	if c.stale(time.Now()) {
+		t.Fatal("expected refreshed keys not to be stale")
+	}
+}
+
+func TestJWKSBackoff(t *testing.T) {
+	for failures := 1; failures < 20; failures++ {
+		d := jwksBackoff(failures)
+		if d < jwksRetryMin/2 || d > jwksRetryMax {
+			t.Fatalf("backoff %s after %d failures out of bounds", d, failures)
+		}
+	}
+	if d := jwksBackoff(20); d < jwksRetryMax/2 {
+		t.Fatalf("expected backoff to reach the maximum, got %s", d)
+	}
+}
@@ -131,7 +131,15 @@ const (
 )
 
 // Validate - validates the id_token.
-func (r *Config) Validate(arn arn.ARN, token, accessToken, dsecs string, claims jwtgo.MapClaims) error {
+func (r *Config) Validate(arn arn.ARN, token, accessToken, dsecs string, claims jwtgo.MapClaims) (err error) {
+	start := time.Now()
+	defer func() {
+		updateValidationMetrics(start, err)
+	}()
+	return r.validate(arn, token, accessToken, dsecs, claims)
+}
+
+func (r *Config) validate(arn arn.ARN, token, accessToken, dsecs string, claims jwtgo.MapClaims) error {
 	jp := new(jwtgo.Parser)
 	jp.ValidMethods = []string{
 		"RS256", "RS384", "RS512", "ES256", "ES384", "ES512",
@@ -143,7 +151,7 @@ func (r *Config) Validate(arn arn.ARN, token, accessToken, dsecs string, claims
 		if !ok {
 			return nil, fmt.Errorf("Invalid kid value %v", jwtToken.Header["kid"])
 		}
-		return r.pubKeys.get(kid), nil
+		return r.publicKey(arn, kid)
 	}
 
 	pCfg, ok := r.arnProviderCfgsMap[arn]
@@ -151,17 +159,15 @@ func (r *Config) Validate(arn arn.ARN, token, accessToken, dsecs string, claims
 		return fmt.Errorf("Role %s does not exist", arn)
 	}
 
+	// Stale keys are refreshed in the background, tokens signed with
+	// keys rotated in meanwhile trigger a refresh when validated.
+	if pCfg.jwks != nil && pCfg.jwks.stale(time.Now()) {
+		r.refreshJWKS(arn, false)
+	}
+
 	jwtToken, err := jp.ParseWithClaims(token, &claims, keyFuncCallback)
 	if err != nil {
-		// Re-populate the public key in-case the JWKS
-		// pubkeys are refreshed
-		if err = r.PopulatePublicKey(arn); err != nil {
-			return err
-		}
-		jwtToken, err = jwtgo.ParseWithClaims(token, &claims, keyFuncCallback)
-		if err != nil {
-			return err
-		}
+		return err
 	}
 
 	if !jwtToken.Valid {
@@ -51,6 +51,9 @@ const (
 	RolePolicy    = "role_policy"
 	DisplayName   = "display_name"
 
+	ClaimUserinfoCacheTTL  = "claim_userinfo_cache_ttl"
+	ClaimUserinfoCacheSize = "claim_userinfo_cache_size"
+
 	Scopes             = "scopes"
 	RedirectURI        = "redirect_uri"
 	RedirectURIDynamic = "redirect_uri_dynamic"
@@ -96,6 +99,14 @@ var (
 			Key:   ClaimUserinfo,
 			Value: "",
 		},
+		config.KV{
+			Key:   ClaimUserinfoCacheTTL,
+			Value: "5m",
+		},
+		config.KV{
+			Key:   ClaimUserinfoCacheSize,
+			Value: "10000",
+		},
 		config.KV{
 			Key:   RolePolicy,
 			Value: "",
@@ -288,6 +299,18 @@ func LookupConfig(s config.Config, transport http.RoundTripper, closeRespFn func
 			return c, errors.New("please specify config_url to enable fetching claims from UserInfo endpoint")
 		}
 
+		if p.ClaimUserinfo {
+			ttl, err := time.ParseDuration(getCfgVal(ClaimUserinfoCacheTTL))
+			if err != nil || ttl < 0 {
+				return c, config.Errorf("invalid %s value %q", ClaimUserinfoCacheTTL, getCfgVal(ClaimUserinfoCacheTTL))
+			}
+			size, err := strconv.Atoi(getCfgVal(ClaimUserinfoCacheSize))
+			if err != nil || size < 0 {
+				return c, config.Errorf("invalid %s value %q", ClaimUserinfoCacheSize, getCfgVal(ClaimUserinfoCacheSize))
+			}
+			p.userInfoCache = newUserInfoCache(ttl, size)
+		}
+
 		if scopeList := getCfgVal(Scopes); scopeList != "" {
 			var scopes []string
 			for _, scope := range strings.Split(scopeList, ",") {
@@ -373,10 +396,11 @@ func LookupConfig(s config.Config, transport http.RoundTripper, closeRespFn func
 			}
 		}
 
+		p.jwks = &jwksCache{}
 		c.arnProviderCfgsMap[arnKey] = &p
 		c.ProviderCfgs[cfgName] = &p
 
-		if err = c.PopulatePublicKey(arnKey); err != nil {
+		if err = c.refreshJWKS(arnKey, true); err != nil {
 			return c, err
 		}
 	}
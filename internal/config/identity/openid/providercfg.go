@@ -50,6 +50,9 @@ type providerCfg struct {
 
 	roleArn  arn.ARN
 	provider provider.Provider
+	jwks     *jwksCache
+
+	userInfoCache *userInfoCache
 }
 
 func newProviderCfgFromConfig(getCfgVal func(cfgName string) string) providerCfg {
@@ -99,11 +102,15 @@ func (p *providerCfg) initializeProvider(cfgGet func(string) string, transport h
 // Some OIDC implementations such as GitLab do not support
 // claims as part of the normal oauth2 flow, instead rely
 // on service providers making calls to IDP to fetch additional
-// claims available from the UserInfo endpoint
+// claims available from the UserInfo endpoint, the claims are
+// cached per access token.
 func (p *providerCfg) UserInfo(accessToken string, transport http.RoundTripper) (map[string]interface{}, error) {
 	if p.JWKS.URL == nil || p.JWKS.URL.String() == "" {
 		return nil, errors.New("openid not configured")
 	}
+	if claims, ok := p.userInfoCache.get(accessToken); ok {
+		return claims, nil
+	}
 	client := &http.Client{
 		Transport: transport,
 	}
@@ -144,5 +151,6 @@ func (p *providerCfg) UserInfo(accessToken string, transport http.RoundTripper)
 		return nil, err
 	}
 
+	p.userInfoCache.set(accessToken, claims)
 	return claims, nil
 }
@@ -0,0 +1,119 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package openid
+
+import (
+	"container/list"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/qkbyte/minio/internal/hash/sha256"
+)
+
+// userInfoCache is a LRU cache of UserInfo claims with a TTL, keyed by
+// the hash of the access token so that tokens are not kept in memory.
+type userInfoCache struct {
+	ttl     time.Duration
+	maxSize int
+
+	mu      sync.Mutex
+	lru     *list.List // of *userInfoEntry, most recently used first
+	entries map[string]*list.Element
+}
+
+type userInfoEntry struct {
+	key     string
+	claims  map[string]interface{}
+	expires time.Time
+}
+
+// newUserInfoCache returns a cache of at most maxSize claims kept for
+// ttl, nil when either is zero which disables caching.
+func newUserInfoCache(ttl time.Duration, maxSize int) *userInfoCache {
+	if ttl <= 0 || maxSize <= 0 {
+		return nil
+	}
+	return &userInfoCache{
+		ttl:     ttl,
+		maxSize: maxSize,
+		lru:     list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+func userInfoCacheKey(accessToken string) string {
+	sum := sha256.Sum256([]byte(accessToken))
+	return hex.EncodeToString(sum[:])
+}
+
+// get returns the cached claims of accessToken, the returned map must
+// not be modified.
+func (c *userInfoCache) get(accessToken string) (map[string]interface{}, bool) {
+	if c == nil {
+		return nil, false
+	}
+	key := userInfoCacheKey(accessToken)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	e := elem.Value.(*userInfoEntry)
+	if time.Now().After(e.expires) {
+		c.lru.Remove(elem)
+		delete(c.entries, key)
+		return nil, false
+	}
+	c.lru.MoveToFront(elem)
+	return e.claims, true
+}
+
+func (c *userInfoCache) set(accessToken string, claims map[string]interface{}) {
+	if c == nil {
+		return
+	}
+	key := userInfoCacheKey(accessToken)
+	expires := time.Now().Add(c.ttl)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.entries[key]; ok {
+		e := elem.Value.(*userInfoEntry)
+		e.claims, e.expires = claims, expires
+		c.lru.MoveToFront(elem)
+		return
+	}
+	for c.lru.Len() >= c.maxSize {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*userInfoEntry).key)
+	}
+	c.entries[key] = c.lru.PushFront(&userInfoEntry{key: key, claims: claims, expires: expires})
+}
+
+func (c *userInfoCache) len() int {
+	if c == nil {
+		return 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package openid
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	xnet "github.com/minio/pkg/net"
+)
+
+func TestUserInfoCache(t *testing.T) {
+	if c := newUserInfoCache(0, 10); c != nil {
+		t.Fatal("expected a zero TTL to disable the cache")
+	}
+	if c := newUserInfoCache(time.Minute, 0); c != nil {
+		t.Fatal("expected a zero size to disable the cache")
+	}
+	var disabled *userInfoCache
+	disabled.set("token", map[string]interface{}{})
+	if _, ok := disabled.get("token"); ok {
+		t.Fatal("expected a disabled cache to miss")
+	}
+
+	c := newUserInfoCache(time.Minute, 2)
+	c.set("token1", map[string]interface{}{"groups": "a"})
+	c.set("token2", map[string]interface{}{"groups": "b"})
+	if claims, ok := c.get("token1"); !ok || claims["groups"] != "a" {
+		t.Fatalf("expected cached claims, got %v", claims)
+	}
+	// token2 is the least recently used entry.
+	c.set("token3", map[string]interface{}{"groups": "c"})
+	if _, ok := c.get("token2"); ok {
+		t.Fatal("expected least recently used entry to be evicted")
+	}
+	if c.len() != 2 {
+		t.Fatalf("expected 2 entries, got %d", c.len())
+	}
+	for _, key := range c.entries {
+		if key.Value.(*userInfoEntry).key == "token1" {
+			t.Fatal("expected access tokens not to be stored")
+		}
+	}
+
+	c = newUserInfoCache(time.Millisecond, 2)
+	c.set("token1", map[string]interface{}{})
+	time.Sleep(5 * time.Millisecond)
+	if _, ok := c.get("token1"); ok {
+		t.Fatal("expected expired entry to miss")
+	}
+	if c.len() != 0 {
+		t.Fatalf("expected expired entry to be removed, got %d entries", c.len())
+	}
+}
+
+func TestUserInfoCached(t *testing.T) {
+	var hits int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		io.WriteString(w, `{"groups":["dev"]}`)
+	}))
+	defer ts.Close()
+
+	u, err := xnet.ParseHTTPURL(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := providerCfg{userInfoCache: newUserInfoCache(time.Minute, 10)}
+	p.JWKS.URL = u
+	p.DiscoveryDoc.UserInfoEndpoint = ts.URL
+
+	for i := 0; i < 3; i++ {
+		if _, err = p.UserInfo("token1", http.DefaultTransport); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err = p.UserInfo("token2", http.DefaultTransport); err != nil {
+		t.Fatal(err)
+	}
+	if n := atomic.LoadInt32(&hits); n != 2 {
+		t.Fatalf("expected 2 UserInfo requests, got %d", n)
+	}
+}
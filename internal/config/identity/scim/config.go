@@ -0,0 +1,129 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package scim
+
+import (
+	"strings"
+
+	"github.com/minio/pkg/env"
+	"github.com/minio/pkg/wildcard"
+	"github.com/qkbyte/minio/internal/config"
+)
+
+const (
+	// EnvIdentitySCIMEnable enables the SCIM 2.0 provisioning API,
+	// it is disabled by default.
+	EnvIdentitySCIMEnable = "MINIO_IDENTITY_SCIM_ENABLE"
+
+	// EnvIdentitySCIMToken is the bearer token identity providers
+	// such as Okta or Azure AD must present to the SCIM API.
+	EnvIdentitySCIMToken = "MINIO_IDENTITY_SCIM_TOKEN"
+
+	// EnvIdentitySCIMDefaultPolicy is the policy attached to every
+	// user provisioned through SCIM.
+	EnvIdentitySCIMDefaultPolicy = "MINIO_IDENTITY_SCIM_DEFAULT_POLICY"
+
+	// EnvIdentitySCIMPolicyMapping maps provisioned groups to policies
+	// as semicolon separated group=policy[,policy...] rules, group
+	// names may use wildcards e.g. "admins=consoleAdmin;eng-*=readwrite".
+	EnvIdentitySCIMPolicyMapping = "MINIO_IDENTITY_SCIM_POLICY_MAPPING"
+)
+
+// minTokenLength is the shortest bearer token accepted, short tokens
+// are trivially guessable.
+const minTokenLength = 16
+
+// PolicyRule maps the groups matching Pattern to Policies.
+type PolicyRule struct {
+	Pattern  string   `json:"pattern"`
+	Policies []string `json:"policies"`
+}
+
+// Config contains the SCIM provisioning configuration.
+type Config struct {
+	Enabled       bool         `json:"enabled"`
+	Token         string       `json:"-"`
+	DefaultPolicy string       `json:"defaultPolicy"`
+	PolicyMapping []PolicyRule `json:"policyMapping"`
+}
+
+// GroupPolicies returns the policies mapped to group by all matching
+// rules, in rule order without duplicates.
+func (c Config) GroupPolicies(group string) []string {
+	var policies []string
+	seen := make(map[string]struct{})
+	for _, rule := range c.PolicyMapping {
+		if !wildcard.MatchSimple(rule.Pattern, group) {
+			continue
+		}
+		for _, p := range rule.Policies {
+			if _, ok := seen[p]; ok {
+				continue
+			}
+			seen[p] = struct{}{}
+			policies = append(policies, p)
+		}
+	}
+	return policies
+}
+
+// ParsePolicyMapping parses semicolon separated group=policy[,policy...]
+// rules.
+func ParsePolicyMapping(s string) ([]PolicyRule, error) {
+	var rules []PolicyRule
+	for _, r := range strings.Split(s, ";") {
+		r = strings.TrimSpace(r)
+		if r == "" {
+			continue
+		}
+		pattern, policies, ok := strings.Cut(r, "=")
+		pattern = strings.TrimSpace(pattern)
+		if !ok || pattern == "" {
+			return nil, config.Errorf("invalid SCIM policy mapping rule %q, expected group=policy", r)
+		}
+		rule := PolicyRule{Pattern: pattern}
+		for _, p := range strings.Split(policies, ",") {
+			if p = strings.TrimSpace(p); p != "" {
+				rule.Policies = append(rule.Policies, p)
+			}
+		}
+		if len(rule.Policies) == 0 {
+			return nil, config.Errorf("SCIM policy mapping rule %q has no policies", r)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// LookupConfig returns the SCIM configuration from the environment.
+func LookupConfig() (cfg Config, err error) {
+	cfg.Enabled, err = config.ParseBool(env.Get(EnvIdentitySCIMEnable, config.EnableOff))
+	if err != nil || !cfg.Enabled {
+		return Config{}, err
+	}
+	cfg.Token = env.Get(EnvIdentitySCIMToken, "")
+	if len(cfg.Token) < minTokenLength {
+		return Config{}, config.Errorf("%s must be set to a token of at least %d characters", EnvIdentitySCIMToken, minTokenLength)
+	}
+	cfg.DefaultPolicy = strings.TrimSpace(env.Get(EnvIdentitySCIMDefaultPolicy, ""))
+	cfg.PolicyMapping, err = ParsePolicyMapping(env.Get(EnvIdentitySCIMPolicyMapping, ""))
+	if err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
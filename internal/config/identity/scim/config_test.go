@@ -0,0 +1,92 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package scim
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParsePolicyMapping(t *testing.T) {
+	testCases := []struct {
+		mapping string
+		rules   []PolicyRule
+		success bool
+	}{
+		{"", nil, true},
+		{"admins=consoleAdmin", []PolicyRule{{Pattern: "admins", Policies: []string{"consoleAdmin"}}}, true},
+		{" eng-*= readwrite , diagnostics ;ops=readonly;", []PolicyRule{
+			{Pattern: "eng-*", Policies: []string{"readwrite", "diagnostics"}},
+			{Pattern: "ops", Policies: []string{"readonly"}},
+		}, true},
+		{"admins", nil, false},
+		{"=readonly", nil, false},
+		{"admins=,", nil, false},
+	}
+	for i, testCase := range testCases {
+		rules, err := ParsePolicyMapping(testCase.mapping)
+		if (err == nil) != testCase.success {
+			t.Fatalf("Test %d: expected success %v, got %v", i+1, testCase.success, err)
+		}
+		if !reflect.DeepEqual(rules, testCase.rules) {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.rules, rules)
+		}
+	}
+}
+
+func TestGroupPolicies(t *testing.T) {
+	rules, err := ParsePolicyMapping("eng-*=readwrite;eng-ops=readwrite,diagnostics;*=readonly")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := Config{PolicyMapping: rules}
+	testCases := []struct {
+		group    string
+		policies []string
+	}{
+		{"eng-ops", []string{"readwrite", "diagnostics", "readonly"}},
+		{"eng-web", []string{"readwrite", "readonly"}},
+		{"sales", []string{"readonly"}},
+	}
+	for i, testCase := range testCases {
+		if policies := cfg.GroupPolicies(testCase.group); !reflect.DeepEqual(policies, testCase.policies) {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.policies, policies)
+		}
+	}
+}
+
+func TestLookupConfig(t *testing.T) {
+	t.Setenv(EnvIdentitySCIMEnable, "on")
+	t.Setenv(EnvIdentitySCIMToken, "short")
+	if _, err := LookupConfig(); err == nil {
+		t.Fatal("expected short tokens to be rejected")
+	}
+	t.Setenv(EnvIdentitySCIMToken, "0123456789abcdef")
+	t.Setenv(EnvIdentitySCIMDefaultPolicy, "readonly")
+	cfg, err := LookupConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cfg.Enabled || cfg.DefaultPolicy != "readonly" {
+		t.Fatalf("unexpected config %+v", cfg)
+	}
+	t.Setenv(EnvIdentitySCIMEnable, "off")
+	if cfg, err = LookupConfig(); err != nil || cfg.Enabled {
+		t.Fatalf("expected disabled config, got %+v, %v", cfg, err)
+	}
+}
@@ -37,6 +37,9 @@ import (
 
 const (
 	formatNamespace = "namespace"
+
+	// PayloadVersion - per target event payload version, common to all notification targets.
+	PayloadVersion = "payload_version"
 )
 
 // ErrTargetsOffline - Indicates single/multiple target failures.
@@ -199,7 +202,7 @@ func fetchSubSysTargets(ctx context.Context, cfg config.Config, subSys string, t
 			targets = append(targets, t)
 		}
 	case config.NotifyRedisSubSys:
-		redisTargets, err := GetNotifyRedis(cfg[config.NotifyRedisSubSys])
+		redisTargets, err := GetNotifyRedis(cfg[config.NotifyRedisSubSys], transport.TLSClientConfig.RootCAs)
 		if err != nil {
 			return nil, err
 		}
@@ -244,11 +247,26 @@ func FetchEnabledTargets(ctx context.Context, cfg config.Config, transport *http
 			if err = targetList.Add(t); err != nil {
 				return nil, err
 			}
+			v, err := lookupPayloadVersion(cfg[subSys], subSys, t.ID().ID)
+			if err != nil {
+				return nil, err
+			}
+			targetList.SetPayloadVersion(t.ID(), v)
 		}
 	}
 	return targetList, nil
 }
 
+// lookupPayloadVersion - returns the configured event payload version of
+// a target, honoring MINIO_NOTIFY_<TARGET>_PAYLOAD_VERSION[_<ID>].
+func lookupPayloadVersion(kvs map[string]config.KVS, subSys, id string) (event.PayloadVersion, error) {
+	envName := config.EnvPrefix + strings.ToUpper(subSys) + config.Default + strings.ToUpper(PayloadVersion)
+	if id != config.Default {
+		envName = envName + config.Default + id
+	}
+	return event.ParsePayloadVersion(env.Get(envName, kvs[id].Get(PayloadVersion)))
+}
+
 // DefaultNotificationKVS - default notification list of kvs.
 var (
 	DefaultNotificationKVS = map[string]config.KVS{
@@ -291,6 +309,10 @@ var (
 			Key:   config.Enable,
 			Value: config.EnableOff,
 		},
+		config.KV{
+			Key:   PayloadVersion,
+			Value: event.PayloadV1.String(),
+		},
 		config.KV{
 			Key:   target.KafkaTopic,
 			Value: "",
@@ -347,6 +369,10 @@ var (
 			Key:   target.KafkaVersion,
 			Value: "",
 		},
+		config.KV{
+			Key:   target.KafkaProxy,
+			Value: "",
+		},
 	}
 )
 
@@ -454,6 +480,12 @@ func GetNotifyKafka(kafkaKVS map[string]config.KVS) (map[string]target.KafkaArgs
 		kafkaArgs.TLS.ClientTLSCert = env.Get(tlsClientTLSCertEnv, kv.Get(target.KafkaClientTLSCert))
 		kafkaArgs.TLS.ClientTLSKey = env.Get(tlsClientTLSKeyEnv, kv.Get(target.KafkaClientTLSKey))
 
+		proxyEnv := target.EnvKafkaProxy
+		if k != config.Default {
+			proxyEnv = proxyEnv + config.Default + k
+		}
+		kafkaArgs.Proxy = env.Get(proxyEnv, kv.Get(target.KafkaProxy))
+
 		saslEnableEnv := target.EnvKafkaSASLEnable
 		if k != config.Default {
 			saslEnableEnv = saslEnableEnv + config.Default + k
@@ -492,6 +524,10 @@ var (
 			Key:   config.Enable,
 			Value: config.EnableOff,
 		},
+		config.KV{
+			Key:   PayloadVersion,
+			Value: event.PayloadV1.String(),
+		},
 		config.KV{
 			Key:   target.MqttBroker,
 			Value: "",
@@ -528,6 +564,26 @@ var (
 			Key:   target.MqttQueueLimit,
 			Value: "0",
 		},
+		config.KV{
+			Key:   target.MqttClientCert,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.MqttClientKey,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.MqttProxy,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.MqttClientID,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.MqttPersistentSession,
+			Value: config.EnableOff,
+		},
 	}
 )
 
@@ -618,6 +674,35 @@ func GetNotifyMQTT(mqttKVS map[string]config.KVS, rootCAs *x509.CertPool) (map[s
 			queueDirEnv = queueDirEnv + config.Default + k
 		}
 
+		clientCertEnv := target.EnvMQTTClientCert
+		if k != config.Default {
+			clientCertEnv = clientCertEnv + config.Default + k
+		}
+
+		clientKeyEnv := target.EnvMQTTClientKey
+		if k != config.Default {
+			clientKeyEnv = clientKeyEnv + config.Default + k
+		}
+
+		proxyEnv := target.EnvMQTTProxy
+		if k != config.Default {
+			proxyEnv = proxyEnv + config.Default + k
+		}
+
+		clientIDEnv := target.EnvMQTTClientID
+		if k != config.Default {
+			clientIDEnv = clientIDEnv + config.Default + k
+		}
+
+		persistentSessionEnv := target.EnvMQTTPersistentSession
+		if k != config.Default {
+			persistentSessionEnv = persistentSessionEnv + config.Default + k
+		}
+		persistentSession, err := config.ParseBool(env.Get(persistentSessionEnv, kv.Get(target.MqttPersistentSession)))
+		if err != nil {
+			return nil, err
+		}
+
 		mqttArgs := target.MQTTArgs{
 			Enable:               enabled,
 			Broker:               *brokerURL,
@@ -630,6 +715,11 @@ func GetNotifyMQTT(mqttKVS map[string]config.KVS, rootCAs *x509.CertPool) (map[s
 			RootCAs:              rootCAs,
 			QueueDir:             env.Get(queueDirEnv, kv.Get(target.MqttQueueDir)),
 			QueueLimit:           queueLimit,
+			ClientCert:           env.Get(clientCertEnv, kv.Get(target.MqttClientCert)),
+			ClientKey:            env.Get(clientKeyEnv, kv.Get(target.MqttClientKey)),
+			Proxy:                env.Get(proxyEnv, kv.Get(target.MqttProxy)),
+			ClientID:             env.Get(clientIDEnv, kv.Get(target.MqttClientID)),
+			PersistentSession:    persistentSession,
 		}
 
 		if err = mqttArgs.Validate(); err != nil {
@@ -647,6 +737,10 @@ var (
 			Key:   config.Enable,
 			Value: config.EnableOff,
 		},
+		config.KV{
+			Key:   PayloadVersion,
+			Value: event.PayloadV1.String(),
+		},
 		config.KV{
 			Key:   target.MySQLFormat,
 			Value: formatNamespace,
@@ -671,6 +765,14 @@ var (
 			Key:   target.MySQLMaxOpenConnections,
 			Value: "2",
 		},
+		config.KV{
+			Key:   target.MySQLBatchSize,
+			Value: "1",
+		},
+		config.KV{
+			Key:   target.MySQLPartition,
+			Value: "",
+		},
 	}
 )
 
@@ -730,6 +832,21 @@ func GetNotifyMySQL(mysqlKVS map[string]config.KVS) (map[string]target.MySQLArgs
 			return nil, cErr
 		}
 
+		batchSizeEnv := target.EnvMySQLBatchSize
+		if k != config.Default {
+			batchSizeEnv = batchSizeEnv + config.Default + k
+		}
+
+		batchSize, cErr := strconv.Atoi(env.Get(batchSizeEnv, kv.Get(target.MySQLBatchSize)))
+		if cErr != nil {
+			return nil, cErr
+		}
+
+		partitionEnv := target.EnvMySQLPartition
+		if k != config.Default {
+			partitionEnv = partitionEnv + config.Default + k
+		}
+
 		mysqlArgs := target.MySQLArgs{
 			Enable:             enabled,
 			Format:             env.Get(formatEnv, kv.Get(target.MySQLFormat)),
@@ -738,6 +855,8 @@ func GetNotifyMySQL(mysqlKVS map[string]config.KVS) (map[string]target.MySQLArgs
 			QueueDir:           env.Get(queueDirEnv, kv.Get(target.MySQLQueueDir)),
 			QueueLimit:         queueLimit,
 			MaxOpenConnections: maxOpenConnections,
+			BatchSize:          batchSize,
+			Partition:          env.Get(partitionEnv, kv.Get(target.MySQLPartition)),
 		}
 		if err = mysqlArgs.Validate(); err != nil {
 			return nil, err
@@ -754,6 +873,10 @@ var (
 			Key:   config.Enable,
 			Value: config.EnableOff,
 		},
+		config.KV{
+			Key:   PayloadVersion,
+			Value: event.PayloadV1.String(),
+		},
 		config.KV{
 			Key:   target.NATSAddress,
 			Value: "",
@@ -794,6 +917,10 @@ var (
 			Key:   target.NATSClientKey,
 			Value: "",
 		},
+		config.KV{
+			Key:   target.NATSProxy,
+			Value: "",
+		},
 		config.KV{
 			Key:   target.NATSPingInterval,
 			Value: "0",
@@ -926,6 +1053,11 @@ func GetNotifyNATS(natsKVS map[string]config.KVS, rootCAs *x509.CertPool) (map[s
 			clientKeyEnv = clientKeyEnv + config.Default + k
 		}
 
+		proxyEnv := target.EnvNATSProxy
+		if k != config.Default {
+			proxyEnv = proxyEnv + config.Default + k
+		}
+
 		jetStreamEnableEnv := target.EnvNATSJetStream
 		if k != config.Default {
 			jetStreamEnableEnv = jetStreamEnableEnv + config.Default + k
@@ -940,6 +1072,7 @@ func GetNotifyNATS(natsKVS map[string]config.KVS, rootCAs *x509.CertPool) (map[s
 			CertAuthority: env.Get(certAuthorityEnv, kv.Get(target.NATSCertAuthority)),
 			ClientCert:    env.Get(clientCertEnv, kv.Get(target.NATSClientCert)),
 			ClientKey:     env.Get(clientKeyEnv, kv.Get(target.NATSClientKey)),
+			Proxy:         env.Get(proxyEnv, kv.Get(target.NATSProxy)),
 			Token:         env.Get(tokenEnv, kv.Get(target.NATSToken)),
 			TLS:           env.Get(tlsEnv, kv.Get(target.NATSTLS)) == config.EnableOn,
 			TLSSkipVerify: env.Get(tlsSkipVerifyEnv, kv.Get(target.NATSTLSSkipVerify)) == config.EnableOn,
@@ -996,6 +1129,10 @@ var (
 			Key:   config.Enable,
 			Value: config.EnableOff,
 		},
+		config.KV{
+			Key:   PayloadVersion,
+			Value: event.PayloadV1.String(),
+		},
 		config.KV{
 			Key:   target.NSQAddress,
 			Value: "",
@@ -1101,6 +1238,10 @@ var (
 			Key:   config.Enable,
 			Value: config.EnableOff,
 		},
+		config.KV{
+			Key:   PayloadVersion,
+			Value: event.PayloadV1.String(),
+		},
 		config.KV{
 			Key:   target.PostgresFormat,
 			Value: formatNamespace,
@@ -1125,6 +1266,14 @@ var (
 			Key:   target.PostgresMaxOpenConnections,
 			Value: "2",
 		},
+		config.KV{
+			Key:   target.PostgresBatchSize,
+			Value: "1",
+		},
+		config.KV{
+			Key:   target.PostgresPartition,
+			Value: "",
+		},
 	}
 )
 
@@ -1185,6 +1334,21 @@ func GetNotifyPostgres(postgresKVS map[string]config.KVS) (map[string]target.Pos
 			return nil, cErr
 		}
 
+		batchSizeEnv := target.EnvPostgresBatchSize
+		if k != config.Default {
+			batchSizeEnv = batchSizeEnv + config.Default + k
+		}
+
+		batchSize, cErr := strconv.Atoi(env.Get(batchSizeEnv, kv.Get(target.PostgresBatchSize)))
+		if cErr != nil {
+			return nil, cErr
+		}
+
+		partitionEnv := target.EnvPostgresPartition
+		if k != config.Default {
+			partitionEnv = partitionEnv + config.Default + k
+		}
+
 		psqlArgs := target.PostgreSQLArgs{
 			Enable:             enabled,
 			Format:             env.Get(formatEnv, kv.Get(target.PostgresFormat)),
@@ -1193,6 +1357,8 @@ func GetNotifyPostgres(postgresKVS map[string]config.KVS) (map[string]target.Pos
 			QueueDir:           env.Get(queueDirEnv, kv.Get(target.PostgresQueueDir)),
 			QueueLimit:         uint64(queueLimit),
 			MaxOpenConnections: maxOpenConnections,
+			BatchSize:          batchSize,
+			Partition:          env.Get(partitionEnv, kv.Get(target.PostgresPartition)),
 		}
 		if err = psqlArgs.Validate(); err != nil {
 			return nil, err
@@ -1210,6 +1376,10 @@ var (
 			Key:   config.Enable,
 			Value: config.EnableOff,
 		},
+		config.KV{
+			Key:   PayloadVersion,
+			Value: event.PayloadV1.String(),
+		},
 		config.KV{
 			Key:   target.RedisFormat,
 			Value: formatNamespace,
@@ -1234,11 +1404,43 @@ var (
 			Key:   target.RedisQueueLimit,
 			Value: "0",
 		},
+		config.KV{
+			Key:   target.RedisUsername,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.RedisMode,
+			Value: target.RedisModeStandalone,
+		},
+		config.KV{
+			Key:   target.RedisSentinelMaster,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.RedisSentinelPassword,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.RedisTLS,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   target.RedisTLSSkipVerify,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   target.RedisStream,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   target.RedisStreamMaxLen,
+			Value: "0",
+		},
 	}
 )
 
 // GetNotifyRedis - returns a map of registered notification 'redis' targets
-func GetNotifyRedis(redisKVS map[string]config.KVS) (map[string]target.RedisArgs, error) {
+func GetNotifyRedis(redisKVS map[string]config.KVS, rootCAs *x509.CertPool) (map[string]target.RedisArgs, error) {
 	redisTargets := make(map[string]target.RedisArgs)
 	for k, kv := range config.Merge(redisKVS, target.EnvRedisEnable, DefaultRedisKVS) {
 		enableEnv := target.EnvRedisEnable
@@ -1258,9 +1460,13 @@ func GetNotifyRedis(redisKVS map[string]config.KVS) (map[string]target.RedisArgs
 		if k != config.Default {
 			addressEnv = addressEnv + config.Default + k
 		}
-		addr, err := xnet.ParseHost(env.Get(addressEnv, kv.Get(target.RedisAddress)))
-		if err != nil {
-			return nil, err
+		var addrs []xnet.Host
+		for _, s := range strings.Split(env.Get(addressEnv, kv.Get(target.RedisAddress)), ",") {
+			addr, err := xnet.ParseHost(strings.TrimSpace(s))
+			if err != nil {
+				return nil, err
+			}
+			addrs = append(addrs, *addr)
 		}
 		queueLimitEnv := target.EnvRedisQueueLimit
 		if k != config.Default {
@@ -1286,14 +1492,60 @@ func GetNotifyRedis(redisKVS map[string]config.KVS) (map[string]target.RedisArgs
 		if k != config.Default {
 			queueDirEnv = queueDirEnv + config.Default + k
 		}
+		usernameEnv := target.EnvRedisUsername
+		if k != config.Default {
+			usernameEnv = usernameEnv + config.Default + k
+		}
+		modeEnv := target.EnvRedisMode
+		if k != config.Default {
+			modeEnv = modeEnv + config.Default + k
+		}
+		sentinelMasterEnv := target.EnvRedisSentinelMaster
+		if k != config.Default {
+			sentinelMasterEnv = sentinelMasterEnv + config.Default + k
+		}
+		sentinelPasswordEnv := target.EnvRedisSentinelPassword
+		if k != config.Default {
+			sentinelPasswordEnv = sentinelPasswordEnv + config.Default + k
+		}
+		tlsEnv := target.EnvRedisTLS
+		if k != config.Default {
+			tlsEnv = tlsEnv + config.Default + k
+		}
+		tlsSkipVerifyEnv := target.EnvRedisTLSSkipVerify
+		if k != config.Default {
+			tlsSkipVerifyEnv = tlsSkipVerifyEnv + config.Default + k
+		}
+		streamEnv := target.EnvRedisStream
+		if k != config.Default {
+			streamEnv = streamEnv + config.Default + k
+		}
+		streamMaxLenEnv := target.EnvRedisStreamMaxLen
+		if k != config.Default {
+			streamMaxLenEnv = streamMaxLenEnv + config.Default + k
+		}
+		streamMaxLen, err := strconv.ParseUint(env.Get(streamMaxLenEnv, kv.Get(target.RedisStreamMaxLen)), 10, 64)
+		if err != nil {
+			return nil, err
+		}
 		redisArgs := target.RedisArgs{
-			Enable:     enabled,
-			Format:     env.Get(formatEnv, kv.Get(target.RedisFormat)),
-			Addr:       *addr,
-			Password:   env.Get(passwordEnv, kv.Get(target.RedisPassword)),
-			Key:        env.Get(keyEnv, kv.Get(target.RedisKey)),
-			QueueDir:   env.Get(queueDirEnv, kv.Get(target.RedisQueueDir)),
-			QueueLimit: uint64(queueLimit),
+			Enable:           enabled,
+			Format:           env.Get(formatEnv, kv.Get(target.RedisFormat)),
+			Addr:             addrs[0],
+			Addrs:            addrs,
+			Password:         env.Get(passwordEnv, kv.Get(target.RedisPassword)),
+			Key:              env.Get(keyEnv, kv.Get(target.RedisKey)),
+			QueueDir:         env.Get(queueDirEnv, kv.Get(target.RedisQueueDir)),
+			QueueLimit:       uint64(queueLimit),
+			Username:         env.Get(usernameEnv, kv.Get(target.RedisUsername)),
+			Mode:             env.Get(modeEnv, kv.Get(target.RedisMode)),
+			SentinelMaster:   env.Get(sentinelMasterEnv, kv.Get(target.RedisSentinelMaster)),
+			SentinelPassword: env.Get(sentinelPasswordEnv, kv.Get(target.RedisSentinelPassword)),
+			TLS:              env.Get(tlsEnv, kv.Get(target.RedisTLS)) == config.EnableOn,
+			TLSSkipVerify:    env.Get(tlsSkipVerifyEnv, kv.Get(target.RedisTLSSkipVerify)) == config.EnableOn,
+			RootCAs:          rootCAs,
+			Stream:           env.Get(streamEnv, kv.Get(target.RedisStream)) == config.EnableOn,
+			StreamMaxLen:     streamMaxLen,
 		}
 		if err = redisArgs.Validate(); err != nil {
 			return nil, err
@@ -1310,6 +1562,10 @@ var (
 			Key:   config.Enable,
 			Value: config.EnableOff,
 		},
+		config.KV{
+			Key:   PayloadVersion,
+			Value: event.PayloadV1.String(),
+		},
 		config.KV{
 			Key:   target.WebhookEndpoint,
 			Value: "",
@@ -1334,6 +1590,10 @@ var (
 			Key:   target.WebhookClientKey,
 			Value: "",
 		},
+		config.KV{
+			Key:   target.WebhookProxy,
+			Value: "",
+		},
 	}
 )
 
@@ -1388,6 +1648,11 @@ func GetNotifyWebhook(webhookKVS map[string]config.KVS, transport *http.Transpor
 			clientKeyEnv = clientKeyEnv + config.Default + k
 		}
 
+		proxyEnv := target.EnvWebhookProxy
+		if k != config.Default {
+			proxyEnv = proxyEnv + config.Default + k
+		}
+
 		webhookArgs := target.WebhookArgs{
 			Enable:     enabled,
 			Endpoint:   *url,
@@ -1397,6 +1662,7 @@ func GetNotifyWebhook(webhookKVS map[string]config.KVS, transport *http.Transpor
 			QueueLimit: uint64(queueLimit),
 			ClientCert: env.Get(clientCertEnv, kv.Get(target.WebhookClientCert)),
 			ClientKey:  env.Get(clientKeyEnv, kv.Get(target.WebhookClientKey)),
+			Proxy:      env.Get(proxyEnv, kv.Get(target.WebhookProxy)),
 		}
 		if err = webhookArgs.Validate(); err != nil {
 			return nil, err
@@ -1413,6 +1679,10 @@ var (
 			Key:   config.Enable,
 			Value: config.EnableOff,
 		},
+		config.KV{
+			Key:   PayloadVersion,
+			Value: event.PayloadV1.String(),
+		},
 		config.KV{
 			Key:   target.ElasticURL,
 			Value: "",
@@ -1441,6 +1711,18 @@ var (
 			Key:   target.ElasticPassword,
 			Value: "",
 		},
+		config.KV{
+			Key:   target.ElasticClientCert,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.ElasticClientKey,
+			Value: "",
+		},
+		config.KV{
+			Key:   target.ElasticProxy,
+			Value: "",
+		},
 	}
 )
 
@@ -1505,6 +1787,21 @@ func GetNotifyES(esKVS map[string]config.KVS, transport *http.Transport) (map[st
 			passwordEnv = passwordEnv + config.Default + k
 		}
 
+		clientCertEnv := target.EnvElasticClientCert
+		if k != config.Default {
+			clientCertEnv = clientCertEnv + config.Default + k
+		}
+
+		clientKeyEnv := target.EnvElasticClientKey
+		if k != config.Default {
+			clientKeyEnv = clientKeyEnv + config.Default + k
+		}
+
+		proxyEnv := target.EnvElasticProxy
+		if k != config.Default {
+			proxyEnv = proxyEnv + config.Default + k
+		}
+
 		esArgs := target.ElasticsearchArgs{
 			Enable:     enabled,
 			Format:     env.Get(formatEnv, kv.Get(target.ElasticFormat)),
@@ -1515,6 +1812,9 @@ func GetNotifyES(esKVS map[string]config.KVS, transport *http.Transport) (map[st
 			Transport:  transport,
 			Username:   env.Get(usernameEnv, kv.Get(target.ElasticUsername)),
 			Password:   env.Get(passwordEnv, kv.Get(target.ElasticPassword)),
+			ClientCert: env.Get(clientCertEnv, kv.Get(target.ElasticClientCert)),
+			ClientKey:  env.Get(clientKeyEnv, kv.Get(target.ElasticClientKey)),
+			Proxy:      env.Get(proxyEnv, kv.Get(target.ElasticProxy)),
 		}
 		if err = esArgs.Validate(); err != nil {
 			return nil, err
@@ -1531,6 +1831,10 @@ var (
 			Key:   config.Enable,
 			Value: config.EnableOff,
 		},
+		config.KV{
+			Key:   PayloadVersion,
+			Value: event.PayloadV1.String(),
+		},
 		config.KV{
 			Key:   target.AmqpURL,
 			Value: "",
@@ -44,5 +44,23 @@ var (
 			Optional:    true,
 			Type:        "duration",
 		},
+		config.HelpKV{
+			Key:         IOClass,
+			Description: `kernel I/O class for scanner drive I/O on Linux, one of "none", "best-effort", "idle"` + defaultHelpPostfix(IOClass),
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         LifecycleWorkers,
+			Description: `number of workers evaluating lifecycle rules on scanned objects, 0 to evaluate them on the scanner` + defaultHelpPostfix(LifecycleWorkers),
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         ReplicationWorkers,
+			Description: `number of workers queuing replication heal of scanned objects, 0 to queue them on the scanner` + defaultHelpPostfix(ReplicationWorkers),
+			Optional:    true,
+			Type:        "number",
+		},
 	}
 )
@@ -18,11 +18,13 @@
 package scanner
 
 import (
+	"errors"
 	"strconv"
 	"time"
 
 	"github.com/minio/pkg/env"
 	"github.com/qkbyte/minio/internal/config"
+	"github.com/qkbyte/minio/internal/ioprio"
 )
 
 // Compression environment variables
@@ -30,12 +32,20 @@ const (
 	Delay   = "delay"
 	MaxWait = "max_wait"
 	Cycle   = "cycle"
+	IOClass = "io_class"
+
+	LifecycleWorkers   = "lifecycle_workers"
+	ReplicationWorkers = "replication_workers"
 
 	EnvDelay         = "MINIO_SCANNER_DELAY"
 	EnvCycle         = "MINIO_SCANNER_CYCLE"
+	EnvIOClass       = "MINIO_SCANNER_IO_CLASS"
 	EnvDelayLegacy   = "MINIO_CRAWLER_DELAY"
 	EnvMaxWait       = "MINIO_SCANNER_MAX_WAIT"
 	EnvMaxWaitLegacy = "MINIO_CRAWLER_MAX_WAIT"
+
+	EnvLifecycleWorkers   = "MINIO_SCANNER_LIFECYCLE_WORKERS"
+	EnvReplicationWorkers = "MINIO_SCANNER_REPLICATION_WORKERS"
 )
 
 // Config represents the heal settings.
@@ -46,6 +56,14 @@ type Config struct {
 	MaxWait time.Duration
 	// Cycle is the time.Duration between each scanner cycles
 	Cycle time.Duration
+	// IOClass is the kernel I/O class scanner drive I/O runs under
+	IOClass ioprio.Class
+	// LifecycleWorkers evaluate lifecycle rules on scanned objects,
+	// 0 evaluates them on the scanner itself.
+	LifecycleWorkers int
+	// ReplicationWorkers queue replication heal of scanned objects,
+	// 0 queues them from the scanner itself.
+	ReplicationWorkers int
 }
 
 // DefaultKVS - default KV config for heal settings
@@ -62,6 +80,18 @@ var DefaultKVS = config.KVS{
 		Key:   Cycle,
 		Value: "1m",
 	},
+	config.KV{
+		Key:   IOClass,
+		Value: "none",
+	},
+	config.KV{
+		Key:   LifecycleWorkers,
+		Value: "0",
+	},
+	config.KV{
+		Key:   ReplicationWorkers,
+		Value: "0",
+	},
 }
 
 // LookupConfig - lookup config and override with valid environment settings if any.
@@ -90,5 +120,28 @@ func LookupConfig(kvs config.KVS) (cfg Config, err error) {
 	if err != nil {
 		return cfg, err
 	}
+	cfg.IOClass, err = ioprio.Parse(env.Get(EnvIOClass, kvs.GetWithDefault(IOClass, DefaultKVS)))
+	if err != nil {
+		return cfg, err
+	}
+	cfg.LifecycleWorkers, err = parseWorkers(env.Get(EnvLifecycleWorkers, kvs.GetWithDefault(LifecycleWorkers, DefaultKVS)))
+	if err != nil {
+		return cfg, err
+	}
+	cfg.ReplicationWorkers, err = parseWorkers(env.Get(EnvReplicationWorkers, kvs.GetWithDefault(ReplicationWorkers, DefaultKVS)))
+	if err != nil {
+		return cfg, err
+	}
 	return cfg, nil
 }
+
+func parseWorkers(v string) (int, error) {
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, err
+	}
+	if n < 0 {
+		return 0, errors.New("number of workers cannot be negative")
+	}
+	return n, nil
+}
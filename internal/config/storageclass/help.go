@@ -38,6 +38,18 @@ var (
 			Optional:    true,
 			Type:        "string",
 		},
+		config.HelpKV{
+			Key:         StandardInlineThreshold,
+			Description: `inline objects whose per drive shard is smaller than this in xl.meta for standard storage class e.g. "64KiB"`,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         RRSInlineThreshold,
+			Description: `inline objects whose per drive shard is smaller than this in xl.meta for reduced redundancy storage class e.g. "64KiB"`,
+			Optional:    true,
+			Type:        "string",
+		},
 		config.HelpKV{
 			Key:         config.Comment,
 			Description: config.DefaultComment,
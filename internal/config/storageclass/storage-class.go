@@ -24,6 +24,7 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/dustin/go-humanize"
 	"github.com/minio/pkg/env"
 	"github.com/qkbyte/minio/internal/config"
 )
@@ -41,11 +42,23 @@ const (
 	ClassStandard = "standard"
 	ClassRRS      = "rrs"
 
+	// Inline data thresholds per storage class
+	StandardInlineThreshold = "standard_inline_threshold"
+	RRSInlineThreshold      = "rrs_inline_threshold"
+
 	// Reduced redundancy storage class environment variable
 	RRSEnv = "MINIO_STORAGE_CLASS_RRS"
 	// Standard storage class environment variable
 	StandardEnv = "MINIO_STORAGE_CLASS_STANDARD"
 
+	// Inline data threshold environment variables
+	StandardInlineThresholdEnv = "MINIO_STORAGE_CLASS_STANDARD_INLINE_THRESHOLD"
+	RRSInlineThresholdEnv      = "MINIO_STORAGE_CLASS_RRS_INLINE_THRESHOLD"
+
+	// MaxInlineThreshold is the largest per shard size that
+	// may be configured to be inlined in xl.meta
+	MaxInlineThreshold = 1 * humanize.MiByte
+
 	// Supported storage class scheme is EC
 	schemePrefix = "EC"
 
@@ -67,6 +80,14 @@ var (
 			Key:   ClassRRS,
 			Value: "EC:1",
 		},
+		config.KV{
+			Key:   StandardInlineThreshold,
+			Value: "",
+		},
+		config.KV{
+			Key:   RRSInlineThreshold,
+			Value: "",
+		},
 	}
 )
 
@@ -82,6 +103,11 @@ var ConfigLock sync.RWMutex
 type Config struct {
 	Standard StorageClass `json:"standard"`
 	RRS      StorageClass `json:"rrs"`
+
+	// Shard sizes below which object data is inlined in
+	// xl.meta, zero means the server default.
+	StandardInline int64 `json:"-"`
+	RRSInline      int64 `json:"-"`
 }
 
 // UnmarshalJSON - Validate SS and RRS parity when unmarshalling JSON.
@@ -236,19 +262,51 @@ func (sCfg Config) GetParityForSC(sc string) (parity int) {
 	}
 }
 
+// GetInlineThresholdForSC - returns the configured inline data
+// threshold for the storage class, zero is returned when the
+// server default applies.
+func (sCfg Config) GetInlineThresholdForSC(sc string) int64 {
+	ConfigLock.RLock()
+	defer ConfigLock.RUnlock()
+	switch strings.TrimSpace(sc) {
+	case RRS:
+		return sCfg.RRSInline
+	default:
+		return sCfg.StandardInline
+	}
+}
+
 // Update update storage-class with new config
 func (sCfg *Config) Update(newCfg Config) {
 	ConfigLock.Lock()
 	defer ConfigLock.Unlock()
 	sCfg.RRS = newCfg.RRS
 	sCfg.Standard = newCfg.Standard
+	sCfg.RRSInline = newCfg.RRSInline
+	sCfg.StandardInline = newCfg.StandardInline
 }
 
 // Enabled returns if etcd is enabled.
 func Enabled(kvs config.KVS) bool {
 	ssc := kvs.Get(ClassStandard)
 	rrsc := kvs.Get(ClassRRS)
-	return ssc != "" || rrsc != ""
+	return ssc != "" || rrsc != "" ||
+		kvs.Get(StandardInlineThreshold) != "" || kvs.Get(RRSInlineThreshold) != ""
+}
+
+// parseInlineThreshold parses an inline data threshold such as "64KiB".
+func parseInlineThreshold(v string) (int64, error) {
+	if v == "" {
+		return 0, nil
+	}
+	threshold, err := humanize.ParseBytes(v)
+	if err != nil {
+		return 0, config.ErrStorageClassValue(err).Msg("Invalid inline threshold " + v)
+	}
+	if threshold > MaxInlineThreshold {
+		return 0, config.ErrStorageClassValue(nil).Msg(fmt.Sprintf("Inline threshold %s exceeds the maximum of %s", v, humanize.IBytes(MaxInlineThreshold)))
+	}
+	return int64(threshold), nil
 }
 
 // DefaultParityBlocks returns default parity blocks for 'drive' count
@@ -301,6 +359,15 @@ func LookupConfig(kvs config.KVS, setDriveCount int) (cfg Config, err error) {
 		}
 	}
 
+	cfg.StandardInline, err = parseInlineThreshold(env.Get(StandardInlineThresholdEnv, kvs.Get(StandardInlineThreshold)))
+	if err != nil {
+		return Config{}, err
+	}
+	cfg.RRSInline, err = parseInlineThreshold(env.Get(RRSInlineThresholdEnv, kvs.Get(RRSInlineThreshold)))
+	if err != nil {
+		return Config{}, err
+	}
+
 	// Validation is done after parsing both the storage classes. This is needed because we need one
 	// storage class value to deduce the correct value of the other storage class.
 	if err = validateParity(cfg.Standard.Parity, cfg.RRS.Parity, setDriveCount); err != nil {
@@ -187,3 +187,27 @@ func TestIsValidStorageClassKind(t *testing.T) {
 		}
 	}
 }
+
+func TestParseInlineThreshold(t *testing.T) {
+	tests := []struct {
+		threshold string
+		want      int64
+		wantErr   bool
+	}{
+		{"", 0, false},
+		{"64KiB", 64 << 10, false},
+		{"1MiB", 1 << 20, false},
+		{"2MiB", 0, true},
+		{"sixty", 0, true},
+	}
+	for i, tt := range tests {
+		got, err := parseInlineThreshold(tt.threshold)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Test %d, Expected error %t, got %v", i+1, tt.wantErr, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Test %d, Expected threshold %d, got %d", i+1, tt.want, got)
+		}
+	}
+}
@@ -17,6 +17,12 @@
 
 package disk
 
+import "errors"
+
+// ErrSerialNotFound is returned when the serial number of
+// the drive backing a path cannot be determined.
+var ErrSerialNotFound = errors.New("drive serial number not found")
+
 // Info stat fs struct is container which holds following values
 // Total - total size of the volume / disk
 // Free - free size of the volume / disk
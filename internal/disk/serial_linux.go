@@ -0,0 +1,66 @@
+//go:build linux
+// +build linux
+
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package disk
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// GetSerial returns the serial number (or WWID when the serial is
+// not exposed) of the physical drive backing the given path, as
+// reported by sysfs.
+func GetSerial(path string) (string, error) {
+	st := syscall.Stat_t{}
+	if err := syscall.Stat(path, &st); err != nil {
+		return "", err
+	}
+	//nolint:unconvert
+	devID := uint64(st.Dev) // Needed to support multiple GOARCHs
+	devPath, err := filepath.EvalSymlinks(fmt.Sprintf("/sys/dev/block/%d:%d", unix.Major(devID), unix.Minor(devID)))
+	if err != nil {
+		return "", err
+	}
+
+	// Partitions do not carry device attributes, look at the parent.
+	candidates := []string{devPath}
+	if _, err = os.Stat(filepath.Join(devPath, "partition")); err == nil {
+		candidates = append(candidates, filepath.Dir(devPath))
+	}
+
+	for _, dir := range candidates {
+		for _, attr := range []string{"device/serial", "serial", "device/wwid", "wwid"} {
+			b, err := os.ReadFile(filepath.Join(dir, attr))
+			if err != nil {
+				continue
+			}
+			if serial := strings.TrimSpace(string(b)); serial != "" {
+				return serial, nil
+			}
+		}
+	}
+	return "", ErrSerialNotFound
+}
@@ -20,9 +20,11 @@ package event
 import (
 	"encoding/xml"
 	"errors"
+	"fmt"
 	"io"
 	"reflect"
 	"strings"
+	"time"
 	"unicode/utf8"
 
 	"github.com/minio/minio-go/v7/pkg/set"
@@ -159,10 +161,62 @@ type common struct {
 	Events []Name `xml:"Event" json:"Event"`
 }
 
+// Synchronous failure policies.
+const (
+	// SyncFailRequest fails the request when the event is not delivered.
+	SyncFailRequest = "fail"
+	// SyncIgnoreFailure only logs the delivery failure.
+	SyncIgnoreFailure = "ignore"
+
+	// DefaultSyncTimeout is the default delivery timeout of synchronous events.
+	DefaultSyncTimeout = 5 * time.Second
+	maxSyncTimeout     = time.Minute
+)
+
+// Synchronous - MinIO extension to deliver the events of a queue
+// configuration before the response to the request is returned.
+type Synchronous struct {
+	Timeout   string `xml:"Timeout,omitempty" json:"Timeout,omitempty"`
+	OnFailure string `xml:"OnFailure,omitempty" json:"OnFailure,omitempty"`
+}
+
+// Validate - checks whether synchronous delivery has valid values or not.
+func (s Synchronous) Validate() error {
+	if s.Timeout != "" {
+		timeout, err := time.ParseDuration(s.Timeout)
+		if err != nil || timeout <= 0 || timeout > maxSyncTimeout {
+			return &ErrInvalidSynchronous{fmt.Sprintf("timeout '%v' must be a duration up to %v", s.Timeout, maxSyncTimeout)}
+		}
+	}
+	switch s.OnFailure {
+	case "", SyncFailRequest, SyncIgnoreFailure:
+	default:
+		return &ErrInvalidSynchronous{fmt.Sprintf("failure policy '%v' must be either %v or %v", s.OnFailure, SyncFailRequest, SyncIgnoreFailure)}
+	}
+	return nil
+}
+
+// TimeoutDuration - returns the delivery timeout.
+func (s Synchronous) TimeoutDuration() time.Duration {
+	timeout, err := time.ParseDuration(s.Timeout)
+	if err != nil || timeout <= 0 {
+		return DefaultSyncTimeout
+	}
+	return timeout
+}
+
+// FailRequest - returns whether a delivery failure fails the request.
+func (s Synchronous) FailRequest() bool {
+	return s.OnFailure != SyncIgnoreFailure
+}
+
 // Queue - represents elements inside <QueueConfiguration>
 type Queue struct {
 	common
 	ARN ARN `xml:"Queue"`
+
+	// Sync is set when the events are delivered synchronously.
+	Sync *Synchronous `xml:"Synchronous,omitempty" json:"Synchronous,omitempty"`
 }
 
 // UnmarshalXML - decodes XML data.
@@ -187,6 +241,12 @@ func (q *Queue) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 		eventStringSet.Add(eventName.String())
 	}
 
+	if parsedQueue.Sync != nil {
+		if err := parsedQueue.Sync.Validate(); err != nil {
+			return err
+		}
+	}
+
 	*q = Queue(parsedQueue)
 
 	return nil
@@ -194,14 +254,7 @@ func (q *Queue) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 
 // Validate - checks whether queue has valid values or not.
 func (q Queue) Validate(region string, targetList *TargetList) error {
-	if q.ARN.region == "" {
-		if !targetList.Exists(q.ARN.TargetID) {
-			return &ErrARNNotFound{q.ARN}
-		}
-		return nil
-	}
-
-	if region != "" && q.ARN.region != region {
+	if q.ARN.region != "" && region != "" && q.ARN.region != region {
 		return &ErrUnknownRegion{q.ARN.region}
 	}
 
@@ -209,6 +262,10 @@ func (q Queue) Validate(region string, targetList *TargetList) error {
 		return &ErrARNNotFound{q.ARN}
 	}
 
+	if q.Sync != nil && !targetList.SupportsSync(q.ARN.TargetID) {
+		return &ErrInvalidSynchronous{fmt.Sprintf("target %v does not support synchronous delivery", q.ARN.TargetID)}
+	}
+
 	return nil
 }
 
@@ -294,17 +351,33 @@ func (conf *Config) SetRegion(region string) {
 	}
 }
 
-// ToRulesMap - converts all queue configuration to RulesMap.
+// ToRulesMap - converts all queue configuration to RulesMap,
+// synchronous queue configurations are not included.
 func (conf *Config) ToRulesMap() RulesMap {
 	rulesMap := make(RulesMap)
 
 	for _, queue := range conf.QueueList {
+		if queue.Sync != nil {
+			continue
+		}
 		rulesMap.Add(queue.ToRulesMap())
 	}
 
 	return rulesMap
 }
 
+// SyncQueues - returns the synchronous queue configurations
+// matching the event name and the object name.
+func (conf *Config) SyncQueues(eventName Name, objectName string) []Queue {
+	var queues []Queue
+	for _, queue := range conf.QueueList {
+		if queue.Sync != nil && queue.ToRulesMap().MatchSimple(eventName, objectName) {
+			queues = append(queues, queue)
+		}
+	}
+	return queues
+}
+
 // ParseConfig - parses data in reader to notification configuration.
 func ParseConfig(reader io.Reader, region string, targetList *TargetList) (*Config, error) {
 	var config Config
@@ -22,6 +22,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestValidateFilterRuleValue(t *testing.T) {
@@ -251,6 +252,19 @@ func TestQueueValidate(t *testing.T) {
 		panic(err)
 	}
 
+	data = []byte(`
+<QueueConfiguration>
+   <Id>1</Id>
+   <Filter></Filter>
+   <Queue>arn:minio:sqs:us-east-1:1:webhook</Queue>
+   <Event>s3:ObjectCreated:*</Event>
+   <Synchronous><Timeout>2s</Timeout></Synchronous>
+</QueueConfiguration>`)
+	queue4 := &Queue{}
+	if err := xml.Unmarshal(data, queue4); err != nil {
+		panic(err)
+	}
+
 	targetList1 := NewTargetList()
 
 	targetList2 := NewTargetList()
@@ -258,6 +272,11 @@ func TestQueueValidate(t *testing.T) {
 		panic(err)
 	}
 
+	targetList3 := NewTargetList()
+	if err := targetList3.Add(syncTarget{ExampleTarget{id: TargetID{"1", "webhook"}}, nil}); err != nil {
+		panic(err)
+	}
+
 	testCases := []struct {
 		queue      *Queue
 		region     string
@@ -268,6 +287,8 @@ func TestQueueValidate(t *testing.T) {
 		{queue2, "us-east-1", targetList1, true},
 		{queue3, "", targetList2, false},
 		{queue2, "us-east-1", targetList2, false},
+		{queue4, "us-east-1", targetList2, true},
+		{queue4, "us-east-1", targetList3, false},
 	}
 
 	for i, testCase := range testCases {
@@ -959,3 +980,70 @@ func TestParseConfig(t *testing.T) {
 		}
 	}
 }
+
+func TestConfigSyncQueues(t *testing.T) {
+	data := []byte(`
+<NotificationConfiguration  xmlns="http://s3.amazonaws.com/doc/2006-03-01/">
+   <QueueConfiguration>
+      <Id>1</Id>
+      <Filter></Filter>
+      <Queue>arn:minio:sqs:us-east-1:1:webhook</Queue>
+      <Event>s3:ObjectRemoved:*</Event>
+   </QueueConfiguration>
+   <QueueConfiguration>
+      <Id>2</Id>
+      <Filter>
+          <S3Key>
+              <FilterRule>
+                  <Name>prefix</Name>
+                  <Value>images/</Value>
+              </FilterRule>
+          </S3Key>
+      </Filter>
+      <Queue>arn:minio:sqs:us-east-1:1:webhook</Queue>
+      <Event>s3:ObjectCreated:*</Event>
+      <Synchronous>
+          <Timeout>2s</Timeout>
+          <OnFailure>ignore</OnFailure>
+      </Synchronous>
+   </QueueConfiguration>
+</NotificationConfiguration>
+`)
+	config := &Config{}
+	if err := xml.Unmarshal(data, config); err != nil {
+		t.Fatal(err)
+	}
+
+	rulesMap := config.ToRulesMap()
+	if rulesMap.MatchSimple(ObjectCreatedPut, "images/a.jpg") {
+		t.Fatal("synchronous queue must not be part of the rules map")
+	}
+	if !rulesMap.MatchSimple(ObjectRemovedDelete, "images/a.jpg") {
+		t.Fatal("expected asynchronous queue in the rules map")
+	}
+
+	queues := config.SyncQueues(ObjectCreatedPut, "images/a.jpg")
+	if len(queues) != 1 {
+		t.Fatalf("expected 1 synchronous queue, got %d", len(queues))
+	}
+	if timeout := queues[0].Sync.TimeoutDuration(); timeout != 2*time.Second {
+		t.Fatalf("unexpected timeout %v", timeout)
+	}
+	if queues[0].Sync.FailRequest() {
+		t.Fatal("expected failure to be ignored")
+	}
+	if queues = config.SyncQueues(ObjectCreatedPut, "docs/a.txt"); len(queues) != 0 {
+		t.Fatalf("expected no synchronous queue, got %d", len(queues))
+	}
+
+	for _, sync := range []string{
+		`<Synchronous><Timeout>2h</Timeout></Synchronous>`,
+		`<Synchronous><Timeout>abc</Timeout></Synchronous>`,
+		`<Synchronous><OnFailure>retry</OnFailure></Synchronous>`,
+	} {
+		data := []byte(`<QueueConfiguration><Id>1</Id><Filter></Filter><Queue>arn:minio:sqs:us-east-1:1:webhook</Queue><Event>s3:ObjectCreated:Put</Event>` + sync + `</QueueConfiguration>`)
+		if err := xml.Unmarshal(data, &Queue{}); err == nil {
+			t.Fatalf("expected error for %s", sync)
+		}
+	}
+}
@@ -47,6 +47,8 @@ func IsEventError(err error) bool {
 		return true
 	case ErrInvalidEventName, *ErrInvalidEventName:
 		return true
+	case ErrInvalidSynchronous, *ErrInvalidSynchronous:
+		return true
 	}
 
 	return false
@@ -151,3 +153,12 @@ type ErrInvalidEventName struct {
 func (err ErrInvalidEventName) Error() string {
 	return fmt.Sprintf("invalid event name '%v'", err.Name)
 }
+
+// ErrInvalidSynchronous - invalid synchronous delivery error.
+type ErrInvalidSynchronous struct {
+	Reason string
+}
+
+func (err ErrInvalidSynchronous) Error() string {
+	return fmt.Sprintf("invalid synchronous delivery: %v", err.Reason)
+}
@@ -18,6 +18,9 @@
 package event
 
 import (
+	"fmt"
+	"strconv"
+
 	"github.com/minio/madmin-go"
 )
 
@@ -32,6 +35,34 @@ const (
 	AMZTimeFormat = "2006-01-02T15:04:05.000Z"
 )
 
+// PayloadVersion - version of the event record payload delivered to a target.
+type PayloadVersion int
+
+const (
+	// PayloadV1 - AWS S3 compatible event records, the default.
+	PayloadV1 PayloadVersion = 1
+
+	// PayloadV2 - event records enriched with object tags, storage class
+	// and replication status.
+	PayloadV2 PayloadVersion = 2
+)
+
+// ParsePayloadVersion - parses payload version string, empty value defaults to PayloadV1.
+func ParsePayloadVersion(s string) (PayloadVersion, error) {
+	switch s {
+	case "", "1":
+		return PayloadV1, nil
+	case "2":
+		return PayloadV2, nil
+	}
+	return 0, fmt.Errorf("invalid payload version '%s', expected one of [1, 2]", s)
+}
+
+// String - returns the payload version as configured.
+func (v PayloadVersion) String() string {
+	return strconv.Itoa(int(v))
+}
+
 // Identity represents access key who caused the event.
 type Identity struct {
 	PrincipalID string `json:"principalId"`
@@ -53,6 +84,11 @@ type Object struct {
 	UserMetadata map[string]string `json:"userMetadata,omitempty"`
 	VersionID    string            `json:"versionId,omitempty"`
 	Sequencer    string            `json:"sequencer"`
+
+	// Only populated in PayloadV2 records.
+	Tags              map[string]string `json:"tags,omitempty"`
+	StorageClass      string            `json:"storageClass,omitempty"`
+	ReplicationStatus string            `json:"replicationStatus,omitempty"`
 }
 
 // Metadata represents event metadata.
@@ -86,6 +122,19 @@ type Event struct {
 	Type              madmin.TraceType  `json:"-"`
 }
 
+// WithPayloadVersion returns the event as delivered with the given payload
+// version, PayloadV1 records do not carry the enriched object fields.
+func (e Event) WithPayloadVersion(v PayloadVersion) Event {
+	if v == PayloadV2 {
+		e.S3.SchemaVersion = "2.0"
+		return e
+	}
+	e.S3.Object.Tags = nil
+	e.S3.Object.StorageClass = ""
+	e.S3.Object.ReplicationStatus = ""
+	return e
+}
+
 // Mask returns the type as mask.
 func (e Event) Mask() uint64 {
 	return e.EventName.Mask()
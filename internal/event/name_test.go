@@ -38,6 +38,7 @@ func TestNameExpand(t *testing.T) {
 		}},
 		{ObjectRemovedAll, []Name{ObjectRemovedDelete, ObjectRemovedDeleteMarkerCreated}},
 		{ObjectAccessedHead, []Name{ObjectAccessedHead}},
+		{ObjectRejectedAll, []Name{ObjectRejectedStorageFull}},
 	}
 
 	for i, testCase := range testCases {
@@ -72,6 +73,7 @@ func TestNameString(t *testing.T) {
 		{ObjectCreatedPutLegalHold, "s3:ObjectCreated:PutLegalHold"},
 		{ObjectAccessedGetRetention, "s3:ObjectAccessed:GetRetention"},
 		{ObjectAccessedGetLegalHold, "s3:ObjectAccessed:GetLegalHold"},
+		{ObjectRejectedStorageFull, "s3:ObjectRejected:StorageFull"},
 
 		{blankName, ""},
 	}
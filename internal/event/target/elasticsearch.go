@@ -49,6 +49,9 @@ const (
 	ElasticQueueLimit = "queue_limit"
 	ElasticUsername   = "username"
 	ElasticPassword   = "password"
+	ElasticClientCert = "client_cert"
+	ElasticClientKey  = "client_key"
+	ElasticProxy      = "proxy"
 
 	EnvElasticEnable     = "MINIO_NOTIFY_ELASTICSEARCH_ENABLE"
 	EnvElasticFormat     = "MINIO_NOTIFY_ELASTICSEARCH_FORMAT"
@@ -58,6 +61,9 @@ const (
 	EnvElasticQueueLimit = "MINIO_NOTIFY_ELASTICSEARCH_QUEUE_LIMIT"
 	EnvElasticUsername   = "MINIO_NOTIFY_ELASTICSEARCH_USERNAME"
 	EnvElasticPassword   = "MINIO_NOTIFY_ELASTICSEARCH_PASSWORD"
+	EnvElasticClientCert = "MINIO_NOTIFY_ELASTICSEARCH_CLIENT_CERT"
+	EnvElasticClientKey  = "MINIO_NOTIFY_ELASTICSEARCH_CLIENT_KEY"
+	EnvElasticProxy      = "MINIO_NOTIFY_ELASTICSEARCH_PROXY"
 )
 
 // ESSupportStatus is a typed string representing the support status for
@@ -123,6 +129,9 @@ type ElasticsearchArgs struct {
 	Transport  *http.Transport `json:"-"`
 	Username   string          `json:"username"`
 	Password   string          `json:"password"`
+	ClientCert string          `json:"clientCert"`
+	ClientKey  string          `json:"clientKey"`
+	Proxy      string          `json:"proxy"`
 }
 
 // Validate ElasticsearchArgs fields
@@ -147,7 +156,11 @@ func (a ElasticsearchArgs) Validate() error {
 		return errors.New("username and password should be set in pairs")
 	}
 
-	return nil
+	if err := validateCertPair(a.ClientCert, a.ClientKey); err != nil {
+		return err
+	}
+
+	return validateProxy(a.Proxy)
 }
 
 // ElasticsearchTarget - Elasticsearch target.
@@ -212,11 +225,28 @@ func (target *ElasticsearchTarget) Save(eventData event.Event) error {
 	return err
 }
 
+// SendSync - sends the event directly to Elasticsearch, bypassing the
+// queue store, the request is aborted when ctx is canceled.
+func (target *ElasticsearchTarget) SendSync(ctx context.Context, eventData event.Event) error {
+	if err := target.init(); err != nil {
+		return err
+	}
+	if err := target.checkAndInitClient(ctx); err != nil {
+		return err
+	}
+	return target.sendWithContext(ctx, eventData)
+}
+
 // send - sends the event to the target.
 func (target *ElasticsearchTarget) send(eventData event.Event) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
+	return target.sendWithContext(ctx, eventData)
+}
+
+func (target *ElasticsearchTarget) sendWithContext(ctx context.Context, eventData event.Event) error {
+
 	if target.args.Format == event.NamespaceFormat {
 		objectName, err := url.QueryUnescape(eventData.S3.Object.Key)
 		if err != nil {
@@ -382,10 +412,15 @@ type esClientV7 struct {
 }
 
 func newClientV7(args ElasticsearchArgs) (*esClientV7, error) {
+	// Clone the shared transport for a per-target proxy or client certificate.
+	transport, err := newTargetTransport(args.Transport, args.Proxy, args.ClientCert, args.ClientKey)
+	if err != nil {
+		return nil, err
+	}
 	// Client options
 	elasticConfig := elasticsearch7.Config{
 		Addresses:  []string{args.URL.String()},
-		Transport:  args.Transport,
+		Transport:  transport,
 		MaxRetries: 10,
 	}
 	// Set basic auth
@@ -35,6 +35,7 @@ import (
 
 	sarama "github.com/Shopify/sarama"
 	saramatls "github.com/Shopify/sarama/tools/tls"
+	"golang.org/x/net/proxy"
 )
 
 // Kafka input constants
@@ -53,6 +54,7 @@ const (
 	KafkaClientTLSCert = "client_tls_cert"
 	KafkaClientTLSKey  = "client_tls_key"
 	KafkaVersion       = "version"
+	KafkaProxy         = "proxy"
 
 	EnvKafkaEnable        = "MINIO_NOTIFY_KAFKA_ENABLE"
 	EnvKafkaBrokers       = "MINIO_NOTIFY_KAFKA_BROKERS"
@@ -69,6 +71,7 @@ const (
 	EnvKafkaClientTLSCert = "MINIO_NOTIFY_KAFKA_CLIENT_TLS_CERT"
 	EnvKafkaClientTLSKey  = "MINIO_NOTIFY_KAFKA_CLIENT_TLS_KEY"
 	EnvKafkaVersion       = "MINIO_NOTIFY_KAFKA_VERSION"
+	EnvKafkaProxy         = "MINIO_NOTIFY_KAFKA_PROXY"
 )
 
 // KafkaArgs - Kafka target arguments.
@@ -79,6 +82,7 @@ type KafkaArgs struct {
 	QueueDir   string      `json:"queueDir"`
 	QueueLimit uint64      `json:"queueLimit"`
 	Version    string      `json:"version"`
+	Proxy      string      `json:"proxy"`
 	TLS        struct {
 		Enable        bool               `json:"enable"`
 		RootCAs       *x509.CertPool     `json:"-"`
@@ -118,7 +122,7 @@ func (k KafkaArgs) Validate() error {
 			return err
 		}
 	}
-	return nil
+	return validateProxy(k.Proxy)
 }
 
 // KafkaTarget - Kafka target.
@@ -257,15 +261,33 @@ func (target *KafkaTarget) Close() error {
 
 // Check if atleast one broker in cluster is active
 func (k KafkaArgs) pingBrokers() bool {
+	dialer, err := k.dialer()
+	if err != nil {
+		return false
+	}
 	for _, broker := range k.Brokers {
-		_, dErr := net.Dial("tcp", broker.String())
+		conn, dErr := dialer.Dial("tcp", broker.String())
 		if dErr == nil {
+			conn.Close()
 			return true
 		}
 	}
 	return false
 }
 
+// dialer returns the dialer used to reach the brokers, through the
+// configured proxy if any.
+func (k KafkaArgs) dialer() (proxy.Dialer, error) {
+	proxyURL, err := parseProxy(k.Proxy)
+	if err != nil {
+		return nil, err
+	}
+	if proxyURL == nil {
+		return &net.Dialer{}, nil
+	}
+	return newProxyDialer(proxyURL, nil)
+}
+
 func (target *KafkaTarget) init() error {
 	return target.lazyInit.Do(target.initKafka)
 }
@@ -300,6 +322,16 @@ func (target *KafkaTarget) initKafka() error {
 	config.Net.TLS.Config.ClientAuth = args.TLS.ClientAuth
 	config.Net.TLS.Config.RootCAs = args.TLS.RootCAs
 
+	if args.Proxy != "" {
+		dialer, err := args.dialer()
+		if err != nil {
+			target.loggerOnce(context.Background(), err, target.ID().String())
+			return err
+		}
+		config.Net.Proxy.Enable = true
+		config.Net.Proxy.Dialer = dialer
+	}
+
 	config.Producer.RequiredAcks = sarama.WaitForAll
 	config.Producer.Retry.Max = 10
 	config.Producer.Return.Successes = true
@@ -23,9 +23,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
+	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	mqtt "github.com/eclipse/paho.mqtt.golang"
@@ -50,6 +53,11 @@ const (
 	MqttKeepAliveInterval = "keep_alive_interval"
 	MqttQueueDir          = "queue_dir"
 	MqttQueueLimit        = "queue_limit"
+	MqttClientCert        = "client_cert"
+	MqttClientKey         = "client_key"
+	MqttProxy             = "proxy"
+	MqttClientID          = "client_id"
+	MqttPersistentSession = "persistent_session"
 
 	EnvMQTTEnable            = "MINIO_NOTIFY_MQTT_ENABLE"
 	EnvMQTTBroker            = "MINIO_NOTIFY_MQTT_BROKER"
@@ -61,6 +69,11 @@ const (
 	EnvMQTTKeepAliveInterval = "MINIO_NOTIFY_MQTT_KEEP_ALIVE_INTERVAL"
 	EnvMQTTQueueDir          = "MINIO_NOTIFY_MQTT_QUEUE_DIR"
 	EnvMQTTQueueLimit        = "MINIO_NOTIFY_MQTT_QUEUE_LIMIT"
+	EnvMQTTClientCert        = "MINIO_NOTIFY_MQTT_CLIENT_CERT"
+	EnvMQTTClientKey         = "MINIO_NOTIFY_MQTT_CLIENT_KEY"
+	EnvMQTTProxy             = "MINIO_NOTIFY_MQTT_PROXY"
+	EnvMQTTClientID          = "MINIO_NOTIFY_MQTT_CLIENT_ID"
+	EnvMQTTPersistentSession = "MINIO_NOTIFY_MQTT_PERSISTENT_SESSION"
 )
 
 // MQTTArgs - MQTT target arguments.
@@ -76,6 +89,11 @@ type MQTTArgs struct {
 	RootCAs              *x509.CertPool `json:"-"`
 	QueueDir             string         `json:"queueDir"`
 	QueueLimit           uint64         `json:"queueLimit"`
+	ClientCert           string         `json:"clientCert"`
+	ClientKey            string         `json:"clientKey"`
+	Proxy                string         `json:"proxy"`
+	ClientID             string         `json:"clientID"`
+	PersistentSession    bool           `json:"persistentSession"`
 }
 
 // Validate MQTTArgs fields
@@ -92,6 +110,9 @@ func (m MQTTArgs) Validate() error {
 	default:
 		return errors.New("unknown protocol in broker address")
 	}
+	if m.QoS > 2 {
+		return errors.New("qos should be 0, 1 or 2")
+	}
 	if m.QueueDir != "" {
 		if !filepath.IsAbs(m.QueueDir) {
 			return errors.New("queueDir path should be absolute")
@@ -100,7 +121,59 @@ func (m MQTTArgs) Validate() error {
 			return errors.New("qos should be set to 1 or 2 if queueDir is set")
 		}
 	}
+	if m.PersistentSession {
+		// The broker keeps the session of a client ID, a generated
+		// one would start a new session upon every restart.
+		if m.ClientID == "" {
+			return errors.New("client id should be set if persistent session is enabled")
+		}
+		if m.QoS == 0 {
+			return errors.New("qos should be set to 1 or 2 if persistent session is enabled")
+		}
+	}
+	if err := validateCertPair(m.ClientCert, m.ClientKey); err != nil {
+		return err
+	}
 
+	return validateProxy(m.Proxy)
+}
+
+// setProxy configures options to reach the broker through the configured
+// proxy, websocket brokers use the proxy support of the websocket client.
+func (m MQTTArgs) setProxy(options *mqtt.ClientOptions) error {
+	proxyURL, err := parseProxy(m.Proxy)
+	if err != nil || proxyURL == nil {
+		return err
+	}
+	switch m.Broker.Scheme {
+	case "ws", "wss":
+		options.SetWebsocketOptions(&mqtt.WebsocketOptions{Proxy: http.ProxyURL(proxyURL)})
+		return nil
+	}
+	dialer, err := newProxyDialer(proxyURL, nil)
+	if err != nil {
+		return err
+	}
+	options.SetCustomOpenConnectionFn(func(uri *url.URL, options mqtt.ClientOptions) (net.Conn, error) {
+		conn, err := dialer.Dial("tcp", uri.Host)
+		if err != nil {
+			return nil, err
+		}
+		switch uri.Scheme {
+		case "ssl", "tls", "tcps", "mqtts", "mqtt+ssl":
+			tlsConfig := options.TLSConfig.Clone()
+			if tlsConfig.ServerName == "" {
+				tlsConfig.ServerName = uri.Hostname()
+			}
+			tlsConn := tls.Client(conn, tlsConfig)
+			if err = tlsConn.Handshake(); err != nil {
+				conn.Close()
+				return nil, err
+			}
+			return tlsConn, nil
+		}
+		return conn, nil
+	})
 	return nil
 }
 
@@ -136,6 +209,53 @@ func (target *MQTTTarget) isActive() (bool, error) {
 	return true, nil
 }
 
+// mqttNodeName is the name of the local node, see SetMQTTNodeName.
+var mqttNodeName string
+
+// SetMQTTNodeName -- the name of the local node from the main package is set here,
+// every node of a cluster connects with its own client ID derived from it.
+func SetMQTTNodeName(name string) {
+	mqttNodeName = name
+}
+
+// mqttClientID returns the client ID of a node, the configured client
+// ID is shared by all nodes and a broker disconnects a client when
+// another one connects with the same ID.
+func mqttClientID(clientID, nodeName string) string {
+	if clientID == "" || nodeName == "" {
+		return clientID
+	}
+	return clientID + "-" + strings.Map(func(r rune) rune {
+		switch {
+		case r >= '0' && r <= '9', r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+			return r
+		}
+		return '-'
+	}, nodeName)
+}
+
+// mqttTopicEscaper replaces the characters not allowed in a published
+// topic name.
+var mqttTopicEscaper = strings.NewReplacer("+", "_", "#", "_", "\x00", "_")
+
+// mqttTopic expands the placeholders of a topic template with the event
+// fields, "{bucket}", "{object}" and "{event}" are supported e.g.
+// "minio/{bucket}/{event}".
+func mqttTopic(topic string, eventData event.Event) string {
+	if !strings.Contains(topic, "{") {
+		return topic
+	}
+	object, err := url.QueryUnescape(eventData.S3.Object.Key)
+	if err != nil {
+		object = eventData.S3.Object.Key
+	}
+	return strings.NewReplacer(
+		"{bucket}", mqttTopicEscaper.Replace(eventData.S3.Bucket.Name),
+		"{object}", mqttTopicEscaper.Replace(object),
+		"{event}", mqttTopicEscaper.Replace(eventData.EventName.String()),
+	).Replace(topic)
+}
+
 // send - sends an event to the mqtt.
 func (target *MQTTTarget) send(eventData event.Event) error {
 	objectName, err := url.QueryUnescape(eventData.S3.Object.Key)
@@ -149,7 +269,7 @@ func (target *MQTTTarget) send(eventData event.Event) error {
 		return err
 	}
 
-	token := target.client.Publish(target.args.Topic, target.args.QoS, false, string(data))
+	token := target.client.Publish(mqttTopic(target.args.Topic, eventData), target.args.QoS, false, string(data))
 	if !token.WaitTimeout(reconnectInterval) {
 		return errNotConnected
 	}
@@ -220,21 +340,43 @@ func (target *MQTTTarget) init() error {
 func (target *MQTTTarget) initMQTT() error {
 	args := target.args
 
-	// Using hex here, to make sure we avoid 23
-	// character limit on client_id according to
-	// MQTT spec.
-	clientID := fmt.Sprintf("%x", time.Now().UnixNano())
+	clientID := mqttClientID(args.ClientID, mqttNodeName)
+	if clientID == "" {
+		// Using hex here, to make sure we avoid 23
+		// character limit on client_id according to
+		// MQTT spec.
+		clientID = fmt.Sprintf("%x", time.Now().UnixNano())
+	}
+
+	tlsConfig := &tls.Config{RootCAs: args.RootCAs}
+	if args.ClientCert != "" && args.ClientKey != "" {
+		manager, err := newClientCertManager(args.ClientCert, args.ClientKey)
+		if err != nil {
+			return err
+		}
+		tlsConfig.GetClientCertificate = manager.GetClientCertificate
+	}
 
 	options := mqtt.NewClientOptions().
 		SetClientID(clientID).
-		SetCleanSession(true).
+		SetCleanSession(!args.PersistentSession).
 		SetUsername(args.User).
 		SetPassword(args.Password).
 		SetMaxReconnectInterval(args.MaxReconnectInterval).
 		SetKeepAlive(args.KeepAlive).
-		SetTLSConfig(&tls.Config{RootCAs: args.RootCAs}).
+		SetTLSConfig(tlsConfig).
 		AddBroker(args.Broker.String())
 
+	if err := args.setProxy(options); err != nil {
+		return err
+	}
+
+	if args.PersistentSession && args.QueueDir != "" {
+		// Keep the in-flight QoS 1 and 2 messages across restarts,
+		// they are resent when the session is resumed.
+		options.SetStore(mqtt.NewFileStore(filepath.Join(args.QueueDir, storePrefix+"-mqtt-session-"+target.id.ID)))
+	}
+
 	target.client = mqtt.NewClient(options)
 
 	token := target.client.Connect()
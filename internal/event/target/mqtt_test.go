@@ -0,0 +1,88 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package target
+
+import (
+	"testing"
+
+	xnet "github.com/minio/pkg/net"
+	"github.com/qkbyte/minio/internal/event"
+)
+
+func TestMQTTTopic(t *testing.T) {
+	var eventData event.Event
+	eventData.EventName = event.ObjectCreatedPut
+	eventData.S3.Bucket.Name = "photos"
+	eventData.S3.Object.Key = "2022%2Fa%2Bb%23c.jpg"
+
+	testCases := []struct {
+		topic    string
+		expected string
+	}{
+		{"minio", "minio"},
+		{"minio/{bucket}", "minio/photos"},
+		{"minio/{bucket}/{event}", "minio/photos/s3:ObjectCreated:Put"},
+		{"{bucket}/{object}", "photos/2022/a_b_c.jpg"},
+	}
+	for i, testCase := range testCases {
+		if topic := mqttTopic(testCase.topic, eventData); topic != testCase.expected {
+			t.Errorf("test %d: expected %q, got %q", i+1, testCase.expected, topic)
+		}
+	}
+}
+
+func TestMQTTArgsValidate(t *testing.T) {
+	broker, err := xnet.ParseURL("tcp://localhost:1883")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		args      MQTTArgs
+		expectErr bool
+	}{
+		{MQTTArgs{Enable: true, Broker: *broker, Topic: "minio", QoS: 2}, false},
+		{MQTTArgs{Enable: true, Broker: *broker, Topic: "minio", QoS: 3}, true},
+		{MQTTArgs{Enable: true, Broker: *broker, Topic: "minio/#"}, false},
+		{MQTTArgs{Enable: true, Broker: *broker, Topic: "minio", QoS: 1, PersistentSession: true}, true},
+		{MQTTArgs{Enable: true, Broker: *broker, Topic: "minio", ClientID: "minio", PersistentSession: true}, true},
+		{MQTTArgs{Enable: true, Broker: *broker, Topic: "minio", QoS: 2, ClientID: "minio", PersistentSession: true}, false},
+	}
+	for i, testCase := range testCases {
+		err := testCase.args.Validate()
+		if expectErr := err != nil; expectErr != testCase.expectErr {
+			t.Errorf("test %d: expected error %v, got %v", i+1, testCase.expectErr, err)
+		}
+	}
+}
+
+func TestMQTTClientID(t *testing.T) {
+	testCases := []struct {
+		clientID, nodeName string
+		expected           string
+	}{
+		{"", "node1:9000", ""},
+		{"minio", "", "minio"},
+		{"minio", "node1:9000", "minio-node1-9000"},
+		{"minio", "10.0.0.2:9000", "minio-10-0-0-2-9000"},
+	}
+	for i, testCase := range testCases {
+		if clientID := mqttClientID(testCase.clientID, testCase.nodeName); clientID != testCase.expected {
+			t.Errorf("test %d: expected %q, got %q", i+1, testCase.expected, clientID)
+		}
+	}
+}
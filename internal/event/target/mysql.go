@@ -24,10 +24,10 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
-	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-sql-driver/mysql"
@@ -37,21 +37,63 @@ import (
 )
 
 const (
-	mysqlTableExists = `SELECT 1 FROM %s;`
 	// Some MySQL has a 3072 byte limit on key sizes.
-	mysqlCreateNamespaceTable = `CREATE TABLE %s (
+	mysqlCreateNamespaceTable = `CREATE TABLE IF NOT EXISTS %s (
              key_name VARCHAR(3072) NOT NULL,
              key_hash CHAR(64) GENERATED ALWAYS AS (SHA2(key_name, 256)) STORED NOT NULL PRIMARY KEY,
              value JSON)
            CHARACTER SET = utf8mb4 COLLATE = utf8mb4_bin ROW_FORMAT = Dynamic;`
-	mysqlCreateAccessTable = `CREATE TABLE %s (event_time DATETIME NOT NULL, event_data JSON)
+	mysqlCreateAccessTable = `CREATE TABLE IF NOT EXISTS %s (event_time DATETIME NOT NULL, event_data JSON)
                                     ROW_FORMAT = Dynamic;`
+	// Partitions are split off the catch-all "pmax" partition as needed.
+	mysqlCreatePartitionedTable = `CREATE TABLE IF NOT EXISTS %s (event_time DATETIME NOT NULL, event_data JSON)
+                                    ROW_FORMAT = Dynamic
+                                    PARTITION BY RANGE COLUMNS(event_time) (PARTITION pmax VALUES LESS THAN (MAXVALUE));`
+	mysqlLastPartition = `SELECT PARTITION_NAME FROM information_schema.PARTITIONS
+                                    WHERE TABLE_SCHEMA = COALESCE(NULLIF(?, ''), DATABASE()) AND TABLE_NAME = ? AND PARTITION_NAME <> 'pmax'
+                                    ORDER BY PARTITION_NAME DESC LIMIT 1;`
+	mysqlIsPartitioned = `SELECT COUNT(*) FROM information_schema.PARTITIONS
+                                    WHERE TABLE_SCHEMA = COALESCE(NULLIF(?, ''), DATABASE()) AND TABLE_NAME = ? AND PARTITION_NAME = 'pmax';`
+	mysqlAddPartition = `ALTER TABLE %s REORGANIZE PARTITION pmax INTO
+                                    (PARTITION %s VALUES LESS THAN ('%s'), PARTITION pmax VALUES LESS THAN (MAXVALUE));`
 
 	mysqlUpdateRow = `INSERT INTO %s (key_name, value) VALUES (?, ?) ON DUPLICATE KEY UPDATE value=VALUES(value);`
 	mysqlDeleteRow = `DELETE FROM %s WHERE key_hash = SHA2(?, 256);`
 	mysqlInsertRow = `INSERT INTO %s (event_time, event_data) VALUES (?, ?);`
 )
 
+var mysqlDialect = sqlDialect{
+	createMigrations: `CREATE TABLE IF NOT EXISTS ` + sqlMigrationsTable + ` (table_name VARCHAR(255) PRIMARY KEY, version INTEGER NOT NULL);`,
+	selectVersion:    `SELECT version FROM ` + sqlMigrationsTable + ` WHERE table_name = ?;`,
+	upsertVersion:    `INSERT INTO ` + sqlMigrationsTable + ` (table_name, version) VALUES (?, ?) ON DUPLICATE KEY UPDATE version = VALUES(version);`,
+	existsErr:        mysqlDuplicateKeyName,
+}
+
+// mysqlDuplicateKeyName reports an index which already exists, MySQL
+// has no CREATE INDEX IF NOT EXISTS.
+func mysqlDuplicateKeyName(err error) bool {
+	var mErr *mysql.MySQLError
+	return errors.As(err, &mErr) && mErr.Number == 1061
+}
+
+// mysqlMigrations returns the schema migrations of a table, new versions
+// must only be appended.
+func mysqlMigrations(format, partition, table string) []sqlMigration {
+	if format == event.NamespaceFormat {
+		return []sqlMigration{
+			{version: 1, stmts: []string{mysqlCreateNamespaceTable}},
+		}
+	}
+	create := mysqlCreateAccessTable
+	if partition != "" {
+		create = mysqlCreatePartitionedTable
+	}
+	return []sqlMigration{
+		{version: 1, stmts: []string{create}},
+		{version: 2, stmts: []string{`CREATE INDEX ` + unqualifiedTable(table) + `_event_time_idx ON %s (event_time);`}},
+	}
+}
+
 // MySQL related constants
 const (
 	MySQLFormat             = "format"
@@ -65,6 +107,8 @@ const (
 	MySQLQueueLimit         = "queue_limit"
 	MySQLQueueDir           = "queue_dir"
 	MySQLMaxOpenConnections = "max_open_connections"
+	MySQLBatchSize          = "batch_size"
+	MySQLPartition          = "partition"
 
 	EnvMySQLEnable             = "MINIO_NOTIFY_MYSQL_ENABLE"
 	EnvMySQLFormat             = "MINIO_NOTIFY_MYSQL_FORMAT"
@@ -78,6 +122,8 @@ const (
 	EnvMySQLQueueLimit         = "MINIO_NOTIFY_MYSQL_QUEUE_LIMIT"
 	EnvMySQLQueueDir           = "MINIO_NOTIFY_MYSQL_QUEUE_DIR"
 	EnvMySQLMaxOpenConnections = "MINIO_NOTIFY_MYSQL_MAX_OPEN_CONNECTIONS"
+	EnvMySQLBatchSize          = "MINIO_NOTIFY_MYSQL_BATCH_SIZE"
+	EnvMySQLPartition          = "MINIO_NOTIFY_MYSQL_PARTITION"
 )
 
 // MySQLArgs - MySQL target arguments.
@@ -94,6 +140,8 @@ type MySQLArgs struct {
 	QueueDir           string   `json:"queueDir"`
 	QueueLimit         uint64   `json:"queueLimit"`
 	MaxOpenConnections int      `json:"maxOpenConnections"`
+	BatchSize          int      `json:"batchSize"`
+	Partition          string   `json:"partition"`
 }
 
 // Validate MySQLArgs fields
@@ -140,7 +188,11 @@ func (m MySQLArgs) Validate() error {
 		return errors.New("maxOpenConnections cannot be less than zero")
 	}
 
-	return nil
+	if m.BatchSize < 0 {
+		return errors.New("batchSize cannot be less than zero")
+	}
+
+	return validateSQLPartition(m.Partition, strings.ToLower(m.Format))
 }
 
 // MySQLTarget - MySQL target.
@@ -158,6 +210,9 @@ type MySQLTarget struct {
 	loggerOnce logger.LogOnce
 
 	quitCh chan struct{}
+
+	partitionMu   sync.Mutex
+	lastPartition string
 }
 
 // ID - returns target ID.
@@ -201,6 +256,38 @@ func (target *MySQLTarget) Save(eventData event.Event) error {
 
 // send - sends an event to the mysql.
 func (target *MySQLTarget) send(eventData event.Event) error {
+	return target.sendBatch([]event.Event{eventData})
+}
+
+// sendBatch - sends the events to the mysql in a single transaction.
+func (target *MySQLTarget) sendBatch(events []event.Event) error {
+	if target.args.Format == event.AccessFormat && target.args.Partition != "" {
+		for _, eventData := range events {
+			eventTime, err := time.Parse(event.AMZTimeFormat, eventData.EventTime)
+			if err != nil {
+				return err
+			}
+			if err = target.ensurePartition(eventTime); err != nil {
+				return err
+			}
+		}
+	}
+
+	tx, err := target.db.Begin()
+	if err != nil {
+		return err
+	}
+	for _, eventData := range events {
+		if err = target.write(tx, eventData); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// write - writes an event using the prepared statements in tx.
+func (target *MySQLTarget) write(tx *sql.Tx, eventData event.Event) error {
 	if target.args.Format == event.NamespaceFormat {
 		objectName, err := url.QueryUnescape(eventData.S3.Object.Key)
 		if err != nil {
@@ -209,14 +296,14 @@ func (target *MySQLTarget) send(eventData event.Event) error {
 		key := eventData.S3.Bucket.Name + "/" + objectName
 
 		if eventData.EventName == event.ObjectRemovedDelete {
-			_, err = target.deleteStmt.Exec(key)
+			_, err = tx.Stmt(target.deleteStmt).Exec(key)
 		} else {
 			var data []byte
 			if data, err = json.Marshal(struct{ Records []event.Event }{[]event.Event{eventData}}); err != nil {
 				return err
 			}
 
-			_, err = target.updateStmt.Exec(key, data)
+			_, err = tx.Stmt(target.updateStmt).Exec(key, data)
 		}
 
 		return err
@@ -233,7 +320,7 @@ func (target *MySQLTarget) send(eventData event.Event) error {
 			return err
 		}
 
-		_, err = target.insertStmt.Exec(eventTime, data)
+		_, err = tx.Stmt(target.insertStmt).Exec(eventTime, data)
 
 		return err
 	}
@@ -241,6 +328,68 @@ func (target *MySQLTarget) send(eventData event.Event) error {
 	return nil
 }
 
+// ensurePartition splits a partition ending after eventTime off the
+// catch-all partition. Events older than the last partition already
+// fall in an existing one.
+func (target *MySQLTarget) ensurePartition(eventTime time.Time) error {
+	suffix, _, to := sqlPartitionRange(target.args.Partition, eventTime)
+	name := "p" + suffix
+
+	target.partitionMu.Lock()
+	defer target.partitionMu.Unlock()
+	if target.lastPartition == "" {
+		if err := target.loadLastPartition(); err != nil {
+			return err
+		}
+	}
+	if name <= target.lastPartition {
+		return nil
+	}
+	stmt := fmt.Sprintf(mysqlAddPartition, target.args.Table, name, to.Format("2006-01-02 15:04:05"))
+	if _, err := target.db.Exec(stmt); err != nil {
+		// Another server may have added it meanwhile.
+		if lErr := target.loadLastPartition(); lErr != nil || name > target.lastPartition {
+			return err
+		}
+		return nil
+	}
+	target.lastPartition = name
+	return nil
+}
+
+// checkPartitioned fails if the table was created before partitioning
+// was enabled, as no partition could ever be added to it.
+func (target *MySQLTarget) checkPartitioned() error {
+	schema, table := target.splitTable()
+	var count int
+	if err := target.db.QueryRow(mysqlIsPartitioned, schema, table).Scan(&count); err != nil {
+		return err
+	}
+	if count == 0 {
+		return errSQLTableNotPartitioned(target.args.Table)
+	}
+	return nil
+}
+
+func (target *MySQLTarget) splitTable() (schema, table string) {
+	table = target.args.Table
+	if i := strings.LastIndexByte(table, '.'); i >= 0 {
+		schema, table = table[:i], table[i+1:]
+	}
+	return schema, table
+}
+
+func (target *MySQLTarget) loadLastPartition() error {
+	schema, table := target.splitTable()
+	var name string
+	err := target.db.QueryRow(mysqlLastPartition, schema, table).Scan(&name)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	target.lastPartition = name
+	return nil
+}
+
 // Send - reads an event from store and sends it to MySQL.
 func (target *MySQLTarget) Send(eventKey string) error {
 	if err := target.init(); err != nil {
@@ -261,25 +410,25 @@ func (target *MySQLTarget) Send(eventKey string) error {
 		}
 	}
 
-	eventData, eErr := target.store.Get(eventKey)
+	// The last event key in a successful batch will be sent in the channel atmost once by the replayEvents()
+	// Such events will not exist and wouldve been already been sent successfully.
+	eventKeys, events, eErr := readStoreBatch(target.store, eventKey, target.args.BatchSize)
 	if eErr != nil {
-		// The last event key in a successful batch will be sent in the channel atmost once by the replayEvents()
-		// Such events will not exist and wouldve been already been sent successfully.
-		if os.IsNotExist(eErr) {
-			return nil
-		}
 		return eErr
 	}
+	if len(events) == 0 {
+		return nil
+	}
 
-	if err := target.send(eventData); err != nil {
+	if err := target.sendBatch(events); err != nil {
 		if IsConnErr(err) {
 			return errNotConnected
 		}
 		return err
 	}
 
-	// Delete the event from store.
-	return target.store.Del(eventKey)
+	// Delete the events from store.
+	return delStoreBatch(target.store, eventKeys)
 }
 
 // Close - closes underneath connections to MySQL database.
@@ -303,17 +452,16 @@ func (target *MySQLTarget) Close() error {
 	return target.db.Close()
 }
 
-// Executes the table creation statements.
+// Migrates the table schema and prepares the statements.
 func (target *MySQLTarget) executeStmts() error {
-	_, err := target.db.Exec(fmt.Sprintf(mysqlTableExists, target.args.Table))
+	migrations := mysqlMigrations(target.args.Format, target.args.Partition, target.args.Table)
+	err := migrateSQLTable(target.db, mysqlDialect, target.args.Table, migrations)
 	if err != nil {
-		createStmt := mysqlCreateNamespaceTable
-		if target.args.Format == event.AccessFormat {
-			createStmt = mysqlCreateAccessTable
-		}
-
-		if _, dbErr := target.db.Exec(fmt.Sprintf(createStmt, target.args.Table)); dbErr != nil {
-			return dbErr
+		return err
+	}
+	if target.args.Partition != "" {
+		if err = target.checkPartitioned(); err != nil {
+			return err
 		}
 	}
 
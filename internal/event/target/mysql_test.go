@@ -19,7 +19,11 @@ package target
 
 import (
 	"database/sql"
+	"errors"
+	"fmt"
 	"testing"
+
+	"github.com/go-sql-driver/mysql"
 )
 
 // TestPostgreSQLRegistration checks if sql driver
@@ -36,3 +40,16 @@ func TestMySQLRegistration(t *testing.T) {
 		t.Fatal("mysql driver not registered")
 	}
 }
+
+func TestMySQLDuplicateKeyName(t *testing.T) {
+	dup := &mysql.MySQLError{Number: 1061, Message: "Duplicate key name 'access_event_time_idx'"}
+	if !mysqlDuplicateKeyName(fmt.Errorf("wrapped: %w", dup)) {
+		t.Fatal("expected duplicate key name error to be ignored")
+	}
+	if mysqlDuplicateKeyName(&mysql.MySQLError{Number: 1146}) {
+		t.Fatal("unexpected missing table error to be ignored")
+	}
+	if mysqlDuplicateKeyName(errors.New("connection refused")) {
+		t.Fatal("unexpected non mysql error to be ignored")
+	}
+}
@@ -50,6 +50,7 @@ const (
 	NATSCertAuthority = "cert_authority"
 	NATSClientCert    = "client_cert"
 	NATSClientKey     = "client_key"
+	NATSProxy         = "proxy"
 
 	// Streaming constants - deprecated
 	NATSStreaming                   = "streaming"
@@ -74,6 +75,7 @@ const (
 	EnvNATSCertAuthority = "MINIO_NOTIFY_NATS_CERT_AUTHORITY"
 	EnvNATSClientCert    = "MINIO_NOTIFY_NATS_CLIENT_CERT"
 	EnvNATSClientKey     = "MINIO_NOTIFY_NATS_CLIENT_KEY"
+	EnvNATSProxy         = "MINIO_NOTIFY_NATS_PROXY"
 
 	// Streaming constants - deprecated
 	EnvNATSStreaming                   = "MINIO_NOTIFY_NATS_STREAMING"
@@ -99,6 +101,7 @@ type NATSArgs struct {
 	CertAuthority string    `json:"certAuthority"`
 	ClientCert    string    `json:"clientCert"`
 	ClientKey     string    `json:"clientKey"`
+	Proxy         string    `json:"proxy"`
 	PingInterval  int64     `json:"pingInterval"`
 	QueueDir      string    `json:"queueDir"`
 	QueueLimit    uint64    `json:"queueLimit"`
@@ -129,8 +132,12 @@ func (n NATSArgs) Validate() error {
 		return errors.New("empty subject")
 	}
 
-	if n.ClientCert != "" && n.ClientKey == "" || n.ClientCert == "" && n.ClientKey != "" {
-		return errors.New("cert and key must be specified as a pair")
+	if err := validateCertPair(n.ClientCert, n.ClientKey); err != nil {
+		return err
+	}
+
+	if err := validateProxy(n.Proxy); err != nil {
+		return err
 	}
 
 	if n.Username != "" && n.Password == "" || n.Username == "" && n.Password != "" {
@@ -178,9 +185,30 @@ func (n NATSArgs) connectNats() (*nats.Conn, error) {
 	if n.ClientCert != "" && n.ClientKey != "" {
 		connOpts = append(connOpts, nats.ClientCert(n.ClientCert, n.ClientKey))
 	}
+	dialOpt, err := n.proxyDialOption()
+	if err != nil {
+		return nil, err
+	}
+	if dialOpt != nil {
+		connOpts = append(connOpts, dialOpt)
+	}
 	return nats.Connect(n.Address.String(), connOpts...)
 }
 
+// proxyDialOption returns the option dialing the NATS server through the
+// configured proxy, nil when no proxy is configured.
+func (n NATSArgs) proxyDialOption() (nats.Option, error) {
+	proxyURL, err := parseProxy(n.Proxy)
+	if err != nil || proxyURL == nil {
+		return nil, err
+	}
+	dialer, err := newProxyDialer(proxyURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	return nats.SetCustomDialer(dialer), nil
+}
+
 // To obtain a streaming connection from args.
 func (n NATSArgs) connectStan() (stan.Conn, error) {
 	scheme := "nats"
@@ -204,6 +232,13 @@ func (n NATSArgs) connectStan() (stan.Conn, error) {
 	}
 
 	connOpts := []stan.Option{stan.NatsURL(addressURL)}
+	dialOpt, err := n.proxyDialOption()
+	if err != nil {
+		return nil, err
+	}
+	if dialOpt != nil {
+		connOpts = append(connOpts, stan.NatsOptions(dialOpt))
+	}
 	if n.Streaming.MaxPubAcksInflight > 0 {
 		connOpts = append(connOpts, stan.MaxPubAcksInflight(n.Streaming.MaxPubAcksInflight))
 	}
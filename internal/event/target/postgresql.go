@@ -24,10 +24,10 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
-	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	_ "github.com/lib/pq" // Register postgres driver
@@ -38,15 +38,41 @@ import (
 )
 
 const (
-	psqlTableExists          = `SELECT 1 FROM %s;`
-	psqlCreateNamespaceTable = `CREATE TABLE %s (key VARCHAR PRIMARY KEY, value JSONB);`
-	psqlCreateAccessTable    = `CREATE TABLE %s (event_time TIMESTAMP WITH TIME ZONE NOT NULL, event_data JSONB);`
+	psqlCreateNamespaceTable   = `CREATE TABLE IF NOT EXISTS %s (key VARCHAR PRIMARY KEY, value JSONB);`
+	psqlCreateAccessTable      = `CREATE TABLE IF NOT EXISTS %s (event_time TIMESTAMP WITH TIME ZONE NOT NULL, event_data JSONB);`
+	psqlCreatePartitionedTable = `CREATE TABLE IF NOT EXISTS %s (event_time TIMESTAMP WITH TIME ZONE NOT NULL, event_data JSONB) PARTITION BY RANGE (event_time);`
+	psqlIsPartitioned          = `SELECT COUNT(*) FROM pg_partitioned_table WHERE partrelid = to_regclass($1);`
+	psqlCreatePartition        = `CREATE TABLE IF NOT EXISTS %s PARTITION OF %s FOR VALUES FROM ('%s') TO ('%s');`
 
 	psqlUpdateRow = `INSERT INTO %s (key, value) VALUES ($1, $2) ON CONFLICT (key) DO UPDATE SET value = EXCLUDED.value;`
 	psqlDeleteRow = `DELETE FROM %s WHERE key = $1;`
 	psqlInsertRow = `INSERT INTO %s (event_time, event_data) VALUES ($1, $2);`
 )
 
+var psqlDialect = sqlDialect{
+	createMigrations: `CREATE TABLE IF NOT EXISTS ` + sqlMigrationsTable + ` (table_name VARCHAR(255) PRIMARY KEY, version INTEGER NOT NULL);`,
+	selectVersion:    `SELECT version FROM ` + sqlMigrationsTable + ` WHERE table_name = $1;`,
+	upsertVersion:    `INSERT INTO ` + sqlMigrationsTable + ` (table_name, version) VALUES ($1, $2) ON CONFLICT (table_name) DO UPDATE SET version = EXCLUDED.version;`,
+}
+
+// psqlMigrations returns the schema migrations of a table, new versions
+// must only be appended.
+func psqlMigrations(format, partition, table string) []sqlMigration {
+	if format == event.NamespaceFormat {
+		return []sqlMigration{
+			{version: 1, stmts: []string{psqlCreateNamespaceTable}},
+		}
+	}
+	create := psqlCreateAccessTable
+	if partition != "" {
+		create = psqlCreatePartitionedTable
+	}
+	return []sqlMigration{
+		{version: 1, stmts: []string{create}},
+		{version: 2, stmts: []string{`CREATE INDEX IF NOT EXISTS ` + unqualifiedTable(table) + `_event_time_idx ON %s (event_time);`}},
+	}
+}
+
 // Postgres constants
 const (
 	PostgresFormat             = "format"
@@ -60,6 +86,8 @@ const (
 	PostgresQueueDir           = "queue_dir"
 	PostgresQueueLimit         = "queue_limit"
 	PostgresMaxOpenConnections = "max_open_connections"
+	PostgresBatchSize          = "batch_size"
+	PostgresPartition          = "partition"
 
 	EnvPostgresEnable             = "MINIO_NOTIFY_POSTGRES_ENABLE"
 	EnvPostgresFormat             = "MINIO_NOTIFY_POSTGRES_FORMAT"
@@ -73,6 +101,8 @@ const (
 	EnvPostgresQueueDir           = "MINIO_NOTIFY_POSTGRES_QUEUE_DIR"
 	EnvPostgresQueueLimit         = "MINIO_NOTIFY_POSTGRES_QUEUE_LIMIT"
 	EnvPostgresMaxOpenConnections = "MINIO_NOTIFY_POSTGRES_MAX_OPEN_CONNECTIONS"
+	EnvPostgresBatchSize          = "MINIO_NOTIFY_POSTGRES_BATCH_SIZE"
+	EnvPostgresPartition          = "MINIO_NOTIFY_POSTGRES_PARTITION"
 )
 
 // PostgreSQLArgs - PostgreSQL target arguments.
@@ -89,6 +119,8 @@ type PostgreSQLArgs struct {
 	QueueDir           string    `json:"queueDir"`
 	QueueLimit         uint64    `json:"queueLimit"`
 	MaxOpenConnections int       `json:"maxOpenConnections"`
+	BatchSize          int       `json:"batchSize"`
+	Partition          string    `json:"partition"`
 }
 
 // Validate PostgreSQLArgs fields
@@ -132,7 +164,11 @@ func (p PostgreSQLArgs) Validate() error {
 		return errors.New("maxOpenConnections cannot be less than zero")
 	}
 
-	return nil
+	if p.BatchSize < 0 {
+		return errors.New("batchSize cannot be less than zero")
+	}
+
+	return validateSQLPartition(p.Partition, strings.ToLower(p.Format))
 }
 
 // PostgreSQLTarget - PostgreSQL target.
@@ -150,6 +186,9 @@ type PostgreSQLTarget struct {
 	connString string
 	loggerOnce logger.LogOnce
 	quitCh     chan struct{}
+
+	partitionsMu sync.Mutex
+	partitions   map[string]struct{}
 }
 
 // ID - returns target ID.
@@ -198,6 +237,38 @@ func IsConnErr(err error) bool {
 
 // send - sends an event to the PostgreSQL.
 func (target *PostgreSQLTarget) send(eventData event.Event) error {
+	return target.sendBatch([]event.Event{eventData})
+}
+
+// sendBatch - sends the events to the PostgreSQL in a single transaction.
+func (target *PostgreSQLTarget) sendBatch(events []event.Event) error {
+	if target.args.Format == event.AccessFormat && target.args.Partition != "" {
+		for _, eventData := range events {
+			eventTime, err := time.Parse(event.AMZTimeFormat, eventData.EventTime)
+			if err != nil {
+				return err
+			}
+			if err = target.ensurePartition(eventTime); err != nil {
+				return err
+			}
+		}
+	}
+
+	tx, err := target.db.Begin()
+	if err != nil {
+		return err
+	}
+	for _, eventData := range events {
+		if err = target.write(tx, eventData); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// write - writes an event using the prepared statements in tx.
+func (target *PostgreSQLTarget) write(tx *sql.Tx, eventData event.Event) error {
 	if target.args.Format == event.NamespaceFormat {
 		objectName, err := url.QueryUnescape(eventData.S3.Object.Key)
 		if err != nil {
@@ -206,14 +277,14 @@ func (target *PostgreSQLTarget) send(eventData event.Event) error {
 		key := eventData.S3.Bucket.Name + "/" + objectName
 
 		if eventData.EventName == event.ObjectRemovedDelete {
-			_, err = target.deleteStmt.Exec(key)
+			_, err = tx.Stmt(target.deleteStmt).Exec(key)
 		} else {
 			var data []byte
 			if data, err = json.Marshal(struct{ Records []event.Event }{[]event.Event{eventData}}); err != nil {
 				return err
 			}
 
-			_, err = target.updateStmt.Exec(key, data)
+			_, err = tx.Stmt(target.updateStmt).Exec(key, data)
 		}
 		return err
 	}
@@ -229,7 +300,7 @@ func (target *PostgreSQLTarget) send(eventData event.Event) error {
 			return err
 		}
 
-		if _, err = target.insertStmt.Exec(eventTime, data); err != nil {
+		if _, err = tx.Stmt(target.insertStmt).Exec(eventTime, data); err != nil {
 			return err
 		}
 	}
@@ -237,6 +308,24 @@ func (target *PostgreSQLTarget) send(eventData event.Event) error {
 	return nil
 }
 
+// ensurePartition creates the partition holding eventTime.
+func (target *PostgreSQLTarget) ensurePartition(eventTime time.Time) error {
+	suffix, from, to := sqlPartitionRange(target.args.Partition, eventTime)
+
+	target.partitionsMu.Lock()
+	defer target.partitionsMu.Unlock()
+	if _, ok := target.partitions[suffix]; ok {
+		return nil
+	}
+	stmt := fmt.Sprintf(psqlCreatePartition, target.args.Table+"_"+suffix, target.args.Table,
+		from.Format(time.RFC3339), to.Format(time.RFC3339))
+	if _, err := target.db.Exec(stmt); err != nil {
+		return err
+	}
+	target.partitions[suffix] = struct{}{}
+	return nil
+}
+
 // Send - reads an event from store and sends it to PostgreSQL.
 func (target *PostgreSQLTarget) Send(eventKey string) error {
 	if err := target.init(); err != nil {
@@ -256,25 +345,25 @@ func (target *PostgreSQLTarget) Send(eventKey string) error {
 		}
 	}
 
-	eventData, eErr := target.store.Get(eventKey)
+	// The last event key in a successful batch will be sent in the channel atmost once by the replayEvents()
+	// Such events will not exist and wouldve been already been sent successfully.
+	eventKeys, events, eErr := readStoreBatch(target.store, eventKey, target.args.BatchSize)
 	if eErr != nil {
-		// The last event key in a successful batch will be sent in the channel atmost once by the replayEvents()
-		// Such events will not exist and wouldve been already been sent successfully.
-		if os.IsNotExist(eErr) {
-			return nil
-		}
 		return eErr
 	}
+	if len(events) == 0 {
+		return nil
+	}
 
-	if err := target.send(eventData); err != nil {
+	if err := target.sendBatch(events); err != nil {
 		if IsConnErr(err) {
 			return errNotConnected
 		}
 		return err
 	}
 
-	// Delete the event from store.
-	return target.store.Del(eventKey)
+	// Delete the events from store.
+	return delStoreBatch(target.store, eventKeys)
 }
 
 // Close - closes underneath connections to PostgreSQL database.
@@ -298,17 +387,22 @@ func (target *PostgreSQLTarget) Close() error {
 	return target.db.Close()
 }
 
-// Executes the table creation statements.
+// Migrates the table schema and prepares the statements.
 func (target *PostgreSQLTarget) executeStmts() error {
-	_, err := target.db.Exec(fmt.Sprintf(psqlTableExists, target.args.Table))
+	migrations := psqlMigrations(target.args.Format, target.args.Partition, target.args.Table)
+	err := migrateSQLTable(target.db, psqlDialect, target.args.Table, migrations)
 	if err != nil {
-		createStmt := psqlCreateNamespaceTable
-		if target.args.Format == event.AccessFormat {
-			createStmt = psqlCreateAccessTable
+		return err
+	}
+	if target.args.Partition != "" {
+		// A table created before partitioning was enabled can never
+		// get a partition attached.
+		var count int
+		if err = target.db.QueryRow(psqlIsPartitioned, target.args.Table).Scan(&count); err != nil {
+			return err
 		}
-
-		if _, dbErr := target.db.Exec(fmt.Sprintf(createStmt, target.args.Table)); dbErr != nil {
-			return dbErr
+		if count == 0 {
+			return errSQLTableNotPartitioned(target.args.Table)
 		}
 	}
 
@@ -422,5 +516,6 @@ func NewPostgreSQLTarget(id string, args PostgreSQLArgs, loggerOnce logger.LogOn
 		connString: connStr,
 		loggerOnce: loggerOnce,
 		quitCh:     make(chan struct{}),
+		partitions: make(map[string]struct{}),
 	}, nil
 }
@@ -0,0 +1,224 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package target
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+
+	"github.com/minio/pkg/certs"
+	"golang.org/x/net/proxy"
+)
+
+// Timeout for establishing a tunnel through a proxy.
+const proxyDialTimeout = 15 * time.Second
+
+// parseProxy parses a proxy URL, an empty value means no proxy.
+func parseProxy(p string) (*url.URL, error) {
+	if p == "" {
+		return nil, nil
+	}
+	u, err := url.Parse(p)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy %q: %w", p, err)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("invalid proxy %q: scheme must be one of http, https or socks5", p)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("invalid proxy %q: missing host", p)
+	}
+	return u, nil
+}
+
+// validateProxy validates a proxy URL configured on a target.
+func validateProxy(p string) error {
+	_, err := parseProxy(p)
+	return err
+}
+
+// validateCertPair validates that the client certificate and key are
+// either both set or both empty.
+func validateCertPair(cert, key string) error {
+	if cert != "" && key == "" || cert == "" && key != "" {
+		return errors.New("cert and key must be specified as a pair")
+	}
+	return nil
+}
+
+// newClientCertManager loads the client certificate and reloads it upon SIGHUP.
+func newClientCertManager(cert, key string) (*certs.Manager, error) {
+	manager, err := certs.NewManager(context.Background(), cert, key, tls.LoadX509KeyPair)
+	if err != nil {
+		return nil, err
+	}
+	manager.ReloadOnSignal(syscall.SIGHUP) // allow reloads upon SIGHUP
+	return manager, nil
+}
+
+// newTargetTransport returns the transport used by a single HTTP based
+// target. The shared transport is cloned whenever the target configures
+// its own proxy or client certificate so that these never leak into the
+// other targets.
+func newTargetTransport(transport *http.Transport, p, cert, key string) (*http.Transport, error) {
+	if p == "" && cert == "" && key == "" {
+		return transport, nil
+	}
+	if transport == nil {
+		transport = http.DefaultTransport.(*http.Transport)
+	}
+	transport = transport.Clone()
+	proxyURL, err := parseProxy(p)
+	if err != nil {
+		return nil, err
+	}
+	if proxyURL != nil {
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+	if cert != "" && key != "" {
+		manager, err := newClientCertManager(cert, key)
+		if err != nil {
+			return nil, err
+		}
+		if transport.TLSClientConfig == nil {
+			transport.TLSClientConfig = &tls.Config{}
+		}
+		transport.TLSClientConfig.GetClientCertificate = manager.GetClientCertificate
+	}
+	return transport, nil
+}
+
+// newProxyDialer returns a dialer connecting through proxyURL, socks5
+// proxies are dialed directly while http and https proxies are tunneled
+// using the CONNECT method.
+func newProxyDialer(proxyURL *url.URL, forward *net.Dialer) (proxy.Dialer, error) {
+	if forward == nil {
+		forward = &net.Dialer{Timeout: proxyDialTimeout}
+	}
+	switch proxyURL.Scheme {
+	case "socks5":
+		return proxy.FromURL(proxyURL, forward)
+	case "http", "https":
+		return &httpConnectDialer{proxyURL: proxyURL, forward: forward}, nil
+	}
+	return nil, fmt.Errorf("unsupported proxy scheme %q", proxyURL.Scheme)
+}
+
+// httpConnectDialer tunnels TCP connections through an HTTP proxy.
+type httpConnectDialer struct {
+	proxyURL *url.URL
+	forward  *net.Dialer
+}
+
+func (d *httpConnectDialer) proxyAddr() string {
+	if d.proxyURL.Port() != "" {
+		return d.proxyURL.Host
+	}
+	if d.proxyURL.Scheme == "https" {
+		return net.JoinHostPort(d.proxyURL.Hostname(), "443")
+	}
+	return net.JoinHostPort(d.proxyURL.Hostname(), "80")
+}
+
+// Dial - implements proxy.Dialer.
+func (d *httpConnectDialer) Dial(network, addr string) (net.Conn, error) {
+	return d.DialContext(context.Background(), network, addr)
+}
+
+// DialContext - dials addr through the proxy.
+func (d *httpConnectDialer) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, proxyDialTimeout)
+		defer cancel()
+	}
+
+	conn, err := d.forward.DialContext(ctx, "tcp", d.proxyAddr())
+	if err != nil {
+		return nil, err
+	}
+	if d.proxyURL.Scheme == "https" {
+		tlsConn := tls.Client(conn, &tls.Config{ServerName: d.proxyURL.Hostname()})
+		if err = tlsConn.HandshakeContext(ctx); err != nil {
+			conn.Close()
+			return nil, err
+		}
+		conn = tlsConn
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+		defer conn.SetDeadline(time.Time{})
+	}
+
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: addr},
+		Host:   addr,
+		Header: make(http.Header),
+	}
+	if u := d.proxyURL.User; u != nil {
+		password, _ := u.Password()
+		auth := base64.StdEncoding.EncodeToString([]byte(u.Username() + ":" + password))
+		req.Header.Set("Proxy-Authorization", "Basic "+auth)
+	}
+	if err = req.Write(conn); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, req)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		conn.Close()
+		return nil, fmt.Errorf("proxy %s refused to connect to %s: %s", d.proxyURL.Host, addr, resp.Status)
+	}
+
+	if br.Buffered() > 0 {
+		// The server may have spoken first, keep whatever was read along
+		// with the proxy response.
+		return &bufferedConn{Conn: conn, r: br}, nil
+	}
+	return conn, nil
+}
+
+// bufferedConn serves reads from r before reading from the connection.
+type bufferedConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+func (c *bufferedConn) Read(p []byte) (int, error) {
+	return c.r.Read(p)
+}
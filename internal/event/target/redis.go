@@ -19,6 +19,7 @@ package target
 
 import (
 	"context"
+	"crypto/x509"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -36,20 +37,36 @@ import (
 
 // Redis constants
 const (
-	RedisFormat     = "format"
-	RedisAddress    = "address"
-	RedisPassword   = "password"
-	RedisKey        = "key"
-	RedisQueueDir   = "queue_dir"
-	RedisQueueLimit = "queue_limit"
-
-	EnvRedisEnable     = "MINIO_NOTIFY_REDIS_ENABLE"
-	EnvRedisFormat     = "MINIO_NOTIFY_REDIS_FORMAT"
-	EnvRedisAddress    = "MINIO_NOTIFY_REDIS_ADDRESS"
-	EnvRedisPassword   = "MINIO_NOTIFY_REDIS_PASSWORD"
-	EnvRedisKey        = "MINIO_NOTIFY_REDIS_KEY"
-	EnvRedisQueueDir   = "MINIO_NOTIFY_REDIS_QUEUE_DIR"
-	EnvRedisQueueLimit = "MINIO_NOTIFY_REDIS_QUEUE_LIMIT"
+	RedisFormat           = "format"
+	RedisAddress          = "address"
+	RedisPassword         = "password"
+	RedisKey              = "key"
+	RedisQueueDir         = "queue_dir"
+	RedisQueueLimit       = "queue_limit"
+	RedisUsername         = "username"
+	RedisMode             = "mode"
+	RedisSentinelMaster   = "sentinel_master"
+	RedisSentinelPassword = "sentinel_password"
+	RedisTLS              = "tls"
+	RedisTLSSkipVerify    = "tls_skip_verify"
+	RedisStream           = "stream"
+	RedisStreamMaxLen     = "stream_max_len"
+
+	EnvRedisEnable           = "MINIO_NOTIFY_REDIS_ENABLE"
+	EnvRedisFormat           = "MINIO_NOTIFY_REDIS_FORMAT"
+	EnvRedisAddress          = "MINIO_NOTIFY_REDIS_ADDRESS"
+	EnvRedisPassword         = "MINIO_NOTIFY_REDIS_PASSWORD"
+	EnvRedisKey              = "MINIO_NOTIFY_REDIS_KEY"
+	EnvRedisQueueDir         = "MINIO_NOTIFY_REDIS_QUEUE_DIR"
+	EnvRedisQueueLimit       = "MINIO_NOTIFY_REDIS_QUEUE_LIMIT"
+	EnvRedisUsername         = "MINIO_NOTIFY_REDIS_USERNAME"
+	EnvRedisMode             = "MINIO_NOTIFY_REDIS_MODE"
+	EnvRedisSentinelMaster   = "MINIO_NOTIFY_REDIS_SENTINEL_MASTER"
+	EnvRedisSentinelPassword = "MINIO_NOTIFY_REDIS_SENTINEL_PASSWORD"
+	EnvRedisTLS              = "MINIO_NOTIFY_REDIS_TLS"
+	EnvRedisTLSSkipVerify    = "MINIO_NOTIFY_REDIS_TLS_SKIP_VERIFY"
+	EnvRedisStream           = "MINIO_NOTIFY_REDIS_STREAM"
+	EnvRedisStreamMaxLen     = "MINIO_NOTIFY_REDIS_STREAM_MAX_LEN"
 )
 
 // RedisArgs - Redis target arguments.
@@ -61,6 +78,31 @@ type RedisArgs struct {
 	Key        string    `json:"key"`
 	QueueDir   string    `json:"queueDir"`
 	QueueLimit uint64    `json:"queueLimit"`
+
+	// Addrs lists the cluster seed nodes or the sentinels, Addr is the
+	// first of them.
+	Addrs            []xnet.Host    `json:"addresses"`
+	Username         string         `json:"username"`
+	Mode             string         `json:"mode"`
+	SentinelMaster   string         `json:"sentinelMaster"`
+	SentinelPassword string         `json:"sentinelPassword"`
+	TLS              bool           `json:"tls"`
+	TLSSkipVerify    bool           `json:"tlsSkipVerify"`
+	RootCAs          *x509.CertPool `json:"-"`
+	Stream           bool           `json:"stream"`
+	StreamMaxLen     uint64         `json:"streamMaxLen"`
+}
+
+// addrs returns the addresses to connect to.
+func (r RedisArgs) addrs() []string {
+	if len(r.Addrs) == 0 {
+		return []string{r.Addr.String()}
+	}
+	addrs := make([]string, 0, len(r.Addrs))
+	for _, addr := range r.Addrs {
+		addrs = append(addrs, addr.String())
+	}
+	return addrs
 }
 
 // RedisAccessEvent holds event log data and timestamp
@@ -92,6 +134,24 @@ func (r RedisArgs) Validate() error {
 		}
 	}
 
+	switch r.Mode {
+	case "", RedisModeStandalone:
+		if len(r.Addrs) > 1 {
+			return errors.New("multiple addresses are only supported in cluster and sentinel mode")
+		}
+	case RedisModeCluster:
+	case RedisModeSentinel:
+		if r.SentinelMaster == "" {
+			return errors.New("empty sentinel master")
+		}
+	default:
+		return fmt.Errorf("unrecognized mode %s", r.Mode)
+	}
+
+	if r.StreamMaxLen > 0 && !r.Stream {
+		return errors.New("stream max length is only supported with streams")
+	}
+
 	return nil
 }
 
@@ -103,7 +163,10 @@ func (r RedisArgs) validateFormat(c redis.Conn) error {
 
 	if typeAvailable != "none" {
 		expectedType := "hash"
-		if r.Format == event.AccessFormat {
+		switch {
+		case r.Stream:
+			expectedType = "stream"
+		case r.Format == event.AccessFormat:
 			expectedType = "list"
 		}
 
@@ -121,6 +184,7 @@ type RedisTarget struct {
 
 	id         event.TargetID
 	args       RedisArgs
+	nodes      *redisNodes
 	pool       *redis.Pool
 	store      Store
 	firstPing  bool
@@ -174,7 +238,40 @@ func (target *RedisTarget) Save(eventData event.Event) error {
 // send - sends an event to the redis.
 func (target *RedisTarget) send(eventData event.Event) error {
 	conn := target.pool.Get()
-	defer conn.Close()
+	err := target.write(conn, eventData)
+	conn.Close()
+	if err != nil && target.nodes.redirect(err) {
+		// The key moved to another node, retry there.
+		conn = target.pool.Get()
+		defer conn.Close()
+		err = target.write(conn, eventData)
+	}
+	return err
+}
+
+// write - writes an event using conn.
+func (target *RedisTarget) write(conn redis.Conn, eventData event.Event) error {
+	if target.args.Stream {
+		objectName, err := url.QueryUnescape(eventData.S3.Object.Key)
+		if err != nil {
+			return err
+		}
+		key := eventData.S3.Bucket.Name + "/" + objectName
+
+		data, err := json.Marshal(event.Log{EventName: eventData.EventName, Key: key, Records: []event.Event{eventData}})
+		if err != nil {
+			return err
+		}
+
+		args := redis.Args{target.args.Key}
+		if target.args.StreamMaxLen > 0 {
+			// Approximate trimming is much cheaper for the server.
+			args = args.Add("MAXLEN", "~", target.args.StreamMaxLen)
+		}
+		args = args.Add("*", "event", eventData.EventName.String(), "key", key, "data", data)
+		_, err = conn.Do("XADD", args...)
+		return err
+	}
 
 	if target.args.Format == event.NamespaceFormat {
 		objectName, err := url.QueryUnescape(eventData.S3.Object.Key)
@@ -313,31 +410,15 @@ func NewRedisTarget(id string, args RedisArgs, loggerOnce logger.LogOnce) (*Redi
 		}
 	}
 
+	nodes := newRedisNodes(args)
 	pool := &redis.Pool{
 		MaxIdle:     3,
 		IdleTimeout: 2 * 60 * time.Second,
-		Dial: func() (redis.Conn, error) {
-			conn, err := redis.Dial("tcp", args.Addr.String())
-			if err != nil {
-				return nil, err
-			}
-
-			if args.Password != "" {
-				if _, err = conn.Do("AUTH", args.Password); err != nil {
-					conn.Close()
-					return nil, err
-				}
-			}
-
-			// Must be done after AUTH
-			if _, err = conn.Do("CLIENT", "SETNAME", "MinIO"); err != nil {
-				conn.Close()
-				return nil, err
-			}
-
-			return conn, nil
-		},
+		Dial:        nodes.dial,
 		TestOnBorrow: func(c redis.Conn, t time.Time) error {
+			if nodes.stale(c) {
+				return errRedisStaleConn
+			}
 			_, err := c.Do("PING")
 			return err
 		},
@@ -346,6 +427,7 @@ func NewRedisTarget(id string, args RedisArgs, loggerOnce logger.LogOnce) (*Redi
 	return &RedisTarget{
 		id:         event.TargetID{ID: id, Name: "redis"},
 		args:       args,
+		nodes:      nodes,
 		pool:       pool,
 		store:      store,
 		loggerOnce: loggerOnce,
@@ -0,0 +1,278 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package target
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// Redis deployment modes.
+const (
+	RedisModeStandalone = "standalone"
+	RedisModeCluster    = "cluster"
+	RedisModeSentinel   = "sentinel"
+)
+
+const redisDialTimeout = 10 * time.Second
+
+var errRedisStaleConn = errors.New("redis connection to a stale node")
+
+// redisNodeConn is a connection to the node owning the target key at the
+// time it was dialed.
+type redisNodeConn struct {
+	redis.Conn
+	gen uint64
+}
+
+// redisNodes resolves the node the events are written to. A standalone
+// server is used as is, a cluster is asked for the master owning the slot
+// of the target key and sentinels for the current master. Connections to
+// a node that lost the key are discarded by the pool.
+type redisNodes struct {
+	args RedisArgs
+
+	// gen is bumped whenever the resolved node changes.
+	gen uint64
+
+	mu   sync.Mutex
+	addr string
+}
+
+func newRedisNodes(args RedisArgs) *redisNodes {
+	return &redisNodes{args: args}
+}
+
+func (n *redisNodes) dialOptions(password string) []redis.DialOption {
+	opts := []redis.DialOption{
+		redis.DialConnectTimeout(redisDialTimeout),
+		redis.DialPassword(password),
+	}
+	if n.args.TLS {
+		opts = append(opts,
+			redis.DialUseTLS(true),
+			redis.DialTLSConfig(&tls.Config{RootCAs: n.args.RootCAs}),
+			redis.DialTLSSkipVerify(n.args.TLSSkipVerify),
+		)
+	}
+	return opts
+}
+
+// dial connects to the node owning the target key.
+func (n *redisNodes) dial() (redis.Conn, error) {
+	gen := atomic.LoadUint64(&n.gen)
+	addr, err := n.resolve()
+	if err != nil {
+		return nil, err
+	}
+	opts := append(n.dialOptions(n.args.Password),
+		redis.DialUsername(n.args.Username),
+		redis.DialClientName("MinIO"), // Must be done after AUTH
+	)
+	conn, err := redis.Dial("tcp", addr, opts...)
+	if err != nil {
+		if n.args.Mode != RedisModeStandalone && n.args.Mode != "" {
+			// The node may be gone, resolve it again next time.
+			n.reset(addr, "")
+		}
+		return nil, err
+	}
+	return &redisNodeConn{Conn: conn, gen: gen}, nil
+}
+
+// stale returns true if the connection was dialed before the node
+// owning the target key changed.
+func (n *redisNodes) stale(c redis.Conn) bool {
+	nc, ok := c.(*redisNodeConn)
+	return ok && nc.gen != atomic.LoadUint64(&n.gen)
+}
+
+func (n *redisNodes) resolve() (string, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.addr != "" {
+		return n.addr, nil
+	}
+
+	var addr string
+	var err error
+	switch n.args.Mode {
+	case RedisModeCluster:
+		addr, err = n.clusterMaster()
+	case RedisModeSentinel:
+		addr, err = n.sentinelMaster()
+	default:
+		addr = n.args.Addr.String()
+	}
+	if err != nil {
+		return "", err
+	}
+	n.addr = addr
+	return addr, nil
+}
+
+// reset replaces the resolved node if it is still from.
+func (n *redisNodes) reset(from, to string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.addr == from {
+		n.addr = to
+		atomic.AddUint64(&n.gen, 1)
+	}
+}
+
+// redirect handles the errors of a node no longer accepting writes to the
+// target key, it returns true if the write should be retried.
+func (n *redisNodes) redirect(err error) bool {
+	var rerr redis.Error
+	if !errors.As(err, &rerr) {
+		return false
+	}
+	n.mu.Lock()
+	from := n.addr
+	n.mu.Unlock()
+
+	msg := string(rerr)
+	switch {
+	case strings.HasPrefix(msg, "MOVED "):
+		// MOVED <slot> <host:port>
+		fields := strings.Fields(msg)
+		if len(fields) != 3 {
+			return false
+		}
+		n.reset(from, fields[2])
+	case strings.HasPrefix(msg, "READONLY "), strings.HasPrefix(msg, "CLUSTERDOWN "):
+		// Failed over to a replica, look for the new master.
+		n.reset(from, "")
+	default:
+		return false
+	}
+	return true
+}
+
+// clusterMaster returns the address of the master serving the slot of
+// the target key.
+func (n *redisNodes) clusterMaster() (string, error) {
+	slot := redisKeySlot(n.args.Key)
+	var lastErr error
+	for _, seed := range n.args.addrs() {
+		addr, err := n.clusterSlotOwner(seed, slot)
+		if err == nil {
+			return addr, nil
+		}
+		lastErr = err
+	}
+	return "", fmt.Errorf("unable to find the redis cluster node serving key %s: %w", n.args.Key, lastErr)
+}
+
+func (n *redisNodes) clusterSlotOwner(seed string, slot int64) (string, error) {
+	opts := append(n.dialOptions(n.args.Password), redis.DialUsername(n.args.Username))
+	conn, err := redis.Dial("tcp", seed, opts...)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	ranges, err := redis.Values(conn.Do("CLUSTER", "SLOTS"))
+	if err != nil {
+		return "", err
+	}
+	// Each range is [start, end, [host, port, id], replicas...]
+	for _, r := range ranges {
+		fields, err := redis.Values(r, nil)
+		if err != nil || len(fields) < 3 {
+			continue
+		}
+		start, _ := redis.Int64(fields[0], nil)
+		end, _ := redis.Int64(fields[1], nil)
+		if slot < start || slot > end {
+			continue
+		}
+		master, err := redis.Values(fields[2], nil)
+		if err != nil || len(master) < 2 {
+			return "", errors.New("malformed CLUSTER SLOTS reply")
+		}
+		host, _ := redis.String(master[0], nil)
+		port, _ := redis.Int64(master[1], nil)
+		if host == "" {
+			// Empty host means the node we asked.
+			host, _, _ = net.SplitHostPort(seed)
+		}
+		return net.JoinHostPort(host, strconv.FormatInt(port, 10)), nil
+	}
+	return "", fmt.Errorf("slot %d is not served", slot)
+}
+
+// sentinelMaster asks the sentinels for the current master address.
+func (n *redisNodes) sentinelMaster() (string, error) {
+	var lastErr error
+	for _, sentinel := range n.args.addrs() {
+		conn, err := redis.Dial("tcp", sentinel, n.dialOptions(n.args.SentinelPassword)...)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		master, err := redis.Strings(conn.Do("SENTINEL", "get-master-addr-by-name", n.args.SentinelMaster))
+		conn.Close()
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if len(master) != 2 {
+			lastErr = fmt.Errorf("sentinel %s does not know master %s", sentinel, n.args.SentinelMaster)
+			continue
+		}
+		return net.JoinHostPort(master[0], master[1]), nil
+	}
+	return "", fmt.Errorf("unable to find redis master %s: %w", n.args.SentinelMaster, lastErr)
+}
+
+// redisKeySlot returns the cluster hash slot of key, honoring hash tags.
+func redisKeySlot(key string) int64 {
+	if s := strings.IndexByte(key, '{'); s >= 0 {
+		if e := strings.IndexByte(key[s+1:], '}'); e > 0 {
+			key = key[s+1 : s+1+e]
+		}
+	}
+	return int64(crc16(key) % 16384)
+}
+
+// crc16 implements CRC16-CCITT (XMODEM) used by redis cluster.
+func crc16(s string) uint16 {
+	var crc uint16
+	for i := 0; i < len(s); i++ {
+		crc ^= uint16(s[i]) << 8
+		for j := 0; j < 8; j++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package target
+
+import (
+	"testing"
+
+	"github.com/gomodule/redigo/redis"
+	xnet "github.com/minio/pkg/net"
+)
+
+func TestRedisKeySlot(t *testing.T) {
+	testCases := []struct {
+		key  string
+		slot int64
+	}{
+		{"123456789", 12739},
+		{"foo", 12182},
+		{"{foo}.events", 12182},
+		{"bar{foo}", 12182},
+	}
+	for i, testCase := range testCases {
+		if slot := redisKeySlot(testCase.key); slot != testCase.slot {
+			t.Errorf("test %d: expected slot %d for %q, got %d", i+1, testCase.slot, testCase.key, slot)
+		}
+	}
+}
+
+func TestRedisArgsValidate(t *testing.T) {
+	addr := xnet.Host{Name: "localhost", Port: 6379, IsPortSet: true}
+	testCases := []struct {
+		args      RedisArgs
+		expectErr bool
+	}{
+		{RedisArgs{Enable: true, Addr: addr, Key: "events"}, false},
+		{RedisArgs{Enable: true, Addr: addr, Addrs: []xnet.Host{addr, addr}, Key: "events"}, true},
+		{RedisArgs{Enable: true, Addr: addr, Addrs: []xnet.Host{addr, addr}, Key: "events", Mode: RedisModeCluster}, false},
+		{RedisArgs{Enable: true, Addr: addr, Key: "events", Mode: RedisModeSentinel}, true},
+		{RedisArgs{Enable: true, Addr: addr, Key: "events", Mode: RedisModeSentinel, SentinelMaster: "mymaster"}, false},
+		{RedisArgs{Enable: true, Addr: addr, Key: "events", Mode: "replicated"}, true},
+		{RedisArgs{Enable: true, Addr: addr, Key: "events", StreamMaxLen: 100}, true},
+		{RedisArgs{Enable: true, Addr: addr, Key: "events", Stream: true, StreamMaxLen: 100}, false},
+	}
+	for i, testCase := range testCases {
+		err := testCase.args.Validate()
+		if expectErr := err != nil; expectErr != testCase.expectErr {
+			t.Errorf("test %d: expected error %v, got %v", i+1, testCase.expectErr, err)
+		}
+	}
+}
+
+func TestRedisNodesRedirect(t *testing.T) {
+	nodes := newRedisNodes(RedisArgs{Mode: RedisModeCluster, Key: "events"})
+	nodes.addr = "10.0.0.1:6379"
+	conn := &redisNodeConn{gen: nodes.gen}
+
+	if nodes.redirect(redis.Error("ERR wrong number of arguments")) {
+		t.Fatal("unexpected redirect on a command error")
+	}
+	if nodes.stale(conn) {
+		t.Fatal("connection should not be stale")
+	}
+	if !nodes.redirect(redis.Error("MOVED 7438 10.0.0.2:6379")) {
+		t.Fatal("expected redirect on MOVED")
+	}
+	if nodes.addr != "10.0.0.2:6379" {
+		t.Fatalf("expected node 10.0.0.2:6379, got %s", nodes.addr)
+	}
+	if !nodes.stale(conn) {
+		t.Fatal("connection to the previous node should be stale")
+	}
+	if !nodes.redirect(redis.Error("READONLY You can't write against a read only replica.")) {
+		t.Fatal("expected redirect on READONLY")
+	}
+	if nodes.addr != "" {
+		t.Fatalf("expected node to be resolved again, got %s", nodes.addr)
+	}
+}
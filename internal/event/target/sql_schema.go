@@ -0,0 +1,191 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package target
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/qkbyte/minio/internal/event"
+)
+
+// Date partitioning of access format tables.
+const (
+	SQLPartitionDay   = "day"
+	SQLPartitionMonth = "month"
+)
+
+// sqlMigrationsTable records the schema version of every target table.
+const sqlMigrationsTable = "minio_schema_migrations"
+
+// sqlMigration is a versioned change of the schema of a target table,
+// statements are formatted with the table name.
+type sqlMigration struct {
+	version int
+	stmts   []string
+}
+
+// sqlDialect holds the migration statements differing between databases.
+type sqlDialect struct {
+	createMigrations string
+	selectVersion    string
+	upsertVersion    string
+	// existsErr reports a statement failing only because its object
+	// already exists, for databases lacking IF NOT EXISTS on it.
+	existsErr func(err error) bool
+}
+
+// migrateSQLTable applies the migrations newer than the recorded schema
+// version of table, each in its own transaction.
+func migrateSQLTable(db *sql.DB, dialect sqlDialect, table string, migrations []sqlMigration) error {
+	if _, err := db.Exec(dialect.createMigrations); err != nil {
+		return err
+	}
+
+	var version int
+	err := db.QueryRow(dialect.selectVersion, table).Scan(&version)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	for _, m := range migrations {
+		if m.version <= version {
+			continue
+		}
+		tx, err := db.Begin()
+		if err != nil {
+			return err
+		}
+		for _, stmt := range m.stmts {
+			_, err = tx.Exec(fmt.Sprintf(stmt, table))
+			if err != nil && dialect.existsErr != nil && dialect.existsErr(err) {
+				err = nil
+			}
+			if err != nil {
+				tx.Rollback()
+				return fmt.Errorf("unable to migrate table %s to version %d: %w", table, m.version, err)
+			}
+		}
+		if _, err = tx.Exec(dialect.upsertVersion, table, m.version); err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err = tx.Commit(); err != nil {
+			return err
+		}
+		version = m.version
+	}
+	return nil
+}
+
+// validateSQLPartition validates the date partitioning of a table.
+func validateSQLPartition(partition, format string) error {
+	switch partition {
+	case "":
+		return nil
+	case SQLPartitionDay, SQLPartitionMonth:
+	default:
+		return fmt.Errorf("unrecognized partition value %s", partition)
+	}
+	if format != event.AccessFormat {
+		return errors.New("partitioned tables are only supported with access format")
+	}
+	return nil
+}
+
+// errSQLTableNotPartitioned is returned when a partitioned table was
+// requested but the existing table was created without partitions.
+func errSQLTableNotPartitioned(table string) error {
+	return fmt.Errorf("table %s exists but is not partitioned, drop it or disable partitioning", table)
+}
+
+// sqlPartitionRange returns the name suffix and the bounds of the
+// partition holding t.
+func sqlPartitionRange(partition string, t time.Time) (suffix string, from, to time.Time) {
+	t = t.UTC()
+	if partition == SQLPartitionMonth {
+		from = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+		return from.Format("200601"), from, from.AddDate(0, 1, 0)
+	}
+	from = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return from.Format("20060102"), from, from.AddDate(0, 0, 1)
+}
+
+// unqualifiedTable returns the table name without its schema.
+func unqualifiedTable(table string) string {
+	if i := strings.LastIndexByte(table, '.'); i >= 0 {
+		return table[i+1:]
+	}
+	return table
+}
+
+// readStoreBatch reads the event of key followed by up to limit-1 other
+// queued events in queued order. Keys no longer in the store were sent
+// with an earlier batch.
+func readStoreBatch(store Store, key string, limit int) (keys []string, events []event.Event, err error) {
+	eventData, err := store.Get(key)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+	keys = append(keys, key)
+	events = append(events, eventData)
+	if limit <= 1 {
+		return keys, events, nil
+	}
+
+	names, err := store.List()
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, name := range names {
+		if len(keys) >= limit {
+			break
+		}
+		name = strings.TrimSuffix(name, eventExt)
+		if name == key {
+			continue
+		}
+		eventData, err := store.Get(name)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, nil, err
+		}
+		keys = append(keys, name)
+		events = append(events, eventData)
+	}
+	return keys, events, nil
+}
+
+// delStoreBatch deletes the keys of a sent batch.
+func delStoreBatch(store Store, keys []string) error {
+	for _, key := range keys {
+		if err := store.Del(key); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
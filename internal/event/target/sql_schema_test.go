@@ -0,0 +1,114 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package target
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/qkbyte/minio/internal/event"
+)
+
+func TestSQLPartitionRange(t *testing.T) {
+	eventTime := time.Date(2022, time.December, 31, 23, 30, 0, 0, time.UTC)
+
+	suffix, from, to := sqlPartitionRange(SQLPartitionDay, eventTime)
+	if suffix != "20221231" || !from.Equal(time.Date(2022, time.December, 31, 0, 0, 0, 0, time.UTC)) ||
+		!to.Equal(time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected day partition %s [%s, %s)", suffix, from, to)
+	}
+
+	suffix, from, to = sqlPartitionRange(SQLPartitionMonth, eventTime)
+	if suffix != "202212" || !from.Equal(time.Date(2022, time.December, 1, 0, 0, 0, 0, time.UTC)) ||
+		!to.Equal(time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected month partition %s [%s, %s)", suffix, from, to)
+	}
+}
+
+func TestValidateSQLPartition(t *testing.T) {
+	testCases := []struct {
+		partition string
+		format    string
+		expectErr bool
+	}{
+		{"", event.NamespaceFormat, false},
+		{SQLPartitionDay, event.AccessFormat, false},
+		{SQLPartitionMonth, event.AccessFormat, false},
+		{SQLPartitionDay, event.NamespaceFormat, true},
+		{"week", event.AccessFormat, true},
+	}
+	for i, testCase := range testCases {
+		err := validateSQLPartition(testCase.partition, testCase.format)
+		if expectErr := err != nil; expectErr != testCase.expectErr {
+			t.Errorf("test %d: expected error %v, got %v", i+1, testCase.expectErr, err)
+		}
+	}
+}
+
+func TestReadStoreBatch(t *testing.T) {
+	defer func() {
+		if err := tearDownStore(); err != nil {
+			t.Fatal("Failed to tear down store ", err)
+		}
+	}()
+	store, err := setUpStore(queueDir, 100)
+	if err != nil {
+		t.Fatal("Failed to create a queue store ", err)
+	}
+	for i := 0; i < 10; i++ {
+		if err := store.Put(testEvent); err != nil {
+			t.Fatal("Failed to put to queue store ", err)
+		}
+	}
+	names, err := store.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	first := strings.TrimSuffix(names[len(names)-1], eventExt)
+
+	keys, events, err := readStoreBatch(store, first, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 4 || len(events) != 4 {
+		t.Fatalf("expected a batch of 4 events, got %d", len(events))
+	}
+	if keys[0] != first {
+		t.Fatalf("expected the batch to start with %s, got %s", first, keys[0])
+	}
+	if err = delStoreBatch(store, keys); err != nil {
+		t.Fatal(err)
+	}
+
+	// Keys of a sent batch are skipped.
+	keys, events, err = readStoreBatch(store, first, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 0 || len(events) != 0 {
+		t.Fatalf("expected no events for a sent key, got %d", len(events))
+	}
+
+	if names, err = store.List(); err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != 6 {
+		t.Fatalf("expected 6 queued events, got %d", len(names))
+	}
+}
@@ -20,7 +20,6 @@ package target
 import (
 	"bytes"
 	"context"
-	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -30,10 +29,8 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
-	"syscall"
 	"time"
 
-	"github.com/minio/pkg/certs"
 	xnet "github.com/minio/pkg/net"
 	"github.com/qkbyte/minio/internal/event"
 	"github.com/qkbyte/minio/internal/logger"
@@ -47,6 +44,7 @@ const (
 	WebhookQueueLimit = "queue_limit"
 	WebhookClientCert = "client_cert"
 	WebhookClientKey  = "client_key"
+	WebhookProxy      = "proxy"
 
 	EnvWebhookEnable     = "MINIO_NOTIFY_WEBHOOK_ENABLE"
 	EnvWebhookEndpoint   = "MINIO_NOTIFY_WEBHOOK_ENDPOINT"
@@ -55,6 +53,7 @@ const (
 	EnvWebhookQueueLimit = "MINIO_NOTIFY_WEBHOOK_QUEUE_LIMIT"
 	EnvWebhookClientCert = "MINIO_NOTIFY_WEBHOOK_CLIENT_CERT"
 	EnvWebhookClientKey  = "MINIO_NOTIFY_WEBHOOK_CLIENT_KEY"
+	EnvWebhookProxy      = "MINIO_NOTIFY_WEBHOOK_PROXY"
 )
 
 // WebhookArgs - Webhook target arguments.
@@ -67,6 +66,7 @@ type WebhookArgs struct {
 	QueueLimit uint64          `json:"queueLimit"`
 	ClientCert string          `json:"clientCert"`
 	ClientKey  string          `json:"clientKey"`
+	Proxy      string          `json:"proxy"`
 }
 
 // Validate WebhookArgs fields
@@ -82,10 +82,10 @@ func (w WebhookArgs) Validate() error {
 			return errors.New("queueDir path should be absolute")
 		}
 	}
-	if w.ClientCert != "" && w.ClientKey == "" || w.ClientCert == "" && w.ClientKey != "" {
-		return errors.New("cert and key must be specified as a pair")
+	if err := validateCertPair(w.ClientCert, w.ClientKey); err != nil {
+		return err
 	}
-	return nil
+	return validateProxy(w.Proxy)
 }
 
 // WebhookTarget - Webhook target.
@@ -156,7 +156,7 @@ func (target *WebhookTarget) Save(eventData event.Event) error {
 	if target.store != nil {
 		return target.store.Put(eventData)
 	}
-	err := target.send(eventData)
+	err := target.send(context.Background(), eventData)
 	if err != nil {
 		if xnet.IsNetworkOrHostDown(err, false) {
 			return errNotConnected
@@ -165,8 +165,17 @@ func (target *WebhookTarget) Save(eventData event.Event) error {
 	return err
 }
 
+// SendSync - sends the event directly to the webhook, bypassing the
+// queue store, the request is aborted when ctx is canceled.
+func (target *WebhookTarget) SendSync(ctx context.Context, eventData event.Event) error {
+	if err := target.init(); err != nil {
+		return err
+	}
+	return target.send(ctx, eventData)
+}
+
 // send - sends an event to the webhook.
-func (target *WebhookTarget) send(eventData event.Event) error {
+func (target *WebhookTarget) send(ctx context.Context, eventData event.Event) error {
 	objectName, err := url.QueryUnescape(eventData.S3.Object.Key)
 	if err != nil {
 		return err
@@ -178,7 +187,7 @@ func (target *WebhookTarget) send(eventData event.Event) error {
 		return err
 	}
 
-	req, err := http.NewRequest("POST", target.args.Endpoint.String(), bytes.NewReader(data))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target.args.Endpoint.String(), bytes.NewReader(data))
 	if err != nil {
 		return err
 	}
@@ -229,7 +238,7 @@ func (target *WebhookTarget) Send(eventKey string) error {
 		return eErr
 	}
 
-	if err := target.send(eventData); err != nil {
+	if err := target.send(context.Background(), eventData); err != nil {
 		if xnet.IsNetworkOrHostDown(err, false) {
 			return errNotConnected
 		}
@@ -253,15 +262,11 @@ func (target *WebhookTarget) init() error {
 // Only called from init()
 func (target *WebhookTarget) initWebhook() error {
 	args := target.args
-	transport := target.transport
 
-	if args.ClientCert != "" && args.ClientKey != "" {
-		manager, err := certs.NewManager(context.Background(), args.ClientCert, args.ClientKey, tls.LoadX509KeyPair)
-		if err != nil {
-			return err
-		}
-		manager.ReloadOnSignal(syscall.SIGHUP) // allow reloads upon SIGHUP
-		transport.TLSClientConfig.GetClientCertificate = manager.GetClientCertificate
+	// Clone the shared transport for a per-target proxy or client certificate.
+	transport, err := newTargetTransport(target.transport, args.Proxy, args.ClientCert, args.ClientKey)
+	if err != nil {
+		return err
 	}
 	target.httpClient = &http.Client{Transport: transport}
 
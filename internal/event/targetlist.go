@@ -18,9 +18,12 @@
 package event
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"sync/atomic"
+	"time"
 )
 
 const (
@@ -37,13 +40,20 @@ type Target interface {
 	Close() error
 }
 
+// SyncTarget - implemented by targets able to deliver an event to the
+// remote system directly, bypassing their queue store.
+type SyncTarget interface {
+	SendSync(ctx context.Context, event Event) error
+}
+
 // TargetList - holds list of targets indexed by target ID.
 type TargetList struct {
 	// The number of concurrent async Send calls to all targets
 	currentSendCalls int64
 
 	sync.RWMutex
-	targets map[TargetID]Target
+	targets  map[TargetID]Target
+	versions map[TargetID]PayloadVersion
 }
 
 // Add - adds unique target to target list.
@@ -61,6 +71,29 @@ func (list *TargetList) Add(targets ...Target) error {
 	return nil
 }
 
+// SetPayloadVersion - sets the event payload version delivered to the target.
+func (list *TargetList) SetPayloadVersion(id TargetID, v PayloadVersion) {
+	list.Lock()
+	defer list.Unlock()
+
+	if v == PayloadV1 {
+		delete(list.versions, id)
+		return
+	}
+	list.versions[id] = v
+}
+
+// PayloadVersion - returns the event payload version delivered to the target.
+func (list *TargetList) PayloadVersion(id TargetID) PayloadVersion {
+	list.RLock()
+	defer list.RUnlock()
+
+	if v, ok := list.versions[id]; ok {
+		return v
+	}
+	return PayloadV1
+}
+
 // Exists - checks whether target by target ID exists or not.
 func (list *TargetList) Exists(id TargetID) bool {
 	list.RLock()
@@ -70,6 +103,42 @@ func (list *TargetList) Exists(id TargetID) bool {
 	return found
 }
 
+// SupportsSync - checks whether the target by target ID is able to
+// deliver events synchronously.
+func (list *TargetList) SupportsSync(id TargetID) bool {
+	list.RLock()
+	defer list.RUnlock()
+
+	_, ok := list.targets[id].(SyncTarget)
+	return ok
+}
+
+// SendSync - delivers the event directly to the remote system of the
+// target, bypassing its queue store, and gives up once timeout has
+// elapsed or ctx is canceled.
+func (list *TargetList) SendSync(ctx context.Context, event Event, targetID TargetID, timeout time.Duration) error {
+	list.RLock()
+	target, ok := list.targets[targetID]
+	list.RUnlock()
+	if !ok {
+		return fmt.Errorf("target %v not found", targetID)
+	}
+	syncTarget, ok := target.(SyncTarget)
+	if !ok {
+		return fmt.Errorf("target %v does not support synchronous delivery", targetID)
+	}
+	event = event.WithPayloadVersion(list.PayloadVersion(targetID))
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	err := syncTarget.SendSync(ctx, event)
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("target %v: event delivery timed out after %v", targetID, timeout)
+	}
+	return err
+}
+
 // TargetIDResult returns result of Remove/Send operation, sets err if
 // any for the associated TargetID
 type TargetIDResult struct {
@@ -89,6 +158,7 @@ func (list *TargetList) Remove(targetIDSet TargetIDSet) {
 		if ok {
 			target.Close()
 			delete(list.targets, id)
+			delete(list.versions, id)
 		}
 	}
 }
@@ -153,7 +223,7 @@ func (list *TargetList) Send(event Event, targetIDset TargetIDSet, resCh chan<-
 					defer atomic.AddInt64(&list.currentSendCalls, -1)
 					defer wg.Done()
 					tgtRes := TargetIDResult{ID: id}
-					if err := target.Save(event); err != nil {
+					if err := target.Save(event.WithPayloadVersion(list.PayloadVersion(id))); err != nil {
 						tgtRes.Err = err
 					}
 					resCh <- tgtRes
@@ -168,5 +238,8 @@ func (list *TargetList) Send(event Event, targetIDset TargetIDSet, resCh chan<-
 
 // NewTargetList - creates TargetList.
 func NewTargetList() *TargetList {
-	return &TargetList{targets: make(map[TargetID]Target)}
+	return &TargetList{
+		targets:  make(map[TargetID]Target),
+		versions: make(map[TargetID]PayloadVersion),
+	}
 }
@@ -18,6 +18,7 @@
 package event
 
 import (
+	"context"
 	"crypto/rand"
 	"errors"
 	"reflect"
@@ -259,3 +260,117 @@ func TestNewTargetList(t *testing.T) {
 		t.Fatalf("test: result: expected: <non-nil>, got: <nil>")
 	}
 }
+
+type syncTarget struct {
+	ExampleTarget
+	events chan Event
+}
+
+func (target syncTarget) SendSync(ctx context.Context, eventData Event) error {
+	if target.sendErr {
+		return errors.New("send error")
+	}
+	select {
+	case target.events <- eventData:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func TestTargetListSendSync(t *testing.T) {
+	targetList := NewTargetList()
+	if err := targetList.Add(
+		syncTarget{ExampleTarget{id: TargetID{"1", "webhook"}}, make(chan Event, 1)},
+		syncTarget{ExampleTarget{id: TargetID{"2", "webhook"}, sendErr: true}, nil},
+		syncTarget{ExampleTarget{id: TargetID{"4", "webhook"}}, make(chan Event)},
+		&ExampleTarget{TargetID{"5", "webhook"}, false, false},
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := targetList.SendSync(context.Background(), Event{}, TargetID{"1", "webhook"}, time.Second); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if err := targetList.SendSync(context.Background(), Event{}, TargetID{"2", "webhook"}, time.Second); err == nil {
+		t.Fatal("expected send error")
+	}
+	if err := targetList.SendSync(context.Background(), Event{}, TargetID{"3", "webhook"}, time.Second); err == nil {
+		t.Fatal("expected target not found error")
+	}
+	// The delivery must be canceled, not left running, on timeout.
+	if err := targetList.SendSync(context.Background(), Event{}, TargetID{"4", "webhook"}, 10*time.Millisecond); err == nil {
+		t.Fatal("expected timeout error")
+	}
+	if targetList.SupportsSync(TargetID{"5", "webhook"}) {
+		t.Fatal("expected target without synchronous delivery")
+	}
+	if err := targetList.SendSync(context.Background(), Event{}, TargetID{"5", "webhook"}, time.Second); err == nil {
+		t.Fatal("expected unsupported target error")
+	}
+}
+
+type recordTarget struct {
+	ExampleTarget
+	events chan Event
+}
+
+func (target recordTarget) Save(eventData Event) error {
+	target.events <- eventData
+	return nil
+}
+
+func (target recordTarget) SendSync(_ context.Context, eventData Event) error {
+	return target.Save(eventData)
+}
+
+func TestTargetListPayloadVersion(t *testing.T) {
+	v1 := recordTarget{ExampleTarget{id: TargetID{"1", "webhook"}}, make(chan Event, 1)}
+	v2 := recordTarget{ExampleTarget{id: TargetID{"2", "webhook"}}, make(chan Event, 1)}
+	targetList := NewTargetList()
+	if err := targetList.Add(v1, v2); err != nil {
+		t.Fatal(err)
+	}
+	targetList.SetPayloadVersion(v2.ID(), PayloadV2)
+	if v := targetList.PayloadVersion(v1.ID()); v != PayloadV1 {
+		t.Fatalf("expected payload version 1, got %v", v)
+	}
+
+	ev := Event{S3: Metadata{
+		SchemaVersion: "1.0",
+		Object: Object{
+			Key:               "object",
+			Tags:              map[string]string{"project": "alpha"},
+			StorageClass:      "STANDARD",
+			ReplicationStatus: "COMPLETED",
+		},
+	}}
+
+	resCh := make(chan TargetIDResult, 2)
+	targetList.Send(ev, NewTargetIDSet(v1.ID(), v2.ID()), resCh)
+	for i := 0; i < 2; i++ {
+		if res := <-resCh; res.Err != nil {
+			t.Fatal(res.Err)
+		}
+	}
+
+	got := <-v1.events
+	if got.S3.SchemaVersion != "1.0" || got.S3.Object.Tags != nil || got.S3.Object.StorageClass != "" || got.S3.Object.ReplicationStatus != "" {
+		t.Fatalf("unexpected v1 payload %#v", got.S3)
+	}
+	got = <-v2.events
+	if got.S3.SchemaVersion != "2.0" || !reflect.DeepEqual(got.S3.Object, ev.S3.Object) {
+		t.Fatalf("unexpected v2 payload %#v", got.S3)
+	}
+
+	if err := targetList.SendSync(context.Background(), ev, v2.ID(), time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if got = <-v2.events; got.S3.Object.StorageClass != "STANDARD" {
+		t.Fatalf("unexpected v2 payload %#v", got.S3)
+	}
+
+	if _, err := ParsePayloadVersion("3"); err == nil {
+		t.Fatal("expected invalid payload version error")
+	}
+}
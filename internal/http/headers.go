@@ -103,6 +103,10 @@ const (
 	// Dummy putBucketACL
 	AmzACL = "x-amz-acl"
 
+	// Requester pays buckets
+	AmzRequestPayer   = "x-amz-request-payer"
+	AmzRequestCharged = "x-amz-request-charged"
+
 	// Signature V4 related contants.
 	AmzContentSha256        = "X-Amz-Content-Sha256"
 	AmzDate                 = "X-Amz-Date"
@@ -114,6 +118,7 @@ const (
 	AmzSecurityToken        = "X-Amz-Security-Token"
 	AmzDecodedContentLength = "X-Amz-Decoded-Content-Length"
 	AmzTrailer              = "X-Amz-Trailer"
+	AmzRegionSet            = "X-Amz-Region-Set"
 
 	AmzMetaUnencryptedContentLength = "X-Amz-Meta-X-Amz-Unencrypted-Content-Length"
 	AmzMetaUnencryptedContentMD5    = "X-Amz-Meta-X-Amz-Unencrypted-Content-Md5"
@@ -185,6 +190,23 @@ const (
 	MinIOSourceProxyRequest = "X-Minio-Source-Proxy-Request"
 	// Header indicates that this request is a replication request to create a REPLICA
 	MinIOSourceReplicationRequest = "X-Minio-Source-Replication-Request"
+	// Header returned by a read-only site replication follower with the
+	// endpoint of the primary site writes should be sent to.
+	MinIOSiteReplicationPrimary = "X-Minio-Site-Replication-Primary"
+	// Request header asking GET and HEAD object responses to include
+	// whether the object retention was inherited from the bucket default.
+	MinIOIncludeRetentionSource = "X-Minio-Include-Retention-Source"
+	// Response header with the source of the object retention, either
+	// DEFAULT or EXPLICIT.
+	MinIOObjectLockRetentionSource = "X-Minio-Object-Lock-Retention-Source"
+	// Header carrying a bucket share link token, it may also be sent
+	// as the lower cased query parameter.
+	MinIOShareToken = "X-Minio-Share-Token"
+	// Request header with object tags, e.g. status=published, the object
+	// is only returned by GET and HEAD when it carries all of them.
+	MinIOIfTags = "X-Minio-If-Tags"
+	// Response header with the id of the admin job started by a request.
+	MinIOJobID = "X-Minio-Job-Id"
 	// Header indicates replication reset status.
 	MinIOReplicationResetStatus = "X-Minio-Replication-Reset-Status"
 
@@ -199,6 +221,41 @@ const (
 
 	// MinIOCompressed is returned when object is compressed
 	MinIOCompressed = "X-Minio-Compressed"
+
+	// MinIOAppend requests the PUT data to be appended to the existing object
+	MinIOAppend = "X-Minio-Append"
+
+	// MinIODeadline carries the time left on the caller's deadline
+	// for internode requests
+	MinIODeadline = "X-Minio-Deadline"
+
+	// MinIOIOClass carries the I/O class drive I/O of internode
+	// requests runs under
+	MinIOIOClass = "X-Minio-Io-Class"
+
+	// MinIOListBarrier requests a listing to reflect all writes
+	// acknowledged before the given RFC3339 time
+	MinIOListBarrier = "X-Minio-List-Barrier"
+
+	// MinIOListBarrierObject requests a listing to reflect the write
+	// of the given object and all writes acknowledged before it, the
+	// object must have the ETag in MinIOListBarrierETag when set
+	MinIOListBarrierObject = "X-Minio-List-Barrier-Object"
+	MinIOListBarrierETag   = "X-Minio-List-Barrier-Etag"
+)
+
+// Standard CORS headers
+const (
+	Origin                        = "Origin"
+	Vary                          = "Vary"
+	AccessControlAllowOrigin      = "Access-Control-Allow-Origin"
+	AccessControlAllowCredentials = "Access-Control-Allow-Credentials"
+	AccessControlAllowMethods     = "Access-Control-Allow-Methods"
+	AccessControlAllowHeaders     = "Access-Control-Allow-Headers"
+	AccessControlExposeHeaders    = "Access-Control-Expose-Headers"
+	AccessControlMaxAge           = "Access-Control-Max-Age"
+	AccessControlRequestMethod    = "Access-Control-Request-Method"
+	AccessControlRequestHeaders   = "Access-Control-Request-Headers"
 )
 
 // Common http query params S3 API
@@ -159,3 +159,30 @@ func newHTTPListener(ctx context.Context, serverAddrs []string) (listener *httpL
 
 	return listener, nil
 }
+
+// ListenPacket opens a UDP socket on every address of serverAddrs,
+// sockets on the same address share the port like the TCP listeners.
+func ListenPacket(ctx context.Context, serverAddrs []string) (_ []net.PacketConn, err error) {
+	var conns []net.PacketConn
+
+	// Close all opened sockets on error
+	defer func() {
+		if err == nil {
+			return
+		}
+
+		for _, conn := range conns {
+			// Ignore error on close.
+			conn.Close()
+		}
+	}()
+
+	for _, serverAddr := range serverAddrs {
+		var conn net.PacketConn
+		if conn, err = listenCfg.ListenPacket(ctx, "udp", serverAddr); err != nil {
+			return nil, err
+		}
+		conns = append(conns, conn)
+	}
+	return conns, nil
+}
@@ -92,9 +92,27 @@ func (srv *Server) Start(ctx context.Context) (err error) {
 		return err
 	}
 
-	// Wrap given handler to do additional
-	// * return 503 (service unavailable) if the server in shutdown.
-	wrappedHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	wrappedHandler := srv.WrapHandler(handler)
+
+	srv.listenerMutex.Lock()
+	srv.Handler = wrappedHandler
+	srv.listener = listener
+	srv.listenerMutex.Unlock()
+
+	// Start servicing with listener.
+	if tlsConfig != nil {
+		return srv.Server.Serve(tls.NewListener(listener, tlsConfig))
+	}
+	return srv.Server.Serve(listener)
+}
+
+// WrapHandler - wraps given handler to do additional
+// * return 503 (service unavailable) if the server in shutdown.
+// * count the request in progress, Shutdown waits for it.
+// Handlers served over other transports are wrapped with it
+// to be drained along with the server.
+func (srv *Server) WrapHandler(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// If server is in shutdown.
 		if atomic.LoadUint32(&srv.inShutdown) != 0 {
 			// To indicate disable keep-alives
@@ -110,17 +128,6 @@ func (srv *Server) Start(ctx context.Context) (err error) {
 		// Handle request using passed handler.
 		handler.ServeHTTP(w, r)
 	})
-
-	srv.listenerMutex.Lock()
-	srv.Handler = wrappedHandler
-	srv.listener = listener
-	srv.listenerMutex.Unlock()
-
-	// Start servicing with listener.
-	if tlsConfig != nil {
-		return srv.Server.Serve(tls.NewListener(listener, tlsConfig))
-	}
-	return srv.Server.Serve(listener)
 }
 
 // Shutdown - shuts down HTTP server.
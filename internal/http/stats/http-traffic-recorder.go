@@ -55,6 +55,13 @@ func (w *OutgoingTrafficMeter) Write(p []byte) (n int, err error) {
 	return n, err
 }
 
+// ReadFrom calls the underlying ReadFrom, if any, and counts the output bytes
+func (w *OutgoingTrafficMeter) ReadFrom(r io.Reader) (n int64, err error) {
+	n, err = io.Copy(w.ResponseWriter, r)
+	w.countBytes += n
+	return n, err
+}
+
 // Flush calls the underlying Flush.
 func (w *OutgoingTrafficMeter) Flush() {
 	w.ResponseWriter.(http.Flusher).Flush()
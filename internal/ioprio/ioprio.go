@@ -0,0 +1,121 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package ioprio runs background drive I/O under a lower kernel I/O
+// priority class so that foreground requests are served first.
+package ioprio
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Class is an I/O scheduling class.
+type Class int
+
+const (
+	// None leaves the I/O priority of the caller untouched.
+	None Class = iota
+	// BestEffort is the lowest level of the best-effort class.
+	BestEffort
+	// Idle only gets drive time when no other I/O is pending.
+	Idle
+)
+
+// String returns the configuration value of c.
+func (c Class) String() string {
+	switch c {
+	case BestEffort:
+		return "best-effort"
+	case Idle:
+		return "idle"
+	}
+	return "none"
+}
+
+// Parse parses a configured I/O class, empty is None.
+func Parse(s string) (Class, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "none", "off":
+		return None, nil
+	case "best-effort", "besteffort", "be":
+		return BestEffort, nil
+	case "idle":
+		return Idle, nil
+	}
+	return None, fmt.Errorf("unknown I/O class %q, expected one of none, best-effort, idle", s)
+}
+
+type ctxKey struct{}
+
+// WithClass returns a context whose drive I/O runs under class c.
+func WithClass(ctx context.Context, c Class) context.Context {
+	if c == None {
+		return ctx
+	}
+	return context.WithValue(ctx, ctxKey{}, c)
+}
+
+// FromContext returns the I/O class set on ctx, None if unset.
+func FromContext(ctx context.Context) Class {
+	if c, ok := ctx.Value(ctxKey{}).(Class); ok {
+		return c
+	}
+	return None
+}
+
+// DoContext runs fn under the I/O class of ctx.
+func DoContext(ctx context.Context, fn func() error) error {
+	return Do(FromContext(ctx), fn)
+}
+
+type reader struct {
+	io.ReadCloser
+	c Class
+}
+
+func (r *reader) Read(p []byte) (n int, err error) {
+	err = Do(r.c, func() (err error) {
+		n, err = r.ReadCloser.Read(p)
+		return err
+	})
+	return n, err
+}
+
+// WriteTo forwards to the io.WriterTo of the wrapped reader, so that
+// zero-copy transfers are still used.
+func (r *reader) WriteTo(w io.Writer) (n int64, err error) {
+	wt, ok := r.ReadCloser.(io.WriterTo)
+	if !ok {
+		return io.Copy(w, struct{ io.Reader }{r})
+	}
+	err = Do(r.c, func() (err error) {
+		n, err = wt.WriteTo(w)
+		return err
+	})
+	return n, err
+}
+
+// ReadCloser returns rc with every Read and WriteTo run under class c.
+func ReadCloser(c Class, rc io.ReadCloser) io.ReadCloser {
+	if c == None || rc == nil {
+		return rc
+	}
+	return &reader{ReadCloser: rc, c: c}
+}
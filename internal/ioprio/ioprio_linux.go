@@ -0,0 +1,90 @@
+//go:build linux
+// +build linux
+
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ioprio
+
+import (
+	"runtime"
+
+	"golang.org/x/sys/unix"
+)
+
+// From linux/ioprio.h
+const (
+	ioprioClassShift = 13
+	ioprioClassBE    = 2
+	ioprioClassIdle  = 3
+	ioprioWhoProcess = 1
+
+	// Lowest priority level of the best-effort class.
+	ioprioBELowest = 7
+)
+
+func (c Class) value() int {
+	switch c {
+	case BestEffort:
+		return ioprioClassBE<<ioprioClassShift | ioprioBELowest
+	case Idle:
+		return ioprioClassIdle << ioprioClassShift
+	}
+	return 0
+}
+
+// I/O priorities are per thread, a 'who' of zero is the calling thread.
+func get() (int, error) {
+	r, _, errno := unix.Syscall(unix.SYS_IOPRIO_GET, ioprioWhoProcess, 0, 0)
+	if errno != 0 {
+		return 0, errno
+	}
+	return int(r), nil
+}
+
+func set(prio int) error {
+	_, _, errno := unix.Syscall(unix.SYS_IOPRIO_SET, ioprioWhoProcess, 0, uintptr(prio))
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// Do runs fn with the calling goroutine wired to its OS thread and the
+// thread's I/O priority lowered to c, the previous priority is
+// restored afterwards. fn runs unchanged if c is None or the priority
+// cannot be changed.
+func Do(c Class, fn func() error) error {
+	if c == None {
+		return fn()
+	}
+	runtime.LockOSThread()
+	prev, err := get()
+	if err != nil || prev == c.value() || set(c.value()) != nil {
+		runtime.UnlockOSThread()
+		return fn()
+	}
+	defer func() {
+		// If the priority cannot be restored, keep the goroutine
+		// wired so the thread exits along with it instead of
+		// serving other goroutines at the lower priority.
+		if set(prev) == nil {
+			runtime.UnlockOSThread()
+		}
+	}()
+	return fn()
+}
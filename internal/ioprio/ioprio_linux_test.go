@@ -0,0 +1,49 @@
+//go:build linux
+// +build linux
+
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ioprio
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestDoRestores(t *testing.T) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	prev, err := get()
+	if err != nil {
+		t.Skip("ioprio_get not available:", err)
+	}
+	for _, c := range []Class{BestEffort, Idle} {
+		var during int
+		Do(c, func() error {
+			during, _ = get()
+			return nil
+		})
+		if during != c.value() {
+			t.Fatalf("%v: expected priority %x while running, got %x", c, c.value(), during)
+		}
+		if after, _ := get(); after != prev {
+			t.Fatalf("%v: expected priority %x to be restored, got %x", c, prev, after)
+		}
+	}
+}
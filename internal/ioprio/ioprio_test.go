@@ -0,0 +1,101 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ioprio
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		value   string
+		class   Class
+		success bool
+	}{
+		{"", None, true},
+		{"none", None, true},
+		{"best-effort", BestEffort, true},
+		{"Idle", Idle, true},
+		{"realtime", None, false},
+	}
+	for _, testCase := range testCases {
+		c, err := Parse(testCase.value)
+		if (err == nil) != testCase.success {
+			t.Fatalf("%q: expected success %v, got %v", testCase.value, testCase.success, err)
+		}
+		if c != testCase.class {
+			t.Fatalf("%q: expected %v, got %v", testCase.value, testCase.class, c)
+		}
+		if err == nil && c != None {
+			if rc, _ := Parse(c.String()); rc != c {
+				t.Fatalf("%v: does not round trip, got %v", c, rc)
+			}
+		}
+	}
+}
+
+func TestContext(t *testing.T) {
+	ctx := context.Background()
+	if c := FromContext(ctx); c != None {
+		t.Fatalf("expected none, got %v", c)
+	}
+	if c := FromContext(WithClass(ctx, Idle)); c != Idle {
+		t.Fatalf("expected idle, got %v", c)
+	}
+
+	var ran bool
+	if err := DoContext(WithClass(ctx, Idle), func() error {
+		ran = true
+		return nil
+	}); err != nil || !ran {
+		t.Fatalf("expected fn to run, got %v", err)
+	}
+}
+
+type writerToReadCloser struct {
+	io.ReadCloser
+	wrote bool
+}
+
+func (r *writerToReadCloser) WriteTo(w io.Writer) (int64, error) {
+	r.wrote = true
+	return io.Copy(w, r.ReadCloser)
+}
+
+func TestReadCloserWriteTo(t *testing.T) {
+	rc := &writerToReadCloser{ReadCloser: io.NopCloser(strings.NewReader("data"))}
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, ReadCloser(Idle, rc)); err != nil {
+		t.Fatal(err)
+	}
+	if !rc.wrote || buf.String() != "data" {
+		t.Fatalf("expected WriteTo to be forwarded, got %q", buf.String())
+	}
+
+	buf.Reset()
+	if _, err := io.Copy(&buf, ReadCloser(Idle, io.NopCloser(struct{ io.Reader }{strings.NewReader("data")}))); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "data" {
+		t.Fatalf("expected data, got %q", buf.String())
+	}
+}
@@ -46,6 +46,16 @@ func (w *WriteOnCloser) Write(p []byte) (int, error) {
 	return w.Writer.Write(p)
 }
 
+// ReadFrom forwards to the underlying writer so that a zero-copy
+// implementation such as http.ResponseWriter's sendfile path is used.
+func (w *WriteOnCloser) ReadFrom(r io.Reader) (int64, error) {
+	n, err := io.Copy(w.Writer, r)
+	if n > 0 {
+		w.hasWritten = true
+	}
+	return n, err
+}
+
 // Close closes the WriteOnCloser. It behaves like io.Closer.
 func (w *WriteOnCloser) Close() error {
 	if !w.hasWritten {
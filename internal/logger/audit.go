@@ -85,6 +85,24 @@ func (lrw *ResponseWriter) Write(p []byte) (int, error) {
 	return n, err
 }
 
+// ReadFrom - copies from r to the underlying writer, allowing it to
+// use sendfile when the response body is not being recorded.
+func (lrw *ResponseWriter) ReadFrom(r io.Reader) (int64, error) {
+	if !lrw.headersLogged {
+		lrw.WriteHeader(http.StatusOK)
+	}
+	if (lrw.LogErrBody && lrw.StatusCode >= http.StatusBadRequest) || lrw.LogAllBody {
+		// Body needs to be recorded, go through Write().
+		return io.Copy(struct{ io.Writer }{lrw}, r)
+	}
+	if lrw.TimeToFirstByte == 0 {
+		lrw.TimeToFirstByte = time.Now().UTC().Sub(lrw.StartTime)
+	}
+	n, err := io.Copy(lrw.ResponseWriter, r)
+	lrw.bytesWritten += int(n)
+	return n, err
+}
+
 // Write the headers into the given buffer
 func (lrw *ResponseWriter) writeHeaders(w io.Writer, statusCode int, headers http.Header) {
 	n, _ := fmt.Fprintf(w, "%d %s\n", statusCode, http.StatusText(statusCode))
@@ -215,6 +233,7 @@ func AuditLog(ctx context.Context, w http.ResponseWriter, r *http.Request, reqCl
 			headerBytes = int64(st.HeaderSize())
 		}
 
+		entry.AccessKey = reqInfo.Cred.AccessKey
 		entry.API.Name = reqInfo.API
 		entry.API.Bucket = reqInfo.BucketName
 		entry.API.Object = reqInfo.ObjectName
@@ -126,6 +126,23 @@ func initKafkaTargets(cfgMap map[string]kafka.Config) (tgts []Target, err error)
 	return tgts, err
 }
 
+// AddAuditTarget adds a new target to the list of enabled
+// audit loggers, used by targets internal to the server.
+func AddAuditTarget(t Target) error {
+	if err := t.Init(); err != nil {
+		return err
+	}
+
+	swapAuditMuRW.Lock()
+	defer swapAuditMuRW.Unlock()
+
+	updated := append(make([]Target, 0, len(auditTargets)+1), auditTargets...)
+	updated = append(updated, t)
+	auditTargets = updated
+
+	return nil
+}
+
 // Split targets into two groups:
 //
 //	group1 contains all targets of type t
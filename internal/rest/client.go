@@ -34,6 +34,7 @@ import (
 
 	xnet "github.com/minio/pkg/net"
 	xhttp "github.com/qkbyte/minio/internal/http"
+	"github.com/qkbyte/minio/internal/ioprio"
 	"github.com/qkbyte/minio/internal/logger"
 )
 
@@ -92,6 +93,11 @@ type Client struct {
 	// Avoid metrics update if set to true
 	NoMetrics bool
 
+	// HandshakeFn, when set, validates the identity headers sent
+	// back by the remote end on every response. A failed handshake
+	// is treated as a network error and takes the client offline.
+	HandshakeFn func(host string, h http.Header) error
+
 	httpClient   *http.Client
 	url          *url.URL
 	newAuthToken func(audience string) string
@@ -190,6 +196,12 @@ func (c *Client) newRequest(ctx context.Context, u *url.URL, body io.Reader) (*h
 		req.Header.Set("Authorization", "Bearer "+c.newAuthToken(u.RawQuery))
 	}
 	req.Header.Set("X-Minio-Time", time.Now().UTC().Format(time.RFC3339))
+	if xhttp.GlobalDeploymentID != "" {
+		req.Header.Set(xhttp.MinioDeploymentID, xhttp.GlobalDeploymentID)
+	}
+	if xhttp.GlobalMinIOVersion != "" {
+		req.Header.Set(xhttp.MinIOVersion, xhttp.GlobalMinIOVersion)
+	}
 	if body != nil {
 		req.Header.Set("Expect", "100-continue")
 	}
@@ -239,13 +251,21 @@ func (c *Client) Call(ctx context.Context, method string, values url.Values, bod
 	if length > 0 {
 		req.ContentLength = length
 	}
+	if deadline, ok := ctx.Deadline(); ok {
+		// Let the remote end give up along with us.
+		req.Header.Set(xhttp.MinIODeadline, time.Until(deadline).String())
+	}
+	if class := ioprio.FromContext(ctx); class != ioprio.None {
+		req.Header.Set(xhttp.MinIOIOClass, class.String())
+	}
 
 	req, update := setupReqStatsUpdate(req)
 	defer update()
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		if xnet.IsNetworkOrHostDown(err, c.ExpectTimeouts) {
+		// An expired caller deadline says nothing about the remote host.
+		if ctx.Err() == nil && xnet.IsNetworkOrHostDown(err, c.ExpectTimeouts) {
 			if !c.NoMetrics {
 				atomic.AddUint64(&globalStats.errs, 1)
 			}
@@ -256,6 +276,16 @@ func (c *Client) Call(ctx context.Context, method string, values url.Values, bod
 		return nil, &NetworkError{err}
 	}
 
+	if c.HandshakeFn != nil {
+		if err = c.HandshakeFn(c.url.Host, resp.Header); err != nil {
+			xhttp.DrainBody(resp.Body)
+			if c.MarkOffline(err) {
+				logger.LogOnceIf(ctx, fmt.Errorf("Marking %s offline temporarily; caused by %w", c.url.Host, err), c.url.Host)
+			}
+			return nil, &NetworkError{err}
+		}
+	}
+
 	final := resp.Trailer.Get("FinalStatus")
 	if final != "" && final != "Success" {
 		defer xhttp.DrainBody(resp.Body)